@@ -0,0 +1,298 @@
+// Package acl simulates PostgreSQL's access privileges by replaying the
+// statements that change them, so that questions such as "can role bob
+// SELECT from table app.orders?" can be answered without a server.
+//
+// A Model applies DDL to a catalog.Catalog, which tracks the objects and
+// their owners, and keeps the access control list of every table, column,
+// sequence, function, schema and type besides. GRANT and REVOKE on these
+// objects, ALTER DEFAULT PRIVILEGES, CREATE, ALTER and DROP ROLE, GRANT
+// and REVOKE of role membership, ownership changes, and SET ROLE and SET
+// SESSION AUTHORIZATION are followed with the semantics of PostgreSQL 16
+// and later: grant options and their cascading revocation, the privileges
+// PUBLIC holds by default, and membership inheritance as set by the
+// INHERIT option of each membership grant. ACLs are rendered in the text
+// form of aclitem[], as psql's \dp and pg_class.relacl show them.
+//
+// Privileges on databases, languages, tablespaces, foreign data wrappers,
+// servers, large objects and parameters are not modelled; statements
+// granting them are accepted and ignored. Permission to run DDL is not
+// checked either: only GRANT, REVOKE, ALTER DEFAULT PRIVILEGES, GRANT of
+// roles and SET ROLE check the privileges of the current role.
+package acl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pgplex/pgparser/catalog"
+)
+
+// Privileges is a set of privileges.
+type Privileges uint32
+
+// The privileges, in the order of their aclitem letters.
+const (
+	Insert      Privileges = 1 << iota // a
+	Select                             // r
+	Update                             // w
+	Delete                             // d
+	Truncate                           // D
+	References                         // x
+	Trigger                            // t
+	Execute                            // X
+	Usage                              // U
+	Create                             // C
+	Temporary                          // T
+	Connect                            // c
+	Set                                // s
+	AlterSystem                        // A
+	Maintain                           // m
+)
+
+// privilegeLetters holds the aclitem letter of each privilege, in bit
+// order, as ACL_ALL_RIGHTS_STR does.
+const privilegeLetters = "arwdDxtXUCTcsAm"
+
+var privilegeNames = []string{
+	"INSERT", "SELECT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER",
+	"EXECUTE", "USAGE", "CREATE", "TEMPORARY", "CONNECT", "SET", "ALTER SYSTEM", "MAINTAIN",
+}
+
+// The privileges that apply to each kind of object.
+const (
+	allRelation = Insert | Select | Update | Delete | Truncate | References | Trigger | Maintain
+	allSequence = Usage | Select | Update
+	allColumn   = Insert | Select | Update | References
+	allFunction = Execute
+	allSchema   = Usage | Create
+	allType     = Usage
+)
+
+// String returns the names of the privileges, separated by commas, as in
+// "SELECT, UPDATE".
+func (p Privileges) String() string {
+	var names []string
+	for i, name := range privilegeNames {
+		if p&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// privilegeByName returns the privilege a GRANT or REVOKE names, given in
+// lower case as the parser returns it.
+func privilegeByName(name string) (Privileges, bool) {
+	if name == "temp" {
+		return Temporary, true
+	}
+	for i, n := range privilegeNames {
+		if strings.ToLower(n) == name {
+			return 1 << i, true
+		}
+	}
+	return 0, false
+}
+
+// ParsePrivileges parses a comma-separated list of privilege names, such
+// as "SELECT, UPDATE", in the form has_table_privilege accepts.
+func ParsePrivileges(s string) (Privileges, error) {
+	var out Privileges
+	for _, part := range strings.Split(s, ",") {
+		name := strings.ToLower(strings.Join(strings.Fields(part), " "))
+		p, ok := privilegeByName(name)
+		if !ok {
+			return 0, &catalog.Error{Code: catalog.CodeInvalidParameterValue, Message: fmt.Sprintf("unrecognized privilege type: %q", strings.TrimSpace(part))}
+		}
+		out |= p
+	}
+	return out, nil
+}
+
+// Item is an entry of an access control list: the privileges a grantor
+// has granted to a grantee.
+type Item struct {
+	Grantee string // role name; "" for PUBLIC
+	Grantor string
+
+	Privileges   Privileges
+	GrantOptions Privileges // the privileges the grantee may grant to others; a subset of Privileges
+}
+
+// String returns the item in aclitem text form, e.g. "bob=r*w/alice": the
+// grantee (empty for PUBLIC), the letter of each privilege followed by
+// "*" if it is held with grant option, and the grantor.
+func (it Item) String() string {
+	var b strings.Builder
+	b.WriteString(quoteRole(it.Grantee))
+	b.WriteByte('=')
+	for i := range privilegeLetters {
+		bit := Privileges(1) << i
+		if it.Privileges&bit != 0 {
+			b.WriteByte(privilegeLetters[i])
+			if it.GrantOptions&bit != 0 {
+				b.WriteByte('*')
+			}
+		}
+	}
+	b.WriteByte('/')
+	b.WriteString(quoteRole(it.Grantor))
+	return b.String()
+}
+
+// quoteRole quotes a role name for aclitem output when it holds anything
+// but letters, digits and underscores, as putid does.
+func quoteRole(name string) string {
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_') {
+			return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+		}
+	}
+	return name
+}
+
+// ACL is an access control list.
+type ACL []Item
+
+// String returns the list in aclitem[] text form, e.g.
+// "{alice=arwdDxtm/alice,=r/alice}".
+func (a ACL) String() string {
+	parts := make([]string, len(a))
+	for i, it := range a {
+		parts[i] = quoteArrayElement(it.String())
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// quoteArrayElement quotes an element of an array's text form when it
+// holds characters that would otherwise be taken as array syntax.
+func quoteArrayElement(s string) string {
+	if s != "" && !strings.ContainsAny(s, "{},\"\\ \t\n\r\v\f") && !strings.EqualFold(s, "NULL") {
+		return s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// Has reports whether the list gives grantee, by name, every privilege
+// of p. It does not consider role membership; see Model.Has for that.
+func (a ACL) Has(grantee string, p Privileges) bool {
+	var held Privileges
+	for _, it := range a {
+		if it.Grantee == grantee {
+			held |= it.Privileges
+		}
+	}
+	return held&p == p
+}
+
+// kind is the kind of an object with an ACL, using the codes of
+// pg_default_acl.defaclobjtype where they exist.
+type kind byte
+
+const (
+	kindRelation kind = 'r'
+	kindSequence kind = 'S'
+	kindFunction kind = 'f'
+	kindType     kind = 'T'
+	kindSchema   kind = 'n'
+	kindColumn   kind = 'c'
+)
+
+// allPrivileges returns the privileges that apply to objects of kind k.
+func allPrivileges(k kind) Privileges {
+	switch k {
+	case kindRelation:
+		return allRelation
+	case kindSequence:
+		return allSequence
+	case kindFunction:
+		return allFunction
+	case kindType:
+		return allType
+	case kindSchema:
+		return allSchema
+	case kindColumn:
+		return allColumn
+	}
+	return 0
+}
+
+// defaultACL returns the ACL an object of kind k owned by owner has when
+// no privileges have been granted or revoked on it, following acldefault:
+// the owner holds every privilege, and PUBLIC may execute functions and
+// use types.
+func defaultACL(k kind, owner string) ACL {
+	var world, own Privileges
+	switch k {
+	case kindFunction:
+		world, own = Execute, Execute
+	case kindType:
+		world, own = Usage, Usage
+	case kindColumn:
+	default:
+		own = allPrivileges(k)
+	}
+	var out ACL
+	if world != 0 {
+		out = append(out, Item{Grantor: owner, Privileges: world})
+	}
+	if own != 0 {
+		out = append(out, Item{Grantee: owner, Grantor: owner, Privileges: own})
+	}
+	return out
+}
+
+// newOwner rewrites an ACL for a change of owner, as aclnewowner does:
+// the old owner is replaced by the new one as grantee and grantor, and
+// items that then coincide are merged.
+func newOwner(a ACL, oldOwner, owner string) ACL {
+	var out ACL
+	for _, it := range a {
+		if it.Grantee == oldOwner {
+			it.Grantee = owner
+		}
+		if it.Grantor == oldOwner {
+			it.Grantor = owner
+		}
+		merged := false
+		for i := range out {
+			if out[i].Grantee == it.Grantee && out[i].Grantor == it.Grantor {
+				out[i].Privileges |= it.Privileges
+				out[i].GrantOptions |= it.GrantOptions
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+// equalACL reports whether two ACLs hold the same items, in any order.
+func equalACL(a, b ACL) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sorted := func(l ACL) ACL {
+		out := append(ACL(nil), l...)
+		sort.Slice(out, func(i, j int) bool {
+			if out[i].Grantee != out[j].Grantee {
+				return out[i].Grantee < out[j].Grantee
+			}
+			return out[i].Grantor < out[j].Grantor
+		})
+		return out
+	}
+	sa, sb := sorted(a), sorted(b)
+	for i := range sa {
+		if sa[i] != sb[i] {
+			return false
+		}
+	}
+	return true
+}
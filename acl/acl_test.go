@@ -0,0 +1,228 @@
+package acl
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgparser/catalog"
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// model returns a model that has run sql as the superuser postgres.
+func model(t *testing.T, sql string) *Model {
+	t.Helper()
+	m := New(catalog.New())
+	if err := m.Exec(sql); err != nil {
+		t.Fatalf("Exec error: %v", err)
+	}
+	return m
+}
+
+// checkACL checks the ACL of an object.
+func checkACL(t *testing.T, m *Model, objType nodes.ObjectType, name, want string) {
+	t.Helper()
+	acl, err := m.ACL(objType, name)
+	if err != nil {
+		t.Fatalf("ACL(%q) error: %v", name, err)
+	}
+	if got := acl.String(); got != want {
+		t.Errorf("ACL(%q) = %s, want %s", name, got, want)
+	}
+}
+
+// checkHas checks whether role holds privileges on a table.
+func checkHas(t *testing.T, m *Model, role, table string, p Privileges, want bool) {
+	t.Helper()
+	got, err := m.Has(role, nodes.OBJECT_TABLE, table, p)
+	if err != nil {
+		t.Fatalf("Has(%q, %q) error: %v", role, table, err)
+	}
+	if got != want {
+		t.Errorf("Has(%q, %q, %s) = %v, want %v", role, table, p, got, want)
+	}
+}
+
+// checkError checks that sql fails with the given message.
+func checkError(t *testing.T, m *Model, sql, want string) {
+	t.Helper()
+	err := m.Exec(sql)
+	var e *catalog.Error
+	if !errors.As(err, &e) {
+		t.Fatalf("Exec(%q) error = %v, want %q", sql, err, want)
+	}
+	if e.Message != want {
+		t.Errorf("Exec(%q) error = %q, want %q", sql, e.Message, want)
+	}
+}
+
+func TestGrant(t *testing.T) {
+	m := model(t, `
+		CREATE TABLE t (a int, b int);
+		CREATE FUNCTION f(int) RETURNS int LANGUAGE sql AS 'SELECT 1';
+		GRANT SELECT ON t TO alice;
+		REVOKE EXECUTE ON FUNCTION f(int) FROM PUBLIC;
+		CREATE ROLE "Bob Smith";
+		GRANT SELECT, INSERT ON TABLE t TO "Bob Smith" WITH GRANT OPTION;
+	`)
+	checkACL(t, m, nodes.OBJECT_TABLE, "t", `{postgres=arwdDxtm/postgres,alice=r/postgres,"\"Bob Smith\"=a*r*/postgres"}`)
+	checkACL(t, m, nodes.OBJECT_FUNCTION, "f(int)", "{postgres=X/postgres}")
+	checkACL(t, m, nodes.OBJECT_SCHEMA, "public", "{pg_database_owner=UC/pg_database_owner,=U/pg_database_owner}")
+	checkHas(t, m, "alice", "t", Select, true)
+	checkHas(t, m, "alice", "t", Select|Update, false)
+
+	if err := m.Exec("REVOKE ALL ON t FROM alice, \"Bob Smith\""); err != nil {
+		t.Fatal(err)
+	}
+	checkACL(t, m, nodes.OBJECT_TABLE, "t", "{postgres=arwdDxtm/postgres}")
+}
+
+func TestInheritance(t *testing.T) {
+	m := model(t, `
+		CREATE ROLE readers;
+		CREATE ROLE alice IN ROLE readers;
+		CREATE ROLE carol NOINHERIT;
+		GRANT readers TO carol;
+		CREATE ROLE dave;
+		GRANT readers TO dave WITH INHERIT FALSE;
+		CREATE TABLE t (a int);
+		CREATE TABLE pub (a int);
+		GRANT SELECT ON t TO readers;
+		GRANT SELECT ON pub TO PUBLIC;
+	`)
+	checkHas(t, m, "alice", "t", Select, true)
+	checkHas(t, m, "carol", "t", Select, false)
+	checkHas(t, m, "dave", "t", Select, false)
+	checkHas(t, m, "dave", "pub", Select, true)
+	checkHas(t, m, "postgres", "t", Insert|Delete, true)
+}
+
+func TestDefaultPrivileges(t *testing.T) {
+	m := model(t, `
+		CREATE SCHEMA app;
+		CREATE TABLE app.before (a int);
+		ALTER DEFAULT PRIVILEGES IN SCHEMA app GRANT SELECT ON TABLES TO readers;
+		ALTER DEFAULT PRIVILEGES REVOKE EXECUTE ON FUNCTIONS FROM PUBLIC;
+		CREATE TABLE app.after (a int);
+		CREATE TABLE public.other (a int);
+		CREATE FUNCTION app.f() RETURNS int LANGUAGE sql AS 'SELECT 1';
+	`)
+	checkACL(t, m, nodes.OBJECT_TABLE, "app.before", "{postgres=arwdDxtm/postgres}")
+	checkACL(t, m, nodes.OBJECT_TABLE, "app.after", "{postgres=arwdDxtm/postgres,readers=r/postgres}")
+	checkACL(t, m, nodes.OBJECT_TABLE, "other", "{postgres=arwdDxtm/postgres}")
+	checkACL(t, m, nodes.OBJECT_FUNCTION, "app.f()", "{postgres=X/postgres}")
+
+	acl, err := m.DefaultACL("postgres", "app", nodes.OBJECT_TABLE)
+	if err != nil || acl.String() != "{readers=r/postgres}" {
+		t.Errorf("DefaultACL = %v, %v", acl, err)
+	}
+	if err := m.Exec("ALTER DEFAULT PRIVILEGES GRANT EXECUTE ON FUNCTIONS TO PUBLIC"); err != nil {
+		t.Fatal(err)
+	}
+	if acl, _ := m.DefaultACL("postgres", "", nodes.OBJECT_FUNCTION); acl != nil {
+		t.Errorf("DefaultACL after restoring the default = %v, want nil", acl)
+	}
+	checkError(t, m, "ALTER DEFAULT PRIVILEGES IN SCHEMA app GRANT USAGE ON SCHEMAS TO readers",
+		"cannot use IN SCHEMA clause when using GRANT/REVOKE ON SCHEMAS")
+	checkError(t, m, "SET ROLE readers; ALTER DEFAULT PRIVILEGES FOR ROLE postgres GRANT SELECT ON TABLES TO readers",
+		"permission denied to change default privileges")
+}
+
+func TestGrantOptions(t *testing.T) {
+	m := model(t, `
+		CREATE TABLE t (a int);
+		GRANT SELECT ON t TO alice WITH GRANT OPTION;
+		SET ROLE alice;
+		GRANT SELECT ON t TO bob;
+		RESET ROLE;
+	`)
+	checkACL(t, m, nodes.OBJECT_TABLE, "t", "{postgres=arwdDxtm/postgres,alice=r*/postgres,bob=r/alice}")
+	checkError(t, m, "REVOKE SELECT ON t FROM alice", "dependent privileges exist")
+	if err := m.Exec("REVOKE GRANT OPTION FOR SELECT ON t FROM alice CASCADE"); err != nil {
+		t.Fatal(err)
+	}
+	checkACL(t, m, nodes.OBJECT_TABLE, "t", "{postgres=arwdDxtm/postgres,alice=r/postgres}")
+
+	var warnings []string
+	m.WarningFunc = func(msg string) { warnings = append(warnings, msg) }
+	if err := m.Exec("SET ROLE alice; GRANT SELECT ON t TO bob"); err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 1 || warnings[0] != `no privileges were granted for "t"` {
+		t.Errorf("warnings = %q", warnings)
+	}
+	checkError(t, m, "SET ROLE bob; GRANT SELECT ON t TO carol", "permission denied for table t")
+}
+
+func TestOwnerChange(t *testing.T) {
+	m := model(t, `
+		CREATE TABLE t (a int);
+		GRANT SELECT ON t TO alice;
+		ALTER TABLE t OWNER TO bob;
+	`)
+	checkACL(t, m, nodes.OBJECT_TABLE, "t", "{bob=arwdDxtm/bob,alice=r/bob}")
+	checkHas(t, m, "bob", "t", Truncate, true)
+}
+
+func TestColumns(t *testing.T) {
+	m := model(t, `
+		CREATE TABLE t (a int, b int);
+		GRANT SELECT (a), UPDATE (a, b) ON t TO alice;
+	`)
+	acl, err := m.ColumnACL("t", "a")
+	if err != nil || acl.String() != "{alice=rw/postgres}" {
+		t.Errorf("ColumnACL(a) = %v, %v", acl, err)
+	}
+	for _, c := range []struct {
+		column string
+		p      Privileges
+		want   bool
+	}{
+		{"a", Select | Update, true},
+		{"b", Select, false},
+		{"b", Update, true},
+	} {
+		if got, err := m.HasColumn("alice", "t", c.column, c.p); err != nil || got != c.want {
+			t.Errorf("HasColumn(%s, %s) = %v, %v; want %v", c.column, c.p, got, err, c.want)
+		}
+	}
+	checkHas(t, m, "alice", "t", Select, false)
+	if err := m.Exec("REVOKE UPDATE ON t FROM alice"); err != nil {
+		t.Fatal(err)
+	}
+	if acl, _ := m.ColumnACL("t", "b"); acl.String() != "{}" {
+		t.Errorf("ColumnACL(b) after REVOKE = %s, want {}", acl)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	m := model(t, "CREATE TABLE t (a int); CREATE SEQUENCE s;")
+	for _, c := range []struct{ sql, want string }{
+		{"GRANT SELECT ON t TO PUBLIC WITH GRANT OPTION", "grant options can only be granted to roles"},
+		{"GRANT EXECUTE ON t TO alice", "invalid privilege type EXECUTE for table"},
+		{"GRANT SELECT ON SEQUENCE t TO alice", `"t" is not a sequence`},
+		{"GRANT SELECT ON missing TO alice", `relation "missing" does not exist`},
+		{"GRANT SELECT (nope) ON t TO alice", `column "nope" of relation "t" does not exist`},
+		{"CREATE ROLE x; CREATE ROLE x", `role "x" already exists`},
+		{"CREATE ROLE g1; CREATE ROLE g2 IN ROLE g1; GRANT g2 TO g1", `role "g2" is a member of role "g1"`},
+	} {
+		checkError(t, m, c.sql, c.want)
+	}
+	// A failing statement leaves no trace.
+	checkACL(t, m, nodes.OBJECT_TABLE, "t", "{postgres=arwdDxtm/postgres}")
+	checkACL(t, m, nodes.OBJECT_SEQUENCE, "s", "{postgres=rwU/postgres}")
+}
+
+func TestParsePrivileges(t *testing.T) {
+	p, err := ParsePrivileges("select, Update ,temp")
+	if err != nil || p != Select|Update|Temporary {
+		t.Errorf("ParsePrivileges = %v, %v", p, err)
+	}
+	if _, err := ParsePrivileges("select, frob"); err == nil || !strings.Contains(err.Error(), "frob") {
+		t.Errorf("ParsePrivileges(frob) error = %v", err)
+	}
+	it := Item{Grantor: "alice", Privileges: Select | Usage, GrantOptions: Usage}
+	if got := (ACL{it}).String(); got != "{=rU*/alice}" {
+		t.Errorf("String = %s", got)
+	}
+}
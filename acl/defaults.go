@@ -0,0 +1,202 @@
+package acl
+
+import (
+	"github.com/pgplex/pgparser/catalog"
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// DefaultACL returns the default privileges ALTER DEFAULT PRIVILEGES has
+// set for the objects of type objType that role creates: in schema, or,
+// if schema is "", in any schema. objType is OBJECT_TABLE,
+// OBJECT_SEQUENCE, OBJECT_FUNCTION, OBJECT_TYPE or OBJECT_SCHEMA. The
+// result is nil if no such default privileges are set, as for an absent
+// pg_default_acl row.
+func (m *Model) DefaultACL(role, schema string, objType nodes.ObjectType) (ACL, error) {
+	k, ok := defaultKind(objType)
+	if !ok {
+		return nil, errorf(catalog.CodeFeatureNotSupported, "default privileges of object type %d are not modelled", int(objType))
+	}
+	key := defaultKey{role: role, kind: k}
+	if schema != "" {
+		if key.schema = m.cat.Schema(schema); key.schema == nil {
+			return nil, errorf(catalog.CodeInvalidSchemaName, "schema %q does not exist", schema)
+		}
+	}
+	return append(ACL(nil), m.defaults[key]...), nil
+}
+
+// defaultKind returns the kind of objects ALTER DEFAULT PRIVILEGES ON
+// objType applies to.
+func defaultKind(objType nodes.ObjectType) (kind, bool) {
+	switch objType {
+	case nodes.OBJECT_TABLE:
+		return kindRelation, true
+	case nodes.OBJECT_SEQUENCE:
+		return kindSequence, true
+	case nodes.OBJECT_FUNCTION, nodes.OBJECT_PROCEDURE, nodes.OBJECT_ROUTINE:
+		return kindFunction, true
+	case nodes.OBJECT_TYPE, nodes.OBJECT_DOMAIN:
+		return kindType, true
+	case nodes.OBJECT_SCHEMA:
+		return kindSchema, true
+	}
+	return 0, false
+}
+
+// alterDefaultPrivileges implements ALTER DEFAULT PRIVILEGES, following
+// SetDefaultACLsInSchemas and SetDefaultACL.
+func (m *Model) alterDefaultPrivileges(n *nodes.AlterDefaultPrivilegesStmt) error {
+	var roles []string
+	var schemas []*catalog.Schema
+	for _, item := range listItems(n.Options) {
+		d, ok := item.(*nodes.DefElem)
+		if !ok {
+			continue
+		}
+		switch d.Defname {
+		case "roles":
+			for _, spec := range roleSpecs(d.Arg) {
+				role, err := m.existingRole(spec)
+				if err != nil {
+					return err
+				}
+				roles = append(roles, role)
+			}
+		case "schemas":
+			for _, name := range stringList(asList(d.Arg)) {
+				s := m.cat.Schema(name)
+				if s == nil {
+					return errorf(catalog.CodeInvalidSchemaName, "schema %q does not exist", name)
+				}
+				schemas = append(schemas, s)
+			}
+		}
+	}
+	if roles == nil {
+		roles = []string{m.cat.User}
+	}
+	if schemas == nil {
+		schemas = []*catalog.Schema{nil}
+	}
+	g := n.Action
+	k, ok := defaultKind(g.Objtype)
+	if !ok {
+		return nil
+	}
+	if k == kindSchema && schemas[0] != nil {
+		return errorf(catalog.CodeInvalidGrantOperation, "cannot use IN SCHEMA clause when using GRANT/REVOKE ON SCHEMAS")
+	}
+	privs, cols, _, err := requested(g, k, map[kind]string{kindRelation: "relation", kindSequence: "sequence", kindFunction: "function", kindType: "type", kindSchema: "schema"}[k])
+	if err != nil {
+		return err
+	}
+	if cols != nil {
+		return errorf(catalog.CodeInvalidGrantOperation, "default privileges cannot be set for columns")
+	}
+	grantees, err := m.grantees(g)
+	if err != nil {
+		return err
+	}
+	if m.known == nil {
+		m.startTracking()
+	}
+	for _, role := range roles {
+		if !m.HasPrivsOfRole(m.cat.User, role) {
+			return errorf(catalog.CodeInsufficientPrivilege, "permission denied to change default privileges")
+		}
+		for _, s := range schemas {
+			key := defaultKey{role: role, schema: s, kind: k}
+			old, had := m.defaults[key]
+			acl := old
+			if !had && s == nil {
+				acl = defaultACL(k, role)
+			}
+			acl, err := m.mergeGrant(acl, g, grantees, privs, role, role)
+			if err != nil {
+				return err
+			}
+			if len(acl) == 0 || s == nil && equalACL(acl, defaultACL(k, role)) {
+				delete(m.defaults, key)
+			} else {
+				m.defaults[key] = acl
+			}
+			m.undo = append(m.undo, func() {
+				if had {
+					m.defaults[key] = old
+				} else {
+					delete(m.defaults, key)
+				}
+			})
+		}
+	}
+	return nil
+}
+
+// startTracking records the objects that exist, so that the objects later
+// statements create can be told apart and given default privileges.
+func (m *Model) startTracking() {
+	known := map[any]bool{}
+	m.eachObject(func(o object) { known[o.key] = true })
+	setField(m, &m.known, known)
+}
+
+// applyDefaults gives the objects created since the last call the default
+// privileges set for their owners, following get_user_default_acl.
+func (m *Model) applyDefaults() {
+	if m.known == nil {
+		return
+	}
+	m.eachObject(func(o object) {
+		if m.known[o.key] {
+			return
+		}
+		m.known[o.key] = true
+		glob, ok := m.defaults[defaultKey{role: o.owner, kind: o.kind}]
+		if !ok {
+			glob = defaultACL(o.kind, o.owner)
+		}
+		acl := append(ACL(nil), glob...)
+		if o.kind != kindSchema {
+			s := m.schemaOf(o)
+			for _, it := range m.defaults[defaultKey{role: o.owner, schema: s, kind: o.kind}] {
+				acl = addItem(acl, it)
+			}
+		}
+		if !equalACL(acl, defaultACL(o.kind, o.owner)) {
+			m.acls[o.key] = &entry{acl: acl, owner: o.owner}
+		}
+	})
+}
+
+// schemaOf returns the schema an object is in.
+func (m *Model) schemaOf(o object) *catalog.Schema {
+	switch v := o.key.(type) {
+	case *catalog.Relation:
+		return v.Schema
+	case *catalog.Function:
+		return v.Schema
+	case *catalog.Type:
+		return v.Schema
+	}
+	return nil
+}
+
+// eachObject calls fn for every schema, relation, routine and type that
+// default privileges apply to.
+func (m *Model) eachObject(fn func(object)) {
+	for _, s := range m.cat.Schemas() {
+		fn(m.schemaObject(s))
+		for _, rel := range s.Relations() {
+			if rel.Kind == catalog.RelKindIndex || rel.Kind == catalog.RelKindPartitionedIndex {
+				continue
+			}
+			fn(m.relationObject(rel))
+		}
+		for _, f := range s.Functions() {
+			fn(functionObject(f))
+		}
+		for _, t := range s.Types() {
+			fn(typeObject(t))
+		}
+	}
+}
@@ -0,0 +1,891 @@
+package acl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pgplex/pgparser/catalog"
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+)
+
+// Model is a catalog together with the roles and access privileges that
+// replayed statements have set up.
+type Model struct {
+	// WarningFunc, if set, receives the WARNING messages PostgreSQL would
+	// send, such as when a GRANT grants fewer privileges than asked for
+	// because the current role lacks their grant options.
+	WarningFunc func(message string)
+
+	cat       *catalog.Catalog
+	bootstrap string // the role the model started as, a superuser
+	session   string // the session user, as SET SESSION AUTHORIZATION sets it
+
+	roles   map[string]*Role
+	members []*Membership
+
+	acls     map[any]*entry
+	defaults map[defaultKey]ACL
+
+	// known holds the objects that existed when the first default
+	// privileges were set, and those created since. It is nil while no
+	// default privileges have been set.
+	known map[any]bool
+
+	// undo holds closures reverting the changes made by the statement
+	// being applied, so that a failing statement leaves no trace.
+	undo []func()
+}
+
+// entry is the ACL of an object. owner is the owner the ACL was written
+// for; a change of owner is applied to it when it is next used.
+type entry struct {
+	acl   ACL
+	owner string
+}
+
+// defaultKey identifies the default privileges ALTER DEFAULT PRIVILEGES
+// sets for the objects a role creates, in one schema or, for a nil
+// schema, in all of them.
+type defaultKey struct {
+	role   string
+	schema *catalog.Schema
+	kind   kind
+}
+
+// New returns a model of the privileges on the objects of c. Statements
+// are run as c.User, which is taken to be a superuser, as the role
+// restoring a dump usually is; it defaults to "postgres".
+func New(c *catalog.Catalog) *Model {
+	if c.User == "" {
+		c.User = "postgres"
+	}
+	m := &Model{
+		cat:       c,
+		bootstrap: c.User,
+		session:   c.User,
+		roles:     map[string]*Role{},
+		acls:      map[any]*entry{},
+		defaults:  map[defaultKey]ACL{},
+	}
+	m.roles[c.User] = &Role{Name: c.User, Superuser: true, Inherit: true}
+	m.roles[databaseOwner] = &Role{Name: databaseOwner, Inherit: true}
+	// The built-in schemas, which the catalog leaves without an owner,
+	// have the privileges initdb gives them.
+	for _, s := range c.Schemas() {
+		if s.Owner == "" {
+			owner := m.schemaOwner(s)
+			m.acls[s] = &entry{owner: owner, acl: ACL{
+				{Grantee: owner, Grantor: owner, Privileges: allSchema},
+				{Grantor: owner, Privileges: Usage},
+			}}
+		}
+	}
+	return m
+}
+
+// Catalog returns the catalog the model applies DDL to.
+func (m *Model) Catalog() *catalog.Catalog {
+	return m.cat
+}
+
+// User returns the current role.
+func (m *Model) User() string {
+	return m.cat.User
+}
+
+// Exec parses sql and applies each statement in turn. It stops at the
+// first statement that fails; the changes of earlier statements are kept.
+func (m *Model) Exec(sql string) error {
+	stmts, err := parser.Parse(sql)
+	if err != nil {
+		return err
+	}
+	for _, stmt := range listItems(stmts) {
+		if err := m.Apply(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Apply applies a single statement. If it fails, the model is left as it
+// was before the call.
+func (m *Model) Apply(stmt nodes.Node) (err error) {
+	m.undo = m.undo[:0]
+	defer func() {
+		if err != nil {
+			for i := len(m.undo) - 1; i >= 0; i-- {
+				m.undo[i]()
+			}
+		}
+		m.undo = m.undo[:0]
+	}()
+	if raw, ok := stmt.(*nodes.RawStmt); ok {
+		stmt = raw.Stmt
+	}
+	switch n := stmt.(type) {
+	case *nodes.GrantStmt:
+		return m.grant(n)
+	case *nodes.AlterDefaultPrivilegesStmt:
+		return m.alterDefaultPrivileges(n)
+	case *nodes.GrantRoleStmt:
+		return m.grantRole(n)
+	case *nodes.CreateRoleStmt:
+		return m.createRole(n)
+	case *nodes.AlterRoleStmt:
+		return m.alterRole(n)
+	case *nodes.DropRoleStmt:
+		return m.dropRole(n)
+	case *nodes.VariableSetStmt:
+		if n.Name == "role" || n.Name == "session_authorization" {
+			return m.setRole(n)
+		}
+	}
+	if err := m.cat.Apply(stmt); err != nil {
+		return err
+	}
+	m.applyDefaults()
+	return nil
+}
+
+// ACL returns the access control list of an object, named as in GRANT:
+// objType is OBJECT_TABLE (for any relation), OBJECT_SEQUENCE,
+// OBJECT_FUNCTION, OBJECT_PROCEDURE, OBJECT_ROUTINE, OBJECT_SCHEMA,
+// OBJECT_TYPE or OBJECT_DOMAIN, and name is the object's name as SQL
+// would write it, with an argument list for routines, as in
+// "app.total(integer)". Unqualified names are looked up in the search
+// path. For objects whose privileges were never changed, the ACL is the
+// default one PostgreSQL's acldefault gives, rather than NULL.
+func (m *Model) ACL(objType nodes.ObjectType, name string) (ACL, error) {
+	o, err := m.lookup(objType, name)
+	if err != nil {
+		return nil, err
+	}
+	return m.aclOf(o), nil
+}
+
+// ColumnACL returns the access control list of a column of a table. It
+// lists only the privileges granted on the column itself; those granted
+// on the table apply to all its columns.
+func (m *Model) ColumnACL(table, column string) (ACL, error) {
+	o, err := m.lookupColumn(table, column)
+	if err != nil {
+		return nil, err
+	}
+	return m.aclOf(o), nil
+}
+
+// Has reports whether role holds every privilege of p on an object, named
+// as for ACL, directly, through PUBLIC or through the roles whose
+// privileges it inherits, as has_table_privilege and its kin do.
+// Superusers hold every privilege. As with those functions, privileges
+// needed to reach the object, such as USAGE on its schema, are not
+// considered.
+func (m *Model) Has(role string, objType nodes.ObjectType, name string, p Privileges) (bool, error) {
+	o, err := m.lookup(objType, name)
+	if err != nil {
+		return false, err
+	}
+	if p&^allPrivileges(o.kind) != 0 {
+		return false, errorf(catalog.CodeInvalidParameterValue, "invalid privilege type %s for %s", (p &^ allPrivileges(o.kind)).String(), o.noun)
+	}
+	return m.holds(role, o, p), nil
+}
+
+// HasColumn reports whether role holds every privilege of p on a column
+// of a table, by grants on the column or on the table, as
+// has_column_privilege does.
+func (m *Model) HasColumn(role, table, column string, p Privileges) (bool, error) {
+	o, err := m.lookupColumn(table, column)
+	if err != nil {
+		return false, err
+	}
+	if p&^allColumn != 0 {
+		return false, errorf(catalog.CodeInvalidParameterValue, "invalid privilege type %s for column", (p &^ allColumn).String())
+	}
+	rel := m.relationObject(o.key.(*catalog.Column).Relation)
+	if m.holds(role, rel, p) {
+		return true, nil
+	}
+	return m.holds(role, o, p&^m.mask(role, rel, p, false)), nil
+}
+
+// holds reports whether role holds every privilege of p on o.
+func (m *Model) holds(role string, o object, p Privileges) bool {
+	if m.superuser(role) {
+		return true
+	}
+	return m.mask(role, o, p, false) == p
+}
+
+// object is an object with an ACL.
+type object struct {
+	key   any // *catalog.Relation, *catalog.Column, *catalog.Function, *catalog.Schema or *catalog.Type
+	kind  kind
+	owner string
+	noun  string // as in "permission denied for table t"
+	name  string
+}
+
+// describe returns the object as messages name it.
+func (o object) describe() string {
+	if col, ok := o.key.(*catalog.Column); ok {
+		return fmt.Sprintf("column %q of relation %q", col.Name, col.Relation.Name)
+	}
+	return o.noun + " " + o.name
+}
+
+// quoted returns the object's name as GRANT's warnings quote it.
+func (o object) quoted() string {
+	if col, ok := o.key.(*catalog.Column); ok {
+		return fmt.Sprintf("column %q of relation %q", col.Name, col.Relation.Name)
+	}
+	return fmt.Sprintf("%q", o.name)
+}
+
+func (m *Model) relationObject(r *catalog.Relation) object {
+	o := object{key: r, kind: kindRelation, owner: r.Owner, noun: "table", name: r.Name}
+	switch r.Kind {
+	case catalog.RelKindSequence:
+		o.kind, o.noun = kindSequence, "sequence"
+	case catalog.RelKindView:
+		o.noun = "view"
+	case catalog.RelKindMatView:
+		o.noun = "materialized view"
+	case catalog.RelKindForeignTable:
+		o.noun = "foreign table"
+	}
+	return o
+}
+
+func columnObject(c *catalog.Column) object {
+	return object{key: c, kind: kindColumn, owner: c.Relation.Owner, noun: "column", name: c.Name}
+}
+
+func functionObject(f *catalog.Function) object {
+	noun := "function"
+	if f.Kind == catalog.FuncKindProcedure {
+		noun = "procedure"
+	}
+	return object{key: f, kind: kindFunction, owner: f.Owner, noun: noun, name: f.Name}
+}
+
+// databaseOwner is the role standing for the owner of the database,
+// which owns the public schema.
+const databaseOwner = "pg_database_owner"
+
+func (m *Model) schemaObject(s *catalog.Schema) object {
+	return object{key: s, kind: kindSchema, owner: m.schemaOwner(s), noun: "schema", name: s.Name}
+}
+
+// schemaOwner returns the owner of a schema. The catalog leaves the
+// built-in schemas without one.
+func (m *Model) schemaOwner(s *catalog.Schema) string {
+	switch {
+	case s.Owner != "":
+		return s.Owner
+	case s.Name == "public":
+		return databaseOwner
+	}
+	return m.bootstrap
+}
+
+func typeObject(t *catalog.Type) object {
+	noun := "type"
+	if t.Kind == catalog.TypeKindDomain {
+		noun = "domain"
+	}
+	return object{key: t, kind: kindType, owner: t.Owner, noun: noun, name: t.Name}
+}
+
+// aclOf returns the current ACL of an object.
+func (m *Model) aclOf(o object) ACL {
+	e := m.acls[o.key]
+	if e == nil {
+		return defaultACL(o.kind, o.owner)
+	}
+	if e.owner != o.owner {
+		return newOwner(e.acl, e.owner, o.owner)
+	}
+	return append(ACL(nil), e.acl...)
+}
+
+// setACL sets the ACL of an object.
+func (m *Model) setACL(o object, acl ACL) {
+	old, had := m.acls[o.key]
+	m.acls[o.key] = &entry{acl: acl, owner: o.owner}
+	m.undo = append(m.undo, func() {
+		if had {
+			m.acls[o.key] = old
+		} else {
+			delete(m.acls, o.key)
+		}
+	})
+}
+
+// mask returns the privileges of want that role holds on o, or, with
+// grantOption, those it holds the grant option of, following aclmask.
+// The owner, and the roles with its privileges, implicitly hold every
+// grant option.
+func (m *Model) mask(role string, o object, want Privileges, grantOption bool) Privileges {
+	return m.aclMask(m.aclOf(o), role, o.owner, want, grantOption)
+}
+
+func (m *Model) aclMask(acl ACL, role, owner string, want Privileges, grantOption bool) Privileges {
+	var result Privileges
+	if grantOption && m.HasPrivsOfRole(role, owner) {
+		return want
+	}
+	held := func(it Item) Privileges {
+		if grantOption {
+			return it.GrantOptions & want
+		}
+		return it.Privileges & want
+	}
+	for _, it := range acl {
+		if it.Grantee == "" || it.Grantee == role {
+			result |= held(it)
+		}
+	}
+	if result == want {
+		return result
+	}
+	for _, it := range acl {
+		if it.Grantee != "" && it.Grantee != role && held(it)&^result != 0 && m.HasPrivsOfRole(role, it.Grantee) {
+			result |= held(it)
+		}
+	}
+	return result
+}
+
+// lookup finds an object named as for ACL.
+func (m *Model) lookup(objType nodes.ObjectType, name string) (object, error) {
+	keyword := map[nodes.ObjectType]string{
+		nodes.OBJECT_TABLE:         "TABLE",
+		nodes.OBJECT_VIEW:          "TABLE",
+		nodes.OBJECT_MATVIEW:       "TABLE",
+		nodes.OBJECT_FOREIGN_TABLE: "TABLE",
+		nodes.OBJECT_SEQUENCE:      "SEQUENCE",
+		nodes.OBJECT_FUNCTION:      "FUNCTION",
+		nodes.OBJECT_AGGREGATE:     "FUNCTION",
+		nodes.OBJECT_PROCEDURE:     "PROCEDURE",
+		nodes.OBJECT_ROUTINE:       "ROUTINE",
+		nodes.OBJECT_SCHEMA:        "SCHEMA",
+		nodes.OBJECT_TYPE:          "TYPE",
+		nodes.OBJECT_DOMAIN:        "DOMAIN",
+	}[objType]
+	if keyword == "" {
+		return object{}, errorf(catalog.CodeFeatureNotSupported, "privileges of object type %d are not modelled", int(objType))
+	}
+	g, err := parseGrant(keyword + " " + name)
+	if err != nil {
+		return object{}, err
+	}
+	objs, err := m.grantObjects(g)
+	if err != nil {
+		return object{}, err
+	}
+	if len(objs) != 1 {
+		return object{}, errorf(catalog.CodeSyntaxError, "%q does not name a single object", name)
+	}
+	return objs[0], nil
+}
+
+// lookupColumn finds a column of a table named as for ACL.
+func (m *Model) lookupColumn(table, column string) (object, error) {
+	o, err := m.lookup(nodes.OBJECT_TABLE, table)
+	if err != nil {
+		return object{}, err
+	}
+	rel := o.key.(*catalog.Relation)
+	col := findColumn(rel, column)
+	if col == nil {
+		return object{}, errorf(catalog.CodeUndefinedColumn, "column %q of relation %q does not exist", column, rel.Name)
+	}
+	return columnObject(col), nil
+}
+
+// parseGrant parses the object part of a GRANT statement, such as
+// "TABLE app.t".
+func parseGrant(target string) (*nodes.GrantStmt, error) {
+	stmts, err := parser.Parse("GRANT ALL ON " + target + " TO PUBLIC")
+	if err != nil {
+		return nil, err
+	}
+	items := listItems(stmts)
+	if len(items) != 1 {
+		return nil, errorf(catalog.CodeSyntaxError, "invalid object name %q", target)
+	}
+	g, ok := items[0].(*nodes.GrantStmt)
+	if !ok || g.Targtype != nodes.ACL_TARGET_OBJECT {
+		return nil, errorf(catalog.CodeSyntaxError, "invalid object name %q", target)
+	}
+	return g, nil
+}
+
+func findColumn(rel *catalog.Relation, name string) *catalog.Column {
+	for _, c := range rel.Columns {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// grantObjects resolves the objects of a GRANT or REVOKE. It returns no
+// objects, and no error, for object types the model does not track.
+func (m *Model) grantObjects(n *nodes.GrantStmt) ([]object, error) {
+	if n.Targtype == nodes.ACL_TARGET_ALL_IN_SCHEMA {
+		return m.allInSchema(n)
+	}
+	var out []object
+	for _, item := range listItems(n.Objects) {
+		switch n.Objtype {
+		case nodes.OBJECT_TABLE, nodes.OBJECT_SEQUENCE:
+			rv, ok := item.(*nodes.RangeVar)
+			if !ok {
+				continue
+			}
+			if rv.Schemaname != "" && m.cat.Schema(rv.Schemaname) == nil {
+				return nil, errorf(catalog.CodeInvalidSchemaName, "schema %q does not exist", rv.Schemaname)
+			}
+			rel := m.cat.LookupRelation(rv.Schemaname, rv.Relname)
+			if rel == nil {
+				return nil, errorf(catalog.CodeUndefinedTable, "relation %q does not exist", rangeVarName(rv))
+			}
+			switch {
+			case n.Objtype == nodes.OBJECT_SEQUENCE && rel.Kind != catalog.RelKindSequence:
+				return nil, errorf(catalog.CodeWrongObjectType, "%q is not a sequence", rel.Name)
+			case rel.Kind == catalog.RelKindIndex || rel.Kind == catalog.RelKindPartitionedIndex:
+				return nil, errorf(catalog.CodeWrongObjectType, "%q is an index", rel.Name)
+			}
+			out = append(out, m.relationObject(rel))
+		case nodes.OBJECT_FUNCTION, nodes.OBJECT_PROCEDURE, nodes.OBJECT_ROUTINE:
+			f, err := m.cat.LookupRoutine(item, n.Objtype)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, functionObject(f))
+		case nodes.OBJECT_SCHEMA:
+			name := strings.Join(stringList(asList(item)), ".")
+			s := m.cat.Schema(name)
+			if s == nil {
+				return nil, errorf(catalog.CodeInvalidSchemaName, "schema %q does not exist", name)
+			}
+			out = append(out, m.schemaObject(s))
+		case nodes.OBJECT_TYPE, nodes.OBJECT_DOMAIN:
+			names := stringList(asList(item))
+			var schema, name string
+			switch len(names) {
+			case 1:
+				name = names[0]
+			case 2, 3:
+				schema, name = names[len(names)-2], names[len(names)-1]
+			}
+			t := m.cat.LookupType(schema, name)
+			if t == nil {
+				return nil, errorf(catalog.CodeUndefinedObject, "type %q does not exist", strings.Join(names, "."))
+			}
+			if n.Objtype == nodes.OBJECT_DOMAIN && t.Kind != catalog.TypeKindDomain {
+				return nil, errorf(catalog.CodeWrongObjectType, "%q is not a domain", t.Name)
+			}
+			out = append(out, typeObject(t))
+		}
+	}
+	return out, nil
+}
+
+// allInSchema resolves the objects of GRANT ... ON ALL TABLES, SEQUENCES,
+// FUNCTIONS, PROCEDURES or ROUTINES IN SCHEMA.
+func (m *Model) allInSchema(n *nodes.GrantStmt) ([]object, error) {
+	var out []object
+	for _, item := range listItems(n.Objects) {
+		name := strings.Join(stringList(asList(item)), ".")
+		s := m.cat.Schema(name)
+		if s == nil {
+			return nil, errorf(catalog.CodeInvalidSchemaName, "schema %q does not exist", name)
+		}
+		switch n.Objtype {
+		case nodes.OBJECT_TABLE, nodes.OBJECT_SEQUENCE:
+			for _, rel := range s.Relations() {
+				switch rel.Kind {
+				case catalog.RelKindIndex, catalog.RelKindPartitionedIndex:
+					continue
+				case catalog.RelKindSequence:
+					if n.Objtype == nodes.OBJECT_TABLE {
+						continue
+					}
+				default:
+					if n.Objtype == nodes.OBJECT_SEQUENCE {
+						continue
+					}
+				}
+				out = append(out, m.relationObject(rel))
+			}
+		case nodes.OBJECT_FUNCTION, nodes.OBJECT_PROCEDURE, nodes.OBJECT_ROUTINE:
+			for _, f := range s.Functions() {
+				isProc := f.Kind == catalog.FuncKindProcedure
+				if n.Objtype == nodes.OBJECT_FUNCTION && isProc || n.Objtype == nodes.OBJECT_PROCEDURE && !isProc {
+					continue
+				}
+				out = append(out, functionObject(f))
+			}
+		}
+	}
+	return out, nil
+}
+
+// requested returns the privileges a GRANT or REVOKE names for objects of
+// kind k, and the columns the privileges are limited to, by privilege.
+// all is set for ALL PRIVILEGES.
+func requested(n *nodes.GrantStmt, k kind, noun string) (privs Privileges, cols map[string]Privileges, all bool, err error) {
+	if n.Privileges == nil {
+		return allPrivileges(k), nil, true, nil
+	}
+	for _, item := range listItems(n.Privileges) {
+		ap, ok := item.(*nodes.AccessPriv)
+		if !ok {
+			continue
+		}
+		var p Privileges
+		if ap.PrivName == "" {
+			p = allColumn // ALL (columns)
+		} else if p, ok = privilegeByName(ap.PrivName); !ok {
+			return 0, nil, false, errorf(catalog.CodeSyntaxError, "unrecognized privilege type %q", ap.PrivName)
+		}
+		if ap.Cols == nil {
+			privs |= p
+			continue
+		}
+		if k != kindRelation && k != kindSequence {
+			return 0, nil, false, errorf(catalog.CodeInvalidGrantOperation, "column privileges are only valid for relations")
+		}
+		if p&^allColumn != 0 {
+			return 0, nil, false, errorf(catalog.CodeInvalidGrantOperation, "invalid privilege type %s for column", p.String())
+		}
+		if cols == nil {
+			cols = map[string]Privileges{}
+		}
+		for _, c := range stringList(ap.Cols) {
+			cols[c] |= p
+		}
+	}
+	if bad := privs &^ allPrivileges(k); bad != 0 {
+		return 0, nil, false, errorf(catalog.CodeInvalidGrantOperation, "invalid privilege type %s for %s", firstPrivilege(bad), noun)
+	}
+	return privs, cols, false, nil
+}
+
+// firstPrivilege returns the name of the first privilege of p.
+func firstPrivilege(p Privileges) string {
+	for i := range privilegeNames {
+		if p&(1<<i) != 0 {
+			return privilegeNames[i]
+		}
+	}
+	return ""
+}
+
+// grantees returns the roles a GRANT or REVOKE names, "" for PUBLIC.
+func (m *Model) grantees(n *nodes.GrantStmt) ([]string, error) {
+	var out []string
+	for _, spec := range roleSpecs(n.Grantees) {
+		name := m.roleName(spec)
+		if name == "" {
+			if n.IsGrant && n.GrantOption {
+				return nil, errorf(catalog.CodeInvalidGrantOperation, "grant options can only be granted to roles")
+			}
+		} else {
+			m.role(name)
+		}
+		out = append(out, name)
+	}
+	return out, nil
+}
+
+// grant implements GRANT and REVOKE on objects, following
+// ExecGrantStmt.
+func (m *Model) grant(n *nodes.GrantStmt) error {
+	objs, err := m.grantObjects(n)
+	if err != nil {
+		return err
+	}
+	grantees, err := m.grantees(n)
+	if err != nil {
+		return err
+	}
+	actor := m.cat.User
+	if n.Grantor != nil {
+		grantor, err := m.existingRole(n.Grantor)
+		if err != nil {
+			return err
+		}
+		if !m.HasPrivsOfRole(actor, grantor) {
+			return errorf(catalog.CodeInsufficientPrivilege, "must inherit privileges of role %q", grantor)
+		}
+		actor = grantor
+	}
+	for _, o := range objs {
+		privs, cols, all, err := requested(n, o.kind, o.noun)
+		if err != nil {
+			return err
+		}
+		if o.kind == kindSequence && n.Objtype == nodes.OBJECT_TABLE && !all {
+			if privs&^allSequence != 0 {
+				m.warn("sequence %q only supports USAGE, SELECT, and UPDATE privileges", o.name)
+				privs &= allSequence
+			}
+		}
+		if privs != 0 || all {
+			if err := m.grantOn(n, o, actor, privs, all, grantees); err != nil {
+				return err
+			}
+		}
+		rel, _ := o.key.(*catalog.Relation)
+		if rel == nil {
+			continue
+		}
+		// REVOKE on a table also revokes the privileges from every column.
+		if !n.IsGrant && privs&allColumn != 0 {
+			for _, c := range rel.Columns {
+				if m.acls[c] != nil {
+					if err := m.grantOn(n, columnObject(c), actor, privs&allColumn, true, grantees); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		for _, name := range sortedKeys(cols) {
+			col := findColumn(rel, name)
+			if col == nil {
+				return errorf(catalog.CodeUndefinedColumn, "column %q of relation %q does not exist", name, rel.Name)
+			}
+			if err := m.grantOn(n, columnObject(col), actor, cols[name], false, grantees); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// grantOn grants or revokes privileges on one object as actor, following
+// ExecGrant_Relation and restrict_and_check_grant.
+func (m *Model) grantOn(n *nodes.GrantStmt, o object, actor string, privs Privileges, all bool, grantees []string) error {
+	acl := m.aclOf(o)
+	grantor, avail := m.bestGrantor(actor, privs, acl, o.owner)
+	if avail == 0 && m.aclMask(acl, grantor, o.owner, allPrivileges(o.kind), false) == 0 &&
+		m.aclMask(acl, grantor, o.owner, allPrivileges(o.kind), true) == 0 {
+		return errorf(catalog.CodeInsufficientPrivilege, "permission denied for %s", o.describe())
+	}
+	granted := privs & avail
+	switch {
+	case n.IsGrant && granted == 0:
+		m.warn("no privileges were granted for %s", o.quoted())
+	case n.IsGrant && !all && granted != privs:
+		m.warn("not all privileges were granted for %s", o.quoted())
+	case !n.IsGrant && granted == 0:
+		m.warn("no privileges could be revoked for %s", o.quoted())
+	case !n.IsGrant && !all && granted != privs:
+		m.warn("not all privileges could be revoked for %s", o.quoted())
+	}
+	acl, err := m.mergeGrant(acl, n, grantees, granted, grantor, o.owner)
+	if err != nil {
+		return err
+	}
+	m.setACL(o, acl)
+	return nil
+}
+
+// bestGrantor picks the role a grant is recorded as coming from, and the
+// grant options it holds of privs, following select_best_grantor: the
+// owner if the actor has its privileges, else the actor or the inherited
+// role holding the most of the grant options needed.
+func (m *Model) bestGrantor(actor string, privs Privileges, acl ACL, owner string) (string, Privileges) {
+	if actor == owner || m.superuser(actor) {
+		return owner, privs
+	}
+	if m.HasPrivsOfRole(actor, owner) {
+		return owner, privs
+	}
+	best, bestOpts := actor, m.aclMask(acl, actor, owner, privs, true)
+	if bestOpts == privs {
+		return best, bestOpts
+	}
+	for _, role := range m.rolesOf(actor)[1:] {
+		var opts Privileges
+		for _, it := range acl {
+			if it.Grantee == role {
+				opts |= it.GrantOptions & privs
+			}
+		}
+		if opts == privs {
+			return role, opts
+		}
+		if bits(opts) > bits(bestOpts) {
+			best, bestOpts = role, opts
+		}
+	}
+	return best, bestOpts
+}
+
+func bits(p Privileges) int {
+	n := 0
+	for ; p != 0; p &= p - 1 {
+		n++
+	}
+	return n
+}
+
+// mergeGrant applies a GRANT or REVOKE of privs by grantor to each
+// grantee, following merge_acl_with_grant.
+func (m *Model) mergeGrant(acl ACL, n *nodes.GrantStmt, grantees []string, privs Privileges, grantor, owner string) (ACL, error) {
+	cascade := n.Behavior == nodes.DROP_CASCADE
+	for _, grantee := range grantees {
+		var err error
+		if n.IsGrant {
+			opts := Privileges(0)
+			if n.GrantOption {
+				opts = privs
+			}
+			acl = addItem(acl, Item{Grantee: grantee, Grantor: grantor, Privileges: privs, GrantOptions: opts})
+			continue
+		}
+		revoke := privs
+		if n.GrantOption {
+			revoke = 0
+		}
+		if acl, err = m.revokeItem(acl, grantee, grantor, revoke, privs, owner, cascade); err != nil {
+			return nil, err
+		}
+	}
+	return acl, nil
+}
+
+// addItem adds privileges to the item for the grantee and grantor, or
+// appends a new item.
+func addItem(acl ACL, it Item) ACL {
+	out := append(ACL(nil), acl...)
+	for i := range out {
+		if out[i].Grantee == it.Grantee && out[i].Grantor == it.Grantor {
+			out[i].Privileges |= it.Privileges
+			out[i].GrantOptions |= it.GrantOptions
+			return out
+		}
+	}
+	if it.Privileges == 0 {
+		return out
+	}
+	return append(out, it)
+}
+
+// revokeItem removes privileges and grant options from the item for the
+// grantee and grantor, as aclupdate does. Grant options lost are revoked
+// in turn from the roles the grantee granted them to, if the grantee does
+// not hold them from another grantor; without cascade, that is an error.
+func (m *Model) revokeItem(acl ACL, grantee, grantor string, privs, opts Privileges, owner string, cascade bool) (ACL, error) {
+	out := append(ACL(nil), acl...)
+	lost := Privileges(0)
+	for i := 0; i < len(out); i++ {
+		it := &out[i]
+		if it.Grantee != grantee || it.Grantor != grantor {
+			continue
+		}
+		lost = it.GrantOptions & opts
+		it.Privileges &^= privs
+		it.GrantOptions &^= opts | privs
+		if it.Privileges == 0 {
+			out = append(out[:i], out[i+1:]...)
+		}
+		break
+	}
+	if lost == 0 || grantee == "" || grantee == owner {
+		return out, nil
+	}
+	lost &^= m.aclMask(out, grantee, owner, lost, true)
+	if lost == 0 {
+		return out, nil
+	}
+	var dependents []string
+	for _, it := range out {
+		if it.Grantor == grantee && it.Privileges&lost != 0 {
+			dependents = append(dependents, it.Grantee)
+		}
+	}
+	if len(dependents) > 0 && !cascade {
+		e := errorf(catalog.CodeDependentObjectsStillExist, "dependent privileges exist")
+		e.Hint = "Use CASCADE to revoke them too."
+		return nil, e
+	}
+	for _, d := range dependents {
+		var err error
+		if out, err = m.revokeItem(out, d, grantee, lost, lost, owner, true); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (m *Model) warn(format string, args ...any) {
+	if m.WarningFunc != nil {
+		m.WarningFunc(fmt.Sprintf(format, args...))
+	}
+}
+
+func errorf(code, format string, args ...any) *catalog.Error {
+	return &catalog.Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func rangeVarName(rv *nodes.RangeVar) string {
+	if rv.Schemaname != "" {
+		return rv.Schemaname + "." + rv.Relname
+	}
+	return rv.Relname
+}
+
+// stringConst returns the value of a string literal.
+func stringConst(n nodes.Node) (string, bool) {
+	if ac, ok := n.(*nodes.A_Const); ok {
+		if s, ok := ac.Val.(*nodes.String); ok {
+			return s.Sval, true
+		}
+	}
+	return "", false
+}
+
+// asList returns n as a list, wrapping a lone node.
+func asList(n nodes.Node) *nodes.List {
+	if l, ok := n.(*nodes.List); ok {
+		return l
+	}
+	return &nodes.List{Items: []nodes.Node{n}}
+}
+
+// stringList returns the values of the String nodes of a list.
+func stringList(l *nodes.List) []string {
+	var out []string
+	for _, item := range listItems(l) {
+		if s, ok := item.(*nodes.String); ok {
+			out = append(out, s.Sval)
+		}
+	}
+	return out
+}
+
+// listItems returns the items of a possibly-nil list.
+func listItems(l *nodes.List) []nodes.Node {
+	if l == nil {
+		return nil
+	}
+	return l.Items
+}
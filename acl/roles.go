@@ -0,0 +1,492 @@
+package acl
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pgplex/pgparser/catalog"
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// Role is a database role.
+type Role struct {
+	Name      string
+	Superuser bool
+
+	// Inherit is the default for the INHERIT option of the memberships
+	// granted to the role.
+	Inherit bool
+
+	// implicit is set for roles the model has only seen referred to,
+	// which a later CREATE ROLE may define.
+	implicit bool
+}
+
+// Membership is a grant of membership in a role.
+type Membership struct {
+	Role    string // the role granted
+	Member  string
+	Grantor string
+
+	Admin   bool // the member may grant the role to others
+	Inherit bool // the member has the privileges of the role
+	Set     bool // the member may SET ROLE to the role
+}
+
+// Role returns the named role, or nil if the model has not seen it.
+func (m *Model) Role(name string) *Role {
+	return m.roles[name]
+}
+
+// Roles returns the roles the model knows, sorted by name. Roles that
+// statements referred to without creating them are included, with the
+// attributes of CREATE ROLE's defaults.
+func (m *Model) Roles() []*Role {
+	out := make([]*Role, 0, len(m.roles))
+	for _, r := range m.roles {
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Memberships returns the grants of membership the member holds directly.
+func (m *Model) Memberships(member string) []Membership {
+	var out []Membership
+	for _, ms := range m.members {
+		if ms.Member == member {
+			out = append(out, *ms)
+		}
+	}
+	return out
+}
+
+// role returns the named role, adding it if the model has not seen it:
+// scripts commonly refer to roles created outside them.
+func (m *Model) role(name string) *Role {
+	r := m.roles[name]
+	if r == nil {
+		r = &Role{Name: name, Inherit: true, implicit: true}
+		m.addRole(r)
+	}
+	return r
+}
+
+func (m *Model) addRole(r *Role) {
+	m.roles[r.Name] = r
+	m.undo = append(m.undo, func() { delete(m.roles, r.Name) })
+}
+
+func (m *Model) superuser(name string) bool {
+	r := m.roles[name]
+	return r != nil && r.Superuser
+}
+
+// HasPrivsOfRole reports whether member has the privileges of role: it
+// is the role, a superuser, or a member of the role through a chain of
+// memberships granted with INHERIT.
+func (m *Model) HasPrivsOfRole(member, role string) bool {
+	if member == role || m.superuser(member) {
+		return true
+	}
+	return m.reaches(member, role, func(ms *Membership) bool { return ms.Inherit })
+}
+
+// isMemberOf reports whether member may SET ROLE to role.
+func (m *Model) isMemberOf(member, role string) bool {
+	if member == role || m.superuser(member) {
+		return true
+	}
+	return m.reaches(member, role, func(ms *Membership) bool { return ms.Set })
+}
+
+// isAdminOf reports whether member may grant and revoke membership in
+// role.
+func (m *Model) isAdminOf(member, role string) bool {
+	if m.superuser(member) {
+		return true
+	}
+	for _, ms := range m.members {
+		if ms.Role == role && ms.Admin && m.HasPrivsOfRole(member, ms.Member) {
+			return true
+		}
+	}
+	return false
+}
+
+// reaches reports whether a chain of memberships accepted by follow leads
+// from member to role.
+func (m *Model) reaches(member, role string, follow func(*Membership) bool) bool {
+	seen := map[string]bool{member: true}
+	queue := []string{member}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, ms := range m.members {
+			if ms.Member != cur || seen[ms.Role] || !follow(ms) {
+				continue
+			}
+			if ms.Role == role {
+				return true
+			}
+			seen[ms.Role] = true
+			queue = append(queue, ms.Role)
+		}
+	}
+	return false
+}
+
+// rolesOf returns the roles whose privileges member has, member first and
+// then in order of distance, as roles_is_member_of does.
+func (m *Model) rolesOf(member string) []string {
+	out := []string{member}
+	seen := map[string]bool{member: true}
+	for i := 0; i < len(out); i++ {
+		for _, ms := range m.members {
+			if ms.Member == out[i] && ms.Inherit && !seen[ms.Role] {
+				seen[ms.Role] = true
+				out = append(out, ms.Role)
+			}
+		}
+	}
+	return out
+}
+
+// roleName returns the role a RoleSpec denotes, "" for PUBLIC.
+func (m *Model) roleName(r *nodes.RoleSpec) string {
+	if r == nil {
+		return ""
+	}
+	switch nodes.RoleSpecType(r.Roletype) {
+	case nodes.ROLESPEC_CSTRING:
+		return r.Rolename
+	case nodes.ROLESPEC_SESSION_USER:
+		return m.session
+	case nodes.ROLESPEC_PUBLIC:
+		return ""
+	}
+	return m.cat.User
+}
+
+// existingRole returns the role a RoleSpec denotes, which may not be
+// PUBLIC.
+func (m *Model) existingRole(r *nodes.RoleSpec) (string, error) {
+	name := m.roleName(r)
+	if name == "" {
+		return "", errorf(catalog.CodeUndefinedObject, "role %q does not exist", "public")
+	}
+	m.role(name)
+	return name, nil
+}
+
+// createRole implements CREATE ROLE, USER and GROUP.
+func (m *Model) createRole(n *nodes.CreateRoleStmt) error {
+	r := m.roles[n.Role]
+	if r != nil && !r.implicit {
+		return errorf(catalog.CodeDuplicateObject, "role %q already exists", n.Role)
+	}
+	if r == nil {
+		r = &Role{Name: n.Role}
+		m.addRole(r)
+	}
+	setField(m, &r.implicit, false)
+	setField(m, &r.Inherit, true)
+	setField(m, &r.Superuser, false)
+	creator := m.cat.User
+	if !m.superuser(creator) {
+		// The creator of a role may administer it, as PostgreSQL 16
+		// arranges with a membership granted by the bootstrap superuser.
+		m.addMembership(Membership{Role: r.Name, Member: creator, Grantor: m.bootstrap, Admin: true})
+	}
+	return m.roleOptions(r, n.Options, 0)
+}
+
+// alterRole implements ALTER ROLE and ALTER GROUP ... ADD|DROP USER.
+func (m *Model) alterRole(n *nodes.AlterRoleStmt) error {
+	name, err := m.existingRole(n.Role)
+	if err != nil {
+		return err
+	}
+	return m.roleOptions(m.role(name), n.Options, n.Action)
+}
+
+// roleOptions applies the options of CREATE or ALTER ROLE. action is -1
+// when ALTER GROUP drops the listed members.
+func (m *Model) roleOptions(r *Role, options *nodes.List, action int) error {
+	for _, item := range listItems(options) {
+		d, ok := item.(*nodes.DefElem)
+		if !ok {
+			continue
+		}
+		switch d.Defname {
+		case "superuser":
+			setField(m, &r.Superuser, boolValue(d.Arg))
+		case "inherit":
+			setField(m, &r.Inherit, boolValue(d.Arg))
+		case "addroleto":
+			for _, role := range roleSpecs(d.Arg) {
+				name, err := m.existingRole(role)
+				if err != nil {
+					return err
+				}
+				if err := m.grantMembership(name, r.Name, m.cat.User, nil); err != nil {
+					return err
+				}
+			}
+		case "rolemembers", "adminmembers":
+			for _, member := range roleSpecs(d.Arg) {
+				name, err := m.existingRole(member)
+				if err != nil {
+					return err
+				}
+				if action < 0 {
+					m.removeMembership(r.Name, name)
+					continue
+				}
+				var opts map[string]bool
+				if d.Defname == "adminmembers" {
+					opts = map[string]bool{"admin": true}
+				}
+				if err := m.grantMembership(r.Name, name, m.cat.User, opts); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// dropRole implements DROP ROLE.
+func (m *Model) dropRole(n *nodes.DropRoleStmt) error {
+	for _, spec := range roleSpecs(n.Roles) {
+		name := m.roleName(spec)
+		if m.roles[name] == nil {
+			if n.MissingOk {
+				continue
+			}
+			return errorf(catalog.CodeUndefinedObject, "role %q does not exist", name)
+		}
+		if name == m.cat.User || name == m.session {
+			return errorf(catalog.CodeObjectInUse, "current user cannot be dropped")
+		}
+		r := m.roles[name]
+		delete(m.roles, name)
+		m.undo = append(m.undo, func() { m.roles[name] = r })
+		for _, ms := range m.members {
+			if ms.Role == name || ms.Member == name {
+				m.removeMembership(ms.Role, ms.Member)
+			}
+		}
+	}
+	return nil
+}
+
+// grantRole implements GRANT and REVOKE of role membership.
+func (m *Model) grantRole(n *nodes.GrantRoleStmt) error {
+	grantor := m.cat.User
+	if n.Grantor != nil {
+		var err error
+		if grantor, err = m.existingRole(n.Grantor); err != nil {
+			return err
+		}
+	}
+	opts := map[string]bool{}
+	for _, item := range listItems(n.Opt) {
+		if d, ok := item.(*nodes.DefElem); ok {
+			opts[d.Defname] = boolValue(d.Arg)
+		}
+	}
+	for _, item := range listItems(n.GrantedRoles) {
+		ap, ok := item.(*nodes.AccessPriv)
+		if !ok {
+			continue
+		}
+		role := ap.PrivName
+		if role == "public" {
+			return errorf(catalog.CodeUndefinedObject, "role %q does not exist", role)
+		}
+		m.role(role)
+		if !m.isAdminOf(m.cat.User, role) {
+			e := errorf(catalog.CodeInsufficientPrivilege, "permission denied to grant role %q", role)
+			e.Detail = fmt.Sprintf("Only roles with the ADMIN option on role %q may grant this role.", role)
+			return e
+		}
+		for _, spec := range roleSpecs(n.GranteeRoles) {
+			member, err := m.existingRole(spec)
+			if err != nil {
+				return err
+			}
+			if n.IsGrant {
+				if err := m.grantMembership(role, member, grantor, opts); err != nil {
+					return err
+				}
+				continue
+			}
+			if len(opts) == 0 {
+				m.removeMembership(role, member)
+				continue
+			}
+			// REVOKE ... OPTION FOR clears the options named.
+			if ms := m.membership(role, member); ms != nil {
+				upd := *ms
+				for opt := range opts {
+					switch opt {
+					case "admin":
+						upd.Admin = false
+					case "inherit":
+						upd.Inherit = false
+					case "set":
+						upd.Set = false
+					}
+				}
+				m.replaceMembership(ms, upd)
+			}
+		}
+	}
+	return nil
+}
+
+// grantMembership grants role to member. opts holds the ADMIN, INHERIT
+// and SET options given; INHERIT defaults to the member's attribute, SET
+// to true, and options of an existing grant are only ever turned on.
+func (m *Model) grantMembership(role, member, grantor string, opts map[string]bool) error {
+	if role == member || m.reaches(role, member, func(*Membership) bool { return true }) {
+		return errorf(catalog.CodeInvalidGrantOperation, "role %q is a member of role %q", role, member)
+	}
+	if ms := m.membership(role, member); ms != nil {
+		upd := *ms
+		for opt, v := range opts {
+			switch opt {
+			case "admin":
+				upd.Admin = v
+			case "inherit":
+				upd.Inherit = v
+			case "set":
+				upd.Set = v
+			}
+		}
+		m.replaceMembership(ms, upd)
+		return nil
+	}
+	ms := Membership{Role: role, Member: member, Grantor: grantor, Inherit: m.role(member).Inherit, Set: true}
+	if v, ok := opts["admin"]; ok {
+		ms.Admin = v
+	}
+	if v, ok := opts["inherit"]; ok {
+		ms.Inherit = v
+	}
+	if v, ok := opts["set"]; ok {
+		ms.Set = v
+	}
+	m.addMembership(ms)
+	return nil
+}
+
+func (m *Model) membership(role, member string) *Membership {
+	for _, ms := range m.members {
+		if ms.Role == role && ms.Member == member {
+			return ms
+		}
+	}
+	return nil
+}
+
+func (m *Model) addMembership(ms Membership) {
+	old := m.members
+	m.members = append(append([]*Membership(nil), old...), &ms)
+	m.undo = append(m.undo, func() { m.members = old })
+}
+
+func (m *Model) replaceMembership(ms *Membership, upd Membership) {
+	old := *ms
+	*ms = upd
+	m.undo = append(m.undo, func() { *ms = old })
+}
+
+func (m *Model) removeMembership(role, member string) {
+	old := m.members
+	var kept []*Membership
+	for _, ms := range old {
+		if ms.Role != role || ms.Member != member {
+			kept = append(kept, ms)
+		}
+	}
+	m.members = kept
+	m.undo = append(m.undo, func() { m.members = old })
+}
+
+// setRole implements SET ROLE, SET SESSION AUTHORIZATION and their RESET
+// forms, which change the role running the statements that follow.
+func (m *Model) setRole(n *nodes.VariableSetStmt) error {
+	var value string
+	if n.Kind == nodes.VAR_SET_VALUE {
+		for _, arg := range listItems(n.Args) {
+			if s, ok := stringConst(arg); ok {
+				value = s
+			}
+		}
+	}
+	switch n.Name {
+	case "role":
+		if value == "" || value == "none" {
+			m.setUser(m.session)
+			return nil
+		}
+		m.role(value)
+		if !m.isMemberOf(m.session, value) {
+			return errorf(catalog.CodeInsufficientPrivilege, "permission denied to set role %q", value)
+		}
+		m.setUser(value)
+	case "session_authorization":
+		if value == "" {
+			value = m.bootstrap
+		}
+		if !m.superuser(m.bootstrap) {
+			return errorf(catalog.CodeInsufficientPrivilege, "permission denied to set session authorization %q", value)
+		}
+		m.role(value)
+		setField(m, &m.session, value)
+		m.setUser(value)
+	}
+	return nil
+}
+
+func (m *Model) setUser(name string) {
+	setField(m, &m.cat.User, name)
+}
+
+// setField assigns v to *p, recording the old value so that a failing
+// statement can be rolled back.
+func setField[T any](m *Model, p *T, v T) {
+	old := *p
+	m.undo = append(m.undo, func() { *p = old })
+	*p = v
+}
+
+// roleSpecs returns the RoleSpec nodes of a list.
+func roleSpecs(n nodes.Node) []*nodes.RoleSpec {
+	var out []*nodes.RoleSpec
+	l, _ := n.(*nodes.List)
+	for _, item := range listItems(l) {
+		if r, ok := item.(*nodes.RoleSpec); ok {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// boolValue returns the value of a Boolean option argument; a missing
+// argument means true.
+func boolValue(n nodes.Node) bool {
+	switch v := n.(type) {
+	case nil:
+		return true
+	case *nodes.Boolean:
+		return v.Boolval
+	case *nodes.Integer:
+		return v.Ival != 0
+	case *nodes.String:
+		return v.Sval == "true" || v.Sval == "on"
+	}
+	return true
+}
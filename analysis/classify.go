@@ -0,0 +1,391 @@
+// Package analysis provides statement-level analysis of raw parse trees.
+//
+// The functions in this package work purely on the output of parser.Parse;
+// no catalog access is needed. Where PostgreSQL makes the same decision at
+// execution time, the rules follow the corresponding backend code.
+package analysis
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// Read-only command flags, from PostgreSQL's utility.h.
+//
+// A command that is OK in recovery can be sent to a hot standby.
+const (
+	COMMAND_OK_IN_READ_ONLY_TXN = 0x0001
+	COMMAND_OK_IN_PARALLEL_MODE = 0x0002
+	COMMAND_OK_IN_RECOVERY      = 0x0004
+
+	COMMAND_IS_STRICTLY_READ_ONLY = COMMAND_OK_IN_READ_ONLY_TXN | COMMAND_OK_IN_RECOVERY | COMMAND_OK_IN_PARALLEL_MODE
+	COMMAND_IS_NOT_READ_ONLY      = 0
+)
+
+// Classification describes how a single statement interacts with data,
+// schema and transactions.
+type Classification struct {
+	CmdType       nodes.CmdType // SELECT/INSERT/UPDATE/DELETE/MERGE, or CMD_UTILITY
+	ReadOnlyFlags int           // COMMAND_OK_IN_* bitmask
+
+	ModifiesData   bool // writes table rows (DML, COPY FROM, TRUNCATE, ...)
+	ModifiesSchema bool // creates, alters or drops database objects
+
+	TransactionControl bool                      // BEGIN, COMMIT, SAVEPOINT, ...
+	TransactionKind    nodes.TransactionStmtKind // valid if TransactionControl
+
+	// NoTransactionBlock is set for statements that PostgreSQL refuses to
+	// run inside a transaction block (PreventInTransactionBlock callers).
+	NoTransactionBlock bool
+
+	// RowLocking is the strongest FOR UPDATE/SHARE strength found anywhere
+	// in the statement, or LCS_NONE.
+	RowLocking nodes.LockClauseStrength
+
+	// ModifyingCTE is set when a WITH clause contains INSERT, UPDATE,
+	// DELETE or MERGE.
+	ModifyingCTE bool
+}
+
+// ReadOnly reports whether the statement may run in a read-only transaction.
+func (c *Classification) ReadOnly() bool {
+	return c.ReadOnlyFlags&COMMAND_OK_IN_READ_ONLY_TXN != 0
+}
+
+// OKInRecovery reports whether the statement may run on a hot standby.
+func (c *Classification) OKInRecovery() bool {
+	return c.ReadOnlyFlags&COMMAND_OK_IN_RECOVERY != 0
+}
+
+// Classify classifies a raw statement.
+func Classify(raw *nodes.RawStmt) *Classification {
+	if raw == nil {
+		return &Classification{CmdType: nodes.CMD_UNKNOWN}
+	}
+	return ClassifyStmt(raw.Stmt)
+}
+
+// ClassifyStmt classifies a statement node as returned in the items of
+// parser.Parse.
+func ClassifyStmt(stmt nodes.Node) *Classification {
+	c := &Classification{CmdType: nodes.CMD_UNKNOWN}
+	if stmt == nil {
+		return c
+	}
+
+	switch n := stmt.(type) {
+	case *nodes.SelectStmt:
+		c.CmdType = nodes.CMD_SELECT
+		c.ReadOnlyFlags = COMMAND_IS_STRICTLY_READ_ONLY
+		if hasIntoClause(n) {
+			// SELECT INTO is transformed into CREATE TABLE AS.
+			c.CmdType = nodes.CMD_UTILITY
+			c.ReadOnlyFlags = COMMAND_IS_NOT_READ_ONLY
+			c.ModifiesData = true
+			c.ModifiesSchema = true
+		}
+	case *nodes.InsertStmt:
+		c.setDML(nodes.CMD_INSERT)
+	case *nodes.UpdateStmt:
+		c.setDML(nodes.CMD_UPDATE)
+	case *nodes.DeleteStmt:
+		c.setDML(nodes.CMD_DELETE)
+	case *nodes.MergeStmt:
+		c.setDML(nodes.CMD_MERGE)
+	default:
+		c.CmdType = nodes.CMD_UTILITY
+		c.classifyUtility(stmt)
+		return c
+	}
+
+	c.scanQuery(stmt)
+	return c
+}
+
+// setDML marks c as a data-modifying command of the given type.
+func (c *Classification) setDML(cmd nodes.CmdType) {
+	c.CmdType = cmd
+	c.ReadOnlyFlags = COMMAND_IS_NOT_READ_ONLY
+	c.ModifiesData = true
+}
+
+// scanQuery looks for row-locking clauses and data-modifying CTEs anywhere
+// in an optimizable statement, including sublinks and subqueries in FROM.
+func (c *Classification) scanQuery(stmt nodes.Node) {
+	nodes.Walk(stmt, func(n nodes.Node) bool {
+		switch n := n.(type) {
+		case *nodes.LockingClause:
+			if s := nodes.LockClauseStrength(n.Strength); s > c.RowLocking {
+				c.RowLocking = s
+			}
+		case *nodes.CommonTableExpr:
+			switch n.Ctequery.(type) {
+			case *nodes.InsertStmt, *nodes.UpdateStmt, *nodes.DeleteStmt, *nodes.MergeStmt:
+				c.ModifyingCTE = true
+			}
+		}
+		return true
+	})
+
+	// Row locks need the same permission as UPDATE, and both row locks and
+	// data-modifying CTEs are rejected in read-only transactions.
+	if c.RowLocking != nodes.LCS_NONE {
+		c.ReadOnlyFlags = COMMAND_IS_NOT_READ_ONLY
+	}
+	if c.ModifyingCTE {
+		c.ReadOnlyFlags = COMMAND_IS_NOT_READ_ONLY
+		c.ModifiesData = true
+	}
+}
+
+// merge folds the classification of a statement executed by a utility
+// command (EXPLAIN ANALYZE, DECLARE, COPY (query) TO) into c.
+func (c *Classification) merge(inner *Classification) {
+	c.ReadOnlyFlags &= inner.ReadOnlyFlags
+	c.ModifiesData = c.ModifiesData || inner.ModifiesData
+	c.ModifiesSchema = c.ModifiesSchema || inner.ModifiesSchema
+	if inner.RowLocking > c.RowLocking {
+		c.RowLocking = inner.RowLocking
+	}
+	c.ModifyingCTE = c.ModifyingCTE || inner.ModifyingCTE
+}
+
+// classifyUtility fills in c for a utility statement. The read-only flags
+// follow ClassifyUtilityCommandAsReadOnly in utility.c; anything not listed
+// there changes the catalogs and is not read-only.
+func (c *Classification) classifyUtility(stmt nodes.Node) {
+	c.ReadOnlyFlags = COMMAND_IS_NOT_READ_ONLY
+	c.NoTransactionBlock = preventsTransactionBlock(stmt)
+
+	switch n := stmt.(type) {
+	case *nodes.CheckPointStmt,
+		*nodes.ClosePortalStmt,
+		*nodes.ConstraintsSetStmt,
+		*nodes.DeallocateStmt,
+		*nodes.DiscardStmt,
+		*nodes.ExecuteStmt,
+		*nodes.FetchStmt,
+		*nodes.LoadStmt,
+		*nodes.PrepareStmt,
+		*nodes.UnlistenStmt,
+		*nodes.VariableSetStmt,
+		*nodes.VariableShowStmt:
+		c.ReadOnlyFlags = COMMAND_IS_STRICTLY_READ_ONLY
+
+	case *nodes.DeclareCursorStmt:
+		// The cursor's query is checked when the portal is opened, which
+		// DECLARE does immediately.
+		c.ReadOnlyFlags = COMMAND_IS_STRICTLY_READ_ONLY
+		c.merge(ClassifyStmt(n.Query))
+
+	case *nodes.ExplainStmt:
+		c.ReadOnlyFlags = COMMAND_IS_STRICTLY_READ_ONLY
+		if hasOption(n.Options, "analyze") {
+			c.merge(ClassifyStmt(n.Query))
+		}
+
+	case *nodes.CallStmt, *nodes.DoStmt:
+		// PostgreSQL checks each statement run by the procedure body as it
+		// executes, so the call itself is read-only. The body is invisible
+		// here, so assume it writes.
+		c.ReadOnlyFlags = COMMAND_IS_STRICTLY_READ_ONLY
+		c.ModifiesData = true
+
+	case *nodes.ClusterStmt, *nodes.ReindexStmt, *nodes.VacuumStmt:
+		// These write WAL but do not change the logical database state.
+		c.ReadOnlyFlags = COMMAND_OK_IN_READ_ONLY_TXN
+
+	case *nodes.CopyStmt:
+		if n.IsFrom {
+			c.ReadOnlyFlags = COMMAND_OK_IN_READ_ONLY_TXN
+			c.ModifiesData = true
+		} else {
+			c.ReadOnlyFlags = COMMAND_IS_STRICTLY_READ_ONLY
+			if n.Query != nil {
+				c.merge(ClassifyStmt(n.Query))
+			}
+		}
+
+	case *nodes.ListenStmt, *nodes.NotifyStmt:
+		c.ReadOnlyFlags = COMMAND_OK_IN_READ_ONLY_TXN
+
+	case *nodes.LockStmt:
+		// Only weaker lock modes are allowed during recovery.
+		if n.Mode > nodes.RowExclusiveLock {
+			c.ReadOnlyFlags = COMMAND_OK_IN_READ_ONLY_TXN
+		} else {
+			c.ReadOnlyFlags = COMMAND_IS_STRICTLY_READ_ONLY
+		}
+
+	case *nodes.TransactionStmt:
+		c.TransactionControl = true
+		c.TransactionKind = n.Kind
+		switch n.Kind {
+		case nodes.TRANS_STMT_PREPARE,
+			nodes.TRANS_STMT_COMMIT_PREPARED,
+			nodes.TRANS_STMT_ROLLBACK_PREPARED:
+			c.ReadOnlyFlags = COMMAND_OK_IN_READ_ONLY_TXN
+		default:
+			c.ReadOnlyFlags = COMMAND_IS_STRICTLY_READ_ONLY
+		}
+
+	case *nodes.TruncateStmt, *nodes.RefreshMatViewStmt:
+		c.ModifiesData = true
+
+	case *nodes.CreateTableAsStmt:
+		c.ModifiesData = true
+		c.ModifiesSchema = true
+
+	default:
+		c.ModifiesSchema = true
+	}
+}
+
+// preventsTransactionBlock reports whether PostgreSQL 17 calls
+// PreventInTransactionBlock for stmt.
+func preventsTransactionBlock(stmt nodes.Node) bool {
+	switch n := stmt.(type) {
+	case *nodes.VacuumStmt:
+		return n.IsVacuumcmd
+	case *nodes.IndexStmt:
+		return n.Concurrent
+	case *nodes.DropStmt:
+		return n.Concurrent
+	case *nodes.ReindexStmt:
+		switch n.Kind {
+		case nodes.REINDEX_OBJECT_SCHEMA, nodes.REINDEX_OBJECT_SYSTEM, nodes.REINDEX_OBJECT_DATABASE:
+			return true
+		}
+		return hasOption(n.Params, "concurrently")
+	case *nodes.ClusterStmt:
+		// CLUSTER without a table processes every clustered table, each in
+		// its own transaction.
+		return n.Relation == nil
+	case *nodes.AlterTableStmt:
+		for _, item := range listItems(n.Cmds) {
+			cmd, ok := item.(*nodes.AlterTableCmd)
+			if !ok || nodes.AlterTableType(cmd.Subtype) != nodes.AT_DetachPartition {
+				continue
+			}
+			if pc, ok := cmd.Def.(*nodes.PartitionCmd); ok && pc.Concurrent {
+				return true
+			}
+		}
+	case *nodes.CreatedbStmt, *nodes.DropdbStmt,
+		*nodes.CreateTableSpaceStmt, *nodes.DropTableSpaceStmt,
+		*nodes.AlterSystemStmt:
+		return true
+	case *nodes.AlterDatabaseStmt:
+		return findOption(n.Options, "tablespace") != nil
+	case *nodes.DiscardStmt:
+		return n.Target == nodes.DISCARD_ALL
+	case *nodes.TransactionStmt:
+		return n.Kind == nodes.TRANS_STMT_COMMIT_PREPARED || n.Kind == nodes.TRANS_STMT_ROLLBACK_PREPARED
+	case *nodes.CreateSubscriptionStmt:
+		// create_slot defaults to true, and connect = false implies
+		// create_slot = false.
+		return optionBool(n.Options, "connect", true) && optionBool(n.Options, "create_slot", true)
+	case *nodes.AlterSubscriptionStmt:
+		switch n.Kind {
+		case nodes.ALTER_SUBSCRIPTION_REFRESH:
+			return true
+		case nodes.ALTER_SUBSCRIPTION_SET_PUBLICATION,
+			nodes.ALTER_SUBSCRIPTION_ADD_PUBLICATION,
+			nodes.ALTER_SUBSCRIPTION_DROP_PUBLICATION:
+			return optionBool(n.Options, "refresh", true)
+		}
+	}
+	return false
+}
+
+// hasIntoClause reports whether a SELECT (or either side of a set
+// operation) has an INTO clause.
+func hasIntoClause(n *nodes.SelectStmt) bool {
+	if n == nil {
+		return false
+	}
+	if n.IntoClause != nil {
+		return true
+	}
+	return hasIntoClause(n.Larg)
+}
+
+// listItems returns the items of l, which may be nil.
+func listItems(l *nodes.List) []nodes.Node {
+	if l == nil {
+		return nil
+	}
+	return l.Items
+}
+
+// findOption returns the option with the given name in a DefElem list, or
+// nil.
+func findOption(opts *nodes.List, name string) *nodes.DefElem {
+	for _, item := range listItems(opts) {
+		if d, ok := item.(*nodes.DefElem); ok && d.Defname == name {
+			return d
+		}
+	}
+	return nil
+}
+
+// hasOption reports whether a DefElem list contains an option with the
+// given name that is set to true. A value PostgreSQL rejects counts as
+// false.
+func hasOption(opts *nodes.List, name string) bool {
+	return optionBool(opts, name, false)
+}
+
+// optionBool returns the boolean value of the named option, or def if the
+// option is absent or its value is not a boolean.
+func optionBool(opts *nodes.List, name string, def bool) bool {
+	d := findOption(opts, name)
+	if d == nil {
+		return def
+	}
+	v, err := DefGetBoolean(d)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// DefGetBoolean interprets a DefElem's argument as a boolean, as
+// defGetBoolean in define.c does. A missing argument means true; otherwise
+// only the integers 0 and 1 and the words true, false, on and off, in any
+// case, are accepted, and anything else is an error.
+func DefGetBoolean(d *nodes.DefElem) (bool, error) {
+	if d.Arg == nil {
+		return true, nil
+	}
+	var s string
+	switch a := d.Arg.(type) {
+	case *nodes.Integer:
+		switch a.Ival {
+		case 0:
+			return false, nil
+		case 1:
+			return true, nil
+		}
+	case *nodes.Boolean:
+		return a.Boolval, nil
+	case *nodes.String:
+		s = a.Sval
+	case *nodes.TypeName:
+		// Unquoted words such as off are parsed as type names.
+		if a.Names.Len() == 1 {
+			if str, ok := a.Names.Items[0].(*nodes.String); ok {
+				s = str.Sval
+			}
+		}
+	}
+	switch strings.ToLower(s) {
+	case "true", "on":
+		return true, nil
+	case "false", "off":
+		return false, nil
+	}
+	return false, fmt.Errorf("%s requires a Boolean value", d.Defname)
+}
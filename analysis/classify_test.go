@@ -0,0 +1,232 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+)
+
+// classify parses a single statement and classifies it.
+func classify(t *testing.T, sql string) *Classification {
+	t.Helper()
+	result, err := parser.Parse(sql)
+	if err != nil {
+		t.Fatalf("Parse(%q) error: %v", sql, err)
+	}
+	if result == nil || len(result.Items) != 1 {
+		t.Fatalf("expected 1 statement for %q, got %v", sql, result)
+	}
+	return Classify(&nodes.RawStmt{Stmt: result.Items[0]})
+}
+
+func TestClassifyReadOnly(t *testing.T) {
+	tests := []struct {
+		sql        string
+		cmd        nodes.CmdType
+		readOnly   bool
+		inRecovery bool
+	}{
+		{"SELECT 1", nodes.CMD_SELECT, true, true},
+		{"SELECT * FROM t WHERE id = (SELECT max(id) FROM u)", nodes.CMD_SELECT, true, true},
+		{"SHOW search_path", nodes.CMD_UTILITY, true, true},
+		{"SET search_path = public", nodes.CMD_UTILITY, true, true},
+		{"EXPLAIN SELECT 1", nodes.CMD_UTILITY, true, true},
+		{"EXPLAIN DELETE FROM t", nodes.CMD_UTILITY, true, true},
+		{"BEGIN", nodes.CMD_UTILITY, true, true},
+		{"COPY t TO STDOUT", nodes.CMD_UTILITY, true, true},
+		{"LOCK t IN ACCESS SHARE MODE", nodes.CMD_UTILITY, true, true},
+		{"LOCK t IN SHARE MODE", nodes.CMD_UTILITY, true, false},
+		{"VACUUM t", nodes.CMD_UTILITY, true, false},
+		{"NOTIFY chan", nodes.CMD_UTILITY, true, false},
+		{"COPY t FROM STDIN", nodes.CMD_UTILITY, true, false},
+		{"INSERT INTO t VALUES (1)", nodes.CMD_INSERT, false, false},
+		{"UPDATE t SET a = 1", nodes.CMD_UPDATE, false, false},
+		{"DELETE FROM t", nodes.CMD_DELETE, false, false},
+		{"MERGE INTO t USING s ON t.id = s.id WHEN MATCHED THEN DELETE", nodes.CMD_MERGE, false, false},
+		{"EXPLAIN ANALYZE DELETE FROM t", nodes.CMD_UTILITY, false, false},
+		{"SELECT * INTO t2 FROM t", nodes.CMD_UTILITY, false, false},
+		{"CREATE TABLE t (a int)", nodes.CMD_UTILITY, false, false},
+		{"CALL p()", nodes.CMD_UTILITY, true, true},
+		{"DO $$ BEGIN END $$", nodes.CMD_UTILITY, true, true},
+	}
+	for _, tt := range tests {
+		c := classify(t, tt.sql)
+		if c.CmdType != tt.cmd {
+			t.Errorf("%q: CmdType = %d, want %d", tt.sql, c.CmdType, tt.cmd)
+		}
+		if c.ReadOnly() != tt.readOnly {
+			t.Errorf("%q: ReadOnly() = %v, want %v", tt.sql, c.ReadOnly(), tt.readOnly)
+		}
+		if c.OKInRecovery() != tt.inRecovery {
+			t.Errorf("%q: OKInRecovery() = %v, want %v", tt.sql, c.OKInRecovery(), tt.inRecovery)
+		}
+	}
+}
+
+func TestClassifyModifies(t *testing.T) {
+	tests := []struct {
+		sql    string
+		data   bool
+		schema bool
+	}{
+		{"SELECT 1", false, false},
+		{"INSERT INTO t VALUES (1)", true, false},
+		{"TRUNCATE t", true, false},
+		{"REFRESH MATERIALIZED VIEW mv", true, false},
+		{"CREATE TABLE t2 AS SELECT * FROM t", true, true},
+		{"ALTER TABLE t ADD COLUMN b int", false, true},
+		{"DROP TABLE t", false, true},
+		{"GRANT SELECT ON t TO r", false, true},
+		{"VACUUM t", false, false},
+	}
+	for _, tt := range tests {
+		c := classify(t, tt.sql)
+		if c.ModifiesData != tt.data {
+			t.Errorf("%q: ModifiesData = %v, want %v", tt.sql, c.ModifiesData, tt.data)
+		}
+		if c.ModifiesSchema != tt.schema {
+			t.Errorf("%q: ModifiesSchema = %v, want %v", tt.sql, c.ModifiesSchema, tt.schema)
+		}
+	}
+}
+
+func TestClassifyTransactionControl(t *testing.T) {
+	tests := []struct {
+		sql  string
+		kind nodes.TransactionStmtKind
+	}{
+		{"BEGIN", nodes.TRANS_STMT_BEGIN},
+		{"START TRANSACTION READ ONLY", nodes.TRANS_STMT_START},
+		{"COMMIT", nodes.TRANS_STMT_COMMIT},
+		{"ROLLBACK", nodes.TRANS_STMT_ROLLBACK},
+		{"SAVEPOINT sp", nodes.TRANS_STMT_SAVEPOINT},
+		{"RELEASE SAVEPOINT sp", nodes.TRANS_STMT_RELEASE},
+		{"ROLLBACK TO SAVEPOINT sp", nodes.TRANS_STMT_ROLLBACK_TO},
+		{"PREPARE TRANSACTION 'gid'", nodes.TRANS_STMT_PREPARE},
+	}
+	for _, tt := range tests {
+		c := classify(t, tt.sql)
+		if !c.TransactionControl {
+			t.Errorf("%q: expected TransactionControl", tt.sql)
+		}
+		if c.TransactionKind != tt.kind {
+			t.Errorf("%q: TransactionKind = %d, want %d", tt.sql, c.TransactionKind, tt.kind)
+		}
+	}
+	if c := classify(t, "SELECT 1"); c.TransactionControl {
+		t.Error("SELECT 1: unexpected TransactionControl")
+	}
+}
+
+func TestClassifyNoTransactionBlock(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want bool
+	}{
+		{"VACUUM t", true},
+		{"VACUUM FULL t", true},
+		{"ANALYZE t", false},
+		{"CREATE INDEX CONCURRENTLY i ON t (a)", true},
+		{"CREATE INDEX i ON t (a)", false},
+		{"DROP INDEX CONCURRENTLY i", true},
+		{"DROP INDEX i", false},
+		{"REINDEX TABLE CONCURRENTLY t", true},
+		{"REINDEX TABLE t", false},
+		{"REINDEX DATABASE d", true},
+		{"CLUSTER", true},
+		{"CLUSTER t USING i", false},
+		{"ALTER TABLE t DETACH PARTITION p CONCURRENTLY", true},
+		{"ALTER TABLE t DETACH PARTITION p", false},
+		{"CREATE DATABASE d", true},
+		{"DROP DATABASE d", true},
+		{"ALTER DATABASE d SET TABLESPACE ts", true},
+		{"ALTER DATABASE d SET TABLESPACE off", true},
+		{"ALTER SYSTEM SET work_mem = '64MB'", true},
+		{"DISCARD ALL", true},
+		{"DISCARD PLANS", false},
+		{"COMMIT PREPARED 'gid'", true},
+		{"CREATE SUBSCRIPTION s CONNECTION 'x' PUBLICATION p", true},
+		{"CREATE SUBSCRIPTION s CONNECTION 'x' PUBLICATION p WITH (create_slot = false)", false},
+		{"CREATE SUBSCRIPTION s CONNECTION 'x' PUBLICATION p WITH (connect = off)", false},
+		{"CREATE SUBSCRIPTION s CONNECTION 'x' PUBLICATION p WITH (connect = no)", true},
+		{"ALTER SUBSCRIPTION s REFRESH PUBLICATION", true},
+		{"ALTER SUBSCRIPTION s SET PUBLICATION p WITH (refresh = false)", false},
+	}
+	for _, tt := range tests {
+		c := classify(t, tt.sql)
+		if c.NoTransactionBlock != tt.want {
+			t.Errorf("%q: NoTransactionBlock = %v, want %v", tt.sql, c.NoTransactionBlock, tt.want)
+		}
+	}
+}
+
+func TestClassifyRowLocking(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want nodes.LockClauseStrength
+	}{
+		{"SELECT * FROM t", nodes.LCS_NONE},
+		{"SELECT * FROM t FOR UPDATE", nodes.LCS_FORUPDATE},
+		{"SELECT * FROM t FOR SHARE OF t NOWAIT", nodes.LCS_FORSHARE},
+		{"SELECT * FROM t FOR KEY SHARE FOR NO KEY UPDATE", nodes.LCS_FORNOKEYUPDATE},
+		{"SELECT * FROM (SELECT * FROM t FOR UPDATE) s", nodes.LCS_FORUPDATE},
+		{"DECLARE c CURSOR FOR SELECT * FROM t FOR UPDATE", nodes.LCS_FORUPDATE},
+	}
+	for _, tt := range tests {
+		c := classify(t, tt.sql)
+		if c.RowLocking != tt.want {
+			t.Errorf("%q: RowLocking = %d, want %d", tt.sql, c.RowLocking, tt.want)
+		}
+		if tt.want != nodes.LCS_NONE && c.ReadOnly() {
+			t.Errorf("%q: row-locking statement should not be read-only", tt.sql)
+		}
+	}
+}
+
+func TestClassifyModifyingCTE(t *testing.T) {
+	c := classify(t, "WITH d AS (DELETE FROM t RETURNING *) SELECT * FROM d")
+	if c.CmdType != nodes.CMD_SELECT {
+		t.Errorf("CmdType = %d, want CMD_SELECT", c.CmdType)
+	}
+	if !c.ModifyingCTE || !c.ModifiesData {
+		t.Errorf("expected ModifyingCTE and ModifiesData, got %+v", c)
+	}
+	if c.ReadOnly() {
+		t.Error("data-modifying CTE should not be read-only")
+	}
+
+	c = classify(t, "WITH s AS (SELECT 1) SELECT * FROM s")
+	if c.ModifyingCTE || !c.ReadOnly() {
+		t.Errorf("plain CTE should be read-only, got %+v", c)
+	}
+}
+
+func TestDefGetBoolean(t *testing.T) {
+	tests := []struct {
+		arg  nodes.Node
+		want bool
+		err  bool
+	}{
+		{nil, true, false},
+		{&nodes.Integer{Ival: 0}, false, false},
+		{&nodes.Integer{Ival: 1}, true, false},
+		{&nodes.Integer{Ival: 2}, false, true},
+		{&nodes.String{Sval: "ON"}, true, false},
+		{&nodes.String{Sval: "false"}, false, false},
+		{&nodes.String{Sval: "yes"}, false, true},
+		{&nodes.String{Sval: "f"}, false, true},
+		{&nodes.Boolean{Boolval: true}, true, false},
+		{&nodes.TypeName{Names: &nodes.List{Items: []nodes.Node{&nodes.String{Sval: "off"}}}}, false, false},
+		{&nodes.Float{Fval: "1.0"}, false, true},
+	}
+	for _, tt := range tests {
+		got, err := DefGetBoolean(&nodes.DefElem{Defname: "full", Arg: tt.arg})
+		if got != tt.want || (err != nil) != tt.err {
+			t.Errorf("DefGetBoolean(%v) = %v, %v, want %v (error %v)", tt.arg, got, err, tt.want, tt.err)
+		}
+		if err != nil && err.Error() != "full requires a Boolean value" {
+			t.Errorf("DefGetBoolean(%v) error = %q", tt.arg, err)
+		}
+	}
+}
@@ -0,0 +1,678 @@
+package analysis
+
+import (
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// LockMode is a PostgreSQL table-level lock mode (lockdefs.h). Higher values
+// conflict with more of the other modes.
+type LockMode int
+
+// String returns the lock mode's name as used in pg_locks.
+func (m LockMode) String() string {
+	switch m {
+	case nodes.NoLock:
+		return "NoLock"
+	case nodes.AccessShareLock:
+		return "AccessShareLock"
+	case nodes.RowShareLock:
+		return "RowShareLock"
+	case nodes.RowExclusiveLock:
+		return "RowExclusiveLock"
+	case nodes.ShareUpdateExclusiveLock:
+		return "ShareUpdateExclusiveLock"
+	case nodes.ShareLock:
+		return "ShareLock"
+	case nodes.ShareRowExclusiveLock:
+		return "ShareRowExclusiveLock"
+	case nodes.ExclusiveLock:
+		return "ExclusiveLock"
+	case nodes.AccessExclusiveLock:
+		return "AccessExclusiveLock"
+	}
+	return "UnknownLock"
+}
+
+// lockConflicts is the conflict table from lock.c, indexed by lock mode.
+var lockConflicts = [...]uint16{
+	0,
+	// AccessShareLock
+	1 << nodes.AccessExclusiveLock,
+	// RowShareLock
+	(1 << nodes.ExclusiveLock) | (1 << nodes.AccessExclusiveLock),
+	// RowExclusiveLock
+	(1 << nodes.ShareLock) | (1 << nodes.ShareRowExclusiveLock) |
+		(1 << nodes.ExclusiveLock) | (1 << nodes.AccessExclusiveLock),
+	// ShareUpdateExclusiveLock
+	(1 << nodes.ShareUpdateExclusiveLock) | (1 << nodes.ShareLock) |
+		(1 << nodes.ShareRowExclusiveLock) | (1 << nodes.ExclusiveLock) |
+		(1 << nodes.AccessExclusiveLock),
+	// ShareLock
+	(1 << nodes.RowExclusiveLock) | (1 << nodes.ShareUpdateExclusiveLock) |
+		(1 << nodes.ShareRowExclusiveLock) | (1 << nodes.ExclusiveLock) |
+		(1 << nodes.AccessExclusiveLock),
+	// ShareRowExclusiveLock
+	(1 << nodes.RowExclusiveLock) | (1 << nodes.ShareUpdateExclusiveLock) |
+		(1 << nodes.ShareLock) | (1 << nodes.ShareRowExclusiveLock) |
+		(1 << nodes.ExclusiveLock) | (1 << nodes.AccessExclusiveLock),
+	// ExclusiveLock
+	(1 << nodes.RowShareLock) | (1 << nodes.RowExclusiveLock) |
+		(1 << nodes.ShareUpdateExclusiveLock) | (1 << nodes.ShareLock) |
+		(1 << nodes.ShareRowExclusiveLock) | (1 << nodes.ExclusiveLock) |
+		(1 << nodes.AccessExclusiveLock),
+	// AccessExclusiveLock
+	(1 << nodes.AccessShareLock) | (1 << nodes.RowShareLock) |
+		(1 << nodes.RowExclusiveLock) | (1 << nodes.ShareUpdateExclusiveLock) |
+		(1 << nodes.ShareLock) | (1 << nodes.ShareRowExclusiveLock) |
+		(1 << nodes.ExclusiveLock) | (1 << nodes.AccessExclusiveLock),
+}
+
+// ConflictsWith reports whether a lock held in mode m blocks a request for
+// mode other (and vice versa; the table is symmetric).
+func (m LockMode) ConflictsWith(other LockMode) bool {
+	if m <= nodes.NoLock || other <= nodes.NoLock ||
+		int(m) >= len(lockConflicts) || int(other) >= len(lockConflicts) {
+		return false
+	}
+	return lockConflicts[m]&(1<<uint(other)) != 0
+}
+
+// BlocksReads reports whether the mode conflicts with plain SELECT.
+func (m LockMode) BlocksReads() bool {
+	return m.ConflictsWith(nodes.AccessShareLock)
+}
+
+// BlocksWrites reports whether the mode conflicts with INSERT/UPDATE/DELETE.
+func (m LockMode) BlocksWrites() bool {
+	return m.ConflictsWith(nodes.RowExclusiveLock)
+}
+
+// RelationLock is a table-level lock that a statement acquires.
+type RelationLock struct {
+	Relation *nodes.RangeVar
+	Mode     LockMode
+}
+
+// StatementLocks returns the relation-level locks PostgreSQL 17 takes when
+// executing stmt. Each relation appears once, with the strongest mode that
+// the statement requests on it. Locks on objects that are not named in the
+// statement (such as indexes of a table being rewritten, or catalogs) are
+// not reported.
+func StatementLocks(stmt nodes.Node) []RelationLock {
+	lc := &lockCollector{}
+	lc.collect(stmt)
+	return lc.locks
+}
+
+// lockCollector accumulates RelationLocks, merging duplicates.
+type lockCollector struct {
+	locks []RelationLock
+}
+
+// add records a lock on rv, upgrading any existing lock on the same name.
+func (lc *lockCollector) add(rv *nodes.RangeVar, mode LockMode) {
+	if rv == nil || mode == nodes.NoLock {
+		return
+	}
+	for i := range lc.locks {
+		if sameRelation(lc.locks[i].Relation, rv) {
+			if mode > lc.locks[i].Mode {
+				lc.locks[i].Mode = mode
+			}
+			return
+		}
+	}
+	lc.locks = append(lc.locks, RelationLock{Relation: rv, Mode: mode})
+}
+
+// addList records a lock on every RangeVar in l.
+func (lc *lockCollector) addList(l *nodes.List, mode LockMode) {
+	for _, item := range listItems(l) {
+		if rv, ok := item.(*nodes.RangeVar); ok {
+			lc.add(rv, mode)
+		}
+	}
+}
+
+func (lc *lockCollector) collect(stmt nodes.Node) {
+	switch n := stmt.(type) {
+	case *nodes.SelectStmt, *nodes.InsertStmt, *nodes.UpdateStmt,
+		*nodes.DeleteStmt, *nodes.MergeStmt:
+		lc.collectQuery(stmt)
+
+	case *nodes.AlterTableStmt:
+		lc.add(n.Relation, AlterTableGetLockLevel(n.Cmds))
+		for _, item := range listItems(n.Cmds) {
+			cmd, ok := item.(*nodes.AlterTableCmd)
+			if !ok {
+				continue
+			}
+			switch nodes.AlterTableType(cmd.Subtype) {
+			case nodes.AT_AddConstraint:
+				if con, ok := cmd.Def.(*nodes.Constraint); ok && con.Contype == nodes.CONSTR_FOREIGN {
+					lc.add(con.Pktable, nodes.ShareRowExclusiveLock)
+				}
+			case nodes.AT_AddColumn:
+				if col, ok := cmd.Def.(*nodes.ColumnDef); ok {
+					lc.collectColumnRefs(col)
+				}
+			case nodes.AT_AttachPartition:
+				if pc, ok := cmd.Def.(*nodes.PartitionCmd); ok {
+					lc.add(pc.Name, nodes.AccessExclusiveLock)
+				}
+			case nodes.AT_DetachPartition:
+				if pc, ok := cmd.Def.(*nodes.PartitionCmd); ok {
+					if pc.Concurrent {
+						lc.add(pc.Name, nodes.ShareUpdateExclusiveLock)
+					} else {
+						lc.add(pc.Name, nodes.AccessExclusiveLock)
+					}
+				}
+			case nodes.AT_DetachPartitionFinalize:
+				if pc, ok := cmd.Def.(*nodes.PartitionCmd); ok {
+					lc.add(pc.Name, nodes.ShareUpdateExclusiveLock)
+				}
+			case nodes.AT_AddInherit, nodes.AT_DropInherit:
+				if rv, ok := cmd.Def.(*nodes.RangeVar); ok {
+					lc.add(rv, nodes.ShareUpdateExclusiveLock)
+				}
+			}
+		}
+
+	case *nodes.IndexStmt:
+		if n.Concurrent {
+			lc.add(n.Relation, nodes.ShareUpdateExclusiveLock)
+		} else {
+			lc.add(n.Relation, nodes.ShareLock)
+		}
+
+	case *nodes.CreateStmt:
+		lc.collectCreateStmt(n)
+	case *nodes.CreateForeignTableStmt:
+		lc.collectCreateStmt(&n.Base)
+
+	case *nodes.CreateTrigStmt:
+		lc.add(n.Relation, nodes.ShareRowExclusiveLock)
+		lc.add(n.Constrrel, nodes.AccessShareLock)
+
+	case *nodes.RuleStmt:
+		lc.add(n.Relation, nodes.AccessExclusiveLock)
+	case *nodes.CreatePolicyStmt:
+		lc.add(n.Table, nodes.AccessExclusiveLock)
+	case *nodes.AlterPolicyStmt:
+		lc.add(n.Table, nodes.AccessExclusiveLock)
+	case *nodes.CreateStatsStmt:
+		lc.addList(n.Relations, nodes.ShareUpdateExclusiveLock)
+
+	case *nodes.VacuumStmt:
+		mode := LockMode(nodes.ShareUpdateExclusiveLock)
+		if n.IsVacuumcmd && hasOption(n.Options, "full") {
+			mode = nodes.AccessExclusiveLock
+		}
+		for _, item := range listItems(n.Rels) {
+			if vr, ok := item.(*nodes.VacuumRelation); ok {
+				lc.add(vr.Relation, mode)
+			}
+		}
+
+	case *nodes.ClusterStmt:
+		lc.add(n.Relation, nodes.AccessExclusiveLock)
+
+	case *nodes.ReindexStmt:
+		switch {
+		case n.Relation == nil:
+		case hasOption(n.Params, "concurrently"):
+			lc.add(n.Relation, nodes.ShareUpdateExclusiveLock)
+		case n.Kind == nodes.REINDEX_OBJECT_INDEX:
+			// The parent table is locked in ShareLock as well, but it
+			// is not named in the statement.
+			lc.add(n.Relation, nodes.AccessExclusiveLock)
+		default:
+			lc.add(n.Relation, nodes.ShareLock)
+		}
+
+	case *nodes.RefreshMatViewStmt:
+		if n.Concurrent {
+			lc.add(n.Relation, nodes.ExclusiveLock)
+		} else {
+			lc.add(n.Relation, nodes.AccessExclusiveLock)
+		}
+
+	case *nodes.TruncateStmt:
+		lc.addList(n.Relations, nodes.AccessExclusiveLock)
+
+	case *nodes.LockStmt:
+		lc.addList(n.Relations, LockMode(n.Mode))
+
+	case *nodes.DropStmt:
+		lc.collectDropStmt(n)
+
+	case *nodes.RenameStmt:
+		switch n.RenameType {
+		case nodes.OBJECT_INDEX:
+			lc.add(n.Relation, nodes.ShareUpdateExclusiveLock)
+		case nodes.OBJECT_TABLE, nodes.OBJECT_SEQUENCE, nodes.OBJECT_VIEW,
+			nodes.OBJECT_MATVIEW, nodes.OBJECT_FOREIGN_TABLE,
+			nodes.OBJECT_COLUMN, nodes.OBJECT_ATTRIBUTE,
+			nodes.OBJECT_TABCONSTRAINT, nodes.OBJECT_TRIGGER,
+			nodes.OBJECT_RULE, nodes.OBJECT_POLICY:
+			lc.add(n.Relation, nodes.AccessExclusiveLock)
+		}
+
+	case *nodes.AlterObjectSchemaStmt:
+		lc.add(n.Relation, nodes.AccessExclusiveLock)
+
+	case *nodes.AlterSeqStmt:
+		lc.add(n.Sequence, nodes.ShareRowExclusiveLock)
+
+	case *nodes.ViewStmt:
+		// CREATE OR REPLACE VIEW on an existing view.
+		if n.Replace {
+			lc.add(n.View, nodes.AccessExclusiveLock)
+		}
+		lc.collectQuery(n.Query)
+
+	case *nodes.CreateTableAsStmt:
+		lc.collectQuery(n.Query)
+
+	case *nodes.CommentStmt:
+		switch n.Objtype {
+		case nodes.OBJECT_TABLE, nodes.OBJECT_VIEW, nodes.OBJECT_MATVIEW,
+			nodes.OBJECT_SEQUENCE, nodes.OBJECT_FOREIGN_TABLE, nodes.OBJECT_INDEX:
+			if l, ok := n.Object.(*nodes.List); ok {
+				lc.add(rangeVarFromNameList(l), nodes.ShareUpdateExclusiveLock)
+			}
+		case nodes.OBJECT_COLUMN:
+			if l, ok := n.Object.(*nodes.List); ok && l.Len() > 1 {
+				lc.add(rangeVarFromNameList(&nodes.List{Items: l.Items[:len(l.Items)-1]}), nodes.ShareUpdateExclusiveLock)
+			}
+		}
+
+	case *nodes.CopyStmt:
+		if n.Relation != nil {
+			if n.IsFrom {
+				lc.add(n.Relation, nodes.RowExclusiveLock)
+			} else {
+				lc.add(n.Relation, nodes.AccessShareLock)
+			}
+		}
+		if n.Query != nil {
+			lc.collectQuery(n.Query)
+		}
+
+	case *nodes.ExplainStmt:
+		if hasOption(n.Options, "analyze") {
+			lc.collect(n.Query)
+		}
+
+	case *nodes.DeclareCursorStmt:
+		lc.collectQuery(n.Query)
+	}
+}
+
+// collectCreateStmt records the locks CREATE TABLE takes on other tables:
+// inheritance parents and tables referenced by foreign keys.
+func (lc *lockCollector) collectCreateStmt(n *nodes.CreateStmt) {
+	// DefineRelation locks a partitioned parent more strongly than a
+	// plain inheritance parent.
+	parentMode := LockMode(nodes.ShareUpdateExclusiveLock)
+	if n.Partbound != nil {
+		parentMode = nodes.AccessExclusiveLock
+	}
+	lc.addList(n.InhRelations, parentMode)
+
+	for _, item := range listItems(n.Constraints) {
+		if con, ok := item.(*nodes.Constraint); ok && con.Contype == nodes.CONSTR_FOREIGN {
+			lc.add(con.Pktable, nodes.ShareRowExclusiveLock)
+		}
+	}
+	for _, item := range listItems(n.TableElts) {
+		switch elt := item.(type) {
+		case *nodes.ColumnDef:
+			lc.collectColumnRefs(elt)
+		case *nodes.Constraint:
+			if elt.Contype == nodes.CONSTR_FOREIGN {
+				lc.add(elt.Pktable, nodes.ShareRowExclusiveLock)
+			}
+		case *nodes.TableLikeClause:
+			lc.add(elt.Relation, nodes.AccessShareLock)
+		}
+	}
+}
+
+// collectColumnRefs records locks on tables referenced by inline REFERENCES
+// constraints of a column definition.
+func (lc *lockCollector) collectColumnRefs(col *nodes.ColumnDef) {
+	for _, item := range listItems(col.Constraints) {
+		if con, ok := item.(*nodes.Constraint); ok && con.Contype == nodes.CONSTR_FOREIGN {
+			lc.add(con.Pktable, nodes.ShareRowExclusiveLock)
+		}
+	}
+}
+
+// collectDropStmt records the locks taken by DROP on relations and on the
+// tables that own triggers, rules and policies.
+func (lc *lockCollector) collectDropStmt(n *nodes.DropStmt) {
+	switch nodes.ObjectType(n.RemoveType) {
+	case nodes.OBJECT_TABLE, nodes.OBJECT_SEQUENCE, nodes.OBJECT_VIEW,
+		nodes.OBJECT_MATVIEW, nodes.OBJECT_FOREIGN_TABLE:
+		for _, item := range listItems(n.Objects) {
+			if l, ok := item.(*nodes.List); ok {
+				lc.add(rangeVarFromNameList(l), nodes.AccessExclusiveLock)
+			}
+		}
+	case nodes.OBJECT_INDEX:
+		mode := LockMode(nodes.AccessExclusiveLock)
+		if n.Concurrent {
+			mode = nodes.ShareUpdateExclusiveLock
+		}
+		for _, item := range listItems(n.Objects) {
+			if l, ok := item.(*nodes.List); ok {
+				lc.add(rangeVarFromNameList(l), mode)
+			}
+		}
+	case nodes.OBJECT_TRIGGER, nodes.OBJECT_RULE, nodes.OBJECT_POLICY:
+		// The object name is the owning relation's name followed by the
+		// object's own name.
+		for _, item := range listItems(n.Objects) {
+			if l, ok := item.(*nodes.List); ok && l.Len() > 1 {
+				rel := &nodes.List{Items: l.Items[:len(l.Items)-1]}
+				lc.add(rangeVarFromNameList(rel), nodes.AccessExclusiveLock)
+			}
+		}
+	}
+}
+
+// collectQuery records the locks taken by an optimizable statement: the
+// result relation of INSERT/UPDATE/DELETE/MERGE gets RowExclusiveLock,
+// relations named in FOR UPDATE/SHARE get RowShareLock and everything else
+// that is read gets AccessShareLock.
+func (lc *lockCollector) collectQuery(stmt nodes.Node) {
+	if stmt == nil {
+		return
+	}
+	// WITH clauses are visited after the statement body, so gather CTE
+	// names first to avoid reporting references to them as relations.
+	ctes := make(map[string]bool)
+	nodes.Walk(stmt, func(n nodes.Node) bool {
+		if cte, ok := n.(*nodes.CommonTableExpr); ok {
+			ctes[cte.Ctename] = true
+		}
+		return true
+	})
+	nodes.Walk(stmt, func(n nodes.Node) bool {
+		switch n := n.(type) {
+		case *nodes.InsertStmt:
+			lc.add(n.Relation, nodes.RowExclusiveLock)
+		case *nodes.UpdateStmt:
+			lc.add(n.Relation, nodes.RowExclusiveLock)
+		case *nodes.DeleteStmt:
+			lc.add(n.Relation, nodes.RowExclusiveLock)
+		case *nodes.MergeStmt:
+			lc.add(n.Relation, nodes.RowExclusiveLock)
+		case *nodes.SelectStmt:
+			lc.collectLockingClauses(n)
+		case *nodes.RangeVar:
+			if n.Schemaname == "" && ctes[n.Relname] {
+				return true
+			}
+			lc.add(n, nodes.AccessShareLock)
+		}
+		return true
+	})
+}
+
+// collectLockingClauses records RowShareLock for relations named in a
+// SELECT's FOR UPDATE/SHARE clauses, or for every FROM relation when the
+// clause has no OF list.
+func (lc *lockCollector) collectLockingClauses(n *nodes.SelectStmt) {
+	for _, item := range listItems(n.LockingClause) {
+		clause, ok := item.(*nodes.LockingClause)
+		if !ok {
+			continue
+		}
+		if clause.LockedRels.Len() > 0 {
+			lc.addList(clause.LockedRels, nodes.RowShareLock)
+			continue
+		}
+		for _, from := range listItems(n.FromClause) {
+			nodes.Walk(from, func(fn nodes.Node) bool {
+				switch fn := fn.(type) {
+				case *nodes.RangeSubselect:
+					return false
+				case *nodes.RangeVar:
+					lc.add(fn, nodes.RowShareLock)
+				}
+				return true
+			})
+		}
+	}
+}
+
+// AlterTableGetLockLevel returns the lock mode ALTER TABLE takes on its
+// target relation for the given list of AlterTableCmds. It follows
+// AlterTableGetLockLevel in tablecmds.c: the result is the strongest mode
+// required by any subcommand.
+func AlterTableGetLockLevel(cmds *nodes.List) LockMode {
+	lockmode := LockMode(nodes.ShareUpdateExclusiveLock)
+	for _, item := range listItems(cmds) {
+		cmd, ok := item.(*nodes.AlterTableCmd)
+		if !ok {
+			continue
+		}
+		if m := alterTableCmdLockLevel(cmd); m > lockmode {
+			lockmode = m
+		}
+	}
+	return lockmode
+}
+
+// alterTableCmdLockLevel returns the lock mode for a single subcommand.
+func alterTableCmdLockLevel(cmd *nodes.AlterTableCmd) LockMode {
+	switch nodes.AlterTableType(cmd.Subtype) {
+	// These subcommands rewrite the heap, so require full locks.
+	case nodes.AT_AddColumn, nodes.AT_SetAccessMethod,
+		nodes.AT_SetTableSpace, nodes.AT_AlterColumnType:
+		return nodes.AccessExclusiveLock
+
+	// These subcommands may require addition of toast tables.
+	case nodes.AT_SetStorage:
+		return nodes.AccessExclusiveLock
+
+	// Removing constraints can affect SELECTs that have been optimized
+	// assuming the constraint holds true.
+	case nodes.AT_DropConstraint, nodes.AT_DropNotNull:
+		return nodes.AccessExclusiveLock
+
+	// Subcommands that may be visible to concurrent SELECTs.
+	case nodes.AT_DropColumn, nodes.AT_AddColumnToView, nodes.AT_DropOids,
+		nodes.AT_EnableAlwaysRule, nodes.AT_EnableReplicaRule,
+		nodes.AT_EnableRule, nodes.AT_DisableRule:
+		return nodes.AccessExclusiveLock
+
+	// Changing owner may remove implicit SELECT privileges.
+	case nodes.AT_ChangeOwner:
+		return nodes.AccessExclusiveLock
+
+	// Changing foreign table options may affect optimization.
+	case nodes.AT_GenericOptions, nodes.AT_AlterColumnGenericOptions:
+		return nodes.AccessExclusiveLock
+
+	// These subcommands affect write operations only.
+	case nodes.AT_EnableTrig, nodes.AT_EnableAlwaysTrig,
+		nodes.AT_EnableReplicaTrig, nodes.AT_EnableTrigAll,
+		nodes.AT_EnableTrigUser, nodes.AT_DisableTrig,
+		nodes.AT_DisableTrigAll, nodes.AT_DisableTrigUser:
+		return nodes.ShareRowExclusiveLock
+
+	// These subcommands affect write operations only. XXX Theoretically,
+	// these could be ShareRowExclusiveLock.
+	case nodes.AT_ColumnDefault, nodes.AT_CookedColumnDefault,
+		nodes.AT_AlterConstraint, nodes.AT_AddIndex,
+		nodes.AT_AddIndexConstraint, nodes.AT_ReplicaIdentity,
+		nodes.AT_SetNotNull, nodes.AT_EnableRowSecurity,
+		nodes.AT_DisableRowSecurity, nodes.AT_ForceRowSecurity,
+		nodes.AT_NoForceRowSecurity, nodes.AT_AddIdentity,
+		nodes.AT_DropIdentity, nodes.AT_SetIdentity,
+		nodes.AT_SetExpression, nodes.AT_DropExpression,
+		nodes.AT_SetCompression:
+		return nodes.AccessExclusiveLock
+
+	case nodes.AT_AddConstraint, nodes.AT_ReAddConstraint, nodes.AT_ReAddDomainConstraint:
+		con, ok := cmd.Def.(*nodes.Constraint)
+		if !ok {
+			// PostgreSQL keeps the default it starts every subcommand with.
+			return nodes.AccessExclusiveLock
+		}
+		switch con.Contype {
+		case nodes.CONSTR_EXCLUSION, nodes.CONSTR_PRIMARY, nodes.CONSTR_UNIQUE:
+			// Cases essentially the same as CREATE INDEX.
+			return nodes.AccessExclusiveLock
+		case nodes.CONSTR_FOREIGN:
+			// We add triggers to both tables when we add a foreign key,
+			// so the lock level must be at least as strong as CREATE
+			// TRIGGER.
+			return nodes.ShareRowExclusiveLock
+		default:
+			return nodes.AccessExclusiveLock
+		}
+
+	// These subcommands affect inheritance behaviour.
+	case nodes.AT_AddInherit, nodes.AT_DropInherit:
+		return nodes.AccessExclusiveLock
+
+	// These subcommands affect implicit row type conversion.
+	case nodes.AT_AddOf, nodes.AT_DropOf:
+		return nodes.AccessExclusiveLock
+
+	// Only used by CREATE OR REPLACE VIEW which must conflict with
+	// SELECTs currently using the view.
+	case nodes.AT_ReplaceRelOptions:
+		return nodes.AccessExclusiveLock
+
+	// These subcommands affect general strategies for performance and
+	// maintenance, though don't change the semantic results from normal
+	// data reads and writes.
+	case nodes.AT_SetStatistics, nodes.AT_ClusterOn, nodes.AT_DropCluster,
+		nodes.AT_SetOptions, nodes.AT_ResetOptions:
+		return nodes.ShareUpdateExclusiveLock
+
+	case nodes.AT_SetLogged, nodes.AT_SetUnLogged:
+		return nodes.AccessExclusiveLock
+
+	case nodes.AT_ValidateConstraint:
+		return nodes.ShareUpdateExclusiveLock
+
+	// Rel options are more complex than first appears.
+	case nodes.AT_SetRelOptions, nodes.AT_ResetRelOptions:
+		l, _ := cmd.Def.(*nodes.List)
+		return AlterTableGetRelOptionsLockLevel(l)
+
+	case nodes.AT_AttachPartition:
+		return nodes.ShareUpdateExclusiveLock
+
+	case nodes.AT_DetachPartition:
+		if pc, ok := cmd.Def.(*nodes.PartitionCmd); ok && pc.Concurrent {
+			return nodes.ShareUpdateExclusiveLock
+		}
+		return nodes.AccessExclusiveLock
+
+	case nodes.AT_DetachPartitionFinalize:
+		return nodes.ShareUpdateExclusiveLock
+
+	// This only examines the table's schema; but lock must be strong
+	// enough to prevent concurrent DROP NOT NULL.
+	case nodes.AT_CheckNotNull:
+		return nodes.AccessShareLock
+
+	case nodes.AT_ReAddStatistics:
+		return nodes.ShareUpdateExclusiveLock
+	}
+	// Internal subcommands (AT_ReAddIndex, AT_ReAddComment) only arise
+	// while rewriting a table, which already holds the strongest lock.
+	return nodes.AccessExclusiveLock
+}
+
+// relOptionLockModes gives the lock needed to change each reloption, from
+// the option tables in reloptions.c. Options that are not listed require
+// AccessExclusiveLock.
+var relOptionLockModes = map[string]LockMode{
+	"autosummarize":                         nodes.ShareUpdateExclusiveLock,
+	"autovacuum_enabled":                    nodes.ShareUpdateExclusiveLock,
+	"vacuum_truncate":                       nodes.ShareUpdateExclusiveLock,
+	"deduplicate_items":                     nodes.ShareUpdateExclusiveLock,
+	"fillfactor":                            nodes.ShareUpdateExclusiveLock,
+	"autovacuum_vacuum_threshold":           nodes.ShareUpdateExclusiveLock,
+	"autovacuum_vacuum_insert_threshold":    nodes.ShareUpdateExclusiveLock,
+	"autovacuum_analyze_threshold":          nodes.ShareUpdateExclusiveLock,
+	"autovacuum_vacuum_cost_limit":          nodes.ShareUpdateExclusiveLock,
+	"autovacuum_freeze_min_age":             nodes.ShareUpdateExclusiveLock,
+	"autovacuum_multixact_freeze_min_age":   nodes.ShareUpdateExclusiveLock,
+	"autovacuum_freeze_max_age":             nodes.ShareUpdateExclusiveLock,
+	"autovacuum_multixact_freeze_max_age":   nodes.ShareUpdateExclusiveLock,
+	"autovacuum_freeze_table_age":           nodes.ShareUpdateExclusiveLock,
+	"autovacuum_multixact_freeze_table_age": nodes.ShareUpdateExclusiveLock,
+	"log_autovacuum_min_duration":           nodes.ShareUpdateExclusiveLock,
+	"toast_tuple_target":                    nodes.ShareUpdateExclusiveLock,
+	"pages_per_range":                       nodes.ShareUpdateExclusiveLock,
+	"gin_pending_list_limit":                nodes.ShareUpdateExclusiveLock,
+	"parallel_workers":                      nodes.ShareUpdateExclusiveLock,
+	"vacuum_index_cleanup":                  nodes.ShareUpdateExclusiveLock,
+	"autovacuum_vacuum_cost_delay":          nodes.ShareUpdateExclusiveLock,
+	"autovacuum_vacuum_scale_factor":        nodes.ShareUpdateExclusiveLock,
+	"autovacuum_vacuum_insert_scale_factor": nodes.ShareUpdateExclusiveLock,
+	"autovacuum_analyze_scale_factor":       nodes.ShareUpdateExclusiveLock,
+}
+
+// AlterTableGetRelOptionsLockLevel returns the strongest lock needed to set
+// or reset the given reloptions (a list of DefElem), following
+// AlterTableGetRelOptionsLockLevel in reloptions.c.
+func AlterTableGetRelOptionsLockLevel(defList *nodes.List) LockMode {
+	lockmode := LockMode(nodes.NoLock)
+	for _, item := range listItems(defList) {
+		def, ok := item.(*nodes.DefElem)
+		if !ok {
+			continue
+		}
+		mode, ok := relOptionLockModes[strings.ToLower(def.Defname)]
+		if !ok {
+			mode = nodes.AccessExclusiveLock
+		}
+		if mode > lockmode {
+			lockmode = mode
+		}
+	}
+	return lockmode
+}
+
+// rangeVarFromNameList builds a RangeVar from a qualified name, following
+// makeRangeVarFromNameList in namespace.c.
+func rangeVarFromNameList(names *nodes.List) *nodes.RangeVar {
+	var parts []string
+	for _, item := range listItems(names) {
+		s, ok := item.(*nodes.String)
+		if !ok {
+			return nil
+		}
+		parts = append(parts, s.Sval)
+	}
+	rv := &nodes.RangeVar{Inh: true, Relpersistence: nodes.RELPERSISTENCE_PERMANENT, Location: -1}
+	switch len(parts) {
+	case 1:
+		rv.Relname = parts[0]
+	case 2:
+		rv.Schemaname, rv.Relname = parts[0], parts[1]
+	case 3:
+		rv.Catalogname, rv.Schemaname, rv.Relname = parts[0], parts[1], parts[2]
+	default:
+		return nil
+	}
+	return rv
+}
+
+// sameRelation reports whether two RangeVars name the same relation.
+func sameRelation(a, b *nodes.RangeVar) bool {
+	return a.Catalogname == b.Catalogname && a.Schemaname == b.Schemaname && a.Relname == b.Relname
+}
@@ -0,0 +1,139 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+)
+
+// locksOf parses a single statement and returns its locks keyed by
+// qualified relation name.
+func locksOf(t *testing.T, sql string) map[string]LockMode {
+	t.Helper()
+	result, err := parser.Parse(sql)
+	if err != nil {
+		t.Fatalf("Parse(%q) error: %v", sql, err)
+	}
+	if result == nil || len(result.Items) != 1 {
+		t.Fatalf("expected 1 statement for %q, got %v", sql, result)
+	}
+	out := make(map[string]LockMode)
+	for _, l := range StatementLocks(result.Items[0]) {
+		name := l.Relation.Relname
+		if l.Relation.Schemaname != "" {
+			name = l.Relation.Schemaname + "." + name
+		}
+		out[name] = l.Mode
+	}
+	return out
+}
+
+func TestStatementLocks(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want map[string]LockMode
+	}{
+		// ALTER TABLE subcommands
+		{"ALTER TABLE t ADD COLUMN c int", map[string]LockMode{"t": nodes.AccessExclusiveLock}},
+		{"ALTER TABLE t ALTER COLUMN c TYPE bigint", map[string]LockMode{"t": nodes.AccessExclusiveLock}},
+		{"ALTER TABLE t VALIDATE CONSTRAINT c", map[string]LockMode{"t": nodes.ShareUpdateExclusiveLock}},
+		{"ALTER TABLE t ALTER COLUMN c SET STATISTICS 100", map[string]LockMode{"t": nodes.ShareUpdateExclusiveLock}},
+		{"ALTER TABLE t CLUSTER ON i", map[string]LockMode{"t": nodes.ShareUpdateExclusiveLock}},
+		{"ALTER TABLE t DISABLE TRIGGER ALL", map[string]LockMode{"t": nodes.ShareRowExclusiveLock}},
+		{"ALTER TABLE t SET (fillfactor = 70)", map[string]LockMode{"t": nodes.ShareUpdateExclusiveLock}},
+		{"ALTER TABLE t SET (fillfactor = 70, user_catalog_table = true)", map[string]LockMode{"t": nodes.AccessExclusiveLock}},
+		{"ALTER TABLE t VALIDATE CONSTRAINT c, DROP COLUMN d", map[string]LockMode{"t": nodes.AccessExclusiveLock}},
+		{"ALTER TABLE s.t ADD CONSTRAINT fk FOREIGN KEY (a) REFERENCES u (b) NOT VALID",
+			map[string]LockMode{"s.t": nodes.ShareRowExclusiveLock, "u": nodes.ShareRowExclusiveLock}},
+		{"ALTER TABLE t ADD CONSTRAINT pk PRIMARY KEY (a)", map[string]LockMode{"t": nodes.AccessExclusiveLock}},
+		{"ALTER TABLE p ATTACH PARTITION c FOR VALUES IN (1)",
+			map[string]LockMode{"p": nodes.ShareUpdateExclusiveLock, "c": nodes.AccessExclusiveLock}},
+		{"ALTER TABLE p DETACH PARTITION c CONCURRENTLY",
+			map[string]LockMode{"p": nodes.ShareUpdateExclusiveLock, "c": nodes.ShareUpdateExclusiveLock}},
+		{"ALTER TABLE p DETACH PARTITION c",
+			map[string]LockMode{"p": nodes.AccessExclusiveLock, "c": nodes.AccessExclusiveLock}},
+
+		// Other DDL
+		{"CREATE INDEX i ON t (a)", map[string]LockMode{"t": nodes.ShareLock}},
+		{"CREATE INDEX CONCURRENTLY i ON t (a)", map[string]LockMode{"t": nodes.ShareUpdateExclusiveLock}},
+		{"CREATE TRIGGER tg BEFORE INSERT ON t FOR EACH ROW EXECUTE FUNCTION f()",
+			map[string]LockMode{"t": nodes.ShareRowExclusiveLock}},
+		{"CREATE TABLE c (a int REFERENCES p (a))", map[string]LockMode{"p": nodes.ShareRowExclusiveLock}},
+		{"CREATE TABLE c PARTITION OF p FOR VALUES IN (1)", map[string]LockMode{"p": nodes.AccessExclusiveLock}},
+		{"DROP TABLE a, s.b", map[string]LockMode{"a": nodes.AccessExclusiveLock, "s.b": nodes.AccessExclusiveLock}},
+		{"DROP INDEX CONCURRENTLY i", map[string]LockMode{"i": nodes.ShareUpdateExclusiveLock}},
+		{"DROP TRIGGER tg ON s.t", map[string]LockMode{"s.t": nodes.AccessExclusiveLock}},
+		{"ALTER INDEX i RENAME TO j", map[string]LockMode{"i": nodes.ShareUpdateExclusiveLock}},
+		{"ALTER TABLE t RENAME COLUMN a TO b", map[string]LockMode{"t": nodes.AccessExclusiveLock}},
+		{"TRUNCATE a, b", map[string]LockMode{"a": nodes.AccessExclusiveLock, "b": nodes.AccessExclusiveLock}},
+		{"REFRESH MATERIALIZED VIEW CONCURRENTLY mv", map[string]LockMode{"mv": nodes.ExclusiveLock}},
+
+		// Maintenance
+		{"VACUUM t", map[string]LockMode{"t": nodes.ShareUpdateExclusiveLock}},
+		{"VACUUM FULL t", map[string]LockMode{"t": nodes.AccessExclusiveLock}},
+		{"ANALYZE t", map[string]LockMode{"t": nodes.ShareUpdateExclusiveLock}},
+		{"CLUSTER t USING i", map[string]LockMode{"t": nodes.AccessExclusiveLock}},
+		{"REINDEX TABLE t", map[string]LockMode{"t": nodes.ShareLock}},
+		{"REINDEX TABLE CONCURRENTLY t", map[string]LockMode{"t": nodes.ShareUpdateExclusiveLock}},
+		{"LOCK TABLE t IN EXCLUSIVE MODE", map[string]LockMode{"t": nodes.ExclusiveLock}},
+
+		// DML
+		{"SELECT * FROM a JOIN b ON a.id = b.id", map[string]LockMode{"a": nodes.AccessShareLock, "b": nodes.AccessShareLock}},
+		{"SELECT * FROM a, b FOR UPDATE OF a", map[string]LockMode{"a": nodes.RowShareLock, "b": nodes.AccessShareLock}},
+		{"UPDATE t SET a = 1 FROM u WHERE t.id = u.id",
+			map[string]LockMode{"t": nodes.RowExclusiveLock, "u": nodes.AccessShareLock}},
+		{"WITH x AS (SELECT 1) INSERT INTO t SELECT * FROM x", map[string]LockMode{"t": nodes.RowExclusiveLock}},
+	}
+	for _, tt := range tests {
+		got := locksOf(t, tt.sql)
+		if len(got) != len(tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.sql, got, tt.want)
+			continue
+		}
+		for rel, mode := range tt.want {
+			if got[rel] != mode {
+				t.Errorf("%q: lock on %s = %s, want %s", tt.sql, rel, got[rel], mode)
+			}
+		}
+	}
+}
+
+func TestAlterTableGetLockLevelOddDef(t *testing.T) {
+	// A constraint subcommand without a Constraint keeps the default mode.
+	cmds := &nodes.List{Items: []nodes.Node{
+		&nodes.AlterTableCmd{Subtype: nodes.AT_AddConstraint, Def: &nodes.String{Sval: "c"}},
+	}}
+	if got := AlterTableGetLockLevel(cmds); got != nodes.AccessExclusiveLock {
+		t.Errorf("AlterTableGetLockLevel = %s, want %s", got, LockMode(nodes.AccessExclusiveLock))
+	}
+}
+
+func TestLockModeConflicts(t *testing.T) {
+	tests := []struct {
+		mode         LockMode
+		blocksReads  bool
+		blocksWrites bool
+	}{
+		{nodes.AccessShareLock, false, false},
+		{nodes.ShareUpdateExclusiveLock, false, false},
+		{nodes.ShareLock, false, true},
+		{nodes.ShareRowExclusiveLock, false, true},
+		{nodes.ExclusiveLock, false, true},
+		{nodes.AccessExclusiveLock, true, true},
+	}
+	for _, tt := range tests {
+		if tt.mode.BlocksReads() != tt.blocksReads {
+			t.Errorf("%s.BlocksReads() = %v, want %v", tt.mode, tt.mode.BlocksReads(), tt.blocksReads)
+		}
+		if tt.mode.BlocksWrites() != tt.blocksWrites {
+			t.Errorf("%s.BlocksWrites() = %v, want %v", tt.mode, tt.mode.BlocksWrites(), tt.blocksWrites)
+		}
+	}
+	if !LockMode(nodes.ShareUpdateExclusiveLock).ConflictsWith(nodes.ShareUpdateExclusiveLock) {
+		t.Error("ShareUpdateExclusiveLock should be self-conflicting")
+	}
+	if LockMode(nodes.ShareLock).ConflictsWith(nodes.ShareLock) {
+		t.Error("ShareLock should not be self-conflicting")
+	}
+}
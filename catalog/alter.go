@@ -0,0 +1,744 @@
+package catalog
+
+import (
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// rename implements ALTER ... RENAME TO for the modelled object types.
+func (c *Catalog) rename(n *nodes.RenameStmt) error {
+	switch n.RenameType {
+	case nodes.OBJECT_TABLE, nodes.OBJECT_VIEW, nodes.OBJECT_MATVIEW, nodes.OBJECT_INDEX,
+		nodes.OBJECT_SEQUENCE, nodes.OBJECT_FOREIGN_TABLE:
+		rel, err := c.alterRelationTarget(n.Relation, n.MissingOk)
+		if rel == nil {
+			return err
+		}
+		if err := checkRelationObjectType(rel, n.RenameType); err != nil {
+			return err
+		}
+		return c.renameRelationChecked(rel, n.Newname)
+
+	case nodes.OBJECT_COLUMN, nodes.OBJECT_ATTRIBUTE:
+		if n.RelationType == nodes.OBJECT_TYPE || n.RenameType == nodes.OBJECT_ATTRIBUTE {
+			return c.renameAttribute(n)
+		}
+		rel, err := c.alterRelationTarget(n.Relation, n.MissingOk)
+		if rel == nil {
+			return err
+		}
+		if err := checkRelationObjectType(rel, n.RelationType); err != nil {
+			return err
+		}
+		return c.renameColumn(rel, n.Subname, n.Newname, true)
+
+	case nodes.OBJECT_TABCONSTRAINT:
+		rel, err := c.alterRelationTarget(n.Relation, n.MissingOk)
+		if rel == nil {
+			return err
+		}
+		return c.renameConstraint(rel, n.Subname, n.Newname)
+
+	case nodes.OBJECT_DOMCONSTRAINT:
+		t, err := c.lookupDomain(asList(n.Object))
+		if err != nil {
+			return err
+		}
+		var con *Constraint
+		for _, x := range t.Constraints {
+			if x.Name == n.Subname {
+				con = x
+			}
+			if x.Name == n.Newname {
+				return errorf(CodeDuplicateObject, "constraint %q for domain %s already exists", n.Newname, t.Name)
+			}
+		}
+		if con == nil {
+			return errorf(CodeUndefinedObject, "constraint %q for domain %s does not exist", n.Subname, t.Name)
+		}
+		set(c, &con.Name, n.Newname)
+
+	case nodes.OBJECT_SCHEMA:
+		s, err := c.lookupSchema(n.Subname)
+		if err != nil {
+			return err
+		}
+		if c.schemas[n.Newname] != nil {
+			return errorf(CodeDuplicateSchema, "schema %q already exists", n.Newname)
+		}
+		if err := checkSchemaName(n.Newname); err != nil {
+			return err
+		}
+		c.removeSchema(s)
+		set(c, &s.Name, n.Newname)
+		c.addSchema(s)
+
+	case nodes.OBJECT_TYPE, nodes.OBJECT_DOMAIN:
+		t, err := c.lookupTypeForAlter(asList(n.Object), n.RenameType)
+		if err != nil {
+			return err
+		}
+		if typeNameTaken(t.Schema, n.Newname) {
+			return errorf(CodeDuplicateObject, "type %q already exists", n.Newname)
+		}
+		c.removeType(t)
+		set(c, &t.Name, n.Newname)
+		c.addType(t)
+
+	case nodes.OBJECT_FUNCTION, nodes.OBJECT_PROCEDURE, nodes.OBJECT_AGGREGATE, nodes.OBJECT_ROUTINE:
+		f, err := c.lookupRoutine(n.Object, n.RenameType)
+		if err != nil {
+			return err
+		}
+		if c.findFunction(f.Schema, n.Newname, f.ArgTypes()) != nil {
+			return errorf(CodeDuplicateFunction, "function %s already exists in schema %q",
+				n.Newname+"("+formatArgTypes(f.ArgTypes(), ", ")+")", f.Schema.Name)
+		}
+		c.removeFunction(f)
+		set(c, &f.Name, n.Newname)
+		c.addFunction(f)
+	}
+	return nil
+}
+
+// alterRelationTarget resolves the relation an ALTER statement names. It
+// returns nil, nil after a NOTICE if the relation is missing and
+// missingOk is set.
+func (c *Catalog) alterRelationTarget(rv *nodes.RangeVar, missingOk bool) (*Relation, error) {
+	rel, err := c.relationFor(rv)
+	if err != nil && missingOk {
+		c.notice("relation %q does not exist, skipping", rangeVarName(rv))
+		return nil, nil
+	}
+	return rel, err
+}
+
+// renameRelation renames a relation in place.
+func (c *Catalog) renameRelation(rel *Relation, name string) {
+	c.removeRelation(rel)
+	set(c, &rel.Name, name)
+	c.addRelation(rel)
+}
+
+// renameRelationChecked renames a relation after checking that the new
+// name is free; renaming an index renames the constraint it implements.
+func (c *Catalog) renameRelationChecked(rel *Relation, name string) error {
+	if rel.Schema.relations[name] != nil {
+		return errorf(CodeDuplicateTable, "relation %q already exists", name)
+	}
+	if rel.hasColumns() && rel.Schema.types[name] != nil {
+		return errorf(CodeDuplicateObject, "type %q already exists", name)
+	}
+	if rel.Index != nil {
+		for _, con := range rel.Index.Table.Constraints {
+			if con.Index == rel && con.Type != nodes.CONSTR_FOREIGN {
+				set(c, &con.Name, name)
+			}
+		}
+	}
+	c.renameRelation(rel, name)
+	return nil
+}
+
+// renameColumn implements ALTER TABLE ... RENAME COLUMN, following
+// renameatt_internal. Inheritance children are renamed too.
+func (c *Catalog) renameColumn(rel *Relation, oldName, newName string, top bool) error {
+	col := rel.Column(oldName)
+	if col == nil {
+		return errorf(CodeUndefinedColumn, "column %q does not exist", oldName)
+	}
+	if top && col.Inhcount > 0 {
+		return errorf(CodeInvalidTableDefinition, "cannot rename inherited column %q", oldName)
+	}
+	if rel.Column(newName) != nil {
+		return errorf(CodeDuplicateColumn, "column %q of relation %q already exists", newName, rel.Name)
+	}
+	for _, child := range c.children(rel) {
+		if child.Column(oldName) != nil {
+			if err := c.renameColumn(child, oldName, newName, false); err != nil {
+				return err
+			}
+		}
+	}
+	set(c, &col.Name, newName)
+	c.renameColumnReferences(rel, oldName, newName)
+	return nil
+}
+
+// renameColumnReferences updates the column name lists of constraints and
+// indexes that refer to a renamed column.
+func (c *Catalog) renameColumnReferences(rel *Relation, oldName, newName string) {
+	renamed := func(names []string) ([]string, bool) {
+		i := indexOf(names, oldName)
+		if i < 0 {
+			return names, false
+		}
+		out := append([]string(nil), names...)
+		out[i] = newName
+		return out, true
+	}
+	renamedElems := func(elems []*nodes.IndexElem) ([]*nodes.IndexElem, bool) {
+		changed := false
+		out := append([]*nodes.IndexElem(nil), elems...)
+		for i, e := range out {
+			if e.Name == oldName {
+				cp := *e
+				cp.Name = newName
+				out[i] = &cp
+				changed = true
+			}
+		}
+		return out, changed
+	}
+	for _, s := range c.Schemas() {
+		for _, r := range s.Relations() {
+			for _, con := range r.Constraints {
+				if con.Relation == rel {
+					if names, ok := renamed(con.Columns); ok {
+						set(c, &con.Columns, names)
+					}
+				}
+				if con.RefTable == rel {
+					if names, ok := renamed(con.RefColumns); ok {
+						set(c, &con.RefColumns, names)
+					}
+				}
+			}
+			if r.Index != nil && r.Index.Table == rel {
+				if elems, ok := renamedElems(r.Index.Params); ok {
+					set(c, &r.Index.Params, elems)
+				}
+				if elems, ok := renamedElems(r.Index.Including); ok {
+					set(c, &r.Index.Including, elems)
+				}
+			}
+		}
+	}
+}
+
+// renameAttribute implements ALTER TYPE ... RENAME ATTRIBUTE.
+func (c *Catalog) renameAttribute(n *nodes.RenameStmt) error {
+	t := c.LookupType(n.Relation.Schemaname, n.Relation.Relname)
+	if t == nil {
+		if n.MissingOk {
+			c.notice("type %q does not exist, skipping", rangeVarName(n.Relation))
+			return nil
+		}
+		return errorf(CodeUndefinedObject, "type %q does not exist", rangeVarName(n.Relation))
+	}
+	if t.Kind != TypeKindComposite {
+		return errorf(CodeWrongObjectType, "%s is not a composite type", t.Name)
+	}
+	var attr *Column
+	for _, a := range t.Attributes {
+		if a.Name == n.Subname {
+			attr = a
+		}
+		if a.Name == n.Newname {
+			return errorf(CodeDuplicateColumn, "column %q of relation %q already exists", n.Newname, t.Name)
+		}
+	}
+	if attr == nil {
+		return errorf(CodeUndefinedColumn, "column %q does not exist", n.Subname)
+	}
+	set(c, &attr.Name, n.Newname)
+	return nil
+}
+
+// renameConstraint implements ALTER TABLE ... RENAME CONSTRAINT. The
+// index behind a primary key, unique or exclusion constraint is renamed
+// along with it.
+func (c *Catalog) renameConstraint(rel *Relation, oldName, newName string) error {
+	con := rel.Constraint(oldName)
+	if con == nil {
+		return errorf(CodeUndefinedObject, "constraint %q for table %q does not exist", oldName, rel.Name)
+	}
+	if rel.Constraint(newName) != nil {
+		return errorf(CodeDuplicateObject, "constraint %q for relation %q already exists", newName, rel.Name)
+	}
+	if con.Index != nil && con.Type != nodes.CONSTR_FOREIGN {
+		if rel.Schema.relations[newName] != nil {
+			return errorf(CodeDuplicateTable, "relation %q already exists", newName)
+		}
+		c.renameRelation(con.Index, newName)
+	}
+	set(c, &con.Name, newName)
+	return nil
+}
+
+// lookupTypeForAlter resolves the type named by ALTER TYPE or ALTER
+// DOMAIN, rejecting row types of tables and, for ALTER DOMAIN, types that
+// are not domains.
+func (c *Catalog) lookupTypeForAlter(names *nodes.List, objType nodes.ObjectType) (*Type, error) {
+	t, err := c.lookupTypeList(names)
+	if err != nil {
+		schema, name, _ := qualifiedName(names)
+		if rel := c.LookupRelation(schema, name); rel != nil && rel.hasColumns() {
+			return nil, errorf(CodeWrongObjectType, "%s is a table's row type", name).
+				withHint("Use ALTER TABLE instead.")
+		}
+		return nil, err
+	}
+	if objType == nodes.OBJECT_DOMAIN && t.Kind != TypeKindDomain {
+		return nil, errorf(CodeWrongObjectType, "%s is not a domain", t.Name)
+	}
+	return t, nil
+}
+
+// lookupDomain resolves the domain named by a domain constraint
+// reference, whose name list may start with a TypeName.
+func (c *Catalog) lookupDomain(names *nodes.List) (*Type, error) {
+	if items := listItems(names); len(items) > 0 {
+		if tn, ok := items[0].(*nodes.TypeName); ok {
+			names = tn.Names
+		}
+	}
+	return c.lookupTypeForAlter(names, nodes.OBJECT_DOMAIN)
+}
+
+// routineNoun returns the noun used for a routine object type in
+// messages.
+func routineNoun(objType nodes.ObjectType) string {
+	switch objType {
+	case nodes.OBJECT_PROCEDURE:
+		return "procedure"
+	case nodes.OBJECT_AGGREGATE:
+		return "aggregate"
+	case nodes.OBJECT_ROUTINE:
+		return "routine"
+	}
+	return "function"
+}
+
+// LookupRoutine returns the function, procedure or aggregate a statement
+// such as ALTER FUNCTION or GRANT ... ON FUNCTION refers to. obj is an
+// ObjectWithArgs or, for references without an argument list, a name
+// list; objType is OBJECT_FUNCTION, OBJECT_PROCEDURE, OBJECT_AGGREGATE or
+// OBJECT_ROUTINE.
+func (c *Catalog) LookupRoutine(obj nodes.Node, objType nodes.ObjectType) (*Function, error) {
+	return c.lookupRoutine(obj, objType)
+}
+
+// lookupRoutine resolves a function, procedure or aggregate reference,
+// following LookupFuncWithArgs. obj is an ObjectWithArgs or, for
+// references without an argument list, a name list.
+func (c *Catalog) lookupRoutine(obj nodes.Node, objType nodes.ObjectType) (*Function, error) {
+	var names *nodes.List
+	var args []*nodes.TypeName
+	unspecified := true
+	switch o := obj.(type) {
+	case *nodes.ObjectWithArgs:
+		names = o.Objname
+		unspecified = o.ArgsUnspecified
+		for _, item := range listItems(o.Objargs) {
+			if tn, ok := item.(*nodes.TypeName); ok {
+				args = append(args, tn)
+			}
+		}
+	case *nodes.List:
+		names = o
+	}
+	schema, name, err := qualifiedName(names)
+	if err != nil {
+		return nil, err
+	}
+	var schemas []*Schema
+	if schema != "" {
+		s, err := c.lookupSchema(schema)
+		if err != nil {
+			return nil, err
+		}
+		schemas = []*Schema{s}
+	} else {
+		schemas = c.activeSearchPath()
+	}
+	noun := routineNoun(objType)
+	fullName := nameListString(stringList(names))
+
+	var f *Function
+	if unspecified {
+		var candidates []*Function
+		for _, s := range schemas {
+			for _, g := range s.functions[name] {
+				hidden := false
+				for _, prev := range candidates {
+					hidden = hidden || c.sameArgTypes(prev.ArgTypes(), g.ArgTypes())
+				}
+				if !hidden && kindMatches(g, objType) {
+					candidates = append(candidates, g)
+				}
+			}
+		}
+		switch len(candidates) {
+		case 0:
+			article := "a"
+			if noun == "aggregate" {
+				article = "an"
+			}
+			return nil, errorf(CodeUndefinedFunction, "could not find %s %s named %q", article, noun, fullName)
+		case 1:
+			f = candidates[0]
+		default:
+			return nil, errorf(CodeAmbiguousFunction, "%s name %q is not unique", noun, fullName).
+				withHint("Specify the argument list to select the %s unambiguously.", noun)
+		}
+	} else {
+		for _, tn := range args {
+			if _, err := c.resolveType(tn); err != nil {
+				return nil, err
+			}
+		}
+		for _, s := range schemas {
+			if f = c.findFunction(s, name, args); f != nil {
+				break
+			}
+		}
+		if f == nil {
+			return nil, errorf(CodeUndefinedFunction, "%s %s(%s) does not exist", noun, fullName, formatArgTypes(args, ", "))
+		}
+	}
+
+	sig := fullName + "(" + formatArgTypes(f.ArgTypes(), ", ") + ")"
+	switch objType {
+	case nodes.OBJECT_FUNCTION:
+		if f.Kind == FuncKindProcedure {
+			return nil, errorf(CodeWrongObjectType, "%s is not a function", sig)
+		}
+	case nodes.OBJECT_PROCEDURE:
+		if f.Kind != FuncKindProcedure {
+			return nil, errorf(CodeWrongObjectType, "%s is not a procedure", sig)
+		}
+	case nodes.OBJECT_AGGREGATE:
+		if f.Kind != FuncKindAggregate {
+			return nil, errorf(CodeWrongObjectType, "function %s is not an aggregate", sig)
+		}
+	}
+	return f, nil
+}
+
+// kindMatches reports whether f can be found by a reference of the given
+// object type when no argument list was given.
+func kindMatches(f *Function, objType nodes.ObjectType) bool {
+	switch objType {
+	case nodes.OBJECT_FUNCTION:
+		return f.Kind != FuncKindProcedure
+	case nodes.OBJECT_PROCEDURE:
+		return f.Kind == FuncKindProcedure
+	case nodes.OBJECT_AGGREGATE:
+		return f.Kind == FuncKindAggregate
+	}
+	return true
+}
+
+// alterObjectSchema implements ALTER ... SET SCHEMA.
+func (c *Catalog) alterObjectSchema(n *nodes.AlterObjectSchemaStmt) error {
+	switch n.ObjectType {
+	case nodes.OBJECT_TABLE, nodes.OBJECT_VIEW, nodes.OBJECT_MATVIEW, nodes.OBJECT_SEQUENCE, nodes.OBJECT_FOREIGN_TABLE:
+		rel, err := c.alterRelationTarget(n.Relation, n.MissingOk)
+		if rel == nil {
+			return err
+		}
+		if err := checkRelationObjectType(rel, n.ObjectType); err != nil {
+			return err
+		}
+		s, err := c.lookupSchema(n.Newschema)
+		if err != nil {
+			return err
+		}
+		if seq := rel.Sequence; seq != nil && seq.OwnedBy != nil {
+			return errorf(CodeFeatureNotSupported, "cannot move an owned sequence into another schema").
+				withDetail("Sequence %q is linked to table %q.", rel.Name, seq.OwnedBy.Relation.Name)
+		}
+		return c.setRelationSchema(rel, s)
+
+	case nodes.OBJECT_TYPE, nodes.OBJECT_DOMAIN:
+		t, err := c.lookupTypeForAlter(asList(n.Object), n.ObjectType)
+		if err != nil {
+			if n.MissingOk {
+				c.notice("type %q does not exist, skipping", nameListString(stringList(asList(n.Object))))
+				return nil
+			}
+			return err
+		}
+		s, err := c.lookupSchema(n.Newschema)
+		if err != nil {
+			return err
+		}
+		if s == t.Schema {
+			return nil
+		}
+		if err := checkSetNamespace(t.Schema, s); err != nil {
+			return err
+		}
+		if typeNameTaken(s, t.Name) {
+			return errorf(CodeDuplicateObject, "type %q already exists in schema %q", t.Name, s.Name)
+		}
+		c.removeType(t)
+		set(c, &t.Schema, s)
+		c.addType(t)
+
+	case nodes.OBJECT_FUNCTION, nodes.OBJECT_PROCEDURE, nodes.OBJECT_AGGREGATE, nodes.OBJECT_ROUTINE:
+		f, err := c.lookupRoutine(n.Object, n.ObjectType)
+		if err != nil {
+			return err
+		}
+		s, err := c.lookupSchema(n.Newschema)
+		if err != nil {
+			return err
+		}
+		if s == f.Schema {
+			return nil
+		}
+		if err := checkSetNamespace(f.Schema, s); err != nil {
+			return err
+		}
+		if c.findFunction(s, f.Name, f.ArgTypes()) != nil {
+			return errorf(CodeDuplicateFunction, "function %s already exists in schema %q",
+				f.Name+"("+formatArgTypes(f.ArgTypes(), ", ")+")", s.Name)
+		}
+		c.removeFunction(f)
+		set(c, &f.Schema, s)
+		c.addFunction(f)
+	}
+	return nil
+}
+
+// checkSetNamespace rejects moves into or out of the temporary schema.
+func checkSetNamespace(from, to *Schema) error {
+	if from.Name == "pg_temp" || to.Name == "pg_temp" {
+		return errorf(CodeFeatureNotSupported, "cannot move objects into or out of temporary schemas")
+	}
+	return nil
+}
+
+// setRelationSchema moves a relation, its indexes and the sequences owned
+// by its columns to another schema.
+func (c *Catalog) setRelationSchema(rel *Relation, s *Schema) error {
+	if rel.Schema == s {
+		return nil
+	}
+	if err := checkSetNamespace(rel.Schema, s); err != nil {
+		return err
+	}
+	moving := []*Relation{rel}
+	for _, r := range rel.Schema.Relations() {
+		if r.Index != nil && r.Index.Table == rel {
+			moving = append(moving, r)
+		}
+		if r.Sequence != nil && r.Sequence.OwnedBy != nil && r.Sequence.OwnedBy.Relation == rel {
+			moving = append(moving, r)
+		}
+	}
+	for _, r := range moving {
+		if s.relations[r.Name] != nil {
+			return errorf(CodeDuplicateTable, "relation %q already exists in schema %q", r.Name, s.Name)
+		}
+	}
+	if rel.hasColumns() && s.types[rel.Name] != nil {
+		return errorf(CodeDuplicateObject, "type %q already exists in schema %q", rel.Name, s.Name)
+	}
+	for _, r := range moving {
+		c.removeRelation(r)
+		set(c, &r.Schema, s)
+		c.addRelation(r)
+	}
+	return nil
+}
+
+// alterOwner implements ALTER ... OWNER TO for schemas, types and
+// routines; relations are handled by ALTER TABLE.
+func (c *Catalog) alterOwner(n *nodes.AlterOwnerStmt) error {
+	owner := c.roleName(n.Newowner)
+	switch n.ObjectType {
+	case nodes.OBJECT_SCHEMA:
+		s, err := c.lookupSchema(objectName(n.Object))
+		if err != nil {
+			return err
+		}
+		set(c, &s.Owner, owner)
+	case nodes.OBJECT_TYPE, nodes.OBJECT_DOMAIN:
+		t, err := c.lookupTypeForAlter(asList(n.Object), n.ObjectType)
+		if err != nil {
+			return err
+		}
+		set(c, &t.Owner, owner)
+	case nodes.OBJECT_FUNCTION, nodes.OBJECT_PROCEDURE, nodes.OBJECT_AGGREGATE, nodes.OBJECT_ROUTINE:
+		f, err := c.lookupRoutine(n.Object, n.ObjectType)
+		if err != nil {
+			return err
+		}
+		set(c, &f.Owner, owner)
+	}
+	return nil
+}
+
+// objectName returns the name of an unqualified object given as a String
+// or a one-element name list.
+func objectName(n nodes.Node) string {
+	switch o := n.(type) {
+	case *nodes.String:
+		return o.Sval
+	case *nodes.List:
+		return nameListString(stringList(o))
+	}
+	return ""
+}
+
+// relationKindNouns gives, for each relation object type, the article and
+// noun used in "is not a ..." errors.
+var relationKindNouns = map[nodes.ObjectType]string{
+	nodes.OBJECT_TABLE:         "a table",
+	nodes.OBJECT_VIEW:          "a view",
+	nodes.OBJECT_MATVIEW:       "a materialized view",
+	nodes.OBJECT_INDEX:         "an index",
+	nodes.OBJECT_SEQUENCE:      "a sequence",
+	nodes.OBJECT_FOREIGN_TABLE: "a foreign table",
+}
+
+// relationKindMatches reports whether rel is of the kind an object type
+// names exactly, as COMMENT and DROP require.
+func relationKindMatches(rel *Relation, objType nodes.ObjectType) bool {
+	switch objType {
+	case nodes.OBJECT_TABLE:
+		return rel.Kind == RelKindTable || rel.Kind == RelKindPartitionedTable
+	case nodes.OBJECT_VIEW:
+		return rel.Kind == RelKindView
+	case nodes.OBJECT_MATVIEW:
+		return rel.Kind == RelKindMatView
+	case nodes.OBJECT_INDEX:
+		return rel.Index != nil
+	case nodes.OBJECT_SEQUENCE:
+		return rel.Kind == RelKindSequence
+	case nodes.OBJECT_FOREIGN_TABLE:
+		return rel.Kind == RelKindForeignTable
+	}
+	return true
+}
+
+// rangeVarFromNames builds a RangeVar from a name list of one to three
+// parts.
+func rangeVarFromNames(names []string) (*nodes.RangeVar, error) {
+	switch len(names) {
+	case 1:
+		return &nodes.RangeVar{Relname: names[0]}, nil
+	case 2:
+		return &nodes.RangeVar{Schemaname: names[0], Relname: names[1]}, nil
+	case 3:
+		return &nodes.RangeVar{Catalogname: names[0], Schemaname: names[1], Relname: names[2]}, nil
+	}
+	return nil, errorf(CodeSyntaxError, "improper relation name (too many dotted names): %s", nameListString(names))
+}
+
+// comment implements COMMENT ON for the modelled object types.
+func (c *Catalog) comment(n *nodes.CommentStmt) error {
+	switch n.Objtype {
+	case nodes.OBJECT_TABLE, nodes.OBJECT_VIEW, nodes.OBJECT_MATVIEW, nodes.OBJECT_INDEX,
+		nodes.OBJECT_SEQUENCE, nodes.OBJECT_FOREIGN_TABLE:
+		rv, err := rangeVarFromNames(stringList(asList(n.Object)))
+		if err != nil {
+			return err
+		}
+		rel, err := c.relationFor(rv)
+		if err != nil {
+			return err
+		}
+		if !relationKindMatches(rel, n.Objtype) {
+			return errorf(CodeWrongObjectType, "%q is not %s", rel.Name, relationKindNouns[n.Objtype])
+		}
+		set(c, &rel.Comment, n.Comment)
+
+	case nodes.OBJECT_COLUMN:
+		names := stringList(asList(n.Object))
+		if len(names) < 2 {
+			return errorf(CodeSyntaxError, "column name must be qualified")
+		}
+		rv, err := rangeVarFromNames(names[:len(names)-1])
+		if err != nil {
+			return err
+		}
+		rel, err := c.relationFor(rv)
+		if err != nil {
+			return err
+		}
+		col := rel.Column(names[len(names)-1])
+		if col == nil {
+			return errorf(CodeUndefinedColumn, "column %q of relation %q does not exist", names[len(names)-1], rel.Name)
+		}
+		set(c, &col.Comment, n.Comment)
+
+	case nodes.OBJECT_TABCONSTRAINT:
+		names := stringList(asList(n.Object))
+		if len(names) < 2 {
+			return errorf(CodeSyntaxError, "must specify relation and object name")
+		}
+		rv, err := rangeVarFromNames(names[:len(names)-1])
+		if err != nil {
+			return err
+		}
+		rel, err := c.relationFor(rv)
+		if err != nil {
+			return err
+		}
+		con := rel.Constraint(names[len(names)-1])
+		if con == nil {
+			return errorf(CodeUndefinedObject, "constraint %q for table %q does not exist", names[len(names)-1], rel.Name)
+		}
+		set(c, &con.Comment, n.Comment)
+
+	case nodes.OBJECT_DOMCONSTRAINT:
+		items := listItems(asList(n.Object))
+		if len(items) != 2 {
+			return nil
+		}
+		t, err := c.lookupDomain(asList(n.Object))
+		if err != nil {
+			return err
+		}
+		name := objectName(items[1])
+		for _, con := range t.Constraints {
+			if con.Name == name {
+				set(c, &con.Comment, n.Comment)
+				return nil
+			}
+		}
+		return errorf(CodeUndefinedObject, "constraint %q for domain %s does not exist", name, t.Name)
+
+	case nodes.OBJECT_SCHEMA:
+		s, err := c.lookupSchema(objectName(n.Object))
+		if err != nil {
+			return err
+		}
+		set(c, &s.Comment, n.Comment)
+
+	case nodes.OBJECT_TYPE, nodes.OBJECT_DOMAIN:
+		tn, ok := n.Object.(*nodes.TypeName)
+		if !ok {
+			return nil
+		}
+		t, err := c.lookupTypeForAlter(tn.Names, n.Objtype)
+		if err != nil {
+			return err
+		}
+		set(c, &t.Comment, n.Comment)
+
+	case nodes.OBJECT_FUNCTION, nodes.OBJECT_PROCEDURE, nodes.OBJECT_AGGREGATE, nodes.OBJECT_ROUTINE:
+		f, err := c.lookupRoutine(n.Object, n.Objtype)
+		if err != nil {
+			return err
+		}
+		set(c, &f.Comment, n.Comment)
+	}
+	return nil
+}
+
+// typeNameToString formats a type name as written, the way
+// TypeNameToString does for "does not exist, skipping" notices.
+func typeNameToString(tn *nodes.TypeName) string {
+	s := nameListString(stringList(tn.Names))
+	if tn.PctType {
+		s += "%TYPE"
+	}
+	s += strings.Repeat("[]", len(listItems(tn.ArrayBounds)))
+	return s
+}
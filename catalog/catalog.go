@@ -0,0 +1,649 @@
+// Package catalog maintains an in-memory model of a database schema built
+// by replaying DDL statements, in the spirit of PostgreSQL's system
+// catalogs. It tracks schemas, tables and their columns, constraints,
+// indexes, sequences, views, types and functions, and reports the same
+// errors the server would when DDL refers to objects that do not exist or
+// would conflict with existing ones.
+//
+// Statements that do not change the modelled objects (DML, GRANT, SET of
+// parameters other than search_path, ...) are accepted and ignored.
+package catalog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+)
+
+// Catalog is an in-memory schema model.
+type Catalog struct {
+	// User is the role running the DDL. It is recorded as the owner of
+	// new objects and matched against "$user" in the search path.
+	User string
+
+	// NoticeFunc, if set, receives the NOTICE messages PostgreSQL would
+	// send, such as those for IF [NOT] EXISTS clauses that skip an object
+	// or for objects removed by DROP ... CASCADE.
+	NoticeFunc func(message string)
+
+	schemas    map[string]*Schema
+	searchPath []string
+	deps       []*dependency
+
+	// undo holds closures reverting the changes made by the statement
+	// being applied, so that a failing statement leaves no trace.
+	undo []func()
+}
+
+// New returns a catalog containing the pg_catalog and public schemas, with
+// the default search path "$user", public.
+func New() *Catalog {
+	c := &Catalog{
+		schemas:    make(map[string]*Schema),
+		searchPath: []string{"$user", "public"},
+	}
+	c.schemas["pg_catalog"] = newSchema("pg_catalog")
+	c.schemas["public"] = newSchema("public")
+	return c
+}
+
+// Schema returns the named schema, or nil.
+func (c *Catalog) Schema(name string) *Schema {
+	return c.schemas[name]
+}
+
+// Schemas returns all schemas, sorted by name.
+func (c *Catalog) Schemas() []*Schema {
+	out := make([]*Schema, 0, len(c.schemas))
+	for _, s := range c.schemas {
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// SearchPath returns the configured search path.
+func (c *Catalog) SearchPath() []string {
+	return append([]string(nil), c.searchPath...)
+}
+
+// SetSearchPath sets the search path, as SET search_path would.
+func (c *Catalog) SetSearchPath(schemas ...string) {
+	c.searchPath = append([]string(nil), schemas...)
+}
+
+// ActiveSearchPath returns the names of the schemas searched for
+// unqualified names, in order, as current_schemas(true) reports them:
+// "$user" is replaced by User, schemas that do not exist are left out,
+// and the implicitly searched pg_temp and pg_catalog come first.
+func (c *Catalog) ActiveSearchPath() []string {
+	var out []string
+	for _, s := range c.activeSearchPath() {
+		out = append(out, s.Name)
+	}
+	return out
+}
+
+// CreationSchema returns the name of the schema objects with unqualified
+// names are created in, or "" if no schema of the search path exists.
+func (c *Catalog) CreationSchema() string {
+	s, err := c.creationSchema("")
+	if err != nil {
+		return ""
+	}
+	return s.Name
+}
+
+// Exec parses sql and applies each statement in turn. It stops at the
+// first statement that fails; the changes of earlier statements are kept.
+func (c *Catalog) Exec(sql string) error {
+	stmts, err := parser.Parse(sql)
+	if err != nil {
+		return err
+	}
+	for _, stmt := range listItems(stmts) {
+		if err := c.Apply(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Apply applies a single statement. If it fails, the catalog is left as
+// it was before the call.
+func (c *Catalog) Apply(stmt nodes.Node) (err error) {
+	c.undo = c.undo[:0]
+	defer func() {
+		if err != nil {
+			for i := len(c.undo) - 1; i >= 0; i-- {
+				c.undo[i]()
+			}
+		}
+		c.undo = c.undo[:0]
+	}()
+	return c.apply(stmt)
+}
+
+// apply dispatches a statement to the function implementing it.
+func (c *Catalog) apply(stmt nodes.Node) error {
+	switch n := stmt.(type) {
+	case *nodes.RawStmt:
+		return c.apply(n.Stmt)
+	case *nodes.CreateSchemaStmt:
+		return c.createSchema(n)
+	case *nodes.CreateStmt:
+		return c.createTable(n)
+	case *nodes.CreateForeignTableStmt:
+		return c.createForeignTable(n)
+	case *nodes.CreateTableAsStmt:
+		return c.createTableAs(n)
+	case *nodes.ViewStmt:
+		return c.createView(n)
+	case *nodes.IndexStmt:
+		return c.createIndex(n)
+	case *nodes.CreateSeqStmt:
+		return c.createSequence(n)
+	case *nodes.AlterSeqStmt:
+		return c.alterSequence(n)
+	case *nodes.AlterTableStmt:
+		if nodes.ObjectType(n.Objtype) == nodes.OBJECT_TYPE {
+			return c.alterCompositeType(n)
+		}
+		return c.alterTable(n)
+	case *nodes.CreateEnumStmt:
+		return c.createEnum(n)
+	case *nodes.AlterEnumStmt:
+		return c.alterEnum(n)
+	case *nodes.CreateDomainStmt:
+		return c.createDomain(n)
+	case *nodes.AlterDomainStmt:
+		return c.alterDomain(n)
+	case *nodes.CompositeTypeStmt:
+		return c.createCompositeType(n)
+	case *nodes.CreateRangeStmt:
+		return c.createRange(n)
+	case *nodes.DefineStmt:
+		return c.define(n)
+	case *nodes.CreateFunctionStmt:
+		return c.createFunction(n)
+	case *nodes.RenameStmt:
+		return c.rename(n)
+	case *nodes.AlterObjectSchemaStmt:
+		return c.alterObjectSchema(n)
+	case *nodes.AlterOwnerStmt:
+		return c.alterOwner(n)
+	case *nodes.CommentStmt:
+		return c.comment(n)
+	case *nodes.DropStmt:
+		return c.drop(n)
+	case *nodes.SelectStmt:
+		if n.IntoClause != nil {
+			query := *n
+			query.IntoClause = nil
+			return c.createTableAs(&nodes.CreateTableAsStmt{
+				Query:        &query,
+				Into:         n.IntoClause,
+				Objtype:      nodes.OBJECT_TABLE,
+				IsSelectInto: true,
+			})
+		}
+	case *nodes.VariableSetStmt:
+		c.variableSet(n)
+	}
+	return nil
+}
+
+// notice delivers a NOTICE message.
+func (c *Catalog) notice(format string, args ...any) {
+	if c.NoticeFunc != nil {
+		c.NoticeFunc(fmt.Sprintf(format, args...))
+	}
+}
+
+// set assigns v to *p, recording the old value so that a failing
+// statement can be rolled back.
+func set[T any](c *Catalog, p *T, v T) {
+	old := *p
+	c.undo = append(c.undo, func() { *p = old })
+	*p = v
+}
+
+// without returns a copy of items with the element at index i removed.
+// Slices are never modified in place so that undo closures restoring an
+// old slice header see the old contents.
+func without[T any](items []T, i int) []T {
+	out := make([]T, 0, len(items)-1)
+	out = append(out, items[:i]...)
+	return append(out, items[i+1:]...)
+}
+
+// appended returns a copy of items with v appended.
+func appended[T any](items []T, v T) []T {
+	out := make([]T, 0, len(items)+1)
+	out = append(out, items...)
+	return append(out, v)
+}
+
+func (c *Catalog) addSchema(s *Schema) {
+	c.schemas[s.Name] = s
+	c.undo = append(c.undo, func() { delete(c.schemas, s.Name) })
+}
+
+func (c *Catalog) removeSchema(s *Schema) {
+	delete(c.schemas, s.Name)
+	c.undo = append(c.undo, func() { c.schemas[s.Name] = s })
+}
+
+func (c *Catalog) addRelation(r *Relation) {
+	s, name := r.Schema, r.Name
+	s.relations[name] = r
+	c.undo = append(c.undo, func() { delete(s.relations, name) })
+}
+
+func (c *Catalog) removeRelation(r *Relation) {
+	s, name := r.Schema, r.Name
+	delete(s.relations, name)
+	c.undo = append(c.undo, func() { s.relations[name] = r })
+}
+
+func (c *Catalog) addType(t *Type) {
+	s, name := t.Schema, t.Name
+	s.types[name] = t
+	c.undo = append(c.undo, func() { delete(s.types, name) })
+}
+
+func (c *Catalog) removeType(t *Type) {
+	s, name := t.Schema, t.Name
+	delete(s.types, name)
+	c.undo = append(c.undo, func() { s.types[name] = t })
+}
+
+func (c *Catalog) addFunction(f *Function) {
+	s, name := f.Schema, f.Name
+	old := s.functions[name]
+	s.functions[name] = appended(old, f)
+	c.undo = append(c.undo, func() { setFunctions(s, name, old) })
+}
+
+func (c *Catalog) removeFunction(f *Function) {
+	s, name := f.Schema, f.Name
+	old := s.functions[name]
+	for i, g := range old {
+		if g == f {
+			setFunctions(s, name, without(old, i))
+			break
+		}
+	}
+	c.undo = append(c.undo, func() { setFunctions(s, name, old) })
+}
+
+func setFunctions(s *Schema, name string, fs []*Function) {
+	if len(fs) == 0 {
+		delete(s.functions, name)
+	} else {
+		s.functions[name] = fs
+	}
+}
+
+// activeSearchPath returns the schemas searched for unqualified names, in
+// order. As in PostgreSQL, pg_temp and pg_catalog are searched first
+// unless the path lists them explicitly.
+func (c *Catalog) activeSearchPath() []*Schema {
+	var out []*Schema
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if s := c.schemas[name]; s != nil && !seen[name] {
+			seen[name] = true
+			out = append(out, s)
+		}
+	}
+	explicitTemp, explicitCatalog := false, false
+	for _, name := range c.searchPath {
+		explicitTemp = explicitTemp || name == "pg_temp"
+		explicitCatalog = explicitCatalog || name == "pg_catalog"
+	}
+	if !explicitTemp {
+		add("pg_temp")
+	}
+	if !explicitCatalog {
+		add("pg_catalog")
+	}
+	for _, name := range c.searchPath {
+		if name == "$user" {
+			name = c.User
+		}
+		add(name)
+	}
+	return out
+}
+
+// creationSchema returns the schema in which an object with the given
+// (possibly empty) schema qualification is created.
+func (c *Catalog) creationSchema(schema string) (*Schema, error) {
+	if schema != "" {
+		return c.lookupSchema(schema)
+	}
+	for _, name := range c.searchPath {
+		if name == "$user" {
+			name = c.User
+		}
+		if name == "pg_temp" {
+			continue
+		}
+		if s := c.schemas[name]; s != nil {
+			return s, nil
+		}
+	}
+	return nil, errorf(CodeInvalidSchemaName, "no schema has been selected to create in")
+}
+
+// tempSchema returns the session's temporary schema, creating it on first
+// use.
+func (c *Catalog) tempSchema() *Schema {
+	s := c.schemas["pg_temp"]
+	if s == nil {
+		s = newSchema("pg_temp")
+		c.addSchema(s)
+	}
+	return s
+}
+
+func (c *Catalog) lookupSchema(name string) (*Schema, error) {
+	if s := c.schemas[name]; s != nil {
+		return s, nil
+	}
+	return nil, errorf(CodeInvalidSchemaName, "schema %q does not exist", name)
+}
+
+// visible reports whether objects in s can be referenced unqualified.
+func (c *Catalog) visible(s *Schema) bool {
+	for _, p := range c.activeSearchPath() {
+		if p == s {
+			return true
+		}
+	}
+	return false
+}
+
+// LookupRelation finds a relation by optional schema and name, searching
+// the search path for unqualified names. It returns nil if there is no
+// such relation.
+func (c *Catalog) LookupRelation(schema, name string) *Relation {
+	if schema != "" {
+		if s := c.schemas[schema]; s != nil {
+			return s.relations[name]
+		}
+		return nil
+	}
+	for _, s := range c.activeSearchPath() {
+		if r := s.relations[name]; r != nil {
+			return r
+		}
+	}
+	return nil
+}
+
+// relationFor resolves a RangeVar, reporting PostgreSQL's errors for a
+// missing schema or relation.
+func (c *Catalog) relationFor(rv *nodes.RangeVar) (*Relation, error) {
+	if rv.Schemaname != "" {
+		if _, err := c.lookupSchema(rv.Schemaname); err != nil {
+			return nil, err
+		}
+	}
+	if r := c.LookupRelation(rv.Schemaname, rv.Relname); r != nil {
+		return r, nil
+	}
+	return nil, errorf(CodeUndefinedTable, "relation %q does not exist", rangeVarName(rv))
+}
+
+// LookupType finds a user-defined type by optional schema and name. It
+// returns nil for built-in types and for names that do not exist.
+func (c *Catalog) LookupType(schema, name string) *Type {
+	if schema != "" {
+		if s := c.schemas[schema]; s != nil {
+			return s.types[name]
+		}
+		return nil
+	}
+	for _, s := range c.activeSearchPath() {
+		if t := s.types[name]; t != nil {
+			return t
+		}
+	}
+	return nil
+}
+
+// LookupFunctions returns the functions with the given name in schema, or
+// in the search path for an unqualified name, as FuncnameGetCandidates
+// does. An overload in an earlier schema of the path hides one with the
+// same argument types in a later schema.
+func (c *Catalog) LookupFunctions(schema, name string) []*Function {
+	if schema != "" {
+		if s := c.schemas[schema]; s != nil {
+			return append([]*Function(nil), s.functions[name]...)
+		}
+		return nil
+	}
+	var out []*Function
+	seen := make(map[string]bool)
+	for _, s := range c.activeSearchPath() {
+		for _, f := range s.functions[name] {
+			key := c.argTypesKey(f.ArgTypes())
+			if !seen[key] {
+				seen[key] = true
+				out = append(out, f)
+			}
+		}
+	}
+	return out
+}
+
+func (c *Catalog) argTypesKey(types []*nodes.TypeName) string {
+	keys := make([]string, len(types))
+	for i, t := range types {
+		keys[i] = c.typeKey(t)
+	}
+	return strings.Join(keys, ",")
+}
+
+// typeRef is the target of a type name: a built-in type, a user-defined
+// type, or the row type of a relation.
+type typeRef struct {
+	builtin  string
+	typ      *Type
+	relation *Relation
+}
+
+// resolveType resolves a type name, reporting `type "x" does not exist`
+// for unknown types.
+func (c *Catalog) resolveType(tn *nodes.TypeName) (typeRef, error) {
+	schema, name := typeNameParts(tn)
+	if tn.PctType {
+		return typeRef{}, nil
+	}
+	if _, ok := serialTypes[name]; ok && schema == "" {
+		return typeRef{builtin: name}, nil
+	}
+	if schema != "" {
+		if schema == "pg_catalog" && isBuiltinType(name) {
+			return typeRef{builtin: name}, nil
+		}
+		if _, err := c.lookupSchema(schema); err != nil {
+			return typeRef{}, err
+		}
+	}
+	lookup := strings.TrimPrefix(name, "_")
+	for _, s := range c.typeSearchPath(schema) {
+		if s.Name == "pg_catalog" && isBuiltinType(name) {
+			return typeRef{builtin: name}, nil
+		}
+		if t := s.types[lookup]; t != nil {
+			return typeRef{typ: t}, nil
+		}
+		if r := s.relations[lookup]; r != nil && r.hasColumns() {
+			return typeRef{relation: r}, nil
+		}
+	}
+	return typeRef{}, errorf(CodeUndefinedObject, "type %q does not exist", nameListString(stringList(tn.Names)))
+}
+
+func (c *Catalog) typeSearchPath(schema string) []*Schema {
+	if schema != "" {
+		if s := c.schemas[schema]; s != nil {
+			return []*Schema{s}
+		}
+		return nil
+	}
+	return c.activeSearchPath()
+}
+
+// typeKey returns a string identifying the type a name resolves to,
+// ignoring type modifiers, for comparing function signatures.
+func (c *Catalog) typeKey(tn *nodes.TypeName) string {
+	if tn == nil {
+		return ""
+	}
+	arr := strings.Repeat("[]", len(listItems(tn.ArrayBounds)))
+	ref, err := c.resolveType(tn)
+	schema, name := typeNameParts(tn)
+	switch {
+	case err != nil:
+		return schema + "." + name + arr
+	case ref.typ != nil:
+		if strings.HasPrefix(name, "_") {
+			arr += "[]"
+		}
+		return ref.typ.Schema.Name + "." + ref.typ.Name + arr
+	case ref.relation != nil:
+		return ref.relation.Schema.Name + "." + ref.relation.Name + arr
+	}
+	return "pg_catalog." + ref.builtin + arr
+}
+
+// typeNameTaken reports whether name is in use as a type in s. Relations
+// and types share this namespace because every relation with columns has a
+// row type.
+func typeNameTaken(s *Schema, name string) bool {
+	if s.types[name] != nil {
+		return true
+	}
+	r := s.relations[name]
+	return r != nil && r.hasColumns()
+}
+
+// checkNewRelationName reports an error if a relation named name cannot be
+// created in s.
+func (c *Catalog) checkNewRelationName(s *Schema, name string) error {
+	if s.relations[name] != nil {
+		return errorf(CodeDuplicateTable, "relation %q already exists", name)
+	}
+	if s.types[name] != nil {
+		return errorf(CodeDuplicateObject, "type %q already exists", name).
+			withHint("A relation has an associated type of the same name, so you must use a name that doesn't conflict with any existing type.")
+	}
+	return nil
+}
+
+// roleName returns the role a RoleSpec denotes.
+func (c *Catalog) roleName(r *nodes.RoleSpec) string {
+	if r == nil {
+		return ""
+	}
+	switch nodes.RoleSpecType(r.Roletype) {
+	case nodes.ROLESPEC_CSTRING:
+		return r.Rolename
+	case nodes.ROLESPEC_PUBLIC:
+		return "public"
+	}
+	return c.User
+}
+
+// variableSet tracks changes to search_path.
+func (c *Catalog) variableSet(n *nodes.VariableSetStmt) {
+	if !strings.EqualFold(n.Name, "search_path") {
+		return
+	}
+	switch n.Kind {
+	case nodes.VAR_SET_VALUE:
+		var path []string
+		for _, arg := range listItems(n.Args) {
+			if ac, ok := arg.(*nodes.A_Const); ok {
+				if s, ok := ac.Val.(*nodes.String); ok {
+					path = append(path, splitSearchPath(s.Sval)...)
+				}
+			}
+		}
+		c.searchPath = path
+	case nodes.VAR_SET_DEFAULT, nodes.VAR_RESET, nodes.VAR_RESET_ALL:
+		c.searchPath = []string{"$user", "public"}
+	}
+}
+
+// splitSearchPath splits a search_path value given as a single string,
+// e.g. '"$user", public'.
+func splitSearchPath(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if strings.HasPrefix(part, `"`) && strings.HasSuffix(part, `"`) && len(part) >= 2 {
+			part = strings.ReplaceAll(part[1:len(part)-1], `""`, `"`)
+		} else {
+			part = strings.ToLower(part)
+		}
+		out = append(out, part)
+	}
+	return out
+}
+
+// listItems returns the items of a possibly-nil list.
+func listItems(l *nodes.List) []nodes.Node {
+	if l == nil {
+		return nil
+	}
+	return l.Items
+}
+
+// stringList returns the values of a list of String nodes.
+func stringList(l *nodes.List) []string {
+	var out []string
+	for _, item := range listItems(l) {
+		if s, ok := item.(*nodes.String); ok {
+			out = append(out, s.Sval)
+		}
+	}
+	return out
+}
+
+// nameListString formats a qualified name as NameListToString does.
+func nameListString(names []string) string {
+	return strings.Join(names, ".")
+}
+
+// qualifiedName splits a name list into schema and object name, following
+// DeconstructQualifiedName.
+func qualifiedName(l *nodes.List) (string, string, error) {
+	names := stringList(l)
+	switch len(names) {
+	case 1:
+		return "", names[0], nil
+	case 2:
+		return names[0], names[1], nil
+	case 3:
+		return names[1], names[2], nil
+	}
+	return "", "", errorf(CodeSyntaxError, "improper qualified name (too many dotted names): %s", nameListString(names))
+}
+
+func rangeVarName(rv *nodes.RangeVar) string {
+	if rv.Schemaname != "" {
+		return rv.Schemaname + "." + rv.Relname
+	}
+	return rv.Relname
+}
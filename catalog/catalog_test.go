@@ -0,0 +1,238 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+)
+
+// mustExec applies sql to c and fails the test on error.
+func mustExec(t *testing.T, c *Catalog, sql string) {
+	t.Helper()
+	if err := c.Exec(sql); err != nil {
+		t.Fatalf("Exec(%q) error: %v", sql, err)
+	}
+}
+
+// execError applies sql to a fresh catalog prepared with setup and
+// returns the error it reports.
+func execError(t *testing.T, setup, sql string) *Error {
+	t.Helper()
+	c := New()
+	mustExec(t, c, setup)
+	err := c.Exec(sql)
+	if err == nil {
+		return nil
+	}
+	e, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("Exec(%q) returned %T, want *Error", sql, err)
+	}
+	return e
+}
+
+func TestCreateTable(t *testing.T) {
+	c := New()
+	mustExec(t, c, `CREATE TABLE t (
+		id serial PRIMARY KEY,
+		a int UNIQUE,
+		b text NOT NULL CHECK (b <> ''),
+		CHECK (a > 0)
+	)`)
+	rel := c.LookupRelation("", "t")
+	if rel == nil {
+		t.Fatal("table t not found")
+	}
+	var cols []string
+	for _, col := range rel.Columns {
+		cols = append(cols, col.Name+" "+col.TypeString())
+	}
+	if got, want := strings.Join(cols, ", "), "id integer, a integer, b text"; got != want {
+		t.Errorf("columns = %q, want %q", got, want)
+	}
+	for _, name := range []string{"t_pkey", "t_a_key", "t_b_check", "t_a_check"} {
+		if rel.Constraint(name) == nil {
+			t.Errorf("constraint %s not found", name)
+		}
+	}
+	if !rel.Column("b").NotNull || !rel.Column("id").NotNull {
+		t.Error("b and id should be NOT NULL")
+	}
+	if seq := c.LookupRelation("", "t_id_seq"); seq == nil || seq.Kind != RelKindSequence {
+		t.Error("sequence t_id_seq not created for serial column")
+	}
+	if c.LookupRelation("", "t_pkey") == nil {
+		t.Error("index t_pkey not created for primary key")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		setup, sql string
+		code, msg  string
+	}{
+		{"", "CREATE TABLE t (a int, a text)", CodeDuplicateColumn, `column "a" specified more than once`},
+		{"CREATE TABLE t (a int)", "CREATE TABLE t (b int)", CodeDuplicateTable, `relation "t" already exists`},
+		{"", "CREATE TABLE t (a nosuchtype)", CodeUndefinedObject, `type "nosuchtype" does not exist`},
+		{"", "CREATE TABLE s.t (a int)", CodeInvalidSchemaName, `schema "s" does not exist`},
+		{"CREATE TABLE t (a int)", "CREATE TABLE u (b int REFERENCES t)", CodeInvalidForeignKey,
+			`there is no primary key for referenced table "t"`},
+		{"CREATE TABLE t (a int)", "ALTER TABLE t DROP COLUMN b", CodeUndefinedColumn,
+			`column "b" of relation "t" does not exist`},
+		{"", "DROP TABLE t", CodeUndefinedTable, `table "t" does not exist`},
+		{"CREATE VIEW v AS SELECT 1 AS x", "DROP TABLE v", CodeWrongObjectType, `"v" is not a table`},
+		{"CREATE TABLE t (id int PRIMARY KEY); CREATE TABLE u (id int REFERENCES t)", "DROP TABLE t",
+			CodeDependentObjectsStillExist, "cannot drop table t because other objects depend on it"},
+		{"CREATE TABLE t (id int PRIMARY KEY)", "DROP INDEX t_pkey", CodeDependentObjectsStillExist,
+			"cannot drop index t_pkey because constraint t_pkey on table t requires it"},
+		{"CREATE TABLE t (a int); CREATE TABLE c () INHERITS (t)", "ALTER TABLE c RENAME COLUMN a TO b",
+			CodeInvalidTableDefinition, `cannot rename inherited column "a"`},
+		{"CREATE TABLE t (a int, b int)", "ALTER TABLE t RENAME COLUMN a TO b", CodeDuplicateColumn,
+			`column "b" of relation "t" already exists`},
+		{"CREATE FUNCTION f(int) RETURNS int LANGUAGE sql AS 'select 1'; CREATE FUNCTION f(text) RETURNS int LANGUAGE sql AS 'select 1'",
+			"DROP FUNCTION f", CodeAmbiguousFunction, `function name "f" is not unique`},
+		{"", "DROP FUNCTION f(int)", CodeUndefinedFunction, "function f(integer) does not exist"},
+		{"CREATE TYPE e AS ENUM ('a')", "ALTER TYPE e ADD VALUE 'a'", CodeDuplicateObject,
+			`enum label "a" already exists`},
+		{"CREATE TYPE e AS ENUM ('a')", "DROP DOMAIN e", CodeWrongObjectType, `"e" is not a domain`},
+		{"", "CREATE SCHEMA pg_foo", "42939", `unacceptable schema name "pg_foo"`},
+		{"CREATE TABLE t (a int)", "COMMENT ON COLUMN a IS 'x'", CodeSyntaxError, "column name must be qualified"},
+	}
+	for _, tt := range tests {
+		e := execError(t, tt.setup, tt.sql)
+		if e == nil {
+			t.Errorf("%s: no error, want %q", tt.sql, tt.msg)
+			continue
+		}
+		if e.Code != tt.code || e.Message != tt.msg {
+			t.Errorf("%s: got %s %q, want %s %q", tt.sql, e.Code, e.Message, tt.code, tt.msg)
+		}
+	}
+}
+
+func TestDropCascade(t *testing.T) {
+	c := New()
+	var notices []string
+	c.NoticeFunc = func(m string) { notices = append(notices, m) }
+	mustExec(t, c, `
+		CREATE TABLE t (id serial PRIMARY KEY);
+		CREATE TABLE u (id int REFERENCES t);
+		CREATE VIEW v AS SELECT id FROM t;
+		DROP TABLE t CASCADE;
+		DROP TABLE IF EXISTS t;
+	`)
+	want := []string{"drop cascades to 2 other objects", `table "t" does not exist, skipping`}
+	if strings.Join(notices, "\n") != strings.Join(want, "\n") {
+		t.Errorf("notices = %q, want %q", notices, want)
+	}
+	for _, name := range []string{"t", "v", "t_pkey", "t_id_seq"} {
+		if c.LookupRelation("", name) != nil {
+			t.Errorf("relation %s still exists", name)
+		}
+	}
+	u := c.LookupRelation("", "u")
+	if u == nil || len(u.Constraints) != 0 {
+		t.Errorf("u should remain without its foreign key, got %v", u)
+	}
+}
+
+func TestRename(t *testing.T) {
+	c := New()
+	mustExec(t, c, `
+		CREATE TABLE t (a int PRIMARY KEY, b int);
+		CREATE INDEX t_b_idx ON t (b);
+		ALTER TABLE t RENAME COLUMN b TO c;
+		ALTER TABLE t RENAME CONSTRAINT t_pkey TO t_pk;
+		ALTER TABLE t RENAME TO u;
+	`)
+	u := c.LookupRelation("", "u")
+	if u == nil || c.LookupRelation("", "t") != nil {
+		t.Fatal("table not renamed")
+	}
+	if u.Column("c") == nil || u.Column("b") != nil {
+		t.Error("column not renamed")
+	}
+	if u.Constraint("t_pk") == nil || c.LookupRelation("", "t_pk") == nil {
+		t.Error("primary key constraint and index not renamed together")
+	}
+	idx := c.LookupRelation("", "t_b_idx")
+	if idx == nil || strings.Join(idx.Index.Columns(), ",") != "c" {
+		t.Errorf("index columns not updated after column rename")
+	}
+}
+
+func TestViews(t *testing.T) {
+	c := New()
+	mustExec(t, c, `
+		CREATE TABLE t (id int, name text);
+		CREATE VIEW v AS SELECT id, upper(name), count(*) AS n, 1 + 1 FROM t GROUP BY 1, 2;
+	`)
+	v := c.LookupRelation("", "v")
+	var cols []string
+	for _, col := range v.Columns {
+		cols = append(cols, col.Name)
+	}
+	if got, want := strings.Join(cols, ","), "id,upper,n,?column?"; got != want {
+		t.Errorf("view columns = %q, want %q", got, want)
+	}
+	e := execError(t, "CREATE TABLE t (id int)", "CREATE VIEW v AS SELECT nosuch FROM t")
+	if e == nil || e.Message != `column "nosuch" does not exist` {
+		t.Errorf("got %v, want undefined column error", e)
+	}
+}
+
+func TestFailedStatementIsUndone(t *testing.T) {
+	c := New()
+	mustExec(t, c, "CREATE TABLE t (id int PRIMARY KEY)")
+	if err := c.Exec("CREATE TABLE u (id serial, x int REFERENCES nosuch)"); err == nil {
+		t.Fatal("expected error")
+	}
+	if c.LookupRelation("", "u") != nil || c.LookupRelation("", "u_id_seq") != nil {
+		t.Error("failed CREATE TABLE left objects behind")
+	}
+	if err := c.Exec("ALTER TABLE t ADD COLUMN a int, ADD COLUMN a text"); err == nil {
+		t.Fatal("expected error")
+	}
+	if c.LookupRelation("", "t").Column("a") != nil {
+		t.Error("failed ALTER TABLE left a column behind")
+	}
+}
+
+func TestSearchPath(t *testing.T) {
+	c := New()
+	mustExec(t, c, `
+		CREATE SCHEMA app;
+		SET search_path = app, public;
+		CREATE TABLE t (a int);
+		CREATE TYPE mood AS ENUM ('ok');
+		ALTER TABLE t ADD COLUMN m mood;
+	`)
+	if c.Schema("app").Relation("t") == nil {
+		t.Error("table not created in first search path schema")
+	}
+	if got := c.LookupRelation("app", "t").Column("m").TypeString(); got != "mood" {
+		t.Errorf("column type = %q, want mood", got)
+	}
+}
+
+func TestActiveSearchPath(t *testing.T) {
+	c := New()
+	c.User = "alice"
+	mustExec(t, c, "CREATE SCHEMA alice; CREATE SCHEMA app")
+	if got, want := strings.Join(c.ActiveSearchPath(), ","), "pg_catalog,alice,public"; got != want {
+		t.Errorf("ActiveSearchPath() = %s, want %s", got, want)
+	}
+	if got := c.CreationSchema(); got != "alice" {
+		t.Errorf("CreationSchema() = %q, want alice", got)
+	}
+	mustExec(t, c, "SET search_path = missing, app, pg_catalog")
+	if got, want := strings.Join(c.ActiveSearchPath(), ","), "app,pg_catalog"; got != want {
+		t.Errorf("ActiveSearchPath() = %s, want %s", got, want)
+	}
+	if got := c.CreationSchema(); got != "app" {
+		t.Errorf("CreationSchema() = %q, want app", got)
+	}
+	mustExec(t, c, "SET search_path = missing")
+	if got := c.CreationSchema(); got != "" {
+		t.Errorf("CreationSchema() = %q, want none", got)
+	}
+}
@@ -0,0 +1,1017 @@
+package catalog
+
+import (
+	"math"
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// checkSchemaName rejects names reserved for system schemas.
+func checkSchemaName(name string) error {
+	if strings.HasPrefix(name, "pg_") {
+		return errorf("42939", "unacceptable schema name %q", name).
+			withDetail("The prefix \"pg_\" is reserved for system schemas.")
+	}
+	return nil
+}
+
+// createSchema implements CREATE SCHEMA, including the schema elements,
+// which are created with the new schema alone on the search path.
+func (c *Catalog) createSchema(n *nodes.CreateSchemaStmt) error {
+	name := n.Schemaname
+	owner := c.User
+	if n.Authrole != nil {
+		owner = c.roleName(n.Authrole)
+		if name == "" {
+			name = owner
+		}
+	}
+	if err := checkSchemaName(name); err != nil {
+		return err
+	}
+	if c.schemas[name] != nil {
+		if n.IfNotExists {
+			c.notice("schema %q already exists, skipping", name)
+			return nil
+		}
+		return errorf(CodeDuplicateSchema, "schema %q already exists", name)
+	}
+	s := newSchema(name)
+	s.Owner = owner
+	c.addSchema(s)
+
+	if len(listItems(n.SchemaElts)) == 0 {
+		return nil
+	}
+	saved := c.searchPath
+	c.searchPath = []string{name}
+	defer func() { c.searchPath = saved }()
+	for _, elt := range listItems(n.SchemaElts) {
+		if err := checkSchemaElement(elt, name); err != nil {
+			return err
+		}
+		if err := c.apply(elt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkSchemaElement rejects schema elements qualified with a schema other
+// than the one being created.
+func checkSchemaElement(elt nodes.Node, schema string) error {
+	var rv *nodes.RangeVar
+	switch e := elt.(type) {
+	case *nodes.CreateStmt:
+		rv = e.Relation
+	case *nodes.ViewStmt:
+		rv = e.View
+	case *nodes.CreateSeqStmt:
+		rv = e.Sequence
+	case *nodes.IndexStmt:
+		rv = e.Relation
+	}
+	if rv != nil && rv.Schemaname != "" && rv.Schemaname != schema {
+		return errorf("42P15", "CREATE specifies a schema (%s) different from the one being created (%s)", rv.Schemaname, schema)
+	}
+	return nil
+}
+
+// sequenceBounds returns the range of values of a sequence data type.
+func sequenceBounds(typ string) (int64, int64) {
+	switch typ {
+	case "int2":
+		return math.MinInt16, math.MaxInt16
+	case "int4":
+		return math.MinInt32, math.MaxInt32
+	}
+	return math.MinInt64, math.MaxInt64
+}
+
+// newSequence returns an ascending sequence of the given type with
+// PostgreSQL's default parameters.
+func newSequence(tn *nodes.TypeName) *Sequence {
+	_, typ := typeNameParts(tn)
+	_, max := sequenceBounds(typ)
+	return &Sequence{
+		Type:      tn,
+		Start:     1,
+		Increment: 1,
+		MinValue:  1,
+		MaxValue:  max,
+		Cache:     1,
+	}
+}
+
+// createSequence implements CREATE SEQUENCE.
+func (c *Catalog) createSequence(n *nodes.CreateSeqStmt) error {
+	rel, err := c.newRelation(n.Sequence, RelKindSequence, n.IfNotExists)
+	if rel == nil {
+		return err
+	}
+	rel.Sequence = newSequence(builtinTypeName("int8"))
+	c.addRelation(rel)
+	return c.sequenceOptions(rel, n.Options, true)
+}
+
+// alterSequence implements ALTER SEQUENCE.
+func (c *Catalog) alterSequence(n *nodes.AlterSeqStmt) error {
+	rel, err := c.relationFor(n.Sequence)
+	if err != nil {
+		if n.MissingOk {
+			c.notice("relation %q does not exist, skipping", rangeVarName(n.Sequence))
+			return nil
+		}
+		return err
+	}
+	if rel.Sequence == nil {
+		return errorf(CodeWrongObjectType, "%q is not a sequence", rel.Name)
+	}
+	return c.sequenceOptions(rel, n.Options, false)
+}
+
+// sequenceOptions applies sequence options and checks the resulting
+// parameters, following init_params in sequence.c.
+func (c *Catalog) sequenceOptions(rel *Relation, opts *nodes.List, creating bool) error {
+	seq := *rel.Sequence
+	seen := make(map[string]bool)
+	var ownedBy *nodes.List
+	setMin, setMax, setStart := false, false, false
+	var asType *nodes.TypeName
+	for _, item := range listItems(opts) {
+		d, ok := item.(*nodes.DefElem)
+		if !ok {
+			continue
+		}
+		if seen[d.Defname] {
+			return errorf(CodeSyntaxError, "conflicting or redundant options")
+		}
+		seen[d.Defname] = true
+		switch d.Defname {
+		case "as":
+			asType, _ = d.Arg.(*nodes.TypeName)
+		case "increment":
+			seq.Increment, _ = defElemInt(d)
+			if seq.Increment == 0 {
+				return errorf(CodeInvalidParameterValue, "INCREMENT must not be zero")
+			}
+		case "minvalue":
+			if v, ok := defElemInt(d); ok {
+				seq.MinValue, setMin = v, true
+			}
+		case "maxvalue":
+			if v, ok := defElemInt(d); ok {
+				seq.MaxValue, setMax = v, true
+			}
+		case "start":
+			if v, ok := defElemInt(d); ok {
+				seq.Start, setStart = v, true
+			}
+		case "cache":
+			seq.Cache, _ = defElemInt(d)
+			if seq.Cache <= 0 {
+				return errorf(CodeInvalidParameterValue, "CACHE (%d) must be greater than zero", seq.Cache)
+			}
+		case "cycle":
+			if b, ok := d.Arg.(*nodes.Boolean); ok {
+				seq.Cycle = b.Boolval
+			} else {
+				seq.Cycle = d.Arg == nil
+			}
+		case "owned_by":
+			ownedBy, _ = d.Arg.(*nodes.List)
+		}
+	}
+
+	_, oldType := typeNameParts(seq.Type)
+	oldMin, oldMax := sequenceBounds(oldType)
+	typ := oldType
+	if asType != nil {
+		if !isBuiltinTypeName(asType) {
+			return errorf(CodeInvalidParameterValue, "sequence type must be smallint, integer, or bigint")
+		}
+		_, typ = typeNameParts(asType)
+		if typ != "int2" && typ != "int4" && typ != "int8" {
+			return errorf(CodeInvalidParameterValue, "sequence type must be smallint, integer, or bigint")
+		}
+		seq.Type = asType
+	}
+	typMin, typMax := sequenceBounds(typ)
+	ascending := seq.Increment > 0
+	if !setMax && (creating || seen["increment"] || seen["as"] && seq.MaxValue == oldMax || seen["maxvalue"]) {
+		if ascending {
+			seq.MaxValue = typMax
+		} else {
+			seq.MaxValue = -1
+		}
+	}
+	if !setMin && (creating || seen["increment"] || seen["as"] && seq.MinValue == oldMin || seen["minvalue"]) {
+		if ascending {
+			seq.MinValue = 1
+		} else {
+			seq.MinValue = typMin
+		}
+	}
+	if seq.MaxValue > typMax || seq.MaxValue < typMin {
+		return errorf(CodeInvalidParameterValue, "MAXVALUE (%d) is out of range for sequence data type %s", seq.MaxValue, FormatTypeName(seq.Type))
+	}
+	if seq.MinValue > typMax || seq.MinValue < typMin {
+		return errorf(CodeInvalidParameterValue, "MINVALUE (%d) is out of range for sequence data type %s", seq.MinValue, FormatTypeName(seq.Type))
+	}
+	if seq.MinValue >= seq.MaxValue {
+		return errorf(CodeInvalidParameterValue, "MINVALUE (%d) must be less than MAXVALUE (%d)", seq.MinValue, seq.MaxValue)
+	}
+	if !setStart && creating {
+		if ascending {
+			seq.Start = seq.MinValue
+		} else {
+			seq.Start = seq.MaxValue
+		}
+	}
+	if seq.Start < seq.MinValue {
+		return errorf(CodeInvalidParameterValue, "START value (%d) cannot be less than MINVALUE (%d)", seq.Start, seq.MinValue)
+	}
+	if seq.Start > seq.MaxValue {
+		return errorf(CodeInvalidParameterValue, "START value (%d) cannot be greater than MAXVALUE (%d)", seq.Start, seq.MaxValue)
+	}
+	set(c, &rel.Sequence, &seq)
+	if ownedBy != nil {
+		return c.sequenceOwnedBy(rel, stringList(ownedBy))
+	}
+	return nil
+}
+
+// sequenceOwnedBy implements OWNED BY, following process_owned_by.
+func (c *Catalog) sequenceOwnedBy(seq *Relation, names []string) error {
+	if len(names) == 1 && names[0] == "none" {
+		c.removeDependencies(addr(seq), depAuto)
+		set(c, &seq.Sequence.OwnedBy, nil)
+		return nil
+	}
+	if len(names) < 2 {
+		return errorf(CodeSyntaxError, "invalid OWNED BY option").
+			withHint("Specify OWNED BY table.column or OWNED BY NONE.")
+	}
+	rv := &nodes.RangeVar{Relname: names[len(names)-2]}
+	if len(names) > 2 {
+		rv.Schemaname = names[len(names)-3]
+	}
+	table, err := c.relationFor(rv)
+	if err != nil {
+		return err
+	}
+	switch table.Kind {
+	case RelKindTable, RelKindForeignTable, RelKindView, RelKindPartitionedTable:
+	default:
+		return alterKindDetail(errorf(CodeWrongObjectType, "sequence cannot be owned by relation %q", table.Name), table.Kind)
+	}
+	if table.Owner != seq.Owner {
+		return errorf("55000", "sequence must have same owner as table it is linked to")
+	}
+	if table.Schema != seq.Schema {
+		return errorf("55000", "sequence must be in same schema as table it is linked to")
+	}
+	colName := names[len(names)-1]
+	col := table.Column(colName)
+	if col == nil {
+		return errorf(CodeUndefinedColumn, "column %q of relation %q does not exist", colName, table.Name)
+	}
+	c.removeDependencies(addr(seq), depAuto)
+	set(c, &seq.Sequence.OwnedBy, col)
+	c.recordDependency(addr(seq), addr(col), depAuto)
+	return nil
+}
+
+// newTypeSchema returns the schema a new type named by a qualified name
+// goes in, after checking that the name is free.
+func (c *Catalog) newTypeSchema(names *nodes.List) (*Schema, string, error) {
+	schema, name, err := qualifiedName(names)
+	if err != nil {
+		return nil, "", err
+	}
+	s, err := c.creationSchema(schema)
+	if err != nil {
+		return nil, "", err
+	}
+	if typeNameTaken(s, name) {
+		return nil, "", errorf(CodeDuplicateObject, "type %q already exists", name)
+	}
+	return s, name, nil
+}
+
+// lookupTypeList resolves the qualified name of a user-defined type.
+func (c *Catalog) lookupTypeList(names *nodes.List) (*Type, error) {
+	schema, name, err := qualifiedName(names)
+	if err != nil {
+		return nil, err
+	}
+	if schema != "" {
+		if _, err := c.lookupSchema(schema); err != nil {
+			return nil, err
+		}
+	}
+	if t := c.LookupType(schema, name); t != nil {
+		return t, nil
+	}
+	return nil, errorf(CodeUndefinedObject, "type %q does not exist", nameListString(stringList(names)))
+}
+
+// recordTypeDependency records that dependent uses the type tn, if it is
+// a user-defined type or a row type.
+func (c *Catalog) recordTypeDependency(dependent address, tn *nodes.TypeName) error {
+	if tn == nil {
+		return nil
+	}
+	ref, err := c.resolveType(tn)
+	if err != nil {
+		return err
+	}
+	switch {
+	case ref.typ != nil:
+		c.recordDependency(dependent, addr(ref.typ), depNormal)
+	case ref.relation != nil:
+		c.recordDependency(dependent, addr(ref.relation), depNormal)
+	}
+	return nil
+}
+
+// checkEnumLabel rejects labels longer than a name.
+func checkEnumLabel(label string) error {
+	if len(label) > NAMEDATALEN-1 {
+		return errorf(CodeInvalidParameterValue, "invalid enum label %q", label).
+			withDetail("Labels must be %d bytes or less.", NAMEDATALEN-1)
+	}
+	return nil
+}
+
+// createEnum implements CREATE TYPE ... AS ENUM.
+func (c *Catalog) createEnum(n *nodes.CreateEnumStmt) error {
+	s, name, err := c.newTypeSchema(n.TypeName)
+	if err != nil {
+		return err
+	}
+	labels := stringList(n.Vals)
+	for _, l := range labels {
+		if err := checkEnumLabel(l); err != nil {
+			return err
+		}
+	}
+	c.addType(&Type{Schema: s, Name: name, Kind: TypeKindEnum, Owner: c.User, EnumLabels: labels})
+	return nil
+}
+
+// alterEnum implements ALTER TYPE ... ADD VALUE and RENAME VALUE.
+func (c *Catalog) alterEnum(n *nodes.AlterEnumStmt) error {
+	t, err := c.lookupTypeList(n.TypeName)
+	if err != nil {
+		return err
+	}
+	if t.Kind != TypeKindEnum {
+		return errorf(CodeWrongObjectType, "%s is not an enum", t.Name)
+	}
+	labels := t.EnumLabels
+	if n.OldVal != "" {
+		i := indexOf(labels, n.OldVal)
+		if i < 0 {
+			return errorf(CodeInvalidParameterValue, "%q is not an existing enum label", n.OldVal)
+		}
+		if n.OldVal == n.NewVal {
+			return nil
+		}
+		if indexOf(labels, n.NewVal) >= 0 {
+			return errorf(CodeDuplicateObject, "enum label %q already exists", n.NewVal)
+		}
+		if err := checkEnumLabel(n.NewVal); err != nil {
+			return err
+		}
+		renamed := append([]string(nil), labels...)
+		renamed[i] = n.NewVal
+		set(c, &t.EnumLabels, renamed)
+		return nil
+	}
+
+	if indexOf(labels, n.NewVal) >= 0 {
+		if n.SkipIfNewValExists {
+			c.notice("enum label %q already exists, skipping", n.NewVal)
+			return nil
+		}
+		return errorf(CodeDuplicateObject, "enum label %q already exists", n.NewVal)
+	}
+	if err := checkEnumLabel(n.NewVal); err != nil {
+		return err
+	}
+	pos := len(labels)
+	if n.NewValNeighbor != "" {
+		pos = indexOf(labels, n.NewValNeighbor)
+		if pos < 0 {
+			return errorf(CodeInvalidParameterValue, "%q is not an existing enum label", n.NewValNeighbor)
+		}
+		if n.NewValIsAfter {
+			pos++
+		}
+	}
+	added := make([]string, 0, len(labels)+1)
+	added = append(added, labels[:pos]...)
+	added = append(added, n.NewVal)
+	added = append(added, labels[pos:]...)
+	set(c, &t.EnumLabels, added)
+	return nil
+}
+
+func indexOf(list []string, s string) int {
+	for i, x := range list {
+		if x == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// createDomain implements CREATE DOMAIN, following DefineDomain.
+func (c *Catalog) createDomain(n *nodes.CreateDomainStmt) error {
+	s, name, err := c.newTypeSchema(n.Domainname)
+	if err != nil {
+		return err
+	}
+	if _, err := c.resolveType(n.TypeName); err != nil {
+		return err
+	}
+	t := &Type{Schema: s, Name: name, Kind: TypeKindDomain, Owner: c.User, BaseType: n.TypeName}
+	if n.CollClause != nil {
+		t.Collation = nameListString(stringList(n.CollClause.Collname))
+	}
+	c.addType(t)
+	if err := c.recordTypeDependency(addr(t), n.TypeName); err != nil {
+		return err
+	}
+
+	sawNull, sawNotNull, sawDefault := false, false, false
+	for _, item := range listItems(n.Constraints) {
+		con, ok := item.(*nodes.Constraint)
+		if !ok {
+			continue
+		}
+		switch con.Contype {
+		case nodes.CONSTR_DEFAULT:
+			if sawDefault {
+				return errorf(CodeSyntaxError, "multiple default expressions")
+			}
+			sawDefault = true
+			t.Default = con.RawExpr
+		case nodes.CONSTR_NOTNULL:
+			if sawNull {
+				return errorf(CodeSyntaxError, "conflicting NULL/NOT NULL constraints")
+			}
+			sawNotNull = true
+			t.NotNull = true
+		case nodes.CONSTR_NULL:
+			if sawNotNull {
+				return errorf(CodeSyntaxError, "conflicting NULL/NOT NULL constraints")
+			}
+			sawNull = true
+		case nodes.CONSTR_CHECK:
+			if err := c.addDomainConstraint(t, con); err != nil {
+				return err
+			}
+		default:
+			return domainConstraintError(con.Contype)
+		}
+	}
+	return nil
+}
+
+// domainConstraintError reports a constraint kind domains do not support.
+func domainConstraintError(kind nodes.ConstrType) error {
+	what := map[nodes.ConstrType]string{
+		nodes.CONSTR_PRIMARY:   "primary key",
+		nodes.CONSTR_UNIQUE:    "unique",
+		nodes.CONSTR_EXCLUSION: "exclusion",
+		nodes.CONSTR_FOREIGN:   "foreign key",
+	}[kind]
+	if what == "" {
+		return errorf(CodeSyntaxError, "specifying constraint deferrability not supported for domains")
+	}
+	return errorf(CodeSyntaxError, "%s constraints not possible for domains", what)
+}
+
+// addDomainConstraint adds a CHECK constraint to a domain.
+func (c *Catalog) addDomainConstraint(t *Type, def *nodes.Constraint) error {
+	if def.IsNoInherit {
+		return errorf(CodeInvalidObjectDefinition, "check constraints for domains cannot be marked NO INHERIT")
+	}
+	name := def.Conname
+	if name == "" {
+		name = chooseConstraintName(t.Schema, t.Name, "", "check", nil)
+	} else {
+		for _, con := range t.Constraints {
+			if con.Name == name {
+				return errorf(CodeDuplicateObject, "constraint %q for domain %q already exists", name, t.Name)
+			}
+		}
+	}
+	con := &Constraint{
+		Domain:    t,
+		Name:      name,
+		Type:      nodes.CONSTR_CHECK,
+		Expr:      def.RawExpr,
+		Validated: !def.SkipValidation,
+		Def:       def,
+	}
+	set(c, &t.Constraints, appended(t.Constraints, con))
+	return nil
+}
+
+// alterDomain implements ALTER DOMAIN.
+func (c *Catalog) alterDomain(n *nodes.AlterDomainStmt) error {
+	t, err := c.lookupTypeList(n.TypeName)
+	if err != nil {
+		return err
+	}
+	if t.Kind != TypeKindDomain {
+		return errorf(CodeWrongObjectType, "%s is not a domain", t.Name)
+	}
+	constraint := func() (*Constraint, error) {
+		for _, con := range t.Constraints {
+			if con.Name == n.Name {
+				return con, nil
+			}
+		}
+		return nil, errorf(CodeUndefinedObject, "constraint %q of domain %q does not exist", n.Name, t.Name)
+	}
+	switch n.Subtype {
+	case 'T':
+		set(c, &t.Default, n.Def)
+	case 'N':
+		set(c, &t.NotNull, false)
+	case 'O':
+		set(c, &t.NotNull, true)
+	case 'C':
+		con, ok := n.Def.(*nodes.Constraint)
+		if !ok {
+			return nil
+		}
+		switch con.Contype {
+		case nodes.CONSTR_CHECK:
+			return c.addDomainConstraint(t, con)
+		case nodes.CONSTR_NOTNULL:
+			set(c, &t.NotNull, true)
+		default:
+			return domainConstraintError(con.Contype)
+		}
+	case 'X':
+		con, err := constraint()
+		if err != nil {
+			if n.MissingOk {
+				c.notice("constraint %q of domain %q does not exist, skipping", n.Name, t.Name)
+				return nil
+			}
+			return err
+		}
+		return c.performDeletion([]address{addr(con)}, n.Behavior == nodes.DROP_CASCADE)
+	case 'V':
+		con, err := constraint()
+		if err != nil {
+			return err
+		}
+		set(c, &con.Validated, true)
+	}
+	return nil
+}
+
+// createCompositeType implements CREATE TYPE ... AS (...).
+func (c *Catalog) createCompositeType(n *nodes.CompositeTypeStmt) error {
+	s, err := c.creationSchema(n.Typevar.Schemaname)
+	if err != nil {
+		return err
+	}
+	name := n.Typevar.Relname
+	if s.relations[name] != nil {
+		return errorf(CodeDuplicateTable, "relation %q already exists", name)
+	}
+	if s.types[name] != nil {
+		return errorf(CodeDuplicateObject, "type %q already exists", name)
+	}
+	t := &Type{Schema: s, Name: name, Kind: TypeKindComposite, Owner: c.User}
+	c.addType(t)
+	for _, item := range listItems(n.Coldeflist) {
+		def, ok := item.(*nodes.ColumnDef)
+		if !ok {
+			continue
+		}
+		if err := c.addAttribute(t, def); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addAttribute adds an attribute to a composite type.
+func (c *Catalog) addAttribute(t *Type, def *nodes.ColumnDef) error {
+	for _, attr := range t.Attributes {
+		if attr.Name == def.Colname {
+			return errorf(CodeDuplicateColumn, "column %q specified more than once", def.Colname)
+		}
+	}
+	if _, err := c.resolveType(def.TypeName); err != nil {
+		return err
+	}
+	attr := &Column{Name: def.Colname, Type: def.TypeName, IsLocal: true}
+	if def.CollClause != nil {
+		attr.Collation = nameListString(stringList(def.CollClause.Collname))
+	}
+	set(c, &t.Attributes, appended(t.Attributes, attr))
+	return c.recordTypeDependency(addr(t), def.TypeName)
+}
+
+// alterCompositeType implements the ALTER TYPE forms that reach
+// AlterTableStmt: ADD, DROP and ALTER ATTRIBUTE.
+func (c *Catalog) alterCompositeType(n *nodes.AlterTableStmt) error {
+	t := c.LookupType(n.Relation.Schemaname, n.Relation.Relname)
+	if t == nil {
+		if n.MissingOk {
+			c.notice("type %q does not exist, skipping", rangeVarName(n.Relation))
+			return nil
+		}
+		return errorf(CodeUndefinedObject, "type %q does not exist", rangeVarName(n.Relation))
+	}
+	if t.Kind != TypeKindComposite {
+		return errorf(CodeWrongObjectType, "%s is not a composite type", t.Name)
+	}
+	attribute := func(name string) int {
+		for i, attr := range t.Attributes {
+			if attr.Name == name {
+				return i
+			}
+		}
+		return -1
+	}
+	for _, item := range listItems(n.Cmds) {
+		cmd, ok := item.(*nodes.AlterTableCmd)
+		if !ok {
+			continue
+		}
+		switch nodes.AlterTableType(cmd.Subtype) {
+		case nodes.AT_AddColumn:
+			def := cmd.Def.(*nodes.ColumnDef)
+			if attribute(def.Colname) >= 0 {
+				return errorf(CodeDuplicateColumn, "column %q of relation %q already exists", def.Colname, t.Name)
+			}
+			if err := c.addAttribute(t, def); err != nil {
+				return err
+			}
+		case nodes.AT_DropColumn:
+			i := attribute(cmd.Name)
+			if i < 0 {
+				if cmd.MissingOk {
+					c.notice("column %q of relation %q does not exist, skipping", cmd.Name, t.Name)
+					continue
+				}
+				return errorf(CodeUndefinedColumn, "column %q of relation %q does not exist", cmd.Name, t.Name)
+			}
+			set(c, &t.Attributes, without(t.Attributes, i))
+		case nodes.AT_AlterColumnType:
+			i := attribute(cmd.Name)
+			if i < 0 {
+				return errorf(CodeUndefinedColumn, "column %q of relation %q does not exist", cmd.Name, t.Name)
+			}
+			def, ok := cmd.Def.(*nodes.ColumnDef)
+			if !ok || def.TypeName == nil {
+				continue
+			}
+			if _, err := c.resolveType(def.TypeName); err != nil {
+				return err
+			}
+			attrs := append([]*Column(nil), t.Attributes...)
+			cp := *attrs[i]
+			cp.Type = def.TypeName
+			attrs[i] = &cp
+			set(c, &t.Attributes, attrs)
+			if err := c.recordTypeDependency(addr(t), def.TypeName); err != nil {
+				return err
+			}
+		case nodes.AT_ChangeOwner:
+			set(c, &t.Owner, c.roleName(cmd.Newowner))
+		}
+	}
+	return nil
+}
+
+// createRange implements CREATE TYPE ... AS RANGE.
+func (c *Catalog) createRange(n *nodes.CreateRangeStmt) error {
+	s, name, err := c.newTypeSchema(n.TypeName)
+	if err != nil {
+		return err
+	}
+	var subtype *nodes.TypeName
+	for _, item := range listItems(n.Params) {
+		d, ok := item.(*nodes.DefElem)
+		if !ok {
+			continue
+		}
+		switch d.Defname {
+		case "subtype":
+			if subtype != nil {
+				return errorf(CodeSyntaxError, "conflicting or redundant options")
+			}
+			subtype, _ = d.Arg.(*nodes.TypeName)
+		case "subtype_opclass", "collation", "canonical", "subtype_diff", "multirange_type_name":
+		default:
+			return errorf(CodeSyntaxError, "type attribute %q not recognized", d.Defname)
+		}
+	}
+	if subtype == nil {
+		return errorf(CodeInvalidObjectDefinition, "type attribute \"subtype\" is required")
+	}
+	if _, err := c.resolveType(subtype); err != nil {
+		return err
+	}
+	t := &Type{Schema: s, Name: name, Kind: TypeKindRange, Owner: c.User, Subtype: subtype, Definition: n.Params}
+	c.addType(t)
+	return c.recordTypeDependency(addr(t), subtype)
+}
+
+// define implements the DefineStmt forms the catalog models: CREATE TYPE
+// (shell and base types) and CREATE AGGREGATE.
+func (c *Catalog) define(n *nodes.DefineStmt) error {
+	switch n.Kind {
+	case nodes.OBJECT_TYPE:
+		schema, name, err := qualifiedName(n.Defnames)
+		if err != nil {
+			return err
+		}
+		s, err := c.creationSchema(schema)
+		if err != nil {
+			return err
+		}
+		if existing := s.types[name]; existing != nil {
+			if existing.Kind == TypeKindShell && n.Definition != nil {
+				set(c, &existing.Kind, TypeKindBase)
+				set(c, &existing.Definition, n.Definition)
+				return nil
+			}
+			return errorf(CodeDuplicateObject, "type %q already exists", name)
+		}
+		if typeNameTaken(s, name) {
+			return errorf(CodeDuplicateObject, "type %q already exists", name)
+		}
+		kind := TypeKindBase
+		if n.Definition == nil {
+			kind = TypeKindShell
+		}
+		c.addType(&Type{Schema: s, Name: name, Kind: kind, Owner: c.User, Definition: n.Definition})
+	case nodes.OBJECT_AGGREGATE:
+		return c.defineAggregate(n)
+	}
+	return nil
+}
+
+// defineAggregate implements CREATE AGGREGATE.
+func (c *Catalog) defineAggregate(n *nodes.DefineStmt) error {
+	var params []*nodes.FunctionParameter
+	if args := listItems(n.Args); len(args) > 0 {
+		for _, item := range listItems(asList(args[0])) {
+			if p, ok := item.(*nodes.FunctionParameter); ok {
+				params = append(params, p)
+			}
+		}
+	}
+	var stype, finalType *nodes.TypeName
+	for _, item := range listItems(n.Definition) {
+		d, ok := item.(*nodes.DefElem)
+		if !ok {
+			continue
+		}
+		switch strings.ToLower(d.Defname) {
+		case "stype", "stype1":
+			stype, _ = d.Arg.(*nodes.TypeName)
+		case "finalfunc_type":
+			finalType, _ = d.Arg.(*nodes.TypeName)
+		}
+	}
+	if stype == nil {
+		return errorf(CodeInvalidFunctionDefinition, "aggregate stype must be specified")
+	}
+	ret := stype
+	if finalType != nil {
+		ret = finalType
+	}
+	stmt := &nodes.CreateFunctionStmt{
+		Replace:    n.Replace,
+		Funcname:   n.Defnames,
+		Parameters: &nodes.List{},
+		ReturnType: ret,
+		Options:    n.Definition,
+	}
+	for _, p := range params {
+		stmt.Parameters.Items = append(stmt.Parameters.Items, p)
+	}
+	return c.defineFunction(stmt, FuncKindAggregate)
+}
+
+func asList(n nodes.Node) *nodes.List {
+	l, _ := n.(*nodes.List)
+	return l
+}
+
+// createFunction implements CREATE [OR REPLACE] FUNCTION and PROCEDURE.
+func (c *Catalog) createFunction(n *nodes.CreateFunctionStmt) error {
+	kind := FuncKindFunction
+	if n.IsProcedure {
+		kind = FuncKindProcedure
+	}
+	for _, item := range listItems(n.Options) {
+		if d, ok := item.(*nodes.DefElem); ok && d.Defname == "window" {
+			if b, ok := d.Arg.(*nodes.Boolean); !ok || b.Boolval {
+				kind = FuncKindWindow
+			}
+		}
+	}
+	return c.defineFunction(n, kind)
+}
+
+// defineFunction creates or replaces a routine, following
+// CreateFunction and ProcedureCreate.
+func (c *Catalog) defineFunction(n *nodes.CreateFunctionStmt, kind FuncKind) error {
+	schema, name, err := qualifiedName(n.Funcname)
+	if err != nil {
+		return err
+	}
+	s, err := c.creationSchema(schema)
+	if err != nil {
+		return err
+	}
+
+	f := &Function{Schema: s, Name: name, Kind: kind, Owner: c.User, ReturnType: n.ReturnType, SQLBody: n.SqlBody}
+	var outTypes []*nodes.TypeName
+	isTable := false
+	for _, item := range listItems(n.Parameters) {
+		fp, ok := item.(*nodes.FunctionParameter)
+		if !ok {
+			continue
+		}
+		mode := fp.Mode
+		if mode == 0 || mode == nodes.FUNC_PARAM_DEFAULT {
+			mode = nodes.FUNC_PARAM_IN
+		}
+		if _, err := c.resolveType(fp.ArgType); err != nil {
+			return err
+		}
+		p := &FuncParam{Name: fp.Name, Type: fp.ArgType, Mode: mode, Default: fp.Defexpr}
+		switch mode {
+		case nodes.FUNC_PARAM_OUT, nodes.FUNC_PARAM_INOUT:
+			outTypes = append(outTypes, fp.ArgType)
+		case nodes.FUNC_PARAM_TABLE:
+			outTypes = append(outTypes, fp.ArgType)
+			isTable = true
+		}
+		f.Params = append(f.Params, p)
+	}
+	if f.ReturnType != nil {
+		if _, err := c.resolveType(f.ReturnType); err != nil {
+			return err
+		}
+		f.ReturnsSet = f.ReturnType.Setof || isTable
+	} else if kind != FuncKindProcedure {
+		switch len(outTypes) {
+		case 0:
+			return errorf(CodeInvalidFunctionDefinition, "function result type must be specified")
+		case 1:
+			f.ReturnType = outTypes[0]
+		default:
+			f.ReturnType = builtinTypeName("record")
+		}
+	}
+	for _, item := range listItems(n.Options) {
+		d, ok := item.(*nodes.DefElem)
+		if !ok {
+			continue
+		}
+		switch d.Defname {
+		case "language":
+			f.Language = defElemString(d)
+		case "as":
+			f.Body = strings.Join(stringList(asList(d.Arg)), "\n")
+		case "window":
+		default:
+			f.Options = appendedList(f.Options, d)
+		}
+	}
+	if f.Language == "" && f.SQLBody != nil {
+		f.Language = "sql"
+	}
+	if f.Language == "" && kind != FuncKindAggregate {
+		return errorf(CodeInvalidFunctionDefinition, "no language specified")
+	}
+
+	if old := c.findFunction(s, name, f.ArgTypes()); old != nil {
+		if !n.Replace {
+			what := "function"
+			if kind == FuncKindAggregate {
+				what = "aggregate"
+			}
+			return errorf(CodeDuplicateFunction, "%s %q already exists with same argument types", what, name)
+		}
+		return c.replaceFunction(old, f)
+	}
+	c.addFunction(f)
+	return c.recordFunctionDependencies(f)
+}
+
+// replaceFunction applies CREATE OR REPLACE to an existing routine.
+func (c *Catalog) replaceFunction(old, f *Function) error {
+	if old.Kind != f.Kind {
+		e := errorf(CodeWrongObjectType, "cannot change routine kind")
+		switch old.Kind {
+		case FuncKindAggregate:
+			return e.withDetail("%q is an aggregate function.", old.Name)
+		case FuncKindProcedure:
+			return e.withDetail("%q is a procedure.", old.Name)
+		case FuncKindWindow:
+			return e.withDetail("%q is a window function.", old.Name)
+		}
+		return e.withDetail("%q is a function.", old.Name)
+	}
+	dropHint := "Use DROP FUNCTION " + c.describeSignature(old) + " first."
+	if old.Kind == FuncKindProcedure {
+		dropHint = "Use DROP PROCEDURE " + c.describeSignature(old) + " first."
+	}
+	if c.typeKey(old.ReturnType) != c.typeKey(f.ReturnType) || old.ReturnsSet != f.ReturnsSet {
+		return errorf(CodeInvalidFunctionDefinition, "cannot change return type of existing function").
+			withHint("%s", dropHint)
+	}
+	oldIn, newIn := inputParams(old), inputParams(f)
+	for i, p := range oldIn {
+		if p.Name != "" && p.Name != newIn[i].Name {
+			return errorf(CodeInvalidFunctionDefinition, "cannot change name of input parameter %q", p.Name).
+				withHint("%s", dropHint)
+		}
+		if p.Default != nil && newIn[i].Default == nil {
+			return errorf(CodeInvalidFunctionDefinition, "cannot remove parameter defaults from existing function").
+				withHint("%s", dropHint)
+		}
+	}
+	set(c, &old.Params, f.Params)
+	set(c, &old.ReturnType, f.ReturnType)
+	set(c, &old.Language, f.Language)
+	set(c, &old.Body, f.Body)
+	set(c, &old.SQLBody, f.SQLBody)
+	set(c, &old.Options, f.Options)
+	c.removeDependencies(addr(old), depNormal)
+	return c.recordFunctionDependencies(old)
+}
+
+func inputParams(f *Function) []*FuncParam {
+	var out []*FuncParam
+	for _, p := range f.Params {
+		if p.isInput() {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// describeSignature formats a routine as format_procedure does, qualifying
+// it if its schema is not on the search path.
+func (c *Catalog) describeSignature(f *Function) string {
+	return c.displayName(f.Schema, f.Name) + "(" + formatArgTypes(f.ArgTypes(), ",") + ")"
+}
+
+// recordFunctionDependencies records a routine's dependencies on the
+// user-defined types of its parameters and result.
+func (c *Catalog) recordFunctionDependencies(f *Function) error {
+	for _, p := range f.Params {
+		if err := c.recordTypeDependency(addr(f), p.Type); err != nil {
+			return err
+		}
+	}
+	return c.recordTypeDependency(addr(f), f.ReturnType)
+}
+
+// findFunction returns the routine in s with the given name and input
+// argument types.
+func (c *Catalog) findFunction(s *Schema, name string, args []*nodes.TypeName) *Function {
+	for _, f := range s.functions[name] {
+		if c.sameArgTypes(f.ArgTypes(), args) {
+			return f
+		}
+	}
+	return nil
+}
+
+func (c *Catalog) sameArgTypes(a, b []*nodes.TypeName) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if c.typeKey(a[i]) != c.typeKey(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// appendedList returns a copy of l with n appended.
+func appendedList(l *nodes.List, n nodes.Node) *nodes.List {
+	return &nodes.List{Items: appended(listItems(l), n)}
+}
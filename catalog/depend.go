@@ -0,0 +1,375 @@
+package catalog
+
+import (
+	"fmt"
+	"strings"
+)
+
+// depKind is the kind of a dependency, following pg_depend.deptype.
+type depKind byte
+
+const (
+	// depNormal: the dependent may be dropped on its own; dropping the
+	// referenced object requires CASCADE.
+	depNormal depKind = 'n'
+	// depAuto: the dependent is dropped silently with the referenced
+	// object.
+	depAuto depKind = 'a'
+	// depInternal: the dependent is part of the referenced object's
+	// implementation and cannot be dropped on its own.
+	depInternal depKind = 'i'
+)
+
+// address identifies a catalog object or sub-object, like PostgreSQL's
+// ObjectAddress. obj is a *Schema, *Relation, *Column, *Constraint, *Type
+// or *Function; isDefault selects the default expression of a *Column.
+type address struct {
+	obj       any
+	isDefault bool
+}
+
+func addr(obj any) address { return address{obj: obj} }
+
+func defaultAddr(col *Column) address { return address{obj: col, isDefault: true} }
+
+type dependency struct {
+	dependent  address
+	referenced address
+	kind       depKind
+}
+
+// recordDependency notes that dependent depends on referenced.
+func (c *Catalog) recordDependency(dependent, referenced address, kind depKind) {
+	if dependent == referenced {
+		return
+	}
+	for _, d := range c.deps {
+		if d.dependent == dependent && d.referenced == referenced {
+			return
+		}
+	}
+	d := &dependency{dependent: dependent, referenced: referenced, kind: kind}
+	set(c, &c.deps, appended(c.deps, d))
+}
+
+// removeDependencies forgets every dependency of dependent on other
+// objects of the given kinds (all kinds if none are given).
+func (c *Catalog) removeDependencies(dependent address, kinds ...depKind) {
+	var keep []*dependency
+	changed := false
+	for _, d := range c.deps {
+		if d.dependent == dependent && (len(kinds) == 0 || containsKind(kinds, d.kind)) {
+			changed = true
+			continue
+		}
+		keep = append(keep, d)
+	}
+	if changed {
+		set(c, &c.deps, keep)
+	}
+}
+
+func containsKind(kinds []depKind, k depKind) bool {
+	for _, x := range kinds {
+		if x == k {
+			return true
+		}
+	}
+	return false
+}
+
+// owner returns the object that a sub-object belongs to: the relation of a
+// column, default or table constraint, or the domain of a domain
+// constraint. Whole objects are their own owner.
+func owner(a address) address {
+	switch o := a.obj.(type) {
+	case *Column:
+		if o.Relation != nil {
+			return addr(o.Relation)
+		}
+	case *Constraint:
+		if o.Relation != nil {
+			return addr(o.Relation)
+		}
+		if o.Domain != nil {
+			return addr(o.Domain)
+		}
+	}
+	return a
+}
+
+// within reports whether a is b or one of b's sub-objects.
+func within(a, b address) bool {
+	if a == b {
+		return true
+	}
+	if b.isDefault {
+		return false
+	}
+	if _, ok := b.obj.(*Column); ok {
+		// A column contains its default.
+		return a.obj == b.obj
+	}
+	return owner(a) == b
+}
+
+// schemaOf returns the schema an object lives in, or nil for sub-objects.
+func schemaOf(a address) *Schema {
+	switch o := a.obj.(type) {
+	case *Relation:
+		return o.Schema
+	case *Type:
+		return o.Schema
+	case *Function:
+		return o.Schema
+	}
+	return nil
+}
+
+// deletion is an object scheduled for removal and the object through
+// which it was reached.
+type deletion struct {
+	target  address
+	via     address
+	kind    depKind
+	primary bool
+}
+
+// performDeletion drops the given objects and everything that depends on
+// them, following performDeletion in dependency.c. Without cascade, normal
+// dependents cause an error listing them.
+func (c *Catalog) performDeletion(targets []address, cascade bool) error {
+	var order []*deletion
+	in := func(a address) bool {
+		for _, d := range order {
+			if within(a, d.target) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, t := range targets {
+		if in(t) {
+			continue
+		}
+		if err := c.checkInternalOwner(t, targets); err != nil {
+			return err
+		}
+		order = append(order, &deletion{target: t, via: t, primary: true})
+	}
+
+	// Breadth-first closure over dependents.
+	for i := 0; i < len(order); i++ {
+		cur := order[i]
+		for _, dep := range c.dependentsOf(cur.target) {
+			if in(dep.dependent) {
+				continue
+			}
+			order = append(order, &deletion{target: dep.dependent, via: cur.target, kind: dep.kind})
+		}
+	}
+
+	var normal []*deletion
+	for _, d := range order {
+		if !d.primary && d.kind == depNormal {
+			normal = append(normal, d)
+		}
+	}
+	if len(normal) > 0 {
+		lines := make([]string, len(normal))
+		for i, d := range normal {
+			lines[i] = fmt.Sprintf("%s depends on %s", c.describe(d.target), c.describe(d.via))
+		}
+		if !cascade {
+			what := c.describe(order[0].target)
+			return errorf(CodeDependentObjectsStillExist, "cannot drop %s because other objects depend on it", what).
+				withDetail("%s", strings.Join(lines, "\n")).
+				withHint("Use DROP ... CASCADE to drop the dependent objects too.")
+		}
+		if len(normal) == 1 {
+			c.notice("drop cascades to %s", c.describe(normal[0].target))
+		} else {
+			c.notice("drop cascades to %d other objects", len(normal))
+		}
+	}
+
+	// Remove dependents before the objects they depend on.
+	for i := len(order) - 1; i >= 0; i-- {
+		c.removeObject(order[i].target)
+	}
+	var keep []*dependency
+	for _, d := range c.deps {
+		if !in(d.dependent) && !in(d.referenced) {
+			keep = append(keep, d)
+		}
+	}
+	set(c, &c.deps, keep)
+	return nil
+}
+
+// checkInternalOwner rejects dropping an object that implements another
+// one, e.g. the index behind a primary key, unless that owner is being
+// dropped too.
+func (c *Catalog) checkInternalOwner(t address, targets []address) error {
+	for _, d := range c.deps {
+		if d.kind != depInternal || d.dependent != t {
+			continue
+		}
+		dropped := false
+		for _, other := range targets {
+			if within(d.referenced, other) {
+				dropped = true
+			}
+		}
+		if !dropped {
+			return errorf(CodeDependentObjectsStillExist, "cannot drop %s because %s requires it",
+				c.describe(t), c.describe(d.referenced)).
+				withHint("You can drop %s instead.", c.describe(d.referenced))
+		}
+	}
+	return nil
+}
+
+// DependsOn returns the objects that obj, or one of its columns,
+// constraints or column defaults, directly depends on, in the order the
+// dependencies were recorded. obj and the results are *Relation, *Column,
+// *Constraint, *Type or *Function values; a *Column result stands for the
+// column itself, not its default.
+func (c *Catalog) DependsOn(obj any) []any {
+	a := addr(obj)
+	var out []any
+	seen := make(map[any]bool)
+	for _, d := range c.deps {
+		if within(d.dependent, a) && !within(d.referenced, a) && !seen[d.referenced.obj] {
+			seen[d.referenced.obj] = true
+			out = append(out, d.referenced.obj)
+		}
+	}
+	return out
+}
+
+// dependentsOf returns the dependencies on a or any of its sub-objects,
+// including the members of a schema.
+func (c *Catalog) dependentsOf(a address) []*dependency {
+	var out []*dependency
+	for _, d := range c.deps {
+		if within(d.referenced, a) && !within(d.dependent, a) {
+			out = append(out, d)
+		}
+	}
+	if s, ok := a.obj.(*Schema); ok {
+		for _, r := range s.Relations() {
+			if r.Index == nil && !c.ownedByColumn(r) {
+				out = append(out, &dependency{dependent: addr(r), referenced: a, kind: depNormal})
+			}
+		}
+		for _, t := range s.Types() {
+			out = append(out, &dependency{dependent: addr(t), referenced: a, kind: depNormal})
+		}
+		for _, f := range s.Functions() {
+			out = append(out, &dependency{dependent: addr(f), referenced: a, kind: depNormal})
+		}
+	}
+	return out
+}
+
+// ownedByColumn reports whether r is a sequence owned by a column of a
+// table; such sequences are dropped along with their table.
+func (c *Catalog) ownedByColumn(r *Relation) bool {
+	return r.Sequence != nil && r.Sequence.OwnedBy != nil
+}
+
+// removeObject removes a single object from the catalog.
+func (c *Catalog) removeObject(a address) {
+	switch o := a.obj.(type) {
+	case *Schema:
+		c.removeSchema(o)
+	case *Relation:
+		if c.LookupRelation(o.Schema.Name, o.Name) == o {
+			c.removeRelation(o)
+		}
+	case *Column:
+		if a.isDefault {
+			set(c, &o.Default, nil)
+			return
+		}
+		if r := o.Relation; r != nil {
+			for i, col := range r.Columns {
+				if col == o {
+					set(c, &r.Columns, without(r.Columns, i))
+					break
+				}
+			}
+		}
+	case *Constraint:
+		if r := o.Relation; r != nil {
+			for i, con := range r.Constraints {
+				if con == o {
+					set(c, &r.Constraints, without(r.Constraints, i))
+					break
+				}
+			}
+		}
+		if t := o.Domain; t != nil {
+			for i, con := range t.Constraints {
+				if con == o {
+					set(c, &t.Constraints, without(t.Constraints, i))
+					break
+				}
+			}
+		}
+	case *Type:
+		if o.Schema.types[o.Name] == o {
+			c.removeType(o)
+		}
+	case *Function:
+		c.removeFunction(o)
+	}
+}
+
+// describe returns an object description in the style of
+// getObjectDescription, e.g. "column a of table t".
+func (c *Catalog) describe(a address) string {
+	switch o := a.obj.(type) {
+	case *Schema:
+		return "schema " + o.Name
+	case *Relation:
+		return o.Kind.String() + " " + c.displayName(o.Schema, o.Name)
+	case *Column:
+		if o.Relation == nil {
+			return "column " + o.Name
+		}
+		desc := fmt.Sprintf("column %s of %s", o.Name, c.describe(addr(o.Relation)))
+		if a.isDefault {
+			desc = "default value for " + desc
+		}
+		return desc
+	case *Constraint:
+		if o.Domain != nil {
+			return fmt.Sprintf("constraint %s on %s", o.Name, c.describe(addr(o.Domain)))
+		}
+		return fmt.Sprintf("constraint %s on %s", o.Name, c.describe(addr(o.Relation)))
+	case *Type:
+		if o.Kind == TypeKindDomain {
+			return "type " + c.displayName(o.Schema, o.Name)
+		}
+		return "type " + c.displayName(o.Schema, o.Name)
+	case *Function:
+		kind := "function"
+		if o.Kind == FuncKindProcedure {
+			kind = "procedure"
+		}
+		return kind + " " + c.displayName(o.Schema, o.Name) + "(" + formatArgTypes(o.ArgTypes(), ",") + ")"
+	}
+	return "object"
+}
+
+// displayName qualifies name with its schema unless the schema is on the
+// search path.
+func (c *Catalog) displayName(s *Schema, name string) string {
+	if c.visible(s) {
+		return name
+	}
+	return s.Name + "." + name
+}
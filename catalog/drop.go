@@ -0,0 +1,210 @@
+package catalog
+
+import (
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// dropRelationMessages holds the messages DROP uses for each relation
+// object type, from dropmsgstringarray in tablecmds.c.
+var dropRelationMessages = map[nodes.ObjectType]struct {
+	code, noun, hint string
+}{
+	nodes.OBJECT_TABLE:         {CodeUndefinedTable, "table", "Use DROP TABLE to remove a table."},
+	nodes.OBJECT_SEQUENCE:      {CodeUndefinedTable, "sequence", "Use DROP SEQUENCE to remove a sequence."},
+	nodes.OBJECT_VIEW:          {CodeUndefinedTable, "view", "Use DROP VIEW to remove a view."},
+	nodes.OBJECT_MATVIEW:       {CodeUndefinedTable, "materialized view", "Use DROP MATERIALIZED VIEW to remove a materialized view."},
+	nodes.OBJECT_INDEX:         {CodeUndefinedObject, "index", "Use DROP INDEX to remove an index."},
+	nodes.OBJECT_FOREIGN_TABLE: {CodeUndefinedObject, "foreign table", "Use DROP FOREIGN TABLE to remove a foreign table."},
+}
+
+// relKindObjectType maps a relation kind to the object type DROP uses for
+// it.
+func relKindObjectType(k RelKind) nodes.ObjectType {
+	switch k {
+	case RelKindSequence:
+		return nodes.OBJECT_SEQUENCE
+	case RelKindView:
+		return nodes.OBJECT_VIEW
+	case RelKindMatView:
+		return nodes.OBJECT_MATVIEW
+	case RelKindIndex, RelKindPartitionedIndex:
+		return nodes.OBJECT_INDEX
+	case RelKindForeignTable:
+		return nodes.OBJECT_FOREIGN_TABLE
+	}
+	return nodes.OBJECT_TABLE
+}
+
+// drop implements DROP for the modelled object types, following
+// RemoveRelations and RemoveObjects. All named objects are resolved first
+// and then dropped together.
+func (c *Catalog) drop(n *nodes.DropStmt) error {
+	objType := nodes.ObjectType(n.RemoveType)
+	var targets []address
+	for _, obj := range listItems(n.Objects) {
+		a, err := c.dropTarget(objType, obj, n.MissingOk)
+		if err != nil {
+			return err
+		}
+		if a != nil {
+			targets = append(targets, *a)
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+	return c.performDeletion(targets, nodes.DropBehavior(n.Behavior) == nodes.DROP_CASCADE)
+}
+
+// dropTarget resolves one object named in a DROP statement. It returns
+// nil if the object is missing and missingOk is set, or if the object
+// type is not modelled.
+func (c *Catalog) dropTarget(objType nodes.ObjectType, obj nodes.Node, missingOk bool) (*address, error) {
+	switch objType {
+	case nodes.OBJECT_TABLE, nodes.OBJECT_VIEW, nodes.OBJECT_MATVIEW, nodes.OBJECT_INDEX,
+		nodes.OBJECT_SEQUENCE, nodes.OBJECT_FOREIGN_TABLE:
+		rel, err := c.dropRelationTarget(objType, stringList(asList(obj)), missingOk)
+		if rel == nil {
+			return nil, err
+		}
+		a := addr(rel)
+		return &a, nil
+
+	case nodes.OBJECT_SCHEMA:
+		name := objectName(obj)
+		s := c.schemas[name]
+		if s == nil {
+			if missingOk {
+				c.notice("schema %q does not exist, skipping", name)
+				return nil, nil
+			}
+			return nil, errorf(CodeInvalidSchemaName, "schema %q does not exist", name)
+		}
+		if name == "pg_catalog" {
+			return nil, errorf(CodeDependentObjectsStillExist, "cannot drop schema %s because it is required by the database system", name)
+		}
+		a := addr(s)
+		return &a, nil
+
+	case nodes.OBJECT_TYPE, nodes.OBJECT_DOMAIN:
+		names := asList(obj)
+		if tn, ok := obj.(*nodes.TypeName); ok {
+			names = tn.Names
+		}
+		t, err := c.dropTypeTarget(objType, names, missingOk)
+		if t == nil {
+			return nil, err
+		}
+		a := addr(t)
+		return &a, nil
+
+	case nodes.OBJECT_FUNCTION, nodes.OBJECT_PROCEDURE, nodes.OBJECT_AGGREGATE, nodes.OBJECT_ROUTINE:
+		f, err := c.lookupRoutine(obj, objType)
+		if err != nil {
+			if missingOk {
+				if e, ok := err.(*Error); ok && (e.Code == CodeUndefinedFunction || e.Code == CodeUndefinedObject || e.Code == CodeInvalidSchemaName) {
+					c.notice("%s", routineSkipMessage(objType, obj, e))
+					return nil, nil
+				}
+			}
+			return nil, err
+		}
+		if objType == nodes.OBJECT_FUNCTION && f.Kind == FuncKindAggregate {
+			return nil, errorf(CodeWrongObjectType, "%q is an aggregate function", f.Name).
+				withHint("Use DROP AGGREGATE to drop aggregate functions.")
+		}
+		a := addr(f)
+		return &a, nil
+	}
+	return nil, nil
+}
+
+// dropRelationTarget resolves a relation named in DROP TABLE, DROP VIEW
+// and so on, reporting DropErrorMsgNonExistent and DropErrorMsgWrongType
+// errors.
+func (c *Catalog) dropRelationTarget(objType nodes.ObjectType, names []string, missingOk bool) (*Relation, error) {
+	rv, err := rangeVarFromNames(names)
+	if err != nil {
+		return nil, err
+	}
+	msgs := dropRelationMessages[objType]
+	if rv.Schemaname != "" && c.schemas[rv.Schemaname] == nil {
+		if missingOk {
+			c.notice("schema %q does not exist, skipping", rv.Schemaname)
+			return nil, nil
+		}
+		return nil, errorf(CodeInvalidSchemaName, "schema %q does not exist", rv.Schemaname)
+	}
+	rel := c.LookupRelation(rv.Schemaname, rv.Relname)
+	if rel == nil {
+		if missingOk {
+			c.notice("%s %q does not exist, skipping", msgs.noun, rv.Relname)
+			return nil, nil
+		}
+		return nil, errorf(msgs.code, "%s %q does not exist", msgs.noun, rv.Relname)
+	}
+	if !relationKindMatches(rel, objType) {
+		actual := dropRelationMessages[relKindObjectType(rel.Kind)]
+		return nil, errorf(CodeWrongObjectType, "%q is not %s", rel.Name, relationKindNouns[objType]).
+			withHint("%s", actual.hint)
+	}
+	return rel, nil
+}
+
+// dropTypeTarget resolves a type named in DROP TYPE or DROP DOMAIN.
+func (c *Catalog) dropTypeTarget(objType nodes.ObjectType, names *nodes.List, missingOk bool) (*Type, error) {
+	schema, name, err := qualifiedName(names)
+	if err != nil {
+		return nil, err
+	}
+	full := nameListString(stringList(names))
+	if schema != "" && c.schemas[schema] == nil {
+		if missingOk {
+			c.notice("schema %q does not exist, skipping", schema)
+			return nil, nil
+		}
+		return nil, errorf(CodeInvalidSchemaName, "schema %q does not exist", schema)
+	}
+	t := c.LookupType(schema, name)
+	if t == nil {
+		if rel := c.LookupRelation(schema, name); rel != nil && rel.hasColumns() {
+			return nil, errorf(CodeDependentObjectsStillExist, "cannot drop type %s because %s requires it",
+				c.displayName(rel.Schema, rel.Name), c.describe(addr(rel))).
+				withHint("You can drop %s instead.", c.describe(addr(rel)))
+		}
+		if missingOk {
+			c.notice("type %q does not exist, skipping", full)
+			return nil, nil
+		}
+		return nil, errorf(CodeUndefinedObject, "type %q does not exist", full)
+	}
+	if objType == nodes.OBJECT_DOMAIN && t.Kind != TypeKindDomain {
+		return nil, errorf(CodeWrongObjectType, "%q is not a domain", t.Name)
+	}
+	return t, nil
+}
+
+// routineSkipMessage builds the NOTICE for DROP FUNCTION IF EXISTS of a
+// missing routine, following does_not_exist_skipping: argument types are
+// shown as written.
+func routineSkipMessage(objType nodes.ObjectType, obj nodes.Node, err *Error) string {
+	if err.Code != CodeUndefinedFunction {
+		return strings.TrimSuffix(err.Message, ".") + ", skipping"
+	}
+	var names *nodes.List
+	var args []string
+	switch o := obj.(type) {
+	case *nodes.ObjectWithArgs:
+		names = o.Objname
+		for _, item := range listItems(o.Objargs) {
+			if tn, ok := item.(*nodes.TypeName); ok {
+				args = append(args, typeNameToString(tn))
+			}
+		}
+	case *nodes.List:
+		names = o
+	}
+	return routineNoun(objType) + " " + nameListString(stringList(names)) + "(" + strings.Join(args, ",") + ") does not exist, skipping"
+}
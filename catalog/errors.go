@@ -0,0 +1,60 @@
+package catalog
+
+import "fmt"
+
+// SQLSTATE codes reported by the catalog. The names follow the ERRCODE_*
+// macros in PostgreSQL's errcodes.txt.
+const (
+	CodeFeatureNotSupported        = "0A000"
+	CodeInvalidGrantOperation      = "0LP01"
+	CodeInvalidParameterValue      = "22023"
+	CodeDependentObjectsStillExist = "2BP01"
+	CodeInvalidSchemaName          = "3F000"
+	CodeInsufficientPrivilege      = "42501"
+	CodeSyntaxError                = "42601"
+	CodeInvalidColumnReference     = "42P10"
+	CodeInvalidForeignKey          = "42830"
+	CodeInvalidTableDefinition     = "42P16"
+	CodeInvalidFunctionDefinition  = "42P13"
+	CodeInvalidObjectDefinition    = "42P17"
+	CodeWrongObjectType            = "42809"
+	CodeUndefinedColumn            = "42703"
+	CodeUndefinedFunction          = "42883"
+	CodeUndefinedTable             = "42P01"
+	CodeUndefinedObject            = "42704"
+	CodeDuplicateColumn            = "42701"
+	CodeDuplicateFunction          = "42723"
+	CodeDuplicateSchema            = "42P06"
+	CodeDuplicateTable             = "42P07"
+	CodeDuplicateObject            = "42710"
+	CodeAmbiguousFunction          = "42725"
+	CodeObjectInUse                = "55006"
+)
+
+// Error is an error raised while applying DDL to a catalog. Its fields
+// mirror the parts of a PostgreSQL error report, so that callers can show
+// the same message the server would.
+type Error struct {
+	Code    string // SQLSTATE
+	Message string // primary message
+	Detail  string // optional detail
+	Hint    string // optional hint
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func errorf(code, format string, args ...any) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *Error) withDetail(format string, args ...any) *Error {
+	e.Detail = fmt.Sprintf(format, args...)
+	return e
+}
+
+func (e *Error) withHint(format string, args ...any) *Error {
+	e.Hint = fmt.Sprintf(format, args...)
+	return e
+}
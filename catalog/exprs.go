@@ -0,0 +1,138 @@
+package catalog
+
+import (
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// columnRefNames returns the distinct column names referenced by an
+// expression, in order of first appearance. Qualified references
+// contribute their last field; references inside sub-selects are
+// ignored, since they may name columns of other relations.
+func columnRefNames(expr nodes.Node) []string {
+	var out []string
+	seen := make(map[string]bool)
+	nodes.Walk(expr, func(n nodes.Node) bool {
+		switch n := n.(type) {
+		case *nodes.SubLink:
+			return false
+		case *nodes.ColumnRef:
+			fields := listItems(n.Fields)
+			if len(fields) == 0 {
+				return false
+			}
+			if s, ok := fields[len(fields)-1].(*nodes.String); ok && !seen[s.Sval] {
+				seen[s.Sval] = true
+				out = append(out, s.Sval)
+			}
+			return false
+		}
+		return true
+	})
+	return out
+}
+
+// nextvalSequences returns the sequence names passed as string literals
+// to nextval() in an expression, as in the defaults of serial columns.
+func nextvalSequences(expr nodes.Node) []string {
+	var out []string
+	nodes.Walk(expr, func(n nodes.Node) bool {
+		fc, ok := n.(*nodes.FuncCall)
+		if !ok {
+			return true
+		}
+		names := stringList(fc.Funcname)
+		args := listItems(fc.Args)
+		if len(names) == 0 || names[len(names)-1] != "nextval" || len(args) != 1 {
+			return true
+		}
+		arg := args[0]
+		if tc, ok := arg.(*nodes.TypeCast); ok {
+			arg = tc.Arg
+		}
+		if ac, ok := arg.(*nodes.A_Const); ok {
+			if s, ok := ac.Val.(*nodes.String); ok {
+				out = append(out, s.Sval)
+			}
+		}
+		return true
+	})
+	return out
+}
+
+// splitQualifiedString splits a possibly-qualified name written as a
+// string, such as the argument of nextval('s.seq'), into schema and name.
+// Unquoted parts are folded to lower case as the regclass input function
+// does.
+func splitQualifiedString(s string) (string, string) {
+	var parts []string
+	for s != "" {
+		var part string
+		if strings.HasPrefix(s, `"`) {
+			i := 1
+			for i < len(s) {
+				if s[i] == '"' {
+					if i+1 < len(s) && s[i+1] == '"' {
+						part += `"`
+						i += 2
+						continue
+					}
+					break
+				}
+				part += s[i : i+1]
+				i++
+			}
+			s = strings.TrimPrefix(s[min(i+1, len(s)):], ".")
+		} else {
+			part, s, _ = strings.Cut(s, ".")
+			part = strings.ToLower(part)
+		}
+		parts = append(parts, part)
+	}
+	switch len(parts) {
+	case 0:
+		return "", ""
+	case 1:
+		return "", parts[0]
+	}
+	return parts[len(parts)-2], parts[len(parts)-1]
+}
+
+// defElemInt returns the integer value of an option, accepting the Float
+// nodes the grammar produces for literals that overflow int32.
+func defElemInt(d *nodes.DefElem) (int64, bool) {
+	switch v := d.Arg.(type) {
+	case *nodes.Integer:
+		return v.Ival, true
+	case *nodes.Float:
+		var n int64
+		s := strings.ReplaceAll(v.Fval, "_", "")
+		neg := strings.HasPrefix(s, "-")
+		for _, ch := range strings.TrimPrefix(s, "-") {
+			if ch < '0' || ch > '9' {
+				return 0, false
+			}
+			n = n*10 + int64(ch-'0')
+		}
+		if neg {
+			n = -n
+		}
+		return n, true
+	}
+	return 0, false
+}
+
+// defElemString returns the string value of an option given as a String,
+// an identifier or a single-element name list.
+func defElemString(d *nodes.DefElem) string {
+	switch v := d.Arg.(type) {
+	case *nodes.String:
+		return v.Sval
+	case *nodes.TypeName:
+		return nameListString(stringList(v.Names))
+	case *nodes.List:
+		return nameListString(stringList(v))
+	}
+	return ""
+}
@@ -0,0 +1,141 @@
+package catalog
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// NAMEDATALEN is the size of PostgreSQL's name type, including the
+// terminating NUL; identifiers are at most NAMEDATALEN-1 bytes.
+const NAMEDATALEN = 64
+
+// makeObjectName builds "name1_name2_label", truncating name1 and name2 so
+// the result fits in NAMEDATALEN-1 bytes, as makeObjectName in indexcmds.c
+// does. name2 and label may be empty.
+func makeObjectName(name1, name2, label string) string {
+	overhead := 0
+	if name2 != "" {
+		overhead++
+	}
+	if label != "" {
+		overhead += len(label) + 1
+	}
+	avail := NAMEDATALEN - 1 - overhead
+	n1, n2 := len(name1), len(name2)
+	for n1+n2 > avail {
+		if n1 > n2 {
+			n1--
+		} else {
+			n2--
+		}
+	}
+	s := clipName(name1, n1)
+	if name2 != "" {
+		s += "_" + clipName(name2, n2)
+	}
+	if label != "" {
+		s += "_" + label
+	}
+	return s
+}
+
+// clipName truncates s to at most n bytes without splitting a UTF-8
+// sequence, like pg_mbcliplen.
+func clipName(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// chooseName picks the first of name1_name2_label, name1_name2_label1, ...
+// for which taken returns false, following ChooseRelationName.
+func chooseName(name1, name2, label string, taken func(string) bool) string {
+	name := makeObjectName(name1, name2, label)
+	for pass := 1; taken(name); pass++ {
+		name = makeObjectName(name1, name2, fmt.Sprintf("%s%d", label, pass))
+	}
+	return name
+}
+
+// chooseRelationName picks a relation name not used in s or in pending.
+func chooseRelationName(s *Schema, name1, name2, label string, pending map[string]bool) string {
+	return chooseName(name1, name2, label, func(n string) bool {
+		return s.relations[n] != nil || pending[n]
+	})
+}
+
+// chooseConstraintName picks a constraint name not used by any
+// constraint in s or in pending, following ChooseConstraintName.
+func chooseConstraintName(s *Schema, name1, name2, label string, pending map[string]bool) string {
+	return chooseName(name1, name2, label, func(n string) bool {
+		return pending[n] || constraintNameTaken(s, n)
+	})
+}
+
+func constraintNameTaken(s *Schema, name string) bool {
+	for _, r := range s.relations {
+		if r.Constraint(name) != nil {
+			return true
+		}
+	}
+	for _, t := range s.types {
+		for _, con := range t.Constraints {
+			if con.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// joinColumnNames joins column names with underscores, stopping once the
+// result is long enough to fill a name, like ChooseIndexNameAddition.
+func joinColumnNames(cols []string) string {
+	var sb strings.Builder
+	for _, name := range cols {
+		if sb.Len() > 0 {
+			sb.WriteByte('_')
+		}
+		sb.WriteString(name)
+		if sb.Len() >= NAMEDATALEN {
+			break
+		}
+	}
+	return sb.String()
+}
+
+// indexColumnNames returns the names ChooseIndexColumnNames derives for
+// the key columns of an index.
+func indexColumnNames(params []*nodes.IndexElem) []string {
+	var out []string
+	for _, p := range params {
+		switch {
+		case p.Indexcolname != "":
+			out = append(out, p.Indexcolname)
+		case p.Name != "":
+			out = append(out, p.Name)
+		default:
+			out = append(out, figureIndexColname(p.Expr))
+		}
+	}
+	return out
+}
+
+// figureIndexColname names an index expression column after its function
+// if it is a plain function call, and "expr" otherwise.
+func figureIndexColname(expr nodes.Node) string {
+	if fc, ok := expr.(*nodes.FuncCall); ok {
+		names := stringList(fc.Funcname)
+		if len(names) > 0 {
+			return names[len(names)-1]
+		}
+	}
+	return "expr"
+}
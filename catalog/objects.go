@@ -0,0 +1,410 @@
+package catalog
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// Schema is a namespace holding relations, types and functions
+// (pg_namespace).
+type Schema struct {
+	Name    string
+	Owner   string
+	Comment string
+
+	relations map[string]*Relation
+	types     map[string]*Type
+	functions map[string][]*Function
+}
+
+func newSchema(name string) *Schema {
+	return &Schema{
+		Name:      name,
+		relations: make(map[string]*Relation),
+		types:     make(map[string]*Type),
+		functions: make(map[string][]*Function),
+	}
+}
+
+// Relation returns the relation with the given name, or nil.
+func (s *Schema) Relation(name string) *Relation {
+	return s.relations[name]
+}
+
+// Relations returns every relation in the schema, sorted by name.
+func (s *Schema) Relations() []*Relation {
+	out := make([]*Relation, 0, len(s.relations))
+	for _, r := range s.relations {
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Tables returns the ordinary, partitioned and foreign tables in the
+// schema, sorted by name.
+func (s *Schema) Tables() []*Relation {
+	var out []*Relation
+	for _, r := range s.Relations() {
+		if r.IsTable() {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// Type returns the user-defined type with the given name, or nil.
+func (s *Schema) Type(name string) *Type {
+	return s.types[name]
+}
+
+// Types returns the user-defined types in the schema, sorted by name.
+func (s *Schema) Types() []*Type {
+	out := make([]*Type, 0, len(s.types))
+	for _, t := range s.types {
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Functions returns the functions, procedures and aggregates in the
+// schema, sorted by signature.
+func (s *Schema) Functions() []*Function {
+	var out []*Function
+	for _, fs := range s.functions {
+		out = append(out, fs...)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Signature() < out[j].Signature() })
+	return out
+}
+
+// FunctionsNamed returns the overloads of the named function.
+func (s *Schema) FunctionsNamed(name string) []*Function {
+	return s.functions[name]
+}
+
+// RelKind identifies the kind of a relation, using pg_class.relkind codes.
+type RelKind byte
+
+const (
+	RelKindTable            RelKind = 'r'
+	RelKindIndex            RelKind = 'i'
+	RelKindSequence         RelKind = 'S'
+	RelKindView             RelKind = 'v'
+	RelKindMatView          RelKind = 'm'
+	RelKindForeignTable     RelKind = 'f'
+	RelKindPartitionedTable RelKind = 'p'
+	RelKindPartitionedIndex RelKind = 'I'
+)
+
+// String returns the noun PostgreSQL uses for the relation kind in
+// messages, e.g. "materialized view".
+func (k RelKind) String() string {
+	switch k {
+	case RelKindTable, RelKindPartitionedTable:
+		return "table"
+	case RelKindIndex, RelKindPartitionedIndex:
+		return "index"
+	case RelKindSequence:
+		return "sequence"
+	case RelKindView:
+		return "view"
+	case RelKindMatView:
+		return "materialized view"
+	case RelKindForeignTable:
+		return "foreign table"
+	}
+	return "relation"
+}
+
+// Relation is a table, view, materialized view, sequence or index
+// (pg_class).
+type Relation struct {
+	Schema      *Schema
+	Name        string
+	Kind        RelKind
+	Persistence byte // RELPERSISTENCE_*
+	Owner       string
+	Comment     string
+	Options     *nodes.List // storage parameters (WITH clause), list of DefElem
+
+	Columns     []*Column
+	Constraints []*Constraint
+
+	Inherits       []*Relation          // parents, in INHERITS order
+	PartitionKey   *nodes.PartitionSpec // PARTITION BY, for partitioned tables
+	PartitionOf    *Relation            // parent, for partitions
+	PartitionBound *nodes.PartitionBoundSpec
+
+	// Foreign tables.
+	Server         string      // foreign server name
+	ForeignOptions *nodes.List // OPTIONS clause, list of DefElem
+
+	// Views and materialized views.
+	Query       nodes.Node            // defining SELECT, as a raw parse tree
+	CheckOption nodes.ViewCheckOption // WITH [LOCAL|CASCADED] CHECK OPTION
+
+	Index    *Index    // set for indexes
+	Sequence *Sequence // set for sequences
+}
+
+// IsTable reports whether the relation is an ordinary, partitioned or
+// foreign table.
+func (r *Relation) IsTable() bool {
+	return r.Kind == RelKindTable || r.Kind == RelKindPartitionedTable || r.Kind == RelKindForeignTable
+}
+
+// hasColumns reports whether the relation kind has user-visible columns.
+func (r *Relation) hasColumns() bool {
+	return r.Kind != RelKindIndex && r.Kind != RelKindPartitionedIndex && r.Kind != RelKindSequence
+}
+
+// QualifiedName returns the schema-qualified, quoted name of the relation.
+func (r *Relation) QualifiedName() string {
+	return QuoteIdentifier(r.Schema.Name) + "." + QuoteIdentifier(r.Name)
+}
+
+// Column returns the named column, or nil.
+func (r *Relation) Column(name string) *Column {
+	for _, c := range r.Columns {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// Constraint returns the named constraint, or nil.
+func (r *Relation) Constraint(name string) *Constraint {
+	for _, c := range r.Constraints {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// PrimaryKey returns the primary key constraint, or nil.
+func (r *Relation) PrimaryKey() *Constraint {
+	for _, c := range r.Constraints {
+		if c.Type == nodes.CONSTR_PRIMARY {
+			return c
+		}
+	}
+	return nil
+}
+
+// Indexes returns the indexes on the relation, sorted by name.
+func (r *Relation) Indexes() []*Relation {
+	var out []*Relation
+	for _, rel := range r.Schema.Relations() {
+		if rel.Index != nil && rel.Index.Table == r {
+			out = append(out, rel)
+		}
+	}
+	return out
+}
+
+// Column is a column of a relation or an attribute of a composite type
+// (pg_attribute).
+type Column struct {
+	Relation  *Relation // owning relation; nil for composite type attributes
+	Name      string
+	Type      *nodes.TypeName // declared type; nil if it could not be determined
+	NotNull   bool
+	Default   nodes.Node // DEFAULT expression, or generation expression if Generated is set
+	Identity  byte       // ATTRIBUTE_IDENTITY_ALWAYS ('a'), BY DEFAULT ('d') or 0
+	Generated byte       // ATTRIBUTE_GENERATED_STORED ('s') or 0
+	Collation string
+	Comment   string
+
+	IsLocal  bool // column has a local definition
+	Inhcount int  // number of parents the column is inherited from
+}
+
+// TypeString returns the column type in format_type form, such as
+// "character varying(20)", or "" if unknown.
+func (c *Column) TypeString() string {
+	if c.Type == nil {
+		return ""
+	}
+	return FormatTypeName(c.Type)
+}
+
+// Constraint is a table or domain constraint (pg_constraint).
+type Constraint struct {
+	Relation *Relation // owning table; nil for domain constraints
+	Domain   *Type     // owning domain; nil for table constraints
+	Name     string
+	Type     nodes.ConstrType
+
+	Columns []string   // constrained columns (keys, FK columns)
+	Expr    nodes.Node // CHECK expression
+
+	RefTable    *Relation // referenced table, for foreign keys
+	RefColumns  []string  // referenced columns, for foreign keys
+	FkMatchType byte
+	FkUpdAction byte
+	FkDelAction byte
+
+	Deferrable   bool
+	InitDeferred bool
+	NoInherit    bool
+	Validated    bool
+
+	// Index is the index enforcing a primary key, unique or exclusion
+	// constraint, or the referenced unique index of a foreign key.
+	Index *Relation
+
+	Def     *nodes.Constraint // original definition
+	Comment string
+}
+
+// Index describes an index relation (pg_index).
+type Index struct {
+	Table            *Relation
+	Unique           bool
+	Primary          bool
+	IsConstraint     bool
+	NullsNotDistinct bool
+	AccessMethod     string
+	Params           []*nodes.IndexElem
+	Including        []*nodes.IndexElem
+	Where            nodes.Node
+}
+
+// Columns returns the names of the plain column keys of the index; keys
+// that are expressions are returned as "".
+func (i *Index) Columns() []string {
+	out := make([]string, len(i.Params))
+	for n, p := range i.Params {
+		out[n] = p.Name
+	}
+	return out
+}
+
+// Sequence describes a sequence relation (pg_sequence).
+type Sequence struct {
+	Type      *nodes.TypeName // AS data type
+	Start     int64
+	Increment int64
+	MinValue  int64
+	MaxValue  int64
+	Cache     int64
+	Cycle     bool
+	OwnedBy   *Column // column the sequence is OWNED BY, or nil
+	Identity  bool    // sequence backs an identity column
+}
+
+// TypeKind identifies the kind of a user-defined type, using
+// pg_type.typtype codes.
+type TypeKind byte
+
+const (
+	TypeKindBase      TypeKind = 'b'
+	TypeKindComposite TypeKind = 'c'
+	TypeKindDomain    TypeKind = 'd'
+	TypeKindEnum      TypeKind = 'e'
+	TypeKindRange     TypeKind = 'r'
+	TypeKindShell     TypeKind = 'p'
+)
+
+// Type is a user-defined type (pg_type).
+type Type struct {
+	Schema  *Schema
+	Name    string
+	Kind    TypeKind
+	Owner   string
+	Comment string
+
+	EnumLabels []string // enums
+
+	// Domains.
+	BaseType    *nodes.TypeName
+	NotNull     bool
+	Default     nodes.Node
+	Collation   string
+	Constraints []*Constraint
+
+	Attributes []*Column // composite types
+
+	Subtype    *nodes.TypeName // ranges
+	Definition *nodes.List     // CREATE TYPE / range parameters, list of DefElem
+}
+
+// QualifiedName returns the schema-qualified, quoted name of the type.
+func (t *Type) QualifiedName() string {
+	return QuoteIdentifier(t.Schema.Name) + "." + QuoteIdentifier(t.Name)
+}
+
+// FuncKind identifies the kind of a routine, using pg_proc.prokind codes.
+type FuncKind byte
+
+const (
+	FuncKindFunction  FuncKind = 'f'
+	FuncKindProcedure FuncKind = 'p'
+	FuncKindAggregate FuncKind = 'a'
+	FuncKindWindow    FuncKind = 'w'
+)
+
+// FuncParam is a declared parameter of a function.
+type FuncParam struct {
+	Name    string
+	Type    *nodes.TypeName
+	Mode    nodes.FunctionParameterMode
+	Default nodes.Node
+}
+
+// isInput reports whether the parameter is part of the call signature.
+func (p *FuncParam) isInput() bool {
+	switch p.Mode {
+	case nodes.FUNC_PARAM_OUT, nodes.FUNC_PARAM_TABLE:
+		return false
+	}
+	return true
+}
+
+// Function is a function, procedure or aggregate (pg_proc).
+type Function struct {
+	Schema     *Schema
+	Name       string
+	Kind       FuncKind
+	Params     []*FuncParam
+	ReturnType *nodes.TypeName // nil for procedures
+	ReturnsSet bool
+	Language   string
+	Body       string      // AS 'definition'
+	SQLBody    nodes.Node  // BEGIN ATOMIC / RETURN body
+	Options    *nodes.List // other options, or the CREATE AGGREGATE definition; list of DefElem
+	Owner      string
+	Comment    string
+}
+
+// ArgTypes returns the types of the input parameters, which make up the
+// function's identity.
+func (f *Function) ArgTypes() []*nodes.TypeName {
+	var out []*nodes.TypeName
+	for _, p := range f.Params {
+		if p.isInput() {
+			out = append(out, p.Type)
+		}
+	}
+	return out
+}
+
+// Signature returns the function name and input argument types in the
+// form used by pg_proc's regprocedure output, e.g. "f(integer,text)".
+func (f *Function) Signature() string {
+	return f.Name + "(" + formatArgTypes(f.ArgTypes(), ",") + ")"
+}
+
+func formatArgTypes(types []*nodes.TypeName, sep string) string {
+	parts := make([]string, len(types))
+	for i, t := range types {
+		parts[i] = FormatTypeName(t)
+	}
+	return strings.Join(parts, sep)
+}
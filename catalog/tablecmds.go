@@ -0,0 +1,1746 @@
+package catalog
+
+import (
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// CREATE TABLE ... LIKE option bits (TableLikeOption).
+const (
+	likeComments    = 1 << 0
+	likeCompression = 1 << 1
+	likeConstraints = 1 << 2
+	likeDefaults    = 1 << 3
+	likeGenerated   = 1 << 4
+	likeIdentity    = 1 << 5
+	likeIndexes     = 1 << 6
+	likeStatistics  = 1 << 7
+	likeStorage     = 1 << 8
+)
+
+// tableBuilder collects the pieces of a relation being created or altered
+// before they are committed to the catalog.
+type tableBuilder struct {
+	c   *Catalog
+	rel *Relation
+
+	// constraints and indexes still to be created, in declaration order.
+	constraints []*nodes.Constraint
+	likeIndexes []*Relation
+
+	// names chosen so far in this statement.
+	pendingRelNames map[string]bool
+	pendingConNames map[string]bool
+}
+
+func (c *Catalog) newTableBuilder(rel *Relation) *tableBuilder {
+	return &tableBuilder{
+		c:               c,
+		rel:             rel,
+		pendingRelNames: make(map[string]bool),
+		pendingConNames: make(map[string]bool),
+	}
+}
+
+// relationSchema returns the schema a new relation named by rv goes in.
+func (c *Catalog) relationSchema(rv *nodes.RangeVar) (*Schema, error) {
+	if rv.Relpersistence == nodes.RELPERSISTENCE_TEMP {
+		if rv.Schemaname != "" && rv.Schemaname != "pg_temp" {
+			return nil, errorf(CodeInvalidTableDefinition, "cannot create temporary relation in non-temporary schema")
+		}
+		return c.tempSchema(), nil
+	}
+	if rv.Schemaname == "pg_temp" {
+		return c.tempSchema(), nil
+	}
+	return c.creationSchema(rv.Schemaname)
+}
+
+// newRelation checks that rv can be created and returns the (not yet
+// added) relation. It returns nil, nil if the relation exists and
+// ifNotExists is set.
+func (c *Catalog) newRelation(rv *nodes.RangeVar, kind RelKind, ifNotExists bool) (*Relation, error) {
+	s, err := c.relationSchema(rv)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkNewRelationName(s, rv.Relname); err != nil {
+		if ifNotExists && s.relations[rv.Relname] != nil {
+			c.notice("relation %q already exists, skipping", rv.Relname)
+			return nil, nil
+		}
+		return nil, err
+	}
+	persistence := rv.Relpersistence
+	if persistence == 0 || s.Name == "pg_temp" {
+		persistence = nodes.RELPERSISTENCE_PERMANENT
+		if s.Name == "pg_temp" {
+			persistence = nodes.RELPERSISTENCE_TEMP
+		}
+	}
+	return &Relation{
+		Schema:      s,
+		Name:        rv.Relname,
+		Kind:        kind,
+		Persistence: persistence,
+		Owner:       c.User,
+	}, nil
+}
+
+func (c *Catalog) createTable(n *nodes.CreateStmt) error {
+	kind := RelKindTable
+	if n.Partspec != nil {
+		kind = RelKindPartitionedTable
+	}
+	rel, err := c.newRelation(n.Relation, kind, n.IfNotExists)
+	if rel == nil {
+		return err
+	}
+	return c.defineRelation(n, rel)
+}
+
+func (c *Catalog) createForeignTable(n *nodes.CreateForeignTableStmt) error {
+	rel, err := c.newRelation(n.Base.Relation, RelKindForeignTable, n.Base.IfNotExists)
+	if rel == nil {
+		return err
+	}
+	rel.Server = n.Servername
+	rel.ForeignOptions = n.Options
+	return c.defineRelation(&n.Base, rel)
+}
+
+// defineRelation implements CREATE TABLE and CREATE FOREIGN TABLE,
+// following DefineRelation and MergeAttributes in tablecmds.c. rel is the
+// new relation, not yet added to its schema.
+func (c *Catalog) defineRelation(n *nodes.CreateStmt, rel *Relation) error {
+	rel.Options = n.Options
+	rel.PartitionKey = n.Partspec
+	b := c.newTableBuilder(rel)
+
+	if bound := n.Partbound; bound != nil {
+		if err := b.inheritPartition(listItems(n.InhRelations), bound); err != nil {
+			return err
+		}
+	} else {
+		for _, item := range listItems(n.InhRelations) {
+			if err := b.inherit(item.(*nodes.RangeVar)); err != nil {
+				return err
+			}
+		}
+	}
+	if n.OfTypename != nil {
+		if err := b.ofType(n.OfTypename); err != nil {
+			return err
+		}
+	}
+	for _, elt := range listItems(n.TableElts) {
+		switch e := elt.(type) {
+		case *nodes.ColumnDef:
+			if err := b.addColumnDef(e, true); err != nil {
+				return err
+			}
+		case *nodes.Constraint:
+			b.constraints = append(b.constraints, e)
+		case *nodes.TableLikeClause:
+			if err := b.like(e); err != nil {
+				return err
+			}
+		}
+	}
+	for _, con := range listItems(n.Constraints) {
+		if con, ok := con.(*nodes.Constraint); ok {
+			b.constraints = append(b.constraints, con)
+		}
+	}
+	if rel.PartitionKey != nil {
+		if err := b.checkPartitionKey(); err != nil {
+			return err
+		}
+	}
+
+	c.addRelation(rel)
+	if err := b.finish(true); err != nil {
+		return err
+	}
+	return nil
+}
+
+// inherit merges the columns and inheritable constraints of an INHERITS
+// parent into the new relation.
+func (b *tableBuilder) inherit(rv *nodes.RangeVar) error {
+	parent, err := b.c.relationFor(rv)
+	if err != nil {
+		return err
+	}
+	switch parent.Kind {
+	case RelKindTable, RelKindForeignTable:
+	case RelKindPartitionedTable:
+		return errorf(CodeWrongObjectType, "cannot inherit from partitioned table %q", parent.Name)
+	default:
+		return errorf(CodeWrongObjectType, "inherited relation %q is not a table or foreign table", parent.Name)
+	}
+	if parent.PartitionOf != nil {
+		return errorf(CodeWrongObjectType, "cannot inherit from partition %q", parent.Name)
+	}
+	for _, p := range b.rel.Inherits {
+		if p == parent {
+			return errorf(CodeDuplicateTable, "relation %q would be inherited from more than once", parent.Name)
+		}
+	}
+	b.rel.Inherits = append(b.rel.Inherits, parent)
+	for _, pc := range parent.Columns {
+		if existing := b.rel.Column(pc.Name); existing != nil {
+			b.c.notice("merging multiple inherited definitions of column %q", pc.Name)
+			if !sameType(existing.Type, pc.Type) {
+				return errorf("42804", "inherited column %q has a type conflict", pc.Name).
+					withDetail("%s versus %s", existing.TypeString(), pc.TypeString())
+			}
+			existing.Inhcount++
+			existing.NotNull = existing.NotNull || pc.NotNull
+			continue
+		}
+		col := inheritedColumn(b.rel, pc)
+		b.rel.Columns = append(b.rel.Columns, col)
+	}
+	for _, con := range parent.Constraints {
+		if con.Type == nodes.CONSTR_CHECK && !con.NoInherit && b.rel.Constraint(con.Name) == nil {
+			b.rel.Constraints = append(b.rel.Constraints, copyConstraint(b.rel, con))
+		}
+	}
+	return nil
+}
+
+// inheritPartition copies the parent's columns into a new partition.
+func (b *tableBuilder) inheritPartition(parents []nodes.Node, bound *nodes.PartitionBoundSpec) error {
+	if len(parents) != 1 {
+		return errorf(CodeSyntaxError, "a partition must have exactly one parent")
+	}
+	parent, err := b.c.relationFor(parents[0].(*nodes.RangeVar))
+	if err != nil {
+		return err
+	}
+	if parent.Kind != RelKindPartitionedTable {
+		return errorf(CodeWrongObjectType, "%q is not partitioned", parent.Name)
+	}
+	b.rel.PartitionOf = parent
+	b.rel.PartitionBound = bound
+	for _, pc := range parent.Columns {
+		b.rel.Columns = append(b.rel.Columns, inheritedColumn(b.rel, pc))
+	}
+	for _, con := range parent.Constraints {
+		if con.Type == nodes.CONSTR_CHECK {
+			b.rel.Constraints = append(b.rel.Constraints, copyConstraint(b.rel, con))
+		}
+	}
+	return nil
+}
+
+func inheritedColumn(rel *Relation, pc *Column) *Column {
+	return &Column{
+		Relation:  rel,
+		Name:      pc.Name,
+		Type:      pc.Type,
+		NotNull:   pc.NotNull,
+		Default:   pc.Default,
+		Generated: pc.Generated,
+		Collation: pc.Collation,
+		Inhcount:  1,
+	}
+}
+
+func copyConstraint(rel *Relation, con *Constraint) *Constraint {
+	cp := *con
+	cp.Relation = rel
+	cp.Domain = nil
+	cp.Comment = ""
+	cp.Columns = append([]string(nil), con.Columns...)
+	return &cp
+}
+
+// ofType creates the columns of a typed table from a composite type.
+func (b *tableBuilder) ofType(tn *nodes.TypeName) error {
+	ref, err := b.c.resolveType(tn)
+	if err != nil {
+		return err
+	}
+	if ref.typ == nil || ref.typ.Kind != TypeKindComposite {
+		return errorf(CodeWrongObjectType, "type %s is not a composite type", FormatTypeName(tn))
+	}
+	for _, attr := range ref.typ.Attributes {
+		b.rel.Columns = append(b.rel.Columns, &Column{
+			Relation:  b.rel,
+			Name:      attr.Name,
+			Type:      attr.Type,
+			Collation: attr.Collation,
+			IsLocal:   true,
+		})
+	}
+	b.c.recordDependency(addr(b.rel), addr(ref.typ), depNormal)
+	return nil
+}
+
+// like copies column definitions (and, depending on the options, defaults,
+// constraints and indexes) from another relation.
+func (b *tableBuilder) like(n *nodes.TableLikeClause) error {
+	src, err := b.c.relationFor(n.Relation)
+	if err != nil {
+		return err
+	}
+	if !src.hasColumns() {
+		return errorf(CodeWrongObjectType, "relation %q is invalid in LIKE clause", src.Name).
+			withDetail("This operation is not supported for %ss.", src.Kind)
+	}
+	for _, sc := range src.Columns {
+		if b.rel.Column(sc.Name) != nil {
+			return errorf(CodeDuplicateColumn, "column %q specified more than once", sc.Name)
+		}
+		col := &Column{
+			Relation: b.rel,
+			Name:     sc.Name,
+			Type:     sc.Type,
+			NotNull:  sc.NotNull,
+			IsLocal:  true,
+		}
+		if n.Options&likeDefaults != 0 && sc.Generated == 0 && sc.Identity == 0 {
+			col.Default = sc.Default
+		}
+		if n.Options&likeGenerated != 0 && sc.Generated != 0 {
+			col.Default, col.Generated = sc.Default, sc.Generated
+		}
+		if n.Options&likeIdentity != 0 && sc.Identity != 0 {
+			col.Identity = sc.Identity
+		}
+		if n.Options&likeComments != 0 {
+			col.Comment = sc.Comment
+		}
+		b.rel.Columns = append(b.rel.Columns, col)
+	}
+	if n.Options&likeConstraints != 0 {
+		for _, con := range src.Constraints {
+			if con.Type == nodes.CONSTR_CHECK {
+				b.constraints = append(b.constraints, &nodes.Constraint{
+					Contype:        nodes.CONSTR_CHECK,
+					Conname:        con.Name,
+					RawExpr:        con.Expr,
+					IsNoInherit:    con.NoInherit,
+					InitiallyValid: true,
+				})
+			}
+		}
+	}
+	if n.Options&likeIndexes != 0 {
+		b.likeIndexes = append(b.likeIndexes, src.Indexes()...)
+	}
+	return nil
+}
+
+// addColumnDef adds a column definition, merging it with an inherited
+// column of the same name. Column constraints other than NOT NULL and
+// DEFAULT become table constraints.
+func (b *tableBuilder) addColumnDef(def *nodes.ColumnDef, creating bool) error {
+	rel := b.rel
+	col := rel.Column(def.Colname)
+	if col != nil && (!creating || col.IsLocal || col.Inhcount == 0) {
+		if creating {
+			return errorf(CodeDuplicateColumn, "column %q specified more than once", def.Colname)
+		}
+		return errorf(CodeDuplicateColumn, "column %q of relation %q already exists", def.Colname, rel.Name)
+	}
+	if col != nil {
+		// Merge with the inherited definition.
+		if def.TypeName != nil && rel.PartitionOf == nil {
+			b.c.notice("merging column %q with inherited definition", def.Colname)
+			if !sameType(col.Type, def.TypeName) {
+				return errorf("42804", "column %q has a type conflict", def.Colname).
+					withDetail("%s versus %s", col.TypeString(), FormatTypeName(def.TypeName))
+			}
+		}
+		col.IsLocal = true
+	} else {
+		if def.TypeName == nil {
+			return errorf(CodeUndefinedColumn, "column %q does not exist", def.Colname)
+		}
+		col = &Column{Relation: rel, Name: def.Colname, IsLocal: true}
+		rel.Columns = append(rel.Columns, col)
+	}
+	if def.TypeName != nil {
+		tn, err := b.columnType(col, def.TypeName)
+		if err != nil {
+			return err
+		}
+		col.Type = tn
+	}
+	if def.CollClause != nil {
+		col.Collation = nameListString(stringList(def.CollClause.Collname))
+	}
+	if def.IsNotNull {
+		col.NotNull = true
+	}
+	if def.RawDefault != nil {
+		col.Default = def.RawDefault
+	}
+	if def.Identity != 0 {
+		col.Identity = def.Identity
+	}
+	return b.columnConstraints(col, def)
+}
+
+// columnType resolves a column's declared type, expanding serial types
+// into an integer column with a sequence default.
+func (b *tableBuilder) columnType(col *Column, tn *nodes.TypeName) (*nodes.TypeName, error) {
+	schema, name := typeNameParts(tn)
+	if base, ok := serialTypes[name]; ok && schema == "" && tn.ArrayBounds == nil && !tn.PctType {
+		seq := b.ownedSequence(col, base, false)
+		col.NotNull = true
+		col.Default = nextvalCall(b.c.displayName(seq.Schema, seq.Name))
+		return builtinTypeName(base), nil
+	}
+	if _, err := b.c.resolveType(tn); err != nil {
+		return nil, err
+	}
+	return tn, nil
+}
+
+// ownedSequence creates the implicit sequence of a serial or identity
+// column.
+func (b *tableBuilder) ownedSequence(col *Column, typ string, identity bool) *Relation {
+	s := b.rel.Schema
+	name := chooseRelationName(s, b.rel.Name, col.Name, "seq", b.pendingRelNames)
+	b.pendingRelNames[name] = true
+	seq := &Relation{
+		Schema:      s,
+		Name:        name,
+		Kind:        RelKindSequence,
+		Persistence: b.rel.Persistence,
+		Owner:       b.rel.Owner,
+		Sequence:    newSequence(builtinTypeName(typ)),
+	}
+	seq.Sequence.OwnedBy = col
+	seq.Sequence.Identity = identity
+	b.c.addRelation(seq)
+	kind := depAuto
+	if identity {
+		kind = depInternal
+	}
+	b.c.recordDependency(addr(seq), addr(col), kind)
+	return seq
+}
+
+// columnConstraints applies the constraints attached to a column
+// definition.
+func (b *tableBuilder) columnConstraints(col *Column, def *nodes.ColumnDef) error {
+	sawNull, sawNotNull, sawDefault := false, def.IsNotNull, def.RawDefault != nil
+	var last *nodes.Constraint
+	for _, item := range listItems(def.Constraints) {
+		con, ok := item.(*nodes.Constraint)
+		if !ok {
+			continue
+		}
+		switch con.Contype {
+		case nodes.CONSTR_NULL:
+			if sawNotNull {
+				return errorf(CodeSyntaxError, "conflicting NULL/NOT NULL declarations for column %q of table %q", col.Name, b.rel.Name)
+			}
+			sawNull = true
+			col.NotNull = false
+		case nodes.CONSTR_NOTNULL:
+			if sawNull {
+				return errorf(CodeSyntaxError, "conflicting NULL/NOT NULL declarations for column %q of table %q", col.Name, b.rel.Name)
+			}
+			sawNotNull = true
+			col.NotNull = true
+		case nodes.CONSTR_DEFAULT:
+			if sawDefault {
+				return errorf(CodeSyntaxError, "multiple default values specified for column %q of table %q", col.Name, b.rel.Name)
+			}
+			sawDefault = true
+			col.Default = con.RawExpr
+		case nodes.CONSTR_IDENTITY:
+			if col.Identity != 0 {
+				return errorf(CodeSyntaxError, "multiple identity specifications for column %q of table %q", col.Name, b.rel.Name)
+			}
+			_, name := typeNameParts(col.Type)
+			if name != "int2" && name != "int4" && name != "int8" {
+				return errorf(CodeFeatureNotSupported, "identity column type must be smallint, integer, or bigint")
+			}
+			col.Identity = con.GeneratedWhen
+			if col.Identity == 0 {
+				col.Identity = 'a'
+			}
+			col.NotNull = true
+			b.ownedSequence(col, name, true)
+		case nodes.CONSTR_GENERATED:
+			col.Generated = 's'
+			col.Default = con.RawExpr
+		case nodes.CONSTR_ATTR_DEFERRABLE, nodes.CONSTR_ATTR_NOT_DEFERRABLE,
+			nodes.CONSTR_ATTR_DEFERRED, nodes.CONSTR_ATTR_IMMEDIATE:
+			if last != nil {
+				applyConstraintAttr(last, con.Contype)
+			}
+			continue
+		case nodes.CONSTR_PRIMARY, nodes.CONSTR_UNIQUE:
+			cp := *con
+			cp.Keys = &nodes.List{Items: []nodes.Node{&nodes.String{Sval: col.Name}}}
+			b.constraints = append(b.constraints, &cp)
+			last = &cp
+			continue
+		case nodes.CONSTR_FOREIGN:
+			cp := *con
+			cp.FkAttrs = &nodes.List{Items: []nodes.Node{&nodes.String{Sval: col.Name}}}
+			b.constraints = append(b.constraints, &cp)
+			last = &cp
+			continue
+		case nodes.CONSTR_CHECK:
+			b.constraints = append(b.constraints, con)
+		}
+		last = con
+	}
+	if col.Identity != 0 && col.Default != nil && col.Generated == 0 {
+		return errorf(CodeSyntaxError, "both default and identity specified for column %q of table %q", col.Name, b.rel.Name)
+	}
+	if col.Generated != 0 && sawDefault {
+		return errorf(CodeSyntaxError, "both default and generation expression specified for column %q of table %q", col.Name, b.rel.Name)
+	}
+	return nil
+}
+
+func applyConstraintAttr(con *nodes.Constraint, attr nodes.ConstrType) {
+	switch attr {
+	case nodes.CONSTR_ATTR_DEFERRABLE:
+		con.Deferrable = true
+	case nodes.CONSTR_ATTR_NOT_DEFERRABLE:
+		con.Deferrable = false
+	case nodes.CONSTR_ATTR_DEFERRED:
+		con.Initdeferred = true
+	case nodes.CONSTR_ATTR_IMMEDIATE:
+		con.Initdeferred = false
+	}
+}
+
+// checkPartitionKey verifies that the partition key columns exist.
+func (b *tableBuilder) checkPartitionKey() error {
+	for _, item := range listItems(b.rel.PartitionKey.PartParams) {
+		elem, ok := item.(*nodes.PartitionElem)
+		if !ok || elem.Name == "" {
+			continue
+		}
+		if b.rel.Column(elem.Name) == nil {
+			return errorf(CodeUndefinedColumn, "column %q named in partition key does not exist", elem.Name)
+		}
+	}
+	return nil
+}
+
+// finish records the dependencies of the relation's columns and creates
+// its pending constraints and indexes. creating is true for CREATE TABLE,
+// where foreign keys need no validation.
+func (b *tableBuilder) finish(creating bool) error {
+	rel := b.rel
+	for _, col := range rel.Columns {
+		if err := b.c.recordColumnDependencies(col); err != nil {
+			return err
+		}
+	}
+	for _, parent := range rel.Inherits {
+		b.c.recordDependency(addr(rel), addr(parent), depNormal)
+	}
+	if rel.PartitionOf != nil {
+		b.c.recordDependency(addr(rel), addr(rel.PartitionOf), depAuto)
+	}
+	for _, con := range rel.Constraints {
+		b.c.recordConstraintDependencies(con)
+	}
+	// Index-backed constraints first, so that foreign keys in the same
+	// statement can reference them.
+	for _, con := range b.constraints {
+		if con.Contype == nodes.CONSTR_PRIMARY || con.Contype == nodes.CONSTR_UNIQUE || con.Contype == nodes.CONSTR_EXCLUSION {
+			if err := b.addConstraint(con, creating); err != nil {
+				return err
+			}
+		}
+	}
+	for _, con := range b.constraints {
+		if con.Contype != nodes.CONSTR_PRIMARY && con.Contype != nodes.CONSTR_UNIQUE && con.Contype != nodes.CONSTR_EXCLUSION {
+			if err := b.addConstraint(con, creating); err != nil {
+				return err
+			}
+		}
+	}
+	for _, src := range b.likeIndexes {
+		if err := b.copyIndex(src); err != nil {
+			return err
+		}
+	}
+	b.constraints, b.likeIndexes = nil, nil
+	return nil
+}
+
+// recordColumnDependencies records a column's dependencies on its type and
+// on sequences named in its default.
+func (c *Catalog) recordColumnDependencies(col *Column) error {
+	if col.Type != nil {
+		ref, err := c.resolveType(col.Type)
+		if err != nil {
+			return err
+		}
+		switch {
+		case ref.typ != nil:
+			c.recordDependency(addr(col), addr(ref.typ), depNormal)
+		case ref.relation != nil:
+			c.recordDependency(addr(col), addr(ref.relation), depNormal)
+		}
+	}
+	c.recordDefaultDependencies(col)
+	return nil
+}
+
+// recordDefaultDependencies records that a column default depends on the
+// sequences it calls nextval() on.
+func (c *Catalog) recordDefaultDependencies(col *Column) {
+	if col.Default == nil {
+		return
+	}
+	for _, seqName := range nextvalSequences(col.Default) {
+		schema, name := splitQualifiedString(seqName)
+		if seq := c.LookupRelation(schema, name); seq != nil {
+			c.recordDependency(defaultAddr(col), addr(seq), depNormal)
+		}
+	}
+}
+
+// recordConstraintDependencies records that a constraint depends on the
+// columns it constrains.
+func (c *Catalog) recordConstraintDependencies(con *Constraint) {
+	if con.Relation == nil {
+		return
+	}
+	for _, name := range con.Columns {
+		if col := con.Relation.Column(name); col != nil {
+			c.recordDependency(addr(con), addr(col), depAuto)
+		}
+	}
+	if con.Expr != nil {
+		for _, name := range columnRefNames(con.Expr) {
+			if col := con.Relation.Column(name); col != nil {
+				c.recordDependency(addr(con), addr(col), depAuto)
+			}
+		}
+	}
+}
+
+// addConstraint adds a table constraint, choosing a name for it if none
+// was given.
+func (b *tableBuilder) addConstraint(def *nodes.Constraint, creating bool) error {
+	rel := b.rel
+	if def.Conname != "" && (rel.Constraint(def.Conname) != nil || b.pendingConNames[def.Conname]) {
+		return errorf(CodeDuplicateObject, "constraint %q for relation %q already exists", def.Conname, rel.Name)
+	}
+	switch def.Contype {
+	case nodes.CONSTR_CHECK:
+		return b.addCheckConstraint(def, creating)
+	case nodes.CONSTR_PRIMARY, nodes.CONSTR_UNIQUE, nodes.CONSTR_EXCLUSION:
+		return b.addIndexConstraint(def)
+	case nodes.CONSTR_FOREIGN:
+		return b.addForeignKey(def, creating)
+	case nodes.CONSTR_NOTNULL:
+		for _, name := range stringList(def.Keys) {
+			col := rel.Column(name)
+			if col == nil {
+				return errorf(CodeUndefinedColumn, "column %q of relation %q does not exist", name, rel.Name)
+			}
+			set(b.c, &col.NotNull, true)
+		}
+	}
+	return nil
+}
+
+func (b *tableBuilder) addCheckConstraint(def *nodes.Constraint, creating bool) error {
+	rel := b.rel
+	cols := columnRefNames(def.RawExpr)
+	for _, name := range cols {
+		if rel.Column(name) == nil {
+			return errorf(CodeUndefinedColumn, "column %q does not exist", name)
+		}
+	}
+	name := def.Conname
+	if name == "" {
+		colname := ""
+		if len(cols) == 1 {
+			colname = cols[0]
+		}
+		name = chooseConstraintName(rel.Schema, rel.Name, colname, "check", b.pendingConNames)
+	}
+	b.pendingConNames[name] = true
+	con := &Constraint{
+		Relation:  rel,
+		Name:      name,
+		Type:      nodes.CONSTR_CHECK,
+		Expr:      def.RawExpr,
+		NoInherit: def.IsNoInherit,
+		Validated: creating || !def.SkipValidation,
+		Def:       def,
+	}
+	b.c.attachConstraint(con)
+	return nil
+}
+
+// attachConstraint adds con to its relation and records its dependencies.
+func (c *Catalog) attachConstraint(con *Constraint) {
+	set(c, &con.Relation.Constraints, appended(con.Relation.Constraints, con))
+	c.recordConstraintDependencies(con)
+}
+
+// addIndexConstraint adds a primary key, unique or exclusion constraint
+// and the index that enforces it.
+func (b *tableBuilder) addIndexConstraint(def *nodes.Constraint) error {
+	rel := b.rel
+	if def.Contype == nodes.CONSTR_PRIMARY && rel.PrimaryKey() != nil {
+		return errorf(CodeInvalidTableDefinition, "multiple primary keys for table %q are not allowed", rel.Name)
+	}
+
+	var params []*nodes.IndexElem
+	var cols []string
+	if def.Contype == nodes.CONSTR_EXCLUSION {
+		for _, item := range listItems(def.Exclusions) {
+			pair, ok := item.(*nodes.List)
+			if !ok || len(pair.Items) == 0 {
+				continue
+			}
+			if elem, ok := pair.Items[0].(*nodes.IndexElem); ok {
+				params = append(params, elem)
+				if elem.Name != "" {
+					cols = append(cols, elem.Name)
+				}
+			}
+		}
+	} else {
+		for _, name := range stringList(def.Keys) {
+			params = append(params, &nodes.IndexElem{Name: name})
+			cols = append(cols, name)
+		}
+	}
+	var existing *Relation
+	if def.Indexname != "" {
+		existing = rel.Schema.relations[def.Indexname]
+		if existing == nil || existing.Index == nil {
+			return errorf(CodeUndefinedObject, "index %q does not exist", def.Indexname)
+		}
+		if existing.Index.Table != rel {
+			return errorf(CodeWrongObjectType, "index %q does not belong to table %q", def.Indexname, rel.Name)
+		}
+		if !existing.Index.Unique {
+			return errorf(CodeWrongObjectType, "%q is not a unique index", def.Indexname).
+				withDetail("Cannot create a primary key or unique constraint using such an index.")
+		}
+		params = existing.Index.Params
+		cols = nil
+		for _, p := range params {
+			cols = append(cols, p.Name)
+		}
+	}
+	for _, p := range params {
+		if p.Name != "" && rel.Column(p.Name) == nil {
+			return errorf(CodeUndefinedColumn, "column %q named in key does not exist", p.Name)
+		}
+	}
+
+	name := def.Conname
+	switch {
+	case name != "":
+		if existing == nil || existing.Name != name {
+			if rel.Schema.relations[name] != nil || b.pendingRelNames[name] {
+				return errorf(CodeDuplicateTable, "relation %q already exists", name)
+			}
+		}
+	case existing != nil:
+		name = existing.Name
+	default:
+		label := "key"
+		switch def.Contype {
+		case nodes.CONSTR_PRIMARY:
+			name = chooseRelationName(rel.Schema, rel.Name, "", "pkey", b.pendingRelNames)
+		case nodes.CONSTR_EXCLUSION:
+			label = "excl"
+			fallthrough
+		default:
+			name = chooseRelationName(rel.Schema, rel.Name, joinColumnNames(indexColumnNames(params)), label, b.pendingRelNames)
+		}
+	}
+	b.pendingRelNames[name] = true
+	b.pendingConNames[name] = true
+
+	con := &Constraint{
+		Relation:     rel,
+		Name:         name,
+		Type:         def.Contype,
+		Columns:      cols,
+		Expr:         def.WhereClause,
+		Deferrable:   def.Deferrable,
+		InitDeferred: def.Initdeferred,
+		Validated:    true,
+		Def:          def,
+	}
+	if def.Contype == nodes.CONSTR_PRIMARY {
+		for _, name := range cols {
+			set(b.c, &rel.Column(name).NotNull, true)
+		}
+	}
+
+	idx := existing
+	if idx != nil {
+		if idx.Name != name {
+			b.c.renameRelation(idx, name)
+		}
+		set(b.c, &idx.Index.IsConstraint, true)
+		set(b.c, &idx.Index.Primary, def.Contype == nodes.CONSTR_PRIMARY)
+	} else {
+		idx = &Relation{
+			Schema:      rel.Schema,
+			Name:        name,
+			Kind:        indexKindFor(rel),
+			Persistence: rel.Persistence,
+			Owner:       rel.Owner,
+			Options:     def.Options,
+			Index: &Index{
+				Table:            rel,
+				Unique:           def.Contype != nodes.CONSTR_EXCLUSION,
+				Primary:          def.Contype == nodes.CONSTR_PRIMARY,
+				IsConstraint:     true,
+				NullsNotDistinct: def.NullsNotDistinct,
+				AccessMethod:     def.AccessMethod,
+				Params:           params,
+				Where:            def.WhereClause,
+			},
+		}
+		for _, name := range stringList(def.Including) {
+			idx.Index.Including = append(idx.Index.Including, &nodes.IndexElem{Name: name})
+		}
+		if idx.Index.AccessMethod == "" {
+			idx.Index.AccessMethod = "btree"
+		}
+		b.c.addRelation(idx)
+		b.c.recordIndexDependencies(idx)
+	}
+	con.Index = idx
+	b.c.attachConstraint(con)
+	b.c.recordDependency(addr(idx), addr(con), depInternal)
+	return nil
+}
+
+func indexKindFor(rel *Relation) RelKind {
+	if rel.Kind == RelKindPartitionedTable {
+		return RelKindPartitionedIndex
+	}
+	return RelKindIndex
+}
+
+// recordIndexDependencies records that an index depends on its table and
+// on the columns it uses.
+func (c *Catalog) recordIndexDependencies(idx *Relation) {
+	table := idx.Index.Table
+	c.recordDependency(addr(idx), addr(table), depAuto)
+	var names []string
+	for _, p := range append(append([]*nodes.IndexElem(nil), idx.Index.Params...), idx.Index.Including...) {
+		if p.Name != "" {
+			names = append(names, p.Name)
+		} else {
+			names = append(names, columnRefNames(p.Expr)...)
+		}
+	}
+	names = append(names, columnRefNames(idx.Index.Where)...)
+	for _, name := range names {
+		if col := table.Column(name); col != nil {
+			c.recordDependency(addr(idx), addr(col), depAuto)
+		}
+	}
+}
+
+// addForeignKey adds a FOREIGN KEY constraint after checking the
+// referenced table, following ATAddForeignKeyConstraint.
+func (b *tableBuilder) addForeignKey(def *nodes.Constraint, creating bool) error {
+	rel := b.rel
+	fkCols := stringList(def.FkAttrs)
+	for _, name := range fkCols {
+		if rel.Column(name) == nil {
+			return errorf(CodeUndefinedColumn, "column %q referenced in foreign key constraint does not exist", name)
+		}
+	}
+	ref, err := b.c.relationFor(def.Pktable)
+	if err != nil {
+		return err
+	}
+	if ref.Kind != RelKindTable && ref.Kind != RelKindPartitionedTable {
+		return errorf(CodeWrongObjectType, "referenced relation %q is not a table", ref.Name)
+	}
+	pkCols := stringList(def.PkAttrs)
+	var refIndex *Relation
+	if len(pkCols) == 0 {
+		pk := ref.PrimaryKey()
+		if pk == nil {
+			return errorf(CodeInvalidForeignKey, "there is no primary key for referenced table %q", ref.Name)
+		}
+		pkCols, refIndex = pk.Columns, pk.Index
+	} else {
+		for _, name := range pkCols {
+			if ref.Column(name) == nil {
+				return errorf(CodeUndefinedColumn, "column %q referenced in foreign key constraint does not exist", name)
+			}
+		}
+		refIndex = findUniqueIndex(ref, pkCols)
+		if refIndex == nil {
+			return errorf(CodeInvalidForeignKey, "there is no unique constraint matching given keys for referenced table %q", ref.Name)
+		}
+	}
+	if len(fkCols) != len(pkCols) {
+		return errorf(CodeInvalidForeignKey, "number of referencing and referenced columns for foreign key disagree")
+	}
+
+	name := def.Conname
+	if name == "" {
+		name = chooseConstraintName(rel.Schema, rel.Name, joinColumnNames(fkCols), "fkey", b.pendingConNames)
+	}
+	b.pendingConNames[name] = true
+	con := &Constraint{
+		Relation:     rel,
+		Name:         name,
+		Type:         nodes.CONSTR_FOREIGN,
+		Columns:      fkCols,
+		RefTable:     ref,
+		RefColumns:   pkCols,
+		FkMatchType:  def.FkMatchtype,
+		FkUpdAction:  def.FkUpdAction,
+		FkDelAction:  def.FkDelAction,
+		Deferrable:   def.Deferrable,
+		InitDeferred: def.Initdeferred,
+		Validated:    creating || !def.SkipValidation,
+		Index:        refIndex,
+		Def:          def,
+	}
+	b.c.attachConstraint(con)
+	if refIndex != nil {
+		b.c.recordDependency(addr(con), addr(refIndex), depNormal)
+	}
+	for _, name := range pkCols {
+		if col := ref.Column(name); col != nil {
+			b.c.recordDependency(addr(con), addr(col), depNormal)
+		}
+	}
+	return nil
+}
+
+// findUniqueIndex returns a non-partial unique index on exactly the given
+// columns, in any order.
+func findUniqueIndex(rel *Relation, cols []string) *Relation {
+	want := make(map[string]bool)
+	for _, c := range cols {
+		want[c] = true
+	}
+	var best *Relation
+	for _, idx := range rel.Indexes() {
+		ix := idx.Index
+		if !ix.Unique || ix.Where != nil || len(ix.Params) != len(want) {
+			continue
+		}
+		match := true
+		for _, p := range ix.Params {
+			if p.Name == "" || !want[p.Name] {
+				match = false
+			}
+		}
+		if match && (best == nil || ix.Primary) {
+			best = idx
+		}
+	}
+	return best
+}
+
+// copyIndex recreates an index of a LIKE source on the new table.
+func (b *tableBuilder) copyIndex(src *Relation) error {
+	ix := src.Index
+	for _, con := range ix.Table.Constraints {
+		if con.Index == src && con.Type != nodes.CONSTR_FOREIGN {
+			def := *con.Def
+			def.Conname = ""
+			def.Indexname = ""
+			if def.Contype == nodes.CONSTR_PRIMARY && b.rel.PrimaryKey() != nil {
+				return errorf(CodeInvalidTableDefinition, "multiple primary keys for table %q are not allowed", b.rel.Name)
+			}
+			if def.Keys == nil && def.Contype != nodes.CONSTR_EXCLUSION {
+				def.Keys = &nodes.List{}
+				for _, c := range con.Columns {
+					def.Keys.Items = append(def.Keys.Items, &nodes.String{Sval: c})
+				}
+			}
+			return b.addIndexConstraint(&def)
+		}
+	}
+	label := "idx"
+	name := chooseRelationName(b.rel.Schema, b.rel.Name, joinColumnNames(indexColumnNames(ix.Params)), label, b.pendingRelNames)
+	b.pendingRelNames[name] = true
+	cp := *ix
+	cp.Table = b.rel
+	idx := &Relation{
+		Schema:      b.rel.Schema,
+		Name:        name,
+		Kind:        indexKindFor(b.rel),
+		Persistence: b.rel.Persistence,
+		Owner:       b.rel.Owner,
+		Options:     src.Options,
+		Index:       &cp,
+	}
+	b.c.addRelation(idx)
+	b.c.recordIndexDependencies(idx)
+	return nil
+}
+
+// createIndex implements CREATE INDEX.
+func (c *Catalog) createIndex(n *nodes.IndexStmt) error {
+	rel, err := c.relationFor(n.Relation)
+	if err != nil {
+		return err
+	}
+	switch rel.Kind {
+	case RelKindTable, RelKindPartitionedTable, RelKindMatView:
+	case RelKindForeignTable:
+		return errorf(CodeWrongObjectType, "cannot create index on foreign table %q", rel.Name)
+	default:
+		return errorf(CodeWrongObjectType, "cannot create index on relation %q", rel.Name).
+			withDetail("This operation is not supported for %ss.", rel.Kind)
+	}
+
+	var params, including []*nodes.IndexElem
+	for _, item := range listItems(n.IndexParams) {
+		if elem, ok := item.(*nodes.IndexElem); ok {
+			params = append(params, elem)
+		}
+	}
+	for _, item := range listItems(n.IndexIncludingParams) {
+		if elem, ok := item.(*nodes.IndexElem); ok {
+			including = append(including, elem)
+		}
+	}
+	for _, elem := range append(append([]*nodes.IndexElem(nil), params...), including...) {
+		names := columnRefNames(elem.Expr)
+		if elem.Name != "" {
+			names = []string{elem.Name}
+		}
+		for _, name := range names {
+			if rel.Column(name) == nil {
+				return errorf(CodeUndefinedColumn, "column %q does not exist", name)
+			}
+		}
+	}
+
+	name := n.Idxname
+	if name == "" {
+		label := "idx"
+		switch {
+		case n.Primary:
+			label = "pkey"
+		case n.ExcludeOpNames != nil:
+			label = "excl"
+		}
+		name = chooseRelationName(rel.Schema, rel.Name, joinColumnNames(indexColumnNames(params)), label, nil)
+	} else if rel.Schema.relations[name] != nil {
+		if n.IfNotExists {
+			c.notice("relation %q already exists, skipping", name)
+			return nil
+		}
+		return errorf(CodeDuplicateTable, "relation %q already exists", name)
+	}
+
+	am := n.AccessMethod
+	if am == "" {
+		am = "btree"
+	}
+	idx := &Relation{
+		Schema:      rel.Schema,
+		Name:        name,
+		Kind:        indexKindFor(rel),
+		Persistence: rel.Persistence,
+		Owner:       rel.Owner,
+		Options:     n.Options,
+		Index: &Index{
+			Table:            rel,
+			Unique:           n.Unique,
+			Primary:          n.Primary,
+			NullsNotDistinct: n.NullsNotDistinct,
+			AccessMethod:     am,
+			Params:           params,
+			Including:        including,
+			Where:            n.WhereClause,
+		},
+	}
+	c.addRelation(idx)
+	c.recordIndexDependencies(idx)
+	return nil
+}
+
+// alterKindDetail is errdetail_relkind_not_supported.
+func alterKindDetail(e *Error, kind RelKind) *Error {
+	return e.withDetail("This operation is not supported for %ss.", kind)
+}
+
+// children returns the relations that inherit from rel, including its
+// partitions.
+func (c *Catalog) children(rel *Relation) []*Relation {
+	var out []*Relation
+	for _, s := range c.Schemas() {
+		for _, r := range s.Relations() {
+			if r.PartitionOf == rel {
+				out = append(out, r)
+				continue
+			}
+			for _, p := range r.Inherits {
+				if p == rel {
+					out = append(out, r)
+					break
+				}
+			}
+		}
+	}
+	return out
+}
+
+// alterTable implements ALTER TABLE (and ALTER VIEW/INDEX/SEQUENCE/...
+// forms that reach AlterTableStmt).
+func (c *Catalog) alterTable(n *nodes.AlterTableStmt) error {
+	rel, err := c.relationFor(n.Relation)
+	if err != nil {
+		if n.MissingOk {
+			c.notice("relation %q does not exist, skipping", rangeVarName(n.Relation))
+			return nil
+		}
+		return err
+	}
+	if err := checkRelationObjectType(rel, nodes.ObjectType(n.Objtype)); err != nil {
+		return err
+	}
+	for _, item := range listItems(n.Cmds) {
+		cmd, ok := item.(*nodes.AlterTableCmd)
+		if !ok {
+			continue
+		}
+		if err := c.alterTableCmd(rel, cmd, n.Relation.Inh); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRelationObjectType verifies that the statement's object type
+// matches the relation, as RangeVarCallbackForAlterRelation does.
+func checkRelationObjectType(rel *Relation, objType nodes.ObjectType) error {
+	var want string
+	switch objType {
+	case nodes.OBJECT_VIEW:
+		if rel.Kind != RelKindView {
+			want = "a view"
+		}
+	case nodes.OBJECT_MATVIEW:
+		if rel.Kind != RelKindMatView {
+			want = "a materialized view"
+		}
+	case nodes.OBJECT_SEQUENCE:
+		if rel.Kind != RelKindSequence {
+			want = "a sequence"
+		}
+	case nodes.OBJECT_INDEX:
+		if rel.Index == nil {
+			want = "an index"
+		}
+	case nodes.OBJECT_FOREIGN_TABLE:
+		if rel.Kind != RelKindForeignTable {
+			want = "a foreign table"
+		}
+	}
+	if want != "" {
+		return errorf(CodeWrongObjectType, "%q is not %s", rel.Name, want)
+	}
+	return nil
+}
+
+// alterTableCmd applies a single ALTER TABLE subcommand.
+func (c *Catalog) alterTableCmd(rel *Relation, cmd *nodes.AlterTableCmd, recurse bool) error {
+	tablesOnly := func(action string) error {
+		if !rel.IsTable() {
+			return alterKindDetail(errorf(CodeWrongObjectType, "ALTER action %s cannot be performed on relation %q", action, rel.Name), rel.Kind)
+		}
+		return nil
+	}
+	column := func() (*Column, error) {
+		if !rel.hasColumns() {
+			return nil, errorf(CodeWrongObjectType, "%q is not a table, view, materialized view, composite type, or foreign table", rel.Name)
+		}
+		col := rel.Column(cmd.Name)
+		if col == nil {
+			return nil, errorf(CodeUndefinedColumn, "column %q of relation %q does not exist", cmd.Name, rel.Name)
+		}
+		return col, nil
+	}
+
+	switch nodes.AlterTableType(cmd.Subtype) {
+	case nodes.AT_AddColumn, nodes.AT_AddColumnToView:
+		if nodes.AlterTableType(cmd.Subtype) == nodes.AT_AddColumn {
+			if err := tablesOnly("ADD COLUMN"); err != nil {
+				return err
+			}
+		}
+		def := cmd.Def.(*nodes.ColumnDef)
+		return c.addColumn(rel, def, cmd.MissingOk, recurse)
+
+	case nodes.AT_ColumnDefault:
+		col, err := column()
+		if err != nil {
+			return err
+		}
+		if cmd.Def != nil && col.Identity != 0 {
+			return errorf("55000", "column %q of relation %q is an identity column", col.Name, rel.Name).
+				withHint("Use ALTER TABLE ... ALTER COLUMN ... DROP IDENTITY instead.")
+		}
+		if col.Generated != 0 {
+			e := errorf("55000", "column %q of relation %q is a generated column", col.Name, rel.Name)
+			if cmd.Def != nil {
+				e.withHint("Use ALTER TABLE ... ALTER COLUMN ... SET EXPRESSION instead.")
+			} else {
+				e.withHint("Use ALTER TABLE ... ALTER COLUMN ... DROP EXPRESSION instead.")
+			}
+			return e
+		}
+		c.removeDependencies(defaultAddr(col))
+		set(c, &col.Default, cmd.Def)
+		c.recordDefaultDependencies(col)
+
+	case nodes.AT_DropNotNull:
+		if err := tablesOnly("ALTER COLUMN ... DROP NOT NULL"); err != nil {
+			return err
+		}
+		col, err := column()
+		if err != nil {
+			return err
+		}
+		if pk := rel.PrimaryKey(); pk != nil && containsString(pk.Columns, col.Name) {
+			return errorf(CodeInvalidTableDefinition, "column %q is in a primary key", col.Name)
+		}
+		if col.Identity != 0 {
+			return errorf(CodeInvalidTableDefinition, "column %q of relation %q is an identity column", col.Name, rel.Name)
+		}
+		set(c, &col.NotNull, false)
+
+	case nodes.AT_SetNotNull:
+		if err := tablesOnly("ALTER COLUMN ... SET NOT NULL"); err != nil {
+			return err
+		}
+		col, err := column()
+		if err != nil {
+			return err
+		}
+		set(c, &col.NotNull, true)
+
+	case nodes.AT_DropExpression:
+		col, err := column()
+		if err != nil {
+			return err
+		}
+		if col.Generated == 0 {
+			if cmd.MissingOk {
+				c.notice("column %q of relation %q is not a stored generated column, skipping", col.Name, rel.Name)
+				return nil
+			}
+			return errorf(CodeSyntaxError, "column %q of relation %q is not a stored generated column", col.Name, rel.Name)
+		}
+		set(c, &col.Generated, 0)
+		set(c, &col.Default, nil)
+
+	case nodes.AT_SetExpression:
+		col, err := column()
+		if err != nil {
+			return err
+		}
+		if col.Generated == 0 {
+			return errorf(CodeSyntaxError, "column %q of relation %q is not a generated column", col.Name, rel.Name)
+		}
+		set(c, &col.Default, cmd.Def)
+
+	case nodes.AT_SetStatistics, nodes.AT_SetOptions, nodes.AT_ResetOptions,
+		nodes.AT_SetStorage, nodes.AT_SetCompression, nodes.AT_AlterColumnGenericOptions:
+		if cmd.Name != "" {
+			if _, err := column(); err != nil {
+				return err
+			}
+		}
+
+	case nodes.AT_DropColumn:
+		if err := tablesOnly("DROP COLUMN"); err != nil {
+			return err
+		}
+		return c.dropColumn(rel, cmd, recurse)
+
+	case nodes.AT_AddConstraint, nodes.AT_AddIndexConstraint:
+		if err := tablesOnly("ADD CONSTRAINT"); err != nil {
+			return err
+		}
+		switch def := cmd.Def.(type) {
+		case *nodes.Constraint:
+			b := c.newTableBuilder(rel)
+			b.constraints = []*nodes.Constraint{def}
+			if err := b.finish(false); err != nil {
+				return err
+			}
+			if def.Contype == nodes.CONSTR_CHECK && !def.IsNoInherit && recurse {
+				for _, child := range c.children(rel) {
+					if child.Constraint(rel.Constraints[len(rel.Constraints)-1].Name) != nil {
+						continue
+					}
+					cb := c.newTableBuilder(child)
+					cp := *def
+					cp.Conname = rel.Constraints[len(rel.Constraints)-1].Name
+					cb.constraints = []*nodes.Constraint{&cp}
+					if err := cb.finish(false); err != nil {
+						return err
+					}
+				}
+			}
+		case *nodes.IndexStmt:
+			return c.createIndex(def)
+		}
+
+	case nodes.AT_AlterConstraint:
+		def, ok := cmd.Def.(*nodes.Constraint)
+		if !ok {
+			return nil
+		}
+		con := rel.Constraint(def.Conname)
+		if con == nil {
+			return errorf(CodeUndefinedObject, "constraint %q of relation %q does not exist", def.Conname, rel.Name)
+		}
+		if con.Type != nodes.CONSTR_FOREIGN {
+			return errorf(CodeWrongObjectType, "constraint %q of relation %q is not a foreign key constraint", con.Name, rel.Name)
+		}
+		set(c, &con.Deferrable, def.Deferrable)
+		set(c, &con.InitDeferred, def.Initdeferred)
+
+	case nodes.AT_ValidateConstraint:
+		con := rel.Constraint(cmd.Name)
+		if con == nil {
+			return errorf(CodeUndefinedObject, "constraint %q of relation %q does not exist", cmd.Name, rel.Name)
+		}
+		if con.Type != nodes.CONSTR_FOREIGN && con.Type != nodes.CONSTR_CHECK {
+			return errorf(CodeWrongObjectType, "constraint %q of relation %q is not a foreign key or check constraint", con.Name, rel.Name)
+		}
+		set(c, &con.Validated, true)
+
+	case nodes.AT_DropConstraint:
+		con := rel.Constraint(cmd.Name)
+		if con == nil {
+			if cmd.MissingOk {
+				c.notice("constraint %q of relation %q does not exist, skipping", cmd.Name, rel.Name)
+				return nil
+			}
+			return errorf(CodeUndefinedObject, "constraint %q of relation %q does not exist", cmd.Name, rel.Name)
+		}
+		return c.performDeletion([]address{addr(con)}, nodes.DropBehavior(cmd.Behavior) == nodes.DROP_CASCADE)
+
+	case nodes.AT_AlterColumnType:
+		if err := tablesOnly("ALTER COLUMN ... SET DATA TYPE"); err != nil {
+			return err
+		}
+		return c.alterColumnType(rel, cmd, recurse)
+
+	case nodes.AT_ChangeOwner:
+		set(c, &rel.Owner, c.roleName(cmd.Newowner))
+		for _, idx := range rel.Indexes() {
+			set(c, &idx.Owner, rel.Owner)
+		}
+
+	case nodes.AT_SetLogged:
+		set(c, &rel.Persistence, byte(nodes.RELPERSISTENCE_PERMANENT))
+	case nodes.AT_SetUnLogged:
+		set(c, &rel.Persistence, byte(nodes.RELPERSISTENCE_UNLOGGED))
+
+	case nodes.AT_SetRelOptions, nodes.AT_ResetRelOptions, nodes.AT_ReplaceRelOptions:
+		opts, _ := cmd.Def.(*nodes.List)
+		set(c, &rel.Options, mergeOptions(rel.Options, opts, nodes.AlterTableType(cmd.Subtype)))
+
+	case nodes.AT_AddInherit:
+		return c.addInherit(rel, cmd.Def.(*nodes.RangeVar))
+	case nodes.AT_DropInherit:
+		return c.dropInherit(rel, cmd.Def.(*nodes.RangeVar))
+
+	case nodes.AT_AttachPartition:
+		if pc, ok := cmd.Def.(*nodes.PartitionCmd); ok {
+			return c.attachPartition(rel, pc)
+		}
+	case nodes.AT_DetachPartition:
+		if pc, ok := cmd.Def.(*nodes.PartitionCmd); ok {
+			return c.detachPartition(rel, pc)
+		}
+
+	case nodes.AT_AddIdentity:
+		col, err := column()
+		if err != nil {
+			return err
+		}
+		if !col.NotNull {
+			return errorf("55000", "column %q of relation %q must be declared NOT NULL before identity can be added", col.Name, rel.Name)
+		}
+		if col.Identity != 0 {
+			return errorf("55000", "column %q of relation %q is already an identity column", col.Name, rel.Name)
+		}
+		if col.Default != nil {
+			return errorf("55000", "column %q of relation %q already has a default value", col.Name, rel.Name)
+		}
+		when := byte('a')
+		if def, ok := cmd.Def.(*nodes.Constraint); ok && def.GeneratedWhen != 0 {
+			when = def.GeneratedWhen
+		}
+		if def, ok := cmd.Def.(*nodes.ColumnDef); ok && def.Identity != 0 {
+			when = def.Identity
+		}
+		_, typ := typeNameParts(col.Type)
+		b := c.newTableBuilder(rel)
+		set(c, &col.Identity, when)
+		b.ownedSequence(col, typ, true)
+
+	case nodes.AT_SetIdentity, nodes.AT_DropIdentity:
+		col, err := column()
+		if err != nil {
+			return err
+		}
+		if col.Identity == 0 {
+			if cmd.MissingOk {
+				c.notice("column %q of relation %q is not an identity column, skipping", col.Name, rel.Name)
+				return nil
+			}
+			return errorf("55000", "column %q of relation %q is not an identity column", col.Name, rel.Name)
+		}
+		if nodes.AlterTableType(cmd.Subtype) == nodes.AT_DropIdentity {
+			set(c, &col.Identity, 0)
+			for _, seq := range rel.Schema.Relations() {
+				if seq.Sequence != nil && seq.Sequence.OwnedBy == col && seq.Sequence.Identity {
+					if err := c.performDeletion([]address{addr(seq)}, false); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}
+		for _, opt := range listItems(asList(cmd.Def)) {
+			if de, ok := opt.(*nodes.DefElem); ok && de.Defname == "generated" {
+				if i, ok := de.Arg.(*nodes.Integer); ok {
+					set(c, &col.Identity, byte(i.Ival))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// addColumn implements ALTER TABLE ... ADD COLUMN, recursing to
+// inheritance children.
+func (c *Catalog) addColumn(rel *Relation, def *nodes.ColumnDef, ifNotExists, recurse bool) error {
+	if existing := rel.Column(def.Colname); existing != nil {
+		if ifNotExists {
+			c.notice("column %q of relation %q already exists, skipping", def.Colname, rel.Name)
+			return nil
+		}
+		return errorf(CodeDuplicateColumn, "column %q of relation %q already exists", def.Colname, rel.Name)
+	}
+	b := c.newTableBuilder(rel)
+	before := rel.Columns
+	cols := append([]*Column(nil), rel.Columns...)
+	rel.Columns = cols
+	c.undo = append(c.undo, func() { rel.Columns = before })
+	if err := b.addColumnDef(def, false); err != nil {
+		return err
+	}
+	col := rel.Columns[len(rel.Columns)-1]
+	if err := c.recordColumnDependencies(col); err != nil {
+		return err
+	}
+	if err := b.finish(false); err != nil {
+		return err
+	}
+	for _, child := range c.children(rel) {
+		if existing := child.Column(col.Name); existing != nil {
+			if !sameType(existing.Type, col.Type) {
+				return errorf("42804", "child table %q has different type for column %q", child.Name, col.Name)
+			}
+			c.notice("merging definition of column %q for child %q", col.Name, child.Name)
+			set(c, &existing.Inhcount, existing.Inhcount+1)
+			continue
+		}
+		cc := inheritedColumn(child, col)
+		set(c, &child.Columns, appended(child.Columns, cc))
+		if err := c.recordColumnDependencies(cc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropColumn implements ALTER TABLE ... DROP COLUMN.
+func (c *Catalog) dropColumn(rel *Relation, cmd *nodes.AlterTableCmd, recurse bool) error {
+	col := rel.Column(cmd.Name)
+	if col == nil {
+		if cmd.MissingOk {
+			c.notice("column %q of relation %q does not exist, skipping", cmd.Name, rel.Name)
+			return nil
+		}
+		return errorf(CodeUndefinedColumn, "column %q of relation %q does not exist", cmd.Name, rel.Name)
+	}
+	if col.Inhcount > 0 {
+		return errorf(CodeInvalidTableDefinition, "cannot drop inherited column %q", col.Name)
+	}
+	if rel.PartitionKey != nil {
+		for _, item := range listItems(rel.PartitionKey.PartParams) {
+			if elem, ok := item.(*nodes.PartitionElem); ok && elem.Name == col.Name {
+				return errorf(CodeInvalidTableDefinition, "cannot drop column %q because it is part of the partition key of relation %q", col.Name, rel.Name)
+			}
+		}
+	}
+	targets := []address{addr(col)}
+	for _, child := range c.children(rel) {
+		cc := child.Column(col.Name)
+		if cc == nil {
+			continue
+		}
+		if cc.Inhcount == 1 && !cc.IsLocal {
+			targets = append(targets, addr(cc))
+		} else {
+			set(c, &cc.Inhcount, cc.Inhcount-1)
+			if cc.Inhcount == 0 {
+				set(c, &cc.IsLocal, true)
+			}
+		}
+	}
+	return c.performDeletion(targets, nodes.DropBehavior(cmd.Behavior) == nodes.DROP_CASCADE)
+}
+
+// alterColumnType implements ALTER COLUMN ... TYPE.
+func (c *Catalog) alterColumnType(rel *Relation, cmd *nodes.AlterTableCmd, recurse bool) error {
+	col := rel.Column(cmd.Name)
+	if col == nil {
+		return errorf(CodeUndefinedColumn, "column %q of relation %q does not exist", cmd.Name, rel.Name)
+	}
+	def, ok := cmd.Def.(*nodes.ColumnDef)
+	if !ok || def.TypeName == nil {
+		return nil
+	}
+	if col.Inhcount > 0 && !recurse {
+		return errorf(CodeInvalidTableDefinition, "cannot alter inherited column %q", col.Name)
+	}
+	if _, err := c.resolveType(def.TypeName); err != nil {
+		return err
+	}
+	for _, d := range c.deps {
+		if d.referenced != addr(col) {
+			continue
+		}
+		if r, ok := d.dependent.obj.(*Relation); ok && (r.Kind == RelKindView || r.Kind == RelKindMatView) {
+			return errorf(CodeFeatureNotSupported, "cannot alter type of a column used by a view or rule").
+				withDetail("rule _RETURN on %s depends on column %q", c.describe(addr(r)), col.Name)
+		}
+	}
+	cols := []*Column{col}
+	for _, child := range c.children(rel) {
+		if cc := child.Column(col.Name); cc != nil {
+			cols = append(cols, cc)
+		}
+	}
+	for _, cc := range cols {
+		c.removeDependencies(addr(cc), depNormal)
+		set(c, &cc.Type, def.TypeName)
+		if def.CollClause != nil {
+			set(c, &cc.Collation, nameListString(stringList(def.CollClause.Collname)))
+		}
+		if err := c.recordColumnDependencies(cc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addInherit implements ALTER TABLE ... INHERIT.
+func (c *Catalog) addInherit(rel *Relation, rv *nodes.RangeVar) error {
+	parent, err := c.relationFor(rv)
+	if err != nil {
+		return err
+	}
+	if parent == rel {
+		return errorf(CodeDuplicateTable, "circular inheritance not allowed").
+			withDetail("%q is already a child of %q.", rel.Name, rel.Name)
+	}
+	for _, p := range rel.Inherits {
+		if p == parent {
+			return errorf(CodeDuplicateTable, "relation %q would be inherited from more than once", parent.Name)
+		}
+	}
+	for _, pc := range parent.Columns {
+		cc := rel.Column(pc.Name)
+		if cc == nil {
+			return errorf("42804", "child table is missing column %q", pc.Name)
+		}
+		if !sameType(cc.Type, pc.Type) {
+			return errorf("42804", "child table %q has different type for column %q", rel.Name, pc.Name)
+		}
+		set(c, &cc.Inhcount, cc.Inhcount+1)
+	}
+	set(c, &rel.Inherits, appended(rel.Inherits, parent))
+	c.recordDependency(addr(rel), addr(parent), depNormal)
+	return nil
+}
+
+// dropInherit implements ALTER TABLE ... NO INHERIT.
+func (c *Catalog) dropInherit(rel *Relation, rv *nodes.RangeVar) error {
+	parent, err := c.relationFor(rv)
+	if err != nil {
+		return err
+	}
+	for i, p := range rel.Inherits {
+		if p != parent {
+			continue
+		}
+		set(c, &rel.Inherits, without(rel.Inherits, i))
+		for _, pc := range parent.Columns {
+			if cc := rel.Column(pc.Name); cc != nil && cc.Inhcount > 0 {
+				set(c, &cc.Inhcount, cc.Inhcount-1)
+				if cc.Inhcount == 0 {
+					set(c, &cc.IsLocal, true)
+				}
+			}
+		}
+		c.removeInheritDependency(rel, parent)
+		return nil
+	}
+	return errorf(CodeUndefinedTable, "relation %q is not a parent of relation %q", parent.Name, rel.Name)
+}
+
+func (c *Catalog) removeInheritDependency(child, parent *Relation) {
+	var keep []*dependency
+	for _, d := range c.deps {
+		if d.dependent == addr(child) && d.referenced == addr(parent) {
+			continue
+		}
+		keep = append(keep, d)
+	}
+	set(c, &c.deps, keep)
+}
+
+// attachPartition implements ALTER TABLE ... ATTACH PARTITION.
+func (c *Catalog) attachPartition(rel *Relation, pc *nodes.PartitionCmd) error {
+	if rel.Kind != RelKindPartitionedTable {
+		return errorf(CodeInvalidObjectDefinition, "table %q is not partitioned", rel.Name)
+	}
+	part, err := c.relationFor(pc.Name)
+	if err != nil {
+		return err
+	}
+	if !part.IsTable() {
+		return alterKindDetail(errorf(CodeWrongObjectType, "cannot attach relation %q as partition", part.Name), part.Kind)
+	}
+	if part.PartitionOf != nil {
+		return errorf(CodeInvalidObjectDefinition, "%q is already a partition", part.Name)
+	}
+	if len(part.Inherits) > 0 {
+		return errorf(CodeWrongObjectType, "cannot attach inheritance child as partition")
+	}
+	for _, col := range part.Columns {
+		if rel.Column(col.Name) == nil {
+			return errorf("42804", "table %q contains column %q not found in parent %q", part.Name, col.Name, rel.Name).
+				withDetail("The new partition may contain only the columns present in parent.")
+		}
+	}
+	for _, pcol := range rel.Columns {
+		col := part.Column(pcol.Name)
+		if col == nil {
+			return errorf("42804", "child table is missing column %q", pcol.Name)
+		}
+		if !sameType(col.Type, pcol.Type) {
+			return errorf("42804", "child table %q has different type for column %q", part.Name, pcol.Name)
+		}
+		set(c, &col.Inhcount, col.Inhcount+1)
+	}
+	set(c, &part.PartitionOf, rel)
+	set(c, &part.PartitionBound, pc.Bound)
+	c.recordDependency(addr(part), addr(rel), depAuto)
+	return nil
+}
+
+// detachPartition implements ALTER TABLE ... DETACH PARTITION.
+func (c *Catalog) detachPartition(rel *Relation, pc *nodes.PartitionCmd) error {
+	part, err := c.relationFor(pc.Name)
+	if err != nil {
+		return err
+	}
+	if part.PartitionOf != rel {
+		return errorf(CodeUndefinedTable, "relation %q is not a partition of relation %q", part.Name, rel.Name)
+	}
+	for _, col := range part.Columns {
+		if col.Inhcount > 0 {
+			set(c, &col.Inhcount, col.Inhcount-1)
+			set(c, &col.IsLocal, true)
+		}
+	}
+	set(c, &part.PartitionOf, nil)
+	set(c, &part.PartitionBound, nil)
+	c.removeInheritDependency(part, rel)
+	return nil
+}
+
+// mergeOptions applies SET/RESET of storage parameters to opts.
+func mergeOptions(opts, change *nodes.List, op nodes.AlterTableType) *nodes.List {
+	if op == nodes.AT_ReplaceRelOptions {
+		return change
+	}
+	out := &nodes.List{}
+	changed := make(map[string]bool)
+	for _, item := range listItems(change) {
+		if d, ok := item.(*nodes.DefElem); ok {
+			changed[d.Defname] = true
+		}
+	}
+	for _, item := range listItems(opts) {
+		if d, ok := item.(*nodes.DefElem); ok && changed[d.Defname] {
+			continue
+		}
+		out.Items = append(out.Items, item)
+	}
+	if op == nodes.AT_SetRelOptions {
+		out.Items = append(out.Items, listItems(change)...)
+	}
+	if len(out.Items) == 0 {
+		return nil
+	}
+	return out
+}
+
+func containsString(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+// builtinTypeName returns a pg_catalog-qualified TypeName.
+func builtinTypeName(name string) *nodes.TypeName {
+	return &nodes.TypeName{
+		Names:    &nodes.List{Items: []nodes.Node{&nodes.String{Sval: "pg_catalog"}, &nodes.String{Sval: name}}},
+		Location: -1,
+	}
+}
+
+// nextvalCall builds the expression nextval('seq'::regclass).
+func nextvalCall(seq string) nodes.Node {
+	return &nodes.FuncCall{
+		Funcname: &nodes.List{Items: []nodes.Node{&nodes.String{Sval: "nextval"}}},
+		Args: &nodes.List{Items: []nodes.Node{&nodes.TypeCast{
+			Arg:      &nodes.A_Const{Val: &nodes.String{Sval: seq}, Location: -1},
+			TypeName: builtinTypeName("regclass"),
+			Location: -1,
+		}}},
+		Funcformat: nodes.COERCE_EXPLICIT_CALL,
+		Location:   -1,
+	}
+}
@@ -0,0 +1,270 @@
+package catalog
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+)
+
+// builtinTypes is the set of type names defined in pg_catalog by a fresh
+// PostgreSQL 17 cluster (excluding the implicit array types, which are
+// recognized by their leading underscore).
+var builtinTypes = map[string]bool{
+	"bool": true, "bytea": true, "char": true, "name": true, "int8": true,
+	"int2": true, "int2vector": true, "int4": true, "regproc": true,
+	"text": true, "oid": true, "tid": true, "xid": true, "cid": true,
+	"oidvector": true, "json": true, "xml": true, "pg_node_tree": true,
+	"pg_ndistinct": true, "pg_dependencies": true, "pg_mcv_list": true,
+	"pg_ddl_command": true, "xid8": true, "point": true, "lseg": true,
+	"path": true, "box": true, "polygon": true, "line": true,
+	"float4": true, "float8": true, "unknown": true, "circle": true,
+	"money": true, "macaddr": true, "inet": true, "cidr": true,
+	"macaddr8": true, "aclitem": true, "bpchar": true, "varchar": true,
+	"date": true, "time": true, "timestamp": true, "timestamptz": true,
+	"interval": true, "timetz": true, "bit": true, "varbit": true,
+	"numeric": true, "refcursor": true, "regprocedure": true,
+	"regoper": true, "regoperator": true, "regclass": true,
+	"regcollation": true, "regtype": true, "regrole": true,
+	"regnamespace": true, "uuid": true, "pg_lsn": true, "tsvector": true,
+	"gtsvector": true, "tsquery": true, "regconfig": true,
+	"regdictionary": true, "jsonb": true, "jsonpath": true,
+	"txid_snapshot": true, "pg_snapshot": true, "int4range": true,
+	"numrange": true, "tsrange": true, "tstzrange": true, "daterange": true,
+	"int8range": true, "int4multirange": true, "nummultirange": true,
+	"tsmultirange": true, "tstzmultirange": true, "datemultirange": true,
+	"int8multirange": true, "record": true, "cstring": true, "any": true,
+	"anyarray": true, "void": true, "trigger": true, "event_trigger": true,
+	"language_handler": true, "internal": true, "anyelement": true,
+	"anynonarray": true, "anyenum": true, "fdw_handler": true,
+	"index_am_handler": true, "tsm_handler": true, "table_am_handler": true,
+	"anyrange": true, "anycompatible": true, "anycompatiblearray": true,
+	"anycompatiblenonarray": true, "anycompatiblerange": true,
+	"anymultirange": true, "anycompatiblemultirange": true,
+	"pg_brin_bloom_summary": true, "pg_brin_minmax_multi_summary": true,
+}
+
+// isBuiltinType reports whether name is a pg_catalog type.
+func isBuiltinType(name string) bool {
+	if builtinTypes[name] {
+		return true
+	}
+	return strings.HasPrefix(name, "_") && builtinTypes[name[1:]]
+}
+
+// serialTypes maps the serial pseudo-types to their underlying integer
+// types.
+var serialTypes = map[string]string{
+	"smallserial": "int2",
+	"serial2":     "int2",
+	"serial":      "int4",
+	"serial4":     "int4",
+	"bigserial":   "int8",
+	"serial8":     "int8",
+}
+
+// sqlTypeNames maps pg_catalog type names to the SQL-standard spelling
+// format_type uses for them.
+var sqlTypeNames = map[string]string{
+	"bool":        "boolean",
+	"int2":        "smallint",
+	"int4":        "integer",
+	"int8":        "bigint",
+	"float4":      "real",
+	"float8":      "double precision",
+	"varchar":     "character varying",
+	"varbit":      "bit varying",
+	"time":        "time without time zone",
+	"timetz":      "time with time zone",
+	"timestamp":   "timestamp without time zone",
+	"timestamptz": "timestamp with time zone",
+}
+
+// typeNameParts returns the schema (possibly "") and name of a TypeName.
+func typeNameParts(tn *nodes.TypeName) (string, string) {
+	names := stringList(tn.Names)
+	switch len(names) {
+	case 0:
+		return "", ""
+	case 1:
+		return "", names[0]
+	default:
+		return names[len(names)-2], names[len(names)-1]
+	}
+}
+
+// isBuiltinTypeName reports whether tn refers to a pg_catalog type,
+// either explicitly or because an unqualified name matches one.
+func isBuiltinTypeName(tn *nodes.TypeName) bool {
+	schema, name := typeNameParts(tn)
+	return (schema == "" || schema == "pg_catalog") && isBuiltinType(name)
+}
+
+// FormatTypeName renders a type name the way PostgreSQL's format_type
+// does for a column of that type, e.g. "character varying(10)",
+// "numeric(10,2)" or "timestamp(3) with time zone". User-defined types are
+// rendered with the qualification they were written with.
+func FormatTypeName(tn *nodes.TypeName) string {
+	if tn == nil {
+		return ""
+	}
+	schema, name := typeNameParts(tn)
+	mods := typmodValues(tn.Typmods)
+
+	var s string
+	if schema == "" || schema == "pg_catalog" {
+		s = formatBuiltinType(name, mods)
+	}
+	if s == "" {
+		s = QuoteIdentifier(name)
+		if schema != "" && schema != "pg_catalog" {
+			s = QuoteIdentifier(schema) + "." + s
+		}
+		if len(mods) > 0 {
+			s += "(" + joinInts(mods) + ")"
+		}
+	}
+	if tn.PctType {
+		s += "%TYPE"
+	}
+	for range listItems(tn.ArrayBounds) {
+		s += "[]"
+	}
+	if tn.Setof {
+		s = "SETOF " + s
+	}
+	return s
+}
+
+// formatBuiltinType formats a pg_catalog type with its modifiers, or
+// returns "" if name is not a type format_type special-cases.
+func formatBuiltinType(name string, mods []int64) string {
+	switch name {
+	case "bpchar":
+		if len(mods) == 0 {
+			return "bpchar"
+		}
+		return "character(" + joinInts(mods) + ")"
+	case "varchar", "varbit", "bit", "numeric":
+		s := name
+		if sql, ok := sqlTypeNames[name]; ok {
+			s = sql
+		}
+		if len(mods) > 0 {
+			s += "(" + joinInts(mods) + ")"
+		}
+		return s
+	case "time", "timetz", "timestamp", "timestamptz":
+		base, zone, _ := strings.Cut(sqlTypeNames[name], " ")
+		if len(mods) > 0 {
+			base += "(" + joinInts(mods[:1]) + ")"
+		}
+		return base + " " + zone
+	case "interval":
+		return formatInterval(mods)
+	}
+	if sql, ok := sqlTypeNames[name]; ok {
+		return sql
+	}
+	if isBuiltinType(name) && !strings.HasPrefix(name, "_") {
+		return name
+	}
+	return ""
+}
+
+// Interval field masks, from datetime.h.
+const (
+	intervalMaskYear   = 1 << 2
+	intervalMaskMonth  = 1 << 1
+	intervalMaskDay    = 1 << 3
+	intervalMaskHour   = 1 << 10
+	intervalMaskMinute = 1 << 11
+	intervalMaskSecond = 1 << 12
+	intervalFullRange  = 0x7FFF
+)
+
+var intervalFields = map[int64]string{
+	intervalMaskYear:                     " year",
+	intervalMaskMonth:                    " month",
+	intervalMaskDay:                      " day",
+	intervalMaskHour:                     " hour",
+	intervalMaskMinute:                   " minute",
+	intervalMaskSecond:                   " second",
+	intervalMaskYear | intervalMaskMonth: " year to month",
+	intervalMaskDay | intervalMaskHour:   " day to hour",
+	intervalMaskDay | intervalMaskHour | intervalMaskMinute:                      " day to minute",
+	intervalMaskDay | intervalMaskHour | intervalMaskMinute | intervalMaskSecond: " day to second",
+	intervalMaskHour | intervalMaskMinute:                                        " hour to minute",
+	intervalMaskHour | intervalMaskMinute | intervalMaskSecond:                   " hour to second",
+	intervalMaskMinute | intervalMaskSecond:                                      " minute to second",
+}
+
+// formatInterval follows intervaltypmodout: the grammar passes the field
+// mask first and the optional seconds precision second.
+func formatInterval(mods []int64) string {
+	s := "interval"
+	if len(mods) == 0 {
+		return s
+	}
+	if mods[0] != intervalFullRange {
+		s += intervalFields[mods[0]]
+	}
+	if len(mods) > 1 {
+		s += "(" + strconv.FormatInt(mods[1], 10) + ")"
+	}
+	return s
+}
+
+// typmodValues extracts integer type modifiers, which the grammar
+// produces either as bare Integer nodes or wrapped in A_Const.
+func typmodValues(l *nodes.List) []int64 {
+	var out []int64
+	for _, item := range listItems(l) {
+		if c, ok := item.(*nodes.A_Const); ok {
+			item = c.Val
+		}
+		if i, ok := item.(*nodes.Integer); ok {
+			out = append(out, i.Ival)
+		}
+	}
+	return out
+}
+
+func joinInts(vals []int64) string {
+	parts := make([]string, len(vals))
+	for i, v := range vals {
+		parts[i] = strconv.FormatInt(v, 10)
+	}
+	return strings.Join(parts, ",")
+}
+
+// QuoteIdentifier quotes an identifier if necessary, following
+// quote_identifier in ruleutils.c: names that are not all lower-case
+// letters, digits and underscores, or that collide with a non-unreserved
+// keyword, are double-quoted.
+func QuoteIdentifier(ident string) string {
+	safe := ident != "" && (ident[0] >= 'a' && ident[0] <= 'z' || ident[0] == '_')
+	for i := 0; safe && i < len(ident); i++ {
+		ch := ident[i]
+		safe = ch >= 'a' && ch <= 'z' || ch >= '0' && ch <= '9' || ch == '_'
+	}
+	if safe {
+		if kw := parser.LookupKeyword(ident); kw != nil && kw.Category != parser.UnreservedKeyword {
+			safe = false
+		}
+	}
+	if safe {
+		return ident
+	}
+	return `"` + strings.ReplaceAll(ident, `"`, `""`) + `"`
+}
+
+// sameType reports whether two type names denote the same type, ignoring
+// whether a built-in type was written schema-qualified.
+func sameType(a, b *nodes.TypeName) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return FormatTypeName(a) == FormatTypeName(b)
+}
@@ -0,0 +1,739 @@
+package catalog
+
+import (
+	"fmt"
+
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// outputColumn is a column of a query's result.
+type outputColumn struct {
+	name string
+	typ  *nodes.TypeName // nil if it cannot be determined without analysis
+	src  *Column         // table column it is a plain reference to, or nil
+}
+
+// rangeEntry is a FROM-clause item visible to a query's target list.
+type rangeEntry struct {
+	name string
+	cols []outputColumn
+	// complete is false when the columns could not be determined, e.g.
+	// for set-returning functions; references to unknown columns are then
+	// not reported.
+	complete bool
+}
+
+// queryAnalyzer derives the output columns of a raw SELECT and the
+// relations and columns it depends on, doing just enough of parse
+// analysis to create views and CREATE TABLE AS targets.
+type queryAnalyzer struct {
+	c        *Catalog
+	cteNames map[string]bool
+	ctes     map[string][]outputColumn
+
+	relations []*Relation
+	columns   []*Column
+}
+
+func (c *Catalog) analyzeQuery(query nodes.Node) (*queryAnalyzer, []outputColumn, error) {
+	a := &queryAnalyzer{c: c, cteNames: make(map[string]bool), ctes: make(map[string][]outputColumn)}
+	if err := a.checkRelations(query); err != nil {
+		return nil, nil, err
+	}
+	cols, err := a.selectColumns(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, cols, nil
+}
+
+// checkRelations verifies that every relation the query names exists,
+// treating unqualified names of CTEs defined anywhere in the query as
+// CTE references.
+func (a *queryAnalyzer) checkRelations(query nodes.Node) error {
+	nodes.Walk(query, func(n nodes.Node) bool {
+		if cte, ok := n.(*nodes.CommonTableExpr); ok {
+			a.cteNames[cte.Ctename] = true
+		}
+		return true
+	})
+	var err error
+	seen := make(map[*Relation]bool)
+	nodes.Walk(query, func(n nodes.Node) bool {
+		rv, ok := n.(*nodes.RangeVar)
+		if !ok || err != nil {
+			return err == nil
+		}
+		if rv.Schemaname == "" && a.cteNames[rv.Relname] {
+			return false
+		}
+		var rel *Relation
+		rel, err = a.c.relationFor(rv)
+		if err == nil && !seen[rel] {
+			seen[rel] = true
+			a.relations = append(a.relations, rel)
+		}
+		return false
+	})
+	return err
+}
+
+// usesTemp reports whether the query references a temporary relation.
+func (a *queryAnalyzer) usesTemp() bool {
+	for _, r := range a.relations {
+		if r.Persistence == nodes.RELPERSISTENCE_TEMP {
+			return true
+		}
+	}
+	return false
+}
+
+// selectColumns returns the output columns of a SELECT, VALUES or set
+// operation.
+func (a *queryAnalyzer) selectColumns(n nodes.Node) ([]outputColumn, error) {
+	sel, ok := n.(*nodes.SelectStmt)
+	if !ok {
+		// INSERT ... RETURNING in a CTE, EXECUTE in CREATE TABLE AS,
+		// and so on: the columns are not known.
+		return nil, nil
+	}
+	if sel.WithClause != nil {
+		saved := a.ctes
+		a.ctes = make(map[string][]outputColumn, len(saved))
+		for k, v := range saved {
+			a.ctes[k] = v
+		}
+		defer func() { a.ctes = saved }()
+		for _, item := range listItems(sel.WithClause.Ctes) {
+			cte, ok := item.(*nodes.CommonTableExpr)
+			if !ok {
+				continue
+			}
+			cols, err := a.selectColumns(cte.Ctequery)
+			if err != nil {
+				return nil, err
+			}
+			a.ctes[cte.Ctename] = renameColumns(cols, stringList(cte.Aliascolnames))
+		}
+	}
+	if sel.Op != nodes.SETOP_NONE {
+		cols, err := a.selectColumns(sel.Larg)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := a.selectColumns(sel.Rarg); err != nil {
+			return nil, err
+		}
+		return cols, nil
+	}
+	if sel.ValuesLists != nil {
+		var cols []outputColumn
+		if rows := listItems(sel.ValuesLists); len(rows) > 0 {
+			for i, v := range listItems(asList(rows[0])) {
+				cols = append(cols, outputColumn{name: fmt.Sprintf("column%d", i+1), typ: exprType(v, nil)})
+			}
+		}
+		return cols, nil
+	}
+
+	var scope []*rangeEntry
+	var star []outputColumn
+	lateral := false
+	for _, item := range listItems(sel.FromClause) {
+		entries, cols, lat, err := a.fromItem(item)
+		if err != nil {
+			return nil, err
+		}
+		scope = append(scope, entries...)
+		star = append(star, cols...)
+		lateral = lateral || lat
+	}
+	strict := !lateral
+	for _, e := range scope {
+		strict = strict && e.complete
+	}
+	a.recordColumnRefs(sel, scope)
+
+	var out []outputColumn
+	for _, item := range listItems(sel.TargetList) {
+		rt, ok := item.(*nodes.ResTarget)
+		if !ok {
+			continue
+		}
+		if cr, ok := rt.Val.(*nodes.ColumnRef); ok && isStarRef(cr) {
+			cols, err := expandStar(cr, scope, star, strict)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, cols...)
+			continue
+		}
+		col := outputColumn{name: rt.Name}
+		if col.name == "" {
+			col.name = figureColname(rt.Val)
+		}
+		if cr, ok := rt.Val.(*nodes.ColumnRef); ok {
+			src, err := resolveColumnRef(cr, scope, strict)
+			if err != nil {
+				return nil, err
+			}
+			if src != nil {
+				col.typ, col.src = src.typ, src.src
+			}
+		} else {
+			col.typ = exprType(rt.Val, scope)
+		}
+		out = append(out, col)
+	}
+	return out, nil
+}
+
+// fromItem returns the range entries a FROM item makes visible and the
+// columns it contributes to SELECT *.
+func (a *queryAnalyzer) fromItem(item nodes.Node) ([]*rangeEntry, []outputColumn, bool, error) {
+	switch n := item.(type) {
+	case *nodes.RangeVar:
+		e := &rangeEntry{name: n.Relname, complete: true}
+		if cols, ok := a.ctes[n.Relname]; ok && n.Schemaname == "" {
+			e.cols = cols
+		} else if rel := a.c.LookupRelation(n.Schemaname, n.Relname); rel != nil {
+			for _, col := range rel.Columns {
+				e.cols = append(e.cols, outputColumn{name: col.Name, typ: col.Type, src: col})
+			}
+		} else {
+			// A reference to a CTE that is still being defined.
+			e.complete = false
+		}
+		applyAlias(e, n.Alias)
+		return []*rangeEntry{e}, e.cols, false, nil
+
+	case *nodes.RangeSubselect:
+		cols, err := a.selectColumns(n.Subquery)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		e := &rangeEntry{cols: cols, complete: cols != nil}
+		applyAlias(e, n.Alias)
+		return []*rangeEntry{e}, e.cols, n.Lateral, nil
+
+	case *nodes.JoinExpr:
+		left, lstar, llat, err := a.fromItem(n.Larg)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		right, rstar, rlat, err := a.fromItem(n.Rarg)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		using := stringList(n.UsingClause)
+		if n.IsNatural {
+			for _, l := range lstar {
+				for _, r := range rstar {
+					if l.name == r.name {
+						using = append(using, l.name)
+					}
+				}
+			}
+		}
+		var star []outputColumn
+		merged := make(map[string]bool)
+		for _, name := range using {
+			for _, l := range lstar {
+				if l.name == name && !merged[name] {
+					merged[name] = true
+					star = append(star, l)
+				}
+			}
+		}
+		for _, l := range lstar {
+			if !merged[l.name] {
+				star = append(star, l)
+			}
+		}
+		for _, r := range rstar {
+			if !merged[r.name] {
+				star = append(star, r)
+			}
+		}
+		entries := append(left, right...)
+		if n.Alias != nil {
+			e := &rangeEntry{cols: star, complete: true}
+			for _, x := range entries {
+				e.complete = e.complete && x.complete
+			}
+			applyAlias(e, n.Alias)
+			entries, star = []*rangeEntry{e}, e.cols
+		}
+		return entries, star, llat || rlat, nil
+
+	case *nodes.RangeFunction:
+		e := &rangeEntry{}
+		if n.Alias != nil {
+			e.name = n.Alias.Aliasname
+			for _, name := range stringList(n.Alias.Colnames) {
+				e.cols = append(e.cols, outputColumn{name: name})
+			}
+		}
+		for _, item := range listItems(n.Coldeflist) {
+			if def, ok := item.(*nodes.ColumnDef); ok {
+				e.cols = append(e.cols, outputColumn{name: def.Colname, typ: def.TypeName})
+			}
+		}
+		if e.name == "" {
+			if fns := listItems(n.Functions); len(fns) > 0 {
+				if pair := listItems(asList(fns[0])); len(pair) > 0 {
+					e.name = figureColname(pair[0])
+				}
+			}
+		}
+		return []*rangeEntry{e}, e.cols, n.Lateral, nil
+	}
+	return []*rangeEntry{{}}, nil, false, nil
+}
+
+// applyAlias renames a range entry and its leading columns.
+func applyAlias(e *rangeEntry, alias *nodes.Alias) {
+	if alias == nil {
+		return
+	}
+	e.name = alias.Aliasname
+	e.cols = renameColumns(e.cols, stringList(alias.Colnames))
+}
+
+func renameColumns(cols []outputColumn, names []string) []outputColumn {
+	if len(names) == 0 {
+		return cols
+	}
+	out := append([]outputColumn(nil), cols...)
+	for i, name := range names {
+		if i < len(out) {
+			out[i].name = name
+		}
+	}
+	return out
+}
+
+func isStarRef(cr *nodes.ColumnRef) bool {
+	fields := listItems(cr.Fields)
+	if len(fields) == 0 {
+		return false
+	}
+	_, ok := fields[len(fields)-1].(*nodes.A_Star)
+	return ok
+}
+
+// expandStar expands * or rel.* in a target list.
+func expandStar(cr *nodes.ColumnRef, scope []*rangeEntry, star []outputColumn, strict bool) ([]outputColumn, error) {
+	quals := stringList(cr.Fields)
+	if len(quals) == 0 {
+		if len(scope) == 0 {
+			return nil, errorf(CodeSyntaxError, "SELECT * with no tables specified is not valid")
+		}
+		return star, nil
+	}
+	name := quals[len(quals)-1]
+	for _, e := range scope {
+		if e.name == name {
+			return e.cols, nil
+		}
+	}
+	if strict {
+		return nil, errorf(CodeUndefinedTable, "missing FROM-clause entry for table %q", name)
+	}
+	return nil, nil
+}
+
+// resolveColumnRef finds the column a reference names. When strict is
+// set, references to unknown columns are errors.
+func resolveColumnRef(cr *nodes.ColumnRef, scope []*rangeEntry, strict bool) (*outputColumn, error) {
+	fields := stringList(cr.Fields)
+	switch len(fields) {
+	case 0:
+		return nil, nil
+	case 1:
+		for _, e := range scope {
+			for i := range e.cols {
+				if e.cols[i].name == fields[0] {
+					return &e.cols[i], nil
+				}
+			}
+		}
+		if strict {
+			return nil, errorf(CodeUndefinedColumn, "column %q does not exist", fields[0])
+		}
+		return nil, nil
+	}
+	rel, col := fields[len(fields)-2], fields[len(fields)-1]
+	for _, e := range scope {
+		if e.name != rel {
+			continue
+		}
+		for i := range e.cols {
+			if e.cols[i].name == col {
+				return &e.cols[i], nil
+			}
+		}
+		if strict && e.complete {
+			return nil, errorf(CodeUndefinedColumn, "column %s.%s does not exist", rel, col)
+		}
+		return nil, nil
+	}
+	if len(fields) == 2 {
+		// Possibly a field of a composite column: (col).field.
+		for _, e := range scope {
+			for _, c := range e.cols {
+				if c.name == rel {
+					return nil, nil
+				}
+			}
+		}
+	}
+	if strict {
+		return nil, errorf(CodeUndefinedTable, "missing FROM-clause entry for table %q", rel)
+	}
+	return nil, nil
+}
+
+// recordColumnRefs notes the table columns referenced anywhere in a
+// SELECT outside of sub-selects, including those a * expands to.
+func (a *queryAnalyzer) recordColumnRefs(sel *nodes.SelectStmt, scope []*rangeEntry) {
+	seen := make(map[*Column]bool)
+	for _, col := range a.columns {
+		seen[col] = true
+	}
+	note := func(oc *outputColumn) {
+		if oc != nil && oc.src != nil && !seen[oc.src] {
+			seen[oc.src] = true
+			a.columns = append(a.columns, oc.src)
+		}
+	}
+	visit := func(n nodes.Node) bool {
+		switch n := n.(type) {
+		case *nodes.SubLink, *nodes.RangeSubselect, *nodes.SelectStmt:
+			return false
+		case *nodes.ColumnRef:
+			if isStarRef(n) {
+				cols, _ := expandStar(n, scope, allColumns(scope), false)
+				for i := range cols {
+					note(&cols[i])
+				}
+			} else {
+				oc, _ := resolveColumnRef(n, scope, false)
+				note(oc)
+			}
+			return false
+		}
+		return true
+	}
+	for _, part := range []nodes.Node{sel.TargetList, sel.WhereClause, sel.GroupClause, sel.HavingClause, sel.SortClause, sel.DistinctClause, sel.WindowClause} {
+		if l, ok := part.(*nodes.List); ok && l == nil {
+			continue
+		}
+		nodes.Walk(part, visit)
+	}
+	for _, item := range listItems(sel.FromClause) {
+		nodes.Walk(item, func(n nodes.Node) bool {
+			if j, ok := n.(*nodes.JoinExpr); ok {
+				nodes.Walk(j.Quals, visit)
+				for _, name := range stringList(j.UsingClause) {
+					oc, _ := resolveColumnRef(&nodes.ColumnRef{Fields: &nodes.List{Items: []nodes.Node{&nodes.String{Sval: name}}}}, scope, false)
+					note(oc)
+				}
+			}
+			_, sub := n.(*nodes.RangeSubselect)
+			return !sub
+		})
+	}
+}
+
+func allColumns(scope []*rangeEntry) []outputColumn {
+	var out []outputColumn
+	for _, e := range scope {
+		out = append(out, e.cols...)
+	}
+	return out
+}
+
+// exprType returns the type of an expression when it is evident from the
+// raw tree: casts, literals and column references.
+func exprType(expr nodes.Node, scope []*rangeEntry) *nodes.TypeName {
+	switch e := expr.(type) {
+	case *nodes.TypeCast:
+		return e.TypeName
+	case *nodes.A_Const:
+		switch e.Val.(type) {
+		case *nodes.Integer:
+			return builtinTypeName("int4")
+		case *nodes.Float:
+			return builtinTypeName("numeric")
+		case *nodes.String:
+			return &nodes.TypeName{Names: &nodes.List{Items: []nodes.Node{&nodes.String{Sval: "text"}}}, Location: -1}
+		case *nodes.Boolean:
+			return builtinTypeName("bool")
+		}
+	case *nodes.ColumnRef:
+		if oc, _ := resolveColumnRef(e, scope, false); oc != nil {
+			return oc.typ
+		}
+	}
+	return nil
+}
+
+// figureColname chooses the name of an unnamed result column, following
+// FigureColname in parse_target.c.
+func figureColname(expr nodes.Node) string {
+	name, _ := figureColnameInternal(expr)
+	if name == "" {
+		return "?column?"
+	}
+	return name
+}
+
+// figureColnameInternal returns a name and its strength: 2 for a name
+// taken from a column or function, 1 for a fallback like "case".
+func figureColnameInternal(expr nodes.Node) (string, int) {
+	switch e := expr.(type) {
+	case *nodes.ColumnRef:
+		fields := listItems(e.Fields)
+		for i := len(fields) - 1; i >= 0; i-- {
+			if s, ok := fields[i].(*nodes.String); ok {
+				return s.Sval, 2
+			}
+		}
+	case *nodes.A_Indirection:
+		ind := listItems(e.Indirection)
+		for i := len(ind) - 1; i >= 0; i-- {
+			if s, ok := ind[i].(*nodes.String); ok {
+				return s.Sval, 2
+			}
+		}
+		return figureColnameInternal(e.Arg)
+	case *nodes.FuncCall:
+		names := stringList(e.Funcname)
+		if len(names) > 0 {
+			return names[len(names)-1], 2
+		}
+	case *nodes.A_Expr:
+		if e.Kind == nodes.AEXPR_NULLIF {
+			return "nullif", 2
+		}
+	case *nodes.TypeCast:
+		name, strength := figureColnameInternal(e.Arg)
+		if strength <= 1 && e.TypeName != nil {
+			_, typ := typeNameParts(e.TypeName)
+			if typ != "" {
+				return typ, 1
+			}
+		}
+		return name, strength
+	case *nodes.CollateClause:
+		return figureColnameInternal(e.Arg)
+	case *nodes.GroupingFunc:
+		return "grouping", 2
+	case *nodes.SubLink:
+		switch nodes.SubLinkType(e.SubLinkType) {
+		case nodes.EXISTS_SUBLINK:
+			return "exists", 2
+		case nodes.ARRAY_SUBLINK:
+			return "array", 2
+		case nodes.EXPR_SUBLINK, nodes.MULTIEXPR_SUBLINK:
+			if sel, ok := e.Subselect.(*nodes.SelectStmt); ok {
+				if targets := listItems(sel.TargetList); len(targets) > 0 {
+					if rt, ok := targets[0].(*nodes.ResTarget); ok {
+						if rt.Name != "" {
+							return rt.Name, 2
+						}
+						return figureColnameInternal(rt.Val)
+					}
+				}
+			}
+		}
+	case *nodes.CaseExpr:
+		name, strength := figureColnameInternal(e.Defresult)
+		if strength <= 1 {
+			return "case", 1
+		}
+		return name, strength
+	case *nodes.A_ArrayExpr:
+		return "array", 2
+	case *nodes.RowExpr:
+		return "row", 2
+	case *nodes.CoalesceExpr:
+		return "coalesce", 2
+	case *nodes.MinMaxExpr:
+		if e.Op == nodes.IS_GREATEST {
+			return "greatest", 2
+		}
+		return "least", 2
+	case *nodes.SQLValueFunction:
+		return sqlValueFunctionNames[e.Op], 2
+	case *nodes.XmlSerialize:
+		return "xmlserialize", 2
+	}
+	return "", 0
+}
+
+var sqlValueFunctionNames = map[nodes.SQLValueFunctionOp]string{
+	nodes.SVFOP_CURRENT_DATE:        "current_date",
+	nodes.SVFOP_CURRENT_TIME:        "current_time",
+	nodes.SVFOP_CURRENT_TIME_N:      "current_time",
+	nodes.SVFOP_CURRENT_TIMESTAMP:   "current_timestamp",
+	nodes.SVFOP_CURRENT_TIMESTAMP_N: "current_timestamp",
+	nodes.SVFOP_LOCALTIME:           "localtime",
+	nodes.SVFOP_LOCALTIME_N:         "localtime",
+	nodes.SVFOP_LOCALTIMESTAMP:      "localtimestamp",
+	nodes.SVFOP_LOCALTIMESTAMP_N:    "localtimestamp",
+	nodes.SVFOP_CURRENT_ROLE:        "current_role",
+	nodes.SVFOP_CURRENT_USER:        "current_user",
+	nodes.SVFOP_USER:                "user",
+	nodes.SVFOP_SESSION_USER:        "session_user",
+	nodes.SVFOP_CURRENT_CATALOG:     "current_catalog",
+	nodes.SVFOP_CURRENT_SCHEMA:      "current_schema",
+}
+
+// createView implements CREATE [OR REPLACE] VIEW, following DefineView.
+func (c *Catalog) createView(n *nodes.ViewStmt) error {
+	a, cols, err := c.analyzeQuery(n.Query)
+	if err != nil {
+		return err
+	}
+	aliases := stringList(n.Aliases)
+	if len(aliases) > len(cols) && cols != nil {
+		return errorf(CodeSyntaxError, "CREATE VIEW specifies more column names than columns")
+	}
+	cols = renameColumns(cols, aliases)
+	seen := make(map[string]bool)
+	for _, col := range cols {
+		if seen[col.name] {
+			return errorf(CodeDuplicateColumn, "column %q specified more than once", col.name)
+		}
+		seen[col.name] = true
+	}
+
+	rv := *n.View
+	if rv.Relpersistence != nodes.RELPERSISTENCE_TEMP && a.usesTemp() {
+		rv.Relpersistence = nodes.RELPERSISTENCE_TEMP
+		c.notice("view %q will be a temporary view", rv.Relname)
+	}
+	if n.Replace {
+		s, err := c.relationSchema(&rv)
+		if err != nil {
+			return err
+		}
+		if old := s.relations[rv.Relname]; old != nil {
+			return c.replaceView(old, n, a, cols)
+		}
+	}
+	rel, err := c.newRelation(&rv, RelKindView, false)
+	if err != nil {
+		return err
+	}
+	rel.Options = n.Options
+	rel.Query = n.Query
+	rel.CheckOption = n.WithCheckOption
+	for _, col := range cols {
+		rel.Columns = append(rel.Columns, &Column{Relation: rel, Name: col.name, Type: col.typ, IsLocal: true})
+	}
+	c.addRelation(rel)
+	return c.recordQueryDependencies(rel, a)
+}
+
+// replaceView implements CREATE OR REPLACE VIEW for an existing view,
+// which may only add columns at the end, following checkViewColumns.
+func (c *Catalog) replaceView(rel *Relation, n *nodes.ViewStmt, a *queryAnalyzer, cols []outputColumn) error {
+	if rel.Kind != RelKindView {
+		return errorf(CodeWrongObjectType, "%q is not a view", rel.Name)
+	}
+	if len(cols) < len(rel.Columns) {
+		return errorf(CodeInvalidTableDefinition, "cannot drop columns from view")
+	}
+	newCols := append([]*Column(nil), rel.Columns...)
+	for i, oc := range cols {
+		if i >= len(rel.Columns) {
+			newCols = append(newCols, &Column{Relation: rel, Name: oc.name, Type: oc.typ, IsLocal: true})
+			continue
+		}
+		old := rel.Columns[i]
+		if old.Name != oc.name {
+			return errorf(CodeInvalidTableDefinition, "cannot change name of view column %q to %q", old.Name, oc.name).
+				withHint("Use ALTER VIEW ... RENAME COLUMN ... to change name of view column instead.")
+		}
+		if old.Type != nil && oc.typ != nil && !sameType(old.Type, oc.typ) {
+			return errorf(CodeInvalidTableDefinition, "cannot change data type of view column %q from %s to %s",
+				old.Name, old.TypeString(), FormatTypeName(oc.typ))
+		}
+		if old.Type == nil && oc.typ != nil {
+			set(c, &old.Type, oc.typ)
+		}
+	}
+	set(c, &rel.Columns, newCols)
+	set(c, &rel.Query, n.Query)
+	set(c, &rel.Options, n.Options)
+	set(c, &rel.CheckOption, n.WithCheckOption)
+	c.removeDependencies(addr(rel), depNormal)
+	return c.recordQueryDependencies(rel, a)
+}
+
+// recordQueryDependencies records that a view or materialized view
+// depends on the relations and columns its query uses.
+func (c *Catalog) recordQueryDependencies(rel *Relation, a *queryAnalyzer) error {
+	for _, r := range a.relations {
+		c.recordDependency(addr(rel), addr(r), depNormal)
+	}
+	for _, col := range a.columns {
+		c.recordDependency(addr(rel), addr(col), depNormal)
+	}
+	for _, col := range rel.Columns {
+		if err := c.recordColumnDependencies(col); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createTableAs implements CREATE TABLE AS, SELECT INTO and CREATE
+// MATERIALIZED VIEW.
+func (c *Catalog) createTableAs(n *nodes.CreateTableAsStmt) error {
+	a, cols, err := c.analyzeQuery(n.Query)
+	if err != nil {
+		return err
+	}
+	kind := RelKindTable
+	if n.Objtype == nodes.OBJECT_MATVIEW {
+		kind = RelKindMatView
+		if a.usesTemp() {
+			return errorf(CodeFeatureNotSupported, "materialized views must not use temporary tables or views")
+		}
+	}
+	names := stringList(n.Into.ColNames)
+	if len(names) > len(cols) && cols != nil {
+		return errorf(CodeSyntaxError, "too many column names were specified")
+	}
+	cols = renameColumns(cols, names)
+	seen := make(map[string]bool)
+	for _, col := range cols {
+		if seen[col.name] {
+			return errorf(CodeDuplicateColumn, "column %q specified more than once", col.name)
+		}
+		seen[col.name] = true
+	}
+	rel, err := c.newRelation(n.Into.Rel, kind, n.IfNotExists)
+	if rel == nil {
+		return err
+	}
+	rel.Options = n.Into.Options
+	for _, col := range cols {
+		rel.Columns = append(rel.Columns, &Column{Relation: rel, Name: col.name, Type: col.typ, IsLocal: true})
+	}
+	c.addRelation(rel)
+	if kind == RelKindMatView {
+		rel.Query = n.Query
+		return c.recordQueryDependencies(rel, a)
+	}
+	for _, col := range rel.Columns {
+		if err := c.recordColumnDependencies(col); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Command pgfmt formats SQL in a canonical layout.
+//
+// Usage:
+//
+//	pgfmt [-w | -l] [-lower] [-indent n] [-width n] [-leading-commas] [-break-joins=false] [-break-conditions] [-align=false] [file ...]
+//
+// With no files, pgfmt reads standard input and writes the formatted SQL to
+// standard output. With -w it rewrites each file in place, and with -l it
+// lists the files whose formatting differs instead, or that have
+// statements pgfmt cannot format. The formatted SQL is parsed again and
+// checked to produce the same parse tree as the input before anything is
+// written. Statements left as they are, because they have comments inside
+// them or are of a kind pgfmt does not format, are reported on standard
+// error. pgfmt exits with status 1 if -l lists any file and 2 if a file
+// cannot be read, parsed or written.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pgplex/pgparser/format"
+)
+
+func main() {
+	write := flag.Bool("w", false, "write the result to the file instead of standard output")
+	list := flag.Bool("l", false, "list files whose formatting differs")
+	lower := flag.Bool("lower", false, "write keywords in lower case")
+	indent := flag.Int("indent", 4, "indentation width")
+	width := flag.Int("width", 80, "line width")
+	leadingCommas := flag.Bool("leading-commas", false, "place commas at the start of broken lines")
+	breakJoins := flag.Bool("break-joins", true, "start every JOIN on a new line")
+	breakConditions := flag.Bool("break-conditions", false, "place each AND or OR operand of a condition on its own line")
+	align := flag.Bool("align", true, "align CREATE TABLE column definitions")
+	flag.Parse()
+
+	opts := format.Options{
+		KeywordCase:     format.Upper,
+		IndentWidth:     *indent,
+		LineWidth:       *width,
+		LeadingCommas:   *leadingCommas,
+		BreakJoins:      *breakJoins,
+		BreakConditions: *breakConditions,
+		AlignColumns:    *align,
+	}
+	if *lower {
+		opts.KeywordCase = format.Lower
+	}
+	if *write && *list {
+		fmt.Fprintln(os.Stderr, "pgfmt: -w and -l cannot be used together")
+		os.Exit(2)
+	}
+
+	files := flag.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	if *write {
+		for _, name := range files {
+			if name == "-" {
+				fmt.Fprintln(os.Stderr, "pgfmt: cannot use -w with standard input")
+				os.Exit(2)
+			}
+		}
+	}
+
+	status := 0
+	for _, name := range files {
+		src, out, skipped, err := formatFile(name, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", displayName(name), err)
+			status = 2
+			continue
+		}
+		for _, s := range skipped {
+			fmt.Fprintf(os.Stderr, "%s:%s\n", displayName(name), s)
+		}
+		switch {
+		case *list:
+			if out != src || len(skipped) > 0 {
+				fmt.Println(displayName(name))
+				if status == 0 {
+					status = 1
+				}
+			}
+		case *write:
+			if out == src {
+				continue
+			}
+			if err := os.WriteFile(name, []byte(out), 0o666); err != nil {
+				fmt.Fprintf(os.Stderr, "pgfmt: %v\n", err)
+				status = 2
+			}
+		default:
+			fmt.Print(out)
+		}
+	}
+	os.Exit(status)
+}
+
+// formatFile returns the contents of the named file, their formatted form
+// and the statements left unformatted. format.FormatSkipped has checked
+// that the two parse to the same statements.
+func formatFile(name string, opts format.Options) (src, out string, skipped []format.Skipped, err error) {
+	var data []byte
+	if name == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(name)
+	}
+	if err != nil {
+		return "", "", nil, err
+	}
+	src = string(data)
+	out, skipped, err = format.FormatSkipped(src, opts)
+	if err != nil {
+		return "", "", nil, err
+	}
+	return src, out, skipped, nil
+}
+
+func displayName(name string) string {
+	if name == "-" {
+		return "<stdin>"
+	}
+	return name
+}
@@ -0,0 +1,138 @@
+// Command pglint checks SQL migration files for DDL that is unsafe to run
+// against a live database.
+//
+// Usage:
+//
+//	pglint [-format text|json] [-disable id,...] [-min-severity level] [file ...]
+//
+// With no files, pglint reads standard input. It exits with status 1 if any
+// error-level finding is reported and 2 if a file cannot be read or parsed.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pgplex/pgparser/lint"
+)
+
+type jsonDiagnostic struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Location int    `json:"location"`
+	Length   int    `json:"length"`
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Fix      string `json:"fix"`
+}
+
+func main() {
+	format := flag.String("format", "text", "output format: text or json")
+	disable := flag.String("disable", "", "comma-separated list of rule IDs to skip")
+	minSeverity := flag.String("min-severity", "info", "lowest severity to report: info, warning or error")
+	listRules := flag.Bool("rules", false, "list the available rules and exit")
+	flag.Parse()
+
+	linter := lint.New()
+	if *listRules {
+		for _, r := range linter.Rules() {
+			fmt.Printf("%-40s %-8s %s\n", r.ID, r.Severity, r.Description)
+		}
+		return
+	}
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "pglint: unknown format %q\n", *format)
+		os.Exit(2)
+	}
+	if *disable != "" {
+		if err := linter.Disable(strings.Split(*disable, ",")...); err != nil {
+			fmt.Fprintf(os.Stderr, "pglint: %v\n", err)
+			os.Exit(2)
+		}
+	}
+	min, err := lint.ParseSeverity(*minSeverity)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "pglint: %v\n", err)
+		os.Exit(2)
+	}
+
+	files := flag.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	status := 0
+	var out []jsonDiagnostic
+	for _, name := range files {
+		diags, err := lintFile(linter, name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", displayName(name), err)
+			status = 2
+			continue
+		}
+		for _, d := range diags {
+			if d.Severity < min {
+				continue
+			}
+			if d.Severity == lint.SeverityError && status == 0 {
+				status = 1
+			}
+			if *format == "json" {
+				out = append(out, jsonDiagnostic{
+					File:     displayName(name),
+					Line:     d.Line,
+					Column:   d.Column,
+					Location: d.Location,
+					Length:   d.StmtLen,
+					Rule:     d.RuleID,
+					Severity: d.Severity.String(),
+					Message:  d.Message,
+					Fix:      d.Fix,
+				})
+				continue
+			}
+			fmt.Printf("%s:%s\n", displayName(name), d)
+			fmt.Printf("\tfix: %s\n", d.Fix)
+		}
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if out == nil {
+			out = []jsonDiagnostic{}
+		}
+		if err := enc.Encode(out); err != nil {
+			fmt.Fprintf(os.Stderr, "pglint: %v\n", err)
+			os.Exit(2)
+		}
+	}
+	os.Exit(status)
+}
+
+func lintFile(linter *lint.Linter, name string) ([]lint.Diagnostic, error) {
+	var data []byte
+	var err error
+	if name == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return linter.Lint(string(data))
+}
+
+func displayName(name string) string {
+	if name == "-" {
+		return "<stdin>"
+	}
+	return name
+}
@@ -0,0 +1,236 @@
+// Command pgparse parses SQL and prints what the parser makes of it.
+//
+// Usage:
+//
+//	pgparse [-format json|tree|sql|fingerprint|normalize|scan|split] [file ...]
+//
+// The formats are:
+//
+//	json         the parse tree as JSON, in the shape of pg_query's output
+//	tree         the parse tree of each statement in nodeToString form
+//	sql          the statements formatted back into SQL
+//	fingerprint  a hash of each statement that ignores constants and layout
+//	normalize    the SQL with its constants replaced by $n parameters
+//	scan         the tokens, one per line: start, end, name, keyword kind, text
+//	split        the span of each statement, one per line: start and length
+//
+// With no files, pgparse reads standard input. With more than one, each
+// line of output is prefixed with the file name. pgparse exits with status
+// 1 if any input does not parse and 2 if a file cannot be read or the
+// arguments are invalid; errors are reported as file:line:column.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pgplex/pgparser/format"
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+)
+
+// pgVersionNum is the PG_VERSION_NUM of the grammar, which pg_query's
+// JSON output records.
+const pgVersionNum = 170007
+
+var formats = map[string]func(sql string) (string, error){
+	"json":        toJSON,
+	"tree":        toTree,
+	"sql":         toSQL,
+	"fingerprint": toFingerprint,
+	"normalize":   toNormalized,
+	"scan":        toTokens,
+	"split":       toSpans,
+}
+
+func main() {
+	outFormat := flag.String("format", "json", "output format: json, tree, sql, fingerprint, normalize, scan or split")
+	flag.Parse()
+
+	conv, ok := formats[*outFormat]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "pgparse: unknown format %q\n", *outFormat)
+		os.Exit(2)
+	}
+	files := flag.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	status := 0
+	for _, name := range files {
+		sql, err := readFile(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "pgparse: %v\n", err)
+			status = 2
+			continue
+		}
+		out, err := conv(sql)
+		if err != nil {
+			var perr *parser.ParseError
+			if errors.As(err, &perr) {
+				line, col := lineCol(sql, perr.Position)
+				fmt.Fprintf(os.Stderr, "%s:%d:%d: %v\n", displayName(name), line, col, err)
+			} else {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", displayName(name), err)
+			}
+			if status == 0 {
+				status = 1
+			}
+			continue
+		}
+		if len(files) > 1 {
+			out = prefixLines(displayName(name)+":", out)
+		}
+		fmt.Print(out)
+	}
+	os.Exit(status)
+}
+
+func toJSON(sql string) (string, error) {
+	raws, err := parser.ParseRaw(sql)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, `{"version":%d,"stmts":[`, pgVersionNum)
+	for i, raw := range raws {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString(`{"stmt":` + nodes.NodeToJSON(raw.Stmt))
+		if raw.StmtLocation != 0 {
+			fmt.Fprintf(&b, `,"stmt_location":%d`, raw.StmtLocation)
+		}
+		if raw.StmtLen != 0 {
+			fmt.Fprintf(&b, `,"stmt_len":%d`, raw.StmtLen)
+		}
+		b.WriteString("}")
+	}
+	b.WriteString("]}\n")
+	return b.String(), nil
+}
+
+func toTree(sql string) (string, error) {
+	stmts, err := parser.Parse(sql)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, stmt := range stmts.Items {
+		b.WriteString(nodes.NodeToString(stmt) + "\n")
+	}
+	return b.String(), nil
+}
+
+func toSQL(sql string) (string, error) {
+	return format.Format(sql, format.DefaultOptions())
+}
+
+func toFingerprint(sql string) (string, error) {
+	stmts, err := parser.Parse(sql)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, stmt := range stmts.Items {
+		fmt.Fprintf(&b, "%016x\n", nodes.Fingerprint(stmt))
+	}
+	return b.String(), nil
+}
+
+func toNormalized(sql string) (string, error) {
+	out, err := parser.Normalize(sql)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	return out, nil
+}
+
+// keywordKinds are the names pg_query gives the keyword categories.
+var keywordKinds = map[parser.KeywordCategory]string{
+	parser.UnreservedKeyword:   "UNRESERVED_KEYWORD",
+	parser.ColNameKeyword:      "COL_NAME_KEYWORD",
+	parser.TypeFuncNameKeyword: "TYPE_FUNC_NAME_KEYWORD",
+	parser.ReservedKeyword:     "RESERVED_KEYWORD",
+}
+
+func toTokens(sql string) (string, error) {
+	toks, err := parser.Scan(sql)
+	var b strings.Builder
+	for _, tok := range toks {
+		kind := "NO_KEYWORD"
+		if tok.Keyword != nil {
+			kind = keywordKinds[tok.Keyword.Category]
+		}
+		fmt.Fprintf(&b, "%d\t%d\t%s\t%s\t%q\n", tok.Start, tok.End, tok.Name, kind, sql[tok.Start:tok.End])
+	}
+	if err != nil {
+		// Print the tokens before the error too.
+		fmt.Print(b.String())
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func toSpans(sql string) (string, error) {
+	raws, err := parser.ParseRaw(sql)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, raw := range raws {
+		length := int(raw.StmtLen)
+		if length == 0 {
+			length = len(sql) - int(raw.StmtLocation)
+		}
+		fmt.Fprintf(&b, "%d\t%d\n", raw.StmtLocation, length)
+	}
+	return b.String(), nil
+}
+
+func readFile(name string) (string, error) {
+	var data []byte
+	var err error
+	if name == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(name)
+	}
+	return string(data), err
+}
+
+// lineCol converts a byte offset into 1-based line and column numbers.
+func lineCol(src string, offset int) (int, int) {
+	if offset > len(src) {
+		offset = len(src)
+	}
+	line := 1 + strings.Count(src[:offset], "\n")
+	col := offset - strings.LastIndexByte(src[:offset], '\n')
+	return line, col
+}
+
+func prefixLines(prefix, s string) string {
+	lines := strings.SplitAfter(s, "\n")
+	var b strings.Builder
+	for _, line := range lines {
+		if line != "" {
+			b.WriteString(prefix + line)
+		}
+	}
+	return b.String()
+}
+
+func displayName(name string) string {
+	if name == "-" {
+		return "<stdin>"
+	}
+	return name
+}
@@ -0,0 +1,322 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// catalogFile is the file of package sema that -catalog generates.
+const catalogFile = "builtinproc_generated.go"
+
+// uncallableTypes are the pseudo-types of functions that SQL cannot call
+// directly: I/O and support functions, handlers and trigger functions.
+var uncallableTypes = map[string]bool{
+	"internal":         true,
+	"cstring":          true,
+	"trigger":          true,
+	"event_trigger":    true,
+	"language_handler": true,
+	"fdw_handler":      true,
+	"index_am_handler": true,
+	"table_am_handler": true,
+	"tsm_handler":      true,
+}
+
+// generateCatalog writes builtinproc_generated.go to semaDir, from the
+// pg_type.dat, pg_proc.dat and pg_operator.dat files of catalogDir.
+func generateCatalog(catalogDir, semaDir string) error {
+	code, err := genCatalog(catalogDir, semaDir)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(semaDir, catalogFile), code, 0644)
+}
+
+// checkCatalog reports whether builtinproc_generated.go is stale.
+func checkCatalog(catalogDir, semaDir string) ([]string, error) {
+	code, err := genCatalog(catalogDir, semaDir)
+	if err != nil {
+		return nil, err
+	}
+	if msg := compareFile(filepath.Join(semaDir, catalogFile), code); msg != "" {
+		return []string{msg}, nil
+	}
+	return nil, nil
+}
+
+// genCatalog returns the built-in function and operator tables of package
+// sema. A row is left out if any of its types has no OID constant in
+// semaDir, so the tables only mention types the analyzer knows.
+//
+// builtinProcTable gets the plain functions of pg_proc.dat (prokind 'f')
+// without parameter names or OUT parameters, which builtinNamedProcs
+// describes, and without functions named after a type that take one
+// argument, since such a call is analyzed as a cast. builtinOperatorTable
+// gets every operator of pg_operator.dat.
+func genCatalog(catalogDir, semaDir string) ([]byte, error) {
+	read := func(name string) ([]map[string]string, error) {
+		src, err := os.ReadFile(filepath.Join(catalogDir, name))
+		if err != nil {
+			return nil, err
+		}
+		rows, err := parseCatalogData(string(src))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		return rows, nil
+	}
+	typeRows, err := read("pg_type.dat")
+	if err != nil {
+		return nil, err
+	}
+	procRows, err := read("pg_proc.dat")
+	if err != nil {
+		return nil, err
+	}
+	operRows, err := read("pg_operator.dat")
+	if err != nil {
+		return nil, err
+	}
+	consts, err := loadOidConsts(semaDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// symbols maps type names, including those of the implicit array
+	// types, to the sema constants of their OIDs.
+	symbols := map[string]string{}
+	typeNames := map[string]bool{}
+	for _, t := range typeRows {
+		name := t["typname"]
+		typeNames[name] = true
+		sym := t["oid_symbol"]
+		if sym == "" {
+			sym = typeSymbol(name)
+		}
+		if consts[sym] {
+			symbols[name] = sym
+		}
+		if t["array_type_oid"] != "" && consts[typeSymbol("_"+name)] {
+			symbols["_"+name] = typeSymbol("_" + name)
+		}
+	}
+	oid := func(name string) (string, bool) {
+		if uncallableTypes[name] {
+			return "", false
+		}
+		sym, ok := symbols[name]
+		return sym, ok
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by pgsema-gen. DO NOT EDIT.\n")
+	buf.WriteString("// Source: PostgreSQL catalog/pg_proc.dat and catalog/pg_operator.dat\n\n")
+	buf.WriteString("package sema\n\n")
+	buf.WriteString("import \"github.com/pgplex/pgparser/nodes\"\n\n")
+
+	buf.WriteString("// builtinProcTable lists the scalar and set-returning functions of\n")
+	buf.WriteString("// pg_proc.dat. Function OIDs are not included.\n")
+	buf.WriteString("var builtinProcTable = []builtinProc{\n")
+	for _, p := range procRows {
+		if kind := p["prokind"]; kind != "" && kind != "f" {
+			continue
+		}
+		if p["proargnames"] != "" || p["proallargtypes"] != "" {
+			continue
+		}
+		args := strings.Fields(p["proargtypes"])
+		if len(args) == 1 && typeNames[p["proname"]] {
+			continue
+		}
+		var argSyms []string
+		ok := true
+		for _, a := range args {
+			sym, found := oid(a)
+			ok = ok && found
+			argSyms = append(argSyms, sym)
+		}
+		result, found := oid(p["prorettype"])
+		if !ok || !found {
+			continue
+		}
+		var flags []string
+		if v := p["provariadic"]; v != "" && v != "0" {
+			flags = append(flags, "procVariadic")
+		}
+		if p["proretset"] == "t" {
+			flags = append(flags, "procRetset")
+		}
+		flag := "0"
+		if flags != nil {
+			flag = strings.Join(flags, " | ")
+		}
+		fmt.Fprintf(&buf, "\t{%q, []nodes.Oid{%s}, %s, %s},\n", p["proname"], strings.Join(argSyms, ", "), result, flag)
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// builtinOperatorTable lists the operators of pg_operator.dat. Operator\n")
+	buf.WriteString("// and function OIDs are not included.\n")
+	buf.WriteString("var builtinOperatorTable = []builtinOperator{\n")
+	for _, o := range operRows {
+		var syms []string
+		ok := true
+		for _, field := range []string{"oprleft", "oprright", "oprresult"} {
+			name := o[field]
+			if name == "" || name == "0" {
+				syms = append(syms, "0")
+				continue
+			}
+			sym, found := oid(name)
+			ok = ok && found
+			syms = append(syms, sym)
+		}
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&buf, "\t{%q, %s},\n", o["oprname"], strings.Join(syms, ", "))
+	}
+	buf.WriteString("}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+// typeSymbol returns the name genbki.pl gives the OID macro of a type
+// without an oid_symbol: foo_bar becomes FOO_BAROID and _foo_bar, its
+// array type, FOO_BARARRAYOID.
+func typeSymbol(name string) string {
+	if strings.HasPrefix(name, "_") {
+		return strings.ToUpper(name[1:]) + "ARRAYOID"
+	}
+	return strings.ToUpper(name) + "OID"
+}
+
+// loadOidConsts returns the names of the constants of type nodes.Oid
+// declared by the hand-written files of dir.
+func loadOidConsts(dir string) (map[string]bool, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && !isGeneratedFile(fi.Name())
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	consts := map[string]bool{}
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			for _, decl := range f.Decls {
+				gd, ok := decl.(*ast.GenDecl)
+				if !ok || gd.Tok != token.CONST {
+					continue
+				}
+				for _, spec := range gd.Specs {
+					vs := spec.(*ast.ValueSpec)
+					if sel, ok := vs.Type.(*ast.SelectorExpr); !ok || sel.Sel.Name != "Oid" {
+						continue
+					}
+					for _, n := range vs.Names {
+						consts[n.Name] = true
+					}
+				}
+			}
+		}
+	}
+	return consts, nil
+}
+
+// parseCatalogData parses the rows of a PostgreSQL catalog .dat file: a
+// Perl array of hashes whose values are single-quoted strings, with
+// comments from # to the end of the line.
+func parseCatalogData(src string) ([]map[string]string, error) {
+	var rows []map[string]string
+	var row map[string]string
+	line := 1
+	errorf := func(format string, args ...interface{}) error {
+		return fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, args...))
+	}
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r' || c == ',':
+			i++
+		case c == '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case c == '[' || c == ']':
+			if row != nil {
+				return nil, errorf("unexpected %q in a row", c)
+			}
+			i++
+		case c == '{':
+			if row != nil {
+				return nil, errorf("nested rows")
+			}
+			row = map[string]string{}
+			i++
+		case c == '}':
+			if row == nil {
+				return nil, errorf("unexpected '}'")
+			}
+			rows = append(rows, row)
+			row = nil
+			i++
+		case isIdentStart(c):
+			if row == nil {
+				return nil, errorf("key outside a row")
+			}
+			start := i
+			for i < len(src) && (isIdentStart(src[i]) || isDigit(src[i])) {
+				i++
+			}
+			key := src[start:i]
+			for i < len(src) && (src[i] == ' ' || src[i] == '\t') {
+				i++
+			}
+			if !strings.HasPrefix(src[i:], "=>") {
+				return nil, errorf("expected => after %s", key)
+			}
+			i += 2
+			for i < len(src) && (src[i] == ' ' || src[i] == '\t' || src[i] == '\n') {
+				if src[i] == '\n' {
+					line++
+				}
+				i++
+			}
+			if i >= len(src) || src[i] != '\'' {
+				return nil, errorf("expected a quoted value for %s", key)
+			}
+			i++
+			var val strings.Builder
+			for ; i < len(src) && src[i] != '\''; i++ {
+				if src[i] == '\\' && i+1 < len(src) {
+					i++
+				}
+				if src[i] == '\n' {
+					line++
+				}
+				val.WriteByte(src[i])
+			}
+			if i >= len(src) {
+				return nil, errorf("unterminated value for %s", key)
+			}
+			i++
+			row[key] = val.String()
+		default:
+			return nil, errorf("unexpected %q", c)
+		}
+	}
+	if row != nil {
+		return nil, errorf("unterminated row")
+	}
+	return rows, nil
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testTypeData = `
+# pg_type.dat excerpt
+[
+
+{ oid => '16', array_type_oid => '1000',
+  descr => 'boolean, \'true\'/\'false\'',
+  typname => 'bool', typlen => '1', typbyval => 't', typcategory => 'B' },
+{ oid => '23', array_type_oid => '1007',
+  typname => 'int4', typlen => '4', typbyval => 't', typcategory => 'N' },
+{ oid => '25', array_type_oid => '1009',
+  typname => 'text', typlen => '-1', typbyval => 'f', typcategory => 'S' },
+{ oid => '2276', descr => 'pseudo-type representing any type',
+  typname => 'any', typlen => '4', typtype => 'p' },
+{ oid => '2275', typname => 'cstring', typlen => '-2', typtype => 'p' },
+{ oid => '600', typname => 'point', typlen => '16' },
+{ oid => '2249', oid_symbol => 'RECORDOID',
+  typname => 'record', typtype => 'p' },
+
+]
+`
+
+const testProcData = `
+[
+
+{ oid => '1242', descr => 'I/O',
+  proname => 'boolin', prorettype => 'bool', proargtypes => 'cstring',
+  prosrc => 'boolin' },
+{ oid => '1317', descr => 'length',
+  proname => 'length', prorettype => 'int4', proargtypes => 'text',
+  prosrc => 'textlen' },
+{ oid => '3058', descr => 'concatenate values',
+  proname => 'concat', provolatile => 's', provariadic => 'any',
+  prorettype => 'text', proargtypes => 'any',
+  proallargtypes => '{any}', proargmodes => '{v}', prosrc => 'text_concat' },
+{ oid => '3060', descr => 'concatenate values with separators',
+  proname => 'concat_ws', provolatile => 's', provariadic => 'any',
+  prorettype => 'text', proargtypes => 'text any', prosrc => 'text_concat_ws' },
+{ oid => '2515', descr => 'boolean-and aggregate',
+  proname => 'bool_and', prokind => 'a', proisstrict => 'f',
+  prorettype => 'bool', proargtypes => 'bool', prosrc => 'aggregate_dummy' },
+{ oid => '313', descr => 'convert boolean to text',
+  proname => 'text', prorettype => 'text', proargtypes => 'bool',
+  prosrc => 'booltext' },
+{ oid => '1069', descr => 'split string',
+  proname => 'regexp_split_to_table', prorows => '1000', proretset => 't',
+  prorettype => 'text', proargtypes => 'text text',
+  prosrc => 'regexp_split_to_table_no_flags' },
+{ oid => '1422', proname => 'box', prorettype => 'point',
+  proargtypes => 'point point', prosrc => 'points_box' },
+{ oid => '2700', proname => 'words', prorettype => '_text',
+  proargtypes => 'text', proargnames => '{str}', prosrc => 'words' },
+{ oid => '2701', proname => 'split', prorettype => '_text',
+  proargtypes => 'text', prosrc => 'split' },
+
+]
+`
+
+const testOperatorData = `
+[
+
+{ oid => '96', oid_symbol => 'Int4EqualOperator', descr => 'equal',
+  oprname => '=', oprcanmerge => 't', oprcanhash => 't', oprleft => 'int4',
+  oprright => 'int4', oprresult => 'bool', oprcom => '=(int4,int4)',
+  oprnegate => '<>(int4,int4)', oprcode => 'int4eq' },
+{ oid => '558', descr => 'negate',
+  oprname => '-', oprkind => 'l', oprleft => '0', oprright => 'int4',
+  oprresult => 'int4', oprcode => 'int4um' },
+{ oid => '654', descr => 'concatenate',
+  oprname => '||', oprleft => 'text', oprright => 'text', oprresult => 'text',
+  oprcode => 'textcat' },
+{ oid => '511', descr => 'is contained by',
+  oprname => '<@', oprleft => 'point', oprright => 'point',
+  oprresult => 'bool', oprcode => 'on_pb' },
+
+]
+`
+
+const testSemaConsts = `package sema
+
+import "github.com/pgplex/pgparser/nodes"
+
+const (
+	BOOLOID      nodes.Oid = 16
+	INT4OID      nodes.Oid = 23
+	TEXTOID      nodes.Oid = 25
+	TEXTARRAYOID nodes.Oid = 1009
+	ANYOID       nodes.Oid = 2276
+	CSTRINGOID   nodes.Oid = 2275
+	RECORDOID    nodes.Oid = 2249
+)
+
+const notAnOid = 1
+`
+
+// writeTestCatalog writes the catalog data and a sema package declaring
+// the OIDs of every type but point, and returns their directories.
+func writeTestCatalog(t *testing.T) (catalogDir, semaDir string) {
+	t.Helper()
+	catalogDir, semaDir = t.TempDir(), t.TempDir()
+	for name, src := range map[string]string{
+		"pg_type.dat":     testTypeData,
+		"pg_proc.dat":     testProcData,
+		"pg_operator.dat": testOperatorData,
+	} {
+		if err := os.WriteFile(filepath.Join(catalogDir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(semaDir, "builtin.go"), []byte(testSemaConsts), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return catalogDir, semaDir
+}
+
+func TestParseCatalogData(t *testing.T) {
+	rows, err := parseCatalogData(testTypeData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 7 {
+		t.Fatalf("got %d rows, want 7", len(rows))
+	}
+	if got := rows[0]["descr"]; got != "boolean, 'true'/'false'" {
+		t.Errorf("descr = %q, want the escaped quotes resolved", got)
+	}
+	if got := rows[6]["oid_symbol"]; got != "RECORDOID" {
+		t.Errorf("oid_symbol = %q", got)
+	}
+	for _, src := range []string{"[ { oid => 16 } ]", "[ { oid => '16' ]", "[ { oid '16' } ]", "{ oid => '16'"} {
+		if _, err := parseCatalogData(src); err == nil {
+			t.Errorf("parseCatalogData(%q): expected an error", src)
+		}
+	}
+}
+
+func TestTypeSymbol(t *testing.T) {
+	for name, want := range map[string]string{
+		"int4":           "INT4OID",
+		"_text":          "TEXTARRAYOID",
+		"pg_ddl_command": "PG_DDL_COMMANDOID",
+	} {
+		if got := typeSymbol(name); got != want {
+			t.Errorf("typeSymbol(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestGenCatalog(t *testing.T) {
+	catalogDir, semaDir := writeTestCatalog(t)
+	code, err := genCatalog(catalogDir, semaDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := string(code)
+	for _, want := range []string{
+		"// Code generated by pgsema-gen. DO NOT EDIT.",
+		`{"length", []nodes.Oid{TEXTOID}, INT4OID, 0},`,
+		`{"concat_ws", []nodes.Oid{TEXTOID, ANYOID}, TEXTOID, procVariadic},`,
+		`{"regexp_split_to_table", []nodes.Oid{TEXTOID, TEXTOID}, TEXTOID, procRetset},`,
+		`{"split", []nodes.Oid{TEXTOID}, TEXTARRAYOID, 0},`,
+		`{"=", INT4OID, INT4OID, BOOLOID},`,
+		`{"-", 0, INT4OID, INT4OID},`,
+		`{"||", TEXTOID, TEXTOID, TEXTOID},`,
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("missing %s in\n%s", want, src)
+		}
+	}
+	for _, unwanted := range []string{
+		`"boolin"`,   // takes cstring
+		`"concat"`,   // has OUT or VARIADIC parameters in proallargtypes
+		`"bool_and"`, // an aggregate
+		`"text"`,     // named after a type
+		`"box"`,      // point has no OID constant
+		`"words"`,    // has parameter names
+		`"<@"`,       // point again
+	} {
+		if strings.Contains(src, unwanted) {
+			t.Errorf("unexpected %s in\n%s", unwanted, src)
+		}
+	}
+}
+
+func TestCheckCatalog(t *testing.T) {
+	catalogDir, semaDir := writeTestCatalog(t)
+	drift, err := checkCatalog(catalogDir, semaDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(drift) != 1 || !strings.Contains(drift[0], "missing") {
+		t.Fatalf("drift = %v, want the missing file", drift)
+	}
+	if err := generateCatalog(catalogDir, semaDir); err != nil {
+		t.Fatal(err)
+	}
+	if drift, err := checkCatalog(catalogDir, semaDir); err != nil || len(drift) != 0 {
+		t.Fatalf("drift after generating = %v, %v", drift, err)
+	}
+	// The generated file's own declarations do not count as known types.
+	if consts, err := loadOidConsts(semaDir); err != nil || len(consts) != 7 || consts["notAnOid"] {
+		t.Errorf("loadOidConsts = %v, %v", consts, err)
+	}
+}
@@ -0,0 +1,720 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// This file contains a small parser for the node declarations of
+// PostgreSQL's nodes/*.h headers. It understands just enough C for
+// "typedef struct", "typedef enum" and "typedef A B" declarations and for
+// "#define NAME value" constants, and skips everything else (function-like
+// macros, extern declarations, inline functions).
+
+// cHeader holds the declarations found in a header.
+type cHeader struct {
+	Structs []*cStruct
+	Enums   []*cEnum
+	Aliases []cAlias // typedef OpExpr DistinctExpr;
+	Macros  []*cMacroGroup
+	Decls   []interface{} // all of the above, in the order of the header
+}
+
+// cStruct is a "typedef struct" declaration.
+type cStruct struct {
+	Name    string
+	Comment string   // block comment preceding the typedef
+	Attrs   []string // pg_node_attr() of the struct
+	Fields  []cField
+}
+
+// cField is a member of a struct.
+type cField struct {
+	Name    string
+	Type    string // base type name, without "struct" or "const"
+	Pointer int    // levels of indirection
+	Array   bool   // declared as name[N]
+	Attrs   []string
+	Comment string
+}
+
+// cEnum is a "typedef enum" declaration.
+type cEnum struct {
+	Name    string
+	Comment string
+	Values  []cEnumValue
+}
+
+// cEnumValue is an enumerator; Value is the explicit initializer, if any.
+type cEnumValue struct {
+	Name    string
+	Value   string
+	Comment string
+}
+
+// cAlias is a "typedef Type Name" declaration.
+type cAlias struct {
+	Name, Type string
+}
+
+// cMacroGroup is a run of object-like macros on consecutive lines, such
+// as the FRAMEOPTION_* bits of parsenodes.h.
+type cMacroGroup struct {
+	Comment string // block comment preceding the first macro
+	Macros  []cMacro
+	end     int // last line of the group
+}
+
+// cMacro is "#define Name Value"; Value holds the tokens of the
+// replacement list, without the comments.
+type cMacro struct {
+	Name    string
+	Value   []string
+	Comment string
+}
+
+// cToken is a lexical token of the header. Comments are kept as tokens
+// so that they can be attached to declarations.
+type cToken struct {
+	kind    cTokenKind
+	text    string
+	newline bool // a newline precedes the token
+	line    int
+}
+
+type cTokenKind int
+
+const (
+	cIdent cTokenKind = iota
+	cNumber
+	cPunct
+	cComment
+	cLiteral
+	cDirective // a preprocessor line, continuations and comments included
+)
+
+// lexHeader splits C source into tokens, dropping preprocessor lines.
+func lexHeader(src string) ([]cToken, error) {
+	var toks []cToken
+	line := 1
+	newline := true
+	bol := true // only whitespace since the start of the line
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			newline, bol = true, true
+			i++
+			continue
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f':
+			i++
+			continue
+		case c == '#' && bol:
+			// Preprocessor directive, possibly continued with backslashes
+			// or by a comment.
+			start := i
+			tok := cToken{kind: cDirective, newline: newline, line: line}
+			for i < len(src) && src[i] != '\n' {
+				switch {
+				case src[i] == '\\' && i+1 < len(src) && src[i+1] == '\n':
+					line++
+					i++
+				case strings.HasPrefix(src[i:], "/*"):
+					end := strings.Index(src[i+2:], "*/")
+					if end < 0 {
+						return nil, fmt.Errorf("line %d: unterminated comment", line)
+					}
+					line += strings.Count(src[i:i+end+4], "\n")
+					i += end + 3
+				}
+				i++
+			}
+			tok.text = src[start:i]
+			toks = append(toks, tok)
+			newline = false
+			continue
+		}
+		bol = false
+		start := i
+		tok := cToken{newline: newline, line: line}
+		switch {
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated comment", line)
+			}
+			i += end + 4
+			tok.kind = cComment
+			tok.text = src[start:i]
+			line += strings.Count(tok.text, "\n")
+		case strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			tok.kind = cComment
+			tok.text = src[start:i]
+		case isIdentStart(c):
+			for i < len(src) && (isIdentStart(src[i]) || isDigit(src[i])) {
+				i++
+			}
+			tok.kind = cIdent
+			tok.text = src[start:i]
+		case isDigit(c):
+			for i < len(src) && (isIdentStart(src[i]) || isDigit(src[i]) || src[i] == '.') {
+				i++
+			}
+			tok.kind = cNumber
+			tok.text = src[start:i]
+		case c == '\'' || c == '"':
+			i++
+			for i < len(src) && src[i] != c {
+				if src[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			if i >= len(src) {
+				return nil, fmt.Errorf("line %d: unterminated literal", line)
+			}
+			i++
+			tok.kind = cLiteral
+			tok.text = src[start:i]
+		case strings.HasPrefix(src[i:], "<<") || strings.HasPrefix(src[i:], ">>"):
+			i += 2
+			tok.kind = cPunct
+			tok.text = src[start:i]
+		default:
+			i++
+			tok.kind = cPunct
+			tok.text = src[start:i]
+		}
+		toks = append(toks, tok)
+		newline = false
+	}
+	return toks, nil
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// parseHeader parses the node declarations of a header.
+func parseHeader(src string) (*cHeader, error) {
+	toks, err := lexHeader(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &headerParser{toks: toks}
+	return p.parse()
+}
+
+type headerParser struct {
+	toks []cToken
+	pos  int
+}
+
+// peek returns the next token other than a preprocessor line, which
+// only the top level of a header looks at.
+func (p *headerParser) peek() *cToken {
+	for p.pos < len(p.toks) && p.toks[p.pos].kind == cDirective {
+		p.pos++
+	}
+	if p.pos < len(p.toks) {
+		return &p.toks[p.pos]
+	}
+	return nil
+}
+
+func (p *headerParser) next() *cToken {
+	t := p.peek()
+	if t != nil {
+		p.pos++
+	}
+	return t
+}
+
+func (p *headerParser) errorf(format string, args ...interface{}) error {
+	line := 0
+	if t := p.peek(); t != nil {
+		line = t.line
+	} else if len(p.toks) > 0 {
+		line = p.toks[len(p.toks)-1].line
+	}
+	return fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+func (p *headerParser) expect(text string) error {
+	t := p.next()
+	if t == nil || t.kind == cComment || t.text != text {
+		p.pos--
+		return p.errorf("expected %q", text)
+	}
+	return nil
+}
+
+// nextCode returns the next token that is not a comment.
+func (p *headerParser) nextCode() *cToken {
+	for {
+		t := p.next()
+		if t == nil || t.kind != cComment {
+			return t
+		}
+	}
+}
+
+func (p *headerParser) parse() (*cHeader, error) {
+	h := &cHeader{}
+	comment := ""
+	var group *cMacroGroup // the group the previous line added to
+	for p.pos < len(p.toks) {
+		if t := p.toks[p.pos]; t.kind == cDirective {
+			p.pos++
+			m, ok := parseDefine(t.text)
+			switch {
+			case !ok:
+				group = nil
+			case group == nil || t.line > group.end+1:
+				group = &cMacroGroup{Comment: comment}
+				h.Macros = append(h.Macros, group)
+				h.Decls = append(h.Decls, group)
+				fallthrough
+			default:
+				group.Macros = append(group.Macros, m)
+				group.end = t.line + strings.Count(t.text, "\n")
+			}
+			comment = ""
+			continue
+		}
+		group = nil
+		t := p.next()
+		if t.kind == cComment {
+			comment = commentText(t.text)
+			continue
+		}
+		if t.kind == cIdent && t.text == "struct" {
+			if err := p.parseStruct(h, comment, false); err != nil {
+				return nil, err
+			}
+			comment = ""
+			continue
+		}
+		if t.kind != cIdent || t.text != "typedef" {
+			p.skipStatement(t)
+			comment = ""
+			continue
+		}
+		if err := p.parseTypedef(h, comment); err != nil {
+			return nil, err
+		}
+		comment = ""
+	}
+	return h, nil
+}
+
+// skipStatement skips to the end of a declaration or function definition
+// starting with t.
+func (p *headerParser) skipStatement(t *cToken) {
+	depth := 0
+	for ; t != nil; t = p.next() {
+		switch t.text {
+		case "{", "(", "[":
+			depth++
+		case "}", ")", "]":
+			depth--
+			if depth == 0 && t.text == "}" {
+				// The end of a function body, unless a declarator follows.
+				if n := p.peek(); n == nil || n.text != ";" {
+					return
+				}
+			}
+		case ";":
+			if depth == 0 {
+				return
+			}
+		}
+	}
+}
+
+func (p *headerParser) parseTypedef(h *cHeader, comment string) error {
+	t := p.nextCode()
+	if t == nil {
+		return p.errorf("unexpected end of header after typedef")
+	}
+	switch t.text {
+	case "struct":
+		return p.parseStruct(h, comment, true)
+	case "enum":
+		return p.parseEnum(h, comment)
+	}
+	// typedef Type Name; anything more complex is skipped.
+	start := p.pos - 1
+	p.skipStatement(t)
+	var idents []string
+	for _, tok := range p.toks[start:p.pos] {
+		switch {
+		case tok.kind == cComment:
+		case tok.kind == cIdent:
+			idents = append(idents, tok.text)
+		case tok.text != ";":
+			return nil
+		}
+	}
+	if len(idents) == 2 {
+		a := cAlias{Name: idents[1], Type: idents[0]}
+		h.Aliases = append(h.Aliases, a)
+		h.Decls = append(h.Decls, a)
+	}
+	return nil
+}
+
+// parseStruct parses a struct definition after the struct keyword. A
+// typedef names the struct after its body; a plain "struct Foo {...};",
+// whose typedef lives in another header, is named by its tag.
+func (p *headerParser) parseStruct(h *cHeader, comment string, typedef bool) error {
+	t := p.nextCode()
+	tag := ""
+	if t != nil && t.kind == cIdent {
+		tag = t.text
+		t = p.nextCode()
+	}
+	if t == nil || t.text != "{" || (!typedef && tag == "") {
+		// A forward declaration, such as "typedef struct Foo Foo;".
+		p.skipStatement(t)
+		return nil
+	}
+	s := &cStruct{Comment: comment, Name: tag}
+	if err := p.parseStructBody(s); err != nil {
+		return err
+	}
+	if typedef {
+		name := p.nextCode()
+		if name == nil || name.kind != cIdent {
+			return p.errorf("expected struct typedef name")
+		}
+		s.Name = name.text
+	}
+	if err := p.expect(";"); err != nil {
+		return err
+	}
+	h.Structs = append(h.Structs, s)
+	h.Decls = append(h.Decls, s)
+	return nil
+}
+
+// parseStructBody parses the members of a struct up to the closing brace.
+// A comment on the same line as a member belongs to it; other comments
+// precede the member they describe.
+func (p *headerParser) parseStructBody(s *cStruct) error {
+	var stmt []cToken
+	var leading []string
+	var last *cField // the member the previous ";" ended
+	for {
+		t := p.next()
+		if t == nil {
+			return p.errorf("unterminated struct")
+		}
+		switch {
+		case t.kind == cComment:
+			if !t.newline && last != nil && len(stmt) == 0 {
+				last.Comment = joinComment(last.Comment, t.text)
+			} else {
+				leading = append(leading, t.text)
+			}
+			continue
+		case t.text == "}" && len(stmt) == 0:
+			return nil
+		case t.text == "{":
+			// A nested struct or union: not a node field.
+			p.pos--
+			p.skipStatement(p.next())
+			for t := p.next(); t != nil && t.text != ";"; t = p.next() {
+			}
+			stmt, leading, last = nil, nil, nil
+			continue
+		case t.kind == cIdent && t.text == "pg_node_attr":
+			attrs, err := p.parseAttrs()
+			if err != nil {
+				return err
+			}
+			if len(stmt) == 0 {
+				s.Attrs = append(s.Attrs, attrs...)
+			} else {
+				stmt = append(stmt, cToken{kind: cIdent, text: "pg_node_attr(" + strings.Join(attrs, ", ") + ")"})
+			}
+			continue
+		case t.text != ";":
+			stmt = append(stmt, *t)
+			continue
+		}
+		fields, err := parseMember(stmt)
+		if err != nil {
+			return fmt.Errorf("line %d: %v", t.line, err)
+		}
+		for i := range fields {
+			fields[i].Comment = joinComment(strings.Join(leading, "\n"), "")
+		}
+		s.Fields = append(s.Fields, fields...)
+		last = nil
+		if len(fields) > 0 {
+			last = &s.Fields[len(s.Fields)-1]
+		}
+		stmt, leading = nil, nil
+	}
+}
+
+// parseAttrs parses the parenthesized argument of pg_node_attr.
+func (p *headerParser) parseAttrs() ([]string, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var attrs []string
+	var cur strings.Builder
+	depth := 1
+	for {
+		t := p.next()
+		if t == nil {
+			return nil, p.errorf("unterminated pg_node_attr")
+		}
+		switch t.text {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth == 0 {
+				if cur.Len() > 0 {
+					attrs = append(attrs, cur.String())
+				}
+				return attrs, nil
+			}
+		case ",":
+			if depth == 1 {
+				attrs = append(attrs, cur.String())
+				cur.Reset()
+				continue
+			}
+		}
+		if t.kind != cComment {
+			cur.WriteString(t.text)
+		}
+	}
+}
+
+// parseMember parses the tokens of one member declaration, such as
+// "List *args" or "int a, b".
+func parseMember(stmt []cToken) ([]cField, error) {
+	var fields []cField
+	var base []string
+	decl := cField{}
+	var idents []string
+	flush := func() error {
+		if len(fields) == 0 {
+			// The first declarator carries the type specifiers.
+			if len(idents) < 2 {
+				return fmt.Errorf("cannot parse member %q", tokensText(stmt))
+			}
+			base = idents[:len(idents)-1]
+		} else if len(idents) != 1 {
+			return fmt.Errorf("cannot parse member %q", tokensText(stmt))
+		}
+		decl.Name = idents[len(idents)-1]
+		decl.Type = baseTypeName(base)
+		fields = append(fields, decl)
+		decl, idents = cField{}, nil
+		return nil
+	}
+	for i := 0; i < len(stmt); i++ {
+		t := stmt[i]
+		switch {
+		case t.text == ",":
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		case t.text == "*":
+			decl.Pointer++
+		case t.text == "[":
+			decl.Array = true
+			for i < len(stmt) && stmt[i].text != "]" {
+				i++
+			}
+		case strings.HasPrefix(t.text, "pg_node_attr("):
+			attr := strings.TrimSuffix(strings.TrimPrefix(t.text, "pg_node_attr("), ")")
+			decl.Attrs = append(decl.Attrs, strings.Split(attr, ", ")...)
+		case t.kind == cIdent:
+			idents = append(idents, t.text)
+		default:
+			return nil, fmt.Errorf("cannot parse member %q", tokensText(stmt))
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// baseTypeName returns the type name of a member's declaration
+// specifiers, ignoring qualifiers.
+func baseTypeName(specs []string) string {
+	var out []string
+	for _, s := range specs {
+		switch s {
+		case "const", "struct", "volatile", "enum":
+			continue
+		}
+		out = append(out, s)
+	}
+	return strings.Join(out, " ")
+}
+
+func (p *headerParser) parseEnum(h *cHeader, comment string) error {
+	t := p.nextCode()
+	if t != nil && t.kind == cIdent {
+		t = p.nextCode()
+	}
+	if t == nil || t.text != "{" {
+		p.skipStatement(t)
+		return nil
+	}
+	e := &cEnum{Comment: comment}
+	var leading []string
+	var last *cEnumValue
+	var cur *cEnumValue
+	var init []string
+	finish := func() {
+		if cur != nil {
+			cur.Value = strings.Join(init, " ")
+			cur.Comment = joinComment(strings.Join(leading, "\n"), "")
+			e.Values = append(e.Values, *cur)
+			last = &e.Values[len(e.Values)-1]
+		}
+		cur, init, leading = nil, nil, nil
+	}
+	for {
+		t := p.next()
+		if t == nil {
+			return p.errorf("unterminated enum")
+		}
+		switch {
+		case t.kind == cComment:
+			switch {
+			case !t.newline && cur != nil:
+				// "NAME /* comment */," or the last value without a comma.
+				leading = append(leading, t.text)
+			case !t.newline && last != nil:
+				last.Comment = joinComment(last.Comment, t.text)
+			default:
+				leading = append(leading, t.text)
+			}
+			continue
+		case t.text == "}":
+			finish()
+			name := p.nextCode()
+			if name == nil || name.kind != cIdent {
+				return p.errorf("expected enum typedef name")
+			}
+			e.Name = name.text
+			if err := p.expect(";"); err != nil {
+				return err
+			}
+			h.Enums = append(h.Enums, e)
+			h.Decls = append(h.Decls, e)
+			return nil
+		case t.text == ",":
+			finish()
+		case cur == nil && t.kind == cIdent:
+			cur = &cEnumValue{Name: t.text}
+		case cur != nil && t.text == "=":
+		case cur != nil:
+			init = append(init, t.text)
+		}
+	}
+}
+
+// parseDefine parses a "#define NAME value" line, reporting whether it is
+// one. Function-like macros and macros without a value, such as include
+// guards, are not.
+func parseDefine(text string) (cMacro, bool) {
+	text = strings.TrimSpace(strings.TrimPrefix(text, "#"))
+	if !strings.HasPrefix(text, "define") {
+		return cMacro{}, false
+	}
+	text = strings.TrimLeft(text[len("define"):], " \t")
+	n := 0
+	for n < len(text) && (isIdentStart(text[n]) || isDigit(text[n])) {
+		n++
+	}
+	if n == 0 || n < len(text) && text[n] == '(' {
+		return cMacro{}, false
+	}
+	toks, err := lexHeader(strings.ReplaceAll(text, "\\\n", " "))
+	if err != nil || len(toks) < 2 {
+		return cMacro{}, false
+	}
+	m := cMacro{Name: toks[0].text}
+	for _, t := range toks[1:] {
+		if t.kind == cComment {
+			m.Comment = joinComment(m.Comment, t.text)
+		} else {
+			m.Value = append(m.Value, t.text)
+		}
+	}
+	return m, len(m.Value) > 0
+}
+
+func tokensText(toks []cToken) string {
+	var parts []string
+	for _, t := range toks {
+		parts = append(parts, t.text)
+	}
+	return strings.Join(parts, " ")
+}
+
+// joinComment appends the text of C comment c to the plain text a.
+func joinComment(a, c string) string {
+	text := commentText(c)
+	if a != "" {
+		a = commentText(a)
+	}
+	switch {
+	case a == "":
+		return text
+	case text == "":
+		return a
+	}
+	return a + "\n" + text
+}
+
+// commentText returns the text of a C comment with the comment markers,
+// leading asterisks and separator lines removed. Text that is already
+// plain is returned as is.
+func commentText(c string) string {
+	switch {
+	case strings.HasPrefix(c, "/*"):
+		c = strings.TrimSuffix(strings.TrimPrefix(c, "/*"), "*/")
+	case strings.HasPrefix(c, "//"):
+		c = strings.TrimPrefix(c, "//")
+	}
+	var lines []string
+	for _, l := range strings.Split(c, "\n") {
+		l = strings.TrimSpace(l)
+		if strings.HasPrefix(l, "/*") || strings.HasSuffix(l, "*/") {
+			l = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(l, "/*"), "*/"))
+		}
+		l = strings.TrimSpace(strings.TrimPrefix(l, "*"))
+		if strings.Trim(l, "-=") == "" && l != "" {
+			continue // separator line
+		}
+		lines = append(lines, l)
+	}
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// checkNodes reports the generated node files of outDir that differ from
+// what the headers of includeDir generate, byte for byte.
+func checkNodes(includeDir, outDir string) ([]string, error) {
+	files, err := genNodeFiles(includeDir, outDir)
+	if err != nil {
+		return nil, err
+	}
+	var drift []string
+	for _, name := range sortedKeys(files) {
+		if msg := compareFile(filepath.Join(outDir, name), files[name]); msg != "" {
+			drift = append(drift, msg)
+		}
+	}
+	return drift, nil
+}
+
+// checkOutfuncs reports whether outfuncs_generated.go is stale.
+func checkOutfuncs(outDir string) ([]string, error) {
+	code, err := genOutfuncs(outDir)
+	if err != nil {
+		return nil, err
+	}
+	if msg := compareFile(filepath.Join(outDir, "outfuncs_generated.go"), code); msg != "" {
+		return []string{msg}, nil
+	}
+	return nil, nil
+}
+
+// compareFile returns a message if the file at path does not contain
+// want.
+func compareFile(path string, want []byte) string {
+	got, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		return fmt.Sprintf("%s: missing; run pgsema-gen to generate it", filepath.Base(path))
+	case err != nil:
+		return fmt.Sprintf("%s: %v", filepath.Base(path), err)
+	case !bytes.Equal(got, want):
+		return fmt.Sprintf("%s: out of date; run pgsema-gen to regenerate it", filepath.Base(path))
+	}
+	return ""
+}
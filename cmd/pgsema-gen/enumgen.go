@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// generateEnumNames writes enumnames_generated.go in outDir: a function
+// returning the name of a value of any enum type of the nodes package,
+// for serializers that print enums by name as PostgreSQL's do.
+func generateEnumNames(outDir string) error {
+	code, err := genEnumNames(outDir)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(outDir, "enumnames_generated.go"), code, 0644)
+}
+
+// enumType is an enum type of the nodes package and its constants, in
+// declaration order, with one name per value.
+type enumType struct {
+	name   string
+	pos    token.Pos
+	values []string
+}
+
+func genEnumNames(dir string) ([]byte, error) {
+	enums, err := loadEnums(dir)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code generated by pgsema-gen. DO NOT EDIT.")
+	fmt.Fprintln(&buf, "// Source: the enum types of the nodes package")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package nodes")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "// enumName returns the name of v if it is a value of an enum type,")
+	fmt.Fprintln(&buf, "// reporting whether it is one.")
+	fmt.Fprintln(&buf, "func enumName(v interface{}) (string, bool) {")
+	fmt.Fprintln(&buf, "\tswitch v := v.(type) {")
+	for _, e := range enums {
+		fmt.Fprintf(&buf, "\tcase %s:\n\t\tswitch v {\n", e.name)
+		for _, name := range e.values {
+			fmt.Fprintf(&buf, "\t\tcase %s:\n\t\t\treturn %q, true\n", name, name)
+		}
+		fmt.Fprintln(&buf, "\t\t}")
+	}
+	fmt.Fprintln(&buf, "\t}\n\treturn \"\", false\n}")
+	return format.Source(buf.Bytes())
+}
+
+// notEnums are the named integer types with constants that are not enums:
+// NodeTag, whose names NodeTagName already gives, and Oid, whose constant
+// only names a special value.
+var notEnums = map[string]bool{"NodeTag": true, "Oid": true}
+
+// loadEnums returns the enum types of the nodes package in dir: the named
+// integer types that have constants, other than notEnums. Where constants
+// share a value, the first declared names it.
+func loadEnums(dir string) ([]*enumType, error) {
+	fset := token.NewFileSet()
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, path := range paths {
+		name := filepath.Base(path)
+		if strings.HasSuffix(name, "_test.go") || name == "enumnames_generated.go" {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	// Errors from the unresolved imports do not affect the constants.
+	conf := types.Config{
+		Importer: importerFunc(func(path string) (*types.Package, error) {
+			return nil, fmt.Errorf("not imported")
+		}),
+		Error: func(error) {},
+	}
+	pkg, _ := conf.Check("nodes", fset, files, nil)
+	scope := pkg.Scope()
+	byName := map[string]*enumType{}
+	var consts []*types.Const
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok {
+			continue
+		}
+		named, ok := c.Type().(*types.Named)
+		if !ok || named.Obj().Pkg() != pkg || notEnums[named.Obj().Name()] {
+			continue
+		}
+		if b, ok := named.Underlying().(*types.Basic); !ok || b.Info()&types.IsInteger == 0 {
+			continue
+		}
+		consts = append(consts, c)
+	}
+	sort.Slice(consts, func(i, j int) bool { return consts[i].Pos() < consts[j].Pos() })
+
+	seen := map[string]bool{}
+	for _, c := range consts {
+		obj := c.Type().(*types.Named).Obj()
+		e := byName[obj.Name()]
+		if e == nil {
+			e = &enumType{name: obj.Name(), pos: obj.Pos()}
+			byName[obj.Name()] = e
+		}
+		key := obj.Name() + " " + c.Val().ExactString()
+		if c.Val().Kind() != constant.Int || seen[key] {
+			continue
+		}
+		seen[key] = true
+		e.values = append(e.values, c.Name())
+	}
+	var out []*enumType
+	for _, e := range byName {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].pos < out[j].pos })
+	return out, nil
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
+
+// checkEnumNames reports whether enumnames_generated.go is stale.
+func checkEnumNames(outDir string) ([]string, error) {
+	code, err := genEnumNames(outDir)
+	if err != nil {
+		return nil, err
+	}
+	if msg := compareFile(filepath.Join(outDir, "enumnames_generated.go"), code); msg != "" {
+		return []string{msg}, nil
+	}
+	return nil, nil
+}
@@ -1,21 +1,39 @@
 // Command pgsema-gen generates Go code from PostgreSQL source files.
 // Currently it generates:
-// - keywords.go from kwlist.h
-// - node types from parsenodes.h (TODO)
+//   - keywords.go from kwlist.h
+//   - <header>_generated.go from each of the nodes/*.h headers of
+//     nodeHeaders: their node structs, enums and constants
+//   - nodetags_generated.go from nodes.h and nodetags.h: the NodeTags
+//   - outfuncs_generated.go: nodeToString writers for the nodes outfuncs.go
+//     does not write by hand
+//   - enumnames_generated.go: the names of the values of the enum types
+//   - with -catalog, sema's builtinproc_generated.go from pg_type.dat,
+//     pg_proc.dat and pg_operator.dat: the built-in function and operator
+//     tables
+//
+// With -check, nothing is written; instead each generated file that
+// differs from what would be generated is reported, and the exit status is
+// 1 if there is any.
 package main
 
 import (
 	"bufio"
 	"flag"
 	"fmt"
+	"go/format"
 	"os"
 	"regexp"
 	"strings"
 )
 
 var (
-	kwlistPath = flag.String("kwlist", "", "path to PostgreSQL kwlist.h")
-	outDir     = flag.String("outdir", "pkg/parser", "output directory")
+	kwlistPath   = flag.String("kwlist", "", "path to PostgreSQL kwlist.h")
+	includeDir   = flag.String("include", "", "path to PostgreSQL src/include, for the nodes/*.h headers")
+	outfuncsOnly = flag.Bool("outfuncs", false, "regenerate only outfuncs_generated.go and enumnames_generated.go from the Go node declarations")
+	catalogDir   = flag.String("catalog", "", "path to PostgreSQL src/include/catalog, for the pg_type, pg_proc and pg_operator data")
+	check        = flag.Bool("check", false, "report drift between the headers and -outdir instead of generating")
+	outDir       = flag.String("outdir", "pkg/parser", "output directory")
+	semaDir      = flag.String("semadir", "sema", "directory of the sema package, for -catalog")
 )
 
 func main() {
@@ -28,6 +46,80 @@ func main() {
 		}
 		fmt.Println("Generated keywords.go")
 	}
+
+	if *check && *catalogDir != "" {
+		drift, err := checkCatalog(*catalogDir, *semaDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error checking the catalog tables: %v\n", err)
+			os.Exit(1)
+		}
+		for _, line := range drift {
+			fmt.Println(line)
+		}
+		if len(drift) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+	if *catalogDir != "" {
+		if err := generateCatalog(*catalogDir, *semaDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating the catalog tables: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("Generated " + catalogFile)
+		return
+	}
+
+	if *check {
+		var drift []string
+		if *includeDir != "" {
+			d, err := checkNodes(*includeDir, *outDir)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error checking nodes: %v\n", err)
+				os.Exit(1)
+			}
+			drift = append(drift, d...)
+		}
+		d, err := checkOutfuncs(*outDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error checking outfuncs: %v\n", err)
+			os.Exit(1)
+		}
+		drift = append(drift, d...)
+		d, err = checkEnumNames(*outDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error checking enum names: %v\n", err)
+			os.Exit(1)
+		}
+		drift = append(drift, d...)
+		for _, line := range drift {
+			fmt.Println(line)
+		}
+		if len(drift) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *includeDir != "" {
+		if err := generateNodes(*includeDir, *outDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating nodes: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("Generated the node files")
+	}
+	if *includeDir != "" || *outfuncsOnly {
+		if err := generateOutfuncs(*outDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating outfuncs: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("Generated outfuncs_generated.go")
+		if err := generateEnumNames(*outDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating enum names: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("Generated enumnames_generated.go")
+	}
 }
 
 // KeywordCategory represents the PostgreSQL keyword category.
@@ -42,10 +134,10 @@ const (
 
 // Keyword represents a PostgreSQL keyword.
 type Keyword struct {
-	Name     string          // keyword text (lowercase)
-	Token    string          // token name in grammar
-	Category KeywordCategory
-	BareLable bool           // can be used as bare label
+	Name      string // keyword text (lowercase)
+	Token     string // token name in grammar
+	Category  KeywordCategory
+	BareLable bool // can be used as bare label
 }
 
 func generateKeywords(kwlistPath, outDir string) error {
@@ -215,5 +307,9 @@ func addImport(path, pkg string) error {
 		}
 	}
 
-	return os.WriteFile(path, []byte(strings.Join(result, "\n")), 0644)
+	code, err := format.Source([]byte(strings.Join(result, "\n")))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, code, 0644)
 }
@@ -0,0 +1,672 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// nodeHeaders are the headers of include/nodes that the nodes package is
+// generated from. Each header <name>.h becomes <name>_generated.go.
+var nodeHeaders = []string{"nodes.h", "value.h", "lockoptions.h", "primnodes.h", "parsenodes.h"}
+
+// generateNodes writes the generated files of the nodes package in outDir
+// from the headers of includeDir: a file for each of nodeHeaders with its
+// node structs, enums and constants, and nodetags_generated.go with the
+// NodeTags of nodetags.h.
+//
+// Where the Go representation of a node differs from C, fieldTypes and
+// laterFields say how; the nodes package declares by hand only what the
+// headers lack, such as List and the nodes of later major versions.
+func generateNodes(includeDir, outDir string) error {
+	files, err := genNodeFiles(includeDir, outDir)
+	if err != nil {
+		return err
+	}
+	for _, name := range sortedKeys(files) {
+		if err := os.WriteFile(filepath.Join(outDir, name), files[name], 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// genNodeFiles returns the contents of the files generateNodes writes, by
+// file name.
+func genNodeFiles(includeDir, outDir string) (map[string][]byte, error) {
+	decls, err := loadGoDecls(outDir)
+	if err != nil {
+		return nil, err
+	}
+	var headers []*cHeader
+	for _, name := range nodeHeaders {
+		h, err := readHeader(filepath.Join(includeDir, "nodes", name))
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, h)
+	}
+	g := newNodeGen(headers, decls)
+	files := map[string][]byte{}
+	var errs []string
+	for i, h := range headers {
+		code, err := g.genHeaderFile(h, "nodes/"+nodeHeaders[i])
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", nodeHeaders[i], err))
+			continue
+		}
+		files[strings.TrimSuffix(nodeHeaders[i], ".h")+"_generated.go"] = code
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	tagsPath := filepath.Join(includeDir, "nodes", "nodetags.h")
+	tags, err := readNodeTags(tagsPath)
+	if err != nil {
+		return nil, err
+	}
+	code, err := g.genNodeTags(tags)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", tagsPath, err)
+	}
+	files["nodetags_generated.go"] = code
+	return files, nil
+}
+
+func readHeader(path string) (*cHeader, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	h, err := parseHeader(string(src))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return h, nil
+}
+
+// readNodeTags returns the "T_Name = N," entries of nodetags.h.
+func readNodeTags(path string) ([]cEnumValue, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	toks, err := lexHeader(string(src))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	var code []cToken
+	for _, t := range toks {
+		if t.kind != cComment && t.kind != cDirective {
+			code = append(code, t)
+		}
+	}
+	var tags []cEnumValue
+	for i := 0; i < len(code); {
+		if i+2 >= len(code) || code[i].kind != cIdent || code[i+1].text != "=" || code[i+2].kind != cNumber {
+			return nil, fmt.Errorf("%s: line %d: expected \"T_Name = N,\"", path, code[i].line)
+		}
+		tags = append(tags, cEnumValue{Name: code[i].text, Value: code[i+2].text})
+		i += 3
+		if i < len(code) && code[i].text == "," {
+			i++
+		}
+	}
+	return tags, nil
+}
+
+// goDecls describes the hand-written declarations of the nodes package.
+type goDecls struct {
+	names map[string]bool // package-level names
+	tags  []string        // the NodeTags the Tag methods return, in order
+}
+
+// isGeneratedFile reports whether a file of the nodes package is written
+// by this command.
+func isGeneratedFile(name string) bool {
+	return strings.HasSuffix(name, "_generated.go")
+}
+
+// loadGoDecls collects the declarations of the hand-written Go files in
+// dir.
+func loadGoDecls(dir string) (*goDecls, error) {
+	d := &goDecls{names: map[string]bool{}}
+	fset := token.NewFileSet()
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		name := filepath.Base(path)
+		if strings.HasSuffix(name, "_test.go") || isGeneratedFile(name) {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					d.names[decl.Name.Name] = true
+				} else if tag := returnedTag(decl); tag != "" {
+					d.tags = append(d.tags, tag)
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						d.names[spec.Name.Name] = true
+					case *ast.ValueSpec:
+						for _, n := range spec.Names {
+							d.names[n.Name] = true
+						}
+					}
+				}
+			}
+		}
+	}
+	return d, nil
+}
+
+// returnedTag returns the NodeTag that fn returns if it is a Tag method
+// such as "func (n *Foo) Tag() NodeTag { return T_Foo }".
+func returnedTag(fn *ast.FuncDecl) string {
+	if fn.Name.Name != "Tag" || fn.Body == nil || len(fn.Body.List) != 1 {
+		return ""
+	}
+	if ret, ok := fn.Body.List[0].(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+		if id, ok := ret.Results[0].(*ast.Ident); ok && strings.HasPrefix(id.Name, "T_") {
+			return id.Name
+		}
+	}
+	return ""
+}
+
+// nodeGen generates Go declarations for the nodes of a set of headers.
+type nodeGen struct {
+	decls   *goDecls
+	structs map[string]*cStruct
+	enums   map[string]*cEnum
+	consts  map[string]bool // the enum values and constants generated so far
+	buf     bytes.Buffer
+}
+
+func newNodeGen(headers []*cHeader, decls *goDecls) *nodeGen {
+	g := &nodeGen{decls: decls, structs: map[string]*cStruct{}, enums: map[string]*cEnum{}, consts: map[string]bool{}}
+	for _, h := range headers {
+		for _, s := range h.Structs {
+			g.structs[s.Name] = s
+		}
+		for _, e := range h.Enums {
+			g.enums[e.Name] = e
+			for _, v := range e.Values {
+				g.consts[v.Name] = true
+			}
+		}
+	}
+	// typedef OpExpr DistinctExpr declares a node with the fields of
+	// OpExpr.
+	for _, h := range headers {
+		for _, a := range h.Aliases {
+			if s := g.structs[a.Type]; s != nil && g.isNode(s) {
+				g.structs[a.Name] = g.alias(a)
+			}
+		}
+	}
+	return g
+}
+
+func (g *nodeGen) alias(a cAlias) *cStruct {
+	s := g.structs[a.Type]
+	return &cStruct{Name: a.Name, Fields: s.Fields, Attrs: s.Attrs,
+		Comment: fmt.Sprintf("%s is a node with the same fields as %s.", a.Name, a.Type)}
+}
+
+// genHeaderFile returns the source of the Go file declaring the node
+// structs, enums and constants of h, in the order of the header, except
+// those the package declares by hand.
+func (g *nodeGen) genHeaderFile(h *cHeader, source string) ([]byte, error) {
+	g.buf.Reset()
+	fmt.Fprintln(&g.buf, "// Code generated by pgsema-gen. DO NOT EDIT.")
+	fmt.Fprintf(&g.buf, "// Source: PostgreSQL %s\n\n", source)
+	fmt.Fprintln(&g.buf, "package nodes")
+
+	var errs []string
+	for _, d := range h.Decls {
+		switch d := d.(type) {
+		case *cEnum:
+			// nodetags_generated.go declares the NodeTag enum.
+			if d.Name != "NodeTag" {
+				g.genEnum(d)
+			}
+		case *cMacroGroup:
+			g.genMacros(d)
+		case *cStruct:
+			if !g.isNode(d) || hasAttr(d.Attrs, "abstract") || g.decls.names[d.Name] {
+				continue
+			}
+			if err := g.genStruct(d); err != nil {
+				errs = append(errs, err.Error())
+			}
+		case cAlias:
+			if s := g.structs[d.Name]; s != nil && !g.decls.names[d.Name] {
+				if err := g.genStruct(s); err != nil {
+					errs = append(errs, err.Error())
+				}
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return format.Source(g.buf.Bytes())
+}
+
+// genNodeTags returns the source of nodetags_generated.go: the NodeTag
+// enum of nodes.h, whose members nodetags.h lists, followed by the tags of
+// the hand-written nodes that nodetags.h lacks.
+func (g *nodeGen) genNodeTags(tags []cEnumValue) ([]byte, error) {
+	e := g.enums["NodeTag"]
+	if e == nil {
+		return nil, fmt.Errorf("nodes.h declares no NodeTag enum")
+	}
+	g.buf.Reset()
+	fmt.Fprintln(&g.buf, "// Code generated by pgsema-gen. DO NOT EDIT.")
+	fmt.Fprintf(&g.buf, "// Source: PostgreSQL nodes/nodes.h and nodes/nodetags.h\n\n")
+	fmt.Fprintln(&g.buf, "package nodes")
+	fmt.Fprintln(&g.buf)
+	writeDocComment(&g.buf, "", e.Comment)
+	fmt.Fprintf(&g.buf, "type NodeTag int\n\nconst (\n")
+	all := append(append([]cEnumValue(nil), e.Values...), tags...)
+	have := map[string]bool{}
+	last := "0"
+	for _, v := range all {
+		fmt.Fprintf(&g.buf, "\t%s NodeTag = %s\n", v.Name, v.Value)
+		have[v.Name] = true
+		last = v.Value
+	}
+	var later []string
+	for _, tag := range g.decls.tags {
+		if !have[tag] {
+			later = append(later, tag)
+			have[tag] = true
+		}
+	}
+	if len(later) > 0 {
+		fmt.Fprintf(&g.buf, "\n\t// NodeTags of the hand-written nodes of later major versions,\n\t// numbered after those of nodetags.h.\n")
+		for i, tag := range later {
+			if i == 0 {
+				fmt.Fprintf(&g.buf, "\t%s NodeTag = %s + 1 + iota\n", tag, last)
+			} else {
+				fmt.Fprintf(&g.buf, "\t%s\n", tag)
+			}
+		}
+	}
+	fmt.Fprintln(&g.buf, ")")
+	fmt.Fprintln(&g.buf)
+	fmt.Fprintln(&g.buf, "// NodeTagName returns the name of tag, or \"Unknown\".")
+	fmt.Fprintln(&g.buf, "func NodeTagName(tag NodeTag) string {\n\tswitch tag {")
+	for _, v := range all {
+		fmt.Fprintf(&g.buf, "\tcase %s:\n\t\treturn %q\n", v.Name, strings.TrimPrefix(v.Name, "T_"))
+	}
+	for _, tag := range later {
+		fmt.Fprintf(&g.buf, "\tcase %s:\n\t\treturn %q\n", tag, strings.TrimPrefix(tag, "T_"))
+	}
+	fmt.Fprintln(&g.buf, "\t}\n\treturn \"Unknown\"\n}")
+	return format.Source(g.buf.Bytes())
+}
+
+// isNode reports whether s is a node struct: its first member is the
+// NodeTag, or an embedded node such as "Expr xpr".
+func (g *nodeGen) isNode(s *cStruct) bool {
+	if len(s.Fields) == 0 {
+		return false
+	}
+	f := s.Fields[0]
+	if f.Pointer > 0 || f.Array {
+		return false
+	}
+	if f.Type == "NodeTag" {
+		return true
+	}
+	if super := g.structs[f.Type]; super != nil && super != s {
+		return g.isNode(super)
+	}
+	return false
+}
+
+// nodeFields returns the fields of node s, with those of an embedded
+// abstract node such as Expr inlined and the NodeTag dropped. A concrete
+// node embedded as the first member, such as the CreateStmt of
+// CreateForeignTableStmt, stays one field.
+func (g *nodeGen) nodeFields(s *cStruct) []cField {
+	var out []cField
+	for i, f := range s.Fields {
+		if f.Type == "NodeTag" && f.Pointer == 0 {
+			continue
+		}
+		if super := g.structs[f.Type]; i == 0 && super != nil && f.Pointer == 0 && hasAttr(super.Attrs, "abstract") {
+			out = append(out, g.nodeFields(super)...)
+			continue
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+func (g *nodeGen) genEnum(e *cEnum) {
+	if g.decls.names[e.Name] {
+		return
+	}
+	for _, v := range e.Values {
+		if g.decls.names[v.Name] {
+			// The package declares the values under another type.
+			return
+		}
+	}
+	fmt.Fprintln(&g.buf)
+	writeDocComment(&g.buf, "", e.Comment)
+	fmt.Fprintf(&g.buf, "type %s int\n\nconst (\n", e.Name)
+	explicit := false
+	for _, v := range e.Values {
+		explicit = explicit || v.Value != ""
+	}
+	prev := ""
+	for i, v := range e.Values {
+		writeDocComment(&g.buf, "\t", multiline(v.Comment))
+		switch {
+		case !explicit && i == 0:
+			fmt.Fprintf(&g.buf, "\t%s %s = iota", v.Name, e.Name)
+		case !explicit:
+			fmt.Fprintf(&g.buf, "\t%s", v.Name)
+		case v.Value != "":
+			fmt.Fprintf(&g.buf, "\t%s %s = %s", v.Name, e.Name, goEnumValue(v.Value))
+		case prev == "":
+			fmt.Fprintf(&g.buf, "\t%s %s = 0", v.Name, e.Name)
+		default:
+			fmt.Fprintf(&g.buf, "\t%s %s = %s + 1", v.Name, e.Name, prev)
+		}
+		writeLineComment(&g.buf, v.Comment)
+		prev = v.Name
+	}
+	fmt.Fprintln(&g.buf, ")")
+}
+
+// genMacros declares the constants of a group of macros whose values are
+// constant expressions of numbers, characters and earlier constants.
+// Others, such as those involving casts, are left out.
+func (g *nodeGen) genMacros(group *cMacroGroup) {
+	var lines []string
+	var buf bytes.Buffer
+	for _, m := range group.Macros {
+		value, ok := g.goMacroValue(m.Value)
+		if !ok || g.decls.names[m.Name] {
+			continue
+		}
+		buf.Reset()
+		writeDocComment(&buf, "\t", multiline(m.Comment))
+		fmt.Fprintf(&buf, "\t%s = %s", m.Name, value)
+		writeLineComment(&buf, m.Comment)
+		lines = append(lines, buf.String())
+		g.consts[m.Name] = true
+	}
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Fprintln(&g.buf)
+	writeDocComment(&g.buf, "", group.Comment)
+	fmt.Fprintf(&g.buf, "const (\n%s)\n", strings.Join(lines, ""))
+}
+
+// goMacroValue returns the Go form of the replacement list of a macro,
+// reporting whether it is a constant expression.
+func (g *nodeGen) goMacroValue(toks []string) (string, bool) {
+	var out []string
+	for _, t := range toks {
+		switch {
+		case t == "~":
+			out = append(out, "^")
+		case strings.Contains("()|&+-*", t) || t == "<<" || t == ">>":
+			out = append(out, t)
+		case strings.HasPrefix(t, "'") || strings.HasPrefix(t, `"`):
+			out = append(out, t)
+		case isDigit(t[0]):
+			out = append(out, strings.TrimRight(t, "uUlL"))
+		case cLimits[t] != "":
+			out = append(out, cLimits[t])
+		case g.consts[t]:
+			out = append(out, t)
+		default:
+			return "", false
+		}
+	}
+	return strings.Join(out, " "), true
+}
+
+func (g *nodeGen) genStruct(s *cStruct) error {
+	fields := g.nodeFields(s)
+	later := map[string][]laterField{}
+	for _, lf := range laterFields[s.Name] {
+		later[lf.after] = append(later[lf.after], lf)
+	}
+	var lines []string
+	var errs []string
+	var buf bytes.Buffer
+	for _, f := range fields {
+		typ, err := g.goType(s, f)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		buf.Reset()
+		writeDocComment(&buf, "\t", multiline(f.Comment))
+		name := goFieldName(f.Name)
+		fmt.Fprintf(&buf, "\t%s %s", name, typ)
+		if lowerFirst(name) != f.Name {
+			fmt.Fprintf(&buf, " `pg:%q`", f.Name)
+		}
+		writeLineComment(&buf, f.Comment)
+		for _, lf := range later[f.Name] {
+			fmt.Fprintf(&buf, "\t%s %s `since:%q` // %s\n", lf.name, lf.typ, lf.since, lf.comment)
+		}
+		lines = append(lines, buf.String())
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	fmt.Fprintln(&g.buf)
+	writeDocComment(&g.buf, "", s.Comment)
+	fmt.Fprintf(&g.buf, "type %s struct {\n%s}\n\n", s.Name, strings.Join(lines, ""))
+	fmt.Fprintf(&g.buf, "func (n *%s) Tag() NodeTag { return T_%s }\n", s.Name, s.Name)
+	return nil
+}
+
+// cScalarTypes maps the C scalar types of node fields to Go.
+var cScalarTypes = map[string]string{
+	"bool":             "bool",
+	"char":             "byte",
+	"int":              "int",
+	"int16":            "int16",
+	"int32":            "int32",
+	"int64":            "int64",
+	"uint8":            "uint8",
+	"uint16":           "uint16",
+	"uint32":           "uint32",
+	"uint64":           "uint64",
+	"bits32":           "uint32",
+	"double":           "float64",
+	"Cost":             "float64",
+	"Cardinality":      "float64",
+	"Selectivity":      "float64",
+	"Index":            "uint32",
+	"AttrNumber":       "AttrNumber",
+	"Oid":              "Oid",
+	"RegProcedure":     "Oid",
+	"RelFileNumber":    "Oid",
+	"ParseLoc":         "ParseLoc",
+	"Datum":            "Node", // a value node, as in Const
+	"Relids":           "*Bitmapset",
+	"unsigned int":     "uint32",
+	"signed char":      "int8",
+	"unsigned char":    "uint8",
+	"long":             "int64",
+	"unsigned long":    "uint64",
+	"int8":             "int8",
+	"Size":             "uint64",
+	"SubTransactionId": "uint32",
+	"AclMode":          "uint64",
+}
+
+// goType returns the Go type of a node field.
+func (g *nodeGen) goType(s *cStruct, f cField) (string, error) {
+	if t, ok := fieldTypes[s.Name+"."+f.Name]; ok {
+		return t, nil
+	}
+	unsupported := func() (string, error) {
+		stars := strings.Repeat("*", f.Pointer)
+		return "", fmt.Errorf("%s.%s: unsupported type %s%s", s.Name, f.Name, f.Type, stars)
+	}
+	if f.Array {
+		return unsupported()
+	}
+	switch f.Pointer {
+	case 0:
+		if t, ok := cScalarTypes[f.Type]; ok {
+			return t, nil
+		}
+		if g.enums[f.Type] != nil || g.decls.names[f.Type] && g.structs[f.Type] == nil {
+			return f.Type, nil
+		}
+		if s := g.structs[f.Type]; s != nil && g.isNode(s) && !hasAttr(s.Attrs, "abstract") {
+			return f.Type, nil
+		}
+	case 1:
+		switch f.Type {
+		case "char":
+			return "string", nil
+		case "Node", "Expr":
+			return "Node", nil
+		case "List":
+			return listType(f.Comment), nil
+		case "Bitmapset":
+			return "*Bitmapset", nil
+		}
+		if s := g.structs[f.Type]; s != nil && g.isNode(s) && hasAttr(s.Attrs, "abstract") {
+			// A pointer to an abstract node such as JsonTablePlan holds
+			// one of its subtypes.
+			return "Node", nil
+		}
+		if s := g.structs[f.Type]; s != nil && g.isNode(s) || g.decls.names[f.Type] {
+			return "*" + f.Type, nil
+		}
+		if t, ok := cScalarTypes[f.Type]; ok && !strings.HasPrefix(t, "*") && t != "Node" {
+			return "[]" + t, nil
+		}
+	}
+	return unsupported()
+}
+
+// cLimits maps the limit macros of C constants to Go.
+var cLimits = map[string]string{
+	"PG_INT16_MAX": "0x7FFF",
+	"PG_INT32_MAX": "0x7FFFFFFF",
+	"LONG_MAX":     "0x7FFFFFFFFFFFFFFF",
+}
+
+// goEnumValue returns the Go form of the initializer of a C enumerator.
+func goEnumValue(value string) string {
+	if v, ok := cLimits[value]; ok {
+		return v
+	}
+	return value
+}
+
+// listType guesses the element type of a List field from its comment,
+// since C declares integer and OID lists as plain Lists.
+func listType(comment string) string {
+	c := strings.ToLower(comment)
+	switch {
+	case strings.Contains(c, "integer list"), strings.Contains(c, "list of integers"),
+		strings.Contains(c, "int list"):
+		return "*IntList"
+	case strings.Contains(c, "oid list"), strings.Contains(c, "list of oids"):
+		return "*OidList"
+	}
+	return "*List"
+}
+
+func hasAttr(attrs []string, name string) bool {
+	for _, a := range attrs {
+		if a == name || strings.HasPrefix(a, name+"(") {
+			return true
+		}
+	}
+	return false
+}
+
+// goFieldName converts a C field name to an exported Go name:
+// "targetList" becomes "TargetList", "plan_id" becomes "PlanId".
+func goFieldName(name string) string {
+	var sb strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+		sb.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return sb.String()
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// multiline returns text if it spans several lines, for use as a doc
+// comment; one-line comments are written at the end of the line.
+func multiline(text string) string {
+	if strings.Contains(text, "\n") {
+		return text
+	}
+	return ""
+}
+
+func writeDocComment(buf *bytes.Buffer, indent, text string) {
+	if text == "" {
+		return
+	}
+	for _, l := range strings.Split(text, "\n") {
+		if l == "" {
+			fmt.Fprintf(buf, "%s//\n", indent)
+		} else {
+			fmt.Fprintf(buf, "%s// %s\n", indent, l)
+		}
+	}
+}
+
+func writeLineComment(buf *bytes.Buffer, text string) {
+	if text != "" && !strings.Contains(text, "\n") {
+		fmt.Fprintf(buf, " // %s", text)
+	}
+	fmt.Fprintln(buf)
+}
+
+func sortedKeys(m map[string][]byte) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
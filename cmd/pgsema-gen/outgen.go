@@ -0,0 +1,284 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// outfuncFiles returns the files of the nodes package in dir that may
+// declare nodes: those generated from nodeHeaders, then the hand-written
+// ones such as pg18nodes.go.
+func outfuncFiles(dir string) ([]string, error) {
+	var files []string
+	for _, h := range nodeHeaders {
+		files = append(files, strings.TrimSuffix(h, ".h")+"_generated.go")
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		if name := filepath.Base(path); !strings.HasSuffix(name, "_test.go") && !isGeneratedFile(name) {
+			files = append(files, name)
+		}
+	}
+	return files, nil
+}
+
+// generateOutfuncs writes outfuncs_generated.go in outDir: a nodeToString
+// writer for every node struct of outfuncFiles that outfuncs.go does not
+// write by hand. It works from the Go declarations, so it also covers
+// the fields of later major versions.
+func generateOutfuncs(outDir string) error {
+	code, err := genOutfuncs(outDir)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(outDir, "outfuncs_generated.go"), code, 0644)
+}
+
+// outNode is a node struct to write.
+type outNode struct {
+	name   string
+	fields []outField
+}
+
+type outField struct {
+	name  string // Go field name
+	label string // field name in the output
+	kind  outKind
+}
+
+type outKind int
+
+const (
+	outInt outKind = iota
+	outBool
+	outChar
+	outFloat
+	outString
+	outInterface // a Node interface value
+	outPointer   // a pointer to a node or list
+	outBitmapset // a *Bitmapset
+)
+
+func genOutfuncs(dir string) ([]byte, error) {
+	fset := token.NewFileSet()
+	handWritten := map[string]bool{}
+	f, err := parser.ParseFile(fset, filepath.Join(dir, "outfuncs.go"), nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil {
+			handWritten[fn.Name.Name] = true
+		}
+	}
+
+	files, err := outfuncFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	var out []*outNode
+	for _, name := range files {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		nodes, err := outNodes(f, handWritten)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		out = append(out, nodes...)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code generated by pgsema-gen. DO NOT EDIT.")
+	fmt.Fprintln(&buf, "// Source: the node declarations of the nodes package")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package nodes")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "import (\n\t\"fmt\"\n\t\"strings\"\n)")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "// writeGeneratedNode writes a node whose outfunc is generated, reporting")
+	fmt.Fprintln(&buf, "// whether node was one.")
+	fmt.Fprintln(&buf, "func writeGeneratedNode(sb *strings.Builder, node Node) bool {")
+	if len(out) > 0 {
+		fmt.Fprintln(&buf, "\tswitch n := node.(type) {")
+	} else {
+		fmt.Fprintln(&buf, "\tswitch node.(type) {")
+	}
+	for _, n := range out {
+		fmt.Fprintf(&buf, "\tcase *%s:\n\t\twrite%s(sb, n)\n", n.name, n.name)
+	}
+	fmt.Fprintln(&buf, "\tdefault:\n\t\treturn false\n\t}\n\treturn true\n}")
+	fmt.Fprintln(&buf)
+	for _, n := range out {
+		writeOutfunc(&buf, n)
+	}
+	return format.Source(buf.Bytes())
+}
+
+// outNodes returns the node structs declared in f, in order, except
+// those with a hand-written writer. A node struct is one with a Tag
+// method.
+func outNodes(f *ast.File, handWritten map[string]bool) ([]*outNode, error) {
+	tags := map[string]string{}
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || fn.Name.Name != "Tag" || len(fn.Body.List) != 1 {
+			continue
+		}
+		star, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+		if !ok {
+			continue
+		}
+		recv, ok := star.X.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		if ret, ok := fn.Body.List[0].(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+			if id, ok := ret.Results[0].(*ast.Ident); ok {
+				tags[recv.Name] = id.Name
+			}
+		}
+	}
+
+	structs := map[string]*ast.StructType{}
+	var names []string
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if st, ok := ts.Type.(*ast.StructType); ok && tags[ts.Name.Name] != "" {
+				structs[ts.Name.Name] = st
+				names = append(names, ts.Name.Name)
+			}
+		}
+	}
+
+	var out []*outNode
+	for _, name := range names {
+		if handWritten["write"+name] {
+			continue
+		}
+		fields, err := outStructFields(structs, structs[name], "", "")
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		out = append(out, &outNode{name: name, fields: fields})
+	}
+	return out, nil
+}
+
+// outStructFields returns the fields of st to write. A node struct held by
+// value, such as the CreateStmt a CreateForeignTableStmt extends, is
+// flattened into its fields the way outfuncs.c writes base.relation.
+func outStructFields(structs map[string]*ast.StructType, st *ast.StructType, goPrefix, labelPrefix string) ([]outField, error) {
+	var out []outField
+	for _, field := range st.Fields.List {
+		var tag string
+		if field.Tag != nil {
+			s, _ := strconv.Unquote(field.Tag.Value)
+			tag = reflect.StructTag(s).Get("pg")
+		}
+		for _, name := range field.Names {
+			label := tag
+			if label == "" {
+				label = lowerFirst(name.Name)
+			}
+			if id, ok := field.Type.(*ast.Ident); ok && structs[id.Name] != nil {
+				sub, err := outStructFields(structs, structs[id.Name], goPrefix+name.Name+".", labelPrefix+label+".")
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, sub...)
+				continue
+			}
+			kind, err := outFieldKind(field.Type)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, outField{name: goPrefix + name.Name, label: labelPrefix + label, kind: kind})
+		}
+	}
+	return out, nil
+}
+
+func outFieldKind(typ ast.Expr) (outKind, error) {
+	switch t := typ.(type) {
+	case *ast.StarExpr:
+		if id, ok := t.X.(*ast.Ident); ok && id.Name == "Bitmapset" {
+			return outBitmapset, nil
+		}
+		return outPointer, nil
+	case *ast.Ident:
+		switch t.Name {
+		case "Node":
+			return outInterface, nil
+		case "bool":
+			return outBool, nil
+		case "byte":
+			return outChar, nil
+		case "string":
+			return outString, nil
+		case "float32", "float64":
+			return outFloat, nil
+		}
+		// Integers, OIDs and enums.
+		return outInt, nil
+	}
+	return 0, fmt.Errorf("unsupported field type %T", typ)
+}
+
+// writeOutfunc writes the nodeToString function of a node, which prints
+// every field in the style of PostgreSQL's outfuncs.c.
+func writeOutfunc(buf *bytes.Buffer, n *outNode) {
+	fmt.Fprintf(buf, "\nfunc write%s(sb *strings.Builder, n *%s) {\n", n.name, n.name)
+	fmt.Fprintf(buf, "\tsb.WriteString(%q)\n", "{"+strings.ToUpper(n.name))
+	for _, f := range n.fields {
+		label := " :" + f.label
+		switch f.kind {
+		case outInt:
+			fmt.Fprintf(buf, "\tsb.WriteString(fmt.Sprintf(%q, n.%s))\n", label+" %d", f.name)
+		case outBool:
+			fmt.Fprintf(buf, "\tsb.WriteString(fmt.Sprintf(%q, n.%s))\n", label+" %t", f.name)
+		case outFloat:
+			fmt.Fprintf(buf, "\tsb.WriteString(fmt.Sprintf(%q, n.%s))\n", label+" %g", f.name)
+		case outChar:
+			fmt.Fprintf(buf, "\twriteCharField(sb, %q, n.%s)\n", label, f.name)
+		case outString:
+			fmt.Fprintf(buf, "\tif n.%s != \"\" {\n", f.name)
+			fmt.Fprintf(buf, "\t\tsb.WriteString(%q + escapeString(n.%s) + %q)\n", label+" \"", f.name, "\"")
+			fmt.Fprintf(buf, "\t} else {\n\t\tsb.WriteString(%q)\n\t}\n", label+" <>")
+		case outInterface:
+			fmt.Fprintf(buf, "\tsb.WriteString(%q)\n", label+" ")
+			fmt.Fprintf(buf, "\twriteNode(sb, n.%s)\n", f.name)
+		case outPointer:
+			fmt.Fprintf(buf, "\tif n.%s != nil {\n", f.name)
+			fmt.Fprintf(buf, "\t\tsb.WriteString(%q)\n", label+" ")
+			fmt.Fprintf(buf, "\t\twriteNode(sb, n.%s)\n", f.name)
+			fmt.Fprintf(buf, "\t} else {\n\t\tsb.WriteString(%q)\n\t}\n", label+" <>")
+		case outBitmapset:
+			fmt.Fprintf(buf, "\tsb.WriteString(%q)\n", label+" ")
+			fmt.Fprintf(buf, "\twriteBitmapset(sb, n.%s)\n", f.name)
+		}
+	}
+	fmt.Fprintln(buf, "\tsb.WriteString(\"}\")\n}")
+}
@@ -0,0 +1,34 @@
+package main
+
+// fieldTypes gives the Go type of the node fields whose C type does not
+// say it: the integer lists whose comment does not tell them from lists
+// of nodes, and the fields whose Go representation differs from C.
+var fieldTypes = map[string]string{
+	"Aggref.aggargtypes":          "*OidList",
+	"MergeAction.updateColnos":    "*IntList",
+	"GroupingFunc.cols":           "*IntList",
+	"RangeTblEntry.joinleftcols":  "*IntList",
+	"RangeTblEntry.joinrightcols": "*IntList",
+	"A_Const.val":                 "Node", // union ValUnion: one of the value nodes
+	"Integer.ival":                "int64",
+}
+
+// laterField is a field that a later major version than the headers adds
+// to a node. It is declared after the field named after, with a
+// `since:"..."` tag.
+type laterField struct {
+	after   string // the C name of the preceding field
+	name    string
+	typ     string
+	since   string
+	comment string
+}
+
+// laterFields are the later fields of the nodes, by node.
+var laterFields = map[string][]laterField{
+	"InsertStmt": {{after: "returningList", name: "ReturningOptions", typ: "*List", since: "18", comment: "RETURNING WITH OLD/NEW aliases"}},
+	"UpdateStmt": {{after: "returningList", name: "ReturningOptions", typ: "*List", since: "18", comment: "RETURNING WITH OLD/NEW aliases"}},
+	"DeleteStmt": {{after: "returningList", name: "ReturningOptions", typ: "*List", since: "18", comment: "RETURNING WITH OLD/NEW aliases"}},
+	"MergeStmt":  {{after: "returningList", name: "ReturningOptions", typ: "*List", since: "18", comment: "RETURNING WITH OLD/NEW aliases"}},
+	"Constraint": {{after: "generated_when", name: "GeneratedKind", typ: "byte", since: "18", comment: "STORED or VIRTUAL; zero before PG 18"}},
+}
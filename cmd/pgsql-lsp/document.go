@@ -0,0 +1,134 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+)
+
+// document is an open text document, with what the parser makes of it.
+type document struct {
+	text  string
+	lines []int // byte offset of the start of each line
+
+	toks    []parser.ScanToken // tokens up to the first scan error
+	stmts   []statement
+	scanErr error // error scanning the text, if any
+	err     error // error parsing the text, if any
+}
+
+// statement is the span of a statement's tokens, from the start of the
+// first to the end of the last.
+type statement struct {
+	start, end int
+	node       nodes.Node // nil if the document does not parse
+}
+
+func newDocument(text string) *document {
+	d := &document{text: text, lines: []int{0}}
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			d.lines = append(d.lines, i+1)
+		}
+	}
+	d.toks, d.scanErr = parser.Scan(text)
+	raws, err := parser.ParseRaw(text)
+	d.err = err
+	if err != nil {
+		// Fall back on splitting at semicolons, so that folding still
+		// works while a statement is being typed.
+		first := 0
+		for i, tok := range d.toks {
+			if tok.Name == "';'" || i == len(d.toks)-1 {
+				d.stmts = append(d.stmts, statement{start: d.toks[first].Start, end: tok.End})
+				first = i + 1
+			}
+		}
+		return d
+	}
+	for _, raw := range raws {
+		start, end := int(raw.StmtLocation), len(text)
+		if raw.StmtLen > 0 {
+			end = start + int(raw.StmtLen)
+		}
+		st := statement{start: -1, node: raw.Stmt}
+		for _, tok := range d.toks {
+			if tok.Start >= start && tok.End <= end {
+				if st.start < 0 {
+					st.start = tok.Start
+				}
+				st.end = tok.End
+			}
+		}
+		if st.start >= 0 {
+			d.stmts = append(d.stmts, st)
+		}
+	}
+	return d
+}
+
+// findName returns the span of the first token between offsets from and
+// to that is name, written as an identifier, a keyword or a string.
+func (d *document) findName(name string, from, to int) (int, int, bool) {
+	for _, tok := range d.toks {
+		if tok.Start < from || tok.End > to {
+			continue
+		}
+		text := d.text[tok.Start:tok.End]
+		switch {
+		case tok.Name == "SCONST" && strings.HasPrefix(text, "'"):
+			text = strings.ReplaceAll(strings.TrimSuffix(text[1:], "'"), "''", "'")
+		case tok.Name == "IDENT" && strings.HasPrefix(text, `"`):
+			text = strings.ReplaceAll(strings.TrimSuffix(text[1:], `"`), `""`, `"`)
+		case tok.Name == "IDENT" || tok.Keyword != nil:
+			text = strings.ToLower(text)
+		default:
+			continue
+		}
+		if text == name {
+			return tok.Start, tok.End, true
+		}
+	}
+	return 0, 0, false
+}
+
+// position converts a byte offset into a protocol position, whose
+// character is counted in UTF-16 code units.
+func (d *document) position(offset int) position {
+	if offset > len(d.text) {
+		offset = len(d.text)
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	line := sort.Search(len(d.lines), func(i int) bool { return d.lines[i] > offset }) - 1
+	return position{Line: line, Character: utf16Len(d.text[d.lines[line]:offset])}
+}
+
+func (d *document) span(start, end int) lspRange {
+	return lspRange{Start: d.position(start), End: d.position(end)}
+}
+
+// lineEnd returns the offset of the end of the line containing offset,
+// before its newline.
+func (d *document) lineEnd(offset int) int {
+	line := d.position(offset).Line
+	if line+1 < len(d.lines) {
+		return d.lines[line+1] - 1
+	}
+	return len(d.text)
+}
+
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n
+}
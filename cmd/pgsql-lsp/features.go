@@ -0,0 +1,365 @@
+package main
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/pgplex/pgparser/catalog"
+	"github.com/pgplex/pgparser/format"
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+)
+
+// diagnostics returns the syntax error of the document, if any. The parser
+// stops at the first error, so there is at most one.
+func (d *document) diagnostics() []diagnostic {
+	diags := []diagnostic{}
+	var perr, serr *parser.ParseError
+	errors.As(d.err, &perr)
+	if errors.As(d.scanErr, &serr) && (perr == nil || serr.Position < perr.Position) {
+		// The parser does not always stop at an error of the lexer.
+		perr = serr
+	}
+	if perr == nil {
+		return diags
+	}
+	start, end := perr.Position, d.lineEnd(perr.Position)
+	for _, tok := range d.toks {
+		if tok.Start == start {
+			end = tok.End
+			break
+		}
+	}
+	return append(diags, diagnostic{
+		Range:    d.span(start, end),
+		Severity: severityError,
+		Source:   "pgsql",
+		Message:  perr.Message,
+	})
+}
+
+// tokenTypes is the legend of the semantic tokens; a token's type is an
+// index into it.
+var tokenTypes = []string{"keyword", "string", "number", "operator", "parameter", "comment"}
+
+const (
+	tokenKeyword = iota
+	tokenString
+	tokenNumber
+	tokenOperator
+	tokenParameter
+	tokenComment
+)
+
+// tokenType returns the semantic token type of tok, or -1 for identifiers
+// and punctuation.
+func tokenType(tok parser.ScanToken) int {
+	if tok.Keyword != nil {
+		return tokenKeyword
+	}
+	switch tok.Name {
+	case "SCONST", "BCONST", "XCONST":
+		return tokenString
+	case "ICONST", "FCONST":
+		return tokenNumber
+	case "PARAM":
+		return tokenParameter
+	case "Op", "TYPECAST", "DOT_DOT", "COLON_EQUALS", "EQUALS_GREATER",
+		"LESS_EQUALS", "GREATER_EQUALS", "NOT_EQUALS",
+		"'+'", "'-'", "'*'", "'/'", "'%'", "'^'", "'<'", "'>'", "'='":
+		return tokenOperator
+	}
+	return -1
+}
+
+// semanticTokens returns the tokens of the document, and the comments
+// between them, in the relative encoding of the protocol. Tokens that
+// span lines are split into one per line.
+func (d *document) semanticTokens() []int {
+	type span struct{ start, end, typ int }
+	var spans []span
+	prev := 0
+	for _, tok := range d.toks {
+		for _, c := range comments(d.text, prev, tok.Start) {
+			spans = append(spans, span{c[0], c[1], tokenComment})
+		}
+		if typ := tokenType(tok); typ >= 0 {
+			spans = append(spans, span{tok.Start, tok.End, typ})
+		}
+		prev = tok.End
+	}
+	if d.scanErr == nil {
+		for _, c := range comments(d.text, prev, len(d.text)) {
+			spans = append(spans, span{c[0], c[1], tokenComment})
+		}
+	}
+
+	data := []int{}
+	var last position
+	emit := func(start, end, typ int) {
+		if start == end {
+			return
+		}
+		pos := d.position(start)
+		char := pos.Character
+		if pos.Line == last.Line {
+			char -= last.Character
+		}
+		data = append(data, pos.Line-last.Line, char, utf16Len(d.text[start:end]), typ, 0)
+		last = pos
+	}
+	for _, s := range spans {
+		for s.start < s.end {
+			end := s.end
+			if i := strings.IndexByte(d.text[s.start:end], '\n'); i >= 0 {
+				end = s.start + i
+			}
+			emit(s.start, end, s.typ)
+			s.start = end + 1
+		}
+	}
+	return data
+}
+
+// comments returns the spans of the comments in text[from:to], which
+// holds only whitespace and comments.
+func comments(text string, from, to int) [][2]int {
+	var spans [][2]int
+	for i := from; i < to; {
+		switch {
+		case strings.HasPrefix(text[i:to], "--"):
+			end := strings.IndexAny(text[i:to], "\r\n")
+			if end < 0 {
+				end = to - i
+			}
+			spans = append(spans, [2]int{i, i + end})
+			i += end
+		case strings.HasPrefix(text[i:to], "/*"):
+			// Block comments nest.
+			depth, j := 1, i+2
+			for j < to && depth > 0 {
+				switch {
+				case strings.HasPrefix(text[j:to], "/*"):
+					depth++
+					j += 2
+				case strings.HasPrefix(text[j:to], "*/"):
+					depth--
+					j += 2
+				default:
+					j++
+				}
+			}
+			spans = append(spans, [2]int{i, j})
+			i = j
+		default:
+			i++
+		}
+	}
+	return spans
+}
+
+// foldingRanges returns a range for each statement that spans lines.
+func (d *document) foldingRanges() []foldingRange {
+	ranges := []foldingRange{}
+	for _, st := range d.stmts {
+		start, end := d.position(st.start).Line, d.position(st.end).Line
+		if end > start {
+			ranges = append(ranges, foldingRange{StartLine: start, EndLine: end})
+		}
+	}
+	return ranges
+}
+
+// formatting returns an edit replacing the document with its formatted
+// text, or no edits if it is already formatted. It fails if the document
+// does not parse.
+func (d *document) formatting(tabSize int) ([]textEdit, error) {
+	opts := format.DefaultOptions()
+	if tabSize > 0 {
+		opts.IndentWidth = tabSize
+	}
+	out, err := format.Format(d.text, opts)
+	if err != nil {
+		return nil, err
+	}
+	if out == d.text {
+		return []textEdit{}, nil
+	}
+	return []textEdit{{Range: d.span(0, len(d.text)), NewText: out}}, nil
+}
+
+// documentSymbols returns a symbol for each object the document creates.
+func (d *document) documentSymbols() []documentSymbol {
+	syms := []documentSymbol{}
+	for _, st := range d.stmts {
+		syms = append(syms, d.symbols(st.node, st)...)
+	}
+	return syms
+}
+
+// symbols returns the symbols of the objects that n, statement st or one
+// of its schema elements, creates.
+func (d *document) symbols(n nodes.Node, st statement) []documentSymbol {
+	switch n := n.(type) {
+	case *nodes.CreateSchemaStmt:
+		sym, _ := d.symbol(symbolNamespace, "", n.Schemaname, "schema", st, st.start)
+		for _, elt := range listItems(n.SchemaElts) {
+			sym.Children = append(sym.Children, d.symbols(elt, st)...)
+		}
+		return []documentSymbol{sym}
+	case *nodes.CreateExtensionStmt:
+		sym, _ := d.symbol(symbolPackage, "", n.Extname, "extension", st, st.start)
+		return []documentSymbol{sym}
+	case *nodes.CreateStmt:
+		return []documentSymbol{d.table(n, "table", st)}
+	case *nodes.CreateForeignTableStmt:
+		return []documentSymbol{d.table(&n.Base, "foreign table", st)}
+	case *nodes.ViewStmt:
+		sym, _ := d.symbol(symbolInterface, n.View.Schemaname, n.View.Relname, "view", st, st.start)
+		return []documentSymbol{sym}
+	case *nodes.CreateTableAsStmt:
+		if n.Into == nil || n.Into.Rel == nil {
+			return nil
+		}
+		kind, detail := symbolClass, "table"
+		if n.Objtype == nodes.OBJECT_MATVIEW {
+			kind, detail = symbolInterface, "materialized view"
+		}
+		sym, _ := d.symbol(kind, n.Into.Rel.Schemaname, n.Into.Rel.Relname, detail, st, st.start)
+		return []documentSymbol{sym}
+	case *nodes.CreateSeqStmt:
+		sym, _ := d.symbol(symbolVariable, n.Sequence.Schemaname, n.Sequence.Relname, "sequence", st, st.start)
+		return []documentSymbol{sym}
+	case *nodes.IndexStmt:
+		if n.Idxname == "" {
+			return nil
+		}
+		sym, _ := d.symbol(symbolKey, "", n.Idxname, "index on "+n.Relation.Relname, st, st.start)
+		return []documentSymbol{sym}
+	case *nodes.CreateTrigStmt:
+		sym, _ := d.symbol(symbolEvent, "", n.Trigname, "trigger on "+n.Relation.Relname, st, st.start)
+		return []documentSymbol{sym}
+	case *nodes.CreateFunctionStmt:
+		detail := "function"
+		if n.IsProcedure {
+			detail = "procedure"
+		}
+		var args []string
+		for _, item := range listItems(n.Parameters) {
+			if p, ok := item.(*nodes.FunctionParameter); ok && p.Mode != nodes.FUNC_PARAM_OUT && p.Mode != nodes.FUNC_PARAM_TABLE {
+				args = append(args, catalog.FormatTypeName(p.ArgType))
+			}
+		}
+		schema, name := qualifiedName(n.Funcname)
+		sym, _ := d.symbol(symbolFunction, schema, name, detail+"("+strings.Join(args, ", ")+")", st, st.start)
+		return []documentSymbol{sym}
+	case *nodes.DefineStmt:
+		if n.Kind != nodes.OBJECT_AGGREGATE && n.Kind != nodes.OBJECT_TYPE {
+			return nil
+		}
+		kind, detail := symbolStruct, "type"
+		if n.Kind == nodes.OBJECT_AGGREGATE {
+			kind, detail = symbolFunction, "aggregate"
+		}
+		schema, name := qualifiedName(n.Defnames)
+		sym, _ := d.symbol(kind, schema, name, detail, st, st.start)
+		return []documentSymbol{sym}
+	case *nodes.CreateEnumStmt:
+		schema, name := qualifiedName(n.TypeName)
+		sym, from := d.symbol(symbolEnum, schema, name, "enum", st, st.start)
+		for _, item := range listItems(n.Vals) {
+			if s, ok := item.(*nodes.String); ok {
+				var member documentSymbol
+				member, from = d.child(symbolEnumMember, s.Sval, "", st, from)
+				sym.Children = append(sym.Children, member)
+			}
+		}
+		return []documentSymbol{sym}
+	case *nodes.CompositeTypeStmt:
+		sym, from := d.symbol(symbolStruct, n.Typevar.Schemaname, n.Typevar.Relname, "type", st, st.start)
+		sym.Children = d.columns(n.Coldeflist, st, from)
+		return []documentSymbol{sym}
+	case *nodes.CreateRangeStmt:
+		schema, name := qualifiedName(n.TypeName)
+		sym, _ := d.symbol(symbolStruct, schema, name, "range type", st, st.start)
+		return []documentSymbol{sym}
+	case *nodes.CreateDomainStmt:
+		schema, name := qualifiedName(n.Domainname)
+		sym, _ := d.symbol(symbolTypeParameter, schema, name, "domain over "+catalog.FormatTypeName(n.TypeName), st, st.start)
+		return []documentSymbol{sym}
+	}
+	return nil
+}
+
+// table returns the symbol of a table, with its columns as children.
+func (d *document) table(n *nodes.CreateStmt, detail string, st statement) documentSymbol {
+	sym, from := d.symbol(symbolClass, n.Relation.Schemaname, n.Relation.Relname, detail, st, st.start)
+	sym.Children = d.columns(n.TableElts, st, from)
+	return sym
+}
+
+// columns returns the symbols of the column definitions in elts, looking
+// for their names in st from offset from on.
+func (d *document) columns(elts *nodes.List, st statement, from int) []documentSymbol {
+	var syms []documentSymbol
+	for _, elt := range listItems(elts) {
+		if cd, ok := elt.(*nodes.ColumnDef); ok {
+			var sym documentSymbol
+			sym, from = d.child(symbolField, cd.Colname, catalog.FormatTypeName(cd.TypeName), st, from)
+			syms = append(syms, sym)
+		}
+	}
+	return syms
+}
+
+// symbol returns the symbol of an object named in statement st, which is
+// its range. Its selection range is the first mention of its name from
+// offset from on, whose end symbol also returns.
+func (d *document) symbol(kind int, schema, name, detail string, st statement, from int) (documentSymbol, int) {
+	sym := documentSymbol{Name: name, Detail: detail, Kind: kind, Range: d.span(st.start, st.end)}
+	if schema != "" {
+		sym.Name = schema + "." + name
+	}
+	start, end, ok := d.findName(name, from, st.end)
+	if !ok {
+		start, end = st.start, st.start
+	}
+	sym.SelectionRange = d.span(start, end)
+	if !ok {
+		end = from
+	}
+	return sym, end
+}
+
+// child returns the symbol of a part of an object, such as a column, whose
+// range is its name.
+func (d *document) child(kind int, name, detail string, st statement, from int) (documentSymbol, int) {
+	sym, end := d.symbol(kind, "", name, detail, st, from)
+	sym.Range = sym.SelectionRange
+	return sym, end
+}
+
+// qualifiedName splits a list of String nodes, such as a function name,
+// into its schema and name.
+func qualifiedName(l *nodes.List) (schema, name string) {
+	items := listItems(l)
+	if len(items) == 0 {
+		return "", ""
+	}
+	if s, ok := items[len(items)-1].(*nodes.String); ok {
+		name = s.Sval
+	}
+	if len(items) > 1 {
+		if s, ok := items[len(items)-2].(*nodes.String); ok {
+			schema = s.Sval
+		}
+	}
+	return schema, name
+}
+
+func listItems(l *nodes.List) []nodes.Node {
+	if l == nil {
+		return nil
+	}
+	return l.Items
+}
@@ -0,0 +1,41 @@
+// Command pgsql-lsp is a language server for SQL files, which editors run
+// and talk to over standard input and output.
+//
+// Usage:
+//
+//	pgsql-lsp
+//
+// It reports syntax errors as diagnostics and provides semantic tokens
+// from the lexer, a document symbol for each object a DDL statement
+// creates, a folding range for each statement and formatting by the
+// format package. Everything runs locally; no database is needed.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: pgsql-lsp\n\nThe server speaks the Language Server Protocol over standard input and output.")
+	}
+	flag.Parse()
+	if flag.NArg() > 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	err := newServer(os.Stdin, os.Stdout).serve()
+	if exit, ok := err.(errExit); ok {
+		if !exit.clean {
+			os.Exit(1)
+		}
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "pgsql-lsp: %v\n", err)
+		os.Exit(1)
+	}
+}
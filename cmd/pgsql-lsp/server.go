@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// server is a language server for one client. It handles one message at a
+// time, so its state needs no locking.
+type server struct {
+	in   *bufio.Reader
+	out  io.Writer
+	docs map[string]*document
+
+	initialized bool
+	shutdown    bool
+}
+
+func newServer(in io.Reader, out io.Writer) *server {
+	return &server{in: bufio.NewReader(in), out: out, docs: map[string]*document{}}
+}
+
+// errExit is returned by serve when the client sends exit. The client
+// should have sent shutdown first.
+type errExit struct{ clean bool }
+
+func (e errExit) Error() string { return "exit" }
+
+// serve handles messages until the client exits or the input ends.
+func (s *server) serve() error {
+	for {
+		data, err := s.read()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		var msg message
+		if err := json.Unmarshal(data, &msg); err != nil {
+			s.reply(nil, nil, &responseError{Code: codeParseError, Message: err.Error()})
+			continue
+		}
+		if msg.Method == "exit" {
+			return errExit{clean: s.shutdown}
+		}
+		if msg.ID == nil {
+			s.notification(&msg)
+			continue
+		}
+		result, rerr := s.request(&msg)
+		s.reply(msg.ID, result, rerr)
+	}
+}
+
+// read reads the content of the next message, which follows a header
+// giving its length.
+func (s *server) read() ([]byte, error) {
+	header, err := textproto.NewReader(s.in).ReadMIMEHeader()
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("reading header: %v", err)
+	}
+	n, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("bad Content-Length %q", header.Get("Content-Length"))
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(s.in, data); err != nil {
+		return nil, fmt.Errorf("reading message: %v", err)
+	}
+	return data, nil
+}
+
+// write sends a message with its header.
+func (s *server) write(msg *message) {
+	msg.JSONRPC = "2.0"
+	data, err := json.Marshal(msg)
+	if err != nil {
+		// Every message the server sends is built from types that marshal.
+		panic(err)
+	}
+	fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(data), data)
+}
+
+func (s *server) reply(id *json.RawMessage, result interface{}, rerr *responseError) {
+	if id == nil {
+		null := json.RawMessage("null")
+		id = &null
+	}
+	if rerr == nil && result == nil {
+		// A response must have a result or an error.
+		result = json.RawMessage("null")
+	}
+	s.write(&message{ID: id, Result: result, Error: rerr})
+}
+
+func (s *server) notify(method string, params interface{}) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		panic(err)
+	}
+	s.write(&message{Method: method, Params: data})
+}
+
+func (s *server) request(msg *message) (interface{}, *responseError) {
+	if msg.Method == "initialize" {
+		s.initialized = true
+		return map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync":           1, // full text on every change
+				"documentSymbolProvider":     true,
+				"foldingRangeProvider":       true,
+				"documentFormattingProvider": true,
+				"semanticTokensProvider": map[string]interface{}{
+					"legend": map[string]interface{}{"tokenTypes": tokenTypes, "tokenModifiers": []string{}},
+					"full":   true,
+				},
+			},
+			"serverInfo": map[string]string{"name": "pgsql-lsp"},
+		}, nil
+	}
+	if !s.initialized {
+		return nil, &responseError{Code: codeServerNotInitialized, Message: "server not initialized"}
+	}
+	if msg.Method == "shutdown" {
+		s.shutdown = true
+		return nil, nil
+	}
+	if !strings.HasPrefix(msg.Method, "textDocument/") {
+		return nil, &responseError{Code: codeMethodNotFound, Message: "method not found: " + msg.Method}
+	}
+
+	var params formattingParams
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		return nil, &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	d, ok := s.docs[params.TextDocument.URI]
+	if !ok {
+		return nil, &responseError{Code: codeInvalidParams, Message: "document not open: " + params.TextDocument.URI}
+	}
+	switch msg.Method {
+	case "textDocument/documentSymbol":
+		return d.documentSymbols(), nil
+	case "textDocument/foldingRange":
+		return d.foldingRanges(), nil
+	case "textDocument/semanticTokens/full":
+		return semanticTokens{Data: d.semanticTokens()}, nil
+	case "textDocument/formatting":
+		edits, err := d.formatting(params.Options.TabSize)
+		if err != nil {
+			// Leave a document that does not parse as it is; its
+			// diagnostics already show why.
+			return []textEdit{}, nil
+		}
+		return edits, nil
+	}
+	return nil, &responseError{Code: codeMethodNotFound, Message: "method not found: " + msg.Method}
+}
+
+func (s *server) notification(msg *message) {
+	if !s.initialized {
+		return
+	}
+	switch msg.Method {
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if json.Unmarshal(msg.Params, &params) == nil {
+			s.open(params.TextDocument.URI, params.TextDocument.Text)
+		}
+	case "textDocument/didChange":
+		var params didChangeParams
+		if json.Unmarshal(msg.Params, &params) == nil && len(params.ContentChanges) > 0 {
+			s.open(params.TextDocument.URI, params.ContentChanges[len(params.ContentChanges)-1].Text)
+		}
+	case "textDocument/didClose":
+		var params documentParams
+		if json.Unmarshal(msg.Params, &params) == nil {
+			delete(s.docs, params.TextDocument.URI)
+			s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: params.TextDocument.URI, Diagnostics: []diagnostic{}})
+		}
+	}
+}
+
+// open records the text of a document and publishes its diagnostics.
+func (s *server) open(uri, text string) {
+	d := newDocument(text)
+	s.docs[uri] = d
+	s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: d.diagnostics()})
+}
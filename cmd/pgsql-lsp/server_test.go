@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// session runs the server over the given messages and returns the
+// messages it sends, keyed by request id or, for notifications, method.
+func session(t *testing.T, msgs ...string) map[string][]json.RawMessage {
+	t.Helper()
+	var in bytes.Buffer
+	for _, m := range msgs {
+		fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(m), m)
+	}
+	var out bytes.Buffer
+	if err := newServer(&in, &out).serve(); err != nil {
+		if exit, ok := err.(errExit); !ok || !exit.clean {
+			t.Fatalf("serve: %v", err)
+		}
+	}
+
+	got := map[string][]json.RawMessage{}
+	r := bufio.NewReader(&out)
+	for {
+		header, err := textproto.NewReader(r).ReadMIMEHeader()
+		if err == io.EOF {
+			return got
+		}
+		if err != nil {
+			t.Fatalf("reading header: %v", err)
+		}
+		n, _ := strconv.Atoi(header.Get("Content-Length"))
+		data := make([]byte, n)
+		if _, err := io.ReadFull(r, data); err != nil {
+			t.Fatal(err)
+		}
+		var msg struct {
+			ID     json.RawMessage
+			Method string
+			Params json.RawMessage
+			Result json.RawMessage
+			Error  *responseError
+		}
+		if err := json.Unmarshal(data, &msg); err != nil {
+			t.Fatal(err)
+		}
+		switch {
+		case msg.Error != nil:
+			got[string(msg.ID)] = append(got[string(msg.ID)], json.RawMessage(`"error: `+msg.Error.Message+`"`))
+		case msg.Method != "":
+			got[msg.Method] = append(got[msg.Method], msg.Params)
+		default:
+			got[string(msg.ID)] = append(got[string(msg.ID)], msg.Result)
+		}
+	}
+}
+
+func request(id int, method, params string) string {
+	return fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":%q,"params":%s}`, id, method, params)
+}
+
+func notification(method, params string) string {
+	return fmt.Sprintf(`{"jsonrpc":"2.0","method":%q,"params":%s}`, method, params)
+}
+
+func didOpen(text string) string {
+	data, _ := json.Marshal(text)
+	return notification("textDocument/didOpen", `{"textDocument":{"uri":"file:///a.sql","version":1,"text":`+string(data)+`}}`)
+}
+
+const doc = `{"textDocument":{"uri":"file:///a.sql"}}`
+
+func TestSession(t *testing.T) {
+	src := "-- tables\nCREATE TABLE s.t (\n    id int,\n    \"Name\" text\n);\nCREATE TYPE mood AS ENUM ('sad', 'ok');\nselect 1"
+	got := session(t,
+		request(1, "initialize", `{}`),
+		notification("initialized", `{}`),
+		didOpen(src),
+		request(2, "textDocument/documentSymbol", doc),
+		request(3, "textDocument/foldingRange", doc),
+		request(4, "textDocument/formatting", `{"textDocument":{"uri":"file:///a.sql"},"options":{"tabSize":2,"insertSpaces":true}}`),
+		request(5, "textDocument/hover", doc),
+		notification("textDocument/didChange", `{"textDocument":{"uri":"file:///a.sql","version":2},"contentChanges":[{"text":"SELECT 1;\nSELECT FROM ("}]}`),
+		request(6, "shutdown", `null`),
+		notification("exit", `null`),
+	)
+
+	var syms []documentSymbol
+	if err := json.Unmarshal(got["2"][0], &syms); err != nil {
+		t.Fatal(err)
+	}
+	pos := func(line, char int) position { return position{Line: line, Character: char} }
+	if len(syms) != 2 {
+		t.Fatalf("got %d symbols, want 2: %s", len(syms), got["2"][0])
+	}
+	table := syms[0]
+	if table.Name != "s.t" || table.Kind != symbolClass ||
+		table.Range != (lspRange{pos(1, 0), pos(4, 1)}) || table.SelectionRange != (lspRange{pos(1, 15), pos(1, 16)}) {
+		t.Errorf("table symbol = %+v", table)
+	}
+	if len(table.Children) != 2 || table.Children[1].Name != "Name" || table.Children[1].Detail != "text" ||
+		table.Children[1].Range != (lspRange{pos(3, 4), pos(3, 10)}) {
+		t.Errorf("column symbols = %+v", table.Children)
+	}
+	enum := syms[1]
+	if enum.Name != "mood" || enum.Kind != symbolEnum || len(enum.Children) != 2 ||
+		enum.Children[1].SelectionRange != (lspRange{pos(5, 33), pos(5, 37)}) {
+		t.Errorf("enum symbol = %+v", enum)
+	}
+
+	if want := `[{"startLine":1,"endLine":4}]`; string(got["3"][0]) != want {
+		t.Errorf("folding ranges = %s, want %s", got["3"][0], want)
+	}
+
+	var edits []textEdit
+	if err := json.Unmarshal(got["4"][0], &edits); err != nil {
+		t.Fatal(err)
+	}
+	if len(edits) != 1 || edits[0].Range.End != pos(6, 8) || !strings.Contains(edits[0].NewText, "\n  id") {
+		t.Errorf("formatting edits = %+v", edits)
+	}
+
+	if !strings.HasPrefix(string(got["5"][0]), `"error: method not found`) {
+		t.Errorf("hover = %s, want an error", got["5"][0])
+	}
+
+	diags := got["textDocument/publishDiagnostics"]
+	if len(diags) != 2 {
+		t.Fatalf("got %d diagnostic notifications, want 2", len(diags))
+	}
+	if want := `{"uri":"file:///a.sql","diagnostics":[]}`; string(diags[0]) != want {
+		t.Errorf("diagnostics = %s, want %s", diags[0], want)
+	}
+	var params publishDiagnosticsParams
+	if err := json.Unmarshal(diags[1], &params); err != nil {
+		t.Fatal(err)
+	}
+	if len(params.Diagnostics) != 1 || params.Diagnostics[0].Range.Start != pos(1, 13) {
+		t.Errorf("diagnostics = %s", diags[1])
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	tests := []struct {
+		src  string
+		want lspRange
+	}{
+		{"SELECT 1;\nSELECT * FROM FROM t", lspRange{position{1, 14}, position{1, 18}}},
+		{"SELECT 1;\nSELECT 'abc\n", lspRange{position{1, 7}, position{1, 11}}},
+		{"SELECT 1", lspRange{}},
+	}
+	for _, tt := range tests {
+		diags := newDocument(tt.src).diagnostics()
+		var got lspRange
+		if len(diags) > 0 {
+			got = diags[0].Range
+		}
+		if got != tt.want {
+			t.Errorf("diagnostics(%q) = %+v, want range %+v", tt.src, diags, tt.want)
+		}
+	}
+}
+
+func TestNotInitialized(t *testing.T) {
+	got := session(t, request(1, "textDocument/documentSymbol", doc))
+	if want := `"error: server not initialized"`; string(got["1"][0]) != want {
+		t.Errorf("got %s, want %s", got["1"][0], want)
+	}
+}
+
+func TestSemanticTokens(t *testing.T) {
+	d := newDocument("SELECT 'a', /* x\ny */ $1 -- é\nFROM t WHERE a >= 2.5")
+	want := []int{
+		0, 0, 6, tokenKeyword, 0,
+		0, 7, 3, tokenString, 0,
+		0, 5, 4, tokenComment, 0,
+		1, 0, 4, tokenComment, 0,
+		0, 5, 2, tokenParameter, 0,
+		0, 3, 4, tokenComment, 0,
+		1, 0, 4, tokenKeyword, 0,
+		0, 7, 5, tokenKeyword, 0,
+		0, 8, 2, tokenOperator, 0,
+		0, 3, 3, tokenNumber, 0,
+	}
+	if got := d.semanticTokens(); !reflect.DeepEqual(got, want) {
+		t.Errorf("semanticTokens:\ngot  %v\nwant %v", got, want)
+	}
+}
+
+func TestPosition(t *testing.T) {
+	d := newDocument("a\n😀b\n")
+	tests := []struct {
+		offset int
+		want   position
+	}{
+		{0, position{0, 0}},
+		{2, position{1, 0}},
+		{6, position{1, 2}},
+		{7, position{1, 3}},
+		{8, position{2, 0}},
+		{100, position{2, 0}},
+	}
+	for _, tt := range tests {
+		if got := d.position(tt.offset); got != tt.want {
+			t.Errorf("position(%d) = %+v, want %+v", tt.offset, got, tt.want)
+		}
+	}
+}
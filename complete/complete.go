@@ -0,0 +1,369 @@
+// Package complete suggests what can be typed at a position in SQL text,
+// for editors and consoles that have no database to ask.
+//
+// Keywords come from the grammar: the parser's tables are run over the
+// statement up to the cursor, and the keywords they accept next are
+// suggested. With a catalog, relation names are suggested where the
+// grammar takes a name after FROM, JOIN, UPDATE, INTO or TABLE, and the
+// columns of the relations of the statement's FROM clause where it takes
+// one in an expression. A name qualified by an alias or relation, as in
+// "t.", is completed with that relation's columns only; one qualified by
+// a schema, as in "FROM s.", with the relations of that schema.
+package complete
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pgplex/pgparser/catalog"
+	"github.com/pgplex/pgparser/parser"
+)
+
+// Kind identifies what a candidate is.
+type Kind int
+
+const (
+	Keyword Kind = iota
+	Schema
+	Relation
+	Column
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Keyword:
+		return "keyword"
+	case Schema:
+		return "schema"
+	case Relation:
+		return "relation"
+	case Column:
+		return "column"
+	}
+	return "unknown"
+}
+
+// Candidate is a suggestion.
+type Candidate struct {
+	Text   string // text to insert, in place of the word at the cursor
+	Kind   Kind
+	Detail string // for relations, the relation kind; for columns, the relation and type
+}
+
+// Result is the suggestions at a position.
+type Result struct {
+	// Start is the offset of the start of the word at the cursor, which
+	// the candidates replace; it is the cursor position if there is none.
+	Start      int
+	Candidates []Candidate
+}
+
+// Completer completes SQL text, taking the names of relations and columns
+// from a catalog.
+type Completer struct {
+	cat *catalog.Catalog
+}
+
+// New returns a completer for names in c, which may be nil to complete
+// keywords only.
+func New(c *catalog.Catalog) *Completer {
+	return &Completer{cat: c}
+}
+
+// At returns the keywords that can be typed at offset in sql.
+func At(sql string, offset int) *Result {
+	return New(nil).At(sql, offset)
+}
+
+// At returns the keywords, and the names from the catalog, that can be
+// typed at offset in sql, starting with the word before offset. Nothing is
+// suggested inside strings and comments, or where the statement before
+// offset does not parse.
+func (c *Completer) At(sql string, offset int) *Result {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > len(sql) {
+		offset = len(sql)
+	}
+	res := &Result{Start: offset}
+	toks, err := parser.Scan(sql)
+	if perr, ok := err.(*parser.ParseError); ok && perr.Position < offset {
+		// The cursor is in or after an unterminated string or comment.
+		return res
+	}
+
+	// Find the statement the cursor is in, and the tokens before it.
+	stmtStart, stmtEnd := 0, len(toks)
+	cur := len(toks) // index of the first token at or after the word
+	for i, tok := range toks {
+		if tok.End < offset || (tok.End == offset && !isWord(sql, tok)) {
+			if tok.Name == "';'" {
+				stmtStart = i + 1
+			}
+			continue
+		}
+		if tok.Start < offset {
+			if !isWord(sql, tok) {
+				// The cursor is inside a string, number or operator.
+				return res
+			}
+			res.Start = tok.Start
+		}
+		cur = i
+		break
+	}
+	for i := cur; i < len(toks); i++ {
+		if toks[i].Name == "';'" {
+			stmtEnd = i
+			break
+		}
+	}
+	from, last := 0, 0
+	if stmtStart > 0 {
+		from = toks[stmtStart-1].End
+	}
+	if cur > 0 {
+		last = toks[cur-1].End
+	}
+	if inComment(sql[last:res.Start]) {
+		return res
+	}
+	before := toks[stmtStart:cur]
+	word := strings.ToLower(sql[res.Start:offset])
+
+	exp, err := parser.ExpectedAfter(sql[from:res.Start])
+	if err != nil {
+		return res
+	}
+
+	// A word after "name." completes a column or a relation of a schema.
+	var qualifier string
+	if n := len(before); n >= 2 && before[n-1].Name == "'.'" && isWord(sql, before[n-2]) {
+		qualifier = identifier(sql, before[n-2])
+		before = before[:n-2]
+	}
+
+	if exp.Ident && c.cat != nil {
+		stmt := toks[stmtStart:stmtEnd]
+		switch {
+		case relationContext(before):
+			res.Candidates = append(res.Candidates, c.relations(qualifier, word)...)
+		case columnContext(before):
+			res.Candidates = append(res.Candidates, c.columns(fromItems(sql, stmt), qualifier, word)...)
+		}
+	}
+	if qualifier == "" {
+		for _, kw := range exp.Keywords {
+			if strings.HasPrefix(kw.Name, word) {
+				res.Candidates = append(res.Candidates, Candidate{Text: strings.ToUpper(kw.Name), Kind: Keyword})
+			}
+		}
+	}
+	return res
+}
+
+// isWord reports whether tok is a name, or a keyword, written without
+// quotes.
+func isWord(sql string, tok parser.ScanToken) bool {
+	return (tok.Name == "IDENT" || tok.Keyword != nil) && !strings.HasPrefix(sql[tok.Start:], `"`)
+}
+
+// identifier returns the name tok spells.
+func identifier(sql string, tok parser.ScanToken) string {
+	text := sql[tok.Start:tok.End]
+	if strings.HasPrefix(text, `"`) {
+		return strings.ReplaceAll(strings.TrimSuffix(text[1:], `"`), `""`, `"`)
+	}
+	return strings.ToLower(text)
+}
+
+// inComment reports whether gap, the text between the last token and the
+// cursor, leaves the cursor inside a comment.
+func inComment(gap string) bool {
+	for i := 0; i < len(gap); i++ {
+		switch {
+		case strings.HasPrefix(gap[i:], "--"):
+			end := strings.IndexAny(gap[i:], "\r\n")
+			if end < 0 {
+				return true
+			}
+			i += end
+		case strings.HasPrefix(gap[i:], "/*"):
+			end := strings.Index(gap[i:], "*/")
+			if end < 0 {
+				return true
+			}
+			i += end + 1
+		}
+	}
+	return false
+}
+
+// keyword returns the lower-case name of tok if it is a keyword, or "".
+func keyword(tok parser.ScanToken) string {
+	if tok.Keyword == nil {
+		return ""
+	}
+	return tok.Keyword.Name
+}
+
+// relationContext reports whether a name after the tokens before is a
+// relation: whether they end in FROM, JOIN, UPDATE, INTO, TABLE or ONLY,
+// or in a comma in a FROM clause.
+func relationContext(before []parser.ScanToken) bool {
+	if len(before) == 0 {
+		return false
+	}
+	switch last := before[len(before)-1]; keyword(last) {
+	case "from", "join", "update", "into", "table", "only":
+		return true
+	case "":
+		return last.Name == "','" && clause(before) == "from"
+	}
+	return false
+}
+
+// columnContext reports whether a name after the tokens before is in an
+// expression that can refer to the columns of the FROM clause.
+func columnContext(before []parser.ScanToken) bool {
+	switch clause(before) {
+	case "select", "where", "on", "by", "having", "set", "returning":
+		return true
+	}
+	return false
+}
+
+// clauseKeywords are the keywords that start the clauses clause tells
+// apart.
+var clauseKeywords = map[string]bool{
+	"select": true, "from": true, "join": true, "where": true, "on": true, "using": true,
+	"by": true, "having": true, "window": true, "limit": true, "offset": true,
+	"set": true, "returning": true, "values": true, "into": true, "update": true,
+}
+
+// clause returns the keyword that starts the clause the tokens before
+// end in, skipping parenthesized subqueries before the end but not those
+// the end is in; JOIN counts as FROM.
+func clause(before []parser.ScanToken) string {
+	depth := 0
+	for i := len(before) - 1; i >= 0; i-- {
+		switch before[i].Name {
+		case "')'":
+			depth++
+		case "'('":
+			if depth > 0 {
+				depth--
+			}
+		default:
+			if kw := keyword(before[i]); depth == 0 && clauseKeywords[kw] {
+				if kw == "join" {
+					return "from"
+				}
+				return kw
+			}
+		}
+	}
+	return ""
+}
+
+// fromItem is a relation of a FROM clause.
+type fromItem struct {
+	schema, name, alias string
+}
+
+// fromItems returns the relations named in the FROM clauses of the
+// statement with tokens stmt, at any level, and in the target of UPDATE
+// and INSERT.
+func fromItems(sql string, stmt []parser.ScanToken) []fromItem {
+	var items []fromItem
+	for i := 0; i < len(stmt); i++ {
+		if !relationContext(stmt[:i]) || !isName(stmt[i]) {
+			continue
+		}
+		item := fromItem{name: identifier(sql, stmt[i])}
+		if i+2 < len(stmt) && stmt[i+1].Name == "'.'" && isName(stmt[i+2]) {
+			item.schema, item.name = item.name, identifier(sql, stmt[i+2])
+			i += 2
+		}
+		j := i + 1
+		if j < len(stmt) && keyword(stmt[j]) == "as" {
+			j++
+		}
+		if j < len(stmt) && stmt[j].Name == "IDENT" {
+			item.alias = identifier(sql, stmt[j])
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func isName(tok parser.ScanToken) bool {
+	return tok.Name == "IDENT" || (tok.Keyword != nil && tok.Keyword.Category == parser.UnreservedKeyword)
+}
+
+// relations returns the relations of schema, or of the search path if
+// schema is "", whose names start with prefix, followed in the second case
+// by the schemas that do.
+func (c *Completer) relations(schema, prefix string) []Candidate {
+	var out []Candidate
+	add := func(s *catalog.Schema) {
+		for _, r := range s.Relations() {
+			if r.Kind == catalog.RelKindIndex || r.Kind == catalog.RelKindPartitionedIndex {
+				continue
+			}
+			if strings.HasPrefix(strings.ToLower(r.Name), prefix) {
+				out = append(out, Candidate{Text: catalog.QuoteIdentifier(r.Name), Kind: Relation, Detail: r.Kind.String()})
+			}
+		}
+	}
+	if schema != "" {
+		if s := c.cat.Schema(schema); s != nil {
+			add(s)
+		}
+		return out
+	}
+	for _, name := range c.cat.ActiveSearchPath() {
+		add(c.cat.Schema(name))
+	}
+	for _, s := range c.cat.Schemas() {
+		if strings.HasPrefix(strings.ToLower(s.Name), prefix) && s.Name != "pg_catalog" {
+			out = append(out, Candidate{Text: catalog.QuoteIdentifier(s.Name), Kind: Schema})
+		}
+	}
+	return out
+}
+
+// columns returns the columns whose names start with prefix of the
+// relations of items, or only of the one qualifier names, by alias or by
+// name, if it is not "".
+func (c *Completer) columns(items []fromItem, qualifier, prefix string) []Candidate {
+	var out []Candidate
+	seen := map[*catalog.Relation]bool{}
+	for _, item := range items {
+		if qualifier != "" && qualifier != item.alias && (item.alias != "" || qualifier != item.name) {
+			continue
+		}
+		r := c.cat.LookupRelation(item.schema, item.name)
+		if r == nil || seen[r] {
+			continue
+		}
+		seen[r] = true
+		ref := item.name
+		if item.alias != "" {
+			ref = item.alias
+		}
+		for _, col := range r.Columns {
+			if !strings.HasPrefix(strings.ToLower(col.Name), prefix) {
+				continue
+			}
+			detail := ref + "." + col.Name
+			if col.Type != nil {
+				detail += " " + catalog.FormatTypeName(col.Type)
+			}
+			out = append(out, Candidate{Text: catalog.QuoteIdentifier(col.Name), Kind: Column, Detail: detail})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Text < out[j].Text })
+	return out
+}
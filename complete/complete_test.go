@@ -0,0 +1,115 @@
+package complete
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgparser/catalog"
+)
+
+func TestKeywords(t *testing.T) {
+	tests := []struct {
+		sql     string // | marks the cursor
+		want    []string
+		notWant []string
+	}{
+		{"|", []string{"SELECT", "CREATE", "WITH"}, []string{"FROM", "WHERE"}},
+		{"SELECT a FROM t |", []string{"WHERE", "JOIN", "ORDER", "LIMIT"}, []string{"SELECT", "ACTION"}},
+		{"SELECT a FROM t WH|", []string{"WHERE"}, []string{"JOIN", "WINDOW"}},
+		{"select a from t wh|ere", []string{"WHERE"}, nil},
+		{"SELECT a FROM t WHERE a NOT |", []string{"BETWEEN", "IN", "LIKE"}, []string{"WHERE"}},
+		{"CREATE |", []string{"TABLE", "INDEX", "VIEW", "FUNCTION"}, []string{"SELECT"}},
+		{"CREATE TABLE t (a |", []string{"INTEGER", "VARCHAR", "NUMERIC"}, []string{"ABORT"}},
+		{"SELECT 1; DROP |", []string{"TABLE"}, []string{"SELECT"}},
+		{"SELECT a AS |", nil, []string{"FROM", "ABORT"}},
+		{"SELECT 'it|'", nil, []string{"FROM"}},
+		{"SELECT a -- note |", nil, []string{"FROM"}},
+		{"SELECT FROM FROM |", nil, []string{"FROM"}},
+	}
+	for _, tt := range tests {
+		offset := strings.Index(tt.sql, "|")
+		sql := tt.sql[:offset] + tt.sql[offset+1:]
+		r := At(sql, offset)
+		got := map[string]bool{}
+		for _, c := range r.Candidates {
+			got[c.Text] = true
+		}
+		for _, w := range tt.want {
+			if !got[w] {
+				t.Errorf("At(%q) lacks %s", tt.sql, w)
+			}
+		}
+		for _, w := range tt.notWant {
+			if got[w] {
+				t.Errorf("At(%q) suggests %s", tt.sql, w)
+			}
+		}
+	}
+}
+
+func TestStart(t *testing.T) {
+	if r := At("SELECT a FROM t WH", 18); r.Start != 16 {
+		t.Errorf("Start = %d, want 16", r.Start)
+	}
+	if r := At("SELECT a FROM t ", 16); r.Start != 16 {
+		t.Errorf("Start = %d, want 16", r.Start)
+	}
+}
+
+func TestNames(t *testing.T) {
+	cat := catalog.New()
+	if err := cat.Exec(`
+		CREATE SCHEMA app;
+		CREATE TABLE users (id int, name text, "Email" text);
+		CREATE TABLE orders (id int, user_id int, total numeric(10,2));
+		CREATE VIEW big_orders AS SELECT * FROM orders WHERE total > 100;
+		CREATE INDEX orders_user ON orders (user_id);
+		CREATE TABLE app.settings (key text, value text);
+	`); err != nil {
+		t.Fatal(err)
+	}
+	c := New(cat)
+
+	tests := []struct {
+		sql     string
+		want    []string
+		notWant []string
+	}{
+		{"SELECT * FROM |", []string{"relation:users", "relation:orders", "relation:big_orders", "schema:app"},
+			[]string{"relation:orders_user", "relation:settings", "column:id"}},
+		{"SELECT * FROM us|", []string{"relation:users"}, []string{"relation:orders", "schema:app"}},
+		{"SELECT * FROM users u JOIN |", []string{"relation:orders"}, nil},
+		{"SELECT * FROM app.|", []string{"relation:settings"}, []string{"relation:users", "keyword:WHERE"}},
+		{"SELECT | FROM users u JOIN orders o ON u.id = o.user_id",
+			[]string{"column:id", "column:name", `column:"Email"`, "column:total", "keyword:DISTINCT"},
+			[]string{"column:key", "relation:users"}},
+		{"SELECT o.| FROM users u JOIN orders o ON u.id = o.user_id", []string{"column:total", "column:user_id"},
+			[]string{"column:name", "keyword:DISTINCT"}},
+		{"SELECT users.na| FROM users", []string{"column:name"}, []string{"column:id"}},
+		{"SELECT * FROM users WHERE |", []string{"column:name", "keyword:NOT"}, []string{"column:total"}},
+		{"SELECT * FROM users WHERE name |", nil, []string{"column:name"}},
+		{"UPDATE orders SET |", []string{"column:total"}, []string{"column:name"}},
+		{"SELECT * FROM (SELECT | FROM orders) s", []string{"column:total"}, nil},
+		{"SELECT count(|) FROM orders", []string{"column:total"}, nil},
+		{"SELECT * FROM orders WHERE id IN (SELECT id FROM users) AND |", []string{"column:total"}, nil},
+	}
+	for _, tt := range tests {
+		offset := strings.Index(tt.sql, "|")
+		sql := tt.sql[:offset] + tt.sql[offset+1:]
+		r := c.At(sql, offset)
+		got := map[string]bool{}
+		for _, c := range r.Candidates {
+			got[c.Kind.String()+":"+c.Text] = true
+		}
+		for _, w := range tt.want {
+			if !got[w] {
+				t.Errorf("At(%q) lacks %s", tt.sql, w)
+			}
+		}
+		for _, w := range tt.notWant {
+			if got[w] {
+				t.Errorf("At(%q) suggests %s", tt.sql, w)
+			}
+		}
+	}
+}
@@ -0,0 +1,1024 @@
+package deps
+
+import (
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// qname is a schema-qualified name.
+type qname struct {
+	schema, name string
+}
+
+// builder builds a Graph. Objects are defined in a first pass over the
+// statements; the references between them are resolved afterwards, so
+// that a statement may refer to objects defined further down the script.
+type builder struct {
+	g    *Graph
+	path []string // search path of the statement being processed
+
+	schemas map[string]bool
+	rels    map[qname]Kind
+	types   map[qname]Kind
+	funcs   map[qname]bool
+	columns map[qname][]string // columns of tables, when all are known
+
+	pending []pending
+}
+
+// pending is reference resolution deferred to after the first pass.
+type pending struct {
+	path []string
+	fn   func()
+}
+
+// scope holds the relations a query's column references may refer to.
+type scope struct {
+	parent *scope
+	rels   []scopeRel
+	ctes   map[string]bool
+}
+
+// scopeRel is an item of a FROM clause.
+type scopeRel struct {
+	name string // alias, or relation name
+	rel  Object
+	ok   bool // rel is set: the item is a relation, not a subquery or function
+}
+
+// definitionFuncs holds the options of CREATE AGGREGATE and CREATE TYPE
+// whose value names a function.
+var definitionFuncs = map[string]bool{
+	"sfunc": true, "finalfunc": true, "combinefunc": true, "serialfunc": true,
+	"deserialfunc": true, "msfunc": true, "minvfunc": true, "mfinalfunc": true,
+	"canonical": true, "subtype_diff": true,
+}
+
+// Build returns the dependency graph of the objects defined by stmts, as
+// returned by parser.Parse.
+//
+// Besides the objects themselves, the graph holds the columns and
+// constraints of tables, with each depending on its table. Views depend
+// on the relations and columns their query reads; foreign keys on the
+// referenced table and columns; indexes, triggers and policies on their
+// table and the columns and functions they use; column defaults and
+// generated columns on the sequences, functions and columns in their
+// expression; domains on their base type; and every object on the schema
+// it is created in, when the script creates that schema. Unnamed
+// constraints and indexes are given the names PostgreSQL would choose.
+func Build(stmts []nodes.Node) *Graph {
+	b := &builder{
+		g:       newGraph(),
+		path:    []string{"$user", "public"},
+		schemas: map[string]bool{},
+		rels:    map[qname]Kind{},
+		types:   map[qname]Kind{},
+		funcs:   map[qname]bool{},
+		columns: map[qname][]string{},
+	}
+	for i, stmt := range stmts {
+		if raw, ok := stmt.(*nodes.RawStmt); ok {
+			stmt = raw.Stmt
+		}
+		b.stmt(stmt, i)
+	}
+	for _, p := range b.pending {
+		b.path = p.path
+		p.fn()
+	}
+	return b.g
+}
+
+// later defers fn, run with the current search path, to after all
+// objects are defined.
+func (b *builder) later(fn func()) {
+	b.pending = append(b.pending, pending{path: b.path, fn: fn})
+}
+
+// stmt defines the objects of a statement and records the search path
+// changes it makes.
+func (b *builder) stmt(n nodes.Node, stmt int) {
+	switch n := n.(type) {
+	case *nodes.VariableSetStmt:
+		if !strings.EqualFold(n.Name, "search_path") {
+			return
+		}
+		switch n.Kind {
+		case nodes.VAR_SET_VALUE:
+			var path []string
+			for _, arg := range listItems(n.Args) {
+				if s, ok := stringConst(arg); ok {
+					path = append(path, splitSearchPath(s)...)
+				}
+			}
+			b.path = path
+		case nodes.VAR_SET_DEFAULT, nodes.VAR_RESET, nodes.VAR_RESET_ALL:
+			b.path = []string{"$user", "public"}
+		}
+	case *nodes.SelectStmt:
+		if n.IntoClause != nil {
+			rel := b.defineRel(Table, n.IntoClause.Rel, stmt)
+			query := *n
+			query.IntoClause = nil
+			b.later(func() { b.query(rel, &query, nil) })
+			return
+		}
+		b.setConfig(n)
+
+	case *nodes.CreateSchemaStmt:
+		b.schemas[n.Schemaname] = true
+		b.define(Object{Kind: Schema, Name: n.Schemaname}, stmt)
+		saved := b.path
+		b.path = []string{n.Schemaname}
+		for _, elt := range listItems(n.SchemaElts) {
+			b.stmt(elt, stmt)
+		}
+		b.path = saved
+	case *nodes.CreateStmt:
+		b.createTable(n, stmt)
+	case *nodes.CreateForeignTableStmt:
+		b.createTable(&n.Base, stmt)
+	case *nodes.ViewStmt:
+		rel := b.defineRel(View, n.View, stmt)
+		b.later(func() { b.query(rel, n.Query, nil) })
+	case *nodes.CreateTableAsStmt:
+		if n.Into == nil || n.Into.Rel == nil {
+			return
+		}
+		kind := Table
+		if n.Objtype == nodes.OBJECT_MATVIEW {
+			kind = MaterializedView
+		}
+		rel := b.defineRel(kind, n.Into.Rel, stmt)
+		b.later(func() { b.query(rel, n.Query, nil) })
+	case *nodes.CreateSeqStmt:
+		// OWNED BY is left out: the owning column's default usually
+		// depends on the sequence.
+		seq := b.defineRel(Sequence, n.Sequence, stmt)
+		b.later(func() {
+			for _, item := range listItems(n.Options) {
+				if d, ok := item.(*nodes.DefElem); ok && d.Defname == "as" {
+					b.expr(seq, d.Arg, nil)
+				}
+			}
+		})
+	case *nodes.IndexStmt:
+		b.createIndex(n, stmt)
+	case *nodes.CreateTrigStmt:
+		b.createTrigger(n, stmt)
+	case *nodes.CreatePolicyStmt:
+		rel := b.lookupRel(n.Table)
+		pol := Object{Kind: Policy, Schema: rel.Schema, Name: rel.Name, Sub: n.PolicyName}
+		b.define(pol, stmt)
+		b.later(func() {
+			b.g.addEdge(pol, rel)
+			sc := tableScope(rel)
+			b.expr(pol, n.Qual, sc)
+			b.expr(pol, n.WithCheck, sc)
+		})
+
+	case *nodes.CreateDomainStmt:
+		dom := b.defineType(Domain, n.Domainname, stmt)
+		b.later(func() {
+			b.typeRef(dom, n.TypeName)
+			for _, item := range listItems(n.Constraints) {
+				if c, ok := item.(*nodes.Constraint); ok {
+					b.expr(dom, c.RawExpr, nil)
+				}
+			}
+		})
+	case *nodes.CreateEnumStmt:
+		b.defineType(Type, n.TypeName, stmt)
+	case *nodes.CompositeTypeStmt:
+		typ := b.defineTop(Object{Kind: Type, Schema: b.schemaFor(n.Typevar.Schemaname), Name: n.Typevar.Relname}, stmt)
+		b.types[qname{typ.Schema, typ.Name}] = Type
+		b.later(func() {
+			for _, item := range listItems(n.Coldeflist) {
+				if cd, ok := item.(*nodes.ColumnDef); ok {
+					b.typeRef(typ, cd.TypeName)
+				}
+			}
+		})
+	case *nodes.CreateRangeStmt:
+		typ := b.defineType(Type, n.TypeName, stmt)
+		b.later(func() { b.definition(typ, n.Params) })
+	case *nodes.DefineStmt:
+		switch n.Kind {
+		case nodes.OBJECT_TYPE:
+			// A base type and its I/O functions depend on each other,
+			// which PostgreSQL breaks with a shell type; the type is
+			// recorded without its dependencies.
+			b.defineType(Type, n.Defnames, stmt)
+		case nodes.OBJECT_AGGREGATE:
+			agg := b.defineFunc(n.Defnames, stmt)
+			b.later(func() {
+				b.expr(agg, n.Args, nil)
+				b.definition(agg, n.Definition)
+			})
+		}
+	case *nodes.CreateFunctionStmt:
+		fn := b.defineFunc(n.Funcname, stmt)
+		b.later(func() {
+			for _, item := range listItems(n.Parameters) {
+				if p, ok := item.(*nodes.FunctionParameter); ok {
+					b.typeRef(fn, p.ArgType)
+					b.expr(fn, p.Defexpr, nil)
+				}
+			}
+			b.typeRef(fn, n.ReturnType)
+			b.expr(fn, n.SqlBody, nil)
+		})
+
+	case *nodes.AlterTableStmt:
+		b.alterTable(n, stmt)
+	}
+}
+
+// setConfig records a search path change made with set_config, as in
+// pg_dump's SELECT pg_catalog.set_config('search_path', ”, false).
+func (b *builder) setConfig(n *nodes.SelectStmt) {
+	if n.FromClause != nil {
+		return
+	}
+	for _, item := range listItems(n.TargetList) {
+		rt, ok := item.(*nodes.ResTarget)
+		if !ok {
+			continue
+		}
+		fc, ok := rt.Val.(*nodes.FuncCall)
+		if !ok {
+			continue
+		}
+		if schema, name := qualifiedName(fc.Funcname); name != "set_config" || (schema != "" && schema != "pg_catalog") {
+			continue
+		}
+		args := listItems(fc.Args)
+		if len(args) != 3 {
+			continue
+		}
+		if param, ok := stringConst(args[0]); !ok || !strings.EqualFold(param, "search_path") {
+			continue
+		}
+		if value, ok := stringConst(args[1]); ok {
+			b.path = splitSearchPath(value)
+		}
+	}
+}
+
+// define records that stmt defines o.
+func (b *builder) define(o Object, stmt int) {
+	b.g.node(o)
+	if _, ok := b.g.stmt[o]; !ok {
+		b.g.stmt[o] = stmt
+	}
+}
+
+// defineTop defines an object that belongs to a schema directly, making
+// it depend on the schema if the script creates it.
+func (b *builder) defineTop(o Object, stmt int) Object {
+	b.define(o, stmt)
+	b.later(func() {
+		if b.schemas[o.Schema] {
+			b.g.addEdge(o, Object{Kind: Schema, Name: o.Schema})
+		}
+	})
+	return o
+}
+
+func (b *builder) defineRel(kind Kind, rv *nodes.RangeVar, stmt int) Object {
+	o := Object{Kind: kind, Schema: b.schemaFor(rv.Schemaname), Name: rv.Relname}
+	b.rels[qname{o.Schema, o.Name}] = kind
+	return b.defineTop(o, stmt)
+}
+
+func (b *builder) defineType(kind Kind, l *nodes.List, stmt int) Object {
+	schema, name := qualifiedName(l)
+	o := Object{Kind: kind, Schema: b.schemaFor(schema), Name: name}
+	b.types[qname{o.Schema, o.Name}] = kind
+	return b.defineTop(o, stmt)
+}
+
+func (b *builder) defineFunc(l *nodes.List, stmt int) Object {
+	schema, name := qualifiedName(l)
+	o := Object{Kind: Function, Schema: b.schemaFor(schema), Name: name}
+	b.funcs[qname{o.Schema, o.Name}] = true
+	return b.defineTop(o, stmt)
+}
+
+// createTable defines a table with its columns and constraints.
+func (b *builder) createTable(n *nodes.CreateStmt, stmt int) {
+	rel := b.defineRel(Table, n.Relation, stmt)
+	q := qname{rel.Schema, rel.Name}
+	known := n.InhRelations == nil && n.OfTypename == nil
+	var cols []string
+	for _, elt := range listItems(n.TableElts) {
+		switch elt := elt.(type) {
+		case *nodes.ColumnDef:
+			cols = append(cols, elt.Colname)
+			b.columnDef(rel, elt, stmt)
+		case *nodes.Constraint:
+			b.constraint(rel, elt, "", stmt)
+		default: // LIKE
+			known = false
+		}
+	}
+	if known {
+		b.columns[q] = cols
+	}
+	for _, item := range listItems(n.Constraints) {
+		if c, ok := item.(*nodes.Constraint); ok {
+			b.constraint(rel, c, "", stmt)
+		}
+	}
+	b.later(func() {
+		for _, item := range listItems(n.InhRelations) {
+			if rv, ok := item.(*nodes.RangeVar); ok {
+				b.g.addEdge(rel, b.lookupRel(rv))
+			}
+		}
+		b.typeRef(rel, n.OfTypename)
+	})
+}
+
+// columnDef defines a column of rel, with the constraints written on it.
+func (b *builder) columnDef(rel Object, cd *nodes.ColumnDef, stmt int) {
+	col := Object{Kind: Column, Schema: rel.Schema, Name: rel.Name, Sub: cd.Colname}
+	b.define(col, stmt)
+	b.g.addEdge(col, rel)
+	var exprs []nodes.Node
+	if cd.RawDefault != nil {
+		exprs = append(exprs, cd.RawDefault)
+	}
+	for _, item := range listItems(cd.Constraints) {
+		c, ok := item.(*nodes.Constraint)
+		if !ok {
+			continue
+		}
+		switch c.Contype {
+		case nodes.CONSTR_DEFAULT, nodes.CONSTR_GENERATED:
+			exprs = append(exprs, c.RawExpr)
+		default:
+			b.constraint(rel, c, cd.Colname, stmt)
+		}
+	}
+	b.later(func() {
+		b.typeRef(col, cd.TypeName)
+		sc := tableScope(rel)
+		for _, e := range exprs {
+			b.expr(col, e, sc)
+		}
+	})
+}
+
+// constraint defines a constraint of rel; colname is the column of a
+// column constraint.
+func (b *builder) constraint(rel Object, c *nodes.Constraint, colname string, stmt int) {
+	var label string
+	var keys []string
+	switch c.Contype {
+	case nodes.CONSTR_PRIMARY:
+		label, keys = "pkey", stringList(c.Keys)
+	case nodes.CONSTR_UNIQUE:
+		label, keys = "key", stringList(c.Keys)
+	case nodes.CONSTR_FOREIGN:
+		label, keys = "fkey", stringList(c.FkAttrs)
+	case nodes.CONSTR_EXCLUSION:
+		label = "excl"
+		for _, item := range listItems(c.Exclusions) {
+			if pair, ok := item.(*nodes.List); ok && len(pair.Items) > 0 {
+				if elem, ok := pair.Items[0].(*nodes.IndexElem); ok && elem.Name != "" {
+					keys = append(keys, elem.Name)
+				}
+			}
+		}
+	case nodes.CONSTR_CHECK:
+		label = "check"
+	default:
+		return
+	}
+	if colname != "" && len(keys) == 0 {
+		keys = []string{colname}
+	}
+	name := c.Conname
+	if name == "" {
+		nameCols := keys
+		switch c.Contype {
+		case nodes.CONSTR_PRIMARY:
+			nameCols = nil
+		case nodes.CONSTR_CHECK:
+			// A table check constraint is named after its column if it
+			// refers to exactly one.
+			if colname == "" {
+				nameCols = nil
+				if refs := columnNames(c.RawExpr); len(refs) == 1 {
+					nameCols = refs
+				}
+			}
+		}
+		name = chooseName(rel.Name, nameCols, label)
+	}
+	con := Object{Kind: Constraint, Schema: rel.Schema, Name: rel.Name, Sub: name}
+	b.define(con, stmt)
+	b.later(func() {
+		b.g.addEdge(con, rel)
+		for _, key := range keys {
+			b.g.addEdge(con, b.column(rel, key))
+		}
+		for _, key := range stringList(c.Including) {
+			b.g.addEdge(con, b.column(rel, key))
+		}
+		sc := tableScope(rel)
+		b.expr(con, c.RawExpr, sc)
+		b.expr(con, c.WhereClause, sc)
+		if c.Pktable != nil {
+			pk := b.lookupRel(c.Pktable)
+			b.g.addEdge(con, pk)
+			for _, key := range stringList(c.PkAttrs) {
+				b.g.addEdge(con, b.column(pk, key))
+			}
+		}
+	})
+}
+
+// createIndex defines an index and its dependencies on its table.
+func (b *builder) createIndex(n *nodes.IndexStmt, stmt int) {
+	rel := b.lookupRel(n.Relation)
+	name := n.Idxname
+	if name == "" {
+		var cols []string
+		for _, item := range listItems(n.IndexParams) {
+			if elem, ok := item.(*nodes.IndexElem); ok {
+				switch {
+				case elem.Indexcolname != "":
+					cols = append(cols, elem.Indexcolname)
+				case elem.Name != "":
+					cols = append(cols, elem.Name)
+				default:
+					cols = append(cols, "expr")
+				}
+			}
+		}
+		name = chooseName(rel.Name, cols, "idx")
+	}
+	idx := Object{Kind: Index, Schema: rel.Schema, Name: name}
+	b.rels[qname{idx.Schema, idx.Name}] = Index
+	b.define(idx, stmt)
+	b.later(func() {
+		b.g.addEdge(idx, rel)
+		sc := tableScope(rel)
+		for _, item := range append(listItems(n.IndexParams), listItems(n.IndexIncludingParams)...) {
+			if elem, ok := item.(*nodes.IndexElem); ok {
+				if elem.Name != "" {
+					b.g.addEdge(idx, b.column(rel, elem.Name))
+				}
+				b.expr(idx, elem.Expr, sc)
+			}
+		}
+		b.expr(idx, n.WhereClause, sc)
+	})
+}
+
+// createTrigger defines a trigger and its dependencies on its table and
+// function.
+func (b *builder) createTrigger(n *nodes.CreateTrigStmt, stmt int) {
+	rel := b.lookupRel(n.Relation)
+	trig := Object{Kind: Trigger, Schema: rel.Schema, Name: rel.Name, Sub: n.Trigname}
+	b.define(trig, stmt)
+	b.later(func() {
+		b.g.addEdge(trig, rel)
+		if fn, ok := b.lookupFunc(n.Funcname); ok {
+			b.g.addEdge(trig, fn)
+		}
+		for _, col := range stringList(n.Columns) {
+			b.g.addEdge(trig, b.column(rel, col))
+		}
+		sc := &scope{rels: []scopeRel{{name: "new", rel: rel, ok: true}, {name: "old", rel: rel, ok: true}}}
+		b.expr(trig, n.WhenClause, sc)
+		if n.Constrrel != nil {
+			b.g.addEdge(trig, b.lookupRel(n.Constrrel))
+		}
+	})
+}
+
+// alterTable handles the ALTER TABLE subcommands that add columns,
+// constraints and defaults, as found in pg_dump output.
+func (b *builder) alterTable(n *nodes.AlterTableStmt, stmt int) {
+	if n.Relation == nil {
+		return
+	}
+	rel := b.lookupRel(n.Relation)
+	q := qname{rel.Schema, rel.Name}
+	for _, item := range listItems(n.Cmds) {
+		cmd, ok := item.(*nodes.AlterTableCmd)
+		if !ok {
+			continue
+		}
+		switch nodes.AlterTableType(cmd.Subtype) {
+		case nodes.AT_AddColumn:
+			if cd, ok := cmd.Def.(*nodes.ColumnDef); ok {
+				if cols, ok := b.columns[q]; ok {
+					b.columns[q] = append(cols, cd.Colname)
+				}
+				b.columnDef(rel, cd, stmt)
+			}
+		case nodes.AT_AddConstraint:
+			if c, ok := cmd.Def.(*nodes.Constraint); ok {
+				b.constraint(rel, c, "", stmt)
+			}
+		case nodes.AT_ColumnDefault:
+			def := cmd.Def
+			col := cmd.Name
+			b.later(func() { b.expr(b.column(rel, col), def, tableScope(rel)) })
+		}
+	}
+}
+
+// definition adds the dependencies of the options of CREATE AGGREGATE
+// and CREATE TYPE ... AS RANGE, where types and functions are written
+// like type names.
+func (b *builder) definition(from Object, l *nodes.List) {
+	for _, item := range listItems(l) {
+		d, ok := item.(*nodes.DefElem)
+		if !ok {
+			continue
+		}
+		if tn, ok := d.Arg.(*nodes.TypeName); ok && definitionFuncs[strings.ToLower(d.Defname)] {
+			if fn, ok := b.lookupFunc(tn.Names); ok {
+				b.g.addEdge(from, fn)
+			}
+			continue
+		}
+		b.expr(from, d.Arg, nil)
+	}
+}
+
+// query adds the dependencies of a query: the relations it reads, the
+// columns it refers to and the functions and types it uses.
+func (b *builder) query(from Object, n nodes.Node, outer *scope) {
+	s, ok := n.(*nodes.SelectStmt)
+	if !ok {
+		b.expr(from, n, outer)
+		return
+	}
+	sc := &scope{parent: outer}
+	if s.WithClause != nil {
+		sc.ctes = map[string]bool{}
+		for _, item := range listItems(s.WithClause.Ctes) {
+			sc.ctes[item.(*nodes.CommonTableExpr).Ctename] = true
+		}
+		for _, item := range listItems(s.WithClause.Ctes) {
+			b.query(from, item.(*nodes.CommonTableExpr).Ctequery, sc)
+		}
+	}
+	if s.Op != nodes.SETOP_NONE {
+		b.query(from, s.Larg, sc)
+		b.query(from, s.Rarg, sc)
+		b.expr(from, s.SortClause, sc)
+		b.expr(from, s.LimitOffset, sc)
+		b.expr(from, s.LimitCount, sc)
+		return
+	}
+	for _, item := range listItems(s.FromClause) {
+		b.fromItem(from, item, sc)
+	}
+	for _, part := range []nodes.Node{
+		s.DistinctClause, s.TargetList, s.WhereClause, s.GroupClause, s.HavingClause,
+		s.WindowClause, s.ValuesLists, s.SortClause, s.LimitOffset, s.LimitCount,
+	} {
+		b.expr(from, part, sc)
+	}
+}
+
+// fromItem adds the dependencies of a FROM clause item and adds it to
+// sc.
+func (b *builder) fromItem(from Object, n nodes.Node, sc *scope) {
+	switch n := n.(type) {
+	case *nodes.RangeVar:
+		name := n.Relname
+		if n.Alias != nil {
+			name = n.Alias.Aliasname
+		}
+		if n.Schemaname == "" && sc.isCTE(n.Relname) {
+			sc.rels = append(sc.rels, scopeRel{name: name})
+			return
+		}
+		rel, ok := b.relRef(from, n)
+		sc.rels = append(sc.rels, scopeRel{name: name, rel: rel, ok: ok})
+	case *nodes.JoinExpr:
+		b.fromItem(from, n.Larg, sc)
+		b.fromItem(from, n.Rarg, sc)
+		b.expr(from, n.Quals, sc)
+		if n.Alias != nil {
+			sc.rels = append(sc.rels, scopeRel{name: n.Alias.Aliasname})
+		}
+	case *nodes.RangeSubselect:
+		b.query(from, n.Subquery, sc)
+		if n.Alias != nil {
+			sc.rels = append(sc.rels, scopeRel{name: n.Alias.Aliasname})
+		}
+	case *nodes.RangeFunction:
+		b.expr(from, n.Functions, sc)
+		b.expr(from, n.Coldeflist, sc)
+		if n.Alias != nil {
+			sc.rels = append(sc.rels, scopeRel{name: n.Alias.Aliasname})
+		}
+	default:
+		b.expr(from, n, sc)
+	}
+}
+
+// expr adds the dependencies of an expression, or of any other part of a
+// statement, evaluated in sc.
+func (b *builder) expr(from Object, n nodes.Node, sc *scope) {
+	nodes.Walk(n, func(n nodes.Node) bool {
+		switch n := n.(type) {
+		case *nodes.SelectStmt:
+			b.query(from, n, sc)
+			return false
+		case *nodes.ColumnRef:
+			b.columnRef(from, n, sc)
+			return false
+		case *nodes.TypeName:
+			b.typeRef(from, n)
+			return false
+		case *nodes.RangeVar:
+			b.relRef(from, n)
+			return false
+		case *nodes.FuncCall:
+			if fn, ok := b.lookupFunc(n.Funcname); ok {
+				b.g.addEdge(from, fn)
+			}
+			// nextval('seq') and the like name a sequence in a string.
+			schema, name := qualifiedName(n.Funcname)
+			args := listItems(n.Args)
+			if (schema == "" || schema == "pg_catalog") && sequenceFuncs[name] && len(args) > 0 {
+				if s, ok := regclassConst(args[0]); ok {
+					b.relName(from, s, Sequence)
+					for _, arg := range args[1:] {
+						b.expr(from, arg, sc)
+					}
+					return false
+				}
+			}
+		case *nodes.TypeCast:
+			if s, ok := regclassConst(n); ok {
+				b.relName(from, s, Table)
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// sequenceFuncs holds the functions taking a sequence name.
+var sequenceFuncs = map[string]bool{"nextval": true, "currval": true, "setval": true}
+
+// relName adds a dependency on the relation named by a regclass literal.
+// A relation the script does not define is taken to be of kind kind.
+func (b *builder) relName(from Object, s string, kind Kind) {
+	parts := splitName(s)
+	if len(parts) == 0 {
+		return
+	}
+	schema := ""
+	if len(parts) > 1 {
+		schema = parts[len(parts)-2]
+	}
+	if rel, ok := b.lookupRelName(schema, parts[len(parts)-1], kind); ok {
+		b.g.addEdge(from, rel)
+	}
+}
+
+// relRef adds a dependency on the relation rv refers to.
+func (b *builder) relRef(from Object, rv *nodes.RangeVar) (Object, bool) {
+	rel, ok := b.lookupRelName(rv.Schemaname, rv.Relname, Table)
+	if ok {
+		b.g.addEdge(from, rel)
+	}
+	return rel, ok
+}
+
+// typeRef adds a dependency on the type tn names, unless it is built-in.
+func (b *builder) typeRef(from Object, tn *nodes.TypeName) {
+	if tn == nil || tn.PctType {
+		return
+	}
+	schema, name := qualifiedName(tn.Names)
+	if name == "" || system(schema) {
+		return
+	}
+	for _, s := range b.candidates(schema) {
+		if kind, ok := b.types[qname{s, name}]; ok {
+			b.g.addEdge(from, Object{Kind: kind, Schema: s, Name: name})
+			return
+		}
+		// The row type of a table.
+		if kind, ok := b.rels[qname{s, name}]; ok {
+			b.g.addEdge(from, Object{Kind: kind, Schema: s, Name: name})
+			return
+		}
+	}
+	if schema != "" {
+		b.g.addEdge(from, Object{Kind: Type, Schema: schema, Name: name})
+	}
+}
+
+// columnRef adds a dependency on the column a column reference refers
+// to, if it can be told.
+func (b *builder) columnRef(from Object, cr *nodes.ColumnRef, sc *scope) {
+	fields := stringList(cr.Fields)
+	if len(fields) != len(listItems(cr.Fields)) || len(fields) == 0 {
+		return // rel.* or subscripts
+	}
+	col := fields[len(fields)-1]
+	if len(fields) == 1 {
+		for ; sc != nil; sc = sc.parent {
+			var hits []Object
+			for _, r := range sc.rels {
+				if !r.ok {
+					continue
+				}
+				if cols, ok := b.columns[qname{r.rel.Schema, r.rel.Name}]; ok && contains(cols, col) {
+					hits = append(hits, r.rel)
+				}
+			}
+			// A lone relation whose columns are not known is taken to
+			// have the column.
+			if len(hits) == 0 && len(sc.rels) == 1 && sc.rels[0].ok {
+				if _, ok := b.columns[qname{sc.rels[0].rel.Schema, sc.rels[0].rel.Name}]; !ok {
+					hits = append(hits, sc.rels[0].rel)
+				}
+			}
+			switch len(hits) {
+			case 0:
+				continue
+			case 1:
+				b.g.addEdge(from, b.column(hits[0], col))
+			}
+			return
+		}
+		return
+	}
+	relName := fields[len(fields)-2]
+	schema := ""
+	if len(fields) > 2 {
+		schema = fields[len(fields)-3]
+	}
+	for ; sc != nil; sc = sc.parent {
+		for _, r := range sc.rels {
+			if r.name != relName || (schema != "" && (!r.ok || r.rel.Schema != schema)) {
+				continue
+			}
+			if !r.ok {
+				return
+			}
+			if cols, ok := b.columns[qname{r.rel.Schema, r.rel.Name}]; !ok || contains(cols, col) {
+				b.g.addEdge(from, b.column(r.rel, col))
+			}
+			return
+		}
+	}
+}
+
+// column returns a column of rel, which depends on rel.
+func (b *builder) column(rel Object, name string) Object {
+	col := Object{Kind: Column, Schema: rel.Schema, Name: rel.Name, Sub: name}
+	b.g.addEdge(col, rel)
+	return col
+}
+
+// lookupRel resolves a relation name; a relation the script does not
+// define is taken to be a table.
+func (b *builder) lookupRel(rv *nodes.RangeVar) Object {
+	rel, ok := b.lookupRelName(rv.Schemaname, rv.Relname, Table)
+	if !ok {
+		// A system catalog; keep it, since sub-objects need an owner.
+		schema := rv.Schemaname
+		if schema == "" {
+			schema = "pg_catalog"
+		}
+		return Object{Kind: Table, Schema: schema, Name: rv.Relname}
+	}
+	return rel
+}
+
+// lookupRelName resolves a relation name. A relation the script does not
+// define is taken to be of kind kind, in the named schema or the creation
+// schema. It returns false for system relations: those in pg_catalog or
+// information_schema, and unqualified names beginning with "pg_" that the
+// script does not define.
+func (b *builder) lookupRelName(schema, name string, kind Kind) (Object, bool) {
+	if system(schema) {
+		return Object{}, false
+	}
+	for _, s := range b.candidates(schema) {
+		if k, ok := b.rels[qname{s, name}]; ok {
+			return Object{Kind: k, Schema: s, Name: name}, true
+		}
+	}
+	if schema == "" {
+		if strings.HasPrefix(name, "pg_") {
+			return Object{}, false
+		}
+		schema = b.creationSchema()
+	}
+	return Object{Kind: kind, Schema: schema, Name: name}, true
+}
+
+// lookupFunc resolves a function name. Unqualified functions the script
+// does not define are taken to be built-in, and left out.
+func (b *builder) lookupFunc(l *nodes.List) (Object, bool) {
+	schema, name := qualifiedName(l)
+	if name == "" || system(schema) {
+		return Object{}, false
+	}
+	for _, s := range b.candidates(schema) {
+		if b.funcs[qname{s, name}] {
+			return Object{Kind: Function, Schema: s, Name: name}, true
+		}
+	}
+	if schema != "" {
+		return Object{Kind: Function, Schema: schema, Name: name}, true
+	}
+	return Object{}, false
+}
+
+// candidates returns the schemas to look for a name in: the schema it is
+// qualified with, or the search path.
+func (b *builder) candidates(schema string) []string {
+	if schema != "" {
+		return []string{schema}
+	}
+	return b.path
+}
+
+// schemaFor returns the schema an object is created in.
+func (b *builder) schemaFor(schema string) string {
+	if schema != "" {
+		return schema
+	}
+	return b.creationSchema()
+}
+
+// creationSchema returns the first schema of the search path, not
+// counting "$user", or "" if there is none. Unlike PostgreSQL, it does
+// not check that the schema exists.
+func (b *builder) creationSchema() string {
+	for _, s := range b.path {
+		if s != "$user" && s != "pg_temp" && s != "pg_catalog" {
+			return s
+		}
+	}
+	return ""
+}
+
+// isCTE reports whether an unqualified relation name refers to a CTE in
+// scope.
+func (sc *scope) isCTE(name string) bool {
+	for ; sc != nil; sc = sc.parent {
+		if sc.ctes[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// tableScope returns the scope of expressions attached to a table, such
+// as defaults, constraints and index expressions.
+func tableScope(rel Object) *scope {
+	return &scope{rels: []scopeRel{{name: rel.Name, rel: rel, ok: true}}}
+}
+
+// chooseName builds the name PostgreSQL gives an unnamed constraint or
+// index, as ChooseRelationName does: the table name, the column names and
+// a label joined with underscores.
+func chooseName(rel string, cols []string, label string) string {
+	parts := append([]string{rel}, cols...)
+	return strings.Join(append(parts, label), "_")
+}
+
+// columnNames returns the distinct unqualified column names an
+// expression refers to, in order.
+func columnNames(n nodes.Node) []string {
+	var out []string
+	nodes.Walk(n, func(n nodes.Node) bool {
+		if cr, ok := n.(*nodes.ColumnRef); ok {
+			if fields := stringList(cr.Fields); len(fields) > 0 && !contains(out, fields[len(fields)-1]) {
+				out = append(out, fields[len(fields)-1])
+			}
+			return false
+		}
+		return true
+	})
+	return out
+}
+
+// system reports whether schema holds system objects.
+func system(schema string) bool {
+	return schema == "pg_catalog" || schema == "information_schema"
+}
+
+// splitSearchPath splits a search_path value given as a single string,
+// e.g. '"$user", public'.
+func splitSearchPath(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, splitName(part)...)
+		}
+	}
+	return out
+}
+
+// splitName splits a possibly qualified name written in a string, such
+// as the argument of nextval, folding unquoted identifiers to lower case.
+func splitName(s string) []string {
+	var out []string
+	var b strings.Builder
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' && quoted && i+1 < len(s) && s[i+1] == '"':
+			b.WriteByte('"')
+			i++
+		case c == '"':
+			quoted = !quoted
+		case c == '.' && !quoted:
+			out = append(out, b.String())
+			b.Reset()
+		case quoted:
+			b.WriteByte(c)
+		default:
+			b.WriteString(strings.ToLower(string(c)))
+		}
+	}
+	return append(out, strings.TrimSpace(b.String()))
+}
+
+// regclassConst returns the relation name of a string literal, or of one
+// cast to regclass.
+func regclassConst(n nodes.Node) (string, bool) {
+	if tc, ok := n.(*nodes.TypeCast); ok {
+		if tc.TypeName == nil {
+			return "", false
+		}
+		if schema, name := qualifiedName(tc.TypeName.Names); name != "regclass" || (schema != "" && schema != "pg_catalog") {
+			return "", false
+		}
+		n = tc.Arg
+	}
+	return stringConst(n)
+}
+
+// stringConst returns the value of a string literal.
+func stringConst(n nodes.Node) (string, bool) {
+	if ac, ok := n.(*nodes.A_Const); ok {
+		if s, ok := ac.Val.(*nodes.String); ok {
+			return s.Sval, true
+		}
+	}
+	return "", false
+}
+
+// qualifiedName splits a possibly qualified name given as a list of
+// String nodes. A database name in front of the schema is ignored.
+func qualifiedName(l *nodes.List) (schema, name string) {
+	parts := stringList(l)
+	if len(parts) == 0 || len(parts) != len(listItems(l)) {
+		return "", ""
+	}
+	if len(parts) == 1 {
+		return "", parts[0]
+	}
+	return parts[len(parts)-2], parts[len(parts)-1]
+}
+
+// stringList returns the values of the String nodes of a list.
+func stringList(l *nodes.List) []string {
+	var out []string
+	for _, item := range listItems(l) {
+		if s, ok := item.(*nodes.String); ok {
+			out = append(out, s.Sval)
+		}
+	}
+	return out
+}
+
+// listItems returns the items of a possibly-nil list.
+func listItems(l *nodes.List) []nodes.Node {
+	if l == nil {
+		return nil
+	}
+	return l.Items
+}
+
+func contains(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,348 @@
+// Package deps extracts the dependencies between the objects defined by a
+// DDL script, for ordering statements and for impact analysis.
+//
+// The graph is built from raw parse trees alone. Names are resolved
+// against the objects the script defines, following the search path set
+// by SET search_path or pg_dump's set_config call; a qualified name that
+// the script does not define becomes an external object of the graph.
+// Objects in pg_catalog are left out, as are unqualified types and
+// functions the script does not define, which are taken to be built-in.
+package deps
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kind is the kind of a database object.
+type Kind int
+
+const (
+	Schema Kind = iota
+	Table
+	View
+	MaterializedView
+	Sequence
+	Index
+	Column
+	Constraint
+	Type // enum, composite, range or base type
+	Domain
+	Function // function, procedure or aggregate
+	Trigger
+	Policy
+)
+
+var kindNames = [...]string{
+	Schema:           "schema",
+	Table:            "table",
+	View:             "view",
+	MaterializedView: "materialized view",
+	Sequence:         "sequence",
+	Index:            "index",
+	Column:           "column",
+	Constraint:       "constraint",
+	Type:             "type",
+	Domain:           "domain",
+	Function:         "function",
+	Trigger:          "trigger",
+	Policy:           "policy",
+}
+
+func (k Kind) String() string {
+	if k >= 0 && int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// isRelation reports whether objects of kind k share the relation
+// namespace of a schema.
+func (k Kind) isRelation() bool {
+	switch k {
+	case Table, View, MaterializedView, Sequence, Index:
+		return true
+	}
+	return false
+}
+
+// Object identifies a database object. Columns, constraints, triggers and
+// policies belong to the relation named by Schema and Name, and are told
+// apart by Sub; for a schema, Name is the schema name and Schema is "".
+type Object struct {
+	Kind   Kind
+	Schema string
+	Name   string
+	Sub    string
+}
+
+// String describes the object, as in "table public.t", "column
+// public.t.a" or "trigger audit on public.t".
+func (o Object) String() string {
+	name := o.Name
+	if o.Schema != "" {
+		name = o.Schema + "." + name
+	}
+	switch o.Kind {
+	case Column:
+		return fmt.Sprintf("%s %s.%s", o.Kind, name, o.Sub)
+	case Constraint, Trigger, Policy:
+		return fmt.Sprintf("%s %s on %s", o.Kind, o.Sub, name)
+	}
+	return fmt.Sprintf("%s %s", o.Kind, name)
+}
+
+// Graph is a dependency graph of database objects.
+type Graph struct {
+	objects []Object       // in order of first appearance
+	index   map[Object]int // position in objects
+	stmt    map[Object]int // defining statement, for defined objects
+	deps    [][]int        // deps[i]: objects that objects[i] depends on
+	rdeps   [][]int        // rdeps[i]: objects that depend on objects[i]
+}
+
+func newGraph() *Graph {
+	return &Graph{index: map[Object]int{}, stmt: map[Object]int{}}
+}
+
+// node returns the position of o, adding it if needed.
+func (g *Graph) node(o Object) int {
+	if i, ok := g.index[o]; ok {
+		return i
+	}
+	g.index[o] = len(g.objects)
+	g.objects = append(g.objects, o)
+	g.deps = append(g.deps, nil)
+	g.rdeps = append(g.rdeps, nil)
+	return len(g.objects) - 1
+}
+
+// addEdge records that from depends on to.
+func (g *Graph) addEdge(from, to Object) {
+	if from == to {
+		return
+	}
+	i, j := g.node(from), g.node(to)
+	for _, k := range g.deps[i] {
+		if k == j {
+			return
+		}
+	}
+	g.deps[i] = append(g.deps[i], j)
+	g.rdeps[j] = append(g.rdeps[j], i)
+}
+
+// Objects returns the objects of the graph: those the script defines, in
+// the order they are defined, and those it only refers to, in the order
+// they are first referred to.
+func (g *Graph) Objects() []Object {
+	return append([]Object(nil), g.objects...)
+}
+
+// Statement returns the index of the statement defining o, and false if
+// the script does not define it.
+func (g *Graph) Statement(o Object) (int, bool) {
+	i, ok := g.stmt[o]
+	return i, ok
+}
+
+// DependsOn returns the objects o depends on directly.
+func (g *Graph) DependsOn(o Object) []Object {
+	i, ok := g.index[o]
+	if !ok {
+		return nil
+	}
+	return g.list(g.deps[i])
+}
+
+// Dependents returns the objects that depend on o directly.
+func (g *Graph) Dependents(o Object) []Object {
+	i, ok := g.index[o]
+	if !ok {
+		return nil
+	}
+	return g.list(g.rdeps[i])
+}
+
+// Impact returns the objects that depend on o directly or indirectly,
+// nearest first: those a change to o may affect.
+func (g *Graph) Impact(o Object) []Object {
+	start, ok := g.index[o]
+	if !ok {
+		return nil
+	}
+	seen := map[int]bool{start: true}
+	queue := []int{start}
+	var out []int
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		for _, j := range g.rdeps[i] {
+			if !seen[j] {
+				seen[j] = true
+				out = append(out, j)
+				queue = append(queue, j)
+			}
+		}
+	}
+	return g.list(out)
+}
+
+func (g *Graph) list(ids []int) []Object {
+	out := make([]Object, len(ids))
+	for i, id := range ids {
+		out[i] = g.objects[id]
+	}
+	return out
+}
+
+// CycleError is returned by Sort when the graph has a cycle.
+type CycleError struct {
+	Cycle []Object // objects of the cycle, each depending on the next and the last on the first
+}
+
+func (e *CycleError) Error() string {
+	parts := make([]string, len(e.Cycle)+1)
+	for i, o := range e.Cycle {
+		parts[i] = o.String()
+	}
+	parts[len(e.Cycle)] = e.Cycle[0].String()
+	return "dependency cycle: " + strings.Join(parts, " -> ")
+}
+
+// Sort returns the objects in an order in which each comes after the
+// objects it depends on. Among objects whose order is not constrained,
+// the order of Objects is kept. If the graph has a cycle, Sort returns a
+// *CycleError describing one.
+func (g *Graph) Sort() ([]Object, error) {
+	if cycles := g.Cycles(); len(cycles) > 0 {
+		return nil, &CycleError{Cycle: cycles[0]}
+	}
+	pending := make([]int, len(g.objects))
+	for i := range g.objects {
+		pending[i] = len(g.deps[i])
+	}
+	done := make([]bool, len(g.objects))
+	out := make([]Object, 0, len(g.objects))
+	// Repeatedly take the first object whose dependencies are all
+	// placed; quadratic, but scripts are small and the order is stable.
+	for len(out) < len(g.objects) {
+		for i := range g.objects {
+			if done[i] || pending[i] > 0 {
+				continue
+			}
+			done[i] = true
+			out = append(out, g.objects[i])
+			for _, j := range g.rdeps[i] {
+				pending[j]--
+			}
+			break
+		}
+	}
+	return out, nil
+}
+
+// Cycles returns the dependency cycles of the graph, in the order of
+// their first object. Each cycle lists its objects so that each depends
+// on the next and the last on the first; objects that are part of several
+// cycles are reported in one of them.
+func (g *Graph) Cycles() [][]Object {
+	// Tarjan's strongly connected components.
+	n := len(g.objects)
+	index := make([]int, n)
+	low := make([]int, n)
+	onStack := make([]bool, n)
+	for i := range index {
+		index[i] = -1
+	}
+	var stack []int
+	var comps [][]int
+	next := 0
+	var connect func(v int)
+	connect = func(v int) {
+		index[v], low[v] = next, next
+		next++
+		stack = append(stack, v)
+		onStack[v] = true
+		for _, w := range g.deps[v] {
+			if index[w] < 0 {
+				connect(w)
+				low[v] = min(low[v], low[w])
+			} else if onStack[w] {
+				low[v] = min(low[v], index[w])
+			}
+		}
+		if low[v] == index[v] {
+			var comp []int
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				comp = append(comp, w)
+				if w == v {
+					break
+				}
+			}
+			if len(comp) > 1 {
+				comps = append(comps, comp)
+			}
+		}
+	}
+	for v := 0; v < n; v++ {
+		if index[v] < 0 {
+			connect(v)
+		}
+	}
+
+	var out [][]Object
+	for _, comp := range comps {
+		out = append(out, g.list(g.cycleIn(comp)))
+	}
+	// Order cycles by their first object.
+	for i := 1; i < len(out); i++ {
+		for j := i; j > 0 && g.index[out[j][0]] < g.index[out[j-1][0]]; j-- {
+			out[j], out[j-1] = out[j-1], out[j]
+		}
+	}
+	return out
+}
+
+// cycleIn returns a cycle through the strongly connected component comp,
+// starting at its earliest object.
+func (g *Graph) cycleIn(comp []int) []int {
+	in := map[int]bool{}
+	start := comp[0]
+	for _, v := range comp {
+		in[v] = true
+		if v < start {
+			start = v
+		}
+	}
+	// Breadth-first search for the shortest path back to start.
+	prev := map[int]int{}
+	queue := []int{start}
+	seen := map[int]bool{start: true}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, w := range g.deps[v] {
+			if w == start {
+				var path []int
+				for u := v; u != start; u = prev[u] {
+					path = append(path, u)
+				}
+				path = append(path, start)
+				for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return path
+			}
+			if in[w] && !seen[w] {
+				seen[w] = true
+				prev[w] = v
+				queue = append(queue, w)
+			}
+		}
+	}
+	return comp
+}
@@ -0,0 +1,264 @@
+package deps
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgparser/parser"
+)
+
+// build parses sql and returns its dependency graph.
+func build(t *testing.T, sql string) *Graph {
+	t.Helper()
+	stmts, err := parser.Parse(sql)
+	if err != nil {
+		t.Fatalf("Parse(%q) error: %v", sql, err)
+	}
+	return Build(stmts.Items)
+}
+
+// edges returns the dependencies of the graph as sorted "a -> b" lines.
+func edges(g *Graph) []string {
+	var out []string
+	for _, o := range g.Objects() {
+		for _, d := range g.DependsOn(o) {
+			out = append(out, o.String()+" -> "+d.String())
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// checkEdges checks that the graph has every edge of want.
+func checkEdges(t *testing.T, g *Graph, want ...string) {
+	t.Helper()
+	got := edges(g)
+	for _, w := range want {
+		i := sort.SearchStrings(got, w)
+		if i == len(got) || got[i] != w {
+			t.Errorf("missing edge %s; have\n  %s", w, strings.Join(got, "\n  "))
+			return
+		}
+	}
+}
+
+// checkNoEdges checks that the graph has none of the edges of unwanted.
+func checkNoEdges(t *testing.T, g *Graph, unwanted ...string) {
+	t.Helper()
+	got := edges(g)
+	for _, u := range unwanted {
+		if i := sort.SearchStrings(got, u); i < len(got) && got[i] == u {
+			t.Errorf("unexpected edge %s", u)
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	g := build(t, `
+		CREATE SCHEMA app;
+		SET search_path = app, public;
+		CREATE DOMAIN email AS text CHECK (VALUE ~ '@');
+		CREATE SEQUENCE users_id_seq;
+		CREATE TABLE users (
+			id int DEFAULT nextval('users_id_seq') PRIMARY KEY,
+			mail email UNIQUE,
+			age int CHECK (age > 0)
+		);
+		CREATE TABLE orders (
+			id serial,
+			user_id int REFERENCES users (id),
+			total numeric,
+			CHECK (total >= 0)
+		);
+		CREATE INDEX ON orders (user_id) WHERE total > 0;
+		CREATE FUNCTION audit() RETURNS trigger LANGUAGE plpgsql AS 'BEGIN RETURN NEW; END';
+		CREATE TRIGGER orders_audit AFTER INSERT ON orders FOR EACH ROW EXECUTE FUNCTION audit();
+		CREATE POLICY own ON orders USING (user_id = current_setting('app.user')::int);
+		CREATE VIEW big AS SELECT u.mail, o.total FROM users u JOIN orders o ON o.user_id = u.id WHERE total > 100;
+	`)
+	checkEdges(t, g,
+		"column app.orders.user_id -> table app.orders",
+		"constraint orders_total_check on app.orders -> column app.orders.total",
+		"constraint orders_user_id_fkey on app.orders -> column app.orders.user_id",
+		"constraint orders_user_id_fkey on app.orders -> column app.users.id",
+		"constraint orders_user_id_fkey on app.orders -> table app.users",
+		"constraint users_age_check on app.users -> column app.users.age",
+		"constraint users_mail_key on app.users -> column app.users.mail",
+		"constraint users_pkey on app.users -> column app.users.id",
+		"column app.users.id -> sequence app.users_id_seq",
+		"column app.users.mail -> domain app.email",
+		"domain app.email -> schema app",
+		"index app.orders_user_id_idx -> column app.orders.total",
+		"index app.orders_user_id_idx -> column app.orders.user_id",
+		"index app.orders_user_id_idx -> table app.orders",
+		"policy own on app.orders -> column app.orders.user_id",
+		"policy own on app.orders -> table app.orders",
+		"trigger orders_audit on app.orders -> function app.audit",
+		"trigger orders_audit on app.orders -> table app.orders",
+		"view app.big -> column app.orders.total",
+		"view app.big -> column app.orders.user_id",
+		"view app.big -> column app.users.id",
+		"view app.big -> column app.users.mail",
+		"view app.big -> table app.orders",
+		"view app.big -> table app.users",
+	)
+	if i, ok := g.Statement(Object{Kind: Index, Schema: "app", Name: "orders_user_id_idx"}); !ok || i != 6 {
+		t.Errorf("Statement(index) = %d, %v", i, ok)
+	}
+	if _, ok := g.Statement(Object{Kind: Table, Schema: "public", Name: "users"}); ok {
+		t.Errorf("public.users is not defined")
+	}
+}
+
+func TestBuildPgDump(t *testing.T) {
+	// The layout of pg_dump output: qualified names, an empty search
+	// path, and constraints and defaults added after the tables.
+	g := build(t, `
+		SELECT pg_catalog.set_config('search_path', '', false);
+		CREATE TABLE public.t (id integer NOT NULL, parent integer, CONSTRAINT t_id_check CHECK (public.positive(id)));
+		CREATE SEQUENCE public.t_id_seq AS integer;
+		ALTER SEQUENCE public.t_id_seq OWNED BY public.t.id;
+		ALTER TABLE ONLY public.t ALTER COLUMN id SET DEFAULT nextval('public.t_id_seq'::regclass);
+		ALTER TABLE ONLY public.t ADD CONSTRAINT t_pkey PRIMARY KEY (id);
+		ALTER TABLE ONLY public.t ADD CONSTRAINT t_parent_fkey FOREIGN KEY (parent) REFERENCES public.t(id);
+		CREATE FUNCTION public.positive(integer) RETURNS boolean LANGUAGE sql AS 'SELECT $1 > 0';
+		CREATE MATERIALIZED VIEW public.roots AS SELECT id FROM public.t WHERE parent IS NULL;
+	`)
+	checkEdges(t, g,
+		"column public.t.id -> sequence public.t_id_seq",
+		"constraint t_id_check on public.t -> function public.positive",
+		"constraint t_parent_fkey on public.t -> column public.t.id",
+		"constraint t_parent_fkey on public.t -> column public.t.parent",
+		"constraint t_pkey on public.t -> column public.t.id",
+		"materialized view public.roots -> column public.t.id",
+		"materialized view public.roots -> column public.t.parent",
+		"materialized view public.roots -> table public.t",
+	)
+	checkNoEdges(t, g, "sequence public.t_id_seq -> column public.t.id")
+	for _, o := range g.Objects() {
+		if o.Schema == "" && o.Kind != Schema {
+			t.Errorf("unqualified object %s", o)
+		}
+	}
+	if _, err := g.Sort(); err != nil {
+		t.Errorf("Sort error: %v", err)
+	}
+}
+
+func TestBuildExternal(t *testing.T) {
+	g := build(t, `
+		CREATE VIEW v AS
+			WITH recent AS (SELECT * FROM events WHERE at > now())
+			SELECT r.kind, c.relname, lower(x.name) FROM recent r, pg_class c, ext.things x;
+		CREATE TABLE t (a int DEFAULT ext.next_id(), m ext.money2);
+	`)
+	checkEdges(t, g,
+		"column ext.things.name -> table ext.things",
+		"column public.events.at -> table public.events",
+		"column public.t.a -> function ext.next_id",
+		"column public.t.m -> type ext.money2",
+		"view public.v -> column ext.things.name",
+		"view public.v -> column public.events.at",
+		"view public.v -> table ext.things",
+		"view public.v -> table public.events",
+	)
+	for _, o := range g.Objects() {
+		if o.Name == "recent" || o.Name == "pg_class" || o.Name == "lower" || o.Name == "now" {
+			t.Errorf("unexpected object %s", o)
+		}
+	}
+	if _, ok := g.Statement(Object{Kind: Table, Schema: "ext", Name: "things"}); ok {
+		t.Errorf("ext.things is not defined by the script")
+	}
+}
+
+func TestImpact(t *testing.T) {
+	g := build(t, `
+		CREATE TABLE a (id int PRIMARY KEY);
+		CREATE VIEW b AS SELECT id FROM a;
+		CREATE VIEW c AS SELECT id FROM b;
+		CREATE TABLE d (x int);
+	`)
+	a := Object{Kind: Table, Schema: "public", Name: "a"}
+	var got []string
+	for _, o := range g.Impact(a) {
+		got = append(got, o.String())
+	}
+	want := []string{
+		"column public.a.id",
+		"column public.b.id",
+		"constraint a_pkey on public.a",
+		"view public.b",
+		"view public.c",
+	}
+	sort.Strings(got)
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("Impact(a) = %s", strings.Join(got, ", "))
+	}
+	if got := g.Dependents(Object{Kind: Table, Schema: "public", Name: "d"}); len(got) != 1 {
+		t.Errorf("Dependents(d) = %v", got)
+	}
+}
+
+func TestSort(t *testing.T) {
+	g := build(t, `
+		CREATE VIEW v AS SELECT * FROM t;
+		CREATE INDEX i ON t (a);
+		CREATE TABLE t (a int REFERENCES u);
+		CREATE TABLE u (id int PRIMARY KEY);
+	`)
+	order, err := g.Sort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pos := map[Object]int{}
+	for i, o := range order {
+		pos[o] = i
+	}
+	if len(pos) != len(g.Objects()) {
+		t.Fatalf("Sort returned %d objects, want %d", len(pos), len(g.Objects()))
+	}
+	for _, o := range order {
+		for _, d := range g.DependsOn(o) {
+			if pos[d] > pos[o] {
+				t.Errorf("%s sorted before its dependency %s", o, d)
+			}
+		}
+	}
+	// Unconstrained objects keep their order: the table goes first, as
+	// only its foreign key depends on u.
+	if order[0] != (Object{Kind: Table, Schema: "public", Name: "t"}) {
+		t.Errorf("first object = %s, want table public.t", order[0])
+	}
+}
+
+func TestCycles(t *testing.T) {
+	g := build(t, `
+		CREATE VIEW a AS SELECT * FROM b;
+		CREATE VIEW b AS SELECT * FROM c;
+		CREATE VIEW c AS SELECT * FROM a;
+		CREATE VIEW d AS SELECT * FROM a;
+		CREATE TABLE self (id int PRIMARY KEY, parent int REFERENCES self);
+	`)
+	cycles := g.Cycles()
+	if len(cycles) != 1 {
+		t.Fatalf("Cycles() = %v, want one cycle", cycles)
+	}
+	var names []string
+	for _, o := range cycles[0] {
+		names = append(names, o.Name)
+	}
+	if got := strings.Join(names, " "); got != "a b c" {
+		t.Errorf("cycle = %s, want a b c", got)
+	}
+	_, err := g.Sort()
+	var ce *CycleError
+	if !errors.As(err, &ce) {
+		t.Fatalf("Sort error = %v, want *CycleError", err)
+	}
+	if want := "dependency cycle: view public.a -> view public.b -> view public.c -> view public.a"; err.Error() != want {
+		t.Errorf("Sort error = %q, want %q", err, want)
+	}
+}
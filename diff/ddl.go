@@ -0,0 +1,701 @@
+package diff
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pgplex/pgparser/catalog"
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// This file renders catalog objects as the DDL that creates them. The
+// same text is used to decide whether an object changed, so rendering must
+// be deterministic.
+
+// indent is the indentation of column and attribute lists.
+const indent = "    "
+
+// funcName returns the schema-qualified name of a routine.
+func funcName(f *catalog.Function) string {
+	return quoteIdent(f.Schema.Name) + "." + quoteIdent(f.Name)
+}
+
+// funcIdentity returns the name and input argument types identifying a
+// routine in DROP, ALTER and COMMENT statements.
+func funcIdentity(f *catalog.Function) string {
+	var args []string
+	for _, t := range f.ArgTypes() {
+		args = append(args, typeName(t))
+	}
+	if f.Kind == catalog.FuncKindAggregate && len(args) == 0 {
+		return funcName(f) + "(*)"
+	}
+	return funcName(f) + "(" + strings.Join(args, ", ") + ")"
+}
+
+// routineKeyword returns FUNCTION, PROCEDURE or AGGREGATE.
+func routineKeyword(f *catalog.Function) string {
+	switch f.Kind {
+	case catalog.FuncKindProcedure:
+		return "PROCEDURE"
+	case catalog.FuncKindAggregate:
+		return "AGGREGATE"
+	}
+	return "FUNCTION"
+}
+
+// relationKeyword returns the keyword naming a relation's kind in DDL.
+func relationKeyword(r *catalog.Relation) string {
+	switch r.Kind {
+	case catalog.RelKindView:
+		return "VIEW"
+	case catalog.RelKindMatView:
+		return "MATERIALIZED VIEW"
+	case catalog.RelKindSequence:
+		return "SEQUENCE"
+	case catalog.RelKindIndex, catalog.RelKindPartitionedIndex:
+		return "INDEX"
+	case catalog.RelKindForeignTable:
+		return "FOREIGN TABLE"
+	}
+	return "TABLE"
+}
+
+// collation renders a collation name stored as a dotted string.
+func collation(name string) string {
+	parts := strings.Split(name, ".")
+	for i, p := range parts {
+		parts[i] = quoteIdent(p)
+	}
+	return strings.Join(parts, ".")
+}
+
+// defArg renders the value of a DefElem option.
+func (d *deparser) defArg(n nodes.Node) string {
+	switch v := n.(type) {
+	case *nodes.TypeName:
+		return typeName(v)
+	case *nodes.List:
+		return nameList(v)
+	case *nodes.String:
+		return quoteLiteral(v.Sval)
+	case *nodes.A_Const:
+		return d.value(v.Val)
+	case *nodes.Integer, *nodes.Float, *nodes.Boolean:
+		return d.value(v)
+	}
+	return d.expr(n)
+}
+
+// defList renders a list of DefElems as "(name = value, ...)".
+func (d *deparser) defList(l *nodes.List) string {
+	var parts []string
+	for _, n := range items(l) {
+		de, ok := n.(*nodes.DefElem)
+		if !ok {
+			continue
+		}
+		name := de.Defname
+		if de.Defnamespace != "" {
+			name = de.Defnamespace + "." + name
+		}
+		if de.Arg == nil {
+			parts = append(parts, name)
+		} else {
+			parts = append(parts, name+" = "+d.defArg(de.Arg))
+		}
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+// genericOptions renders a foreign-data OPTIONS clause.
+func genericOptions(l *nodes.List) string {
+	var parts []string
+	for _, n := range items(l) {
+		if de, ok := n.(*nodes.DefElem); ok {
+			val := ""
+			if s, ok := de.Arg.(*nodes.String); ok {
+				val = s.Sval
+			}
+			parts = append(parts, quoteIdent(de.Defname)+" "+quoteLiteral(val))
+		}
+	}
+	return "OPTIONS (" + strings.Join(parts, ", ") + ")"
+}
+
+// columnDef renders a column definition as used in CREATE TABLE and ADD
+// COLUMN.
+func (d *deparser) columnDef(col *catalog.Column) string {
+	s := quoteIdent(col.Name) + " " + col.TypeString()
+	if col.Collation != "" {
+		s += " COLLATE " + collation(col.Collation)
+	}
+	switch {
+	case col.Generated != 0:
+		s += " GENERATED ALWAYS AS (" + d.expr(col.Default) + ") STORED"
+	case col.Identity != 0:
+		s += " " + identityClause(col.Identity)
+	case col.Default != nil:
+		s += " DEFAULT " + d.expr(col.Default)
+	}
+	if col.NotNull {
+		s += " NOT NULL"
+	}
+	return s
+}
+
+func identityClause(identity byte) string {
+	if identity == 'a' {
+		return "GENERATED ALWAYS AS IDENTITY"
+	}
+	return "GENERATED BY DEFAULT AS IDENTITY"
+}
+
+// constraintDef renders a table or domain constraint without its name.
+// NOT VALID is left out; callers add it where it applies.
+func (d *deparser) constraintDef(con *catalog.Constraint) string {
+	var s string
+	def := con.Def
+	if def == nil {
+		def = &nodes.Constraint{}
+	}
+	switch con.Type {
+	case nodes.CONSTR_CHECK:
+		s = "CHECK (" + d.expr(con.Expr) + ")"
+		if con.NoInherit {
+			s += " NO INHERIT"
+		}
+	case nodes.CONSTR_PRIMARY, nodes.CONSTR_UNIQUE:
+		if con.Type == nodes.CONSTR_PRIMARY {
+			s = "PRIMARY KEY"
+		} else {
+			s = "UNIQUE"
+			if def.NullsNotDistinct {
+				s += " NULLS NOT DISTINCT"
+			}
+		}
+		s += " (" + quoteIdents(con.Columns) + ")"
+		s += d.indexParameters(def)
+	case nodes.CONSTR_EXCLUSION:
+		method := def.AccessMethod
+		if method == "" {
+			method = "btree"
+		}
+		var elems []string
+		for _, n := range items(def.Exclusions) {
+			pair := items(asList(n))
+			if len(pair) != 2 {
+				continue
+			}
+			elem, _ := pair[0].(*nodes.IndexElem)
+			elems = append(elems, d.indexElem(elem)+" WITH "+operatorName(asList(pair[1])))
+		}
+		s = "EXCLUDE USING " + quoteIdent(method) + " (" + strings.Join(elems, ", ") + ")"
+		s += d.indexParameters(def)
+		if def.WhereClause != nil {
+			s += " WHERE (" + d.expr(def.WhereClause) + ")"
+		}
+	case nodes.CONSTR_FOREIGN:
+		s = "FOREIGN KEY (" + quoteIdents(con.Columns) + ") REFERENCES " + con.RefTable.QualifiedName()
+		if len(con.RefColumns) > 0 {
+			s += " (" + quoteIdents(con.RefColumns) + ")"
+		}
+		switch con.FkMatchType {
+		case 'f':
+			s += " MATCH FULL"
+		case 'p':
+			s += " MATCH PARTIAL"
+		}
+		if a := fkAction(con.FkUpdAction); a != "" {
+			s += " ON UPDATE " + a
+		}
+		if a := fkAction(con.FkDelAction); a != "" {
+			s += " ON DELETE " + a
+			if def.FkDelSetCols != nil {
+				s += " (" + identList(def.FkDelSetCols) + ")"
+			}
+		}
+	default:
+		return d.fail("cannot deparse constraint type %d", con.Type)
+	}
+	if con.Deferrable {
+		s += " DEFERRABLE"
+		if con.InitDeferred {
+			s += " INITIALLY DEFERRED"
+		}
+	}
+	return s
+}
+
+func fkAction(action byte) string {
+	switch action {
+	case 'r':
+		return "RESTRICT"
+	case 'c':
+		return "CASCADE"
+	case 'n':
+		return "SET NULL"
+	case 'd':
+		return "SET DEFAULT"
+	}
+	return ""
+}
+
+// indexParameters renders the INCLUDE and WITH clauses of an index-backed
+// constraint.
+func (d *deparser) indexParameters(def *nodes.Constraint) string {
+	var s string
+	if def.Including != nil {
+		s += " INCLUDE (" + identList(def.Including) + ")"
+	}
+	if def.Options != nil {
+		s += " WITH " + d.defList(def.Options)
+	}
+	return s
+}
+
+func (d *deparser) indexElem(e *nodes.IndexElem) string {
+	if e == nil {
+		return d.fail("malformed index element")
+	}
+	var s string
+	if e.Expr != nil {
+		s = "(" + d.expr(e.Expr) + ")"
+	} else {
+		s = quoteIdent(e.Name)
+	}
+	if e.Collation != nil {
+		s += " COLLATE " + nameList(e.Collation)
+	}
+	if e.Opclass != nil {
+		s += " " + nameList(e.Opclass)
+		if e.Opclassopts != nil {
+			s += " " + d.defList(e.Opclassopts)
+		}
+	}
+	switch e.Ordering {
+	case nodes.SORTBY_ASC:
+		s += " ASC"
+	case nodes.SORTBY_DESC:
+		s += " DESC"
+	}
+	switch e.NullsOrdering {
+	case nodes.SORTBY_NULLS_FIRST:
+		s += " NULLS FIRST"
+	case nodes.SORTBY_NULLS_LAST:
+		s += " NULLS LAST"
+	}
+	return s
+}
+
+func (d *deparser) indexElems(elems []*nodes.IndexElem) string {
+	parts := make([]string, len(elems))
+	for i, e := range elems {
+		parts[i] = d.indexElem(e)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// createIndex renders CREATE INDEX for an index that does not implement a
+// constraint.
+func (d *deparser) createIndex(idx *catalog.Relation) string {
+	ix := idx.Index
+	s := "CREATE "
+	if ix.Unique {
+		s += "UNIQUE "
+	}
+	method := ix.AccessMethod
+	if method == "" {
+		method = "btree"
+	}
+	s += "INDEX " + quoteIdent(idx.Name) + " ON " + ix.Table.QualifiedName() +
+		" USING " + quoteIdent(method) + " (" + d.indexElems(ix.Params) + ")"
+	if len(ix.Including) > 0 {
+		s += " INCLUDE (" + d.indexElems(ix.Including) + ")"
+	}
+	if ix.NullsNotDistinct {
+		s += " NULLS NOT DISTINCT"
+	}
+	if idx.Options != nil {
+		s += " WITH " + d.defList(idx.Options)
+	}
+	if ix.Where != nil {
+		s += " WHERE (" + d.expr(ix.Where) + ")"
+	}
+	return s
+}
+
+func (d *deparser) partitionSpec(ps *nodes.PartitionSpec) string {
+	strategy := map[nodes.PartitionStrategy]string{
+		nodes.PARTITION_STRATEGY_RANGE: "RANGE",
+		nodes.PARTITION_STRATEGY_LIST:  "LIST",
+		nodes.PARTITION_STRATEGY_HASH:  "HASH",
+	}[ps.Strategy]
+	var elems []string
+	for _, n := range items(ps.PartParams) {
+		pe, ok := n.(*nodes.PartitionElem)
+		if !ok {
+			continue
+		}
+		var s string
+		if pe.Expr != nil {
+			s = "(" + d.expr(pe.Expr) + ")"
+		} else {
+			s = quoteIdent(pe.Name)
+		}
+		if pe.Collation != nil {
+			s += " COLLATE " + nameList(pe.Collation)
+		}
+		if pe.Opclass != nil {
+			s += " " + nameList(pe.Opclass)
+		}
+		elems = append(elems, s)
+	}
+	return "PARTITION BY " + strategy + " (" + strings.Join(elems, ", ") + ")"
+}
+
+func (d *deparser) partitionBound(b *nodes.PartitionBoundSpec) string {
+	if b.IsDefault {
+		return "DEFAULT"
+	}
+	switch b.Strategy {
+	case 'h':
+		return "FOR VALUES WITH (MODULUS " + strconv.Itoa(b.Modulus) + ", REMAINDER " + strconv.Itoa(b.Remainder) + ")"
+	case 'l':
+		return "FOR VALUES IN (" + d.exprList(b.Listdatums) + ")"
+	}
+	return "FOR VALUES FROM (" + d.exprList(b.Lowerdatums) + ") TO (" + d.exprList(b.Upperdatums) + ")"
+}
+
+// createTable renders CREATE TABLE for a table, listing its local columns
+// and the given constraints.
+func (d *deparser) createTable(rel *catalog.Relation, constraints []*catalog.Constraint) string {
+	var b strings.Builder
+	b.WriteString("CREATE ")
+	if rel.Persistence == 'u' {
+		b.WriteString("UNLOGGED ")
+	}
+	if rel.Kind == catalog.RelKindForeignTable {
+		b.WriteString("FOREIGN ")
+	}
+	b.WriteString("TABLE " + rel.QualifiedName())
+
+	var elems []string
+	if rel.PartitionOf == nil {
+		for _, col := range rel.Columns {
+			if col.IsLocal {
+				elems = append(elems, d.columnDef(col))
+			}
+		}
+	}
+	for _, con := range constraints {
+		elems = append(elems, "CONSTRAINT "+quoteIdent(con.Name)+" "+d.constraintDef(con))
+	}
+	if rel.PartitionOf != nil {
+		b.WriteString(" PARTITION OF " + rel.PartitionOf.QualifiedName())
+		if len(elems) > 0 {
+			b.WriteString(" (\n" + indent + strings.Join(elems, ",\n"+indent) + "\n)")
+		}
+		b.WriteString(" " + d.partitionBound(rel.PartitionBound))
+	} else {
+		b.WriteString(" (")
+		if len(elems) > 0 {
+			b.WriteString("\n" + indent + strings.Join(elems, ",\n"+indent) + "\n")
+		}
+		b.WriteString(")")
+		if len(rel.Inherits) > 0 {
+			var parents []string
+			for _, p := range rel.Inherits {
+				parents = append(parents, p.QualifiedName())
+			}
+			b.WriteString(" INHERITS (" + strings.Join(parents, ", ") + ")")
+		}
+	}
+	if rel.PartitionKey != nil {
+		b.WriteString(" " + d.partitionSpec(rel.PartitionKey))
+	}
+	if rel.Options != nil {
+		b.WriteString(" WITH " + d.defList(rel.Options))
+	}
+	if rel.Kind == catalog.RelKindForeignTable {
+		b.WriteString(" SERVER " + quoteIdent(rel.Server))
+		if rel.ForeignOptions != nil {
+			b.WriteString(" " + genericOptions(rel.ForeignOptions))
+		}
+	}
+	return b.String()
+}
+
+// createView renders CREATE VIEW or CREATE MATERIALIZED VIEW.
+func (d *deparser) createView(rel *catalog.Relation) string {
+	s := "CREATE " + relationKeyword(rel) + " " + rel.QualifiedName()
+	var cols []string
+	for _, col := range rel.Columns {
+		cols = append(cols, col.Name)
+	}
+	if len(cols) > 0 {
+		s += " (" + quoteIdents(cols) + ")"
+	}
+	if rel.Options != nil {
+		s += " WITH " + d.defList(rel.Options)
+	}
+	query, ok := rel.Query.(*nodes.SelectStmt)
+	if !ok {
+		return d.fail("cannot deparse query of view %s", rel.QualifiedName())
+	}
+	s += " AS\n" + d.selectStmt(query)
+	switch rel.CheckOption {
+	case 1:
+		s += "\nWITH LOCAL CHECK OPTION"
+	case 2:
+		s += "\nWITH CASCADED CHECK OPTION"
+	}
+	return s
+}
+
+// sequenceClauses renders the options of a sequence that differ from
+// those of old, or all of them if old is nil.
+func sequenceClauses(seq, old *catalog.Sequence) string {
+	var parts []string
+	if seq.Type != nil && (old == nil || old.Type == nil || typeName(old.Type) != typeName(seq.Type)) {
+		parts = append(parts, "AS "+typeName(seq.Type))
+	}
+	num := func(label string, v, was int64) {
+		if old == nil || v != was {
+			parts = append(parts, label+" "+strconv.FormatInt(v, 10))
+		}
+	}
+	var o catalog.Sequence
+	if old != nil {
+		o = *old
+	}
+	num("INCREMENT BY", seq.Increment, o.Increment)
+	num("MINVALUE", seq.MinValue, o.MinValue)
+	num("MAXVALUE", seq.MaxValue, o.MaxValue)
+	num("START WITH", seq.Start, o.Start)
+	num("CACHE", seq.Cache, o.Cache)
+	if old == nil || seq.Cycle != o.Cycle {
+		if seq.Cycle {
+			parts = append(parts, "CYCLE")
+		} else {
+			parts = append(parts, "NO CYCLE")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func createSequence(rel *catalog.Relation) string {
+	s := "CREATE "
+	if rel.Persistence == 'u' {
+		s += "UNLOGGED "
+	}
+	return s + "SEQUENCE " + rel.QualifiedName() + " " + sequenceClauses(rel.Sequence, nil)
+}
+
+// createType renders CREATE TYPE or CREATE DOMAIN.
+func (d *deparser) createType(t *catalog.Type) string {
+	name := t.QualifiedName()
+	switch t.Kind {
+	case catalog.TypeKindEnum:
+		labels := make([]string, len(t.EnumLabels))
+		for i, l := range t.EnumLabels {
+			labels[i] = quoteLiteral(l)
+		}
+		return "CREATE TYPE " + name + " AS ENUM (" + strings.Join(labels, ", ") + ")"
+	case catalog.TypeKindComposite:
+		var attrs []string
+		for _, a := range t.Attributes {
+			attr := quoteIdent(a.Name) + " " + a.TypeString()
+			if a.Collation != "" {
+				attr += " COLLATE " + collation(a.Collation)
+			}
+			attrs = append(attrs, attr)
+		}
+		if len(attrs) == 0 {
+			return "CREATE TYPE " + name + " AS ()"
+		}
+		return "CREATE TYPE " + name + " AS (\n" + indent + strings.Join(attrs, ",\n"+indent) + "\n)"
+	case catalog.TypeKindRange:
+		return "CREATE TYPE " + name + " AS RANGE " + d.defList(t.Definition)
+	case catalog.TypeKindDomain:
+		s := "CREATE DOMAIN " + name + " AS " + typeName(t.BaseType)
+		if t.Collation != "" {
+			s += " COLLATE " + collation(t.Collation)
+		}
+		if t.Default != nil {
+			s += " DEFAULT " + d.expr(t.Default)
+		}
+		if t.NotNull {
+			s += " NOT NULL"
+		}
+		for _, con := range t.Constraints {
+			s += " CONSTRAINT " + quoteIdent(con.Name) + " " + d.constraintDef(con)
+		}
+		return s
+	case catalog.TypeKindShell:
+		return "CREATE TYPE " + name
+	}
+	return "CREATE TYPE " + name + " " + d.defList(t.Definition)
+}
+
+// dollarQuote quotes a routine body with a dollar-quote tag that does not
+// occur in it.
+func dollarQuote(body string) string {
+	tag := "$$"
+	for i := 0; strings.Contains(body, tag); i++ {
+		tag = "$body" + strconv.Itoa(i) + "$"
+		if i == 0 {
+			tag = "$body$"
+		}
+	}
+	return tag + body + tag
+}
+
+// createFunction renders CREATE FUNCTION, CREATE PROCEDURE or CREATE
+// AGGREGATE.
+func (d *deparser) createFunction(f *catalog.Function, orReplace bool) string {
+	var b strings.Builder
+	b.WriteString("CREATE ")
+	if orReplace {
+		b.WriteString("OR REPLACE ")
+	}
+	b.WriteString(routineKeyword(f) + " " + funcName(f))
+
+	if f.Kind == catalog.FuncKindAggregate {
+		var args []string
+		for _, t := range f.ArgTypes() {
+			args = append(args, typeName(t))
+		}
+		if len(args) == 0 {
+			args = []string{"*"}
+		}
+		b.WriteString("(" + strings.Join(args, ", ") + ") " + d.defList(f.Options))
+		return b.String()
+	}
+
+	var params, table []string
+	for _, p := range f.Params {
+		var s string
+		switch p.Mode {
+		case nodes.FUNC_PARAM_OUT:
+			s = "OUT "
+		case nodes.FUNC_PARAM_INOUT:
+			s = "INOUT "
+		case nodes.FUNC_PARAM_VARIADIC:
+			s = "VARIADIC "
+		}
+		if p.Name != "" {
+			s += quoteIdent(p.Name) + " "
+		}
+		s += typeName(p.Type)
+		if p.Default != nil {
+			s += " DEFAULT " + d.expr(p.Default)
+		}
+		if p.Mode == nodes.FUNC_PARAM_TABLE {
+			table = append(table, s)
+		} else {
+			params = append(params, s)
+		}
+	}
+	b.WriteString("(" + strings.Join(params, ", ") + ")")
+	switch {
+	case len(table) > 0:
+		b.WriteString("\nRETURNS TABLE (" + strings.Join(table, ", ") + ")")
+	case f.ReturnType != nil && f.Kind != catalog.FuncKindProcedure && !hasOutParams(f):
+		b.WriteString("\nRETURNS " + typeName(f.ReturnType))
+	}
+	b.WriteString("\nLANGUAGE " + quoteIdent(f.Language))
+	if f.Kind == catalog.FuncKindWindow {
+		b.WriteString("\nWINDOW")
+	}
+	for _, n := range items(f.Options) {
+		if de, ok := n.(*nodes.DefElem); ok {
+			b.WriteString("\n" + d.functionOption(de))
+		}
+	}
+	switch {
+	case f.SQLBody != nil:
+		b.WriteString("\n" + d.routineBody(f.SQLBody))
+	case strings.EqualFold(f.Language, "c") && strings.Contains(f.Body, "\n"):
+		file, symbol, _ := strings.Cut(f.Body, "\n")
+		b.WriteString("\nAS " + quoteLiteral(file) + ", " + quoteLiteral(symbol))
+	default:
+		b.WriteString("\nAS " + dollarQuote(f.Body))
+	}
+	return b.String()
+}
+
+func hasOutParams(f *catalog.Function) bool {
+	for _, p := range f.Params {
+		if p.Mode == nodes.FUNC_PARAM_OUT || p.Mode == nodes.FUNC_PARAM_INOUT {
+			return true
+		}
+	}
+	return false
+}
+
+// functionOption renders one routine attribute such as IMMUTABLE or SET.
+func (d *deparser) functionOption(de *nodes.DefElem) string {
+	flag := func(on, off string) string {
+		if b, ok := de.Arg.(*nodes.Boolean); ok && !b.Boolval {
+			return off
+		}
+		return on
+	}
+	switch de.Defname {
+	case "volatility":
+		if s, ok := de.Arg.(*nodes.String); ok {
+			return strings.ToUpper(s.Sval)
+		}
+	case "strict":
+		return flag("STRICT", "CALLED ON NULL INPUT")
+	case "security":
+		return flag("SECURITY DEFINER", "SECURITY INVOKER")
+	case "leakproof":
+		return flag("LEAKPROOF", "NOT LEAKPROOF")
+	case "parallel":
+		if s, ok := de.Arg.(*nodes.String); ok {
+			return "PARALLEL " + strings.ToUpper(s.Sval)
+		}
+	case "cost":
+		return "COST " + d.defArg(de.Arg)
+	case "rows":
+		return "ROWS " + d.defArg(de.Arg)
+	case "support":
+		return "SUPPORT " + d.defArg(de.Arg)
+	case "set":
+		if vs, ok := de.Arg.(*nodes.VariableSetStmt); ok {
+			switch vs.Kind {
+			case nodes.VAR_SET_VALUE:
+				return "SET " + quoteIdent(vs.Name) + " TO " + d.exprList(vs.Args)
+			case nodes.VAR_SET_CURRENT:
+				return "SET " + quoteIdent(vs.Name) + " FROM CURRENT"
+			}
+		}
+	}
+	return d.fail("cannot deparse routine option %q", de.Defname)
+}
+
+// routineBody renders a SQL-standard routine body: RETURN expr or BEGIN
+// ATOMIC ... END.
+func (d *deparser) routineBody(n nodes.Node) string {
+	if r, ok := n.(*nodes.ReturnStmt); ok {
+		return "RETURN " + d.expr(r.Returnval)
+	}
+	var b strings.Builder
+	b.WriteString("BEGIN ATOMIC\n")
+	for _, block := range items(asList(n)) {
+		for _, stmt := range items(asList(block)) {
+			switch s := stmt.(type) {
+			case *nodes.ReturnStmt:
+				b.WriteString(indent + "RETURN " + d.expr(s.Returnval) + ";\n")
+			case *nodes.SelectStmt:
+				b.WriteString(indent + d.selectStmt(s) + ";\n")
+			default:
+				return d.fail("cannot deparse %T in routine body", stmt)
+			}
+		}
+	}
+	b.WriteString("END")
+	return b.String()
+}
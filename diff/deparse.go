@@ -0,0 +1,867 @@
+package diff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pgplex/pgparser/catalog"
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// deparser renders raw parse trees back to SQL text. The output is meant
+// to be read back by the parser, not to be pretty: every compound operand
+// is parenthesized, so precedence never depends on the operators involved.
+// Nodes it cannot render are reported through err.
+type deparser struct {
+	err error
+}
+
+func (d *deparser) fail(format string, args ...any) string {
+	if d.err == nil {
+		d.err = fmt.Errorf(format, args...)
+	}
+	return ""
+}
+
+func items(l *nodes.List) []nodes.Node {
+	if l == nil {
+		return nil
+	}
+	return l.Items
+}
+
+func quoteIdent(s string) string {
+	return catalog.QuoteIdentifier(s)
+}
+
+// quoteLiteral renders s as a standard-conforming string literal.
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// nameList renders a possibly-qualified name given as a list of String
+// nodes.
+func nameList(l *nodes.List) string {
+	var parts []string
+	for _, n := range items(l) {
+		if s, ok := n.(*nodes.String); ok {
+			parts = append(parts, quoteIdent(s.Sval))
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// identList renders a list of String nodes as a comma-separated list of
+// identifiers.
+func identList(l *nodes.List) string {
+	var parts []string
+	for _, n := range items(l) {
+		if s, ok := n.(*nodes.String); ok {
+			parts = append(parts, quoteIdent(s.Sval))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func quoteIdents(names []string) string {
+	parts := make([]string, len(names))
+	for i, n := range names {
+		parts[i] = quoteIdent(n)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// operatorName renders an operator name, using OPERATOR() syntax for
+// qualified operators.
+func operatorName(l *nodes.List) string {
+	names := items(l)
+	if len(names) == 1 {
+		if s, ok := names[0].(*nodes.String); ok {
+			return s.Sval
+		}
+	}
+	var parts []string
+	for i, n := range names {
+		if s, ok := n.(*nodes.String); ok {
+			if i < len(names)-1 {
+				parts = append(parts, quoteIdent(s.Sval))
+			} else {
+				parts = append(parts, s.Sval)
+			}
+		}
+	}
+	return "OPERATOR(" + strings.Join(parts, ".") + ")"
+}
+
+func typeName(tn *nodes.TypeName) string {
+	return catalog.FormatTypeName(tn)
+}
+
+// exprList renders a list of expressions separated by commas.
+func (d *deparser) exprList(l *nodes.List) string {
+	var parts []string
+	for _, n := range items(l) {
+		parts = append(parts, d.expr(n))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// operand renders an expression used as the operand of an operator,
+// parenthesizing it unless it is atomic.
+func (d *deparser) operand(n nodes.Node) string {
+	s := d.expr(n)
+	switch v := n.(type) {
+	case *nodes.ColumnRef, *nodes.ParamRef, *nodes.FuncCall, *nodes.CaseExpr,
+		*nodes.CoalesceExpr, *nodes.MinMaxExpr, *nodes.A_ArrayExpr, *nodes.SQLValueFunction,
+		*nodes.GroupingFunc, *nodes.A_Indirection:
+		return s
+	case *nodes.RowExpr:
+		return s
+	case *nodes.SubLink:
+		if v.SubLinkType == nodes.EXPR_SUBLINK || v.SubLinkType == nodes.ARRAY_SUBLINK ||
+			v.SubLinkType == nodes.EXISTS_SUBLINK {
+			return s
+		}
+	case *nodes.A_Const:
+		if !strings.HasPrefix(s, "-") {
+			return s
+		}
+	}
+	return "(" + s + ")"
+}
+
+// expr renders an expression.
+func (d *deparser) expr(n nodes.Node) string {
+	switch v := n.(type) {
+	case nil:
+		return "NULL"
+	case *nodes.ColumnRef:
+		var parts []string
+		for _, f := range items(v.Fields) {
+			switch f := f.(type) {
+			case *nodes.String:
+				parts = append(parts, quoteIdent(f.Sval))
+			case *nodes.A_Star:
+				parts = append(parts, "*")
+			}
+		}
+		return strings.Join(parts, ".")
+	case *nodes.ParamRef:
+		return "$" + strconv.Itoa(v.Number)
+	case *nodes.A_Const:
+		if v.Isnull {
+			return "NULL"
+		}
+		return d.value(v.Val)
+	case *nodes.Integer, *nodes.Float, *nodes.String, *nodes.Boolean, *nodes.BitString:
+		return d.value(v)
+	case *nodes.TypeCast:
+		return d.operand(v.Arg) + "::" + typeName(v.TypeName)
+	case *nodes.CollateClause:
+		return d.operand(v.Arg) + " COLLATE " + nameList(v.Collname)
+	case *nodes.A_Expr:
+		return d.aExpr(v)
+	case *nodes.BoolExpr:
+		var parts []string
+		for _, a := range items(v.Args) {
+			parts = append(parts, d.operand(a))
+		}
+		switch v.Boolop {
+		case nodes.AND_EXPR:
+			return strings.Join(parts, " AND ")
+		case nodes.OR_EXPR:
+			return strings.Join(parts, " OR ")
+		}
+		return "NOT " + strings.Join(parts, "")
+	case *nodes.NullTest:
+		if v.Nulltesttype == nodes.IS_NULL {
+			return d.operand(v.Arg) + " IS NULL"
+		}
+		return d.operand(v.Arg) + " IS NOT NULL"
+	case *nodes.BooleanTest:
+		tests := []string{"IS TRUE", "IS NOT TRUE", "IS FALSE", "IS NOT FALSE", "IS UNKNOWN", "IS NOT UNKNOWN"}
+		return d.operand(v.Arg) + " " + tests[v.Booltesttype]
+	case *nodes.FuncCall:
+		return d.funcCall(v)
+	case *nodes.NamedArgExpr:
+		return quoteIdent(v.Name) + " => " + d.expr(v.Arg)
+	case *nodes.CaseExpr:
+		var b strings.Builder
+		b.WriteString("CASE")
+		if v.Arg != nil {
+			b.WriteString(" " + d.operand(v.Arg))
+		}
+		for _, w := range items(v.Args) {
+			if w, ok := w.(*nodes.CaseWhen); ok {
+				b.WriteString(" WHEN " + d.expr(w.Expr) + " THEN " + d.expr(w.Result))
+			}
+		}
+		if v.Defresult != nil {
+			b.WriteString(" ELSE " + d.expr(v.Defresult))
+		}
+		b.WriteString(" END")
+		return b.String()
+	case *nodes.CoalesceExpr:
+		return "COALESCE(" + d.exprList(v.Args) + ")"
+	case *nodes.MinMaxExpr:
+		if v.Op == nodes.IS_GREATEST {
+			return "GREATEST(" + d.exprList(v.Args) + ")"
+		}
+		return "LEAST(" + d.exprList(v.Args) + ")"
+	case *nodes.NullIfExpr:
+		return "NULLIF(" + d.exprList(v.Args) + ")"
+	case *nodes.RowExpr:
+		if v.RowFormat == nodes.COERCE_IMPLICIT_CAST && len(items(v.Args)) > 1 {
+			return "(" + d.exprList(v.Args) + ")"
+		}
+		return "ROW(" + d.exprList(v.Args) + ")"
+	case *nodes.A_ArrayExpr:
+		return "ARRAY[" + d.exprList(v.Elements) + "]"
+	case *nodes.A_Indirection:
+		s := "(" + d.expr(v.Arg) + ")"
+		for _, ind := range items(v.Indirection) {
+			s += d.indirection(ind)
+		}
+		return s
+	case *nodes.SubLink:
+		return d.subLink(v)
+	case *nodes.SQLValueFunction:
+		return sqlValueFunction(v)
+	case *nodes.GroupingFunc:
+		return "GROUPING(" + d.exprList(v.Args) + ")"
+	case *nodes.SetToDefault:
+		return "DEFAULT"
+	case *nodes.A_Star:
+		return "*"
+	case *nodes.SelectStmt:
+		return "(" + d.selectStmt(v) + ")"
+	}
+	return d.fail("cannot deparse %T", n)
+}
+
+// value renders a constant value node.
+func (d *deparser) value(n nodes.Node) string {
+	switch v := n.(type) {
+	case *nodes.Integer:
+		return strconv.FormatInt(v.Ival, 10)
+	case *nodes.Float:
+		return v.Fval
+	case *nodes.Boolean:
+		if v.Boolval {
+			return "true"
+		}
+		return "false"
+	case *nodes.String:
+		return quoteLiteral(v.Sval)
+	case *nodes.BitString:
+		if v.Bsval == "" {
+			return "B''"
+		}
+		return strings.ToUpper(v.Bsval[:1]) + quoteLiteral(v.Bsval[1:])
+	}
+	return d.fail("cannot deparse constant %T", n)
+}
+
+func (d *deparser) indirection(n nodes.Node) string {
+	switch v := n.(type) {
+	case *nodes.String:
+		return "." + quoteIdent(v.Sval)
+	case *nodes.A_Star:
+		return ".*"
+	case *nodes.A_Indices:
+		if !v.IsSlice {
+			return "[" + d.expr(v.Uidx) + "]"
+		}
+		s := "["
+		if v.Lidx != nil {
+			s += d.expr(v.Lidx)
+		}
+		s += ":"
+		if v.Uidx != nil {
+			s += d.expr(v.Uidx)
+		}
+		return s + "]"
+	}
+	return d.fail("cannot deparse indirection %T", n)
+}
+
+func (d *deparser) aExpr(v *nodes.A_Expr) string {
+	op := operatorName(v.Name)
+	switch v.Kind {
+	case nodes.AEXPR_OP:
+		if v.Lexpr == nil {
+			return op + " " + d.operand(v.Rexpr)
+		}
+		return d.operand(v.Lexpr) + " " + op + " " + d.operand(v.Rexpr)
+	case nodes.AEXPR_OP_ANY:
+		return d.operand(v.Lexpr) + " " + op + " ANY (" + d.expr(v.Rexpr) + ")"
+	case nodes.AEXPR_OP_ALL:
+		return d.operand(v.Lexpr) + " " + op + " ALL (" + d.expr(v.Rexpr) + ")"
+	case nodes.AEXPR_DISTINCT:
+		return d.operand(v.Lexpr) + " IS DISTINCT FROM " + d.operand(v.Rexpr)
+	case nodes.AEXPR_NOT_DISTINCT:
+		return d.operand(v.Lexpr) + " IS NOT DISTINCT FROM " + d.operand(v.Rexpr)
+	case nodes.AEXPR_NULLIF:
+		return "NULLIF(" + d.expr(v.Lexpr) + ", " + d.expr(v.Rexpr) + ")"
+	case nodes.AEXPR_IN:
+		list, _ := v.Rexpr.(*nodes.List)
+		if op == "<>" {
+			return d.operand(v.Lexpr) + " NOT IN (" + d.exprList(list) + ")"
+		}
+		return d.operand(v.Lexpr) + " IN (" + d.exprList(list) + ")"
+	case nodes.AEXPR_LIKE, nodes.AEXPR_ILIKE, nodes.AEXPR_SIMILAR:
+		word := map[nodes.A_Expr_Kind]string{
+			nodes.AEXPR_LIKE:    "LIKE",
+			nodes.AEXPR_ILIKE:   "ILIKE",
+			nodes.AEXPR_SIMILAR: "SIMILAR TO",
+		}[v.Kind]
+		if strings.HasPrefix(op, "!") {
+			word = "NOT " + word
+		}
+		return d.operand(v.Lexpr) + " " + word + " " + d.pattern(v.Rexpr)
+	case nodes.AEXPR_BETWEEN, nodes.AEXPR_NOT_BETWEEN, nodes.AEXPR_BETWEEN_SYM, nodes.AEXPR_NOT_BETWEEN_SYM:
+		bounds := items(asList(v.Rexpr))
+		if len(bounds) != 2 {
+			return d.fail("malformed BETWEEN")
+		}
+		return d.operand(v.Lexpr) + " " + op + " " + d.operand(bounds[0]) + " AND " + d.operand(bounds[1])
+	}
+	return d.fail("cannot deparse A_Expr kind %d", v.Kind)
+}
+
+// pattern renders the right operand of LIKE or SIMILAR TO, turning the
+// escape function the grammar inserts back into an ESCAPE clause.
+func (d *deparser) pattern(n nodes.Node) string {
+	if fc, ok := n.(*nodes.FuncCall); ok {
+		names := items(fc.Funcname)
+		if len(names) == 2 {
+			if s, ok := names[1].(*nodes.String); ok && (s.Sval == "like_escape" || s.Sval == "similar_to_escape") {
+				args := items(fc.Args)
+				if len(args) == 1 {
+					return d.operand(args[0])
+				}
+				if len(args) == 2 {
+					return d.operand(args[0]) + " ESCAPE " + d.operand(args[1])
+				}
+			}
+		}
+	}
+	return d.operand(n)
+}
+
+func asList(n nodes.Node) *nodes.List {
+	l, _ := n.(*nodes.List)
+	return l
+}
+
+func (d *deparser) subLink(v *nodes.SubLink) string {
+	sub := d.expr(v.Subselect)
+	switch nodes.SubLinkType(v.SubLinkType) {
+	case nodes.EXISTS_SUBLINK:
+		return "EXISTS " + sub
+	case nodes.ANY_SUBLINK:
+		if v.OperName == nil {
+			return d.operand(v.Testexpr) + " IN " + sub
+		}
+		return d.operand(v.Testexpr) + " " + operatorName(v.OperName) + " ANY " + sub
+	case nodes.ALL_SUBLINK:
+		return d.operand(v.Testexpr) + " " + operatorName(v.OperName) + " ALL " + sub
+	case nodes.ROWCOMPARE_SUBLINK:
+		return d.operand(v.Testexpr) + " " + operatorName(v.OperName) + " " + sub
+	case nodes.ARRAY_SUBLINK:
+		return "ARRAY" + sub
+	}
+	return sub
+}
+
+func sqlValueFunction(v *nodes.SQLValueFunction) string {
+	withPrecision := func(name string) string {
+		if v.Typmod >= 0 {
+			return name + "(" + strconv.Itoa(int(v.Typmod)) + ")"
+		}
+		return name
+	}
+	switch v.Op {
+	case nodes.SVFOP_CURRENT_DATE:
+		return "CURRENT_DATE"
+	case nodes.SVFOP_CURRENT_TIME, nodes.SVFOP_CURRENT_TIME_N:
+		return withPrecision("CURRENT_TIME")
+	case nodes.SVFOP_CURRENT_TIMESTAMP, nodes.SVFOP_CURRENT_TIMESTAMP_N:
+		return withPrecision("CURRENT_TIMESTAMP")
+	case nodes.SVFOP_LOCALTIME, nodes.SVFOP_LOCALTIME_N:
+		return withPrecision("LOCALTIME")
+	case nodes.SVFOP_LOCALTIMESTAMP, nodes.SVFOP_LOCALTIMESTAMP_N:
+		return withPrecision("LOCALTIMESTAMP")
+	case nodes.SVFOP_CURRENT_ROLE:
+		return "CURRENT_ROLE"
+	case nodes.SVFOP_CURRENT_USER:
+		return "CURRENT_USER"
+	case nodes.SVFOP_USER:
+		return "USER"
+	case nodes.SVFOP_SESSION_USER:
+		return "SESSION_USER"
+	case nodes.SVFOP_CURRENT_CATALOG:
+		return "CURRENT_CATALOG"
+	}
+	return "CURRENT_SCHEMA"
+}
+
+func (d *deparser) funcCall(v *nodes.FuncCall) string {
+	var b strings.Builder
+	b.WriteString(nameList(v.Funcname))
+	b.WriteString("(")
+	if v.AggStar {
+		b.WriteString("*")
+	} else {
+		if v.AggDistinct {
+			b.WriteString("DISTINCT ")
+		}
+		args := items(v.Args)
+		for i, a := range args {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			if v.FuncVariadic && i == len(args)-1 {
+				b.WriteString("VARIADIC ")
+			}
+			b.WriteString(d.expr(a))
+		}
+		if v.AggOrder != nil && !v.AggWithinGroup {
+			b.WriteString(" ORDER BY " + d.sortList(v.AggOrder))
+		}
+	}
+	b.WriteString(")")
+	if v.AggWithinGroup {
+		b.WriteString(" WITHIN GROUP (ORDER BY " + d.sortList(v.AggOrder) + ")")
+	}
+	if v.AggFilter != nil {
+		b.WriteString(" FILTER (WHERE " + d.expr(v.AggFilter) + ")")
+	}
+	if w := v.Over; w != nil {
+		if w.Name != "" && w.Refname == "" && w.PartitionClause == nil && w.OrderClause == nil &&
+			w.FrameOptions&nodes.FRAMEOPTION_NONDEFAULT == 0 {
+			b.WriteString(" OVER " + quoteIdent(w.Name))
+		} else {
+			b.WriteString(" OVER (" + d.windowSpec(w) + ")")
+		}
+	}
+	return b.String()
+}
+
+func (d *deparser) sortList(l *nodes.List) string {
+	var parts []string
+	for _, n := range items(l) {
+		s, ok := n.(*nodes.SortBy)
+		if !ok {
+			parts = append(parts, d.expr(n))
+			continue
+		}
+		part := d.expr(s.Node)
+		switch s.SortbyDir {
+		case nodes.SORTBY_ASC:
+			part += " ASC"
+		case nodes.SORTBY_DESC:
+			part += " DESC"
+		case nodes.SORTBY_USING:
+			part += " USING " + operatorName(s.UseOp)
+		}
+		switch s.SortbyNulls {
+		case nodes.SORTBY_NULLS_FIRST:
+			part += " NULLS FIRST"
+		case nodes.SORTBY_NULLS_LAST:
+			part += " NULLS LAST"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// windowSpec renders the parenthesized part of a window definition.
+func (d *deparser) windowSpec(w *nodes.WindowDef) string {
+	var parts []string
+	if w.Refname != "" {
+		parts = append(parts, quoteIdent(w.Refname))
+	}
+	if w.PartitionClause != nil {
+		parts = append(parts, "PARTITION BY "+d.exprList(w.PartitionClause))
+	}
+	if w.OrderClause != nil {
+		parts = append(parts, "ORDER BY "+d.sortList(w.OrderClause))
+	}
+	opts := w.FrameOptions
+	if opts&nodes.FRAMEOPTION_NONDEFAULT != 0 {
+		var frame string
+		switch {
+		case opts&nodes.FRAMEOPTION_RANGE != 0:
+			frame = "RANGE "
+		case opts&nodes.FRAMEOPTION_ROWS != 0:
+			frame = "ROWS "
+		default:
+			frame = "GROUPS "
+		}
+		bound := func(unboundedPreceding, unboundedFollowing, currentRow, offsetPreceding, offsetFollowing int, offset nodes.Node) string {
+			switch {
+			case opts&unboundedPreceding != 0:
+				return "UNBOUNDED PRECEDING"
+			case opts&unboundedFollowing != 0:
+				return "UNBOUNDED FOLLOWING"
+			case opts&currentRow != 0:
+				return "CURRENT ROW"
+			case opts&offsetPreceding != 0:
+				return d.operand(offset) + " PRECEDING"
+			case opts&offsetFollowing != 0:
+				return d.operand(offset) + " FOLLOWING"
+			}
+			return ""
+		}
+		start := bound(nodes.FRAMEOPTION_START_UNBOUNDED_PRECEDING, nodes.FRAMEOPTION_START_UNBOUNDED_FOLLOWING,
+			nodes.FRAMEOPTION_START_CURRENT_ROW, nodes.FRAMEOPTION_START_OFFSET_PRECEDING,
+			nodes.FRAMEOPTION_START_OFFSET_FOLLOWING, w.StartOffset)
+		if opts&nodes.FRAMEOPTION_BETWEEN != 0 {
+			end := bound(nodes.FRAMEOPTION_END_UNBOUNDED_PRECEDING, nodes.FRAMEOPTION_END_UNBOUNDED_FOLLOWING,
+				nodes.FRAMEOPTION_END_CURRENT_ROW, nodes.FRAMEOPTION_END_OFFSET_PRECEDING,
+				nodes.FRAMEOPTION_END_OFFSET_FOLLOWING, w.EndOffset)
+			frame += "BETWEEN " + start + " AND " + end
+		} else {
+			frame += start
+		}
+		switch {
+		case opts&nodes.FRAMEOPTION_EXCLUDE_CURRENT_ROW != 0:
+			frame += " EXCLUDE CURRENT ROW"
+		case opts&nodes.FRAMEOPTION_EXCLUDE_GROUP != 0:
+			frame += " EXCLUDE GROUP"
+		case opts&nodes.FRAMEOPTION_EXCLUDE_TIES != 0:
+			frame += " EXCLUDE TIES"
+		}
+		parts = append(parts, frame)
+	}
+	return strings.Join(parts, " ")
+}
+
+// selectStmt renders a SELECT, VALUES or set operation.
+func (d *deparser) selectStmt(s *nodes.SelectStmt) string {
+	var b strings.Builder
+	if s.WithClause != nil {
+		b.WriteString(d.withClause(s.WithClause) + " ")
+	}
+	switch {
+	case s.Op != nodes.SETOP_NONE:
+		b.WriteString(d.setOperand(s.Larg))
+		b.WriteString([]string{"", " UNION ", " INTERSECT ", " EXCEPT "}[s.Op])
+		if s.All {
+			b.WriteString("ALL ")
+		}
+		b.WriteString(d.setOperand(s.Rarg))
+	case s.ValuesLists != nil:
+		var rows []string
+		for _, row := range items(s.ValuesLists) {
+			rows = append(rows, "("+d.exprList(asList(row))+")")
+		}
+		b.WriteString("VALUES " + strings.Join(rows, ", "))
+	default:
+		b.WriteString(d.simpleSelect(s))
+	}
+	if s.SortClause != nil {
+		b.WriteString(" ORDER BY " + d.sortList(s.SortClause))
+	}
+	if s.LimitOption == nodes.LIMIT_OPTION_WITH_TIES {
+		if s.LimitOffset != nil {
+			b.WriteString(" OFFSET " + d.operand(s.LimitOffset) + " ROWS")
+		}
+		b.WriteString(" FETCH FIRST " + d.operand(s.LimitCount) + " ROWS WITH TIES")
+	} else {
+		if s.LimitCount != nil {
+			b.WriteString(" LIMIT " + d.operand(s.LimitCount))
+		}
+		if s.LimitOffset != nil {
+			b.WriteString(" OFFSET " + d.operand(s.LimitOffset))
+		}
+	}
+	for _, n := range items(s.LockingClause) {
+		if lc, ok := n.(*nodes.LockingClause); ok {
+			b.WriteString(d.lockingClause(lc))
+		}
+	}
+	return b.String()
+}
+
+// setOperand renders one side of a set operation, parenthesizing it if it
+// carries clauses that would otherwise attach to the whole operation.
+func (d *deparser) setOperand(s *nodes.SelectStmt) string {
+	if s == nil {
+		return d.fail("malformed set operation")
+	}
+	text := d.selectStmt(s)
+	if s.Op != nodes.SETOP_NONE || s.SortClause != nil || s.LimitCount != nil || s.LimitOffset != nil ||
+		s.LockingClause != nil || s.WithClause != nil {
+		return "(" + text + ")"
+	}
+	return text
+}
+
+func (d *deparser) simpleSelect(s *nodes.SelectStmt) string {
+	var b strings.Builder
+	b.WriteString("SELECT")
+	if s.DistinctClause != nil {
+		exprs := items(s.DistinctClause)
+		if len(exprs) == 1 && exprs[0] == nil {
+			b.WriteString(" DISTINCT")
+		} else {
+			b.WriteString(" DISTINCT ON (" + d.exprList(s.DistinctClause) + ")")
+		}
+	}
+	var targets []string
+	for _, n := range items(s.TargetList) {
+		rt, ok := n.(*nodes.ResTarget)
+		if !ok {
+			continue
+		}
+		t := d.expr(rt.Val)
+		if rt.Name != "" {
+			t += " AS " + quoteIdent(rt.Name)
+		}
+		targets = append(targets, t)
+	}
+	if len(targets) > 0 {
+		b.WriteString(" " + strings.Join(targets, ", "))
+	}
+	if s.IntoClause != nil {
+		return d.fail("cannot deparse SELECT INTO")
+	}
+	if s.FromClause != nil {
+		var from []string
+		for _, n := range items(s.FromClause) {
+			from = append(from, d.fromItem(n))
+		}
+		b.WriteString(" FROM " + strings.Join(from, ", "))
+	}
+	if s.WhereClause != nil {
+		b.WriteString(" WHERE " + d.expr(s.WhereClause))
+	}
+	if s.GroupClause != nil {
+		b.WriteString(" GROUP BY ")
+		if s.GroupDistinct {
+			b.WriteString("DISTINCT ")
+		}
+		var groups []string
+		for _, n := range items(s.GroupClause) {
+			groups = append(groups, d.groupItem(n))
+		}
+		b.WriteString(strings.Join(groups, ", "))
+	}
+	if s.HavingClause != nil {
+		b.WriteString(" HAVING " + d.expr(s.HavingClause))
+	}
+	if s.WindowClause != nil {
+		var windows []string
+		for _, n := range items(s.WindowClause) {
+			if w, ok := n.(*nodes.WindowDef); ok {
+				windows = append(windows, quoteIdent(w.Name)+" AS ("+d.windowSpec(w)+")")
+			}
+		}
+		b.WriteString(" WINDOW " + strings.Join(windows, ", "))
+	}
+	return b.String()
+}
+
+func (d *deparser) groupItem(n nodes.Node) string {
+	gs, ok := n.(*nodes.GroupingSet)
+	if !ok {
+		return d.expr(n)
+	}
+	var parts []string
+	for _, c := range items(gs.Content) {
+		parts = append(parts, d.groupItem(c))
+	}
+	inner := strings.Join(parts, ", ")
+	switch gs.Kind {
+	case nodes.GROUPING_SET_EMPTY:
+		return "()"
+	case nodes.GROUPING_SET_ROLLUP:
+		return "ROLLUP (" + inner + ")"
+	case nodes.GROUPING_SET_CUBE:
+		return "CUBE (" + inner + ")"
+	case nodes.GROUPING_SET_SETS:
+		return "GROUPING SETS (" + inner + ")"
+	}
+	return "(" + inner + ")"
+}
+
+func (d *deparser) lockingClause(lc *nodes.LockingClause) string {
+	s := []string{"", " FOR KEY SHARE", " FOR SHARE", " FOR NO KEY UPDATE", " FOR UPDATE"}[lc.Strength]
+	if lc.LockedRels != nil {
+		var rels []string
+		for _, n := range items(lc.LockedRels) {
+			rels = append(rels, d.fromItem(n))
+		}
+		s += " OF " + strings.Join(rels, ", ")
+	}
+	switch nodes.LockWaitPolicy(lc.WaitPolicy) {
+	case nodes.LockWaitSkip:
+		s += " SKIP LOCKED"
+	case nodes.LockWaitError:
+		s += " NOWAIT"
+	}
+	return s
+}
+
+func (d *deparser) withClause(w *nodes.WithClause) string {
+	var ctes []string
+	for _, n := range items(w.Ctes) {
+		cte, ok := n.(*nodes.CommonTableExpr)
+		if !ok {
+			continue
+		}
+		if cte.SearchClause != nil || cte.CycleClause != nil {
+			return d.fail("cannot deparse SEARCH or CYCLE clauses")
+		}
+		s := quoteIdent(cte.Ctename)
+		if cte.Aliascolnames != nil {
+			s += " (" + identList(cte.Aliascolnames) + ")"
+		}
+		s += " AS "
+		switch nodes.CTEMaterialize(cte.Ctematerialized) {
+		case nodes.CTEMaterializeAlways:
+			s += "MATERIALIZED "
+		case nodes.CTEMaterializeNever:
+			s += "NOT MATERIALIZED "
+		}
+		sel, ok := cte.Ctequery.(*nodes.SelectStmt)
+		if !ok {
+			return d.fail("cannot deparse data-modifying WITH query")
+		}
+		ctes = append(ctes, s+"("+d.selectStmt(sel)+")")
+	}
+	prefix := "WITH "
+	if w.Recursive {
+		prefix += "RECURSIVE "
+	}
+	return prefix + strings.Join(ctes, ", ")
+}
+
+func alias(a *nodes.Alias) string {
+	if a == nil {
+		return ""
+	}
+	s := " AS " + quoteIdent(a.Aliasname)
+	if a.Colnames != nil {
+		s += " (" + identList(a.Colnames) + ")"
+	}
+	return s
+}
+
+func rangeVar(rv *nodes.RangeVar) string {
+	s := quoteIdent(rv.Relname)
+	if rv.Schemaname != "" {
+		s = quoteIdent(rv.Schemaname) + "." + s
+	}
+	if rv.Catalogname != "" {
+		s = quoteIdent(rv.Catalogname) + "." + s
+	}
+	return s
+}
+
+func (d *deparser) fromItem(n nodes.Node) string {
+	switch v := n.(type) {
+	case *nodes.RangeVar:
+		s := rangeVar(v)
+		if !v.Inh {
+			s = "ONLY " + s
+		}
+		return s + alias(v.Alias)
+	case *nodes.RangeSubselect:
+		s := d.expr(v.Subquery) + alias(v.Alias)
+		if v.Lateral {
+			s = "LATERAL " + s
+		}
+		return s
+	case *nodes.RangeFunction:
+		return d.rangeFunction(v)
+	case *nodes.JoinExpr:
+		return d.joinExpr(v)
+	}
+	return d.fail("cannot deparse FROM item %T", n)
+}
+
+func (d *deparser) rangeFunction(v *nodes.RangeFunction) string {
+	var funcs []string
+	for _, n := range items(v.Functions) {
+		pair := items(asList(n))
+		if len(pair) == 0 {
+			continue
+		}
+		s := d.expr(pair[0])
+		if len(pair) > 1 && pair[1] != nil {
+			s += " AS (" + d.columnDefList(asList(pair[1])) + ")"
+		}
+		funcs = append(funcs, s)
+	}
+	s := strings.Join(funcs, ", ")
+	if v.IsRowsfrom {
+		s = "ROWS FROM (" + s + ")"
+	}
+	if v.Lateral {
+		s = "LATERAL " + s
+	}
+	if v.Ordinality {
+		s += " WITH ORDINALITY"
+	}
+	s += alias(v.Alias)
+	if v.Coldeflist != nil {
+		if v.Alias == nil {
+			s += " AS"
+		}
+		s += " (" + d.columnDefList(v.Coldeflist) + ")"
+	}
+	return s
+}
+
+func (d *deparser) columnDefList(l *nodes.List) string {
+	var parts []string
+	for _, n := range items(l) {
+		if cd, ok := n.(*nodes.ColumnDef); ok {
+			parts = append(parts, quoteIdent(cd.Colname)+" "+typeName(cd.TypeName))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (d *deparser) joinExpr(v *nodes.JoinExpr) string {
+	left := d.fromItem(v.Larg)
+	right := d.fromItem(v.Rarg)
+	if _, ok := v.Rarg.(*nodes.JoinExpr); ok {
+		right = "(" + right + ")"
+	}
+	var kind string
+	switch v.Jointype {
+	case nodes.JOIN_LEFT:
+		kind = "LEFT JOIN"
+	case nodes.JOIN_FULL:
+		kind = "FULL JOIN"
+	case nodes.JOIN_RIGHT:
+		kind = "RIGHT JOIN"
+	default:
+		kind = "JOIN"
+		if !v.IsNatural && v.Quals == nil && v.UsingClause == nil {
+			kind = "CROSS JOIN"
+		}
+	}
+	if v.IsNatural {
+		kind = "NATURAL " + kind
+	}
+	s := left + " " + kind + " " + right
+	if v.UsingClause != nil {
+		s += " USING (" + identList(v.UsingClause) + ")"
+		if v.JoinUsingAlias != nil {
+			s += " AS " + quoteIdent(v.JoinUsingAlias.Aliasname)
+		}
+	}
+	if v.Quals != nil {
+		s += " ON " + d.expr(v.Quals)
+	}
+	if v.Alias != nil {
+		s = "(" + s + ")" + alias(v.Alias)
+	}
+	return s
+}
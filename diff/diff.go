@@ -0,0 +1,1225 @@
+// Package diff compares two catalog states and generates the DDL that
+// migrates a database from the first to the second.
+//
+// Objects are matched by schema-qualified name, and routines by name and
+// argument types, so a rename shows up as a drop and a create. Owners and
+// privileges are not compared. Objects whose definition cannot change in
+// place, such as views and tables whose kind or partitioning changes, are
+// dropped and recreated, together with whatever depends on them.
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pgplex/pgparser/catalog"
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// SQL loads the DDL scripts from and to into empty catalogs and returns
+// the statements that migrate the first schema to the second.
+func SQL(from, to string) ([]string, error) {
+	a := catalog.New()
+	if err := a.Exec(from); err != nil {
+		return nil, err
+	}
+	b := catalog.New()
+	if err := b.Exec(to); err != nil {
+		return nil, err
+	}
+	return Diff(a, b)
+}
+
+// Diff returns the statements, without trailing semicolons, that migrate
+// a database whose schema is described by from to the schema described by
+// to. The statements are ordered so that each one only refers to objects
+// that exist at that point.
+func Diff(from, to *catalog.Catalog) ([]string, error) {
+	m := &differ{
+		from:        from,
+		to:          to,
+		droppedRels: make(map[*catalog.Relation]bool),
+		droppedCons: make(map[*catalog.Constraint]bool),
+		replaced:    make(map[*catalog.Type]bool),
+		recreated:   make(map[*catalog.Function]bool),
+		released:    make(map[*catalog.Relation]bool),
+	}
+	m.plan()
+	m.releaseSequences()
+	m.dropViews()
+	m.dropForeignKeys()
+	m.dropConstraints()
+	m.dropTables()
+	m.dropRecreatedFunctions()
+	m.createSchemas()
+	m.alterTypes()
+	m.alterSequences()
+	m.alterFunctions()
+	m.completeBaseTypes()
+	m.createTables()
+	m.alterTables()
+	m.addConstraints(false)
+	m.addConstraints(true)
+	m.ownSequences()
+	m.createViews()
+	m.dropRemoved()
+	m.comments()
+	if m.d.err != nil {
+		return nil, m.d.err
+	}
+	return m.out, nil
+}
+
+// differ holds the state of one comparison. The maps are keyed by objects
+// of the from catalog.
+type differ struct {
+	from, to *catalog.Catalog
+	d        deparser
+	out      []string
+
+	droppedRels map[*catalog.Relation]bool   // tables, views and indexes dropped or recreated
+	droppedCons map[*catalog.Constraint]bool // constraints dropped or recreated
+	replaced    map[*catalog.Type]bool       // types renamed away and created anew
+	recreated   map[*catalog.Function]bool   // routines dropped and created anew
+	released    map[*catalog.Relation]bool   // sequences detached from their owning column
+}
+
+func (m *differ) emit(format string, args ...any) {
+	m.out = append(m.out, fmt.Sprintf(format, args...))
+}
+
+// skipSchema reports whether objects in the schema are left out of the
+// comparison.
+func skipSchema(s *catalog.Schema) bool {
+	return s.Name == "pg_catalog" || strings.HasPrefix(s.Name, "pg_temp") || strings.HasPrefix(s.Name, "pg_toast")
+}
+
+// Lookups of an object's counterpart in the other catalog.
+
+func schemaIn(c *catalog.Catalog, s *catalog.Schema) *catalog.Schema {
+	return c.Schema(s.Name)
+}
+
+func relationIn(c *catalog.Catalog, r *catalog.Relation) *catalog.Relation {
+	if r == nil {
+		return nil
+	}
+	if s := c.Schema(r.Schema.Name); s != nil {
+		return s.Relation(r.Name)
+	}
+	return nil
+}
+
+func typeIn(c *catalog.Catalog, t *catalog.Type) *catalog.Type {
+	if s := c.Schema(t.Schema.Name); s != nil {
+		return s.Type(t.Name)
+	}
+	return nil
+}
+
+func functionIn(c *catalog.Catalog, f *catalog.Function) *catalog.Function {
+	s := c.Schema(f.Schema.Name)
+	if s == nil {
+		return nil
+	}
+	sig := f.Signature()
+	for _, g := range s.FunctionsNamed(f.Name) {
+		if g.Signature() == sig {
+			return g
+		}
+	}
+	return nil
+}
+
+func constraintIn(c *catalog.Catalog, con *catalog.Constraint) *catalog.Constraint {
+	if con.Domain != nil {
+		if t := typeIn(c, con.Domain); t != nil {
+			for _, x := range t.Constraints {
+				if x.Name == con.Name {
+					return x
+				}
+			}
+		}
+		return nil
+	}
+	if r := relationIn(c, con.Relation); r != nil {
+		return r.Constraint(con.Name)
+	}
+	return nil
+}
+
+// Object enumeration.
+
+func relations(c *catalog.Catalog) []*catalog.Relation {
+	var out []*catalog.Relation
+	for _, s := range c.Schemas() {
+		if !skipSchema(s) {
+			out = append(out, s.Relations()...)
+		}
+	}
+	return out
+}
+
+func types(c *catalog.Catalog) []*catalog.Type {
+	var out []*catalog.Type
+	for _, s := range c.Schemas() {
+		if !skipSchema(s) {
+			out = append(out, s.Types()...)
+		}
+	}
+	return out
+}
+
+func functions(c *catalog.Catalog) []*catalog.Function {
+	var out []*catalog.Function
+	for _, s := range c.Schemas() {
+		if !skipSchema(s) {
+			out = append(out, s.Functions()...)
+		}
+	}
+	return out
+}
+
+func isView(r *catalog.Relation) bool {
+	return r.Kind == catalog.RelKindView || r.Kind == catalog.RelKindMatView
+}
+
+// isPlainIndex reports whether r is an index created by CREATE INDEX
+// rather than by a constraint.
+func isPlainIndex(r *catalog.Relation) bool {
+	return r.Index != nil && !r.Index.IsConstraint
+}
+
+// isSequence reports whether r is a sequence created by CREATE SEQUENCE
+// or a serial column, as opposed to one backing an identity column.
+func isSequence(r *catalog.Relation) bool {
+	return r.Sequence != nil && !r.Sequence.Identity
+}
+
+// isInherited reports whether a CHECK constraint is inherited from a
+// parent, in which case it is created and dropped with the parent's.
+func isInherited(con *catalog.Constraint) bool {
+	if con.Type != nodes.CONSTR_CHECK || con.Relation == nil {
+		return false
+	}
+	parents := append([]*catalog.Relation(nil), con.Relation.Inherits...)
+	if con.Relation.PartitionOf != nil {
+		parents = append(parents, con.Relation.PartitionOf)
+	}
+	for _, p := range parents {
+		if pc := p.Constraint(con.Name); pc != nil && pc.Type == nodes.CONSTR_CHECK && !pc.NoInherit {
+			return true
+		}
+	}
+	return false
+}
+
+// toposort orders items so that each comes after the items it depends on,
+// keeping the given order otherwise. Dependencies outside items are
+// ignored, and cycles are broken arbitrarily.
+func toposort[T comparable](items []T, deps func(T) []T) []T {
+	in := make(map[T]bool, len(items))
+	for _, it := range items {
+		in[it] = true
+	}
+	done := make(map[T]bool, len(items))
+	var out []T
+	var visit func(T)
+	visit = func(it T) {
+		if done[it] {
+			return
+		}
+		done[it] = true
+		for _, dep := range deps(it) {
+			if in[dep] {
+				visit(dep)
+			}
+		}
+		out = append(out, it)
+	}
+	for _, it := range items {
+		visit(it)
+	}
+	return out
+}
+
+func reversed[T any](items []T) []T {
+	out := make([]T, len(items))
+	for i, it := range items {
+		out[len(items)-1-i] = it
+	}
+	return out
+}
+
+// relationDeps returns the relations r depends on in c.
+func relationDeps(c *catalog.Catalog) func(*catalog.Relation) []*catalog.Relation {
+	return func(r *catalog.Relation) []*catalog.Relation {
+		var out []*catalog.Relation
+		out = append(out, r.Inherits...)
+		if r.PartitionOf != nil {
+			out = append(out, r.PartitionOf)
+		}
+		for _, dep := range c.DependsOn(r) {
+			switch dep := dep.(type) {
+			case *catalog.Relation:
+				out = append(out, dep)
+			case *catalog.Column:
+				out = append(out, dep.Relation)
+			}
+		}
+		return out
+	}
+}
+
+// Rendered definitions, used both for output and to detect changes.
+
+func (m *differ) tableShape(r *catalog.Relation) string {
+	var s string
+	s += string(r.Kind)
+	if r.PartitionKey != nil {
+		s += " " + m.d.partitionSpec(r.PartitionKey)
+	}
+	if r.PartitionOf != nil {
+		s += " " + r.PartitionOf.QualifiedName() + " " + m.d.partitionBound(r.PartitionBound)
+	}
+	for _, p := range r.Inherits {
+		s += " " + p.QualifiedName()
+	}
+	if r.Kind == catalog.RelKindForeignTable {
+		s += " " + r.Server + " " + genericOptions(r.ForeignOptions)
+	}
+	return s
+}
+
+func (m *differ) typeShape(t *catalog.Type) string {
+	switch t.Kind {
+	case catalog.TypeKindDomain:
+		s := string(t.Kind) + " " + typeName(t.BaseType)
+		if t.Collation != "" {
+			s += " " + collation(t.Collation)
+		}
+		return s
+	case catalog.TypeKindComposite, catalog.TypeKindEnum:
+		return string(t.Kind)
+	}
+	return m.d.createType(t)
+}
+
+// plan decides which objects of the from catalog are dropped or replaced.
+func (m *differ) plan() {
+	for _, t := range types(m.from) {
+		nt := typeIn(m.to, t)
+		if nt == nil {
+			continue
+		}
+		if m.typeShape(t) != m.typeShape(nt) || t.Kind == catalog.TypeKindEnum && enumAdditions(t.EnumLabels, nt.EnumLabels) == nil {
+			m.replaced[t] = true
+		}
+	}
+	for _, f := range functions(m.from) {
+		if g := functionIn(m.to, f); g != nil && m.needsRecreate(f, g) {
+			m.recreated[f] = true
+		}
+	}
+
+	rels := relations(m.from)
+	for _, r := range rels {
+		if !r.IsTable() {
+			continue
+		}
+		nr := relationIn(m.to, r)
+		if nr == nil || m.tableShape(r) != m.tableShape(nr) {
+			m.droppedRels[r] = true
+		}
+	}
+	// Children of dropped tables go with them.
+	for changed := true; changed; {
+		changed = false
+		for _, r := range rels {
+			if !r.IsTable() || m.droppedRels[r] {
+				continue
+			}
+			if m.droppedRels[r.PartitionOf] || anyRel(r.Inherits, m.droppedRels) {
+				m.droppedRels[r] = true
+				changed = true
+			}
+		}
+	}
+
+	for _, r := range rels {
+		if isView(r) {
+			nr := relationIn(m.to, r)
+			if nr == nil || nr.Kind != r.Kind || m.d.createView(r) != m.d.createView(nr) {
+				m.droppedRels[r] = true
+			}
+		}
+	}
+	// Views are recreated when anything they use goes away or changes
+	// type.
+	for changed := true; changed; {
+		changed = false
+		for _, r := range rels {
+			if !isView(r) || m.droppedRels[r] {
+				continue
+			}
+			for _, dep := range m.from.DependsOn(r) {
+				if m.invalidates(dep) {
+					m.droppedRels[r] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	for _, r := range rels {
+		if m.droppedRels[r] || r.Index == nil {
+			continue
+		}
+		if m.droppedRels[r.Index.Table] {
+			m.droppedRels[r] = true
+			continue
+		}
+		if isPlainIndex(r) {
+			nr := relationIn(m.to, r)
+			if nr == nil || !isPlainIndex(nr) || m.d.createIndex(r) != m.d.createIndex(nr) {
+				m.droppedRels[r] = true
+			}
+		}
+	}
+
+	for _, r := range rels {
+		for _, con := range r.Constraints {
+			if m.droppedRels[r] {
+				m.droppedCons[con] = true
+				continue
+			}
+			if isInherited(con) {
+				continue
+			}
+			nc := constraintIn(m.to, con)
+			if nc == nil || m.d.constraintDef(con) != m.d.constraintDef(nc) || con.Validated && !nc.Validated {
+				m.droppedCons[con] = true
+			}
+		}
+	}
+	// Foreign keys go when the referenced table or key does.
+	for _, r := range rels {
+		for _, con := range r.Constraints {
+			if con.Type != nodes.CONSTR_FOREIGN || m.droppedCons[con] {
+				continue
+			}
+			if m.droppedRels[con.RefTable] || con.Index != nil && (m.droppedRels[con.Index] || m.keyDropped(con.Index)) {
+				m.droppedCons[con] = true
+			}
+		}
+	}
+	for _, r := range rels {
+		if r.Index != nil && r.Index.IsConstraint && m.keyDropped(r) {
+			m.droppedRels[r] = true
+		}
+	}
+}
+
+func anyRel(rels []*catalog.Relation, set map[*catalog.Relation]bool) bool {
+	for _, r := range rels {
+		if set[r] {
+			return true
+		}
+	}
+	return false
+}
+
+// keyDropped reports whether idx enforces a constraint that is dropped.
+func (m *differ) keyDropped(idx *catalog.Relation) bool {
+	for _, con := range idx.Index.Table.Constraints {
+		if con.Index == idx && con.Type != nodes.CONSTR_FOREIGN && m.droppedCons[con] {
+			return true
+		}
+	}
+	return false
+}
+
+// invalidates reports whether a view using the from object obj must be
+// recreated.
+func (m *differ) invalidates(obj any) bool {
+	switch o := obj.(type) {
+	case *catalog.Relation:
+		return m.droppedRels[o]
+	case *catalog.Column:
+		return m.droppedRels[o.Relation] || m.columnRetyped(o)
+	case *catalog.Type:
+		return m.replaced[o] || typeIn(m.to, o) == nil
+	case *catalog.Function:
+		return m.recreated[o] || functionIn(m.to, o) == nil
+	}
+	return false
+}
+
+// columnRetyped reports whether a column of a kept relation is dropped or
+// changes type.
+func (m *differ) columnRetyped(col *catalog.Column) bool {
+	nr := relationIn(m.to, col.Relation)
+	if nr == nil {
+		return true
+	}
+	nc := nr.Column(col.Name)
+	if nc == nil || nc.TypeString() != col.TypeString() || nc.Collation != col.Collation {
+		return true
+	}
+	return m.usesReplacedType(col)
+}
+
+func (m *differ) usesReplacedType(obj any) bool {
+	for _, dep := range m.from.DependsOn(obj) {
+		if t, ok := dep.(*catalog.Type); ok && m.replaced[t] {
+			return true
+		}
+	}
+	return false
+}
+
+// needsRecreate reports whether a routine must be dropped and created
+// again rather than replaced.
+func (m *differ) needsRecreate(f, g *catalog.Function) bool {
+	if f.Kind != g.Kind || f.Kind == catalog.FuncKindAggregate && m.d.createFunction(f, false) != m.d.createFunction(g, false) {
+		return true
+	}
+	if typeName(f.ReturnType) != typeName(g.ReturnType) || f.ReturnsSet != g.ReturnsSet || len(f.Params) != len(g.Params) {
+		return true
+	}
+	for i, p := range f.Params {
+		q := g.Params[i]
+		if p.Name != q.Name || p.Mode != q.Mode || typeName(p.Type) != typeName(q.Type) || p.Default != nil && q.Default == nil {
+			return true
+		}
+	}
+	return m.usesReplacedType(f)
+}
+
+// relationCreated reports whether the to relation r does not exist, or is
+// dropped, at the point where new objects are created.
+func (m *differ) relationCreated(r *catalog.Relation) bool {
+	old := relationIn(m.from, r)
+	return old == nil || m.droppedRels[old]
+}
+
+// constraintCreated reports the same for a to constraint.
+func (m *differ) constraintCreated(con *catalog.Constraint) bool {
+	if m.relationCreated(con.Relation) {
+		return true
+	}
+	old := constraintIn(m.from, con)
+	return old == nil || m.droppedCons[old]
+}
+
+// seqOwner returns a description of the column owning a sequence.
+func seqOwner(seq *catalog.Relation) string {
+	if col := seq.Sequence.OwnedBy; col != nil {
+		return col.Relation.QualifiedName() + "." + quoteIdent(col.Name)
+	}
+	return ""
+}
+
+// releaseSequences detaches kept sequences from owning columns that go
+// away or change, so that dropping the column does not drop the sequence.
+func (m *differ) releaseSequences() {
+	for _, r := range relations(m.from) {
+		if !isSequence(r) || r.Sequence.OwnedBy == nil {
+			continue
+		}
+		nr := relationIn(m.to, r)
+		if nr == nil || !isSequence(nr) {
+			continue
+		}
+		col := r.Sequence.OwnedBy
+		if seqOwner(r) != seqOwner(nr) || m.droppedRels[col.Relation] {
+			m.released[r] = true
+			m.emit("ALTER SEQUENCE %s OWNED BY NONE", r.QualifiedName())
+		}
+	}
+}
+
+// dropViews drops the views that are removed or recreated, dependents
+// first.
+func (m *differ) dropViews() {
+	var views []*catalog.Relation
+	for _, r := range relations(m.from) {
+		if isView(r) && m.droppedRels[r] {
+			views = append(views, r)
+		}
+	}
+	for _, r := range reversed(toposort(views, relationDeps(m.from))) {
+		m.emit("DROP %s %s", relationKeyword(r), r.QualifiedName())
+	}
+}
+
+func (m *differ) dropConstraint(con *catalog.Constraint) {
+	m.emit("ALTER TABLE %s DROP CONSTRAINT %s", con.Relation.QualifiedName(), quoteIdent(con.Name))
+}
+
+// dropForeignKeys drops the foreign keys that change or whose referenced
+// key goes away. Foreign keys of dropped tables are dropped with the
+// table unless they reference another dropped table.
+func (m *differ) dropForeignKeys() {
+	for _, r := range relations(m.from) {
+		for _, con := range r.Constraints {
+			if con.Type != nodes.CONSTR_FOREIGN || !m.droppedCons[con] {
+				continue
+			}
+			if m.droppedRels[r] && (con.RefTable == r || !m.droppedRels[con.RefTable]) {
+				continue
+			}
+			m.dropConstraint(con)
+		}
+	}
+}
+
+// dropConstraints drops the other constraints and the indexes of kept
+// tables that are removed or changed.
+func (m *differ) dropConstraints() {
+	for _, r := range relations(m.from) {
+		if m.droppedRels[r] {
+			continue
+		}
+		for _, con := range r.Constraints {
+			if con.Type != nodes.CONSTR_FOREIGN && m.droppedCons[con] && !isInherited(con) {
+				m.dropConstraint(con)
+			}
+		}
+	}
+	for _, r := range relations(m.from) {
+		if isPlainIndex(r) && m.droppedRels[r] && !m.droppedRels[r.Index.Table] {
+			m.emit("DROP INDEX %s", r.QualifiedName())
+		}
+	}
+}
+
+// dropTables drops the tables that are removed or recreated, children
+// before their parents.
+func (m *differ) dropTables() {
+	var tables []*catalog.Relation
+	for _, r := range relations(m.from) {
+		if r.IsTable() && m.droppedRels[r] {
+			tables = append(tables, r)
+		}
+	}
+	for _, r := range reversed(toposort(tables, relationDeps(m.from))) {
+		m.emit("DROP %s %s", relationKeyword(r), r.QualifiedName())
+	}
+}
+
+func (m *differ) createSchemas() {
+	for _, s := range m.to.Schemas() {
+		if !skipSchema(s) && schemaIn(m.from, s) == nil {
+			m.emit("CREATE SCHEMA %s", quoteIdent(s.Name))
+		}
+	}
+}
+
+// typeDeps returns the types t depends on in c.
+func typeDeps(c *catalog.Catalog) func(*catalog.Type) []*catalog.Type {
+	return func(t *catalog.Type) []*catalog.Type {
+		var out []*catalog.Type
+		for _, dep := range c.DependsOn(t) {
+			if dt, ok := dep.(*catalog.Type); ok {
+				out = append(out, dt)
+			}
+		}
+		return out
+	}
+}
+
+// enumAdditions returns the ALTER TYPE ... ADD VALUE clauses that turn the
+// labels old into labels, or nil if labels are removed or reordered.
+func enumAdditions(old, labels []string) []string {
+	pos := make(map[string]int, len(labels))
+	for i, l := range labels {
+		pos[l] = i
+	}
+	last := -1
+	for _, l := range old {
+		i, ok := pos[l]
+		if !ok || i < last {
+			return nil
+		}
+		last = i
+	}
+	have := make(map[string]bool, len(old))
+	for _, l := range old {
+		have[l] = true
+	}
+	out := []string{}
+	for i, l := range labels {
+		if have[l] {
+			continue
+		}
+		clause := "ADD VALUE " + quoteLiteral(l)
+		switch {
+		case i+1 < len(labels) && have[labels[i+1]]:
+			clause += " BEFORE " + quoteLiteral(labels[i+1])
+		case i > 0:
+			clause += " AFTER " + quoteLiteral(labels[i-1])
+		}
+		have[l] = true
+		out = append(out, clause)
+	}
+	return out
+}
+
+// oldTypeName returns the name a replaced type is renamed to while its
+// users are converted.
+func oldTypeName(t *catalog.Type) string {
+	return t.Name + "_old"
+}
+
+// alterTypes creates new types and changes existing ones. A type whose
+// definition cannot be altered in place is renamed, created anew, and the
+// columns using it are converted through text.
+func (m *differ) alterTypes() {
+	for _, t := range toposort(types(m.to), typeDeps(m.to)) {
+		old := typeIn(m.from, t)
+		if old != nil && m.replaced[old] {
+			m.emit("ALTER TYPE %s RENAME TO %s", old.QualifiedName(), quoteIdent(oldTypeName(old)))
+		}
+		switch {
+		case old == nil || m.replaced[old]:
+			if t.Kind == catalog.TypeKindBase {
+				m.emit("CREATE TYPE %s", t.QualifiedName())
+			} else {
+				m.emit("%s", m.d.createType(t))
+			}
+		case t.Kind == catalog.TypeKindEnum:
+			for _, clause := range enumAdditions(old.EnumLabels, t.EnumLabels) {
+				m.emit("ALTER TYPE %s %s", t.QualifiedName(), clause)
+			}
+		case t.Kind == catalog.TypeKindDomain:
+			m.alterDomain(old, t)
+		case t.Kind == catalog.TypeKindComposite:
+			m.alterComposite(old, t)
+		}
+		if old != nil && m.replaced[old] {
+			m.convertColumns(old, t)
+		}
+	}
+}
+
+// convertColumns changes the columns of kept tables that use the replaced
+// type old over to its replacement t.
+func (m *differ) convertColumns(old, t *catalog.Type) {
+	for _, r := range relations(m.from) {
+		if !r.IsTable() || m.droppedRels[r] {
+			continue
+		}
+		nr := relationIn(m.to, r)
+		for _, col := range r.Columns {
+			if !col.IsLocal || nr.Column(col.Name) == nil || !dependsOnType(m.from, col, old) {
+				continue
+			}
+			nc := nr.Column(col.Name)
+			if col.Default != nil {
+				m.emit("ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT", r.QualifiedName(), quoteIdent(col.Name))
+			}
+			m.emit("ALTER TABLE %s ALTER COLUMN %s TYPE %s USING %s::text::%s", r.QualifiedName(),
+				quoteIdent(col.Name), nc.TypeString(), quoteIdent(col.Name), nc.TypeString())
+			if nc.Default != nil && nc.Generated == 0 {
+				m.emit("ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s", r.QualifiedName(), quoteIdent(col.Name), m.d.expr(nc.Default))
+			}
+		}
+	}
+}
+
+func dependsOnType(c *catalog.Catalog, obj any, t *catalog.Type) bool {
+	for _, dep := range c.DependsOn(obj) {
+		if dep == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *differ) alterDomain(old, t *catalog.Type) {
+	name := t.QualifiedName()
+	if d := m.d.expr(t.Default); t.Default == nil && old.Default != nil {
+		m.emit("ALTER DOMAIN %s DROP DEFAULT", name)
+	} else if t.Default != nil && (old.Default == nil || m.d.expr(old.Default) != d) {
+		m.emit("ALTER DOMAIN %s SET DEFAULT %s", name, d)
+	}
+	if t.NotNull != old.NotNull {
+		if t.NotNull {
+			m.emit("ALTER DOMAIN %s SET NOT NULL", name)
+		} else {
+			m.emit("ALTER DOMAIN %s DROP NOT NULL", name)
+		}
+	}
+	for _, con := range old.Constraints {
+		nc := constraintIn(m.to, con)
+		if nc == nil || m.d.constraintDef(con) != m.d.constraintDef(nc) {
+			m.droppedCons[con] = true
+			m.emit("ALTER DOMAIN %s DROP CONSTRAINT %s", name, quoteIdent(con.Name))
+		}
+	}
+	for _, con := range t.Constraints {
+		oc := constraintIn(m.from, con)
+		switch {
+		case oc == nil || m.droppedCons[oc]:
+			s := "ALTER DOMAIN " + name + " ADD CONSTRAINT " + quoteIdent(con.Name) + " " + m.d.constraintDef(con)
+			if !con.Validated {
+				s += " NOT VALID"
+			}
+			m.emit("%s", s)
+		case con.Validated && !oc.Validated:
+			m.emit("ALTER DOMAIN %s VALIDATE CONSTRAINT %s", name, quoteIdent(con.Name))
+		}
+	}
+}
+
+func attribute(cols []*catalog.Column, name string) *catalog.Column {
+	for _, c := range cols {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (m *differ) alterComposite(old, t *catalog.Type) {
+	name := t.QualifiedName()
+	for _, a := range old.Attributes {
+		if attribute(t.Attributes, a.Name) == nil {
+			m.emit("ALTER TYPE %s DROP ATTRIBUTE %s", name, quoteIdent(a.Name))
+		}
+	}
+	for _, a := range t.Attributes {
+		oa := attribute(old.Attributes, a.Name)
+		def := a.TypeString()
+		if a.Collation != "" {
+			def += " COLLATE " + collation(a.Collation)
+		}
+		switch {
+		case oa == nil:
+			m.emit("ALTER TYPE %s ADD ATTRIBUTE %s %s", name, quoteIdent(a.Name), def)
+		case oa.TypeString() != a.TypeString() || oa.Collation != a.Collation:
+			m.emit("ALTER TYPE %s ALTER ATTRIBUTE %s TYPE %s", name, quoteIdent(a.Name), def)
+		}
+	}
+}
+
+// alterSequences creates new sequences, recreates those that are dropped
+// with their owning table or column, and alters changed ones.
+func (m *differ) alterSequences() {
+	for _, r := range relations(m.to) {
+		if !isSequence(r) {
+			continue
+		}
+		old := relationIn(m.from, r)
+		switch {
+		case old == nil || !isSequence(old) || m.implicitlyDropped(old):
+			m.emit("%s", createSequence(r))
+		default:
+			if clauses := sequenceClauses(r.Sequence, old.Sequence); clauses != "" {
+				m.emit("ALTER SEQUENCE %s %s", r.QualifiedName(), clauses)
+			}
+		}
+	}
+}
+
+// implicitlyDropped reports whether a from sequence goes away together
+// with the column that owns it.
+func (m *differ) implicitlyDropped(seq *catalog.Relation) bool {
+	col := seq.Sequence.OwnedBy
+	if col == nil || m.released[seq] {
+		return false
+	}
+	nr := relationIn(m.to, col.Relation)
+	return m.droppedRels[col.Relation] || nr == nil || nr.Column(col.Name) == nil
+}
+
+// dropRecreatedFunctions drops the routines that are created anew, while
+// the types they use still have their original names.
+func (m *differ) dropRecreatedFunctions() {
+	for _, f := range functions(m.from) {
+		if m.recreated[f] {
+			m.emit("DROP %s %s", routineKeyword(f), funcIdentity(f))
+		}
+	}
+}
+
+// alterFunctions creates new routines and replaces changed ones.
+func (m *differ) alterFunctions() {
+	var creates []string
+	bodies := false
+	for _, f := range functions(m.to) {
+		old := functionIn(m.from, f)
+		switch {
+		case old == nil:
+			creates = append(creates, m.d.createFunction(f, false))
+		case m.recreated[old]:
+			creates = append(creates, m.d.createFunction(f, false))
+		case m.d.createFunction(old, false) != m.d.createFunction(f, false):
+			creates = append(creates, m.d.createFunction(f, true))
+		default:
+			continue
+		}
+		if strings.EqualFold(f.Language, "sql") && f.SQLBody == nil {
+			bodies = true
+		}
+	}
+	if bodies {
+		// Bodies may refer to tables that are created later.
+		m.emit("SET check_function_bodies = false")
+	}
+	for _, s := range creates {
+		m.emit("%s", s)
+	}
+}
+
+// completeBaseTypes defines the base types whose shells were created
+// before their I/O functions.
+func (m *differ) completeBaseTypes() {
+	for _, t := range types(m.to) {
+		if old := typeIn(m.from, t); t.Kind == catalog.TypeKindBase && (old == nil || m.replaced[old]) {
+			m.emit("%s", m.d.createType(t))
+		}
+	}
+}
+
+// inlineConstraint reports whether a constraint of a new table is written
+// in its CREATE TABLE statement.
+func inlineConstraint(con *catalog.Constraint) bool {
+	return con.Type != nodes.CONSTR_FOREIGN && con.Validated && !isInherited(con)
+}
+
+// createTables creates new and recreated tables, parents first.
+func (m *differ) createTables() {
+	var tables []*catalog.Relation
+	for _, r := range relations(m.to) {
+		if r.IsTable() && m.relationCreated(r) {
+			tables = append(tables, r)
+		}
+	}
+	for _, r := range toposort(tables, relationDeps(m.to)) {
+		var cons []*catalog.Constraint
+		for _, con := range r.Constraints {
+			if inlineConstraint(con) {
+				cons = append(cons, con)
+			}
+		}
+		m.emit("%s", m.d.createTable(r, cons))
+	}
+}
+
+// alterTables changes the columns and table options of kept tables.
+func (m *differ) alterTables() {
+	for _, r := range relations(m.to) {
+		if !r.IsTable() || m.relationCreated(r) {
+			continue
+		}
+		old := relationIn(m.from, r)
+		name := r.QualifiedName()
+		for _, col := range old.Columns {
+			if col.IsLocal && r.Column(col.Name) == nil {
+				m.emit("ALTER TABLE %s DROP COLUMN %s", name, quoteIdent(col.Name))
+			}
+		}
+		for _, col := range r.Columns {
+			oc := old.Column(col.Name)
+			switch {
+			case !col.IsLocal:
+			case oc == nil || oc.Generated == 0 && col.Generated != 0:
+				if oc != nil {
+					m.emit("ALTER TABLE %s DROP COLUMN %s", name, quoteIdent(col.Name))
+				}
+				m.emit("ALTER TABLE %s ADD COLUMN %s", name, m.d.columnDef(col))
+			default:
+				m.alterColumn(r, oc, col)
+			}
+		}
+		if r.Persistence != old.Persistence && r.Kind != catalog.RelKindForeignTable {
+			if r.Persistence == 'u' {
+				m.emit("ALTER TABLE %s SET UNLOGGED", name)
+			} else {
+				m.emit("ALTER TABLE %s SET LOGGED", name)
+			}
+		}
+		m.alterOptions(name, old.Options, r.Options)
+	}
+}
+
+func (m *differ) alterColumn(r *catalog.Relation, old, col *catalog.Column) {
+	prefix := "ALTER TABLE " + r.QualifiedName() + " ALTER COLUMN " + quoteIdent(col.Name)
+	retyped := (old.TypeString() != col.TypeString() || old.Collation != col.Collation) && !m.usesReplacedType(old)
+
+	oldDefault, newDefault := "", ""
+	if old.Default != nil && old.Generated == 0 {
+		oldDefault = m.d.expr(old.Default)
+	}
+	if col.Default != nil && col.Generated == 0 {
+		newDefault = m.d.expr(col.Default)
+	}
+	if m.usesReplacedType(old) {
+		// convertColumns already dropped and restored the default.
+		oldDefault = newDefault
+	}
+
+	if retyped {
+		if oldDefault != "" {
+			m.emit("%s DROP DEFAULT", prefix)
+			oldDefault = ""
+		}
+		s := prefix + " TYPE " + col.TypeString()
+		if col.Collation != "" {
+			s += " COLLATE " + collation(col.Collation)
+		}
+		m.emit("%s USING %s::%s", s, quoteIdent(col.Name), col.TypeString())
+	}
+	if old.Generated != 0 {
+		switch {
+		case col.Generated == 0:
+			m.emit("%s DROP EXPRESSION", prefix)
+		case m.d.expr(old.Default) != m.d.expr(col.Default):
+			m.emit("%s SET EXPRESSION AS (%s)", prefix, m.d.expr(col.Default))
+		}
+	}
+	if oldDefault != newDefault {
+		if newDefault == "" {
+			m.emit("%s DROP DEFAULT", prefix)
+		} else {
+			m.emit("%s SET DEFAULT %s", prefix, newDefault)
+		}
+	}
+	if old.NotNull != col.NotNull {
+		if col.NotNull {
+			m.emit("%s SET NOT NULL", prefix)
+		} else if col.Identity == 0 {
+			m.emit("%s DROP NOT NULL", prefix)
+		}
+	}
+	switch {
+	case old.Identity == col.Identity:
+	case old.Identity == 0:
+		m.emit("%s ADD %s", prefix, identityClause(col.Identity))
+	case col.Identity == 0:
+		m.emit("%s DROP IDENTITY", prefix)
+		if !col.NotNull {
+			m.emit("%s DROP NOT NULL", prefix)
+		}
+	case col.Identity == 'a':
+		m.emit("%s SET GENERATED ALWAYS", prefix)
+	default:
+		m.emit("%s SET GENERATED BY DEFAULT", prefix)
+	}
+}
+
+// alterOptions emits ALTER TABLE SET and RESET for changed storage
+// parameters.
+func (m *differ) alterOptions(name string, old, options *nodes.List) {
+	render := func(l *nodes.List) map[string]string {
+		out := make(map[string]string)
+		for _, n := range items(l) {
+			if de, ok := n.(*nodes.DefElem); ok {
+				out[de.Defname] = m.d.defList(&nodes.List{Items: []nodes.Node{de}})
+			}
+		}
+		return out
+	}
+	was, now := render(old), render(options)
+	var set, reset []string
+	for _, n := range items(options) {
+		de := n.(*nodes.DefElem)
+		if was[de.Defname] != now[de.Defname] {
+			set = append(set, strings.Trim(now[de.Defname], "()"))
+		}
+	}
+	for _, n := range items(old) {
+		de := n.(*nodes.DefElem)
+		if _, ok := now[de.Defname]; !ok {
+			reset = append(reset, de.Defname)
+		}
+	}
+	if len(reset) > 0 {
+		m.emit("ALTER TABLE %s RESET (%s)", name, strings.Join(reset, ", "))
+	}
+	if len(set) > 0 {
+		m.emit("ALTER TABLE %s SET (%s)", name, strings.Join(set, ", "))
+	}
+}
+
+// addConstraints adds the constraints and indexes that are new, changed
+// or were dropped with their table; foreign keys are added in a second
+// pass once every key they reference exists.
+func (m *differ) addConstraints(foreign bool) {
+	for _, r := range relations(m.to) {
+		if !r.IsTable() {
+			continue
+		}
+		created := m.relationCreated(r)
+		for _, con := range r.Constraints {
+			if (con.Type == nodes.CONSTR_FOREIGN) != foreign || isInherited(con) {
+				continue
+			}
+			if created && inlineConstraint(con) {
+				continue
+			}
+			old := constraintIn(m.from, con)
+			switch {
+			case m.constraintCreated(con):
+				s := "ALTER TABLE " + r.QualifiedName() + " ADD CONSTRAINT " + quoteIdent(con.Name) + " " + m.d.constraintDef(con)
+				if !con.Validated {
+					s += " NOT VALID"
+				}
+				m.emit("%s", s)
+			case con.Validated && !old.Validated:
+				m.emit("ALTER TABLE %s VALIDATE CONSTRAINT %s", r.QualifiedName(), quoteIdent(con.Name))
+			}
+		}
+	}
+	if !foreign {
+		m.createIndexes(false)
+	}
+}
+
+// createIndexes creates the plain indexes that are new or changed, on
+// tables or, if views is set, on materialized views.
+func (m *differ) createIndexes(views bool) {
+	for _, r := range relations(m.to) {
+		if !isPlainIndex(r) || isView(r.Index.Table) != views {
+			continue
+		}
+		if m.relationCreated(r) || m.relationCreated(r.Index.Table) {
+			m.emit("%s", m.d.createIndex(r))
+		}
+	}
+}
+
+// ownSequences attaches sequences to their owning columns.
+func (m *differ) ownSequences() {
+	for _, r := range relations(m.to) {
+		if !isSequence(r) || r.Sequence.OwnedBy == nil {
+			continue
+		}
+		old := relationIn(m.from, r)
+		if old == nil || !isSequence(old) || m.implicitlyDropped(old) || m.released[old] || seqOwner(old) != seqOwner(r) {
+			m.emit("ALTER SEQUENCE %s OWNED BY %s", r.QualifiedName(), seqOwner(r))
+		}
+	}
+}
+
+// createViews creates new and recreated views, in dependency order, and
+// then the indexes of materialized views.
+func (m *differ) createViews() {
+	var views []*catalog.Relation
+	for _, r := range relations(m.to) {
+		if isView(r) && m.relationCreated(r) {
+			views = append(views, r)
+		}
+	}
+	for _, r := range toposort(views, relationDeps(m.to)) {
+		m.emit("%s", m.d.createView(r))
+	}
+	m.createIndexes(true)
+}
+
+// dropRemoved drops the routines, sequences, types and schemas that are
+// not in the target schema.
+func (m *differ) dropRemoved() {
+	for _, f := range functions(m.from) {
+		if functionIn(m.to, f) == nil {
+			m.emit("DROP %s %s", routineKeyword(f), funcIdentity(f))
+		}
+	}
+	for _, r := range relations(m.from) {
+		if isSequence(r) && relationIn(m.to, r) == nil && !m.implicitlyDropped(r) {
+			m.emit("DROP SEQUENCE %s", r.QualifiedName())
+		}
+	}
+	for _, t := range reversed(toposort(types(m.from), typeDeps(m.from))) {
+		switch {
+		case m.replaced[t]:
+			m.emit("DROP TYPE %s.%s", quoteIdent(t.Schema.Name), quoteIdent(oldTypeName(t)))
+		case typeIn(m.to, t) == nil && t.Kind == catalog.TypeKindDomain:
+			m.emit("DROP DOMAIN %s", t.QualifiedName())
+		case typeIn(m.to, t) == nil:
+			m.emit("DROP TYPE %s", t.QualifiedName())
+		}
+	}
+	for _, s := range m.from.Schemas() {
+		if !skipSchema(s) && schemaIn(m.to, s) == nil {
+			m.emit("DROP SCHEMA %s", quoteIdent(s.Name))
+		}
+	}
+}
+
+// comment emits COMMENT ON if the comment of an object differs from the
+// one it had, which is "" for created objects.
+func (m *differ) comment(target, old, comment string) {
+	if old == comment {
+		return
+	}
+	if comment == "" {
+		m.emit("COMMENT ON %s IS NULL", target)
+	} else {
+		m.emit("COMMENT ON %s IS %s", target, quoteLiteral(comment))
+	}
+}
+
+func (m *differ) comments() {
+	for _, s := range m.to.Schemas() {
+		if skipSchema(s) {
+			continue
+		}
+		old := ""
+		if os := schemaIn(m.from, s); os != nil {
+			old = os.Comment
+		}
+		m.comment("SCHEMA "+quoteIdent(s.Name), old, s.Comment)
+	}
+	for _, t := range types(m.to) {
+		old := ""
+		if ot := typeIn(m.from, t); ot != nil && !m.replaced[ot] {
+			old = ot.Comment
+		}
+		keyword := "TYPE "
+		if t.Kind == catalog.TypeKindDomain {
+			keyword = "DOMAIN "
+		}
+		m.comment(keyword+t.QualifiedName(), old, t.Comment)
+	}
+	for _, f := range functions(m.to) {
+		old := ""
+		if of := functionIn(m.from, f); of != nil && !m.recreated[of] {
+			old = of.Comment
+		}
+		m.comment(routineKeyword(f)+" "+funcIdentity(f), old, f.Comment)
+	}
+	for _, r := range relations(m.to) {
+		if r.Sequence != nil && r.Sequence.Identity {
+			continue
+		}
+		var old *catalog.Relation
+		if !m.relationCreated(r) {
+			old = relationIn(m.from, r)
+		}
+		oldComment := ""
+		if old != nil {
+			oldComment = old.Comment
+		}
+		m.comment(relationKeyword(r)+" "+r.QualifiedName(), oldComment, r.Comment)
+		for _, col := range r.Columns {
+			oldComment = ""
+			if old != nil {
+				if oc := old.Column(col.Name); oc != nil {
+					oldComment = oc.Comment
+				}
+			}
+			m.comment("COLUMN "+r.QualifiedName()+"."+quoteIdent(col.Name), oldComment, col.Comment)
+		}
+		for _, con := range r.Constraints {
+			oldComment = ""
+			if !m.constraintCreated(con) {
+				oldComment = constraintIn(m.from, con).Comment
+			}
+			m.comment("CONSTRAINT "+quoteIdent(con.Name)+" ON "+r.QualifiedName(), oldComment, con.Comment)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgparser/catalog"
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+)
+
+func TestDeparseRoundTrip(t *testing.T) {
+	queries := []string{
+		"SELECT a, b + 1 AS c FROM t WHERE a > 0 AND NOT b IS NULL ORDER BY 1 DESC NULLS LAST LIMIT 10",
+		"SELECT DISTINCT ON (a) a FROM t t1 JOIN u USING (id) LEFT JOIN LATERAL (SELECT 1) s ON true",
+		"SELECT count(*) FILTER (WHERE x) OVER (PARTITION BY a ORDER BY b ROWS BETWEEN 1 PRECEDING AND CURRENT ROW) FROM t",
+		"WITH RECURSIVE r (n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM r WHERE n < 5) SELECT * FROM r",
+		"SELECT CASE WHEN a LIKE 'x%' ESCAPE '!' THEN 1 ELSE -1 END, x::numeric(10,2), ARRAY[1, 2][1] FROM t",
+		"SELECT a FROM t WHERE a IN (SELECT b FROM u) OR a = ANY ('{1}'::int[]) OR EXISTS (SELECT 1) FOR UPDATE SKIP LOCKED",
+		"SELECT a, sum(b) FROM t GROUP BY ROLLUP (a), CUBE (b) HAVING sum(b) > 1",
+		"VALUES (1, 'a'), (2, 'b')",
+		"SELECT current_timestamp, coalesce(a, b), greatest(a, b), nullif(a, b), (a, b) = (1, 2) FROM t",
+	}
+	for _, q := range queries {
+		first := deparseQuery(t, q)
+		if second := deparseQuery(t, first); second != first {
+			t.Errorf("deparse not stable for %s:\n first: %s\nsecond: %s", q, first, second)
+		}
+	}
+}
+
+func deparseQuery(t *testing.T, sql string) string {
+	t.Helper()
+	list, err := parser.Parse(sql)
+	if err != nil {
+		t.Fatalf("Parse(%q) error: %v", sql, err)
+	}
+	var d deparser
+	s := d.selectStmt(list.Items[0].(*nodes.SelectStmt))
+	if d.err != nil {
+		t.Fatalf("deparse %q: %v", sql, d.err)
+	}
+	return s
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     []string
+	}{
+		{
+			"CREATE TABLE t (a int)",
+			"CREATE TABLE t (a int)",
+			nil,
+		},
+		{
+			"CREATE TABLE t (a int, b text)",
+			"CREATE TABLE t (a bigint NOT NULL, c text DEFAULT 'x')",
+			[]string{
+				"ALTER TABLE public.t DROP COLUMN b",
+				"ALTER TABLE public.t ALTER COLUMN a TYPE bigint USING a::bigint",
+				"ALTER TABLE public.t ALTER COLUMN a SET NOT NULL",
+				"ALTER TABLE public.t ADD COLUMN c text DEFAULT 'x'",
+			},
+		},
+		{
+			"CREATE TYPE mood AS ENUM ('sad', 'happy')",
+			"CREATE TYPE mood AS ENUM ('sad', 'ok', 'happy', 'great')",
+			[]string{
+				"ALTER TYPE public.mood ADD VALUE 'ok' BEFORE 'happy'",
+				"ALTER TYPE public.mood ADD VALUE 'great' AFTER 'happy'",
+			},
+		},
+		{
+			// A view on a column whose type changes is recreated.
+			"CREATE TABLE t (id int); CREATE VIEW v AS SELECT id FROM t",
+			"CREATE TABLE t (id bigint); CREATE VIEW v AS SELECT id FROM t",
+			[]string{
+				"DROP VIEW public.v",
+				"ALTER TABLE public.t ALTER COLUMN id TYPE bigint USING id::bigint",
+				"CREATE VIEW public.v (id) AS\nSELECT id FROM t",
+			},
+		},
+		{
+			// The foreign key goes before the key it references.
+			"CREATE TABLE t (id int PRIMARY KEY); CREATE TABLE u (tid int REFERENCES t)",
+			"CREATE TABLE t (id int UNIQUE); CREATE TABLE u (tid int REFERENCES t (id))",
+			[]string{
+				"ALTER TABLE public.u DROP CONSTRAINT u_tid_fkey",
+				"ALTER TABLE public.t DROP CONSTRAINT t_pkey",
+				"ALTER TABLE public.t ALTER COLUMN id DROP NOT NULL",
+				"ALTER TABLE public.t ADD CONSTRAINT t_id_key UNIQUE (id)",
+				"ALTER TABLE public.u ADD CONSTRAINT u_tid_fkey FOREIGN KEY (tid) REFERENCES public.t (id)",
+			},
+		},
+		{
+			"CREATE SCHEMA old; CREATE FUNCTION f(a int) RETURNS int LANGUAGE sql RETURN a",
+			"CREATE FUNCTION f(a int) RETURNS int LANGUAGE sql IMMUTABLE RETURN a; COMMENT ON FUNCTION f(int) IS 'identity'",
+			[]string{
+				"CREATE OR REPLACE FUNCTION public.f(a integer)\nRETURNS integer\nLANGUAGE sql\nIMMUTABLE\nRETURN a",
+				"DROP SCHEMA old",
+				"COMMENT ON FUNCTION public.f(integer) IS 'identity'",
+			},
+		},
+	}
+	for _, tt := range tests {
+		got, err := SQL(tt.from, tt.to)
+		if err != nil {
+			t.Errorf("SQL(%q, %q) error: %v", tt.from, tt.to, err)
+			continue
+		}
+		if strings.Join(got, ";\n") != strings.Join(tt.want, ";\n") {
+			t.Errorf("SQL(%q, %q) =\n%s\nwant\n%s", tt.from, tt.to, strings.Join(got, ";\n"), strings.Join(tt.want, ";\n"))
+		}
+	}
+}
+
+// TestMigrationConverges applies each generated migration to the source
+// schema and checks that nothing is left to migrate.
+func TestMigrationConverges(t *testing.T) {
+	tests := []struct{ from, to string }{
+		{
+			"CREATE TYPE e AS ENUM ('x', 'y'); CREATE TABLE t (id serial PRIMARY KEY, c e DEFAULT 'x'); CREATE VIEW v AS SELECT c FROM t",
+			"CREATE TYPE e AS ENUM ('y', 'x'); CREATE TABLE t (id bigint PRIMARY KEY, c e DEFAULT 'y'); CREATE VIEW v AS SELECT c FROM t",
+		},
+		{
+			"CREATE SCHEMA s; CREATE TABLE s.p (a int, b text) PARTITION BY RANGE (a); CREATE TABLE s.p1 PARTITION OF s.p FOR VALUES FROM (0) TO (10)",
+			"CREATE SCHEMA s; CREATE TABLE s.p (a int, b text) PARTITION BY LIST (a); CREATE TABLE s.p1 PARTITION OF s.p FOR VALUES IN (1, 2); CREATE TABLE s.p2 PARTITION OF s.p DEFAULT",
+		},
+		{
+			"CREATE TABLE par (x int CHECK (x > 0)); CREATE TABLE ch (y int) INHERITS (par); CREATE SEQUENCE s INCREMENT 2",
+			"CREATE TABLE par (x int CHECK (x > 0), z text); CREATE TABLE ch (y int) INHERITS (par); CREATE SEQUENCE s INCREMENT 3 CACHE 10",
+		},
+		{
+			`CREATE TABLE t (id int GENERATED ALWAYS AS IDENTITY, a int, g int GENERATED ALWAYS AS (a * 2) STORED);
+			 CREATE DOMAIN d AS int CHECK (VALUE > 0); CREATE TYPE c AS (x int, y text);
+			 CREATE MATERIALIZED VIEW mv AS SELECT a FROM t; CREATE INDEX mv_a ON mv (a);
+			 CREATE AGGREGATE mysum(int) (SFUNC = int4pl, STYPE = int)`,
+			`CREATE TABLE t (id int GENERATED BY DEFAULT AS IDENTITY, a bigint DEFAULT 1, g int GENERATED ALWAYS AS (a * 3) STORED) WITH (fillfactor = 70);
+			 CREATE DOMAIN d AS int DEFAULT 1 NOT NULL CHECK (VALUE > 1); CREATE TYPE c AS (x bigint, z text);
+			 CREATE MATERIALIZED VIEW mv AS SELECT a FROM t; CREATE INDEX mv_a ON mv (a);
+			 CREATE AGGREGATE mysum(int) (SFUNC = int4pl, STYPE = int, INITCOND = '0');
+			 COMMENT ON COLUMN t.a IS 'aa'`,
+		},
+		{
+			"CREATE TABLE a (id int PRIMARY KEY); CREATE TABLE b (aid int REFERENCES a, x int); CREATE UNIQUE INDEX bx ON b (x)",
+			`CREATE TABLE a (id int PRIMARY KEY, q int) PARTITION BY HASH (id); CREATE TABLE b (aid int REFERENCES a, x int);
+			 CREATE UNIQUE INDEX bx ON b (x) WHERE x > 0; CREATE VIEW vv AS SELECT * FROM b JOIN a ON a.id = b.aid`,
+		},
+	}
+	for _, tt := range tests {
+		stmts, err := SQL(tt.from, tt.to)
+		if err != nil {
+			t.Errorf("SQL(%q, %q) error: %v", tt.from, tt.to, err)
+			continue
+		}
+		c := catalog.New()
+		if err := c.Exec(tt.from); err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range stmts {
+			if err := c.Exec(s); err != nil {
+				t.Errorf("migration to %q: %s: %v", tt.to, s, err)
+			}
+		}
+		want := catalog.New()
+		if err := want.Exec(tt.to); err != nil {
+			t.Fatal(err)
+		}
+		if rest, err := Diff(c, want); err != nil || len(rest) > 0 {
+			t.Errorf("after migrating to %q, still differs: %q, %v", tt.to, rest, err)
+		}
+	}
+}
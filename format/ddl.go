@@ -0,0 +1,690 @@
+package format
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// createStmt returns the document for CREATE TABLE. Column and table
+// constraint definitions always go on separate lines; if the AlignColumns
+// option is set, column types and constraints start in aligned columns.
+func (p *printer) createStmt(s *nodes.CreateStmt) doc {
+	if s.OfTypename != nil {
+		return p.fail("cannot format CREATE TABLE OF")
+	}
+	out := concat{p.kw("CREATE ")}
+	switch s.Relation.Relpersistence {
+	case nodes.RELPERSISTENCE_TEMP:
+		out = append(out, p.kw("TEMPORARY "))
+	case nodes.RELPERSISTENCE_UNLOGGED:
+		out = append(out, p.kw("UNLOGGED "))
+	}
+	out = append(out, p.kw("TABLE "))
+	if s.IfNotExists {
+		out = append(out, p.kw("IF NOT EXISTS "))
+	}
+	out = append(out, text(rangeVar(s.Relation)))
+	if s.Partbound != nil {
+		parents := items(s.InhRelations)
+		if len(parents) != 1 || s.TableElts != nil {
+			return p.fail("malformed PARTITION OF")
+		}
+		out = append(out, p.kw(" PARTITION OF "), p.fromItem(parents[0]), text(" "), p.partitionBound(s.Partbound))
+	} else {
+		out = append(out, text(" "), p.tableElements(s.TableElts))
+		if s.InhRelations != nil {
+			var parents []doc
+			for _, n := range items(s.InhRelations) {
+				parents = append(parents, p.fromItem(n))
+			}
+			out = append(out, p.kw(" INHERITS "), p.parens(nil, parents))
+		}
+	}
+	if ps := s.Partspec; ps != nil {
+		strategy := map[nodes.PartitionStrategy]string{
+			nodes.PARTITION_STRATEGY_LIST:  "LIST",
+			nodes.PARTITION_STRATEGY_RANGE: "RANGE",
+			nodes.PARTITION_STRATEGY_HASH:  "HASH",
+		}[ps.Strategy]
+		if strategy == "" {
+			return p.fail("unknown partition strategy %q", ps.Strategy)
+		}
+		var keys []doc
+		for _, n := range items(ps.PartParams) {
+			pe, ok := n.(*nodes.PartitionElem)
+			if !ok {
+				return p.fail("malformed PARTITION BY")
+			}
+			var d concat
+			if pe.Expr != nil {
+				d = concat{text("("), p.expr(pe.Expr), text(")")}
+			} else {
+				d = concat{text(ident(pe.Name))}
+			}
+			if pe.Collation != nil {
+				d = append(d, p.kw(" COLLATE "), text(anyName(pe.Collation)))
+			}
+			if pe.Opclass != nil {
+				d = append(d, text(" "+anyName(pe.Opclass)))
+			}
+			keys = append(keys, d)
+		}
+		out = append(out, hardline, p.kw("PARTITION BY "+strategy+" "), p.parens(nil, keys))
+	}
+	if s.AccessMethod != "" {
+		out = append(out, hardline, p.kw("USING "), text(ident(s.AccessMethod)))
+	}
+	if s.Options != nil {
+		out = append(out, hardline, p.kw("WITH "), p.defElems(s.Options))
+	}
+	switch s.Oncommit {
+	case nodes.ONCOMMIT_PRESERVE_ROWS:
+		out = append(out, hardline, p.kw("ON COMMIT PRESERVE ROWS"))
+	case nodes.ONCOMMIT_DELETE_ROWS:
+		out = append(out, hardline, p.kw("ON COMMIT DELETE ROWS"))
+	case nodes.ONCOMMIT_DROP:
+		out = append(out, hardline, p.kw("ON COMMIT DROP"))
+	}
+	if s.Tablespacename != "" {
+		out = append(out, hardline, p.kw("TABLESPACE "), text(ident(s.Tablespacename)))
+	}
+	return out
+}
+
+// tableElements returns the parenthesized column and constraint
+// definitions of CREATE TABLE.
+func (p *printer) tableElements(l *nodes.List) doc {
+	elts := items(l)
+	if len(elts) == 0 {
+		return text("()")
+	}
+	type column struct {
+		name, typ string
+		rest      doc
+	}
+	var cols []*column
+	var nameWidth, typeWidth int
+	for _, n := range elts {
+		cd, ok := n.(*nodes.ColumnDef)
+		if !ok {
+			cols = append(cols, nil)
+			continue
+		}
+		c := &column{name: ident(cd.Colname), typ: render(p.typeName(cd.TypeName), 0), rest: p.columnQuals(cd)}
+		if c.rest != nil {
+			// Only columns with something after the type pad it.
+			typeWidth = max(typeWidth, utf8.RuneCountInString(c.typ))
+		}
+		nameWidth = max(nameWidth, utf8.RuneCountInString(c.name))
+		cols = append(cols, c)
+	}
+	var docs []doc
+	for i, n := range elts {
+		c := cols[i]
+		if c == nil {
+			docs = append(docs, p.constraint(n, false))
+			continue
+		}
+		pad := func(s string, width int) string {
+			if !p.opts.AlignColumns {
+				return s
+			}
+			return s + strings.Repeat(" ", width-utf8.RuneCountInString(s))
+		}
+		d := concat{text(pad(c.name, nameWidth) + " ")}
+		if c.rest != nil {
+			d = append(d, text(pad(c.typ, typeWidth)+" "), c.rest)
+		} else {
+			d = append(d, text(c.typ))
+		}
+		docs = append(docs, d)
+	}
+	sep := cat(text(","), hardline)
+	if p.opts.LeadingCommas {
+		sep = cat(hardline, text(", "))
+	}
+	return cat(text("("), indent(p.opts.IndentWidth, hardline, join(docs, sep)), hardline, text(")"))
+}
+
+// columnQuals returns what follows the type in a column definition, or
+// nil if nothing does.
+func (p *printer) columnQuals(cd *nodes.ColumnDef) doc {
+	if cd.Fdwoptions != nil || cd.RawDefault != nil || cd.IsNotNull || cd.Identity != 0 || cd.Generated != 0 {
+		return p.fail("cannot format column definition of %s", cd.Colname)
+	}
+	var quals []doc
+	if cd.StorageName != "" {
+		quals = append(quals, cat(p.kw("STORAGE "), text(ident(cd.StorageName))))
+	}
+	if cd.Compression != "" {
+		quals = append(quals, cat(p.kw("COMPRESSION "), text(ident(cd.Compression))))
+	}
+	if cd.CollClause != nil {
+		quals = append(quals, cat(p.kw("COLLATE "), text(anyName(cd.CollClause.Collname))))
+	}
+	for _, n := range items(cd.Constraints) {
+		quals = append(quals, p.constraint(n, true))
+	}
+	if len(quals) == 0 {
+		return nil
+	}
+	return join(quals, text(" "))
+}
+
+// constraint returns a column or table constraint.
+func (p *printer) constraint(n nodes.Node, column bool) doc {
+	c, ok := n.(*nodes.Constraint)
+	if !ok {
+		return p.fail("cannot format table element %T", n)
+	}
+	out := concat{}
+	if c.Conname != "" {
+		out = append(out, p.kw("CONSTRAINT "), text(ident(c.Conname)), text(" "))
+	}
+	keys := func(l *nodes.List) doc {
+		if l == nil {
+			return nil
+		}
+		return cat(text(" "), p.parens(nil, identDocs(l)))
+	}
+	switch c.Contype {
+	case nodes.CONSTR_NULL:
+		out = append(out, p.kw("NULL"))
+	case nodes.CONSTR_NOTNULL:
+		if c.Keys != nil {
+			return p.fail("cannot format table NOT NULL constraint")
+		}
+		out = append(out, p.kw("NOT NULL"))
+	case nodes.CONSTR_DEFAULT:
+		out = append(out, p.kw("DEFAULT "), p.bExpr(c.RawExpr))
+	case nodes.CONSTR_CHECK:
+		out = append(out, p.kw("CHECK "), text("("), p.expr(c.RawExpr), text(")"))
+		if c.IsNoInherit {
+			out = append(out, p.kw(" NO INHERIT"))
+		}
+	case nodes.CONSTR_PRIMARY, nodes.CONSTR_UNIQUE:
+		if c.Options != nil || c.Indexspace != "" || c.Indexname != "" {
+			return p.fail("cannot format index options of a constraint")
+		}
+		if c.Contype == nodes.CONSTR_PRIMARY {
+			out = append(out, p.kw("PRIMARY KEY"))
+		} else {
+			out = append(out, p.kw("UNIQUE"))
+			if c.NullsNotDistinct {
+				out = append(out, p.kw(" NULLS NOT DISTINCT"))
+			}
+		}
+		out = append(out, keys(c.Keys))
+		if c.Including != nil {
+			out = append(out, p.kw(" INCLUDE"), keys(c.Including))
+		}
+	case nodes.CONSTR_FOREIGN:
+		if !column {
+			out = append(out, p.kw("FOREIGN KEY"), keys(c.FkAttrs), text(" "))
+		}
+		out = append(out, p.kw("REFERENCES "), text(rangeVar(c.Pktable)), keys(c.PkAttrs))
+		switch c.FkMatchtype {
+		case 'f':
+			out = append(out, p.kw(" MATCH FULL"))
+		case 'p':
+			out = append(out, p.kw(" MATCH PARTIAL"))
+		}
+		actions := map[byte]string{'r': "RESTRICT", 'c': "CASCADE", 'n': "SET NULL", 'd': "SET DEFAULT"}
+		if a := actions[c.FkUpdAction]; a != "" {
+			out = append(out, p.kw(" ON UPDATE "+a))
+		}
+		if a := actions[c.FkDelAction]; a != "" {
+			out = append(out, p.kw(" ON DELETE "+a), keys(c.FkDelSetCols))
+		}
+	case nodes.CONSTR_GENERATED:
+		if c.GeneratedWhen != 'a' {
+			return p.fail("malformed generated column")
+		}
+		out = append(out, p.kw("GENERATED ALWAYS AS "), text("("), p.expr(c.RawExpr), text(")"), p.kw(" STORED"))
+	case nodes.CONSTR_IDENTITY:
+		if c.Options != nil {
+			return p.fail("cannot format identity sequence options")
+		}
+		if c.GeneratedWhen == 'a' {
+			out = append(out, p.kw("GENERATED ALWAYS AS IDENTITY"))
+		} else {
+			out = append(out, p.kw("GENERATED BY DEFAULT AS IDENTITY"))
+		}
+	case nodes.CONSTR_ATTR_DEFERRABLE:
+		out = append(out, p.kw("DEFERRABLE"))
+	case nodes.CONSTR_ATTR_NOT_DEFERRABLE:
+		out = append(out, p.kw("NOT DEFERRABLE"))
+	case nodes.CONSTR_ATTR_DEFERRED:
+		out = append(out, p.kw("INITIALLY DEFERRED"))
+	case nodes.CONSTR_ATTR_IMMEDIATE:
+		out = append(out, p.kw("INITIALLY IMMEDIATE"))
+	default:
+		return p.fail("cannot format constraint type %d", c.Contype)
+	}
+	if c.Deferrable {
+		out = append(out, p.kw(" DEFERRABLE"))
+	}
+	if c.Initdeferred {
+		out = append(out, p.kw(" INITIALLY DEFERRED"))
+	}
+	if c.SkipValidation {
+		out = append(out, p.kw(" NOT VALID"))
+	}
+	return out
+}
+
+// bExpr returns an expression in a position where the grammar only
+// accepts b_expr, which has no boolean, IS, LIKE, AT or COLLATE operators.
+func (p *printer) bExpr(n nodes.Node) doc {
+	d, prec := p.exprPrec(n)
+	if prec < precOp || prec == precAt || prec == precCollate {
+		return cat(text("("), d, text(")"))
+	}
+	return d
+}
+
+func (p *printer) partitionBound(n nodes.Node) doc {
+	b, ok := n.(*nodes.PartitionBoundSpec)
+	if !ok {
+		return p.fail("malformed partition bound")
+	}
+	if b.IsDefault {
+		return p.kw("DEFAULT")
+	}
+	switch b.Strategy {
+	case 'l':
+		return cat(p.kw("FOR VALUES IN "), p.parens(nil, p.exprList(b.Listdatums)))
+	case 'r':
+		return cat(p.kw("FOR VALUES FROM "), p.parens(nil, p.exprList(b.Lowerdatums)),
+			p.kw(" TO "), p.parens(nil, p.exprList(b.Upperdatums)))
+	case 'h':
+		return cat(p.kw("FOR VALUES WITH "), text("("), p.kw("MODULUS "), text(strconv.Itoa(b.Modulus)),
+			text(", "), p.kw("REMAINDER "), text(strconv.Itoa(b.Remainder)), text(")"))
+	}
+	return p.fail("unknown partition strategy %q", b.Strategy)
+}
+
+// defElems returns a parenthesized list of name = value options, as in a
+// WITH clause.
+func (p *printer) defElems(l *nodes.List) doc {
+	var docs []doc
+	for _, n := range items(l) {
+		d, ok := n.(*nodes.DefElem)
+		if !ok || d.Defaction != 0 {
+			return p.fail("malformed option list")
+		}
+		name := label(d.Defname)
+		if d.Defnamespace != "" {
+			name = label(d.Defnamespace) + "." + name
+		}
+		var arg doc
+		switch v := d.Arg.(type) {
+		case nil:
+			docs = append(docs, text(name))
+			continue
+		case *nodes.Integer, *nodes.Float, *nodes.String:
+			arg, _ = p.value(v)
+		case *nodes.TypeName:
+			arg = p.typeName(v)
+		default:
+			return p.fail("cannot format option value %T", d.Arg)
+		}
+		docs = append(docs, cat(text(name+" = "), arg))
+	}
+	return p.parens(nil, docs)
+}
+
+func (p *printer) viewStmt(s *nodes.ViewStmt, br doc) doc {
+	out := concat{p.kw("CREATE ")}
+	if s.Replace {
+		out = append(out, p.kw("OR REPLACE "))
+	}
+	switch s.View.Relpersistence {
+	case nodes.RELPERSISTENCE_TEMP:
+		out = append(out, p.kw("TEMPORARY "))
+	case nodes.RELPERSISTENCE_UNLOGGED:
+		return p.fail("malformed view persistence")
+	}
+	out = append(out, p.kw("VIEW "), text(rangeVar(s.View)))
+	if s.Aliases != nil {
+		out = append(out, text(" "), p.parens(nil, identDocs(s.Aliases)))
+	}
+	if s.Options != nil {
+		out = append(out, p.kw(" WITH "), p.defElems(s.Options))
+	}
+	out = append(out, p.kw(" AS"), br, p.stmt(s.Query, br == hardline))
+	switch s.WithCheckOption {
+	case 1:
+		out = append(out, br, p.kw("WITH LOCAL CHECK OPTION"))
+	case 2:
+		out = append(out, br, p.kw("WITH CASCADED CHECK OPTION"))
+	}
+	return out
+}
+
+func (p *printer) indexStmt(s *nodes.IndexStmt) doc {
+	if s.ExcludeOpNames != nil || s.Primary || s.Isconstraint {
+		return p.fail("malformed CREATE INDEX")
+	}
+	out := concat{p.kw("CREATE ")}
+	if s.Unique {
+		out = append(out, p.kw("UNIQUE "))
+	}
+	out = append(out, p.kw("INDEX "))
+	if s.Concurrent {
+		out = append(out, p.kw("CONCURRENTLY "))
+	}
+	if s.IfNotExists {
+		out = append(out, p.kw("IF NOT EXISTS "))
+	}
+	if s.Idxname != "" {
+		out = append(out, text(ident(s.Idxname)), text(" "))
+	}
+	out = append(out, p.kw("ON "), p.fromItem(s.Relation))
+	if s.AccessMethod != "" && s.AccessMethod != "btree" {
+		out = append(out, p.kw(" USING "), text(ident(s.AccessMethod)))
+	}
+	out = append(out, text(" "), p.parens(nil, p.indexElems(s.IndexParams)))
+	var tail []doc
+	if s.IndexIncludingParams != nil {
+		tail = append(tail, p.parens(p.kw("INCLUDE "), p.indexElems(s.IndexIncludingParams)))
+	}
+	if s.NullsNotDistinct {
+		tail = append(tail, p.kw("NULLS NOT DISTINCT"))
+	}
+	if s.Options != nil {
+		tail = append(tail, cat(p.kw("WITH "), p.defElems(s.Options)))
+	}
+	if s.TableSpace != "" {
+		tail = append(tail, cat(p.kw("TABLESPACE "), text(ident(s.TableSpace))))
+	}
+	if s.WhereClause != nil {
+		tail = append(tail, p.where(p.kw("WHERE"), s.WhereClause))
+	}
+	for _, d := range tail {
+		out = append(out, space, d)
+	}
+	return grp(out)
+}
+
+// indexElems returns the columns and expressions of an index or of an ON
+// CONFLICT inference clause.
+func (p *printer) indexElems(l *nodes.List) []doc {
+	var docs []doc
+	for _, n := range items(l) {
+		e, ok := n.(*nodes.IndexElem)
+		if !ok || e.Opclassopts != nil {
+			return []doc{p.fail("malformed index element")}
+		}
+		var d concat
+		switch fc, _ := e.Expr.(*nodes.FuncCall); {
+		case e.Expr == nil:
+			d = concat{text(ident(e.Name))}
+		case fc != nil && fc.Funcformat == nodes.COERCE_EXPLICIT_CALL && fc.Over == nil && fc.AggFilter == nil && !fc.AggWithinGroup:
+			d = concat{p.expr(fc)}
+		default:
+			d = concat{text("("), p.expr(e.Expr), text(")")}
+		}
+		if e.Collation != nil {
+			d = append(d, p.kw(" COLLATE "), text(anyName(e.Collation)))
+		}
+		if e.Opclass != nil {
+			d = append(d, text(" "+anyName(e.Opclass)))
+		}
+		switch e.Ordering {
+		case nodes.SORTBY_ASC:
+			d = append(d, p.kw(" ASC"))
+		case nodes.SORTBY_DESC:
+			d = append(d, p.kw(" DESC"))
+		}
+		switch e.NullsOrdering {
+		case nodes.SORTBY_NULLS_FIRST:
+			d = append(d, p.kw(" NULLS FIRST"))
+		case nodes.SORTBY_NULLS_LAST:
+			d = append(d, p.kw(" NULLS LAST"))
+		}
+		docs = append(docs, d)
+	}
+	return docs
+}
+
+// alterObjectTypes are the keywords of the relation kinds ALTER TABLE and
+// its siblings alter.
+var alterObjectTypes = map[nodes.ObjectType]string{
+	nodes.OBJECT_TABLE:         "TABLE",
+	nodes.OBJECT_FOREIGN_TABLE: "FOREIGN TABLE",
+	nodes.OBJECT_INDEX:         "INDEX",
+	nodes.OBJECT_SEQUENCE:      "SEQUENCE",
+	nodes.OBJECT_VIEW:          "VIEW",
+	nodes.OBJECT_MATVIEW:       "MATERIALIZED VIEW",
+}
+
+// alterTableStmt returns ALTER TABLE, with each subcommand on its own line
+// if they do not all fit on one.
+func (p *printer) alterTableStmt(s *nodes.AlterTableStmt) doc {
+	kind := alterObjectTypes[s.Objtype]
+	if kind == "" {
+		return p.fail("cannot format ALTER of object type %d", s.Objtype)
+	}
+	out := concat{p.kw("ALTER " + kind + " ")}
+	if s.MissingOk {
+		out = append(out, p.kw("IF EXISTS "))
+	}
+	if s.Objtype == nodes.OBJECT_TABLE || s.Objtype == nodes.OBJECT_FOREIGN_TABLE {
+		out = append(out, p.fromItem(s.Relation))
+	} else {
+		out = append(out, text(rangeVar(s.Relation)))
+	}
+	var cmds []doc
+	for _, n := range items(s.Cmds) {
+		cmd, ok := n.(*nodes.AlterTableCmd)
+		if !ok {
+			return p.fail("malformed ALTER TABLE")
+		}
+		cmds = append(cmds, p.alterTableCmd(cmd))
+	}
+	return grp(out, indent(p.opts.IndentWidth, space, join(cmds, p.comma())))
+}
+
+func (p *printer) alterTableCmd(c *nodes.AlterTableCmd) doc {
+	column := cat(p.kw("ALTER COLUMN "), text(ident(c.Name)), text(" "))
+	ifExists := func() doc {
+		if c.MissingOk {
+			return p.kw("IF EXISTS ")
+		}
+		return nil
+	}
+	switch c.Subtype {
+	case nodes.AT_AddColumn:
+		cd, ok := c.Def.(*nodes.ColumnDef)
+		if !ok {
+			return p.fail("malformed ADD COLUMN")
+		}
+		out := concat{p.kw("ADD COLUMN ")}
+		if c.MissingOk {
+			out = append(out, p.kw("IF NOT EXISTS "))
+		}
+		out = append(out, text(ident(cd.Colname)+" "), p.typeName(cd.TypeName))
+		if quals := p.columnQuals(cd); quals != nil {
+			out = append(out, text(" "), quals)
+		}
+		return out
+	case nodes.AT_DropColumn:
+		return cat(p.kw("DROP COLUMN "), ifExists(), text(ident(c.Name)), p.behavior(c.Behavior))
+	case nodes.AT_ColumnDefault:
+		if c.Def == nil {
+			return cat(column, p.kw("DROP DEFAULT"))
+		}
+		return cat(column, p.kw("SET DEFAULT "), p.expr(c.Def))
+	case nodes.AT_SetNotNull:
+		return cat(column, p.kw("SET NOT NULL"))
+	case nodes.AT_DropNotNull:
+		return cat(column, p.kw("DROP NOT NULL"))
+	case nodes.AT_AlterColumnType:
+		cd, ok := c.Def.(*nodes.ColumnDef)
+		if !ok {
+			return p.fail("malformed ALTER COLUMN TYPE")
+		}
+		out := concat{column, p.kw("TYPE "), p.typeName(cd.TypeName)}
+		if cd.CollClause != nil {
+			out = append(out, p.kw(" COLLATE "), text(anyName(cd.CollClause.Collname)))
+		}
+		if cd.RawDefault != nil {
+			out = append(out, p.kw(" USING "), p.expr(cd.RawDefault))
+		}
+		return out
+	case nodes.AT_SetStatistics:
+		if c.Name == "" {
+			return p.fail("cannot format SET STATISTICS of a column number")
+		}
+		target, _ := p.value(c.Def)
+		return cat(column, p.kw("SET STATISTICS "), target)
+	case nodes.AT_SetStorage, nodes.AT_SetCompression:
+		s, ok := c.Def.(*nodes.String)
+		if !ok {
+			return p.fail("malformed ALTER COLUMN")
+		}
+		kw := "SET STORAGE "
+		if c.Subtype == nodes.AT_SetCompression {
+			kw = "SET COMPRESSION "
+		}
+		if s.Sval == "default" {
+			return cat(column, p.kw(kw+"DEFAULT"))
+		}
+		return cat(column, p.kw(kw), text(ident(s.Sval)))
+	case nodes.AT_SetExpression:
+		return cat(column, p.kw("SET EXPRESSION AS "), text("("), p.expr(c.Def), text(")"))
+	case nodes.AT_SetOptions:
+		return cat(column, p.kw("SET "), p.defElems(asList(c.Def)))
+	case nodes.AT_ResetOptions:
+		return cat(column, p.kw("RESET "), p.defElems(asList(c.Def)))
+	case nodes.AT_DropExpression, nodes.AT_DropIdentity:
+		kw := "DROP EXPRESSION"
+		if c.Subtype == nodes.AT_DropIdentity {
+			kw = "DROP IDENTITY"
+		}
+		if c.MissingOk {
+			kw += " IF EXISTS"
+		}
+		return cat(column, p.kw(kw))
+	case nodes.AT_AddConstraint:
+		return cat(p.kw("ADD "), p.constraint(c.Def, false))
+	case nodes.AT_DropConstraint:
+		return cat(p.kw("DROP CONSTRAINT "), ifExists(), text(ident(c.Name)), p.behavior(c.Behavior))
+	case nodes.AT_ValidateConstraint:
+		return cat(p.kw("VALIDATE CONSTRAINT "), text(ident(c.Name)))
+	case nodes.AT_ChangeOwner:
+		return cat(p.kw("OWNER TO "), p.roleSpec(c.Newowner))
+	case nodes.AT_AddInherit, nodes.AT_DropInherit:
+		rv, ok := c.Def.(*nodes.RangeVar)
+		if !ok {
+			return p.fail("malformed INHERIT")
+		}
+		kw := "INHERIT "
+		if c.Subtype == nodes.AT_DropInherit {
+			kw = "NO INHERIT "
+		}
+		return cat(p.kw(kw), text(rangeVar(rv)))
+	case nodes.AT_AttachPartition, nodes.AT_DetachPartition, nodes.AT_DetachPartitionFinalize:
+		pc, ok := c.Def.(*nodes.PartitionCmd)
+		if !ok || pc.Name == nil {
+			return p.fail("malformed partition command")
+		}
+		name := text(rangeVar(pc.Name))
+		switch {
+		case c.Subtype == nodes.AT_DetachPartitionFinalize:
+			return cat(p.kw("DETACH PARTITION "), name, p.kw(" FINALIZE"))
+		case c.Subtype == nodes.AT_DetachPartition && pc.Concurrent:
+			return cat(p.kw("DETACH PARTITION "), name, p.kw(" CONCURRENTLY"))
+		case c.Subtype == nodes.AT_DetachPartition:
+			return cat(p.kw("DETACH PARTITION "), name)
+		case pc.Bound == nil:
+			return cat(p.kw("ATTACH PARTITION "), name)
+		}
+		return cat(p.kw("ATTACH PARTITION "), name, text(" "), p.partitionBound(pc.Bound))
+	case nodes.AT_SetRelOptions:
+		return cat(p.kw("SET "), p.defElems(asList(c.Def)))
+	case nodes.AT_ResetRelOptions:
+		return cat(p.kw("RESET "), p.defElems(asList(c.Def)))
+	case nodes.AT_ReplicaIdentity:
+		ri, ok := c.Def.(*nodes.ReplicaIdentityStmt)
+		if !ok {
+			return p.fail("malformed REPLICA IDENTITY")
+		}
+		switch ri.IdentityType {
+		case 'd':
+			return p.kw("REPLICA IDENTITY DEFAULT")
+		case 'f':
+			return p.kw("REPLICA IDENTITY FULL")
+		case 'n':
+			return p.kw("REPLICA IDENTITY NOTHING")
+		case 'i':
+			return cat(p.kw("REPLICA IDENTITY USING INDEX "), text(ident(ri.Name)))
+		}
+		return p.fail("unknown replica identity %q", ri.IdentityType)
+	case nodes.AT_SetTableSpace:
+		return cat(p.kw("SET TABLESPACE "), text(ident(c.Name)))
+	case nodes.AT_ClusterOn:
+		return cat(p.kw("CLUSTER ON "), text(ident(c.Name)))
+	case nodes.AT_SetAccessMethod:
+		if c.Name == "" {
+			return p.kw("SET ACCESS METHOD DEFAULT")
+		}
+		return cat(p.kw("SET ACCESS METHOD "), text(ident(c.Name)))
+	}
+	if kw := alterTableFlags[c.Subtype]; kw != "" {
+		if c.Name != "" {
+			return cat(p.kw(kw+" "), text(ident(c.Name)))
+		}
+		return p.kw(kw)
+	}
+	return p.fail("cannot format ALTER TABLE subcommand %d", c.Subtype)
+}
+
+// alterTableFlags are the keywords of the ALTER TABLE subcommands that
+// take at most a name.
+var alterTableFlags = map[nodes.AlterTableType]string{
+	nodes.AT_EnableTrig:         "ENABLE TRIGGER",
+	nodes.AT_EnableAlwaysTrig:   "ENABLE ALWAYS TRIGGER",
+	nodes.AT_EnableReplicaTrig:  "ENABLE REPLICA TRIGGER",
+	nodes.AT_DisableTrig:        "DISABLE TRIGGER",
+	nodes.AT_EnableTrigAll:      "ENABLE TRIGGER ALL",
+	nodes.AT_DisableTrigAll:     "DISABLE TRIGGER ALL",
+	nodes.AT_EnableTrigUser:     "ENABLE TRIGGER USER",
+	nodes.AT_DisableTrigUser:    "DISABLE TRIGGER USER",
+	nodes.AT_EnableRule:         "ENABLE RULE",
+	nodes.AT_EnableAlwaysRule:   "ENABLE ALWAYS RULE",
+	nodes.AT_EnableReplicaRule:  "ENABLE REPLICA RULE",
+	nodes.AT_DisableRule:        "DISABLE RULE",
+	nodes.AT_EnableRowSecurity:  "ENABLE ROW LEVEL SECURITY",
+	nodes.AT_DisableRowSecurity: "DISABLE ROW LEVEL SECURITY",
+	nodes.AT_ForceRowSecurity:   "FORCE ROW LEVEL SECURITY",
+	nodes.AT_NoForceRowSecurity: "NO FORCE ROW LEVEL SECURITY",
+	nodes.AT_DropCluster:        "SET WITHOUT CLUSTER",
+	nodes.AT_SetLogged:          "SET LOGGED",
+	nodes.AT_SetUnLogged:        "SET UNLOGGED",
+	nodes.AT_DropOf:             "NOT OF",
+}
+
+// roleSpec returns a role name or one of the role keywords.
+func (p *printer) roleSpec(r *nodes.RoleSpec) doc {
+	if r == nil {
+		return p.fail("missing role")
+	}
+	switch r.Roletype {
+	case nodes.ROLESPEC_CSTRING:
+		return text(ident(r.Rolename))
+	case nodes.ROLESPEC_CURRENT_ROLE:
+		return p.kw("CURRENT_ROLE")
+	case nodes.ROLESPEC_CURRENT_USER:
+		return p.kw("CURRENT_USER")
+	case nodes.ROLESPEC_SESSION_USER:
+		return p.kw("SESSION_USER")
+	case nodes.ROLESPEC_PUBLIC:
+		return p.kw("PUBLIC")
+	}
+	return p.fail("unknown role type %d", r.Roletype)
+}
@@ -0,0 +1,214 @@
+package format
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// doc is a document in the layout algebra of Wadler's "A prettier
+// printer": text, possible line breaks, nesting and groups. A group is
+// laid out on one line if it fits in the remaining width, and with all
+// its own line breaks taken otherwise.
+type doc interface{}
+
+// text is literal text. It is kept on one line unless it holds newlines
+// itself, as multi-line string constants do.
+type text string
+
+// line is a possible line break. Outside a broken group it renders as a
+// space, or as nothing if soft; a hard line always breaks, and breaks
+// every group containing it.
+type line struct {
+	soft, hard bool
+}
+
+var (
+	space    = line{}
+	softline = line{soft: true}
+	hardline = line{hard: true}
+)
+
+// concat is a sequence of documents.
+type concat []doc
+
+// nest indents the lines broken within a document.
+type nest struct {
+	indent int
+	doc    doc
+}
+
+// group marks a document to be laid out flat if it fits.
+type group struct {
+	doc  doc
+	hard int8 // 0 unknown, 1 contains a hard line, -1 does not
+}
+
+// ifBreak renders as broken inside a broken group and as flat otherwise.
+type ifBreak struct {
+	broken, flat doc
+}
+
+func cat(docs ...doc) doc {
+	return concat(docs)
+}
+
+func grp(docs ...doc) doc {
+	return &group{doc: concat(docs)}
+}
+
+func indent(n int, docs ...doc) doc {
+	return nest{indent: n, doc: concat(docs)}
+}
+
+// join places sep between the documents.
+func join(docs []doc, sep doc) doc {
+	out := make(concat, 0, 2*len(docs))
+	for i, d := range docs {
+		if i > 0 {
+			out = append(out, sep)
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+// hasHardLine reports whether d holds a hard line outside a nested group
+// that has already been found to hold one.
+func hasHardLine(d doc) bool {
+	switch v := d.(type) {
+	case line:
+		return v.hard
+	case concat:
+		for _, c := range v {
+			if hasHardLine(c) {
+				return true
+			}
+		}
+	case nest:
+		return hasHardLine(v.doc)
+	case *group:
+		if v.hard == 0 {
+			v.hard = -1
+			if hasHardLine(v.doc) {
+				v.hard = 1
+			}
+		}
+		return v.hard > 0
+	case ifBreak:
+		return hasHardLine(v.broken)
+	}
+	return false
+}
+
+// cmd is a document to lay out, with the indentation and mode in force.
+type cmd struct {
+	indent int
+	flat   bool
+	doc    doc
+}
+
+// render lays out d for the given line width.
+func render(d doc, width int) string {
+	var b strings.Builder
+	col := 0
+	pending := -1 // indentation owed to the current line, written lazily
+	stack := []cmd{{doc: d}}
+	for len(stack) > 0 {
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		switch v := c.doc.(type) {
+		case nil:
+		case text:
+			if v == "" {
+				continue
+			}
+			if pending >= 0 {
+				b.WriteString(strings.Repeat(" ", pending))
+				pending = -1
+			}
+			b.WriteString(string(v))
+			if i := strings.LastIndexByte(string(v), '\n'); i >= 0 {
+				col = utf8.RuneCountInString(string(v[i+1:]))
+			} else {
+				col += utf8.RuneCountInString(string(v))
+			}
+		case concat:
+			for i := len(v) - 1; i >= 0; i-- {
+				stack = append(stack, cmd{c.indent, c.flat, v[i]})
+			}
+		case nest:
+			stack = append(stack, cmd{c.indent + v.indent, c.flat, v.doc})
+		case *group:
+			flat := c.flat
+			if !flat && !hasHardLine(v) {
+				flat = fits(width-col, cmd{c.indent, true, v.doc}, stack)
+			}
+			stack = append(stack, cmd{c.indent, flat, v.doc})
+		case ifBreak:
+			if c.flat {
+				stack = append(stack, cmd{c.indent, c.flat, v.flat})
+			} else {
+				stack = append(stack, cmd{c.indent, c.flat, v.broken})
+			}
+		case line:
+			if c.flat && !v.hard {
+				if !v.soft {
+					b.WriteByte(' ')
+					col++
+				}
+				continue
+			}
+			b.WriteByte('\n')
+			pending, col = c.indent, c.indent
+		}
+	}
+	return b.String()
+}
+
+// fits reports whether next, laid out flat, and what follows it up to the
+// next line break fit in width columns.
+func fits(width int, next cmd, rest []cmd) bool {
+	stack := []cmd{next}
+	for i := len(rest); width >= 0; {
+		if len(stack) == 0 {
+			if i == 0 {
+				return true
+			}
+			i--
+			stack = append(stack, rest[i])
+			continue
+		}
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		switch v := c.doc.(type) {
+		case text:
+			s := string(v)
+			if j := strings.IndexByte(s, '\n'); j >= 0 {
+				return width >= utf8.RuneCountInString(s[:j])
+			}
+			width -= utf8.RuneCountInString(s)
+		case concat:
+			for j := len(v) - 1; j >= 0; j-- {
+				stack = append(stack, cmd{c.indent, c.flat, v[j]})
+			}
+		case nest:
+			stack = append(stack, cmd{c.indent + v.indent, c.flat, v.doc})
+		case *group:
+			stack = append(stack, cmd{c.indent, c.flat, v.doc})
+		case ifBreak:
+			if c.flat {
+				stack = append(stack, cmd{c.indent, c.flat, v.flat})
+			} else {
+				stack = append(stack, cmd{c.indent, c.flat, v.broken})
+			}
+		case line:
+			if !c.flat || v.hard {
+				return true
+			}
+			if !v.soft {
+				width--
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,875 @@
+package format
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+)
+
+// Operator precedence levels, from loosest to tightest binding, following
+// the precedence declarations of gram.y. An operand is parenthesized if
+// its level is below the one its position requires.
+const (
+	precOr = iota + 1
+	precAnd
+	precNot
+	precIs      // IS, ISNULL, NOTNULL
+	precCmp     // < > = <= >= <>
+	precLike    // BETWEEN IN LIKE ILIKE SIMILAR
+	precOp      // any other operator
+	precAdd     // + -
+	precMul     // * / %
+	precExp     // ^
+	precAt      // AT TIME ZONE
+	precCollate // COLLATE
+	precUnary   // unary minus
+	precCast    // ::
+	precAtom
+)
+
+// binaryPrec returns the precedence of a binary operator.
+func binaryPrec(name *nodes.List) int {
+	if len(items(name)) != 1 {
+		return precOp
+	}
+	switch operatorName(name) {
+	case "+", "-":
+		return precAdd
+	case "*", "/", "%":
+		return precMul
+	case "^":
+		return precExp
+	case "<", ">", "=", "<=", ">=", "<>":
+		return precCmp
+	}
+	return precOp
+}
+
+// expr returns the document for an expression.
+func (p *printer) expr(n nodes.Node) doc {
+	d, _ := p.exprPrec(n)
+	return d
+}
+
+// operand returns the document for an expression in a position requiring
+// precedence min, parenthesizing it if it binds more loosely.
+func (p *printer) operand(n nodes.Node, min int) doc {
+	d, prec := p.exprPrec(n)
+	if prec < min {
+		return cat(text("("), d, text(")"))
+	}
+	return d
+}
+
+// exprList returns the documents for a list of expressions.
+func (p *printer) exprList(l *nodes.List) []doc {
+	var docs []doc
+	for _, n := range items(l) {
+		docs = append(docs, p.expr(n))
+	}
+	return docs
+}
+
+// parens returns a parenthesized, comma-separated list that breaks onto
+// indented lines if it does not fit.
+func (p *printer) parens(prefix doc, docs []doc) doc {
+	if len(docs) == 0 {
+		return cat(prefix, text("()"))
+	}
+	return grp(prefix, text("("), indent(p.opts.IndentWidth, softline, join(docs, cat(text(","), space))), softline, text(")"))
+}
+
+// exprPrec returns the document for an expression and its precedence.
+func (p *printer) exprPrec(n nodes.Node) (doc, int) {
+	switch v := n.(type) {
+	case *nodes.ColumnRef:
+		var parts []string
+		for i, f := range items(v.Fields) {
+			switch f := f.(type) {
+			case *nodes.String:
+				if i == 0 {
+					parts = append(parts, ident(f.Sval))
+				} else {
+					parts = append(parts, label(f.Sval))
+				}
+			case *nodes.A_Star:
+				parts = append(parts, "*")
+			}
+		}
+		return text(strings.Join(parts, ".")), precAtom
+	case *nodes.ParamRef:
+		return text("$" + strconv.Itoa(v.Number)), precAtom
+	case *nodes.A_Const:
+		if v.Isnull {
+			return p.kw("NULL"), precAtom
+		}
+		return p.value(v.Val)
+	case *nodes.TypeCast:
+		return cat(p.operand(v.Arg, precCast), text("::"), p.typeName(v.TypeName)), precCast
+	case *nodes.CollateClause:
+		return cat(p.operand(v.Arg, precCollate), p.kw(" COLLATE "), text(anyName(v.Collname))), precCollate
+	case *nodes.A_Expr:
+		return p.aExpr(v)
+	case *nodes.BoolExpr:
+		return p.boolExpr(v, false)
+	case *nodes.NullTest:
+		test := " IS NULL"
+		if v.Nulltesttype == nodes.IS_NOT_NULL {
+			test = " IS NOT NULL"
+		}
+		return cat(p.operand(v.Arg, precIs+1), p.kw(test)), precIs
+	case *nodes.BooleanTest:
+		tests := []string{" IS TRUE", " IS NOT TRUE", " IS FALSE", " IS NOT FALSE", " IS UNKNOWN", " IS NOT UNKNOWN"}
+		if int(v.Booltesttype) >= len(tests) {
+			return p.fail("unknown boolean test %d", v.Booltesttype), precAtom
+		}
+		return cat(p.operand(v.Arg, precIs+1), p.kw(tests[v.Booltesttype])), precIs
+	case *nodes.FuncCall:
+		return p.funcCall(v)
+	case *nodes.NamedArgExpr:
+		return cat(text(funcName(v.Name)), text(" => "), p.expr(v.Arg)), precAtom
+	case *nodes.CaseExpr:
+		return p.caseExpr(v), precAtom
+	case *nodes.CoalesceExpr:
+		return p.parens(p.kw("COALESCE"), p.exprList(v.Args)), precAtom
+	case *nodes.MinMaxExpr:
+		name := "GREATEST"
+		if v.Op == nodes.IS_LEAST {
+			name = "LEAST"
+		}
+		return p.parens(p.kw(name), p.exprList(v.Args)), precAtom
+	case *nodes.RowExpr:
+		if v.RowFormat == nodes.COERCE_IMPLICIT_CAST && len(items(v.Args)) > 1 {
+			return p.parens(nil, p.exprList(v.Args)), precAtom
+		}
+		return p.parens(p.kw("ROW"), p.exprList(v.Args)), precAtom
+	case *nodes.A_ArrayExpr:
+		return cat(p.kw("ARRAY"), p.array(v)), precAtom
+	case *nodes.A_Indirection:
+		var arg doc
+		first := items(v.Indirection)
+		_, isRef := v.Arg.(*nodes.ParamRef)
+		if _, ok := v.Arg.(*nodes.ColumnRef); ok && len(first) > 0 {
+			_, isRef = first[0].(*nodes.A_Indices)
+		}
+		if isRef {
+			arg = p.expr(v.Arg)
+		} else {
+			arg = cat(text("("), p.expr(v.Arg), text(")"))
+		}
+		out := concat{arg}
+		for _, ind := range items(v.Indirection) {
+			out = append(out, p.indirection(ind))
+		}
+		return out, precAtom
+	case *nodes.SubLink:
+		return p.subLink(v)
+	case *nodes.SQLValueFunction:
+		return p.kw(sqlValueFunction(v)), precAtom
+	case *nodes.GroupingFunc:
+		return p.parens(p.kw("GROUPING"), p.exprList(v.Args)), precAtom
+	case *nodes.MergeSupportFunc:
+		return cat(p.kw("MERGE_ACTION"), text("()")), precAtom
+	case *nodes.SetToDefault:
+		return p.kw("DEFAULT"), precAtom
+	case *nodes.SelectStmt:
+		return p.subquery(v), precAtom
+	}
+	return p.fail("cannot format %T", n), precAtom
+}
+
+// value returns the document for a constant and its precedence.
+func (p *printer) value(n nodes.Node) (doc, int) {
+	switch v := n.(type) {
+	case *nodes.Integer:
+		if v.Ival < 0 {
+			return text(strconv.FormatInt(v.Ival, 10)), precUnary
+		}
+		return text(strconv.FormatInt(v.Ival, 10)), precAtom
+	case *nodes.Float:
+		if strings.HasPrefix(v.Fval, "-") {
+			return text(v.Fval), precUnary
+		}
+		return text(v.Fval), precAtom
+	case *nodes.Boolean:
+		if v.Boolval {
+			return p.kw("TRUE"), precAtom
+		}
+		return p.kw("FALSE"), precAtom
+	case *nodes.String:
+		return text(quoteLiteral(v.Sval)), precAtom
+	case *nodes.BitString:
+		if v.Bsval == "" {
+			return text("B''"), precAtom
+		}
+		return text(strings.ToUpper(v.Bsval[:1]) + quoteLiteral(v.Bsval[1:])), precAtom
+	}
+	return p.fail("cannot format constant %T", n), precAtom
+}
+
+// array returns the bracketed elements of an ARRAY constructor; nested
+// constructors are written without the ARRAY keyword.
+func (p *printer) array(v *nodes.A_ArrayExpr) doc {
+	var docs []doc
+	for _, e := range items(v.Elements) {
+		if sub, ok := e.(*nodes.A_ArrayExpr); ok {
+			docs = append(docs, p.array(sub))
+		} else {
+			docs = append(docs, p.expr(e))
+		}
+	}
+	if len(docs) == 0 {
+		return text("[]")
+	}
+	return grp(text("["), indent(p.opts.IndentWidth, softline, join(docs, cat(text(","), space))), softline, text("]"))
+}
+
+func (p *printer) indirection(n nodes.Node) doc {
+	switch v := n.(type) {
+	case *nodes.String:
+		return text("." + label(v.Sval))
+	case *nodes.A_Star:
+		return text(".*")
+	case *nodes.A_Indices:
+		if !v.IsSlice {
+			return cat(text("["), p.expr(v.Uidx), text("]"))
+		}
+		out := concat{text("[")}
+		if v.Lidx != nil {
+			out = append(out, p.expr(v.Lidx))
+		}
+		out = append(out, text(":"))
+		if v.Uidx != nil {
+			out = append(out, p.expr(v.Uidx))
+		}
+		return append(out, text("]"))
+	}
+	return p.fail("cannot format indirection %T", n)
+}
+
+func (p *printer) aExpr(v *nodes.A_Expr) (doc, int) {
+	op := operatorName(v.Name)
+	switch v.Kind {
+	case nodes.AEXPR_OP:
+		prec := binaryPrec(v.Name)
+		if v.Lexpr == nil {
+			if op == "-" || op == "+" {
+				// Keep a space before an operand that itself starts with
+				// an operator, lest "- -x" turn into a comment.
+				sep := ""
+				switch r := v.Rexpr.(type) {
+				case *nodes.A_Expr:
+					if r.Kind == nodes.AEXPR_OP && r.Lexpr == nil {
+						sep = " "
+					}
+				case *nodes.A_Const:
+					if _, prec := p.value(r.Val); prec == precUnary {
+						sep = " "
+					}
+				}
+				return cat(text(op+sep), p.operand(v.Rexpr, precUnary)), precUnary
+			}
+			return cat(text(op+" "), p.operand(v.Rexpr, precOp+1)), precOp
+		}
+		left := prec
+		if prec == precCmp {
+			left++
+		}
+		return cat(p.operand(v.Lexpr, left), text(" "+op+" "), p.operand(v.Rexpr, prec+1)), prec
+	case nodes.AEXPR_OP_ANY, nodes.AEXPR_OP_ALL:
+		prec := binaryPrec(v.Name)
+		word := " ANY "
+		if v.Kind == nodes.AEXPR_OP_ALL {
+			word = " ALL "
+		}
+		return cat(p.operand(v.Lexpr, prec+1), text(" "+op), p.kw(word), text("("), p.expr(v.Rexpr), text(")")), prec
+	case nodes.AEXPR_DISTINCT, nodes.AEXPR_NOT_DISTINCT:
+		word := " IS DISTINCT FROM "
+		if v.Kind == nodes.AEXPR_NOT_DISTINCT {
+			word = " IS NOT DISTINCT FROM "
+		}
+		return cat(p.operand(v.Lexpr, precIs+1), p.kw(word), p.operand(v.Rexpr, precIs+1)), precIs
+	case nodes.AEXPR_NULLIF:
+		return p.parens(p.kw("NULLIF"), []doc{p.expr(v.Lexpr), p.expr(v.Rexpr)}), precAtom
+	case nodes.AEXPR_IN:
+		list, ok := v.Rexpr.(*nodes.List)
+		if !ok {
+			return p.fail("malformed IN"), precAtom
+		}
+		word := " IN "
+		if op == "<>" {
+			word = " NOT IN "
+		}
+		return cat(p.operand(v.Lexpr, precLike+1), p.kw(word), p.parens(nil, p.exprList(list))), precLike
+	case nodes.AEXPR_LIKE, nodes.AEXPR_ILIKE, nodes.AEXPR_SIMILAR:
+		word := map[nodes.A_Expr_Kind]string{
+			nodes.AEXPR_LIKE:    "LIKE",
+			nodes.AEXPR_ILIKE:   "ILIKE",
+			nodes.AEXPR_SIMILAR: "SIMILAR TO",
+		}[v.Kind]
+		if strings.HasPrefix(op, "!") {
+			word = "NOT " + word
+		}
+		return cat(p.operand(v.Lexpr, precLike+1), p.kw(" "+word+" "), p.pattern(v.Rexpr)), precLike
+	case nodes.AEXPR_BETWEEN, nodes.AEXPR_NOT_BETWEEN, nodes.AEXPR_BETWEEN_SYM, nodes.AEXPR_NOT_BETWEEN_SYM:
+		bounds := items(asList(v.Rexpr))
+		if len(bounds) != 2 {
+			return p.fail("malformed BETWEEN"), precAtom
+		}
+		return cat(p.operand(v.Lexpr, precLike+1), p.kw(" "+op+" "), p.operand(bounds[0], precOp),
+			p.kw(" AND "), p.operand(bounds[1], precOp)), precLike
+	}
+	return p.fail("cannot format A_Expr kind %d", v.Kind), precAtom
+}
+
+// pattern returns the right operand of LIKE or SIMILAR TO, turning the
+// escape function the grammar inserts back into an ESCAPE clause.
+func (p *printer) pattern(n nodes.Node) doc {
+	if fc, ok := n.(*nodes.FuncCall); ok && fc.Funcformat == nodes.COERCE_EXPLICIT_CALL {
+		names := stringList(fc.Funcname)
+		if len(names) == 2 && names[0] == "pg_catalog" && (names[1] == "like_escape" || names[1] == "similar_to_escape") {
+			args := items(fc.Args)
+			if len(args) == 1 && names[1] == "similar_to_escape" {
+				return p.operand(args[0], precOp)
+			}
+			if len(args) == 2 {
+				return cat(p.operand(args[0], precOp), p.kw(" ESCAPE "), p.operand(args[1], precOp))
+			}
+		}
+	}
+	return p.operand(n, precOp)
+}
+
+// boolExpr returns the document for AND, OR and NOT. The operands of a
+// chain of ANDs or ORs go on separate lines if the chain does not fit, or
+// always if hard is set.
+func (p *printer) boolExpr(v *nodes.BoolExpr, hard bool) (doc, int) {
+	args := items(v.Args)
+	if v.Boolop == nodes.NOT_EXPR {
+		if len(args) != 1 {
+			return p.fail("malformed NOT"), precAtom
+		}
+		return cat(p.kw("NOT "), p.operand(args[0], precNot)), precNot
+	}
+	word, prec := "AND ", precAnd
+	if v.Boolop == nodes.OR_EXPR {
+		word, prec = "OR ", precOr
+	}
+	// The grammar nests chains to the left, so a left operand of the same
+	// kind reads back without parentheses.
+	var docs []doc
+	var chain func(*nodes.BoolExpr)
+	chain = func(b *nodes.BoolExpr) {
+		for i, a := range items(b.Args) {
+			if sub, ok := a.(*nodes.BoolExpr); ok && i == 0 && sub.Boolop == b.Boolop {
+				chain(sub)
+				continue
+			}
+			min := prec
+			if i > 0 {
+				min++
+			}
+			docs = append(docs, p.operand(a, min))
+		}
+	}
+	chain(v)
+	br := space
+	if hard {
+		br = hardline
+	}
+	return grp(join(docs, cat(br, p.kw(word)))), prec
+}
+
+// condition returns the document for a WHERE, HAVING or ON condition,
+// placing the operands of a top-level AND or OR on separate lines if the
+// BreakConditions option is set.
+func (p *printer) condition(n nodes.Node) doc {
+	if b, ok := n.(*nodes.BoolExpr); ok && b.Boolop != nodes.NOT_EXPR {
+		d, _ := p.boolExpr(b, p.opts.BreakConditions)
+		return d
+	}
+	return p.expr(n)
+}
+
+func (p *printer) caseExpr(v *nodes.CaseExpr) doc {
+	w := p.opts.IndentWidth
+	out := concat{p.kw("CASE")}
+	if v.Arg != nil {
+		out = append(out, text(" "), p.expr(v.Arg))
+	}
+	var arms concat
+	for _, n := range items(v.Args) {
+		cw, ok := n.(*nodes.CaseWhen)
+		if !ok {
+			return p.fail("malformed CASE")
+		}
+		arms = append(arms, space, p.kw("WHEN "), p.expr(cw.Expr), p.kw(" THEN "), p.expr(cw.Result))
+	}
+	if v.Defresult != nil {
+		arms = append(arms, space, p.kw("ELSE "), p.expr(v.Defresult))
+	}
+	return grp(out, indent(w, arms), space, p.kw("END"))
+}
+
+func (p *printer) subLink(v *nodes.SubLink) (doc, int) {
+	sub := p.subquery(v.Subselect)
+	switch nodes.SubLinkType(v.SubLinkType) {
+	case nodes.EXISTS_SUBLINK:
+		return cat(p.kw("EXISTS "), sub), precAtom
+	case nodes.ANY_SUBLINK:
+		if v.OperName == nil {
+			return cat(p.operand(v.Testexpr, precLike+1), p.kw(" IN "), sub), precLike
+		}
+		prec := binaryPrec(v.OperName)
+		return cat(p.operand(v.Testexpr, prec+1), text(" "+operatorName(v.OperName)), p.kw(" ANY "), sub), prec
+	case nodes.ALL_SUBLINK:
+		prec := binaryPrec(v.OperName)
+		return cat(p.operand(v.Testexpr, prec+1), text(" "+operatorName(v.OperName)), p.kw(" ALL "), sub), prec
+	case nodes.EXPR_SUBLINK:
+		return sub, precAtom
+	case nodes.ARRAY_SUBLINK:
+		return cat(p.kw("ARRAY"), sub), precAtom
+	}
+	return p.fail("cannot format sublink type %d", v.SubLinkType), precAtom
+}
+
+func sqlValueFunction(v *nodes.SQLValueFunction) string {
+	withPrecision := func(name string) string {
+		return name + "(" + strconv.Itoa(int(v.Typmod)) + ")"
+	}
+	switch v.Op {
+	case nodes.SVFOP_CURRENT_DATE:
+		return "CURRENT_DATE"
+	case nodes.SVFOP_CURRENT_TIME:
+		return "CURRENT_TIME"
+	case nodes.SVFOP_CURRENT_TIME_N:
+		return withPrecision("CURRENT_TIME")
+	case nodes.SVFOP_CURRENT_TIMESTAMP:
+		return "CURRENT_TIMESTAMP"
+	case nodes.SVFOP_CURRENT_TIMESTAMP_N:
+		return withPrecision("CURRENT_TIMESTAMP")
+	case nodes.SVFOP_LOCALTIME:
+		return "LOCALTIME"
+	case nodes.SVFOP_LOCALTIME_N:
+		return withPrecision("LOCALTIME")
+	case nodes.SVFOP_LOCALTIMESTAMP:
+		return "LOCALTIMESTAMP"
+	case nodes.SVFOP_LOCALTIMESTAMP_N:
+		return withPrecision("LOCALTIMESTAMP")
+	case nodes.SVFOP_CURRENT_ROLE:
+		return "CURRENT_ROLE"
+	case nodes.SVFOP_CURRENT_USER:
+		return "CURRENT_USER"
+	case nodes.SVFOP_USER:
+		return "USER"
+	case nodes.SVFOP_SESSION_USER:
+		return "SESSION_USER"
+	case nodes.SVFOP_CURRENT_CATALOG:
+		return "CURRENT_CATALOG"
+	}
+	return "CURRENT_SCHEMA"
+}
+
+func (p *printer) funcCall(v *nodes.FuncCall) (doc, int) {
+	if v.Funcformat == nodes.COERCE_SQL_SYNTAX {
+		return p.sqlSyntaxCall(v)
+	}
+	name := text(funcNameList(v.Funcname))
+	var call doc
+	switch {
+	case v.AggStar:
+		call = cat(name, text("(*)"))
+	case v.AggOrder != nil && !v.AggWithinGroup || v.AggDistinct || v.FuncVariadic:
+		var args []doc
+		for i, a := range items(v.Args) {
+			d := p.expr(a)
+			if i == 0 && v.AggDistinct {
+				d = cat(p.kw("DISTINCT "), d)
+			}
+			if i == len(v.Args.Items)-1 && v.FuncVariadic {
+				d = cat(p.kw("VARIADIC "), d)
+			}
+			args = append(args, d)
+		}
+		inner := concat{join(args, cat(text(","), space))}
+		if v.AggOrder != nil && !v.AggWithinGroup {
+			inner = append(inner, space, p.kw("ORDER BY "), p.sortList(v.AggOrder))
+		}
+		call = grp(name, text("("), indent(p.opts.IndentWidth, softline, inner), softline, text(")"))
+	default:
+		call = p.parens(name, p.exprList(v.Args))
+	}
+	out := concat{call}
+	if v.AggWithinGroup {
+		out = append(out, p.kw(" WITHIN GROUP (ORDER BY "), p.sortList(v.AggOrder), text(")"))
+	}
+	if v.AggFilter != nil {
+		out = append(out, p.kw(" FILTER (WHERE "), p.expr(v.AggFilter), text(")"))
+	}
+	if w := v.Over; w != nil {
+		if w.Name != "" && w.Refname == "" && w.PartitionClause == nil && w.OrderClause == nil &&
+			w.FrameOptions&nodes.FRAMEOPTION_NONDEFAULT == 0 {
+			out = append(out, p.kw(" OVER "), text(ident(w.Name)))
+		} else {
+			out = append(out, p.kw(" OVER "), p.windowSpec(w))
+		}
+	}
+	return out, precAtom
+}
+
+// sqlSyntaxCall returns the document for a function call the grammar
+// produced from special SQL syntax, writing that syntax back.
+func (p *printer) sqlSyntaxCall(v *nodes.FuncCall) (doc, int) {
+	names := stringList(v.Funcname)
+	if len(names) == 2 && names[0] == "pg_catalog" {
+		names = names[1:]
+	}
+	args := items(v.Args)
+	if len(names) != 1 || v.AggStar || v.AggDistinct || v.AggOrder != nil || v.AggFilter != nil || v.Over != nil || v.FuncVariadic {
+		return p.fail("cannot format SQL syntax call %s", strings.Join(names, ".")), precAtom
+	}
+	call := func(name string, body ...doc) (doc, int) {
+		return cat(p.kw(name), text("("), cat(body...), text(")")), precAtom
+	}
+	switch name := names[0]; {
+	case name == "timezone" && len(args) == 2:
+		return cat(p.operand(args[1], precAt), p.kw(" AT TIME ZONE "), p.operand(args[0], precAt+1)), precAt
+	case name == "timezone" && len(args) == 1:
+		return cat(p.operand(args[0], precAt), p.kw(" AT LOCAL")), precAt
+	case name == "overlaps" && len(args) == 4:
+		return cat(p.parens(nil, []doc{p.expr(args[0]), p.expr(args[1])}), p.kw(" OVERLAPS "),
+			p.parens(nil, []doc{p.expr(args[2]), p.expr(args[3])})), precIs
+	case name == "is_normalized" && (len(args) == 1 || len(args) == 2):
+		out := concat{p.operand(args[0], precIs+1), p.kw(" IS ")}
+		if len(args) == 2 {
+			form, ok := normalForm(args[1])
+			if !ok {
+				break
+			}
+			out = append(out, p.kw(form+" "))
+		}
+		return append(out, p.kw("NORMALIZED")), precIs
+	case name == "normalize" && len(args) == 1:
+		return call("NORMALIZE", p.expr(args[0]))
+	case name == "normalize" && len(args) == 2:
+		form, ok := normalForm(args[1])
+		if !ok {
+			break
+		}
+		return call("NORMALIZE", p.expr(args[0]), text(", "), p.kw(form))
+	case name == "pg_collation_for" && len(args) == 1:
+		return call("COLLATION FOR ", p.expr(args[0]))
+	case name == "system_user" && len(args) == 0:
+		return p.kw("SYSTEM_USER"), precAtom
+	case name == "extract" && len(args) == 2:
+		c, ok := args[0].(*nodes.A_Const)
+		if !ok {
+			break
+		}
+		s, ok := c.Val.(*nodes.String)
+		if !ok {
+			break
+		}
+		var field doc = text(quoteLiteral(s.Sval))
+		if kw := parser.LookupKeyword(s.Sval); ident(s.Sval) == s.Sval && kw == nil {
+			field = p.kw(strings.ToUpper(s.Sval))
+		}
+		switch s.Sval {
+		case "year", "month", "day", "hour", "minute", "second":
+			field = p.kw(strings.ToUpper(s.Sval))
+		}
+		return call("EXTRACT", field, p.kw(" FROM "), p.expr(args[1]))
+	case name == "overlay" && (len(args) == 3 || len(args) == 4):
+		body := concat{p.expr(args[0]), p.kw(" PLACING "), p.expr(args[1]), p.kw(" FROM "), p.expr(args[2])}
+		if len(args) == 4 {
+			body = append(body, p.kw(" FOR "), p.expr(args[3]))
+		}
+		return call("OVERLAY", body)
+	case name == "position" && len(args) == 2:
+		return call("POSITION", p.operand(args[1], precOp), p.kw(" IN "), p.operand(args[0], precOp))
+	case name == "substring" && (len(args) == 2 || len(args) == 3):
+		body := concat{p.expr(args[0]), p.kw(" FROM "), p.expr(args[1])}
+		if len(args) == 3 {
+			body = append(body, p.kw(" FOR "), p.expr(args[2]))
+		}
+		return call("SUBSTRING", body)
+	case (name == "btrim" || name == "ltrim" || name == "rtrim") && len(args) > 0:
+		// "FROM list" passes the list through as it is, whatever order
+		// the other forms of trim_list build.
+		side := map[string]string{"btrim": "BOTH", "ltrim": "LEADING", "rtrim": "TRAILING"}[name]
+		return call("TRIM", p.kw(side+" FROM "), join(p.exprList(v.Args), text(", ")))
+	}
+	return p.fail("cannot format SQL syntax call %s", names[0]), precAtom
+}
+
+// normalForm returns the Unicode normal form named by a NORMALIZE or IS
+// NORMALIZED argument.
+func normalForm(n nodes.Node) (string, bool) {
+	if c, ok := n.(*nodes.A_Const); ok {
+		if s, ok := c.Val.(*nodes.String); ok {
+			switch s.Sval {
+			case "NFC", "NFD", "NFKC", "NFKD":
+				return s.Sval, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (p *printer) sortList(l *nodes.List) doc {
+	var docs []doc
+	for _, n := range items(l) {
+		s, ok := n.(*nodes.SortBy)
+		if !ok {
+			docs = append(docs, p.expr(n))
+			continue
+		}
+		d := concat{p.expr(s.Node)}
+		switch s.SortbyDir {
+		case nodes.SORTBY_ASC:
+			d = append(d, p.kw(" ASC"))
+		case nodes.SORTBY_DESC:
+			d = append(d, p.kw(" DESC"))
+		case nodes.SORTBY_USING:
+			d = append(d, p.kw(" USING "), text(operatorName(s.UseOp)))
+		}
+		switch s.SortbyNulls {
+		case nodes.SORTBY_NULLS_FIRST:
+			d = append(d, p.kw(" NULLS FIRST"))
+		case nodes.SORTBY_NULLS_LAST:
+			d = append(d, p.kw(" NULLS LAST"))
+		}
+		docs = append(docs, d)
+	}
+	return join(docs, cat(text(","), space))
+}
+
+// windowSpec returns the parenthesized part of a window definition.
+func (p *printer) windowSpec(w *nodes.WindowDef) doc {
+	var parts []doc
+	if w.Refname != "" {
+		parts = append(parts, text(ident(w.Refname)))
+	}
+	if w.PartitionClause != nil {
+		parts = append(parts, cat(p.kw("PARTITION BY "), join(p.exprList(w.PartitionClause), cat(text(","), space))))
+	}
+	if w.OrderClause != nil {
+		parts = append(parts, cat(p.kw("ORDER BY "), p.sortList(w.OrderClause)))
+	}
+	opts := w.FrameOptions
+	if opts&nodes.FRAMEOPTION_NONDEFAULT != 0 {
+		frame := concat{}
+		switch {
+		case opts&nodes.FRAMEOPTION_RANGE != 0:
+			frame = append(frame, p.kw("RANGE "))
+		case opts&nodes.FRAMEOPTION_ROWS != 0:
+			frame = append(frame, p.kw("ROWS "))
+		default:
+			frame = append(frame, p.kw("GROUPS "))
+		}
+		bound := func(unboundedPreceding, unboundedFollowing, currentRow, offsetPreceding, offsetFollowing int, offset nodes.Node) doc {
+			switch {
+			case opts&unboundedPreceding != 0:
+				return p.kw("UNBOUNDED PRECEDING")
+			case opts&unboundedFollowing != 0:
+				return p.kw("UNBOUNDED FOLLOWING")
+			case opts&currentRow != 0:
+				return p.kw("CURRENT ROW")
+			case opts&offsetPreceding != 0:
+				return cat(p.operand(offset, precOp), p.kw(" PRECEDING"))
+			case opts&offsetFollowing != 0:
+				return cat(p.operand(offset, precOp), p.kw(" FOLLOWING"))
+			}
+			return nil
+		}
+		start := bound(nodes.FRAMEOPTION_START_UNBOUNDED_PRECEDING, nodes.FRAMEOPTION_START_UNBOUNDED_FOLLOWING,
+			nodes.FRAMEOPTION_START_CURRENT_ROW, nodes.FRAMEOPTION_START_OFFSET_PRECEDING,
+			nodes.FRAMEOPTION_START_OFFSET_FOLLOWING, w.StartOffset)
+		if opts&nodes.FRAMEOPTION_BETWEEN != 0 {
+			end := bound(nodes.FRAMEOPTION_END_UNBOUNDED_PRECEDING, nodes.FRAMEOPTION_END_UNBOUNDED_FOLLOWING,
+				nodes.FRAMEOPTION_END_CURRENT_ROW, nodes.FRAMEOPTION_END_OFFSET_PRECEDING,
+				nodes.FRAMEOPTION_END_OFFSET_FOLLOWING, w.EndOffset)
+			frame = append(frame, p.kw("BETWEEN "), start, p.kw(" AND "), end)
+		} else {
+			frame = append(frame, start)
+		}
+		switch {
+		case opts&nodes.FRAMEOPTION_EXCLUDE_CURRENT_ROW != 0:
+			frame = append(frame, p.kw(" EXCLUDE CURRENT ROW"))
+		case opts&nodes.FRAMEOPTION_EXCLUDE_GROUP != 0:
+			frame = append(frame, p.kw(" EXCLUDE GROUP"))
+		case opts&nodes.FRAMEOPTION_EXCLUDE_TIES != 0:
+			frame = append(frame, p.kw(" EXCLUDE TIES"))
+		}
+		parts = append(parts, frame)
+	}
+	if len(parts) == 0 {
+		return text("()")
+	}
+	return grp(text("("), indent(p.opts.IndentWidth, softline, join(parts, space)), softline, text(")"))
+}
+
+// Interval field masks, as INTERVAL_MASK in datetime.h builds them.
+var intervalFields = map[int64]string{
+	1 << 2:                       "YEAR",
+	1 << 1:                       "MONTH",
+	1 << 3:                       "DAY",
+	1 << 10:                      "HOUR",
+	1 << 11:                      "MINUTE",
+	1 << 12:                      "SECOND",
+	1<<2 | 1<<1:                  "YEAR TO MONTH",
+	1<<3 | 1<<10:                 "DAY TO HOUR",
+	1<<3 | 1<<10 | 1<<11:         "DAY TO MINUTE",
+	1<<3 | 1<<10 | 1<<11 | 1<<12: "DAY TO SECOND",
+	1<<10 | 1<<11:                "HOUR TO MINUTE",
+	1<<10 | 1<<11 | 1<<12:        "HOUR TO SECOND",
+	1<<11 | 1<<12:                "MINUTE TO SECOND",
+}
+
+const intervalFullRange = 0x7FFF
+
+// typeName returns the document for a type name. Built-in types the
+// grammar qualifies with pg_catalog are written in the SQL-standard
+// syntax that produces them, so that the output parses to the same tree.
+func (p *printer) typeName(tn *nodes.TypeName) doc {
+	if tn == nil {
+		return p.fail("missing type name")
+	}
+	out := concat{}
+	if tn.Setof {
+		out = append(out, p.kw("SETOF "))
+	}
+	names := stringList(tn.Names)
+	mods, intMods := typmods(tn.Typmods)
+	var name doc
+	if len(names) == 2 && names[0] == "pg_catalog" && !tn.PctType {
+		name = p.builtinType(names[1], mods, intMods)
+	}
+	if name == nil {
+		parts := make([]string, len(names))
+		for i, n := range names {
+			if i == 0 {
+				parts[i] = ident(n)
+			} else {
+				parts[i] = label(n)
+			}
+		}
+		s := strings.Join(parts, ".")
+		if tn.PctType {
+			s += "%TYPE"
+		}
+		if tn.Typmods != nil {
+			name = cat(text(s), text("("), join(p.exprList(tn.Typmods), text(", ")), text(")"))
+		} else {
+			name = text(s)
+		}
+	}
+	out = append(out, name)
+	for _, b := range items(tn.ArrayBounds) {
+		i, ok := b.(*nodes.Integer)
+		if !ok {
+			return p.fail("malformed array bounds")
+		}
+		if i.Ival < 0 {
+			out = append(out, text("[]"))
+		} else {
+			out = append(out, text("["+strconv.FormatInt(i.Ival, 10)+"]"))
+		}
+	}
+	return out
+}
+
+// builtinType returns the SQL-standard spelling of a pg_catalog type, or
+// nil if the type or its modifiers have none.
+func (p *printer) builtinType(name string, mods []int64, intMods bool) doc {
+	if !intMods {
+		return nil
+	}
+	withMods := func(kw string) doc {
+		if len(mods) == 0 {
+			return p.kw(kw)
+		}
+		s := make([]string, len(mods))
+		for i, m := range mods {
+			s[i] = strconv.FormatInt(m, 10)
+		}
+		return cat(p.kw(kw), text("("+strings.Join(s, ", ")+")"))
+	}
+	plain := map[string]string{
+		"int2": "SMALLINT", "int4": "INTEGER", "int8": "BIGINT", "float4": "REAL",
+		"float8": "DOUBLE PRECISION", "bool": "BOOLEAN", "json": "JSON",
+	}
+	if kw, ok := plain[name]; ok {
+		if len(mods) > 0 {
+			return nil
+		}
+		return p.kw(kw)
+	}
+	switch name {
+	case "numeric":
+		return withMods("NUMERIC")
+	case "bpchar":
+		if len(mods) == 1 && mods[0] == 1 {
+			// The length the grammar gives a bare CHAR.
+			return p.kw("CHAR")
+		}
+		if len(mods) <= 1 {
+			return withMods("CHAR")
+		}
+	case "varchar":
+		if len(mods) <= 1 {
+			return withMods("VARCHAR")
+		}
+	case "bit":
+		if len(mods) == 1 && mods[0] == 1 {
+			// The length the grammar gives a bare BIT.
+			return p.kw("BIT")
+		}
+		if len(mods) <= 1 {
+			return withMods("BIT")
+		}
+	case "varbit":
+		if len(mods) <= 1 {
+			return withMods("BIT VARYING")
+		}
+	case "timestamp", "timestamptz", "time", "timetz":
+		if len(mods) > 1 {
+			return nil
+		}
+		d := withMods(strings.ToUpper(strings.TrimSuffix(name, "tz")))
+		if strings.HasSuffix(name, "tz") {
+			return cat(d, p.kw(" WITH TIME ZONE"))
+		}
+		return d
+	case "interval":
+		return p.intervalType(mods)
+	}
+	return nil
+}
+
+// intervalType returns the spelling of an interval type with the given
+// modifiers: a field mask and possibly a fractional seconds precision.
+func (p *printer) intervalType(mods []int64) doc {
+	switch {
+	case len(mods) == 0:
+		return p.kw("INTERVAL")
+	case len(mods) == 2 && mods[0] == intervalFullRange:
+		return cat(p.kw("INTERVAL"), text("("+strconv.FormatInt(mods[1], 10)+")"))
+	case len(mods) > 2:
+		return nil
+	}
+	fields, ok := intervalFields[mods[0]]
+	if !ok {
+		return nil
+	}
+	d := cat(p.kw("INTERVAL " + fields))
+	if len(mods) == 2 {
+		if !strings.HasSuffix(fields, "SECOND") {
+			return nil
+		}
+		d = cat(d, text("("+strconv.FormatInt(mods[1], 10)+")"))
+	}
+	return d
+}
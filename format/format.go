@@ -0,0 +1,515 @@
+// Package format re-emits SQL in a canonical layout.
+//
+// Format parses its input with the parser package and prints each
+// statement back from its parse tree, choosing line breaks to fit a line
+// width. Comments and blank lines between statements are kept. Every
+// formatted statement is parsed again and compared with the original
+// tree; a statement whose output would not parse to an equal tree, one
+// that has comments inside it, and one of a kind the printer does not
+// handle are all copied through unchanged, so formatting never changes
+// what the SQL means. FormatSkipped also reports those statements.
+//
+// SELECT, VALUES, INSERT, UPDATE, DELETE, CREATE TABLE, CREATE VIEW,
+// CREATE INDEX, ALTER TABLE, DROP, TRUNCATE, SET, RESET, SHOW, the
+// transaction statements, EXPLAIN, VACUUM and ANALYZE are formatted.
+package format
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pgplex/pgparser/catalog"
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+)
+
+// Case is the letter case keywords are written in.
+type Case int
+
+const (
+	Upper Case = iota
+	Lower
+)
+
+// Options controls the layout.
+type Options struct {
+	// KeywordCase is the case keywords are written in. Identifiers are
+	// left as they are.
+	KeywordCase Case
+	// IndentWidth is the number of spaces continuation lines are indented
+	// by; 0 selects the default of 4.
+	IndentWidth int
+	// LineWidth is the width lines are broken to fit, where possible; 0
+	// selects the default of 80.
+	LineWidth int
+	// LeadingCommas places the commas of a broken list at the start of
+	// lines rather than at the end.
+	LeadingCommas bool
+	// BreakJoins starts every JOIN on a new line.
+	BreakJoins bool
+	// BreakConditions places the operands of a top-level AND or OR in a
+	// WHERE, HAVING or ON condition on separate lines, even if they fit
+	// on one.
+	BreakConditions bool
+	// AlignColumns aligns the types and constraints of the column
+	// definitions of CREATE TABLE.
+	AlignColumns bool
+}
+
+// DefaultOptions returns the default layout: upper-case keywords,
+// four-space indentation, 80-column lines, trailing commas, a line per
+// JOIN and aligned column definitions.
+func DefaultOptions() Options {
+	return Options{
+		KeywordCase:  Upper,
+		IndentWidth:  4,
+		LineWidth:    80,
+		BreakJoins:   true,
+		AlignColumns: true,
+	}
+}
+
+// Skipped is a statement that Format copied through unchanged.
+type Skipped struct {
+	Reason   string
+	Location int // byte offset of the statement
+	Line     int // 1-based line of Location
+	Column   int // 1-based column (in bytes) of Location
+}
+
+func (s Skipped) String() string {
+	return fmt.Sprintf("%d:%d: statement not formatted: %s", s.Line, s.Column, s.Reason)
+}
+
+// Format returns sql in canonical layout. It fails only if sql does not
+// parse. The result ends in a newline, and every statement in it ends in
+// a semicolon.
+func Format(sql string, opts Options) (string, error) {
+	out, _, err := FormatSkipped(sql, opts)
+	return out, err
+}
+
+// FormatSkipped is Format, also returning the statements it copied
+// through unchanged, in order.
+func FormatSkipped(sql string, opts Options) (string, []Skipped, error) {
+	if opts.IndentWidth <= 0 {
+		opts.IndentWidth = 4
+	}
+	if opts.LineWidth <= 0 {
+		opts.LineWidth = 80
+	}
+	raws, err := parser.ParseRaw(sql)
+	if err != nil {
+		return "", nil, err
+	}
+	comments := scanComments(sql)
+	var b strings.Builder
+	var skipped []Skipped
+	pos := 0 // end of the previous statement, or 0
+	for _, raw := range raws {
+		end := len(sql)
+		if raw.StmtLen > 0 {
+			end = int(raw.StmtLocation + raw.StmtLen)
+		}
+		start, stop := body(sql, int(raw.StmtLocation), end, comments)
+		writeGap(&b, sql[pos:start], pos == 0, false)
+		out, reason := formatStmt(sql[start:stop], raw.Stmt, hasComment(comments, start, stop), opts)
+		if reason != "" {
+			line, col := lineCol(sql, start)
+			skipped = append(skipped, Skipped{Reason: reason, Location: start, Line: line, Column: col})
+		}
+		b.WriteString(out)
+		b.WriteByte(';')
+		pos = stop
+	}
+	writeGap(&b, sql[pos:], pos == 0, true)
+	out := b.String()
+	if err := Verify(sql, out); err != nil {
+		return "", nil, err
+	}
+	return out, skipped, nil
+}
+
+// Verify reports whether formatted parses to the same statements as
+// original, ignoring source locations.
+func Verify(original, formatted string) error {
+	want, err := parser.Parse(original)
+	if err != nil {
+		return err
+	}
+	got, err := parser.Parse(formatted)
+	if err != nil {
+		return fmt.Errorf("formatted SQL does not parse: %w", err)
+	}
+	if want.Len() != got.Len() {
+		return fmt.Errorf("formatted SQL has %d statements, want %d", got.Len(), want.Len())
+	}
+	for i := range items(want) {
+		if !nodes.Equal(want.Items[i], got.Items[i]) {
+			return fmt.Errorf("statement %d parses differently after formatting", i+1)
+		}
+	}
+	return nil
+}
+
+// formatStmt returns the formatted text of the statement stmt, whose
+// source text is src, or src itself and the reason if it cannot be
+// formatted safely.
+func formatStmt(src string, stmt nodes.Node, comments bool, opts Options) (string, string) {
+	if comments {
+		return src, "it has comments inside it"
+	}
+	p := &printer{opts: opts}
+	d := p.stmt(stmt, true)
+	if p.err != nil {
+		return src, p.err.Error()
+	}
+	out := render(d, opts.LineWidth)
+	l, err := parser.Parse(out)
+	if err != nil || l.Len() != 1 || !nodes.Equal(stmt, l.Items[0]) {
+		return src, "its formatted text parses differently"
+	}
+	return out, ""
+}
+
+// lineCol converts a byte offset into 1-based line and column numbers.
+func lineCol(src string, offset int) (int, int) {
+	line := 1 + strings.Count(src[:offset], "\n")
+	col := offset - strings.LastIndexByte(src[:offset], '\n')
+	return line, col
+}
+
+// span is a range of byte offsets.
+type span struct {
+	start, end int
+}
+
+// scanComments returns the comments in sql, skipping over string
+// constants, quoted identifiers and dollar-quoted strings as the lexer
+// does.
+func scanComments(sql string) []span {
+	var spans []span
+	identChar := func(c byte) bool {
+		return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+	}
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		switch {
+		case strings.HasPrefix(sql[i:], "--"):
+			j := strings.IndexAny(sql[i:], "\r\n")
+			if j < 0 {
+				j = len(sql) - i
+			}
+			spans = append(spans, span{i, i + j})
+			i += j
+		case strings.HasPrefix(sql[i:], "/*"):
+			j, depth := i+2, 1
+			for j < len(sql) && depth > 0 {
+				switch {
+				case strings.HasPrefix(sql[j:], "/*"):
+					depth++
+					j += 2
+				case strings.HasPrefix(sql[j:], "*/"):
+					depth--
+					j += 2
+				default:
+					j++
+				}
+			}
+			spans = append(spans, span{i, j})
+			i = j
+		case c == '\'' || c == '"':
+			backslash := c == '\'' && i > 0 && (sql[i-1] == 'e' || sql[i-1] == 'E') && (i == 1 || !identChar(sql[i-2]))
+			j := i + 1
+			for j < len(sql) {
+				if backslash && sql[j] == '\\' {
+					j += 2
+					continue
+				}
+				if sql[j] == c {
+					if j+1 < len(sql) && sql[j+1] == c {
+						j += 2
+						continue
+					}
+					break
+				}
+				j++
+			}
+			i = j + 1
+		case c == '$' && (i == 0 || !identChar(sql[i-1])):
+			j := i + 1
+			for j < len(sql) && identChar(sql[j]) && sql[j] != '$' && !(j == i+1 && sql[j] >= '0' && sql[j] <= '9') {
+				j++
+			}
+			if j >= len(sql) || sql[j] != '$' {
+				i = j
+				continue
+			}
+			tag := sql[i : j+1]
+			k := strings.Index(sql[j+1:], tag)
+			if k < 0 {
+				i = len(sql)
+			} else {
+				i = j + 1 + k + len(tag)
+			}
+		case identChar(c):
+			for i < len(sql) && identChar(sql[i]) {
+				i++
+			}
+		default:
+			i++
+		}
+	}
+	return spans
+}
+
+// body returns the extent of the code of a statement within its span, the
+// leading and trailing whitespace and comments excluded.
+func body(sql string, start, end int, comments []span) (int, int) {
+	k := sort.Search(len(comments), func(k int) bool { return comments[k].end > start })
+	first, last := -1, start
+	for i := start; i < end; {
+		if k < len(comments) && i >= comments[k].start {
+			i = comments[k].end
+			k++
+			continue
+		}
+		if !isSpace(sql[i]) {
+			if first < 0 {
+				first = i
+			}
+			last = i + 1
+		}
+		i++
+	}
+	if first < 0 {
+		return start, start
+	}
+	return first, last
+}
+
+func hasComment(comments []span, start, end int) bool {
+	k := sort.Search(len(comments), func(k int) bool { return comments[k].start >= start })
+	return k < len(comments) && comments[k].start < end
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
+
+// writeGap writes what separates two statements, given the source text
+// between them: the semicolons are dropped and the comments kept, each
+// on a line of its own unless it shares a line with the end of the
+// previous statement. A run of blank lines becomes a single one. At the
+// start of the input, first is set and leading blank lines are dropped;
+// at the end, last is set and the output is ended with a single newline.
+func writeGap(b *strings.Builder, gap string, first, last bool) {
+	newlines := 0
+	atStart := first
+	for i := 0; i < len(gap); {
+		c := gap[i]
+		if c == '\n' || c == '\r' && !strings.HasPrefix(gap[i+1:], "\n") {
+			newlines++
+			i++
+			continue
+		}
+		if c == ';' || isSpace(c) {
+			i++
+			continue
+		}
+		// A comment: the gap holds nothing else.
+		j := i + 2
+		if strings.HasPrefix(gap[i:], "--") {
+			if k := strings.IndexAny(gap[i:], "\r\n"); k >= 0 {
+				j = i + k
+			} else {
+				j = len(gap)
+			}
+		} else {
+			for depth := 1; j < len(gap) && depth > 0; {
+				switch {
+				case strings.HasPrefix(gap[j:], "/*"):
+					depth++
+					j += 2
+				case strings.HasPrefix(gap[j:], "*/"):
+					depth--
+					j += 2
+				default:
+					j++
+				}
+			}
+		}
+		switch {
+		case atStart:
+		case newlines == 0 && !first:
+			b.WriteByte(' ')
+		case newlines > 1:
+			b.WriteString("\n\n")
+		default:
+			b.WriteByte('\n')
+		}
+		b.WriteString(strings.TrimRight(gap[i:j], " \t\r"))
+		atStart, first, newlines = false, false, 0
+		i = j
+	}
+	switch {
+	case atStart || last && b.Len() == 0:
+	case last:
+		b.WriteByte('\n')
+	case newlines > 1:
+		b.WriteString("\n\n")
+	default:
+		b.WriteByte('\n')
+	}
+}
+
+// printer builds the layout documents for parse trees. A node it cannot
+// print sets err, and the statement is then copied through unchanged.
+type printer struct {
+	opts Options
+	err  error
+}
+
+func (p *printer) fail(format string, args ...any) doc {
+	if p.err == nil {
+		p.err = fmt.Errorf(format, args...)
+	}
+	return text("")
+}
+
+// kw returns keywords, in the case the options call for.
+func (p *printer) kw(s string) doc {
+	if p.opts.KeywordCase == Lower {
+		return text(strings.ToLower(s))
+	}
+	return text(s)
+}
+
+func items(l *nodes.List) []nodes.Node {
+	if l == nil {
+		return nil
+	}
+	return l.Items
+}
+
+func asList(n nodes.Node) *nodes.List {
+	l, _ := n.(*nodes.List)
+	return l
+}
+
+// stringList returns the strings of a list of String nodes.
+func stringList(l *nodes.List) []string {
+	var out []string
+	for _, n := range items(l) {
+		if s, ok := n.(*nodes.String); ok {
+			out = append(out, s.Sval)
+		}
+	}
+	return out
+}
+
+// typmods returns the type modifiers of a type name as integers, and
+// whether they all are integer constants.
+func typmods(l *nodes.List) ([]int64, bool) {
+	var mods []int64
+	for _, n := range items(l) {
+		if c, ok := n.(*nodes.A_Const); ok {
+			n = c.Val
+		}
+		i, ok := n.(*nodes.Integer)
+		if !ok {
+			return nil, false
+		}
+		mods = append(mods, i.Ival)
+	}
+	return mods, true
+}
+
+// ident quotes an identifier where the grammar wants a ColId.
+func ident(s string) string {
+	return catalog.QuoteIdentifier(s)
+}
+
+// label quotes an identifier where the grammar accepts any keyword, as
+// after AS or a dot.
+func label(s string) string {
+	safe := s != "" && (s[0] >= 'a' && s[0] <= 'z' || s[0] == '_')
+	for i := 0; safe && i < len(s); i++ {
+		c := s[i]
+		safe = c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '_' || c == '$'
+	}
+	if safe {
+		return s
+	}
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// funcName quotes an unqualified function or parameter name, which may be
+// a type_func_name keyword but not a col_name or reserved one.
+func funcName(s string) string {
+	if kw := parser.LookupKeyword(s); kw != nil && kw.Category == parser.TypeFuncNameKeyword && label(s) == s {
+		return s
+	}
+	return ident(s)
+}
+
+// funcNameList returns a possibly-qualified function name.
+func funcNameList(l *nodes.List) string {
+	names := stringList(l)
+	if len(names) == 1 {
+		return funcName(names[0])
+	}
+	return anyNameOf(names)
+}
+
+// anyName returns a possibly-qualified name given as String nodes.
+func anyName(l *nodes.List) string {
+	return anyNameOf(stringList(l))
+}
+
+func anyNameOf(names []string) string {
+	parts := make([]string, len(names))
+	for i, n := range names {
+		if i == 0 {
+			parts[i] = ident(n)
+		} else {
+			parts[i] = label(n)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// identDocs returns a list of String nodes as identifiers.
+func identDocs(l *nodes.List) []doc {
+	var docs []doc
+	for _, s := range stringList(l) {
+		docs = append(docs, text(ident(s)))
+	}
+	return docs
+}
+
+func rangeVar(rv *nodes.RangeVar) string {
+	var names []string
+	for _, s := range []string{rv.Catalogname, rv.Schemaname} {
+		if s != "" {
+			names = append(names, s)
+		}
+	}
+	return anyNameOf(append(names, rv.Relname))
+}
+
+// quoteLiteral returns s as a standard-conforming string constant.
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// operatorName returns an operator name, using OPERATOR() syntax for a
+// qualified one.
+func operatorName(l *nodes.List) string {
+	names := stringList(l)
+	if len(names) == 1 {
+		return names[0]
+	}
+	return "OPERATOR(" + anyNameOf(names[:len(names)-1]) + "." + names[len(names)-1] + ")"
+}
@@ -0,0 +1,294 @@
+package format
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgparser/parser"
+	"github.com/pgplex/pgparser/parser/pgregress"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		opts func(*Options)
+		want string
+	}{
+		{
+			name: "select",
+			sql:  "select a, b from t where a = 1 and b = 2",
+			want: "SELECT a, b\nFROM t\nWHERE a = 1 AND b = 2;\n",
+		},
+		{
+			name: "lower case",
+			sql:  "SELECT a FROM t WHERE a IS NOT NULL ORDER BY a DESC LIMIT 5",
+			opts: func(o *Options) { o.KeywordCase = Lower },
+			want: "select a\nfrom t\nwhere a is not null\norder by a desc\nlimit 5;\n",
+		},
+		{
+			name: "line width",
+			sql:  "select first_column, second_column, third_column, fourth_column from some_table",
+			opts: func(o *Options) { o.LineWidth = 40 },
+			want: "SELECT first_column,\n    second_column,\n    third_column,\n    fourth_column\nFROM some_table;\n",
+		},
+		{
+			name: "leading commas",
+			sql:  "select first_column, second_column, third_column, fourth_column from some_table",
+			opts: func(o *Options) { o.LineWidth = 40; o.LeadingCommas = true },
+			want: "SELECT first_column\n    , second_column\n    , third_column\n    , fourth_column\nFROM some_table;\n",
+		},
+		{
+			name: "indent width",
+			sql:  "select first_column, second_column, third_column, fourth_column from some_table",
+			opts: func(o *Options) { o.LineWidth = 40; o.IndentWidth = 2 },
+			want: "SELECT first_column,\n  second_column,\n  third_column,\n  fourth_column\nFROM some_table;\n",
+		},
+		{
+			name: "break joins",
+			sql:  "select * from a join b on a.id = b.id left join c using (id)",
+			want: "SELECT *\nFROM a\n    JOIN b ON a.id = b.id\n    LEFT JOIN c USING (id);\n",
+		},
+		{
+			name: "inline joins",
+			sql:  "select * from a join b on a.id = b.id left join c using (id)",
+			opts: func(o *Options) { o.BreakJoins = false },
+			want: "SELECT *\nFROM a JOIN b ON a.id = b.id LEFT JOIN c USING (id);\n",
+		},
+		{
+			name: "break conditions",
+			sql:  "select * from t where a = 1 and (b = 2 or c = 3) and d",
+			opts: func(o *Options) { o.BreakConditions = true },
+			want: "SELECT *\nFROM t\nWHERE a = 1\n    AND (b = 2 OR c = 3)\n    AND d;\n",
+		},
+		{
+			name: "aligned columns",
+			sql:  "create table t (id bigint primary key, name text not null, created_at timestamptz default now())",
+			want: "CREATE TABLE t (\n" +
+				"    id         BIGINT      PRIMARY KEY,\n" +
+				"    name       text        NOT NULL,\n" +
+				"    created_at timestamptz DEFAULT now()\n" +
+				");\n",
+		},
+		{
+			name: "unaligned columns",
+			sql:  "create table t (id bigint primary key, name text not null, created_at timestamptz default now())",
+			opts: func(o *Options) { o.AlignColumns = false },
+			want: "CREATE TABLE t (\n    id BIGINT PRIMARY KEY,\n    name text NOT NULL,\n    created_at timestamptz DEFAULT now()\n);\n",
+		},
+		{
+			name: "dml",
+			sql:  "insert into t (a, b) values (1, 'x') on conflict (a) do update set b = excluded.b returning *; update t set a = a + 1 where b",
+			want: "INSERT INTO t (a, b)\nVALUES (1, 'x')\nON CONFLICT (a) DO UPDATE\nSET b = excluded.b\nRETURNING *;\nUPDATE t\nSET a = a + 1\nWHERE b;\n",
+		},
+		{
+			name: "set operation",
+			sql:  "create or replace view v as select a from t union all select b from u",
+			want: "CREATE OR REPLACE VIEW v AS\nSELECT a\nFROM t\nUNION ALL\nSELECT b\nFROM u;\n",
+		},
+		{
+			name: "nested query",
+			sql:  "with x as (select 1 as n) select n from x where n in (select m from y)",
+			want: "WITH x AS (SELECT 1 AS n)\nSELECT n\nFROM x\nWHERE n IN (SELECT m FROM y);\n",
+		},
+		{
+			name: "parentheses",
+			sql:  "select (a + b) * c, a - (b - c), not (a and b), x::int[]",
+			want: "SELECT (a + b) * c, a - (b - c), NOT (a AND b), x::INTEGER[];\n",
+		},
+		{
+			name: "default type lengths",
+			sql:  "select a::char, a::char(1), a::char(3), a::bit, a::bit(4) from t",
+			want: "SELECT a::CHAR, a::CHAR, a::CHAR(3), a::BIT, a::BIT(4)\nFROM t;\n",
+		},
+		{
+			name: "comments and blank lines",
+			sql:  "-- header\n\nselect 1; -- one\n\n\n/* two */\nselect  2;\n",
+			want: "-- header\n\nSELECT 1; -- one\n\n/* two */\nSELECT 2;\n",
+		},
+		{
+			name: "comment inside statement",
+			sql:  "select 1, -- keep\n 2;\nselect   3",
+			want: "select 1, -- keep\n 2;\nSELECT 3;\n",
+		},
+		{
+			name: "alter table",
+			sql:  "alter table only t add column c int not null, alter column d type text using d::text, drop constraint if exists k cascade",
+			want: "ALTER TABLE ONLY t\n    ADD COLUMN c INTEGER NOT NULL,\n    ALTER COLUMN d TYPE text USING d::text,\n    DROP CONSTRAINT IF EXISTS k CASCADE;\n",
+		},
+		{
+			name: "drop",
+			sql:  "drop table if exists a, s.b cascade; drop function f(int, out x text); drop trigger tr on s.t",
+			want: "DROP TABLE IF EXISTS a, s.b CASCADE;\nDROP FUNCTION f(INTEGER, OUT x text);\nDROP TRIGGER tr ON s.t;\n",
+		},
+		{
+			name: "session and transaction",
+			sql:  "begin isolation level serializable read only; set local search_path = a, b; show work_mem; reset all; commit and chain",
+			want: "BEGIN ISOLATION LEVEL SERIALIZABLE, READ ONLY;\nSET LOCAL search_path TO 'a', 'b';\nSHOW work_mem;\nRESET ALL;\nCOMMIT AND CHAIN;\n",
+		},
+		{
+			name: "maintenance",
+			sql:  "explain analyze select a from t; vacuum full verbose t (a), u; truncate only t restart identity",
+			want: "EXPLAIN (ANALYZE) SELECT a\nFROM t;\nVACUUM (FULL, VERBOSE) t (a), u;\nTRUNCATE ONLY t RESTART IDENTITY;\n",
+		},
+		{
+			name: "unsupported statement",
+			sql:  "grant select on t to  u",
+			want: "grant select on t to  u;\n",
+		},
+	}
+	for _, tt := range tests {
+		opts := DefaultOptions()
+		if tt.opts != nil {
+			tt.opts(&opts)
+		}
+		got, err := Format(tt.sql, opts)
+		if err != nil {
+			t.Errorf("%s: Format error: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: got\n%s\nwant\n%s", tt.name, got, tt.want)
+			continue
+		}
+		again, err := Format(got, opts)
+		if err != nil || again != got {
+			t.Errorf("%s: formatting is not idempotent: got\n%s", tt.name, again)
+		}
+	}
+}
+
+func TestFormatError(t *testing.T) {
+	if _, err := Format("select from where", DefaultOptions()); err == nil {
+		t.Error("expected an error for SQL that does not parse")
+	}
+}
+
+func TestFormatSkipped(t *testing.T) {
+	sql := "select 1;\ngrant select on t to u;\n  select 2, -- keep\n 3;\n"
+	_, skipped, err := FormatSkipped(sql, DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"2:1: statement not formatted: cannot format *nodes.GrantStmt",
+		"3:3: statement not formatted: it has comments inside it",
+	}
+	if len(skipped) != len(want) {
+		t.Fatalf("skipped = %v, want %d statements", skipped, len(want))
+	}
+	for i, s := range skipped {
+		if s.String() != want[i] {
+			t.Errorf("skipped[%d] = %q, want %q", i, s, want[i])
+		}
+	}
+	if got := sql[skipped[1].Location:]; !strings.HasPrefix(got, "select 2") {
+		t.Errorf("skipped[1].Location points at %q", got)
+	}
+	if _, skipped, _ := FormatSkipped("select 1; insert into t values (1)", DefaultOptions()); len(skipped) != 0 {
+		t.Errorf("skipped = %v, want none", skipped)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		original, formatted string
+		wantErr             string
+	}{
+		{"select 1", "SELECT\n  1;", ""},
+		{"select a  from t", "SELECT a FROM t", ""},
+		{"select 1", "SELECT 2", "statement 1 parses differently"},
+		{"select 1; select 2", "SELECT 1", "has 1 statements, want 2"},
+		{"select 1", "SELECT (", "does not parse"},
+	}
+	for _, tt := range tests {
+		err := Verify(tt.original, tt.formatted)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("Verify(%q, %q): %v", tt.original, tt.formatted, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Verify(%q, %q) = %v, want error containing %q", tt.original, tt.formatted, err, tt.wantErr)
+		}
+	}
+}
+
+// maxCorpusSkipped bounds the statements of the regression corpus that
+// are copied through unformatted; lower it as the printer learns more.
+const maxCorpusSkipped = 7850
+
+// TestRegressionCorpus formats every statement of the PostgreSQL
+// regression tests that parses, which checks that the output parses to
+// the same tree, and that no more than maxCorpusSkipped statements are
+// left unformatted.
+func TestRegressionCorpus(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping regression corpus in short mode")
+	}
+	files, err := filepath.Glob(filepath.Join("..", "parser", "pgregress", "testdata", "sql", "*.sql"))
+	if err != nil {
+		t.Fatalf("glob corpus: %v", err)
+	}
+	if len(files) == 0 {
+		t.Skip("regression corpus not found")
+	}
+	opts := DefaultOptions()
+	total, skipped := 0, 0
+	for _, path := range files {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read %s: %v", path, err)
+		}
+		for _, stmt := range pgregress.ExtractStatements(filepath.Base(path), content) {
+			if stmt.HasPsqlVar {
+				continue
+			}
+			if _, err := parser.Parse(stmt.SQL); err != nil {
+				continue
+			}
+			_, sk, err := FormatSkipped(stmt.SQL, opts)
+			if err != nil {
+				t.Errorf("%s:%d: %v", stmt.File, stmt.StartLine, err)
+				continue
+			}
+			total++
+			skipped += len(sk)
+		}
+	}
+	t.Logf("formatted %d of %d statements", total-skipped, total)
+	if skipped > maxCorpusSkipped {
+		t.Errorf("%d statements were not formatted, want at most %d", skipped, maxCorpusSkipped)
+	}
+}
+
+// FuzzFormat checks that whatever parses formats to SQL that parses to the
+// same tree.
+func FuzzFormat(f *testing.F) {
+	files, _ := filepath.Glob(filepath.Join("..", "parser", "pgregress", "testdata", "sql", "*.sql"))
+	for _, path := range files {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			f.Fatalf("read %s: %v", path, err)
+		}
+		stmts := pgregress.ExtractStatements(filepath.Base(path), content)
+		if len(stmts) > 10 {
+			stmts = stmts[:10]
+		}
+		for _, stmt := range stmts {
+			f.Add(stmt.SQL)
+		}
+	}
+	opts := DefaultOptions()
+	f.Fuzz(func(t *testing.T, sql string) {
+		if _, err := parser.Parse(sql); err != nil {
+			return
+		}
+		if _, err := Format(sql, opts); err != nil {
+			t.Errorf("Format(%q): %v", sql, err)
+		}
+	})
+}
@@ -0,0 +1,581 @@
+package format
+
+import (
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// subquery returns the document for a parenthesized statement nested in
+// another, which stays on one line if it fits.
+func (p *printer) subquery(n nodes.Node) doc {
+	return grp(text("("), indent(p.opts.IndentWidth, softline, p.stmt(n, false)), softline, text(")"))
+}
+
+// stmt returns the document for a statement. The clauses of a top-level
+// statement always go on separate lines; those of a nested one only if
+// the statement does not fit on one.
+func (p *printer) stmt(n nodes.Node, top bool) doc {
+	br := space
+	if top {
+		br = hardline
+	}
+	var d doc
+	switch v := n.(type) {
+	case *nodes.SelectStmt:
+		d = p.selectStmt(v, br)
+	case *nodes.InsertStmt:
+		d = p.insertStmt(v, br)
+	case *nodes.UpdateStmt:
+		d = p.updateStmt(v, br)
+	case *nodes.DeleteStmt:
+		d = p.deleteStmt(v, br)
+	case *nodes.CreateStmt:
+		d = p.createStmt(v)
+	case *nodes.ViewStmt:
+		d = p.viewStmt(v, br)
+	case *nodes.IndexStmt:
+		d = p.indexStmt(v)
+	case *nodes.AlterTableStmt:
+		d = p.alterTableStmt(v)
+	case *nodes.DropStmt:
+		d = p.dropStmt(v)
+	case *nodes.TruncateStmt:
+		d = p.truncateStmt(v)
+	case *nodes.VariableSetStmt:
+		d = p.variableSetStmt(v)
+	case *nodes.VariableShowStmt:
+		d = p.variableShowStmt(v)
+	case *nodes.TransactionStmt:
+		d = p.transactionStmt(v)
+	case *nodes.ExplainStmt:
+		d = p.explainStmt(v, top)
+	case *nodes.VacuumStmt:
+		d = p.vacuumStmt(v)
+	default:
+		return p.fail("cannot format %T", n)
+	}
+	if top {
+		return d
+	}
+	return grp(d)
+}
+
+// clause returns a clause keyword followed by its items, which continue
+// on indented lines if they do not fit on one.
+func (p *printer) clause(keyword doc, docs []doc) doc {
+	return grp(keyword, text(" "), indent(p.opts.IndentWidth, join(docs, p.comma())))
+}
+
+// comma returns the separator of a list that may break onto lines, which
+// starts the next line if the LeadingCommas option is set.
+func (p *printer) comma() doc {
+	if p.opts.LeadingCommas {
+		return ifBreak{broken: cat(space, text(", ")), flat: text(", ")}
+	}
+	return cat(text(","), space)
+}
+
+func (p *printer) withClause(w *nodes.WithClause, br doc) doc {
+	var ctes []doc
+	for _, n := range items(w.Ctes) {
+		cte, ok := n.(*nodes.CommonTableExpr)
+		if !ok {
+			return p.fail("malformed WITH")
+		}
+		if cte.SearchClause != nil || cte.CycleClause != nil {
+			return p.fail("cannot format SEARCH or CYCLE clauses")
+		}
+		d := concat{text(ident(cte.Ctename))}
+		if cte.Aliascolnames != nil {
+			d = append(d, text(" "), p.parens(nil, identDocs(cte.Aliascolnames)))
+		}
+		d = append(d, p.kw(" AS "))
+		switch nodes.CTEMaterialize(cte.Ctematerialized) {
+		case nodes.CTEMaterializeAlways:
+			d = append(d, p.kw("MATERIALIZED "))
+		case nodes.CTEMaterializeNever:
+			d = append(d, p.kw("NOT MATERIALIZED "))
+		}
+		ctes = append(ctes, append(d, p.subquery(cte.Ctequery)))
+	}
+	kw := "WITH "
+	if w.Recursive {
+		kw = "WITH RECURSIVE "
+	}
+	return cat(p.kw(kw), join(ctes, cat(text(","), br)), br)
+}
+
+// selectStmt returns the document for a SELECT, VALUES or set operation.
+func (p *printer) selectStmt(s *nodes.SelectStmt, br doc) doc {
+	out := concat{}
+	if s.WithClause != nil {
+		out = append(out, p.withClause(s.WithClause, br))
+	}
+	switch {
+	case s.Op != nodes.SETOP_NONE:
+		word := []string{"", "UNION", "INTERSECT", "EXCEPT"}[s.Op]
+		if s.All {
+			word += " ALL"
+		}
+		out = append(out, p.setOperand(s.Larg, s.Op, false, br), br, p.kw(word), br, p.setOperand(s.Rarg, s.Op, true, br))
+	case s.ValuesLists != nil:
+		var rows []doc
+		for _, row := range items(s.ValuesLists) {
+			rows = append(rows, p.parens(nil, p.exprList(asList(row))))
+		}
+		out = append(out, p.clause(p.kw("VALUES"), rows))
+	default:
+		out = append(out, p.simpleSelect(s, br))
+	}
+	if s.SortClause != nil {
+		out = append(out, br, p.kw("ORDER BY "), indent(p.opts.IndentWidth, grp(p.sortList(s.SortClause))))
+	}
+	if s.LimitOption == nodes.LIMIT_OPTION_WITH_TIES {
+		if s.LimitOffset != nil {
+			out = append(out, br, p.kw("OFFSET "), p.expr(s.LimitOffset))
+		}
+		out = append(out, br, p.kw("FETCH FIRST "), p.operand(s.LimitCount, precAtom), p.kw(" ROWS WITH TIES"))
+	} else {
+		if s.LimitCount != nil {
+			out = append(out, br, p.kw("LIMIT "))
+			if c, ok := s.LimitCount.(*nodes.A_Const); ok && c.Isnull {
+				out = append(out, p.kw("ALL"))
+			} else {
+				out = append(out, p.expr(s.LimitCount))
+			}
+		}
+		if s.LimitOffset != nil {
+			out = append(out, br, p.kw("OFFSET "), p.expr(s.LimitOffset))
+		}
+	}
+	for _, n := range items(s.LockingClause) {
+		lc, ok := n.(*nodes.LockingClause)
+		if !ok || lc.Strength <= nodes.LCS_NONE || lc.Strength > nodes.LCS_FORUPDATE {
+			return p.fail("malformed locking clause")
+		}
+		out = append(out, br, p.kw([]string{"", "FOR KEY SHARE", "FOR SHARE", "FOR NO KEY UPDATE", "FOR UPDATE"}[lc.Strength]))
+		if lc.LockedRels != nil {
+			var rels []doc
+			for _, r := range items(lc.LockedRels) {
+				rels = append(rels, p.fromItem(r))
+			}
+			out = append(out, p.kw(" OF "), join(rels, text(", ")))
+		}
+		switch nodes.LockWaitPolicy(lc.WaitPolicy) {
+		case nodes.LockWaitSkip:
+			out = append(out, p.kw(" SKIP LOCKED"))
+		case nodes.LockWaitError:
+			out = append(out, p.kw(" NOWAIT"))
+		}
+	}
+	return out
+}
+
+// setOperand returns one side of a set operation, parenthesizing it if it
+// carries clauses that would otherwise attach to the whole operation or
+// if it is a set operation that binds more loosely than op.
+func (p *printer) setOperand(s *nodes.SelectStmt, op nodes.SetOperation, right bool, br doc) doc {
+	if s == nil {
+		return p.fail("malformed set operation")
+	}
+	prec := func(op nodes.SetOperation) int {
+		if op == nodes.SETOP_INTERSECT {
+			return 2
+		}
+		return 1
+	}
+	paren := s.SortClause != nil || s.LimitCount != nil || s.LimitOffset != nil ||
+		s.LockingClause != nil || s.WithClause != nil
+	if s.Op != nodes.SETOP_NONE {
+		if right {
+			paren = paren || prec(s.Op) <= prec(op)
+		} else {
+			paren = paren || prec(s.Op) < prec(op)
+		}
+	}
+	if paren {
+		return cat(text("("), indent(p.opts.IndentWidth, br, p.selectStmt(s, br)), br, text(")"))
+	}
+	return p.selectStmt(s, br)
+}
+
+func (p *printer) simpleSelect(s *nodes.SelectStmt, br doc) doc {
+	if s.IntoClause != nil {
+		return p.fail("cannot format SELECT INTO")
+	}
+	kw := concat{p.kw("SELECT")}
+	if s.DistinctClause != nil {
+		exprs := items(s.DistinctClause)
+		if len(exprs) == 1 && exprs[0] == nil {
+			kw = append(kw, p.kw(" DISTINCT"))
+		} else {
+			kw = append(kw, p.kw(" DISTINCT ON "), p.parens(nil, p.exprList(s.DistinctClause)))
+		}
+	}
+	var out concat
+	if targets := p.targetList(s.TargetList); len(targets) > 0 {
+		out = append(out, p.clause(kw, targets))
+	} else {
+		out = append(out, kw)
+	}
+	if s.FromClause != nil {
+		out = append(out, br, p.fromClause(p.kw("FROM"), s.FromClause))
+	}
+	if s.WhereClause != nil {
+		out = append(out, br, p.where(p.kw("WHERE"), s.WhereClause))
+	}
+	if s.GroupClause != nil {
+		kw := p.kw("GROUP BY")
+		if s.GroupDistinct {
+			kw = p.kw("GROUP BY DISTINCT")
+		}
+		var groups []doc
+		for _, n := range items(s.GroupClause) {
+			groups = append(groups, p.groupItem(n))
+		}
+		out = append(out, br, p.clause(kw, groups))
+	}
+	if s.HavingClause != nil {
+		out = append(out, br, p.where(p.kw("HAVING"), s.HavingClause))
+	}
+	if s.WindowClause != nil {
+		var windows []doc
+		for _, n := range items(s.WindowClause) {
+			w, ok := n.(*nodes.WindowDef)
+			if !ok {
+				return p.fail("malformed WINDOW")
+			}
+			windows = append(windows, cat(text(ident(w.Name)), p.kw(" AS "), p.windowSpec(w)))
+		}
+		out = append(out, br, p.clause(p.kw("WINDOW"), windows))
+	}
+	return out
+}
+
+// targetList returns the items of a SELECT or RETURNING list.
+func (p *printer) targetList(l *nodes.List) []doc {
+	var docs []doc
+	for _, n := range items(l) {
+		rt, ok := n.(*nodes.ResTarget)
+		if !ok {
+			return []doc{p.fail("malformed target list")}
+		}
+		d := p.expr(rt.Val)
+		if rt.Name != "" {
+			d = cat(d, p.kw(" AS "), text(label(rt.Name)))
+		}
+		docs = append(docs, d)
+	}
+	return docs
+}
+
+// where returns a WHERE or HAVING clause.
+func (p *printer) where(keyword doc, cond nodes.Node) doc {
+	return cat(keyword, text(" "), indent(p.opts.IndentWidth, p.condition(cond)))
+}
+
+func (p *printer) groupItem(n nodes.Node) doc {
+	gs, ok := n.(*nodes.GroupingSet)
+	if !ok {
+		return p.expr(n)
+	}
+	var docs []doc
+	for _, c := range items(gs.Content) {
+		docs = append(docs, p.groupItem(c))
+	}
+	switch gs.Kind {
+	case nodes.GROUPING_SET_EMPTY:
+		return text("()")
+	case nodes.GROUPING_SET_ROLLUP:
+		return p.parens(p.kw("ROLLUP "), docs)
+	case nodes.GROUPING_SET_CUBE:
+		return p.parens(p.kw("CUBE "), docs)
+	case nodes.GROUPING_SET_SETS:
+		return p.parens(p.kw("GROUPING SETS "), docs)
+	}
+	return p.parens(nil, docs)
+}
+
+// fromClause returns a FROM or USING clause.
+func (p *printer) fromClause(keyword doc, l *nodes.List) doc {
+	var docs []doc
+	for _, n := range items(l) {
+		docs = append(docs, p.fromItem(n))
+	}
+	return p.clause(keyword, docs)
+}
+
+func (p *printer) alias(a *nodes.Alias) doc {
+	if a == nil {
+		return nil
+	}
+	d := concat{p.kw(" AS "), text(ident(a.Aliasname))}
+	if a.Colnames != nil {
+		d = append(d, text(" ("), join(identDocs(a.Colnames), text(", ")), text(")"))
+	}
+	return d
+}
+
+func (p *printer) fromItem(n nodes.Node) doc {
+	switch v := n.(type) {
+	case *nodes.RangeVar:
+		d := concat{}
+		if !v.Inh {
+			d = append(d, p.kw("ONLY "))
+		}
+		return append(d, text(rangeVar(v)), p.alias(v.Alias))
+	case *nodes.RangeSubselect:
+		d := concat{}
+		if v.Lateral {
+			d = append(d, p.kw("LATERAL "))
+		}
+		return append(d, p.subquery(v.Subquery), p.alias(v.Alias))
+	case *nodes.RangeFunction:
+		return p.rangeFunction(v)
+	case *nodes.JoinExpr:
+		return p.joinExpr(v)
+	}
+	return p.fail("cannot format FROM item %T", n)
+}
+
+func (p *printer) rangeFunction(v *nodes.RangeFunction) doc {
+	var funcs []doc
+	for _, n := range items(v.Functions) {
+		pair := items(asList(n))
+		if len(pair) == 0 {
+			return p.fail("malformed function in FROM")
+		}
+		// The grammar only takes plain function calls here, not the
+		// keyword-syntax forms that parse to other nodes in expressions.
+		if fc, ok := pair[0].(*nodes.FuncCall); !ok || fc.Funcformat == nodes.COERCE_SQL_SYNTAX {
+			return p.fail("cannot format function in FROM")
+		}
+		d := concat{p.expr(pair[0])}
+		if len(pair) > 1 && len(items(asList(pair[1]))) > 0 {
+			d = append(d, p.kw(" AS "), p.parens(nil, p.columnDefList(asList(pair[1]))))
+		}
+		funcs = append(funcs, d)
+	}
+	d := concat{}
+	if v.Lateral {
+		d = append(d, p.kw("LATERAL "))
+	}
+	if v.IsRowsfrom {
+		d = append(d, p.parens(p.kw("ROWS FROM "), funcs))
+	} else {
+		d = append(d, join(funcs, text(", ")))
+	}
+	if v.Ordinality {
+		d = append(d, p.kw(" WITH ORDINALITY"))
+	}
+	d = append(d, p.alias(v.Alias))
+	if v.Coldeflist != nil {
+		if v.Alias == nil {
+			d = append(d, p.kw(" AS"))
+		}
+		d = append(d, text(" "), p.parens(nil, p.columnDefList(v.Coldeflist)))
+	}
+	return d
+}
+
+// columnDefList returns the column definitions of a function in FROM.
+func (p *printer) columnDefList(l *nodes.List) []doc {
+	var docs []doc
+	for _, n := range items(l) {
+		cd, ok := n.(*nodes.ColumnDef)
+		if !ok {
+			return []doc{p.fail("malformed column definition list")}
+		}
+		docs = append(docs, cat(text(ident(cd.Colname)), text(" "), p.typeName(cd.TypeName)))
+	}
+	return docs
+}
+
+// joinExpr returns a join, each JOIN starting a new line if the BreakJoins
+// option is set.
+func (p *printer) joinExpr(v *nodes.JoinExpr) doc {
+	br := space
+	if p.opts.BreakJoins {
+		br = hardline
+	}
+	right := p.fromItem(v.Rarg)
+	if j, ok := v.Rarg.(*nodes.JoinExpr); ok && j.Alias == nil {
+		right = cat(text("("), right, text(")"))
+	}
+	var kind string
+	switch v.Jointype {
+	case nodes.JOIN_INNER:
+		kind = "JOIN "
+		if !v.IsNatural && v.Quals == nil && v.UsingClause == nil {
+			kind = "CROSS JOIN "
+		}
+	case nodes.JOIN_LEFT:
+		kind = "LEFT JOIN "
+	case nodes.JOIN_FULL:
+		kind = "FULL JOIN "
+	case nodes.JOIN_RIGHT:
+		kind = "RIGHT JOIN "
+	default:
+		return p.fail("cannot format join type %d", v.Jointype)
+	}
+	if v.IsNatural {
+		kind = "NATURAL " + kind
+	}
+	d := concat{p.fromItem(v.Larg), br, p.kw(kind), right}
+	if v.UsingClause != nil {
+		d = append(d, p.kw(" USING "), p.parens(nil, identDocs(v.UsingClause)))
+		if v.JoinUsingAlias != nil {
+			d = append(d, p.kw(" AS "), text(ident(v.JoinUsingAlias.Aliasname)))
+		}
+	}
+	if v.Quals != nil {
+		d = append(d, p.kw(" ON "), indent(p.opts.IndentWidth, p.condition(v.Quals)))
+	}
+	if v.Alias != nil {
+		return cat(text("("), d, text(")"), p.alias(v.Alias))
+	}
+	return d
+}
+
+func (p *printer) returning(l *nodes.List, br doc) doc {
+	if l == nil {
+		return nil
+	}
+	return cat(br, p.clause(p.kw("RETURNING"), p.targetList(l)))
+}
+
+func (p *printer) insertStmt(s *nodes.InsertStmt, br doc) doc {
+	out := concat{}
+	if s.WithClause != nil {
+		out = append(out, p.withClause(s.WithClause, br))
+	}
+	out = append(out, p.kw("INSERT INTO "), text(rangeVar(s.Relation)))
+	if s.Relation.Alias != nil {
+		if s.Relation.Alias.Colnames != nil {
+			return p.fail("malformed INSERT alias")
+		}
+		out = append(out, p.alias(s.Relation.Alias))
+	}
+	if s.Cols != nil {
+		var cols []doc
+		for _, n := range items(s.Cols) {
+			rt, ok := n.(*nodes.ResTarget)
+			if !ok {
+				return p.fail("malformed INSERT column list")
+			}
+			d := concat{text(ident(rt.Name))}
+			for _, ind := range items(rt.Indirection) {
+				d = append(d, p.indirection(ind))
+			}
+			cols = append(cols, d)
+		}
+		out = append(out, text(" "), p.parens(nil, cols))
+	}
+	switch s.Override {
+	case nodes.OVERRIDING_USER_VALUE:
+		out = append(out, br, p.kw("OVERRIDING USER VALUE"))
+	case nodes.OVERRIDING_SYSTEM_VALUE:
+		out = append(out, br, p.kw("OVERRIDING SYSTEM VALUE"))
+	}
+	if s.SelectStmt == nil {
+		out = append(out, br, p.kw("DEFAULT VALUES"))
+	} else if sel, ok := s.SelectStmt.(*nodes.SelectStmt); ok {
+		out = append(out, br, p.selectStmt(sel, br))
+	} else {
+		return p.fail("malformed INSERT")
+	}
+	if oc := s.OnConflictClause; oc != nil {
+		out = append(out, br, p.kw("ON CONFLICT"))
+		if inf := oc.Infer; inf != nil {
+			if inf.Conname != "" {
+				out = append(out, p.kw(" ON CONSTRAINT "), text(ident(inf.Conname)))
+			} else {
+				out = append(out, text(" "), p.parens(nil, p.indexElems(inf.IndexElems)))
+				if inf.WhereClause != nil {
+					out = append(out, p.kw(" WHERE "), p.expr(inf.WhereClause))
+				}
+			}
+		}
+		switch nodes.OnConflictAction(oc.Action) {
+		case nodes.ONCONFLICT_NOTHING:
+			out = append(out, p.kw(" DO NOTHING"))
+		case nodes.ONCONFLICT_UPDATE:
+			out = append(out, p.kw(" DO UPDATE"), br, p.setClause(oc.TargetList))
+			if oc.WhereClause != nil {
+				out = append(out, br, p.where(p.kw("WHERE"), oc.WhereClause))
+			}
+		default:
+			return p.fail("malformed ON CONFLICT")
+		}
+	}
+	return append(out, p.returning(s.ReturningList, br))
+}
+
+// setClause returns the SET clause of UPDATE or ON CONFLICT DO UPDATE.
+func (p *printer) setClause(l *nodes.List) doc {
+	var docs []doc
+	targets := items(l)
+	for i := 0; i < len(targets); i++ {
+		rt, ok := targets[i].(*nodes.ResTarget)
+		if !ok {
+			return p.fail("malformed SET clause")
+		}
+		col := concat{text(ident(rt.Name))}
+		for _, ind := range items(rt.Indirection) {
+			col = append(col, p.indirection(ind))
+		}
+		mr, ok := rt.Val.(*nodes.MultiAssignRef)
+		if !ok {
+			docs = append(docs, cat(col, text(" = "), p.expr(rt.Val)))
+			continue
+		}
+		// The targets of (a, b) = source share one MultiAssignRef source.
+		if mr.Colno != 1 || i+mr.Ncolumns > len(targets) {
+			return p.fail("malformed multiple-column assignment")
+		}
+		cols := []doc{col}
+		for j := 1; j < mr.Ncolumns; j++ {
+			rt, ok := targets[i+j].(*nodes.ResTarget)
+			if !ok {
+				return p.fail("malformed multiple-column assignment")
+			}
+			col := concat{text(ident(rt.Name))}
+			for _, ind := range items(rt.Indirection) {
+				col = append(col, p.indirection(ind))
+			}
+			cols = append(cols, col)
+		}
+		docs = append(docs, cat(p.parens(nil, cols), text(" = "), p.expr(mr.Source)))
+		i += mr.Ncolumns - 1
+	}
+	return p.clause(p.kw("SET"), docs)
+}
+
+func (p *printer) updateStmt(s *nodes.UpdateStmt, br doc) doc {
+	out := concat{}
+	if s.WithClause != nil {
+		out = append(out, p.withClause(s.WithClause, br))
+	}
+	out = append(out, p.kw("UPDATE "), p.fromItem(s.Relation), br, p.setClause(s.TargetList))
+	if s.FromClause != nil {
+		out = append(out, br, p.fromClause(p.kw("FROM"), s.FromClause))
+	}
+	if s.WhereClause != nil {
+		out = append(out, br, p.where(p.kw("WHERE"), s.WhereClause))
+	}
+	return append(out, p.returning(s.ReturningList, br))
+}
+
+func (p *printer) deleteStmt(s *nodes.DeleteStmt, br doc) doc {
+	out := concat{}
+	if s.WithClause != nil {
+		out = append(out, p.withClause(s.WithClause, br))
+	}
+	out = append(out, p.kw("DELETE FROM "), p.fromItem(s.Relation))
+	if s.UsingClause != nil {
+		out = append(out, br, p.fromClause(p.kw("USING"), s.UsingClause))
+	}
+	if s.WhereClause != nil {
+		out = append(out, br, p.where(p.kw("WHERE"), s.WhereClause))
+	}
+	return append(out, p.returning(s.ReturningList, br))
+}
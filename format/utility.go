@@ -0,0 +1,457 @@
+package format
+
+import (
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+)
+
+// dropObjectTypes are the keywords of the object types DROP removes.
+var dropObjectTypes = map[nodes.ObjectType]string{
+	nodes.OBJECT_ACCESS_METHOD:   "ACCESS METHOD",
+	nodes.OBJECT_AGGREGATE:       "AGGREGATE",
+	nodes.OBJECT_CAST:            "CAST",
+	nodes.OBJECT_COLLATION:       "COLLATION",
+	nodes.OBJECT_CONVERSION:      "CONVERSION",
+	nodes.OBJECT_DOMAIN:          "DOMAIN",
+	nodes.OBJECT_EVENT_TRIGGER:   "EVENT TRIGGER",
+	nodes.OBJECT_EXTENSION:       "EXTENSION",
+	nodes.OBJECT_FDW:             "FOREIGN DATA WRAPPER",
+	nodes.OBJECT_FOREIGN_SERVER:  "SERVER",
+	nodes.OBJECT_FOREIGN_TABLE:   "FOREIGN TABLE",
+	nodes.OBJECT_FUNCTION:        "FUNCTION",
+	nodes.OBJECT_INDEX:           "INDEX",
+	nodes.OBJECT_LANGUAGE:        "LANGUAGE",
+	nodes.OBJECT_MATVIEW:         "MATERIALIZED VIEW",
+	nodes.OBJECT_OPCLASS:         "OPERATOR CLASS",
+	nodes.OBJECT_OPERATOR:        "OPERATOR",
+	nodes.OBJECT_OPFAMILY:        "OPERATOR FAMILY",
+	nodes.OBJECT_POLICY:          "POLICY",
+	nodes.OBJECT_PROCEDURE:       "PROCEDURE",
+	nodes.OBJECT_PUBLICATION:     "PUBLICATION",
+	nodes.OBJECT_ROUTINE:         "ROUTINE",
+	nodes.OBJECT_RULE:            "RULE",
+	nodes.OBJECT_SCHEMA:          "SCHEMA",
+	nodes.OBJECT_SEQUENCE:        "SEQUENCE",
+	nodes.OBJECT_STATISTIC_EXT:   "STATISTICS",
+	nodes.OBJECT_SUBSCRIPTION:    "SUBSCRIPTION",
+	nodes.OBJECT_TABLE:           "TABLE",
+	nodes.OBJECT_TRANSFORM:       "TRANSFORM",
+	nodes.OBJECT_TRIGGER:         "TRIGGER",
+	nodes.OBJECT_TSCONFIGURATION: "TEXT SEARCH CONFIGURATION",
+	nodes.OBJECT_TSDICTIONARY:    "TEXT SEARCH DICTIONARY",
+	nodes.OBJECT_TSPARSER:        "TEXT SEARCH PARSER",
+	nodes.OBJECT_TSTEMPLATE:      "TEXT SEARCH TEMPLATE",
+	nodes.OBJECT_TYPE:            "TYPE",
+	nodes.OBJECT_VIEW:            "VIEW",
+}
+
+func (p *printer) dropStmt(s *nodes.DropStmt) doc {
+	kind := dropObjectTypes[s.RemoveType]
+	if kind == "" {
+		return p.fail("cannot format DROP of object type %d", s.RemoveType)
+	}
+	out := concat{p.kw("DROP " + kind + " ")}
+	if s.Concurrent {
+		out = append(out, p.kw("CONCURRENTLY "))
+	}
+	if s.MissingOk {
+		out = append(out, p.kw("IF EXISTS "))
+	}
+	var objects []doc
+	for _, n := range items(s.Objects) {
+		objects = append(objects, p.dropObject(s.RemoveType, n))
+	}
+	out = append(out, indent(p.opts.IndentWidth, join(objects, p.comma())), p.behavior(s.Behavior))
+	return grp(out)
+}
+
+// dropObject returns one of the objects of DROP, in the shape the grammar
+// gives objects of its type.
+func (p *printer) dropObject(typ nodes.ObjectType, n nodes.Node) doc {
+	if owa, ok := n.(*nodes.ObjectWithArgs); ok {
+		if typ == nodes.OBJECT_OPERATOR {
+			return p.operatorWithArgs(owa)
+		}
+		return p.functionWithArgs(owa, typ == nodes.OBJECT_AGGREGATE)
+	}
+	switch n := n.(type) {
+	case *nodes.String:
+		return text(ident(n.Sval))
+	case *nodes.TypeName:
+		return p.typeName(n)
+	}
+	l, ok := n.(*nodes.List)
+	if !ok || len(l.Items) == 0 {
+		return p.fail("malformed DROP object %T", n)
+	}
+	names := stringList(l)
+	switch typ {
+	case nodes.OBJECT_TRIGGER, nodes.OBJECT_POLICY, nodes.OBJECT_RULE:
+		if len(names) < 2 {
+			return p.fail("malformed DROP %s", dropObjectTypes[typ])
+		}
+		last := len(names) - 1
+		return cat(text(ident(names[last])), p.kw(" ON "), text(anyNameOf(names[:last])))
+	case nodes.OBJECT_OPCLASS, nodes.OBJECT_OPFAMILY:
+		if len(names) < 2 {
+			return p.fail("malformed DROP %s", dropObjectTypes[typ])
+		}
+		return cat(text(anyNameOf(names[1:])), p.kw(" USING "), text(ident(names[0])))
+	case nodes.OBJECT_CAST:
+		src, ok1 := l.Items[0].(*nodes.TypeName)
+		dst, ok2 := l.Items[len(l.Items)-1].(*nodes.TypeName)
+		if len(l.Items) != 2 || !ok1 || !ok2 {
+			return p.fail("malformed DROP CAST")
+		}
+		return cat(text("("), p.typeName(src), p.kw(" AS "), p.typeName(dst), text(")"))
+	case nodes.OBJECT_TRANSFORM:
+		tn, ok := l.Items[0].(*nodes.TypeName)
+		if len(l.Items) != 2 || !ok || len(names) != 1 {
+			return p.fail("malformed DROP TRANSFORM")
+		}
+		return cat(p.kw("FOR "), p.typeName(tn), p.kw(" LANGUAGE "), text(ident(names[0])))
+	}
+	if len(names) != len(l.Items) {
+		return p.fail("malformed DROP object name")
+	}
+	return text(anyNameOf(names))
+}
+
+// functionWithArgs returns a function, procedure or aggregate with its
+// argument types, as DROP and other utility statements name them.
+func (p *printer) functionWithArgs(owa *nodes.ObjectWithArgs, aggregate bool) doc {
+	name := text(funcNameList(owa.Objname))
+	if owa.ArgsUnspecified {
+		return name
+	}
+	var args []doc
+	if owa.Objfuncargs != nil {
+		for _, n := range items(owa.Objfuncargs) {
+			fp, ok := n.(*nodes.FunctionParameter)
+			if !ok || fp.Defexpr != nil {
+				return p.fail("malformed function argument")
+			}
+			d := concat{}
+			switch fp.Mode {
+			case nodes.FUNC_PARAM_IN:
+				d = append(d, p.kw("IN "))
+			case nodes.FUNC_PARAM_OUT:
+				d = append(d, p.kw("OUT "))
+			case nodes.FUNC_PARAM_INOUT:
+				d = append(d, p.kw("INOUT "))
+			case nodes.FUNC_PARAM_VARIADIC:
+				d = append(d, p.kw("VARIADIC "))
+			}
+			if fp.Name != "" {
+				d = append(d, text(funcName(fp.Name)+" "))
+			}
+			args = append(args, append(d, p.typeName(fp.ArgType)))
+		}
+	} else {
+		for _, n := range items(owa.Objargs) {
+			tn, ok := n.(*nodes.TypeName)
+			if !ok {
+				return p.fail("malformed function argument")
+			}
+			args = append(args, p.typeName(tn))
+		}
+	}
+	if aggregate && len(args) == 0 {
+		return cat(name, text("(*)"))
+	}
+	return cat(name, text("("), join(args, text(", ")), text(")"))
+}
+
+// operatorWithArgs returns an operator with its operand types, NONE
+// standing in for the missing operand of a prefix operator.
+func (p *printer) operatorWithArgs(owa *nodes.ObjectWithArgs) doc {
+	names := stringList(owa.Objname)
+	if len(names) == 0 || len(items(owa.Objargs)) != 2 {
+		return p.fail("malformed operator")
+	}
+	last := len(names) - 1
+	name := names[last]
+	if last > 0 {
+		name = anyNameOf(names[:last]) + "." + name
+	}
+	var args []doc
+	for _, n := range items(owa.Objargs) {
+		switch v := n.(type) {
+		case nil:
+			args = append(args, p.kw("NONE"))
+		case *nodes.TypeName:
+			args = append(args, p.typeName(v))
+		default:
+			return p.fail("malformed operator argument %T", n)
+		}
+	}
+	return cat(text(name+" ("), join(args, text(", ")), text(")"))
+}
+
+// behavior returns the CASCADE option of DROP and similar statements;
+// RESTRICT is the default and is left out.
+func (p *printer) behavior(b nodes.DropBehavior) doc {
+	if b == nodes.DROP_CASCADE {
+		return p.kw(" CASCADE")
+	}
+	return nil
+}
+
+func (p *printer) truncateStmt(s *nodes.TruncateStmt) doc {
+	var rels []doc
+	for _, n := range items(s.Relations) {
+		rels = append(rels, p.fromItem(n))
+	}
+	out := concat{p.kw("TRUNCATE "), indent(p.opts.IndentWidth, join(rels, p.comma()))}
+	if s.RestartSeqs {
+		out = append(out, p.kw(" RESTART IDENTITY"))
+	}
+	return grp(append(out, p.behavior(s.Behavior)))
+}
+
+// variableSetStmt returns SET and RESET, which share a node.
+func (p *printer) variableSetStmt(s *nodes.VariableSetStmt) doc {
+	set := p.kw("SET ")
+	if s.IsLocal {
+		set = p.kw("SET LOCAL ")
+	}
+	name := text(varName(s.Name))
+	switch s.Kind {
+	case nodes.VAR_SET_VALUE:
+		var values []doc
+		for _, n := range items(s.Args) {
+			values = append(values, p.varValue(n))
+		}
+		return cat(set, name, p.kw(" TO "), join(values, text(", ")))
+	case nodes.VAR_SET_DEFAULT:
+		return cat(set, name, p.kw(" TO DEFAULT"))
+	case nodes.VAR_SET_CURRENT:
+		return cat(set, name, p.kw(" FROM CURRENT"))
+	case nodes.VAR_SET_MULTI:
+		switch s.Name {
+		case "TRANSACTION", "SESSION CHARACTERISTICS":
+			kw := "TRANSACTION "
+			if s.Name != "TRANSACTION" {
+				kw = "SESSION CHARACTERISTICS AS TRANSACTION "
+			}
+			return cat(set, p.kw(kw), p.transactionModes(s.Args))
+		case "TRANSACTION SNAPSHOT":
+			args := items(s.Args)
+			if len(args) != 1 {
+				return p.fail("malformed SET TRANSACTION SNAPSHOT")
+			}
+			return cat(set, p.kw("TRANSACTION SNAPSHOT "), p.varValue(args[0]))
+		}
+	case nodes.VAR_RESET:
+		return cat(p.kw("RESET "), name)
+	case nodes.VAR_RESET_ALL:
+		return p.kw("RESET ALL")
+	}
+	return p.fail("cannot format SET %s", s.Name)
+}
+
+// varName returns the name of a run-time parameter, whose parts are
+// joined with dots in the tree.
+func varName(name string) string {
+	parts := strings.Split(name, ".")
+	for i, s := range parts {
+		parts[i] = ident(s)
+	}
+	return strings.Join(parts, ".")
+}
+
+// varValue returns a value of SET: a string or number constant.
+func (p *printer) varValue(n nodes.Node) doc {
+	c, ok := n.(*nodes.A_Const)
+	if !ok || c.Isnull {
+		return p.fail("cannot format SET value %T", n)
+	}
+	switch c.Val.(type) {
+	case *nodes.String, *nodes.Integer, *nodes.Float:
+		d, _ := p.value(c.Val)
+		return d
+	}
+	return p.fail("cannot format SET value %T", c.Val)
+}
+
+func (p *printer) variableShowStmt(s *nodes.VariableShowStmt) doc {
+	if s.Name == "all" {
+		return p.kw("SHOW ALL")
+	}
+	return cat(p.kw("SHOW "), text(varName(s.Name)))
+}
+
+// transactionModes returns the isolation level, access mode and
+// deferrability of BEGIN and SET TRANSACTION.
+func (p *printer) transactionModes(l *nodes.List) doc {
+	var modes []doc
+	for _, n := range items(l) {
+		d, ok := n.(*nodes.DefElem)
+		if !ok {
+			return p.fail("malformed transaction mode")
+		}
+		var arg nodes.Node
+		if c, ok := d.Arg.(*nodes.A_Const); ok {
+			arg = c.Val
+		}
+		var mode string
+		switch v := arg.(type) {
+		case *nodes.String:
+			if d.Defname == "transaction_isolation" {
+				switch v.Sval {
+				case "read uncommitted", "read committed", "repeatable read", "serializable":
+					mode = "ISOLATION LEVEL " + strings.ToUpper(v.Sval)
+				}
+			}
+		case *nodes.Integer:
+			switch {
+			case d.Defname == "transaction_read_only" && v.Ival == 1:
+				mode = "READ ONLY"
+			case d.Defname == "transaction_read_only" && v.Ival == 0:
+				mode = "READ WRITE"
+			case d.Defname == "transaction_deferrable" && v.Ival == 1:
+				mode = "DEFERRABLE"
+			case d.Defname == "transaction_deferrable" && v.Ival == 0:
+				mode = "NOT DEFERRABLE"
+			}
+		}
+		if mode == "" {
+			return p.fail("cannot format transaction mode %s", d.Defname)
+		}
+		modes = append(modes, p.kw(mode))
+	}
+	return join(modes, text(", "))
+}
+
+func (p *printer) transactionStmt(s *nodes.TransactionStmt) doc {
+	chain := func(d doc) doc {
+		if s.Chain {
+			return cat(d, p.kw(" AND CHAIN"))
+		}
+		return d
+	}
+	switch s.Kind {
+	case nodes.TRANS_STMT_BEGIN, nodes.TRANS_STMT_START:
+		kw := "BEGIN"
+		if s.Kind == nodes.TRANS_STMT_START {
+			kw = "START TRANSACTION"
+		}
+		if s.Options == nil {
+			return p.kw(kw)
+		}
+		return cat(p.kw(kw+" "), p.transactionModes(s.Options))
+	case nodes.TRANS_STMT_COMMIT:
+		return chain(p.kw("COMMIT"))
+	case nodes.TRANS_STMT_ROLLBACK:
+		return chain(p.kw("ROLLBACK"))
+	case nodes.TRANS_STMT_SAVEPOINT:
+		return cat(p.kw("SAVEPOINT "), text(ident(s.SavepointName)))
+	case nodes.TRANS_STMT_RELEASE:
+		return cat(p.kw("RELEASE SAVEPOINT "), text(ident(s.SavepointName)))
+	case nodes.TRANS_STMT_ROLLBACK_TO:
+		return cat(p.kw("ROLLBACK TO SAVEPOINT "), text(ident(s.SavepointName)))
+	case nodes.TRANS_STMT_PREPARE:
+		return cat(p.kw("PREPARE TRANSACTION "), text(quoteLiteral(s.Gid)))
+	case nodes.TRANS_STMT_COMMIT_PREPARED:
+		return cat(p.kw("COMMIT PREPARED "), text(quoteLiteral(s.Gid)))
+	case nodes.TRANS_STMT_ROLLBACK_PREPARED:
+		return cat(p.kw("ROLLBACK PREPARED "), text(quoteLiteral(s.Gid)))
+	}
+	return p.fail("unknown transaction statement kind %d", s.Kind)
+}
+
+// explainStmt returns EXPLAIN, always with its options in parentheses.
+// The statement it explains starts on the same line.
+func (p *printer) explainStmt(s *nodes.ExplainStmt, top bool) doc {
+	out := concat{p.kw("EXPLAIN ")}
+	if s.Options != nil {
+		out = append(out, p.utilityOptions(s.Options), text(" "))
+	}
+	return append(out, p.stmt(s.Query, top))
+}
+
+// vacuumStmt returns VACUUM and ANALYZE, which share a node.
+func (p *printer) vacuumStmt(s *nodes.VacuumStmt) doc {
+	out := concat{p.kw("ANALYZE")}
+	if s.IsVacuumcmd {
+		out = concat{p.kw("VACUUM")}
+	}
+	if s.Options != nil {
+		out = append(out, text(" "), p.utilityOptions(s.Options))
+	}
+	var rels []doc
+	for _, n := range items(s.Rels) {
+		vr, ok := n.(*nodes.VacuumRelation)
+		if !ok || vr.Relation == nil {
+			return p.fail("malformed VACUUM relation")
+		}
+		d := concat{text(rangeVar(vr.Relation))}
+		if vr.VaCols != nil {
+			d = append(d, text(" "), p.parens(nil, identDocs(vr.VaCols)))
+		}
+		rels = append(rels, d)
+	}
+	if rels != nil {
+		out = append(out, text(" "), indent(p.opts.IndentWidth, join(rels, p.comma())))
+	}
+	return grp(out)
+}
+
+// utilityOptions returns the parenthesized options of EXPLAIN, VACUUM and
+// ANALYZE.
+func (p *printer) utilityOptions(l *nodes.List) doc {
+	var opts []doc
+	for _, n := range items(l) {
+		d, ok := n.(*nodes.DefElem)
+		if !ok || d.Defnamespace != "" || d.Defaction != 0 {
+			return p.fail("malformed utility option")
+		}
+		opt := concat{p.optionWord(d.Defname)}
+		if d.Arg != nil {
+			opt = append(opt, text(" "), p.optionArg(d.Arg))
+		}
+		opts = append(opts, opt)
+	}
+	return p.parens(nil, opts)
+}
+
+// optionWord returns the name of a utility option as a keyword where it
+// reads back as the same word, and quoted otherwise.
+func (p *printer) optionWord(s string) doc {
+	if s == "analyze" || s == "default" || plainWord(s) {
+		return p.kw(strings.ToUpper(s))
+	}
+	return text(ident(s))
+}
+
+// plainWord reports whether s can be written as an unquoted word where
+// the grammar accepts any but a reserved keyword.
+func plainWord(s string) bool {
+	kw := parser.LookupKeyword(s)
+	return label(s) == s && (kw == nil || kw.Category != parser.ReservedKeyword)
+}
+
+func (p *printer) optionArg(n nodes.Node) doc {
+	switch v := n.(type) {
+	case *nodes.String:
+		switch s := v.Sval; {
+		case s == "true" || s == "false" || s == "on" || s == "default" || plainWord(s):
+			return p.kw(strings.ToUpper(s))
+		}
+		return text(quoteLiteral(v.Sval))
+	case *nodes.Integer, *nodes.Float:
+		d, _ := p.value(v)
+		return d
+	case *nodes.A_Star:
+		return text("*")
+	case *nodes.List:
+		var args []doc
+		for _, a := range v.Items {
+			args = append(args, p.optionArg(a))
+		}
+		return p.parens(nil, args)
+	}
+	return p.fail("cannot format option value %T", n)
+}
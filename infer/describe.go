@@ -0,0 +1,135 @@
+package infer
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pgplex/pgparser/catalog"
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+	"github.com/pgplex/pgparser/sema"
+)
+
+// Description is what the server reports when a statement is prepared: the
+// types of its parameters and the columns of its result.
+type Description struct {
+	Params  []Type   // types of $1..$n
+	Columns []Column // result columns; nil if the statement returns no rows
+}
+
+// Column is a result column of a described statement.
+type Column struct {
+	Name string
+	Type Type
+
+	// NotNull reports that the column can never be null. A false value
+	// means only that nullability could not be proven.
+	NotNull bool
+
+	// Table and TableColumn name the table column the result column is
+	// a plain reference to, as in a RowDescription message; both are ""
+	// otherwise. Table is schema-qualified.
+	Table       string
+	TableColumn string
+}
+
+// FromDDL returns a catalog holding the built-in objects and those created
+// by the given DDL scripts, applied in order.
+func FromDDL(scripts ...string) (sema.Catalog, error) {
+	c := catalog.New()
+	for _, s := range scripts {
+		if err := c.Exec(s); err != nil {
+			return nil, err
+		}
+	}
+	return sema.FromCatalog(c), nil
+}
+
+// FromFiles is like FromDDL, but reads the scripts from files.
+func FromFiles(paths ...string) (sema.Catalog, error) {
+	c := catalog.New()
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.Exec(string(data)); err != nil {
+			return nil, fmt.Errorf("%s: %w", p, err)
+		}
+	}
+	return sema.FromCatalog(c), nil
+}
+
+// Describe analyzes a single SQL statement against cat, inferring the
+// types of its $n parameters from the context they appear in, and returns
+// what PREPARE followed by a Describe message would report. Like the
+// server, it fails if a parameter's type cannot be determined. It fails
+// with an *UnknownBuiltinError if a column's type depends on a function or
+// operator that cat does not have.
+func Describe(sql string, cat sema.Catalog) (*Description, error) {
+	list, err := parser.Parse(sql)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil || len(list.Items) == 0 {
+		return &Description{}, nil
+	}
+	if len(list.Items) > 1 {
+		return nil, &sema.Error{
+			Code:     sema.CodeSyntaxError,
+			Message:  "cannot insert multiple commands into a prepared statement",
+			Position: -1,
+		}
+	}
+	q, paramTypes, unresolved, err := sema.AnalyzeUnresolved(list.Items[0], cat, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Description{}
+	for i, oid := range paramTypes {
+		if oid == sema.UNKNOWNOID || oid == nodes.InvalidOid {
+			return nil, &sema.Error{
+				Code:     sema.CodeIndeterminateDatatype,
+				Message:  fmt.Sprintf("could not determine data type of parameter $%d", i+1),
+				Position: -1,
+			}
+		}
+		d.Params = append(d.Params, Type{Oid: oid, Typmod: -1, Name: sema.FormatType(cat, oid, -1)})
+	}
+
+	var tlist *nodes.List
+	switch q.CommandType {
+	case nodes.CMD_SELECT:
+		tlist = q.TargetList
+	case nodes.CMD_INSERT, nodes.CMD_UPDATE, nodes.CMD_DELETE, nodes.CMD_MERGE:
+		tlist = q.ReturningList
+	}
+	if tlist == nil {
+		return d, nil
+	}
+	nn := newNullness(q, cat, nil)
+	d.Columns = []Column{}
+	for _, item := range tlist.Items {
+		tle := item.(*nodes.TargetEntry)
+		if tle.Resjunk {
+			continue
+		}
+		col := Column{
+			Name:    tle.Resname,
+			Type:    exprType(tle.Expr, cat),
+			NotNull: nn.notNull(tle.Expr),
+		}
+		if col.Type.Oid == nodes.InvalidOid {
+			if err := unknownBuiltin(unresolved, cat); err != nil {
+				return nil, err
+			}
+		}
+		if rel := cat.RelationByOid(tle.Resorigtbl); rel != nil && tle.Resorigcol > 0 && int(tle.Resorigcol) <= len(rel.Columns) {
+			col.Table = rel.Schema + "." + rel.Name
+			col.TableColumn = rel.Columns[tle.Resorigcol-1].Name
+		}
+		d.Columns = append(d.Columns, col)
+	}
+	return d, nil
+}
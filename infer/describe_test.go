@@ -0,0 +1,204 @@
+package infer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testSchema = `
+CREATE TABLE authors (
+	id bigserial PRIMARY KEY,
+	name text NOT NULL,
+	bio text,
+	born date
+);
+CREATE TABLE books (
+	id int PRIMARY KEY,
+	author_id bigint NOT NULL REFERENCES authors,
+	title varchar(200) NOT NULL,
+	tags text[],
+	price numeric(10,2)
+);
+`
+
+// formatColumns renders columns as "name type [not null]" strings.
+func formatColumns(cols []Column) []string {
+	out := []string{}
+	for _, c := range cols {
+		s := c.Name + " " + c.Type.Name
+		if c.NotNull {
+			s += " not null"
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func formatParams(params []Type) []string {
+	out := []string{}
+	for _, p := range params {
+		out = append(out, p.Name)
+	}
+	return out
+}
+
+func TestDescribeParams(t *testing.T) {
+	cat, err := FromDDL(testSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		sql  string
+		want []string
+	}{
+		{"SELECT * FROM authors WHERE id = $1", []string{"bigint"}},
+		{"SELECT * FROM authors WHERE name LIKE $1 AND born > $2", []string{"text", "date"}},
+		{"SELECT * FROM books WHERE id IN ($1, $2)", []string{"integer", "integer"}},
+		{"SELECT * FROM books WHERE tags && $1", []string{"text[]"}},
+		{"SELECT * FROM authors LIMIT $1 OFFSET $2", []string{"bigint", "bigint"}},
+		{"SELECT lower($1), $2::int", []string{"text", "integer"}},
+		{"SELECT $1", []string{"text"}},
+		{"INSERT INTO authors (name, bio) VALUES ($1, $2)", []string{"text", "text"}},
+		{"INSERT INTO books VALUES ($1, $2, $3)", []string{"integer", "bigint", "character varying"}},
+		{"UPDATE books SET price = $1 WHERE id = $2", []string{"numeric", "integer"}},
+		{"DELETE FROM books WHERE author_id = $1 AND $2", []string{"bigint", "boolean"}},
+	}
+	for _, tt := range tests {
+		d, err := Describe(tt.sql, cat)
+		if err != nil {
+			t.Errorf("Describe(%s) error: %v", tt.sql, err)
+			continue
+		}
+		if got := formatParams(d.Params); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Describe(%s) params = %q, want %q", tt.sql, got, tt.want)
+		}
+	}
+}
+
+func TestDescribeColumns(t *testing.T) {
+	cat, err := FromDDL(testSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		sql  string
+		want []string
+	}{
+		{"SELECT * FROM authors", []string{"id bigint not null", "name text not null", "bio text", "born date"}},
+		{"SELECT b.title, a.name FROM books b JOIN authors a ON a.id = b.author_id",
+			[]string{"title character varying(200) not null", "name text not null"}},
+		{"SELECT a.name, b.title FROM authors a LEFT JOIN books b ON a.id = b.author_id",
+			[]string{"name text not null", "title character varying(200)"}},
+		{"SELECT a.name, b.title FROM authors a FULL JOIN books b ON a.id = b.author_id",
+			[]string{"name text", "title character varying(200)"}},
+		{"SELECT count(*), count(bio), max(authors.id), sum(price) FROM authors, books",
+			[]string{"count bigint not null", "count bigint not null", "max bigint", "sum numeric"}},
+		{"SELECT COALESCE(bio, ''), COALESCE(bio, born::text), bio IS NULL FROM authors",
+			[]string{"coalesce text not null", "coalesce text", "?column? boolean not null"}},
+		{"SELECT id + 1, name || bio, upper(name), name::varchar(10) FROM authors",
+			[]string{"?column? bigint not null", "?column? text", "upper text", "name character varying(10) not null"}},
+		{"SELECT row_number() OVER (), rank() OVER (ORDER BY name) FROM authors",
+			[]string{"row_number bigint not null", "rank bigint not null"}},
+		{"SELECT EXISTS (SELECT 1 FROM books), (SELECT max(id) FROM books)",
+			[]string{"exists boolean not null", "max integer"}},
+		{"SELECT x FROM (SELECT name AS x FROM authors) s", []string{"x text not null"}},
+		{"WITH w AS (SELECT id, bio FROM authors) SELECT * FROM w", []string{"id bigint not null", "bio text"}},
+		{"SELECT * FROM (VALUES (1, NULL), (2, 'a')) v(a, b)", []string{"a integer not null", "b text"}},
+		{"SELECT name FROM authors UNION SELECT title FROM books", []string{"name text not null"}},
+		{"SELECT CASE WHEN id > 1 THEN name ELSE 'x' END AS c, CASE WHEN id > 1 THEN bio END AS d FROM authors",
+			[]string{"c text not null", "d text"}},
+		{"INSERT INTO authors (name) VALUES ($1) RETURNING id, bio", []string{"id bigint not null", "bio text"}},
+		{"UPDATE books SET title = $1 RETURNING *",
+			[]string{"id integer not null", "author_id bigint not null", "title character varying(200) not null", "tags text[]", "price numeric(10,2)"}},
+		{"INSERT INTO authors (name) VALUES ('x')", nil},
+		{"DELETE FROM books", nil},
+	}
+	for _, tt := range tests {
+		d, err := Describe(tt.sql, cat)
+		if err != nil {
+			t.Errorf("Describe(%s) error: %v", tt.sql, err)
+			continue
+		}
+		if tt.want == nil {
+			if d.Columns != nil {
+				t.Errorf("Describe(%s) columns = %q, want none", tt.sql, formatColumns(d.Columns))
+			}
+			continue
+		}
+		if got := formatColumns(d.Columns); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Describe(%s) columns = %q, want %q", tt.sql, got, tt.want)
+		}
+	}
+}
+
+func TestDescribeOrigin(t *testing.T) {
+	cat, err := FromDDL(testSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := Describe("SELECT b.title AS t, b.id + 1 FROM books b", cat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := d.Columns[0]; c.Table != "public.books" || c.TableColumn != "title" {
+		t.Errorf("column t origin = %s.%s, want public.books.title", c.Table, c.TableColumn)
+	}
+	if c := d.Columns[1]; c.Table != "" || c.TableColumn != "" {
+		t.Errorf("column ?column? origin = %s.%s, want none", c.Table, c.TableColumn)
+	}
+}
+
+func TestDescribeErrors(t *testing.T) {
+	cat, err := FromDDL(testSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{"SELECT 1; SELECT 2", "cannot insert multiple commands into a prepared statement"},
+		{"SELECT nope FROM authors", `column "nope" does not exist`},
+		{"SELECT * FROM missing", `relation "missing" does not exist`},
+		{"SELECT $1 = $2", "operator is not unique: unknown = unknown"},
+		{"SELECT * FROM authors WHERE id = $2", "could not determine data type of parameter $1"},
+		{"SELECT slugify(name) FROM authors", "cannot infer the type of function slugify(text): it is not in the catalog"},
+	}
+	for _, tt := range tests {
+		_, err := Describe(tt.sql, cat)
+		if err == nil {
+			t.Errorf("Describe(%s) succeeded, want error %q", tt.sql, tt.want)
+			continue
+		}
+		if err.Error() != tt.want {
+			t.Errorf("Describe(%s) error = %q, want %q", tt.sql, err.Error(), tt.want)
+		}
+	}
+}
+
+func TestFromFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "schema.sql")
+	if err := os.WriteFile(path, []byte(testSchema), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cat, err := FromFiles(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Describe("SELECT title FROM books", cat); err != nil {
+		t.Error(err)
+	}
+
+	bad := filepath.Join(dir, "bad.sql")
+	if err := os.WriteFile(bad, []byte("CREATE TABLE books (id int);"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = FromFiles(path, bad)
+	if err == nil || !strings.HasPrefix(err.Error(), bad+": ") {
+		t.Errorf("FromFiles error = %v, want one naming %s", err, bad)
+	}
+}
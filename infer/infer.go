@@ -0,0 +1,125 @@
+// Package infer works out the types of SQL expressions and statements
+// without running them, for tools that generate code from queries.
+//
+// Types are resolved by the parse analysis of package sema against a
+// sema.Catalog, so casts, operators, overloaded and polymorphic functions,
+// CASE and COALESCE come out with the types PostgreSQL would give them,
+// following func_select_candidate and the coercion rules.
+//
+// The built-in objects are those of PostgreSQL 17's pg_type and pg_cast.
+// Built-in functions and operators come from the builtinProcTable and
+// builtinOperatorTable of package sema, which list functions and operators
+// of pg_proc and pg_operator without their OIDs; pgsema-gen -catalog
+// generates them from PostgreSQL's catalog data files. Aggregates, window
+// functions and functions with named or OUT parameters are described by
+// hand. An expression whose type depends on a function or operator missing
+// from these tables fails with an *UnknownBuiltinError rather than coming
+// out with no type.
+package infer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pgplex/pgparser/catalog"
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/sema"
+)
+
+// Type is a data type together with its typmod.
+type Type struct {
+	Oid    nodes.Oid
+	Typmod int32  // -1 if the type has no modifier
+	Name   string // as format_type prints it, such as "character varying(10)"
+}
+
+// String returns the name of the type.
+func (t Type) String() string {
+	return t.Name
+}
+
+// Builtin returns a catalog holding only the built-in objects of
+// pg_catalog, with the subset of functions and operators described in the
+// package comment.
+func Builtin() sema.Catalog {
+	return sema.FromCatalog(catalog.New())
+}
+
+// Expr returns the type of a raw expression, such as the Val of a
+// ResTarget: a TypeCast, A_Expr, FuncCall, CaseExpr, CoalesceExpr,
+// A_Const or any other expression the parser produces. The expression
+// may not refer to columns. An untyped literal is text, as it would be
+// in a select list. If the type depends on a function or operator that
+// cat does not have, the error is an *UnknownBuiltinError.
+func Expr(expr nodes.Node, cat sema.Catalog) (Type, error) {
+	sel := &nodes.SelectStmt{
+		TargetList: &nodes.List{Items: []nodes.Node{&nodes.ResTarget{Val: expr, Location: -1}}},
+	}
+	q, _, unresolved, err := sema.AnalyzeUnresolved(sel, cat, nil)
+	if err != nil {
+		return Type{}, err
+	}
+	var tles []nodes.Node
+	if q.TargetList != nil {
+		tles = q.TargetList.Items
+	}
+	if len(tles) != 1 {
+		return Type{}, fmt.Errorf("expression yields %d columns", len(tles))
+	}
+	tle := tles[0].(*nodes.TargetEntry)
+	t := exprType(tle.Expr, cat)
+	if t.Oid == nodes.InvalidOid {
+		if err := unknownBuiltin(unresolved, cat); err != nil {
+			return Type{}, err
+		}
+	}
+	return t, nil
+}
+
+// UnknownBuiltinError reports that the type of an expression could not be
+// inferred because it calls a function or operator the catalog does not
+// have. Since Builtin holds only part of pg_proc and pg_operator, it may
+// be a built-in that PostgreSQL knows; it may also be a user-defined one
+// missing from the DDL the catalog was built from.
+type UnknownBuiltinError struct {
+	Kind     string         // "function" or "operator"
+	Name     string         // as written, such as "gen_random_uuid" or "<->"
+	ArgTypes []Type         // types of the arguments or operands
+	Position nodes.ParseLoc // location of the call, or -1
+}
+
+func (e *UnknownBuiltinError) Error() string {
+	var names []string
+	for _, t := range e.ArgTypes {
+		names = append(names, t.Name)
+	}
+	call := e.Name + "(" + strings.Join(names, ", ") + ")"
+	if e.Kind == "operator" {
+		// A prefix operator has only a right operand.
+		call = e.Name + " " + names[len(names)-1]
+		if len(names) == 2 {
+			call = names[0] + " " + call
+		}
+	}
+	return fmt.Sprintf("cannot infer the type of %s %s: it is not in the catalog", e.Kind, call)
+}
+
+// unknownBuiltin returns an UnknownBuiltinError for the first of the
+// calls that sema left unresolved, or nil if there are none.
+func unknownBuiltin(calls []sema.UnresolvedCall, cat sema.Catalog) error {
+	if len(calls) == 0 {
+		return nil
+	}
+	c := calls[0]
+	e := &UnknownBuiltinError{Kind: c.Kind, Name: c.Name, Position: c.Location}
+	for _, oid := range c.ArgTypes {
+		e.ArgTypes = append(e.ArgTypes, Type{Oid: oid, Typmod: -1, Name: sema.FormatType(cat, oid, -1)})
+	}
+	return e
+}
+
+// exprType returns the type of an analyzed expression.
+func exprType(expr nodes.Node, cat sema.Catalog) Type {
+	oid, typmod := sema.ExprType(expr), sema.ExprTypmod(expr)
+	return Type{Oid: oid, Typmod: typmod, Name: sema.FormatType(cat, oid, typmod)}
+}
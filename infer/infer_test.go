@@ -0,0 +1,143 @@
+package infer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+)
+
+// parseExpr parses sql as the single target of a SELECT.
+func parseExpr(t *testing.T, sql string) nodes.Node {
+	t.Helper()
+	list, err := parser.Parse("SELECT " + sql)
+	if err != nil {
+		t.Fatalf("Parse(%q) error: %v", sql, err)
+	}
+	sel := list.Items[0].(*nodes.SelectStmt)
+	return sel.TargetList.Items[0].(*nodes.ResTarget).Val
+}
+
+func TestExpr(t *testing.T) {
+	cat := Builtin()
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		// Literals.
+		{"1", "integer"},
+		{"3000000000", "bigint"},
+		{"1.5", "numeric"},
+		{"'x'", "text"},
+		{"true", "boolean"},
+		{"NULL", "text"},
+
+		// Casts.
+		{"'1'::int8", "bigint"},
+		{"CAST('a' AS varchar(10))", "character varying(10)"},
+		{"'{1,2}'::int[]", "integer[]"},
+		{"int4range('[1,2)')", "int4range"},
+		{"text(1)", "text"},
+
+		// Operators.
+		{"1 + 2", "integer"},
+		{"1 + 2.5", "numeric"},
+		{"1::int2 + 1::int8", "bigint"},
+		{"2 ^ 3", "double precision"},
+		{"now() - interval '1 day'", "timestamp with time zone"},
+		{"date '2024-01-01' - date '2023-01-01'", "integer"},
+		{"'a' || 'b'", "text"},
+		{"'a' || 1", "text"},
+		{"'{\"a\":1}'::jsonb ->> 'a'", "text"},
+		{"1 < 2", "boolean"},
+		{"'x' LIKE 'y'", "boolean"},
+
+		// Function overloads.
+		{"length('abc')", "integer"},
+		{"round(1.5)", "numeric"},
+		{"round(1.5::float8)", "double precision"},
+		{"round(1)", "double precision"},
+		{"abs(-1::int2)", "smallint"},
+		{"concat('a', 1, now())", "text"},
+		{"substring('abc' FROM 2)", "text"},
+		{"position('b' IN 'abc')", "integer"},
+		{"extract(year FROM now())", "numeric"},
+		{"date_trunc('day', now())", "timestamp with time zone"},
+		{"now() AT TIME ZONE 'UTC'", "timestamp without time zone"},
+		{"generate_series(1, 3)", "integer"},
+		{"generate_series(date '2024-01-01', '2024-02-01', '1 day')", "timestamp with time zone"},
+		{"make_interval(days => 1)", "interval"},
+		{"jsonb_set('{}', '{a}', '1')", "jsonb"},
+		{"jsonb_build_object('a', 1)", "jsonb"},
+		{"to_jsonb(1)", "jsonb"},
+
+		// Polymorphic functions.
+		{"array_append(ARRAY[1, 2], 3)", "integer[]"},
+		{"array_append(ARRAY[1, 2], 2.5)", "numeric[]"},
+		{"unnest(ARRAY['a', 'b'])", "text"},
+		{"lower(int4range(1, 5))", "integer"},
+		{"upper('abc')", "text"},
+		{"array_length(ARRAY[1], 1)", "integer"},
+
+		// Conditional expressions.
+		{"CASE WHEN true THEN 1 ELSE 2.5 END", "numeric"},
+		{"CASE WHEN true THEN 'a' END", "text"},
+		{"CASE 1 WHEN 1 THEN 1::int8 ELSE 2 END", "bigint"},
+		{"COALESCE(NULL, 1::int8, 2)", "bigint"},
+		{"COALESCE('a', 'b')", "text"},
+		{"NULLIF(1, 2)", "integer"},
+		{"GREATEST(1, 2.5)", "numeric"},
+	}
+	for _, tt := range tests {
+		got, err := Expr(parseExpr(t, tt.sql), cat)
+		if err != nil {
+			t.Errorf("Expr(%s) error: %v", tt.sql, err)
+			continue
+		}
+		if got.Name != tt.want {
+			t.Errorf("Expr(%s) = %s, want %s", tt.sql, got, tt.want)
+		}
+	}
+}
+
+func TestExprErrors(t *testing.T) {
+	cat := Builtin()
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{"date_trunc('day', '2024-01-01')", "function date_trunc(unknown, unknown) is not unique"},
+		{"length(1)", "function length(integer) does not exist"},
+		{"CASE WHEN true THEN 1 ELSE 'true'::bool END", "CASE types boolean and integer cannot be matched"},
+		{"a + 1", `column "a" does not exist`},
+		{"point '(0,0)' <-> point '(1,1)'", "cannot infer the type of operator point <-> point: it is not in the catalog"},
+		{"nope(1) + 1", "cannot infer the type of function nope(integer): it is not in the catalog"},
+	}
+	for _, tt := range tests {
+		_, err := Expr(parseExpr(t, tt.sql), cat)
+		if err == nil {
+			t.Errorf("Expr(%s) succeeded, want error %q", tt.sql, tt.want)
+			continue
+		}
+		if err.Error() != tt.want {
+			t.Errorf("Expr(%s) error = %q, want %q", tt.sql, err.Error(), tt.want)
+		}
+	}
+}
+
+func TestExprUnknownBuiltin(t *testing.T) {
+	_, err := Expr(parseExpr(t, "nope(1, 'a') || 'x'"), Builtin())
+	var ub *UnknownBuiltinError
+	if !errors.As(err, &ub) {
+		t.Fatalf("Expr error = %v, want *UnknownBuiltinError", err)
+	}
+	if ub.Kind != "function" || ub.Name != "nope" || len(ub.ArgTypes) != 2 || ub.ArgTypes[1].Name != "unknown" {
+		t.Errorf("UnknownBuiltinError = %+v", ub)
+	}
+
+	// A cast gives the call a type even though the call is unknown.
+	if typ, err := Expr(parseExpr(t, "nope(1)::int"), Builtin()); err != nil || typ.Name != "integer" {
+		t.Errorf("Expr(nope(1)::int) = %v, %v, want integer", typ, err)
+	}
+}
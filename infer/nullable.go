@@ -0,0 +1,259 @@
+package infer
+
+import (
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/sema"
+)
+
+// nullness decides whether expressions of an analyzed query can be null.
+// The analysis is conservative: an expression is not null only when that
+// follows from NOT NULL columns, non-null constants and the semantics of
+// the nodes above them.
+type nullness struct {
+	q      *nodes.Query
+	cat    sema.Catalog
+	parent *nullness    // the query level above, for outer references
+	outer  map[int]bool // range table indexes on the nullable side of an outer join
+	all    bool         // every Var may be null, as under GROUPING SETS
+}
+
+func newNullness(q *nodes.Query, cat sema.Catalog, parent *nullness) *nullness {
+	n := &nullness{q: q, cat: cat, parent: parent, outer: map[int]bool{}}
+	n.all = q.GroupingSets != nil && len(q.GroupingSets.Items) > 0
+	if q.Jointree != nil && q.Jointree.Fromlist != nil {
+		for _, item := range q.Jointree.Fromlist.Items {
+			n.markOuter(item, false)
+		}
+	}
+	return n
+}
+
+// markOuter records the range table entries below node that an outer join
+// may null-extend. nullable is set when node is itself on the nullable
+// side of a join above it.
+func (n *nullness) markOuter(node nodes.Node, nullable bool) {
+	switch node := node.(type) {
+	case *nodes.RangeTblRef:
+		if nullable {
+			n.outer[node.Rtindex] = true
+		}
+	case *nodes.JoinExpr:
+		if nullable {
+			n.outer[node.Rtindex] = true
+		}
+		full := node.Jointype == nodes.JOIN_FULL
+		n.markOuter(node.Larg, nullable || full || node.Jointype == nodes.JOIN_RIGHT)
+		n.markOuter(node.Rarg, nullable || full || node.Jointype == nodes.JOIN_LEFT)
+	case *nodes.FromExpr:
+		if node.Fromlist != nil {
+			for _, item := range node.Fromlist.Items {
+				n.markOuter(item, nullable)
+			}
+		}
+	}
+}
+
+// notNull reports whether expr, evaluated in the query of n, can never
+// be null.
+func (n *nullness) notNull(expr nodes.Node) bool {
+	switch e := expr.(type) {
+	case *nodes.Var:
+		return n.varNotNull(e)
+	case *nodes.Const:
+		return !e.Constisnull
+	case *nodes.RelabelType:
+		return n.notNull(e.Arg)
+	case *nodes.CoerceViaIO:
+		return n.notNull(e.Arg)
+	case *nodes.ArrayCoerceExpr:
+		return n.notNull(e.Arg)
+	case *nodes.CoerceToDomain:
+		return n.notNull(e.Arg)
+	case *nodes.CollateExpr:
+		return n.notNull(e.Arg)
+	case *nodes.FuncExpr:
+		// Only casts are known to be strict and never to return null
+		// for a non-null input.
+		if e.Funcformat != nodes.COERCE_EXPLICIT_CAST && e.Funcformat != nodes.COERCE_IMPLICIT_CAST {
+			return false
+		}
+		return n.allNotNull(e.Args)
+	case *nodes.OpExpr:
+		// The json and jsonb extraction operators yield null for a
+		// missing key or element.
+		if e.Args != nil && len(e.Args.Items) == 2 && e.Opresulttype != sema.BOOLOID {
+			if t := sema.ExprType(e.Args.Items[0]); t == sema.JSONOID || t == sema.JSONBOID {
+				return false
+			}
+		}
+		return n.allNotNull(e.Args)
+	case *nodes.DistinctExpr, *nodes.NullTest, *nodes.BooleanTest:
+		return true
+	case *nodes.ScalarArrayOpExpr:
+		if e.Args == nil || len(e.Args.Items) != 2 || !n.notNull(e.Args.Items[0]) {
+			return false
+		}
+		arr, ok := e.Args.Items[1].(*nodes.ArrayExpr)
+		return ok && n.allNotNull(arr.Elements)
+	case *nodes.BoolExpr:
+		return n.allNotNull(e.Args)
+	case *nodes.CaseExpr:
+		if e.Defresult == nil || !n.notNull(e.Defresult) {
+			return false
+		}
+		if e.Args != nil {
+			for _, w := range e.Args.Items {
+				if !n.notNull(w.(*nodes.CaseWhen).Result) {
+					return false
+				}
+			}
+		}
+		return true
+	case *nodes.CoalesceExpr:
+		return n.anyNotNull(e.Args)
+	case *nodes.MinMaxExpr:
+		return n.anyNotNull(e.Args)
+	case *nodes.ArrayExpr, *nodes.RowExpr, *nodes.SQLValueFunction, *nodes.GroupingFunc:
+		return true
+	case *nodes.SubLink:
+		return e.SubLinkType == nodes.EXISTS_SUBLINK || e.SubLinkType == nodes.ARRAY_SUBLINK
+	case *nodes.Aggref:
+		return e.Aggfnoid == sema.F_COUNT_ || e.Aggfnoid == sema.F_COUNT_ANY
+	case *nodes.WindowFunc:
+		switch e.Winfnoid {
+		case sema.F_COUNT_, sema.F_COUNT_ANY, sema.F_ROW_NUMBER, sema.F_RANK_,
+			sema.F_DENSE_RANK_, sema.F_PERCENT_RANK_, sema.F_CUME_DIST_:
+			return true
+		}
+	}
+	return false
+}
+
+func (n *nullness) allNotNull(args *nodes.List) bool {
+	if args == nil {
+		return true
+	}
+	for _, a := range args.Items {
+		if !n.notNull(a) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *nullness) anyNotNull(args *nodes.List) bool {
+	if args == nil {
+		return false
+	}
+	for _, a := range args.Items {
+		if n.notNull(a) {
+			return true
+		}
+	}
+	return false
+}
+
+// varNotNull reports whether a column reference can never be null.
+func (n *nullness) varNotNull(v *nodes.Var) bool {
+	s := n
+	for i := uint32(0); i < v.Varlevelsup && s != nil; i++ {
+		s = s.parent
+	}
+	if s == nil || s.all || s.outer[v.Varno] {
+		return false
+	}
+	if s.q.Rtable == nil || v.Varno < 1 || v.Varno > len(s.q.Rtable.Items) {
+		return false
+	}
+	rte := s.q.Rtable.Items[v.Varno-1].(*nodes.RangeTblEntry)
+	if v.Varattno <= 0 {
+		// System columns and whole-row references.
+		return rte.Rtekind == nodes.RTE_RELATION || v.Varattno == 0
+	}
+	switch rte.Rtekind {
+	case nodes.RTE_RELATION:
+		rel := s.cat.RelationByOid(rte.Relid)
+		if rel == nil || int(v.Varattno) > len(rel.Columns) {
+			return false
+		}
+		return rel.Columns[v.Varattno-1].NotNull
+	case nodes.RTE_SUBQUERY:
+		return s.columnNotNull(rte.Subquery, v.Varattno)
+	case nodes.RTE_JOIN:
+		if rte.Joinaliasvars == nil || int(v.Varattno) > len(rte.Joinaliasvars.Items) {
+			return false
+		}
+		alias := rte.Joinaliasvars.Items[v.Varattno-1]
+		return alias != nil && s.notNull(alias)
+	case nodes.RTE_CTE:
+		cs := s
+		for i := uint32(0); i < rte.Ctelevelsup && cs != nil; i++ {
+			cs = cs.parent
+		}
+		if cs == nil || cs.q.CteList == nil || rte.SelfReference {
+			return false
+		}
+		for _, item := range cs.q.CteList.Items {
+			cte := item.(*nodes.CommonTableExpr)
+			if cte.Ctename != rte.Ctename {
+				continue
+			}
+			cq, ok := cte.Ctequery.(*nodes.Query)
+			return ok && !cte.Cterecursive && cs.columnNotNull(cq, v.Varattno)
+		}
+	case nodes.RTE_VALUES:
+		if rte.ValuesLists == nil {
+			return false
+		}
+		for _, row := range rte.ValuesLists.Items {
+			exprs := row.(*nodes.List)
+			if int(v.Varattno) > len(exprs.Items) || !s.notNull(exprs.Items[v.Varattno-1]) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// columnNotNull reports whether output column attno of q, a subquery of
+// the query of n, can never be null.
+func (n *nullness) columnNotNull(q *nodes.Query, attno nodes.AttrNumber) bool {
+	if q.SetOperations != nil {
+		return n.setOpNotNull(q, q.SetOperations, attno)
+	}
+	tlist := q.TargetList
+	if q.CommandType != nodes.CMD_SELECT {
+		tlist = q.ReturningList
+	}
+	if tlist == nil {
+		return false
+	}
+	for _, item := range tlist.Items {
+		tle := item.(*nodes.TargetEntry)
+		if tle.Resno == attno && !tle.Resjunk {
+			return newNullness(q, n.cat, n).notNull(tle.Expr)
+		}
+	}
+	return false
+}
+
+// setOpNotNull reports whether output column attno of a set operation
+// tree of q can never be null.
+func (n *nullness) setOpNotNull(q *nodes.Query, op nodes.Node, attno nodes.AttrNumber) bool {
+	switch op := op.(type) {
+	case *nodes.RangeTblRef:
+		rte := q.Rtable.Items[op.Rtindex-1].(*nodes.RangeTblEntry)
+		return rte.Subquery != nil && newNullness(q, n.cat, n).columnNotNull(rte.Subquery, attno)
+	case *nodes.SetOperationStmt:
+		switch op.Op {
+		case nodes.SETOP_INTERSECT:
+			return n.setOpNotNull(q, op.Larg, attno) || n.setOpNotNull(q, op.Rarg, attno)
+		case nodes.SETOP_EXCEPT:
+			return n.setOpNotNull(q, op.Larg, attno)
+		default:
+			return n.setOpNotNull(q, op.Larg, attno) && n.setOpNotNull(q, op.Rarg, attno)
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package lineage
+
+// Edge records that values of Source flow into Target.
+type Edge struct {
+	Source Column `json:"source"`
+	Target Column `json:"target"`
+	Direct bool   `json:"direct"` // Target is a plain copy of Source
+}
+
+// Graph is a column lineage graph over the relations of a schema. It
+// encodes to JSON as a list of edges.
+type Graph struct {
+	Edges []Edge `json:"edges"`
+}
+
+// Add adds the edges of a statement's lineage to g. Outputs that are not
+// relation columns, such as the result columns of a SELECT, are skipped,
+// as are edges g already has.
+func (g *Graph) Add(l *Lineage) {
+	for _, out := range l.Outputs {
+		if out.Column.Table == "" {
+			continue
+		}
+		for _, src := range out.Sources {
+			e := Edge{Source: src, Target: out.Column, Direct: out.Direct}
+			if !g.has(e) {
+				g.Edges = append(g.Edges, e)
+			}
+		}
+	}
+}
+
+func (g *Graph) has(e Edge) bool {
+	for _, x := range g.Edges {
+		if x == e {
+			return true
+		}
+	}
+	return false
+}
+
+// Upstream returns the columns whose values flow into c, directly or
+// through other columns of the graph, nearest first.
+func (g *Graph) Upstream(c Column) []Column {
+	return g.reach(c, func(e Edge) (Column, Column) { return e.Target, e.Source })
+}
+
+// Downstream returns the columns that values of c flow into, directly or
+// through other columns of the graph, nearest first.
+func (g *Graph) Downstream(c Column) []Column {
+	return g.reach(c, func(e Edge) (Column, Column) { return e.Source, e.Target })
+}
+
+// reach returns the columns reachable from c, breadth first, following
+// edges from the first column dir returns to the second.
+func (g *Graph) reach(c Column, dir func(Edge) (Column, Column)) []Column {
+	var out []Column
+	seen := map[Column]bool{c: true}
+	queue := []Column{c}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, e := range g.Edges {
+			from, to := dir(e)
+			if from == cur && !seen[to] {
+				seen[to] = true
+				out = append(out, to)
+				queue = append(queue, to)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,208 @@
+// Package lineage computes column-level lineage: for each column a
+// statement produces or writes, the table columns whose values flow into
+// it.
+//
+// Statements are analyzed by package sema, so references are resolved the
+// way PostgreSQL resolves them: "*" is expanded against the catalog,
+// JOIN USING and NATURAL joins merge their columns, and references into
+// subqueries in FROM, CTEs and VALUES lists are followed down to the
+// relations they read. Columns that only filter rows, such as those in
+// WHERE or JOIN ON, are not sources.
+package lineage
+
+import (
+	"github.com/pgplex/pgparser/catalog"
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+	"github.com/pgplex/pgparser/sema"
+)
+
+// Column identifies a column of a relation, or an output column of a
+// query when Table is "".
+type Column struct {
+	Schema string `json:"schema,omitempty"`
+	Table  string `json:"table,omitempty"`
+	Name   string `json:"column"`
+}
+
+// String returns the column as a dotted name.
+func (c Column) String() string {
+	s := c.Name
+	if c.Table != "" {
+		s = c.Table + "." + s
+	}
+	if c.Schema != "" {
+		s = c.Schema + "." + s
+	}
+	return s
+}
+
+// Output is a column produced or written by a statement.
+type Output struct {
+	Column  Column   `json:"column"`
+	Sources []Column `json:"sources"` // in order of first reference
+
+	// Direct reports that the column is a plain copy of its single
+	// source, possibly through subqueries, CTEs and joins, rather than
+	// computed from it.
+	Direct bool `json:"direct"`
+}
+
+// Lineage is the column lineage of one statement.
+type Lineage struct {
+	Outputs []Output `json:"outputs"`
+}
+
+// Of returns the column lineage of a statement:
+//
+//   - SELECT: its result columns, with an empty Table;
+//   - CREATE VIEW, CREATE MATERIALIZED VIEW, CREATE TABLE AS and
+//     SELECT INTO: the columns of the new relation;
+//   - INSERT and UPDATE: the target columns written.
+//
+// For a new relation whose name is not schema-qualified, Schema is "".
+// Of returns nil for other statements.
+func Of(stmt nodes.Node, cat sema.Catalog) (*Lineage, error) {
+	if raw, ok := stmt.(*nodes.RawStmt); ok {
+		stmt = raw.Stmt
+	}
+	var (
+		query   nodes.Node
+		target  *nodes.RangeVar
+		aliases *nodes.List
+	)
+	switch n := stmt.(type) {
+	case *nodes.SelectStmt:
+		query = n
+		if n.IntoClause != nil {
+			sel := *n
+			sel.IntoClause = nil
+			query, target, aliases = &sel, n.IntoClause.Rel, n.IntoClause.ColNames
+		}
+	case *nodes.ViewStmt:
+		query, target, aliases = n.Query, n.View, n.Aliases
+	case *nodes.CreateTableAsStmt:
+		if n.Into == nil {
+			return nil, nil
+		}
+		query, target, aliases = n.Query, n.Into.Rel, n.Into.ColNames
+	case *nodes.InsertStmt, *nodes.UpdateStmt:
+		query = n
+	default:
+		return nil, nil
+	}
+
+	q, err := sema.Analyze(query, cat)
+	if err != nil {
+		return nil, err
+	}
+	if q.CommandType == nodes.CMD_UTILITY {
+		return nil, nil
+	}
+	t := &tracer{cat: cat, active: map[colKey]bool{}}
+	s := &scope{q: q}
+	l := &Lineage{Outputs: []Output{}}
+
+	var rel *sema.Relation
+	if q.CommandType == nodes.CMD_INSERT || q.CommandType == nodes.CMD_UPDATE {
+		rte := q.Rtable.Items[q.ResultRelation-1].(*nodes.RangeTblEntry)
+		if rel = cat.RelationByOid(rte.Relid); rel == nil {
+			return nil, nil
+		}
+	}
+	i := 0
+	for _, item := range items(q.TargetList) {
+		tle := item.(*nodes.TargetEntry)
+		if tle.Resjunk {
+			continue
+		}
+		out := Output{Column: Column{Name: tle.Resname}}
+		switch {
+		case rel != nil:
+			if int(tle.Resno) > len(rel.Columns) {
+				continue
+			}
+			out.Column = Column{Schema: rel.Schema, Table: rel.Name, Name: rel.Columns[tle.Resno-1].Name}
+		case target != nil:
+			out.Column.Schema, out.Column.Table = target.Schemaname, target.Relname
+			if a := items(aliases); i < len(a) {
+				out.Column.Name = a[i].(*nodes.String).Sval
+			}
+		}
+		i++
+		var set columnSet
+		if q.SetOperations != nil {
+			t.column(q, tle.Resno, nil, &set)
+		} else {
+			t.expr(tle.Expr, s, &set)
+			out.Direct = t.direct(tle.Expr, s)
+		}
+		out.Sources = set.cols
+		if out.Sources == nil {
+			out.Sources = []Column{}
+		}
+		l.Outputs = append(l.Outputs, out)
+	}
+	return l, nil
+}
+
+// Script computes the lineage of every statement of a SQL script and
+// collects it into a graph. DDL statements are applied to c as they are
+// reached, so later statements see the relations created by earlier
+// ones; c is left reflecting the whole script.
+func Script(sql string, c *catalog.Catalog) (*Graph, error) {
+	stmts, err := parser.Parse(sql)
+	if err != nil {
+		return nil, err
+	}
+	g := &Graph{Edges: []Edge{}}
+	for _, stmt := range items(stmts) {
+		l, err := Of(stmt, sema.FromCatalog(c))
+		if err != nil {
+			return nil, err
+		}
+		if err := c.Apply(stmt); err != nil {
+			return nil, err
+		}
+		if l == nil {
+			continue
+		}
+		if rv := newRelation(stmt); rv != nil && rv.Schemaname == "" {
+			if rel := c.LookupRelation("", rv.Relname); rel != nil {
+				for i := range l.Outputs {
+					l.Outputs[i].Column.Schema = rel.Schema.Name
+				}
+			}
+		}
+		g.Add(l)
+	}
+	return g, nil
+}
+
+// newRelation returns the name of the relation a statement creates from a
+// query, or nil.
+func newRelation(stmt nodes.Node) *nodes.RangeVar {
+	if raw, ok := stmt.(*nodes.RawStmt); ok {
+		stmt = raw.Stmt
+	}
+	switch n := stmt.(type) {
+	case *nodes.ViewStmt:
+		return n.View
+	case *nodes.CreateTableAsStmt:
+		if n.Into != nil {
+			return n.Into.Rel
+		}
+	case *nodes.SelectStmt:
+		if n.IntoClause != nil {
+			return n.IntoClause.Rel
+		}
+	}
+	return nil
+}
+
+func items(l *nodes.List) []nodes.Node {
+	if l == nil {
+		return nil
+	}
+	return l.Items
+}
@@ -0,0 +1,178 @@
+package lineage
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgparser/catalog"
+	"github.com/pgplex/pgparser/parser"
+	"github.com/pgplex/pgparser/sema"
+)
+
+const testSchema = `
+CREATE TABLE customers (id int PRIMARY KEY, name text, email text, region text);
+CREATE TABLE orders (id int PRIMARY KEY, customer_id int, amount numeric, region text);
+CREATE SCHEMA dw;
+CREATE TABLE dw.sales (customer text, total numeric, region text);
+`
+
+// lineageOf returns the lineage of a single statement as
+// "column <- source, source" strings, with "=" for direct copies.
+func lineageOf(t *testing.T, sql string) []string {
+	t.Helper()
+	c := catalog.New()
+	if err := c.Exec(testSchema); err != nil {
+		t.Fatal(err)
+	}
+	stmts, err := parser.Parse(sql)
+	if err != nil {
+		t.Fatalf("Parse(%q) error: %v", sql, err)
+	}
+	l, err := Of(stmts.Items[0], sema.FromCatalog(c))
+	if err != nil {
+		t.Fatalf("Of(%q) error: %v", sql, err)
+	}
+	if l == nil {
+		return nil
+	}
+	var out []string
+	for _, o := range l.Outputs {
+		var srcs []string
+		for _, s := range o.Sources {
+			srcs = append(srcs, s.String())
+		}
+		arrow := " <- "
+		if o.Direct {
+			arrow = " = "
+		}
+		out = append(out, o.Column.String()+arrow+strings.Join(srcs, ", "))
+	}
+	return out
+}
+
+func TestOf(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want []string
+	}{
+		{"SELECT * FROM customers", []string{
+			"id = public.customers.id",
+			"name = public.customers.name",
+			"email = public.customers.email",
+			"region = public.customers.region",
+		}},
+		{"SELECT c.name, o.amount * 2 AS doubled FROM customers c JOIN orders o ON o.customer_id = c.id WHERE o.amount > 0", []string{
+			"name = public.customers.name",
+			"doubled <- public.orders.amount",
+		}},
+		// The merged column of a USING join reads both sides.
+		{"SELECT region FROM customers FULL JOIN orders USING (region)", []string{
+			"region <- public.customers.region, public.orders.region",
+		}},
+		{"SELECT region, id FROM customers NATURAL JOIN orders", []string{
+			"region = public.customers.region",
+			"id = public.customers.id",
+		}},
+		{"SELECT x.n FROM (SELECT name || email AS n FROM customers) x", []string{
+			"n <- public.customers.name, public.customers.email",
+		}},
+		{"WITH big AS (SELECT customer_id, sum(amount) AS s FROM orders GROUP BY 1) SELECT c.name, big.s FROM big JOIN customers c ON c.id = big.customer_id", []string{
+			"name = public.customers.name",
+			"s <- public.orders.amount",
+		}},
+		{"SELECT name FROM customers UNION SELECT region FROM orders", []string{
+			"name <- public.customers.name, public.orders.region",
+		}},
+		{"SELECT (SELECT max(amount) FROM orders o WHERE o.customer_id = c.id) AS top, count(*) FROM customers c GROUP BY c.id", []string{
+			"top <- public.orders.amount",
+			"count <- ",
+		}},
+		{"SELECT v.a FROM (VALUES (1), (2)) v(a)", []string{"a <- "}},
+		{"WITH RECURSIVE r(n) AS (SELECT id FROM orders UNION ALL SELECT n + 1 FROM r) SELECT n FROM r", []string{
+			"n <- public.orders.id",
+		}},
+		{"CREATE VIEW v (who, spent) AS SELECT c.name, sum(o.amount) FROM customers c JOIN orders o ON o.customer_id = c.id GROUP BY c.name", []string{
+			"v.who = public.customers.name",
+			"v.spent <- public.orders.amount",
+		}},
+		{"CREATE TABLE t2 AS SELECT email FROM customers", []string{"t2.email = public.customers.email"}},
+		{"INSERT INTO dw.sales (customer, total) SELECT c.name, o.amount FROM customers c JOIN orders o ON o.customer_id = c.id", []string{
+			"dw.sales.customer = public.customers.name",
+			"dw.sales.total = public.orders.amount",
+		}},
+		{"INSERT INTO dw.sales VALUES ('x', 1, 'eu')", []string{
+			"dw.sales.customer <- ",
+			"dw.sales.total <- ",
+			"dw.sales.region <- ",
+		}},
+		{"UPDATE dw.sales s SET region = c.region FROM customers c WHERE c.name = s.customer", []string{
+			"dw.sales.region = public.customers.region",
+		}},
+		{"CREATE INDEX ON customers (name)", nil},
+	}
+	for _, tt := range tests {
+		got := lineageOf(t, tt.sql)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Of(%s) =\n  %q\nwant\n  %q", tt.sql, got, tt.want)
+		}
+	}
+}
+
+func TestScript(t *testing.T) {
+	c := catalog.New()
+	if err := c.Exec(testSchema); err != nil {
+		t.Fatal(err)
+	}
+	g, err := Script(`
+		CREATE VIEW customer_orders AS
+			SELECT c.name, c.region, o.amount FROM customers c JOIN orders o ON o.customer_id = c.id;
+		INSERT INTO dw.sales (customer, total, region)
+			SELECT name, sum(amount), upper(region) FROM customer_orders GROUP BY name, region;
+	`, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, col := range g.Upstream(Column{Schema: "dw", Table: "sales", Name: "total"}) {
+		got = append(got, col.String())
+	}
+	want := []string{"public.customer_orders.amount", "public.orders.amount"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Upstream(dw.sales.total) = %q, want %q", got, want)
+	}
+
+	got = nil
+	for _, col := range g.Downstream(Column{Schema: "public", Table: "customers", Name: "region"}) {
+		got = append(got, col.String())
+	}
+	want = []string{"public.customer_orders.region", "dw.sales.region"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Downstream(public.customers.region) = %q, want %q", got, want)
+	}
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Graph
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&decoded, g) {
+		t.Errorf("JSON round trip changed the graph:\n%s", data)
+	}
+	if !strings.Contains(string(data), `{"source":{"schema":"public","table":"customers","column":"name"},"target":{"schema":"public","table":"customer_orders","column":"name"},"direct":true}`) {
+		t.Errorf("JSON lacks the customers.name edge:\n%s", data)
+	}
+}
+
+func TestScriptErrors(t *testing.T) {
+	c := catalog.New()
+	_, err := Script("CREATE VIEW v AS SELECT x FROM missing", c)
+	if err == nil || err.Error() != `relation "missing" does not exist` {
+		t.Errorf("Script error = %v", err)
+	}
+}
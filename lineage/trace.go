@@ -0,0 +1,238 @@
+package lineage
+
+import (
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/sema"
+)
+
+// scope is a query level of an analyzed statement. Vars with a nonzero
+// varlevelsup are resolved against the parent levels.
+type scope struct {
+	q      *nodes.Query
+	parent *scope
+}
+
+// up returns the scope levels above s, or nil.
+func (s *scope) up(levels uint32) *scope {
+	for ; levels > 0 && s != nil; levels-- {
+		s = s.parent
+	}
+	return s
+}
+
+// colKey identifies an output column of a query.
+type colKey struct {
+	q     *nodes.Query
+	attno nodes.AttrNumber
+}
+
+// tracer follows Vars down to the relation columns they read.
+type tracer struct {
+	cat sema.Catalog
+
+	// active holds the query columns being traced, so that the
+	// self-reference of a recursive CTE ends the search.
+	active map[colKey]bool
+}
+
+// columnSet is a set of columns that remembers insertion order.
+type columnSet struct {
+	cols []Column
+	seen map[Column]bool
+}
+
+func (s *columnSet) add(c Column) {
+	if s.seen == nil {
+		s.seen = map[Column]bool{}
+	}
+	if !s.seen[c] {
+		s.seen[c] = true
+		s.cols = append(s.cols, c)
+	}
+}
+
+// expr adds the columns that flow into the value of an expression
+// evaluated in scope s.
+func (t *tracer) expr(expr nodes.Node, s *scope, out *columnSet) {
+	nodes.Walk(expr, func(n nodes.Node) bool {
+		switch n := n.(type) {
+		case *nodes.Var:
+			t.variable(n, s, out)
+		case *nodes.SubLink:
+			// The value of an EXISTS, ANY or ALL sublink is a truth
+			// value; that of an expression or ARRAY sublink comes from
+			// the subquery's only column.
+			t.expr(n.Testexpr, s, out)
+			if q, ok := n.Subselect.(*nodes.Query); ok &&
+				(n.SubLinkType == nodes.EXPR_SUBLINK || n.SubLinkType == nodes.ARRAY_SUBLINK) {
+				t.column(q, 1, s, out)
+			}
+			return false
+		case *nodes.Query:
+			return false
+		}
+		return true
+	})
+}
+
+// variable adds the columns a Var of scope s refers to.
+func (t *tracer) variable(v *nodes.Var, s *scope, out *columnSet) {
+	if s = s.up(v.Varlevelsup); s == nil {
+		return
+	}
+	rtable := items(s.q.Rtable)
+	if v.Varno < 1 || v.Varno > len(rtable) {
+		return
+	}
+	rte := rtable[v.Varno-1].(*nodes.RangeTblEntry)
+	if v.Varattno < 0 {
+		return // system column
+	}
+	if v.Varattno == 0 {
+		// A whole-row reference reads every column.
+		if rte.Eref == nil {
+			return
+		}
+		for i := range items(rte.Eref.Colnames) {
+			t.variable(&nodes.Var{Varno: v.Varno, Varattno: nodes.AttrNumber(i + 1)}, s, out)
+		}
+		return
+	}
+	switch rte.Rtekind {
+	case nodes.RTE_RELATION:
+		rel := t.cat.RelationByOid(rte.Relid)
+		if rel != nil && int(v.Varattno) <= len(rel.Columns) {
+			out.add(Column{Schema: rel.Schema, Table: rel.Name, Name: rel.Columns[v.Varattno-1].Name})
+		}
+	case nodes.RTE_SUBQUERY:
+		t.column(rte.Subquery, v.Varattno, s, out)
+	case nodes.RTE_JOIN:
+		if alias := items(rte.Joinaliasvars); int(v.Varattno) <= len(alias) {
+			t.expr(alias[v.Varattno-1], s, out)
+		}
+	case nodes.RTE_CTE:
+		if cs, cq := cteQuery(rte, s); cq != nil {
+			t.column(cq, v.Varattno, cs, out)
+		}
+	case nodes.RTE_VALUES:
+		for _, row := range items(rte.ValuesLists) {
+			if exprs := items(row.(*nodes.List)); int(v.Varattno) <= len(exprs) {
+				t.expr(exprs[v.Varattno-1], s, out)
+			}
+		}
+	case nodes.RTE_FUNCTION:
+		// The columns of a function in FROM derive from its arguments.
+		for _, f := range items(rte.Functions) {
+			t.expr(f.(*nodes.RangeTblFunction).Funcexpr, s, out)
+		}
+	}
+}
+
+// column adds the columns that flow into output column attno of q, a
+// query evaluated one level below scope parent.
+func (t *tracer) column(q *nodes.Query, attno nodes.AttrNumber, parent *scope, out *columnSet) {
+	key := colKey{q, attno}
+	if t.active[key] {
+		return
+	}
+	t.active[key] = true
+	defer delete(t.active, key)
+
+	s := &scope{q: q, parent: parent}
+	if q.SetOperations != nil {
+		// Every branch of a set operation contributes.
+		nodes.Walk(q.SetOperations, func(n nodes.Node) bool {
+			if ref, ok := n.(*nodes.RangeTblRef); ok {
+				rte := q.Rtable.Items[ref.Rtindex-1].(*nodes.RangeTblEntry)
+				t.column(rte.Subquery, attno, s, out)
+			}
+			return true
+		})
+		return
+	}
+	if tle := outputColumn(q, attno); tle != nil {
+		t.expr(tle.Expr, s, out)
+	}
+}
+
+// direct reports whether expr, evaluated in scope s, copies a single
+// relation column unchanged.
+func (t *tracer) direct(expr nodes.Node, s *scope) bool {
+	for {
+		r, ok := expr.(*nodes.RelabelType)
+		if !ok {
+			break
+		}
+		expr = r.Arg
+	}
+	v, ok := expr.(*nodes.Var)
+	if !ok || v.Varattno <= 0 {
+		return false
+	}
+	if s = s.up(v.Varlevelsup); s == nil {
+		return false
+	}
+	rtable := items(s.q.Rtable)
+	if v.Varno < 1 || v.Varno > len(rtable) {
+		return false
+	}
+	rte := rtable[v.Varno-1].(*nodes.RangeTblEntry)
+	switch rte.Rtekind {
+	case nodes.RTE_RELATION:
+		return true
+	case nodes.RTE_SUBQUERY:
+		return t.directColumn(rte.Subquery, v.Varattno, s)
+	case nodes.RTE_JOIN:
+		alias := items(rte.Joinaliasvars)
+		return int(v.Varattno) <= len(alias) && t.direct(alias[v.Varattno-1], s)
+	case nodes.RTE_CTE:
+		cs, cq := cteQuery(rte, s)
+		return cq != nil && t.directColumn(cq, v.Varattno, cs)
+	}
+	return false
+}
+
+// directColumn reports whether output column attno of q copies a single
+// relation column unchanged.
+func (t *tracer) directColumn(q *nodes.Query, attno nodes.AttrNumber, parent *scope) bool {
+	key := colKey{q, attno}
+	if q.SetOperations != nil || t.active[key] {
+		return false
+	}
+	t.active[key] = true
+	defer delete(t.active, key)
+	tle := outputColumn(q, attno)
+	return tle != nil && t.direct(tle.Expr, &scope{q: q, parent: parent})
+}
+
+// outputColumn returns the target entry for output column attno of q:
+// its RETURNING list for a data-modifying CTE.
+func outputColumn(q *nodes.Query, attno nodes.AttrNumber) *nodes.TargetEntry {
+	tlist := q.TargetList
+	if q.CommandType != nodes.CMD_SELECT {
+		tlist = q.ReturningList
+	}
+	for _, item := range items(tlist) {
+		if tle := item.(*nodes.TargetEntry); tle.Resno == attno && !tle.Resjunk {
+			return tle
+		}
+	}
+	return nil
+}
+
+// cteQuery returns the query of the CTE an RTE_CTE entry of scope s reads,
+// and the scope of the query that defines it.
+func cteQuery(rte *nodes.RangeTblEntry, s *scope) (*scope, *nodes.Query) {
+	cs := s.up(rte.Ctelevelsup)
+	if cs == nil {
+		return nil, nil
+	}
+	for _, item := range items(cs.q.CteList) {
+		cte := item.(*nodes.CommonTableExpr)
+		if cte.Ctename == rte.Ctename {
+			q, _ := cte.Ctequery.(*nodes.Query)
+			return cs, q
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,218 @@
+// Package lint checks migration scripts for DDL that is unsafe to run
+// against a busy database, such as statements that rewrite a table or hold
+// an ACCESS EXCLUSIVE lock for longer than necessary.
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+)
+
+// Severity indicates how serious a finding is.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+var severityNames = [...]string{"info", "warning", "error"}
+
+func (s Severity) String() string {
+	if s >= 0 && int(s) < len(severityNames) {
+		return severityNames[s]
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// ParseSeverity converts a severity name as printed by String back to a
+// Severity.
+func ParseSeverity(name string) (Severity, error) {
+	for i, n := range severityNames {
+		if strings.EqualFold(name, n) {
+			return Severity(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity %q", name)
+}
+
+// Rule is a single lint check. Check is called once per top-level
+// statement and reports problems through the Context.
+type Rule struct {
+	ID          string   // stable identifier, e.g. "require-concurrent-index"
+	Severity    Severity // default severity of findings
+	Description string   // one-line summary of what the rule detects
+	Check       func(ctx *Context, stmt nodes.Node)
+}
+
+// Diagnostic is a problem found by a rule.
+type Diagnostic struct {
+	RuleID   string
+	Severity Severity
+	Message  string
+	Fix      string // suggested safer alternative
+	Location int    // byte offset of the offending statement
+	Line     int    // 1-based line of Location
+	Column   int    // 1-based column (in bytes) of Location
+	StmtLen  int    // length of the statement in bytes, or 0 if it runs to end of input
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: %s [%s] %s", d.Line, d.Column, d.Severity, d.RuleID, d.Message)
+}
+
+// Context carries per-statement state passed to rules.
+type Context struct {
+	rule    *Rule
+	stmt    *nodes.RawStmt
+	src     string
+	created map[string]bool
+	diags   []Diagnostic
+}
+
+// Report records a finding for the current statement.
+func (c *Context) Report(message, fix string) {
+	loc := int(c.stmt.StmtLocation)
+	length := int(c.stmt.StmtLen)
+	if start := firstTokenOffset(c.src, loc, length); start > loc {
+		if length > 0 {
+			length -= start - loc
+		}
+		loc = start
+	}
+	line, col := lineCol(c.src, loc)
+	c.diags = append(c.diags, Diagnostic{
+		RuleID:   c.rule.ID,
+		Severity: c.rule.Severity,
+		Message:  message,
+		Fix:      fix,
+		Location: loc,
+		Line:     line,
+		Column:   col,
+		StmtLen:  length,
+	})
+}
+
+// CreatedInScript reports whether rel was created by an earlier statement
+// of the script being linted. Operations on such tables cannot block other
+// sessions, so most rules skip them.
+func (c *Context) CreatedInScript(rel *nodes.RangeVar) bool {
+	return rel != nil && c.created[relationKey(rel)]
+}
+
+// Linter runs a set of rules over SQL scripts.
+type Linter struct {
+	rules []*Rule
+}
+
+// New returns a Linter running the given rules, or the built-in rule set
+// if none are given.
+func New(rules ...*Rule) *Linter {
+	if len(rules) == 0 {
+		rules = DefaultRules()
+	}
+	return &Linter{rules: rules}
+}
+
+// Rules returns the rules the linter runs.
+func (l *Linter) Rules() []*Rule {
+	return l.rules
+}
+
+// Disable removes the rules with the given IDs. Unknown IDs are reported as
+// an error.
+func (l *Linter) Disable(ids ...string) error {
+	for _, id := range ids {
+		found := false
+		for i, r := range l.rules {
+			if r.ID == id {
+				l.rules = append(l.rules[:i:i], l.rules[i+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown rule %q", id)
+		}
+	}
+	return nil
+}
+
+// Lint parses sql and runs every rule over each statement. Diagnostics are
+// returned in source order.
+func (l *Linter) Lint(sql string) ([]Diagnostic, error) {
+	stmts, err := parser.ParseRaw(sql)
+	if err != nil {
+		return nil, err
+	}
+	return l.LintStmts(sql, stmts), nil
+}
+
+// LintStmts runs every rule over already-parsed statements. src is the
+// text the statements were parsed from and is used to compute positions.
+func (l *Linter) LintStmts(src string, stmts []*nodes.RawStmt) []Diagnostic {
+	ctx := &Context{src: src, created: make(map[string]bool)}
+	for _, raw := range stmts {
+		ctx.stmt = raw
+		for _, r := range l.rules {
+			ctx.rule = r
+			r.Check(ctx, raw.Stmt)
+		}
+		ctx.noteCreated(raw.Stmt)
+	}
+	sort.SliceStable(ctx.diags, func(i, j int) bool {
+		return ctx.diags[i].Location < ctx.diags[j].Location
+	})
+	return ctx.diags
+}
+
+// noteCreated remembers tables created by stmt.
+func (c *Context) noteCreated(stmt nodes.Node) {
+	switch n := stmt.(type) {
+	case *nodes.CreateStmt:
+		c.created[relationKey(n.Relation)] = true
+	case *nodes.CreateTableAsStmt:
+		if n.Into != nil {
+			c.created[relationKey(n.Into.Rel)] = true
+		}
+	}
+}
+
+func relationKey(rel *nodes.RangeVar) string {
+	if rel == nil {
+		return ""
+	}
+	if rel.Schemaname != "" {
+		return rel.Schemaname + "." + rel.Relname
+	}
+	return rel.Relname
+}
+
+// firstTokenOffset returns the offset of the first token of the statement
+// spanning src[loc:loc+length], skipping leading whitespace and comments.
+func firstTokenOffset(src string, loc, length int) int {
+	if loc < 0 || loc >= len(src) {
+		return loc
+	}
+	end := len(src)
+	if length > 0 && loc+length <= len(src) {
+		end = loc + length
+	}
+	tok := parser.NewLexer(src[loc:end]).NextToken()
+	return loc + tok.Loc
+}
+
+// lineCol converts a byte offset into 1-based line and column numbers.
+func lineCol(src string, offset int) (int, int) {
+	if offset > len(src) {
+		offset = len(src)
+	}
+	line := 1 + strings.Count(src[:offset], "\n")
+	col := offset - strings.LastIndexByte(src[:offset], '\n')
+	return line, col
+}
@@ -0,0 +1,141 @@
+package lint
+
+import "testing"
+
+// ruleIDs lints sql with the default rules and returns the IDs reported.
+func ruleIDs(t *testing.T, sql string) []string {
+	t.Helper()
+	diags, err := New().Lint(sql)
+	if err != nil {
+		t.Fatalf("Lint(%q) error: %v", sql, err)
+	}
+	var ids []string
+	for _, d := range diags {
+		ids = append(ids, d.RuleID)
+	}
+	return ids
+}
+
+func TestDefaultRules(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want []string
+	}{
+		// NOT NULL columns
+		{"ALTER TABLE t ADD COLUMN c int NOT NULL", []string{RuleAddingNotNullColumn}},
+		{"ALTER TABLE t ADD COLUMN c int NOT NULL DEFAULT 0", nil},
+		{"ALTER TABLE t ADD COLUMN c int GENERATED ALWAYS AS IDENTITY", nil},
+		{"ALTER TABLE t ADD COLUMN c int", nil},
+		{"ALTER TABLE t ADD COLUMN c int PRIMARY KEY", []string{RuleAddingNotNullColumn}},
+
+		// Indexes
+		{"CREATE INDEX i ON t (a)", []string{RuleRequireConcurrentIndex}},
+		{"CREATE UNIQUE INDEX i ON t (a)", []string{RuleRequireConcurrentIndex}},
+		{"CREATE INDEX CONCURRENTLY i ON t (a)", nil},
+
+		// Column types
+		{"ALTER TABLE t ALTER COLUMN c TYPE bigint", []string{RuleChangingColumnType}},
+		{"ALTER TABLE t ALTER COLUMN c SET DATA TYPE text USING c::text", []string{RuleChangingColumnType}},
+
+		// Foreign keys
+		{"ALTER TABLE t ADD CONSTRAINT fk FOREIGN KEY (a) REFERENCES u (b)", []string{RuleAddingForeignKey}},
+		{"ALTER TABLE t ADD CONSTRAINT fk FOREIGN KEY (a) REFERENCES u (b) NOT VALID", nil},
+		{"ALTER TABLE t ADD COLUMN a int REFERENCES u (b)", []string{RuleAddingForeignKey}},
+		{"ALTER TABLE t VALIDATE CONSTRAINT fk", nil},
+
+		// Drops and renames
+		{"ALTER TABLE t DROP COLUMN c", []string{RuleDroppingColumn}},
+		{"ALTER TABLE t DROP COLUMN IF EXISTS c, DROP COLUMN d", []string{RuleDroppingColumn, RuleDroppingColumn}},
+		{"ALTER TABLE t RENAME TO u", []string{RuleRenamingTable}},
+		{"ALTER TABLE t RENAME COLUMN a TO b", nil},
+		{"ALTER INDEX i RENAME TO j", nil},
+
+		// VACUUM
+		{"VACUUM FULL t", []string{RuleVacuumFull}},
+		{"VACUUM (FULL, ANALYZE) t", []string{RuleVacuumFull}},
+		{"VACUUM (FULL false) t", nil},
+		{"VACUUM (FULL off, VERBOSE) t", nil},
+		{"VACUUM (FULL 1) t", []string{RuleVacuumFull}},
+		{"VACUUM (FULL yes) t", nil},
+		{"VACUUM (FULL 2) t", nil},
+		{"VACUUM t", nil},
+
+		// Safe statements
+		{"SELECT 1", nil},
+		{"CREATE TABLE t (a int NOT NULL)", nil},
+	}
+	for _, tt := range tests {
+		got := ruleIDs(t, tt.sql)
+		if len(got) != len(tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.sql, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%q: got %v, want %v", tt.sql, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestTablesCreatedInScript(t *testing.T) {
+	sql := `CREATE TABLE t (a int);
+CREATE INDEX i ON t (a);
+ALTER TABLE t ADD COLUMN b int NOT NULL;
+CREATE INDEX j ON u (a);`
+	got := ruleIDs(t, sql)
+	if len(got) != 1 || got[0] != RuleRequireConcurrentIndex {
+		t.Errorf("got %v, want only the index on u", got)
+	}
+}
+
+func TestDiagnosticLocation(t *testing.T) {
+	sql := "SELECT 1;\n\n-- rebuild\n  VACUUM FULL t;\nCREATE INDEX i ON t (a)"
+	diags, err := New().Lint(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %v", diags)
+	}
+
+	d := diags[0]
+	if d.RuleID != RuleVacuumFull || d.Severity != SeverityError {
+		t.Errorf("unexpected first diagnostic %+v", d)
+	}
+	if d.Line != 4 || d.Column != 3 {
+		t.Errorf("VACUUM at %d:%d, want 4:3", d.Line, d.Column)
+	}
+	if got := sql[d.Location : d.Location+d.StmtLen]; got != "VACUUM FULL t" {
+		t.Errorf("statement text = %q", got)
+	}
+	if d.Fix == "" {
+		t.Error("expected a suggested fix")
+	}
+
+	d = diags[1]
+	if d.Line != 5 || d.Column != 1 || d.StmtLen != 0 {
+		t.Errorf("CREATE INDEX at %d:%d len %d, want 5:1 len 0", d.Line, d.Column, d.StmtLen)
+	}
+}
+
+func TestDisable(t *testing.T) {
+	l := New()
+	if err := l.Disable(RuleRequireConcurrentIndex); err != nil {
+		t.Fatal(err)
+	}
+	diags, err := l.Lint("CREATE INDEX i ON t (a)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diags) != 0 {
+		t.Errorf("expected no diagnostics, got %v", diags)
+	}
+	if err := l.Disable("no-such-rule"); err == nil {
+		t.Error("expected error for unknown rule")
+	}
+	if len(DefaultRules()) != 7 {
+		t.Error("Disable should not affect DefaultRules")
+	}
+}
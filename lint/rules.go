@@ -0,0 +1,237 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pgplex/pgparser/analysis"
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// Built-in rule IDs.
+const (
+	RuleAddingNotNullColumn    = "adding-not-null-column"
+	RuleRequireConcurrentIndex = "require-concurrent-index"
+	RuleChangingColumnType     = "changing-column-type"
+	RuleAddingForeignKey       = "adding-foreign-key-without-not-valid"
+	RuleDroppingColumn         = "dropping-column"
+	RuleRenamingTable          = "renaming-table"
+	RuleVacuumFull             = "vacuum-full"
+)
+
+// DefaultRules returns the built-in rule set. Each call returns fresh
+// values so callers may adjust severities without affecting others.
+func DefaultRules() []*Rule {
+	return []*Rule{
+		{
+			ID:          RuleAddingNotNullColumn,
+			Severity:    SeverityError,
+			Description: "adding a NOT NULL column without a default fails on non-empty tables",
+			Check:       checkAddingNotNullColumn,
+		},
+		{
+			ID:          RuleRequireConcurrentIndex,
+			Severity:    SeverityWarning,
+			Description: "CREATE INDEX without CONCURRENTLY blocks writes for the whole build",
+			Check:       checkRequireConcurrentIndex,
+		},
+		{
+			ID:          RuleChangingColumnType,
+			Severity:    SeverityError,
+			Description: "changing a column type usually rewrites the table under ACCESS EXCLUSIVE lock",
+			Check:       checkChangingColumnType,
+		},
+		{
+			ID:          RuleAddingForeignKey,
+			Severity:    SeverityWarning,
+			Description: "adding a foreign key validates all rows while blocking writes on both tables",
+			Check:       checkAddingForeignKey,
+		},
+		{
+			ID:          RuleDroppingColumn,
+			Severity:    SeverityWarning,
+			Description: "dropping a column breaks clients that still reference it",
+			Check:       checkDroppingColumn,
+		},
+		{
+			ID:          RuleRenamingTable,
+			Severity:    SeverityWarning,
+			Description: "renaming a table breaks clients that still reference the old name",
+			Check:       checkRenamingTable,
+		},
+		{
+			ID:          RuleVacuumFull,
+			Severity:    SeverityError,
+			Description: "VACUUM FULL rewrites the table under ACCESS EXCLUSIVE lock",
+			Check:       checkVacuumFull,
+		},
+	}
+}
+
+// alterTableCmds returns the subcommands of an ALTER TABLE on a table that
+// existed before the script, or nil.
+func alterTableCmds(ctx *Context, stmt nodes.Node) (*nodes.RangeVar, []*nodes.AlterTableCmd) {
+	at, ok := stmt.(*nodes.AlterTableStmt)
+	if !ok || nodes.ObjectType(at.Objtype) != nodes.OBJECT_TABLE || ctx.CreatedInScript(at.Relation) {
+		return nil, nil
+	}
+	var cmds []*nodes.AlterTableCmd
+	if at.Cmds != nil {
+		for _, item := range at.Cmds.Items {
+			if cmd, ok := item.(*nodes.AlterTableCmd); ok {
+				cmds = append(cmds, cmd)
+			}
+		}
+	}
+	return at.Relation, cmds
+}
+
+func checkAddingNotNullColumn(ctx *Context, stmt nodes.Node) {
+	rel, cmds := alterTableCmds(ctx, stmt)
+	for _, cmd := range cmds {
+		if nodes.AlterTableType(cmd.Subtype) != nodes.AT_AddColumn {
+			continue
+		}
+		col, ok := cmd.Def.(*nodes.ColumnDef)
+		if !ok {
+			continue
+		}
+		notNull := col.IsNotNull
+		hasDefault := col.RawDefault != nil || col.Identity != 0 || col.Generated != 0
+		if col.Constraints != nil {
+			for _, item := range col.Constraints.Items {
+				c, ok := item.(*nodes.Constraint)
+				if !ok {
+					continue
+				}
+				switch c.Contype {
+				case nodes.CONSTR_NOTNULL, nodes.CONSTR_PRIMARY:
+					notNull = true
+				case nodes.CONSTR_DEFAULT, nodes.CONSTR_IDENTITY, nodes.CONSTR_GENERATED:
+					hasDefault = true
+				}
+			}
+		}
+		if notNull && !hasDefault {
+			ctx.Report(
+				fmt.Sprintf("adding NOT NULL column %q to %s without a default fails if the table has rows",
+					col.Colname, relationKey(rel)),
+				"add the column as nullable, backfill it, then add a CHECK (... IS NOT NULL) NOT VALID constraint, VALIDATE it and SET NOT NULL; or give the column a DEFAULT")
+		}
+	}
+}
+
+func checkRequireConcurrentIndex(ctx *Context, stmt nodes.Node) {
+	idx, ok := stmt.(*nodes.IndexStmt)
+	if !ok || idx.Concurrent || ctx.CreatedInScript(idx.Relation) {
+		return
+	}
+	ctx.Report(
+		fmt.Sprintf("CREATE INDEX on %s takes a SHARE lock that blocks writes until the build finishes",
+			relationKey(idx.Relation)),
+		"use CREATE INDEX CONCURRENTLY outside a transaction block")
+}
+
+func checkChangingColumnType(ctx *Context, stmt nodes.Node) {
+	rel, cmds := alterTableCmds(ctx, stmt)
+	for _, cmd := range cmds {
+		if nodes.AlterTableType(cmd.Subtype) != nodes.AT_AlterColumnType {
+			continue
+		}
+		ctx.Report(
+			fmt.Sprintf("changing the type of column %q on %s may rewrite the table under ACCESS EXCLUSIVE lock",
+				cmd.Name, relationKey(rel)),
+			"add a new column with the new type, backfill it in batches and switch readers over before dropping the old column")
+	}
+}
+
+func checkAddingForeignKey(ctx *Context, stmt nodes.Node) {
+	rel, cmds := alterTableCmds(ctx, stmt)
+	for _, cmd := range cmds {
+		var constraints []nodes.Node
+		switch nodes.AlterTableType(cmd.Subtype) {
+		case nodes.AT_AddConstraint:
+			constraints = []nodes.Node{cmd.Def}
+		case nodes.AT_AddColumn:
+			if col, ok := cmd.Def.(*nodes.ColumnDef); ok && col.Constraints != nil {
+				constraints = col.Constraints.Items
+			}
+		}
+		for _, item := range constraints {
+			c, ok := item.(*nodes.Constraint)
+			if !ok || c.Contype != nodes.CONSTR_FOREIGN || c.SkipValidation {
+				continue
+			}
+			name := c.Conname
+			if name == "" {
+				name = "foreign key"
+			} else {
+				name = fmt.Sprintf("foreign key %q", name)
+			}
+			ctx.Report(
+				fmt.Sprintf("adding %s on %s validates existing rows while blocking writes on %s and %s",
+					name, relationKey(rel), relationKey(rel), relationKey(c.Pktable)),
+				"add the constraint with NOT VALID, then run ALTER TABLE ... VALIDATE CONSTRAINT in a separate transaction")
+		}
+	}
+}
+
+func checkDroppingColumn(ctx *Context, stmt nodes.Node) {
+	rel, cmds := alterTableCmds(ctx, stmt)
+	for _, cmd := range cmds {
+		if nodes.AlterTableType(cmd.Subtype) != nodes.AT_DropColumn {
+			continue
+		}
+		ctx.Report(
+			fmt.Sprintf("dropping column %q from %s breaks clients that still use it",
+				cmd.Name, relationKey(rel)),
+			"deploy application code that no longer references the column before dropping it")
+	}
+}
+
+func checkRenamingTable(ctx *Context, stmt nodes.Node) {
+	rs, ok := stmt.(*nodes.RenameStmt)
+	if !ok || rs.RenameType != nodes.OBJECT_TABLE || ctx.CreatedInScript(rs.Relation) {
+		return
+	}
+	ctx.Report(
+		fmt.Sprintf("renaming table %s to %q breaks clients that still use the old name",
+			relationKey(rs.Relation), rs.Newname),
+		"create the new table name as an updatable view over the old one (or vice versa) until all clients have migrated")
+}
+
+func checkVacuumFull(ctx *Context, stmt nodes.Node) {
+	vs, ok := stmt.(*nodes.VacuumStmt)
+	if !ok || !vs.IsVacuumcmd || !vacuumFull(vs.Options) {
+		return
+	}
+	var rels []string
+	if vs.Rels != nil {
+		for _, item := range vs.Rels.Items {
+			if vr, ok := item.(*nodes.VacuumRelation); ok && vr.Relation != nil {
+				rels = append(rels, relationKey(vr.Relation))
+			}
+		}
+	}
+	target := "every table in the database"
+	if len(rels) > 0 {
+		target = strings.Join(rels, ", ")
+	}
+	ctx.Report(
+		fmt.Sprintf("VACUUM FULL rewrites %s under ACCESS EXCLUSIVE lock", target),
+		"use plain VACUUM, or an online tool such as pg_repack to reclaim space")
+}
+
+// vacuumFull reports whether the VACUUM options enable FULL.
+func vacuumFull(opts *nodes.List) bool {
+	if opts == nil {
+		return false
+	}
+	for _, item := range opts.Items {
+		if d, ok := item.(*nodes.DefElem); ok && d.Defname == "full" {
+			full, err := analysis.DefGetBoolean(d)
+			return err == nil && full
+		}
+	}
+	return false
+}
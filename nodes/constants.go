@@ -0,0 +1,85 @@
+package nodes
+
+// Constants that parse trees use but PostgreSQL defines outside the node
+// headers the rest of the package is generated from.
+
+// Lock mode constants (from PostgreSQL's lockdefs.h)
+const (
+	NoLock                   = 0
+	AccessShareLock          = 1
+	RowShareLock             = 2
+	RowExclusiveLock         = 3
+	ShareUpdateExclusiveLock = 4
+	ShareLock                = 5
+	ShareRowExclusiveLock    = 6
+	ExclusiveLock            = 7
+	AccessExclusiveLock      = 8
+)
+
+// ClusterOption bitmask values (from cluster.h)
+const (
+	CLUOPT_VERBOSE = 1 << 0
+)
+
+// Trigger type bitmask constants (from trigger.h).
+const (
+	TRIGGER_TYPE_ROW      = 1 << 0
+	TRIGGER_TYPE_BEFORE   = 1 << 1
+	TRIGGER_TYPE_INSERT   = 1 << 2
+	TRIGGER_TYPE_DELETE   = 1 << 3
+	TRIGGER_TYPE_UPDATE   = 1 << 4
+	TRIGGER_TYPE_TRUNCATE = 1 << 5
+	TRIGGER_TYPE_INSTEAD  = 1 << 6
+	TRIGGER_TYPE_AFTER    = 0 // default (not BEFORE, not INSTEAD)
+)
+
+// Trigger fire condition constants (from trigger.h).
+const (
+	TRIGGER_FIRES_ON_ORIGIN  = 'O'
+	TRIGGER_FIRES_ALWAYS     = 'A'
+	TRIGGER_FIRES_ON_REPLICA = 'R'
+	TRIGGER_DISABLED         = 'D'
+)
+
+// ConstraintAttributeSpec bit constants (from gram.y).
+const (
+	CAS_NOT_DEFERRABLE      = 1 << 0
+	CAS_DEFERRABLE          = 1 << 1
+	CAS_INITIALLY_IMMEDIATE = 1 << 2
+	CAS_INITIALLY_DEFERRED  = 1 << 3
+	CAS_NOT_VALID           = 1 << 4
+	CAS_NO_INHERIT          = 1 << 5
+)
+
+// AMTYPE constants for access method types (from pg_am.h).
+const (
+	AMTYPE_INDEX = 'i'
+	AMTYPE_TABLE = 't'
+)
+
+// Interval field codes (from postgres datetime.h).
+// Used in INTERVAL type modifiers.
+const (
+	INTERVAL_MASK_YEAR   = 1 << 2
+	INTERVAL_MASK_MONTH  = 1 << 1
+	INTERVAL_MASK_DAY    = 1 << 3
+	INTERVAL_MASK_HOUR   = 1 << 10
+	INTERVAL_MASK_MINUTE = 1 << 11
+	INTERVAL_MASK_SECOND = 1 << 12
+	INTERVAL_FULL_RANGE  = 0x7FFF
+)
+
+// RELPERSISTENCE_* constants (from pg_class.h).
+const (
+	RELPERSISTENCE_PERMANENT = 'p'
+	RELPERSISTENCE_UNLOGGED  = 'u'
+	RELPERSISTENCE_TEMP      = 't'
+)
+
+// XML standalone constants (from xml.h)
+const (
+	XML_STANDALONE_YES      = 0
+	XML_STANDALONE_NO       = 1
+	XML_STANDALONE_NO_VALUE = 2
+	XML_STANDALONE_OMITTED  = 3
+)
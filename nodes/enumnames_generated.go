@@ -0,0 +1,1128 @@
+// Code generated by pgsema-gen. DO NOT EDIT.
+// Source: the enum types of the nodes package
+
+package nodes
+
+// enumName returns the name of v if it is a value of an enum type,
+// reporting whether it is one.
+func enumName(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case LockClauseStrength:
+		switch v {
+		case LCS_NONE:
+			return "LCS_NONE", true
+		case LCS_FORKEYSHARE:
+			return "LCS_FORKEYSHARE", true
+		case LCS_FORSHARE:
+			return "LCS_FORSHARE", true
+		case LCS_FORNOKEYUPDATE:
+			return "LCS_FORNOKEYUPDATE", true
+		case LCS_FORUPDATE:
+			return "LCS_FORUPDATE", true
+		}
+	case LockWaitPolicy:
+		switch v {
+		case LockWaitBlock:
+			return "LockWaitBlock", true
+		case LockWaitSkip:
+			return "LockWaitSkip", true
+		case LockWaitError:
+			return "LockWaitError", true
+		}
+	case LockTupleMode:
+		switch v {
+		case LockTupleKeyShare:
+			return "LockTupleKeyShare", true
+		case LockTupleShare:
+			return "LockTupleShare", true
+		case LockTupleNoKeyExclusive:
+			return "LockTupleNoKeyExclusive", true
+		case LockTupleExclusive:
+			return "LockTupleExclusive", true
+		}
+	case CmdType:
+		switch v {
+		case CMD_UNKNOWN:
+			return "CMD_UNKNOWN", true
+		case CMD_SELECT:
+			return "CMD_SELECT", true
+		case CMD_UPDATE:
+			return "CMD_UPDATE", true
+		case CMD_INSERT:
+			return "CMD_INSERT", true
+		case CMD_DELETE:
+			return "CMD_DELETE", true
+		case CMD_MERGE:
+			return "CMD_MERGE", true
+		case CMD_UTILITY:
+			return "CMD_UTILITY", true
+		case CMD_NOTHING:
+			return "CMD_NOTHING", true
+		}
+	case JoinType:
+		switch v {
+		case JOIN_INNER:
+			return "JOIN_INNER", true
+		case JOIN_LEFT:
+			return "JOIN_LEFT", true
+		case JOIN_FULL:
+			return "JOIN_FULL", true
+		case JOIN_RIGHT:
+			return "JOIN_RIGHT", true
+		case JOIN_SEMI:
+			return "JOIN_SEMI", true
+		case JOIN_ANTI:
+			return "JOIN_ANTI", true
+		case JOIN_RIGHT_ANTI:
+			return "JOIN_RIGHT_ANTI", true
+		case JOIN_UNIQUE_OUTER:
+			return "JOIN_UNIQUE_OUTER", true
+		case JOIN_UNIQUE_INNER:
+			return "JOIN_UNIQUE_INNER", true
+		}
+	case AggStrategy:
+		switch v {
+		case AGG_PLAIN:
+			return "AGG_PLAIN", true
+		case AGG_SORTED:
+			return "AGG_SORTED", true
+		case AGG_HASHED:
+			return "AGG_HASHED", true
+		case AGG_MIXED:
+			return "AGG_MIXED", true
+		}
+	case AggSplit:
+		switch v {
+		case AGGSPLIT_SIMPLE:
+			return "AGGSPLIT_SIMPLE", true
+		case AGGSPLIT_INITIAL_SERIAL:
+			return "AGGSPLIT_INITIAL_SERIAL", true
+		case AGGSPLIT_FINAL_DESERIAL:
+			return "AGGSPLIT_FINAL_DESERIAL", true
+		}
+	case SetOpCmd:
+		switch v {
+		case SETOPCMD_INTERSECT:
+			return "SETOPCMD_INTERSECT", true
+		case SETOPCMD_INTERSECT_ALL:
+			return "SETOPCMD_INTERSECT_ALL", true
+		case SETOPCMD_EXCEPT:
+			return "SETOPCMD_EXCEPT", true
+		case SETOPCMD_EXCEPT_ALL:
+			return "SETOPCMD_EXCEPT_ALL", true
+		}
+	case SetOpStrategy:
+		switch v {
+		case SETOP_SORTED:
+			return "SETOP_SORTED", true
+		case SETOP_HASHED:
+			return "SETOP_HASHED", true
+		}
+	case OnConflictAction:
+		switch v {
+		case ONCONFLICT_NONE:
+			return "ONCONFLICT_NONE", true
+		case ONCONFLICT_NOTHING:
+			return "ONCONFLICT_NOTHING", true
+		case ONCONFLICT_UPDATE:
+			return "ONCONFLICT_UPDATE", true
+		}
+	case LimitOption:
+		switch v {
+		case LIMIT_OPTION_DEFAULT:
+			return "LIMIT_OPTION_DEFAULT", true
+		case LIMIT_OPTION_COUNT:
+			return "LIMIT_OPTION_COUNT", true
+		case LIMIT_OPTION_WITH_TIES:
+			return "LIMIT_OPTION_WITH_TIES", true
+		}
+	case QuerySource:
+		switch v {
+		case QSRC_ORIGINAL:
+			return "QSRC_ORIGINAL", true
+		case QSRC_PARSER:
+			return "QSRC_PARSER", true
+		case QSRC_INSTEAD_RULE:
+			return "QSRC_INSTEAD_RULE", true
+		case QSRC_QUAL_INSTEAD_RULE:
+			return "QSRC_QUAL_INSTEAD_RULE", true
+		case QSRC_NON_INSTEAD_RULE:
+			return "QSRC_NON_INSTEAD_RULE", true
+		}
+	case SortByDir:
+		switch v {
+		case SORTBY_DEFAULT:
+			return "SORTBY_DEFAULT", true
+		case SORTBY_ASC:
+			return "SORTBY_ASC", true
+		case SORTBY_DESC:
+			return "SORTBY_DESC", true
+		case SORTBY_USING:
+			return "SORTBY_USING", true
+		}
+	case SortByNulls:
+		switch v {
+		case SORTBY_NULLS_DEFAULT:
+			return "SORTBY_NULLS_DEFAULT", true
+		case SORTBY_NULLS_FIRST:
+			return "SORTBY_NULLS_FIRST", true
+		case SORTBY_NULLS_LAST:
+			return "SORTBY_NULLS_LAST", true
+		}
+	case SetQuantifier:
+		switch v {
+		case SET_QUANTIFIER_DEFAULT:
+			return "SET_QUANTIFIER_DEFAULT", true
+		case SET_QUANTIFIER_ALL:
+			return "SET_QUANTIFIER_ALL", true
+		case SET_QUANTIFIER_DISTINCT:
+			return "SET_QUANTIFIER_DISTINCT", true
+		}
+	case A_Expr_Kind:
+		switch v {
+		case AEXPR_OP:
+			return "AEXPR_OP", true
+		case AEXPR_OP_ANY:
+			return "AEXPR_OP_ANY", true
+		case AEXPR_OP_ALL:
+			return "AEXPR_OP_ALL", true
+		case AEXPR_DISTINCT:
+			return "AEXPR_DISTINCT", true
+		case AEXPR_NOT_DISTINCT:
+			return "AEXPR_NOT_DISTINCT", true
+		case AEXPR_NULLIF:
+			return "AEXPR_NULLIF", true
+		case AEXPR_IN:
+			return "AEXPR_IN", true
+		case AEXPR_LIKE:
+			return "AEXPR_LIKE", true
+		case AEXPR_ILIKE:
+			return "AEXPR_ILIKE", true
+		case AEXPR_SIMILAR:
+			return "AEXPR_SIMILAR", true
+		case AEXPR_BETWEEN:
+			return "AEXPR_BETWEEN", true
+		case AEXPR_NOT_BETWEEN:
+			return "AEXPR_NOT_BETWEEN", true
+		case AEXPR_BETWEEN_SYM:
+			return "AEXPR_BETWEEN_SYM", true
+		case AEXPR_NOT_BETWEEN_SYM:
+			return "AEXPR_NOT_BETWEEN_SYM", true
+		}
+	case RoleSpecType:
+		switch v {
+		case ROLESPEC_CSTRING:
+			return "ROLESPEC_CSTRING", true
+		case ROLESPEC_CURRENT_ROLE:
+			return "ROLESPEC_CURRENT_ROLE", true
+		case ROLESPEC_CURRENT_USER:
+			return "ROLESPEC_CURRENT_USER", true
+		case ROLESPEC_SESSION_USER:
+			return "ROLESPEC_SESSION_USER", true
+		case ROLESPEC_PUBLIC:
+			return "ROLESPEC_PUBLIC", true
+		}
+	case TableLikeOption:
+		switch v {
+		case CREATE_TABLE_LIKE_COMMENTS:
+			return "CREATE_TABLE_LIKE_COMMENTS", true
+		case CREATE_TABLE_LIKE_COMPRESSION:
+			return "CREATE_TABLE_LIKE_COMPRESSION", true
+		case CREATE_TABLE_LIKE_CONSTRAINTS:
+			return "CREATE_TABLE_LIKE_CONSTRAINTS", true
+		case CREATE_TABLE_LIKE_DEFAULTS:
+			return "CREATE_TABLE_LIKE_DEFAULTS", true
+		case CREATE_TABLE_LIKE_GENERATED:
+			return "CREATE_TABLE_LIKE_GENERATED", true
+		case CREATE_TABLE_LIKE_IDENTITY:
+			return "CREATE_TABLE_LIKE_IDENTITY", true
+		case CREATE_TABLE_LIKE_INDEXES:
+			return "CREATE_TABLE_LIKE_INDEXES", true
+		case CREATE_TABLE_LIKE_STATISTICS:
+			return "CREATE_TABLE_LIKE_STATISTICS", true
+		case CREATE_TABLE_LIKE_STORAGE:
+			return "CREATE_TABLE_LIKE_STORAGE", true
+		case CREATE_TABLE_LIKE_ALL:
+			return "CREATE_TABLE_LIKE_ALL", true
+		}
+	case DefElemAction:
+		switch v {
+		case DEFELEM_UNSPEC:
+			return "DEFELEM_UNSPEC", true
+		case DEFELEM_SET:
+			return "DEFELEM_SET", true
+		case DEFELEM_ADD:
+			return "DEFELEM_ADD", true
+		case DEFELEM_DROP:
+			return "DEFELEM_DROP", true
+		}
+	case PartitionStrategy:
+		switch v {
+		case PARTITION_STRATEGY_LIST:
+			return "PARTITION_STRATEGY_LIST", true
+		case PARTITION_STRATEGY_RANGE:
+			return "PARTITION_STRATEGY_RANGE", true
+		case PARTITION_STRATEGY_HASH:
+			return "PARTITION_STRATEGY_HASH", true
+		}
+	case PartitionRangeDatumKind:
+		switch v {
+		case PARTITION_RANGE_DATUM_MINVALUE:
+			return "PARTITION_RANGE_DATUM_MINVALUE", true
+		case PARTITION_RANGE_DATUM_VALUE:
+			return "PARTITION_RANGE_DATUM_VALUE", true
+		case PARTITION_RANGE_DATUM_MAXVALUE:
+			return "PARTITION_RANGE_DATUM_MAXVALUE", true
+		}
+	case RTEKind:
+		switch v {
+		case RTE_RELATION:
+			return "RTE_RELATION", true
+		case RTE_SUBQUERY:
+			return "RTE_SUBQUERY", true
+		case RTE_JOIN:
+			return "RTE_JOIN", true
+		case RTE_FUNCTION:
+			return "RTE_FUNCTION", true
+		case RTE_TABLEFUNC:
+			return "RTE_TABLEFUNC", true
+		case RTE_VALUES:
+			return "RTE_VALUES", true
+		case RTE_CTE:
+			return "RTE_CTE", true
+		case RTE_NAMEDTUPLESTORE:
+			return "RTE_NAMEDTUPLESTORE", true
+		case RTE_RESULT:
+			return "RTE_RESULT", true
+		}
+	case WCOKind:
+		switch v {
+		case WCO_VIEW_CHECK:
+			return "WCO_VIEW_CHECK", true
+		case WCO_RLS_INSERT_CHECK:
+			return "WCO_RLS_INSERT_CHECK", true
+		case WCO_RLS_UPDATE_CHECK:
+			return "WCO_RLS_UPDATE_CHECK", true
+		case WCO_RLS_CONFLICT_CHECK:
+			return "WCO_RLS_CONFLICT_CHECK", true
+		case WCO_RLS_MERGE_UPDATE_CHECK:
+			return "WCO_RLS_MERGE_UPDATE_CHECK", true
+		case WCO_RLS_MERGE_DELETE_CHECK:
+			return "WCO_RLS_MERGE_DELETE_CHECK", true
+		}
+	case GroupingSetKind:
+		switch v {
+		case GROUPING_SET_EMPTY:
+			return "GROUPING_SET_EMPTY", true
+		case GROUPING_SET_SIMPLE:
+			return "GROUPING_SET_SIMPLE", true
+		case GROUPING_SET_ROLLUP:
+			return "GROUPING_SET_ROLLUP", true
+		case GROUPING_SET_CUBE:
+			return "GROUPING_SET_CUBE", true
+		case GROUPING_SET_SETS:
+			return "GROUPING_SET_SETS", true
+		}
+	case CTEMaterialize:
+		switch v {
+		case CTEMaterializeDefault:
+			return "CTEMaterializeDefault", true
+		case CTEMaterializeAlways:
+			return "CTEMaterializeAlways", true
+		case CTEMaterializeNever:
+			return "CTEMaterializeNever", true
+		}
+	case JsonQuotes:
+		switch v {
+		case JS_QUOTES_UNSPEC:
+			return "JS_QUOTES_UNSPEC", true
+		case JS_QUOTES_KEEP:
+			return "JS_QUOTES_KEEP", true
+		case JS_QUOTES_OMIT:
+			return "JS_QUOTES_OMIT", true
+		}
+	case JsonTableColumnType:
+		switch v {
+		case JTC_FOR_ORDINALITY:
+			return "JTC_FOR_ORDINALITY", true
+		case JTC_REGULAR:
+			return "JTC_REGULAR", true
+		case JTC_EXISTS:
+			return "JTC_EXISTS", true
+		case JTC_FORMATTED:
+			return "JTC_FORMATTED", true
+		case JTC_NESTED:
+			return "JTC_NESTED", true
+		}
+	case SetOperation:
+		switch v {
+		case SETOP_NONE:
+			return "SETOP_NONE", true
+		case SETOP_UNION:
+			return "SETOP_UNION", true
+		case SETOP_INTERSECT:
+			return "SETOP_INTERSECT", true
+		case SETOP_EXCEPT:
+			return "SETOP_EXCEPT", true
+		}
+	case ObjectType:
+		switch v {
+		case OBJECT_ACCESS_METHOD:
+			return "OBJECT_ACCESS_METHOD", true
+		case OBJECT_AGGREGATE:
+			return "OBJECT_AGGREGATE", true
+		case OBJECT_AMOP:
+			return "OBJECT_AMOP", true
+		case OBJECT_AMPROC:
+			return "OBJECT_AMPROC", true
+		case OBJECT_ATTRIBUTE:
+			return "OBJECT_ATTRIBUTE", true
+		case OBJECT_CAST:
+			return "OBJECT_CAST", true
+		case OBJECT_COLUMN:
+			return "OBJECT_COLUMN", true
+		case OBJECT_COLLATION:
+			return "OBJECT_COLLATION", true
+		case OBJECT_CONVERSION:
+			return "OBJECT_CONVERSION", true
+		case OBJECT_DATABASE:
+			return "OBJECT_DATABASE", true
+		case OBJECT_DEFAULT:
+			return "OBJECT_DEFAULT", true
+		case OBJECT_DEFACL:
+			return "OBJECT_DEFACL", true
+		case OBJECT_DOMAIN:
+			return "OBJECT_DOMAIN", true
+		case OBJECT_DOMCONSTRAINT:
+			return "OBJECT_DOMCONSTRAINT", true
+		case OBJECT_EVENT_TRIGGER:
+			return "OBJECT_EVENT_TRIGGER", true
+		case OBJECT_EXTENSION:
+			return "OBJECT_EXTENSION", true
+		case OBJECT_FDW:
+			return "OBJECT_FDW", true
+		case OBJECT_FOREIGN_SERVER:
+			return "OBJECT_FOREIGN_SERVER", true
+		case OBJECT_FOREIGN_TABLE:
+			return "OBJECT_FOREIGN_TABLE", true
+		case OBJECT_FUNCTION:
+			return "OBJECT_FUNCTION", true
+		case OBJECT_INDEX:
+			return "OBJECT_INDEX", true
+		case OBJECT_LANGUAGE:
+			return "OBJECT_LANGUAGE", true
+		case OBJECT_LARGEOBJECT:
+			return "OBJECT_LARGEOBJECT", true
+		case OBJECT_MATVIEW:
+			return "OBJECT_MATVIEW", true
+		case OBJECT_OPCLASS:
+			return "OBJECT_OPCLASS", true
+		case OBJECT_OPERATOR:
+			return "OBJECT_OPERATOR", true
+		case OBJECT_OPFAMILY:
+			return "OBJECT_OPFAMILY", true
+		case OBJECT_PARAMETER_ACL:
+			return "OBJECT_PARAMETER_ACL", true
+		case OBJECT_POLICY:
+			return "OBJECT_POLICY", true
+		case OBJECT_PROCEDURE:
+			return "OBJECT_PROCEDURE", true
+		case OBJECT_PUBLICATION:
+			return "OBJECT_PUBLICATION", true
+		case OBJECT_PUBLICATION_NAMESPACE:
+			return "OBJECT_PUBLICATION_NAMESPACE", true
+		case OBJECT_PUBLICATION_REL:
+			return "OBJECT_PUBLICATION_REL", true
+		case OBJECT_ROLE:
+			return "OBJECT_ROLE", true
+		case OBJECT_ROUTINE:
+			return "OBJECT_ROUTINE", true
+		case OBJECT_RULE:
+			return "OBJECT_RULE", true
+		case OBJECT_SCHEMA:
+			return "OBJECT_SCHEMA", true
+		case OBJECT_SEQUENCE:
+			return "OBJECT_SEQUENCE", true
+		case OBJECT_SUBSCRIPTION:
+			return "OBJECT_SUBSCRIPTION", true
+		case OBJECT_STATISTIC_EXT:
+			return "OBJECT_STATISTIC_EXT", true
+		case OBJECT_TABCONSTRAINT:
+			return "OBJECT_TABCONSTRAINT", true
+		case OBJECT_TABLE:
+			return "OBJECT_TABLE", true
+		case OBJECT_TABLESPACE:
+			return "OBJECT_TABLESPACE", true
+		case OBJECT_TRANSFORM:
+			return "OBJECT_TRANSFORM", true
+		case OBJECT_TRIGGER:
+			return "OBJECT_TRIGGER", true
+		case OBJECT_TSCONFIGURATION:
+			return "OBJECT_TSCONFIGURATION", true
+		case OBJECT_TSDICTIONARY:
+			return "OBJECT_TSDICTIONARY", true
+		case OBJECT_TSPARSER:
+			return "OBJECT_TSPARSER", true
+		case OBJECT_TSTEMPLATE:
+			return "OBJECT_TSTEMPLATE", true
+		case OBJECT_TYPE:
+			return "OBJECT_TYPE", true
+		case OBJECT_USER_MAPPING:
+			return "OBJECT_USER_MAPPING", true
+		case OBJECT_VIEW:
+			return "OBJECT_VIEW", true
+		}
+	case DropBehavior:
+		switch v {
+		case DROP_RESTRICT:
+			return "DROP_RESTRICT", true
+		case DROP_CASCADE:
+			return "DROP_CASCADE", true
+		}
+	case AlterTableType:
+		switch v {
+		case AT_AddColumn:
+			return "AT_AddColumn", true
+		case AT_AddColumnToView:
+			return "AT_AddColumnToView", true
+		case AT_ColumnDefault:
+			return "AT_ColumnDefault", true
+		case AT_CookedColumnDefault:
+			return "AT_CookedColumnDefault", true
+		case AT_DropNotNull:
+			return "AT_DropNotNull", true
+		case AT_SetNotNull:
+			return "AT_SetNotNull", true
+		case AT_SetExpression:
+			return "AT_SetExpression", true
+		case AT_DropExpression:
+			return "AT_DropExpression", true
+		case AT_CheckNotNull:
+			return "AT_CheckNotNull", true
+		case AT_SetStatistics:
+			return "AT_SetStatistics", true
+		case AT_SetOptions:
+			return "AT_SetOptions", true
+		case AT_ResetOptions:
+			return "AT_ResetOptions", true
+		case AT_SetStorage:
+			return "AT_SetStorage", true
+		case AT_SetCompression:
+			return "AT_SetCompression", true
+		case AT_DropColumn:
+			return "AT_DropColumn", true
+		case AT_AddIndex:
+			return "AT_AddIndex", true
+		case AT_ReAddIndex:
+			return "AT_ReAddIndex", true
+		case AT_AddConstraint:
+			return "AT_AddConstraint", true
+		case AT_ReAddConstraint:
+			return "AT_ReAddConstraint", true
+		case AT_ReAddDomainConstraint:
+			return "AT_ReAddDomainConstraint", true
+		case AT_AlterConstraint:
+			return "AT_AlterConstraint", true
+		case AT_ValidateConstraint:
+			return "AT_ValidateConstraint", true
+		case AT_AddIndexConstraint:
+			return "AT_AddIndexConstraint", true
+		case AT_DropConstraint:
+			return "AT_DropConstraint", true
+		case AT_ReAddComment:
+			return "AT_ReAddComment", true
+		case AT_AlterColumnType:
+			return "AT_AlterColumnType", true
+		case AT_AlterColumnGenericOptions:
+			return "AT_AlterColumnGenericOptions", true
+		case AT_ChangeOwner:
+			return "AT_ChangeOwner", true
+		case AT_ClusterOn:
+			return "AT_ClusterOn", true
+		case AT_DropCluster:
+			return "AT_DropCluster", true
+		case AT_SetLogged:
+			return "AT_SetLogged", true
+		case AT_SetUnLogged:
+			return "AT_SetUnLogged", true
+		case AT_DropOids:
+			return "AT_DropOids", true
+		case AT_SetAccessMethod:
+			return "AT_SetAccessMethod", true
+		case AT_SetTableSpace:
+			return "AT_SetTableSpace", true
+		case AT_SetRelOptions:
+			return "AT_SetRelOptions", true
+		case AT_ResetRelOptions:
+			return "AT_ResetRelOptions", true
+		case AT_ReplaceRelOptions:
+			return "AT_ReplaceRelOptions", true
+		case AT_EnableTrig:
+			return "AT_EnableTrig", true
+		case AT_EnableAlwaysTrig:
+			return "AT_EnableAlwaysTrig", true
+		case AT_EnableReplicaTrig:
+			return "AT_EnableReplicaTrig", true
+		case AT_DisableTrig:
+			return "AT_DisableTrig", true
+		case AT_EnableTrigAll:
+			return "AT_EnableTrigAll", true
+		case AT_DisableTrigAll:
+			return "AT_DisableTrigAll", true
+		case AT_EnableTrigUser:
+			return "AT_EnableTrigUser", true
+		case AT_DisableTrigUser:
+			return "AT_DisableTrigUser", true
+		case AT_EnableRule:
+			return "AT_EnableRule", true
+		case AT_EnableAlwaysRule:
+			return "AT_EnableAlwaysRule", true
+		case AT_EnableReplicaRule:
+			return "AT_EnableReplicaRule", true
+		case AT_DisableRule:
+			return "AT_DisableRule", true
+		case AT_AddInherit:
+			return "AT_AddInherit", true
+		case AT_DropInherit:
+			return "AT_DropInherit", true
+		case AT_AddOf:
+			return "AT_AddOf", true
+		case AT_DropOf:
+			return "AT_DropOf", true
+		case AT_ReplicaIdentity:
+			return "AT_ReplicaIdentity", true
+		case AT_EnableRowSecurity:
+			return "AT_EnableRowSecurity", true
+		case AT_DisableRowSecurity:
+			return "AT_DisableRowSecurity", true
+		case AT_ForceRowSecurity:
+			return "AT_ForceRowSecurity", true
+		case AT_NoForceRowSecurity:
+			return "AT_NoForceRowSecurity", true
+		case AT_GenericOptions:
+			return "AT_GenericOptions", true
+		case AT_AttachPartition:
+			return "AT_AttachPartition", true
+		case AT_DetachPartition:
+			return "AT_DetachPartition", true
+		case AT_DetachPartitionFinalize:
+			return "AT_DetachPartitionFinalize", true
+		case AT_AddIdentity:
+			return "AT_AddIdentity", true
+		case AT_SetIdentity:
+			return "AT_SetIdentity", true
+		case AT_DropIdentity:
+			return "AT_DropIdentity", true
+		case AT_ReAddStatistics:
+			return "AT_ReAddStatistics", true
+		}
+	case GrantTargetType:
+		switch v {
+		case ACL_TARGET_OBJECT:
+			return "ACL_TARGET_OBJECT", true
+		case ACL_TARGET_ALL_IN_SCHEMA:
+			return "ACL_TARGET_ALL_IN_SCHEMA", true
+		case ACL_TARGET_DEFAULTS:
+			return "ACL_TARGET_DEFAULTS", true
+		}
+	case VariableSetKind:
+		switch v {
+		case VAR_SET_VALUE:
+			return "VAR_SET_VALUE", true
+		case VAR_SET_DEFAULT:
+			return "VAR_SET_DEFAULT", true
+		case VAR_SET_CURRENT:
+			return "VAR_SET_CURRENT", true
+		case VAR_SET_MULTI:
+			return "VAR_SET_MULTI", true
+		case VAR_RESET:
+			return "VAR_RESET", true
+		case VAR_RESET_ALL:
+			return "VAR_RESET_ALL", true
+		}
+	case ConstrType:
+		switch v {
+		case CONSTR_NULL:
+			return "CONSTR_NULL", true
+		case CONSTR_NOTNULL:
+			return "CONSTR_NOTNULL", true
+		case CONSTR_DEFAULT:
+			return "CONSTR_DEFAULT", true
+		case CONSTR_IDENTITY:
+			return "CONSTR_IDENTITY", true
+		case CONSTR_GENERATED:
+			return "CONSTR_GENERATED", true
+		case CONSTR_CHECK:
+			return "CONSTR_CHECK", true
+		case CONSTR_PRIMARY:
+			return "CONSTR_PRIMARY", true
+		case CONSTR_UNIQUE:
+			return "CONSTR_UNIQUE", true
+		case CONSTR_EXCLUSION:
+			return "CONSTR_EXCLUSION", true
+		case CONSTR_FOREIGN:
+			return "CONSTR_FOREIGN", true
+		case CONSTR_ATTR_DEFERRABLE:
+			return "CONSTR_ATTR_DEFERRABLE", true
+		case CONSTR_ATTR_NOT_DEFERRABLE:
+			return "CONSTR_ATTR_NOT_DEFERRABLE", true
+		case CONSTR_ATTR_DEFERRED:
+			return "CONSTR_ATTR_DEFERRED", true
+		case CONSTR_ATTR_IMMEDIATE:
+			return "CONSTR_ATTR_IMMEDIATE", true
+		}
+	case ImportForeignSchemaType:
+		switch v {
+		case FDW_IMPORT_SCHEMA_ALL:
+			return "FDW_IMPORT_SCHEMA_ALL", true
+		case FDW_IMPORT_SCHEMA_LIMIT_TO:
+			return "FDW_IMPORT_SCHEMA_LIMIT_TO", true
+		case FDW_IMPORT_SCHEMA_EXCEPT:
+			return "FDW_IMPORT_SCHEMA_EXCEPT", true
+		}
+	case RoleStmtType:
+		switch v {
+		case ROLESTMT_ROLE:
+			return "ROLESTMT_ROLE", true
+		case ROLESTMT_USER:
+			return "ROLESTMT_USER", true
+		case ROLESTMT_GROUP:
+			return "ROLESTMT_GROUP", true
+		}
+	case FetchDirection:
+		switch v {
+		case FETCH_FORWARD:
+			return "FETCH_FORWARD", true
+		case FETCH_BACKWARD:
+			return "FETCH_BACKWARD", true
+		case FETCH_ABSOLUTE:
+			return "FETCH_ABSOLUTE", true
+		case FETCH_RELATIVE:
+			return "FETCH_RELATIVE", true
+		}
+	case FunctionParameterMode:
+		switch v {
+		case FUNC_PARAM_IN:
+			return "FUNC_PARAM_IN", true
+		case FUNC_PARAM_OUT:
+			return "FUNC_PARAM_OUT", true
+		case FUNC_PARAM_INOUT:
+			return "FUNC_PARAM_INOUT", true
+		case FUNC_PARAM_VARIADIC:
+			return "FUNC_PARAM_VARIADIC", true
+		case FUNC_PARAM_TABLE:
+			return "FUNC_PARAM_TABLE", true
+		case FUNC_PARAM_DEFAULT:
+			return "FUNC_PARAM_DEFAULT", true
+		}
+	case TransactionStmtKind:
+		switch v {
+		case TRANS_STMT_BEGIN:
+			return "TRANS_STMT_BEGIN", true
+		case TRANS_STMT_START:
+			return "TRANS_STMT_START", true
+		case TRANS_STMT_COMMIT:
+			return "TRANS_STMT_COMMIT", true
+		case TRANS_STMT_ROLLBACK:
+			return "TRANS_STMT_ROLLBACK", true
+		case TRANS_STMT_SAVEPOINT:
+			return "TRANS_STMT_SAVEPOINT", true
+		case TRANS_STMT_RELEASE:
+			return "TRANS_STMT_RELEASE", true
+		case TRANS_STMT_ROLLBACK_TO:
+			return "TRANS_STMT_ROLLBACK_TO", true
+		case TRANS_STMT_PREPARE:
+			return "TRANS_STMT_PREPARE", true
+		case TRANS_STMT_COMMIT_PREPARED:
+			return "TRANS_STMT_COMMIT_PREPARED", true
+		case TRANS_STMT_ROLLBACK_PREPARED:
+			return "TRANS_STMT_ROLLBACK_PREPARED", true
+		}
+	case ViewCheckOption:
+		switch v {
+		case NO_CHECK_OPTION:
+			return "NO_CHECK_OPTION", true
+		case LOCAL_CHECK_OPTION:
+			return "LOCAL_CHECK_OPTION", true
+		case CASCADED_CHECK_OPTION:
+			return "CASCADED_CHECK_OPTION", true
+		}
+	case DiscardMode:
+		switch v {
+		case DISCARD_ALL:
+			return "DISCARD_ALL", true
+		case DISCARD_PLANS:
+			return "DISCARD_PLANS", true
+		case DISCARD_SEQUENCES:
+			return "DISCARD_SEQUENCES", true
+		case DISCARD_TEMP:
+			return "DISCARD_TEMP", true
+		}
+	case ReindexObjectType:
+		switch v {
+		case REINDEX_OBJECT_INDEX:
+			return "REINDEX_OBJECT_INDEX", true
+		case REINDEX_OBJECT_TABLE:
+			return "REINDEX_OBJECT_TABLE", true
+		case REINDEX_OBJECT_SCHEMA:
+			return "REINDEX_OBJECT_SCHEMA", true
+		case REINDEX_OBJECT_SYSTEM:
+			return "REINDEX_OBJECT_SYSTEM", true
+		case REINDEX_OBJECT_DATABASE:
+			return "REINDEX_OBJECT_DATABASE", true
+		}
+	case AlterTSConfigType:
+		switch v {
+		case ALTER_TSCONFIG_ADD_MAPPING:
+			return "ALTER_TSCONFIG_ADD_MAPPING", true
+		case ALTER_TSCONFIG_ALTER_MAPPING_FOR_TOKEN:
+			return "ALTER_TSCONFIG_ALTER_MAPPING_FOR_TOKEN", true
+		case ALTER_TSCONFIG_REPLACE_DICT:
+			return "ALTER_TSCONFIG_REPLACE_DICT", true
+		case ALTER_TSCONFIG_REPLACE_DICT_FOR_TOKEN:
+			return "ALTER_TSCONFIG_REPLACE_DICT_FOR_TOKEN", true
+		case ALTER_TSCONFIG_DROP_MAPPING:
+			return "ALTER_TSCONFIG_DROP_MAPPING", true
+		}
+	case PublicationObjSpecType:
+		switch v {
+		case PUBLICATIONOBJ_TABLE:
+			return "PUBLICATIONOBJ_TABLE", true
+		case PUBLICATIONOBJ_TABLES_IN_SCHEMA:
+			return "PUBLICATIONOBJ_TABLES_IN_SCHEMA", true
+		case PUBLICATIONOBJ_TABLES_IN_CUR_SCHEMA:
+			return "PUBLICATIONOBJ_TABLES_IN_CUR_SCHEMA", true
+		case PUBLICATIONOBJ_CONTINUATION:
+			return "PUBLICATIONOBJ_CONTINUATION", true
+		}
+	case AlterPublicationAction:
+		switch v {
+		case AP_AddObjects:
+			return "AP_AddObjects", true
+		case AP_DropObjects:
+			return "AP_DropObjects", true
+		case AP_SetObjects:
+			return "AP_SetObjects", true
+		}
+	case AlterSubscriptionType:
+		switch v {
+		case ALTER_SUBSCRIPTION_OPTIONS:
+			return "ALTER_SUBSCRIPTION_OPTIONS", true
+		case ALTER_SUBSCRIPTION_CONNECTION:
+			return "ALTER_SUBSCRIPTION_CONNECTION", true
+		case ALTER_SUBSCRIPTION_SET_PUBLICATION:
+			return "ALTER_SUBSCRIPTION_SET_PUBLICATION", true
+		case ALTER_SUBSCRIPTION_ADD_PUBLICATION:
+			return "ALTER_SUBSCRIPTION_ADD_PUBLICATION", true
+		case ALTER_SUBSCRIPTION_DROP_PUBLICATION:
+			return "ALTER_SUBSCRIPTION_DROP_PUBLICATION", true
+		case ALTER_SUBSCRIPTION_REFRESH:
+			return "ALTER_SUBSCRIPTION_REFRESH", true
+		case ALTER_SUBSCRIPTION_ENABLED:
+			return "ALTER_SUBSCRIPTION_ENABLED", true
+		case ALTER_SUBSCRIPTION_SKIP:
+			return "ALTER_SUBSCRIPTION_SKIP", true
+		}
+	case ReturningOptionKind:
+		switch v {
+		case RETURNING_OPTION_OLD:
+			return "RETURNING_OPTION_OLD", true
+		case RETURNING_OPTION_NEW:
+			return "RETURNING_OPTION_NEW", true
+		}
+	case OverridingKind:
+		switch v {
+		case OVERRIDING_NOT_SET:
+			return "OVERRIDING_NOT_SET", true
+		case OVERRIDING_USER_VALUE:
+			return "OVERRIDING_USER_VALUE", true
+		case OVERRIDING_SYSTEM_VALUE:
+			return "OVERRIDING_SYSTEM_VALUE", true
+		}
+	case OnCommitAction:
+		switch v {
+		case ONCOMMIT_NOOP:
+			return "ONCOMMIT_NOOP", true
+		case ONCOMMIT_PRESERVE_ROWS:
+			return "ONCOMMIT_PRESERVE_ROWS", true
+		case ONCOMMIT_DELETE_ROWS:
+			return "ONCOMMIT_DELETE_ROWS", true
+		case ONCOMMIT_DROP:
+			return "ONCOMMIT_DROP", true
+		}
+	case TableFuncType:
+		switch v {
+		case TFT_XMLTABLE:
+			return "TFT_XMLTABLE", true
+		case TFT_JSON_TABLE:
+			return "TFT_JSON_TABLE", true
+		}
+	case ParamKind:
+		switch v {
+		case PARAM_EXTERN:
+			return "PARAM_EXTERN", true
+		case PARAM_EXEC:
+			return "PARAM_EXEC", true
+		case PARAM_SUBLINK:
+			return "PARAM_SUBLINK", true
+		case PARAM_MULTIEXPR:
+			return "PARAM_MULTIEXPR", true
+		}
+	case CoercionContext:
+		switch v {
+		case COERCION_IMPLICIT:
+			return "COERCION_IMPLICIT", true
+		case COERCION_ASSIGNMENT:
+			return "COERCION_ASSIGNMENT", true
+		case COERCION_PLPGSQL:
+			return "COERCION_PLPGSQL", true
+		case COERCION_EXPLICIT:
+			return "COERCION_EXPLICIT", true
+		}
+	case CoercionForm:
+		switch v {
+		case COERCE_EXPLICIT_CALL:
+			return "COERCE_EXPLICIT_CALL", true
+		case COERCE_EXPLICIT_CAST:
+			return "COERCE_EXPLICIT_CAST", true
+		case COERCE_IMPLICIT_CAST:
+			return "COERCE_IMPLICIT_CAST", true
+		case COERCE_SQL_SYNTAX:
+			return "COERCE_SQL_SYNTAX", true
+		}
+	case BoolExprType:
+		switch v {
+		case AND_EXPR:
+			return "AND_EXPR", true
+		case OR_EXPR:
+			return "OR_EXPR", true
+		case NOT_EXPR:
+			return "NOT_EXPR", true
+		}
+	case SubLinkType:
+		switch v {
+		case EXISTS_SUBLINK:
+			return "EXISTS_SUBLINK", true
+		case ALL_SUBLINK:
+			return "ALL_SUBLINK", true
+		case ANY_SUBLINK:
+			return "ANY_SUBLINK", true
+		case ROWCOMPARE_SUBLINK:
+			return "ROWCOMPARE_SUBLINK", true
+		case EXPR_SUBLINK:
+			return "EXPR_SUBLINK", true
+		case MULTIEXPR_SUBLINK:
+			return "MULTIEXPR_SUBLINK", true
+		case ARRAY_SUBLINK:
+			return "ARRAY_SUBLINK", true
+		case CTE_SUBLINK:
+			return "CTE_SUBLINK", true
+		}
+	case RowCompareType:
+		switch v {
+		case ROWCOMPARE_LT:
+			return "ROWCOMPARE_LT", true
+		case ROWCOMPARE_LE:
+			return "ROWCOMPARE_LE", true
+		case ROWCOMPARE_EQ:
+			return "ROWCOMPARE_EQ", true
+		case ROWCOMPARE_GE:
+			return "ROWCOMPARE_GE", true
+		case ROWCOMPARE_GT:
+			return "ROWCOMPARE_GT", true
+		case ROWCOMPARE_NE:
+			return "ROWCOMPARE_NE", true
+		}
+	case MinMaxOp:
+		switch v {
+		case IS_GREATEST:
+			return "IS_GREATEST", true
+		case IS_LEAST:
+			return "IS_LEAST", true
+		}
+	case SQLValueFunctionOp:
+		switch v {
+		case SVFOP_CURRENT_DATE:
+			return "SVFOP_CURRENT_DATE", true
+		case SVFOP_CURRENT_TIME:
+			return "SVFOP_CURRENT_TIME", true
+		case SVFOP_CURRENT_TIME_N:
+			return "SVFOP_CURRENT_TIME_N", true
+		case SVFOP_CURRENT_TIMESTAMP:
+			return "SVFOP_CURRENT_TIMESTAMP", true
+		case SVFOP_CURRENT_TIMESTAMP_N:
+			return "SVFOP_CURRENT_TIMESTAMP_N", true
+		case SVFOP_LOCALTIME:
+			return "SVFOP_LOCALTIME", true
+		case SVFOP_LOCALTIME_N:
+			return "SVFOP_LOCALTIME_N", true
+		case SVFOP_LOCALTIMESTAMP:
+			return "SVFOP_LOCALTIMESTAMP", true
+		case SVFOP_LOCALTIMESTAMP_N:
+			return "SVFOP_LOCALTIMESTAMP_N", true
+		case SVFOP_CURRENT_ROLE:
+			return "SVFOP_CURRENT_ROLE", true
+		case SVFOP_CURRENT_USER:
+			return "SVFOP_CURRENT_USER", true
+		case SVFOP_USER:
+			return "SVFOP_USER", true
+		case SVFOP_SESSION_USER:
+			return "SVFOP_SESSION_USER", true
+		case SVFOP_CURRENT_CATALOG:
+			return "SVFOP_CURRENT_CATALOG", true
+		case SVFOP_CURRENT_SCHEMA:
+			return "SVFOP_CURRENT_SCHEMA", true
+		}
+	case XmlExprOp:
+		switch v {
+		case IS_XMLCONCAT:
+			return "IS_XMLCONCAT", true
+		case IS_XMLELEMENT:
+			return "IS_XMLELEMENT", true
+		case IS_XMLFOREST:
+			return "IS_XMLFOREST", true
+		case IS_XMLPARSE:
+			return "IS_XMLPARSE", true
+		case IS_XMLPI:
+			return "IS_XMLPI", true
+		case IS_XMLROOT:
+			return "IS_XMLROOT", true
+		case IS_XMLSERIALIZE:
+			return "IS_XMLSERIALIZE", true
+		case IS_DOCUMENT:
+			return "IS_DOCUMENT", true
+		}
+	case XmlOptionType:
+		switch v {
+		case XMLOPTION_DOCUMENT:
+			return "XMLOPTION_DOCUMENT", true
+		case XMLOPTION_CONTENT:
+			return "XMLOPTION_CONTENT", true
+		}
+	case JsonEncoding:
+		switch v {
+		case JS_ENC_DEFAULT:
+			return "JS_ENC_DEFAULT", true
+		case JS_ENC_UTF8:
+			return "JS_ENC_UTF8", true
+		case JS_ENC_UTF16:
+			return "JS_ENC_UTF16", true
+		case JS_ENC_UTF32:
+			return "JS_ENC_UTF32", true
+		}
+	case JsonFormatType:
+		switch v {
+		case JS_FORMAT_DEFAULT:
+			return "JS_FORMAT_DEFAULT", true
+		case JS_FORMAT_JSON:
+			return "JS_FORMAT_JSON", true
+		case JS_FORMAT_JSONB:
+			return "JS_FORMAT_JSONB", true
+		}
+	case JsonConstructorType:
+		switch v {
+		case JSCTOR_JSON_OBJECT:
+			return "JSCTOR_JSON_OBJECT", true
+		case JSCTOR_JSON_ARRAY:
+			return "JSCTOR_JSON_ARRAY", true
+		case JSCTOR_JSON_OBJECTAGG:
+			return "JSCTOR_JSON_OBJECTAGG", true
+		case JSCTOR_JSON_ARRAYAGG:
+			return "JSCTOR_JSON_ARRAYAGG", true
+		case JSCTOR_JSON_PARSE:
+			return "JSCTOR_JSON_PARSE", true
+		case JSCTOR_JSON_SCALAR:
+			return "JSCTOR_JSON_SCALAR", true
+		case JSCTOR_JSON_SERIALIZE:
+			return "JSCTOR_JSON_SERIALIZE", true
+		}
+	case JsonValueType:
+		switch v {
+		case JS_TYPE_ANY:
+			return "JS_TYPE_ANY", true
+		case JS_TYPE_OBJECT:
+			return "JS_TYPE_OBJECT", true
+		case JS_TYPE_ARRAY:
+			return "JS_TYPE_ARRAY", true
+		case JS_TYPE_SCALAR:
+			return "JS_TYPE_SCALAR", true
+		}
+	case JsonWrapper:
+		switch v {
+		case JSW_UNSPEC:
+			return "JSW_UNSPEC", true
+		case JSW_NONE:
+			return "JSW_NONE", true
+		case JSW_CONDITIONAL:
+			return "JSW_CONDITIONAL", true
+		case JSW_UNCONDITIONAL:
+			return "JSW_UNCONDITIONAL", true
+		}
+	case JsonBehaviorType:
+		switch v {
+		case JSON_BEHAVIOR_NULL:
+			return "JSON_BEHAVIOR_NULL", true
+		case JSON_BEHAVIOR_ERROR:
+			return "JSON_BEHAVIOR_ERROR", true
+		case JSON_BEHAVIOR_EMPTY:
+			return "JSON_BEHAVIOR_EMPTY", true
+		case JSON_BEHAVIOR_TRUE:
+			return "JSON_BEHAVIOR_TRUE", true
+		case JSON_BEHAVIOR_FALSE:
+			return "JSON_BEHAVIOR_FALSE", true
+		case JSON_BEHAVIOR_UNKNOWN:
+			return "JSON_BEHAVIOR_UNKNOWN", true
+		case JSON_BEHAVIOR_EMPTY_ARRAY:
+			return "JSON_BEHAVIOR_EMPTY_ARRAY", true
+		case JSON_BEHAVIOR_EMPTY_OBJECT:
+			return "JSON_BEHAVIOR_EMPTY_OBJECT", true
+		case JSON_BEHAVIOR_DEFAULT:
+			return "JSON_BEHAVIOR_DEFAULT", true
+		}
+	case JsonExprOp:
+		switch v {
+		case JSON_EXISTS_OP:
+			return "JSON_EXISTS_OP", true
+		case JSON_QUERY_OP:
+			return "JSON_QUERY_OP", true
+		case JSON_VALUE_OP:
+			return "JSON_VALUE_OP", true
+		case JSON_TABLE_OP:
+			return "JSON_TABLE_OP", true
+		}
+	case NullTestType:
+		switch v {
+		case IS_NULL:
+			return "IS_NULL", true
+		case IS_NOT_NULL:
+			return "IS_NOT_NULL", true
+		}
+	case BoolTestType:
+		switch v {
+		case IS_TRUE:
+			return "IS_TRUE", true
+		case IS_NOT_TRUE:
+			return "IS_NOT_TRUE", true
+		case IS_FALSE:
+			return "IS_FALSE", true
+		case IS_NOT_FALSE:
+			return "IS_NOT_FALSE", true
+		case IS_UNKNOWN:
+			return "IS_UNKNOWN", true
+		case IS_NOT_UNKNOWN:
+			return "IS_NOT_UNKNOWN", true
+		}
+	case MergeMatchKind:
+		switch v {
+		case MERGE_WHEN_MATCHED:
+			return "MERGE_WHEN_MATCHED", true
+		case MERGE_WHEN_NOT_MATCHED_BY_SOURCE:
+			return "MERGE_WHEN_NOT_MATCHED_BY_SOURCE", true
+		case MERGE_WHEN_NOT_MATCHED_BY_TARGET:
+			return "MERGE_WHEN_NOT_MATCHED_BY_TARGET", true
+		case NUM_MERGE_MATCH_KINDS:
+			return "NUM_MERGE_MATCH_KINDS", true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,88 @@
+package nodes
+
+import "reflect"
+
+// Equal reports whether two trees are equal, ignoring the source
+// locations recorded in them, as PostgreSQL's equal() does. A nil list
+// equals an empty one.
+func Equal(a, b Node) bool {
+	return equalValue(reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+var parseLocType = reflect.TypeOf(ParseLoc(0))
+
+// equalValue compares a and b, either of which may be invalid (a nil
+// interface).
+func equalValue(a, b reflect.Value) bool {
+	a, b = deref(a), deref(b)
+	if !a.IsValid() || !b.IsValid() {
+		return emptyValue(a) && emptyValue(b)
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			return emptyValue(a) && emptyValue(b)
+		}
+		return equalValue(a.Elem(), b.Elem())
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if a.Type().Field(i).Type == parseLocType {
+				continue
+			}
+			if !equalValue(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !equalValue(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for _, k := range a.MapKeys() {
+			if !equalValue(a.MapIndex(k), b.MapIndex(k)) {
+				return false
+			}
+		}
+		return true
+	}
+	return a.Interface() == b.Interface()
+}
+
+// deref strips interfaces, returning an invalid value for a nil one.
+func deref(v reflect.Value) reflect.Value {
+	for v.IsValid() && v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// emptyValue reports whether v is nil or an empty *List.
+func emptyValue(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+	if v.Kind() != reflect.Ptr {
+		return false
+	}
+	if v.IsNil() {
+		return true
+	}
+	l, ok := v.Interface().(*List)
+	return ok && len(l.Items) == 0
+}
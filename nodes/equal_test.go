@@ -0,0 +1,27 @@
+package nodes
+
+import "testing"
+
+func TestEqual(t *testing.T) {
+	col := func(name string, loc ParseLoc) Node {
+		return &ColumnRef{Fields: &List{Items: []Node{&String{Sval: name}}}, Location: loc}
+	}
+	a := &SelectStmt{TargetList: &List{Items: []Node{&ResTarget{Val: col("a", 7), Location: 7}}}}
+	b := &SelectStmt{
+		TargetList:  &List{Items: []Node{&ResTarget{Val: col("a", 12), Location: 12}}},
+		GroupClause: &List{},
+	}
+	if !Equal(a, b) {
+		t.Error("Equal should ignore locations and treat an empty list as nil")
+	}
+	b.TargetList.Items[0].(*ResTarget).Name = "x"
+	if Equal(a, b) {
+		t.Error("Equal should compare names")
+	}
+	if Equal(col("a", 0), &String{Sval: "a"}) {
+		t.Error("Equal should compare node types")
+	}
+	if !Equal(nil, (*List)(nil)) || Equal(nil, col("a", 0)) {
+		t.Error("Equal mishandles nil")
+	}
+}
@@ -0,0 +1,75 @@
+package nodes
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+)
+
+// Fingerprint returns a hash of the tree rooted at node that ignores
+// source locations and the values of constants, so that statements which
+// differ only in their literals, layout or comments have the same
+// fingerprint. Trees that are Equal always have the same fingerprint. The
+// hash is FNV-1a, not the XXH3 of pg_query, so the values differ from
+// pg_query's fingerprints.
+func Fingerprint(node Node) uint64 {
+	h := fnv.New64a()
+	fingerprintValue(h, reflect.ValueOf(node))
+	return h.Sum64()
+}
+
+var aConstType = reflect.TypeOf(A_Const{})
+
+// fingerprintValue hashes v, which may be invalid (a nil interface). Nil
+// values and empty lists hash alike, as Equal treats them alike.
+func fingerprintValue(h hash.Hash64, v reflect.Value) {
+	v = deref(v)
+	if emptyValue(v) {
+		h.Write([]byte{0})
+		return
+	}
+	var buf [8]byte
+	switch v.Kind() {
+	case reflect.Ptr:
+		fingerprintValue(h, v.Elem())
+	case reflect.Struct:
+		h.Write([]byte(v.Type().Name()))
+		h.Write([]byte{'{'})
+		if v.Type() != aConstType {
+			for i := 0; i < v.NumField(); i++ {
+				if v.Type().Field(i).Type == parseLocType {
+					continue
+				}
+				fingerprintValue(h, v.Field(i))
+			}
+		}
+		h.Write([]byte{'}'})
+	case reflect.Slice, reflect.Array:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v.Len()))
+		h.Write(buf[:])
+		for i := 0; i < v.Len(); i++ {
+			fingerprintValue(h, v.Index(i))
+		}
+	case reflect.String:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v.Len()))
+		h.Write(buf[:])
+		h.Write([]byte(v.String()))
+	case reflect.Bool:
+		if v.Bool() {
+			h.Write([]byte{1})
+		} else {
+			h.Write([]byte{2})
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v.Int()))
+		h.Write(buf[:])
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		binary.LittleEndian.PutUint64(buf[:], v.Uint())
+		h.Write(buf[:])
+	case reflect.Float32, reflect.Float64:
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v.Float()))
+		h.Write(buf[:])
+	}
+}
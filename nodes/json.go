@@ -0,0 +1,255 @@
+package nodes
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// NodeToJSON converts a Node to JSON in the shape of pg_query's JSON
+// output. A node is an object whose only key is its type name and whose
+// value holds its fields, except where a field's type is a specific node
+// type, which holds the fields directly. Lists are arrays. Fields that are
+// zero, false, empty or nil are left out, except enums, which are written
+// by name, and the values of String and Boolean nodes. Field names are
+// PostgreSQL's: the `pg` tag of a field, or else the Go name with its first
+// letter lowered.
+func NodeToJSON(node Node) string {
+	var sb strings.Builder
+	writeJSONNode(&sb, node)
+	return sb.String()
+}
+
+// jsonFieldName returns the name of field f in JSON output.
+func jsonFieldName(f reflect.StructField) string {
+	if tag := f.Tag.Get("pg"); tag != "" {
+		return tag
+	}
+	return strings.ToLower(f.Name[:1]) + f.Name[1:]
+}
+
+// writeJSONNode writes node wrapped in an object keyed by its type name.
+func writeJSONNode(sb *strings.Builder, node Node) {
+	v := reflect.ValueOf(node)
+	if node == nil || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		sb.WriteString("{}")
+		return
+	}
+	name := v.Elem().Type().Name()
+	sb.WriteString(`{"` + name + `":`)
+	switch n := node.(type) {
+	case *List:
+		sb.WriteString(`{"items":`)
+		writeJSONList(sb, n)
+		sb.WriteString("}")
+	case *IntList, *OidList:
+		sb.WriteString(`{"items":`)
+		writeJSONValue(sb, v)
+		sb.WriteString("}")
+	case *A_Const:
+		writeJSONAConst(sb, n)
+	case *String, *Boolean:
+		writeJSONValueFields(sb, n)
+	default:
+		writeJSONFields(sb, v.Elem())
+	}
+	sb.WriteString("}")
+}
+
+// writeJSONValueFields writes the field of a String or Boolean, which
+// pg_query writes even when it is empty or false.
+func writeJSONValueFields(sb *strings.Builder, node Node) {
+	switch n := node.(type) {
+	case *String:
+		sb.WriteString(`{"sval":`)
+		writeJSONString(sb, n.Sval)
+		sb.WriteString("}")
+	case *Boolean:
+		sb.WriteString(`{"boolval":` + strconv.FormatBool(n.Boolval) + "}")
+	}
+}
+
+// writeJSONAConst writes the fields of an A_Const, whose value is keyed by
+// its type, as in pg_query.
+func writeJSONAConst(sb *strings.Builder, n *A_Const) {
+	sb.WriteString("{")
+	sep := ""
+	if n.Isnull {
+		sb.WriteString(`"isnull":true`)
+		sep = ","
+	} else if n.Val != nil {
+		sb.WriteString(`"`)
+		switch n.Val.(type) {
+		case *Integer:
+			sb.WriteString("ival")
+		case *Float:
+			sb.WriteString("fval")
+		case *Boolean:
+			sb.WriteString("boolval")
+		case *String:
+			sb.WriteString("sval")
+		case *BitString:
+			sb.WriteString("bsval")
+		default:
+			sb.WriteString("val")
+		}
+		sb.WriteString(`":`)
+		// pg_query writes a constant's false boolval as {}, unlike a
+		// Boolean node's.
+		if s, ok := n.Val.(*String); ok {
+			writeJSONValueFields(sb, s)
+		} else {
+			writeJSONFields(sb, reflect.ValueOf(n.Val).Elem())
+		}
+		sep = ","
+	}
+	if n.Location != 0 {
+		sb.WriteString(sep + `"location":` + strconv.Itoa(int(n.Location)))
+	}
+	sb.WriteString("}")
+}
+
+// writeJSONFields writes the fields of struct value v as an object.
+func writeJSONFields(sb *strings.Builder, v reflect.Value) {
+	sb.WriteString("{")
+	n := 0
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		fv := v.Field(i)
+		if !f.IsExported() || omitJSON(fv) {
+			continue
+		}
+		if n > 0 {
+			sb.WriteString(",")
+		}
+		n++
+		sb.WriteString(`"` + jsonFieldName(f) + `":`)
+		writeJSONValue(sb, fv)
+	}
+	sb.WriteString("}")
+}
+
+// omitJSON reports whether a field is left out of JSON output.
+func omitJSON(v reflect.Value) bool {
+	if v.CanInterface() {
+		if _, ok := enumName(v.Interface()); ok {
+			return false
+		}
+	}
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if v.IsNil() {
+			return true
+		}
+		if l, ok := v.Interface().(*List); ok {
+			return len(l.Items) == 0
+		}
+		return false
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	case reflect.Struct:
+		return false
+	}
+	return v.IsZero()
+}
+
+// writeJSONValue writes a field value.
+func writeJSONValue(sb *strings.Builder, v reflect.Value) {
+	if v.CanInterface() {
+		if name, ok := enumName(v.Interface()); ok {
+			sb.WriteString(`"` + name + `"`)
+			return
+		}
+	}
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			sb.WriteString("{}")
+			return
+		}
+		if n, ok := v.Interface().(Node); ok {
+			writeJSONNode(sb, n)
+			return
+		}
+		writeJSONValue(sb, v.Elem())
+	case reflect.Ptr:
+		switch n := v.Interface().(type) {
+		case *List:
+			writeJSONList(sb, n)
+		case *IntList:
+			writeJSONValue(sb, reflect.ValueOf(n.Items))
+		case *Bitmapset:
+			writeJSONValue(sb, reflect.ValueOf(n.Items))
+		case *OidList:
+			writeJSONValue(sb, reflect.ValueOf(n.Items))
+		default:
+			if v.IsNil() {
+				sb.WriteString("{}")
+				return
+			}
+			writeJSONValue(sb, v.Elem())
+		}
+	case reflect.Struct:
+		writeJSONFields(sb, v)
+	case reflect.Slice:
+		sb.WriteString("[")
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+			writeJSONValue(sb, v.Index(i))
+		}
+		sb.WriteString("]")
+	case reflect.Bool:
+		sb.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.String:
+		writeJSONString(sb, v.String())
+	case reflect.Uint8:
+		// A C char field, such as relpersistence.
+		writeJSONString(sb, string(rune(v.Uint())))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		sb.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		sb.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		sb.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, 64))
+	default:
+		sb.WriteString("null")
+	}
+}
+
+// writeJSONList writes the items of a list as an array of nodes.
+func writeJSONList(sb *strings.Builder, l *List) {
+	sb.WriteString("[")
+	for i, item := range l.Items {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		writeJSONNode(sb, item)
+	}
+	sb.WriteString("]")
+}
+
+// writeJSONString writes s as a JSON string.
+func writeJSONString(sb *strings.Builder, s string) {
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '\r':
+			sb.WriteString(`\r`)
+		case r == '\t':
+			sb.WriteString(`\t`)
+		case r < 0x20:
+			fmt.Fprintf(sb, `\u%04x`, r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+}
@@ -0,0 +1,57 @@
+package nodes
+
+import "testing"
+
+func TestNodeToJSON(t *testing.T) {
+	stmt := &SelectStmt{
+		TargetList: &List{Items: []Node{
+			&ResTarget{Val: &ColumnRef{Fields: &List{Items: []Node{&String{Sval: "a"}}}, Location: 7}, Location: 7},
+			&ResTarget{Val: &A_Const{Val: &Integer{Ival: 1}, Location: 10}, Location: 10},
+		}},
+		FromClause: &List{Items: []Node{&RangeVar{Relname: "t", Inh: true, Relpersistence: 'p', Location: 17}}},
+	}
+	want := `{"SelectStmt":{"targetList":[` +
+		`{"ResTarget":{"val":{"ColumnRef":{"fields":[{"String":{"sval":"a"}}],"location":7}},"location":7}},` +
+		`{"ResTarget":{"val":{"A_Const":{"ival":{"ival":1},"location":10}},"location":10}}],` +
+		`"fromClause":[{"RangeVar":{"relname":"t","inh":true,"relpersistence":"p","location":17}}],` +
+		`"limitOption":"LIMIT_OPTION_DEFAULT","op":"SETOP_NONE"}}`
+	if got := NodeToJSON(stmt); got != want {
+		t.Errorf("NodeToJSON:\ngot  %s\nwant %s", got, want)
+	}
+
+	tests := []struct {
+		node Node
+		want string
+	}{
+		{&A_Const{Isnull: true, Location: 3}, `{"A_Const":{"isnull":true,"location":3}}`},
+		{&String{Sval: "a\"b\n"}, `{"String":{"sval":"a\"b\n"}}`},
+		{&AlterObjectSchemaStmt{ObjectType: OBJECT_TABLE, Newschema: "s", MissingOk: true},
+			`{"AlterObjectSchemaStmt":{"objectType":"OBJECT_TABLE","newschema":"s","missing_ok":true}}`},
+		{&List{Items: []Node{&Integer{Ival: 1}}}, `{"List":{"items":[{"Integer":{"ival":1}}]}}`},
+		{nil, `{}`},
+	}
+	for _, tt := range tests {
+		if got := NodeToJSON(tt.node); got != tt.want {
+			t.Errorf("NodeToJSON(%T):\ngot  %s\nwant %s", tt.node, got, tt.want)
+		}
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	sel := func(col string, val Node, loc ParseLoc) Node {
+		return &SelectStmt{
+			TargetList:  &List{Items: []Node{&ResTarget{Val: &ColumnRef{Fields: &List{Items: []Node{&String{Sval: col}}}}, Location: loc}}},
+			WhereClause: &A_Expr{Kind: AEXPR_OP, Name: &List{Items: []Node{&String{Sval: "="}}}, Lexpr: &ColumnRef{Fields: &List{Items: []Node{&String{Sval: col}}}}, Rexpr: &A_Const{Val: val, Location: loc + 20}},
+		}
+	}
+	a := Fingerprint(sel("a", &Integer{Ival: 1}, 7))
+	if b := Fingerprint(sel("a", &String{Sval: "x"}, 12)); a != b {
+		t.Error("Fingerprint should ignore constants and locations")
+	}
+	if b := Fingerprint(sel("b", &Integer{Ival: 1}, 7)); a == b {
+		t.Error("Fingerprint should depend on column names")
+	}
+	if Fingerprint(&List{}) != Fingerprint(nil) {
+		t.Error("Fingerprint should treat an empty list as nil")
+	}
+}
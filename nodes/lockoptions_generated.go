@@ -0,0 +1,39 @@
+// Code generated by pgsema-gen. DO NOT EDIT.
+// Source: PostgreSQL nodes/lockoptions.h
+
+package nodes
+
+// This enum represents the different strengths of FOR UPDATE/SHARE clauses.
+// The ordering here is important, because the highest numerical value takes
+// precedence when a RTE is specified multiple ways.  See applyLockingClause.
+type LockClauseStrength int
+
+const (
+	LCS_NONE           LockClauseStrength = iota // no such clause - only used in PlanRowMark
+	LCS_FORKEYSHARE                              // FOR KEY SHARE
+	LCS_FORSHARE                                 // FOR SHARE
+	LCS_FORNOKEYUPDATE                           // FOR NO KEY UPDATE
+	LCS_FORUPDATE                                // FOR UPDATE
+)
+
+// This enum controls how to deal with rows being locked by FOR UPDATE/SHARE
+// clauses (i.e., it represents the NOWAIT and SKIP LOCKED options).
+// The ordering here is important, because the highest numerical value takes
+// precedence when a RTE is specified multiple ways.  See applyLockingClause.
+type LockWaitPolicy int
+
+const (
+	LockWaitBlock LockWaitPolicy = iota // Wait for the lock to become available (default behavior)
+	LockWaitSkip                        // Skip rows that can't be locked (SKIP LOCKED)
+	LockWaitError                       // Raise an error if a row cannot be locked (NOWAIT)
+)
+
+// Possible lock modes for a tuple.
+type LockTupleMode int
+
+const (
+	LockTupleKeyShare       LockTupleMode = iota // SELECT FOR KEY SHARE
+	LockTupleShare                               // SELECT FOR SHARE
+	LockTupleNoKeyExclusive                      // SELECT FOR NO KEY UPDATE, and UPDATEs that don't modify key columns
+	LockTupleExclusive                           // SELECT FOR UPDATE, UPDATEs that modify key columns, and DELETE
+)
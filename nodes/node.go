@@ -45,45 +45,15 @@ type IntList struct {
 
 func (l *IntList) Tag() NodeTag { return T_IntList }
 
+// Bitmapset is a set of non-negative integers, such as the range table
+// indexes of a Var's varnullingrels. Unlike the lists it is not a node.
+type Bitmapset struct {
+	Items []int // members, in increasing order
+}
+
 // OidList represents a list of OIDs.
 type OidList struct {
 	Items []Oid
 }
 
 func (l *OidList) Tag() NodeTag { return T_OidList }
-
-// String represents a PostgreSQL String value node.
-type String struct {
-	Str string
-}
-
-func (s *String) Tag() NodeTag { return T_String }
-
-// Integer represents a PostgreSQL Integer value node.
-type Integer struct {
-	Ival int64
-}
-
-func (i *Integer) Tag() NodeTag { return T_Integer }
-
-// Float represents a PostgreSQL Float value node.
-// Note: PostgreSQL stores floats as strings to preserve precision.
-type Float struct {
-	Fval string
-}
-
-func (f *Float) Tag() NodeTag { return T_Float }
-
-// Boolean represents a PostgreSQL Boolean value node.
-type Boolean struct {
-	Boolval bool
-}
-
-func (b *Boolean) Tag() NodeTag { return T_Boolean }
-
-// BitString represents a PostgreSQL bit string value node.
-type BitString struct {
-	Bsval string
-}
-
-func (b *BitString) Tag() NodeTag { return T_BitString }
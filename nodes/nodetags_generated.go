@@ -0,0 +1,1457 @@
+// Code generated by pgsema-gen. DO NOT EDIT.
+// Source: PostgreSQL nodes/nodes.h and nodes/nodetags.h
+
+package nodes
+
+// The first field of every node is NodeTag. Each node created (with makeNode)
+// will have one of the following tags as the value of its first field.
+//
+// Note that inserting or deleting node types changes the numbers of other
+// node types later in the list.  This is no problem during development, since
+// the node numbers are never stored on disk.  But don't do it in a released
+// branch, because that would represent an ABI break for extensions.
+type NodeTag int
+
+const (
+	T_Invalid                            NodeTag = 0
+	T_List                               NodeTag = 1
+	T_Alias                              NodeTag = 2
+	T_RangeVar                           NodeTag = 3
+	T_TableFunc                          NodeTag = 4
+	T_IntoClause                         NodeTag = 5
+	T_Var                                NodeTag = 6
+	T_Const                              NodeTag = 7
+	T_Param                              NodeTag = 8
+	T_Aggref                             NodeTag = 9
+	T_GroupingFunc                       NodeTag = 10
+	T_WindowFunc                         NodeTag = 11
+	T_WindowFuncRunCondition             NodeTag = 12
+	T_MergeSupportFunc                   NodeTag = 13
+	T_SubscriptingRef                    NodeTag = 14
+	T_FuncExpr                           NodeTag = 15
+	T_NamedArgExpr                       NodeTag = 16
+	T_OpExpr                             NodeTag = 17
+	T_DistinctExpr                       NodeTag = 18
+	T_NullIfExpr                         NodeTag = 19
+	T_ScalarArrayOpExpr                  NodeTag = 20
+	T_BoolExpr                           NodeTag = 21
+	T_SubLink                            NodeTag = 22
+	T_SubPlan                            NodeTag = 23
+	T_AlternativeSubPlan                 NodeTag = 24
+	T_FieldSelect                        NodeTag = 25
+	T_FieldStore                         NodeTag = 26
+	T_RelabelType                        NodeTag = 27
+	T_CoerceViaIO                        NodeTag = 28
+	T_ArrayCoerceExpr                    NodeTag = 29
+	T_ConvertRowtypeExpr                 NodeTag = 30
+	T_CollateExpr                        NodeTag = 31
+	T_CaseExpr                           NodeTag = 32
+	T_CaseWhen                           NodeTag = 33
+	T_CaseTestExpr                       NodeTag = 34
+	T_ArrayExpr                          NodeTag = 35
+	T_RowExpr                            NodeTag = 36
+	T_RowCompareExpr                     NodeTag = 37
+	T_CoalesceExpr                       NodeTag = 38
+	T_MinMaxExpr                         NodeTag = 39
+	T_SQLValueFunction                   NodeTag = 40
+	T_XmlExpr                            NodeTag = 41
+	T_JsonFormat                         NodeTag = 42
+	T_JsonReturning                      NodeTag = 43
+	T_JsonValueExpr                      NodeTag = 44
+	T_JsonConstructorExpr                NodeTag = 45
+	T_JsonIsPredicate                    NodeTag = 46
+	T_JsonBehavior                       NodeTag = 47
+	T_JsonExpr                           NodeTag = 48
+	T_JsonTablePath                      NodeTag = 49
+	T_JsonTablePathScan                  NodeTag = 50
+	T_JsonTableSiblingJoin               NodeTag = 51
+	T_NullTest                           NodeTag = 52
+	T_BooleanTest                        NodeTag = 53
+	T_MergeAction                        NodeTag = 54
+	T_CoerceToDomain                     NodeTag = 55
+	T_CoerceToDomainValue                NodeTag = 56
+	T_SetToDefault                       NodeTag = 57
+	T_CurrentOfExpr                      NodeTag = 58
+	T_NextValueExpr                      NodeTag = 59
+	T_InferenceElem                      NodeTag = 60
+	T_TargetEntry                        NodeTag = 61
+	T_RangeTblRef                        NodeTag = 62
+	T_JoinExpr                           NodeTag = 63
+	T_FromExpr                           NodeTag = 64
+	T_OnConflictExpr                     NodeTag = 65
+	T_Query                              NodeTag = 66
+	T_TypeName                           NodeTag = 67
+	T_ColumnRef                          NodeTag = 68
+	T_ParamRef                           NodeTag = 69
+	T_A_Expr                             NodeTag = 70
+	T_A_Const                            NodeTag = 71
+	T_TypeCast                           NodeTag = 72
+	T_CollateClause                      NodeTag = 73
+	T_RoleSpec                           NodeTag = 74
+	T_FuncCall                           NodeTag = 75
+	T_A_Star                             NodeTag = 76
+	T_A_Indices                          NodeTag = 77
+	T_A_Indirection                      NodeTag = 78
+	T_A_ArrayExpr                        NodeTag = 79
+	T_ResTarget                          NodeTag = 80
+	T_MultiAssignRef                     NodeTag = 81
+	T_SortBy                             NodeTag = 82
+	T_WindowDef                          NodeTag = 83
+	T_RangeSubselect                     NodeTag = 84
+	T_RangeFunction                      NodeTag = 85
+	T_RangeTableFunc                     NodeTag = 86
+	T_RangeTableFuncCol                  NodeTag = 87
+	T_RangeTableSample                   NodeTag = 88
+	T_ColumnDef                          NodeTag = 89
+	T_TableLikeClause                    NodeTag = 90
+	T_IndexElem                          NodeTag = 91
+	T_DefElem                            NodeTag = 92
+	T_LockingClause                      NodeTag = 93
+	T_XmlSerialize                       NodeTag = 94
+	T_PartitionElem                      NodeTag = 95
+	T_PartitionSpec                      NodeTag = 96
+	T_PartitionBoundSpec                 NodeTag = 97
+	T_PartitionRangeDatum                NodeTag = 98
+	T_SinglePartitionSpec                NodeTag = 99
+	T_PartitionCmd                       NodeTag = 100
+	T_RangeTblEntry                      NodeTag = 101
+	T_RTEPermissionInfo                  NodeTag = 102
+	T_RangeTblFunction                   NodeTag = 103
+	T_TableSampleClause                  NodeTag = 104
+	T_WithCheckOption                    NodeTag = 105
+	T_SortGroupClause                    NodeTag = 106
+	T_GroupingSet                        NodeTag = 107
+	T_WindowClause                       NodeTag = 108
+	T_RowMarkClause                      NodeTag = 109
+	T_WithClause                         NodeTag = 110
+	T_InferClause                        NodeTag = 111
+	T_OnConflictClause                   NodeTag = 112
+	T_CTESearchClause                    NodeTag = 113
+	T_CTECycleClause                     NodeTag = 114
+	T_CommonTableExpr                    NodeTag = 115
+	T_MergeWhenClause                    NodeTag = 116
+	T_TriggerTransition                  NodeTag = 117
+	T_JsonOutput                         NodeTag = 118
+	T_JsonArgument                       NodeTag = 119
+	T_JsonFuncExpr                       NodeTag = 120
+	T_JsonTablePathSpec                  NodeTag = 121
+	T_JsonTable                          NodeTag = 122
+	T_JsonTableColumn                    NodeTag = 123
+	T_JsonKeyValue                       NodeTag = 124
+	T_JsonParseExpr                      NodeTag = 125
+	T_JsonScalarExpr                     NodeTag = 126
+	T_JsonSerializeExpr                  NodeTag = 127
+	T_JsonObjectConstructor              NodeTag = 128
+	T_JsonArrayConstructor               NodeTag = 129
+	T_JsonArrayQueryConstructor          NodeTag = 130
+	T_JsonAggConstructor                 NodeTag = 131
+	T_JsonObjectAgg                      NodeTag = 132
+	T_JsonArrayAgg                       NodeTag = 133
+	T_RawStmt                            NodeTag = 134
+	T_InsertStmt                         NodeTag = 135
+	T_DeleteStmt                         NodeTag = 136
+	T_UpdateStmt                         NodeTag = 137
+	T_MergeStmt                          NodeTag = 138
+	T_SelectStmt                         NodeTag = 139
+	T_SetOperationStmt                   NodeTag = 140
+	T_ReturnStmt                         NodeTag = 141
+	T_PLAssignStmt                       NodeTag = 142
+	T_CreateSchemaStmt                   NodeTag = 143
+	T_AlterTableStmt                     NodeTag = 144
+	T_ReplicaIdentityStmt                NodeTag = 145
+	T_AlterTableCmd                      NodeTag = 146
+	T_AlterCollationStmt                 NodeTag = 147
+	T_AlterDomainStmt                    NodeTag = 148
+	T_GrantStmt                          NodeTag = 149
+	T_ObjectWithArgs                     NodeTag = 150
+	T_AccessPriv                         NodeTag = 151
+	T_GrantRoleStmt                      NodeTag = 152
+	T_AlterDefaultPrivilegesStmt         NodeTag = 153
+	T_CopyStmt                           NodeTag = 154
+	T_VariableSetStmt                    NodeTag = 155
+	T_VariableShowStmt                   NodeTag = 156
+	T_CreateStmt                         NodeTag = 157
+	T_Constraint                         NodeTag = 158
+	T_CreateTableSpaceStmt               NodeTag = 159
+	T_DropTableSpaceStmt                 NodeTag = 160
+	T_AlterTableSpaceOptionsStmt         NodeTag = 161
+	T_AlterTableMoveAllStmt              NodeTag = 162
+	T_CreateExtensionStmt                NodeTag = 163
+	T_AlterExtensionStmt                 NodeTag = 164
+	T_AlterExtensionContentsStmt         NodeTag = 165
+	T_CreateFdwStmt                      NodeTag = 166
+	T_AlterFdwStmt                       NodeTag = 167
+	T_CreateForeignServerStmt            NodeTag = 168
+	T_AlterForeignServerStmt             NodeTag = 169
+	T_CreateForeignTableStmt             NodeTag = 170
+	T_CreateUserMappingStmt              NodeTag = 171
+	T_AlterUserMappingStmt               NodeTag = 172
+	T_DropUserMappingStmt                NodeTag = 173
+	T_ImportForeignSchemaStmt            NodeTag = 174
+	T_CreatePolicyStmt                   NodeTag = 175
+	T_AlterPolicyStmt                    NodeTag = 176
+	T_CreateAmStmt                       NodeTag = 177
+	T_CreateTrigStmt                     NodeTag = 178
+	T_CreateEventTrigStmt                NodeTag = 179
+	T_AlterEventTrigStmt                 NodeTag = 180
+	T_CreatePLangStmt                    NodeTag = 181
+	T_CreateRoleStmt                     NodeTag = 182
+	T_AlterRoleStmt                      NodeTag = 183
+	T_AlterRoleSetStmt                   NodeTag = 184
+	T_DropRoleStmt                       NodeTag = 185
+	T_CreateSeqStmt                      NodeTag = 186
+	T_AlterSeqStmt                       NodeTag = 187
+	T_DefineStmt                         NodeTag = 188
+	T_CreateDomainStmt                   NodeTag = 189
+	T_CreateOpClassStmt                  NodeTag = 190
+	T_CreateOpClassItem                  NodeTag = 191
+	T_CreateOpFamilyStmt                 NodeTag = 192
+	T_AlterOpFamilyStmt                  NodeTag = 193
+	T_DropStmt                           NodeTag = 194
+	T_TruncateStmt                       NodeTag = 195
+	T_CommentStmt                        NodeTag = 196
+	T_SecLabelStmt                       NodeTag = 197
+	T_DeclareCursorStmt                  NodeTag = 198
+	T_ClosePortalStmt                    NodeTag = 199
+	T_FetchStmt                          NodeTag = 200
+	T_IndexStmt                          NodeTag = 201
+	T_CreateStatsStmt                    NodeTag = 202
+	T_StatsElem                          NodeTag = 203
+	T_AlterStatsStmt                     NodeTag = 204
+	T_CreateFunctionStmt                 NodeTag = 205
+	T_FunctionParameter                  NodeTag = 206
+	T_AlterFunctionStmt                  NodeTag = 207
+	T_DoStmt                             NodeTag = 208
+	T_InlineCodeBlock                    NodeTag = 209
+	T_CallStmt                           NodeTag = 210
+	T_CallContext                        NodeTag = 211
+	T_RenameStmt                         NodeTag = 212
+	T_AlterObjectDependsStmt             NodeTag = 213
+	T_AlterObjectSchemaStmt              NodeTag = 214
+	T_AlterOwnerStmt                     NodeTag = 215
+	T_AlterOperatorStmt                  NodeTag = 216
+	T_AlterTypeStmt                      NodeTag = 217
+	T_RuleStmt                           NodeTag = 218
+	T_NotifyStmt                         NodeTag = 219
+	T_ListenStmt                         NodeTag = 220
+	T_UnlistenStmt                       NodeTag = 221
+	T_TransactionStmt                    NodeTag = 222
+	T_CompositeTypeStmt                  NodeTag = 223
+	T_CreateEnumStmt                     NodeTag = 224
+	T_CreateRangeStmt                    NodeTag = 225
+	T_AlterEnumStmt                      NodeTag = 226
+	T_ViewStmt                           NodeTag = 227
+	T_LoadStmt                           NodeTag = 228
+	T_CreatedbStmt                       NodeTag = 229
+	T_AlterDatabaseStmt                  NodeTag = 230
+	T_AlterDatabaseRefreshCollStmt       NodeTag = 231
+	T_AlterDatabaseSetStmt               NodeTag = 232
+	T_DropdbStmt                         NodeTag = 233
+	T_AlterSystemStmt                    NodeTag = 234
+	T_ClusterStmt                        NodeTag = 235
+	T_VacuumStmt                         NodeTag = 236
+	T_VacuumRelation                     NodeTag = 237
+	T_ExplainStmt                        NodeTag = 238
+	T_CreateTableAsStmt                  NodeTag = 239
+	T_RefreshMatViewStmt                 NodeTag = 240
+	T_CheckPointStmt                     NodeTag = 241
+	T_DiscardStmt                        NodeTag = 242
+	T_LockStmt                           NodeTag = 243
+	T_ConstraintsSetStmt                 NodeTag = 244
+	T_ReindexStmt                        NodeTag = 245
+	T_CreateConversionStmt               NodeTag = 246
+	T_CreateCastStmt                     NodeTag = 247
+	T_CreateTransformStmt                NodeTag = 248
+	T_PrepareStmt                        NodeTag = 249
+	T_ExecuteStmt                        NodeTag = 250
+	T_DeallocateStmt                     NodeTag = 251
+	T_DropOwnedStmt                      NodeTag = 252
+	T_ReassignOwnedStmt                  NodeTag = 253
+	T_AlterTSDictionaryStmt              NodeTag = 254
+	T_AlterTSConfigurationStmt           NodeTag = 255
+	T_PublicationTable                   NodeTag = 256
+	T_PublicationObjSpec                 NodeTag = 257
+	T_CreatePublicationStmt              NodeTag = 258
+	T_AlterPublicationStmt               NodeTag = 259
+	T_CreateSubscriptionStmt             NodeTag = 260
+	T_AlterSubscriptionStmt              NodeTag = 261
+	T_DropSubscriptionStmt               NodeTag = 262
+	T_PlannerGlobal                      NodeTag = 263
+	T_PlannerInfo                        NodeTag = 264
+	T_RelOptInfo                         NodeTag = 265
+	T_IndexOptInfo                       NodeTag = 266
+	T_ForeignKeyOptInfo                  NodeTag = 267
+	T_StatisticExtInfo                   NodeTag = 268
+	T_JoinDomain                         NodeTag = 269
+	T_EquivalenceClass                   NodeTag = 270
+	T_EquivalenceMember                  NodeTag = 271
+	T_PathKey                            NodeTag = 272
+	T_GroupByOrdering                    NodeTag = 273
+	T_PathTarget                         NodeTag = 274
+	T_ParamPathInfo                      NodeTag = 275
+	T_Path                               NodeTag = 276
+	T_IndexPath                          NodeTag = 277
+	T_IndexClause                        NodeTag = 278
+	T_BitmapHeapPath                     NodeTag = 279
+	T_BitmapAndPath                      NodeTag = 280
+	T_BitmapOrPath                       NodeTag = 281
+	T_TidPath                            NodeTag = 282
+	T_TidRangePath                       NodeTag = 283
+	T_SubqueryScanPath                   NodeTag = 284
+	T_ForeignPath                        NodeTag = 285
+	T_CustomPath                         NodeTag = 286
+	T_AppendPath                         NodeTag = 287
+	T_MergeAppendPath                    NodeTag = 288
+	T_GroupResultPath                    NodeTag = 289
+	T_MaterialPath                       NodeTag = 290
+	T_MemoizePath                        NodeTag = 291
+	T_UniquePath                         NodeTag = 292
+	T_GatherPath                         NodeTag = 293
+	T_GatherMergePath                    NodeTag = 294
+	T_NestPath                           NodeTag = 295
+	T_MergePath                          NodeTag = 296
+	T_HashPath                           NodeTag = 297
+	T_ProjectionPath                     NodeTag = 298
+	T_ProjectSetPath                     NodeTag = 299
+	T_SortPath                           NodeTag = 300
+	T_IncrementalSortPath                NodeTag = 301
+	T_GroupPath                          NodeTag = 302
+	T_UpperUniquePath                    NodeTag = 303
+	T_AggPath                            NodeTag = 304
+	T_GroupingSetData                    NodeTag = 305
+	T_RollupData                         NodeTag = 306
+	T_GroupingSetsPath                   NodeTag = 307
+	T_MinMaxAggPath                      NodeTag = 308
+	T_WindowAggPath                      NodeTag = 309
+	T_SetOpPath                          NodeTag = 310
+	T_RecursiveUnionPath                 NodeTag = 311
+	T_LockRowsPath                       NodeTag = 312
+	T_ModifyTablePath                    NodeTag = 313
+	T_LimitPath                          NodeTag = 314
+	T_RestrictInfo                       NodeTag = 315
+	T_PlaceHolderVar                     NodeTag = 316
+	T_SpecialJoinInfo                    NodeTag = 317
+	T_OuterJoinClauseInfo                NodeTag = 318
+	T_AppendRelInfo                      NodeTag = 319
+	T_RowIdentityVarInfo                 NodeTag = 320
+	T_PlaceHolderInfo                    NodeTag = 321
+	T_MinMaxAggInfo                      NodeTag = 322
+	T_PlannerParamItem                   NodeTag = 323
+	T_AggInfo                            NodeTag = 324
+	T_AggTransInfo                       NodeTag = 325
+	T_PlannedStmt                        NodeTag = 326
+	T_Result                             NodeTag = 327
+	T_ProjectSet                         NodeTag = 328
+	T_ModifyTable                        NodeTag = 329
+	T_Append                             NodeTag = 330
+	T_MergeAppend                        NodeTag = 331
+	T_RecursiveUnion                     NodeTag = 332
+	T_BitmapAnd                          NodeTag = 333
+	T_BitmapOr                           NodeTag = 334
+	T_SeqScan                            NodeTag = 335
+	T_SampleScan                         NodeTag = 336
+	T_IndexScan                          NodeTag = 337
+	T_IndexOnlyScan                      NodeTag = 338
+	T_BitmapIndexScan                    NodeTag = 339
+	T_BitmapHeapScan                     NodeTag = 340
+	T_TidScan                            NodeTag = 341
+	T_TidRangeScan                       NodeTag = 342
+	T_SubqueryScan                       NodeTag = 343
+	T_FunctionScan                       NodeTag = 344
+	T_ValuesScan                         NodeTag = 345
+	T_TableFuncScan                      NodeTag = 346
+	T_CteScan                            NodeTag = 347
+	T_NamedTuplestoreScan                NodeTag = 348
+	T_WorkTableScan                      NodeTag = 349
+	T_ForeignScan                        NodeTag = 350
+	T_CustomScan                         NodeTag = 351
+	T_NestLoop                           NodeTag = 352
+	T_NestLoopParam                      NodeTag = 353
+	T_MergeJoin                          NodeTag = 354
+	T_HashJoin                           NodeTag = 355
+	T_Material                           NodeTag = 356
+	T_Memoize                            NodeTag = 357
+	T_Sort                               NodeTag = 358
+	T_IncrementalSort                    NodeTag = 359
+	T_Group                              NodeTag = 360
+	T_Agg                                NodeTag = 361
+	T_WindowAgg                          NodeTag = 362
+	T_Unique                             NodeTag = 363
+	T_Gather                             NodeTag = 364
+	T_GatherMerge                        NodeTag = 365
+	T_Hash                               NodeTag = 366
+	T_SetOp                              NodeTag = 367
+	T_LockRows                           NodeTag = 368
+	T_Limit                              NodeTag = 369
+	T_PlanRowMark                        NodeTag = 370
+	T_PartitionPruneInfo                 NodeTag = 371
+	T_PartitionedRelPruneInfo            NodeTag = 372
+	T_PartitionPruneStepOp               NodeTag = 373
+	T_PartitionPruneStepCombine          NodeTag = 374
+	T_PlanInvalItem                      NodeTag = 375
+	T_ExprState                          NodeTag = 376
+	T_IndexInfo                          NodeTag = 377
+	T_ExprContext                        NodeTag = 378
+	T_ReturnSetInfo                      NodeTag = 379
+	T_ProjectionInfo                     NodeTag = 380
+	T_JunkFilter                         NodeTag = 381
+	T_OnConflictSetState                 NodeTag = 382
+	T_MergeActionState                   NodeTag = 383
+	T_ResultRelInfo                      NodeTag = 384
+	T_EState                             NodeTag = 385
+	T_WindowFuncExprState                NodeTag = 386
+	T_SetExprState                       NodeTag = 387
+	T_SubPlanState                       NodeTag = 388
+	T_DomainConstraintState              NodeTag = 389
+	T_ResultState                        NodeTag = 390
+	T_ProjectSetState                    NodeTag = 391
+	T_ModifyTableState                   NodeTag = 392
+	T_AppendState                        NodeTag = 393
+	T_MergeAppendState                   NodeTag = 394
+	T_RecursiveUnionState                NodeTag = 395
+	T_BitmapAndState                     NodeTag = 396
+	T_BitmapOrState                      NodeTag = 397
+	T_ScanState                          NodeTag = 398
+	T_SeqScanState                       NodeTag = 399
+	T_SampleScanState                    NodeTag = 400
+	T_IndexScanState                     NodeTag = 401
+	T_IndexOnlyScanState                 NodeTag = 402
+	T_BitmapIndexScanState               NodeTag = 403
+	T_BitmapHeapScanState                NodeTag = 404
+	T_TidScanState                       NodeTag = 405
+	T_TidRangeScanState                  NodeTag = 406
+	T_SubqueryScanState                  NodeTag = 407
+	T_FunctionScanState                  NodeTag = 408
+	T_ValuesScanState                    NodeTag = 409
+	T_TableFuncScanState                 NodeTag = 410
+	T_CteScanState                       NodeTag = 411
+	T_NamedTuplestoreScanState           NodeTag = 412
+	T_WorkTableScanState                 NodeTag = 413
+	T_ForeignScanState                   NodeTag = 414
+	T_CustomScanState                    NodeTag = 415
+	T_JoinState                          NodeTag = 416
+	T_NestLoopState                      NodeTag = 417
+	T_MergeJoinState                     NodeTag = 418
+	T_HashJoinState                      NodeTag = 419
+	T_MaterialState                      NodeTag = 420
+	T_MemoizeState                       NodeTag = 421
+	T_SortState                          NodeTag = 422
+	T_IncrementalSortState               NodeTag = 423
+	T_GroupState                         NodeTag = 424
+	T_AggState                           NodeTag = 425
+	T_WindowAggState                     NodeTag = 426
+	T_UniqueState                        NodeTag = 427
+	T_GatherState                        NodeTag = 428
+	T_GatherMergeState                   NodeTag = 429
+	T_HashState                          NodeTag = 430
+	T_SetOpState                         NodeTag = 431
+	T_LockRowsState                      NodeTag = 432
+	T_LimitState                         NodeTag = 433
+	T_IndexAmRoutine                     NodeTag = 434
+	T_TableAmRoutine                     NodeTag = 435
+	T_TsmRoutine                         NodeTag = 436
+	T_EventTriggerData                   NodeTag = 437
+	T_TriggerData                        NodeTag = 438
+	T_TupleTableSlot                     NodeTag = 439
+	T_FdwRoutine                         NodeTag = 440
+	T_Bitmapset                          NodeTag = 441
+	T_ExtensibleNode                     NodeTag = 442
+	T_ErrorSaveContext                   NodeTag = 443
+	T_IdentifySystemCmd                  NodeTag = 444
+	T_BaseBackupCmd                      NodeTag = 445
+	T_CreateReplicationSlotCmd           NodeTag = 446
+	T_DropReplicationSlotCmd             NodeTag = 447
+	T_AlterReplicationSlotCmd            NodeTag = 448
+	T_StartReplicationCmd                NodeTag = 449
+	T_ReadReplicationSlotCmd             NodeTag = 450
+	T_TimeLineHistoryCmd                 NodeTag = 451
+	T_UploadManifestCmd                  NodeTag = 452
+	T_SupportRequestSimplify             NodeTag = 453
+	T_SupportRequestSelectivity          NodeTag = 454
+	T_SupportRequestCost                 NodeTag = 455
+	T_SupportRequestRows                 NodeTag = 456
+	T_SupportRequestIndexCondition       NodeTag = 457
+	T_SupportRequestWFuncMonotonic       NodeTag = 458
+	T_SupportRequestOptimizeWindowClause NodeTag = 459
+	T_Integer                            NodeTag = 460
+	T_Float                              NodeTag = 461
+	T_Boolean                            NodeTag = 462
+	T_String                             NodeTag = 463
+	T_BitString                          NodeTag = 464
+	T_ForeignKeyCacheInfo                NodeTag = 465
+	T_IntList                            NodeTag = 466
+	T_OidList                            NodeTag = 467
+	T_XidList                            NodeTag = 468
+	T_AllocSetContext                    NodeTag = 469
+	T_GenerationContext                  NodeTag = 470
+	T_SlabContext                        NodeTag = 471
+	T_BumpContext                        NodeTag = 472
+	T_TIDBitmap                          NodeTag = 473
+	T_WindowObjectData                   NodeTag = 474
+
+	// NodeTags of the hand-written nodes of later major versions,
+	// numbered after those of nodetags.h.
+	T_ReturningOption NodeTag = 474 + 1 + iota
+	T_ReturningClause
+)
+
+// NodeTagName returns the name of tag, or "Unknown".
+func NodeTagName(tag NodeTag) string {
+	switch tag {
+	case T_Invalid:
+		return "Invalid"
+	case T_List:
+		return "List"
+	case T_Alias:
+		return "Alias"
+	case T_RangeVar:
+		return "RangeVar"
+	case T_TableFunc:
+		return "TableFunc"
+	case T_IntoClause:
+		return "IntoClause"
+	case T_Var:
+		return "Var"
+	case T_Const:
+		return "Const"
+	case T_Param:
+		return "Param"
+	case T_Aggref:
+		return "Aggref"
+	case T_GroupingFunc:
+		return "GroupingFunc"
+	case T_WindowFunc:
+		return "WindowFunc"
+	case T_WindowFuncRunCondition:
+		return "WindowFuncRunCondition"
+	case T_MergeSupportFunc:
+		return "MergeSupportFunc"
+	case T_SubscriptingRef:
+		return "SubscriptingRef"
+	case T_FuncExpr:
+		return "FuncExpr"
+	case T_NamedArgExpr:
+		return "NamedArgExpr"
+	case T_OpExpr:
+		return "OpExpr"
+	case T_DistinctExpr:
+		return "DistinctExpr"
+	case T_NullIfExpr:
+		return "NullIfExpr"
+	case T_ScalarArrayOpExpr:
+		return "ScalarArrayOpExpr"
+	case T_BoolExpr:
+		return "BoolExpr"
+	case T_SubLink:
+		return "SubLink"
+	case T_SubPlan:
+		return "SubPlan"
+	case T_AlternativeSubPlan:
+		return "AlternativeSubPlan"
+	case T_FieldSelect:
+		return "FieldSelect"
+	case T_FieldStore:
+		return "FieldStore"
+	case T_RelabelType:
+		return "RelabelType"
+	case T_CoerceViaIO:
+		return "CoerceViaIO"
+	case T_ArrayCoerceExpr:
+		return "ArrayCoerceExpr"
+	case T_ConvertRowtypeExpr:
+		return "ConvertRowtypeExpr"
+	case T_CollateExpr:
+		return "CollateExpr"
+	case T_CaseExpr:
+		return "CaseExpr"
+	case T_CaseWhen:
+		return "CaseWhen"
+	case T_CaseTestExpr:
+		return "CaseTestExpr"
+	case T_ArrayExpr:
+		return "ArrayExpr"
+	case T_RowExpr:
+		return "RowExpr"
+	case T_RowCompareExpr:
+		return "RowCompareExpr"
+	case T_CoalesceExpr:
+		return "CoalesceExpr"
+	case T_MinMaxExpr:
+		return "MinMaxExpr"
+	case T_SQLValueFunction:
+		return "SQLValueFunction"
+	case T_XmlExpr:
+		return "XmlExpr"
+	case T_JsonFormat:
+		return "JsonFormat"
+	case T_JsonReturning:
+		return "JsonReturning"
+	case T_JsonValueExpr:
+		return "JsonValueExpr"
+	case T_JsonConstructorExpr:
+		return "JsonConstructorExpr"
+	case T_JsonIsPredicate:
+		return "JsonIsPredicate"
+	case T_JsonBehavior:
+		return "JsonBehavior"
+	case T_JsonExpr:
+		return "JsonExpr"
+	case T_JsonTablePath:
+		return "JsonTablePath"
+	case T_JsonTablePathScan:
+		return "JsonTablePathScan"
+	case T_JsonTableSiblingJoin:
+		return "JsonTableSiblingJoin"
+	case T_NullTest:
+		return "NullTest"
+	case T_BooleanTest:
+		return "BooleanTest"
+	case T_MergeAction:
+		return "MergeAction"
+	case T_CoerceToDomain:
+		return "CoerceToDomain"
+	case T_CoerceToDomainValue:
+		return "CoerceToDomainValue"
+	case T_SetToDefault:
+		return "SetToDefault"
+	case T_CurrentOfExpr:
+		return "CurrentOfExpr"
+	case T_NextValueExpr:
+		return "NextValueExpr"
+	case T_InferenceElem:
+		return "InferenceElem"
+	case T_TargetEntry:
+		return "TargetEntry"
+	case T_RangeTblRef:
+		return "RangeTblRef"
+	case T_JoinExpr:
+		return "JoinExpr"
+	case T_FromExpr:
+		return "FromExpr"
+	case T_OnConflictExpr:
+		return "OnConflictExpr"
+	case T_Query:
+		return "Query"
+	case T_TypeName:
+		return "TypeName"
+	case T_ColumnRef:
+		return "ColumnRef"
+	case T_ParamRef:
+		return "ParamRef"
+	case T_A_Expr:
+		return "A_Expr"
+	case T_A_Const:
+		return "A_Const"
+	case T_TypeCast:
+		return "TypeCast"
+	case T_CollateClause:
+		return "CollateClause"
+	case T_RoleSpec:
+		return "RoleSpec"
+	case T_FuncCall:
+		return "FuncCall"
+	case T_A_Star:
+		return "A_Star"
+	case T_A_Indices:
+		return "A_Indices"
+	case T_A_Indirection:
+		return "A_Indirection"
+	case T_A_ArrayExpr:
+		return "A_ArrayExpr"
+	case T_ResTarget:
+		return "ResTarget"
+	case T_MultiAssignRef:
+		return "MultiAssignRef"
+	case T_SortBy:
+		return "SortBy"
+	case T_WindowDef:
+		return "WindowDef"
+	case T_RangeSubselect:
+		return "RangeSubselect"
+	case T_RangeFunction:
+		return "RangeFunction"
+	case T_RangeTableFunc:
+		return "RangeTableFunc"
+	case T_RangeTableFuncCol:
+		return "RangeTableFuncCol"
+	case T_RangeTableSample:
+		return "RangeTableSample"
+	case T_ColumnDef:
+		return "ColumnDef"
+	case T_TableLikeClause:
+		return "TableLikeClause"
+	case T_IndexElem:
+		return "IndexElem"
+	case T_DefElem:
+		return "DefElem"
+	case T_LockingClause:
+		return "LockingClause"
+	case T_XmlSerialize:
+		return "XmlSerialize"
+	case T_PartitionElem:
+		return "PartitionElem"
+	case T_PartitionSpec:
+		return "PartitionSpec"
+	case T_PartitionBoundSpec:
+		return "PartitionBoundSpec"
+	case T_PartitionRangeDatum:
+		return "PartitionRangeDatum"
+	case T_SinglePartitionSpec:
+		return "SinglePartitionSpec"
+	case T_PartitionCmd:
+		return "PartitionCmd"
+	case T_RangeTblEntry:
+		return "RangeTblEntry"
+	case T_RTEPermissionInfo:
+		return "RTEPermissionInfo"
+	case T_RangeTblFunction:
+		return "RangeTblFunction"
+	case T_TableSampleClause:
+		return "TableSampleClause"
+	case T_WithCheckOption:
+		return "WithCheckOption"
+	case T_SortGroupClause:
+		return "SortGroupClause"
+	case T_GroupingSet:
+		return "GroupingSet"
+	case T_WindowClause:
+		return "WindowClause"
+	case T_RowMarkClause:
+		return "RowMarkClause"
+	case T_WithClause:
+		return "WithClause"
+	case T_InferClause:
+		return "InferClause"
+	case T_OnConflictClause:
+		return "OnConflictClause"
+	case T_CTESearchClause:
+		return "CTESearchClause"
+	case T_CTECycleClause:
+		return "CTECycleClause"
+	case T_CommonTableExpr:
+		return "CommonTableExpr"
+	case T_MergeWhenClause:
+		return "MergeWhenClause"
+	case T_TriggerTransition:
+		return "TriggerTransition"
+	case T_JsonOutput:
+		return "JsonOutput"
+	case T_JsonArgument:
+		return "JsonArgument"
+	case T_JsonFuncExpr:
+		return "JsonFuncExpr"
+	case T_JsonTablePathSpec:
+		return "JsonTablePathSpec"
+	case T_JsonTable:
+		return "JsonTable"
+	case T_JsonTableColumn:
+		return "JsonTableColumn"
+	case T_JsonKeyValue:
+		return "JsonKeyValue"
+	case T_JsonParseExpr:
+		return "JsonParseExpr"
+	case T_JsonScalarExpr:
+		return "JsonScalarExpr"
+	case T_JsonSerializeExpr:
+		return "JsonSerializeExpr"
+	case T_JsonObjectConstructor:
+		return "JsonObjectConstructor"
+	case T_JsonArrayConstructor:
+		return "JsonArrayConstructor"
+	case T_JsonArrayQueryConstructor:
+		return "JsonArrayQueryConstructor"
+	case T_JsonAggConstructor:
+		return "JsonAggConstructor"
+	case T_JsonObjectAgg:
+		return "JsonObjectAgg"
+	case T_JsonArrayAgg:
+		return "JsonArrayAgg"
+	case T_RawStmt:
+		return "RawStmt"
+	case T_InsertStmt:
+		return "InsertStmt"
+	case T_DeleteStmt:
+		return "DeleteStmt"
+	case T_UpdateStmt:
+		return "UpdateStmt"
+	case T_MergeStmt:
+		return "MergeStmt"
+	case T_SelectStmt:
+		return "SelectStmt"
+	case T_SetOperationStmt:
+		return "SetOperationStmt"
+	case T_ReturnStmt:
+		return "ReturnStmt"
+	case T_PLAssignStmt:
+		return "PLAssignStmt"
+	case T_CreateSchemaStmt:
+		return "CreateSchemaStmt"
+	case T_AlterTableStmt:
+		return "AlterTableStmt"
+	case T_ReplicaIdentityStmt:
+		return "ReplicaIdentityStmt"
+	case T_AlterTableCmd:
+		return "AlterTableCmd"
+	case T_AlterCollationStmt:
+		return "AlterCollationStmt"
+	case T_AlterDomainStmt:
+		return "AlterDomainStmt"
+	case T_GrantStmt:
+		return "GrantStmt"
+	case T_ObjectWithArgs:
+		return "ObjectWithArgs"
+	case T_AccessPriv:
+		return "AccessPriv"
+	case T_GrantRoleStmt:
+		return "GrantRoleStmt"
+	case T_AlterDefaultPrivilegesStmt:
+		return "AlterDefaultPrivilegesStmt"
+	case T_CopyStmt:
+		return "CopyStmt"
+	case T_VariableSetStmt:
+		return "VariableSetStmt"
+	case T_VariableShowStmt:
+		return "VariableShowStmt"
+	case T_CreateStmt:
+		return "CreateStmt"
+	case T_Constraint:
+		return "Constraint"
+	case T_CreateTableSpaceStmt:
+		return "CreateTableSpaceStmt"
+	case T_DropTableSpaceStmt:
+		return "DropTableSpaceStmt"
+	case T_AlterTableSpaceOptionsStmt:
+		return "AlterTableSpaceOptionsStmt"
+	case T_AlterTableMoveAllStmt:
+		return "AlterTableMoveAllStmt"
+	case T_CreateExtensionStmt:
+		return "CreateExtensionStmt"
+	case T_AlterExtensionStmt:
+		return "AlterExtensionStmt"
+	case T_AlterExtensionContentsStmt:
+		return "AlterExtensionContentsStmt"
+	case T_CreateFdwStmt:
+		return "CreateFdwStmt"
+	case T_AlterFdwStmt:
+		return "AlterFdwStmt"
+	case T_CreateForeignServerStmt:
+		return "CreateForeignServerStmt"
+	case T_AlterForeignServerStmt:
+		return "AlterForeignServerStmt"
+	case T_CreateForeignTableStmt:
+		return "CreateForeignTableStmt"
+	case T_CreateUserMappingStmt:
+		return "CreateUserMappingStmt"
+	case T_AlterUserMappingStmt:
+		return "AlterUserMappingStmt"
+	case T_DropUserMappingStmt:
+		return "DropUserMappingStmt"
+	case T_ImportForeignSchemaStmt:
+		return "ImportForeignSchemaStmt"
+	case T_CreatePolicyStmt:
+		return "CreatePolicyStmt"
+	case T_AlterPolicyStmt:
+		return "AlterPolicyStmt"
+	case T_CreateAmStmt:
+		return "CreateAmStmt"
+	case T_CreateTrigStmt:
+		return "CreateTrigStmt"
+	case T_CreateEventTrigStmt:
+		return "CreateEventTrigStmt"
+	case T_AlterEventTrigStmt:
+		return "AlterEventTrigStmt"
+	case T_CreatePLangStmt:
+		return "CreatePLangStmt"
+	case T_CreateRoleStmt:
+		return "CreateRoleStmt"
+	case T_AlterRoleStmt:
+		return "AlterRoleStmt"
+	case T_AlterRoleSetStmt:
+		return "AlterRoleSetStmt"
+	case T_DropRoleStmt:
+		return "DropRoleStmt"
+	case T_CreateSeqStmt:
+		return "CreateSeqStmt"
+	case T_AlterSeqStmt:
+		return "AlterSeqStmt"
+	case T_DefineStmt:
+		return "DefineStmt"
+	case T_CreateDomainStmt:
+		return "CreateDomainStmt"
+	case T_CreateOpClassStmt:
+		return "CreateOpClassStmt"
+	case T_CreateOpClassItem:
+		return "CreateOpClassItem"
+	case T_CreateOpFamilyStmt:
+		return "CreateOpFamilyStmt"
+	case T_AlterOpFamilyStmt:
+		return "AlterOpFamilyStmt"
+	case T_DropStmt:
+		return "DropStmt"
+	case T_TruncateStmt:
+		return "TruncateStmt"
+	case T_CommentStmt:
+		return "CommentStmt"
+	case T_SecLabelStmt:
+		return "SecLabelStmt"
+	case T_DeclareCursorStmt:
+		return "DeclareCursorStmt"
+	case T_ClosePortalStmt:
+		return "ClosePortalStmt"
+	case T_FetchStmt:
+		return "FetchStmt"
+	case T_IndexStmt:
+		return "IndexStmt"
+	case T_CreateStatsStmt:
+		return "CreateStatsStmt"
+	case T_StatsElem:
+		return "StatsElem"
+	case T_AlterStatsStmt:
+		return "AlterStatsStmt"
+	case T_CreateFunctionStmt:
+		return "CreateFunctionStmt"
+	case T_FunctionParameter:
+		return "FunctionParameter"
+	case T_AlterFunctionStmt:
+		return "AlterFunctionStmt"
+	case T_DoStmt:
+		return "DoStmt"
+	case T_InlineCodeBlock:
+		return "InlineCodeBlock"
+	case T_CallStmt:
+		return "CallStmt"
+	case T_CallContext:
+		return "CallContext"
+	case T_RenameStmt:
+		return "RenameStmt"
+	case T_AlterObjectDependsStmt:
+		return "AlterObjectDependsStmt"
+	case T_AlterObjectSchemaStmt:
+		return "AlterObjectSchemaStmt"
+	case T_AlterOwnerStmt:
+		return "AlterOwnerStmt"
+	case T_AlterOperatorStmt:
+		return "AlterOperatorStmt"
+	case T_AlterTypeStmt:
+		return "AlterTypeStmt"
+	case T_RuleStmt:
+		return "RuleStmt"
+	case T_NotifyStmt:
+		return "NotifyStmt"
+	case T_ListenStmt:
+		return "ListenStmt"
+	case T_UnlistenStmt:
+		return "UnlistenStmt"
+	case T_TransactionStmt:
+		return "TransactionStmt"
+	case T_CompositeTypeStmt:
+		return "CompositeTypeStmt"
+	case T_CreateEnumStmt:
+		return "CreateEnumStmt"
+	case T_CreateRangeStmt:
+		return "CreateRangeStmt"
+	case T_AlterEnumStmt:
+		return "AlterEnumStmt"
+	case T_ViewStmt:
+		return "ViewStmt"
+	case T_LoadStmt:
+		return "LoadStmt"
+	case T_CreatedbStmt:
+		return "CreatedbStmt"
+	case T_AlterDatabaseStmt:
+		return "AlterDatabaseStmt"
+	case T_AlterDatabaseRefreshCollStmt:
+		return "AlterDatabaseRefreshCollStmt"
+	case T_AlterDatabaseSetStmt:
+		return "AlterDatabaseSetStmt"
+	case T_DropdbStmt:
+		return "DropdbStmt"
+	case T_AlterSystemStmt:
+		return "AlterSystemStmt"
+	case T_ClusterStmt:
+		return "ClusterStmt"
+	case T_VacuumStmt:
+		return "VacuumStmt"
+	case T_VacuumRelation:
+		return "VacuumRelation"
+	case T_ExplainStmt:
+		return "ExplainStmt"
+	case T_CreateTableAsStmt:
+		return "CreateTableAsStmt"
+	case T_RefreshMatViewStmt:
+		return "RefreshMatViewStmt"
+	case T_CheckPointStmt:
+		return "CheckPointStmt"
+	case T_DiscardStmt:
+		return "DiscardStmt"
+	case T_LockStmt:
+		return "LockStmt"
+	case T_ConstraintsSetStmt:
+		return "ConstraintsSetStmt"
+	case T_ReindexStmt:
+		return "ReindexStmt"
+	case T_CreateConversionStmt:
+		return "CreateConversionStmt"
+	case T_CreateCastStmt:
+		return "CreateCastStmt"
+	case T_CreateTransformStmt:
+		return "CreateTransformStmt"
+	case T_PrepareStmt:
+		return "PrepareStmt"
+	case T_ExecuteStmt:
+		return "ExecuteStmt"
+	case T_DeallocateStmt:
+		return "DeallocateStmt"
+	case T_DropOwnedStmt:
+		return "DropOwnedStmt"
+	case T_ReassignOwnedStmt:
+		return "ReassignOwnedStmt"
+	case T_AlterTSDictionaryStmt:
+		return "AlterTSDictionaryStmt"
+	case T_AlterTSConfigurationStmt:
+		return "AlterTSConfigurationStmt"
+	case T_PublicationTable:
+		return "PublicationTable"
+	case T_PublicationObjSpec:
+		return "PublicationObjSpec"
+	case T_CreatePublicationStmt:
+		return "CreatePublicationStmt"
+	case T_AlterPublicationStmt:
+		return "AlterPublicationStmt"
+	case T_CreateSubscriptionStmt:
+		return "CreateSubscriptionStmt"
+	case T_AlterSubscriptionStmt:
+		return "AlterSubscriptionStmt"
+	case T_DropSubscriptionStmt:
+		return "DropSubscriptionStmt"
+	case T_PlannerGlobal:
+		return "PlannerGlobal"
+	case T_PlannerInfo:
+		return "PlannerInfo"
+	case T_RelOptInfo:
+		return "RelOptInfo"
+	case T_IndexOptInfo:
+		return "IndexOptInfo"
+	case T_ForeignKeyOptInfo:
+		return "ForeignKeyOptInfo"
+	case T_StatisticExtInfo:
+		return "StatisticExtInfo"
+	case T_JoinDomain:
+		return "JoinDomain"
+	case T_EquivalenceClass:
+		return "EquivalenceClass"
+	case T_EquivalenceMember:
+		return "EquivalenceMember"
+	case T_PathKey:
+		return "PathKey"
+	case T_GroupByOrdering:
+		return "GroupByOrdering"
+	case T_PathTarget:
+		return "PathTarget"
+	case T_ParamPathInfo:
+		return "ParamPathInfo"
+	case T_Path:
+		return "Path"
+	case T_IndexPath:
+		return "IndexPath"
+	case T_IndexClause:
+		return "IndexClause"
+	case T_BitmapHeapPath:
+		return "BitmapHeapPath"
+	case T_BitmapAndPath:
+		return "BitmapAndPath"
+	case T_BitmapOrPath:
+		return "BitmapOrPath"
+	case T_TidPath:
+		return "TidPath"
+	case T_TidRangePath:
+		return "TidRangePath"
+	case T_SubqueryScanPath:
+		return "SubqueryScanPath"
+	case T_ForeignPath:
+		return "ForeignPath"
+	case T_CustomPath:
+		return "CustomPath"
+	case T_AppendPath:
+		return "AppendPath"
+	case T_MergeAppendPath:
+		return "MergeAppendPath"
+	case T_GroupResultPath:
+		return "GroupResultPath"
+	case T_MaterialPath:
+		return "MaterialPath"
+	case T_MemoizePath:
+		return "MemoizePath"
+	case T_UniquePath:
+		return "UniquePath"
+	case T_GatherPath:
+		return "GatherPath"
+	case T_GatherMergePath:
+		return "GatherMergePath"
+	case T_NestPath:
+		return "NestPath"
+	case T_MergePath:
+		return "MergePath"
+	case T_HashPath:
+		return "HashPath"
+	case T_ProjectionPath:
+		return "ProjectionPath"
+	case T_ProjectSetPath:
+		return "ProjectSetPath"
+	case T_SortPath:
+		return "SortPath"
+	case T_IncrementalSortPath:
+		return "IncrementalSortPath"
+	case T_GroupPath:
+		return "GroupPath"
+	case T_UpperUniquePath:
+		return "UpperUniquePath"
+	case T_AggPath:
+		return "AggPath"
+	case T_GroupingSetData:
+		return "GroupingSetData"
+	case T_RollupData:
+		return "RollupData"
+	case T_GroupingSetsPath:
+		return "GroupingSetsPath"
+	case T_MinMaxAggPath:
+		return "MinMaxAggPath"
+	case T_WindowAggPath:
+		return "WindowAggPath"
+	case T_SetOpPath:
+		return "SetOpPath"
+	case T_RecursiveUnionPath:
+		return "RecursiveUnionPath"
+	case T_LockRowsPath:
+		return "LockRowsPath"
+	case T_ModifyTablePath:
+		return "ModifyTablePath"
+	case T_LimitPath:
+		return "LimitPath"
+	case T_RestrictInfo:
+		return "RestrictInfo"
+	case T_PlaceHolderVar:
+		return "PlaceHolderVar"
+	case T_SpecialJoinInfo:
+		return "SpecialJoinInfo"
+	case T_OuterJoinClauseInfo:
+		return "OuterJoinClauseInfo"
+	case T_AppendRelInfo:
+		return "AppendRelInfo"
+	case T_RowIdentityVarInfo:
+		return "RowIdentityVarInfo"
+	case T_PlaceHolderInfo:
+		return "PlaceHolderInfo"
+	case T_MinMaxAggInfo:
+		return "MinMaxAggInfo"
+	case T_PlannerParamItem:
+		return "PlannerParamItem"
+	case T_AggInfo:
+		return "AggInfo"
+	case T_AggTransInfo:
+		return "AggTransInfo"
+	case T_PlannedStmt:
+		return "PlannedStmt"
+	case T_Result:
+		return "Result"
+	case T_ProjectSet:
+		return "ProjectSet"
+	case T_ModifyTable:
+		return "ModifyTable"
+	case T_Append:
+		return "Append"
+	case T_MergeAppend:
+		return "MergeAppend"
+	case T_RecursiveUnion:
+		return "RecursiveUnion"
+	case T_BitmapAnd:
+		return "BitmapAnd"
+	case T_BitmapOr:
+		return "BitmapOr"
+	case T_SeqScan:
+		return "SeqScan"
+	case T_SampleScan:
+		return "SampleScan"
+	case T_IndexScan:
+		return "IndexScan"
+	case T_IndexOnlyScan:
+		return "IndexOnlyScan"
+	case T_BitmapIndexScan:
+		return "BitmapIndexScan"
+	case T_BitmapHeapScan:
+		return "BitmapHeapScan"
+	case T_TidScan:
+		return "TidScan"
+	case T_TidRangeScan:
+		return "TidRangeScan"
+	case T_SubqueryScan:
+		return "SubqueryScan"
+	case T_FunctionScan:
+		return "FunctionScan"
+	case T_ValuesScan:
+		return "ValuesScan"
+	case T_TableFuncScan:
+		return "TableFuncScan"
+	case T_CteScan:
+		return "CteScan"
+	case T_NamedTuplestoreScan:
+		return "NamedTuplestoreScan"
+	case T_WorkTableScan:
+		return "WorkTableScan"
+	case T_ForeignScan:
+		return "ForeignScan"
+	case T_CustomScan:
+		return "CustomScan"
+	case T_NestLoop:
+		return "NestLoop"
+	case T_NestLoopParam:
+		return "NestLoopParam"
+	case T_MergeJoin:
+		return "MergeJoin"
+	case T_HashJoin:
+		return "HashJoin"
+	case T_Material:
+		return "Material"
+	case T_Memoize:
+		return "Memoize"
+	case T_Sort:
+		return "Sort"
+	case T_IncrementalSort:
+		return "IncrementalSort"
+	case T_Group:
+		return "Group"
+	case T_Agg:
+		return "Agg"
+	case T_WindowAgg:
+		return "WindowAgg"
+	case T_Unique:
+		return "Unique"
+	case T_Gather:
+		return "Gather"
+	case T_GatherMerge:
+		return "GatherMerge"
+	case T_Hash:
+		return "Hash"
+	case T_SetOp:
+		return "SetOp"
+	case T_LockRows:
+		return "LockRows"
+	case T_Limit:
+		return "Limit"
+	case T_PlanRowMark:
+		return "PlanRowMark"
+	case T_PartitionPruneInfo:
+		return "PartitionPruneInfo"
+	case T_PartitionedRelPruneInfo:
+		return "PartitionedRelPruneInfo"
+	case T_PartitionPruneStepOp:
+		return "PartitionPruneStepOp"
+	case T_PartitionPruneStepCombine:
+		return "PartitionPruneStepCombine"
+	case T_PlanInvalItem:
+		return "PlanInvalItem"
+	case T_ExprState:
+		return "ExprState"
+	case T_IndexInfo:
+		return "IndexInfo"
+	case T_ExprContext:
+		return "ExprContext"
+	case T_ReturnSetInfo:
+		return "ReturnSetInfo"
+	case T_ProjectionInfo:
+		return "ProjectionInfo"
+	case T_JunkFilter:
+		return "JunkFilter"
+	case T_OnConflictSetState:
+		return "OnConflictSetState"
+	case T_MergeActionState:
+		return "MergeActionState"
+	case T_ResultRelInfo:
+		return "ResultRelInfo"
+	case T_EState:
+		return "EState"
+	case T_WindowFuncExprState:
+		return "WindowFuncExprState"
+	case T_SetExprState:
+		return "SetExprState"
+	case T_SubPlanState:
+		return "SubPlanState"
+	case T_DomainConstraintState:
+		return "DomainConstraintState"
+	case T_ResultState:
+		return "ResultState"
+	case T_ProjectSetState:
+		return "ProjectSetState"
+	case T_ModifyTableState:
+		return "ModifyTableState"
+	case T_AppendState:
+		return "AppendState"
+	case T_MergeAppendState:
+		return "MergeAppendState"
+	case T_RecursiveUnionState:
+		return "RecursiveUnionState"
+	case T_BitmapAndState:
+		return "BitmapAndState"
+	case T_BitmapOrState:
+		return "BitmapOrState"
+	case T_ScanState:
+		return "ScanState"
+	case T_SeqScanState:
+		return "SeqScanState"
+	case T_SampleScanState:
+		return "SampleScanState"
+	case T_IndexScanState:
+		return "IndexScanState"
+	case T_IndexOnlyScanState:
+		return "IndexOnlyScanState"
+	case T_BitmapIndexScanState:
+		return "BitmapIndexScanState"
+	case T_BitmapHeapScanState:
+		return "BitmapHeapScanState"
+	case T_TidScanState:
+		return "TidScanState"
+	case T_TidRangeScanState:
+		return "TidRangeScanState"
+	case T_SubqueryScanState:
+		return "SubqueryScanState"
+	case T_FunctionScanState:
+		return "FunctionScanState"
+	case T_ValuesScanState:
+		return "ValuesScanState"
+	case T_TableFuncScanState:
+		return "TableFuncScanState"
+	case T_CteScanState:
+		return "CteScanState"
+	case T_NamedTuplestoreScanState:
+		return "NamedTuplestoreScanState"
+	case T_WorkTableScanState:
+		return "WorkTableScanState"
+	case T_ForeignScanState:
+		return "ForeignScanState"
+	case T_CustomScanState:
+		return "CustomScanState"
+	case T_JoinState:
+		return "JoinState"
+	case T_NestLoopState:
+		return "NestLoopState"
+	case T_MergeJoinState:
+		return "MergeJoinState"
+	case T_HashJoinState:
+		return "HashJoinState"
+	case T_MaterialState:
+		return "MaterialState"
+	case T_MemoizeState:
+		return "MemoizeState"
+	case T_SortState:
+		return "SortState"
+	case T_IncrementalSortState:
+		return "IncrementalSortState"
+	case T_GroupState:
+		return "GroupState"
+	case T_AggState:
+		return "AggState"
+	case T_WindowAggState:
+		return "WindowAggState"
+	case T_UniqueState:
+		return "UniqueState"
+	case T_GatherState:
+		return "GatherState"
+	case T_GatherMergeState:
+		return "GatherMergeState"
+	case T_HashState:
+		return "HashState"
+	case T_SetOpState:
+		return "SetOpState"
+	case T_LockRowsState:
+		return "LockRowsState"
+	case T_LimitState:
+		return "LimitState"
+	case T_IndexAmRoutine:
+		return "IndexAmRoutine"
+	case T_TableAmRoutine:
+		return "TableAmRoutine"
+	case T_TsmRoutine:
+		return "TsmRoutine"
+	case T_EventTriggerData:
+		return "EventTriggerData"
+	case T_TriggerData:
+		return "TriggerData"
+	case T_TupleTableSlot:
+		return "TupleTableSlot"
+	case T_FdwRoutine:
+		return "FdwRoutine"
+	case T_Bitmapset:
+		return "Bitmapset"
+	case T_ExtensibleNode:
+		return "ExtensibleNode"
+	case T_ErrorSaveContext:
+		return "ErrorSaveContext"
+	case T_IdentifySystemCmd:
+		return "IdentifySystemCmd"
+	case T_BaseBackupCmd:
+		return "BaseBackupCmd"
+	case T_CreateReplicationSlotCmd:
+		return "CreateReplicationSlotCmd"
+	case T_DropReplicationSlotCmd:
+		return "DropReplicationSlotCmd"
+	case T_AlterReplicationSlotCmd:
+		return "AlterReplicationSlotCmd"
+	case T_StartReplicationCmd:
+		return "StartReplicationCmd"
+	case T_ReadReplicationSlotCmd:
+		return "ReadReplicationSlotCmd"
+	case T_TimeLineHistoryCmd:
+		return "TimeLineHistoryCmd"
+	case T_UploadManifestCmd:
+		return "UploadManifestCmd"
+	case T_SupportRequestSimplify:
+		return "SupportRequestSimplify"
+	case T_SupportRequestSelectivity:
+		return "SupportRequestSelectivity"
+	case T_SupportRequestCost:
+		return "SupportRequestCost"
+	case T_SupportRequestRows:
+		return "SupportRequestRows"
+	case T_SupportRequestIndexCondition:
+		return "SupportRequestIndexCondition"
+	case T_SupportRequestWFuncMonotonic:
+		return "SupportRequestWFuncMonotonic"
+	case T_SupportRequestOptimizeWindowClause:
+		return "SupportRequestOptimizeWindowClause"
+	case T_Integer:
+		return "Integer"
+	case T_Float:
+		return "Float"
+	case T_Boolean:
+		return "Boolean"
+	case T_String:
+		return "String"
+	case T_BitString:
+		return "BitString"
+	case T_ForeignKeyCacheInfo:
+		return "ForeignKeyCacheInfo"
+	case T_IntList:
+		return "IntList"
+	case T_OidList:
+		return "OidList"
+	case T_XidList:
+		return "XidList"
+	case T_AllocSetContext:
+		return "AllocSetContext"
+	case T_GenerationContext:
+		return "GenerationContext"
+	case T_SlabContext:
+		return "SlabContext"
+	case T_BumpContext:
+		return "BumpContext"
+	case T_TIDBitmap:
+		return "TIDBitmap"
+	case T_WindowObjectData:
+		return "WindowObjectData"
+	case T_ReturningOption:
+		return "ReturningOption"
+	case T_ReturningClause:
+		return "ReturningClause"
+	}
+	return "Unknown"
+}
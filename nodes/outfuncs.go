@@ -52,9 +52,9 @@ func writeNode(sb *strings.Builder, node Node) {
 	case *ColumnRef:
 		writeColumnRef(sb, n)
 	case *A_Const:
-		writeAConst(sb, n)
+		writeA_Const(sb, n)
 	case *A_Expr:
-		writeAExpr(sb, n)
+		writeA_Expr(sb, n)
 	case *TypeCast:
 		writeTypeCast(sb, n)
 	case *TypeName:
@@ -68,7 +68,7 @@ func writeNode(sb *strings.Builder, node Node) {
 	case *Alias:
 		writeAlias(sb, n)
 	case *A_Star:
-		writeAStar(sb, n)
+		writeA_Star(sb, n)
 	case *BoolExpr:
 		writeBoolExpr(sb, n)
 	case *NullTest:
@@ -89,7 +89,14 @@ func writeNode(sb *strings.Builder, node Node) {
 		writeViewStmt(sb, n)
 	case *RawStmt:
 		writeRawStmt(sb, n)
+	case *IntList:
+		writeIntList(sb, n)
+	case *OidList:
+		writeOidList(sb, n)
 	default:
+		if writeGeneratedNode(sb, node) {
+			return
+		}
 		// Generic fallback for unhandled node types
 		sb.WriteString("{")
 		sb.WriteString(NodeTagName(node.Tag()))
@@ -110,6 +117,37 @@ func writeList(sb *strings.Builder, n *List) {
 	sb.WriteString(")")
 }
 
+func writeIntList(sb *strings.Builder, n *IntList) {
+	sb.WriteString("(i")
+	for _, v := range n.Items {
+		sb.WriteString(" ")
+		sb.WriteString(strconv.Itoa(v))
+	}
+	sb.WriteString(")")
+}
+
+// writeBitmapset writes n as PostgreSQL's outBitmapset does; an empty
+// set is "(b)".
+func writeBitmapset(sb *strings.Builder, n *Bitmapset) {
+	sb.WriteString("(b")
+	if n != nil {
+		for _, v := range n.Items {
+			sb.WriteString(" ")
+			sb.WriteString(strconv.Itoa(v))
+		}
+	}
+	sb.WriteString(")")
+}
+
+func writeOidList(sb *strings.Builder, n *OidList) {
+	sb.WriteString("(o")
+	for _, v := range n.Items {
+		sb.WriteString(" ")
+		sb.WriteString(strconv.FormatUint(uint64(v), 10))
+	}
+	sb.WriteString(")")
+}
+
 func writeInteger(sb *strings.Builder, n *Integer) {
 	sb.WriteString(strconv.FormatInt(n.Ival, 10))
 }
@@ -128,7 +166,7 @@ func writeBoolean(sb *strings.Builder, n *Boolean) {
 
 func writeString(sb *strings.Builder, n *String) {
 	sb.WriteString("\"")
-	sb.WriteString(escapeString(n.Str))
+	sb.WriteString(escapeString(n.Sval))
 	sb.WriteString("\"")
 }
 
@@ -281,7 +319,7 @@ func writeRangeVar(sb *strings.Builder, n *RangeVar) {
 		sb.WriteString("\"")
 	}
 	sb.WriteString(fmt.Sprintf(" :inh %t", n.Inh))
-	sb.WriteString(fmt.Sprintf(" :relpersistence %c", n.Relpersistence))
+	writeCharField(sb, " :relpersistence", n.Relpersistence)
 	if n.Alias != nil {
 		sb.WriteString(" :alias ")
 		writeNode(sb, n.Alias)
@@ -300,7 +338,7 @@ func writeColumnRef(sb *strings.Builder, n *ColumnRef) {
 	sb.WriteString("}")
 }
 
-func writeAConst(sb *strings.Builder, n *A_Const) {
+func writeA_Const(sb *strings.Builder, n *A_Const) {
 	sb.WriteString("{A_CONST")
 	if n.Isnull {
 		sb.WriteString(" :isnull true")
@@ -312,7 +350,7 @@ func writeAConst(sb *strings.Builder, n *A_Const) {
 	sb.WriteString("}")
 }
 
-func writeAExpr(sb *strings.Builder, n *A_Expr) {
+func writeA_Expr(sb *strings.Builder, n *A_Expr) {
 	sb.WriteString("{A_EXPR")
 	sb.WriteString(fmt.Sprintf(" :kind %d", n.Kind))
 	if n.Name != nil {
@@ -393,7 +431,7 @@ func writeFuncCall(sb *strings.Builder, n *FuncCall) {
 	sb.WriteString(fmt.Sprintf(" :agg_star %t", n.AggStar))
 	sb.WriteString(fmt.Sprintf(" :agg_distinct %t", n.AggDistinct))
 	sb.WriteString(fmt.Sprintf(" :func_variadic %t", n.FuncVariadic))
-	sb.WriteString(fmt.Sprintf(" :funcformat %d", n.FuncFormat))
+	sb.WriteString(fmt.Sprintf(" :funcformat %d", n.Funcformat))
 	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
 	sb.WriteString("}")
 }
@@ -449,7 +487,7 @@ func writeAlias(sb *strings.Builder, n *Alias) {
 	sb.WriteString("}")
 }
 
-func writeAStar(sb *strings.Builder, n *A_Star) {
+func writeA_Star(sb *strings.Builder, n *A_Star) {
 	sb.WriteString("{A_STAR}")
 }
 
@@ -546,7 +584,7 @@ func writeCreateStmt(sb *strings.Builder, n *CreateStmt) {
 		sb.WriteString(" :options ")
 		writeNode(sb, n.Options)
 	}
-	sb.WriteString(fmt.Sprintf(" :oncommit %d", n.OnCommit))
+	sb.WriteString(fmt.Sprintf(" :oncommit %d", n.Oncommit))
 	if n.Tablespacename != "" {
 		sb.WriteString(" :tablespacename ")
 		sb.WriteString("\"")
@@ -574,7 +612,7 @@ func writeColumnDef(sb *strings.Builder, n *ColumnDef) {
 	sb.WriteString(fmt.Sprintf(" :is_local %t", n.IsLocal))
 	sb.WriteString(fmt.Sprintf(" :is_not_null %t", n.IsNotNull))
 	sb.WriteString(fmt.Sprintf(" :is_from_type %t", n.IsFromType))
-	sb.WriteString(fmt.Sprintf(" :storage %c", n.Storage))
+	writeCharField(sb, " :storage", n.Storage)
 	if n.RawDefault != nil {
 		sb.WriteString(" :raw_default ")
 		writeNode(sb, n.RawDefault)
@@ -713,6 +751,16 @@ func writeRawStmt(sb *strings.Builder, n *RawStmt) {
 	sb.WriteString("}")
 }
 
+// writeCharField writes a char field as WRITE_CHAR_FIELD does: "<>" for
+// a zero char.
+func writeCharField(sb *strings.Builder, label string, c byte) {
+	if c == 0 {
+		sb.WriteString(label + " <>")
+		return
+	}
+	sb.WriteString(fmt.Sprintf("%s %c", label, c))
+}
+
 // escapeString escapes special characters in a string for output.
 func escapeString(s string) string {
 	var sb strings.Builder
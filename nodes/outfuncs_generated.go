@@ -0,0 +1,5420 @@
+// Code generated by pgsema-gen. DO NOT EDIT.
+// Source: the node declarations of the nodes package
+
+package nodes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// writeGeneratedNode writes a node whose outfunc is generated, reporting
+// whether node was one.
+func writeGeneratedNode(sb *strings.Builder, node Node) bool {
+	switch n := node.(type) {
+	case *TableFunc:
+		writeTableFunc(sb, n)
+	case *IntoClause:
+		writeIntoClause(sb, n)
+	case *Var:
+		writeVar(sb, n)
+	case *Const:
+		writeConst(sb, n)
+	case *Param:
+		writeParam(sb, n)
+	case *Aggref:
+		writeAggref(sb, n)
+	case *GroupingFunc:
+		writeGroupingFunc(sb, n)
+	case *WindowFunc:
+		writeWindowFunc(sb, n)
+	case *WindowFuncRunCondition:
+		writeWindowFuncRunCondition(sb, n)
+	case *MergeSupportFunc:
+		writeMergeSupportFunc(sb, n)
+	case *SubscriptingRef:
+		writeSubscriptingRef(sb, n)
+	case *FuncExpr:
+		writeFuncExpr(sb, n)
+	case *NamedArgExpr:
+		writeNamedArgExpr(sb, n)
+	case *OpExpr:
+		writeOpExpr(sb, n)
+	case *DistinctExpr:
+		writeDistinctExpr(sb, n)
+	case *NullIfExpr:
+		writeNullIfExpr(sb, n)
+	case *ScalarArrayOpExpr:
+		writeScalarArrayOpExpr(sb, n)
+	case *SubPlan:
+		writeSubPlan(sb, n)
+	case *AlternativeSubPlan:
+		writeAlternativeSubPlan(sb, n)
+	case *FieldSelect:
+		writeFieldSelect(sb, n)
+	case *FieldStore:
+		writeFieldStore(sb, n)
+	case *RelabelType:
+		writeRelabelType(sb, n)
+	case *CoerceViaIO:
+		writeCoerceViaIO(sb, n)
+	case *ArrayCoerceExpr:
+		writeArrayCoerceExpr(sb, n)
+	case *ConvertRowtypeExpr:
+		writeConvertRowtypeExpr(sb, n)
+	case *CollateExpr:
+		writeCollateExpr(sb, n)
+	case *CaseExpr:
+		writeCaseExpr(sb, n)
+	case *CaseWhen:
+		writeCaseWhen(sb, n)
+	case *CaseTestExpr:
+		writeCaseTestExpr(sb, n)
+	case *ArrayExpr:
+		writeArrayExpr(sb, n)
+	case *RowExpr:
+		writeRowExpr(sb, n)
+	case *RowCompareExpr:
+		writeRowCompareExpr(sb, n)
+	case *CoalesceExpr:
+		writeCoalesceExpr(sb, n)
+	case *MinMaxExpr:
+		writeMinMaxExpr(sb, n)
+	case *SQLValueFunction:
+		writeSQLValueFunction(sb, n)
+	case *XmlExpr:
+		writeXmlExpr(sb, n)
+	case *JsonFormat:
+		writeJsonFormat(sb, n)
+	case *JsonReturning:
+		writeJsonReturning(sb, n)
+	case *JsonValueExpr:
+		writeJsonValueExpr(sb, n)
+	case *JsonConstructorExpr:
+		writeJsonConstructorExpr(sb, n)
+	case *JsonIsPredicate:
+		writeJsonIsPredicate(sb, n)
+	case *JsonBehavior:
+		writeJsonBehavior(sb, n)
+	case *JsonExpr:
+		writeJsonExpr(sb, n)
+	case *JsonTablePath:
+		writeJsonTablePath(sb, n)
+	case *JsonTablePathScan:
+		writeJsonTablePathScan(sb, n)
+	case *JsonTableSiblingJoin:
+		writeJsonTableSiblingJoin(sb, n)
+	case *BooleanTest:
+		writeBooleanTest(sb, n)
+	case *MergeAction:
+		writeMergeAction(sb, n)
+	case *CoerceToDomain:
+		writeCoerceToDomain(sb, n)
+	case *CoerceToDomainValue:
+		writeCoerceToDomainValue(sb, n)
+	case *SetToDefault:
+		writeSetToDefault(sb, n)
+	case *CurrentOfExpr:
+		writeCurrentOfExpr(sb, n)
+	case *NextValueExpr:
+		writeNextValueExpr(sb, n)
+	case *InferenceElem:
+		writeInferenceElem(sb, n)
+	case *TargetEntry:
+		writeTargetEntry(sb, n)
+	case *RangeTblRef:
+		writeRangeTblRef(sb, n)
+	case *FromExpr:
+		writeFromExpr(sb, n)
+	case *OnConflictExpr:
+		writeOnConflictExpr(sb, n)
+	case *Query:
+		writeQuery(sb, n)
+	case *ParamRef:
+		writeParamRef(sb, n)
+	case *CollateClause:
+		writeCollateClause(sb, n)
+	case *RoleSpec:
+		writeRoleSpec(sb, n)
+	case *A_Indices:
+		writeA_Indices(sb, n)
+	case *A_Indirection:
+		writeA_Indirection(sb, n)
+	case *A_ArrayExpr:
+		writeA_ArrayExpr(sb, n)
+	case *MultiAssignRef:
+		writeMultiAssignRef(sb, n)
+	case *WindowDef:
+		writeWindowDef(sb, n)
+	case *RangeSubselect:
+		writeRangeSubselect(sb, n)
+	case *RangeFunction:
+		writeRangeFunction(sb, n)
+	case *RangeTableFunc:
+		writeRangeTableFunc(sb, n)
+	case *RangeTableFuncCol:
+		writeRangeTableFuncCol(sb, n)
+	case *RangeTableSample:
+		writeRangeTableSample(sb, n)
+	case *TableLikeClause:
+		writeTableLikeClause(sb, n)
+	case *IndexElem:
+		writeIndexElem(sb, n)
+	case *DefElem:
+		writeDefElem(sb, n)
+	case *LockingClause:
+		writeLockingClause(sb, n)
+	case *XmlSerialize:
+		writeXmlSerialize(sb, n)
+	case *PartitionElem:
+		writePartitionElem(sb, n)
+	case *PartitionSpec:
+		writePartitionSpec(sb, n)
+	case *PartitionBoundSpec:
+		writePartitionBoundSpec(sb, n)
+	case *PartitionRangeDatum:
+		writePartitionRangeDatum(sb, n)
+	case *SinglePartitionSpec:
+		writeSinglePartitionSpec(sb, n)
+	case *PartitionCmd:
+		writePartitionCmd(sb, n)
+	case *RangeTblEntry:
+		writeRangeTblEntry(sb, n)
+	case *RTEPermissionInfo:
+		writeRTEPermissionInfo(sb, n)
+	case *RangeTblFunction:
+		writeRangeTblFunction(sb, n)
+	case *TableSampleClause:
+		writeTableSampleClause(sb, n)
+	case *WithCheckOption:
+		writeWithCheckOption(sb, n)
+	case *SortGroupClause:
+		writeSortGroupClause(sb, n)
+	case *GroupingSet:
+		writeGroupingSet(sb, n)
+	case *WindowClause:
+		writeWindowClause(sb, n)
+	case *RowMarkClause:
+		writeRowMarkClause(sb, n)
+	case *WithClause:
+		writeWithClause(sb, n)
+	case *InferClause:
+		writeInferClause(sb, n)
+	case *OnConflictClause:
+		writeOnConflictClause(sb, n)
+	case *CTESearchClause:
+		writeCTESearchClause(sb, n)
+	case *CTECycleClause:
+		writeCTECycleClause(sb, n)
+	case *CommonTableExpr:
+		writeCommonTableExpr(sb, n)
+	case *MergeWhenClause:
+		writeMergeWhenClause(sb, n)
+	case *TriggerTransition:
+		writeTriggerTransition(sb, n)
+	case *JsonOutput:
+		writeJsonOutput(sb, n)
+	case *JsonArgument:
+		writeJsonArgument(sb, n)
+	case *JsonFuncExpr:
+		writeJsonFuncExpr(sb, n)
+	case *JsonTablePathSpec:
+		writeJsonTablePathSpec(sb, n)
+	case *JsonTable:
+		writeJsonTable(sb, n)
+	case *JsonTableColumn:
+		writeJsonTableColumn(sb, n)
+	case *JsonKeyValue:
+		writeJsonKeyValue(sb, n)
+	case *JsonParseExpr:
+		writeJsonParseExpr(sb, n)
+	case *JsonScalarExpr:
+		writeJsonScalarExpr(sb, n)
+	case *JsonSerializeExpr:
+		writeJsonSerializeExpr(sb, n)
+	case *JsonObjectConstructor:
+		writeJsonObjectConstructor(sb, n)
+	case *JsonArrayConstructor:
+		writeJsonArrayConstructor(sb, n)
+	case *JsonArrayQueryConstructor:
+		writeJsonArrayQueryConstructor(sb, n)
+	case *JsonAggConstructor:
+		writeJsonAggConstructor(sb, n)
+	case *JsonObjectAgg:
+		writeJsonObjectAgg(sb, n)
+	case *JsonArrayAgg:
+		writeJsonArrayAgg(sb, n)
+	case *MergeStmt:
+		writeMergeStmt(sb, n)
+	case *SetOperationStmt:
+		writeSetOperationStmt(sb, n)
+	case *ReturnStmt:
+		writeReturnStmt(sb, n)
+	case *PLAssignStmt:
+		writePLAssignStmt(sb, n)
+	case *CreateSchemaStmt:
+		writeCreateSchemaStmt(sb, n)
+	case *AlterTableStmt:
+		writeAlterTableStmt(sb, n)
+	case *ReplicaIdentityStmt:
+		writeReplicaIdentityStmt(sb, n)
+	case *AlterTableCmd:
+		writeAlterTableCmd(sb, n)
+	case *AlterCollationStmt:
+		writeAlterCollationStmt(sb, n)
+	case *AlterDomainStmt:
+		writeAlterDomainStmt(sb, n)
+	case *GrantStmt:
+		writeGrantStmt(sb, n)
+	case *ObjectWithArgs:
+		writeObjectWithArgs(sb, n)
+	case *AccessPriv:
+		writeAccessPriv(sb, n)
+	case *GrantRoleStmt:
+		writeGrantRoleStmt(sb, n)
+	case *AlterDefaultPrivilegesStmt:
+		writeAlterDefaultPrivilegesStmt(sb, n)
+	case *CopyStmt:
+		writeCopyStmt(sb, n)
+	case *VariableSetStmt:
+		writeVariableSetStmt(sb, n)
+	case *VariableShowStmt:
+		writeVariableShowStmt(sb, n)
+	case *CreateTableSpaceStmt:
+		writeCreateTableSpaceStmt(sb, n)
+	case *DropTableSpaceStmt:
+		writeDropTableSpaceStmt(sb, n)
+	case *AlterTableSpaceOptionsStmt:
+		writeAlterTableSpaceOptionsStmt(sb, n)
+	case *AlterTableMoveAllStmt:
+		writeAlterTableMoveAllStmt(sb, n)
+	case *CreateExtensionStmt:
+		writeCreateExtensionStmt(sb, n)
+	case *AlterExtensionStmt:
+		writeAlterExtensionStmt(sb, n)
+	case *AlterExtensionContentsStmt:
+		writeAlterExtensionContentsStmt(sb, n)
+	case *CreateFdwStmt:
+		writeCreateFdwStmt(sb, n)
+	case *AlterFdwStmt:
+		writeAlterFdwStmt(sb, n)
+	case *CreateForeignServerStmt:
+		writeCreateForeignServerStmt(sb, n)
+	case *AlterForeignServerStmt:
+		writeAlterForeignServerStmt(sb, n)
+	case *CreateForeignTableStmt:
+		writeCreateForeignTableStmt(sb, n)
+	case *CreateUserMappingStmt:
+		writeCreateUserMappingStmt(sb, n)
+	case *AlterUserMappingStmt:
+		writeAlterUserMappingStmt(sb, n)
+	case *DropUserMappingStmt:
+		writeDropUserMappingStmt(sb, n)
+	case *ImportForeignSchemaStmt:
+		writeImportForeignSchemaStmt(sb, n)
+	case *CreatePolicyStmt:
+		writeCreatePolicyStmt(sb, n)
+	case *AlterPolicyStmt:
+		writeAlterPolicyStmt(sb, n)
+	case *CreateAmStmt:
+		writeCreateAmStmt(sb, n)
+	case *CreateTrigStmt:
+		writeCreateTrigStmt(sb, n)
+	case *CreateEventTrigStmt:
+		writeCreateEventTrigStmt(sb, n)
+	case *AlterEventTrigStmt:
+		writeAlterEventTrigStmt(sb, n)
+	case *CreatePLangStmt:
+		writeCreatePLangStmt(sb, n)
+	case *CreateRoleStmt:
+		writeCreateRoleStmt(sb, n)
+	case *AlterRoleStmt:
+		writeAlterRoleStmt(sb, n)
+	case *AlterRoleSetStmt:
+		writeAlterRoleSetStmt(sb, n)
+	case *DropRoleStmt:
+		writeDropRoleStmt(sb, n)
+	case *CreateSeqStmt:
+		writeCreateSeqStmt(sb, n)
+	case *AlterSeqStmt:
+		writeAlterSeqStmt(sb, n)
+	case *DefineStmt:
+		writeDefineStmt(sb, n)
+	case *CreateDomainStmt:
+		writeCreateDomainStmt(sb, n)
+	case *CreateOpClassStmt:
+		writeCreateOpClassStmt(sb, n)
+	case *CreateOpClassItem:
+		writeCreateOpClassItem(sb, n)
+	case *CreateOpFamilyStmt:
+		writeCreateOpFamilyStmt(sb, n)
+	case *AlterOpFamilyStmt:
+		writeAlterOpFamilyStmt(sb, n)
+	case *DropStmt:
+		writeDropStmt(sb, n)
+	case *TruncateStmt:
+		writeTruncateStmt(sb, n)
+	case *CommentStmt:
+		writeCommentStmt(sb, n)
+	case *SecLabelStmt:
+		writeSecLabelStmt(sb, n)
+	case *DeclareCursorStmt:
+		writeDeclareCursorStmt(sb, n)
+	case *ClosePortalStmt:
+		writeClosePortalStmt(sb, n)
+	case *FetchStmt:
+		writeFetchStmt(sb, n)
+	case *CreateStatsStmt:
+		writeCreateStatsStmt(sb, n)
+	case *StatsElem:
+		writeStatsElem(sb, n)
+	case *AlterStatsStmt:
+		writeAlterStatsStmt(sb, n)
+	case *CreateFunctionStmt:
+		writeCreateFunctionStmt(sb, n)
+	case *FunctionParameter:
+		writeFunctionParameter(sb, n)
+	case *AlterFunctionStmt:
+		writeAlterFunctionStmt(sb, n)
+	case *DoStmt:
+		writeDoStmt(sb, n)
+	case *InlineCodeBlock:
+		writeInlineCodeBlock(sb, n)
+	case *CallStmt:
+		writeCallStmt(sb, n)
+	case *CallContext:
+		writeCallContext(sb, n)
+	case *RenameStmt:
+		writeRenameStmt(sb, n)
+	case *AlterObjectDependsStmt:
+		writeAlterObjectDependsStmt(sb, n)
+	case *AlterObjectSchemaStmt:
+		writeAlterObjectSchemaStmt(sb, n)
+	case *AlterOwnerStmt:
+		writeAlterOwnerStmt(sb, n)
+	case *AlterOperatorStmt:
+		writeAlterOperatorStmt(sb, n)
+	case *AlterTypeStmt:
+		writeAlterTypeStmt(sb, n)
+	case *RuleStmt:
+		writeRuleStmt(sb, n)
+	case *NotifyStmt:
+		writeNotifyStmt(sb, n)
+	case *ListenStmt:
+		writeListenStmt(sb, n)
+	case *UnlistenStmt:
+		writeUnlistenStmt(sb, n)
+	case *TransactionStmt:
+		writeTransactionStmt(sb, n)
+	case *CompositeTypeStmt:
+		writeCompositeTypeStmt(sb, n)
+	case *CreateEnumStmt:
+		writeCreateEnumStmt(sb, n)
+	case *CreateRangeStmt:
+		writeCreateRangeStmt(sb, n)
+	case *AlterEnumStmt:
+		writeAlterEnumStmt(sb, n)
+	case *LoadStmt:
+		writeLoadStmt(sb, n)
+	case *CreatedbStmt:
+		writeCreatedbStmt(sb, n)
+	case *AlterDatabaseStmt:
+		writeAlterDatabaseStmt(sb, n)
+	case *AlterDatabaseRefreshCollStmt:
+		writeAlterDatabaseRefreshCollStmt(sb, n)
+	case *AlterDatabaseSetStmt:
+		writeAlterDatabaseSetStmt(sb, n)
+	case *DropdbStmt:
+		writeDropdbStmt(sb, n)
+	case *AlterSystemStmt:
+		writeAlterSystemStmt(sb, n)
+	case *ClusterStmt:
+		writeClusterStmt(sb, n)
+	case *VacuumStmt:
+		writeVacuumStmt(sb, n)
+	case *VacuumRelation:
+		writeVacuumRelation(sb, n)
+	case *ExplainStmt:
+		writeExplainStmt(sb, n)
+	case *CreateTableAsStmt:
+		writeCreateTableAsStmt(sb, n)
+	case *RefreshMatViewStmt:
+		writeRefreshMatViewStmt(sb, n)
+	case *CheckPointStmt:
+		writeCheckPointStmt(sb, n)
+	case *DiscardStmt:
+		writeDiscardStmt(sb, n)
+	case *LockStmt:
+		writeLockStmt(sb, n)
+	case *ConstraintsSetStmt:
+		writeConstraintsSetStmt(sb, n)
+	case *ReindexStmt:
+		writeReindexStmt(sb, n)
+	case *CreateConversionStmt:
+		writeCreateConversionStmt(sb, n)
+	case *CreateCastStmt:
+		writeCreateCastStmt(sb, n)
+	case *CreateTransformStmt:
+		writeCreateTransformStmt(sb, n)
+	case *PrepareStmt:
+		writePrepareStmt(sb, n)
+	case *ExecuteStmt:
+		writeExecuteStmt(sb, n)
+	case *DeallocateStmt:
+		writeDeallocateStmt(sb, n)
+	case *DropOwnedStmt:
+		writeDropOwnedStmt(sb, n)
+	case *ReassignOwnedStmt:
+		writeReassignOwnedStmt(sb, n)
+	case *AlterTSDictionaryStmt:
+		writeAlterTSDictionaryStmt(sb, n)
+	case *AlterTSConfigurationStmt:
+		writeAlterTSConfigurationStmt(sb, n)
+	case *PublicationTable:
+		writePublicationTable(sb, n)
+	case *PublicationObjSpec:
+		writePublicationObjSpec(sb, n)
+	case *CreatePublicationStmt:
+		writeCreatePublicationStmt(sb, n)
+	case *AlterPublicationStmt:
+		writeAlterPublicationStmt(sb, n)
+	case *CreateSubscriptionStmt:
+		writeCreateSubscriptionStmt(sb, n)
+	case *AlterSubscriptionStmt:
+		writeAlterSubscriptionStmt(sb, n)
+	case *DropSubscriptionStmt:
+		writeDropSubscriptionStmt(sb, n)
+	case *ReturningOption:
+		writeReturningOption(sb, n)
+	case *ReturningClause:
+		writeReturningClause(sb, n)
+	default:
+		return false
+	}
+	return true
+}
+
+func writeTableFunc(sb *strings.Builder, n *TableFunc) {
+	sb.WriteString("{TABLEFUNC")
+	sb.WriteString(fmt.Sprintf(" :functype %d", n.Functype))
+	if n.NsUris != nil {
+		sb.WriteString(" :ns_uris ")
+		writeNode(sb, n.NsUris)
+	} else {
+		sb.WriteString(" :ns_uris <>")
+	}
+	if n.NsNames != nil {
+		sb.WriteString(" :ns_names ")
+		writeNode(sb, n.NsNames)
+	} else {
+		sb.WriteString(" :ns_names <>")
+	}
+	sb.WriteString(" :docexpr ")
+	writeNode(sb, n.Docexpr)
+	sb.WriteString(" :rowexpr ")
+	writeNode(sb, n.Rowexpr)
+	if n.Colnames != nil {
+		sb.WriteString(" :colnames ")
+		writeNode(sb, n.Colnames)
+	} else {
+		sb.WriteString(" :colnames <>")
+	}
+	if n.Coltypes != nil {
+		sb.WriteString(" :coltypes ")
+		writeNode(sb, n.Coltypes)
+	} else {
+		sb.WriteString(" :coltypes <>")
+	}
+	if n.Coltypmods != nil {
+		sb.WriteString(" :coltypmods ")
+		writeNode(sb, n.Coltypmods)
+	} else {
+		sb.WriteString(" :coltypmods <>")
+	}
+	if n.Colcollations != nil {
+		sb.WriteString(" :colcollations ")
+		writeNode(sb, n.Colcollations)
+	} else {
+		sb.WriteString(" :colcollations <>")
+	}
+	if n.Colexprs != nil {
+		sb.WriteString(" :colexprs ")
+		writeNode(sb, n.Colexprs)
+	} else {
+		sb.WriteString(" :colexprs <>")
+	}
+	if n.Coldefexprs != nil {
+		sb.WriteString(" :coldefexprs ")
+		writeNode(sb, n.Coldefexprs)
+	} else {
+		sb.WriteString(" :coldefexprs <>")
+	}
+	if n.Colvalexprs != nil {
+		sb.WriteString(" :colvalexprs ")
+		writeNode(sb, n.Colvalexprs)
+	} else {
+		sb.WriteString(" :colvalexprs <>")
+	}
+	if n.Passingvalexprs != nil {
+		sb.WriteString(" :passingvalexprs ")
+		writeNode(sb, n.Passingvalexprs)
+	} else {
+		sb.WriteString(" :passingvalexprs <>")
+	}
+	sb.WriteString(" :notnulls ")
+	writeBitmapset(sb, n.Notnulls)
+	sb.WriteString(" :plan ")
+	writeNode(sb, n.Plan)
+	sb.WriteString(fmt.Sprintf(" :ordinalitycol %d", n.Ordinalitycol))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeIntoClause(sb *strings.Builder, n *IntoClause) {
+	sb.WriteString("{INTOCLAUSE")
+	if n.Rel != nil {
+		sb.WriteString(" :rel ")
+		writeNode(sb, n.Rel)
+	} else {
+		sb.WriteString(" :rel <>")
+	}
+	if n.ColNames != nil {
+		sb.WriteString(" :colNames ")
+		writeNode(sb, n.ColNames)
+	} else {
+		sb.WriteString(" :colNames <>")
+	}
+	if n.AccessMethod != "" {
+		sb.WriteString(" :accessMethod \"" + escapeString(n.AccessMethod) + "\"")
+	} else {
+		sb.WriteString(" :accessMethod <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :onCommit %d", n.OnCommit))
+	if n.TableSpaceName != "" {
+		sb.WriteString(" :tableSpaceName \"" + escapeString(n.TableSpaceName) + "\"")
+	} else {
+		sb.WriteString(" :tableSpaceName <>")
+	}
+	sb.WriteString(" :viewQuery ")
+	writeNode(sb, n.ViewQuery)
+	sb.WriteString(fmt.Sprintf(" :skipData %t", n.SkipData))
+	sb.WriteString("}")
+}
+
+func writeVar(sb *strings.Builder, n *Var) {
+	sb.WriteString("{VAR")
+	sb.WriteString(fmt.Sprintf(" :varno %d", n.Varno))
+	sb.WriteString(fmt.Sprintf(" :varattno %d", n.Varattno))
+	sb.WriteString(fmt.Sprintf(" :vartype %d", n.Vartype))
+	sb.WriteString(fmt.Sprintf(" :vartypmod %d", n.Vartypmod))
+	sb.WriteString(fmt.Sprintf(" :varcollid %d", n.Varcollid))
+	sb.WriteString(" :varnullingrels ")
+	writeBitmapset(sb, n.Varnullingrels)
+	sb.WriteString(fmt.Sprintf(" :varlevelsup %d", n.Varlevelsup))
+	sb.WriteString(fmt.Sprintf(" :varnosyn %d", n.Varnosyn))
+	sb.WriteString(fmt.Sprintf(" :varattnosyn %d", n.Varattnosyn))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeConst(sb *strings.Builder, n *Const) {
+	sb.WriteString("{CONST")
+	sb.WriteString(fmt.Sprintf(" :consttype %d", n.Consttype))
+	sb.WriteString(fmt.Sprintf(" :consttypmod %d", n.Consttypmod))
+	sb.WriteString(fmt.Sprintf(" :constcollid %d", n.Constcollid))
+	sb.WriteString(fmt.Sprintf(" :constlen %d", n.Constlen))
+	sb.WriteString(" :constvalue ")
+	writeNode(sb, n.Constvalue)
+	sb.WriteString(fmt.Sprintf(" :constisnull %t", n.Constisnull))
+	sb.WriteString(fmt.Sprintf(" :constbyval %t", n.Constbyval))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeParam(sb *strings.Builder, n *Param) {
+	sb.WriteString("{PARAM")
+	sb.WriteString(fmt.Sprintf(" :paramkind %d", n.Paramkind))
+	sb.WriteString(fmt.Sprintf(" :paramid %d", n.Paramid))
+	sb.WriteString(fmt.Sprintf(" :paramtype %d", n.Paramtype))
+	sb.WriteString(fmt.Sprintf(" :paramtypmod %d", n.Paramtypmod))
+	sb.WriteString(fmt.Sprintf(" :paramcollid %d", n.Paramcollid))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeAggref(sb *strings.Builder, n *Aggref) {
+	sb.WriteString("{AGGREF")
+	sb.WriteString(fmt.Sprintf(" :aggfnoid %d", n.Aggfnoid))
+	sb.WriteString(fmt.Sprintf(" :aggtype %d", n.Aggtype))
+	sb.WriteString(fmt.Sprintf(" :aggcollid %d", n.Aggcollid))
+	sb.WriteString(fmt.Sprintf(" :inputcollid %d", n.Inputcollid))
+	sb.WriteString(fmt.Sprintf(" :aggtranstype %d", n.Aggtranstype))
+	if n.Aggargtypes != nil {
+		sb.WriteString(" :aggargtypes ")
+		writeNode(sb, n.Aggargtypes)
+	} else {
+		sb.WriteString(" :aggargtypes <>")
+	}
+	if n.Aggdirectargs != nil {
+		sb.WriteString(" :aggdirectargs ")
+		writeNode(sb, n.Aggdirectargs)
+	} else {
+		sb.WriteString(" :aggdirectargs <>")
+	}
+	if n.Args != nil {
+		sb.WriteString(" :args ")
+		writeNode(sb, n.Args)
+	} else {
+		sb.WriteString(" :args <>")
+	}
+	if n.Aggorder != nil {
+		sb.WriteString(" :aggorder ")
+		writeNode(sb, n.Aggorder)
+	} else {
+		sb.WriteString(" :aggorder <>")
+	}
+	if n.Aggdistinct != nil {
+		sb.WriteString(" :aggdistinct ")
+		writeNode(sb, n.Aggdistinct)
+	} else {
+		sb.WriteString(" :aggdistinct <>")
+	}
+	sb.WriteString(" :aggfilter ")
+	writeNode(sb, n.Aggfilter)
+	sb.WriteString(fmt.Sprintf(" :aggstar %t", n.Aggstar))
+	sb.WriteString(fmt.Sprintf(" :aggvariadic %t", n.Aggvariadic))
+	writeCharField(sb, " :aggkind", n.Aggkind)
+	sb.WriteString(fmt.Sprintf(" :aggpresorted %t", n.Aggpresorted))
+	sb.WriteString(fmt.Sprintf(" :agglevelsup %d", n.Agglevelsup))
+	sb.WriteString(fmt.Sprintf(" :aggsplit %d", n.Aggsplit))
+	sb.WriteString(fmt.Sprintf(" :aggno %d", n.Aggno))
+	sb.WriteString(fmt.Sprintf(" :aggtransno %d", n.Aggtransno))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeGroupingFunc(sb *strings.Builder, n *GroupingFunc) {
+	sb.WriteString("{GROUPINGFUNC")
+	if n.Args != nil {
+		sb.WriteString(" :args ")
+		writeNode(sb, n.Args)
+	} else {
+		sb.WriteString(" :args <>")
+	}
+	if n.Refs != nil {
+		sb.WriteString(" :refs ")
+		writeNode(sb, n.Refs)
+	} else {
+		sb.WriteString(" :refs <>")
+	}
+	if n.Cols != nil {
+		sb.WriteString(" :cols ")
+		writeNode(sb, n.Cols)
+	} else {
+		sb.WriteString(" :cols <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :agglevelsup %d", n.Agglevelsup))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeWindowFunc(sb *strings.Builder, n *WindowFunc) {
+	sb.WriteString("{WINDOWFUNC")
+	sb.WriteString(fmt.Sprintf(" :winfnoid %d", n.Winfnoid))
+	sb.WriteString(fmt.Sprintf(" :wintype %d", n.Wintype))
+	sb.WriteString(fmt.Sprintf(" :wincollid %d", n.Wincollid))
+	sb.WriteString(fmt.Sprintf(" :inputcollid %d", n.Inputcollid))
+	if n.Args != nil {
+		sb.WriteString(" :args ")
+		writeNode(sb, n.Args)
+	} else {
+		sb.WriteString(" :args <>")
+	}
+	sb.WriteString(" :aggfilter ")
+	writeNode(sb, n.Aggfilter)
+	if n.RunCondition != nil {
+		sb.WriteString(" :runCondition ")
+		writeNode(sb, n.RunCondition)
+	} else {
+		sb.WriteString(" :runCondition <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :winref %d", n.Winref))
+	sb.WriteString(fmt.Sprintf(" :winstar %t", n.Winstar))
+	sb.WriteString(fmt.Sprintf(" :winagg %t", n.Winagg))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeWindowFuncRunCondition(sb *strings.Builder, n *WindowFuncRunCondition) {
+	sb.WriteString("{WINDOWFUNCRUNCONDITION")
+	sb.WriteString(fmt.Sprintf(" :opno %d", n.Opno))
+	sb.WriteString(fmt.Sprintf(" :inputcollid %d", n.Inputcollid))
+	sb.WriteString(fmt.Sprintf(" :wfunc_left %t", n.WfuncLeft))
+	sb.WriteString(" :arg ")
+	writeNode(sb, n.Arg)
+	sb.WriteString("}")
+}
+
+func writeMergeSupportFunc(sb *strings.Builder, n *MergeSupportFunc) {
+	sb.WriteString("{MERGESUPPORTFUNC")
+	sb.WriteString(fmt.Sprintf(" :msftype %d", n.Msftype))
+	sb.WriteString(fmt.Sprintf(" :msfcollid %d", n.Msfcollid))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeSubscriptingRef(sb *strings.Builder, n *SubscriptingRef) {
+	sb.WriteString("{SUBSCRIPTINGREF")
+	sb.WriteString(fmt.Sprintf(" :refcontainertype %d", n.Refcontainertype))
+	sb.WriteString(fmt.Sprintf(" :refelemtype %d", n.Refelemtype))
+	sb.WriteString(fmt.Sprintf(" :refrestype %d", n.Refrestype))
+	sb.WriteString(fmt.Sprintf(" :reftypmod %d", n.Reftypmod))
+	sb.WriteString(fmt.Sprintf(" :refcollid %d", n.Refcollid))
+	if n.Refupperindexpr != nil {
+		sb.WriteString(" :refupperindexpr ")
+		writeNode(sb, n.Refupperindexpr)
+	} else {
+		sb.WriteString(" :refupperindexpr <>")
+	}
+	if n.Reflowerindexpr != nil {
+		sb.WriteString(" :reflowerindexpr ")
+		writeNode(sb, n.Reflowerindexpr)
+	} else {
+		sb.WriteString(" :reflowerindexpr <>")
+	}
+	sb.WriteString(" :refexpr ")
+	writeNode(sb, n.Refexpr)
+	sb.WriteString(" :refassgnexpr ")
+	writeNode(sb, n.Refassgnexpr)
+	sb.WriteString("}")
+}
+
+func writeFuncExpr(sb *strings.Builder, n *FuncExpr) {
+	sb.WriteString("{FUNCEXPR")
+	sb.WriteString(fmt.Sprintf(" :funcid %d", n.Funcid))
+	sb.WriteString(fmt.Sprintf(" :funcresulttype %d", n.Funcresulttype))
+	sb.WriteString(fmt.Sprintf(" :funcretset %t", n.Funcretset))
+	sb.WriteString(fmt.Sprintf(" :funcvariadic %t", n.Funcvariadic))
+	sb.WriteString(fmt.Sprintf(" :funcformat %d", n.Funcformat))
+	sb.WriteString(fmt.Sprintf(" :funccollid %d", n.Funccollid))
+	sb.WriteString(fmt.Sprintf(" :inputcollid %d", n.Inputcollid))
+	if n.Args != nil {
+		sb.WriteString(" :args ")
+		writeNode(sb, n.Args)
+	} else {
+		sb.WriteString(" :args <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeNamedArgExpr(sb *strings.Builder, n *NamedArgExpr) {
+	sb.WriteString("{NAMEDARGEXPR")
+	sb.WriteString(" :arg ")
+	writeNode(sb, n.Arg)
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :argnumber %d", n.Argnumber))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeOpExpr(sb *strings.Builder, n *OpExpr) {
+	sb.WriteString("{OPEXPR")
+	sb.WriteString(fmt.Sprintf(" :opno %d", n.Opno))
+	sb.WriteString(fmt.Sprintf(" :opfuncid %d", n.Opfuncid))
+	sb.WriteString(fmt.Sprintf(" :opresulttype %d", n.Opresulttype))
+	sb.WriteString(fmt.Sprintf(" :opretset %t", n.Opretset))
+	sb.WriteString(fmt.Sprintf(" :opcollid %d", n.Opcollid))
+	sb.WriteString(fmt.Sprintf(" :inputcollid %d", n.Inputcollid))
+	if n.Args != nil {
+		sb.WriteString(" :args ")
+		writeNode(sb, n.Args)
+	} else {
+		sb.WriteString(" :args <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeDistinctExpr(sb *strings.Builder, n *DistinctExpr) {
+	sb.WriteString("{DISTINCTEXPR")
+	sb.WriteString(fmt.Sprintf(" :opno %d", n.Opno))
+	sb.WriteString(fmt.Sprintf(" :opfuncid %d", n.Opfuncid))
+	sb.WriteString(fmt.Sprintf(" :opresulttype %d", n.Opresulttype))
+	sb.WriteString(fmt.Sprintf(" :opretset %t", n.Opretset))
+	sb.WriteString(fmt.Sprintf(" :opcollid %d", n.Opcollid))
+	sb.WriteString(fmt.Sprintf(" :inputcollid %d", n.Inputcollid))
+	if n.Args != nil {
+		sb.WriteString(" :args ")
+		writeNode(sb, n.Args)
+	} else {
+		sb.WriteString(" :args <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeNullIfExpr(sb *strings.Builder, n *NullIfExpr) {
+	sb.WriteString("{NULLIFEXPR")
+	sb.WriteString(fmt.Sprintf(" :opno %d", n.Opno))
+	sb.WriteString(fmt.Sprintf(" :opfuncid %d", n.Opfuncid))
+	sb.WriteString(fmt.Sprintf(" :opresulttype %d", n.Opresulttype))
+	sb.WriteString(fmt.Sprintf(" :opretset %t", n.Opretset))
+	sb.WriteString(fmt.Sprintf(" :opcollid %d", n.Opcollid))
+	sb.WriteString(fmt.Sprintf(" :inputcollid %d", n.Inputcollid))
+	if n.Args != nil {
+		sb.WriteString(" :args ")
+		writeNode(sb, n.Args)
+	} else {
+		sb.WriteString(" :args <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeScalarArrayOpExpr(sb *strings.Builder, n *ScalarArrayOpExpr) {
+	sb.WriteString("{SCALARARRAYOPEXPR")
+	sb.WriteString(fmt.Sprintf(" :opno %d", n.Opno))
+	sb.WriteString(fmt.Sprintf(" :opfuncid %d", n.Opfuncid))
+	sb.WriteString(fmt.Sprintf(" :hashfuncid %d", n.Hashfuncid))
+	sb.WriteString(fmt.Sprintf(" :negfuncid %d", n.Negfuncid))
+	sb.WriteString(fmt.Sprintf(" :useOr %t", n.UseOr))
+	sb.WriteString(fmt.Sprintf(" :inputcollid %d", n.Inputcollid))
+	if n.Args != nil {
+		sb.WriteString(" :args ")
+		writeNode(sb, n.Args)
+	} else {
+		sb.WriteString(" :args <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeSubPlan(sb *strings.Builder, n *SubPlan) {
+	sb.WriteString("{SUBPLAN")
+	sb.WriteString(fmt.Sprintf(" :subLinkType %d", n.SubLinkType))
+	sb.WriteString(" :testexpr ")
+	writeNode(sb, n.Testexpr)
+	if n.ParamIds != nil {
+		sb.WriteString(" :paramIds ")
+		writeNode(sb, n.ParamIds)
+	} else {
+		sb.WriteString(" :paramIds <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :plan_id %d", n.PlanId))
+	if n.PlanName != "" {
+		sb.WriteString(" :plan_name \"" + escapeString(n.PlanName) + "\"")
+	} else {
+		sb.WriteString(" :plan_name <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :firstColType %d", n.FirstColType))
+	sb.WriteString(fmt.Sprintf(" :firstColTypmod %d", n.FirstColTypmod))
+	sb.WriteString(fmt.Sprintf(" :firstColCollation %d", n.FirstColCollation))
+	sb.WriteString(fmt.Sprintf(" :useHashTable %t", n.UseHashTable))
+	sb.WriteString(fmt.Sprintf(" :unknownEqFalse %t", n.UnknownEqFalse))
+	sb.WriteString(fmt.Sprintf(" :parallel_safe %t", n.ParallelSafe))
+	if n.SetParam != nil {
+		sb.WriteString(" :setParam ")
+		writeNode(sb, n.SetParam)
+	} else {
+		sb.WriteString(" :setParam <>")
+	}
+	if n.ParParam != nil {
+		sb.WriteString(" :parParam ")
+		writeNode(sb, n.ParParam)
+	} else {
+		sb.WriteString(" :parParam <>")
+	}
+	if n.Args != nil {
+		sb.WriteString(" :args ")
+		writeNode(sb, n.Args)
+	} else {
+		sb.WriteString(" :args <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :startup_cost %g", n.StartupCost))
+	sb.WriteString(fmt.Sprintf(" :per_call_cost %g", n.PerCallCost))
+	sb.WriteString("}")
+}
+
+func writeAlternativeSubPlan(sb *strings.Builder, n *AlternativeSubPlan) {
+	sb.WriteString("{ALTERNATIVESUBPLAN")
+	if n.Subplans != nil {
+		sb.WriteString(" :subplans ")
+		writeNode(sb, n.Subplans)
+	} else {
+		sb.WriteString(" :subplans <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeFieldSelect(sb *strings.Builder, n *FieldSelect) {
+	sb.WriteString("{FIELDSELECT")
+	sb.WriteString(" :arg ")
+	writeNode(sb, n.Arg)
+	sb.WriteString(fmt.Sprintf(" :fieldnum %d", n.Fieldnum))
+	sb.WriteString(fmt.Sprintf(" :resulttype %d", n.Resulttype))
+	sb.WriteString(fmt.Sprintf(" :resulttypmod %d", n.Resulttypmod))
+	sb.WriteString(fmt.Sprintf(" :resultcollid %d", n.Resultcollid))
+	sb.WriteString("}")
+}
+
+func writeFieldStore(sb *strings.Builder, n *FieldStore) {
+	sb.WriteString("{FIELDSTORE")
+	sb.WriteString(" :arg ")
+	writeNode(sb, n.Arg)
+	if n.Newvals != nil {
+		sb.WriteString(" :newvals ")
+		writeNode(sb, n.Newvals)
+	} else {
+		sb.WriteString(" :newvals <>")
+	}
+	if n.Fieldnums != nil {
+		sb.WriteString(" :fieldnums ")
+		writeNode(sb, n.Fieldnums)
+	} else {
+		sb.WriteString(" :fieldnums <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :resulttype %d", n.Resulttype))
+	sb.WriteString("}")
+}
+
+func writeRelabelType(sb *strings.Builder, n *RelabelType) {
+	sb.WriteString("{RELABELTYPE")
+	sb.WriteString(" :arg ")
+	writeNode(sb, n.Arg)
+	sb.WriteString(fmt.Sprintf(" :resulttype %d", n.Resulttype))
+	sb.WriteString(fmt.Sprintf(" :resulttypmod %d", n.Resulttypmod))
+	sb.WriteString(fmt.Sprintf(" :resultcollid %d", n.Resultcollid))
+	sb.WriteString(fmt.Sprintf(" :relabelformat %d", n.Relabelformat))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeCoerceViaIO(sb *strings.Builder, n *CoerceViaIO) {
+	sb.WriteString("{COERCEVIAIO")
+	sb.WriteString(" :arg ")
+	writeNode(sb, n.Arg)
+	sb.WriteString(fmt.Sprintf(" :resulttype %d", n.Resulttype))
+	sb.WriteString(fmt.Sprintf(" :resultcollid %d", n.Resultcollid))
+	sb.WriteString(fmt.Sprintf(" :coerceformat %d", n.Coerceformat))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeArrayCoerceExpr(sb *strings.Builder, n *ArrayCoerceExpr) {
+	sb.WriteString("{ARRAYCOERCEEXPR")
+	sb.WriteString(" :arg ")
+	writeNode(sb, n.Arg)
+	sb.WriteString(" :elemexpr ")
+	writeNode(sb, n.Elemexpr)
+	sb.WriteString(fmt.Sprintf(" :resulttype %d", n.Resulttype))
+	sb.WriteString(fmt.Sprintf(" :resulttypmod %d", n.Resulttypmod))
+	sb.WriteString(fmt.Sprintf(" :resultcollid %d", n.Resultcollid))
+	sb.WriteString(fmt.Sprintf(" :coerceformat %d", n.Coerceformat))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeConvertRowtypeExpr(sb *strings.Builder, n *ConvertRowtypeExpr) {
+	sb.WriteString("{CONVERTROWTYPEEXPR")
+	sb.WriteString(" :arg ")
+	writeNode(sb, n.Arg)
+	sb.WriteString(fmt.Sprintf(" :resulttype %d", n.Resulttype))
+	sb.WriteString(fmt.Sprintf(" :convertformat %d", n.Convertformat))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeCollateExpr(sb *strings.Builder, n *CollateExpr) {
+	sb.WriteString("{COLLATEEXPR")
+	sb.WriteString(" :arg ")
+	writeNode(sb, n.Arg)
+	sb.WriteString(fmt.Sprintf(" :collOid %d", n.CollOid))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeCaseExpr(sb *strings.Builder, n *CaseExpr) {
+	sb.WriteString("{CASEEXPR")
+	sb.WriteString(fmt.Sprintf(" :casetype %d", n.Casetype))
+	sb.WriteString(fmt.Sprintf(" :casecollid %d", n.Casecollid))
+	sb.WriteString(" :arg ")
+	writeNode(sb, n.Arg)
+	if n.Args != nil {
+		sb.WriteString(" :args ")
+		writeNode(sb, n.Args)
+	} else {
+		sb.WriteString(" :args <>")
+	}
+	sb.WriteString(" :defresult ")
+	writeNode(sb, n.Defresult)
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeCaseWhen(sb *strings.Builder, n *CaseWhen) {
+	sb.WriteString("{CASEWHEN")
+	sb.WriteString(" :expr ")
+	writeNode(sb, n.Expr)
+	sb.WriteString(" :result ")
+	writeNode(sb, n.Result)
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeCaseTestExpr(sb *strings.Builder, n *CaseTestExpr) {
+	sb.WriteString("{CASETESTEXPR")
+	sb.WriteString(fmt.Sprintf(" :typeId %d", n.TypeId))
+	sb.WriteString(fmt.Sprintf(" :typeMod %d", n.TypeMod))
+	sb.WriteString(fmt.Sprintf(" :collation %d", n.Collation))
+	sb.WriteString("}")
+}
+
+func writeArrayExpr(sb *strings.Builder, n *ArrayExpr) {
+	sb.WriteString("{ARRAYEXPR")
+	sb.WriteString(fmt.Sprintf(" :array_typeid %d", n.ArrayTypeid))
+	sb.WriteString(fmt.Sprintf(" :array_collid %d", n.ArrayCollid))
+	sb.WriteString(fmt.Sprintf(" :element_typeid %d", n.ElementTypeid))
+	if n.Elements != nil {
+		sb.WriteString(" :elements ")
+		writeNode(sb, n.Elements)
+	} else {
+		sb.WriteString(" :elements <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :multidims %t", n.Multidims))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeRowExpr(sb *strings.Builder, n *RowExpr) {
+	sb.WriteString("{ROWEXPR")
+	if n.Args != nil {
+		sb.WriteString(" :args ")
+		writeNode(sb, n.Args)
+	} else {
+		sb.WriteString(" :args <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :row_typeid %d", n.RowTypeid))
+	sb.WriteString(fmt.Sprintf(" :row_format %d", n.RowFormat))
+	if n.Colnames != nil {
+		sb.WriteString(" :colnames ")
+		writeNode(sb, n.Colnames)
+	} else {
+		sb.WriteString(" :colnames <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeRowCompareExpr(sb *strings.Builder, n *RowCompareExpr) {
+	sb.WriteString("{ROWCOMPAREEXPR")
+	sb.WriteString(fmt.Sprintf(" :rctype %d", n.Rctype))
+	if n.Opnos != nil {
+		sb.WriteString(" :opnos ")
+		writeNode(sb, n.Opnos)
+	} else {
+		sb.WriteString(" :opnos <>")
+	}
+	if n.Opfamilies != nil {
+		sb.WriteString(" :opfamilies ")
+		writeNode(sb, n.Opfamilies)
+	} else {
+		sb.WriteString(" :opfamilies <>")
+	}
+	if n.Inputcollids != nil {
+		sb.WriteString(" :inputcollids ")
+		writeNode(sb, n.Inputcollids)
+	} else {
+		sb.WriteString(" :inputcollids <>")
+	}
+	if n.Largs != nil {
+		sb.WriteString(" :largs ")
+		writeNode(sb, n.Largs)
+	} else {
+		sb.WriteString(" :largs <>")
+	}
+	if n.Rargs != nil {
+		sb.WriteString(" :rargs ")
+		writeNode(sb, n.Rargs)
+	} else {
+		sb.WriteString(" :rargs <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeCoalesceExpr(sb *strings.Builder, n *CoalesceExpr) {
+	sb.WriteString("{COALESCEEXPR")
+	sb.WriteString(fmt.Sprintf(" :coalescetype %d", n.Coalescetype))
+	sb.WriteString(fmt.Sprintf(" :coalescecollid %d", n.Coalescecollid))
+	if n.Args != nil {
+		sb.WriteString(" :args ")
+		writeNode(sb, n.Args)
+	} else {
+		sb.WriteString(" :args <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeMinMaxExpr(sb *strings.Builder, n *MinMaxExpr) {
+	sb.WriteString("{MINMAXEXPR")
+	sb.WriteString(fmt.Sprintf(" :minmaxtype %d", n.Minmaxtype))
+	sb.WriteString(fmt.Sprintf(" :minmaxcollid %d", n.Minmaxcollid))
+	sb.WriteString(fmt.Sprintf(" :inputcollid %d", n.Inputcollid))
+	sb.WriteString(fmt.Sprintf(" :op %d", n.Op))
+	if n.Args != nil {
+		sb.WriteString(" :args ")
+		writeNode(sb, n.Args)
+	} else {
+		sb.WriteString(" :args <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeSQLValueFunction(sb *strings.Builder, n *SQLValueFunction) {
+	sb.WriteString("{SQLVALUEFUNCTION")
+	sb.WriteString(fmt.Sprintf(" :op %d", n.Op))
+	sb.WriteString(fmt.Sprintf(" :type %d", n.Type))
+	sb.WriteString(fmt.Sprintf(" :typmod %d", n.Typmod))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeXmlExpr(sb *strings.Builder, n *XmlExpr) {
+	sb.WriteString("{XMLEXPR")
+	sb.WriteString(fmt.Sprintf(" :op %d", n.Op))
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	if n.NamedArgs != nil {
+		sb.WriteString(" :named_args ")
+		writeNode(sb, n.NamedArgs)
+	} else {
+		sb.WriteString(" :named_args <>")
+	}
+	if n.ArgNames != nil {
+		sb.WriteString(" :arg_names ")
+		writeNode(sb, n.ArgNames)
+	} else {
+		sb.WriteString(" :arg_names <>")
+	}
+	if n.Args != nil {
+		sb.WriteString(" :args ")
+		writeNode(sb, n.Args)
+	} else {
+		sb.WriteString(" :args <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :xmloption %d", n.Xmloption))
+	sb.WriteString(fmt.Sprintf(" :indent %t", n.Indent))
+	sb.WriteString(fmt.Sprintf(" :type %d", n.Type))
+	sb.WriteString(fmt.Sprintf(" :typmod %d", n.Typmod))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeJsonFormat(sb *strings.Builder, n *JsonFormat) {
+	sb.WriteString("{JSONFORMAT")
+	sb.WriteString(fmt.Sprintf(" :format_type %d", n.FormatType))
+	sb.WriteString(fmt.Sprintf(" :encoding %d", n.Encoding))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeJsonReturning(sb *strings.Builder, n *JsonReturning) {
+	sb.WriteString("{JSONRETURNING")
+	if n.Format != nil {
+		sb.WriteString(" :format ")
+		writeNode(sb, n.Format)
+	} else {
+		sb.WriteString(" :format <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :typid %d", n.Typid))
+	sb.WriteString(fmt.Sprintf(" :typmod %d", n.Typmod))
+	sb.WriteString("}")
+}
+
+func writeJsonValueExpr(sb *strings.Builder, n *JsonValueExpr) {
+	sb.WriteString("{JSONVALUEEXPR")
+	sb.WriteString(" :raw_expr ")
+	writeNode(sb, n.RawExpr)
+	sb.WriteString(" :formatted_expr ")
+	writeNode(sb, n.FormattedExpr)
+	if n.Format != nil {
+		sb.WriteString(" :format ")
+		writeNode(sb, n.Format)
+	} else {
+		sb.WriteString(" :format <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeJsonConstructorExpr(sb *strings.Builder, n *JsonConstructorExpr) {
+	sb.WriteString("{JSONCONSTRUCTOREXPR")
+	sb.WriteString(fmt.Sprintf(" :type %d", n.Type))
+	if n.Args != nil {
+		sb.WriteString(" :args ")
+		writeNode(sb, n.Args)
+	} else {
+		sb.WriteString(" :args <>")
+	}
+	sb.WriteString(" :func ")
+	writeNode(sb, n.Func)
+	sb.WriteString(" :coercion ")
+	writeNode(sb, n.Coercion)
+	if n.Returning != nil {
+		sb.WriteString(" :returning ")
+		writeNode(sb, n.Returning)
+	} else {
+		sb.WriteString(" :returning <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :absent_on_null %t", n.AbsentOnNull))
+	sb.WriteString(fmt.Sprintf(" :unique %t", n.Unique))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeJsonIsPredicate(sb *strings.Builder, n *JsonIsPredicate) {
+	sb.WriteString("{JSONISPREDICATE")
+	sb.WriteString(" :expr ")
+	writeNode(sb, n.Expr)
+	if n.Format != nil {
+		sb.WriteString(" :format ")
+		writeNode(sb, n.Format)
+	} else {
+		sb.WriteString(" :format <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :item_type %d", n.ItemType))
+	sb.WriteString(fmt.Sprintf(" :unique_keys %t", n.UniqueKeys))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeJsonBehavior(sb *strings.Builder, n *JsonBehavior) {
+	sb.WriteString("{JSONBEHAVIOR")
+	sb.WriteString(fmt.Sprintf(" :btype %d", n.Btype))
+	sb.WriteString(" :expr ")
+	writeNode(sb, n.Expr)
+	sb.WriteString(fmt.Sprintf(" :coerce %t", n.Coerce))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeJsonExpr(sb *strings.Builder, n *JsonExpr) {
+	sb.WriteString("{JSONEXPR")
+	sb.WriteString(fmt.Sprintf(" :op %d", n.Op))
+	if n.ColumnName != "" {
+		sb.WriteString(" :column_name \"" + escapeString(n.ColumnName) + "\"")
+	} else {
+		sb.WriteString(" :column_name <>")
+	}
+	sb.WriteString(" :formatted_expr ")
+	writeNode(sb, n.FormattedExpr)
+	if n.Format != nil {
+		sb.WriteString(" :format ")
+		writeNode(sb, n.Format)
+	} else {
+		sb.WriteString(" :format <>")
+	}
+	sb.WriteString(" :path_spec ")
+	writeNode(sb, n.PathSpec)
+	if n.Returning != nil {
+		sb.WriteString(" :returning ")
+		writeNode(sb, n.Returning)
+	} else {
+		sb.WriteString(" :returning <>")
+	}
+	if n.PassingNames != nil {
+		sb.WriteString(" :passing_names ")
+		writeNode(sb, n.PassingNames)
+	} else {
+		sb.WriteString(" :passing_names <>")
+	}
+	if n.PassingValues != nil {
+		sb.WriteString(" :passing_values ")
+		writeNode(sb, n.PassingValues)
+	} else {
+		sb.WriteString(" :passing_values <>")
+	}
+	if n.OnEmpty != nil {
+		sb.WriteString(" :on_empty ")
+		writeNode(sb, n.OnEmpty)
+	} else {
+		sb.WriteString(" :on_empty <>")
+	}
+	if n.OnError != nil {
+		sb.WriteString(" :on_error ")
+		writeNode(sb, n.OnError)
+	} else {
+		sb.WriteString(" :on_error <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :use_io_coercion %t", n.UseIoCoercion))
+	sb.WriteString(fmt.Sprintf(" :use_json_coercion %t", n.UseJsonCoercion))
+	sb.WriteString(fmt.Sprintf(" :wrapper %d", n.Wrapper))
+	sb.WriteString(fmt.Sprintf(" :omit_quotes %t", n.OmitQuotes))
+	sb.WriteString(fmt.Sprintf(" :collation %d", n.Collation))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeJsonTablePath(sb *strings.Builder, n *JsonTablePath) {
+	sb.WriteString("{JSONTABLEPATH")
+	if n.Value != nil {
+		sb.WriteString(" :value ")
+		writeNode(sb, n.Value)
+	} else {
+		sb.WriteString(" :value <>")
+	}
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeJsonTablePathScan(sb *strings.Builder, n *JsonTablePathScan) {
+	sb.WriteString("{JSONTABLEPATHSCAN")
+	if n.Path != nil {
+		sb.WriteString(" :path ")
+		writeNode(sb, n.Path)
+	} else {
+		sb.WriteString(" :path <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :errorOnError %t", n.ErrorOnError))
+	sb.WriteString(" :child ")
+	writeNode(sb, n.Child)
+	sb.WriteString(fmt.Sprintf(" :colMin %d", n.ColMin))
+	sb.WriteString(fmt.Sprintf(" :colMax %d", n.ColMax))
+	sb.WriteString("}")
+}
+
+func writeJsonTableSiblingJoin(sb *strings.Builder, n *JsonTableSiblingJoin) {
+	sb.WriteString("{JSONTABLESIBLINGJOIN")
+	sb.WriteString(" :lplan ")
+	writeNode(sb, n.Lplan)
+	sb.WriteString(" :rplan ")
+	writeNode(sb, n.Rplan)
+	sb.WriteString("}")
+}
+
+func writeBooleanTest(sb *strings.Builder, n *BooleanTest) {
+	sb.WriteString("{BOOLEANTEST")
+	sb.WriteString(" :arg ")
+	writeNode(sb, n.Arg)
+	sb.WriteString(fmt.Sprintf(" :booltesttype %d", n.Booltesttype))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeMergeAction(sb *strings.Builder, n *MergeAction) {
+	sb.WriteString("{MERGEACTION")
+	sb.WriteString(fmt.Sprintf(" :matchKind %d", n.MatchKind))
+	sb.WriteString(fmt.Sprintf(" :commandType %d", n.CommandType))
+	sb.WriteString(fmt.Sprintf(" :override %d", n.Override))
+	sb.WriteString(" :qual ")
+	writeNode(sb, n.Qual)
+	if n.TargetList != nil {
+		sb.WriteString(" :targetList ")
+		writeNode(sb, n.TargetList)
+	} else {
+		sb.WriteString(" :targetList <>")
+	}
+	if n.UpdateColnos != nil {
+		sb.WriteString(" :updateColnos ")
+		writeNode(sb, n.UpdateColnos)
+	} else {
+		sb.WriteString(" :updateColnos <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeCoerceToDomain(sb *strings.Builder, n *CoerceToDomain) {
+	sb.WriteString("{COERCETODOMAIN")
+	sb.WriteString(" :arg ")
+	writeNode(sb, n.Arg)
+	sb.WriteString(fmt.Sprintf(" :resulttype %d", n.Resulttype))
+	sb.WriteString(fmt.Sprintf(" :resulttypmod %d", n.Resulttypmod))
+	sb.WriteString(fmt.Sprintf(" :resultcollid %d", n.Resultcollid))
+	sb.WriteString(fmt.Sprintf(" :coercionformat %d", n.Coercionformat))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeCoerceToDomainValue(sb *strings.Builder, n *CoerceToDomainValue) {
+	sb.WriteString("{COERCETODOMAINVALUE")
+	sb.WriteString(fmt.Sprintf(" :typeId %d", n.TypeId))
+	sb.WriteString(fmt.Sprintf(" :typeMod %d", n.TypeMod))
+	sb.WriteString(fmt.Sprintf(" :collation %d", n.Collation))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeSetToDefault(sb *strings.Builder, n *SetToDefault) {
+	sb.WriteString("{SETTODEFAULT")
+	sb.WriteString(fmt.Sprintf(" :typeId %d", n.TypeId))
+	sb.WriteString(fmt.Sprintf(" :typeMod %d", n.TypeMod))
+	sb.WriteString(fmt.Sprintf(" :collation %d", n.Collation))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeCurrentOfExpr(sb *strings.Builder, n *CurrentOfExpr) {
+	sb.WriteString("{CURRENTOFEXPR")
+	sb.WriteString(fmt.Sprintf(" :cvarno %d", n.Cvarno))
+	if n.CursorName != "" {
+		sb.WriteString(" :cursor_name \"" + escapeString(n.CursorName) + "\"")
+	} else {
+		sb.WriteString(" :cursor_name <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :cursor_param %d", n.CursorParam))
+	sb.WriteString("}")
+}
+
+func writeNextValueExpr(sb *strings.Builder, n *NextValueExpr) {
+	sb.WriteString("{NEXTVALUEEXPR")
+	sb.WriteString(fmt.Sprintf(" :seqid %d", n.Seqid))
+	sb.WriteString(fmt.Sprintf(" :typeId %d", n.TypeId))
+	sb.WriteString("}")
+}
+
+func writeInferenceElem(sb *strings.Builder, n *InferenceElem) {
+	sb.WriteString("{INFERENCEELEM")
+	sb.WriteString(" :expr ")
+	writeNode(sb, n.Expr)
+	sb.WriteString(fmt.Sprintf(" :infercollid %d", n.Infercollid))
+	sb.WriteString(fmt.Sprintf(" :inferopclass %d", n.Inferopclass))
+	sb.WriteString("}")
+}
+
+func writeTargetEntry(sb *strings.Builder, n *TargetEntry) {
+	sb.WriteString("{TARGETENTRY")
+	sb.WriteString(" :expr ")
+	writeNode(sb, n.Expr)
+	sb.WriteString(fmt.Sprintf(" :resno %d", n.Resno))
+	if n.Resname != "" {
+		sb.WriteString(" :resname \"" + escapeString(n.Resname) + "\"")
+	} else {
+		sb.WriteString(" :resname <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :ressortgroupref %d", n.Ressortgroupref))
+	sb.WriteString(fmt.Sprintf(" :resorigtbl %d", n.Resorigtbl))
+	sb.WriteString(fmt.Sprintf(" :resorigcol %d", n.Resorigcol))
+	sb.WriteString(fmt.Sprintf(" :resjunk %t", n.Resjunk))
+	sb.WriteString("}")
+}
+
+func writeRangeTblRef(sb *strings.Builder, n *RangeTblRef) {
+	sb.WriteString("{RANGETBLREF")
+	sb.WriteString(fmt.Sprintf(" :rtindex %d", n.Rtindex))
+	sb.WriteString("}")
+}
+
+func writeFromExpr(sb *strings.Builder, n *FromExpr) {
+	sb.WriteString("{FROMEXPR")
+	if n.Fromlist != nil {
+		sb.WriteString(" :fromlist ")
+		writeNode(sb, n.Fromlist)
+	} else {
+		sb.WriteString(" :fromlist <>")
+	}
+	sb.WriteString(" :quals ")
+	writeNode(sb, n.Quals)
+	sb.WriteString("}")
+}
+
+func writeOnConflictExpr(sb *strings.Builder, n *OnConflictExpr) {
+	sb.WriteString("{ONCONFLICTEXPR")
+	sb.WriteString(fmt.Sprintf(" :action %d", n.Action))
+	if n.ArbiterElems != nil {
+		sb.WriteString(" :arbiterElems ")
+		writeNode(sb, n.ArbiterElems)
+	} else {
+		sb.WriteString(" :arbiterElems <>")
+	}
+	sb.WriteString(" :arbiterWhere ")
+	writeNode(sb, n.ArbiterWhere)
+	sb.WriteString(fmt.Sprintf(" :constraint %d", n.Constraint))
+	if n.OnConflictSet != nil {
+		sb.WriteString(" :onConflictSet ")
+		writeNode(sb, n.OnConflictSet)
+	} else {
+		sb.WriteString(" :onConflictSet <>")
+	}
+	sb.WriteString(" :onConflictWhere ")
+	writeNode(sb, n.OnConflictWhere)
+	sb.WriteString(fmt.Sprintf(" :exclRelIndex %d", n.ExclRelIndex))
+	if n.ExclRelTlist != nil {
+		sb.WriteString(" :exclRelTlist ")
+		writeNode(sb, n.ExclRelTlist)
+	} else {
+		sb.WriteString(" :exclRelTlist <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeQuery(sb *strings.Builder, n *Query) {
+	sb.WriteString("{QUERY")
+	sb.WriteString(fmt.Sprintf(" :commandType %d", n.CommandType))
+	sb.WriteString(fmt.Sprintf(" :querySource %d", n.QuerySource))
+	sb.WriteString(fmt.Sprintf(" :queryId %d", n.QueryId))
+	sb.WriteString(fmt.Sprintf(" :canSetTag %t", n.CanSetTag))
+	sb.WriteString(" :utilityStmt ")
+	writeNode(sb, n.UtilityStmt)
+	sb.WriteString(fmt.Sprintf(" :resultRelation %d", n.ResultRelation))
+	sb.WriteString(fmt.Sprintf(" :hasAggs %t", n.HasAggs))
+	sb.WriteString(fmt.Sprintf(" :hasWindowFuncs %t", n.HasWindowFuncs))
+	sb.WriteString(fmt.Sprintf(" :hasTargetSRFs %t", n.HasTargetSRFs))
+	sb.WriteString(fmt.Sprintf(" :hasSubLinks %t", n.HasSubLinks))
+	sb.WriteString(fmt.Sprintf(" :hasDistinctOn %t", n.HasDistinctOn))
+	sb.WriteString(fmt.Sprintf(" :hasRecursive %t", n.HasRecursive))
+	sb.WriteString(fmt.Sprintf(" :hasModifyingCTE %t", n.HasModifyingCTE))
+	sb.WriteString(fmt.Sprintf(" :hasForUpdate %t", n.HasForUpdate))
+	sb.WriteString(fmt.Sprintf(" :hasRowSecurity %t", n.HasRowSecurity))
+	sb.WriteString(fmt.Sprintf(" :isReturn %t", n.IsReturn))
+	if n.CteList != nil {
+		sb.WriteString(" :cteList ")
+		writeNode(sb, n.CteList)
+	} else {
+		sb.WriteString(" :cteList <>")
+	}
+	if n.Rtable != nil {
+		sb.WriteString(" :rtable ")
+		writeNode(sb, n.Rtable)
+	} else {
+		sb.WriteString(" :rtable <>")
+	}
+	if n.Rteperminfos != nil {
+		sb.WriteString(" :rteperminfos ")
+		writeNode(sb, n.Rteperminfos)
+	} else {
+		sb.WriteString(" :rteperminfos <>")
+	}
+	if n.Jointree != nil {
+		sb.WriteString(" :jointree ")
+		writeNode(sb, n.Jointree)
+	} else {
+		sb.WriteString(" :jointree <>")
+	}
+	if n.MergeActionList != nil {
+		sb.WriteString(" :mergeActionList ")
+		writeNode(sb, n.MergeActionList)
+	} else {
+		sb.WriteString(" :mergeActionList <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :mergeTargetRelation %d", n.MergeTargetRelation))
+	sb.WriteString(" :mergeJoinCondition ")
+	writeNode(sb, n.MergeJoinCondition)
+	if n.TargetList != nil {
+		sb.WriteString(" :targetList ")
+		writeNode(sb, n.TargetList)
+	} else {
+		sb.WriteString(" :targetList <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :override %d", n.Override))
+	if n.OnConflict != nil {
+		sb.WriteString(" :onConflict ")
+		writeNode(sb, n.OnConflict)
+	} else {
+		sb.WriteString(" :onConflict <>")
+	}
+	if n.ReturningList != nil {
+		sb.WriteString(" :returningList ")
+		writeNode(sb, n.ReturningList)
+	} else {
+		sb.WriteString(" :returningList <>")
+	}
+	if n.GroupClause != nil {
+		sb.WriteString(" :groupClause ")
+		writeNode(sb, n.GroupClause)
+	} else {
+		sb.WriteString(" :groupClause <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :groupDistinct %t", n.GroupDistinct))
+	if n.GroupingSets != nil {
+		sb.WriteString(" :groupingSets ")
+		writeNode(sb, n.GroupingSets)
+	} else {
+		sb.WriteString(" :groupingSets <>")
+	}
+	sb.WriteString(" :havingQual ")
+	writeNode(sb, n.HavingQual)
+	if n.WindowClause != nil {
+		sb.WriteString(" :windowClause ")
+		writeNode(sb, n.WindowClause)
+	} else {
+		sb.WriteString(" :windowClause <>")
+	}
+	if n.DistinctClause != nil {
+		sb.WriteString(" :distinctClause ")
+		writeNode(sb, n.DistinctClause)
+	} else {
+		sb.WriteString(" :distinctClause <>")
+	}
+	if n.SortClause != nil {
+		sb.WriteString(" :sortClause ")
+		writeNode(sb, n.SortClause)
+	} else {
+		sb.WriteString(" :sortClause <>")
+	}
+	sb.WriteString(" :limitOffset ")
+	writeNode(sb, n.LimitOffset)
+	sb.WriteString(" :limitCount ")
+	writeNode(sb, n.LimitCount)
+	sb.WriteString(fmt.Sprintf(" :limitOption %d", n.LimitOption))
+	if n.RowMarks != nil {
+		sb.WriteString(" :rowMarks ")
+		writeNode(sb, n.RowMarks)
+	} else {
+		sb.WriteString(" :rowMarks <>")
+	}
+	sb.WriteString(" :setOperations ")
+	writeNode(sb, n.SetOperations)
+	if n.ConstraintDeps != nil {
+		sb.WriteString(" :constraintDeps ")
+		writeNode(sb, n.ConstraintDeps)
+	} else {
+		sb.WriteString(" :constraintDeps <>")
+	}
+	if n.WithCheckOptions != nil {
+		sb.WriteString(" :withCheckOptions ")
+		writeNode(sb, n.WithCheckOptions)
+	} else {
+		sb.WriteString(" :withCheckOptions <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :stmt_location %d", n.StmtLocation))
+	sb.WriteString(fmt.Sprintf(" :stmt_len %d", n.StmtLen))
+	sb.WriteString("}")
+}
+
+func writeParamRef(sb *strings.Builder, n *ParamRef) {
+	sb.WriteString("{PARAMREF")
+	sb.WriteString(fmt.Sprintf(" :number %d", n.Number))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeCollateClause(sb *strings.Builder, n *CollateClause) {
+	sb.WriteString("{COLLATECLAUSE")
+	sb.WriteString(" :arg ")
+	writeNode(sb, n.Arg)
+	if n.Collname != nil {
+		sb.WriteString(" :collname ")
+		writeNode(sb, n.Collname)
+	} else {
+		sb.WriteString(" :collname <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeRoleSpec(sb *strings.Builder, n *RoleSpec) {
+	sb.WriteString("{ROLESPEC")
+	sb.WriteString(fmt.Sprintf(" :roletype %d", n.Roletype))
+	if n.Rolename != "" {
+		sb.WriteString(" :rolename \"" + escapeString(n.Rolename) + "\"")
+	} else {
+		sb.WriteString(" :rolename <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeA_Indices(sb *strings.Builder, n *A_Indices) {
+	sb.WriteString("{A_INDICES")
+	sb.WriteString(fmt.Sprintf(" :is_slice %t", n.IsSlice))
+	sb.WriteString(" :lidx ")
+	writeNode(sb, n.Lidx)
+	sb.WriteString(" :uidx ")
+	writeNode(sb, n.Uidx)
+	sb.WriteString("}")
+}
+
+func writeA_Indirection(sb *strings.Builder, n *A_Indirection) {
+	sb.WriteString("{A_INDIRECTION")
+	sb.WriteString(" :arg ")
+	writeNode(sb, n.Arg)
+	if n.Indirection != nil {
+		sb.WriteString(" :indirection ")
+		writeNode(sb, n.Indirection)
+	} else {
+		sb.WriteString(" :indirection <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeA_ArrayExpr(sb *strings.Builder, n *A_ArrayExpr) {
+	sb.WriteString("{A_ARRAYEXPR")
+	if n.Elements != nil {
+		sb.WriteString(" :elements ")
+		writeNode(sb, n.Elements)
+	} else {
+		sb.WriteString(" :elements <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeMultiAssignRef(sb *strings.Builder, n *MultiAssignRef) {
+	sb.WriteString("{MULTIASSIGNREF")
+	sb.WriteString(" :source ")
+	writeNode(sb, n.Source)
+	sb.WriteString(fmt.Sprintf(" :colno %d", n.Colno))
+	sb.WriteString(fmt.Sprintf(" :ncolumns %d", n.Ncolumns))
+	sb.WriteString("}")
+}
+
+func writeWindowDef(sb *strings.Builder, n *WindowDef) {
+	sb.WriteString("{WINDOWDEF")
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	if n.Refname != "" {
+		sb.WriteString(" :refname \"" + escapeString(n.Refname) + "\"")
+	} else {
+		sb.WriteString(" :refname <>")
+	}
+	if n.PartitionClause != nil {
+		sb.WriteString(" :partitionClause ")
+		writeNode(sb, n.PartitionClause)
+	} else {
+		sb.WriteString(" :partitionClause <>")
+	}
+	if n.OrderClause != nil {
+		sb.WriteString(" :orderClause ")
+		writeNode(sb, n.OrderClause)
+	} else {
+		sb.WriteString(" :orderClause <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :frameOptions %d", n.FrameOptions))
+	sb.WriteString(" :startOffset ")
+	writeNode(sb, n.StartOffset)
+	sb.WriteString(" :endOffset ")
+	writeNode(sb, n.EndOffset)
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeRangeSubselect(sb *strings.Builder, n *RangeSubselect) {
+	sb.WriteString("{RANGESUBSELECT")
+	sb.WriteString(fmt.Sprintf(" :lateral %t", n.Lateral))
+	sb.WriteString(" :subquery ")
+	writeNode(sb, n.Subquery)
+	if n.Alias != nil {
+		sb.WriteString(" :alias ")
+		writeNode(sb, n.Alias)
+	} else {
+		sb.WriteString(" :alias <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeRangeFunction(sb *strings.Builder, n *RangeFunction) {
+	sb.WriteString("{RANGEFUNCTION")
+	sb.WriteString(fmt.Sprintf(" :lateral %t", n.Lateral))
+	sb.WriteString(fmt.Sprintf(" :ordinality %t", n.Ordinality))
+	sb.WriteString(fmt.Sprintf(" :is_rowsfrom %t", n.IsRowsfrom))
+	if n.Functions != nil {
+		sb.WriteString(" :functions ")
+		writeNode(sb, n.Functions)
+	} else {
+		sb.WriteString(" :functions <>")
+	}
+	if n.Alias != nil {
+		sb.WriteString(" :alias ")
+		writeNode(sb, n.Alias)
+	} else {
+		sb.WriteString(" :alias <>")
+	}
+	if n.Coldeflist != nil {
+		sb.WriteString(" :coldeflist ")
+		writeNode(sb, n.Coldeflist)
+	} else {
+		sb.WriteString(" :coldeflist <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeRangeTableFunc(sb *strings.Builder, n *RangeTableFunc) {
+	sb.WriteString("{RANGETABLEFUNC")
+	sb.WriteString(fmt.Sprintf(" :lateral %t", n.Lateral))
+	sb.WriteString(" :docexpr ")
+	writeNode(sb, n.Docexpr)
+	sb.WriteString(" :rowexpr ")
+	writeNode(sb, n.Rowexpr)
+	if n.Namespaces != nil {
+		sb.WriteString(" :namespaces ")
+		writeNode(sb, n.Namespaces)
+	} else {
+		sb.WriteString(" :namespaces <>")
+	}
+	if n.Columns != nil {
+		sb.WriteString(" :columns ")
+		writeNode(sb, n.Columns)
+	} else {
+		sb.WriteString(" :columns <>")
+	}
+	if n.Alias != nil {
+		sb.WriteString(" :alias ")
+		writeNode(sb, n.Alias)
+	} else {
+		sb.WriteString(" :alias <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeRangeTableFuncCol(sb *strings.Builder, n *RangeTableFuncCol) {
+	sb.WriteString("{RANGETABLEFUNCCOL")
+	if n.Colname != "" {
+		sb.WriteString(" :colname \"" + escapeString(n.Colname) + "\"")
+	} else {
+		sb.WriteString(" :colname <>")
+	}
+	if n.TypeName != nil {
+		sb.WriteString(" :typeName ")
+		writeNode(sb, n.TypeName)
+	} else {
+		sb.WriteString(" :typeName <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :for_ordinality %t", n.ForOrdinality))
+	sb.WriteString(fmt.Sprintf(" :is_not_null %t", n.IsNotNull))
+	sb.WriteString(" :colexpr ")
+	writeNode(sb, n.Colexpr)
+	sb.WriteString(" :coldefexpr ")
+	writeNode(sb, n.Coldefexpr)
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeRangeTableSample(sb *strings.Builder, n *RangeTableSample) {
+	sb.WriteString("{RANGETABLESAMPLE")
+	sb.WriteString(" :relation ")
+	writeNode(sb, n.Relation)
+	if n.Method != nil {
+		sb.WriteString(" :method ")
+		writeNode(sb, n.Method)
+	} else {
+		sb.WriteString(" :method <>")
+	}
+	if n.Args != nil {
+		sb.WriteString(" :args ")
+		writeNode(sb, n.Args)
+	} else {
+		sb.WriteString(" :args <>")
+	}
+	sb.WriteString(" :repeatable ")
+	writeNode(sb, n.Repeatable)
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeTableLikeClause(sb *strings.Builder, n *TableLikeClause) {
+	sb.WriteString("{TABLELIKECLAUSE")
+	if n.Relation != nil {
+		sb.WriteString(" :relation ")
+		writeNode(sb, n.Relation)
+	} else {
+		sb.WriteString(" :relation <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :options %d", n.Options))
+	sb.WriteString(fmt.Sprintf(" :relationOid %d", n.RelationOid))
+	sb.WriteString("}")
+}
+
+func writeIndexElem(sb *strings.Builder, n *IndexElem) {
+	sb.WriteString("{INDEXELEM")
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	sb.WriteString(" :expr ")
+	writeNode(sb, n.Expr)
+	if n.Indexcolname != "" {
+		sb.WriteString(" :indexcolname \"" + escapeString(n.Indexcolname) + "\"")
+	} else {
+		sb.WriteString(" :indexcolname <>")
+	}
+	if n.Collation != nil {
+		sb.WriteString(" :collation ")
+		writeNode(sb, n.Collation)
+	} else {
+		sb.WriteString(" :collation <>")
+	}
+	if n.Opclass != nil {
+		sb.WriteString(" :opclass ")
+		writeNode(sb, n.Opclass)
+	} else {
+		sb.WriteString(" :opclass <>")
+	}
+	if n.Opclassopts != nil {
+		sb.WriteString(" :opclassopts ")
+		writeNode(sb, n.Opclassopts)
+	} else {
+		sb.WriteString(" :opclassopts <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :ordering %d", n.Ordering))
+	sb.WriteString(fmt.Sprintf(" :nulls_ordering %d", n.NullsOrdering))
+	sb.WriteString("}")
+}
+
+func writeDefElem(sb *strings.Builder, n *DefElem) {
+	sb.WriteString("{DEFELEM")
+	if n.Defnamespace != "" {
+		sb.WriteString(" :defnamespace \"" + escapeString(n.Defnamespace) + "\"")
+	} else {
+		sb.WriteString(" :defnamespace <>")
+	}
+	if n.Defname != "" {
+		sb.WriteString(" :defname \"" + escapeString(n.Defname) + "\"")
+	} else {
+		sb.WriteString(" :defname <>")
+	}
+	sb.WriteString(" :arg ")
+	writeNode(sb, n.Arg)
+	sb.WriteString(fmt.Sprintf(" :defaction %d", n.Defaction))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeLockingClause(sb *strings.Builder, n *LockingClause) {
+	sb.WriteString("{LOCKINGCLAUSE")
+	if n.LockedRels != nil {
+		sb.WriteString(" :lockedRels ")
+		writeNode(sb, n.LockedRels)
+	} else {
+		sb.WriteString(" :lockedRels <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :strength %d", n.Strength))
+	sb.WriteString(fmt.Sprintf(" :waitPolicy %d", n.WaitPolicy))
+	sb.WriteString("}")
+}
+
+func writeXmlSerialize(sb *strings.Builder, n *XmlSerialize) {
+	sb.WriteString("{XMLSERIALIZE")
+	sb.WriteString(fmt.Sprintf(" :xmloption %d", n.Xmloption))
+	sb.WriteString(" :expr ")
+	writeNode(sb, n.Expr)
+	if n.TypeName != nil {
+		sb.WriteString(" :typeName ")
+		writeNode(sb, n.TypeName)
+	} else {
+		sb.WriteString(" :typeName <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :indent %t", n.Indent))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writePartitionElem(sb *strings.Builder, n *PartitionElem) {
+	sb.WriteString("{PARTITIONELEM")
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	sb.WriteString(" :expr ")
+	writeNode(sb, n.Expr)
+	if n.Collation != nil {
+		sb.WriteString(" :collation ")
+		writeNode(sb, n.Collation)
+	} else {
+		sb.WriteString(" :collation <>")
+	}
+	if n.Opclass != nil {
+		sb.WriteString(" :opclass ")
+		writeNode(sb, n.Opclass)
+	} else {
+		sb.WriteString(" :opclass <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writePartitionSpec(sb *strings.Builder, n *PartitionSpec) {
+	sb.WriteString("{PARTITIONSPEC")
+	sb.WriteString(fmt.Sprintf(" :strategy %d", n.Strategy))
+	if n.PartParams != nil {
+		sb.WriteString(" :partParams ")
+		writeNode(sb, n.PartParams)
+	} else {
+		sb.WriteString(" :partParams <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writePartitionBoundSpec(sb *strings.Builder, n *PartitionBoundSpec) {
+	sb.WriteString("{PARTITIONBOUNDSPEC")
+	writeCharField(sb, " :strategy", n.Strategy)
+	sb.WriteString(fmt.Sprintf(" :is_default %t", n.IsDefault))
+	sb.WriteString(fmt.Sprintf(" :modulus %d", n.Modulus))
+	sb.WriteString(fmt.Sprintf(" :remainder %d", n.Remainder))
+	if n.Listdatums != nil {
+		sb.WriteString(" :listdatums ")
+		writeNode(sb, n.Listdatums)
+	} else {
+		sb.WriteString(" :listdatums <>")
+	}
+	if n.Lowerdatums != nil {
+		sb.WriteString(" :lowerdatums ")
+		writeNode(sb, n.Lowerdatums)
+	} else {
+		sb.WriteString(" :lowerdatums <>")
+	}
+	if n.Upperdatums != nil {
+		sb.WriteString(" :upperdatums ")
+		writeNode(sb, n.Upperdatums)
+	} else {
+		sb.WriteString(" :upperdatums <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writePartitionRangeDatum(sb *strings.Builder, n *PartitionRangeDatum) {
+	sb.WriteString("{PARTITIONRANGEDATUM")
+	sb.WriteString(fmt.Sprintf(" :kind %d", n.Kind))
+	sb.WriteString(" :value ")
+	writeNode(sb, n.Value)
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeSinglePartitionSpec(sb *strings.Builder, n *SinglePartitionSpec) {
+	sb.WriteString("{SINGLEPARTITIONSPEC")
+	sb.WriteString("}")
+}
+
+func writePartitionCmd(sb *strings.Builder, n *PartitionCmd) {
+	sb.WriteString("{PARTITIONCMD")
+	if n.Name != nil {
+		sb.WriteString(" :name ")
+		writeNode(sb, n.Name)
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	if n.Bound != nil {
+		sb.WriteString(" :bound ")
+		writeNode(sb, n.Bound)
+	} else {
+		sb.WriteString(" :bound <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :concurrent %t", n.Concurrent))
+	sb.WriteString("}")
+}
+
+func writeRangeTblEntry(sb *strings.Builder, n *RangeTblEntry) {
+	sb.WriteString("{RANGETBLENTRY")
+	if n.Alias != nil {
+		sb.WriteString(" :alias ")
+		writeNode(sb, n.Alias)
+	} else {
+		sb.WriteString(" :alias <>")
+	}
+	if n.Eref != nil {
+		sb.WriteString(" :eref ")
+		writeNode(sb, n.Eref)
+	} else {
+		sb.WriteString(" :eref <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :rtekind %d", n.Rtekind))
+	sb.WriteString(fmt.Sprintf(" :relid %d", n.Relid))
+	sb.WriteString(fmt.Sprintf(" :inh %t", n.Inh))
+	writeCharField(sb, " :relkind", n.Relkind)
+	sb.WriteString(fmt.Sprintf(" :rellockmode %d", n.Rellockmode))
+	sb.WriteString(fmt.Sprintf(" :perminfoindex %d", n.Perminfoindex))
+	if n.Tablesample != nil {
+		sb.WriteString(" :tablesample ")
+		writeNode(sb, n.Tablesample)
+	} else {
+		sb.WriteString(" :tablesample <>")
+	}
+	if n.Subquery != nil {
+		sb.WriteString(" :subquery ")
+		writeNode(sb, n.Subquery)
+	} else {
+		sb.WriteString(" :subquery <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :security_barrier %t", n.SecurityBarrier))
+	sb.WriteString(fmt.Sprintf(" :jointype %d", n.Jointype))
+	sb.WriteString(fmt.Sprintf(" :joinmergedcols %d", n.Joinmergedcols))
+	if n.Joinaliasvars != nil {
+		sb.WriteString(" :joinaliasvars ")
+		writeNode(sb, n.Joinaliasvars)
+	} else {
+		sb.WriteString(" :joinaliasvars <>")
+	}
+	if n.Joinleftcols != nil {
+		sb.WriteString(" :joinleftcols ")
+		writeNode(sb, n.Joinleftcols)
+	} else {
+		sb.WriteString(" :joinleftcols <>")
+	}
+	if n.Joinrightcols != nil {
+		sb.WriteString(" :joinrightcols ")
+		writeNode(sb, n.Joinrightcols)
+	} else {
+		sb.WriteString(" :joinrightcols <>")
+	}
+	if n.JoinUsingAlias != nil {
+		sb.WriteString(" :join_using_alias ")
+		writeNode(sb, n.JoinUsingAlias)
+	} else {
+		sb.WriteString(" :join_using_alias <>")
+	}
+	if n.Functions != nil {
+		sb.WriteString(" :functions ")
+		writeNode(sb, n.Functions)
+	} else {
+		sb.WriteString(" :functions <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :funcordinality %t", n.Funcordinality))
+	if n.Tablefunc != nil {
+		sb.WriteString(" :tablefunc ")
+		writeNode(sb, n.Tablefunc)
+	} else {
+		sb.WriteString(" :tablefunc <>")
+	}
+	if n.ValuesLists != nil {
+		sb.WriteString(" :values_lists ")
+		writeNode(sb, n.ValuesLists)
+	} else {
+		sb.WriteString(" :values_lists <>")
+	}
+	if n.Ctename != "" {
+		sb.WriteString(" :ctename \"" + escapeString(n.Ctename) + "\"")
+	} else {
+		sb.WriteString(" :ctename <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :ctelevelsup %d", n.Ctelevelsup))
+	sb.WriteString(fmt.Sprintf(" :self_reference %t", n.SelfReference))
+	if n.Coltypes != nil {
+		sb.WriteString(" :coltypes ")
+		writeNode(sb, n.Coltypes)
+	} else {
+		sb.WriteString(" :coltypes <>")
+	}
+	if n.Coltypmods != nil {
+		sb.WriteString(" :coltypmods ")
+		writeNode(sb, n.Coltypmods)
+	} else {
+		sb.WriteString(" :coltypmods <>")
+	}
+	if n.Colcollations != nil {
+		sb.WriteString(" :colcollations ")
+		writeNode(sb, n.Colcollations)
+	} else {
+		sb.WriteString(" :colcollations <>")
+	}
+	if n.Enrname != "" {
+		sb.WriteString(" :enrname \"" + escapeString(n.Enrname) + "\"")
+	} else {
+		sb.WriteString(" :enrname <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :enrtuples %g", n.Enrtuples))
+	sb.WriteString(fmt.Sprintf(" :lateral %t", n.Lateral))
+	sb.WriteString(fmt.Sprintf(" :inFromCl %t", n.InFromCl))
+	if n.SecurityQuals != nil {
+		sb.WriteString(" :securityQuals ")
+		writeNode(sb, n.SecurityQuals)
+	} else {
+		sb.WriteString(" :securityQuals <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeRTEPermissionInfo(sb *strings.Builder, n *RTEPermissionInfo) {
+	sb.WriteString("{RTEPERMISSIONINFO")
+	sb.WriteString(fmt.Sprintf(" :relid %d", n.Relid))
+	sb.WriteString(fmt.Sprintf(" :inh %t", n.Inh))
+	sb.WriteString(fmt.Sprintf(" :requiredPerms %d", n.RequiredPerms))
+	sb.WriteString(fmt.Sprintf(" :checkAsUser %d", n.CheckAsUser))
+	sb.WriteString(" :selectedCols ")
+	writeBitmapset(sb, n.SelectedCols)
+	sb.WriteString(" :insertedCols ")
+	writeBitmapset(sb, n.InsertedCols)
+	sb.WriteString(" :updatedCols ")
+	writeBitmapset(sb, n.UpdatedCols)
+	sb.WriteString("}")
+}
+
+func writeRangeTblFunction(sb *strings.Builder, n *RangeTblFunction) {
+	sb.WriteString("{RANGETBLFUNCTION")
+	sb.WriteString(" :funcexpr ")
+	writeNode(sb, n.Funcexpr)
+	sb.WriteString(fmt.Sprintf(" :funccolcount %d", n.Funccolcount))
+	if n.Funccolnames != nil {
+		sb.WriteString(" :funccolnames ")
+		writeNode(sb, n.Funccolnames)
+	} else {
+		sb.WriteString(" :funccolnames <>")
+	}
+	if n.Funccoltypes != nil {
+		sb.WriteString(" :funccoltypes ")
+		writeNode(sb, n.Funccoltypes)
+	} else {
+		sb.WriteString(" :funccoltypes <>")
+	}
+	if n.Funccoltypmods != nil {
+		sb.WriteString(" :funccoltypmods ")
+		writeNode(sb, n.Funccoltypmods)
+	} else {
+		sb.WriteString(" :funccoltypmods <>")
+	}
+	if n.Funccolcollations != nil {
+		sb.WriteString(" :funccolcollations ")
+		writeNode(sb, n.Funccolcollations)
+	} else {
+		sb.WriteString(" :funccolcollations <>")
+	}
+	sb.WriteString(" :funcparams ")
+	writeBitmapset(sb, n.Funcparams)
+	sb.WriteString("}")
+}
+
+func writeTableSampleClause(sb *strings.Builder, n *TableSampleClause) {
+	sb.WriteString("{TABLESAMPLECLAUSE")
+	sb.WriteString(fmt.Sprintf(" :tsmhandler %d", n.Tsmhandler))
+	if n.Args != nil {
+		sb.WriteString(" :args ")
+		writeNode(sb, n.Args)
+	} else {
+		sb.WriteString(" :args <>")
+	}
+	sb.WriteString(" :repeatable ")
+	writeNode(sb, n.Repeatable)
+	sb.WriteString("}")
+}
+
+func writeWithCheckOption(sb *strings.Builder, n *WithCheckOption) {
+	sb.WriteString("{WITHCHECKOPTION")
+	sb.WriteString(fmt.Sprintf(" :kind %d", n.Kind))
+	if n.Relname != "" {
+		sb.WriteString(" :relname \"" + escapeString(n.Relname) + "\"")
+	} else {
+		sb.WriteString(" :relname <>")
+	}
+	if n.Polname != "" {
+		sb.WriteString(" :polname \"" + escapeString(n.Polname) + "\"")
+	} else {
+		sb.WriteString(" :polname <>")
+	}
+	sb.WriteString(" :qual ")
+	writeNode(sb, n.Qual)
+	sb.WriteString(fmt.Sprintf(" :cascaded %t", n.Cascaded))
+	sb.WriteString("}")
+}
+
+func writeSortGroupClause(sb *strings.Builder, n *SortGroupClause) {
+	sb.WriteString("{SORTGROUPCLAUSE")
+	sb.WriteString(fmt.Sprintf(" :tleSortGroupRef %d", n.TleSortGroupRef))
+	sb.WriteString(fmt.Sprintf(" :eqop %d", n.Eqop))
+	sb.WriteString(fmt.Sprintf(" :sortop %d", n.Sortop))
+	sb.WriteString(fmt.Sprintf(" :nulls_first %t", n.NullsFirst))
+	sb.WriteString(fmt.Sprintf(" :hashable %t", n.Hashable))
+	sb.WriteString("}")
+}
+
+func writeGroupingSet(sb *strings.Builder, n *GroupingSet) {
+	sb.WriteString("{GROUPINGSET")
+	sb.WriteString(fmt.Sprintf(" :kind %d", n.Kind))
+	if n.Content != nil {
+		sb.WriteString(" :content ")
+		writeNode(sb, n.Content)
+	} else {
+		sb.WriteString(" :content <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeWindowClause(sb *strings.Builder, n *WindowClause) {
+	sb.WriteString("{WINDOWCLAUSE")
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	if n.Refname != "" {
+		sb.WriteString(" :refname \"" + escapeString(n.Refname) + "\"")
+	} else {
+		sb.WriteString(" :refname <>")
+	}
+	if n.PartitionClause != nil {
+		sb.WriteString(" :partitionClause ")
+		writeNode(sb, n.PartitionClause)
+	} else {
+		sb.WriteString(" :partitionClause <>")
+	}
+	if n.OrderClause != nil {
+		sb.WriteString(" :orderClause ")
+		writeNode(sb, n.OrderClause)
+	} else {
+		sb.WriteString(" :orderClause <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :frameOptions %d", n.FrameOptions))
+	sb.WriteString(" :startOffset ")
+	writeNode(sb, n.StartOffset)
+	sb.WriteString(" :endOffset ")
+	writeNode(sb, n.EndOffset)
+	sb.WriteString(fmt.Sprintf(" :startInRangeFunc %d", n.StartInRangeFunc))
+	sb.WriteString(fmt.Sprintf(" :endInRangeFunc %d", n.EndInRangeFunc))
+	sb.WriteString(fmt.Sprintf(" :inRangeColl %d", n.InRangeColl))
+	sb.WriteString(fmt.Sprintf(" :inRangeAsc %t", n.InRangeAsc))
+	sb.WriteString(fmt.Sprintf(" :inRangeNullsFirst %t", n.InRangeNullsFirst))
+	sb.WriteString(fmt.Sprintf(" :winref %d", n.Winref))
+	sb.WriteString(fmt.Sprintf(" :copiedOrder %t", n.CopiedOrder))
+	sb.WriteString("}")
+}
+
+func writeRowMarkClause(sb *strings.Builder, n *RowMarkClause) {
+	sb.WriteString("{ROWMARKCLAUSE")
+	sb.WriteString(fmt.Sprintf(" :rti %d", n.Rti))
+	sb.WriteString(fmt.Sprintf(" :strength %d", n.Strength))
+	sb.WriteString(fmt.Sprintf(" :waitPolicy %d", n.WaitPolicy))
+	sb.WriteString(fmt.Sprintf(" :pushedDown %t", n.PushedDown))
+	sb.WriteString("}")
+}
+
+func writeWithClause(sb *strings.Builder, n *WithClause) {
+	sb.WriteString("{WITHCLAUSE")
+	if n.Ctes != nil {
+		sb.WriteString(" :ctes ")
+		writeNode(sb, n.Ctes)
+	} else {
+		sb.WriteString(" :ctes <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :recursive %t", n.Recursive))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeInferClause(sb *strings.Builder, n *InferClause) {
+	sb.WriteString("{INFERCLAUSE")
+	if n.IndexElems != nil {
+		sb.WriteString(" :indexElems ")
+		writeNode(sb, n.IndexElems)
+	} else {
+		sb.WriteString(" :indexElems <>")
+	}
+	sb.WriteString(" :whereClause ")
+	writeNode(sb, n.WhereClause)
+	if n.Conname != "" {
+		sb.WriteString(" :conname \"" + escapeString(n.Conname) + "\"")
+	} else {
+		sb.WriteString(" :conname <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeOnConflictClause(sb *strings.Builder, n *OnConflictClause) {
+	sb.WriteString("{ONCONFLICTCLAUSE")
+	sb.WriteString(fmt.Sprintf(" :action %d", n.Action))
+	if n.Infer != nil {
+		sb.WriteString(" :infer ")
+		writeNode(sb, n.Infer)
+	} else {
+		sb.WriteString(" :infer <>")
+	}
+	if n.TargetList != nil {
+		sb.WriteString(" :targetList ")
+		writeNode(sb, n.TargetList)
+	} else {
+		sb.WriteString(" :targetList <>")
+	}
+	sb.WriteString(" :whereClause ")
+	writeNode(sb, n.WhereClause)
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeCTESearchClause(sb *strings.Builder, n *CTESearchClause) {
+	sb.WriteString("{CTESEARCHCLAUSE")
+	if n.SearchColList != nil {
+		sb.WriteString(" :search_col_list ")
+		writeNode(sb, n.SearchColList)
+	} else {
+		sb.WriteString(" :search_col_list <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :search_breadth_first %t", n.SearchBreadthFirst))
+	if n.SearchSeqColumn != "" {
+		sb.WriteString(" :search_seq_column \"" + escapeString(n.SearchSeqColumn) + "\"")
+	} else {
+		sb.WriteString(" :search_seq_column <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeCTECycleClause(sb *strings.Builder, n *CTECycleClause) {
+	sb.WriteString("{CTECYCLECLAUSE")
+	if n.CycleColList != nil {
+		sb.WriteString(" :cycle_col_list ")
+		writeNode(sb, n.CycleColList)
+	} else {
+		sb.WriteString(" :cycle_col_list <>")
+	}
+	if n.CycleMarkColumn != "" {
+		sb.WriteString(" :cycle_mark_column \"" + escapeString(n.CycleMarkColumn) + "\"")
+	} else {
+		sb.WriteString(" :cycle_mark_column <>")
+	}
+	sb.WriteString(" :cycle_mark_value ")
+	writeNode(sb, n.CycleMarkValue)
+	sb.WriteString(" :cycle_mark_default ")
+	writeNode(sb, n.CycleMarkDefault)
+	if n.CyclePathColumn != "" {
+		sb.WriteString(" :cycle_path_column \"" + escapeString(n.CyclePathColumn) + "\"")
+	} else {
+		sb.WriteString(" :cycle_path_column <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString(fmt.Sprintf(" :cycle_mark_type %d", n.CycleMarkType))
+	sb.WriteString(fmt.Sprintf(" :cycle_mark_typmod %d", n.CycleMarkTypmod))
+	sb.WriteString(fmt.Sprintf(" :cycle_mark_collation %d", n.CycleMarkCollation))
+	sb.WriteString(fmt.Sprintf(" :cycle_mark_neop %d", n.CycleMarkNeop))
+	sb.WriteString("}")
+}
+
+func writeCommonTableExpr(sb *strings.Builder, n *CommonTableExpr) {
+	sb.WriteString("{COMMONTABLEEXPR")
+	if n.Ctename != "" {
+		sb.WriteString(" :ctename \"" + escapeString(n.Ctename) + "\"")
+	} else {
+		sb.WriteString(" :ctename <>")
+	}
+	if n.Aliascolnames != nil {
+		sb.WriteString(" :aliascolnames ")
+		writeNode(sb, n.Aliascolnames)
+	} else {
+		sb.WriteString(" :aliascolnames <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :ctematerialized %d", n.Ctematerialized))
+	sb.WriteString(" :ctequery ")
+	writeNode(sb, n.Ctequery)
+	if n.SearchClause != nil {
+		sb.WriteString(" :search_clause ")
+		writeNode(sb, n.SearchClause)
+	} else {
+		sb.WriteString(" :search_clause <>")
+	}
+	if n.CycleClause != nil {
+		sb.WriteString(" :cycle_clause ")
+		writeNode(sb, n.CycleClause)
+	} else {
+		sb.WriteString(" :cycle_clause <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString(fmt.Sprintf(" :cterecursive %t", n.Cterecursive))
+	sb.WriteString(fmt.Sprintf(" :cterefcount %d", n.Cterefcount))
+	if n.Ctecolnames != nil {
+		sb.WriteString(" :ctecolnames ")
+		writeNode(sb, n.Ctecolnames)
+	} else {
+		sb.WriteString(" :ctecolnames <>")
+	}
+	if n.Ctecoltypes != nil {
+		sb.WriteString(" :ctecoltypes ")
+		writeNode(sb, n.Ctecoltypes)
+	} else {
+		sb.WriteString(" :ctecoltypes <>")
+	}
+	if n.Ctecoltypmods != nil {
+		sb.WriteString(" :ctecoltypmods ")
+		writeNode(sb, n.Ctecoltypmods)
+	} else {
+		sb.WriteString(" :ctecoltypmods <>")
+	}
+	if n.Ctecolcollations != nil {
+		sb.WriteString(" :ctecolcollations ")
+		writeNode(sb, n.Ctecolcollations)
+	} else {
+		sb.WriteString(" :ctecolcollations <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeMergeWhenClause(sb *strings.Builder, n *MergeWhenClause) {
+	sb.WriteString("{MERGEWHENCLAUSE")
+	sb.WriteString(fmt.Sprintf(" :matchKind %d", n.MatchKind))
+	sb.WriteString(fmt.Sprintf(" :commandType %d", n.CommandType))
+	sb.WriteString(fmt.Sprintf(" :override %d", n.Override))
+	sb.WriteString(" :condition ")
+	writeNode(sb, n.Condition)
+	if n.TargetList != nil {
+		sb.WriteString(" :targetList ")
+		writeNode(sb, n.TargetList)
+	} else {
+		sb.WriteString(" :targetList <>")
+	}
+	if n.Values != nil {
+		sb.WriteString(" :values ")
+		writeNode(sb, n.Values)
+	} else {
+		sb.WriteString(" :values <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeTriggerTransition(sb *strings.Builder, n *TriggerTransition) {
+	sb.WriteString("{TRIGGERTRANSITION")
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :isNew %t", n.IsNew))
+	sb.WriteString(fmt.Sprintf(" :isTable %t", n.IsTable))
+	sb.WriteString("}")
+}
+
+func writeJsonOutput(sb *strings.Builder, n *JsonOutput) {
+	sb.WriteString("{JSONOUTPUT")
+	if n.TypeName != nil {
+		sb.WriteString(" :typeName ")
+		writeNode(sb, n.TypeName)
+	} else {
+		sb.WriteString(" :typeName <>")
+	}
+	if n.Returning != nil {
+		sb.WriteString(" :returning ")
+		writeNode(sb, n.Returning)
+	} else {
+		sb.WriteString(" :returning <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeJsonArgument(sb *strings.Builder, n *JsonArgument) {
+	sb.WriteString("{JSONARGUMENT")
+	if n.Val != nil {
+		sb.WriteString(" :val ")
+		writeNode(sb, n.Val)
+	} else {
+		sb.WriteString(" :val <>")
+	}
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeJsonFuncExpr(sb *strings.Builder, n *JsonFuncExpr) {
+	sb.WriteString("{JSONFUNCEXPR")
+	sb.WriteString(fmt.Sprintf(" :op %d", n.Op))
+	if n.ColumnName != "" {
+		sb.WriteString(" :column_name \"" + escapeString(n.ColumnName) + "\"")
+	} else {
+		sb.WriteString(" :column_name <>")
+	}
+	if n.ContextItem != nil {
+		sb.WriteString(" :context_item ")
+		writeNode(sb, n.ContextItem)
+	} else {
+		sb.WriteString(" :context_item <>")
+	}
+	sb.WriteString(" :pathspec ")
+	writeNode(sb, n.Pathspec)
+	if n.Passing != nil {
+		sb.WriteString(" :passing ")
+		writeNode(sb, n.Passing)
+	} else {
+		sb.WriteString(" :passing <>")
+	}
+	if n.Output != nil {
+		sb.WriteString(" :output ")
+		writeNode(sb, n.Output)
+	} else {
+		sb.WriteString(" :output <>")
+	}
+	if n.OnEmpty != nil {
+		sb.WriteString(" :on_empty ")
+		writeNode(sb, n.OnEmpty)
+	} else {
+		sb.WriteString(" :on_empty <>")
+	}
+	if n.OnError != nil {
+		sb.WriteString(" :on_error ")
+		writeNode(sb, n.OnError)
+	} else {
+		sb.WriteString(" :on_error <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :wrapper %d", n.Wrapper))
+	sb.WriteString(fmt.Sprintf(" :quotes %d", n.Quotes))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeJsonTablePathSpec(sb *strings.Builder, n *JsonTablePathSpec) {
+	sb.WriteString("{JSONTABLEPATHSPEC")
+	sb.WriteString(" :string ")
+	writeNode(sb, n.String)
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :name_location %d", n.NameLocation))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeJsonTable(sb *strings.Builder, n *JsonTable) {
+	sb.WriteString("{JSONTABLE")
+	if n.ContextItem != nil {
+		sb.WriteString(" :context_item ")
+		writeNode(sb, n.ContextItem)
+	} else {
+		sb.WriteString(" :context_item <>")
+	}
+	if n.Pathspec != nil {
+		sb.WriteString(" :pathspec ")
+		writeNode(sb, n.Pathspec)
+	} else {
+		sb.WriteString(" :pathspec <>")
+	}
+	if n.Passing != nil {
+		sb.WriteString(" :passing ")
+		writeNode(sb, n.Passing)
+	} else {
+		sb.WriteString(" :passing <>")
+	}
+	if n.Columns != nil {
+		sb.WriteString(" :columns ")
+		writeNode(sb, n.Columns)
+	} else {
+		sb.WriteString(" :columns <>")
+	}
+	if n.OnError != nil {
+		sb.WriteString(" :on_error ")
+		writeNode(sb, n.OnError)
+	} else {
+		sb.WriteString(" :on_error <>")
+	}
+	if n.Alias != nil {
+		sb.WriteString(" :alias ")
+		writeNode(sb, n.Alias)
+	} else {
+		sb.WriteString(" :alias <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :lateral %t", n.Lateral))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeJsonTableColumn(sb *strings.Builder, n *JsonTableColumn) {
+	sb.WriteString("{JSONTABLECOLUMN")
+	sb.WriteString(fmt.Sprintf(" :coltype %d", n.Coltype))
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	if n.TypeName != nil {
+		sb.WriteString(" :typeName ")
+		writeNode(sb, n.TypeName)
+	} else {
+		sb.WriteString(" :typeName <>")
+	}
+	if n.Pathspec != nil {
+		sb.WriteString(" :pathspec ")
+		writeNode(sb, n.Pathspec)
+	} else {
+		sb.WriteString(" :pathspec <>")
+	}
+	if n.Format != nil {
+		sb.WriteString(" :format ")
+		writeNode(sb, n.Format)
+	} else {
+		sb.WriteString(" :format <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :wrapper %d", n.Wrapper))
+	sb.WriteString(fmt.Sprintf(" :quotes %d", n.Quotes))
+	if n.Columns != nil {
+		sb.WriteString(" :columns ")
+		writeNode(sb, n.Columns)
+	} else {
+		sb.WriteString(" :columns <>")
+	}
+	if n.OnEmpty != nil {
+		sb.WriteString(" :on_empty ")
+		writeNode(sb, n.OnEmpty)
+	} else {
+		sb.WriteString(" :on_empty <>")
+	}
+	if n.OnError != nil {
+		sb.WriteString(" :on_error ")
+		writeNode(sb, n.OnError)
+	} else {
+		sb.WriteString(" :on_error <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeJsonKeyValue(sb *strings.Builder, n *JsonKeyValue) {
+	sb.WriteString("{JSONKEYVALUE")
+	sb.WriteString(" :key ")
+	writeNode(sb, n.Key)
+	if n.Value != nil {
+		sb.WriteString(" :value ")
+		writeNode(sb, n.Value)
+	} else {
+		sb.WriteString(" :value <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeJsonParseExpr(sb *strings.Builder, n *JsonParseExpr) {
+	sb.WriteString("{JSONPARSEEXPR")
+	if n.Expr != nil {
+		sb.WriteString(" :expr ")
+		writeNode(sb, n.Expr)
+	} else {
+		sb.WriteString(" :expr <>")
+	}
+	if n.Output != nil {
+		sb.WriteString(" :output ")
+		writeNode(sb, n.Output)
+	} else {
+		sb.WriteString(" :output <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :unique_keys %t", n.UniqueKeys))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeJsonScalarExpr(sb *strings.Builder, n *JsonScalarExpr) {
+	sb.WriteString("{JSONSCALAREXPR")
+	sb.WriteString(" :expr ")
+	writeNode(sb, n.Expr)
+	if n.Output != nil {
+		sb.WriteString(" :output ")
+		writeNode(sb, n.Output)
+	} else {
+		sb.WriteString(" :output <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeJsonSerializeExpr(sb *strings.Builder, n *JsonSerializeExpr) {
+	sb.WriteString("{JSONSERIALIZEEXPR")
+	if n.Expr != nil {
+		sb.WriteString(" :expr ")
+		writeNode(sb, n.Expr)
+	} else {
+		sb.WriteString(" :expr <>")
+	}
+	if n.Output != nil {
+		sb.WriteString(" :output ")
+		writeNode(sb, n.Output)
+	} else {
+		sb.WriteString(" :output <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeJsonObjectConstructor(sb *strings.Builder, n *JsonObjectConstructor) {
+	sb.WriteString("{JSONOBJECTCONSTRUCTOR")
+	if n.Exprs != nil {
+		sb.WriteString(" :exprs ")
+		writeNode(sb, n.Exprs)
+	} else {
+		sb.WriteString(" :exprs <>")
+	}
+	if n.Output != nil {
+		sb.WriteString(" :output ")
+		writeNode(sb, n.Output)
+	} else {
+		sb.WriteString(" :output <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :absent_on_null %t", n.AbsentOnNull))
+	sb.WriteString(fmt.Sprintf(" :unique %t", n.Unique))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeJsonArrayConstructor(sb *strings.Builder, n *JsonArrayConstructor) {
+	sb.WriteString("{JSONARRAYCONSTRUCTOR")
+	if n.Exprs != nil {
+		sb.WriteString(" :exprs ")
+		writeNode(sb, n.Exprs)
+	} else {
+		sb.WriteString(" :exprs <>")
+	}
+	if n.Output != nil {
+		sb.WriteString(" :output ")
+		writeNode(sb, n.Output)
+	} else {
+		sb.WriteString(" :output <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :absent_on_null %t", n.AbsentOnNull))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeJsonArrayQueryConstructor(sb *strings.Builder, n *JsonArrayQueryConstructor) {
+	sb.WriteString("{JSONARRAYQUERYCONSTRUCTOR")
+	sb.WriteString(" :query ")
+	writeNode(sb, n.Query)
+	if n.Output != nil {
+		sb.WriteString(" :output ")
+		writeNode(sb, n.Output)
+	} else {
+		sb.WriteString(" :output <>")
+	}
+	if n.Format != nil {
+		sb.WriteString(" :format ")
+		writeNode(sb, n.Format)
+	} else {
+		sb.WriteString(" :format <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :absent_on_null %t", n.AbsentOnNull))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeJsonAggConstructor(sb *strings.Builder, n *JsonAggConstructor) {
+	sb.WriteString("{JSONAGGCONSTRUCTOR")
+	if n.Output != nil {
+		sb.WriteString(" :output ")
+		writeNode(sb, n.Output)
+	} else {
+		sb.WriteString(" :output <>")
+	}
+	sb.WriteString(" :agg_filter ")
+	writeNode(sb, n.AggFilter)
+	if n.AggOrder != nil {
+		sb.WriteString(" :agg_order ")
+		writeNode(sb, n.AggOrder)
+	} else {
+		sb.WriteString(" :agg_order <>")
+	}
+	if n.Over != nil {
+		sb.WriteString(" :over ")
+		writeNode(sb, n.Over)
+	} else {
+		sb.WriteString(" :over <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeJsonObjectAgg(sb *strings.Builder, n *JsonObjectAgg) {
+	sb.WriteString("{JSONOBJECTAGG")
+	if n.Constructor != nil {
+		sb.WriteString(" :constructor ")
+		writeNode(sb, n.Constructor)
+	} else {
+		sb.WriteString(" :constructor <>")
+	}
+	if n.Arg != nil {
+		sb.WriteString(" :arg ")
+		writeNode(sb, n.Arg)
+	} else {
+		sb.WriteString(" :arg <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :absent_on_null %t", n.AbsentOnNull))
+	sb.WriteString(fmt.Sprintf(" :unique %t", n.Unique))
+	sb.WriteString("}")
+}
+
+func writeJsonArrayAgg(sb *strings.Builder, n *JsonArrayAgg) {
+	sb.WriteString("{JSONARRAYAGG")
+	if n.Constructor != nil {
+		sb.WriteString(" :constructor ")
+		writeNode(sb, n.Constructor)
+	} else {
+		sb.WriteString(" :constructor <>")
+	}
+	if n.Arg != nil {
+		sb.WriteString(" :arg ")
+		writeNode(sb, n.Arg)
+	} else {
+		sb.WriteString(" :arg <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :absent_on_null %t", n.AbsentOnNull))
+	sb.WriteString("}")
+}
+
+func writeMergeStmt(sb *strings.Builder, n *MergeStmt) {
+	sb.WriteString("{MERGESTMT")
+	if n.Relation != nil {
+		sb.WriteString(" :relation ")
+		writeNode(sb, n.Relation)
+	} else {
+		sb.WriteString(" :relation <>")
+	}
+	sb.WriteString(" :sourceRelation ")
+	writeNode(sb, n.SourceRelation)
+	sb.WriteString(" :joinCondition ")
+	writeNode(sb, n.JoinCondition)
+	if n.MergeWhenClauses != nil {
+		sb.WriteString(" :mergeWhenClauses ")
+		writeNode(sb, n.MergeWhenClauses)
+	} else {
+		sb.WriteString(" :mergeWhenClauses <>")
+	}
+	if n.ReturningList != nil {
+		sb.WriteString(" :returningList ")
+		writeNode(sb, n.ReturningList)
+	} else {
+		sb.WriteString(" :returningList <>")
+	}
+	if n.ReturningOptions != nil {
+		sb.WriteString(" :returningOptions ")
+		writeNode(sb, n.ReturningOptions)
+	} else {
+		sb.WriteString(" :returningOptions <>")
+	}
+	if n.WithClause != nil {
+		sb.WriteString(" :withClause ")
+		writeNode(sb, n.WithClause)
+	} else {
+		sb.WriteString(" :withClause <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeSetOperationStmt(sb *strings.Builder, n *SetOperationStmt) {
+	sb.WriteString("{SETOPERATIONSTMT")
+	sb.WriteString(fmt.Sprintf(" :op %d", n.Op))
+	sb.WriteString(fmt.Sprintf(" :all %t", n.All))
+	sb.WriteString(" :larg ")
+	writeNode(sb, n.Larg)
+	sb.WriteString(" :rarg ")
+	writeNode(sb, n.Rarg)
+	if n.ColTypes != nil {
+		sb.WriteString(" :colTypes ")
+		writeNode(sb, n.ColTypes)
+	} else {
+		sb.WriteString(" :colTypes <>")
+	}
+	if n.ColTypmods != nil {
+		sb.WriteString(" :colTypmods ")
+		writeNode(sb, n.ColTypmods)
+	} else {
+		sb.WriteString(" :colTypmods <>")
+	}
+	if n.ColCollations != nil {
+		sb.WriteString(" :colCollations ")
+		writeNode(sb, n.ColCollations)
+	} else {
+		sb.WriteString(" :colCollations <>")
+	}
+	if n.GroupClauses != nil {
+		sb.WriteString(" :groupClauses ")
+		writeNode(sb, n.GroupClauses)
+	} else {
+		sb.WriteString(" :groupClauses <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeReturnStmt(sb *strings.Builder, n *ReturnStmt) {
+	sb.WriteString("{RETURNSTMT")
+	sb.WriteString(" :returnval ")
+	writeNode(sb, n.Returnval)
+	sb.WriteString("}")
+}
+
+func writePLAssignStmt(sb *strings.Builder, n *PLAssignStmt) {
+	sb.WriteString("{PLASSIGNSTMT")
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	if n.Indirection != nil {
+		sb.WriteString(" :indirection ")
+		writeNode(sb, n.Indirection)
+	} else {
+		sb.WriteString(" :indirection <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :nnames %d", n.Nnames))
+	if n.Val != nil {
+		sb.WriteString(" :val ")
+		writeNode(sb, n.Val)
+	} else {
+		sb.WriteString(" :val <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeCreateSchemaStmt(sb *strings.Builder, n *CreateSchemaStmt) {
+	sb.WriteString("{CREATESCHEMASTMT")
+	if n.Schemaname != "" {
+		sb.WriteString(" :schemaname \"" + escapeString(n.Schemaname) + "\"")
+	} else {
+		sb.WriteString(" :schemaname <>")
+	}
+	if n.Authrole != nil {
+		sb.WriteString(" :authrole ")
+		writeNode(sb, n.Authrole)
+	} else {
+		sb.WriteString(" :authrole <>")
+	}
+	if n.SchemaElts != nil {
+		sb.WriteString(" :schemaElts ")
+		writeNode(sb, n.SchemaElts)
+	} else {
+		sb.WriteString(" :schemaElts <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :if_not_exists %t", n.IfNotExists))
+	sb.WriteString("}")
+}
+
+func writeAlterTableStmt(sb *strings.Builder, n *AlterTableStmt) {
+	sb.WriteString("{ALTERTABLESTMT")
+	if n.Relation != nil {
+		sb.WriteString(" :relation ")
+		writeNode(sb, n.Relation)
+	} else {
+		sb.WriteString(" :relation <>")
+	}
+	if n.Cmds != nil {
+		sb.WriteString(" :cmds ")
+		writeNode(sb, n.Cmds)
+	} else {
+		sb.WriteString(" :cmds <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :objtype %d", n.Objtype))
+	sb.WriteString(fmt.Sprintf(" :missing_ok %t", n.MissingOk))
+	sb.WriteString("}")
+}
+
+func writeReplicaIdentityStmt(sb *strings.Builder, n *ReplicaIdentityStmt) {
+	sb.WriteString("{REPLICAIDENTITYSTMT")
+	writeCharField(sb, " :identity_type", n.IdentityType)
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeAlterTableCmd(sb *strings.Builder, n *AlterTableCmd) {
+	sb.WriteString("{ALTERTABLECMD")
+	sb.WriteString(fmt.Sprintf(" :subtype %d", n.Subtype))
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :num %d", n.Num))
+	if n.Newowner != nil {
+		sb.WriteString(" :newowner ")
+		writeNode(sb, n.Newowner)
+	} else {
+		sb.WriteString(" :newowner <>")
+	}
+	sb.WriteString(" :def ")
+	writeNode(sb, n.Def)
+	sb.WriteString(fmt.Sprintf(" :behavior %d", n.Behavior))
+	sb.WriteString(fmt.Sprintf(" :missing_ok %t", n.MissingOk))
+	sb.WriteString(fmt.Sprintf(" :recurse %t", n.Recurse))
+	sb.WriteString("}")
+}
+
+func writeAlterCollationStmt(sb *strings.Builder, n *AlterCollationStmt) {
+	sb.WriteString("{ALTERCOLLATIONSTMT")
+	if n.Collname != nil {
+		sb.WriteString(" :collname ")
+		writeNode(sb, n.Collname)
+	} else {
+		sb.WriteString(" :collname <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeAlterDomainStmt(sb *strings.Builder, n *AlterDomainStmt) {
+	sb.WriteString("{ALTERDOMAINSTMT")
+	writeCharField(sb, " :subtype", n.Subtype)
+	if n.TypeName != nil {
+		sb.WriteString(" :typeName ")
+		writeNode(sb, n.TypeName)
+	} else {
+		sb.WriteString(" :typeName <>")
+	}
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	sb.WriteString(" :def ")
+	writeNode(sb, n.Def)
+	sb.WriteString(fmt.Sprintf(" :behavior %d", n.Behavior))
+	sb.WriteString(fmt.Sprintf(" :missing_ok %t", n.MissingOk))
+	sb.WriteString("}")
+}
+
+func writeGrantStmt(sb *strings.Builder, n *GrantStmt) {
+	sb.WriteString("{GRANTSTMT")
+	sb.WriteString(fmt.Sprintf(" :is_grant %t", n.IsGrant))
+	sb.WriteString(fmt.Sprintf(" :targtype %d", n.Targtype))
+	sb.WriteString(fmt.Sprintf(" :objtype %d", n.Objtype))
+	if n.Objects != nil {
+		sb.WriteString(" :objects ")
+		writeNode(sb, n.Objects)
+	} else {
+		sb.WriteString(" :objects <>")
+	}
+	if n.Privileges != nil {
+		sb.WriteString(" :privileges ")
+		writeNode(sb, n.Privileges)
+	} else {
+		sb.WriteString(" :privileges <>")
+	}
+	if n.Grantees != nil {
+		sb.WriteString(" :grantees ")
+		writeNode(sb, n.Grantees)
+	} else {
+		sb.WriteString(" :grantees <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :grant_option %t", n.GrantOption))
+	if n.Grantor != nil {
+		sb.WriteString(" :grantor ")
+		writeNode(sb, n.Grantor)
+	} else {
+		sb.WriteString(" :grantor <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :behavior %d", n.Behavior))
+	sb.WriteString("}")
+}
+
+func writeObjectWithArgs(sb *strings.Builder, n *ObjectWithArgs) {
+	sb.WriteString("{OBJECTWITHARGS")
+	if n.Objname != nil {
+		sb.WriteString(" :objname ")
+		writeNode(sb, n.Objname)
+	} else {
+		sb.WriteString(" :objname <>")
+	}
+	if n.Objargs != nil {
+		sb.WriteString(" :objargs ")
+		writeNode(sb, n.Objargs)
+	} else {
+		sb.WriteString(" :objargs <>")
+	}
+	if n.Objfuncargs != nil {
+		sb.WriteString(" :objfuncargs ")
+		writeNode(sb, n.Objfuncargs)
+	} else {
+		sb.WriteString(" :objfuncargs <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :args_unspecified %t", n.ArgsUnspecified))
+	sb.WriteString("}")
+}
+
+func writeAccessPriv(sb *strings.Builder, n *AccessPriv) {
+	sb.WriteString("{ACCESSPRIV")
+	if n.PrivName != "" {
+		sb.WriteString(" :priv_name \"" + escapeString(n.PrivName) + "\"")
+	} else {
+		sb.WriteString(" :priv_name <>")
+	}
+	if n.Cols != nil {
+		sb.WriteString(" :cols ")
+		writeNode(sb, n.Cols)
+	} else {
+		sb.WriteString(" :cols <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeGrantRoleStmt(sb *strings.Builder, n *GrantRoleStmt) {
+	sb.WriteString("{GRANTROLESTMT")
+	if n.GrantedRoles != nil {
+		sb.WriteString(" :granted_roles ")
+		writeNode(sb, n.GrantedRoles)
+	} else {
+		sb.WriteString(" :granted_roles <>")
+	}
+	if n.GranteeRoles != nil {
+		sb.WriteString(" :grantee_roles ")
+		writeNode(sb, n.GranteeRoles)
+	} else {
+		sb.WriteString(" :grantee_roles <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :is_grant %t", n.IsGrant))
+	if n.Opt != nil {
+		sb.WriteString(" :opt ")
+		writeNode(sb, n.Opt)
+	} else {
+		sb.WriteString(" :opt <>")
+	}
+	if n.Grantor != nil {
+		sb.WriteString(" :grantor ")
+		writeNode(sb, n.Grantor)
+	} else {
+		sb.WriteString(" :grantor <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :behavior %d", n.Behavior))
+	sb.WriteString("}")
+}
+
+func writeAlterDefaultPrivilegesStmt(sb *strings.Builder, n *AlterDefaultPrivilegesStmt) {
+	sb.WriteString("{ALTERDEFAULTPRIVILEGESSTMT")
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	if n.Action != nil {
+		sb.WriteString(" :action ")
+		writeNode(sb, n.Action)
+	} else {
+		sb.WriteString(" :action <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeCopyStmt(sb *strings.Builder, n *CopyStmt) {
+	sb.WriteString("{COPYSTMT")
+	if n.Relation != nil {
+		sb.WriteString(" :relation ")
+		writeNode(sb, n.Relation)
+	} else {
+		sb.WriteString(" :relation <>")
+	}
+	sb.WriteString(" :query ")
+	writeNode(sb, n.Query)
+	if n.Attlist != nil {
+		sb.WriteString(" :attlist ")
+		writeNode(sb, n.Attlist)
+	} else {
+		sb.WriteString(" :attlist <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :is_from %t", n.IsFrom))
+	sb.WriteString(fmt.Sprintf(" :is_program %t", n.IsProgram))
+	if n.Filename != "" {
+		sb.WriteString(" :filename \"" + escapeString(n.Filename) + "\"")
+	} else {
+		sb.WriteString(" :filename <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString(" :whereClause ")
+	writeNode(sb, n.WhereClause)
+	sb.WriteString("}")
+}
+
+func writeVariableSetStmt(sb *strings.Builder, n *VariableSetStmt) {
+	sb.WriteString("{VARIABLESETSTMT")
+	sb.WriteString(fmt.Sprintf(" :kind %d", n.Kind))
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	if n.Args != nil {
+		sb.WriteString(" :args ")
+		writeNode(sb, n.Args)
+	} else {
+		sb.WriteString(" :args <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :is_local %t", n.IsLocal))
+	sb.WriteString("}")
+}
+
+func writeVariableShowStmt(sb *strings.Builder, n *VariableShowStmt) {
+	sb.WriteString("{VARIABLESHOWSTMT")
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeCreateTableSpaceStmt(sb *strings.Builder, n *CreateTableSpaceStmt) {
+	sb.WriteString("{CREATETABLESPACESTMT")
+	if n.Tablespacename != "" {
+		sb.WriteString(" :tablespacename \"" + escapeString(n.Tablespacename) + "\"")
+	} else {
+		sb.WriteString(" :tablespacename <>")
+	}
+	if n.Owner != nil {
+		sb.WriteString(" :owner ")
+		writeNode(sb, n.Owner)
+	} else {
+		sb.WriteString(" :owner <>")
+	}
+	if n.Location != "" {
+		sb.WriteString(" :location \"" + escapeString(n.Location) + "\"")
+	} else {
+		sb.WriteString(" :location <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeDropTableSpaceStmt(sb *strings.Builder, n *DropTableSpaceStmt) {
+	sb.WriteString("{DROPTABLESPACESTMT")
+	if n.Tablespacename != "" {
+		sb.WriteString(" :tablespacename \"" + escapeString(n.Tablespacename) + "\"")
+	} else {
+		sb.WriteString(" :tablespacename <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :missing_ok %t", n.MissingOk))
+	sb.WriteString("}")
+}
+
+func writeAlterTableSpaceOptionsStmt(sb *strings.Builder, n *AlterTableSpaceOptionsStmt) {
+	sb.WriteString("{ALTERTABLESPACEOPTIONSSTMT")
+	if n.Tablespacename != "" {
+		sb.WriteString(" :tablespacename \"" + escapeString(n.Tablespacename) + "\"")
+	} else {
+		sb.WriteString(" :tablespacename <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :isReset %t", n.IsReset))
+	sb.WriteString("}")
+}
+
+func writeAlterTableMoveAllStmt(sb *strings.Builder, n *AlterTableMoveAllStmt) {
+	sb.WriteString("{ALTERTABLEMOVEALLSTMT")
+	if n.OrigTablespacename != "" {
+		sb.WriteString(" :orig_tablespacename \"" + escapeString(n.OrigTablespacename) + "\"")
+	} else {
+		sb.WriteString(" :orig_tablespacename <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :objtype %d", n.Objtype))
+	if n.Roles != nil {
+		sb.WriteString(" :roles ")
+		writeNode(sb, n.Roles)
+	} else {
+		sb.WriteString(" :roles <>")
+	}
+	if n.NewTablespacename != "" {
+		sb.WriteString(" :new_tablespacename \"" + escapeString(n.NewTablespacename) + "\"")
+	} else {
+		sb.WriteString(" :new_tablespacename <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :nowait %t", n.Nowait))
+	sb.WriteString("}")
+}
+
+func writeCreateExtensionStmt(sb *strings.Builder, n *CreateExtensionStmt) {
+	sb.WriteString("{CREATEEXTENSIONSTMT")
+	if n.Extname != "" {
+		sb.WriteString(" :extname \"" + escapeString(n.Extname) + "\"")
+	} else {
+		sb.WriteString(" :extname <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :if_not_exists %t", n.IfNotExists))
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeAlterExtensionStmt(sb *strings.Builder, n *AlterExtensionStmt) {
+	sb.WriteString("{ALTEREXTENSIONSTMT")
+	if n.Extname != "" {
+		sb.WriteString(" :extname \"" + escapeString(n.Extname) + "\"")
+	} else {
+		sb.WriteString(" :extname <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeAlterExtensionContentsStmt(sb *strings.Builder, n *AlterExtensionContentsStmt) {
+	sb.WriteString("{ALTEREXTENSIONCONTENTSSTMT")
+	if n.Extname != "" {
+		sb.WriteString(" :extname \"" + escapeString(n.Extname) + "\"")
+	} else {
+		sb.WriteString(" :extname <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :action %d", n.Action))
+	sb.WriteString(fmt.Sprintf(" :objtype %d", n.Objtype))
+	sb.WriteString(" :object ")
+	writeNode(sb, n.Object)
+	sb.WriteString("}")
+}
+
+func writeCreateFdwStmt(sb *strings.Builder, n *CreateFdwStmt) {
+	sb.WriteString("{CREATEFDWSTMT")
+	if n.Fdwname != "" {
+		sb.WriteString(" :fdwname \"" + escapeString(n.Fdwname) + "\"")
+	} else {
+		sb.WriteString(" :fdwname <>")
+	}
+	if n.FuncOptions != nil {
+		sb.WriteString(" :func_options ")
+		writeNode(sb, n.FuncOptions)
+	} else {
+		sb.WriteString(" :func_options <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeAlterFdwStmt(sb *strings.Builder, n *AlterFdwStmt) {
+	sb.WriteString("{ALTERFDWSTMT")
+	if n.Fdwname != "" {
+		sb.WriteString(" :fdwname \"" + escapeString(n.Fdwname) + "\"")
+	} else {
+		sb.WriteString(" :fdwname <>")
+	}
+	if n.FuncOptions != nil {
+		sb.WriteString(" :func_options ")
+		writeNode(sb, n.FuncOptions)
+	} else {
+		sb.WriteString(" :func_options <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeCreateForeignServerStmt(sb *strings.Builder, n *CreateForeignServerStmt) {
+	sb.WriteString("{CREATEFOREIGNSERVERSTMT")
+	if n.Servername != "" {
+		sb.WriteString(" :servername \"" + escapeString(n.Servername) + "\"")
+	} else {
+		sb.WriteString(" :servername <>")
+	}
+	if n.Servertype != "" {
+		sb.WriteString(" :servertype \"" + escapeString(n.Servertype) + "\"")
+	} else {
+		sb.WriteString(" :servertype <>")
+	}
+	if n.Version != "" {
+		sb.WriteString(" :version \"" + escapeString(n.Version) + "\"")
+	} else {
+		sb.WriteString(" :version <>")
+	}
+	if n.Fdwname != "" {
+		sb.WriteString(" :fdwname \"" + escapeString(n.Fdwname) + "\"")
+	} else {
+		sb.WriteString(" :fdwname <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :if_not_exists %t", n.IfNotExists))
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeAlterForeignServerStmt(sb *strings.Builder, n *AlterForeignServerStmt) {
+	sb.WriteString("{ALTERFOREIGNSERVERSTMT")
+	if n.Servername != "" {
+		sb.WriteString(" :servername \"" + escapeString(n.Servername) + "\"")
+	} else {
+		sb.WriteString(" :servername <>")
+	}
+	if n.Version != "" {
+		sb.WriteString(" :version \"" + escapeString(n.Version) + "\"")
+	} else {
+		sb.WriteString(" :version <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :has_version %t", n.HasVersion))
+	sb.WriteString("}")
+}
+
+func writeCreateForeignTableStmt(sb *strings.Builder, n *CreateForeignTableStmt) {
+	sb.WriteString("{CREATEFOREIGNTABLESTMT")
+	if n.Base.Relation != nil {
+		sb.WriteString(" :base.relation ")
+		writeNode(sb, n.Base.Relation)
+	} else {
+		sb.WriteString(" :base.relation <>")
+	}
+	if n.Base.TableElts != nil {
+		sb.WriteString(" :base.tableElts ")
+		writeNode(sb, n.Base.TableElts)
+	} else {
+		sb.WriteString(" :base.tableElts <>")
+	}
+	if n.Base.InhRelations != nil {
+		sb.WriteString(" :base.inhRelations ")
+		writeNode(sb, n.Base.InhRelations)
+	} else {
+		sb.WriteString(" :base.inhRelations <>")
+	}
+	if n.Base.Partbound != nil {
+		sb.WriteString(" :base.partbound ")
+		writeNode(sb, n.Base.Partbound)
+	} else {
+		sb.WriteString(" :base.partbound <>")
+	}
+	if n.Base.Partspec != nil {
+		sb.WriteString(" :base.partspec ")
+		writeNode(sb, n.Base.Partspec)
+	} else {
+		sb.WriteString(" :base.partspec <>")
+	}
+	if n.Base.OfTypename != nil {
+		sb.WriteString(" :base.ofTypename ")
+		writeNode(sb, n.Base.OfTypename)
+	} else {
+		sb.WriteString(" :base.ofTypename <>")
+	}
+	if n.Base.Constraints != nil {
+		sb.WriteString(" :base.constraints ")
+		writeNode(sb, n.Base.Constraints)
+	} else {
+		sb.WriteString(" :base.constraints <>")
+	}
+	if n.Base.Options != nil {
+		sb.WriteString(" :base.options ")
+		writeNode(sb, n.Base.Options)
+	} else {
+		sb.WriteString(" :base.options <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :base.oncommit %d", n.Base.Oncommit))
+	if n.Base.Tablespacename != "" {
+		sb.WriteString(" :base.tablespacename \"" + escapeString(n.Base.Tablespacename) + "\"")
+	} else {
+		sb.WriteString(" :base.tablespacename <>")
+	}
+	if n.Base.AccessMethod != "" {
+		sb.WriteString(" :base.accessMethod \"" + escapeString(n.Base.AccessMethod) + "\"")
+	} else {
+		sb.WriteString(" :base.accessMethod <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :base.if_not_exists %t", n.Base.IfNotExists))
+	if n.Servername != "" {
+		sb.WriteString(" :servername \"" + escapeString(n.Servername) + "\"")
+	} else {
+		sb.WriteString(" :servername <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeCreateUserMappingStmt(sb *strings.Builder, n *CreateUserMappingStmt) {
+	sb.WriteString("{CREATEUSERMAPPINGSTMT")
+	if n.User != nil {
+		sb.WriteString(" :user ")
+		writeNode(sb, n.User)
+	} else {
+		sb.WriteString(" :user <>")
+	}
+	if n.Servername != "" {
+		sb.WriteString(" :servername \"" + escapeString(n.Servername) + "\"")
+	} else {
+		sb.WriteString(" :servername <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :if_not_exists %t", n.IfNotExists))
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeAlterUserMappingStmt(sb *strings.Builder, n *AlterUserMappingStmt) {
+	sb.WriteString("{ALTERUSERMAPPINGSTMT")
+	if n.User != nil {
+		sb.WriteString(" :user ")
+		writeNode(sb, n.User)
+	} else {
+		sb.WriteString(" :user <>")
+	}
+	if n.Servername != "" {
+		sb.WriteString(" :servername \"" + escapeString(n.Servername) + "\"")
+	} else {
+		sb.WriteString(" :servername <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeDropUserMappingStmt(sb *strings.Builder, n *DropUserMappingStmt) {
+	sb.WriteString("{DROPUSERMAPPINGSTMT")
+	if n.User != nil {
+		sb.WriteString(" :user ")
+		writeNode(sb, n.User)
+	} else {
+		sb.WriteString(" :user <>")
+	}
+	if n.Servername != "" {
+		sb.WriteString(" :servername \"" + escapeString(n.Servername) + "\"")
+	} else {
+		sb.WriteString(" :servername <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :missing_ok %t", n.MissingOk))
+	sb.WriteString("}")
+}
+
+func writeImportForeignSchemaStmt(sb *strings.Builder, n *ImportForeignSchemaStmt) {
+	sb.WriteString("{IMPORTFOREIGNSCHEMASTMT")
+	if n.ServerName != "" {
+		sb.WriteString(" :server_name \"" + escapeString(n.ServerName) + "\"")
+	} else {
+		sb.WriteString(" :server_name <>")
+	}
+	if n.RemoteSchema != "" {
+		sb.WriteString(" :remote_schema \"" + escapeString(n.RemoteSchema) + "\"")
+	} else {
+		sb.WriteString(" :remote_schema <>")
+	}
+	if n.LocalSchema != "" {
+		sb.WriteString(" :local_schema \"" + escapeString(n.LocalSchema) + "\"")
+	} else {
+		sb.WriteString(" :local_schema <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :list_type %d", n.ListType))
+	if n.TableList != nil {
+		sb.WriteString(" :table_list ")
+		writeNode(sb, n.TableList)
+	} else {
+		sb.WriteString(" :table_list <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeCreatePolicyStmt(sb *strings.Builder, n *CreatePolicyStmt) {
+	sb.WriteString("{CREATEPOLICYSTMT")
+	if n.PolicyName != "" {
+		sb.WriteString(" :policy_name \"" + escapeString(n.PolicyName) + "\"")
+	} else {
+		sb.WriteString(" :policy_name <>")
+	}
+	if n.Table != nil {
+		sb.WriteString(" :table ")
+		writeNode(sb, n.Table)
+	} else {
+		sb.WriteString(" :table <>")
+	}
+	if n.CmdName != "" {
+		sb.WriteString(" :cmd_name \"" + escapeString(n.CmdName) + "\"")
+	} else {
+		sb.WriteString(" :cmd_name <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :permissive %t", n.Permissive))
+	if n.Roles != nil {
+		sb.WriteString(" :roles ")
+		writeNode(sb, n.Roles)
+	} else {
+		sb.WriteString(" :roles <>")
+	}
+	sb.WriteString(" :qual ")
+	writeNode(sb, n.Qual)
+	sb.WriteString(" :with_check ")
+	writeNode(sb, n.WithCheck)
+	sb.WriteString("}")
+}
+
+func writeAlterPolicyStmt(sb *strings.Builder, n *AlterPolicyStmt) {
+	sb.WriteString("{ALTERPOLICYSTMT")
+	if n.PolicyName != "" {
+		sb.WriteString(" :policy_name \"" + escapeString(n.PolicyName) + "\"")
+	} else {
+		sb.WriteString(" :policy_name <>")
+	}
+	if n.Table != nil {
+		sb.WriteString(" :table ")
+		writeNode(sb, n.Table)
+	} else {
+		sb.WriteString(" :table <>")
+	}
+	if n.Roles != nil {
+		sb.WriteString(" :roles ")
+		writeNode(sb, n.Roles)
+	} else {
+		sb.WriteString(" :roles <>")
+	}
+	sb.WriteString(" :qual ")
+	writeNode(sb, n.Qual)
+	sb.WriteString(" :with_check ")
+	writeNode(sb, n.WithCheck)
+	sb.WriteString("}")
+}
+
+func writeCreateAmStmt(sb *strings.Builder, n *CreateAmStmt) {
+	sb.WriteString("{CREATEAMSTMT")
+	if n.Amname != "" {
+		sb.WriteString(" :amname \"" + escapeString(n.Amname) + "\"")
+	} else {
+		sb.WriteString(" :amname <>")
+	}
+	if n.HandlerName != nil {
+		sb.WriteString(" :handler_name ")
+		writeNode(sb, n.HandlerName)
+	} else {
+		sb.WriteString(" :handler_name <>")
+	}
+	writeCharField(sb, " :amtype", n.Amtype)
+	sb.WriteString("}")
+}
+
+func writeCreateTrigStmt(sb *strings.Builder, n *CreateTrigStmt) {
+	sb.WriteString("{CREATETRIGSTMT")
+	sb.WriteString(fmt.Sprintf(" :replace %t", n.Replace))
+	sb.WriteString(fmt.Sprintf(" :isconstraint %t", n.Isconstraint))
+	if n.Trigname != "" {
+		sb.WriteString(" :trigname \"" + escapeString(n.Trigname) + "\"")
+	} else {
+		sb.WriteString(" :trigname <>")
+	}
+	if n.Relation != nil {
+		sb.WriteString(" :relation ")
+		writeNode(sb, n.Relation)
+	} else {
+		sb.WriteString(" :relation <>")
+	}
+	if n.Funcname != nil {
+		sb.WriteString(" :funcname ")
+		writeNode(sb, n.Funcname)
+	} else {
+		sb.WriteString(" :funcname <>")
+	}
+	if n.Args != nil {
+		sb.WriteString(" :args ")
+		writeNode(sb, n.Args)
+	} else {
+		sb.WriteString(" :args <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :row %t", n.Row))
+	sb.WriteString(fmt.Sprintf(" :timing %d", n.Timing))
+	sb.WriteString(fmt.Sprintf(" :events %d", n.Events))
+	if n.Columns != nil {
+		sb.WriteString(" :columns ")
+		writeNode(sb, n.Columns)
+	} else {
+		sb.WriteString(" :columns <>")
+	}
+	sb.WriteString(" :whenClause ")
+	writeNode(sb, n.WhenClause)
+	if n.TransitionRels != nil {
+		sb.WriteString(" :transitionRels ")
+		writeNode(sb, n.TransitionRels)
+	} else {
+		sb.WriteString(" :transitionRels <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :deferrable %t", n.Deferrable))
+	sb.WriteString(fmt.Sprintf(" :initdeferred %t", n.Initdeferred))
+	if n.Constrrel != nil {
+		sb.WriteString(" :constrrel ")
+		writeNode(sb, n.Constrrel)
+	} else {
+		sb.WriteString(" :constrrel <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeCreateEventTrigStmt(sb *strings.Builder, n *CreateEventTrigStmt) {
+	sb.WriteString("{CREATEEVENTTRIGSTMT")
+	if n.Trigname != "" {
+		sb.WriteString(" :trigname \"" + escapeString(n.Trigname) + "\"")
+	} else {
+		sb.WriteString(" :trigname <>")
+	}
+	if n.Eventname != "" {
+		sb.WriteString(" :eventname \"" + escapeString(n.Eventname) + "\"")
+	} else {
+		sb.WriteString(" :eventname <>")
+	}
+	if n.Whenclause != nil {
+		sb.WriteString(" :whenclause ")
+		writeNode(sb, n.Whenclause)
+	} else {
+		sb.WriteString(" :whenclause <>")
+	}
+	if n.Funcname != nil {
+		sb.WriteString(" :funcname ")
+		writeNode(sb, n.Funcname)
+	} else {
+		sb.WriteString(" :funcname <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeAlterEventTrigStmt(sb *strings.Builder, n *AlterEventTrigStmt) {
+	sb.WriteString("{ALTEREVENTTRIGSTMT")
+	if n.Trigname != "" {
+		sb.WriteString(" :trigname \"" + escapeString(n.Trigname) + "\"")
+	} else {
+		sb.WriteString(" :trigname <>")
+	}
+	writeCharField(sb, " :tgenabled", n.Tgenabled)
+	sb.WriteString("}")
+}
+
+func writeCreatePLangStmt(sb *strings.Builder, n *CreatePLangStmt) {
+	sb.WriteString("{CREATEPLANGSTMT")
+	sb.WriteString(fmt.Sprintf(" :replace %t", n.Replace))
+	if n.Plname != "" {
+		sb.WriteString(" :plname \"" + escapeString(n.Plname) + "\"")
+	} else {
+		sb.WriteString(" :plname <>")
+	}
+	if n.Plhandler != nil {
+		sb.WriteString(" :plhandler ")
+		writeNode(sb, n.Plhandler)
+	} else {
+		sb.WriteString(" :plhandler <>")
+	}
+	if n.Plinline != nil {
+		sb.WriteString(" :plinline ")
+		writeNode(sb, n.Plinline)
+	} else {
+		sb.WriteString(" :plinline <>")
+	}
+	if n.Plvalidator != nil {
+		sb.WriteString(" :plvalidator ")
+		writeNode(sb, n.Plvalidator)
+	} else {
+		sb.WriteString(" :plvalidator <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :pltrusted %t", n.Pltrusted))
+	sb.WriteString("}")
+}
+
+func writeCreateRoleStmt(sb *strings.Builder, n *CreateRoleStmt) {
+	sb.WriteString("{CREATEROLESTMT")
+	sb.WriteString(fmt.Sprintf(" :stmt_type %d", n.StmtType))
+	if n.Role != "" {
+		sb.WriteString(" :role \"" + escapeString(n.Role) + "\"")
+	} else {
+		sb.WriteString(" :role <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeAlterRoleStmt(sb *strings.Builder, n *AlterRoleStmt) {
+	sb.WriteString("{ALTERROLESTMT")
+	if n.Role != nil {
+		sb.WriteString(" :role ")
+		writeNode(sb, n.Role)
+	} else {
+		sb.WriteString(" :role <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :action %d", n.Action))
+	sb.WriteString("}")
+}
+
+func writeAlterRoleSetStmt(sb *strings.Builder, n *AlterRoleSetStmt) {
+	sb.WriteString("{ALTERROLESETSTMT")
+	if n.Role != nil {
+		sb.WriteString(" :role ")
+		writeNode(sb, n.Role)
+	} else {
+		sb.WriteString(" :role <>")
+	}
+	if n.Database != "" {
+		sb.WriteString(" :database \"" + escapeString(n.Database) + "\"")
+	} else {
+		sb.WriteString(" :database <>")
+	}
+	if n.Setstmt != nil {
+		sb.WriteString(" :setstmt ")
+		writeNode(sb, n.Setstmt)
+	} else {
+		sb.WriteString(" :setstmt <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeDropRoleStmt(sb *strings.Builder, n *DropRoleStmt) {
+	sb.WriteString("{DROPROLESTMT")
+	if n.Roles != nil {
+		sb.WriteString(" :roles ")
+		writeNode(sb, n.Roles)
+	} else {
+		sb.WriteString(" :roles <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :missing_ok %t", n.MissingOk))
+	sb.WriteString("}")
+}
+
+func writeCreateSeqStmt(sb *strings.Builder, n *CreateSeqStmt) {
+	sb.WriteString("{CREATESEQSTMT")
+	if n.Sequence != nil {
+		sb.WriteString(" :sequence ")
+		writeNode(sb, n.Sequence)
+	} else {
+		sb.WriteString(" :sequence <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :ownerId %d", n.OwnerId))
+	sb.WriteString(fmt.Sprintf(" :for_identity %t", n.ForIdentity))
+	sb.WriteString(fmt.Sprintf(" :if_not_exists %t", n.IfNotExists))
+	sb.WriteString("}")
+}
+
+func writeAlterSeqStmt(sb *strings.Builder, n *AlterSeqStmt) {
+	sb.WriteString("{ALTERSEQSTMT")
+	if n.Sequence != nil {
+		sb.WriteString(" :sequence ")
+		writeNode(sb, n.Sequence)
+	} else {
+		sb.WriteString(" :sequence <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :for_identity %t", n.ForIdentity))
+	sb.WriteString(fmt.Sprintf(" :missing_ok %t", n.MissingOk))
+	sb.WriteString("}")
+}
+
+func writeDefineStmt(sb *strings.Builder, n *DefineStmt) {
+	sb.WriteString("{DEFINESTMT")
+	sb.WriteString(fmt.Sprintf(" :kind %d", n.Kind))
+	sb.WriteString(fmt.Sprintf(" :oldstyle %t", n.Oldstyle))
+	if n.Defnames != nil {
+		sb.WriteString(" :defnames ")
+		writeNode(sb, n.Defnames)
+	} else {
+		sb.WriteString(" :defnames <>")
+	}
+	if n.Args != nil {
+		sb.WriteString(" :args ")
+		writeNode(sb, n.Args)
+	} else {
+		sb.WriteString(" :args <>")
+	}
+	if n.Definition != nil {
+		sb.WriteString(" :definition ")
+		writeNode(sb, n.Definition)
+	} else {
+		sb.WriteString(" :definition <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :if_not_exists %t", n.IfNotExists))
+	sb.WriteString(fmt.Sprintf(" :replace %t", n.Replace))
+	sb.WriteString("}")
+}
+
+func writeCreateDomainStmt(sb *strings.Builder, n *CreateDomainStmt) {
+	sb.WriteString("{CREATEDOMAINSTMT")
+	if n.Domainname != nil {
+		sb.WriteString(" :domainname ")
+		writeNode(sb, n.Domainname)
+	} else {
+		sb.WriteString(" :domainname <>")
+	}
+	if n.TypeName != nil {
+		sb.WriteString(" :typeName ")
+		writeNode(sb, n.TypeName)
+	} else {
+		sb.WriteString(" :typeName <>")
+	}
+	if n.CollClause != nil {
+		sb.WriteString(" :collClause ")
+		writeNode(sb, n.CollClause)
+	} else {
+		sb.WriteString(" :collClause <>")
+	}
+	if n.Constraints != nil {
+		sb.WriteString(" :constraints ")
+		writeNode(sb, n.Constraints)
+	} else {
+		sb.WriteString(" :constraints <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeCreateOpClassStmt(sb *strings.Builder, n *CreateOpClassStmt) {
+	sb.WriteString("{CREATEOPCLASSSTMT")
+	if n.Opclassname != nil {
+		sb.WriteString(" :opclassname ")
+		writeNode(sb, n.Opclassname)
+	} else {
+		sb.WriteString(" :opclassname <>")
+	}
+	if n.Opfamilyname != nil {
+		sb.WriteString(" :opfamilyname ")
+		writeNode(sb, n.Opfamilyname)
+	} else {
+		sb.WriteString(" :opfamilyname <>")
+	}
+	if n.Amname != "" {
+		sb.WriteString(" :amname \"" + escapeString(n.Amname) + "\"")
+	} else {
+		sb.WriteString(" :amname <>")
+	}
+	if n.Datatype != nil {
+		sb.WriteString(" :datatype ")
+		writeNode(sb, n.Datatype)
+	} else {
+		sb.WriteString(" :datatype <>")
+	}
+	if n.Items != nil {
+		sb.WriteString(" :items ")
+		writeNode(sb, n.Items)
+	} else {
+		sb.WriteString(" :items <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :isDefault %t", n.IsDefault))
+	sb.WriteString("}")
+}
+
+func writeCreateOpClassItem(sb *strings.Builder, n *CreateOpClassItem) {
+	sb.WriteString("{CREATEOPCLASSITEM")
+	sb.WriteString(fmt.Sprintf(" :itemtype %d", n.Itemtype))
+	if n.Name != nil {
+		sb.WriteString(" :name ")
+		writeNode(sb, n.Name)
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :number %d", n.Number))
+	if n.OrderFamily != nil {
+		sb.WriteString(" :order_family ")
+		writeNode(sb, n.OrderFamily)
+	} else {
+		sb.WriteString(" :order_family <>")
+	}
+	if n.ClassArgs != nil {
+		sb.WriteString(" :class_args ")
+		writeNode(sb, n.ClassArgs)
+	} else {
+		sb.WriteString(" :class_args <>")
+	}
+	if n.Storedtype != nil {
+		sb.WriteString(" :storedtype ")
+		writeNode(sb, n.Storedtype)
+	} else {
+		sb.WriteString(" :storedtype <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeCreateOpFamilyStmt(sb *strings.Builder, n *CreateOpFamilyStmt) {
+	sb.WriteString("{CREATEOPFAMILYSTMT")
+	if n.Opfamilyname != nil {
+		sb.WriteString(" :opfamilyname ")
+		writeNode(sb, n.Opfamilyname)
+	} else {
+		sb.WriteString(" :opfamilyname <>")
+	}
+	if n.Amname != "" {
+		sb.WriteString(" :amname \"" + escapeString(n.Amname) + "\"")
+	} else {
+		sb.WriteString(" :amname <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeAlterOpFamilyStmt(sb *strings.Builder, n *AlterOpFamilyStmt) {
+	sb.WriteString("{ALTEROPFAMILYSTMT")
+	if n.Opfamilyname != nil {
+		sb.WriteString(" :opfamilyname ")
+		writeNode(sb, n.Opfamilyname)
+	} else {
+		sb.WriteString(" :opfamilyname <>")
+	}
+	if n.Amname != "" {
+		sb.WriteString(" :amname \"" + escapeString(n.Amname) + "\"")
+	} else {
+		sb.WriteString(" :amname <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :isDrop %t", n.IsDrop))
+	if n.Items != nil {
+		sb.WriteString(" :items ")
+		writeNode(sb, n.Items)
+	} else {
+		sb.WriteString(" :items <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeDropStmt(sb *strings.Builder, n *DropStmt) {
+	sb.WriteString("{DROPSTMT")
+	if n.Objects != nil {
+		sb.WriteString(" :objects ")
+		writeNode(sb, n.Objects)
+	} else {
+		sb.WriteString(" :objects <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :removeType %d", n.RemoveType))
+	sb.WriteString(fmt.Sprintf(" :behavior %d", n.Behavior))
+	sb.WriteString(fmt.Sprintf(" :missing_ok %t", n.MissingOk))
+	sb.WriteString(fmt.Sprintf(" :concurrent %t", n.Concurrent))
+	sb.WriteString("}")
+}
+
+func writeTruncateStmt(sb *strings.Builder, n *TruncateStmt) {
+	sb.WriteString("{TRUNCATESTMT")
+	if n.Relations != nil {
+		sb.WriteString(" :relations ")
+		writeNode(sb, n.Relations)
+	} else {
+		sb.WriteString(" :relations <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :restart_seqs %t", n.RestartSeqs))
+	sb.WriteString(fmt.Sprintf(" :behavior %d", n.Behavior))
+	sb.WriteString("}")
+}
+
+func writeCommentStmt(sb *strings.Builder, n *CommentStmt) {
+	sb.WriteString("{COMMENTSTMT")
+	sb.WriteString(fmt.Sprintf(" :objtype %d", n.Objtype))
+	sb.WriteString(" :object ")
+	writeNode(sb, n.Object)
+	if n.Comment != "" {
+		sb.WriteString(" :comment \"" + escapeString(n.Comment) + "\"")
+	} else {
+		sb.WriteString(" :comment <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeSecLabelStmt(sb *strings.Builder, n *SecLabelStmt) {
+	sb.WriteString("{SECLABELSTMT")
+	sb.WriteString(fmt.Sprintf(" :objtype %d", n.Objtype))
+	sb.WriteString(" :object ")
+	writeNode(sb, n.Object)
+	if n.Provider != "" {
+		sb.WriteString(" :provider \"" + escapeString(n.Provider) + "\"")
+	} else {
+		sb.WriteString(" :provider <>")
+	}
+	if n.Label != "" {
+		sb.WriteString(" :label \"" + escapeString(n.Label) + "\"")
+	} else {
+		sb.WriteString(" :label <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeDeclareCursorStmt(sb *strings.Builder, n *DeclareCursorStmt) {
+	sb.WriteString("{DECLARECURSORSTMT")
+	if n.Portalname != "" {
+		sb.WriteString(" :portalname \"" + escapeString(n.Portalname) + "\"")
+	} else {
+		sb.WriteString(" :portalname <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :options %d", n.Options))
+	sb.WriteString(" :query ")
+	writeNode(sb, n.Query)
+	sb.WriteString("}")
+}
+
+func writeClosePortalStmt(sb *strings.Builder, n *ClosePortalStmt) {
+	sb.WriteString("{CLOSEPORTALSTMT")
+	if n.Portalname != "" {
+		sb.WriteString(" :portalname \"" + escapeString(n.Portalname) + "\"")
+	} else {
+		sb.WriteString(" :portalname <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeFetchStmt(sb *strings.Builder, n *FetchStmt) {
+	sb.WriteString("{FETCHSTMT")
+	sb.WriteString(fmt.Sprintf(" :direction %d", n.Direction))
+	sb.WriteString(fmt.Sprintf(" :howMany %d", n.HowMany))
+	if n.Portalname != "" {
+		sb.WriteString(" :portalname \"" + escapeString(n.Portalname) + "\"")
+	} else {
+		sb.WriteString(" :portalname <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :ismove %t", n.Ismove))
+	sb.WriteString("}")
+}
+
+func writeCreateStatsStmt(sb *strings.Builder, n *CreateStatsStmt) {
+	sb.WriteString("{CREATESTATSSTMT")
+	if n.Defnames != nil {
+		sb.WriteString(" :defnames ")
+		writeNode(sb, n.Defnames)
+	} else {
+		sb.WriteString(" :defnames <>")
+	}
+	if n.StatTypes != nil {
+		sb.WriteString(" :stat_types ")
+		writeNode(sb, n.StatTypes)
+	} else {
+		sb.WriteString(" :stat_types <>")
+	}
+	if n.Exprs != nil {
+		sb.WriteString(" :exprs ")
+		writeNode(sb, n.Exprs)
+	} else {
+		sb.WriteString(" :exprs <>")
+	}
+	if n.Relations != nil {
+		sb.WriteString(" :relations ")
+		writeNode(sb, n.Relations)
+	} else {
+		sb.WriteString(" :relations <>")
+	}
+	if n.Stxcomment != "" {
+		sb.WriteString(" :stxcomment \"" + escapeString(n.Stxcomment) + "\"")
+	} else {
+		sb.WriteString(" :stxcomment <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :transformed %t", n.Transformed))
+	sb.WriteString(fmt.Sprintf(" :if_not_exists %t", n.IfNotExists))
+	sb.WriteString("}")
+}
+
+func writeStatsElem(sb *strings.Builder, n *StatsElem) {
+	sb.WriteString("{STATSELEM")
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	sb.WriteString(" :expr ")
+	writeNode(sb, n.Expr)
+	sb.WriteString("}")
+}
+
+func writeAlterStatsStmt(sb *strings.Builder, n *AlterStatsStmt) {
+	sb.WriteString("{ALTERSTATSSTMT")
+	if n.Defnames != nil {
+		sb.WriteString(" :defnames ")
+		writeNode(sb, n.Defnames)
+	} else {
+		sb.WriteString(" :defnames <>")
+	}
+	sb.WriteString(" :stxstattarget ")
+	writeNode(sb, n.Stxstattarget)
+	sb.WriteString(fmt.Sprintf(" :missing_ok %t", n.MissingOk))
+	sb.WriteString("}")
+}
+
+func writeCreateFunctionStmt(sb *strings.Builder, n *CreateFunctionStmt) {
+	sb.WriteString("{CREATEFUNCTIONSTMT")
+	sb.WriteString(fmt.Sprintf(" :is_procedure %t", n.IsProcedure))
+	sb.WriteString(fmt.Sprintf(" :replace %t", n.Replace))
+	if n.Funcname != nil {
+		sb.WriteString(" :funcname ")
+		writeNode(sb, n.Funcname)
+	} else {
+		sb.WriteString(" :funcname <>")
+	}
+	if n.Parameters != nil {
+		sb.WriteString(" :parameters ")
+		writeNode(sb, n.Parameters)
+	} else {
+		sb.WriteString(" :parameters <>")
+	}
+	if n.ReturnType != nil {
+		sb.WriteString(" :returnType ")
+		writeNode(sb, n.ReturnType)
+	} else {
+		sb.WriteString(" :returnType <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString(" :sql_body ")
+	writeNode(sb, n.SqlBody)
+	sb.WriteString("}")
+}
+
+func writeFunctionParameter(sb *strings.Builder, n *FunctionParameter) {
+	sb.WriteString("{FUNCTIONPARAMETER")
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	if n.ArgType != nil {
+		sb.WriteString(" :argType ")
+		writeNode(sb, n.ArgType)
+	} else {
+		sb.WriteString(" :argType <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :mode %d", n.Mode))
+	sb.WriteString(" :defexpr ")
+	writeNode(sb, n.Defexpr)
+	sb.WriteString("}")
+}
+
+func writeAlterFunctionStmt(sb *strings.Builder, n *AlterFunctionStmt) {
+	sb.WriteString("{ALTERFUNCTIONSTMT")
+	sb.WriteString(fmt.Sprintf(" :objtype %d", n.Objtype))
+	if n.Func != nil {
+		sb.WriteString(" :func ")
+		writeNode(sb, n.Func)
+	} else {
+		sb.WriteString(" :func <>")
+	}
+	if n.Actions != nil {
+		sb.WriteString(" :actions ")
+		writeNode(sb, n.Actions)
+	} else {
+		sb.WriteString(" :actions <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeDoStmt(sb *strings.Builder, n *DoStmt) {
+	sb.WriteString("{DOSTMT")
+	if n.Args != nil {
+		sb.WriteString(" :args ")
+		writeNode(sb, n.Args)
+	} else {
+		sb.WriteString(" :args <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeInlineCodeBlock(sb *strings.Builder, n *InlineCodeBlock) {
+	sb.WriteString("{INLINECODEBLOCK")
+	if n.SourceText != "" {
+		sb.WriteString(" :source_text \"" + escapeString(n.SourceText) + "\"")
+	} else {
+		sb.WriteString(" :source_text <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :langOid %d", n.LangOid))
+	sb.WriteString(fmt.Sprintf(" :langIsTrusted %t", n.LangIsTrusted))
+	sb.WriteString(fmt.Sprintf(" :atomic %t", n.Atomic))
+	sb.WriteString("}")
+}
+
+func writeCallStmt(sb *strings.Builder, n *CallStmt) {
+	sb.WriteString("{CALLSTMT")
+	if n.Funccall != nil {
+		sb.WriteString(" :funccall ")
+		writeNode(sb, n.Funccall)
+	} else {
+		sb.WriteString(" :funccall <>")
+	}
+	if n.Funcexpr != nil {
+		sb.WriteString(" :funcexpr ")
+		writeNode(sb, n.Funcexpr)
+	} else {
+		sb.WriteString(" :funcexpr <>")
+	}
+	if n.Outargs != nil {
+		sb.WriteString(" :outargs ")
+		writeNode(sb, n.Outargs)
+	} else {
+		sb.WriteString(" :outargs <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeCallContext(sb *strings.Builder, n *CallContext) {
+	sb.WriteString("{CALLCONTEXT")
+	sb.WriteString(fmt.Sprintf(" :atomic %t", n.Atomic))
+	sb.WriteString("}")
+}
+
+func writeRenameStmt(sb *strings.Builder, n *RenameStmt) {
+	sb.WriteString("{RENAMESTMT")
+	sb.WriteString(fmt.Sprintf(" :renameType %d", n.RenameType))
+	sb.WriteString(fmt.Sprintf(" :relationType %d", n.RelationType))
+	if n.Relation != nil {
+		sb.WriteString(" :relation ")
+		writeNode(sb, n.Relation)
+	} else {
+		sb.WriteString(" :relation <>")
+	}
+	sb.WriteString(" :object ")
+	writeNode(sb, n.Object)
+	if n.Subname != "" {
+		sb.WriteString(" :subname \"" + escapeString(n.Subname) + "\"")
+	} else {
+		sb.WriteString(" :subname <>")
+	}
+	if n.Newname != "" {
+		sb.WriteString(" :newname \"" + escapeString(n.Newname) + "\"")
+	} else {
+		sb.WriteString(" :newname <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :behavior %d", n.Behavior))
+	sb.WriteString(fmt.Sprintf(" :missing_ok %t", n.MissingOk))
+	sb.WriteString("}")
+}
+
+func writeAlterObjectDependsStmt(sb *strings.Builder, n *AlterObjectDependsStmt) {
+	sb.WriteString("{ALTEROBJECTDEPENDSSTMT")
+	sb.WriteString(fmt.Sprintf(" :objectType %d", n.ObjectType))
+	if n.Relation != nil {
+		sb.WriteString(" :relation ")
+		writeNode(sb, n.Relation)
+	} else {
+		sb.WriteString(" :relation <>")
+	}
+	sb.WriteString(" :object ")
+	writeNode(sb, n.Object)
+	if n.Extname != nil {
+		sb.WriteString(" :extname ")
+		writeNode(sb, n.Extname)
+	} else {
+		sb.WriteString(" :extname <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :remove %t", n.Remove))
+	sb.WriteString("}")
+}
+
+func writeAlterObjectSchemaStmt(sb *strings.Builder, n *AlterObjectSchemaStmt) {
+	sb.WriteString("{ALTEROBJECTSCHEMASTMT")
+	sb.WriteString(fmt.Sprintf(" :objectType %d", n.ObjectType))
+	if n.Relation != nil {
+		sb.WriteString(" :relation ")
+		writeNode(sb, n.Relation)
+	} else {
+		sb.WriteString(" :relation <>")
+	}
+	sb.WriteString(" :object ")
+	writeNode(sb, n.Object)
+	if n.Newschema != "" {
+		sb.WriteString(" :newschema \"" + escapeString(n.Newschema) + "\"")
+	} else {
+		sb.WriteString(" :newschema <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :missing_ok %t", n.MissingOk))
+	sb.WriteString("}")
+}
+
+func writeAlterOwnerStmt(sb *strings.Builder, n *AlterOwnerStmt) {
+	sb.WriteString("{ALTEROWNERSTMT")
+	sb.WriteString(fmt.Sprintf(" :objectType %d", n.ObjectType))
+	if n.Relation != nil {
+		sb.WriteString(" :relation ")
+		writeNode(sb, n.Relation)
+	} else {
+		sb.WriteString(" :relation <>")
+	}
+	sb.WriteString(" :object ")
+	writeNode(sb, n.Object)
+	if n.Newowner != nil {
+		sb.WriteString(" :newowner ")
+		writeNode(sb, n.Newowner)
+	} else {
+		sb.WriteString(" :newowner <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeAlterOperatorStmt(sb *strings.Builder, n *AlterOperatorStmt) {
+	sb.WriteString("{ALTEROPERATORSTMT")
+	if n.Opername != nil {
+		sb.WriteString(" :opername ")
+		writeNode(sb, n.Opername)
+	} else {
+		sb.WriteString(" :opername <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeAlterTypeStmt(sb *strings.Builder, n *AlterTypeStmt) {
+	sb.WriteString("{ALTERTYPESTMT")
+	if n.TypeName != nil {
+		sb.WriteString(" :typeName ")
+		writeNode(sb, n.TypeName)
+	} else {
+		sb.WriteString(" :typeName <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeRuleStmt(sb *strings.Builder, n *RuleStmt) {
+	sb.WriteString("{RULESTMT")
+	if n.Relation != nil {
+		sb.WriteString(" :relation ")
+		writeNode(sb, n.Relation)
+	} else {
+		sb.WriteString(" :relation <>")
+	}
+	if n.Rulename != "" {
+		sb.WriteString(" :rulename \"" + escapeString(n.Rulename) + "\"")
+	} else {
+		sb.WriteString(" :rulename <>")
+	}
+	sb.WriteString(" :whereClause ")
+	writeNode(sb, n.WhereClause)
+	sb.WriteString(fmt.Sprintf(" :event %d", n.Event))
+	sb.WriteString(fmt.Sprintf(" :instead %t", n.Instead))
+	if n.Actions != nil {
+		sb.WriteString(" :actions ")
+		writeNode(sb, n.Actions)
+	} else {
+		sb.WriteString(" :actions <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :replace %t", n.Replace))
+	sb.WriteString("}")
+}
+
+func writeNotifyStmt(sb *strings.Builder, n *NotifyStmt) {
+	sb.WriteString("{NOTIFYSTMT")
+	if n.Conditionname != "" {
+		sb.WriteString(" :conditionname \"" + escapeString(n.Conditionname) + "\"")
+	} else {
+		sb.WriteString(" :conditionname <>")
+	}
+	if n.Payload != "" {
+		sb.WriteString(" :payload \"" + escapeString(n.Payload) + "\"")
+	} else {
+		sb.WriteString(" :payload <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeListenStmt(sb *strings.Builder, n *ListenStmt) {
+	sb.WriteString("{LISTENSTMT")
+	if n.Conditionname != "" {
+		sb.WriteString(" :conditionname \"" + escapeString(n.Conditionname) + "\"")
+	} else {
+		sb.WriteString(" :conditionname <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeUnlistenStmt(sb *strings.Builder, n *UnlistenStmt) {
+	sb.WriteString("{UNLISTENSTMT")
+	if n.Conditionname != "" {
+		sb.WriteString(" :conditionname \"" + escapeString(n.Conditionname) + "\"")
+	} else {
+		sb.WriteString(" :conditionname <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeTransactionStmt(sb *strings.Builder, n *TransactionStmt) {
+	sb.WriteString("{TRANSACTIONSTMT")
+	sb.WriteString(fmt.Sprintf(" :kind %d", n.Kind))
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	if n.SavepointName != "" {
+		sb.WriteString(" :savepoint_name \"" + escapeString(n.SavepointName) + "\"")
+	} else {
+		sb.WriteString(" :savepoint_name <>")
+	}
+	if n.Gid != "" {
+		sb.WriteString(" :gid \"" + escapeString(n.Gid) + "\"")
+	} else {
+		sb.WriteString(" :gid <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :chain %t", n.Chain))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeCompositeTypeStmt(sb *strings.Builder, n *CompositeTypeStmt) {
+	sb.WriteString("{COMPOSITETYPESTMT")
+	if n.Typevar != nil {
+		sb.WriteString(" :typevar ")
+		writeNode(sb, n.Typevar)
+	} else {
+		sb.WriteString(" :typevar <>")
+	}
+	if n.Coldeflist != nil {
+		sb.WriteString(" :coldeflist ")
+		writeNode(sb, n.Coldeflist)
+	} else {
+		sb.WriteString(" :coldeflist <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeCreateEnumStmt(sb *strings.Builder, n *CreateEnumStmt) {
+	sb.WriteString("{CREATEENUMSTMT")
+	if n.TypeName != nil {
+		sb.WriteString(" :typeName ")
+		writeNode(sb, n.TypeName)
+	} else {
+		sb.WriteString(" :typeName <>")
+	}
+	if n.Vals != nil {
+		sb.WriteString(" :vals ")
+		writeNode(sb, n.Vals)
+	} else {
+		sb.WriteString(" :vals <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeCreateRangeStmt(sb *strings.Builder, n *CreateRangeStmt) {
+	sb.WriteString("{CREATERANGESTMT")
+	if n.TypeName != nil {
+		sb.WriteString(" :typeName ")
+		writeNode(sb, n.TypeName)
+	} else {
+		sb.WriteString(" :typeName <>")
+	}
+	if n.Params != nil {
+		sb.WriteString(" :params ")
+		writeNode(sb, n.Params)
+	} else {
+		sb.WriteString(" :params <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeAlterEnumStmt(sb *strings.Builder, n *AlterEnumStmt) {
+	sb.WriteString("{ALTERENUMSTMT")
+	if n.TypeName != nil {
+		sb.WriteString(" :typeName ")
+		writeNode(sb, n.TypeName)
+	} else {
+		sb.WriteString(" :typeName <>")
+	}
+	if n.OldVal != "" {
+		sb.WriteString(" :oldVal \"" + escapeString(n.OldVal) + "\"")
+	} else {
+		sb.WriteString(" :oldVal <>")
+	}
+	if n.NewVal != "" {
+		sb.WriteString(" :newVal \"" + escapeString(n.NewVal) + "\"")
+	} else {
+		sb.WriteString(" :newVal <>")
+	}
+	if n.NewValNeighbor != "" {
+		sb.WriteString(" :newValNeighbor \"" + escapeString(n.NewValNeighbor) + "\"")
+	} else {
+		sb.WriteString(" :newValNeighbor <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :newValIsAfter %t", n.NewValIsAfter))
+	sb.WriteString(fmt.Sprintf(" :skipIfNewValExists %t", n.SkipIfNewValExists))
+	sb.WriteString("}")
+}
+
+func writeLoadStmt(sb *strings.Builder, n *LoadStmt) {
+	sb.WriteString("{LOADSTMT")
+	if n.Filename != "" {
+		sb.WriteString(" :filename \"" + escapeString(n.Filename) + "\"")
+	} else {
+		sb.WriteString(" :filename <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeCreatedbStmt(sb *strings.Builder, n *CreatedbStmt) {
+	sb.WriteString("{CREATEDBSTMT")
+	if n.Dbname != "" {
+		sb.WriteString(" :dbname \"" + escapeString(n.Dbname) + "\"")
+	} else {
+		sb.WriteString(" :dbname <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeAlterDatabaseStmt(sb *strings.Builder, n *AlterDatabaseStmt) {
+	sb.WriteString("{ALTERDATABASESTMT")
+	if n.Dbname != "" {
+		sb.WriteString(" :dbname \"" + escapeString(n.Dbname) + "\"")
+	} else {
+		sb.WriteString(" :dbname <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeAlterDatabaseRefreshCollStmt(sb *strings.Builder, n *AlterDatabaseRefreshCollStmt) {
+	sb.WriteString("{ALTERDATABASEREFRESHCOLLSTMT")
+	if n.Dbname != "" {
+		sb.WriteString(" :dbname \"" + escapeString(n.Dbname) + "\"")
+	} else {
+		sb.WriteString(" :dbname <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeAlterDatabaseSetStmt(sb *strings.Builder, n *AlterDatabaseSetStmt) {
+	sb.WriteString("{ALTERDATABASESETSTMT")
+	if n.Dbname != "" {
+		sb.WriteString(" :dbname \"" + escapeString(n.Dbname) + "\"")
+	} else {
+		sb.WriteString(" :dbname <>")
+	}
+	if n.Setstmt != nil {
+		sb.WriteString(" :setstmt ")
+		writeNode(sb, n.Setstmt)
+	} else {
+		sb.WriteString(" :setstmt <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeDropdbStmt(sb *strings.Builder, n *DropdbStmt) {
+	sb.WriteString("{DROPDBSTMT")
+	if n.Dbname != "" {
+		sb.WriteString(" :dbname \"" + escapeString(n.Dbname) + "\"")
+	} else {
+		sb.WriteString(" :dbname <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :missing_ok %t", n.MissingOk))
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeAlterSystemStmt(sb *strings.Builder, n *AlterSystemStmt) {
+	sb.WriteString("{ALTERSYSTEMSTMT")
+	if n.Setstmt != nil {
+		sb.WriteString(" :setstmt ")
+		writeNode(sb, n.Setstmt)
+	} else {
+		sb.WriteString(" :setstmt <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeClusterStmt(sb *strings.Builder, n *ClusterStmt) {
+	sb.WriteString("{CLUSTERSTMT")
+	if n.Relation != nil {
+		sb.WriteString(" :relation ")
+		writeNode(sb, n.Relation)
+	} else {
+		sb.WriteString(" :relation <>")
+	}
+	if n.Indexname != "" {
+		sb.WriteString(" :indexname \"" + escapeString(n.Indexname) + "\"")
+	} else {
+		sb.WriteString(" :indexname <>")
+	}
+	if n.Params != nil {
+		sb.WriteString(" :params ")
+		writeNode(sb, n.Params)
+	} else {
+		sb.WriteString(" :params <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeVacuumStmt(sb *strings.Builder, n *VacuumStmt) {
+	sb.WriteString("{VACUUMSTMT")
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	if n.Rels != nil {
+		sb.WriteString(" :rels ")
+		writeNode(sb, n.Rels)
+	} else {
+		sb.WriteString(" :rels <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :is_vacuumcmd %t", n.IsVacuumcmd))
+	sb.WriteString("}")
+}
+
+func writeVacuumRelation(sb *strings.Builder, n *VacuumRelation) {
+	sb.WriteString("{VACUUMRELATION")
+	if n.Relation != nil {
+		sb.WriteString(" :relation ")
+		writeNode(sb, n.Relation)
+	} else {
+		sb.WriteString(" :relation <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :oid %d", n.Oid))
+	if n.VaCols != nil {
+		sb.WriteString(" :va_cols ")
+		writeNode(sb, n.VaCols)
+	} else {
+		sb.WriteString(" :va_cols <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeExplainStmt(sb *strings.Builder, n *ExplainStmt) {
+	sb.WriteString("{EXPLAINSTMT")
+	sb.WriteString(" :query ")
+	writeNode(sb, n.Query)
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeCreateTableAsStmt(sb *strings.Builder, n *CreateTableAsStmt) {
+	sb.WriteString("{CREATETABLEASSTMT")
+	sb.WriteString(" :query ")
+	writeNode(sb, n.Query)
+	if n.Into != nil {
+		sb.WriteString(" :into ")
+		writeNode(sb, n.Into)
+	} else {
+		sb.WriteString(" :into <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :objtype %d", n.Objtype))
+	sb.WriteString(fmt.Sprintf(" :is_select_into %t", n.IsSelectInto))
+	sb.WriteString(fmt.Sprintf(" :if_not_exists %t", n.IfNotExists))
+	sb.WriteString("}")
+}
+
+func writeRefreshMatViewStmt(sb *strings.Builder, n *RefreshMatViewStmt) {
+	sb.WriteString("{REFRESHMATVIEWSTMT")
+	sb.WriteString(fmt.Sprintf(" :concurrent %t", n.Concurrent))
+	sb.WriteString(fmt.Sprintf(" :skipData %t", n.SkipData))
+	if n.Relation != nil {
+		sb.WriteString(" :relation ")
+		writeNode(sb, n.Relation)
+	} else {
+		sb.WriteString(" :relation <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeCheckPointStmt(sb *strings.Builder, n *CheckPointStmt) {
+	sb.WriteString("{CHECKPOINTSTMT")
+	sb.WriteString("}")
+}
+
+func writeDiscardStmt(sb *strings.Builder, n *DiscardStmt) {
+	sb.WriteString("{DISCARDSTMT")
+	sb.WriteString(fmt.Sprintf(" :target %d", n.Target))
+	sb.WriteString("}")
+}
+
+func writeLockStmt(sb *strings.Builder, n *LockStmt) {
+	sb.WriteString("{LOCKSTMT")
+	if n.Relations != nil {
+		sb.WriteString(" :relations ")
+		writeNode(sb, n.Relations)
+	} else {
+		sb.WriteString(" :relations <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :mode %d", n.Mode))
+	sb.WriteString(fmt.Sprintf(" :nowait %t", n.Nowait))
+	sb.WriteString("}")
+}
+
+func writeConstraintsSetStmt(sb *strings.Builder, n *ConstraintsSetStmt) {
+	sb.WriteString("{CONSTRAINTSSETSTMT")
+	if n.Constraints != nil {
+		sb.WriteString(" :constraints ")
+		writeNode(sb, n.Constraints)
+	} else {
+		sb.WriteString(" :constraints <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :deferred %t", n.Deferred))
+	sb.WriteString("}")
+}
+
+func writeReindexStmt(sb *strings.Builder, n *ReindexStmt) {
+	sb.WriteString("{REINDEXSTMT")
+	sb.WriteString(fmt.Sprintf(" :kind %d", n.Kind))
+	if n.Relation != nil {
+		sb.WriteString(" :relation ")
+		writeNode(sb, n.Relation)
+	} else {
+		sb.WriteString(" :relation <>")
+	}
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	if n.Params != nil {
+		sb.WriteString(" :params ")
+		writeNode(sb, n.Params)
+	} else {
+		sb.WriteString(" :params <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeCreateConversionStmt(sb *strings.Builder, n *CreateConversionStmt) {
+	sb.WriteString("{CREATECONVERSIONSTMT")
+	if n.ConversionName != nil {
+		sb.WriteString(" :conversion_name ")
+		writeNode(sb, n.ConversionName)
+	} else {
+		sb.WriteString(" :conversion_name <>")
+	}
+	if n.ForEncodingName != "" {
+		sb.WriteString(" :for_encoding_name \"" + escapeString(n.ForEncodingName) + "\"")
+	} else {
+		sb.WriteString(" :for_encoding_name <>")
+	}
+	if n.ToEncodingName != "" {
+		sb.WriteString(" :to_encoding_name \"" + escapeString(n.ToEncodingName) + "\"")
+	} else {
+		sb.WriteString(" :to_encoding_name <>")
+	}
+	if n.FuncName != nil {
+		sb.WriteString(" :func_name ")
+		writeNode(sb, n.FuncName)
+	} else {
+		sb.WriteString(" :func_name <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :def %t", n.Def))
+	sb.WriteString("}")
+}
+
+func writeCreateCastStmt(sb *strings.Builder, n *CreateCastStmt) {
+	sb.WriteString("{CREATECASTSTMT")
+	if n.Sourcetype != nil {
+		sb.WriteString(" :sourcetype ")
+		writeNode(sb, n.Sourcetype)
+	} else {
+		sb.WriteString(" :sourcetype <>")
+	}
+	if n.Targettype != nil {
+		sb.WriteString(" :targettype ")
+		writeNode(sb, n.Targettype)
+	} else {
+		sb.WriteString(" :targettype <>")
+	}
+	if n.Func != nil {
+		sb.WriteString(" :func ")
+		writeNode(sb, n.Func)
+	} else {
+		sb.WriteString(" :func <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :context %d", n.Context))
+	sb.WriteString(fmt.Sprintf(" :inout %t", n.Inout))
+	sb.WriteString("}")
+}
+
+func writeCreateTransformStmt(sb *strings.Builder, n *CreateTransformStmt) {
+	sb.WriteString("{CREATETRANSFORMSTMT")
+	sb.WriteString(fmt.Sprintf(" :replace %t", n.Replace))
+	if n.TypeName != nil {
+		sb.WriteString(" :type_name ")
+		writeNode(sb, n.TypeName)
+	} else {
+		sb.WriteString(" :type_name <>")
+	}
+	if n.Lang != "" {
+		sb.WriteString(" :lang \"" + escapeString(n.Lang) + "\"")
+	} else {
+		sb.WriteString(" :lang <>")
+	}
+	if n.Fromsql != nil {
+		sb.WriteString(" :fromsql ")
+		writeNode(sb, n.Fromsql)
+	} else {
+		sb.WriteString(" :fromsql <>")
+	}
+	if n.Tosql != nil {
+		sb.WriteString(" :tosql ")
+		writeNode(sb, n.Tosql)
+	} else {
+		sb.WriteString(" :tosql <>")
+	}
+	sb.WriteString("}")
+}
+
+func writePrepareStmt(sb *strings.Builder, n *PrepareStmt) {
+	sb.WriteString("{PREPARESTMT")
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	if n.Argtypes != nil {
+		sb.WriteString(" :argtypes ")
+		writeNode(sb, n.Argtypes)
+	} else {
+		sb.WriteString(" :argtypes <>")
+	}
+	sb.WriteString(" :query ")
+	writeNode(sb, n.Query)
+	sb.WriteString("}")
+}
+
+func writeExecuteStmt(sb *strings.Builder, n *ExecuteStmt) {
+	sb.WriteString("{EXECUTESTMT")
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	if n.Params != nil {
+		sb.WriteString(" :params ")
+		writeNode(sb, n.Params)
+	} else {
+		sb.WriteString(" :params <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeDeallocateStmt(sb *strings.Builder, n *DeallocateStmt) {
+	sb.WriteString("{DEALLOCATESTMT")
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :isall %t", n.Isall))
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeDropOwnedStmt(sb *strings.Builder, n *DropOwnedStmt) {
+	sb.WriteString("{DROPOWNEDSTMT")
+	if n.Roles != nil {
+		sb.WriteString(" :roles ")
+		writeNode(sb, n.Roles)
+	} else {
+		sb.WriteString(" :roles <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :behavior %d", n.Behavior))
+	sb.WriteString("}")
+}
+
+func writeReassignOwnedStmt(sb *strings.Builder, n *ReassignOwnedStmt) {
+	sb.WriteString("{REASSIGNOWNEDSTMT")
+	if n.Roles != nil {
+		sb.WriteString(" :roles ")
+		writeNode(sb, n.Roles)
+	} else {
+		sb.WriteString(" :roles <>")
+	}
+	if n.Newrole != nil {
+		sb.WriteString(" :newrole ")
+		writeNode(sb, n.Newrole)
+	} else {
+		sb.WriteString(" :newrole <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeAlterTSDictionaryStmt(sb *strings.Builder, n *AlterTSDictionaryStmt) {
+	sb.WriteString("{ALTERTSDICTIONARYSTMT")
+	if n.Dictname != nil {
+		sb.WriteString(" :dictname ")
+		writeNode(sb, n.Dictname)
+	} else {
+		sb.WriteString(" :dictname <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeAlterTSConfigurationStmt(sb *strings.Builder, n *AlterTSConfigurationStmt) {
+	sb.WriteString("{ALTERTSCONFIGURATIONSTMT")
+	sb.WriteString(fmt.Sprintf(" :kind %d", n.Kind))
+	if n.Cfgname != nil {
+		sb.WriteString(" :cfgname ")
+		writeNode(sb, n.Cfgname)
+	} else {
+		sb.WriteString(" :cfgname <>")
+	}
+	if n.Tokentype != nil {
+		sb.WriteString(" :tokentype ")
+		writeNode(sb, n.Tokentype)
+	} else {
+		sb.WriteString(" :tokentype <>")
+	}
+	if n.Dicts != nil {
+		sb.WriteString(" :dicts ")
+		writeNode(sb, n.Dicts)
+	} else {
+		sb.WriteString(" :dicts <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :override %t", n.Override))
+	sb.WriteString(fmt.Sprintf(" :replace %t", n.Replace))
+	sb.WriteString(fmt.Sprintf(" :missing_ok %t", n.MissingOk))
+	sb.WriteString("}")
+}
+
+func writePublicationTable(sb *strings.Builder, n *PublicationTable) {
+	sb.WriteString("{PUBLICATIONTABLE")
+	if n.Relation != nil {
+		sb.WriteString(" :relation ")
+		writeNode(sb, n.Relation)
+	} else {
+		sb.WriteString(" :relation <>")
+	}
+	sb.WriteString(" :whereClause ")
+	writeNode(sb, n.WhereClause)
+	if n.Columns != nil {
+		sb.WriteString(" :columns ")
+		writeNode(sb, n.Columns)
+	} else {
+		sb.WriteString(" :columns <>")
+	}
+	sb.WriteString("}")
+}
+
+func writePublicationObjSpec(sb *strings.Builder, n *PublicationObjSpec) {
+	sb.WriteString("{PUBLICATIONOBJSPEC")
+	sb.WriteString(fmt.Sprintf(" :pubobjtype %d", n.Pubobjtype))
+	if n.Name != "" {
+		sb.WriteString(" :name \"" + escapeString(n.Name) + "\"")
+	} else {
+		sb.WriteString(" :name <>")
+	}
+	if n.Pubtable != nil {
+		sb.WriteString(" :pubtable ")
+		writeNode(sb, n.Pubtable)
+	} else {
+		sb.WriteString(" :pubtable <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeCreatePublicationStmt(sb *strings.Builder, n *CreatePublicationStmt) {
+	sb.WriteString("{CREATEPUBLICATIONSTMT")
+	if n.Pubname != "" {
+		sb.WriteString(" :pubname \"" + escapeString(n.Pubname) + "\"")
+	} else {
+		sb.WriteString(" :pubname <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	if n.Pubobjects != nil {
+		sb.WriteString(" :pubobjects ")
+		writeNode(sb, n.Pubobjects)
+	} else {
+		sb.WriteString(" :pubobjects <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :for_all_tables %t", n.ForAllTables))
+	sb.WriteString("}")
+}
+
+func writeAlterPublicationStmt(sb *strings.Builder, n *AlterPublicationStmt) {
+	sb.WriteString("{ALTERPUBLICATIONSTMT")
+	if n.Pubname != "" {
+		sb.WriteString(" :pubname \"" + escapeString(n.Pubname) + "\"")
+	} else {
+		sb.WriteString(" :pubname <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	if n.Pubobjects != nil {
+		sb.WriteString(" :pubobjects ")
+		writeNode(sb, n.Pubobjects)
+	} else {
+		sb.WriteString(" :pubobjects <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :for_all_tables %t", n.ForAllTables))
+	sb.WriteString(fmt.Sprintf(" :action %d", n.Action))
+	sb.WriteString("}")
+}
+
+func writeCreateSubscriptionStmt(sb *strings.Builder, n *CreateSubscriptionStmt) {
+	sb.WriteString("{CREATESUBSCRIPTIONSTMT")
+	if n.Subname != "" {
+		sb.WriteString(" :subname \"" + escapeString(n.Subname) + "\"")
+	} else {
+		sb.WriteString(" :subname <>")
+	}
+	if n.Conninfo != "" {
+		sb.WriteString(" :conninfo \"" + escapeString(n.Conninfo) + "\"")
+	} else {
+		sb.WriteString(" :conninfo <>")
+	}
+	if n.Publication != nil {
+		sb.WriteString(" :publication ")
+		writeNode(sb, n.Publication)
+	} else {
+		sb.WriteString(" :publication <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeAlterSubscriptionStmt(sb *strings.Builder, n *AlterSubscriptionStmt) {
+	sb.WriteString("{ALTERSUBSCRIPTIONSTMT")
+	sb.WriteString(fmt.Sprintf(" :kind %d", n.Kind))
+	if n.Subname != "" {
+		sb.WriteString(" :subname \"" + escapeString(n.Subname) + "\"")
+	} else {
+		sb.WriteString(" :subname <>")
+	}
+	if n.Conninfo != "" {
+		sb.WriteString(" :conninfo \"" + escapeString(n.Conninfo) + "\"")
+	} else {
+		sb.WriteString(" :conninfo <>")
+	}
+	if n.Publication != nil {
+		sb.WriteString(" :publication ")
+		writeNode(sb, n.Publication)
+	} else {
+		sb.WriteString(" :publication <>")
+	}
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	sb.WriteString("}")
+}
+
+func writeDropSubscriptionStmt(sb *strings.Builder, n *DropSubscriptionStmt) {
+	sb.WriteString("{DROPSUBSCRIPTIONSTMT")
+	if n.Subname != "" {
+		sb.WriteString(" :subname \"" + escapeString(n.Subname) + "\"")
+	} else {
+		sb.WriteString(" :subname <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :missing_ok %t", n.MissingOk))
+	sb.WriteString(fmt.Sprintf(" :behavior %d", n.Behavior))
+	sb.WriteString("}")
+}
+
+func writeReturningOption(sb *strings.Builder, n *ReturningOption) {
+	sb.WriteString("{RETURNINGOPTION")
+	sb.WriteString(fmt.Sprintf(" :option %d", n.Option))
+	if n.Value != "" {
+		sb.WriteString(" :value \"" + escapeString(n.Value) + "\"")
+	} else {
+		sb.WriteString(" :value <>")
+	}
+	sb.WriteString(fmt.Sprintf(" :location %d", n.Location))
+	sb.WriteString("}")
+}
+
+func writeReturningClause(sb *strings.Builder, n *ReturningClause) {
+	sb.WriteString("{RETURNINGCLAUSE")
+	if n.Options != nil {
+		sb.WriteString(" :options ")
+		writeNode(sb, n.Options)
+	} else {
+		sb.WriteString(" :options <>")
+	}
+	if n.Exprs != nil {
+		sb.WriteString(" :exprs ")
+		writeNode(sb, n.Exprs)
+	} else {
+		sb.WriteString(" :exprs <>")
+	}
+	sb.WriteString("}")
+}
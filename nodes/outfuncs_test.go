@@ -78,7 +78,7 @@ func TestNodeToString_Integer(t *testing.T) {
 }
 
 func TestNodeToString_String(t *testing.T) {
-	n := &String{Str: "hello"}
+	n := &String{Sval: "hello"}
 	result := NodeToString(n)
 	if result != `"hello"` {
 		t.Errorf(`expected "hello", got: %s`, result)
@@ -120,8 +120,8 @@ func TestNodeToString_CreateStmt(t *testing.T) {
 					TypeName: &TypeName{
 						Names: &List{
 							Items: []Node{
-								&String{Str: "pg_catalog"},
-								&String{Str: "int4"},
+								&String{Sval: "pg_catalog"},
+								&String{Sval: "int4"},
 							},
 						},
 					},
@@ -151,12 +151,12 @@ func TestNodeToString_BoolExpr(t *testing.T) {
 			Items: []Node{
 				&ColumnRef{
 					Fields: &List{
-						Items: []Node{&String{Str: "a"}},
+						Items: []Node{&String{Sval: "a"}},
 					},
 				},
 				&ColumnRef{
 					Fields: &List{
-						Items: []Node{&String{Str: "b"}},
+						Items: []Node{&String{Sval: "b"}},
 					},
 				},
 			},
@@ -174,10 +174,40 @@ func TestNodeToString_BoolExpr(t *testing.T) {
 }
 
 func TestNodeToString_EscapeString(t *testing.T) {
-	n := &String{Str: "hello\nworld\"test\\end"}
+	n := &String{Sval: "hello\nworld\"test\\end"}
 	result := NodeToString(n)
 	expected := `"hello\nworld\"test\\end"`
 	if result != expected {
 		t.Errorf("expected %s, got: %s", expected, result)
 	}
 }
+
+func TestNodeToString_TargetEntry(t *testing.T) {
+	// The output column of: SELECT a AS x FROM t
+	tle := &TargetEntry{
+		Expr:       &Var{Varno: 1, Varattno: 2, Vartype: 23, Vartypmod: -1, Varnosyn: 1, Varattnosyn: 2, Location: 7},
+		Resno:      1,
+		Resname:    "x",
+		Resorigtbl: 16384,
+		Resorigcol: 2,
+	}
+
+	result := NodeToString(tle)
+	expected := `{TARGETENTRY :expr {VAR :varno 1 :varattno 2 :vartype 23 :vartypmod -1 :varcollid 0 ` +
+		`:varnullingrels (b) :varlevelsup 0 :varnosyn 1 :varattnosyn 2 :location 7} :resno 1 :resname "x" :ressortgroupref 0 ` +
+		`:resorigtbl 16384 :resorigcol 2 :resjunk false}`
+	if result != expected {
+		t.Errorf("expected %s, got: %s", expected, result)
+	}
+	if name := NodeTagName(T_TargetEntry); name != "TargetEntry" {
+		t.Errorf("NodeTagName(T_TargetEntry) = %q", name)
+	}
+}
+
+func TestNodeToString_FieldStore(t *testing.T) {
+	n := &FieldStore{Arg: &Param{Paramid: 1}, Fieldnums: &IntList{Items: []int{1, 3}}, Resulttype: 16390}
+	result := NodeToString(n)
+	if !strings.Contains(result, ":newvals <> :fieldnums (i 1 3) :resulttype 16390}") {
+		t.Errorf("unexpected output: %s", result)
+	}
+}
@@ -0,0 +1,3588 @@
+// Code generated by pgsema-gen. DO NOT EDIT.
+// Source: PostgreSQL nodes/parsenodes.h
+
+package nodes
+
+// Possible sources of a Query
+type QuerySource int
+
+const (
+	QSRC_ORIGINAL          QuerySource = iota // original parsetree (explicit query)
+	QSRC_PARSER                               // added by parse analysis (now unused)
+	QSRC_INSTEAD_RULE                         // added by unconditional INSTEAD rule
+	QSRC_QUAL_INSTEAD_RULE                    // added by conditional INSTEAD rule
+	QSRC_NON_INSTEAD_RULE                     // added by non-INSTEAD rule
+)
+
+// Sort ordering options for ORDER BY and CREATE INDEX
+type SortByDir int
+
+const (
+	SORTBY_DEFAULT SortByDir = iota
+	SORTBY_ASC
+	SORTBY_DESC
+	SORTBY_USING // not allowed in CREATE INDEX ...
+)
+
+type SortByNulls int
+
+const (
+	SORTBY_NULLS_DEFAULT SortByNulls = iota
+	SORTBY_NULLS_FIRST
+	SORTBY_NULLS_LAST
+)
+
+// Options for [ ALL | DISTINCT ]
+type SetQuantifier int
+
+const (
+	SET_QUANTIFIER_DEFAULT SetQuantifier = iota
+	SET_QUANTIFIER_ALL
+	SET_QUANTIFIER_DISTINCT
+)
+
+// a bitmask of privilege bits
+const (
+	ACL_INSERT       = (1 << 0) // for relations
+	ACL_SELECT       = (1 << 1)
+	ACL_UPDATE       = (1 << 2)
+	ACL_DELETE       = (1 << 3)
+	ACL_TRUNCATE     = (1 << 4)
+	ACL_REFERENCES   = (1 << 5)
+	ACL_TRIGGER      = (1 << 6)
+	ACL_EXECUTE      = (1 << 7)  // for functions
+	ACL_USAGE        = (1 << 8)  // for various object types
+	ACL_CREATE       = (1 << 9)  // for namespaces and databases
+	ACL_CREATE_TEMP  = (1 << 10) // for databases
+	ACL_CONNECT      = (1 << 11) // for databases
+	ACL_SET          = (1 << 12) // for configuration parameters
+	ACL_ALTER_SYSTEM = (1 << 13) // for configuration parameters
+	ACL_MAINTAIN     = (1 << 14) // for relations
+	N_ACL_RIGHTS     = 15        // 1 plus the last 1<<x
+	ACL_NO_RIGHTS    = 0
+)
+
+// Currently, SELECT ... FOR [KEY] UPDATE/SHARE requires UPDATE privileges
+const (
+	ACL_SELECT_FOR_UPDATE = ACL_UPDATE
+)
+
+// Query -
+// Parse analysis turns all statements into a Query tree
+// for further processing by the rewriter and planner.
+//
+// Utility statements (i.e. non-optimizable statements) have the
+// utilityStmt field set, and the rest of the Query is mostly dummy.
+//
+// Planning converts a Query tree into a Plan tree headed by a PlannedStmt
+// node --- the Query structure is not used by the executor.
+//
+// All the fields ignored for the query jumbling are not semantically
+// significant (such as alias names), as is ignored anything that can
+// be deduced from child nodes (else we'd just be double-hashing that
+// piece of information).
+type Query struct {
+	CommandType CmdType     // select|insert|update|delete|merge|utility
+	QuerySource QuerySource // where did I come from?
+	// query identifier (can be set by plugins); ignored for equal, as it
+	// might not be set; also not stored.  This is the result of the query
+	// jumble, hence ignored.
+	QueryId     uint64
+	CanSetTag   bool // do I set the command result tag?
+	UtilityStmt Node // non-null if commandType == CMD_UTILITY
+	// rtable index of target relation for INSERT/UPDATE/DELETE/MERGE; 0 for
+	// SELECT.  This is ignored in the query jumble as unrelated to the
+	// compilation of the query ID.
+	ResultRelation  int
+	HasAggs         bool  // has aggregates in tlist or havingQual
+	HasWindowFuncs  bool  // has window functions in tlist
+	HasTargetSRFs   bool  // has set-returning functions in tlist
+	HasSubLinks     bool  // has subquery SubLink
+	HasDistinctOn   bool  // distinctClause is from DISTINCT ON
+	HasRecursive    bool  // WITH RECURSIVE was specified
+	HasModifyingCTE bool  // has INSERT/UPDATE/DELETE/MERGE in WITH
+	HasForUpdate    bool  // FOR [KEY] UPDATE/SHARE was specified
+	HasRowSecurity  bool  // rewriter has applied some RLS policy
+	IsReturn        bool  // is a RETURN statement
+	CteList         *List // WITH list (of CommonTableExpr's)
+	Rtable          *List // list of range table entries
+	// list of RTEPermissionInfo nodes for the rtable entries having
+	// perminfoindex > 0
+	Rteperminfos *List
+	// table join tree (FROM and WHERE clauses);
+	// also USING clause for MERGE
+	Jointree        *FromExpr
+	MergeActionList *List // list of actions for MERGE (only)
+	// rtable index of target relation for MERGE to pull data. Initially, this
+	// is the same as resultRelation, but after query rewriting, if the target
+	// relation is a trigger-updatable view, this is the index of the expanded
+	// view subquery, whereas resultRelation is the index of the target view.
+	MergeTargetRelation int
+	MergeJoinCondition  Node            // join condition between source and target for MERGE
+	TargetList          *List           // target list (of TargetEntry)
+	Override            OverridingKind  // OVERRIDING clause
+	OnConflict          *OnConflictExpr // ON CONFLICT DO [NOTHING | UPDATE]
+	ReturningList       *List           // return-values list (of TargetEntry)
+	GroupClause         *List           // a list of SortGroupClause's
+	GroupDistinct       bool            // is the group by clause distinct?
+	GroupingSets        *List           // a list of GroupingSet's if present
+	HavingQual          Node            // qualifications applied to groups
+	WindowClause        *List           // a list of WindowClause's
+	DistinctClause      *List           // a list of SortGroupClause's
+	SortClause          *List           // a list of SortGroupClause's
+	LimitOffset         Node            // # of result tuples to skip (int8 expr)
+	LimitCount          Node            // # of result tuples to return (int8 expr)
+	LimitOption         LimitOption     // limit type
+	RowMarks            *List           // a list of RowMarkClause's
+	// set-operation tree if this is top level of
+	// a UNION/INTERSECT/EXCEPT query
+	SetOperations Node
+	// A list of pg_constraint OIDs that the query depends on to be
+	// semantically valid
+	ConstraintDeps   *List
+	WithCheckOptions *List // a list of WithCheckOption's (added during rewrite)
+	// The following two fields identify the portion of the source text string
+	// containing this query.  They are typically only populated in top-level
+	// Queries, not in sub-queries.  When not set, they might both be zero, or
+	// both be -1 meaning "unknown".
+	//
+	// start location, or -1 if unknown
+	StmtLocation ParseLoc `pg:"stmt_location"`
+	StmtLen      ParseLoc `pg:"stmt_len"` // length in bytes; 0 means "rest of string"
+}
+
+func (n *Query) Tag() NodeTag { return T_Query }
+
+// TypeName - specifies a type in definitions
+//
+// For TypeName structures generated internally, it is often easier to
+// specify the type by OID than by name.  If "names" is NIL then the
+// actual type OID is given by typeOid, otherwise typeOid is unused.
+// Similarly, if "typmods" is NIL then the actual typmod is expected to
+// be prespecified in typemod, otherwise typemod is unused.
+//
+// If pct_type is true, then names is actually a field name and we look up
+// the type of that field.  Otherwise (the normal case), names is a type
+// name possibly qualified with schema and database name.
+type TypeName struct {
+	Names       *List    // qualified name (list of String nodes)
+	TypeOid     Oid      // type identified by OID
+	Setof       bool     // is a set?
+	PctType     bool     `pg:"pct_type"` // %TYPE specified?
+	Typmods     *List    // type modifier expression(s)
+	Typemod     int32    // prespecified type modifier
+	ArrayBounds *List    // array bounds
+	Location    ParseLoc // token location, or -1 if unknown
+}
+
+func (n *TypeName) Tag() NodeTag { return T_TypeName }
+
+// ColumnRef - specifies a reference to a column, or possibly a whole tuple
+//
+// The "fields" list must be nonempty.  It can contain String nodes
+// (representing names) and A_Star nodes (representing occurrence of a '*').
+// Currently, A_Star must appear only as the last list element --- the grammar
+// is responsible for enforcing this!
+//
+// Note: any container subscripting or selection of fields from composite columns
+// is represented by an A_Indirection node above the ColumnRef.  However,
+// for simplicity in the normal case, initial field selection from a table
+// name is represented within ColumnRef and not by adding A_Indirection.
+type ColumnRef struct {
+	Fields   *List    // field names (String nodes) or A_Star
+	Location ParseLoc // token location, or -1 if unknown
+}
+
+func (n *ColumnRef) Tag() NodeTag { return T_ColumnRef }
+
+// ParamRef - specifies a $n parameter reference
+type ParamRef struct {
+	Number   int      // the number of the parameter
+	Location ParseLoc // token location, or -1 if unknown
+}
+
+func (n *ParamRef) Tag() NodeTag { return T_ParamRef }
+
+// A_Expr - infix, prefix, and postfix expressions
+type A_Expr_Kind int
+
+const (
+	AEXPR_OP              A_Expr_Kind = iota // normal operator
+	AEXPR_OP_ANY                             // scalar op ANY (array)
+	AEXPR_OP_ALL                             // scalar op ALL (array)
+	AEXPR_DISTINCT                           // IS DISTINCT FROM - name must be "="
+	AEXPR_NOT_DISTINCT                       // IS NOT DISTINCT FROM - name must be "="
+	AEXPR_NULLIF                             // NULLIF - name must be "="
+	AEXPR_IN                                 // [NOT] IN - name must be "=" or "<>"
+	AEXPR_LIKE                               // [NOT] LIKE - name must be "~~" or "!~~"
+	AEXPR_ILIKE                              // [NOT] ILIKE - name must be "~~*" or "!~~*"
+	AEXPR_SIMILAR                            // [NOT] SIMILAR - name must be "~" or "!~"
+	AEXPR_BETWEEN                            // name must be "BETWEEN"
+	AEXPR_NOT_BETWEEN                        // name must be "NOT BETWEEN"
+	AEXPR_BETWEEN_SYM                        // name must be "BETWEEN SYMMETRIC"
+	AEXPR_NOT_BETWEEN_SYM                    // name must be "NOT BETWEEN SYMMETRIC"
+)
+
+type A_Expr struct {
+	Kind     A_Expr_Kind // see above
+	Name     *List       // possibly-qualified name of operator
+	Lexpr    Node        // left argument, or NULL if none
+	Rexpr    Node        // right argument, or NULL if none
+	Location ParseLoc    // token location, or -1 if unknown
+}
+
+func (n *A_Expr) Tag() NodeTag { return T_A_Expr }
+
+type A_Const struct {
+	Val      Node
+	Isnull   bool     // SQL NULL constant
+	Location ParseLoc // token location, or -1 if unknown
+}
+
+func (n *A_Const) Tag() NodeTag { return T_A_Const }
+
+// TypeCast - a CAST expression
+type TypeCast struct {
+	Arg      Node      // the expression being casted
+	TypeName *TypeName // the target type
+	Location ParseLoc  // token location, or -1 if unknown
+}
+
+func (n *TypeCast) Tag() NodeTag { return T_TypeCast }
+
+// CollateClause - a COLLATE expression
+type CollateClause struct {
+	Arg      Node     // input expression
+	Collname *List    // possibly-qualified collation name
+	Location ParseLoc // token location, or -1 if unknown
+}
+
+func (n *CollateClause) Tag() NodeTag { return T_CollateClause }
+
+// RoleSpec - a role name or one of a few special values.
+type RoleSpecType int
+
+const (
+	ROLESPEC_CSTRING      RoleSpecType = iota // role name is stored as a C string
+	ROLESPEC_CURRENT_ROLE                     // role spec is CURRENT_ROLE
+	ROLESPEC_CURRENT_USER                     // role spec is CURRENT_USER
+	ROLESPEC_SESSION_USER                     // role spec is SESSION_USER
+	ROLESPEC_PUBLIC                           // role name is "public"
+)
+
+type RoleSpec struct {
+	Roletype RoleSpecType // Type of this rolespec
+	Rolename string       // filled only for ROLESPEC_CSTRING
+	Location ParseLoc     // token location, or -1 if unknown
+}
+
+func (n *RoleSpec) Tag() NodeTag { return T_RoleSpec }
+
+// FuncCall - a function or aggregate invocation
+//
+// agg_order (if not NIL) indicates we saw 'foo(... ORDER BY ...)', or if
+// agg_within_group is true, it was 'foo(...) WITHIN GROUP (ORDER BY ...)'.
+// agg_star indicates we saw a 'foo(*)' construct, while agg_distinct
+// indicates we saw 'foo(DISTINCT ...)'.  In any of these cases, the
+// construct *must* be an aggregate call.  Otherwise, it might be either an
+// aggregate or some other kind of function.  However, if FILTER or OVER is
+// present it had better be an aggregate or window function.
+//
+// Normally, you'd initialize this via makeFuncCall() and then only change the
+// parts of the struct its defaults don't match afterwards, as needed.
+type FuncCall struct {
+	Funcname       *List        // qualified name of function
+	Args           *List        // the arguments (list of exprs)
+	AggOrder       *List        `pg:"agg_order"`  // ORDER BY (list of SortBy)
+	AggFilter      Node         `pg:"agg_filter"` // FILTER clause, if any
+	Over           *WindowDef   // OVER clause, if any
+	AggWithinGroup bool         `pg:"agg_within_group"` // ORDER BY appeared in WITHIN GROUP
+	AggStar        bool         `pg:"agg_star"`         // argument was really '*'
+	AggDistinct    bool         `pg:"agg_distinct"`     // arguments were labeled DISTINCT
+	FuncVariadic   bool         `pg:"func_variadic"`    // last argument was labeled VARIADIC
+	Funcformat     CoercionForm // how to display this node
+	Location       ParseLoc     // token location, or -1 if unknown
+}
+
+func (n *FuncCall) Tag() NodeTag { return T_FuncCall }
+
+// A_Star - '*' representing all columns of a table or compound field
+//
+// This can appear within ColumnRef.fields, A_Indirection.indirection, and
+// ResTarget.indirection lists.
+type A_Star struct {
+}
+
+func (n *A_Star) Tag() NodeTag { return T_A_Star }
+
+// A_Indices - array subscript or slice bounds ([idx] or [lidx:uidx])
+//
+// In slice case, either or both of lidx and uidx can be NULL (omitted).
+// In non-slice case, uidx holds the single subscript and lidx is always NULL.
+type A_Indices struct {
+	IsSlice bool `pg:"is_slice"` // true if slice (i.e., colon present)
+	Lidx    Node // slice lower bound, if any
+	Uidx    Node // subscript, or slice upper bound if any
+}
+
+func (n *A_Indices) Tag() NodeTag { return T_A_Indices }
+
+// A_Indirection - select a field and/or array element from an expression
+//
+// The indirection list can contain A_Indices nodes (representing
+// subscripting), String nodes (representing field selection --- the
+// string value is the name of the field to select), and A_Star nodes
+// (representing selection of all fields of a composite type).
+// For example, a complex selection operation like
+// (foo).field1[42][7].field2
+// would be represented with a single A_Indirection node having a 4-element
+// indirection list.
+//
+// Currently, A_Star must appear only as the last list element --- the grammar
+// is responsible for enforcing this!
+type A_Indirection struct {
+	Arg         Node  // the thing being selected from
+	Indirection *List // subscripts and/or field names and/or *
+}
+
+func (n *A_Indirection) Tag() NodeTag { return T_A_Indirection }
+
+// A_ArrayExpr - an ARRAY[] construct
+type A_ArrayExpr struct {
+	Elements *List    // array element expressions
+	Location ParseLoc // token location, or -1 if unknown
+}
+
+func (n *A_ArrayExpr) Tag() NodeTag { return T_A_ArrayExpr }
+
+// ResTarget -
+// result target (used in target list of pre-transformed parse trees)
+//
+// In a SELECT target list, 'name' is the column label from an
+// 'AS ColumnLabel' clause, or NULL if there was none, and 'val' is the
+// value expression itself.  The 'indirection' field is not used.
+//
+// INSERT uses ResTarget in its target-column-names list.  Here, 'name' is
+// the name of the destination column, 'indirection' stores any subscripts
+// attached to the destination, and 'val' is not used.
+//
+// In an UPDATE target list, 'name' is the name of the destination column,
+// 'indirection' stores any subscripts attached to the destination, and
+// 'val' is the expression to assign.
+//
+// See A_Indirection for more info about what can appear in 'indirection'.
+type ResTarget struct {
+	Name        string   // column name or NULL
+	Indirection *List    // subscripts, field names, and '*', or NIL
+	Val         Node     // the value expression to compute or assign
+	Location    ParseLoc // token location, or -1 if unknown
+}
+
+func (n *ResTarget) Tag() NodeTag { return T_ResTarget }
+
+// MultiAssignRef - element of a row source expression for UPDATE
+//
+// In an UPDATE target list, when we have SET (a,b,c) = row-valued-expression,
+// we generate separate ResTarget items for each of a,b,c.  Their "val" trees
+// are MultiAssignRef nodes numbered 1..n, linking to a common copy of the
+// row-valued-expression (which parse analysis will process only once, when
+// handling the MultiAssignRef with colno=1).
+type MultiAssignRef struct {
+	Source   Node // the row-valued expression
+	Colno    int  // column number for this target (1..n)
+	Ncolumns int  // number of targets in the construct
+}
+
+func (n *MultiAssignRef) Tag() NodeTag { return T_MultiAssignRef }
+
+// SortBy - for ORDER BY clause
+type SortBy struct {
+	Node        Node        // expression to sort on
+	SortbyDir   SortByDir   `pg:"sortby_dir"`   // ASC/DESC/USING/default
+	SortbyNulls SortByNulls `pg:"sortby_nulls"` // NULLS FIRST/LAST
+	UseOp       *List       // name of op to use, if SORTBY_USING
+	Location    ParseLoc    // operator location, or -1 if none/unknown
+}
+
+func (n *SortBy) Tag() NodeTag { return T_SortBy }
+
+// WindowDef - raw representation of WINDOW and OVER clauses
+//
+// For entries in a WINDOW list, "name" is the window name being defined.
+// For OVER clauses, we use "name" for the "OVER window" syntax, or "refname"
+// for the "OVER (window)" syntax, which is subtly different --- the latter
+// implies overriding the window frame clause.
+type WindowDef struct {
+	Name            string   // window's own name
+	Refname         string   // referenced window name, if any
+	PartitionClause *List    // PARTITION BY expression list
+	OrderClause     *List    // ORDER BY (list of SortBy)
+	FrameOptions    int      // frame_clause options, see below
+	StartOffset     Node     // expression for starting bound, if any
+	EndOffset       Node     // expression for ending bound, if any
+	Location        ParseLoc // parse location, or -1 if none/unknown
+}
+
+func (n *WindowDef) Tag() NodeTag { return T_WindowDef }
+
+// frameOptions is an OR of these bits.  The NONDEFAULT and BETWEEN bits are
+// used so that ruleutils.c can tell which properties were specified and
+// which were defaulted; the correct behavioral bits must be set either way.
+// The START_foo and END_foo options must come in pairs of adjacent bits for
+// the convenience of gram.y, even though some of them are useless/invalid.
+const (
+	FRAMEOPTION_NONDEFAULT                = 0x00001 // any specified?
+	FRAMEOPTION_RANGE                     = 0x00002 // RANGE behavior
+	FRAMEOPTION_ROWS                      = 0x00004 // ROWS behavior
+	FRAMEOPTION_GROUPS                    = 0x00008 // GROUPS behavior
+	FRAMEOPTION_BETWEEN                   = 0x00010 // BETWEEN given?
+	FRAMEOPTION_START_UNBOUNDED_PRECEDING = 0x00020 // start is U. P.
+	FRAMEOPTION_END_UNBOUNDED_PRECEDING   = 0x00040 // (disallowed)
+	FRAMEOPTION_START_UNBOUNDED_FOLLOWING = 0x00080 // (disallowed)
+	FRAMEOPTION_END_UNBOUNDED_FOLLOWING   = 0x00100 // end is U. F.
+	FRAMEOPTION_START_CURRENT_ROW         = 0x00200 // start is C. R.
+	FRAMEOPTION_END_CURRENT_ROW           = 0x00400 // end is C. R.
+	FRAMEOPTION_START_OFFSET_PRECEDING    = 0x00800 // start is O. P.
+	FRAMEOPTION_END_OFFSET_PRECEDING      = 0x01000 // end is O. P.
+	FRAMEOPTION_START_OFFSET_FOLLOWING    = 0x02000 // start is O. F.
+	FRAMEOPTION_END_OFFSET_FOLLOWING      = 0x04000 // end is O. F.
+	FRAMEOPTION_EXCLUDE_CURRENT_ROW       = 0x08000 // omit C.R.
+	FRAMEOPTION_EXCLUDE_GROUP             = 0x10000 // omit C.R. & peers
+	FRAMEOPTION_EXCLUDE_TIES              = 0x20000 // omit C.R.'s peers
+)
+
+const (
+	FRAMEOPTION_START_OFFSET = (FRAMEOPTION_START_OFFSET_PRECEDING | FRAMEOPTION_START_OFFSET_FOLLOWING)
+	FRAMEOPTION_END_OFFSET   = (FRAMEOPTION_END_OFFSET_PRECEDING | FRAMEOPTION_END_OFFSET_FOLLOWING)
+	FRAMEOPTION_EXCLUSION    = (FRAMEOPTION_EXCLUDE_CURRENT_ROW | FRAMEOPTION_EXCLUDE_GROUP | FRAMEOPTION_EXCLUDE_TIES)
+)
+
+const (
+	FRAMEOPTION_DEFAULTS = (FRAMEOPTION_RANGE | FRAMEOPTION_START_UNBOUNDED_PRECEDING | FRAMEOPTION_END_CURRENT_ROW)
+)
+
+// RangeSubselect - subquery appearing in a FROM clause
+type RangeSubselect struct {
+	Lateral  bool   // does it have LATERAL prefix?
+	Subquery Node   // the untransformed sub-select clause
+	Alias    *Alias // table alias & optional column aliases
+}
+
+func (n *RangeSubselect) Tag() NodeTag { return T_RangeSubselect }
+
+// RangeFunction - function call appearing in a FROM clause
+//
+// functions is a List because we use this to represent the construct
+// ROWS FROM(func1(...), func2(...), ...).  Each element of this list is a
+// two-element sublist, the first element being the untransformed function
+// call tree, and the second element being a possibly-empty list of ColumnDef
+// nodes representing any columndef list attached to that function within the
+// ROWS FROM() syntax.
+//
+// alias and coldeflist represent any alias and/or columndef list attached
+// at the top level.  (We disallow coldeflist appearing both here and
+// per-function, but that's checked in parse analysis, not by the grammar.)
+type RangeFunction struct {
+	Lateral    bool   // does it have LATERAL prefix?
+	Ordinality bool   // does it have WITH ORDINALITY suffix?
+	IsRowsfrom bool   `pg:"is_rowsfrom"` // is result of ROWS FROM() syntax?
+	Functions  *List  // per-function information, see above
+	Alias      *Alias // table alias & optional column aliases
+	// list of ColumnDef nodes to describe result
+	// of function returning RECORD
+	Coldeflist *List
+}
+
+func (n *RangeFunction) Tag() NodeTag { return T_RangeFunction }
+
+// RangeTableFunc - raw form of "table functions" such as XMLTABLE
+//
+// Note: JSON_TABLE is also a "table function", but it uses JsonTable node,
+// not RangeTableFunc.
+type RangeTableFunc struct {
+	Lateral    bool     // does it have LATERAL prefix?
+	Docexpr    Node     // document expression
+	Rowexpr    Node     // row generator expression
+	Namespaces *List    // list of namespaces as ResTarget
+	Columns    *List    // list of RangeTableFuncCol
+	Alias      *Alias   // table alias & optional column aliases
+	Location   ParseLoc // token location, or -1 if unknown
+}
+
+func (n *RangeTableFunc) Tag() NodeTag { return T_RangeTableFunc }
+
+// RangeTableFuncCol - one column in a RangeTableFunc->columns
+//
+// If for_ordinality is true (FOR ORDINALITY), then the column is an int4
+// column and the rest of the fields are ignored.
+type RangeTableFuncCol struct {
+	Colname       string    // name of generated column
+	TypeName      *TypeName // type of generated column
+	ForOrdinality bool      `pg:"for_ordinality"` // does it have FOR ORDINALITY?
+	IsNotNull     bool      `pg:"is_not_null"`    // does it have NOT NULL?
+	Colexpr       Node      // column filter expression
+	Coldefexpr    Node      // column default value expression
+	Location      ParseLoc  // token location, or -1 if unknown
+}
+
+func (n *RangeTableFuncCol) Tag() NodeTag { return T_RangeTableFuncCol }
+
+// RangeTableSample - TABLESAMPLE appearing in a raw FROM clause
+//
+// This node, appearing only in raw parse trees, represents
+// <relation> TABLESAMPLE <method> (<params>) REPEATABLE (<num>)
+// Currently, the <relation> can only be a RangeVar, but we might in future
+// allow RangeSubselect and other options.  Note that the RangeTableSample
+// is wrapped around the node representing the <relation>, rather than being
+// a subfield of it.
+type RangeTableSample struct {
+	Relation   Node     // relation to be sampled
+	Method     *List    // sampling method name (possibly qualified)
+	Args       *List    // argument(s) for sampling method
+	Repeatable Node     // REPEATABLE expression, or NULL if none
+	Location   ParseLoc // method name location, or -1 if unknown
+}
+
+func (n *RangeTableSample) Tag() NodeTag { return T_RangeTableSample }
+
+// ColumnDef - column definition (used in various creates)
+//
+// If the column has a default value, we may have the value expression
+// in either "raw" form (an untransformed parse tree) or "cooked" form
+// (a post-parse-analysis, executable expression tree), depending on
+// how this ColumnDef node was created (by parsing, or by inheritance
+// from an existing relation).  We should never have both in the same node!
+//
+// Similarly, we may have a COLLATE specification in either raw form
+// (represented as a CollateClause with arg==NULL) or cooked form
+// (the collation's OID).
+//
+// The constraints list may contain a CONSTR_DEFAULT item in a raw
+// parsetree produced by gram.y, but transformCreateStmt will remove
+// the item and set raw_default instead.  CONSTR_DEFAULT items
+// should not appear in any subsequent processing.
+type ColumnDef struct {
+	Colname       string    // name of column
+	TypeName      *TypeName // type of column
+	Compression   string    // compression method for column
+	Inhcount      int       // number of times column is inherited
+	IsLocal       bool      `pg:"is_local"`     // column has local (non-inherited) def'n
+	IsNotNull     bool      `pg:"is_not_null"`  // NOT NULL constraint specified?
+	IsFromType    bool      `pg:"is_from_type"` // column definition came from table type
+	Storage       byte      // attstorage setting, or 0 for default
+	StorageName   string    `pg:"storage_name"`   // attstorage setting name or NULL for default
+	RawDefault    Node      `pg:"raw_default"`    // default value (untransformed parse tree)
+	CookedDefault Node      `pg:"cooked_default"` // default value (transformed expr tree)
+	Identity      byte      // attidentity setting
+	// to store identity sequence name for
+	// ALTER TABLE ... ADD COLUMN
+	IdentitySequence *RangeVar
+	Generated        byte           // attgenerated setting
+	CollClause       *CollateClause // untransformed COLLATE spec, if any
+	CollOid          Oid            // collation OID (InvalidOid if not set)
+	Constraints      *List          // other constraints on column
+	Fdwoptions       *List          // per-column FDW options
+	Location         ParseLoc       // parse location, or -1 if none/unknown
+}
+
+func (n *ColumnDef) Tag() NodeTag { return T_ColumnDef }
+
+// TableLikeClause - CREATE TABLE ( ... LIKE ... ) clause
+type TableLikeClause struct {
+	Relation    *RangeVar
+	Options     uint32 // OR of TableLikeOption flags
+	RelationOid Oid    // If table has been looked up, its OID
+}
+
+func (n *TableLikeClause) Tag() NodeTag { return T_TableLikeClause }
+
+type TableLikeOption int
+
+const (
+	CREATE_TABLE_LIKE_COMMENTS    TableLikeOption = 1 << 0
+	CREATE_TABLE_LIKE_COMPRESSION TableLikeOption = 1 << 1
+	CREATE_TABLE_LIKE_CONSTRAINTS TableLikeOption = 1 << 2
+	CREATE_TABLE_LIKE_DEFAULTS    TableLikeOption = 1 << 3
+	CREATE_TABLE_LIKE_GENERATED   TableLikeOption = 1 << 4
+	CREATE_TABLE_LIKE_IDENTITY    TableLikeOption = 1 << 5
+	CREATE_TABLE_LIKE_INDEXES     TableLikeOption = 1 << 6
+	CREATE_TABLE_LIKE_STATISTICS  TableLikeOption = 1 << 7
+	CREATE_TABLE_LIKE_STORAGE     TableLikeOption = 1 << 8
+	CREATE_TABLE_LIKE_ALL         TableLikeOption = 0x7FFFFFFF
+)
+
+// IndexElem - index parameters (used in CREATE INDEX, and in ON CONFLICT)
+//
+// For a plain index attribute, 'name' is the name of the table column to
+// index, and 'expr' is NULL.  For an index expression, 'name' is NULL and
+// 'expr' is the expression tree.
+type IndexElem struct {
+	Name          string      // name of attribute to index, or NULL
+	Expr          Node        // expression to index, or NULL
+	Indexcolname  string      // name for index column; NULL = default
+	Collation     *List       // name of collation; NIL = default
+	Opclass       *List       // name of desired opclass; NIL = default
+	Opclassopts   *List       // opclass-specific options, or NIL
+	Ordering      SortByDir   // ASC/DESC/default
+	NullsOrdering SortByNulls `pg:"nulls_ordering"` // FIRST/LAST/default
+}
+
+func (n *IndexElem) Tag() NodeTag { return T_IndexElem }
+
+// DefElem - a generic "name = value" option definition
+//
+// In some contexts the name can be qualified.  Also, certain SQL commands
+// allow a SET/ADD/DROP action to be attached to option settings, so it's
+// convenient to carry a field for that too.  (Note: currently, it is our
+// practice that the grammar allows namespace and action only in statements
+// where they are relevant; C code can just ignore those fields in other
+// statements.)
+type DefElemAction int
+
+const (
+	DEFELEM_UNSPEC DefElemAction = iota // no action given
+	DEFELEM_SET
+	DEFELEM_ADD
+	DEFELEM_DROP
+)
+
+type DefElem struct {
+	Defnamespace string // NULL if unqualified name
+	Defname      string
+	// typically Integer, Float, String, or
+	// TypeName
+	Arg       Node
+	Defaction DefElemAction // unspecified action, or SET/ADD/DROP
+	Location  ParseLoc      // token location, or -1 if unknown
+}
+
+func (n *DefElem) Tag() NodeTag { return T_DefElem }
+
+// LockingClause - raw representation of FOR [NO KEY] UPDATE/[KEY] SHARE
+// options
+//
+// Note: lockedRels == NIL means "all relations in query".  Otherwise it
+// is a list of RangeVar nodes.  (We use RangeVar mainly because it carries
+// a location field --- currently, parse analysis insists on unqualified
+// names in LockingClause.)
+type LockingClause struct {
+	LockedRels *List // FOR [KEY] UPDATE/SHARE relations
+	Strength   LockClauseStrength
+	WaitPolicy LockWaitPolicy // NOWAIT and SKIP LOCKED
+}
+
+func (n *LockingClause) Tag() NodeTag { return T_LockingClause }
+
+// XMLSERIALIZE (in raw parse tree only)
+type XmlSerialize struct {
+	Xmloption XmlOptionType // DOCUMENT or CONTENT
+	Expr      Node
+	TypeName  *TypeName
+	Indent    bool     // [NO] INDENT
+	Location  ParseLoc // token location, or -1 if unknown
+}
+
+func (n *XmlSerialize) Tag() NodeTag { return T_XmlSerialize }
+
+// PartitionElem - parse-time representation of a single partition key
+//
+// expr can be either a raw expression tree or a parse-analyzed expression.
+// We don't store these on-disk, though.
+type PartitionElem struct {
+	Name      string   // name of column to partition on, or NULL
+	Expr      Node     // expression to partition on, or NULL
+	Collation *List    // name of collation; NIL = default
+	Opclass   *List    // name of desired opclass; NIL = default
+	Location  ParseLoc // token location, or -1 if unknown
+}
+
+func (n *PartitionElem) Tag() NodeTag { return T_PartitionElem }
+
+type PartitionStrategy int
+
+const (
+	PARTITION_STRATEGY_LIST  PartitionStrategy = 'l'
+	PARTITION_STRATEGY_RANGE PartitionStrategy = 'r'
+	PARTITION_STRATEGY_HASH  PartitionStrategy = 'h'
+)
+
+// PartitionSpec - parse-time representation of a partition key specification
+//
+// This represents the key space we will be partitioning on.
+type PartitionSpec struct {
+	Strategy   PartitionStrategy
+	PartParams *List    // List of PartitionElems
+	Location   ParseLoc // token location, or -1 if unknown
+}
+
+func (n *PartitionSpec) Tag() NodeTag { return T_PartitionSpec }
+
+// PartitionBoundSpec - a partition bound specification
+//
+// This represents the portion of the partition key space assigned to a
+// particular partition.  These are stored on disk in pg_class.relpartbound.
+type PartitionBoundSpec struct {
+	Strategy  byte // see PARTITION_STRATEGY codes above
+	IsDefault bool `pg:"is_default"` // is it a default partition bound?
+	Modulus   int  // Partitioning info for HASH strategy:
+	Remainder int
+	// Partitioning info for LIST strategy:
+	// List of Consts (or A_Consts in raw tree)
+	Listdatums *List
+	// Partitioning info for RANGE strategy:
+	// List of PartitionRangeDatums
+	Lowerdatums *List
+	Upperdatums *List    // List of PartitionRangeDatums
+	Location    ParseLoc // token location, or -1 if unknown
+}
+
+func (n *PartitionBoundSpec) Tag() NodeTag { return T_PartitionBoundSpec }
+
+// PartitionRangeDatum - one of the values in a range partition bound
+//
+// This can be MINVALUE, MAXVALUE or a specific bounded value.
+type PartitionRangeDatumKind int
+
+const (
+	PARTITION_RANGE_DATUM_MINVALUE PartitionRangeDatumKind = -1 // less than any other value
+	PARTITION_RANGE_DATUM_VALUE    PartitionRangeDatumKind = 0  // a specific (bounded) value
+	PARTITION_RANGE_DATUM_MAXVALUE PartitionRangeDatumKind = 1  // greater than any other value
+)
+
+type PartitionRangeDatum struct {
+	Kind PartitionRangeDatumKind
+	// Const (or A_Const in raw tree), if kind is
+	// PARTITION_RANGE_DATUM_VALUE, else NULL
+	Value    Node
+	Location ParseLoc // token location, or -1 if unknown
+}
+
+func (n *PartitionRangeDatum) Tag() NodeTag { return T_PartitionRangeDatum }
+
+// PartitionDesc - used in reverted ALTER TABLE SPLIT PARTITION command
+//
+// Kept as a stub for nodetag ABI compatibility.
+type SinglePartitionSpec struct {
+}
+
+func (n *SinglePartitionSpec) Tag() NodeTag { return T_SinglePartitionSpec }
+
+// PartitionCmd - info for ALTER TABLE/INDEX ATTACH/DETACH PARTITION commands
+type PartitionCmd struct {
+	Name       *RangeVar           // name of partition to attach/detach
+	Bound      *PartitionBoundSpec // FOR VALUES, if attaching
+	Concurrent bool
+}
+
+func (n *PartitionCmd) Tag() NodeTag { return T_PartitionCmd }
+
+// RangeTblEntry -
+// A range table is a List of RangeTblEntry nodes.
+//
+// A range table entry may represent a plain relation, a sub-select in
+// FROM, or the result of a JOIN clause.  (Only explicit JOIN syntax
+// produces an RTE, not the implicit join resulting from multiple FROM
+// items.  This is because we only need the RTE to deal with SQL features
+// like outer joins and join-output-column aliasing.)  Other special
+// RTE types also exist, as indicated by RTEKind.
+//
+// Note that we consider RTE_RELATION to cover anything that has a pg_class
+// entry.  relkind distinguishes the sub-cases.
+//
+// alias is an Alias node representing the AS alias-clause attached to the
+// FROM expression, or NULL if no clause.
+//
+// eref is the table reference name and column reference names (either
+// real or aliases).  Note that system columns (OID etc) are not included
+// in the column list.
+// eref->aliasname is required to be present, and should generally be used
+// to identify the RTE for error messages etc.
+//
+// In RELATION RTEs, the colnames in both alias and eref are indexed by
+// physical attribute number; this means there must be colname entries for
+// dropped columns.  When building an RTE we insert empty strings ("") for
+// dropped columns.  Note however that a stored rule may have nonempty
+// colnames for columns dropped since the rule was created (and for that
+// matter the colnames might be out of date due to column renamings).
+// The same comments apply to FUNCTION RTEs when a function's return type
+// is a named composite type.
+//
+// In JOIN RTEs, the colnames in both alias and eref are one-to-one with
+// joinaliasvars entries.  A JOIN RTE will omit columns of its inputs when
+// those columns are known to be dropped at parse time.  Again, however,
+// a stored rule might contain entries for columns dropped since the rule
+// was created.  (This is only possible for columns not actually referenced
+// in the rule.)  When loading a stored rule, we replace the joinaliasvars
+// items for any such columns with null pointers.  (We can't simply delete
+// them from the joinaliasvars list, because that would affect the attnums
+// of Vars referencing the rest of the list.)
+//
+// inFromCl marks those range variables that are listed in the FROM clause.
+// It's false for RTEs that are added to a query behind the scenes, such
+// as the NEW and OLD variables for a rule, or the subqueries of a UNION.
+// This flag is not used during parsing (except in transformLockingClause,
+// q.v.); the parser now uses a separate "namespace" data structure to
+// control visibility.  But it is needed by ruleutils.c to determine
+// whether RTEs should be shown in decompiled queries.
+//
+// securityQuals is a list of security barrier quals (boolean expressions),
+// to be tested in the listed order before returning a row from the
+// relation.  It is always NIL in parser output.  Entries are added by the
+// rewriter to implement security-barrier views and/or row-level security.
+// Note that the planner turns each boolean expression into an implicitly
+// AND'ed sublist, as is its usual habit with qualification expressions.
+type RTEKind int
+
+const (
+	RTE_RELATION        RTEKind = iota // ordinary relation reference
+	RTE_SUBQUERY                       // subquery in FROM
+	RTE_JOIN                           // join
+	RTE_FUNCTION                       // function in FROM
+	RTE_TABLEFUNC                      // TableFunc(.., column list)
+	RTE_VALUES                         // VALUES (<exprlist>), (<exprlist>), ...
+	RTE_CTE                            // common table expr (WITH list element)
+	RTE_NAMEDTUPLESTORE                // tuplestore, e.g. for AFTER triggers
+	// RTE represents an empty FROM clause; such
+	// RTEs are added by the planner, they're not
+	// present during parsing or rewriting
+	RTE_RESULT
+)
+
+type RangeTblEntry struct {
+	// Fields valid in all RTEs:
+	//
+	// put alias + eref first to make dump more legible
+	//
+	// user-written alias clause, if any
+	Alias   *Alias
+	Eref    *Alias  // expanded reference names
+	Rtekind RTEKind // see above
+	// Fields valid for a plain relation RTE (else zero):
+	//
+	// inh is true for relation references that should be expanded to include
+	// inheritance children, if the rel has any.  In the parser, this will
+	// only be true for RTE_RELATION entries.  The planner also uses this
+	// field to mark RTE_SUBQUERY entries that contain UNION ALL queries that
+	// it has flattened into pulled-up subqueries (creating a structure much
+	// like the effects of inheritance).
+	//
+	// rellockmode is really LOCKMODE, but it's declared int to avoid having
+	// to include lock-related headers here.  It must be RowExclusiveLock if
+	// the RTE is an INSERT/UPDATE/DELETE/MERGE target, else RowShareLock if
+	// the RTE is a SELECT FOR UPDATE/FOR SHARE target, else AccessShareLock.
+	//
+	// Note: in some cases, rule expansion may result in RTEs that are marked
+	// with RowExclusiveLock even though they are not the target of the
+	// current query; this happens if a DO ALSO rule simply scans the original
+	// target table.  We leave such RTEs with their original lockmode so as to
+	// avoid getting an additional, lesser lock.
+	//
+	// perminfoindex is 1-based index of the RTEPermissionInfo belonging to
+	// this RTE in the containing struct's list of same; 0 if permissions need
+	// not be checked for this RTE.
+	//
+	// As a special case, relid, relkind, rellockmode, and perminfoindex can
+	// also be set (nonzero) in an RTE_SUBQUERY RTE.  This occurs when we
+	// convert an RTE_RELATION RTE naming a view into an RTE_SUBQUERY
+	// containing the view's query.  We still need to perform run-time locking
+	// and permission checks on the view, even though it's not directly used
+	// in the query anymore, and the most expedient way to do that is to
+	// retain these fields from the old state of the RTE.
+	//
+	// As a special case, RTE_NAMEDTUPLESTORE can also set relid to indicate
+	// that the tuple format of the tuplestore is the same as the referenced
+	// relation.  This allows plans referencing AFTER trigger transition
+	// tables to be invalidated if the underlying table is altered.
+	//
+	// OID of the relation
+	Relid         Oid
+	Inh           bool               // inheritance requested?
+	Relkind       byte               // relation kind (see pg_class.relkind)
+	Rellockmode   int                // lock level that query requires on the rel
+	Perminfoindex uint32             // index of RTEPermissionInfo entry, or 0
+	Tablesample   *TableSampleClause // sampling info, or NULL
+	// Fields valid for a subquery RTE (else NULL):
+	//
+	// the sub-query
+	Subquery        *Query
+	SecurityBarrier bool `pg:"security_barrier"` // is from security_barrier view?
+	// Fields valid for a join RTE (else NULL/zero):
+	//
+	// joinaliasvars is a list of (usually) Vars corresponding to the columns
+	// of the join result.  An alias Var referencing column K of the join
+	// result can be replaced by the K'th element of joinaliasvars --- but to
+	// simplify the task of reverse-listing aliases correctly, we do not do
+	// that until planning time.  In detail: an element of joinaliasvars can
+	// be a Var of one of the join's input relations, or such a Var with an
+	// implicit coercion to the join's output column type, or a COALESCE
+	// expression containing the two input column Vars (possibly coerced).
+	// Elements beyond the first joinmergedcols entries are always just Vars,
+	// and are never referenced from elsewhere in the query (that is, join
+	// alias Vars are generated only for merged columns).  We keep these
+	// entries only because they're needed in expandRTE() and similar code.
+	//
+	// Vars appearing within joinaliasvars are marked with varnullingrels sets
+	// that describe the nulling effects of this join and lower ones.  This is
+	// essential for FULL JOIN cases, because the COALESCE expression only
+	// describes the semantics correctly if its inputs have been nulled by the
+	// join.  For other cases, it allows expandRTE() to generate a valid
+	// representation of the join's output without consulting additional
+	// parser state.
+	//
+	// Within a Query loaded from a stored rule, it is possible for non-merged
+	// joinaliasvars items to be null pointers, which are placeholders for
+	// (necessarily unreferenced) columns dropped since the rule was made.
+	// Also, once planning begins, joinaliasvars items can be almost anything,
+	// as a result of subquery-flattening substitutions.
+	//
+	// joinleftcols is an integer list of physical column numbers of the left
+	// join input rel that are included in the join; likewise joinrighttcols
+	// for the right join input rel.  (Which rels those are can be determined
+	// from the associated JoinExpr.)  If the join is USING/NATURAL, then the
+	// first joinmergedcols entries in each list identify the merged columns.
+	// The merged columns come first in the join output, then remaining
+	// columns of the left input, then remaining columns of the right.
+	//
+	// Note that input columns could have been dropped after creation of a
+	// stored rule, if they are not referenced in the query (in particular,
+	// merged columns could not be dropped); this is not accounted for in
+	// joinleftcols/joinrighttcols.
+	Jointype       JoinType
+	Joinmergedcols int      // number of merged (JOIN USING) columns
+	Joinaliasvars  *List    // list of alias-var expansions
+	Joinleftcols   *IntList // left-side input column numbers
+	Joinrightcols  *IntList // right-side input column numbers
+	// join_using_alias is an alias clause attached directly to JOIN/USING. It
+	// is different from the alias field (below) in that it does not hide the
+	// range variables of the tables being joined.
+	JoinUsingAlias *Alias `pg:"join_using_alias"`
+	// Fields valid for a function RTE (else NIL/zero):
+	//
+	// When funcordinality is true, the eref->colnames list includes an alias
+	// for the ordinality column.  The ordinality column is otherwise
+	// implicit, and must be accounted for "by hand" in places such as
+	// expandRTE().
+	//
+	// list of RangeTblFunction nodes
+	Functions      *List
+	Funcordinality bool       // is this called WITH ORDINALITY?
+	Tablefunc      *TableFunc // Fields valid for a TableFunc RTE (else NULL):
+	// Fields valid for a values RTE (else NIL):
+	//
+	// list of expression lists
+	ValuesLists *List `pg:"values_lists"`
+	// Fields valid for a CTE RTE (else NULL/zero):
+	//
+	// name of the WITH list item
+	Ctename       string
+	Ctelevelsup   uint32 // number of query levels up
+	SelfReference bool   `pg:"self_reference"` // is this a recursive self-reference?
+	// Fields valid for CTE, VALUES, ENR, and TableFunc RTEs (else NIL):
+	//
+	// We need these for CTE RTEs so that the types of self-referential
+	// columns are well-defined.  For VALUES RTEs, storing these explicitly
+	// saves having to re-determine the info by scanning the values_lists. For
+	// ENRs, we store the types explicitly here (we could get the information
+	// from the catalogs if 'relid' was supplied, but we'd still need these
+	// for TupleDesc-based ENRs, so we might as well always store the type
+	// info here).  For TableFuncs, these fields are redundant with data in
+	// the TableFunc node, but keeping them here allows some code sharing with
+	// the other cases.
+	//
+	// For ENRs only, we have to consider the possibility of dropped columns.
+	// A dropped column is included in these lists, but it will have zeroes in
+	// all three lists (as well as an empty-string entry in eref).  Testing
+	// for zero coltype is the standard way to detect a dropped column.
+	//
+	// OID list of column type OIDs
+	Coltypes      *OidList
+	Coltypmods    *IntList // integer list of column typmods
+	Colcollations *OidList // OID list of column collation OIDs
+	// Fields valid for ENR RTEs (else NULL/zero):
+	//
+	// name of ephemeral named relation
+	Enrname   string
+	Enrtuples float64 // estimated or actual from caller
+	// Fields valid in all RTEs:
+	//
+	// was LATERAL specified?
+	Lateral       bool
+	InFromCl      bool  // present in FROM clause?
+	SecurityQuals *List // security barrier quals to apply, if any
+}
+
+func (n *RangeTblEntry) Tag() NodeTag { return T_RangeTblEntry }
+
+// RTEPermissionInfo
+// Per-relation information for permission checking. Added to the Query
+// node by the parser when adding the corresponding RTE to the query
+// range table and subsequently editorialized on by the rewriter if
+// needed after rule expansion.
+//
+// Only the relations directly mentioned in the query are checked for
+// access permissions by the core executor, so only their RTEPermissionInfos
+// are present in the Query.  However, extensions may want to check inheritance
+// children too, depending on the value of rte->inh, so it's copied in 'inh'
+// for their perusal.
+//
+// requiredPerms and checkAsUser specify run-time access permissions checks
+// to be performed at query startup.  The user must have *all* of the
+// permissions that are OR'd together in requiredPerms (never 0!).  If
+// checkAsUser is not zero, then do the permissions checks using the access
+// rights of that user, not the current effective user ID.  (This allows rules
+// to act as setuid gateways.)
+//
+// For SELECT/INSERT/UPDATE permissions, if the user doesn't have table-wide
+// permissions then it is sufficient to have the permissions on all columns
+// identified in selectedCols (for SELECT) and/or insertedCols and/or
+// updatedCols (INSERT with ON CONFLICT DO UPDATE may have all 3).
+// selectedCols, insertedCols and updatedCols are bitmapsets, which cannot have
+// negative integer members, so we subtract FirstLowInvalidHeapAttributeNumber
+// from column numbers before storing them in these fields.  A whole-row Var
+// reference is represented by setting the bit for InvalidAttrNumber.
+//
+// updatedCols is also used in some other places, for example, to determine
+// which triggers to fire and in FDWs to know which changed columns they need
+// to ship off.
+type RTEPermissionInfo struct {
+	Relid         Oid        // relation OID
+	Inh           bool       // separately check inheritance children?
+	RequiredPerms uint64     // bitmask of required access permissions
+	CheckAsUser   Oid        // if valid, check access as this role
+	SelectedCols  *Bitmapset // columns needing SELECT permission
+	InsertedCols  *Bitmapset // columns needing INSERT permission
+	UpdatedCols   *Bitmapset // columns needing UPDATE permission
+}
+
+func (n *RTEPermissionInfo) Tag() NodeTag { return T_RTEPermissionInfo }
+
+// RangeTblFunction -
+// RangeTblEntry subsidiary data for one function in a FUNCTION RTE.
+//
+// If the function had a column definition list (required for an
+// otherwise-unspecified RECORD result), funccolnames lists the names given
+// in the definition list, funccoltypes lists their declared column types,
+// funccoltypmods lists their typmods, funccolcollations their collations.
+// Otherwise, those fields are NIL.
+//
+// Notice we don't attempt to store info about the results of functions
+// returning named composite types, because those can change from time to
+// time.  We do however remember how many columns we thought the type had
+// (including dropped columns!), so that we can successfully ignore any
+// columns added after the query was parsed.
+//
+// The query jumbling only needs to track the function expression.
+type RangeTblFunction struct {
+	Funcexpr     Node // expression tree for func call
+	Funccolcount int  // number of columns it contributes to RTE
+	// These fields record the contents of a column definition list, if any:
+	// column names (list of String)
+	Funccolnames      *List
+	Funccoltypes      *OidList // OID list of column type OIDs
+	Funccoltypmods    *IntList // integer list of column typmods
+	Funccolcollations *OidList // OID list of column collation OIDs
+	// This is set during planning for use by the executor:
+	// PARAM_EXEC Param IDs affecting this func
+	Funcparams *Bitmapset
+}
+
+func (n *RangeTblFunction) Tag() NodeTag { return T_RangeTblFunction }
+
+// TableSampleClause - TABLESAMPLE appearing in a transformed FROM clause
+//
+// Unlike RangeTableSample, this is a subnode of the relevant RangeTblEntry.
+type TableSampleClause struct {
+	Tsmhandler Oid   // OID of the tablesample handler function
+	Args       *List // tablesample argument expression(s)
+	Repeatable Node  // REPEATABLE expression, or NULL if none
+}
+
+func (n *TableSampleClause) Tag() NodeTag { return T_TableSampleClause }
+
+// WithCheckOption -
+// representation of WITH CHECK OPTION checks to be applied to new tuples
+// when inserting/updating an auto-updatable view, or RLS WITH CHECK
+// policies to be applied when inserting/updating a relation with RLS.
+type WCOKind int
+
+const (
+	WCO_VIEW_CHECK             WCOKind = iota // WCO on an auto-updatable view
+	WCO_RLS_INSERT_CHECK                      // RLS INSERT WITH CHECK policy
+	WCO_RLS_UPDATE_CHECK                      // RLS UPDATE WITH CHECK policy
+	WCO_RLS_CONFLICT_CHECK                    // RLS ON CONFLICT DO UPDATE USING policy
+	WCO_RLS_MERGE_UPDATE_CHECK                // RLS MERGE UPDATE USING policy
+	WCO_RLS_MERGE_DELETE_CHECK                // RLS MERGE DELETE USING policy
+)
+
+type WithCheckOption struct {
+	Kind     WCOKind // kind of WCO
+	Relname  string  // name of relation that specified the WCO
+	Polname  string  // name of RLS policy being checked
+	Qual     Node    // constraint qual to check
+	Cascaded bool    // true for a cascaded WCO on a view
+}
+
+func (n *WithCheckOption) Tag() NodeTag { return T_WithCheckOption }
+
+// SortGroupClause -
+// representation of ORDER BY, GROUP BY, PARTITION BY,
+// DISTINCT, DISTINCT ON items
+//
+// You might think that ORDER BY is only interested in defining ordering,
+// and GROUP/DISTINCT are only interested in defining equality.  However,
+// one way to implement grouping is to sort and then apply a "uniq"-like
+// filter.  So it's also interesting to keep track of possible sort operators
+// for GROUP/DISTINCT, and in particular to try to sort for the grouping
+// in a way that will also yield a requested ORDER BY ordering.  So we need
+// to be able to compare ORDER BY and GROUP/DISTINCT lists, which motivates
+// the decision to give them the same representation.
+//
+// tleSortGroupRef must match ressortgroupref of exactly one entry of the
+// query's targetlist; that is the expression to be sorted or grouped by.
+// eqop is the OID of the equality operator.
+// sortop is the OID of the ordering operator (a "<" or ">" operator),
+// or InvalidOid if not available.
+// nulls_first means about what you'd expect.  If sortop is InvalidOid
+// then nulls_first is meaningless and should be set to false.
+// hashable is true if eqop is hashable (note this condition also depends
+// on the datatype of the input expression).
+//
+// In an ORDER BY item, all fields must be valid.  (The eqop isn't essential
+// here, but it's cheap to get it along with the sortop, and requiring it
+// to be valid eases comparisons to grouping items.)  Note that this isn't
+// actually enough information to determine an ordering: if the sortop is
+// collation-sensitive, a collation OID is needed too.  We don't store the
+// collation in SortGroupClause because it's not available at the time the
+// parser builds the SortGroupClause; instead, consult the exposed collation
+// of the referenced targetlist expression to find out what it is.
+//
+// In a grouping item, eqop must be valid.  If the eqop is a btree equality
+// operator, then sortop should be set to a compatible ordering operator.
+// We prefer to set eqop/sortop/nulls_first to match any ORDER BY item that
+// the query presents for the same tlist item.  If there is none, we just
+// use the default ordering op for the datatype.
+//
+// If the tlist item's type has a hash opclass but no btree opclass, then
+// we will set eqop to the hash equality operator, sortop to InvalidOid,
+// and nulls_first to false.  A grouping item of this kind can only be
+// implemented by hashing, and of course it'll never match an ORDER BY item.
+//
+// The hashable flag is provided since we generally have the requisite
+// information readily available when the SortGroupClause is constructed,
+// and it's relatively expensive to get it again later.  Note there is no
+// need for a "sortable" flag since OidIsValid(sortop) serves the purpose.
+//
+// A query might have both ORDER BY and DISTINCT (or DISTINCT ON) clauses.
+// In SELECT DISTINCT, the distinctClause list is as long or longer than the
+// sortClause list, while in SELECT DISTINCT ON it's typically shorter.
+// The two lists must match up to the end of the shorter one --- the parser
+// rearranges the distinctClause if necessary to make this true.  (This
+// restriction ensures that only one sort step is needed to both satisfy the
+// ORDER BY and set up for the Unique step.  This is semantically necessary
+// for DISTINCT ON, and presents no real drawback for DISTINCT.)
+type SortGroupClause struct {
+	TleSortGroupRef uint32 // reference into targetlist
+	Eqop            Oid    // the equality operator ('=' op)
+	Sortop          Oid    // the ordering operator ('<' op), or 0
+	NullsFirst      bool   `pg:"nulls_first"` // do NULLs come before normal values?
+	Hashable        bool   // can eqop be implemented by hashing?
+}
+
+func (n *SortGroupClause) Tag() NodeTag { return T_SortGroupClause }
+
+// GroupingSet -
+// representation of CUBE, ROLLUP and GROUPING SETS clauses
+//
+// In a Query with grouping sets, the groupClause contains a flat list of
+// SortGroupClause nodes for each distinct expression used.  The actual
+// structure of the GROUP BY clause is given by the groupingSets tree.
+//
+// In the raw parser output, GroupingSet nodes (of all types except SIMPLE
+// which is not used) are potentially mixed in with the expressions in the
+// groupClause of the SelectStmt.  (An expression can't contain a GroupingSet,
+// but a list may mix GroupingSet and expression nodes.)  At this stage, the
+// content of each node is a list of expressions, some of which may be RowExprs
+// which represent sublists rather than actual row constructors, and nested
+// GroupingSet nodes where legal in the grammar.  The structure directly
+// reflects the query syntax.
+//
+// In parse analysis, the transformed expressions are used to build the tlist
+// and groupClause list (of SortGroupClause nodes), and the groupingSets tree
+// is eventually reduced to a fixed format:
+//
+// # EMPTY nodes represent (), and obviously have no content
+//
+// SIMPLE nodes represent a list of one or more expressions to be treated as an
+// atom by the enclosing structure; the content is an integer list of
+// ressortgroupref values (see SortGroupClause)
+//
+// CUBE and ROLLUP nodes contain a list of one or more SIMPLE nodes.
+//
+// SETS nodes contain a list of EMPTY, SIMPLE, CUBE or ROLLUP nodes, but after
+// parse analysis they cannot contain more SETS nodes; enough of the syntactic
+// transforms of the spec have been applied that we no longer have arbitrarily
+// deep nesting (though we still preserve the use of cube/rollup).
+//
+// Note that if the groupingSets tree contains no SIMPLE nodes (only EMPTY
+// nodes at the leaves), then the groupClause will be empty, but this is still
+// an aggregation query (similar to using aggs or HAVING without GROUP BY).
+//
+// As an example, the following clause:
+//
+// GROUP BY GROUPING SETS ((a,b), CUBE(c,(d,e)))
+//
+// looks like this after raw parsing:
+//
+// SETS( RowExpr(a,b) , CUBE( c, RowExpr(d,e) ) )
+//
+// and parse analysis converts it to:
+//
+// SETS( SIMPLE(1,2), CUBE( SIMPLE(3), SIMPLE(4,5) ) )
+type GroupingSetKind int
+
+const (
+	GROUPING_SET_EMPTY GroupingSetKind = iota
+	GROUPING_SET_SIMPLE
+	GROUPING_SET_ROLLUP
+	GROUPING_SET_CUBE
+	GROUPING_SET_SETS
+)
+
+type GroupingSet struct {
+	Kind     GroupingSetKind
+	Content  *List
+	Location ParseLoc
+}
+
+func (n *GroupingSet) Tag() NodeTag { return T_GroupingSet }
+
+// WindowClause -
+// transformed representation of WINDOW and OVER clauses
+//
+// A parsed Query's windowClause list contains these structs.  "name" is set
+// if the clause originally came from WINDOW, and is NULL if it originally
+// was an OVER clause (but note that we collapse out duplicate OVERs).
+// partitionClause and orderClause are lists of SortGroupClause structs.
+// partitionClause is sanitized by the query planner to remove any columns or
+// expressions belonging to redundant PathKeys.
+// If we have RANGE with offset PRECEDING/FOLLOWING, the semantics of that are
+// specified by startInRangeFunc/inRangeColl/inRangeAsc/inRangeNullsFirst
+// for the start offset, or endInRangeFunc/inRange* for the end offset.
+// winref is an ID number referenced by WindowFunc nodes; it must be unique
+// among the members of a Query's windowClause list.
+// When refname isn't null, the partitionClause is always copied from there;
+// the orderClause might or might not be copied (see copiedOrder); the framing
+// options are never copied, per spec.
+//
+// The information relevant for the query jumbling is the partition clause
+// type and its bounds.
+type WindowClause struct {
+	Name              string // window name (NULL in an OVER clause)
+	Refname           string // referenced window name, if any
+	PartitionClause   *List  // PARTITION BY list
+	OrderClause       *List  // ORDER BY list
+	FrameOptions      int    // frame_clause options, see WindowDef
+	StartOffset       Node   // expression for starting bound, if any
+	EndOffset         Node   // expression for ending bound, if any
+	StartInRangeFunc  Oid    // in_range function for startOffset
+	EndInRangeFunc    Oid    // in_range function for endOffset
+	InRangeColl       Oid    // collation for in_range tests
+	InRangeAsc        bool   // use ASC sort order for in_range tests?
+	InRangeNullsFirst bool   // nulls sort first for in_range tests?
+	Winref            uint32 // ID referenced by window functions
+	CopiedOrder       bool   // did we copy orderClause from refname?
+}
+
+func (n *WindowClause) Tag() NodeTag { return T_WindowClause }
+
+// RowMarkClause -
+// parser output representation of FOR [KEY] UPDATE/SHARE clauses
+//
+// Query.rowMarks contains a separate RowMarkClause node for each relation
+// identified as a FOR [KEY] UPDATE/SHARE target.  If one of these clauses
+// is applied to a subquery, we generate RowMarkClauses for all normal and
+// subquery rels in the subquery, but they are marked pushedDown = true to
+// distinguish them from clauses that were explicitly written at this query
+// level.  Also, Query.hasForUpdate tells whether there were explicit FOR
+// UPDATE/SHARE/KEY SHARE clauses in the current query level.
+type RowMarkClause struct {
+	Rti        uint32 // range table index of target relation
+	Strength   LockClauseStrength
+	WaitPolicy LockWaitPolicy // NOWAIT and SKIP LOCKED
+	PushedDown bool           // pushed down from higher query level?
+}
+
+func (n *RowMarkClause) Tag() NodeTag { return T_RowMarkClause }
+
+// WithClause -
+// representation of WITH clause
+//
+// Note: WithClause does not propagate into the Query representation;
+// but CommonTableExpr does.
+type WithClause struct {
+	Ctes      *List    // list of CommonTableExprs
+	Recursive bool     // true = WITH RECURSIVE
+	Location  ParseLoc // token location, or -1 if unknown
+}
+
+func (n *WithClause) Tag() NodeTag { return T_WithClause }
+
+// InferClause -
+// ON CONFLICT unique index inference clause
+//
+// Note: InferClause does not propagate into the Query representation.
+type InferClause struct {
+	IndexElems  *List    // IndexElems to infer unique index
+	WhereClause Node     // qualification (partial-index predicate)
+	Conname     string   // Constraint name, or NULL if unnamed
+	Location    ParseLoc // token location, or -1 if unknown
+}
+
+func (n *InferClause) Tag() NodeTag { return T_InferClause }
+
+// OnConflictClause -
+// representation of ON CONFLICT clause
+//
+// Note: OnConflictClause does not propagate into the Query representation.
+type OnConflictClause struct {
+	Action      OnConflictAction // DO NOTHING or UPDATE?
+	Infer       *InferClause     // Optional index inference clause
+	TargetList  *List            // the target list (of ResTarget)
+	WhereClause Node             // qualifications
+	Location    ParseLoc         // token location, or -1 if unknown
+}
+
+func (n *OnConflictClause) Tag() NodeTag { return T_OnConflictClause }
+
+// CommonTableExpr -
+// representation of WITH list element
+type CTEMaterialize int
+
+const (
+	CTEMaterializeDefault CTEMaterialize = iota // no option specified
+	CTEMaterializeAlways                        // MATERIALIZED
+	CTEMaterializeNever                         // NOT MATERIALIZED
+)
+
+type CTESearchClause struct {
+	SearchColList      *List  `pg:"search_col_list"`
+	SearchBreadthFirst bool   `pg:"search_breadth_first"`
+	SearchSeqColumn    string `pg:"search_seq_column"`
+	Location           ParseLoc
+}
+
+func (n *CTESearchClause) Tag() NodeTag { return T_CTESearchClause }
+
+type CTECycleClause struct {
+	CycleColList     *List  `pg:"cycle_col_list"`
+	CycleMarkColumn  string `pg:"cycle_mark_column"`
+	CycleMarkValue   Node   `pg:"cycle_mark_value"`
+	CycleMarkDefault Node   `pg:"cycle_mark_default"`
+	CyclePathColumn  string `pg:"cycle_path_column"`
+	Location         ParseLoc
+	// These fields are set during parse analysis:
+	// common type of _value and _default
+	CycleMarkType      Oid `pg:"cycle_mark_type"`
+	CycleMarkTypmod    int `pg:"cycle_mark_typmod"`
+	CycleMarkCollation Oid `pg:"cycle_mark_collation"`
+	CycleMarkNeop      Oid `pg:"cycle_mark_neop"` // <> operator for type
+}
+
+func (n *CTECycleClause) Tag() NodeTag { return T_CTECycleClause }
+
+type CommonTableExpr struct {
+	// Query name (never qualified).  The string name is included in the query
+	// jumbling because RTE_CTE RTEs need it.
+	Ctename         string
+	Aliascolnames   *List          // optional list of column names
+	Ctematerialized CTEMaterialize // is this an optimization fence?
+	// SelectStmt/InsertStmt/etc before parse analysis, Query afterwards:
+	// the CTE's subquery
+	Ctequery     Node
+	SearchClause *CTESearchClause `pg:"search_clause"`
+	CycleClause  *CTECycleClause  `pg:"cycle_clause"`
+	Location     ParseLoc         // token location, or -1 if unknown
+	// These fields are set during parse analysis:
+	// is this CTE actually recursive?
+	Cterecursive bool
+	// Number of RTEs referencing this CTE (excluding internal
+	// self-references), irrelevant for query jumbling.
+	Cterefcount      int
+	Ctecolnames      *List    // list of output column names
+	Ctecoltypes      *OidList // OID list of output column type OIDs
+	Ctecoltypmods    *IntList // integer list of output column typmods
+	Ctecolcollations *OidList // OID list of column collation OIDs
+}
+
+func (n *CommonTableExpr) Tag() NodeTag { return T_CommonTableExpr }
+
+// MergeWhenClause -
+// raw parser representation of a WHEN clause in a MERGE statement
+//
+// This is transformed into MergeAction by parse analysis
+type MergeWhenClause struct {
+	MatchKind   MergeMatchKind // MATCHED/NOT MATCHED BY SOURCE/TARGET
+	CommandType CmdType        // INSERT/UPDATE/DELETE/DO NOTHING
+	Override    OverridingKind // OVERRIDING clause
+	Condition   Node           // WHEN conditions (raw parser)
+	TargetList  *List          // INSERT/UPDATE targetlist
+	// the following members are only used in INSERT actions
+	// VALUES to INSERT, or NULL
+	Values *List
+}
+
+func (n *MergeWhenClause) Tag() NodeTag { return T_MergeWhenClause }
+
+// TriggerTransition -
+// representation of transition row or table naming clause
+//
+// Only transition tables are initially supported in the syntax, and only for
+// AFTER triggers, but other permutations are accepted by the parser so we can
+// give a meaningful message from C code.
+type TriggerTransition struct {
+	Name    string
+	IsNew   bool
+	IsTable bool
+}
+
+func (n *TriggerTransition) Tag() NodeTag { return T_TriggerTransition }
+
+// JsonOutput -
+// representation of JSON output clause (RETURNING type [FORMAT format])
+type JsonOutput struct {
+	TypeName  *TypeName      // RETURNING type name, if specified
+	Returning *JsonReturning // RETURNING FORMAT clause and type Oids
+}
+
+func (n *JsonOutput) Tag() NodeTag { return T_JsonOutput }
+
+// JsonArgument -
+// representation of argument from JSON PASSING clause
+type JsonArgument struct {
+	Val  *JsonValueExpr // argument value expression
+	Name string         // argument name
+}
+
+func (n *JsonArgument) Tag() NodeTag { return T_JsonArgument }
+
+// JsonQuotes -
+// representation of [KEEP|OMIT] QUOTES clause for JSON_QUERY()
+type JsonQuotes int
+
+const (
+	JS_QUOTES_UNSPEC JsonQuotes = iota // unspecified
+	JS_QUOTES_KEEP                     // KEEP QUOTES
+	JS_QUOTES_OMIT                     // OMIT QUOTES
+)
+
+// JsonFuncExpr -
+// untransformed representation of function expressions for
+// SQL/JSON query functions
+type JsonFuncExpr struct {
+	Op JsonExprOp // expression type
+	// JSON_TABLE() column name or NULL if this is
+	// not for a JSON_TABLE()
+	ColumnName  string         `pg:"column_name"`
+	ContextItem *JsonValueExpr `pg:"context_item"` // context item expression
+	Pathspec    Node           // JSON path specification expression
+	Passing     *List          // list of PASSING clause arguments, if any
+	Output      *JsonOutput    // output clause, if specified
+	OnEmpty     *JsonBehavior  `pg:"on_empty"` // ON EMPTY behavior
+	OnError     *JsonBehavior  `pg:"on_error"` // ON ERROR behavior
+	Wrapper     JsonWrapper    // array wrapper behavior (JSON_QUERY only)
+	Quotes      JsonQuotes     // omit or keep quotes? (JSON_QUERY only)
+	Location    ParseLoc       // token location, or -1 if unknown
+}
+
+func (n *JsonFuncExpr) Tag() NodeTag { return T_JsonFuncExpr }
+
+// JsonTablePathSpec
+// untransformed specification of JSON path expression with an optional
+// name
+type JsonTablePathSpec struct {
+	String       Node
+	Name         string
+	NameLocation ParseLoc `pg:"name_location"`
+	Location     ParseLoc // location of 'string'
+}
+
+func (n *JsonTablePathSpec) Tag() NodeTag { return T_JsonTablePathSpec }
+
+// JsonTable -
+// untransformed representation of JSON_TABLE
+type JsonTable struct {
+	ContextItem *JsonValueExpr     `pg:"context_item"` // context item expression
+	Pathspec    *JsonTablePathSpec // JSON path specification
+	Passing     *List              // list of PASSING clause arguments, if any
+	Columns     *List              // list of JsonTableColumn
+	OnError     *JsonBehavior      `pg:"on_error"` // ON ERROR behavior
+	Alias       *Alias             // table alias in FROM clause
+	Lateral     bool               // does it have LATERAL prefix?
+	Location    ParseLoc           // token location, or -1 if unknown
+}
+
+func (n *JsonTable) Tag() NodeTag { return T_JsonTable }
+
+// JsonTableColumnType -
+// enumeration of JSON_TABLE column types
+type JsonTableColumnType int
+
+const (
+	JTC_FOR_ORDINALITY JsonTableColumnType = iota
+	JTC_REGULAR
+	JTC_EXISTS
+	JTC_FORMATTED
+	JTC_NESTED
+)
+
+// JsonTableColumn -
+// untransformed representation of JSON_TABLE column
+type JsonTableColumn struct {
+	Coltype  JsonTableColumnType // column type
+	Name     string              // column name
+	TypeName *TypeName           // column type name
+	Pathspec *JsonTablePathSpec  // JSON path specification
+	Format   *JsonFormat         // JSON format clause, if specified
+	Wrapper  JsonWrapper         // WRAPPER behavior for formatted columns
+	Quotes   JsonQuotes          // omit or keep quotes on scalar strings?
+	Columns  *List               // nested columns
+	OnEmpty  *JsonBehavior       `pg:"on_empty"` // ON EMPTY behavior
+	OnError  *JsonBehavior       `pg:"on_error"` // ON ERROR behavior
+	Location ParseLoc            // token location, or -1 if unknown
+}
+
+func (n *JsonTableColumn) Tag() NodeTag { return T_JsonTableColumn }
+
+// JsonKeyValue -
+// untransformed representation of JSON object key-value pair for
+// JSON_OBJECT() and JSON_OBJECTAGG()
+type JsonKeyValue struct {
+	Key   Node           // key expression
+	Value *JsonValueExpr // JSON value expression
+}
+
+func (n *JsonKeyValue) Tag() NodeTag { return T_JsonKeyValue }
+
+// JsonParseExpr -
+// untransformed representation of JSON()
+type JsonParseExpr struct {
+	Expr       *JsonValueExpr // string expression
+	Output     *JsonOutput    // RETURNING clause, if specified
+	UniqueKeys bool           `pg:"unique_keys"` // WITH UNIQUE KEYS?
+	Location   ParseLoc       // token location, or -1 if unknown
+}
+
+func (n *JsonParseExpr) Tag() NodeTag { return T_JsonParseExpr }
+
+// JsonScalarExpr -
+// untransformed representation of JSON_SCALAR()
+type JsonScalarExpr struct {
+	Expr     Node        // scalar expression
+	Output   *JsonOutput // RETURNING clause, if specified
+	Location ParseLoc    // token location, or -1 if unknown
+}
+
+func (n *JsonScalarExpr) Tag() NodeTag { return T_JsonScalarExpr }
+
+// JsonSerializeExpr -
+// untransformed representation of JSON_SERIALIZE() function
+type JsonSerializeExpr struct {
+	Expr     *JsonValueExpr // json value expression
+	Output   *JsonOutput    // RETURNING clause, if specified
+	Location ParseLoc       // token location, or -1 if unknown
+}
+
+func (n *JsonSerializeExpr) Tag() NodeTag { return T_JsonSerializeExpr }
+
+// JsonObjectConstructor -
+// untransformed representation of JSON_OBJECT() constructor
+type JsonObjectConstructor struct {
+	Exprs        *List       // list of JsonKeyValue pairs
+	Output       *JsonOutput // RETURNING clause, if specified
+	AbsentOnNull bool        `pg:"absent_on_null"` // skip NULL values?
+	Unique       bool        // check key uniqueness?
+	Location     ParseLoc    // token location, or -1 if unknown
+}
+
+func (n *JsonObjectConstructor) Tag() NodeTag { return T_JsonObjectConstructor }
+
+// JsonArrayConstructor -
+// untransformed representation of JSON_ARRAY(element,...) constructor
+type JsonArrayConstructor struct {
+	Exprs        *List       // list of JsonValueExpr elements
+	Output       *JsonOutput // RETURNING clause, if specified
+	AbsentOnNull bool        `pg:"absent_on_null"` // skip NULL elements?
+	Location     ParseLoc    // token location, or -1 if unknown
+}
+
+func (n *JsonArrayConstructor) Tag() NodeTag { return T_JsonArrayConstructor }
+
+// JsonArrayQueryConstructor -
+// untransformed representation of JSON_ARRAY(subquery) constructor
+type JsonArrayQueryConstructor struct {
+	Query        Node        // subquery
+	Output       *JsonOutput // RETURNING clause, if specified
+	Format       *JsonFormat // FORMAT clause for subquery, if specified
+	AbsentOnNull bool        `pg:"absent_on_null"` // skip NULL elements?
+	Location     ParseLoc    // token location, or -1 if unknown
+}
+
+func (n *JsonArrayQueryConstructor) Tag() NodeTag { return T_JsonArrayQueryConstructor }
+
+// JsonAggConstructor -
+// common fields of untransformed representation of
+// JSON_ARRAYAGG() and JSON_OBJECTAGG()
+type JsonAggConstructor struct {
+	Output    *JsonOutput // RETURNING clause, if any
+	AggFilter Node        `pg:"agg_filter"` // FILTER clause, if any
+	AggOrder  *List       `pg:"agg_order"`  // ORDER BY clause, if any
+	Over      *WindowDef  // OVER clause, if any
+	Location  ParseLoc    // token location, or -1 if unknown
+}
+
+func (n *JsonAggConstructor) Tag() NodeTag { return T_JsonAggConstructor }
+
+// JsonObjectAgg -
+// untransformed representation of JSON_OBJECTAGG()
+type JsonObjectAgg struct {
+	Constructor  *JsonAggConstructor // common fields
+	Arg          *JsonKeyValue       // object key-value pair
+	AbsentOnNull bool                `pg:"absent_on_null"` // skip NULL values?
+	Unique       bool                // check key uniqueness?
+}
+
+func (n *JsonObjectAgg) Tag() NodeTag { return T_JsonObjectAgg }
+
+// JsonArrayAgg -
+// untransformed representation of JSON_ARRAYAGG()
+type JsonArrayAgg struct {
+	Constructor  *JsonAggConstructor // common fields
+	Arg          *JsonValueExpr      // array element expression
+	AbsentOnNull bool                `pg:"absent_on_null"` // skip NULL elements?
+}
+
+func (n *JsonArrayAgg) Tag() NodeTag { return T_JsonArrayAgg }
+
+// RawStmt --- container for any one statement's raw parse tree
+//
+// Parse analysis converts a raw parse tree headed by a RawStmt node into
+// an analyzed statement headed by a Query node.  For optimizable statements,
+// the conversion is complex.  For utility statements, the parser usually just
+// transfers the raw parse tree (sans RawStmt) into the utilityStmt field of
+// the Query node, and all the useful work happens at execution time.
+//
+// stmt_location/stmt_len identify the portion of the source text string
+// containing this raw statement (useful for multi-statement strings).
+//
+// This is irrelevant for query jumbling, as this is not used in parsed
+// queries.
+type RawStmt struct {
+	Stmt         Node     // raw parse tree
+	StmtLocation ParseLoc `pg:"stmt_location"` // start location, or -1 if unknown
+	StmtLen      ParseLoc `pg:"stmt_len"`      // length in bytes; 0 means "rest of string"
+}
+
+func (n *RawStmt) Tag() NodeTag { return T_RawStmt }
+
+// Insert Statement
+//
+// The source expression is represented by SelectStmt for both the
+// SELECT and VALUES cases.  If selectStmt is NULL, then the query
+// is INSERT ... DEFAULT VALUES.
+type InsertStmt struct {
+	Relation         *RangeVar         // relation to insert into
+	Cols             *List             // optional: names of the target columns
+	SelectStmt       Node              // the source SELECT/VALUES, or NULL
+	OnConflictClause *OnConflictClause // ON CONFLICT clause
+	ReturningList    *List             // list of expressions to return
+	ReturningOptions *List             `since:"18"` // RETURNING WITH OLD/NEW aliases
+	WithClause       *WithClause       // WITH clause
+	Override         OverridingKind    // OVERRIDING clause
+}
+
+func (n *InsertStmt) Tag() NodeTag { return T_InsertStmt }
+
+// Delete Statement
+type DeleteStmt struct {
+	Relation         *RangeVar   // relation to delete from
+	UsingClause      *List       // optional using clause for more tables
+	WhereClause      Node        // qualifications
+	ReturningList    *List       // list of expressions to return
+	ReturningOptions *List       `since:"18"` // RETURNING WITH OLD/NEW aliases
+	WithClause       *WithClause // WITH clause
+}
+
+func (n *DeleteStmt) Tag() NodeTag { return T_DeleteStmt }
+
+// Update Statement
+type UpdateStmt struct {
+	Relation         *RangeVar   // relation to update
+	TargetList       *List       // the target list (of ResTarget)
+	WhereClause      Node        // qualifications
+	FromClause       *List       // optional from clause for more tables
+	ReturningList    *List       // list of expressions to return
+	ReturningOptions *List       `since:"18"` // RETURNING WITH OLD/NEW aliases
+	WithClause       *WithClause // WITH clause
+}
+
+func (n *UpdateStmt) Tag() NodeTag { return T_UpdateStmt }
+
+// Merge Statement
+type MergeStmt struct {
+	Relation         *RangeVar   // target relation to merge into
+	SourceRelation   Node        // source relation
+	JoinCondition    Node        // join condition between source and target
+	MergeWhenClauses *List       // list of MergeWhenClause(es)
+	ReturningList    *List       // list of expressions to return
+	ReturningOptions *List       `since:"18"` // RETURNING WITH OLD/NEW aliases
+	WithClause       *WithClause // WITH clause
+}
+
+func (n *MergeStmt) Tag() NodeTag { return T_MergeStmt }
+
+// Select Statement
+//
+// A "simple" SELECT is represented in the output of gram.y by a single
+// SelectStmt node; so is a VALUES construct.  A query containing set
+// operators (UNION, INTERSECT, EXCEPT) is represented by a tree of SelectStmt
+// nodes, in which the leaf nodes are component SELECTs and the internal nodes
+// represent UNION, INTERSECT, or EXCEPT operators.  Using the same node
+// type for both leaf and internal nodes allows gram.y to stick ORDER BY,
+// LIMIT, etc, clause values into a SELECT statement without worrying
+// whether it is a simple or compound SELECT.
+type SetOperation int
+
+const (
+	SETOP_NONE      SetOperation = 0
+	SETOP_UNION     SetOperation = SETOP_NONE + 1
+	SETOP_INTERSECT SetOperation = SETOP_UNION + 1
+	SETOP_EXCEPT    SetOperation = SETOP_INTERSECT + 1
+)
+
+type SelectStmt struct {
+	// These fields are used only in "leaf" SelectStmts.
+	// NULL, list of DISTINCT ON exprs, or
+	// lcons(NIL,NIL) for all (SELECT DISTINCT)
+	DistinctClause *List
+	IntoClause     *IntoClause // target for SELECT INTO
+	TargetList     *List       // the target list (of ResTarget)
+	FromClause     *List       // the FROM clause
+	WhereClause    Node        // WHERE qualification
+	GroupClause    *List       // GROUP BY clauses
+	GroupDistinct  bool        // Is this GROUP BY DISTINCT?
+	HavingClause   Node        // HAVING conditional-expression
+	WindowClause   *List       // WINDOW window_name AS (...), ...
+	// In a "leaf" node representing a VALUES list, the above fields are all
+	// null, and instead this field is set.  Note that the elements of the
+	// sublists are just expressions, without ResTarget decoration. Also note
+	// that a list element can be DEFAULT (represented as a SetToDefault
+	// node), regardless of the context of the VALUES list. It's up to parse
+	// analysis to reject that where not valid.
+	// untransformed list of expression lists
+	ValuesLists *List
+	// These fields are used in both "leaf" SelectStmts and upper-level
+	// SelectStmts.
+	// sort clause (a list of SortBy's)
+	SortClause    *List
+	LimitOffset   Node        // # of result tuples to skip
+	LimitCount    Node        // # of result tuples to return
+	LimitOption   LimitOption // limit type
+	LockingClause *List       // FOR UPDATE (list of LockingClause's)
+	WithClause    *WithClause // WITH clause
+	// These fields are used only in upper-level SelectStmts.
+	// type of set op
+	Op   SetOperation
+	All  bool        // ALL specified?
+	Larg *SelectStmt // left child
+	Rarg *SelectStmt // right child
+}
+
+func (n *SelectStmt) Tag() NodeTag { return T_SelectStmt }
+
+// Set Operation node for post-analysis query trees
+//
+// After parse analysis, a SELECT with set operations is represented by a
+// top-level Query node containing the leaf SELECTs as subqueries in its
+// range table.  Its setOperations field shows the tree of set operations,
+// with leaf SelectStmt nodes replaced by RangeTblRef nodes, and internal
+// nodes replaced by SetOperationStmt nodes.  Information about the output
+// column types is added, too.  (Note that the child nodes do not necessarily
+// produce these types directly, but we've checked that their output types
+// can be coerced to the output column type.)  Also, if it's not UNION ALL,
+// information about the types' sort/group semantics is provided in the form
+// of a SortGroupClause list (same representation as, eg, DISTINCT).
+// The resolved common column collations are provided too; but note that if
+// it's not UNION ALL, it's okay for a column to not have a common collation,
+// so a member of the colCollations list could be InvalidOid even though the
+// column has a collatable type.
+type SetOperationStmt struct {
+	Op   SetOperation // type of set op
+	All  bool         // ALL specified?
+	Larg Node         // left child
+	Rarg Node         // right child
+	// Eventually add fields for CORRESPONDING spec here
+	// Fields derived during parse analysis (irrelevant for query jumbling):
+	// OID list of output column type OIDs
+	ColTypes      *OidList
+	ColTypmods    *IntList // integer list of output column typmods
+	ColCollations *OidList // OID list of output column collation OIDs
+	GroupClauses  *List    // a list of SortGroupClause's
+}
+
+func (n *SetOperationStmt) Tag() NodeTag { return T_SetOperationStmt }
+
+// RETURN statement (inside SQL function body)
+type ReturnStmt struct {
+	Returnval Node
+}
+
+func (n *ReturnStmt) Tag() NodeTag { return T_ReturnStmt }
+
+// PL/pgSQL Assignment Statement
+//
+// Like SelectStmt, this is transformed into a SELECT Query.
+// However, the targetlist of the result looks more like an UPDATE.
+type PLAssignStmt struct {
+	Name        string      // initial column name
+	Indirection *List       // subscripts and field names, if any
+	Nnames      int         // number of names to use in ColumnRef
+	Val         *SelectStmt // the PL/pgSQL expression to assign
+	Location    ParseLoc    // name's token location, or -1 if unknown
+}
+
+func (n *PLAssignStmt) Tag() NodeTag { return T_PLAssignStmt }
+
+// When a command can act on several kinds of objects with only one
+// parse structure required, use these constants to designate the
+// object type.  Note that commands typically don't support all the types.
+type ObjectType int
+
+const (
+	OBJECT_ACCESS_METHOD ObjectType = iota
+	OBJECT_AGGREGATE
+	OBJECT_AMOP
+	OBJECT_AMPROC
+	OBJECT_ATTRIBUTE // type's attribute, when distinct from column
+	OBJECT_CAST
+	OBJECT_COLUMN
+	OBJECT_COLLATION
+	OBJECT_CONVERSION
+	OBJECT_DATABASE
+	OBJECT_DEFAULT
+	OBJECT_DEFACL
+	OBJECT_DOMAIN
+	OBJECT_DOMCONSTRAINT
+	OBJECT_EVENT_TRIGGER
+	OBJECT_EXTENSION
+	OBJECT_FDW
+	OBJECT_FOREIGN_SERVER
+	OBJECT_FOREIGN_TABLE
+	OBJECT_FUNCTION
+	OBJECT_INDEX
+	OBJECT_LANGUAGE
+	OBJECT_LARGEOBJECT
+	OBJECT_MATVIEW
+	OBJECT_OPCLASS
+	OBJECT_OPERATOR
+	OBJECT_OPFAMILY
+	OBJECT_PARAMETER_ACL
+	OBJECT_POLICY
+	OBJECT_PROCEDURE
+	OBJECT_PUBLICATION
+	OBJECT_PUBLICATION_NAMESPACE
+	OBJECT_PUBLICATION_REL
+	OBJECT_ROLE
+	OBJECT_ROUTINE
+	OBJECT_RULE
+	OBJECT_SCHEMA
+	OBJECT_SEQUENCE
+	OBJECT_SUBSCRIPTION
+	OBJECT_STATISTIC_EXT
+	OBJECT_TABCONSTRAINT
+	OBJECT_TABLE
+	OBJECT_TABLESPACE
+	OBJECT_TRANSFORM
+	OBJECT_TRIGGER
+	OBJECT_TSCONFIGURATION
+	OBJECT_TSDICTIONARY
+	OBJECT_TSPARSER
+	OBJECT_TSTEMPLATE
+	OBJECT_TYPE
+	OBJECT_USER_MAPPING
+	OBJECT_VIEW
+)
+
+// Create Schema Statement
+//
+// NOTE: the schemaElts list contains raw parsetrees for component statements
+// of the schema, such as CREATE TABLE, GRANT, etc.  These are analyzed and
+// executed after the schema itself is created.
+type CreateSchemaStmt struct {
+	Schemaname  string    // the name of the schema to create
+	Authrole    *RoleSpec // the owner of the created schema
+	SchemaElts  *List     // schema components (list of parsenodes)
+	IfNotExists bool      `pg:"if_not_exists"` // just do nothing if schema already exists?
+}
+
+func (n *CreateSchemaStmt) Tag() NodeTag { return T_CreateSchemaStmt }
+
+type DropBehavior int
+
+const (
+	DROP_RESTRICT DropBehavior = iota // drop fails if any dependent objects
+	DROP_CASCADE                      // remove dependent objects too
+)
+
+// Alter Table
+type AlterTableStmt struct {
+	Relation  *RangeVar  // table to work on
+	Cmds      *List      // list of subcommands
+	Objtype   ObjectType // type of object
+	MissingOk bool       `pg:"missing_ok"` // skip error if table missing
+}
+
+func (n *AlterTableStmt) Tag() NodeTag { return T_AlterTableStmt }
+
+type AlterTableType int
+
+const (
+	AT_AddColumn                 AlterTableType = iota // add column
+	AT_AddColumnToView                                 // implicitly via CREATE OR REPLACE VIEW
+	AT_ColumnDefault                                   // alter column default
+	AT_CookedColumnDefault                             // add a pre-cooked column default
+	AT_DropNotNull                                     // alter column drop not null
+	AT_SetNotNull                                      // alter column set not null
+	AT_SetExpression                                   // alter column set expression
+	AT_DropExpression                                  // alter column drop expression
+	AT_CheckNotNull                                    // check column is already marked not null
+	AT_SetStatistics                                   // alter column set statistics
+	AT_SetOptions                                      // alter column set ( options )
+	AT_ResetOptions                                    // alter column reset ( options )
+	AT_SetStorage                                      // alter column set storage
+	AT_SetCompression                                  // alter column set compression
+	AT_DropColumn                                      // drop column
+	AT_AddIndex                                        // add index
+	AT_ReAddIndex                                      // internal to commands/tablecmds.c
+	AT_AddConstraint                                   // add constraint
+	AT_ReAddConstraint                                 // internal to commands/tablecmds.c
+	AT_ReAddDomainConstraint                           // internal to commands/tablecmds.c
+	AT_AlterConstraint                                 // alter constraint
+	AT_ValidateConstraint                              // validate constraint
+	AT_AddIndexConstraint                              // add constraint using existing index
+	AT_DropConstraint                                  // drop constraint
+	AT_ReAddComment                                    // internal to commands/tablecmds.c
+	AT_AlterColumnType                                 // alter column type
+	AT_AlterColumnGenericOptions                       // alter column OPTIONS (...)
+	AT_ChangeOwner                                     // change owner
+	AT_ClusterOn                                       // CLUSTER ON
+	AT_DropCluster                                     // SET WITHOUT CLUSTER
+	AT_SetLogged                                       // SET LOGGED
+	AT_SetUnLogged                                     // SET UNLOGGED
+	AT_DropOids                                        // SET WITHOUT OIDS
+	AT_SetAccessMethod                                 // SET ACCESS METHOD
+	AT_SetTableSpace                                   // SET TABLESPACE
+	AT_SetRelOptions                                   // SET (...) -- AM specific parameters
+	AT_ResetRelOptions                                 // RESET (...) -- AM specific parameters
+	AT_ReplaceRelOptions                               // replace reloption list in its entirety
+	AT_EnableTrig                                      // ENABLE TRIGGER name
+	AT_EnableAlwaysTrig                                // ENABLE ALWAYS TRIGGER name
+	AT_EnableReplicaTrig                               // ENABLE REPLICA TRIGGER name
+	AT_DisableTrig                                     // DISABLE TRIGGER name
+	AT_EnableTrigAll                                   // ENABLE TRIGGER ALL
+	AT_DisableTrigAll                                  // DISABLE TRIGGER ALL
+	AT_EnableTrigUser                                  // ENABLE TRIGGER USER
+	AT_DisableTrigUser                                 // DISABLE TRIGGER USER
+	AT_EnableRule                                      // ENABLE RULE name
+	AT_EnableAlwaysRule                                // ENABLE ALWAYS RULE name
+	AT_EnableReplicaRule                               // ENABLE REPLICA RULE name
+	AT_DisableRule                                     // DISABLE RULE name
+	AT_AddInherit                                      // INHERIT parent
+	AT_DropInherit                                     // NO INHERIT parent
+	AT_AddOf                                           // OF <type_name>
+	AT_DropOf                                          // NOT OF
+	AT_ReplicaIdentity                                 // REPLICA IDENTITY
+	AT_EnableRowSecurity                               // ENABLE ROW SECURITY
+	AT_DisableRowSecurity                              // DISABLE ROW SECURITY
+	AT_ForceRowSecurity                                // FORCE ROW SECURITY
+	AT_NoForceRowSecurity                              // NO FORCE ROW SECURITY
+	AT_GenericOptions                                  // OPTIONS (...)
+	AT_AttachPartition                                 // ATTACH PARTITION
+	AT_DetachPartition                                 // DETACH PARTITION
+	AT_DetachPartitionFinalize                         // DETACH PARTITION FINALIZE
+	AT_AddIdentity                                     // ADD IDENTITY
+	AT_SetIdentity                                     // SET identity column options
+	AT_DropIdentity                                    // DROP IDENTITY
+	AT_ReAddStatistics                                 // internal to commands/tablecmds.c
+)
+
+type ReplicaIdentityStmt struct {
+	IdentityType byte `pg:"identity_type"`
+	Name         string
+}
+
+func (n *ReplicaIdentityStmt) Tag() NodeTag { return T_ReplicaIdentityStmt }
+
+type AlterTableCmd struct {
+	Subtype AlterTableType // Type of table alteration to apply
+	// column, constraint, or trigger to act on,
+	// or tablespace, access method
+	Name string
+	// attribute number for columns referenced by
+	// number
+	Num      int16
+	Newowner *RoleSpec
+	// definition of new column, index,
+	// constraint, or parent table
+	Def       Node
+	Behavior  DropBehavior // RESTRICT or CASCADE for DROP cases
+	MissingOk bool         `pg:"missing_ok"` // skip error if missing?
+	Recurse   bool         // exec-time recursion
+}
+
+func (n *AlterTableCmd) Tag() NodeTag { return T_AlterTableCmd }
+
+// Alter Collation
+type AlterCollationStmt struct {
+	Collname *List
+}
+
+func (n *AlterCollationStmt) Tag() NodeTag { return T_AlterCollationStmt }
+
+// Alter Domain
+//
+// The fields are used in different ways by the different variants of
+// this command.
+type AlterDomainStmt struct {
+	// T = alter column default
+	// N = alter column drop not null
+	// O = alter column set not null
+	// C = add constraint
+	// X = drop constraint
+	Subtype   byte
+	TypeName  *List        // domain to work on
+	Name      string       // column or constraint name to act on
+	Def       Node         // definition of default or constraint
+	Behavior  DropBehavior // RESTRICT or CASCADE for DROP cases
+	MissingOk bool         `pg:"missing_ok"` // skip error if missing?
+}
+
+func (n *AlterDomainStmt) Tag() NodeTag { return T_AlterDomainStmt }
+
+// Grant|Revoke Statement
+type GrantTargetType int
+
+const (
+	ACL_TARGET_OBJECT        GrantTargetType = iota // grant on specific named object(s)
+	ACL_TARGET_ALL_IN_SCHEMA                        // grant on all objects in given schema(s)
+	ACL_TARGET_DEFAULTS                             // ALTER DEFAULT PRIVILEGES
+)
+
+type GrantStmt struct {
+	IsGrant  bool            `pg:"is_grant"` // true = GRANT, false = REVOKE
+	Targtype GrantTargetType // type of the grant target
+	Objtype  ObjectType      // kind of object being operated on
+	// list of RangeVar nodes, ObjectWithArgs
+	// nodes, or plain names (as String values)
+	Objects    *List
+	Privileges *List // list of AccessPriv nodes
+	// privileges == NIL denotes ALL PRIVILEGES
+	// list of RoleSpec nodes
+	Grantees    *List
+	GrantOption bool `pg:"grant_option"` // grant or revoke grant option
+	Grantor     *RoleSpec
+	Behavior    DropBehavior // drop behavior (for REVOKE)
+}
+
+func (n *GrantStmt) Tag() NodeTag { return T_GrantStmt }
+
+// ObjectWithArgs represents a function/procedure/operator name plus parameter
+// identification.
+//
+// objargs includes only the types of the input parameters of the object.
+// In some contexts, that will be all we have, and it's enough to look up
+// objects according to the traditional Postgres rules (i.e., when only input
+// arguments matter).
+//
+// objfuncargs, if not NIL, carries the full specification of the parameter
+// list, including parameter mode annotations.
+//
+// Some grammar productions can set args_unspecified = true instead of
+// providing parameter info.  In this case, lookup will succeed only if
+// the object name is unique.  Note that otherwise, NIL parameter lists
+// mean zero arguments.
+type ObjectWithArgs struct {
+	Objname         *List // qualified name of function/operator
+	Objargs         *List // list of Typename nodes (input args only)
+	Objfuncargs     *List // list of FunctionParameter nodes
+	ArgsUnspecified bool  `pg:"args_unspecified"` // argument list was omitted?
+}
+
+func (n *ObjectWithArgs) Tag() NodeTag { return T_ObjectWithArgs }
+
+// An access privilege, with optional list of column names
+// priv_name == NULL denotes ALL PRIVILEGES (only used with a column list)
+// cols == NIL denotes "all columns"
+// Note that simple "ALL PRIVILEGES" is represented as a NIL list, not
+// an AccessPriv with both fields null.
+type AccessPriv struct {
+	PrivName string `pg:"priv_name"` // string name of privilege
+	Cols     *List  // list of String
+}
+
+func (n *AccessPriv) Tag() NodeTag { return T_AccessPriv }
+
+// Grant/Revoke Role Statement
+//
+// Note: because of the parsing ambiguity with the GRANT <privileges>
+// statement, granted_roles is a list of AccessPriv; the execution code
+// should complain if any column lists appear.  grantee_roles is a list
+// of role names, as String values.
+type GrantRoleStmt struct {
+	GrantedRoles *List        `pg:"granted_roles"` // list of roles to be granted/revoked
+	GranteeRoles *List        `pg:"grantee_roles"` // list of member roles to add/delete
+	IsGrant      bool         `pg:"is_grant"`      // true = GRANT, false = REVOKE
+	Opt          *List        // options e.g. WITH GRANT OPTION
+	Grantor      *RoleSpec    // set grantor to other than current role
+	Behavior     DropBehavior // drop behavior (for REVOKE)
+}
+
+func (n *GrantRoleStmt) Tag() NodeTag { return T_GrantRoleStmt }
+
+// Alter Default Privileges Statement
+type AlterDefaultPrivilegesStmt struct {
+	Options *List      // list of DefElem
+	Action  *GrantStmt // GRANT/REVOKE action (with objects=NIL)
+}
+
+func (n *AlterDefaultPrivilegesStmt) Tag() NodeTag { return T_AlterDefaultPrivilegesStmt }
+
+// Copy Statement
+//
+// We support "COPY relation FROM file", "COPY relation TO file", and
+// "COPY (query) TO file".  In any given CopyStmt, exactly one of "relation"
+// and "query" must be non-NULL.
+type CopyStmt struct {
+	Relation *RangeVar // the relation to copy
+	// the query (SELECT or DML statement with
+	// RETURNING) to copy, as a raw parse tree
+	Query Node
+	// List of column names (as Strings), or NIL
+	// for all columns
+	Attlist     *List
+	IsFrom      bool   `pg:"is_from"`    // TO or FROM
+	IsProgram   bool   `pg:"is_program"` // is 'filename' a program to popen?
+	Filename    string // filename, or NULL for STDIN/STDOUT
+	Options     *List  // List of DefElem nodes
+	WhereClause Node   // WHERE condition (or NULL)
+}
+
+func (n *CopyStmt) Tag() NodeTag { return T_CopyStmt }
+
+// SET Statement (includes RESET)
+//
+// "SET var TO DEFAULT" and "RESET var" are semantically equivalent, but we
+// preserve the distinction in VariableSetKind for CreateCommandTag().
+type VariableSetKind int
+
+const (
+	VAR_SET_VALUE   VariableSetKind = iota // SET var = value
+	VAR_SET_DEFAULT                        // SET var TO DEFAULT
+	VAR_SET_CURRENT                        // SET var FROM CURRENT
+	VAR_SET_MULTI                          // special case for SET TRANSACTION ...
+	VAR_RESET                              // RESET var
+	VAR_RESET_ALL                          // RESET ALL
+)
+
+type VariableSetStmt struct {
+	Kind    VariableSetKind
+	Name    string // variable to be set
+	Args    *List  // List of A_Const nodes
+	IsLocal bool   `pg:"is_local"` // SET LOCAL?
+}
+
+func (n *VariableSetStmt) Tag() NodeTag { return T_VariableSetStmt }
+
+// Show Statement
+type VariableShowStmt struct {
+	Name string
+}
+
+func (n *VariableShowStmt) Tag() NodeTag { return T_VariableShowStmt }
+
+// Create Table Statement
+//
+// NOTE: in the raw gram.y output, ColumnDef and Constraint nodes are
+// intermixed in tableElts, and constraints is NIL.  After parse analysis,
+// tableElts contains just ColumnDefs, and constraints contains just
+// Constraint nodes (in fact, only CONSTR_CHECK nodes, in the present
+// implementation).
+type CreateStmt struct {
+	Relation  *RangeVar // relation to create
+	TableElts *List     // column definitions (list of ColumnDef)
+	// relations to inherit from (list of
+	// RangeVar)
+	InhRelations   *List
+	Partbound      *PartitionBoundSpec // FOR VALUES clause
+	Partspec       *PartitionSpec      // PARTITION BY clause
+	OfTypename     *TypeName           // OF typename
+	Constraints    *List               // constraints (list of Constraint nodes)
+	Options        *List               // options from WITH clause
+	Oncommit       OnCommitAction      // what do we do at COMMIT?
+	Tablespacename string              // table space to use, or NULL
+	AccessMethod   string              // table access method
+	IfNotExists    bool                `pg:"if_not_exists"` // just do nothing if it already exists?
+}
+
+func (n *CreateStmt) Tag() NodeTag { return T_CreateStmt }
+
+// Definitions for constraints in CreateStmt
+//
+// Note that column defaults are treated as a type of constraint,
+// even though that's a bit odd semantically.
+//
+// For constraints that use expressions (CONSTR_CHECK, CONSTR_DEFAULT)
+// we may have the expression in either "raw" form (an untransformed
+// parse tree) or "cooked" form (the nodeToString representation of
+// an executable expression tree), depending on how this Constraint
+// node was created (by parsing, or by inheritance from an existing
+// relation).  We should never have both in the same node!
+//
+// FKCONSTR_ACTION_xxx values are stored into pg_constraint.confupdtype
+// and pg_constraint.confdeltype columns; FKCONSTR_MATCH_xxx values are
+// stored into pg_constraint.confmatchtype.  Changing the code values may
+// require an initdb!
+//
+// If skip_validation is true then we skip checking that the existing rows
+// in the table satisfy the constraint, and just install the catalog entries
+// for the constraint.  A new FK constraint is marked as valid iff
+// initially_valid is true.  (Usually skip_validation and initially_valid
+// are inverses, but we can set both true if the table is known empty.)
+//
+// Constraint attributes (DEFERRABLE etc) are initially represented as
+// separate Constraint nodes for simplicity of parsing.  parse_utilcmd.c makes
+// a pass through the constraints list to insert the info into the appropriate
+// Constraint node.
+type ConstrType int
+
+const (
+	// not standard SQL, but a lot of people
+	// expect it
+	CONSTR_NULL ConstrType = iota
+	CONSTR_NOTNULL
+	CONSTR_DEFAULT
+	CONSTR_IDENTITY
+	CONSTR_GENERATED
+	CONSTR_CHECK
+	CONSTR_PRIMARY
+	CONSTR_UNIQUE
+	CONSTR_EXCLUSION
+	CONSTR_FOREIGN
+	CONSTR_ATTR_DEFERRABLE // attributes for previous constraint node
+	CONSTR_ATTR_NOT_DEFERRABLE
+	CONSTR_ATTR_DEFERRED
+	CONSTR_ATTR_IMMEDIATE
+)
+
+// Foreign key action codes
+const (
+	FKCONSTR_ACTION_NOACTION   = 'a'
+	FKCONSTR_ACTION_RESTRICT   = 'r'
+	FKCONSTR_ACTION_CASCADE    = 'c'
+	FKCONSTR_ACTION_SETNULL    = 'n'
+	FKCONSTR_ACTION_SETDEFAULT = 'd'
+)
+
+// Foreign key matchtype codes
+const (
+	FKCONSTR_MATCH_FULL    = 'f'
+	FKCONSTR_MATCH_PARTIAL = 'p'
+	FKCONSTR_MATCH_SIMPLE  = 's'
+)
+
+type Constraint struct {
+	Contype        ConstrType // see above
+	Conname        string     // Constraint name, or NULL if unnamed
+	Deferrable     bool       // DEFERRABLE?
+	Initdeferred   bool       // INITIALLY DEFERRED?
+	SkipValidation bool       `pg:"skip_validation"` // skip validation of existing rows?
+	InitiallyValid bool       `pg:"initially_valid"` // mark the new constraint as valid?
+	IsNoInherit    bool       `pg:"is_no_inherit"`   // is constraint non-inheritable?
+	// CHECK or DEFAULT expression, as
+	// untransformed parse tree
+	RawExpr Node `pg:"raw_expr"`
+	// CHECK or DEFAULT expression, as
+	// nodeToString representation
+	CookedExpr    string `pg:"cooked_expr"`
+	GeneratedWhen byte   `pg:"generated_when"` // ALWAYS or BY DEFAULT
+	GeneratedKind byte   `since:"18"`          // STORED or VIRTUAL; zero before PG 18
+	// initial inheritance count to apply, for
+	// "raw" NOT NULL constraints
+	Inhcount         int
+	NullsNotDistinct bool `pg:"nulls_not_distinct"` // null treatment for UNIQUE constraints
+	// String nodes naming referenced key
+	// column(s); for UNIQUE/PK/NOT NULL
+	Keys *List
+	// String nodes naming referenced nonkey
+	// column(s); for UNIQUE/PK
+	Including *List
+	// list of (IndexElem, operator name) pairs;
+	// for exclusion constraints
+	Exclusions *List
+	Options    *List  // options from WITH clause
+	Indexname  string // existing index to use; otherwise NULL
+	Indexspace string // index tablespace; NULL for default
+	// reset default_tablespace prior to
+	// creating the index
+	ResetDefaultTblspc bool   `pg:"reset_default_tblspc"`
+	AccessMethod       string `pg:"access_method"` // index access method; NULL for default
+	WhereClause        Node   `pg:"where_clause"`  // partial index predicate
+	// Fields used for FOREIGN KEY constraints:
+	// Primary key table
+	Pktable      *RangeVar
+	FkAttrs      *List `pg:"fk_attrs"`        // Attributes of foreign key
+	PkAttrs      *List `pg:"pk_attrs"`        // Corresponding attrs in PK table
+	FkMatchtype  byte  `pg:"fk_matchtype"`    // FULL, PARTIAL, SIMPLE
+	FkUpdAction  byte  `pg:"fk_upd_action"`   // ON UPDATE action
+	FkDelAction  byte  `pg:"fk_del_action"`   // ON DELETE action
+	FkDelSetCols *List `pg:"fk_del_set_cols"` // ON DELETE SET NULL/DEFAULT (col1, col2)
+	OldConpfeqop *List `pg:"old_conpfeqop"`   // pg_constraint.conpfeqop of my former self
+	// pg_constraint.confrelid of my former
+	// self
+	OldPktableOid Oid      `pg:"old_pktable_oid"`
+	Location      ParseLoc // token location, or -1 if unknown
+}
+
+func (n *Constraint) Tag() NodeTag { return T_Constraint }
+
+// Create/Drop Table Space Statements
+type CreateTableSpaceStmt struct {
+	Tablespacename string
+	Owner          *RoleSpec
+	Location       string
+	Options        *List
+}
+
+func (n *CreateTableSpaceStmt) Tag() NodeTag { return T_CreateTableSpaceStmt }
+
+type DropTableSpaceStmt struct {
+	Tablespacename string
+	MissingOk      bool `pg:"missing_ok"` // skip error if missing?
+}
+
+func (n *DropTableSpaceStmt) Tag() NodeTag { return T_DropTableSpaceStmt }
+
+type AlterTableSpaceOptionsStmt struct {
+	Tablespacename string
+	Options        *List
+	IsReset        bool
+}
+
+func (n *AlterTableSpaceOptionsStmt) Tag() NodeTag { return T_AlterTableSpaceOptionsStmt }
+
+type AlterTableMoveAllStmt struct {
+	OrigTablespacename string     `pg:"orig_tablespacename"`
+	Objtype            ObjectType // Object type to move
+	Roles              *List      // List of roles to move objects of
+	NewTablespacename  string     `pg:"new_tablespacename"`
+	Nowait             bool
+}
+
+func (n *AlterTableMoveAllStmt) Tag() NodeTag { return T_AlterTableMoveAllStmt }
+
+// Create/Alter Extension Statements
+type CreateExtensionStmt struct {
+	Extname     string
+	IfNotExists bool  `pg:"if_not_exists"` // just do nothing if it already exists?
+	Options     *List // List of DefElem nodes
+}
+
+func (n *CreateExtensionStmt) Tag() NodeTag { return T_CreateExtensionStmt }
+
+// Only used for ALTER EXTENSION UPDATE; later might need an action field
+type AlterExtensionStmt struct {
+	Extname string
+	Options *List // List of DefElem nodes
+}
+
+func (n *AlterExtensionStmt) Tag() NodeTag { return T_AlterExtensionStmt }
+
+type AlterExtensionContentsStmt struct {
+	Extname string     // Extension's name
+	Action  int        // +1 = add object, -1 = drop object
+	Objtype ObjectType // Object's type
+	Object  Node       // Qualified name of the object
+}
+
+func (n *AlterExtensionContentsStmt) Tag() NodeTag { return T_AlterExtensionContentsStmt }
+
+// Create/Alter FOREIGN DATA WRAPPER Statements
+type CreateFdwStmt struct {
+	Fdwname     string // foreign-data wrapper name
+	FuncOptions *List  `pg:"func_options"` // HANDLER/VALIDATOR options
+	Options     *List  // generic options to FDW
+}
+
+func (n *CreateFdwStmt) Tag() NodeTag { return T_CreateFdwStmt }
+
+type AlterFdwStmt struct {
+	Fdwname     string // foreign-data wrapper name
+	FuncOptions *List  `pg:"func_options"` // HANDLER/VALIDATOR options
+	Options     *List  // generic options to FDW
+}
+
+func (n *AlterFdwStmt) Tag() NodeTag { return T_AlterFdwStmt }
+
+// Create/Alter FOREIGN SERVER Statements
+type CreateForeignServerStmt struct {
+	Servername  string // server name
+	Servertype  string // optional server type
+	Version     string // optional server version
+	Fdwname     string // FDW name
+	IfNotExists bool   `pg:"if_not_exists"` // just do nothing if it already exists?
+	Options     *List  // generic options to server
+}
+
+func (n *CreateForeignServerStmt) Tag() NodeTag { return T_CreateForeignServerStmt }
+
+type AlterForeignServerStmt struct {
+	Servername string // server name
+	Version    string // optional server version
+	Options    *List  // generic options to server
+	HasVersion bool   `pg:"has_version"` // version specified
+}
+
+func (n *AlterForeignServerStmt) Tag() NodeTag { return T_AlterForeignServerStmt }
+
+// Create FOREIGN TABLE Statement
+type CreateForeignTableStmt struct {
+	Base       CreateStmt
+	Servername string
+	Options    *List
+}
+
+func (n *CreateForeignTableStmt) Tag() NodeTag { return T_CreateForeignTableStmt }
+
+// Create/Drop USER MAPPING Statements
+type CreateUserMappingStmt struct {
+	User        *RoleSpec // user role
+	Servername  string    // server name
+	IfNotExists bool      `pg:"if_not_exists"` // just do nothing if it already exists?
+	Options     *List     // generic options to server
+}
+
+func (n *CreateUserMappingStmt) Tag() NodeTag { return T_CreateUserMappingStmt }
+
+type AlterUserMappingStmt struct {
+	User       *RoleSpec // user role
+	Servername string    // server name
+	Options    *List     // generic options to server
+}
+
+func (n *AlterUserMappingStmt) Tag() NodeTag { return T_AlterUserMappingStmt }
+
+type DropUserMappingStmt struct {
+	User       *RoleSpec // user role
+	Servername string    // server name
+	MissingOk  bool      `pg:"missing_ok"` // ignore missing mappings
+}
+
+func (n *DropUserMappingStmt) Tag() NodeTag { return T_DropUserMappingStmt }
+
+// Import Foreign Schema Statement
+type ImportForeignSchemaType int
+
+const (
+	FDW_IMPORT_SCHEMA_ALL      ImportForeignSchemaType = iota // all relations wanted
+	FDW_IMPORT_SCHEMA_LIMIT_TO                                // include only listed tables in import
+	FDW_IMPORT_SCHEMA_EXCEPT                                  // exclude listed tables from import
+)
+
+type ImportForeignSchemaStmt struct {
+	ServerName   string                  `pg:"server_name"`   // FDW server name
+	RemoteSchema string                  `pg:"remote_schema"` // remote schema name to query
+	LocalSchema  string                  `pg:"local_schema"`  // local schema to create objects in
+	ListType     ImportForeignSchemaType `pg:"list_type"`     // type of table list
+	TableList    *List                   `pg:"table_list"`    // List of RangeVar
+	Options      *List                   // list of options to pass to FDW
+}
+
+func (n *ImportForeignSchemaStmt) Tag() NodeTag { return T_ImportForeignSchemaStmt }
+
+// Create POLICY Statement
+type CreatePolicyStmt struct {
+	PolicyName string    `pg:"policy_name"` // Policy's name
+	Table      *RangeVar // the table name the policy applies to
+	CmdName    string    `pg:"cmd_name"` // the command name the policy applies to
+	Permissive bool      // restrictive or permissive policy
+	Roles      *List     // the roles associated with the policy
+	Qual       Node      // the policy's condition
+	WithCheck  Node      `pg:"with_check"` // the policy's WITH CHECK condition.
+}
+
+func (n *CreatePolicyStmt) Tag() NodeTag { return T_CreatePolicyStmt }
+
+// Alter POLICY Statement
+type AlterPolicyStmt struct {
+	PolicyName string    `pg:"policy_name"` // Policy's name
+	Table      *RangeVar // the table name the policy applies to
+	Roles      *List     // the roles associated with the policy
+	Qual       Node      // the policy's condition
+	WithCheck  Node      `pg:"with_check"` // the policy's WITH CHECK condition.
+}
+
+func (n *AlterPolicyStmt) Tag() NodeTag { return T_AlterPolicyStmt }
+
+// Create ACCESS METHOD Statement
+type CreateAmStmt struct {
+	Amname      string // access method name
+	HandlerName *List  `pg:"handler_name"` // handler function name
+	Amtype      byte   // type of access method
+}
+
+func (n *CreateAmStmt) Tag() NodeTag { return T_CreateAmStmt }
+
+// Create TRIGGER Statement
+type CreateTrigStmt struct {
+	Replace      bool      // replace trigger if already exists
+	Isconstraint bool      // This is a constraint trigger
+	Trigname     string    // TRIGGER's name
+	Relation     *RangeVar // relation trigger is on
+	Funcname     *List     // qual. name of function to call
+	Args         *List     // list of String or NIL
+	Row          bool      // ROW/STATEMENT
+	// timing uses the TRIGGER_TYPE bits defined in catalog/pg_trigger.h
+	// BEFORE, AFTER, or INSTEAD
+	Timing int16
+	// events uses the TRIGGER_TYPE bits defined in catalog/pg_trigger.h
+	// "OR" of INSERT/UPDATE/DELETE/TRUNCATE
+	Events     int16
+	Columns    *List // column names, or NIL for all columns
+	WhenClause Node  // qual expression, or NULL if none
+	// explicitly named transition data
+	// TriggerTransition nodes, or NIL if none
+	TransitionRels *List
+	// The remaining fields are only used for constraint triggers
+	// [NOT] DEFERRABLE
+	Deferrable   bool
+	Initdeferred bool      // INITIALLY {DEFERRED|IMMEDIATE}
+	Constrrel    *RangeVar // opposite relation, if RI trigger
+}
+
+func (n *CreateTrigStmt) Tag() NodeTag { return T_CreateTrigStmt }
+
+// Create EVENT TRIGGER Statement
+type CreateEventTrigStmt struct {
+	Trigname   string // TRIGGER's name
+	Eventname  string // event's identifier
+	Whenclause *List  // list of DefElems indicating filtering
+	Funcname   *List  // qual. name of function to call
+}
+
+func (n *CreateEventTrigStmt) Tag() NodeTag { return T_CreateEventTrigStmt }
+
+// Alter EVENT TRIGGER Statement
+type AlterEventTrigStmt struct {
+	Trigname string // TRIGGER's name
+	// trigger's firing configuration WRT
+	// session_replication_role
+	Tgenabled byte
+}
+
+func (n *AlterEventTrigStmt) Tag() NodeTag { return T_AlterEventTrigStmt }
+
+// Create LANGUAGE Statements
+type CreatePLangStmt struct {
+	Replace     bool   // T => replace if already exists
+	Plname      string // PL name
+	Plhandler   *List  // PL call handler function (qual. name)
+	Plinline    *List  // optional inline function (qual. name)
+	Plvalidator *List  // optional validator function (qual. name)
+	Pltrusted   bool   // PL is trusted
+}
+
+func (n *CreatePLangStmt) Tag() NodeTag { return T_CreatePLangStmt }
+
+// Create/Alter/Drop Role Statements
+//
+// Note: these node types are also used for the backwards-compatible
+// Create/Alter/Drop User/Group statements.  In the ALTER and DROP cases
+// there's really no need to distinguish what the original spelling was,
+// but for CREATE we mark the type because the defaults vary.
+type RoleStmtType int
+
+const (
+	ROLESTMT_ROLE RoleStmtType = iota
+	ROLESTMT_USER
+	ROLESTMT_GROUP
+)
+
+type CreateRoleStmt struct {
+	StmtType RoleStmtType `pg:"stmt_type"` // ROLE/USER/GROUP
+	Role     string       // role name
+	Options  *List        // List of DefElem nodes
+}
+
+func (n *CreateRoleStmt) Tag() NodeTag { return T_CreateRoleStmt }
+
+type AlterRoleStmt struct {
+	Role    *RoleSpec // role
+	Options *List     // List of DefElem nodes
+	Action  int       // +1 = add members, -1 = drop members
+}
+
+func (n *AlterRoleStmt) Tag() NodeTag { return T_AlterRoleStmt }
+
+type AlterRoleSetStmt struct {
+	Role     *RoleSpec        // role
+	Database string           // database name, or NULL
+	Setstmt  *VariableSetStmt // SET or RESET subcommand
+}
+
+func (n *AlterRoleSetStmt) Tag() NodeTag { return T_AlterRoleSetStmt }
+
+type DropRoleStmt struct {
+	Roles     *List // List of roles to remove
+	MissingOk bool  `pg:"missing_ok"` // skip error if a role is missing?
+}
+
+func (n *DropRoleStmt) Tag() NodeTag { return T_DropRoleStmt }
+
+// {Create|Alter} SEQUENCE Statement
+type CreateSeqStmt struct {
+	Sequence    *RangeVar // the sequence to create
+	Options     *List
+	OwnerId     Oid  // ID of owner, or InvalidOid for default
+	ForIdentity bool `pg:"for_identity"`
+	IfNotExists bool `pg:"if_not_exists"` // just do nothing if it already exists?
+}
+
+func (n *CreateSeqStmt) Tag() NodeTag { return T_CreateSeqStmt }
+
+type AlterSeqStmt struct {
+	Sequence    *RangeVar // the sequence to alter
+	Options     *List
+	ForIdentity bool `pg:"for_identity"`
+	MissingOk   bool `pg:"missing_ok"` // skip error if a role is missing?
+}
+
+func (n *AlterSeqStmt) Tag() NodeTag { return T_AlterSeqStmt }
+
+// Create {Aggregate|Operator|Type} Statement
+type DefineStmt struct {
+	Kind        ObjectType // aggregate, operator, type
+	Oldstyle    bool       // hack to signal old CREATE AGG syntax
+	Defnames    *List      // qualified name (list of String)
+	Args        *List      // a list of TypeName (if needed)
+	Definition  *List      // a list of DefElem
+	IfNotExists bool       `pg:"if_not_exists"` // just do nothing if it already exists?
+	Replace     bool       // replace if already exists?
+}
+
+func (n *DefineStmt) Tag() NodeTag { return T_DefineStmt }
+
+// Create Domain Statement
+type CreateDomainStmt struct {
+	Domainname  *List          // qualified name (list of String)
+	TypeName    *TypeName      // the base type
+	CollClause  *CollateClause // untransformed COLLATE spec, if any
+	Constraints *List          // constraints (list of Constraint nodes)
+}
+
+func (n *CreateDomainStmt) Tag() NodeTag { return T_CreateDomainStmt }
+
+// Create Operator Class Statement
+type CreateOpClassStmt struct {
+	Opclassname  *List     // qualified name (list of String)
+	Opfamilyname *List     // qualified name (ditto); NIL if omitted
+	Amname       string    // name of index AM opclass is for
+	Datatype     *TypeName // datatype of indexed column
+	Items        *List     // List of CreateOpClassItem nodes
+	IsDefault    bool      // Should be marked as default for type?
+}
+
+func (n *CreateOpClassStmt) Tag() NodeTag { return T_CreateOpClassStmt }
+
+const (
+	OPCLASS_ITEM_OPERATOR    = 1
+	OPCLASS_ITEM_FUNCTION    = 2
+	OPCLASS_ITEM_STORAGETYPE = 3
+)
+
+type CreateOpClassItem struct {
+	Itemtype    int             // see codes above
+	Name        *ObjectWithArgs // operator or function name and args
+	Number      int             // strategy num or support proc num
+	OrderFamily *List           `pg:"order_family"` // only used for ordering operators
+	// amproclefttype/amprocrighttype or
+	// amoplefttype/amoprighttype
+	ClassArgs *List `pg:"class_args"`
+	// fields used for a storagetype item:
+	// datatype stored in index
+	Storedtype *TypeName
+}
+
+func (n *CreateOpClassItem) Tag() NodeTag { return T_CreateOpClassItem }
+
+// Create Operator Family Statement
+type CreateOpFamilyStmt struct {
+	Opfamilyname *List  // qualified name (list of String)
+	Amname       string // name of index AM opfamily is for
+}
+
+func (n *CreateOpFamilyStmt) Tag() NodeTag { return T_CreateOpFamilyStmt }
+
+// Alter Operator Family Statement
+type AlterOpFamilyStmt struct {
+	Opfamilyname *List  // qualified name (list of String)
+	Amname       string // name of index AM opfamily is for
+	IsDrop       bool   // ADD or DROP the items?
+	Items        *List  // List of CreateOpClassItem nodes
+}
+
+func (n *AlterOpFamilyStmt) Tag() NodeTag { return T_AlterOpFamilyStmt }
+
+// Drop Table|Sequence|View|Index|Type|Domain|Conversion|Schema Statement
+type DropStmt struct {
+	Objects    *List        // list of names
+	RemoveType ObjectType   // object type
+	Behavior   DropBehavior // RESTRICT or CASCADE behavior
+	MissingOk  bool         `pg:"missing_ok"` // skip error if object is missing?
+	Concurrent bool         // drop index concurrently?
+}
+
+func (n *DropStmt) Tag() NodeTag { return T_DropStmt }
+
+// Truncate Table Statement
+type TruncateStmt struct {
+	Relations   *List        // relations (RangeVars) to be truncated
+	RestartSeqs bool         `pg:"restart_seqs"` // restart owned sequences?
+	Behavior    DropBehavior // RESTRICT or CASCADE behavior
+}
+
+func (n *TruncateStmt) Tag() NodeTag { return T_TruncateStmt }
+
+// Comment On Statement
+type CommentStmt struct {
+	Objtype ObjectType // Object's type
+	Object  Node       // Qualified name of the object
+	Comment string     // Comment to insert, or NULL to remove
+}
+
+func (n *CommentStmt) Tag() NodeTag { return T_CommentStmt }
+
+// SECURITY LABEL Statement
+type SecLabelStmt struct {
+	Objtype  ObjectType // Object's type
+	Object   Node       // Qualified name of the object
+	Provider string     // Label provider (or NULL)
+	Label    string     // New security label to be assigned
+}
+
+func (n *SecLabelStmt) Tag() NodeTag { return T_SecLabelStmt }
+
+// Declare Cursor Statement
+//
+// The "query" field is initially a raw parse tree, and is converted to a
+// Query node during parse analysis.  Note that rewriting and planning
+// of the query are always postponed until execution.
+const (
+	CURSOR_OPT_BINARY      = 0x0001 // BINARY
+	CURSOR_OPT_SCROLL      = 0x0002 // SCROLL explicitly given
+	CURSOR_OPT_NO_SCROLL   = 0x0004 // NO SCROLL explicitly given
+	CURSOR_OPT_INSENSITIVE = 0x0008 // INSENSITIVE
+	CURSOR_OPT_ASENSITIVE  = 0x0010 // ASENSITIVE
+	CURSOR_OPT_HOLD        = 0x0020 // WITH HOLD
+)
+
+// these planner-control flags do not correspond to any SQL grammar:
+const (
+	CURSOR_OPT_FAST_PLAN    = 0x0100 // prefer fast-start plan
+	CURSOR_OPT_GENERIC_PLAN = 0x0200 // force use of generic plan
+	CURSOR_OPT_CUSTOM_PLAN  = 0x0400 // force use of custom plan
+	CURSOR_OPT_PARALLEL_OK  = 0x0800 // parallel mode OK
+)
+
+type DeclareCursorStmt struct {
+	Portalname string // name of the portal (cursor)
+	Options    int    // bitmask of options (see above)
+	Query      Node   // the query (see comments above)
+}
+
+func (n *DeclareCursorStmt) Tag() NodeTag { return T_DeclareCursorStmt }
+
+// Close Portal Statement
+type ClosePortalStmt struct {
+	Portalname string // name of the portal (cursor)
+}
+
+func (n *ClosePortalStmt) Tag() NodeTag { return T_ClosePortalStmt }
+
+// Fetch Statement (also Move)
+type FetchDirection int
+
+const (
+	FETCH_FORWARD FetchDirection = iota // for these, howMany is how many rows to fetch; FETCH_ALL means ALL
+	FETCH_BACKWARD
+	FETCH_ABSOLUTE // for these, howMany indicates a position; only one row is fetched
+	FETCH_RELATIVE
+)
+
+const (
+	FETCH_ALL = 0x7FFFFFFFFFFFFFFF
+)
+
+type FetchStmt struct {
+	Direction  FetchDirection // see above
+	HowMany    int64          // number of rows, or position argument
+	Portalname string         // name of portal (cursor)
+	Ismove     bool           // true if MOVE
+}
+
+func (n *FetchStmt) Tag() NodeTag { return T_FetchStmt }
+
+// Create Index Statement
+//
+// This represents creation of an index and/or an associated constraint.
+// If isconstraint is true, we should create a pg_constraint entry along
+// with the index.  But if indexOid isn't InvalidOid, we are not creating an
+// index, just a UNIQUE/PKEY constraint using an existing index.  isconstraint
+// must always be true in this case, and the fields describing the index
+// properties are empty.
+type IndexStmt struct {
+	Idxname      string    // name of new index, or NULL for default
+	Relation     *RangeVar // relation to build index on
+	AccessMethod string    // name of access method (eg. btree)
+	TableSpace   string    // tablespace, or NULL for default
+	IndexParams  *List     // columns to index: a list of IndexElem
+	// additional columns to index: a list
+	// of IndexElem
+	IndexIncludingParams *List
+	Options              *List  // WITH clause options: a list of DefElem
+	WhereClause          Node   // qualification (partial-index predicate)
+	ExcludeOpNames       *List  // exclusion operator names, or NIL if none
+	Idxcomment           string // comment to apply to index, or NULL
+	IndexOid             Oid    // OID of an existing index, if any
+	OldNumber            Oid    // relfilenumber of existing storage, if any
+	OldCreateSubid       uint32 // rd_createSubid of oldNumber
+	// rd_firstRelfilelocatorSubid
+	// of oldNumber
+	OldFirstRelfilelocatorSubid uint32
+	Unique                      bool // is index unique?
+	NullsNotDistinct            bool `pg:"nulls_not_distinct"` // null treatment for UNIQUE constraints
+	Primary                     bool // is index a primary key?
+	Isconstraint                bool // is it for a pkey/unique constraint?
+	Deferrable                  bool // is the constraint DEFERRABLE?
+	Initdeferred                bool // is the constraint INITIALLY DEFERRED?
+	Transformed                 bool // true when transformIndexStmt is finished
+	Concurrent                  bool // should this be a concurrent index build?
+	IfNotExists                 bool `pg:"if_not_exists"` // just do nothing if index already exists?
+	// reset default_tablespace prior to
+	// executing
+	ResetDefaultTblspc bool `pg:"reset_default_tblspc"`
+}
+
+func (n *IndexStmt) Tag() NodeTag { return T_IndexStmt }
+
+// Create Statistics Statement
+type CreateStatsStmt struct {
+	Defnames    *List  // qualified name (list of String)
+	StatTypes   *List  `pg:"stat_types"` // stat types (list of String)
+	Exprs       *List  // expressions to build statistics on
+	Relations   *List  // rels to build stats on (list of RangeVar)
+	Stxcomment  string // comment to apply to stats, or NULL
+	Transformed bool   // true when transformStatsStmt is finished
+	IfNotExists bool   `pg:"if_not_exists"` // do nothing if stats name already exists
+}
+
+func (n *CreateStatsStmt) Tag() NodeTag { return T_CreateStatsStmt }
+
+// StatsElem - statistics parameters (used in CREATE STATISTICS)
+//
+// For a plain attribute, 'name' is the name of the referenced table column
+// and 'expr' is NULL.  For an expression, 'name' is NULL and 'expr' is the
+// expression tree.
+type StatsElem struct {
+	Name string // name of attribute to index, or NULL
+	Expr Node   // expression to index, or NULL
+}
+
+func (n *StatsElem) Tag() NodeTag { return T_StatsElem }
+
+// Alter Statistics Statement
+type AlterStatsStmt struct {
+	Defnames      *List // qualified name (list of String)
+	Stxstattarget Node  // statistics target
+	MissingOk     bool  `pg:"missing_ok"` // skip error if statistics object is missing
+}
+
+func (n *AlterStatsStmt) Tag() NodeTag { return T_AlterStatsStmt }
+
+// Create Function Statement
+type CreateFunctionStmt struct {
+	IsProcedure bool      `pg:"is_procedure"` // it's really CREATE PROCEDURE
+	Replace     bool      // T => replace if already exists
+	Funcname    *List     // qualified name of function to create
+	Parameters  *List     // a list of FunctionParameter
+	ReturnType  *TypeName // the return type
+	Options     *List     // a list of DefElem
+	SqlBody     Node      `pg:"sql_body"`
+}
+
+func (n *CreateFunctionStmt) Tag() NodeTag { return T_CreateFunctionStmt }
+
+type FunctionParameterMode int
+
+const (
+	// the assigned enum values appear in pg_proc, don't change 'em!
+	// input only
+	FUNC_PARAM_IN       FunctionParameterMode = 'i'
+	FUNC_PARAM_OUT      FunctionParameterMode = 'o' // output only
+	FUNC_PARAM_INOUT    FunctionParameterMode = 'b' // both
+	FUNC_PARAM_VARIADIC FunctionParameterMode = 'v' // variadic (always input)
+	FUNC_PARAM_TABLE    FunctionParameterMode = 't' // table function output column
+	// this is not used in pg_proc:
+	// default; effectively same as IN
+	FUNC_PARAM_DEFAULT FunctionParameterMode = 'd'
+)
+
+type FunctionParameter struct {
+	Name    string                // parameter name, or NULL if not given
+	ArgType *TypeName             // TypeName for parameter type
+	Mode    FunctionParameterMode // IN/OUT/etc
+	Defexpr Node                  // raw default expr, or NULL if not given
+}
+
+func (n *FunctionParameter) Tag() NodeTag { return T_FunctionParameter }
+
+type AlterFunctionStmt struct {
+	Objtype ObjectType
+	Func    *ObjectWithArgs // name and args of function
+	Actions *List           // list of DefElem
+}
+
+func (n *AlterFunctionStmt) Tag() NodeTag { return T_AlterFunctionStmt }
+
+// DO Statement
+//
+// DoStmt is the raw parser output, InlineCodeBlock is the execution-time API
+type DoStmt struct {
+	Args *List // List of DefElem nodes
+}
+
+func (n *DoStmt) Tag() NodeTag { return T_DoStmt }
+
+type InlineCodeBlock struct {
+	SourceText    string `pg:"source_text"` // source text of anonymous code block
+	LangOid       Oid    // OID of selected language
+	LangIsTrusted bool   // trusted property of the language
+	Atomic        bool   // atomic execution context
+}
+
+func (n *InlineCodeBlock) Tag() NodeTag { return T_InlineCodeBlock }
+
+// CALL statement
+//
+// OUT-mode arguments are removed from the transformed funcexpr.  The outargs
+// list contains copies of the expressions for all output arguments, in the
+// order of the procedure's declared arguments.  (outargs is never evaluated,
+// but is useful to the caller as a reference for what to assign to.)
+// The transformed call state is not relevant in the query jumbling, only the
+// function call is.
+type CallStmt struct {
+	Funccall *FuncCall // from the parser
+	Funcexpr *FuncExpr // transformed call, with only input args
+	Outargs  *List     // transformed output-argument expressions
+}
+
+func (n *CallStmt) Tag() NodeTag { return T_CallStmt }
+
+type CallContext struct {
+	Atomic bool
+}
+
+func (n *CallContext) Tag() NodeTag { return T_CallContext }
+
+// Alter Object Rename Statement
+type RenameStmt struct {
+	RenameType   ObjectType // OBJECT_TABLE, OBJECT_COLUMN, etc
+	RelationType ObjectType // if column name, associated relation type
+	Relation     *RangeVar  // in case it's a table
+	Object       Node       // in case it's some other object
+	// name of contained object (column, rule,
+	// trigger, etc)
+	Subname   string
+	Newname   string       // the new name
+	Behavior  DropBehavior // RESTRICT or CASCADE behavior
+	MissingOk bool         `pg:"missing_ok"` // skip error if missing?
+}
+
+func (n *RenameStmt) Tag() NodeTag { return T_RenameStmt }
+
+// ALTER object DEPENDS ON EXTENSION extname
+type AlterObjectDependsStmt struct {
+	ObjectType ObjectType // OBJECT_FUNCTION, OBJECT_TRIGGER, etc
+	Relation   *RangeVar  // in case a table is involved
+	Object     Node       // name of the object
+	Extname    *String    // extension name
+	Remove     bool       // set true to remove dep rather than add
+}
+
+func (n *AlterObjectDependsStmt) Tag() NodeTag { return T_AlterObjectDependsStmt }
+
+// ALTER object SET SCHEMA Statement
+type AlterObjectSchemaStmt struct {
+	ObjectType ObjectType // OBJECT_TABLE, OBJECT_TYPE, etc
+	Relation   *RangeVar  // in case it's a table
+	Object     Node       // in case it's some other object
+	Newschema  string     // the new schema
+	MissingOk  bool       `pg:"missing_ok"` // skip error if missing?
+}
+
+func (n *AlterObjectSchemaStmt) Tag() NodeTag { return T_AlterObjectSchemaStmt }
+
+// Alter Object Owner Statement
+type AlterOwnerStmt struct {
+	ObjectType ObjectType // OBJECT_TABLE, OBJECT_TYPE, etc
+	Relation   *RangeVar  // in case it's a table
+	Object     Node       // in case it's some other object
+	Newowner   *RoleSpec  // the new owner
+}
+
+func (n *AlterOwnerStmt) Tag() NodeTag { return T_AlterOwnerStmt }
+
+// Alter Operator Set ( this-n-that )
+type AlterOperatorStmt struct {
+	Opername *ObjectWithArgs // operator name and argument types
+	Options  *List           // List of DefElem nodes
+}
+
+func (n *AlterOperatorStmt) Tag() NodeTag { return T_AlterOperatorStmt }
+
+// Alter Type Set ( this-n-that )
+type AlterTypeStmt struct {
+	TypeName *List // type name (possibly qualified)
+	Options  *List // List of DefElem nodes
+}
+
+func (n *AlterTypeStmt) Tag() NodeTag { return T_AlterTypeStmt }
+
+// Create Rule Statement
+type RuleStmt struct {
+	Relation    *RangeVar // relation the rule is for
+	Rulename    string    // name of the rule
+	WhereClause Node      // qualifications
+	Event       CmdType   // SELECT, INSERT, etc
+	Instead     bool      // is a 'do instead'?
+	Actions     *List     // the action statements
+	Replace     bool      // OR REPLACE
+}
+
+func (n *RuleStmt) Tag() NodeTag { return T_RuleStmt }
+
+// Notify Statement
+type NotifyStmt struct {
+	Conditionname string // condition name to notify
+	Payload       string // the payload string, or NULL if none
+}
+
+func (n *NotifyStmt) Tag() NodeTag { return T_NotifyStmt }
+
+// Listen Statement
+type ListenStmt struct {
+	Conditionname string // condition name to listen on
+}
+
+func (n *ListenStmt) Tag() NodeTag { return T_ListenStmt }
+
+// Unlisten Statement
+type UnlistenStmt struct {
+	Conditionname string // name to unlisten on, or NULL for all
+}
+
+func (n *UnlistenStmt) Tag() NodeTag { return T_UnlistenStmt }
+
+// {Begin|Commit|Rollback} Transaction Statement
+type TransactionStmtKind int
+
+const (
+	TRANS_STMT_BEGIN TransactionStmtKind = iota
+	TRANS_STMT_START                     // semantically identical to BEGIN
+	TRANS_STMT_COMMIT
+	TRANS_STMT_ROLLBACK
+	TRANS_STMT_SAVEPOINT
+	TRANS_STMT_RELEASE
+	TRANS_STMT_ROLLBACK_TO
+	TRANS_STMT_PREPARE
+	TRANS_STMT_COMMIT_PREPARED
+	TRANS_STMT_ROLLBACK_PREPARED
+)
+
+type TransactionStmt struct {
+	Kind          TransactionStmtKind // see above
+	Options       *List               // for BEGIN/START commands
+	SavepointName string              `pg:"savepoint_name"` // for savepoint commands
+	Gid           string              // for two-phase-commit related commands
+	Chain         bool                // AND CHAIN option
+	Location      ParseLoc            // token location, or -1 if unknown
+}
+
+func (n *TransactionStmt) Tag() NodeTag { return T_TransactionStmt }
+
+// Create Type Statement, composite types
+type CompositeTypeStmt struct {
+	Typevar    *RangeVar // the composite type to be created
+	Coldeflist *List     // list of ColumnDef nodes
+}
+
+func (n *CompositeTypeStmt) Tag() NodeTag { return T_CompositeTypeStmt }
+
+// Create Type Statement, enum types
+type CreateEnumStmt struct {
+	TypeName *List // qualified name (list of String)
+	Vals     *List // enum values (list of String)
+}
+
+func (n *CreateEnumStmt) Tag() NodeTag { return T_CreateEnumStmt }
+
+// Create Type Statement, range types
+type CreateRangeStmt struct {
+	TypeName *List // qualified name (list of String)
+	Params   *List // range parameters (list of DefElem)
+}
+
+func (n *CreateRangeStmt) Tag() NodeTag { return T_CreateRangeStmt }
+
+// Alter Type Statement, enum types
+type AlterEnumStmt struct {
+	TypeName           *List  // qualified name (list of String)
+	OldVal             string // old enum value's name, if renaming
+	NewVal             string // new enum value's name
+	NewValNeighbor     string // neighboring enum value, if specified
+	NewValIsAfter      bool   // place new enum value after neighbor?
+	SkipIfNewValExists bool   // no error if new already exists?
+}
+
+func (n *AlterEnumStmt) Tag() NodeTag { return T_AlterEnumStmt }
+
+// Create View Statement
+type ViewCheckOption int
+
+const (
+	NO_CHECK_OPTION ViewCheckOption = iota
+	LOCAL_CHECK_OPTION
+	CASCADED_CHECK_OPTION
+)
+
+type ViewStmt struct {
+	View            *RangeVar       // the view to be created
+	Aliases         *List           // target column names
+	Query           Node            // the SELECT query (as a raw parse tree)
+	Replace         bool            // replace an existing view?
+	Options         *List           // options from WITH clause
+	WithCheckOption ViewCheckOption // WITH CHECK OPTION
+}
+
+func (n *ViewStmt) Tag() NodeTag { return T_ViewStmt }
+
+// Load Statement
+type LoadStmt struct {
+	Filename string // file to load
+}
+
+func (n *LoadStmt) Tag() NodeTag { return T_LoadStmt }
+
+// Createdb Statement
+type CreatedbStmt struct {
+	Dbname  string // name of database to create
+	Options *List  // List of DefElem nodes
+}
+
+func (n *CreatedbStmt) Tag() NodeTag { return T_CreatedbStmt }
+
+// Alter Database
+type AlterDatabaseStmt struct {
+	Dbname  string // name of database to alter
+	Options *List  // List of DefElem nodes
+}
+
+func (n *AlterDatabaseStmt) Tag() NodeTag { return T_AlterDatabaseStmt }
+
+type AlterDatabaseRefreshCollStmt struct {
+	Dbname string
+}
+
+func (n *AlterDatabaseRefreshCollStmt) Tag() NodeTag { return T_AlterDatabaseRefreshCollStmt }
+
+type AlterDatabaseSetStmt struct {
+	Dbname  string           // database name
+	Setstmt *VariableSetStmt // SET or RESET subcommand
+}
+
+func (n *AlterDatabaseSetStmt) Tag() NodeTag { return T_AlterDatabaseSetStmt }
+
+// Dropdb Statement
+type DropdbStmt struct {
+	Dbname    string // database to drop
+	MissingOk bool   `pg:"missing_ok"` // skip error if db is missing?
+	Options   *List  // currently only FORCE is supported
+}
+
+func (n *DropdbStmt) Tag() NodeTag { return T_DropdbStmt }
+
+// Alter System Statement
+type AlterSystemStmt struct {
+	Setstmt *VariableSetStmt // SET subcommand
+}
+
+func (n *AlterSystemStmt) Tag() NodeTag { return T_AlterSystemStmt }
+
+// Cluster Statement (support pbrown's cluster index implementation)
+type ClusterStmt struct {
+	Relation  *RangeVar // relation being indexed, or NULL if all
+	Indexname string    // original index defined
+	Params    *List     // list of DefElem nodes
+}
+
+func (n *ClusterStmt) Tag() NodeTag { return T_ClusterStmt }
+
+// Vacuum and Analyze Statements
+//
+// Even though these are nominally two statements, it's convenient to use
+// just one node type for both.
+type VacuumStmt struct {
+	Options     *List // list of DefElem nodes
+	Rels        *List // list of VacuumRelation, or NIL for all
+	IsVacuumcmd bool  `pg:"is_vacuumcmd"` // true for VACUUM, false for ANALYZE
+}
+
+func (n *VacuumStmt) Tag() NodeTag { return T_VacuumStmt }
+
+// Info about a single target table of VACUUM/ANALYZE.
+//
+// If the OID field is set, it always identifies the table to process.
+// Then the relation field can be NULL; if it isn't, it's used only to report
+// failure to open/lock the relation.
+type VacuumRelation struct {
+	Relation *RangeVar // table name to process, or NULL
+	Oid      Oid       // table's OID; InvalidOid if not looked up
+	VaCols   *List     `pg:"va_cols"` // list of column names, or NIL for all
+}
+
+func (n *VacuumRelation) Tag() NodeTag { return T_VacuumRelation }
+
+// Explain Statement
+//
+// The "query" field is initially a raw parse tree, and is converted to a
+// Query node during parse analysis.  Note that rewriting and planning
+// of the query are always postponed until execution.
+type ExplainStmt struct {
+	Query   Node  // the query (see comments above)
+	Options *List // list of DefElem nodes
+}
+
+func (n *ExplainStmt) Tag() NodeTag { return T_ExplainStmt }
+
+// CREATE TABLE AS Statement (a/k/a SELECT INTO)
+//
+// A query written as CREATE TABLE AS will produce this node type natively.
+// A query written as SELECT ... INTO will be transformed to this form during
+// parse analysis.
+// A query written as CREATE MATERIALIZED view will produce this node type,
+// during parse analysis, since it needs all the same data.
+//
+// The "query" field is handled similarly to EXPLAIN, though note that it
+// can be a SELECT or an EXECUTE, but not other DML statements.
+type CreateTableAsStmt struct {
+	Query        Node        // the query (see comments above)
+	Into         *IntoClause // destination table
+	Objtype      ObjectType  // OBJECT_TABLE or OBJECT_MATVIEW
+	IsSelectInto bool        `pg:"is_select_into"` // it was written as SELECT INTO
+	IfNotExists  bool        `pg:"if_not_exists"`  // just do nothing if it already exists?
+}
+
+func (n *CreateTableAsStmt) Tag() NodeTag { return T_CreateTableAsStmt }
+
+// REFRESH MATERIALIZED VIEW Statement
+type RefreshMatViewStmt struct {
+	Concurrent bool      // allow concurrent access?
+	SkipData   bool      // true for WITH NO DATA
+	Relation   *RangeVar // relation to insert into
+}
+
+func (n *RefreshMatViewStmt) Tag() NodeTag { return T_RefreshMatViewStmt }
+
+// Checkpoint Statement
+type CheckPointStmt struct {
+}
+
+func (n *CheckPointStmt) Tag() NodeTag { return T_CheckPointStmt }
+
+// Discard Statement
+type DiscardMode int
+
+const (
+	DISCARD_ALL DiscardMode = iota
+	DISCARD_PLANS
+	DISCARD_SEQUENCES
+	DISCARD_TEMP
+)
+
+type DiscardStmt struct {
+	Target DiscardMode
+}
+
+func (n *DiscardStmt) Tag() NodeTag { return T_DiscardStmt }
+
+// LOCK Statement
+type LockStmt struct {
+	Relations *List // relations to lock
+	Mode      int   // lock mode
+	Nowait    bool  // no wait mode
+}
+
+func (n *LockStmt) Tag() NodeTag { return T_LockStmt }
+
+// SET CONSTRAINTS Statement
+type ConstraintsSetStmt struct {
+	Constraints *List // List of names as RangeVars
+	Deferred    bool
+}
+
+func (n *ConstraintsSetStmt) Tag() NodeTag { return T_ConstraintsSetStmt }
+
+// REINDEX Statement
+type ReindexObjectType int
+
+const (
+	REINDEX_OBJECT_INDEX    ReindexObjectType = iota // index
+	REINDEX_OBJECT_TABLE                             // table or materialized view
+	REINDEX_OBJECT_SCHEMA                            // schema
+	REINDEX_OBJECT_SYSTEM                            // system catalogs
+	REINDEX_OBJECT_DATABASE                          // database
+)
+
+type ReindexStmt struct {
+	// REINDEX_OBJECT_INDEX, REINDEX_OBJECT_TABLE,
+	// etc.
+	Kind     ReindexObjectType
+	Relation *RangeVar // Table or index to reindex
+	Name     string    // name of database to reindex
+	Params   *List     // list of DefElem nodes
+}
+
+func (n *ReindexStmt) Tag() NodeTag { return T_ReindexStmt }
+
+// CREATE CONVERSION Statement
+type CreateConversionStmt struct {
+	ConversionName  *List  `pg:"conversion_name"`   // Name of the conversion
+	ForEncodingName string `pg:"for_encoding_name"` // source encoding name
+	ToEncodingName  string `pg:"to_encoding_name"`  // destination encoding name
+	FuncName        *List  `pg:"func_name"`         // qualified conversion function name
+	Def             bool   // is this a default conversion?
+}
+
+func (n *CreateConversionStmt) Tag() NodeTag { return T_CreateConversionStmt }
+
+// CREATE CAST Statement
+type CreateCastStmt struct {
+	Sourcetype *TypeName
+	Targettype *TypeName
+	Func       *ObjectWithArgs
+	Context    CoercionContext
+	Inout      bool
+}
+
+func (n *CreateCastStmt) Tag() NodeTag { return T_CreateCastStmt }
+
+// CREATE TRANSFORM Statement
+type CreateTransformStmt struct {
+	Replace  bool
+	TypeName *TypeName `pg:"type_name"`
+	Lang     string
+	Fromsql  *ObjectWithArgs
+	Tosql    *ObjectWithArgs
+}
+
+func (n *CreateTransformStmt) Tag() NodeTag { return T_CreateTransformStmt }
+
+// PREPARE Statement
+type PrepareStmt struct {
+	Name     string // Name of plan, arbitrary
+	Argtypes *List  // Types of parameters (List of TypeName)
+	Query    Node   // The query itself (as a raw parsetree)
+}
+
+func (n *PrepareStmt) Tag() NodeTag { return T_PrepareStmt }
+
+// EXECUTE Statement
+type ExecuteStmt struct {
+	Name   string // The name of the plan to execute
+	Params *List  // Values to assign to parameters
+}
+
+func (n *ExecuteStmt) Tag() NodeTag { return T_ExecuteStmt }
+
+// DEALLOCATE Statement
+type DeallocateStmt struct {
+	Name string // The name of the plan to remove, NULL if DEALLOCATE ALL
+	// True if DEALLOCATE ALL.  This is redundant with "name == NULL", but we
+	// make it a separate field so that exactly this condition (and not the
+	// precise name) will be accounted for in query jumbling.
+	Isall    bool
+	Location ParseLoc // token location, or -1 if unknown
+}
+
+func (n *DeallocateStmt) Tag() NodeTag { return T_DeallocateStmt }
+
+// DROP OWNED statement
+type DropOwnedStmt struct {
+	Roles    *List
+	Behavior DropBehavior
+}
+
+func (n *DropOwnedStmt) Tag() NodeTag { return T_DropOwnedStmt }
+
+// REASSIGN OWNED statement
+type ReassignOwnedStmt struct {
+	Roles   *List
+	Newrole *RoleSpec
+}
+
+func (n *ReassignOwnedStmt) Tag() NodeTag { return T_ReassignOwnedStmt }
+
+// TS Dictionary stmts: DefineStmt, RenameStmt and DropStmt are default
+type AlterTSDictionaryStmt struct {
+	Dictname *List // qualified name (list of String)
+	Options  *List // List of DefElem nodes
+}
+
+func (n *AlterTSDictionaryStmt) Tag() NodeTag { return T_AlterTSDictionaryStmt }
+
+// TS Configuration stmts: DefineStmt, RenameStmt and DropStmt are default
+type AlterTSConfigType int
+
+const (
+	ALTER_TSCONFIG_ADD_MAPPING AlterTSConfigType = iota
+	ALTER_TSCONFIG_ALTER_MAPPING_FOR_TOKEN
+	ALTER_TSCONFIG_REPLACE_DICT
+	ALTER_TSCONFIG_REPLACE_DICT_FOR_TOKEN
+	ALTER_TSCONFIG_DROP_MAPPING
+)
+
+type AlterTSConfigurationStmt struct {
+	Kind    AlterTSConfigType // ALTER_TSCONFIG_ADD_MAPPING, etc
+	Cfgname *List             // qualified name (list of String)
+	// dicts will be non-NIL if ADD/ALTER MAPPING was specified. If dicts is
+	// NIL, but tokentype isn't, DROP MAPPING was specified.
+	// list of String
+	Tokentype *List
+	Dicts     *List // list of list of String
+	Override  bool  // if true - remove old variant
+	Replace   bool  // if true - replace dictionary by another
+	MissingOk bool  `pg:"missing_ok"` // for DROP - skip error if missing?
+}
+
+func (n *AlterTSConfigurationStmt) Tag() NodeTag { return T_AlterTSConfigurationStmt }
+
+type PublicationTable struct {
+	Relation    *RangeVar // relation to be published
+	WhereClause Node      // qualifications
+	Columns     *List     // List of columns in a publication table
+}
+
+func (n *PublicationTable) Tag() NodeTag { return T_PublicationTable }
+
+// Publication object type
+type PublicationObjSpecType int
+
+const (
+	PUBLICATIONOBJ_TABLE            PublicationObjSpecType = iota // A table
+	PUBLICATIONOBJ_TABLES_IN_SCHEMA                               // All tables in schema
+	// All tables in first element of
+	// search_path
+	PUBLICATIONOBJ_TABLES_IN_CUR_SCHEMA
+	PUBLICATIONOBJ_CONTINUATION // Continuation of previous type
+)
+
+type PublicationObjSpec struct {
+	Pubobjtype PublicationObjSpecType // type of this publication object
+	Name       string
+	Pubtable   *PublicationTable
+	Location   ParseLoc // token location, or -1 if unknown
+}
+
+func (n *PublicationObjSpec) Tag() NodeTag { return T_PublicationObjSpec }
+
+type CreatePublicationStmt struct {
+	Pubname      string // Name of the publication
+	Options      *List  // List of DefElem nodes
+	Pubobjects   *List  // Optional list of publication objects
+	ForAllTables bool   `pg:"for_all_tables"` // Special publication for all tables in db
+}
+
+func (n *CreatePublicationStmt) Tag() NodeTag { return T_CreatePublicationStmt }
+
+type AlterPublicationAction int
+
+const (
+	AP_AddObjects  AlterPublicationAction = iota // add objects to publication
+	AP_DropObjects                               // remove objects from publication
+	AP_SetObjects                                // set list of objects
+)
+
+type AlterPublicationStmt struct {
+	Pubname string // Name of the publication
+	// parameters used for ALTER PUBLICATION ... WITH
+	// List of DefElem nodes
+	Options *List
+	// Parameters used for ALTER PUBLICATION ... ADD/DROP/SET publication
+	// objects.
+	// Optional list of publication objects
+	Pubobjects   *List
+	ForAllTables bool `pg:"for_all_tables"` // Special publication for all tables in db
+	// What action to perform with the given
+	// objects
+	Action AlterPublicationAction
+}
+
+func (n *AlterPublicationStmt) Tag() NodeTag { return T_AlterPublicationStmt }
+
+type CreateSubscriptionStmt struct {
+	Subname     string // Name of the subscription
+	Conninfo    string // Connection string to publisher
+	Publication *List  // One or more publication to subscribe to
+	Options     *List  // List of DefElem nodes
+}
+
+func (n *CreateSubscriptionStmt) Tag() NodeTag { return T_CreateSubscriptionStmt }
+
+type AlterSubscriptionType int
+
+const (
+	ALTER_SUBSCRIPTION_OPTIONS AlterSubscriptionType = iota
+	ALTER_SUBSCRIPTION_CONNECTION
+	ALTER_SUBSCRIPTION_SET_PUBLICATION
+	ALTER_SUBSCRIPTION_ADD_PUBLICATION
+	ALTER_SUBSCRIPTION_DROP_PUBLICATION
+	ALTER_SUBSCRIPTION_REFRESH
+	ALTER_SUBSCRIPTION_ENABLED
+	ALTER_SUBSCRIPTION_SKIP
+)
+
+type AlterSubscriptionStmt struct {
+	Kind        AlterSubscriptionType // ALTER_SUBSCRIPTION_OPTIONS, etc
+	Subname     string                // Name of the subscription
+	Conninfo    string                // Connection string to publisher
+	Publication *List                 // One or more publication to subscribe to
+	Options     *List                 // List of DefElem nodes
+}
+
+func (n *AlterSubscriptionStmt) Tag() NodeTag { return T_AlterSubscriptionStmt }
+
+type DropSubscriptionStmt struct {
+	Subname   string       // Name of the subscription
+	MissingOk bool         `pg:"missing_ok"` // Skip error if missing?
+	Behavior  DropBehavior // RESTRICT or CASCADE behavior
+}
+
+func (n *DropSubscriptionStmt) Tag() NodeTag { return T_DropSubscriptionStmt }
@@ -0,0 +1,32 @@
+package nodes
+
+// The nodes of PostgreSQL 18 that the vendored 17 headers lack. They are
+// declared by hand; their NodeTags follow those of nodetags.h. Fields that
+// 18 adds to earlier nodes are generated with a `since` tag instead.
+
+// ReturningOptionKind is the kind of a RETURNING WITH option.
+type ReturningOptionKind int
+
+const (
+	RETURNING_OPTION_OLD ReturningOptionKind = iota // OLD AS alias
+	RETURNING_OPTION_NEW                            // NEW AS alias
+)
+
+// ReturningOption is an OLD or NEW alias of RETURNING WITH (PG 18).
+type ReturningOption struct {
+	Option   ReturningOptionKind // OLD or NEW
+	Value    string              // the alias
+	Location ParseLoc            // token location, or -1 if unknown
+}
+
+func (n *ReturningOption) Tag() NodeTag { return T_ReturningOption }
+
+// ReturningClause is a RETURNING clause (PG 18). The grammar builds it,
+// but the statements keep PostgreSQL 17's shape: its expressions become
+// their ReturningList and its options their ReturningOptions.
+type ReturningClause struct {
+	Options *List // list of ReturningOption
+	Exprs   *List // list of expressions to return
+}
+
+func (n *ReturningClause) Tag() NodeTag { return T_ReturningClause }
@@ -0,0 +1,10 @@
+package nodes
+
+// The expression nodes that parse analysis produces (primnodes.h) are
+// generated from the header into primnodes_generated.go. Unlike the raw
+// parse tree nodes, they carry resolved OIDs for types, functions and
+// operators. This file holds what the generator cannot derive.
+
+// AttrNumber is a column number within a relation (1-based), or a system
+// column number (negative).
+type AttrNumber int16
@@ -0,0 +1,1799 @@
+// Code generated by pgsema-gen. DO NOT EDIT.
+// Source: PostgreSQL nodes/primnodes.h
+
+package nodes
+
+type OverridingKind int
+
+const (
+	OVERRIDING_NOT_SET      OverridingKind = 0
+	OVERRIDING_USER_VALUE   OverridingKind = OVERRIDING_NOT_SET + 1
+	OVERRIDING_SYSTEM_VALUE OverridingKind = OVERRIDING_USER_VALUE + 1
+)
+
+// Alias -
+// specifies an alias for a range variable; the alias might also
+// specify renaming of columns within the table.
+//
+// Note: colnames is a list of String nodes.  In Alias structs
+// associated with RTEs, there may be entries corresponding to dropped
+// columns; these are normally empty strings ("").  See parsenodes.h for info.
+type Alias struct {
+	Aliasname string // aliased rel name (never qualified)
+	Colnames  *List  // optional list of column aliases
+}
+
+func (n *Alias) Tag() NodeTag { return T_Alias }
+
+// What to do at commit time for temporary relations
+type OnCommitAction int
+
+const (
+	ONCOMMIT_NOOP          OnCommitAction = iota // No ON COMMIT clause (do nothing)
+	ONCOMMIT_PRESERVE_ROWS                       // ON COMMIT PRESERVE ROWS (do nothing)
+	ONCOMMIT_DELETE_ROWS                         // ON COMMIT DELETE ROWS
+	ONCOMMIT_DROP                                // ON COMMIT DROP
+)
+
+// RangeVar - range variable, used in FROM clauses
+//
+// Also used to represent table names in utility statements; there, the alias
+// field is not used, and inh tells whether to apply the operation
+// recursively to child tables.  In some contexts it is also useful to carry
+// a TEMP table indication here.
+type RangeVar struct {
+	Catalogname    string   // the catalog (database) name, or NULL
+	Schemaname     string   // the schema name, or NULL
+	Relname        string   // the relation/sequence name
+	Inh            bool     // expand rel by inheritance? recursively act on children?
+	Relpersistence byte     // see RELPERSISTENCE_* in pg_class.h
+	Alias          *Alias   // table alias & optional column aliases
+	Location       ParseLoc // token location, or -1 if unknown
+}
+
+func (n *RangeVar) Tag() NodeTag { return T_RangeVar }
+
+type TableFuncType int
+
+const (
+	TFT_XMLTABLE TableFuncType = iota
+	TFT_JSON_TABLE
+)
+
+// TableFunc - node for a table function, such as XMLTABLE and JSON_TABLE.
+//
+// Entries in the ns_names list are either String nodes containing
+// literal namespace names, or NULL pointers to represent DEFAULT.
+type TableFunc struct {
+	Functype        TableFuncType // XMLTABLE or JSON_TABLE
+	NsUris          *List         `pg:"ns_uris"`  // list of namespace URI expressions
+	NsNames         *List         `pg:"ns_names"` // list of namespace names or NULL
+	Docexpr         Node          // input document expression
+	Rowexpr         Node          // row filter expression
+	Colnames        *List         // column names (list of String)
+	Coltypes        *OidList      // OID list of column type OIDs
+	Coltypmods      *IntList      // integer list of column typmods
+	Colcollations   *OidList      // OID list of column collation OIDs
+	Colexprs        *List         // list of column filter expressions
+	Coldefexprs     *List         // list of column default expressions
+	Colvalexprs     *List         // JSON_TABLE: list of column value expressions
+	Passingvalexprs *List         // JSON_TABLE: list of PASSING argument expressions
+	Notnulls        *Bitmapset    // nullability flag for each output column
+	Plan            Node          // JSON_TABLE plan
+	Ordinalitycol   int           // counts from 0; -1 if none specified
+	Location        ParseLoc      // token location, or -1 if unknown
+}
+
+func (n *TableFunc) Tag() NodeTag { return T_TableFunc }
+
+// IntoClause - target information for SELECT INTO, CREATE TABLE AS, and
+// CREATE MATERIALIZED VIEW
+//
+// For CREATE MATERIALIZED VIEW, viewQuery is the parsed-but-not-rewritten
+// SELECT Query for the view; otherwise it's NULL.  This is irrelevant in
+// the query jumbling as CreateTableAsStmt already includes a reference to
+// its own Query, so ignore it.  (Although it's actually Query*, we declare
+// it as Node* to avoid a forward reference.)
+type IntoClause struct {
+	Rel            *RangeVar      // target relation name
+	ColNames       *List          // column names to assign, or NIL
+	AccessMethod   string         // table access method
+	Options        *List          // options from WITH clause
+	OnCommit       OnCommitAction // what do we do at COMMIT?
+	TableSpaceName string         // table space to use, or NULL
+	ViewQuery      Node           // materialized view's SELECT query
+	SkipData       bool           // true for WITH NO DATA
+}
+
+func (n *IntoClause) Tag() NodeTag { return T_IntoClause }
+
+// Var - expression node representing a variable (ie, a table column)
+//
+// In the parser and planner, varno and varattno identify the semantic
+// referent, which is a base-relation column unless the reference is to a join
+// USING column that isn't semantically equivalent to either join input column
+// (because it is a FULL join or the input column requires a type coercion).
+// In those cases varno and varattno refer to the JOIN RTE.  (Early in the
+// planner, we replace such join references by the implied expression; but up
+// till then we want join reference Vars to keep their original identity for
+// query-printing purposes.)
+//
+// At the end of planning, Var nodes appearing in upper-level plan nodes are
+// reassigned to point to the outputs of their subplans; for example, in a
+// join node varno becomes INNER_VAR or OUTER_VAR and varattno becomes the
+// index of the proper element of that subplan's target list.  Similarly,
+// INDEX_VAR is used to identify Vars that reference an index column rather
+// than a heap column.  (In ForeignScan and CustomScan plan nodes, INDEX_VAR
+// is abused to signify references to columns of a custom scan tuple type.)
+//
+// ROWID_VAR is used in the planner to identify nonce variables that carry
+// row identity information during UPDATE/DELETE/MERGE.  This value should
+// never be seen outside the planner.
+//
+// varnullingrels is the set of RT indexes of outer joins that can force
+// the Var's value to null (at the point where it appears in the query).
+// See optimizer/README for discussion of that.
+//
+// varlevelsup is greater than zero in Vars that represent outer references.
+// Note that it affects the meaning of all of varno, varnullingrels, and
+// varnosyn, all of which refer to the range table of that query level.
+//
+// In the parser, varnosyn and varattnosyn are either identical to
+// varno/varattno, or they specify the column's position in an aliased JOIN
+// RTE that hides the semantic referent RTE's refname.  This is a syntactic
+// identifier as opposed to the semantic identifier; it tells ruleutils.c
+// how to print the Var properly.  varnosyn/varattnosyn retain their values
+// throughout planning and execution, so they are particularly helpful to
+// identify Vars when debugging.  Note, however, that a Var that is generated
+// in the planner and doesn't correspond to any simple relation column may
+// have varnosyn = varattnosyn = 0.
+const (
+	INNER_VAR = (-1) // reference to inner subplan
+	OUTER_VAR = (-2) // reference to outer subplan
+	INDEX_VAR = (-3) // reference to index column
+	ROWID_VAR = (-4) // row identity column during planning
+)
+
+// Symbols for the indexes of the special RTE entries in rules
+const (
+	PRS2_OLD_VARNO = 1
+	PRS2_NEW_VARNO = 2
+)
+
+type Var struct {
+	// index of this var's relation in the range table, or
+	// INNER_VAR/OUTER_VAR/etc
+	Varno     int
+	Varattno  AttrNumber // attribute number of this var, or zero for all attrs ("whole-row Var")
+	Vartype   Oid        // pg_type OID for the type of this var
+	Vartypmod int32      // pg_attribute typmod value
+	Varcollid Oid        // OID of collation, or InvalidOid if none
+	// RT indexes of outer joins that can replace the Var's value with null.
+	// We can omit varnullingrels in the query jumble, because it's fully
+	// determined by varno/varlevelsup plus the Var's query location.
+	Varnullingrels *Bitmapset
+	// for subquery variables referencing outer relations; 0 in a normal var,
+	// >0 means N levels up
+	Varlevelsup uint32
+	// varnosyn/varattnosyn are ignored for equality, because Vars with
+	// different syntactic identifiers are semantically the same as long as
+	// their varno/varattno match.
+	//
+	// syntactic relation index (0 if unknown)
+	Varnosyn    uint32
+	Varattnosyn AttrNumber // syntactic attribute number
+	Location    ParseLoc   // token location, or -1 if unknown
+}
+
+func (n *Var) Tag() NodeTag { return T_Var }
+
+// Const
+//
+// Note: for varlena data types, we make a rule that a Const node's value
+// must be in non-extended form (4-byte header, no compression or external
+// references).  This ensures that the Const node is self-contained and makes
+// it more likely that equal() will see logically identical values as equal.
+//
+// Only the constant type OID is relevant for the query jumbling.
+type Const struct {
+	Consttype   Oid   // pg_type OID of the constant's datatype
+	Consttypmod int32 // typmod value, if any
+	Constcollid Oid   // OID of collation, or InvalidOid if none
+	Constlen    int   // typlen of the constant's datatype
+	Constvalue  Node  // the constant's value
+	Constisnull bool  // whether the constant is null (if true, constvalue is undefined)
+	// Whether this datatype is passed by value.  If true, then all the
+	// information is stored in the Datum.  If false, then the Datum contains
+	// a pointer to the information.
+	Constbyval bool
+	// token location, or -1 if unknown.  All constants are tracked as
+	// locations in query jumbling, to be marked as parameters.
+	Location ParseLoc
+}
+
+func (n *Const) Tag() NodeTag { return T_Const }
+
+// Param
+//
+// paramkind specifies the kind of parameter. The possible values
+// for this field are:
+//
+// PARAM_EXTERN:  The parameter value is supplied from outside the plan.
+// Such parameters are numbered from 1 to n.
+//
+// PARAM_EXEC:  The parameter is an internal executor parameter, used
+// for passing values into and out of sub-queries or from
+// nestloop joins to their inner scans.
+// For historical reasons, such parameters are numbered from 0.
+// These numbers are independent of PARAM_EXTERN numbers.
+//
+// PARAM_SUBLINK:	The parameter represents an output column of a SubLink
+// node's sub-select.  The column number is contained in the
+// `paramid' field.  (This type of Param is converted to
+// PARAM_EXEC during planning.)
+//
+// PARAM_MULTIEXPR:  Like PARAM_SUBLINK, the parameter represents an
+// output column of a SubLink node's sub-select, but here, the
+// SubLink is always a MULTIEXPR SubLink.  The high-order 16 bits
+// of the `paramid' field contain the SubLink's subLinkId, and
+// the low-order 16 bits contain the column number.  (This type
+// of Param is also converted to PARAM_EXEC during planning.)
+type ParamKind int
+
+const (
+	PARAM_EXTERN ParamKind = iota
+	PARAM_EXEC
+	PARAM_SUBLINK
+	PARAM_MULTIEXPR
+)
+
+type Param struct {
+	Paramkind   ParamKind // kind of parameter. See above
+	Paramid     int       // numeric ID for parameter
+	Paramtype   Oid       // pg_type OID of parameter's datatype
+	Paramtypmod int32     // typmod value, if known
+	Paramcollid Oid       // OID of collation, or InvalidOid if none
+	Location    ParseLoc  // token location, or -1 if unknown
+}
+
+func (n *Param) Tag() NodeTag { return T_Param }
+
+// Aggref
+//
+// The aggregate's args list is a targetlist, ie, a list of TargetEntry nodes.
+//
+// For a normal (non-ordered-set) aggregate, the non-resjunk TargetEntries
+// represent the aggregate's regular arguments (if any) and resjunk TLEs can
+// be added at the end to represent ORDER BY expressions that are not also
+// arguments.  As in a top-level Query, the TLEs can be marked with
+// ressortgroupref indexes to let them be referenced by SortGroupClause
+// entries in the aggorder and/or aggdistinct lists.  This represents ORDER BY
+// and DISTINCT operations to be applied to the aggregate input rows before
+// they are passed to the transition function.  The grammar only allows a
+// simple "DISTINCT" specifier for the arguments, but we use the full
+// query-level representation to allow more code sharing.
+//
+// For an ordered-set aggregate, the args list represents the WITHIN GROUP
+// (aggregated) arguments, all of which will be listed in the aggorder list.
+// DISTINCT is not supported in this case, so aggdistinct will be NIL.
+// The direct arguments appear in aggdirectargs (as a list of plain
+// expressions, not TargetEntry nodes).
+//
+// aggtranstype is the data type of the state transition values for this
+// aggregate (resolved to an actual type, if agg's transtype is polymorphic).
+// This is determined during planning and is InvalidOid before that.
+//
+// aggargtypes is an OID list of the data types of the direct and regular
+// arguments.  Normally it's redundant with the aggdirectargs and args lists,
+// but in a combining aggregate, it's not because the args list has been
+// replaced with a single argument representing the partial-aggregate
+// transition values.
+//
+// aggpresorted is set by the query planner for ORDER BY and DISTINCT
+// aggregates where the chosen plan provides presorted input for this
+// aggregate during execution.
+//
+// aggsplit indicates the expected partial-aggregation mode for the Aggref's
+// parent plan node.  It's always set to AGGSPLIT_SIMPLE in the parser, but
+// the planner might change it to something else.  We use this mainly as
+// a crosscheck that the Aggrefs match the plan; but note that when aggsplit
+// indicates a non-final mode, aggtype reflects the transition data type
+// not the SQL-level output type of the aggregate.
+//
+// aggno and aggtransno are -1 in the parse stage, and are set in planning.
+// Aggregates with the same 'aggno' represent the same aggregate expression,
+// and can share the result.  Aggregates with same 'transno' but different
+// 'aggno' can share the same transition state, only the final function needs
+// to be called separately.
+//
+// Information related to collations, transition types and internal states
+// are irrelevant for the query jumbling.
+type Aggref struct {
+	Aggfnoid    Oid // pg_proc Oid of the aggregate
+	Aggtype     Oid // type Oid of result of the aggregate
+	Aggcollid   Oid // OID of collation of result
+	Inputcollid Oid // OID of collation that function should use
+	// type Oid of aggregate's transition value; ignored for equal since it
+	// might not be set yet
+	Aggtranstype  Oid
+	Aggargtypes   *OidList // type Oids of direct and aggregated args
+	Aggdirectargs *List    // direct arguments, if an ordered-set agg
+	Args          *List    // aggregated arguments and sort expressions
+	Aggorder      *List    // ORDER BY (list of SortGroupClause)
+	Aggdistinct   *List    // DISTINCT (list of SortGroupClause)
+	Aggfilter     Node     // FILTER expression, if any
+	Aggstar       bool     // true if argument list was really '*'
+	// true if variadic arguments have been combined into an array last
+	// argument
+	Aggvariadic  bool
+	Aggkind      byte     // aggregate kind (see pg_aggregate.h)
+	Aggpresorted bool     // aggregate input already sorted
+	Agglevelsup  uint32   // > 0 if agg belongs to outer query
+	Aggsplit     AggSplit // expected agg-splitting mode of parent Agg
+	Aggno        int      // unique ID within the Agg node
+	Aggtransno   int      // unique ID of transition state in the Agg
+	Location     ParseLoc // token location, or -1 if unknown
+}
+
+func (n *Aggref) Tag() NodeTag { return T_Aggref }
+
+// GroupingFunc
+//
+// A GroupingFunc is a GROUPING(...) expression, which behaves in many ways
+// like an aggregate function (e.g. it "belongs" to a specific query level,
+// which might not be the one immediately containing it), but also differs in
+// an important respect: it never evaluates its arguments, they merely
+// designate expressions from the GROUP BY clause of the query level to which
+// it belongs.
+//
+// The spec defines the evaluation of GROUPING() purely by syntactic
+// replacement, but we make it a real expression for optimization purposes so
+// that one Agg node can handle multiple grouping sets at once.  Evaluating the
+// result only needs the column positions to check against the grouping set
+// being projected.  However, for EXPLAIN to produce meaningful output, we have
+// to keep the original expressions around, since expression deparse does not
+// give us any feasible way to get at the GROUP BY clause.
+//
+// Also, we treat two GroupingFunc nodes as equal if they have equal arguments
+// lists and agglevelsup, without comparing the refs and cols annotations.
+//
+// In raw parse output we have only the args list; parse analysis fills in the
+// refs list, and the planner fills in the cols list.
+//
+// All the fields used as information for an internal state are irrelevant
+// for the query jumbling.
+type GroupingFunc struct {
+	Args        *List    // arguments, not evaluated but kept for benefit of EXPLAIN etc.
+	Refs        *List    // ressortgrouprefs of arguments
+	Cols        *IntList // actual column positions set by planner
+	Agglevelsup uint32   // same as Aggref.agglevelsup
+	Location    ParseLoc // token location
+}
+
+func (n *GroupingFunc) Tag() NodeTag { return T_GroupingFunc }
+
+// WindowFunc
+//
+// Collation information is irrelevant for the query jumbling, as is the
+// internal state information of the node like "winstar" and "winagg".
+type WindowFunc struct {
+	Winfnoid     Oid      // pg_proc Oid of the function
+	Wintype      Oid      // type Oid of result of the window function
+	Wincollid    Oid      // OID of collation of result
+	Inputcollid  Oid      // OID of collation that function should use
+	Args         *List    // arguments to the window function
+	Aggfilter    Node     // FILTER expression, if any
+	RunCondition *List    // List of WindowFuncRunConditions to help short-circuit execution
+	Winref       uint32   // index of associated WindowClause
+	Winstar      bool     // true if argument list was really '*'
+	Winagg       bool     // is function a simple aggregate?
+	Location     ParseLoc // token location, or -1 if unknown
+}
+
+func (n *WindowFunc) Tag() NodeTag { return T_WindowFunc }
+
+// WindowFuncRunCondition
+//
+// Represents intermediate OpExprs which will be used by WindowAgg to
+// short-circuit execution.
+type WindowFuncRunCondition struct {
+	Opno        Oid // PG_OPERATOR OID of the operator
+	Inputcollid Oid // OID of collation that operator should use
+	// true of WindowFunc belongs on the left of the resulting OpExpr or false
+	// if the WindowFunc is on the right.
+	WfuncLeft bool `pg:"wfunc_left"`
+	// The Expr being compared to the WindowFunc to use in the OpExpr in the
+	// WindowAgg's runCondition
+	Arg Node
+}
+
+func (n *WindowFuncRunCondition) Tag() NodeTag { return T_WindowFuncRunCondition }
+
+// MergeSupportFunc
+//
+// A MergeSupportFunc is a merge support function expression that can only
+// appear in the RETURNING list of a MERGE command.  It returns information
+// about the currently executing merge action.
+//
+// Currently, the only supported function is MERGE_ACTION(), which returns the
+// command executed ("INSERT", "UPDATE", or "DELETE").
+type MergeSupportFunc struct {
+	Msftype   Oid      // type Oid of result
+	Msfcollid Oid      // OID of collation, or InvalidOid if none
+	Location  ParseLoc // token location, or -1 if unknown
+}
+
+func (n *MergeSupportFunc) Tag() NodeTag { return T_MergeSupportFunc }
+
+// SubscriptingRef: describes a subscripting operation over a container
+// (array, etc).
+//
+// A SubscriptingRef can describe fetching a single element from a container,
+// fetching a part of a container (e.g. an array slice), storing a single
+// element into a container, or storing a slice.  The "store" cases work with
+// an initial container value and a source value that is inserted into the
+// appropriate part of the container; the result of the operation is an
+// entire new modified container value.
+//
+// If reflowerindexpr = NIL, then we are fetching or storing a single container
+// element at the subscripts given by refupperindexpr. Otherwise we are
+// fetching or storing a container slice, that is a rectangular subcontainer
+// with lower and upper bounds given by the index expressions.
+// reflowerindexpr must be the same length as refupperindexpr when it
+// is not NIL.
+//
+// In the slice case, individual expressions in the subscript lists can be
+// NULL, meaning "substitute the array's current lower or upper bound".
+// (Non-array containers may or may not support this.)
+//
+// refcontainertype is the actual container type that determines the
+// subscripting semantics.  (This will generally be either the exposed type of
+// refexpr, or the base type if that is a domain.)  refelemtype is the type of
+// the container's elements; this is saved for the use of the subscripting
+// functions, but is not used by the core code.  refrestype, reftypmod, and
+// refcollid describe the type of the SubscriptingRef's result.  In a store
+// expression, refrestype will always match refcontainertype; in a fetch,
+// it could be refelemtype for an element fetch, or refcontainertype for a
+// slice fetch, or possibly something else as determined by type-specific
+// subscripting logic.  Likewise, reftypmod and refcollid will match the
+// container's properties in a store, but could be different in a fetch.
+//
+// Any internal state data is ignored for the query jumbling.
+//
+// Note: for the cases where a container is returned, if refexpr yields a R/W
+// expanded container, then the implementation is allowed to modify that
+// object in-place and return the same object.
+type SubscriptingRef struct {
+	Refcontainertype Oid   // type of the container proper
+	Refelemtype      Oid   // the container type's pg_type.typelem
+	Refrestype       Oid   // type of the SubscriptingRef's result
+	Reftypmod        int32 // typmod of the result
+	Refcollid        Oid   // collation of result, or InvalidOid if none
+	Refupperindexpr  *List // expressions that evaluate to upper container indexes
+	// expressions that evaluate to lower container indexes, or NIL for single
+	// container element.
+	Reflowerindexpr *List
+	Refexpr         Node // the expression that evaluates to a container value
+	Refassgnexpr    Node // expression for the source value, or NULL if fetch
+}
+
+func (n *SubscriptingRef) Tag() NodeTag { return T_SubscriptingRef }
+
+// CoercionContext - distinguishes the allowed set of type casts
+//
+// NB: ordering of the alternatives is significant; later (larger) values
+// allow more casts than earlier ones.
+type CoercionContext int
+
+const (
+	COERCION_IMPLICIT   CoercionContext = iota // coercion in context of expression
+	COERCION_ASSIGNMENT                        // coercion in context of assignment
+	COERCION_PLPGSQL                           // if no assignment cast, use CoerceViaIO
+	COERCION_EXPLICIT                          // explicit cast operation
+)
+
+// CoercionForm - how to display a FuncExpr or related node
+//
+// "Coercion" is a bit of a misnomer, since this value records other
+// special syntaxes besides casts, but for now we'll keep this naming.
+//
+// NB: equal() ignores CoercionForm fields, therefore this *must* not carry
+// any semantically significant information.  We need that behavior so that
+// the planner will consider equivalent implicit and explicit casts to be
+// equivalent.  In cases where those actually behave differently, the coercion
+// function's arguments will be different.
+type CoercionForm int
+
+const (
+	COERCE_EXPLICIT_CALL CoercionForm = iota // display as a function call
+	COERCE_EXPLICIT_CAST                     // display as an explicit cast
+	COERCE_IMPLICIT_CAST                     // implicit cast, so hide it
+	COERCE_SQL_SYNTAX                        // display with SQL-mandated special syntax
+)
+
+// FuncExpr - expression node for a function call
+//
+// Collation information is irrelevant for the query jumbling, only the
+// arguments and the function OID matter.
+type FuncExpr struct {
+	Funcid         Oid  // PG_PROC OID of the function
+	Funcresulttype Oid  // PG_TYPE OID of result value
+	Funcretset     bool // true if function returns set
+	// true if variadic arguments have been combined into an array last
+	// argument
+	Funcvariadic bool
+	Funcformat   CoercionForm // how to display this function call
+	Funccollid   Oid          // OID of collation of result
+	Inputcollid  Oid          // OID of collation that function should use
+	Args         *List        // arguments to the function
+	Location     ParseLoc     // token location, or -1 if unknown
+}
+
+func (n *FuncExpr) Tag() NodeTag { return T_FuncExpr }
+
+// NamedArgExpr - a named argument of a function
+//
+// This node type can only appear in the args list of a FuncCall or FuncExpr
+// node.  We support pure positional call notation (no named arguments),
+// named notation (all arguments are named), and mixed notation (unnamed
+// arguments followed by named ones).
+//
+// Parse analysis sets argnumber to the positional index of the argument,
+// but doesn't rearrange the argument list.
+//
+// The planner will convert argument lists to pure positional notation
+// during expression preprocessing, so execution never sees a NamedArgExpr.
+type NamedArgExpr struct {
+	Arg       Node     // the argument expression
+	Name      string   // the name
+	Argnumber int      // argument's number in positional notation
+	Location  ParseLoc // argument name location, or -1 if unknown
+}
+
+func (n *NamedArgExpr) Tag() NodeTag { return T_NamedArgExpr }
+
+// OpExpr - expression node for an operator invocation
+//
+// Semantically, this is essentially the same as a function call.
+//
+// Note that opfuncid is not necessarily filled in immediately on creation
+// of the node.  The planner makes sure it is valid before passing the node
+// tree to the executor, but during parsing/planning opfuncid can be 0.
+// Therefore, equal() will accept a zero value as being equal to other values.
+//
+// Internal state information and collation data is irrelevant for the query
+// jumbling.
+type OpExpr struct {
+	Opno         Oid      // PG_OPERATOR OID of the operator
+	Opfuncid     Oid      // PG_PROC OID of underlying function
+	Opresulttype Oid      // PG_TYPE OID of result value
+	Opretset     bool     // true if operator returns set
+	Opcollid     Oid      // OID of collation of result
+	Inputcollid  Oid      // OID of collation that operator should use
+	Args         *List    // arguments to the operator (1 or 2)
+	Location     ParseLoc // token location, or -1 if unknown
+}
+
+func (n *OpExpr) Tag() NodeTag { return T_OpExpr }
+
+// DistinctExpr is a node with the same fields as OpExpr.
+type DistinctExpr struct {
+	Opno         Oid      // PG_OPERATOR OID of the operator
+	Opfuncid     Oid      // PG_PROC OID of underlying function
+	Opresulttype Oid      // PG_TYPE OID of result value
+	Opretset     bool     // true if operator returns set
+	Opcollid     Oid      // OID of collation of result
+	Inputcollid  Oid      // OID of collation that operator should use
+	Args         *List    // arguments to the operator (1 or 2)
+	Location     ParseLoc // token location, or -1 if unknown
+}
+
+func (n *DistinctExpr) Tag() NodeTag { return T_DistinctExpr }
+
+// NullIfExpr is a node with the same fields as OpExpr.
+type NullIfExpr struct {
+	Opno         Oid      // PG_OPERATOR OID of the operator
+	Opfuncid     Oid      // PG_PROC OID of underlying function
+	Opresulttype Oid      // PG_TYPE OID of result value
+	Opretset     bool     // true if operator returns set
+	Opcollid     Oid      // OID of collation of result
+	Inputcollid  Oid      // OID of collation that operator should use
+	Args         *List    // arguments to the operator (1 or 2)
+	Location     ParseLoc // token location, or -1 if unknown
+}
+
+func (n *NullIfExpr) Tag() NodeTag { return T_NullIfExpr }
+
+// ScalarArrayOpExpr - expression node for "scalar op ANY/ALL (array)"
+//
+// The operator must yield boolean.  It is applied to the left operand
+// and each element of the righthand array, and the results are combined
+// with OR or AND (for ANY or ALL respectively).  The node representation
+// is almost the same as for the underlying operator, but we need a useOr
+// flag to remember whether it's ANY or ALL, and we don't have to store
+// the result type (or the collation) because it must be boolean.
+//
+// A ScalarArrayOpExpr with a valid hashfuncid is evaluated during execution
+// by building a hash table containing the Const values from the RHS arg.
+// This table is probed during expression evaluation.  The planner will set
+// hashfuncid to the hash function which must be used to build and probe the
+// hash table.  The executor determines if it should use hash-based checks or
+// the more traditional means based on if the hashfuncid is set or not.
+//
+// When performing hashed NOT IN, the negfuncid will also be set to the
+// equality function which the hash table must use to build and probe the hash
+// table.  opno and opfuncid will remain set to the <> operator and its
+// corresponding function and won't be used during execution.  For
+// non-hashtable based NOT INs, negfuncid will be set to InvalidOid.  See
+// convert_saop_to_hashed_saop().
+//
+// Similar to OpExpr, opfuncid, hashfuncid, and negfuncid are not necessarily
+// filled in right away, so will be ignored for equality if they are not set
+// yet.
+//
+// OID entries of the internal function types are irrelevant for the query
+// jumbling, but the operator OID and the arguments are.
+type ScalarArrayOpExpr struct {
+	Opno        Oid      // PG_OPERATOR OID of the operator
+	Opfuncid    Oid      // PG_PROC OID of comparison function
+	Hashfuncid  Oid      // PG_PROC OID of hash func or InvalidOid
+	Negfuncid   Oid      // PG_PROC OID of negator of opfuncid function or InvalidOid.  See above
+	UseOr       bool     // true for ANY, false for ALL
+	Inputcollid Oid      // OID of collation that operator should use
+	Args        *List    // the scalar and array operands
+	Location    ParseLoc // token location, or -1 if unknown
+}
+
+func (n *ScalarArrayOpExpr) Tag() NodeTag { return T_ScalarArrayOpExpr }
+
+// BoolExpr - expression node for the basic Boolean operators AND, OR, NOT
+//
+// Notice the arguments are given as a List.  For NOT, of course the list
+// must always have exactly one element.  For AND and OR, there can be two
+// or more arguments.
+type BoolExprType int
+
+const (
+	AND_EXPR BoolExprType = iota
+	OR_EXPR
+	NOT_EXPR
+)
+
+type BoolExpr struct {
+	Boolop   BoolExprType
+	Args     *List    // arguments to this expression
+	Location ParseLoc // token location, or -1 if unknown
+}
+
+func (n *BoolExpr) Tag() NodeTag { return T_BoolExpr }
+
+// SubLink
+//
+// A SubLink represents a subselect appearing in an expression, and in some
+// cases also the combining operator(s) just above it.  The subLinkType
+// indicates the form of the expression represented:
+// EXISTS_SUBLINK		EXISTS(SELECT ...)
+// ALL_SUBLINK			(lefthand) op ALL (SELECT ...)
+// ANY_SUBLINK			(lefthand) op ANY (SELECT ...)
+// ROWCOMPARE_SUBLINK	(lefthand) op (SELECT ...)
+// EXPR_SUBLINK		(SELECT with single targetlist item ...)
+// MULTIEXPR_SUBLINK	(SELECT with multiple targetlist items ...)
+// ARRAY_SUBLINK		ARRAY(SELECT with single targetlist item ...)
+// CTE_SUBLINK			WITH query (never actually part of an expression)
+// For ALL, ANY, and ROWCOMPARE, the lefthand is a list of expressions of the
+// same length as the subselect's targetlist.  ROWCOMPARE will *always* have
+// a list with more than one entry; if the subselect has just one target
+// then the parser will create an EXPR_SUBLINK instead (and any operator
+// above the subselect will be represented separately).
+// ROWCOMPARE, EXPR, and MULTIEXPR require the subselect to deliver at most
+// one row (if it returns no rows, the result is NULL).
+// ALL, ANY, and ROWCOMPARE require the combining operators to deliver boolean
+// results.  ALL and ANY combine the per-row results using AND and OR
+// semantics respectively.
+// ARRAY requires just one target column, and creates an array of the target
+// column's type using any number of rows resulting from the subselect.
+//
+// SubLink is classed as an Expr node, but it is not actually executable;
+// it must be replaced in the expression tree by a SubPlan node during
+// planning.
+//
+// NOTE: in the raw output of gram.y, testexpr contains just the raw form
+// of the lefthand expression (if any), and operName is the String name of
+// the combining operator.  Also, subselect is a raw parsetree.  During parse
+// analysis, the parser transforms testexpr into a complete boolean expression
+// that compares the lefthand value(s) to PARAM_SUBLINK nodes representing the
+// output columns of the subselect.  And subselect is transformed to a Query.
+// This is the representation seen in saved rules and in the rewriter.
+//
+// In EXISTS, EXPR, MULTIEXPR, and ARRAY SubLinks, testexpr and operName
+// are unused and are always null.
+//
+// subLinkId is currently used only for MULTIEXPR SubLinks, and is zero in
+// other SubLinks.  This number identifies different multiple-assignment
+// subqueries within an UPDATE statement's SET list.  It is unique only
+// within a particular targetlist.  The output column(s) of the MULTIEXPR
+// are referenced by PARAM_MULTIEXPR Params appearing elsewhere in the tlist.
+//
+// The CTE_SUBLINK case never occurs in actual SubLink nodes, but it is used
+// in SubPlans generated for WITH subqueries.
+type SubLinkType int
+
+const (
+	EXISTS_SUBLINK SubLinkType = iota
+	ALL_SUBLINK
+	ANY_SUBLINK
+	ROWCOMPARE_SUBLINK
+	EXPR_SUBLINK
+	MULTIEXPR_SUBLINK
+	ARRAY_SUBLINK
+	CTE_SUBLINK // for SubPlans only
+)
+
+type SubLink struct {
+	SubLinkType SubLinkType // see above
+	SubLinkId   int         // ID (1..n); 0 if not MULTIEXPR
+	Testexpr    Node        // outer-query test for ALL/ANY/ROWCOMPARE
+	OperName    *List       // originally specified operator name
+	Subselect   Node        // subselect as Query* or raw parsetree
+	Location    ParseLoc    // token location, or -1 if unknown
+}
+
+func (n *SubLink) Tag() NodeTag { return T_SubLink }
+
+// SubPlan - executable expression node for a subplan (sub-SELECT)
+//
+// The planner replaces SubLink nodes in expression trees with SubPlan
+// nodes after it has finished planning the subquery.  SubPlan references
+// a sub-plantree stored in the subplans list of the toplevel PlannedStmt.
+// (We avoid a direct link to make it easier to copy expression trees
+// without causing multiple processing of the subplan.)
+//
+// In an ordinary subplan, testexpr points to an executable expression
+// (OpExpr, an AND/OR tree of OpExprs, or RowCompareExpr) for the combining
+// operator(s); the left-hand arguments are the original lefthand expressions,
+// and the right-hand arguments are PARAM_EXEC Param nodes representing the
+// outputs of the sub-select.  (NOTE: runtime coercion functions may be
+// inserted as well.)  This is just the same expression tree as testexpr in
+// the original SubLink node, but the PARAM_SUBLINK nodes are replaced by
+// suitably numbered PARAM_EXEC nodes.
+//
+// If the sub-select becomes an initplan rather than a subplan, the executable
+// expression is part of the outer plan's expression tree (and the SubPlan
+// node itself is not, but rather is found in the outer plan's initPlan
+// list).  In this case testexpr is NULL to avoid duplication.
+//
+// The planner also derives lists of the values that need to be passed into
+// and out of the subplan.  Input values are represented as a list "args" of
+// expressions to be evaluated in the outer-query context (currently these
+// args are always just Vars, but in principle they could be any expression).
+// The values are assigned to the global PARAM_EXEC params indexed by parParam
+// (the parParam and args lists must have the same ordering).  setParam is a
+// list of the PARAM_EXEC params that are computed by the sub-select, if it
+// is an initplan or MULTIEXPR plan; they are listed in order by sub-select
+// output column position.  (parParam and setParam are integer Lists, not
+// Bitmapsets, because their ordering is significant.)
+//
+// Also, the planner computes startup and per-call costs for use of the
+// SubPlan.  Note that these include the cost of the subquery proper,
+// evaluation of the testexpr if any, and any hashtable management overhead.
+type SubPlan struct {
+	// Fields copied from original SubLink:
+	// see above
+	SubLinkType SubLinkType
+	// The combining operators, transformed to an executable expression:
+	// OpExpr or RowCompareExpr expression tree
+	Testexpr Node
+	ParamIds *List // IDs of Params embedded in the above
+	// Identification of the Plan tree to use:
+	// Index (from 1) in PlannedStmt.subplans
+	PlanId int `pg:"plan_id"`
+	// Identification of the SubPlan for EXPLAIN and debugging purposes:
+	// A name assigned during planning
+	PlanName string `pg:"plan_name"`
+	// Extra data useful for determining subplan's output type:
+	// Type of first column of subplan result
+	FirstColType   Oid
+	FirstColTypmod int32 // Typmod of first column of subplan result
+	// Collation of first column of subplan
+	// result
+	FirstColCollation Oid
+	// Information about execution strategy:
+	// true to store subselect output in a hash
+	// table (implies we are doing "IN")
+	UseHashTable bool
+	// true if it's okay to return FALSE when the
+	// spec result is UNKNOWN; this allows much
+	// simpler handling of null values
+	UnknownEqFalse bool
+	ParallelSafe   bool `pg:"parallel_safe"` // is the subplan parallel-safe?
+	// Note: parallel_safe does not consider contents of testexpr or args
+	// Information for passing params into and out of the subselect:
+	// setParam and parParam are lists of integers (param IDs)
+	// initplan and MULTIEXPR subqueries have to
+	// set these Params for parent plan
+	SetParam *List
+	ParParam *List // indices of input Params from parent plan
+	Args     *List // exprs to pass as parParam values
+	// Estimated execution costs:
+	// one-time setup cost
+	StartupCost float64 `pg:"startup_cost"`
+	PerCallCost float64 `pg:"per_call_cost"` // cost for each subplan evaluation
+}
+
+func (n *SubPlan) Tag() NodeTag { return T_SubPlan }
+
+// AlternativeSubPlan - expression node for a choice among SubPlans
+//
+// This is used only transiently during planning: by the time the plan
+// reaches the executor, all AlternativeSubPlan nodes have been removed.
+//
+// The subplans are given as a List so that the node definition need not
+// change if there's ever more than two alternatives.  For the moment,
+// though, there are always exactly two; and the first one is the fast-start
+// plan.
+type AlternativeSubPlan struct {
+	Subplans *List // SubPlan(s) with equivalent results
+}
+
+func (n *AlternativeSubPlan) Tag() NodeTag { return T_AlternativeSubPlan }
+
+// FieldSelect
+//
+// FieldSelect represents the operation of extracting one field from a tuple
+// value.  At runtime, the input expression is expected to yield a rowtype
+// Datum.  The specified field number is extracted and returned as a Datum.
+type FieldSelect struct {
+	Arg          Node       // input expression
+	Fieldnum     AttrNumber // attribute number of field to extract
+	Resulttype   Oid        // type of the field (result type of this node)
+	Resulttypmod int32      // output typmod (usually -1)
+	Resultcollid Oid        // OID of collation of the field
+}
+
+func (n *FieldSelect) Tag() NodeTag { return T_FieldSelect }
+
+// FieldStore
+//
+// FieldStore represents the operation of modifying one field in a tuple
+// value, yielding a new tuple value (the input is not touched!).  Like
+// the assign case of SubscriptingRef, this is used to implement UPDATE of a
+// portion of a column.
+//
+// resulttype is always a named composite type (not a domain).  To update
+// a composite domain value, apply CoerceToDomain to the FieldStore.
+//
+// A single FieldStore can actually represent updates of several different
+// fields.  The parser only generates FieldStores with single-element lists,
+// but the planner will collapse multiple updates of the same base column
+// into one FieldStore.
+type FieldStore struct {
+	Arg        Node     // input tuple value
+	Newvals    *List    // new value(s) for field(s)
+	Fieldnums  *IntList // integer list of field attnums
+	Resulttype Oid      // type of result (same as type of arg)
+}
+
+func (n *FieldStore) Tag() NodeTag { return T_FieldStore }
+
+// RelabelType
+//
+// RelabelType represents a "dummy" type coercion between two binary-
+// compatible datatypes, such as reinterpreting the result of an OID
+// expression as an int4.  It is a no-op at runtime; we only need it
+// to provide a place to store the correct type to be attributed to
+// the expression result during type resolution.  (We can't get away
+// with just overwriting the type field of the input expression node,
+// so we need a separate node to show the coercion's result type.)
+type RelabelType struct {
+	Arg           Node         // input expression
+	Resulttype    Oid          // output type of coercion expression
+	Resulttypmod  int32        // output typmod (usually -1)
+	Resultcollid  Oid          // OID of collation, or InvalidOid if none
+	Relabelformat CoercionForm // how to display this node
+	Location      ParseLoc     // token location, or -1 if unknown
+}
+
+func (n *RelabelType) Tag() NodeTag { return T_RelabelType }
+
+// CoerceViaIO
+//
+// CoerceViaIO represents a type coercion between two types whose textual
+// representations are compatible, implemented by invoking the source type's
+// typoutput function then the destination type's typinput function.
+type CoerceViaIO struct {
+	Arg        Node // input expression
+	Resulttype Oid  // output type of coercion
+	// output typmod is not stored, but is presumed -1
+	// OID of collation, or InvalidOid if none
+	Resultcollid Oid
+	Coerceformat CoercionForm // how to display this node
+	Location     ParseLoc     // token location, or -1 if unknown
+}
+
+func (n *CoerceViaIO) Tag() NodeTag { return T_CoerceViaIO }
+
+// ArrayCoerceExpr
+//
+// ArrayCoerceExpr represents a type coercion from one array type to another,
+// which is implemented by applying the per-element coercion expression
+// "elemexpr" to each element of the source array.  Within elemexpr, the
+// source element is represented by a CaseTestExpr node.  Note that even if
+// elemexpr is a no-op (that is, just CaseTestExpr + RelabelType), the
+// coercion still requires some effort: we have to fix the element type OID
+// stored in the array header.
+type ArrayCoerceExpr struct {
+	Arg          Node         // input expression (yields an array)
+	Elemexpr     Node         // expression representing per-element work
+	Resulttype   Oid          // output type of coercion (an array type)
+	Resulttypmod int32        // output typmod (also element typmod)
+	Resultcollid Oid          // OID of collation, or InvalidOid if none
+	Coerceformat CoercionForm // how to display this node
+	Location     ParseLoc     // token location, or -1 if unknown
+}
+
+func (n *ArrayCoerceExpr) Tag() NodeTag { return T_ArrayCoerceExpr }
+
+// ConvertRowtypeExpr
+//
+// ConvertRowtypeExpr represents a type coercion from one composite type
+// to another, where the source type is guaranteed to contain all the columns
+// needed for the destination type plus possibly others; the columns need not
+// be in the same positions, but are matched up by name.  This is primarily
+// used to convert a whole-row value of an inheritance child table into a
+// valid whole-row value of its parent table's rowtype.  Both resulttype
+// and the exposed type of "arg" must be named composite types (not domains).
+type ConvertRowtypeExpr struct {
+	Arg        Node // input expression
+	Resulttype Oid  // output type (always a composite type)
+	// Like RowExpr, we deliberately omit a typmod and collation here
+	// how to display this node
+	Convertformat CoercionForm
+	Location      ParseLoc // token location, or -1 if unknown
+}
+
+func (n *ConvertRowtypeExpr) Tag() NodeTag { return T_ConvertRowtypeExpr }
+
+// CollateExpr - COLLATE
+//
+// The planner replaces CollateExpr with RelabelType during expression
+// preprocessing, so execution never sees a CollateExpr.
+type CollateExpr struct {
+	Arg      Node     // input expression
+	CollOid  Oid      // collation's OID
+	Location ParseLoc // token location, or -1 if unknown
+}
+
+func (n *CollateExpr) Tag() NodeTag { return T_CollateExpr }
+
+// CaseExpr - a CASE expression
+//
+// We support two distinct forms of CASE expression:
+// CASE WHEN boolexpr THEN expr [ WHEN boolexpr THEN expr ... ]
+// CASE testexpr WHEN compexpr THEN expr [ WHEN compexpr THEN expr ... ]
+// These are distinguishable by the "arg" field being NULL in the first case
+// and the testexpr in the second case.
+//
+// In the raw grammar output for the second form, the condition expressions
+// of the WHEN clauses are just the comparison values.  Parse analysis
+// converts these to valid boolean expressions of the form
+// CaseTestExpr '=' compexpr
+// where the CaseTestExpr node is a placeholder that emits the correct
+// value at runtime.  This structure is used so that the testexpr need be
+// evaluated only once.  Note that after parse analysis, the condition
+// expressions always yield boolean.
+//
+// Note: we can test whether a CaseExpr has been through parse analysis
+// yet by checking whether casetype is InvalidOid or not.
+type CaseExpr struct {
+	Casetype   Oid      // type of expression result
+	Casecollid Oid      // OID of collation, or InvalidOid if none
+	Arg        Node     // implicit equality comparison argument
+	Args       *List    // the arguments (list of WHEN clauses)
+	Defresult  Node     // the default result (ELSE clause)
+	Location   ParseLoc // token location, or -1 if unknown
+}
+
+func (n *CaseExpr) Tag() NodeTag { return T_CaseExpr }
+
+// CaseWhen - one arm of a CASE expression
+type CaseWhen struct {
+	Expr     Node     // condition expression
+	Result   Node     // substitution result
+	Location ParseLoc // token location, or -1 if unknown
+}
+
+func (n *CaseWhen) Tag() NodeTag { return T_CaseWhen }
+
+// Placeholder node for the test value to be processed by a CASE expression.
+// This is effectively like a Param, but can be implemented more simply
+// since we need only one replacement value at a time.
+//
+// We also abuse this node type for some other purposes, including:
+// * Placeholder for the current array element value in ArrayCoerceExpr;
+// see build_coercion_expression().
+// * Nested FieldStore/SubscriptingRef assignment expressions in INSERT/UPDATE;
+// see transformAssignmentIndirection().
+// * Placeholder for intermediate results in some SQL/JSON expression nodes,
+// such as JsonConstructorExpr.
+//
+// The uses in CaseExpr and ArrayCoerceExpr are safe only to the extent that
+// there is not any other CaseExpr or ArrayCoerceExpr between the value source
+// node and its child CaseTestExpr(s).  This is true in the parse analysis
+// output, but the planner's function-inlining logic has to be careful not to
+// break it.
+//
+// The nested-assignment-expression case is safe because the only node types
+// that can be above such CaseTestExprs are FieldStore and SubscriptingRef.
+type CaseTestExpr struct {
+	TypeId    Oid   // type for substituted value
+	TypeMod   int32 // typemod for substituted value
+	Collation Oid   // collation for the substituted value
+}
+
+func (n *CaseTestExpr) Tag() NodeTag { return T_CaseTestExpr }
+
+// ArrayExpr - an ARRAY[] expression
+//
+// Note: if multidims is false, the constituent expressions all yield the
+// scalar type identified by element_typeid.  If multidims is true, the
+// constituent expressions all yield arrays of element_typeid (ie, the same
+// type as array_typeid); at runtime we must check for compatible subscripts.
+type ArrayExpr struct {
+	ArrayTypeid   Oid      `pg:"array_typeid"`   // type of expression result
+	ArrayCollid   Oid      `pg:"array_collid"`   // OID of collation, or InvalidOid if none
+	ElementTypeid Oid      `pg:"element_typeid"` // common type of array elements
+	Elements      *List    // the array elements or sub-arrays
+	Multidims     bool     // true if elements are sub-arrays
+	Location      ParseLoc // token location, or -1 if unknown
+}
+
+func (n *ArrayExpr) Tag() NodeTag { return T_ArrayExpr }
+
+// RowExpr - a ROW() expression
+//
+// Note: the list of fields must have a one-for-one correspondence with
+// physical fields of the associated rowtype, although it is okay for it
+// to be shorter than the rowtype.  That is, the N'th list element must
+// match up with the N'th physical field.  When the N'th physical field
+// is a dropped column (attisdropped) then the N'th list element can just
+// be a NULL constant.  (This case can only occur for named composite types,
+// not RECORD types, since those are built from the RowExpr itself rather
+// than vice versa.)  It is important not to assume that length(args) is
+// the same as the number of columns logically present in the rowtype.
+//
+// colnames provides field names if the ROW() result is of type RECORD.
+// Names *must* be provided if row_typeid is RECORDOID; but if it is a
+// named composite type, colnames will be ignored in favor of using the
+// type's cataloged field names, so colnames should be NIL.  Like the
+// args list, colnames is defined to be one-for-one with physical fields
+// of the rowtype (although dropped columns shouldn't appear in the
+// RECORD case, so this fine point is currently moot).
+type RowExpr struct {
+	Args      *List // the fields
+	RowTypeid Oid   `pg:"row_typeid"` // RECORDOID or a composite type's ID
+	// row_typeid cannot be a domain over composite, only plain composite.  To
+	// create a composite domain value, apply CoerceToDomain to the RowExpr.
+	//
+	// Note: we deliberately do NOT store a typmod.  Although a typmod will be
+	// associated with specific RECORD types at runtime, it will differ for
+	// different backends, and so cannot safely be stored in stored
+	// parsetrees.  We must assume typmod -1 for a RowExpr node.
+	//
+	// We don't need to store a collation either.  The result type is
+	// necessarily composite, and composite types never have a collation.
+	//
+	// how to display this node
+	RowFormat CoercionForm `pg:"row_format"`
+	Colnames  *List        // list of String, or NIL
+	Location  ParseLoc     // token location, or -1 if unknown
+}
+
+func (n *RowExpr) Tag() NodeTag { return T_RowExpr }
+
+// RowCompareExpr - row-wise comparison, such as (a, b) <= (1, 2)
+//
+// We support row comparison for any operator that can be determined to
+// act like =, <>, <, <=, >, or >= (we determine this by looking for the
+// operator in btree opfamilies).  Note that the same operator name might
+// map to a different operator for each pair of row elements, since the
+// element datatypes can vary.
+//
+// A RowCompareExpr node is only generated for the < <= > >= cases;
+// the = and <> cases are translated to simple AND or OR combinations
+// of the pairwise comparisons.  However, we include = and <> in the
+// RowCompareType enum for the convenience of parser logic.
+type RowCompareType int
+
+const (
+	// Values of this enum are chosen to match btree strategy numbers
+	// BTLessStrategyNumber
+	ROWCOMPARE_LT RowCompareType = 1
+	ROWCOMPARE_LE RowCompareType = 2 // BTLessEqualStrategyNumber
+	ROWCOMPARE_EQ RowCompareType = 3 // BTEqualStrategyNumber
+	ROWCOMPARE_GE RowCompareType = 4 // BTGreaterEqualStrategyNumber
+	ROWCOMPARE_GT RowCompareType = 5 // BTGreaterStrategyNumber
+	ROWCOMPARE_NE RowCompareType = 6 // no such btree strategy
+)
+
+type RowCompareExpr struct {
+	Rctype       RowCompareType // LT LE GE or GT, never EQ or NE
+	Opnos        *OidList       // OID list of pairwise comparison ops
+	Opfamilies   *OidList       // OID list of containing operator families
+	Inputcollids *OidList       // OID list of collations for comparisons
+	Largs        *List          // the left-hand input arguments
+	Rargs        *List          // the right-hand input arguments
+}
+
+func (n *RowCompareExpr) Tag() NodeTag { return T_RowCompareExpr }
+
+// CoalesceExpr - a COALESCE expression
+type CoalesceExpr struct {
+	Coalescetype   Oid      // type of expression result
+	Coalescecollid Oid      // OID of collation, or InvalidOid if none
+	Args           *List    // the arguments
+	Location       ParseLoc // token location, or -1 if unknown
+}
+
+func (n *CoalesceExpr) Tag() NodeTag { return T_CoalesceExpr }
+
+// MinMaxExpr - a GREATEST or LEAST function
+type MinMaxOp int
+
+const (
+	IS_GREATEST MinMaxOp = iota
+	IS_LEAST
+)
+
+type MinMaxExpr struct {
+	Minmaxtype   Oid      // common type of arguments and result
+	Minmaxcollid Oid      // OID of collation of result
+	Inputcollid  Oid      // OID of collation that function should use
+	Op           MinMaxOp // function to execute
+	Args         *List    // the arguments
+	Location     ParseLoc // token location, or -1 if unknown
+}
+
+func (n *MinMaxExpr) Tag() NodeTag { return T_MinMaxExpr }
+
+// SQLValueFunction - parameterless functions with special grammar productions
+//
+// The SQL standard categorizes some of these as <datetime value function>
+// and others as <general value specification>.  We call 'em SQLValueFunctions
+// for lack of a better term.  We store type and typmod of the result so that
+// some code doesn't need to know each function individually, and because
+// we would need to store typmod anyway for some of the datetime functions.
+// Note that currently, all variants return non-collating datatypes, so we do
+// not need a collation field; also, all these functions are stable.
+type SQLValueFunctionOp int
+
+const (
+	SVFOP_CURRENT_DATE SQLValueFunctionOp = iota
+	SVFOP_CURRENT_TIME
+	SVFOP_CURRENT_TIME_N
+	SVFOP_CURRENT_TIMESTAMP
+	SVFOP_CURRENT_TIMESTAMP_N
+	SVFOP_LOCALTIME
+	SVFOP_LOCALTIME_N
+	SVFOP_LOCALTIMESTAMP
+	SVFOP_LOCALTIMESTAMP_N
+	SVFOP_CURRENT_ROLE
+	SVFOP_CURRENT_USER
+	SVFOP_USER
+	SVFOP_SESSION_USER
+	SVFOP_CURRENT_CATALOG
+	SVFOP_CURRENT_SCHEMA
+)
+
+type SQLValueFunction struct {
+	Op SQLValueFunctionOp // which function this is
+	// Result type/typmod.  Type is fully determined by "op", so no need to
+	// include this Oid in the query jumbling.
+	Type     Oid
+	Typmod   int32
+	Location ParseLoc // token location, or -1 if unknown
+}
+
+func (n *SQLValueFunction) Tag() NodeTag { return T_SQLValueFunction }
+
+// XmlExpr - various SQL/XML functions requiring special grammar productions
+//
+// 'name' carries the "NAME foo" argument (already XML-escaped).
+// 'named_args' and 'arg_names' represent an xml_attribute list.
+// 'args' carries all other arguments.
+//
+// Note: result type/typmod/collation are not stored, but can be deduced
+// from the XmlExprOp.  The type/typmod fields are just used for display
+// purposes, and are NOT necessarily the true result type of the node.
+type XmlExprOp int
+
+const (
+	IS_XMLCONCAT    XmlExprOp = iota // XMLCONCAT(args)
+	IS_XMLELEMENT                    // XMLELEMENT(name, xml_attributes, args)
+	IS_XMLFOREST                     // XMLFOREST(xml_attributes)
+	IS_XMLPARSE                      // XMLPARSE(text, is_doc, preserve_ws)
+	IS_XMLPI                         // XMLPI(name [, args])
+	IS_XMLROOT                       // XMLROOT(xml, version, standalone)
+	IS_XMLSERIALIZE                  // XMLSERIALIZE(is_document, xmlval, indent)
+	IS_DOCUMENT                      // xmlval IS DOCUMENT
+)
+
+type XmlOptionType int
+
+const (
+	XMLOPTION_DOCUMENT XmlOptionType = iota
+	XMLOPTION_CONTENT
+)
+
+type XmlExpr struct {
+	Op        XmlExprOp     // xml function ID
+	Name      string        // name in xml(NAME foo ...) syntaxes
+	NamedArgs *List         `pg:"named_args"` // non-XML expressions for xml_attributes
+	ArgNames  *List         `pg:"arg_names"`  // parallel list of String values
+	Args      *List         // list of expressions
+	Xmloption XmlOptionType // DOCUMENT or CONTENT
+	Indent    bool          // INDENT option for XMLSERIALIZE
+	Type      Oid           // target type/typmod for XMLSERIALIZE
+	Typmod    int32
+	Location  ParseLoc // token location, or -1 if unknown
+}
+
+func (n *XmlExpr) Tag() NodeTag { return T_XmlExpr }
+
+// JsonEncoding -
+// representation of JSON ENCODING clause
+type JsonEncoding int
+
+const (
+	JS_ENC_DEFAULT JsonEncoding = iota // unspecified
+	JS_ENC_UTF8
+	JS_ENC_UTF16
+	JS_ENC_UTF32
+)
+
+// JsonFormatType -
+// enumeration of JSON formats used in JSON FORMAT clause
+type JsonFormatType int
+
+const (
+	JS_FORMAT_DEFAULT JsonFormatType = iota // unspecified
+	JS_FORMAT_JSON                          // FORMAT JSON [ENCODING ...]
+	// implicit internal format for RETURNING
+	// jsonb
+	JS_FORMAT_JSONB
+)
+
+// JsonFormat -
+// representation of JSON FORMAT clause
+type JsonFormat struct {
+	FormatType JsonFormatType `pg:"format_type"` // format type
+	Encoding   JsonEncoding   // JSON encoding
+	Location   ParseLoc       // token location, or -1 if unknown
+}
+
+func (n *JsonFormat) Tag() NodeTag { return T_JsonFormat }
+
+// JsonReturning -
+// transformed representation of JSON RETURNING clause
+type JsonReturning struct {
+	Format *JsonFormat // output JSON format
+	Typid  Oid         // target type Oid
+	Typmod int32       // target type modifier
+}
+
+func (n *JsonReturning) Tag() NodeTag { return T_JsonReturning }
+
+// JsonValueExpr -
+// representation of JSON value expression (expr [FORMAT JsonFormat])
+//
+// raw_expr is the user-specified value, while formatted_expr is the value
+// obtained by coercing raw_expr to the type required by either the FORMAT
+// clause or an enclosing node's RETURNING clause.
+//
+// When deparsing a JsonValueExpr, get_rule_expr() prints raw_expr. However,
+// during the evaluation of a JsonValueExpr, the value of formatted_expr
+// takes precedence over that of raw_expr.
+type JsonValueExpr struct {
+	RawExpr       Node        `pg:"raw_expr"`       // user-specified expression
+	FormattedExpr Node        `pg:"formatted_expr"` // coerced formatted expression
+	Format        *JsonFormat // FORMAT clause, if specified
+}
+
+func (n *JsonValueExpr) Tag() NodeTag { return T_JsonValueExpr }
+
+type JsonConstructorType int
+
+const (
+	JSCTOR_JSON_OBJECT    JsonConstructorType = 1
+	JSCTOR_JSON_ARRAY     JsonConstructorType = 2
+	JSCTOR_JSON_OBJECTAGG JsonConstructorType = 3
+	JSCTOR_JSON_ARRAYAGG  JsonConstructorType = 4
+	JSCTOR_JSON_PARSE     JsonConstructorType = 5
+	JSCTOR_JSON_SCALAR    JsonConstructorType = 6
+	JSCTOR_JSON_SERIALIZE JsonConstructorType = 7
+)
+
+// JsonConstructorExpr -
+// wrapper over FuncExpr/Aggref/WindowFunc for SQL/JSON constructors
+type JsonConstructorExpr struct {
+	Type         JsonConstructorType // constructor type
+	Args         *List
+	Func         Node           // underlying json[b]_xxx() function call
+	Coercion     Node           // coercion to RETURNING type
+	Returning    *JsonReturning // RETURNING clause
+	AbsentOnNull bool           `pg:"absent_on_null"` // ABSENT ON NULL?
+	Unique       bool           // WITH UNIQUE KEYS? (JSON_OBJECT[AGG] only)
+	Location     ParseLoc
+}
+
+func (n *JsonConstructorExpr) Tag() NodeTag { return T_JsonConstructorExpr }
+
+// JsonValueType -
+// representation of JSON item type in IS JSON predicate
+type JsonValueType int
+
+const (
+	JS_TYPE_ANY    JsonValueType = iota // IS JSON [VALUE]
+	JS_TYPE_OBJECT                      // IS JSON OBJECT
+	JS_TYPE_ARRAY                       // IS JSON ARRAY
+	JS_TYPE_SCALAR                      // IS JSON SCALAR
+)
+
+// JsonIsPredicate -
+// representation of IS JSON predicate
+type JsonIsPredicate struct {
+	Expr       Node          // subject expression
+	Format     *JsonFormat   // FORMAT clause, if specified
+	ItemType   JsonValueType `pg:"item_type"`   // JSON item type
+	UniqueKeys bool          `pg:"unique_keys"` // check key uniqueness?
+	Location   ParseLoc      // token location, or -1 if unknown
+}
+
+func (n *JsonIsPredicate) Tag() NodeTag { return T_JsonIsPredicate }
+
+// JsonWrapper -
+// representation of WRAPPER clause for JSON_QUERY()
+type JsonWrapper int
+
+const (
+	JSW_UNSPEC JsonWrapper = iota
+	JSW_NONE
+	JSW_CONDITIONAL
+	JSW_UNCONDITIONAL
+)
+
+// JsonBehaviorType -
+// enumeration of behavior types used in SQL/JSON ON ERROR/EMPTY clauses
+//
+// If enum members are reordered, get_json_behavior() from ruleutils.c
+// must be updated accordingly.
+type JsonBehaviorType int
+
+const (
+	JSON_BEHAVIOR_NULL         JsonBehaviorType = 0
+	JSON_BEHAVIOR_ERROR        JsonBehaviorType = JSON_BEHAVIOR_NULL + 1
+	JSON_BEHAVIOR_EMPTY        JsonBehaviorType = JSON_BEHAVIOR_ERROR + 1
+	JSON_BEHAVIOR_TRUE         JsonBehaviorType = JSON_BEHAVIOR_EMPTY + 1
+	JSON_BEHAVIOR_FALSE        JsonBehaviorType = JSON_BEHAVIOR_TRUE + 1
+	JSON_BEHAVIOR_UNKNOWN      JsonBehaviorType = JSON_BEHAVIOR_FALSE + 1
+	JSON_BEHAVIOR_EMPTY_ARRAY  JsonBehaviorType = JSON_BEHAVIOR_UNKNOWN + 1
+	JSON_BEHAVIOR_EMPTY_OBJECT JsonBehaviorType = JSON_BEHAVIOR_EMPTY_ARRAY + 1
+	JSON_BEHAVIOR_DEFAULT      JsonBehaviorType = JSON_BEHAVIOR_EMPTY_OBJECT + 1
+)
+
+// JsonBehavior
+// Specifications for ON ERROR / ON EMPTY behaviors of SQL/JSON
+// query functions specified by a JsonExpr
+//
+// 'expr' is the expression to emit when a given behavior (EMPTY or ERROR)
+// occurs on evaluating the SQL/JSON query function.  'coerce' is set to true
+// if 'expr' isn't already of the expected target type given by
+// JsonExpr.returning.
+type JsonBehavior struct {
+	Btype    JsonBehaviorType
+	Expr     Node
+	Coerce   bool
+	Location ParseLoc // token location, or -1 if unknown
+}
+
+func (n *JsonBehavior) Tag() NodeTag { return T_JsonBehavior }
+
+// JsonExprOp -
+// enumeration of SQL/JSON query function types
+type JsonExprOp int
+
+const (
+	JSON_EXISTS_OP JsonExprOp = iota // JSON_EXISTS()
+	JSON_QUERY_OP                    // JSON_QUERY()
+	JSON_VALUE_OP                    // JSON_VALUE()
+	JSON_TABLE_OP                    // JSON_TABLE()
+)
+
+// JsonExpr -
+// Transformed representation of JSON_VALUE(), JSON_QUERY(), and
+// JSON_EXISTS()
+type JsonExpr struct {
+	Op JsonExprOp
+	// JSON_TABLE() column name or NULL if this is
+	// not for a JSON_TABLE()
+	ColumnName    string         `pg:"column_name"`
+	FormattedExpr Node           `pg:"formatted_expr"` // jsonb-valued expression to query
+	Format        *JsonFormat    // Format of the above expression needed by ruleutils.c
+	PathSpec      Node           `pg:"path_spec"` // jsonpath-valued expression containing the query pattern
+	Returning     *JsonReturning // Expected type/format of the output.
+	PassingNames  *List          `pg:"passing_names"` // Information about the PASSING argument expressions
+	PassingValues *List          `pg:"passing_values"`
+	OnEmpty       *JsonBehavior  `pg:"on_empty"` // User-specified or default ON EMPTY and ON ERROR behaviors
+	OnError       *JsonBehavior  `pg:"on_error"`
+	// Information about converting the result of jsonpath functions
+	// JsonPathQuery() and JsonPathValue() to the RETURNING type.
+	UseIoCoercion   bool        `pg:"use_io_coercion"`
+	UseJsonCoercion bool        `pg:"use_json_coercion"`
+	Wrapper         JsonWrapper // WRAPPER specification for JSON_QUERY
+	OmitQuotes      bool        `pg:"omit_quotes"` // KEEP or OMIT QUOTES for singleton scalars returned by JSON_QUERY()
+	Collation       Oid         // JsonExpr's collation.
+	Location        ParseLoc    // Original JsonFuncExpr's location
+}
+
+func (n *JsonExpr) Tag() NodeTag { return T_JsonExpr }
+
+// JsonTablePath
+// A JSON path expression to be computed as part of evaluating
+// a JSON_TABLE plan node
+type JsonTablePath struct {
+	Value *Const
+	Name  string
+}
+
+func (n *JsonTablePath) Tag() NodeTag { return T_JsonTablePath }
+
+// JSON_TABLE plan to evaluate a JSON path expression and NESTED paths, if
+// any.
+type JsonTablePathScan struct {
+	Path *JsonTablePath // JSON path to evaluate
+	// ERROR/EMPTY ON ERROR behavior; only significant in the plan for the
+	// top-level path.
+	ErrorOnError bool
+	Child        Node // Plan(s) for nested columns, if any.
+	// 0-based index in TableFunc.colvalexprs of the 1st and the last column
+	// covered by this plan.  Both are -1 if all columns are nested and thus
+	// computed by the child plan(s).
+	ColMin int
+	ColMax int
+}
+
+func (n *JsonTablePathScan) Tag() NodeTag { return T_JsonTablePathScan }
+
+// JsonTableSiblingJoin -
+// Plan to join rows of sibling NESTED COLUMNS clauses in the same parent
+// COLUMNS clause
+type JsonTableSiblingJoin struct {
+	Lplan Node
+	Rplan Node
+}
+
+func (n *JsonTableSiblingJoin) Tag() NodeTag { return T_JsonTableSiblingJoin }
+
+// NullTest
+//
+// NullTest represents the operation of testing a value for NULLness.
+// The appropriate test is performed and returned as a boolean Datum.
+//
+// When argisrow is false, this simply represents a test for the null value.
+//
+// When argisrow is true, the input expression must yield a rowtype, and
+// the node implements "row IS [NOT] NULL" per the SQL standard.  This
+// includes checking individual fields for NULLness when the row datum
+// itself isn't NULL.
+//
+// NOTE: the combination of a rowtype input and argisrow==false does NOT
+// correspond to the SQL notation "row IS [NOT] NULL"; instead, this case
+// represents the SQL notation "row IS [NOT] DISTINCT FROM NULL".
+type NullTestType int
+
+const (
+	IS_NULL NullTestType = iota
+	IS_NOT_NULL
+)
+
+type NullTest struct {
+	Arg          Node         // input expression
+	Nulltesttype NullTestType // IS NULL, IS NOT NULL
+	Argisrow     bool         // T to perform field-by-field null checks
+	Location     ParseLoc     // token location, or -1 if unknown
+}
+
+func (n *NullTest) Tag() NodeTag { return T_NullTest }
+
+// BooleanTest
+//
+// BooleanTest represents the operation of determining whether a boolean
+// is TRUE, FALSE, or UNKNOWN (ie, NULL).  All six meaningful combinations
+// are supported.  Note that a NULL input does *not* cause a NULL result.
+// The appropriate test is performed and returned as a boolean Datum.
+type BoolTestType int
+
+const (
+	IS_TRUE BoolTestType = iota
+	IS_NOT_TRUE
+	IS_FALSE
+	IS_NOT_FALSE
+	IS_UNKNOWN
+	IS_NOT_UNKNOWN
+)
+
+type BooleanTest struct {
+	Arg          Node         // input expression
+	Booltesttype BoolTestType // test type
+	Location     ParseLoc     // token location, or -1 if unknown
+}
+
+func (n *BooleanTest) Tag() NodeTag { return T_BooleanTest }
+
+// MergeAction
+//
+// Transformed representation of a WHEN clause in a MERGE statement
+type MergeMatchKind int
+
+const (
+	MERGE_WHEN_MATCHED MergeMatchKind = iota
+	MERGE_WHEN_NOT_MATCHED_BY_SOURCE
+	MERGE_WHEN_NOT_MATCHED_BY_TARGET
+)
+
+const (
+	NUM_MERGE_MATCH_KINDS = (MERGE_WHEN_NOT_MATCHED_BY_TARGET + 1)
+)
+
+type MergeAction struct {
+	MatchKind    MergeMatchKind // MATCHED/NOT MATCHED BY SOURCE/TARGET
+	CommandType  CmdType        // INSERT/UPDATE/DELETE/DO NOTHING
+	Override     OverridingKind // OVERRIDING clause
+	Qual         Node           // transformed WHEN conditions
+	TargetList   *List          // the target list (of TargetEntry)
+	UpdateColnos *IntList       // target attribute numbers of an UPDATE
+}
+
+func (n *MergeAction) Tag() NodeTag { return T_MergeAction }
+
+// CoerceToDomain
+//
+// CoerceToDomain represents the operation of coercing a value to a domain
+// type.  At runtime (and not before) the precise set of constraints to be
+// checked will be determined.  If the value passes, it is returned as the
+// result; if not, an error is raised.  Note that this is equivalent to
+// RelabelType in the scenario where no constraints are applied.
+type CoerceToDomain struct {
+	Arg            Node         // input expression
+	Resulttype     Oid          // domain type ID (result type)
+	Resulttypmod   int32        // output typmod (currently always -1)
+	Resultcollid   Oid          // OID of collation, or InvalidOid if none
+	Coercionformat CoercionForm // how to display this node
+	Location       ParseLoc     // token location, or -1 if unknown
+}
+
+func (n *CoerceToDomain) Tag() NodeTag { return T_CoerceToDomain }
+
+// Placeholder node for the value to be processed by a domain's check
+// constraint.  This is effectively like a Param, but can be implemented more
+// simply since we need only one replacement value at a time.
+//
+// Note: the typeId/typeMod/collation will be set from the domain's base type,
+// not the domain itself.  This is because we shouldn't consider the value
+// to be a member of the domain if we haven't yet checked its constraints.
+type CoerceToDomainValue struct {
+	TypeId    Oid      // type for substituted value
+	TypeMod   int32    // typemod for substituted value
+	Collation Oid      // collation for the substituted value
+	Location  ParseLoc // token location, or -1 if unknown
+}
+
+func (n *CoerceToDomainValue) Tag() NodeTag { return T_CoerceToDomainValue }
+
+// Placeholder node for a DEFAULT marker in an INSERT or UPDATE command.
+//
+// This is not an executable expression: it must be replaced by the actual
+// column default expression during rewriting.  But it is convenient to
+// treat it as an expression node during parsing and rewriting.
+type SetToDefault struct {
+	TypeId    Oid      // type for substituted value
+	TypeMod   int32    // typemod for substituted value
+	Collation Oid      // collation for the substituted value
+	Location  ParseLoc // token location, or -1 if unknown
+}
+
+func (n *SetToDefault) Tag() NodeTag { return T_SetToDefault }
+
+// Node representing [WHERE] CURRENT OF cursor_name
+//
+// CURRENT OF is a bit like a Var, in that it carries the rangetable index
+// of the target relation being constrained; this aids placing the expression
+// correctly during planning.  We can assume however that its "levelsup" is
+// always zero, due to the syntactic constraints on where it can appear.
+// Also, cvarno will always be a true RT index, never INNER_VAR etc.
+//
+// The referenced cursor can be represented either as a hardwired string
+// or as a reference to a run-time parameter of type REFCURSOR.  The latter
+// case is for the convenience of plpgsql.
+type CurrentOfExpr struct {
+	Cvarno      uint32 // RT index of target relation
+	CursorName  string `pg:"cursor_name"`  // name of referenced cursor, or NULL
+	CursorParam int    `pg:"cursor_param"` // refcursor parameter number, or 0
+}
+
+func (n *CurrentOfExpr) Tag() NodeTag { return T_CurrentOfExpr }
+
+// NextValueExpr - get next value from sequence
+//
+// This has the same effect as calling the nextval() function, but it does not
+// check permissions on the sequence.  This is used for identity columns,
+// where the sequence is an implicit dependency without its own permissions.
+type NextValueExpr struct {
+	Seqid  Oid
+	TypeId Oid
+}
+
+func (n *NextValueExpr) Tag() NodeTag { return T_NextValueExpr }
+
+// InferenceElem - an element of a unique index inference specification
+//
+// This mostly matches the structure of IndexElems, but having a dedicated
+// primnode allows for a clean separation between the use of index parameters
+// by utility commands, and this node.
+type InferenceElem struct {
+	Expr         Node // expression to infer from, or NULL
+	Infercollid  Oid  // OID of collation, or InvalidOid
+	Inferopclass Oid  // OID of att opclass, or InvalidOid
+}
+
+func (n *InferenceElem) Tag() NodeTag { return T_InferenceElem }
+
+// TargetEntry -
+// a target entry (used in query target lists)
+//
+// Strictly speaking, a TargetEntry isn't an expression node (since it can't
+// be evaluated by ExecEvalExpr).  But we treat it as one anyway, since in
+// very many places it's convenient to process a whole query targetlist as a
+// single expression tree.
+//
+// In a SELECT's targetlist, resno should always be equal to the item's
+// ordinal position (counting from 1).  However, in an INSERT or UPDATE
+// targetlist, resno represents the attribute number of the destination
+// column for the item; so there may be missing or out-of-order resnos.
+// It is even legal to have duplicated resnos; consider
+// UPDATE table SET arraycol[1] = ..., arraycol[2] = ..., ...
+// In an INSERT, the rewriter and planner will normalize the tlist by
+// reordering it into physical column order and filling in default values
+// for any columns not assigned values by the original query.  In an UPDATE,
+// after the rewriter merges multiple assignments for the same column, the
+// planner extracts the target-column numbers into a separate "update_colnos"
+// list, and then renumbers the tlist elements serially.  Thus, tlist resnos
+// match ordinal position in all tlists seen by the executor; but it is wrong
+// to assume that before planning has happened.
+//
+// resname is required to represent the correct column name in non-resjunk
+// entries of top-level SELECT targetlists, since it will be used as the
+// column title sent to the frontend.  In most other contexts it is only
+// a debugging aid, and may be wrong or even NULL.  (In particular, it may
+// be wrong in a tlist from a stored rule, if the referenced column has been
+// renamed by ALTER TABLE since the rule was made.  Also, the planner tends
+// to store NULL rather than look up a valid name for tlist entries in
+// non-toplevel plan nodes.)  In resjunk entries, resname should be either
+// a specific system-generated name (such as "ctid") or NULL; anything else
+// risks confusing ExecGetJunkAttribute!
+//
+// ressortgroupref is used in the representation of ORDER BY, GROUP BY, and
+// DISTINCT items.  Targetlist entries with ressortgroupref=0 are not
+// sort/group items.  If ressortgroupref>0, then this item is an ORDER BY,
+// GROUP BY, and/or DISTINCT target value.  No two entries in a targetlist
+// may have the same nonzero ressortgroupref --- but there is no particular
+// meaning to the nonzero values, except as tags.  (For example, one must
+// not assume that lower ressortgroupref means a more significant sort key.)
+// The order of the associated SortGroupClause lists determine the semantics.
+//
+// resorigtbl/resorigcol identify the source of the column, if it is a
+// simple reference to a column of a base table (or view).  If it is not
+// a simple reference, these fields are zeroes.
+//
+// If resjunk is true then the column is a working column (such as a sort key)
+// that should be removed from the final output of the query.  Resjunk columns
+// must have resnos that cannot duplicate any regular column's resno.  Also
+// note that there are places that assume resjunk columns come after non-junk
+// columns.
+type TargetEntry struct {
+	Expr            Node       // expression to evaluate
+	Resno           AttrNumber // attribute number (see notes above)
+	Resname         string     // name of the column (could be NULL)
+	Ressortgroupref uint32     // nonzero if referenced by a sort/group clause
+	Resorigtbl      Oid        // OID of column's source table
+	Resorigcol      AttrNumber // column's number in source table
+	Resjunk         bool       // set to true to eliminate the attribute from final target list
+}
+
+func (n *TargetEntry) Tag() NodeTag { return T_TargetEntry }
+
+// RangeTblRef - reference to an entry in the query's rangetable
+//
+// We could use direct pointers to the RT entries and skip having these
+// nodes, but multiple pointers to the same node in a querytree cause
+// lots of headaches, so it seems better to store an index into the RT.
+type RangeTblRef struct {
+	Rtindex int
+}
+
+func (n *RangeTblRef) Tag() NodeTag { return T_RangeTblRef }
+
+// JoinExpr - for SQL JOIN expressions
+//
+// isNatural, usingClause, and quals are interdependent.  The user can write
+// only one of NATURAL, USING(), or ON() (this is enforced by the grammar).
+// If he writes NATURAL then parse analysis generates the equivalent USING()
+// list, and from that fills in "quals" with the right equality comparisons.
+// If he writes USING() then "quals" is filled with equality comparisons.
+// If he writes ON() then only "quals" is set.  Note that NATURAL/USING
+// are not equivalent to ON() since they also affect the output column list.
+//
+// alias is an Alias node representing the AS alias-clause attached to the
+// join expression, or NULL if no clause.  NB: presence or absence of the
+// alias has a critical impact on semantics, because a join with an alias
+// restricts visibility of the tables/columns inside it.
+//
+// join_using_alias is an Alias node representing the join correlation
+// name that SQL:2016 and later allow to be attached to JOIN/USING.
+// Its column alias list includes only the common column names from USING,
+// and it does not restrict visibility of the join's input tables.
+//
+// During parse analysis, an RTE is created for the Join, and its index
+// is filled into rtindex.  This RTE is present mainly so that Vars can
+// be created that refer to the outputs of the join.  The planner sometimes
+// generates JoinExprs internally; these can have rtindex = 0 if there are
+// no join alias variables referencing such joins.
+type JoinExpr struct {
+	Jointype       JoinType // type of join
+	IsNatural      bool     // Natural join? Will need to shape table
+	Larg           Node     // left subtree
+	Rarg           Node     // right subtree
+	UsingClause    *List    // USING clause, if any (list of String)
+	JoinUsingAlias *Alias   `pg:"join_using_alias"` // alias attached to USING clause, if any
+	Quals          Node     // qualifiers on join, if any
+	Alias          *Alias   // user-written alias clause, if any
+	Rtindex        int      // RT index assigned for join, or 0
+}
+
+func (n *JoinExpr) Tag() NodeTag { return T_JoinExpr }
+
+// FromExpr - represents a FROM ... WHERE ... construct
+//
+// This is both more flexible than a JoinExpr (it can have any number of
+// children, including zero) and less so --- we don't need to deal with
+// aliases and so on.  The output column set is implicitly just the union
+// of the outputs of the children.
+type FromExpr struct {
+	Fromlist *List // List of join subtrees
+	Quals    Node  // qualifiers on join, if any
+}
+
+func (n *FromExpr) Tag() NodeTag { return T_FromExpr }
+
+// OnConflictExpr - represents an ON CONFLICT DO ... expression
+//
+// The optimizer requires a list of inference elements, and optionally a WHERE
+// clause to infer a unique index.  The unique index (or, occasionally,
+// indexes) inferred are used to arbitrate whether or not the alternative ON
+// CONFLICT path is taken.
+type OnConflictExpr struct {
+	Action OnConflictAction // DO NOTHING or UPDATE?
+	// Arbiter
+	// unique index arbiter list (of
+	// InferenceElem's)
+	ArbiterElems *List
+	ArbiterWhere Node // unique index arbiter WHERE clause
+	Constraint   Oid  // pg_constraint OID for arbiter
+	// ON CONFLICT UPDATE
+	// List of ON CONFLICT SET TargetEntrys
+	OnConflictSet   *List
+	OnConflictWhere Node  // qualifiers to restrict UPDATE to
+	ExclRelIndex    int   // RT index of 'excluded' relation
+	ExclRelTlist    *List // tlist of the EXCLUDED pseudo relation
+}
+
+func (n *OnConflictExpr) Tag() NodeTag { return T_OnConflictExpr }
@@ -0,0 +1,49 @@
+// Code generated by pgsema-gen. DO NOT EDIT.
+// Source: PostgreSQL nodes/value.h
+
+package nodes
+
+// The node types Integer, Float, String, and BitString are used to represent
+// literals in the lexer and are also used to pass constants around in the
+// parser.  One difference between these node types and, say, a plain int or
+// char * is that the nodes can be put into a List.
+//
+// (There used to be a Value node, which encompassed all these different node types.  Hence the name of this file.)
+type Integer struct {
+	Ival int64
+}
+
+func (n *Integer) Tag() NodeTag { return T_Integer }
+
+// Float is internally represented as string.  Using T_Float as the node type
+// simply indicates that the contents of the string look like a valid numeric
+// literal.  The value might end up being converted to NUMERIC, so we can't
+// store it internally as a C double, since that could lose precision.  Since
+// these nodes are generally only used in the parsing process, not for runtime
+// data, it's better to use the more general representation.
+//
+// Note that an integer-looking string will get lexed as T_Float if the value
+// is too large to fit in an 'int'.
+type Float struct {
+	Fval string
+}
+
+func (n *Float) Tag() NodeTag { return T_Float }
+
+type Boolean struct {
+	Boolval bool
+}
+
+func (n *Boolean) Tag() NodeTag { return T_Boolean }
+
+type String struct {
+	Sval string
+}
+
+func (n *String) Tag() NodeTag { return T_String }
+
+type BitString struct {
+	Bsval string
+}
+
+func (n *BitString) Tag() NodeTag { return T_BitString }
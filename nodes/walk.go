@@ -0,0 +1,65 @@
+package nodes
+
+import "reflect"
+
+// Walk traverses the tree rooted at node in depth-first order, calling fn
+// for every node it encounters. If fn returns false, the children of that
+// node are not visited.
+//
+// Lists are traversed transparently: fn is called for each list element but
+// not for the *List itself. This mirrors PostgreSQL's
+// raw_expression_tree_walker, except that every field of every node type is
+// visited, so utility statements are walked as well as expressions.
+func Walk(node Node, fn func(Node) bool) {
+	if node == nil {
+		return
+	}
+	walkValue(reflect.ValueOf(node), fn)
+}
+
+var nodeInterface = reflect.TypeOf((*Node)(nil)).Elem()
+
+// walkValue visits v, which may be a Node, a pointer, a struct or a slice.
+func walkValue(v reflect.Value, fn func(Node) bool) {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		walkValue(v.Elem(), fn)
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		if l, ok := v.Interface().(*List); ok {
+			for _, item := range l.Items {
+				if item != nil {
+					walkValue(reflect.ValueOf(item), fn)
+				}
+			}
+			return
+		}
+		if v.Type().Implements(nodeInterface) {
+			if !fn(v.Interface().(Node)) {
+				return
+			}
+		}
+		walkFields(v.Elem(), fn)
+	case reflect.Struct:
+		walkFields(v, fn)
+	}
+}
+
+// walkFields visits the node-bearing fields of struct value v.
+func walkFields(v reflect.Value, fn func(Node) bool) {
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		switch f.Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Struct:
+			walkValue(f, fn)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package nodes
+
+import "testing"
+
+func TestWalk(t *testing.T) {
+	// SELECT a FROM t WHERE b = (SELECT c FROM u)
+	stmt := &SelectStmt{
+		TargetList: &List{Items: []Node{
+			&ResTarget{Val: &ColumnRef{Fields: &List{Items: []Node{&String{Sval: "a"}}}}},
+		}},
+		FromClause: &List{Items: []Node{&RangeVar{Relname: "t"}}},
+		WhereClause: &A_Expr{
+			Kind:  AEXPR_OP,
+			Name:  &List{Items: []Node{&String{Sval: "="}}},
+			Lexpr: &ColumnRef{Fields: &List{Items: []Node{&String{Sval: "b"}}}},
+			Rexpr: &SubLink{
+				SubLinkType: EXPR_SUBLINK,
+				Subselect: &SelectStmt{
+					FromClause: &List{Items: []Node{&RangeVar{Relname: "u"}}},
+				},
+			},
+		},
+	}
+
+	var rels []string
+	Walk(stmt, func(n Node) bool {
+		if _, ok := n.(*List); ok {
+			t.Errorf("Walk should not visit *List")
+		}
+		if rv, ok := n.(*RangeVar); ok {
+			rels = append(rels, rv.Relname)
+		}
+		return true
+	})
+	if len(rels) != 2 || rels[0] != "t" || rels[1] != "u" {
+		t.Errorf("expected [t u], got %v", rels)
+	}
+
+	// Returning false prunes the subtree.
+	rels = nil
+	Walk(stmt, func(n Node) bool {
+		if _, ok := n.(*SubLink); ok {
+			return false
+		}
+		if rv, ok := n.(*RangeVar); ok {
+			rels = append(rels, rv.Relname)
+		}
+		return true
+	})
+	if len(rels) != 1 || rels[0] != "t" {
+		t.Errorf("expected [t], got %v", rels)
+	}
+}
+
+func TestWalkEmbeddedStruct(t *testing.T) {
+	stmt := &CreateForeignTableStmt{
+		Base: CreateStmt{Relation: &RangeVar{Relname: "ft"}},
+	}
+	found := false
+	Walk(stmt, func(n Node) bool {
+		if rv, ok := n.(*RangeVar); ok && rv.Relname == "ft" {
+			found = true
+		}
+		return true
+	})
+	if !found {
+		t.Error("expected Walk to descend into CreateForeignTableStmt.Base")
+	}
+}
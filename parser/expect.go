@@ -0,0 +1,236 @@
+package parser
+
+// Expected is what the grammar accepts after a prefix of a statement.
+type Expected struct {
+	// Keywords are the keywords that can come next, leaving out those
+	// that would only be taken as a name, as an unreserved keyword after
+	// FROM would be.
+	Keywords []*Keyword
+	// Ident reports whether a name can come next.
+	Ident bool
+}
+
+// ExpectedAfter returns what the grammar accepts after the tokens of
+// prefix, which need not end at the end of a statement. It runs the
+// parser's tables over the tokens without building a tree, so the checks
+// the grammar's actions make are not made. If prefix cannot be scanned,
+// or no statement starts with it, ExpectedAfter returns a *ParseError.
+func ExpectedAfter(prefix string) (*Expected, error) {
+	pl := parserLexer{version: DefaultVersion}
+	lexer := NewLexer(prefix)
+	var toks []Token
+	var types []int
+	for {
+		tok := lexer.NextToken()
+		if lexer.Err != nil {
+			return nil, &ParseError{Message: lexer.Err.Error(), Position: tok.Loc}
+		}
+		if tok.Type == lex_EOF {
+			break
+		}
+		tok = pl.versionToken(tok)
+		toks = append(toks, tok)
+		types = append(types, pl.mapTokenType(tok))
+	}
+
+	// Shift all but the last token, which may be replaced depending on
+	// the one after it.
+	stack := []int{0}
+	for i := 0; i+1 < len(types); i++ {
+		typ := types[i]
+		if pl.needsLookahead(typ) {
+			typ = pl.applyLookahead(typ, types[i+1])
+		}
+		var ok bool
+		if stack, ok = lrShift(stack, tokenNumber(typ)); !ok {
+			return nil, &ParseError{Message: "syntax error", Position: toks[i].Loc}
+		}
+	}
+	after := func(next int) ([]int, bool) { return stack, true }
+	if n := len(types); n > 0 {
+		last, before := types[n-1], stack
+		shifted := map[int][]int{}
+		after = func(next int) ([]int, bool) {
+			typ := last
+			if pl.needsLookahead(typ) {
+				typ = pl.applyLookahead(typ, next)
+			}
+			s, ok := shifted[typ]
+			if !ok {
+				s, _ = lrShift(before, tokenNumber(typ))
+				shifted[typ] = s
+			}
+			return s, s != nil
+		}
+		if _, ok := after(0); !ok && !pl.needsLookahead(last) {
+			return nil, &ParseError{Message: "syntax error", Position: toks[n-1].Loc}
+		}
+	}
+
+	// accepts returns the stack after typ, or the token it becomes before
+	// certain others, comes next.
+	accepts := func(typ int) ([]int, bool) {
+		s, ok := after(typ)
+		if !ok {
+			return nil, false
+		}
+		for _, t := range []int{typ, lookaheadVariant(typ)} {
+			if t == 0 {
+				continue
+			}
+			if next, ok := lrShift(s, tokenNumber(t)); ok {
+				return next, true
+			}
+		}
+		return nil, false
+	}
+
+	exp := &Expected{}
+	identStack, identOK := accepts(IDENT)
+	exp.Ident = identOK
+	var identProbes [][]int
+	if identOK {
+		identProbes = probe(identStack)
+	}
+	for i := range Keywords {
+		kw := &Keywords[i]
+		s, ok := accepts(kw.Token)
+		if !ok {
+			continue
+		}
+		if identOK && sameProbes(probe(s), identProbes) {
+			// The keyword is taken as a name here.
+			continue
+		}
+		exp.Keywords = append(exp.Keywords, kw)
+	}
+	return exp, nil
+}
+
+// probeTokens are the tokens after which a keyword is compared with an
+// identifier, to tell whether the grammar takes it as a name: only then
+// do the two accept the same of these tokens, reaching the same states.
+var probeTokens = []int{'.', '(', ')', ',', ';', '=', Op, IDENT, SCONST, ICONST}
+
+// probe returns the stack after each of probeTokens follows stack, or nil
+// where one cannot.
+func probe(stack []int) [][]int {
+	out := make([][]int, len(probeTokens))
+	for i, tok := range probeTokens {
+		out[i], _ = lrShift(stack, tokenNumber(tok))
+	}
+	return out
+}
+
+func sameProbes(a, b [][]int) bool {
+	for i := range a {
+		if (a[i] == nil) != (b[i] == nil) || !sameStack(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// lookaheadVariant returns the token that typ becomes before certain
+// other tokens, such as NOT_LA for NOT, or 0 if there is none.
+func lookaheadVariant(typ int) int {
+	switch typ {
+	case NOT:
+		return NOT_LA
+	case WITH:
+		return WITH_LA
+	case NULLS_P:
+		return NULLS_LA
+	case WITHOUT:
+		return WITHOUT_LA
+	case FORMAT:
+		return FORMAT_LA
+	}
+	return 0
+}
+
+// lrShift runs the parser's automaton from the states of stack on the
+// token numbered tok, making reductions until it shifts tok. It returns
+// the new stack, leaving stack unchanged, or false if tok is a syntax
+// error there.
+func lrShift(stack []int, tok int) ([]int, bool) {
+	s := append([]int(nil), stack...)
+	for {
+		state := s[len(s)-1]
+		if n := int(pgPact[state]); n > pgFlag {
+			n += tok
+			if n >= 0 && n < pgLast {
+				if next := int(pgAct[n]); int(pgChk[next]) == tok {
+					return append(s, next), true
+				}
+			}
+		}
+		n := int(pgDef[state])
+		if n == -2 {
+			xi := 0
+			for pgExca[xi] != -1 || int(pgExca[xi+1]) != state {
+				xi += 2
+			}
+			for xi += 2; pgExca[xi] >= 0 && int(pgExca[xi]) != tok; xi += 2 {
+			}
+			n = int(pgExca[xi+1])
+		}
+		if n <= 0 {
+			// An error, or acceptance of the input at its end.
+			return nil, false
+		}
+		s = lrReduce(s, n)
+	}
+}
+
+// lrReduce reduces the top of stack s by production n and goes to the
+// state that follows.
+func lrReduce(s []int, n int) []int {
+	s = s[:len(s)-int(pgR2[n])]
+	lhs := int(pgR1[n])
+	g := int(pgPgo[lhs])
+	state := int(pgAct[g])
+	if j := g + s[len(s)-1] + 1; j < pgLast {
+		if next := int(pgAct[j]); int(pgChk[next]) == -lhs {
+			state = next
+		}
+	}
+	return append(s, state)
+}
+
+func sameStack(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenNumber translates a token type into the parser's internal token
+// number, as pglex1 does.
+func tokenNumber(typ int) int {
+	tok := 0
+	switch {
+	case typ <= 0:
+		tok = int(pgTok1[0])
+	case typ < len(pgTok1):
+		tok = int(pgTok1[typ])
+	case typ >= pgPrivate && typ < pgPrivate+len(pgTok2):
+		tok = int(pgTok2[typ-pgPrivate])
+	default:
+		for i := 0; i < len(pgTok3); i += 2 {
+			if int(pgTok3[i]) == typ {
+				tok = int(pgTok3[i+1])
+				break
+			}
+		}
+	}
+	if tok == 0 {
+		tok = int(pgTok2[1]) // unknown char
+	}
+	return tok
+}
@@ -0,0 +1,51 @@
+package parser
+
+import "testing"
+
+func TestExpectedAfter(t *testing.T) {
+	tests := []struct {
+		prefix  string
+		ident   bool
+		want    []string
+		notWant []string
+	}{
+		{"", false, []string{"select", "create", "with"}, []string{"from"}},
+		{"SELECT a FROM t ", true, []string{"where", "join", "as", "order"}, []string{"action", "select"}},
+		{"SELECT a FROM t WHERE a NOT ", false, []string{"between", "in", "like"}, []string{"null"}},
+		{"SELECT a FROM t WHERE a IS NOT ", false, []string{"null", "distinct", "true"}, []string{"between"}},
+		{"SELECT * FROM t ORDER BY a NULLS ", false, []string{"first", "last"}, nil},
+		{"SELECT 1; CREATE ", false, []string{"table", "index"}, []string{"select"}},
+		{"SELECT a AS ", true, nil, []string{"from", "action"}},
+	}
+	for _, tt := range tests {
+		exp, err := ExpectedAfter(tt.prefix)
+		if err != nil {
+			t.Errorf("ExpectedAfter(%q): %v", tt.prefix, err)
+			continue
+		}
+		if exp.Ident != tt.ident {
+			t.Errorf("ExpectedAfter(%q).Ident = %v, want %v", tt.prefix, exp.Ident, tt.ident)
+		}
+		got := map[string]bool{}
+		for _, kw := range exp.Keywords {
+			got[kw.Name] = true
+		}
+		for _, w := range tt.want {
+			if !got[w] {
+				t.Errorf("ExpectedAfter(%q) lacks %s", tt.prefix, w)
+			}
+		}
+		for _, w := range tt.notWant {
+			if got[w] {
+				t.Errorf("ExpectedAfter(%q) has %s", tt.prefix, w)
+			}
+		}
+	}
+
+	if _, err := ExpectedAfter("SELECT FROM FROM "); err == nil {
+		t.Error("ExpectedAfter should fail after a syntax error")
+	}
+	if _, err := ExpectedAfter("SELECT 'abc"); err == nil {
+		t.Error("ExpectedAfter should fail on an unterminated string")
+	}
+}
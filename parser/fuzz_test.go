@@ -0,0 +1,98 @@
+package parser_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+	"github.com/pgplex/pgparser/parser/pgregress"
+)
+
+const seedsPerFile = 10
+
+// addCorpus seeds f with statements of the regression tests: the first
+// few of each file, which keeps the seed corpus small enough to run with
+// the other tests.
+func addCorpus(f *testing.F) {
+	files, err := filepath.Glob("pgregress/testdata/sql/*.sql")
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			f.Fatal(err)
+		}
+		stmts := pgregress.ExtractStatements(filepath.Base(file), content)
+		if len(stmts) > seedsPerFile {
+			stmts = stmts[:seedsPerFile]
+		}
+		for _, stmt := range stmts {
+			f.Add(stmt.SQL)
+		}
+	}
+	f.Add("SELECT $1a; SELECT 1")
+	f.Add("SELECT U&'\\0041' UESCAPE '!'")
+	f.Add("/* unterminated")
+	f.Add("")
+}
+
+func FuzzLexer(f *testing.F) {
+	addCorpus(f)
+	f.Fuzz(func(t *testing.T, input string) {
+		lexer := parser.NewLexer(input)
+		last := 0
+		for n := 0; ; n++ {
+			if n > len(input)+1 {
+				t.Fatalf("lexer returned more tokens than there are bytes")
+			}
+			tok := lexer.NextToken()
+			if tok.Loc < last || tok.Loc > len(input) {
+				t.Fatalf("token %d at %d, after one at %d in %d bytes", n, tok.Loc, last, len(input))
+			}
+			last = tok.Loc
+			if lexer.Err != nil || tok.Type == 0 {
+				break
+			}
+		}
+
+		toks, _ := parser.Scan(input)
+		for _, tok := range toks {
+			if tok.Start < 0 || tok.End < tok.Start || tok.End > len(input) {
+				t.Fatalf("Scan token %+v out of range of %d bytes", tok, len(input))
+			}
+		}
+	})
+}
+
+func FuzzParse(f *testing.F) {
+	addCorpus(f)
+	f.Fuzz(func(t *testing.T, input string) {
+		raws, err := parser.ParseRaw(input)
+		if err != nil {
+			if strings.HasPrefix(err.Error(), "internal parser error") {
+				t.Fatal(err)
+			}
+			return
+		}
+		for _, raw := range raws {
+			if raw.StmtLocation < 0 || raw.StmtLen < 0 || int(raw.StmtLocation+raw.StmtLen) > len(input) {
+				t.Fatalf("statement span %d+%d out of range of %d bytes", raw.StmtLocation, raw.StmtLen, len(input))
+			}
+			nodes.NodeToString(raw.Stmt)
+			if out := nodes.NodeToJSON(raw.Stmt); !json.Valid([]byte(out)) {
+				t.Fatalf("NodeToJSON produced invalid JSON: %s", out)
+			}
+			if !nodes.Equal(raw.Stmt, raw.Stmt) {
+				t.Fatalf("statement is not Equal to itself")
+			}
+			nodes.Fingerprint(raw.Stmt)
+		}
+		parser.Normalize(input)
+		parser.ExpectedAfter(input)
+	})
+}
@@ -9,6 +9,8 @@ package parser
 
 import (
 	"fmt"
+	"math"
+	"strings"
 	"github.com/pgplex/pgparser/nodes"
 )
 
@@ -31,6 +33,7 @@ import (
 	grpclause  *GroupClause    // for GROUP BY clause
 	keyaction  *KeyAction      // for FK key_action
 	keyactions *KeyActions     // for FK key_actions
+	loc       int              // token location, set by the lexer for every token
 }
 
 // Token types from the lexer
@@ -111,7 +114,7 @@ import (
 %token <str> UESCAPE UNBOUNDED UNCONDITIONAL UNCOMMITTED UNENCRYPTED UNION UNIQUE UNKNOWN
 %token <str> UNLISTEN UNLOGGED UNTIL UPDATE USER USING
 %token <str> VACUUM VALID VALIDATE VALIDATOR VALUE_P VALUES VARCHAR VARIADIC VARYING
-%token <str> VERBOSE VERSION_P VIEW VIEWS VOLATILE
+%token <str> VERBOSE VERSION_P VIEW VIEWS VIRTUAL VOLATILE
 %token <str> WHEN WHERE WHITESPACE_P WINDOW WITH WITHIN WITHOUT WORK WRAPPER WRITE
 %token <str> XML_P XMLATTRIBUTES XMLCONCAT XMLELEMENT XMLEXISTS XMLFOREST XMLNAMESPACES
 %token <str> XMLPARSE XMLPI XMLROOT XMLSERIALIZE XMLTABLE
@@ -129,7 +132,7 @@ import (
 %type <node>  target_el where_clause where_or_current_clause
 %type <list>  func_name
 %type <list>  from_clause
-%type <node>  table_ref relation_expr joined_table join_qual
+%type <node>  table_ref relation_expr extended_relation_expr joined_table join_qual
 %type <node>  func_table tablesample_clause
 %type <boolean>  opt_ordinality
 %type <node>  opt_repeatable_clause
@@ -144,9 +147,9 @@ import (
 %type <list>  extract_list overlay_list position_list substr_list trim_list
 %type <str>   extract_arg unicode_normal_form
 %type <node>  sortby indirection_el func_arg_expr
-%type <str>   name ColId ColLabel attr_name
+%type <str>   name ColId ColLabel BareColLabel attr_name
 %type <node>   opt_alias_clause alias_clause func_alias_clause
-%type <str>   unreserved_keyword col_name_keyword type_func_name_keyword reserved_keyword
+%type <str>   unreserved_keyword col_name_keyword type_func_name_keyword reserved_keyword bare_label_keyword
 %type <list>  opt_name_list name_list any_name qualified_name
 %type <ival>  opt_asc_desc
 %type <ival>  Iconst
@@ -189,7 +192,9 @@ import (
 %type <list>      implicit_row
 %type <node>  InsertStmt insert_rest
 %type <node>  insert_target
-%type <list>  insert_column_list returning_clause
+%type <list>  insert_column_list returning_with_clause returning_options
+%type <node>  returning_clause returning_option
+%type <ival>  returning_option_kind opt_virtual_or_stored
 %type <node>  insert_column_item
 %type <node>  opt_on_conflict
 %type <node>  values_clause
@@ -226,7 +231,7 @@ import (
 %type <node>  alter_table_cmd
 %type <ival>  opt_drop_behavior
 %type <ival>  object_type_any_name
-%type <list>  any_name_list
+%type <list>  any_name_list type_name_list
 %type <node>  IndexStmt
 %type <boolean>  opt_unique opt_concurrently
 %type <str>   opt_single_name access_method_clause
@@ -317,7 +322,6 @@ import (
 %type <node>  opt_granted_by
 %type <list>  grant_role_opt_list
 %type <node>  grant_role_opt
-%type <node>  grant_role_opt_value
 %type <ival>  add_drop
 %type <node>  CreatedbStmt AlterDatabaseStmt AlterDatabaseSetStmt DropdbStmt AlterSystemStmt
 %type <list>  createdb_opt_list createdb_opt_items
@@ -529,8 +533,13 @@ stmtblock:
 stmtmulti:
 	stmtmulti ';' stmt
 		{
+			if $1 != nil {
+				/* update length of previous stmt */
+				updateRawStmtEnd(pglex, $<loc>2)
+			}
 			if $3 != nil {
 				$$ = appendList($1, $3)
+				makeRawStmt(pglex, $3, $<loc>2+1)
 			} else {
 				$$ = $1
 			}
@@ -539,6 +548,7 @@ stmtmulti:
 		{
 			if $1 != nil {
 				$$ = makeList($1)
+				makeRawStmt(pglex, $1, firstTokenLoc(pglex))
 			} else {
 				$$ = nil
 			}
@@ -1070,7 +1080,7 @@ InsertStmt:
 			if $6 != nil {
 				n.OnConflictClause = $6.(*nodes.OnConflictClause)
 			}
-			n.ReturningList = $7
+			n.ReturningList, n.ReturningOptions = returningLists($7)
 			if $1 != nil {
 				n.WithClause = $1.(*nodes.WithClause)
 			}
@@ -1081,11 +1091,11 @@ InsertStmt:
 insert_target:
 	qualified_name
 		{
-			$$ = makeRangeVar($1)
+			$$ = makeRangeVar($1, $<loc>1)
 		}
 	| qualified_name AS ColId
 		{
-			rv := makeRangeVar($1)
+			rv := makeRangeVar($1, $<loc>1)
 			rv.(*nodes.RangeVar).Alias = &nodes.Alias{Aliasname: $3}
 			$$ = rv
 		}
@@ -1139,6 +1149,7 @@ insert_column_item:
 			$$ = &nodes.ResTarget{
 				Name:        $1,
 				Indirection: $2,
+				Location:    nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -1148,7 +1159,7 @@ opt_on_conflict:
 		{
 			$$ = &nodes.OnConflictClause{
 				Action:   ONCONFLICT_NOTHING,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| ON CONFLICT DO UPDATE SET set_clause_list where_clause
@@ -1157,7 +1168,7 @@ opt_on_conflict:
 				Action:      ONCONFLICT_UPDATE,
 				TargetList:  $6,
 				WhereClause: $7,
-				Location:    -1,
+				Location:    nodes.ParseLoc($<loc>1),
 			}
 		}
 	| ON CONFLICT '(' index_params ')' DO NOTHING
@@ -1166,9 +1177,9 @@ opt_on_conflict:
 				Action:   ONCONFLICT_NOTHING,
 				Infer: &nodes.InferClause{
 					IndexElems: $4,
-					Location:   -1,
+					Location:   nodes.ParseLoc($<loc>3),
 				},
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| ON CONFLICT '(' index_params ')' DO UPDATE SET set_clause_list where_clause
@@ -1177,11 +1188,11 @@ opt_on_conflict:
 				Action:      ONCONFLICT_UPDATE,
 				Infer: &nodes.InferClause{
 					IndexElems: $4,
-					Location:   -1,
+					Location:   nodes.ParseLoc($<loc>3),
 				},
 				TargetList:  $9,
 				WhereClause: $10,
-				Location:    -1,
+				Location:    nodes.ParseLoc($<loc>1),
 			}
 		}
 	| ON CONFLICT '(' index_params ')' WHERE a_expr DO NOTHING
@@ -1191,9 +1202,9 @@ opt_on_conflict:
 				Infer: &nodes.InferClause{
 					IndexElems:  $4,
 					WhereClause: $7,
-					Location:    -1,
+					Location:    nodes.ParseLoc($<loc>3),
 				},
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| ON CONFLICT '(' index_params ')' WHERE a_expr DO UPDATE SET set_clause_list where_clause
@@ -1203,11 +1214,11 @@ opt_on_conflict:
 				Infer: &nodes.InferClause{
 					IndexElems:  $4,
 					WhereClause: $7,
-					Location:    -1,
+					Location:    nodes.ParseLoc($<loc>3),
 				},
 				TargetList:  $11,
 				WhereClause: $12,
-				Location:    -1,
+				Location:    nodes.ParseLoc($<loc>1),
 			}
 		}
 	| ON CONFLICT ON CONSTRAINT name DO NOTHING
@@ -1216,9 +1227,9 @@ opt_on_conflict:
 				Action:   ONCONFLICT_NOTHING,
 				Infer: &nodes.InferClause{
 					Conname:  $5,
-					Location: -1,
+					Location: nodes.ParseLoc($<loc>3),
 				},
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| ON CONFLICT ON CONSTRAINT name DO UPDATE SET set_clause_list where_clause
@@ -1227,11 +1238,11 @@ opt_on_conflict:
 				Action:      ONCONFLICT_UPDATE,
 				Infer: &nodes.InferClause{
 					Conname:  $5,
-					Location: -1,
+					Location: nodes.ParseLoc($<loc>3),
 				},
 				TargetList:  $9,
 				WhereClause: $10,
-				Location:    -1,
+				Location:    nodes.ParseLoc($<loc>1),
 			}
 		}
 	| /* EMPTY */
@@ -1241,10 +1252,43 @@ opt_on_conflict:
 	;
 
 returning_clause:
-	RETURNING target_list { $$ = $2 }
+	RETURNING returning_with_clause target_list
+		{
+			$$ = &nodes.ReturningClause{Options: $2, Exprs: $3}
+		}
 	| /* EMPTY */ { $$ = nil }
 	;
 
+returning_with_clause:
+	WITH '(' returning_options ')'
+		{
+			requireVersion(pglex, PG18, "RETURNING WITH", $<loc>1)
+			$$ = $3
+		}
+	| /* EMPTY */ { $$ = nil }
+	;
+
+returning_options:
+	returning_option { $$ = makeList($1) }
+	| returning_options ',' returning_option { $$ = appendList($1, $3) }
+	;
+
+returning_option:
+	returning_option_kind AS ColId
+		{
+			$$ = &nodes.ReturningOption{
+				Option:   nodes.ReturningOptionKind($1),
+				Value:    $3,
+				Location: nodes.ParseLoc($<loc>1),
+			}
+		}
+	;
+
+returning_option_kind:
+	OLD { $$ = int64(nodes.RETURNING_OPTION_OLD) }
+	| NEW { $$ = int64(nodes.RETURNING_OPTION_NEW) }
+	;
+
 set_clause_list:
 	set_clause
 		{
@@ -1297,6 +1341,7 @@ set_target:
 			$$ = &nodes.ResTarget{
 				Name:        $1,
 				Indirection: $2,
+				Location:    nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -1317,16 +1362,17 @@ set_target_list:
 UpdateStmt:
 	opt_with_clause UPDATE relation_expr_opt_alias SET set_clause_list from_clause where_or_current_clause returning_clause
 		{
-			$$ = &nodes.UpdateStmt{
-				Relation:      $3.(*nodes.RangeVar),
-				TargetList:    $5,
-				FromClause:    $6,
-				WhereClause:   $7,
-				ReturningList: $8,
+			n := &nodes.UpdateStmt{
+				Relation:    $3.(*nodes.RangeVar),
+				TargetList:  $5,
+				FromClause:  $6,
+				WhereClause: $7,
 			}
+			n.ReturningList, n.ReturningOptions = returningLists($8)
 			if $1 != nil {
-				$$.(*nodes.UpdateStmt).WithClause = $1.(*nodes.WithClause)
+				n.WithClause = $1.(*nodes.WithClause)
 			}
+			$$ = n
 		}
 	;
 
@@ -1339,15 +1385,16 @@ UpdateStmt:
 DeleteStmt:
 	opt_with_clause DELETE_P FROM relation_expr_opt_alias using_clause where_or_current_clause returning_clause
 		{
-			$$ = &nodes.DeleteStmt{
-				Relation:      $4.(*nodes.RangeVar),
-				UsingClause:   $5,
-				WhereClause:   $6,
-				ReturningList: $7,
+			n := &nodes.DeleteStmt{
+				Relation:    $4.(*nodes.RangeVar),
+				UsingClause: $5,
+				WhereClause: $6,
 			}
+			n.ReturningList, n.ReturningOptions = returningLists($7)
 			if $1 != nil {
-				$$.(*nodes.DeleteStmt).WithClause = $1.(*nodes.WithClause)
+				n.WithClause = $1.(*nodes.WithClause)
 			}
+			$$ = n
 		}
 	;
 
@@ -1380,7 +1427,7 @@ relation_expr_opt_alias:
 CreateStmt:
 	CREATE OptTemp TABLE qualified_name '(' OptTableElementList ')' OptInherit OptPartitionSpec OptAccessMethod OptWith OnCommitOption OptTableSpace
 		{
-			rv := makeRangeVar($4)
+			rv := makeRangeVar($4, $<loc>4)
 			rv.(*nodes.RangeVar).Relpersistence = relpersistenceForTemp($2)
 			$$ = &nodes.CreateStmt{
 				Relation:       rv.(*nodes.RangeVar),
@@ -1389,13 +1436,13 @@ CreateStmt:
 				Partspec:       $9,
 				AccessMethod:   $10,
 				Options:        $11,
-				OnCommit:       nodes.OnCommitAction($12),
+				Oncommit:       nodes.OnCommitAction($12),
 				Tablespacename: $13,
 			}
 		}
 	| CREATE OptTemp TABLE IF_P NOT EXISTS qualified_name '(' OptTableElementList ')' OptInherit OptPartitionSpec OptAccessMethod OptWith OnCommitOption OptTableSpace
 		{
-			rv := makeRangeVar($7)
+			rv := makeRangeVar($7, $<loc>7)
 			rv.(*nodes.RangeVar).Relpersistence = relpersistenceForTemp($2)
 			$$ = &nodes.CreateStmt{
 				Relation:       rv.(*nodes.RangeVar),
@@ -1404,16 +1451,16 @@ CreateStmt:
 				Partspec:       $12,
 				AccessMethod:   $13,
 				Options:        $14,
-				OnCommit:       nodes.OnCommitAction($15),
+				Oncommit:       nodes.OnCommitAction($15),
 				Tablespacename: $16,
 				IfNotExists:    true,
 			}
 		}
 	| CREATE OptTemp TABLE qualified_name PARTITION OF qualified_name ForValues OptPartitionSpec OptAccessMethod OptWith OnCommitOption OptTableSpace
 		{
-			rv := makeRangeVar($4)
+			rv := makeRangeVar($4, $<loc>4)
 			rv.(*nodes.RangeVar).Relpersistence = relpersistenceForTemp($2)
-			inh := makeRangeVar($7)
+			inh := makeRangeVar($7, $<loc>7)
 			$$ = &nodes.CreateStmt{
 				Relation:       rv.(*nodes.RangeVar),
 				InhRelations:   makeList(inh),
@@ -1421,15 +1468,15 @@ CreateStmt:
 				Partspec:       $9,
 				AccessMethod:   $10,
 				Options:        $11,
-				OnCommit:       nodes.OnCommitAction($12),
+				Oncommit:       nodes.OnCommitAction($12),
 				Tablespacename: $13,
 			}
 		}
 	| CREATE OptTemp TABLE IF_P NOT EXISTS qualified_name PARTITION OF qualified_name ForValues OptPartitionSpec OptAccessMethod OptWith OnCommitOption OptTableSpace
 		{
-			rv := makeRangeVar($7)
+			rv := makeRangeVar($7, $<loc>7)
 			rv.(*nodes.RangeVar).Relpersistence = relpersistenceForTemp($2)
-			inh := makeRangeVar($10)
+			inh := makeRangeVar($10, $<loc>10)
 			$$ = &nodes.CreateStmt{
 				Relation:       rv.(*nodes.RangeVar),
 				InhRelations:   makeList(inh),
@@ -1437,16 +1484,16 @@ CreateStmt:
 				Partspec:       $12,
 				AccessMethod:   $13,
 				Options:        $14,
-				OnCommit:       nodes.OnCommitAction($15),
+				Oncommit:       nodes.OnCommitAction($15),
 				Tablespacename: $16,
 				IfNotExists:    true,
 			}
 		}
 	| CREATE OptTemp TABLE qualified_name PARTITION OF qualified_name '(' TypedTableElementList ')' ForValues OptPartitionSpec OptAccessMethod OptWith OnCommitOption OptTableSpace
 		{
-			rv := makeRangeVar($4)
+			rv := makeRangeVar($4, $<loc>4)
 			rv.(*nodes.RangeVar).Relpersistence = relpersistenceForTemp($2)
-			inh := makeRangeVar($7)
+			inh := makeRangeVar($7, $<loc>7)
 			$$ = &nodes.CreateStmt{
 				Relation:       rv.(*nodes.RangeVar),
 				TableElts:      $9,
@@ -1455,15 +1502,15 @@ CreateStmt:
 				Partspec:       $12,
 				AccessMethod:   $13,
 				Options:        $14,
-				OnCommit:       nodes.OnCommitAction($15),
+				Oncommit:       nodes.OnCommitAction($15),
 				Tablespacename: $16,
 			}
 		}
 	| CREATE OptTemp TABLE IF_P NOT EXISTS qualified_name PARTITION OF qualified_name '(' TypedTableElementList ')' ForValues OptPartitionSpec OptAccessMethod OptWith OnCommitOption OptTableSpace
 		{
-			rv := makeRangeVar($7)
+			rv := makeRangeVar($7, $<loc>7)
 			rv.(*nodes.RangeVar).Relpersistence = relpersistenceForTemp($2)
-			inh := makeRangeVar($10)
+			inh := makeRangeVar($10, $<loc>10)
 			$$ = &nodes.CreateStmt{
 				Relation:       rv.(*nodes.RangeVar),
 				TableElts:      $12,
@@ -1472,7 +1519,7 @@ CreateStmt:
 				Partspec:       $15,
 				AccessMethod:   $16,
 				Options:        $17,
-				OnCommit:       nodes.OnCommitAction($18),
+				Oncommit:       nodes.OnCommitAction($18),
 				Tablespacename: $19,
 				IfNotExists:    true,
 			}
@@ -1480,31 +1527,31 @@ CreateStmt:
 	/* CREATE TABLE OF typename */
 	| CREATE OptTemp TABLE qualified_name OF any_name OptTypedTableElementList OptAccessMethod OptWith OnCommitOption OptTableSpace
 		{
-			rv := makeRangeVar($4)
+			rv := makeRangeVar($4, $<loc>4)
 			rv.(*nodes.RangeVar).Relpersistence = relpersistenceForTemp($2)
-			tn := makeTypeNameFromNameList($6).(*nodes.TypeName)
+			tn := typeNameAt(makeTypeNameFromNameList($6).(*nodes.TypeName), $<loc>6)
 			$$ = &nodes.CreateStmt{
 				Relation:       rv.(*nodes.RangeVar),
 				TableElts:      $7,
 				OfTypename:     tn,
 				AccessMethod:   $8,
 				Options:        $9,
-				OnCommit:       nodes.OnCommitAction($10),
+				Oncommit:       nodes.OnCommitAction($10),
 				Tablespacename: $11,
 			}
 		}
 	| CREATE OptTemp TABLE IF_P NOT EXISTS qualified_name OF any_name OptTypedTableElementList OptAccessMethod OptWith OnCommitOption OptTableSpace
 		{
-			rv := makeRangeVar($7)
+			rv := makeRangeVar($7, $<loc>7)
 			rv.(*nodes.RangeVar).Relpersistence = relpersistenceForTemp($2)
-			tn := makeTypeNameFromNameList($9).(*nodes.TypeName)
+			tn := typeNameAt(makeTypeNameFromNameList($9).(*nodes.TypeName), $<loc>9)
 			$$ = &nodes.CreateStmt{
 				Relation:       rv.(*nodes.RangeVar),
 				TableElts:      $10,
 				OfTypename:     tn,
 				AccessMethod:   $11,
 				Options:        $12,
-				OnCommit:       nodes.OnCommitAction($13),
+				Oncommit:       nodes.OnCommitAction($13),
 				Tablespacename: $14,
 				IfNotExists:    true,
 			}
@@ -1537,7 +1584,6 @@ OptAccessMethod:
 
 OptWith:
 	WITH reloptions				{ $$ = $2 }
-	| WITH OIDS					{ $$ = nil /* deprecated */ }
 	| WITHOUT OIDS				{ $$ = nil }
 	| /* EMPTY */				{ $$ = nil }
 	;
@@ -1558,9 +1604,9 @@ PartitionSpec_rule:
 	PARTITION BY ColId '(' part_params ')'
 		{
 			$$ = &nodes.PartitionSpec{
-				Strategy:   parsePartitionStrategy($3),
+				Strategy:   parsePartitionStrategy(pglex, $3),
 				PartParams: $5,
-				Location:   -1,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -1579,7 +1625,7 @@ part_elem:
 				Name:      $1,
 				Collation: $2,
 				Opclass:   $3,
-				Location:  -1,
+				Location:  nodes.ParseLoc($<loc>1),
 			}
 		}
 	| func_expr_windowless opt_collate opt_qualified_name
@@ -1588,7 +1634,7 @@ part_elem:
 				Expr:      $1,
 				Collation: $2,
 				Opclass:   $3,
-				Location:  -1,
+				Location:  nodes.ParseLoc($<loc>1),
 			}
 		}
 	| '(' a_expr ')' opt_collate opt_qualified_name
@@ -1597,7 +1643,7 @@ part_elem:
 				Expr:      $2,
 				Collation: $4,
 				Opclass:   $5,
-				Location:  -1,
+				Location:  nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -1613,7 +1659,7 @@ ForValues:
 		{
 			$$ = &nodes.PartitionBoundSpec{
 				IsDefault: true,
-				Location:  -1,
+				Location:  nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -1625,7 +1671,7 @@ PartitionBoundSpec:
 			$$ = &nodes.PartitionBoundSpec{
 				Strategy:   'l',
 				Listdatums: $5,
-				Location:   -1,
+				Location:   nodes.ParseLoc($<loc>3),
 			}
 		}
 	/* a RANGE partition */
@@ -1635,7 +1681,7 @@ PartitionBoundSpec:
 				Strategy:    'r',
 				Lowerdatums: $5,
 				Upperdatums: $9,
-				Location:    -1,
+				Location:    nodes.ParseLoc($<loc>3),
 			}
 		}
 	/* a HASH partition */
@@ -1645,7 +1691,7 @@ PartitionBoundSpec:
 				Strategy:  'h',
 				Modulus:   -1,
 				Remainder: -1,
-				Location:  -1,
+				Location:  nodes.ParseLoc($<loc>3),
 			}
 			for _, item := range $5.Items {
 				opt := item.(*nodes.DefElem)
@@ -1674,7 +1720,7 @@ hash_partbound:
 hash_partbound_elem:
 	NonReservedWord Iconst
 		{
-			$$ = makeDefElem($1, &nodes.Integer{Ival: $2})
+			$$ = makeDefElem($1, &nodes.Integer{Ival: $2}, $<loc>1)
 		}
 	;
 
@@ -1707,7 +1753,7 @@ columnOptions:
 				Colname:     $1,
 				TypeName:    nil,
 				IsLocal:     true,
-				Location:    -1,
+				Location:    nodes.ParseLoc($<loc>1),
 			}
 			splitColQualList($2, n)
 			$$ = n
@@ -1718,7 +1764,7 @@ columnOptions:
 				Colname:     $1,
 				TypeName:    nil,
 				IsLocal:     true,
-				Location:    -1,
+				Location:    nodes.ParseLoc($<loc>1),
 			}
 			splitColQualList($4, n)
 			$$ = n
@@ -1742,13 +1788,13 @@ TableLikeClause:
 	LIKE qualified_name
 		{
 			$$ = &nodes.TableLikeClause{
-				Relation: makeRangeVar($2).(*nodes.RangeVar),
+				Relation: makeRangeVar($2, $<loc>2).(*nodes.RangeVar),
 			}
 		}
 	| LIKE qualified_name TableLikeOptionList
 		{
 			$$ = &nodes.TableLikeClause{
-				Relation: makeRangeVar($2).(*nodes.RangeVar),
+				Relation: makeRangeVar($2, $<loc>2).(*nodes.RangeVar),
 				Options:  uint32($3),
 			}
 		}
@@ -1766,16 +1812,20 @@ TableLikeOptionList:
 	;
 
 TableLikeOption:
-	ALL				{ $$ = 0xFFFFFFFF }
-	| COMMENTS		{ $$ = 1 }
-	| COMPRESSION	{ $$ = 2 }
-	| CONSTRAINTS	{ $$ = 4 }
-	| DEFAULTS		{ $$ = 8 }
-	| GENERATED		{ $$ = 16 }
-	| IDENTITY_P	{ $$ = 32 }
-	| INDEXES		{ $$ = 64 }
-	| STATISTICS	{ $$ = 128 }
-	| STORAGE		{ $$ = 256 }
+	ALL				{ $$ = int64(nodes.CREATE_TABLE_LIKE_ALL) }
+	| COMMENTS		{ $$ = int64(nodes.CREATE_TABLE_LIKE_COMMENTS) }
+	| COMPRESSION
+		{
+			requireVersion(pglex, PG14, "LIKE ... INCLUDING COMPRESSION", $<loc>1)
+			$$ = int64(nodes.CREATE_TABLE_LIKE_COMPRESSION)
+		}
+	| CONSTRAINTS	{ $$ = int64(nodes.CREATE_TABLE_LIKE_CONSTRAINTS) }
+	| DEFAULTS		{ $$ = int64(nodes.CREATE_TABLE_LIKE_DEFAULTS) }
+	| GENERATED		{ $$ = int64(nodes.CREATE_TABLE_LIKE_GENERATED) }
+	| IDENTITY_P	{ $$ = int64(nodes.CREATE_TABLE_LIKE_IDENTITY) }
+	| INDEXES		{ $$ = int64(nodes.CREATE_TABLE_LIKE_INDEXES) }
+	| STATISTICS	{ $$ = int64(nodes.CREATE_TABLE_LIKE_STATISTICS) }
+	| STORAGE		{ $$ = int64(nodes.CREATE_TABLE_LIKE_STORAGE) }
 	;
 
 columnDef:
@@ -1785,7 +1835,7 @@ columnDef:
 				Colname:     $1,
 				TypeName:    $2,
 				IsLocal:     true,
-				Location:    -1,
+				Location:    nodes.ParseLoc($<loc>1),
 			}
 			splitColQualList($3, n)
 			$$ = n
@@ -1809,6 +1859,7 @@ ColConstraint:
 		{
 			n := $3.(*nodes.Constraint)
 			n.Conname = $2
+			n.Location = nodes.ParseLoc($<loc>1)
 			$$ = n
 		}
 	| ColConstraintElem { $$ = $1 }
@@ -1816,15 +1867,16 @@ ColConstraint:
 		{
 			$$ = &nodes.CollateClause{
 				Collname: $2,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| COMPRESSION ColId
 		{
+			requireVersion(pglex, PG14, "COMPRESSION", $<loc>1)
 			/* COMPRESSION is stored directly on ColumnDef, use DefElem as carrier */
 			$$ = &nodes.DefElem{
 				Defname: "compression",
-				Arg:     &nodes.String{Str: $2},
+				Arg:     &nodes.String{Sval: $2},
 			}
 		}
 	| STORAGE ColId
@@ -1832,7 +1884,7 @@ ColConstraint:
 			/* STORAGE is stored directly on ColumnDef, use DefElem as carrier */
 			$$ = &nodes.DefElem{
 				Defname: "storage",
-				Arg:     &nodes.String{Str: $2},
+				Arg:     &nodes.String{Sval: $2},
 			}
 		}
 	| OPTIONS '(' generic_option_list ')'
@@ -1852,28 +1904,28 @@ ConstraintAttr:
 		{
 			$$ = &nodes.Constraint{
 				Contype:  nodes.CONSTR_ATTR_DEFERRABLE,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| NOT DEFERRABLE
 		{
 			$$ = &nodes.Constraint{
 				Contype:  nodes.CONSTR_ATTR_NOT_DEFERRABLE,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| INITIALLY DEFERRED
 		{
 			$$ = &nodes.Constraint{
 				Contype:  nodes.CONSTR_ATTR_DEFERRED,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| INITIALLY IMMEDIATE
 		{
 			$$ = &nodes.Constraint{
 				Contype:  nodes.CONSTR_ATTR_IMMEDIATE,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -1883,14 +1935,14 @@ ColConstraintElem:
 		{
 			$$ = &nodes.Constraint{
 				Contype:  nodes.CONSTR_NOTNULL,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| NULL_P
 		{
 			$$ = &nodes.Constraint{
 				Contype:  nodes.CONSTR_NULL,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| UNIQUE opt_unique_null_treatment opt_definition OptConsTableSpace
@@ -1900,7 +1952,7 @@ ColConstraintElem:
 				NullsNotDistinct:  $2,
 				Options:           $3,
 				Indexspace:        $4,
-				Location:          -1,
+				Location:          nodes.ParseLoc($<loc>1),
 			}
 		}
 	| PRIMARY KEY opt_definition OptConsTableSpace
@@ -1909,7 +1961,7 @@ ColConstraintElem:
 				Contype:    nodes.CONSTR_PRIMARY,
 				Options:    $3,
 				Indexspace:  $4,
-				Location:   -1,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	| CHECK '(' a_expr ')' no_inherit
@@ -1917,7 +1969,7 @@ ColConstraintElem:
 			n := &nodes.Constraint{
 				Contype:        nodes.CONSTR_CHECK,
 				RawExpr:        $3,
-				Location:       -1,
+				Location:       nodes.ParseLoc($<loc>1),
 				InitiallyValid: true,
 			}
 			n.IsNoInherit = $5
@@ -1928,25 +1980,23 @@ ColConstraintElem:
 			$$ = &nodes.Constraint{
 				Contype:  nodes.CONSTR_DEFAULT,
 				RawExpr:  $2,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
-	| REFERENCES qualified_name opt_column_list key_match key_actions ConstraintAttributeSpec
+	| REFERENCES qualified_name opt_column_list key_match key_actions
 		{
-			rv := makeRangeVar($2)
-			n := &nodes.Constraint{
+			rv := makeRangeVar($2, $<loc>2)
+			$$ = &nodes.Constraint{
 				Contype:        nodes.CONSTR_FOREIGN,
 				Pktable:        rv.(*nodes.RangeVar),
 				PkAttrs:        $3,
 				FkMatchtype:    byte($4),
-				FkUpdaction:    $5.UpdateAction.Action,
-				FkDelaction:    $5.DeleteAction.Action,
-				FkDelsetcols:   $5.DeleteAction.Cols,
-				Location:       -1,
+				FkUpdAction:    $5.UpdateAction.Action,
+				FkDelAction:    $5.DeleteAction.Action,
+				FkDelSetCols:   $5.DeleteAction.Cols,
+				Location:       nodes.ParseLoc($<loc>1),
 				InitiallyValid: true,
 			}
-			applyConstraintAttrs(n, $6)
-			$$ = n
 		}
 	| GENERATED ALWAYS AS IDENTITY_P OptParenthesizedSeqOptList
 		{
@@ -1954,7 +2004,7 @@ ColConstraintElem:
 				Contype:       nodes.CONSTR_IDENTITY,
 				GeneratedWhen: 'a',
 				Options:       $5,
-				Location:      -1,
+				Location:      nodes.ParseLoc($<loc>1),
 			}
 		}
 	| GENERATED BY DEFAULT AS IDENTITY_P OptParenthesizedSeqOptList
@@ -1963,34 +2013,45 @@ ColConstraintElem:
 				Contype:       nodes.CONSTR_IDENTITY,
 				GeneratedWhen: 'd',
 				Options:       $6,
-				Location:      -1,
+				Location:      nodes.ParseLoc($<loc>1),
 			}
 		}
-	| GENERATED ALWAYS AS '(' a_expr ')' STORED
+	| GENERATED ALWAYS AS '(' a_expr ')' opt_virtual_or_stored
 		{
-			$$ = &nodes.Constraint{
+			c := &nodes.Constraint{
 				Contype:       nodes.CONSTR_GENERATED,
 				GeneratedWhen: 'a',
 				RawExpr:       $5,
-				Location:      -1,
+				Location:      nodes.ParseLoc($<loc>1),
+			}
+			/* Before 18 generated columns are stored, and have no generated_kind */
+			if $7 != 's' {
+				requireVersion(pglex, PG18, "virtual generated column", $<loc>1)
 			}
+			if l, ok := pglex.(*parserLexer); ok && l.version >= PG18 {
+				c.GeneratedKind = byte($7)
+			}
+			$$ = c
 		}
-	| GENERATED BY DEFAULT AS '(' a_expr ')' STORED
+	| GENERATED BY DEFAULT AS '(' a_expr ')' opt_virtual_or_stored
 		{
-			$$ = &nodes.Constraint{
-				Contype:       nodes.CONSTR_GENERATED,
-				GeneratedWhen: 'd',
-				RawExpr:       $6,
-				Location:      -1,
-			}
+			pglex.Error("for a generated column, GENERATED ALWAYS must be specified")
+			$$ = &nodes.Constraint{Contype: nodes.CONSTR_GENERATED}
 		}
 	;
 
+opt_virtual_or_stored:
+	STORED { $$ = 's' }
+	| VIRTUAL { $$ = 'v' }
+	| /* EMPTY */ { $$ = 'v' }
+	;
+
 DomainConstraint:
 	CONSTRAINT name DomainConstraintElem
 		{
 			n := $3.(*nodes.Constraint)
 			n.Conname = $2
+			n.Location = nodes.ParseLoc($<loc>1)
 			$$ = n
 		}
 	| DomainConstraintElem
@@ -2005,20 +2066,21 @@ DomainConstraintElem:
 			n := &nodes.Constraint{
 				Contype:        nodes.CONSTR_CHECK,
 				RawExpr:        $3,
-				Location:       -1,
+				Location:       nodes.ParseLoc($<loc>1),
 				InitiallyValid: true,
 			}
-			applyConstraintAttrs(n, $5)
+			applyConstraintAttrs(pglex, n, $5)
 			$$ = n
 		}
 	| NOT NULL_P ConstraintAttributeSpec
 		{
 			n := &nodes.Constraint{
 				Contype:        nodes.CONSTR_NOTNULL,
-				Location:       -1,
+				Keys:           makeList(&nodes.String{Sval: "value"}),
+				Location:       nodes.ParseLoc($<loc>1),
 				InitiallyValid: true,
 			}
-			applyConstraintAttrs(n, $3)
+			applyConstraintAttrs(pglex, n, $3)
 			$$ = n
 		}
 	;
@@ -2028,6 +2090,7 @@ TableConstraint:
 		{
 			n := $3.(*nodes.Constraint)
 			n.Conname = $2
+			n.Location = nodes.ParseLoc($<loc>1)
 			$$ = n
 		}
 	| ConstraintElem
@@ -2046,10 +2109,9 @@ ConstraintElem:
 				Including:        $6,
 				Options:          $7,
 				Indexspace:       $8,
-				Location:         -1,
-				InitiallyValid:   true,
+				Location:         nodes.ParseLoc($<loc>1),
 			}
-			applyConstraintAttrs(n, $9)
+			applyConstraintAttrs(pglex, n, $9)
 			$$ = n
 		}
 	| UNIQUE ExistingIndex ConstraintAttributeSpec
@@ -2057,10 +2119,9 @@ ConstraintElem:
 			n := &nodes.Constraint{
 				Contype:    nodes.CONSTR_UNIQUE,
 				Indexname:  $2,
-				Location:   -1,
-				InitiallyValid: true,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
-			applyConstraintAttrs(n, $3)
+			applyConstraintAttrs(pglex, n, $3)
 			$$ = n
 		}
 	| PRIMARY KEY '(' columnList ')' opt_c_include opt_definition OptConsTableSpace ConstraintAttributeSpec
@@ -2071,10 +2132,9 @@ ConstraintElem:
 				Including:  $6,
 				Options:    $7,
 				Indexspace:  $8,
-				Location:   -1,
-				InitiallyValid: true,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
-			applyConstraintAttrs(n, $9)
+			applyConstraintAttrs(pglex, n, $9)
 			$$ = n
 		}
 	| PRIMARY KEY ExistingIndex ConstraintAttributeSpec
@@ -2082,10 +2142,9 @@ ConstraintElem:
 			n := &nodes.Constraint{
 				Contype:    nodes.CONSTR_PRIMARY,
 				Indexname:  $3,
-				Location:   -1,
-				InitiallyValid: true,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
-			applyConstraintAttrs(n, $4)
+			applyConstraintAttrs(pglex, n, $4)
 			$$ = n
 		}
 	| CHECK '(' a_expr ')' ConstraintAttributeSpec
@@ -2093,28 +2152,28 @@ ConstraintElem:
 			n := &nodes.Constraint{
 				Contype:  nodes.CONSTR_CHECK,
 				RawExpr:  $3,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 				InitiallyValid: true,
 			}
-			applyConstraintAttrs(n, $5)
+			applyConstraintAttrs(pglex, n, $5)
 			$$ = n
 		}
 	| FOREIGN KEY '(' columnList ')' REFERENCES qualified_name opt_column_list key_match key_actions ConstraintAttributeSpec
 		{
-			rv := makeRangeVar($7)
+			rv := makeRangeVar($7, $<loc>7)
 			n := &nodes.Constraint{
 				Contype:        nodes.CONSTR_FOREIGN,
 				FkAttrs:        $4,
 				Pktable:        rv.(*nodes.RangeVar),
 				PkAttrs:        $8,
 				FkMatchtype:    byte($9),
-				FkUpdaction:    $10.UpdateAction.Action,
-				FkDelaction:    $10.DeleteAction.Action,
-				FkDelsetcols:   $10.DeleteAction.Cols,
-				Location:       -1,
+				FkUpdAction:    $10.UpdateAction.Action,
+				FkDelAction:    $10.DeleteAction.Action,
+				FkDelSetCols:   $10.DeleteAction.Cols,
+				Location:       nodes.ParseLoc($<loc>1),
 				InitiallyValid: true,
 			}
-			applyConstraintAttrs(n, $11)
+			applyConstraintAttrs(pglex, n, $11)
 			$$ = n
 		}
 	| EXCLUDE access_method_clause '(' ExclusionConstraintList ')' opt_c_include opt_definition OptConsTableSpace where_clause ConstraintAttributeSpec
@@ -2127,10 +2186,9 @@ ConstraintElem:
 				Options:        $7,
 				Indexspace:      $8,
 				WhereClause:    $9,
-				Location:       -1,
-				InitiallyValid: true,
+				Location:       nodes.ParseLoc($<loc>1),
 			}
-			applyConstraintAttrs(n, $10)
+			applyConstraintAttrs(pglex, n, $10)
 			$$ = n
 		}
 	;
@@ -2142,9 +2200,9 @@ opt_column_list:
 
 columnList:
 	name
-		{ $$ = makeList(&nodes.String{Str: $1}) }
+		{ $$ = makeList(&nodes.String{Sval: $1}) }
 	| columnList ',' name
-		{ $$ = appendList($1, &nodes.String{Str: $3}) }
+		{ $$ = appendList($1, &nodes.String{Sval: $3}) }
 	;
 
 key_match:
@@ -2176,7 +2234,17 @@ key_actions:
 	;
 
 key_update:
-	ON UPDATE key_action { $$ = $3 }
+	ON UPDATE key_action
+		{
+			if $3.Cols != nil {
+				action := "SET NULL"
+				if $3.Action == 'd' {
+					action = "SET DEFAULT"
+				}
+				pglex.Error(fmt.Sprintf("a column list with %s is only supported for ON DELETE actions", action))
+			}
+			$$ = $3
+		}
 	;
 
 key_delete:
@@ -2233,45 +2301,45 @@ AlterTableStmt:
 	ALTER TABLE relation_expr alter_table_cmds
 		{
 			$$ = &nodes.AlterTableStmt{
-				Relation:   $3.(*nodes.RangeVar),
-				Cmds:       $4,
-				ObjType:    int(nodes.OBJECT_TABLE),
+				Relation: $3.(*nodes.RangeVar),
+				Cmds:     $4,
+				Objtype:  nodes.OBJECT_TABLE,
 			}
 		}
 	| ALTER TABLE IF_P EXISTS relation_expr alter_table_cmds
 		{
 			$$ = &nodes.AlterTableStmt{
-				Relation:   $5.(*nodes.RangeVar),
-				Cmds:       $6,
-				ObjType:    int(nodes.OBJECT_TABLE),
-				Missing_ok: true,
+				Relation:  $5.(*nodes.RangeVar),
+				Cmds:      $6,
+				Objtype:   nodes.OBJECT_TABLE,
+				MissingOk: true,
 			}
 		}
 	| ALTER INDEX qualified_name alter_table_cmds
 		{
-			rv := makeRangeVarFromAnyName($3)
+			rv := makeRangeVarFromAnyName($3, $<loc>3)
 			$$ = &nodes.AlterTableStmt{
 				Relation: rv,
 				Cmds:     $4,
-				ObjType:  int(nodes.OBJECT_INDEX),
+				Objtype:  nodes.OBJECT_INDEX,
 			}
 		}
 	| ALTER INDEX IF_P EXISTS qualified_name alter_table_cmds
 		{
-			rv := makeRangeVarFromAnyName($5)
+			rv := makeRangeVarFromAnyName($5, $<loc>5)
 			$$ = &nodes.AlterTableStmt{
-				Relation:   rv,
-				Cmds:       $6,
-				ObjType:    int(nodes.OBJECT_INDEX),
-				Missing_ok: true,
+				Relation:  rv,
+				Cmds:      $6,
+				Objtype:   nodes.OBJECT_INDEX,
+				MissingOk: true,
 			}
 		}
 	| ALTER INDEX qualified_name ATTACH PARTITION qualified_name
 		{
-			rvIdx := makeRangeVarFromAnyName($3)
-			rvPart := makeRangeVar($6)
+			rvIdx := makeRangeVarFromAnyName($3, $<loc>3)
+			rvPart := makeRangeVar($6, $<loc>6)
 			cmd := &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AttachPartition),
+				Subtype: nodes.AT_AttachPartition,
 				Def:     &nodes.PartitionCmd{
 					Name: rvPart.(*nodes.RangeVar),
 				},
@@ -2279,64 +2347,64 @@ AlterTableStmt:
 			$$ = &nodes.AlterTableStmt{
 				Relation: rvIdx,
 				Cmds:     makeList(cmd),
-				ObjType:  int(nodes.OBJECT_INDEX),
+				Objtype:  nodes.OBJECT_INDEX,
 			}
 		}
 	| ALTER SEQUENCE qualified_name alter_table_cmds
 		{
-			rv := makeRangeVarFromAnyName($3)
+			rv := makeRangeVarFromAnyName($3, $<loc>3)
 			$$ = &nodes.AlterTableStmt{
 				Relation: rv,
 				Cmds:     $4,
-				ObjType:  int(nodes.OBJECT_SEQUENCE),
+				Objtype:  nodes.OBJECT_SEQUENCE,
 			}
 		}
 	| ALTER SEQUENCE IF_P EXISTS qualified_name alter_table_cmds
 		{
-			rv := makeRangeVarFromAnyName($5)
+			rv := makeRangeVarFromAnyName($5, $<loc>5)
 			$$ = &nodes.AlterTableStmt{
-				Relation:   rv,
-				Cmds:       $6,
-				ObjType:    int(nodes.OBJECT_SEQUENCE),
-				Missing_ok: true,
+				Relation:  rv,
+				Cmds:      $6,
+				Objtype:   nodes.OBJECT_SEQUENCE,
+				MissingOk: true,
 			}
 		}
 	| ALTER VIEW qualified_name alter_table_cmds
 		{
-			rv := makeRangeVarFromAnyName($3)
+			rv := makeRangeVarFromAnyName($3, $<loc>3)
 			$$ = &nodes.AlterTableStmt{
 				Relation: rv,
 				Cmds:     $4,
-				ObjType:  int(nodes.OBJECT_VIEW),
+				Objtype:  nodes.OBJECT_VIEW,
 			}
 		}
 	| ALTER VIEW IF_P EXISTS qualified_name alter_table_cmds
 		{
-			rv := makeRangeVarFromAnyName($5)
+			rv := makeRangeVarFromAnyName($5, $<loc>5)
 			$$ = &nodes.AlterTableStmt{
-				Relation:   rv,
-				Cmds:       $6,
-				ObjType:    int(nodes.OBJECT_VIEW),
-				Missing_ok: true,
+				Relation:  rv,
+				Cmds:      $6,
+				Objtype:   nodes.OBJECT_VIEW,
+				MissingOk: true,
 			}
 		}
 	| ALTER MATERIALIZED VIEW qualified_name alter_table_cmds
 		{
-			rv := makeRangeVarFromAnyName($4)
+			rv := makeRangeVarFromAnyName($4, $<loc>4)
 			$$ = &nodes.AlterTableStmt{
 				Relation: rv,
 				Cmds:     $5,
-				ObjType:  int(nodes.OBJECT_MATVIEW),
+				Objtype:  nodes.OBJECT_MATVIEW,
 			}
 		}
 	| ALTER MATERIALIZED VIEW IF_P EXISTS qualified_name alter_table_cmds
 		{
-			rv := makeRangeVarFromAnyName($6)
+			rv := makeRangeVarFromAnyName($6, $<loc>6)
 			$$ = &nodes.AlterTableStmt{
-				Relation:   rv,
-				Cmds:       $7,
-				ObjType:    int(nodes.OBJECT_MATVIEW),
-				Missing_ok: true,
+				Relation:  rv,
+				Cmds:      $7,
+				Objtype:   nodes.OBJECT_MATVIEW,
+				MissingOk: true,
 			}
 		}
 	| ALTER FOREIGN TABLE relation_expr alter_table_cmds
@@ -2344,16 +2412,16 @@ AlterTableStmt:
 			$$ = &nodes.AlterTableStmt{
 				Relation: $4.(*nodes.RangeVar),
 				Cmds:     $5,
-				ObjType:  int(nodes.OBJECT_FOREIGN_TABLE),
+				Objtype:  nodes.OBJECT_FOREIGN_TABLE,
 			}
 		}
 	| ALTER FOREIGN TABLE IF_P EXISTS relation_expr alter_table_cmds
 		{
 			$$ = &nodes.AlterTableStmt{
-				Relation:   $6.(*nodes.RangeVar),
-				Cmds:       $7,
-				ObjType:    int(nodes.OBJECT_FOREIGN_TABLE),
-				Missing_ok: true,
+				Relation:  $6.(*nodes.RangeVar),
+				Cmds:      $7,
+				Objtype:   nodes.OBJECT_FOREIGN_TABLE,
+				MissingOk: true,
 			}
 		}
 	/* ALTER TABLE ALL IN TABLESPACE ... SET TABLESPACE ... */
@@ -2361,7 +2429,7 @@ AlterTableStmt:
 		{
 			$$ = &nodes.AlterTableMoveAllStmt{
 				OrigTablespacename: $6,
-				ObjType:            int(nodes.OBJECT_TABLE),
+				Objtype:            nodes.OBJECT_TABLE,
 				NewTablespacename:  $9,
 				Nowait:             $10,
 			}
@@ -2370,7 +2438,7 @@ AlterTableStmt:
 		{
 			$$ = &nodes.AlterTableMoveAllStmt{
 				OrigTablespacename: $6,
-				ObjType:            int(nodes.OBJECT_TABLE),
+				Objtype:            nodes.OBJECT_TABLE,
 				Roles:              $9,
 				NewTablespacename:  $12,
 				Nowait:             $13,
@@ -2380,7 +2448,7 @@ AlterTableStmt:
 		{
 			$$ = &nodes.AlterTableMoveAllStmt{
 				OrigTablespacename: $6,
-				ObjType:            int(nodes.OBJECT_INDEX),
+				Objtype:            nodes.OBJECT_INDEX,
 				NewTablespacename:  $9,
 				Nowait:             $10,
 			}
@@ -2389,7 +2457,7 @@ AlterTableStmt:
 		{
 			$$ = &nodes.AlterTableMoveAllStmt{
 				OrigTablespacename: $6,
-				ObjType:            int(nodes.OBJECT_INDEX),
+				Objtype:            nodes.OBJECT_INDEX,
 				Roles:              $9,
 				NewTablespacename:  $12,
 				Nowait:             $13,
@@ -2399,7 +2467,7 @@ AlterTableStmt:
 		{
 			$$ = &nodes.AlterTableMoveAllStmt{
 				OrigTablespacename: $7,
-				ObjType:            int(nodes.OBJECT_MATVIEW),
+				Objtype:            nodes.OBJECT_MATVIEW,
 				NewTablespacename:  $10,
 				Nowait:             $11,
 			}
@@ -2408,7 +2476,7 @@ AlterTableStmt:
 		{
 			$$ = &nodes.AlterTableMoveAllStmt{
 				OrigTablespacename: $7,
-				ObjType:            int(nodes.OBJECT_MATVIEW),
+				Objtype:            nodes.OBJECT_MATVIEW,
 				Roles:              $10,
 				NewTablespacename:  $13,
 				Nowait:             $14,
@@ -2427,46 +2495,46 @@ alter_table_cmd:
 	ADD_P columnDef
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AddColumn),
+				Subtype: nodes.AT_AddColumn,
 				Def:     $2,
 			}
 		}
 	| ADD_P COLUMN columnDef
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AddColumn),
+				Subtype: nodes.AT_AddColumn,
 				Def:     $3,
 			}
 		}
 	| ADD_P COLUMN IF_P NOT EXISTS columnDef
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype:    int(nodes.AT_AddColumn),
-				Def:        $6,
-				Missing_ok: true,
+				Subtype:   nodes.AT_AddColumn,
+				Def:       $6,
+				MissingOk: true,
 			}
 		}
 	| DROP COLUMN ColId opt_drop_behavior
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype:  int(nodes.AT_DropColumn),
+				Subtype:  nodes.AT_DropColumn,
 				Name:     $3,
-				Behavior: int($4),
+				Behavior: nodes.DropBehavior($4),
 			}
 		}
 	| DROP COLUMN IF_P EXISTS ColId opt_drop_behavior
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype:    int(nodes.AT_DropColumn),
-				Name:       $5,
-				Behavior:   int($6),
-				Missing_ok: true,
+				Subtype:   nodes.AT_DropColumn,
+				Name:      $5,
+				Behavior:  nodes.DropBehavior($6),
+				MissingOk: true,
 			}
 		}
 	| ALTER COLUMN ColId SET DEFAULT a_expr
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_ColumnDefault),
+				Subtype: nodes.AT_ColumnDefault,
 				Name:    $3,
 				Def:     $6,
 			}
@@ -2474,32 +2542,32 @@ alter_table_cmd:
 	| ALTER COLUMN ColId DROP DEFAULT
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_ColumnDefault),
+				Subtype: nodes.AT_ColumnDefault,
 				Name:    $3,
 			}
 		}
 	| ALTER COLUMN ColId SET NOT NULL_P
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_SetNotNull),
+				Subtype: nodes.AT_SetNotNull,
 				Name:    $3,
 			}
 		}
 	| ALTER COLUMN ColId DROP NOT NULL_P
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_DropNotNull),
+				Subtype: nodes.AT_DropNotNull,
 				Name:    $3,
 			}
 		}
 	| ALTER COLUMN ColId TYPE_P Typename opt_collate_clause
 		{
-			coldef := &nodes.ColumnDef{TypeName: $5}
+			coldef := &nodes.ColumnDef{TypeName: $5, Location: nodes.ParseLoc($<loc>3)}
 			if $6 != nil {
 				coldef.CollClause = $6.(*nodes.CollateClause)
 			}
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AlterColumnType),
+				Subtype: nodes.AT_AlterColumnType,
 				Name:    $3,
 				Def:     coldef,
 			}
@@ -2507,103 +2575,103 @@ alter_table_cmd:
 	| ADD_P TableConstraint
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AddConstraint),
+				Subtype: nodes.AT_AddConstraint,
 				Def:     $2,
 			}
 		}
 	| DROP CONSTRAINT name opt_drop_behavior
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype:  int(nodes.AT_DropConstraint),
+				Subtype:  nodes.AT_DropConstraint,
 				Name:     $3,
-				Behavior: int($4),
+				Behavior: nodes.DropBehavior($4),
 			}
 		}
 	| DROP CONSTRAINT IF_P EXISTS name opt_drop_behavior
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype:    int(nodes.AT_DropConstraint),
-				Name:       $5,
-				Behavior:   int($6),
-				Missing_ok: true,
+				Subtype:   nodes.AT_DropConstraint,
+				Name:      $5,
+				Behavior:  nodes.DropBehavior($6),
+				MissingOk: true,
 			}
 		}
 	| OWNER TO RoleSpec
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype:  int(nodes.AT_ChangeOwner),
+				Subtype:  nodes.AT_ChangeOwner,
 				Newowner: $3.(*nodes.RoleSpec),
 			}
 		}
 	/* ALTER COLUMN ... TYPE ... USING */
 	| ALTER COLUMN ColId SET DATA_P TYPE_P Typename opt_collate_clause
 		{
-			coldef := &nodes.ColumnDef{TypeName: $7}
+			coldef := &nodes.ColumnDef{TypeName: $7, Location: nodes.ParseLoc($<loc>3)}
 			if $8 != nil {
 				coldef.CollClause = $8.(*nodes.CollateClause)
 			}
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AlterColumnType),
+				Subtype: nodes.AT_AlterColumnType,
 				Name:    $3,
 				Def:     coldef,
 			}
 		}
 	| ALTER COLUMN ColId TYPE_P Typename opt_collate_clause USING a_expr
 		{
-			coldef := &nodes.ColumnDef{TypeName: $5}
+			coldef := &nodes.ColumnDef{TypeName: $5, RawDefault: $8, Location: nodes.ParseLoc($<loc>3)}
 			if $6 != nil {
 				coldef.CollClause = $6.(*nodes.CollateClause)
 			}
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AlterColumnType),
+				Subtype: nodes.AT_AlterColumnType,
 				Name:    $3,
 				Def:     coldef,
 			}
 		}
 	| ALTER COLUMN ColId SET DATA_P TYPE_P Typename opt_collate_clause USING a_expr
 		{
-			coldef := &nodes.ColumnDef{TypeName: $7}
+			coldef := &nodes.ColumnDef{TypeName: $7, RawDefault: $10, Location: nodes.ParseLoc($<loc>3)}
 			if $8 != nil {
 				coldef.CollClause = $8.(*nodes.CollateClause)
 			}
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AlterColumnType),
+				Subtype: nodes.AT_AlterColumnType,
 				Name:    $3,
 				Def:     coldef,
 			}
 		}
 	| ALTER ColId TYPE_P Typename opt_collate_clause
 		{
-			coldef := &nodes.ColumnDef{TypeName: $4}
+			coldef := &nodes.ColumnDef{TypeName: $4, Location: nodes.ParseLoc($<loc>2)}
 			if $5 != nil {
 				coldef.CollClause = $5.(*nodes.CollateClause)
 			}
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AlterColumnType),
+				Subtype: nodes.AT_AlterColumnType,
 				Name:    $2,
 				Def:     coldef,
 			}
 		}
 	| ALTER ColId SET DATA_P TYPE_P Typename opt_collate_clause
 		{
-			coldef := &nodes.ColumnDef{TypeName: $6}
+			coldef := &nodes.ColumnDef{TypeName: $6, Location: nodes.ParseLoc($<loc>2)}
 			if $7 != nil {
 				coldef.CollClause = $7.(*nodes.CollateClause)
 			}
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AlterColumnType),
+				Subtype: nodes.AT_AlterColumnType,
 				Name:    $2,
 				Def:     coldef,
 			}
 		}
 	| ALTER ColId SET DATA_P TYPE_P Typename opt_collate_clause USING a_expr
 		{
-			coldef := &nodes.ColumnDef{TypeName: $6}
+			coldef := &nodes.ColumnDef{TypeName: $6, RawDefault: $9, Location: nodes.ParseLoc($<loc>2)}
 			if $7 != nil {
 				coldef.CollClause = $7.(*nodes.CollateClause)
 			}
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AlterColumnType),
+				Subtype: nodes.AT_AlterColumnType,
 				Name:    $2,
 				Def:     coldef,
 			}
@@ -2611,7 +2679,7 @@ alter_table_cmd:
 	| ALTER ColId SET DEFAULT a_expr
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_ColumnDefault),
+				Subtype: nodes.AT_ColumnDefault,
 				Name:    $2,
 				Def:     $5,
 			}
@@ -2619,32 +2687,32 @@ alter_table_cmd:
 	| ALTER ColId DROP DEFAULT
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_ColumnDefault),
+				Subtype: nodes.AT_ColumnDefault,
 				Name:    $2,
 			}
 		}
 	| ALTER ColId SET NOT NULL_P
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_SetNotNull),
+				Subtype: nodes.AT_SetNotNull,
 				Name:    $2,
 			}
 		}
 	| ALTER ColId DROP NOT NULL_P
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_DropNotNull),
+				Subtype: nodes.AT_DropNotNull,
 				Name:    $2,
 			}
 		}
 	| ALTER ColId TYPE_P Typename opt_collate_clause USING a_expr
 		{
-			coldef := &nodes.ColumnDef{TypeName: $4}
+			coldef := &nodes.ColumnDef{TypeName: $4, RawDefault: $7, Location: nodes.ParseLoc($<loc>2)}
 			if $5 != nil {
 				coldef.CollClause = $5.(*nodes.CollateClause)
 			}
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AlterColumnType),
+				Subtype: nodes.AT_AlterColumnType,
 				Name:    $2,
 				Def:     coldef,
 			}
@@ -2653,7 +2721,7 @@ alter_table_cmd:
 	| ALTER COLUMN ColId alter_generic_options
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AlterColumnGenericOptions),
+				Subtype: nodes.AT_AlterColumnGenericOptions,
 				Name:    $3,
 				Def:     $4,
 			}
@@ -2661,7 +2729,7 @@ alter_table_cmd:
 	| ALTER ColId alter_generic_options
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AlterColumnGenericOptions),
+				Subtype: nodes.AT_AlterColumnGenericOptions,
 				Name:    $2,
 				Def:     $3,
 			}
@@ -2670,41 +2738,46 @@ alter_table_cmd:
 	| VALIDATE CONSTRAINT name
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_ValidateConstraint),
+				Subtype: nodes.AT_ValidateConstraint,
 				Name:    $3,
 			}
 		}
 	/* ALTER CONSTRAINT */
 	| ALTER CONSTRAINT name ConstraintAttributeSpec
 		{
+			c := &nodes.Constraint{
+				Contype: nodes.CONSTR_FOREIGN, /* others not supported, yet */
+				Conname: $3,
+			}
+			processCASbits(pglex, $4, "FOREIGN KEY", &c.Deferrable, &c.Initdeferred, nil, nil)
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AlterConstraint),
-				Name:    $3,
+				Subtype: nodes.AT_AlterConstraint,
+				Def:     c,
 			}
 		}
 	/* INHERIT / NO INHERIT */
 	| INHERIT qualified_name
 		{
-			rv := makeRangeVar($2)
+			rv := makeRangeVar($2, $<loc>2)
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AddInherit),
+				Subtype: nodes.AT_AddInherit,
 				Def:     rv,
 			}
 		}
 	| NO INHERIT qualified_name
 		{
-			rv := makeRangeVar($3)
+			rv := makeRangeVar($3, $<loc>3)
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_DropInherit),
+				Subtype: nodes.AT_DropInherit,
 				Def:     rv,
 			}
 		}
 	/* ATTACH / DETACH PARTITION */
 	| ATTACH PARTITION qualified_name ForValues
 		{
-			rv := makeRangeVar($3)
+			rv := makeRangeVar($3, $<loc>3)
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AttachPartition),
+				Subtype: nodes.AT_AttachPartition,
 				Def:     &nodes.PartitionCmd{
 					Name:  rv.(*nodes.RangeVar),
 					Bound: $4,
@@ -2713,9 +2786,9 @@ alter_table_cmd:
 		}
 	| DETACH PARTITION qualified_name
 		{
-			rv := makeRangeVar($3)
+			rv := makeRangeVar($3, $<loc>3)
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_DetachPartition),
+				Subtype: nodes.AT_DetachPartition,
 				Def:     &nodes.PartitionCmd{
 					Name: rv.(*nodes.RangeVar),
 				},
@@ -2723,9 +2796,10 @@ alter_table_cmd:
 		}
 	| DETACH PARTITION qualified_name CONCURRENTLY
 		{
-			rv := makeRangeVar($3)
+			requireVersion(pglex, PG14, "DETACH PARTITION ... CONCURRENTLY", $<loc>4)
+			rv := makeRangeVar($3, $<loc>3)
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_DetachPartition),
+				Subtype: nodes.AT_DetachPartition,
 				Def:     &nodes.PartitionCmd{
 					Name:       rv.(*nodes.RangeVar),
 					Concurrent: true,
@@ -2734,9 +2808,10 @@ alter_table_cmd:
 		}
 	| DETACH PARTITION qualified_name FINALIZE
 		{
-			rv := makeRangeVar($3)
+			requireVersion(pglex, PG14, "DETACH PARTITION ... FINALIZE", $<loc>4)
+			rv := makeRangeVar($3, $<loc>3)
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_DetachPartitionFinalize),
+				Subtype: nodes.AT_DetachPartitionFinalize,
 				Def:     &nodes.PartitionCmd{
 					Name: rv.(*nodes.RangeVar),
 				},
@@ -2746,81 +2821,81 @@ alter_table_cmd:
 	| ENABLE_P TRIGGER name
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_EnableTrig),
+				Subtype: nodes.AT_EnableTrig,
 				Name:    $3,
 			}
 		}
 	| ENABLE_P ALWAYS TRIGGER name
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_EnableAlwaysTrig),
+				Subtype: nodes.AT_EnableAlwaysTrig,
 				Name:    $4,
 			}
 		}
 	| ENABLE_P REPLICA TRIGGER name
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_EnableReplicaTrig),
+				Subtype: nodes.AT_EnableReplicaTrig,
 				Name:    $4,
 			}
 		}
 	| DISABLE_P TRIGGER name
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_DisableTrig),
+				Subtype: nodes.AT_DisableTrig,
 				Name:    $3,
 			}
 		}
 	| ENABLE_P TRIGGER ALL
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_EnableTrigAll),
+				Subtype: nodes.AT_EnableTrigAll,
 			}
 		}
 	| DISABLE_P TRIGGER ALL
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_DisableTrigAll),
+				Subtype: nodes.AT_DisableTrigAll,
 			}
 		}
 	| ENABLE_P TRIGGER USER
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_EnableTrigUser),
+				Subtype: nodes.AT_EnableTrigUser,
 			}
 		}
 	| DISABLE_P TRIGGER USER
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_DisableTrigUser),
+				Subtype: nodes.AT_DisableTrigUser,
 			}
 		}
 	/* ENABLE/DISABLE RULE */
 	| ENABLE_P RULE name
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_EnableRule),
+				Subtype: nodes.AT_EnableRule,
 				Name:    $3,
 			}
 		}
 	| ENABLE_P ALWAYS RULE name
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_EnableAlwaysRule),
+				Subtype: nodes.AT_EnableAlwaysRule,
 				Name:    $4,
 			}
 		}
 	| ENABLE_P REPLICA RULE name
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_EnableReplicaRule),
+				Subtype: nodes.AT_EnableReplicaRule,
 				Name:    $4,
 			}
 		}
 	| DISABLE_P RULE name
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_DisableRule),
+				Subtype: nodes.AT_DisableRule,
 				Name:    $3,
 			}
 		}
@@ -2828,66 +2903,66 @@ alter_table_cmd:
 	| ENABLE_P ROW LEVEL SECURITY
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_EnableRowSecurity),
+				Subtype: nodes.AT_EnableRowSecurity,
 			}
 		}
 	| DISABLE_P ROW LEVEL SECURITY
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_DisableRowSecurity),
+				Subtype: nodes.AT_DisableRowSecurity,
 			}
 		}
 	| FORCE ROW LEVEL SECURITY
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_ForceRowSecurity),
+				Subtype: nodes.AT_ForceRowSecurity,
 			}
 		}
 	| NO FORCE ROW LEVEL SECURITY
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_NoForceRowSecurity),
+				Subtype: nodes.AT_NoForceRowSecurity,
 			}
 		}
 	/* CLUSTER ON / SET WITHOUT CLUSTER */
 	| CLUSTER ON name
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_ClusterOn),
+				Subtype: nodes.AT_ClusterOn,
 				Name:    $3,
 			}
 		}
 	| SET WITHOUT CLUSTER
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_DropCluster),
+				Subtype: nodes.AT_DropCluster,
 			}
 		}
 	/* SET LOGGED / SET UNLOGGED */
 	| SET LOGGED
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_SetLogged),
+				Subtype: nodes.AT_SetLogged,
 			}
 		}
 	| SET UNLOGGED
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_SetUnLogged),
+				Subtype: nodes.AT_SetUnLogged,
 			}
 		}
 	/* SET ACCESS METHOD */
 	| SET ACCESS METHOD name
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_SetAccessMethod),
+				Subtype: nodes.AT_SetAccessMethod,
 				Name:    $4,
 			}
 		}
 	| SET ACCESS METHOD DEFAULT
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_SetAccessMethod),
+				Subtype: nodes.AT_SetAccessMethod,
 				Name:    "",
 			}
 		}
@@ -2895,14 +2970,14 @@ alter_table_cmd:
 	| SET reloptions
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_SetRelOptions),
+				Subtype: nodes.AT_SetRelOptions,
 				Def:     $2,
 			}
 		}
 	| RESET reloptions
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_ResetRelOptions),
+				Subtype: nodes.AT_ResetRelOptions,
 				Def:     $2,
 			}
 		}
@@ -2910,151 +2985,162 @@ alter_table_cmd:
 	| ADD_P IF_P NOT EXISTS columnDef
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype:    int(nodes.AT_AddColumn),
-				Def:        $5,
-				Missing_ok: true,
+				Subtype:   nodes.AT_AddColumn,
+				Def:       $5,
+				MissingOk: true,
 			}
 		}
 	/* DROP (without COLUMN keyword) */
 	| DROP ColId opt_drop_behavior
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype:  int(nodes.AT_DropColumn),
+				Subtype:  nodes.AT_DropColumn,
 				Name:     $2,
-				Behavior: int($3),
+				Behavior: nodes.DropBehavior($3),
 			}
 		}
 	| DROP IF_P EXISTS ColId opt_drop_behavior
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype:    int(nodes.AT_DropColumn),
-				Name:       $4,
-				Behavior:   int($5),
-				Missing_ok: true,
+				Subtype:   nodes.AT_DropColumn,
+				Name:      $4,
+				Behavior:  nodes.DropBehavior($5),
+				MissingOk: true,
 			}
 		}
 	/* REPLICA IDENTITY */
 	| REPLICA IDENTITY_P DEFAULT
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_ReplicaIdentity),
+				Subtype: nodes.AT_ReplicaIdentity,
+				Def:     &nodes.ReplicaIdentityStmt{IdentityType: 'd'},
 			}
 		}
 	| REPLICA IDENTITY_P FULL
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_ReplicaIdentity),
+				Subtype: nodes.AT_ReplicaIdentity,
+				Def:     &nodes.ReplicaIdentityStmt{IdentityType: 'f'},
 			}
 		}
 	| REPLICA IDENTITY_P NOTHING
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_ReplicaIdentity),
+				Subtype: nodes.AT_ReplicaIdentity,
+				Def:     &nodes.ReplicaIdentityStmt{IdentityType: 'n'},
 			}
 		}
 	| REPLICA IDENTITY_P USING INDEX name
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_ReplicaIdentity),
-				Name:    $5,
+				Subtype: nodes.AT_ReplicaIdentity,
+				Def:     &nodes.ReplicaIdentityStmt{IdentityType: 'i', Name: $5},
 			}
 		}
 	/* SET STORAGE */
 	| ALTER COLUMN ColId SET STORAGE ColId
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_SetStorage),
+				Subtype: nodes.AT_SetStorage,
 				Name:    $3,
-				Def:     &nodes.String{Str: $6},
+				Def:     &nodes.String{Sval: $6},
 			}
 		}
 	| ALTER COLUMN ColId SET STORAGE DEFAULT
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_SetStorage),
+				Subtype: nodes.AT_SetStorage,
 				Name:    $3,
-				Def:     &nodes.String{Str: "default"},
+				Def:     &nodes.String{Sval: "default"},
 			}
 		}
 	| ALTER ColId SET STORAGE ColId
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_SetStorage),
+				Subtype: nodes.AT_SetStorage,
 				Name:    $2,
-				Def:     &nodes.String{Str: $5},
+				Def:     &nodes.String{Sval: $5},
 			}
 		}
 	| ALTER ColId SET STORAGE DEFAULT
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_SetStorage),
+				Subtype: nodes.AT_SetStorage,
 				Name:    $2,
-				Def:     &nodes.String{Str: "default"},
+				Def:     &nodes.String{Sval: "default"},
 			}
 		}
 	/* SET STATISTICS */
 	| ALTER COLUMN ColId SET STATISTICS SignedIconst
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_SetStatistics),
+				Subtype: nodes.AT_SetStatistics,
 				Name:    $3,
-				Def:     makeIntConst($6),
+				Def:     &nodes.Integer{Ival: $6},
 			}
 		}
 	| ALTER ColId SET STATISTICS SignedIconst
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_SetStatistics),
+				Subtype: nodes.AT_SetStatistics,
 				Name:    $2,
-				Def:     makeIntConst($5),
+				Def:     &nodes.Integer{Ival: $5},
 			}
 		}
 	| ALTER COLUMN Iconst SET STATISTICS SignedIconst
 		{
+			if $3 <= 0 || $3 > math.MaxInt16 {
+				pglex.Error(fmt.Sprintf("column number must be in range from 1 to %d", math.MaxInt16))
+			}
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_SetStatistics),
+				Subtype: nodes.AT_SetStatistics,
 				Num:     int16($3),
-				Def:     makeIntConst($6),
+				Def:     &nodes.Integer{Ival: $6},
 			}
 		}
 	| ALTER Iconst SET STATISTICS SignedIconst
 		{
+			if $2 <= 0 || $2 > math.MaxInt16 {
+				pglex.Error(fmt.Sprintf("column number must be in range from 1 to %d", math.MaxInt16))
+			}
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_SetStatistics),
+				Subtype: nodes.AT_SetStatistics,
 				Num:     int16($2),
-				Def:     makeIntConst($5),
+				Def:     &nodes.Integer{Ival: $5},
 			}
 		}
 	/* SET COMPRESSION */
 	| ALTER COLUMN ColId SET column_compression
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_SetCompression),
+				Subtype: nodes.AT_SetCompression,
 				Name:    $3,
-				Def:     &nodes.String{Str: $5},
+				Def:     &nodes.String{Sval: $5},
 			}
 		}
 	| ALTER ColId SET column_compression
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_SetCompression),
+				Subtype: nodes.AT_SetCompression,
 				Name:    $2,
-				Def:     &nodes.String{Str: $4},
+				Def:     &nodes.String{Sval: $4},
 			}
 		}
 	/* SET/DROP EXPRESSION */
 	| ALTER COLUMN ColId SET EXPRESSION AS '(' a_expr ')'
 		{
+			requireVersion(pglex, PG17, "SET EXPRESSION", $<loc>4)
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_SetExpression),
+				Subtype: nodes.AT_SetExpression,
 				Name:    $3,
 				Def:     $8,
 			}
 		}
 	| ALTER ColId SET EXPRESSION AS '(' a_expr ')'
 		{
+			requireVersion(pglex, PG17, "SET EXPRESSION", $<loc>3)
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_SetExpression),
+				Subtype: nodes.AT_SetExpression,
 				Name:    $2,
 				Def:     $7,
 			}
@@ -3062,61 +3148,32 @@ alter_table_cmd:
 	| ALTER COLUMN ColId DROP EXPRESSION
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_DropExpression),
+				Subtype: nodes.AT_DropExpression,
 				Name:    $3,
 			}
 		}
 	| ALTER COLUMN ColId DROP EXPRESSION IF_P EXISTS
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype:    int(nodes.AT_DropExpression),
-				Name:       $3,
-				Missing_ok: true,
-			}
-		}
-	/* ADD GENERATED IDENTITY */
-	| ALTER COLUMN ColId ADD_P GENERATED ALWAYS AS IDENTITY_P
-		{
-			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AddIdentity),
-				Name:    $3,
-			}
-		}
-	| ALTER COLUMN ColId ADD_P GENERATED ALWAYS AS IDENTITY_P '(' OptSeqOptList ')'
-		{
-			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AddIdentity),
-				Name:    $3,
-			}
-		}
-	| ALTER COLUMN ColId ADD_P GENERATED BY DEFAULT AS IDENTITY_P
-		{
-			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AddIdentity),
-				Name:    $3,
-			}
-		}
-	| ALTER COLUMN ColId ADD_P GENERATED BY DEFAULT AS IDENTITY_P '(' OptSeqOptList ')'
-		{
-			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AddIdentity),
-				Name:    $3,
+				Subtype:   nodes.AT_DropExpression,
+				Name:      $3,
+				MissingOk: true,
 			}
 		}
 	/* DROP/SET IDENTITY */
 	| ALTER COLUMN ColId DROP IDENTITY_P
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_DropIdentity),
+				Subtype: nodes.AT_DropIdentity,
 				Name:    $3,
 			}
 		}
 	| ALTER COLUMN ColId DROP IDENTITY_P IF_P EXISTS
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype:    int(nodes.AT_DropIdentity),
-				Name:       $3,
-				Missing_ok: true,
+				Subtype:   nodes.AT_DropIdentity,
+				Name:      $3,
+				MissingOk: true,
 			}
 		}
 	/* ADD GENERATED identity */
@@ -3126,10 +3183,10 @@ alter_table_cmd:
 				Contype:       nodes.CONSTR_IDENTITY,
 				GeneratedWhen: byte($6),
 				Options:       $9,
-				Location:      -1,
+				Location:      nodes.ParseLoc($<loc>5),
 			}
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AddIdentity),
+				Subtype: nodes.AT_AddIdentity,
 				Name:    $3,
 				Def:     c,
 			}
@@ -3140,10 +3197,10 @@ alter_table_cmd:
 				Contype:       nodes.CONSTR_IDENTITY,
 				GeneratedWhen: byte($5),
 				Options:       $8,
-				Location:      -1,
+				Location:      nodes.ParseLoc($<loc>4),
 			}
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AddIdentity),
+				Subtype: nodes.AT_AddIdentity,
 				Name:    $2,
 				Def:     c,
 			}
@@ -3152,7 +3209,7 @@ alter_table_cmd:
 	| ALTER COLUMN ColId alter_identity_column_option_list
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_SetIdentity),
+				Subtype: nodes.AT_SetIdentity,
 				Name:    $3,
 				Def:     $4,
 			}
@@ -3161,7 +3218,7 @@ alter_table_cmd:
 	| ALTER COLUMN ColId SET '(' def_list ')'
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_SetOptions),
+				Subtype: nodes.AT_SetOptions,
 				Name:    $3,
 				Def:     $6,
 			}
@@ -3169,29 +3226,23 @@ alter_table_cmd:
 	| ALTER COLUMN ColId RESET '(' def_list ')'
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_ResetOptions),
+				Subtype: nodes.AT_ResetOptions,
 				Name:    $3,
 				Def:     $6,
 			}
 		}
-	/* SET WITH/WITHOUT OIDS (deprecated but still parsed) */
-	| SET WITH OIDS
-		{
-			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_DropOids), /* deprecated, reuse DropOids */
-			}
-		}
+	/* ALTER TABLE <name> SET WITHOUT OIDS, for backward compat */
 	| SET WITHOUT OIDS
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_DropOids),
+				Subtype: nodes.AT_DropOids,
 			}
 		}
 	/* SET TABLESPACE */
 	| SET TABLESPACE name
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_SetTableSpace),
+				Subtype: nodes.AT_SetTableSpace,
 				Name:    $3,
 			}
 		}
@@ -3199,16 +3250,16 @@ alter_table_cmd:
 	| alter_generic_options
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_GenericOptions),
+				Subtype: nodes.AT_GenericOptions,
 				Def:     $1,
 			}
 		}
 	/* OF typename */
 	| OF any_name
 		{
-			tn := makeTypeNameFromNameList($2)
+			tn := typeNameAt(makeTypeNameFromNameList($2).(*nodes.TypeName), $<loc>2)
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_AddOf),
+				Subtype: nodes.AT_AddOf,
 				Def:     tn,
 			}
 		}
@@ -3216,7 +3267,7 @@ alter_table_cmd:
 	| NOT OF
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype: int(nodes.AT_DropOf),
+				Subtype: nodes.AT_DropOf,
 			}
 		}
 	;
@@ -3228,8 +3279,16 @@ opt_drop_behavior:
 	;
 
 column_compression:
-	COMPRESSION ColId      { $$ = $2 }
-	| COMPRESSION DEFAULT  { $$ = "default" }
+	COMPRESSION ColId
+		{
+			requireVersion(pglex, PG14, "COMPRESSION", $<loc>1)
+			$$ = $2
+		}
+	| COMPRESSION DEFAULT
+		{
+			requireVersion(pglex, PG14, "COMPRESSION", $<loc>1)
+			$$ = "default"
+		}
 	;
 
 /*****************************************************************************
@@ -3279,11 +3338,10 @@ RenameStmt:
 	| ALTER TABLE relation_expr RENAME CONSTRAINT name TO name
 		{
 			$$ = &nodes.RenameStmt{
-				RenameType:   nodes.OBJECT_TABCONSTRAINT,
-				RelationType: nodes.OBJECT_TABLE,
-				Relation:     $3.(*nodes.RangeVar),
-				Subname:      $6,
-				Newname:      $8,
+				RenameType: nodes.OBJECT_TABCONSTRAINT,
+				Relation:   $3.(*nodes.RangeVar),
+				Subname:    $6,
+				Newname:    $8,
 			}
 		}
 	| ALTER TABLE IF_P EXISTS relation_expr RENAME COLUMN ColId TO name
@@ -3311,18 +3369,17 @@ RenameStmt:
 	| ALTER TABLE IF_P EXISTS relation_expr RENAME CONSTRAINT name TO name
 		{
 			$$ = &nodes.RenameStmt{
-				RenameType:   nodes.OBJECT_TABCONSTRAINT,
-				RelationType: nodes.OBJECT_TABLE,
-				Relation:     $5.(*nodes.RangeVar),
-				Subname:      $8,
-				Newname:      $10,
-				MissingOk:    true,
+				RenameType: nodes.OBJECT_TABCONSTRAINT,
+				Relation:   $5.(*nodes.RangeVar),
+				Subname:    $8,
+				Newname:    $10,
+				MissingOk:  true,
 			}
 		}
 	/* RENAME INDEX */
 	| ALTER INDEX qualified_name RENAME TO name
 		{
-			rv := makeRangeVarFromAnyName($3)
+			rv := makeRangeVarFromAnyName($3, $<loc>3)
 			$$ = &nodes.RenameStmt{
 				RenameType: nodes.OBJECT_INDEX,
 				Relation:   rv,
@@ -3331,7 +3388,7 @@ RenameStmt:
 		}
 	| ALTER INDEX IF_P EXISTS qualified_name RENAME TO name
 		{
-			rv := makeRangeVarFromAnyName($5)
+			rv := makeRangeVarFromAnyName($5, $<loc>5)
 			$$ = &nodes.RenameStmt{
 				RenameType: nodes.OBJECT_INDEX,
 				Relation:   rv,
@@ -3342,7 +3399,7 @@ RenameStmt:
 	/* RENAME SEQUENCE */
 	| ALTER SEQUENCE qualified_name RENAME TO name
 		{
-			rv := makeRangeVarFromAnyName($3)
+			rv := makeRangeVarFromAnyName($3, $<loc>3)
 			$$ = &nodes.RenameStmt{
 				RenameType: nodes.OBJECT_SEQUENCE,
 				Relation:   rv,
@@ -3351,7 +3408,7 @@ RenameStmt:
 		}
 	| ALTER SEQUENCE IF_P EXISTS qualified_name RENAME TO name
 		{
-			rv := makeRangeVarFromAnyName($5)
+			rv := makeRangeVarFromAnyName($5, $<loc>5)
 			$$ = &nodes.RenameStmt{
 				RenameType: nodes.OBJECT_SEQUENCE,
 				Relation:   rv,
@@ -3362,7 +3419,7 @@ RenameStmt:
 	/* RENAME VIEW */
 	| ALTER VIEW qualified_name RENAME TO name
 		{
-			rv := makeRangeVarFromAnyName($3)
+			rv := makeRangeVarFromAnyName($3, $<loc>3)
 			$$ = &nodes.RenameStmt{
 				RenameType: nodes.OBJECT_VIEW,
 				Relation:   rv,
@@ -3371,7 +3428,7 @@ RenameStmt:
 		}
 	| ALTER VIEW IF_P EXISTS qualified_name RENAME TO name
 		{
-			rv := makeRangeVarFromAnyName($5)
+			rv := makeRangeVarFromAnyName($5, $<loc>5)
 			$$ = &nodes.RenameStmt{
 				RenameType: nodes.OBJECT_VIEW,
 				Relation:   rv,
@@ -3381,7 +3438,7 @@ RenameStmt:
 		}
 	| ALTER VIEW qualified_name RENAME COLUMN ColId TO name
 		{
-			rv := makeRangeVarFromAnyName($3)
+			rv := makeRangeVarFromAnyName($3, $<loc>3)
 			$$ = &nodes.RenameStmt{
 				RenameType:   nodes.OBJECT_COLUMN,
 				RelationType: nodes.OBJECT_VIEW,
@@ -3392,7 +3449,7 @@ RenameStmt:
 		}
 	| ALTER VIEW qualified_name RENAME ColId TO name
 		{
-			rv := makeRangeVarFromAnyName($3)
+			rv := makeRangeVarFromAnyName($3, $<loc>3)
 			$$ = &nodes.RenameStmt{
 				RenameType:   nodes.OBJECT_COLUMN,
 				RelationType: nodes.OBJECT_VIEW,
@@ -3404,7 +3461,7 @@ RenameStmt:
 	/* RENAME MATERIALIZED VIEW */
 	| ALTER MATERIALIZED VIEW qualified_name RENAME TO name
 		{
-			rv := makeRangeVarFromAnyName($4)
+			rv := makeRangeVarFromAnyName($4, $<loc>4)
 			$$ = &nodes.RenameStmt{
 				RenameType: nodes.OBJECT_MATVIEW,
 				Relation:   rv,
@@ -3413,7 +3470,7 @@ RenameStmt:
 		}
 	| ALTER MATERIALIZED VIEW IF_P EXISTS qualified_name RENAME TO name
 		{
-			rv := makeRangeVarFromAnyName($6)
+			rv := makeRangeVarFromAnyName($6, $<loc>6)
 			$$ = &nodes.RenameStmt{
 				RenameType: nodes.OBJECT_MATVIEW,
 				Relation:   rv,
@@ -3423,7 +3480,7 @@ RenameStmt:
 		}
 	| ALTER MATERIALIZED VIEW qualified_name RENAME COLUMN ColId TO name
 		{
-			rv := makeRangeVarFromAnyName($4)
+			rv := makeRangeVarFromAnyName($4, $<loc>4)
 			$$ = &nodes.RenameStmt{
 				RenameType:   nodes.OBJECT_COLUMN,
 				RelationType: nodes.OBJECT_MATVIEW,
@@ -3434,7 +3491,7 @@ RenameStmt:
 		}
 	| ALTER MATERIALIZED VIEW qualified_name RENAME ColId TO name
 		{
-			rv := makeRangeVarFromAnyName($4)
+			rv := makeRangeVarFromAnyName($4, $<loc>4)
 			$$ = &nodes.RenameStmt{
 				RenameType:   nodes.OBJECT_COLUMN,
 				RelationType: nodes.OBJECT_MATVIEW,
@@ -3527,7 +3584,7 @@ RenameStmt:
 		{
 			$$ = &nodes.RenameStmt{
 				RenameType: nodes.OBJECT_FOREIGN_SERVER,
-				Object:     &nodes.String{Str: $3},
+				Object:     &nodes.String{Sval: $3},
 				Newname:    $6,
 			}
 		}
@@ -3536,7 +3593,7 @@ RenameStmt:
 		{
 			$$ = &nodes.RenameStmt{
 				RenameType: nodes.OBJECT_FDW,
-				Object:     &nodes.String{Str: $5},
+				Object:     &nodes.String{Sval: $5},
 				Newname:    $8,
 			}
 		}
@@ -3551,10 +3608,12 @@ RenameStmt:
 		}
 	| ALTER TYPE_P any_name RENAME ATTRIBUTE name TO name opt_drop_behavior
 		{
+			rv := makeRangeVarFromAnyName($3, $<loc>3)
+			rv.Inh = false
 			$$ = &nodes.RenameStmt{
 				RenameType:   nodes.OBJECT_ATTRIBUTE,
 				RelationType: nodes.OBJECT_TYPE,
-				Relation:     makeRangeVarFromAnyName($3),
+				Relation:     rv,
 				Subname:      $6,
 				Newname:      $8,
 				Behavior:     nodes.DropBehavior($9),
@@ -3625,7 +3684,7 @@ RenameStmt:
 		{
 			$$ = &nodes.RenameStmt{
 				RenameType: nodes.OBJECT_OPCLASS,
-				Object:     prependList(&nodes.String{Str: $6}, $4),
+				Object:     prependList(&nodes.String{Sval: $6}, $4),
 				Newname:    $9,
 			}
 		}
@@ -3633,7 +3692,7 @@ RenameStmt:
 		{
 			$$ = &nodes.RenameStmt{
 				RenameType: nodes.OBJECT_OPFAMILY,
-				Object:     prependList(&nodes.String{Str: $6}, $4),
+				Object:     prependList(&nodes.String{Sval: $6}, $4),
 				Newname:    $9,
 			}
 		}
@@ -3675,7 +3734,7 @@ RenameStmt:
 		{
 			$$ = &nodes.RenameStmt{
 				RenameType: nodes.OBJECT_PUBLICATION,
-				Object:     &nodes.String{Str: $3},
+				Object:     &nodes.String{Sval: $3},
 				Newname:    $6,
 			}
 		}
@@ -3683,7 +3742,7 @@ RenameStmt:
 		{
 			$$ = &nodes.RenameStmt{
 				RenameType: nodes.OBJECT_SUBSCRIPTION,
-				Object:     &nodes.String{Str: $3},
+				Object:     &nodes.String{Sval: $3},
 				Newname:    $6,
 			}
 		}
@@ -3692,7 +3751,7 @@ RenameStmt:
 		{
 			$$ = &nodes.RenameStmt{
 				RenameType: nodes.OBJECT_RULE,
-				Relation:   makeRangeVarFromAnyName($5),
+				Relation:   makeRangeVarFromAnyName($5, $<loc>5),
 				Subname:    $3,
 				Newname:    $8,
 			}
@@ -3702,7 +3761,7 @@ RenameStmt:
 		{
 			$$ = &nodes.RenameStmt{
 				RenameType: nodes.OBJECT_TRIGGER,
-				Relation:   makeRangeVarFromAnyName($5),
+				Relation:   makeRangeVarFromAnyName($5, $<loc>5),
 				Subname:    $3,
 				Newname:    $8,
 			}
@@ -3712,7 +3771,7 @@ RenameStmt:
 		{
 			$$ = &nodes.RenameStmt{
 				RenameType: nodes.OBJECT_EVENT_TRIGGER,
-				Object:     &nodes.String{Str: $4},
+				Object:     &nodes.String{Sval: $4},
 				Newname:    $7,
 			}
 		}
@@ -3730,7 +3789,7 @@ RenameStmt:
 		{
 			$$ = &nodes.RenameStmt{
 				RenameType: nodes.OBJECT_POLICY,
-				Relation:   makeRangeVarFromAnyName($5),
+				Relation:   makeRangeVarFromAnyName($5, $<loc>5),
 				Subname:    $3,
 				Newname:    $8,
 			}
@@ -3740,7 +3799,7 @@ RenameStmt:
 		{
 			$$ = &nodes.RenameStmt{
 				RenameType: nodes.OBJECT_LANGUAGE,
-				Object:     &nodes.String{Str: $4},
+				Object:     &nodes.String{Sval: $4},
 				Newname:    $7,
 			}
 		}
@@ -3789,278 +3848,227 @@ DropStmt:
 		{
 			$$ = &nodes.DropStmt{
 				Objects:    $5,
-				RemoveType: int($2),
-				Behavior:   int($6),
-				Missing_ok: true,
+				RemoveType: nodes.ObjectType($2),
+				Behavior:   nodes.DropBehavior($6),
+				MissingOk:  true,
 			}
 		}
 	| DROP object_type_any_name any_name_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
 				Objects:    $3,
-				RemoveType: int($2),
-				Behavior:   int($4),
+				RemoveType: nodes.ObjectType($2),
+				Behavior:   nodes.DropBehavior($4),
 			}
 		}
 	/* DROP TYPE / DROP DOMAIN */
-	| DROP TYPE_P any_name_list opt_drop_behavior
-		{
-			$$ = &nodes.DropStmt{
-				Objects:    $3,
-				RemoveType: int(nodes.OBJECT_TYPE),
-				Behavior:   int($4),
-			}
-		}
-	| DROP TYPE_P IF_P EXISTS any_name_list opt_drop_behavior
-		{
-			$$ = &nodes.DropStmt{
-				Objects:    $5,
-				RemoveType: int(nodes.OBJECT_TYPE),
-				Behavior:   int($6),
-				Missing_ok: true,
-			}
-		}
-	| DROP DOMAIN_P any_name_list opt_drop_behavior
+	| DROP TYPE_P type_name_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
 				Objects:    $3,
-				RemoveType: int(nodes.OBJECT_DOMAIN),
-				Behavior:   int($4),
+				RemoveType: nodes.OBJECT_TYPE,
+				Behavior:   nodes.DropBehavior($4),
 			}
 		}
-	| DROP DOMAIN_P IF_P EXISTS any_name_list opt_drop_behavior
+	| DROP TYPE_P IF_P EXISTS type_name_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
 				Objects:    $5,
-				RemoveType: int(nodes.OBJECT_DOMAIN),
-				Behavior:   int($6),
-				Missing_ok: true,
-			}
-		}
-	/* DROP SCHEMA / EXTENSION / LANGUAGE / PUBLICATION / SUBSCRIPTION */
-	| DROP SCHEMA name_list opt_drop_behavior
-		{
-			$$ = &nodes.DropStmt{
-				Objects:    makeNameListAsAnyNameList($3),
-				RemoveType: int(nodes.OBJECT_SCHEMA),
-				Behavior:   int($4),
-			}
-		}
-	| DROP SCHEMA IF_P EXISTS name_list opt_drop_behavior
-		{
-			$$ = &nodes.DropStmt{
-				Objects:    makeNameListAsAnyNameList($5),
-				RemoveType: int(nodes.OBJECT_SCHEMA),
-				Behavior:   int($6),
-				Missing_ok: true,
-			}
-		}
-	| DROP EXTENSION name_list opt_drop_behavior
-		{
-			$$ = &nodes.DropStmt{
-				Objects:    makeNameListAsAnyNameList($3),
-				RemoveType: int(nodes.OBJECT_EXTENSION),
-				Behavior:   int($4),
-			}
-		}
-	| DROP EXTENSION IF_P EXISTS name_list opt_drop_behavior
-		{
-			$$ = &nodes.DropStmt{
-				Objects:    makeNameListAsAnyNameList($5),
-				RemoveType: int(nodes.OBJECT_EXTENSION),
-				Behavior:   int($6),
-				Missing_ok: true,
+				RemoveType: nodes.OBJECT_TYPE,
+				Behavior:   nodes.DropBehavior($6),
+				MissingOk:  true,
 			}
 		}
-	/* DROP FUNCTION / PROCEDURE / ROUTINE / AGGREGATE */
-	| DROP FUNCTION any_name_list opt_drop_behavior
+	| DROP DOMAIN_P type_name_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
 				Objects:    $3,
-				RemoveType: int(nodes.OBJECT_FUNCTION),
-				Behavior:   int($4),
+				RemoveType: nodes.OBJECT_DOMAIN,
+				Behavior:   nodes.DropBehavior($4),
 			}
 		}
-	| DROP FUNCTION IF_P EXISTS any_name_list opt_drop_behavior
+	| DROP DOMAIN_P IF_P EXISTS type_name_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
 				Objects:    $5,
-				RemoveType: int(nodes.OBJECT_FUNCTION),
-				Behavior:   int($6),
-				Missing_ok: true,
+				RemoveType: nodes.OBJECT_DOMAIN,
+				Behavior:   nodes.DropBehavior($6),
+				MissingOk:  true,
 			}
 		}
-	| DROP PROCEDURE any_name_list opt_drop_behavior
+	/* DROP ACCESS METHOD */
+	| DROP ACCESS METHOD name_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    $3,
-				RemoveType: int(nodes.OBJECT_PROCEDURE),
-				Behavior:   int($4),
+				Objects:    $4,
+				RemoveType: nodes.OBJECT_ACCESS_METHOD,
+				Behavior:   nodes.DropBehavior($5),
 			}
 		}
-	| DROP PROCEDURE IF_P EXISTS any_name_list opt_drop_behavior
+	| DROP ACCESS METHOD IF_P EXISTS name_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    $5,
-				RemoveType: int(nodes.OBJECT_PROCEDURE),
-				Behavior:   int($6),
-				Missing_ok: true,
+				Objects:    $6,
+				RemoveType: nodes.OBJECT_ACCESS_METHOD,
+				Behavior:   nodes.DropBehavior($7),
+				MissingOk:  true,
 			}
 		}
-	| DROP ROUTINE any_name_list opt_drop_behavior
+	/* DROP SCHEMA / EXTENSION / LANGUAGE / PUBLICATION / SUBSCRIPTION */
+	| DROP SCHEMA name_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
 				Objects:    $3,
-				RemoveType: int(nodes.OBJECT_ROUTINE),
-				Behavior:   int($4),
+				RemoveType: nodes.OBJECT_SCHEMA,
+				Behavior:   nodes.DropBehavior($4),
 			}
 		}
-	| DROP ROUTINE IF_P EXISTS any_name_list opt_drop_behavior
+	| DROP SCHEMA IF_P EXISTS name_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
 				Objects:    $5,
-				RemoveType: int(nodes.OBJECT_ROUTINE),
-				Behavior:   int($6),
-				Missing_ok: true,
+				RemoveType: nodes.OBJECT_SCHEMA,
+				Behavior:   nodes.DropBehavior($6),
+				MissingOk:  true,
 			}
 		}
-	| DROP AGGREGATE any_name_list opt_drop_behavior
+	| DROP EXTENSION name_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
 				Objects:    $3,
-				RemoveType: int(nodes.OBJECT_AGGREGATE),
-				Behavior:   int($4),
+				RemoveType: nodes.OBJECT_EXTENSION,
+				Behavior:   nodes.DropBehavior($4),
 			}
 		}
-	| DROP AGGREGATE IF_P EXISTS any_name_list opt_drop_behavior
+	| DROP EXTENSION IF_P EXISTS name_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
 				Objects:    $5,
-				RemoveType: int(nodes.OBJECT_AGGREGATE),
-				Behavior:   int($6),
-				Missing_ok: true,
+				RemoveType: nodes.OBJECT_EXTENSION,
+				Behavior:   nodes.DropBehavior($6),
+				MissingOk:  true,
 			}
 		}
 	/* DROP TRIGGER / POLICY / RULE ON relation */
 	| DROP TRIGGER name ON any_name opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    &nodes.List{Items: []nodes.Node{$5}},
-				RemoveType: int(nodes.OBJECT_TRIGGER),
-				Behavior:   int($6),
+				Objects:    &nodes.List{Items: []nodes.Node{appendList($5, &nodes.String{Sval: $3})}},
+				RemoveType: nodes.OBJECT_TRIGGER,
+				Behavior:   nodes.DropBehavior($6),
 			}
 		}
 	| DROP TRIGGER IF_P EXISTS name ON any_name opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    &nodes.List{Items: []nodes.Node{$7}},
-				RemoveType: int(nodes.OBJECT_TRIGGER),
-				Behavior:   int($8),
-				Missing_ok: true,
+				Objects:    &nodes.List{Items: []nodes.Node{appendList($7, &nodes.String{Sval: $5})}},
+				RemoveType: nodes.OBJECT_TRIGGER,
+				Behavior:   nodes.DropBehavior($8),
+				MissingOk:  true,
 			}
 		}
 	| DROP POLICY name ON any_name opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    &nodes.List{Items: []nodes.Node{$5}},
-				RemoveType: int(nodes.OBJECT_POLICY),
-				Behavior:   int($6),
+				Objects:    &nodes.List{Items: []nodes.Node{appendList($5, &nodes.String{Sval: $3})}},
+				RemoveType: nodes.OBJECT_POLICY,
+				Behavior:   nodes.DropBehavior($6),
 			}
 		}
 	| DROP POLICY IF_P EXISTS name ON any_name opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    &nodes.List{Items: []nodes.Node{$7}},
-				RemoveType: int(nodes.OBJECT_POLICY),
-				Behavior:   int($8),
-				Missing_ok: true,
+				Objects:    &nodes.List{Items: []nodes.Node{appendList($7, &nodes.String{Sval: $5})}},
+				RemoveType: nodes.OBJECT_POLICY,
+				Behavior:   nodes.DropBehavior($8),
+				MissingOk:  true,
 			}
 		}
 	| DROP RULE name ON any_name opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    &nodes.List{Items: []nodes.Node{$5}},
-				RemoveType: int(nodes.OBJECT_RULE),
-				Behavior:   int($6),
+				Objects:    &nodes.List{Items: []nodes.Node{appendList($5, &nodes.String{Sval: $3})}},
+				RemoveType: nodes.OBJECT_RULE,
+				Behavior:   nodes.DropBehavior($6),
 			}
 		}
 	| DROP RULE IF_P EXISTS name ON any_name opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    &nodes.List{Items: []nodes.Node{$7}},
-				RemoveType: int(nodes.OBJECT_RULE),
-				Behavior:   int($8),
-				Missing_ok: true,
+				Objects:    &nodes.List{Items: []nodes.Node{appendList($7, &nodes.String{Sval: $5})}},
+				RemoveType: nodes.OBJECT_RULE,
+				Behavior:   nodes.DropBehavior($8),
+				MissingOk:  true,
 			}
 		}
 	/* DROP EVENT TRIGGER */
 	| DROP EVENT TRIGGER name opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    makeNameListAsAnyNameList(&nodes.List{Items: []nodes.Node{&nodes.String{Str: $4}}}),
-				RemoveType: int(nodes.OBJECT_EVENT_TRIGGER),
-				Behavior:   int($5),
+				Objects:    makeList(&nodes.String{Sval: $4}),
+				RemoveType: nodes.OBJECT_EVENT_TRIGGER,
+				Behavior:   nodes.DropBehavior($5),
 			}
 		}
 	| DROP EVENT TRIGGER IF_P EXISTS name opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    makeNameListAsAnyNameList(&nodes.List{Items: []nodes.Node{&nodes.String{Str: $6}}}),
-				RemoveType: int(nodes.OBJECT_EVENT_TRIGGER),
-				Behavior:   int($7),
-				Missing_ok: true,
+				Objects:    makeList(&nodes.String{Sval: $6}),
+				RemoveType: nodes.OBJECT_EVENT_TRIGGER,
+				Behavior:   nodes.DropBehavior($7),
+				MissingOk:  true,
 			}
 		}
 	/* DROP LANGUAGE / PUBLICATION / SUBSCRIPTION */
 	| DROP LANGUAGE name opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    makeNameListAsAnyNameList(&nodes.List{Items: []nodes.Node{&nodes.String{Str: $3}}}),
-				RemoveType: int(nodes.OBJECT_LANGUAGE),
-				Behavior:   int($4),
+				Objects:    makeList(&nodes.String{Sval: $3}),
+				RemoveType: nodes.OBJECT_LANGUAGE,
+				Behavior:   nodes.DropBehavior($4),
 			}
 		}
 	| DROP LANGUAGE IF_P EXISTS name opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    makeNameListAsAnyNameList(&nodes.List{Items: []nodes.Node{&nodes.String{Str: $5}}}),
-				RemoveType: int(nodes.OBJECT_LANGUAGE),
-				Behavior:   int($6),
-				Missing_ok: true,
+				Objects:    makeList(&nodes.String{Sval: $5}),
+				RemoveType: nodes.OBJECT_LANGUAGE,
+				Behavior:   nodes.DropBehavior($6),
+				MissingOk:  true,
 			}
 		}
 	| DROP PUBLICATION name_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    makeNameListAsAnyNameList($3),
-				RemoveType: int(nodes.OBJECT_PUBLICATION),
-				Behavior:   int($4),
+				Objects:    $3,
+				RemoveType: nodes.OBJECT_PUBLICATION,
+				Behavior:   nodes.DropBehavior($4),
 			}
 		}
 	| DROP PUBLICATION IF_P EXISTS name_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    makeNameListAsAnyNameList($5),
-				RemoveType: int(nodes.OBJECT_PUBLICATION),
-				Behavior:   int($6),
-				Missing_ok: true,
+				Objects:    $5,
+				RemoveType: nodes.OBJECT_PUBLICATION,
+				Behavior:   nodes.DropBehavior($6),
+				MissingOk:  true,
 			}
 		}
 	| DROP SUBSCRIPTION name_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    makeNameListAsAnyNameList($3),
-				RemoveType: int(nodes.OBJECT_SUBSCRIPTION),
-				Behavior:   int($4),
+				Objects:    $3,
+				RemoveType: nodes.OBJECT_SUBSCRIPTION,
+				Behavior:   nodes.DropBehavior($4),
 			}
 		}
 	| DROP SUBSCRIPTION IF_P EXISTS name_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    makeNameListAsAnyNameList($5),
-				RemoveType: int(nodes.OBJECT_SUBSCRIPTION),
-				Behavior:   int($6),
-				Missing_ok: true,
+				Objects:    $5,
+				RemoveType: nodes.OBJECT_SUBSCRIPTION,
+				Behavior:   nodes.DropBehavior($6),
+				MissingOk:  true,
 			}
 		}
 	/* DROP INDEX CONCURRENTLY */
@@ -4068,8 +4076,8 @@ DropStmt:
 		{
 			$$ = &nodes.DropStmt{
 				Objects:    $4,
-				RemoveType: int(nodes.OBJECT_INDEX),
-				Behavior:   int($5),
+				RemoveType: nodes.OBJECT_INDEX,
+				Behavior:   nodes.DropBehavior($5),
 				Concurrent: true,
 			}
 		}
@@ -4077,9 +4085,9 @@ DropStmt:
 		{
 			$$ = &nodes.DropStmt{
 				Objects:    $6,
-				RemoveType: int(nodes.OBJECT_INDEX),
-				Behavior:   int($7),
-				Missing_ok: true,
+				RemoveType: nodes.OBJECT_INDEX,
+				Behavior:   nodes.DropBehavior($7),
+				MissingOk:  true,
 				Concurrent: true,
 			}
 		}
@@ -4087,44 +4095,44 @@ DropStmt:
 	| DROP FOREIGN DATA_P WRAPPER name_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    makeNameListAsAnyNameList($5),
-				RemoveType: int(nodes.OBJECT_FDW),
-				Behavior:   int($6),
+				Objects:    $5,
+				RemoveType: nodes.OBJECT_FDW,
+				Behavior:   nodes.DropBehavior($6),
 			}
 		}
 	| DROP FOREIGN DATA_P WRAPPER IF_P EXISTS name_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    makeNameListAsAnyNameList($7),
-				RemoveType: int(nodes.OBJECT_FDW),
-				Behavior:   int($8),
-				Missing_ok: true,
+				Objects:    $7,
+				RemoveType: nodes.OBJECT_FDW,
+				Behavior:   nodes.DropBehavior($8),
+				MissingOk:  true,
 			}
 		}
 	| DROP SERVER name_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    makeNameListAsAnyNameList($3),
-				RemoveType: int(nodes.OBJECT_FOREIGN_SERVER),
-				Behavior:   int($4),
+				Objects:    $3,
+				RemoveType: nodes.OBJECT_FOREIGN_SERVER,
+				Behavior:   nodes.DropBehavior($4),
 			}
 		}
 	| DROP SERVER IF_P EXISTS name_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    makeNameListAsAnyNameList($5),
-				RemoveType: int(nodes.OBJECT_FOREIGN_SERVER),
-				Behavior:   int($6),
-				Missing_ok: true,
+				Objects:    $5,
+				RemoveType: nodes.OBJECT_FOREIGN_SERVER,
+				Behavior:   nodes.DropBehavior($6),
+				MissingOk:  true,
 			}
 		}
 	/* DROP OWNED BY */
 	| DROP OWNED BY name_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    makeNameListAsAnyNameList($4),
-				RemoveType: int(nodes.OBJECT_ROLE),
-				Behavior:   int($5),
+				Objects:    $4,
+				RemoveType: nodes.OBJECT_ROLE,
+				Behavior:   nodes.DropBehavior($5),
 			}
 		}
 	;
@@ -4143,7 +4151,6 @@ object_type_any_name:
 	| TEXT_P SEARCH DICTIONARY { $$ = int64(nodes.OBJECT_TSDICTIONARY) }
 	| TEXT_P SEARCH TEMPLATE { $$ = int64(nodes.OBJECT_TSTEMPLATE) }
 	| TEXT_P SEARCH CONFIGURATION { $$ = int64(nodes.OBJECT_TSCONFIGURATION) }
-	| ACCESS METHOD     { $$ = int64(nodes.OBJECT_ACCESS_METHOD) }
 	;
 
 any_name_list:
@@ -4158,6 +4165,13 @@ any_name_list:
 		}
 	;
 
+type_name_list:
+	Typename
+		{ $$ = makeList($1) }
+	| type_name_list ',' Typename
+		{ $$ = appendList($1, $3) }
+	;
+
 /*****************************************************************************
  *
  *      CREATE INDEX statement
@@ -4174,7 +4188,7 @@ IndexStmt:
 				Relation:             rv,
 				AccessMethod:         $8,
 				IndexParams:          $10,
-				Nulls_not_distinct:   $12,
+				NullsNotDistinct:     $12,
 				IndexIncludingParams: $13,
 				Options:              $14,
 				TableSpace:           $15,
@@ -4192,7 +4206,7 @@ IndexStmt:
 				Relation:             rv,
 				AccessMethod:         $11,
 				IndexParams:          $13,
-				Nulls_not_distinct:   $15,
+				NullsNotDistinct:     $15,
 				IndexIncludingParams: $16,
 				Options:              $17,
 				TableSpace:           $18,
@@ -4235,7 +4249,7 @@ opt_single_name:
 
 access_method_clause:
 	USING name  { $$ = $2 }
-	| /* EMPTY */ { $$ = "" }
+	| /* EMPTY */ { $$ = "btree" } /* DEFAULT_INDEX_TYPE */
 	;
 
 index_params:
@@ -4327,20 +4341,20 @@ ViewStmt:
 	CREATE OptTemp VIEW qualified_name opt_column_list opt_reloptions
 	AS SelectStmt opt_check_option
 		{
-			rv := makeRangeVar($4).(*nodes.RangeVar)
+			rv := makeRangeVar($4, $<loc>4).(*nodes.RangeVar)
 			rv.Relpersistence = relpersistenceForTemp($2)
 			$$ = &nodes.ViewStmt{
 				View:            rv,
 				Aliases:         $5,
 				Options:         $6,
 				Query:           $8,
-				WithCheckOption: int($9),
+				WithCheckOption: nodes.ViewCheckOption($9),
 			}
 		}
 	| CREATE OR REPLACE OptTemp VIEW qualified_name opt_column_list opt_reloptions
 	AS SelectStmt opt_check_option
 		{
-			rv := makeRangeVar($6).(*nodes.RangeVar)
+			rv := makeRangeVar($6, $<loc>6).(*nodes.RangeVar)
 			rv.Relpersistence = relpersistenceForTemp($4)
 			$$ = &nodes.ViewStmt{
 				View:            rv,
@@ -4348,81 +4362,42 @@ ViewStmt:
 				Options:         $8,
 				Query:           $10,
 				Replace:         true,
-				WithCheckOption: int($11),
+				WithCheckOption: nodes.ViewCheckOption($11),
 			}
 		}
 	| CREATE OptTemp RECURSIVE VIEW qualified_name '(' columnList ')' opt_reloptions
 	AS SelectStmt opt_check_option
 		{
-			rv := makeRangeVar($5).(*nodes.RangeVar)
+			rv := makeRangeVar($5, $<loc>5).(*nodes.RangeVar)
 			rv.Relpersistence = relpersistenceForTemp($2)
-			/* Create a WITH RECURSIVE CTE wrapper */
-			cte := &nodes.CommonTableExpr{
-				Ctename:      rv.Relname,
-				Ctequery:     $11,
-				Aliascolnames:  $7,
-			}
-			wc := &nodes.WithClause{
-				Ctes:      makeList(cte),
-				Recursive: true,
-			}
-			cr := &nodes.ColumnRef{
-				Fields: &nodes.List{Items: []nodes.Node{&nodes.A_Star{}}},
-				Location: -1,
-			}
-			rt := &nodes.ResTarget{Val: cr, Location: -1}
-			fromRv := &nodes.RangeVar{Relname: rv.Relname, Inh: true, Relpersistence: 'p'}
-			sel := &nodes.SelectStmt{
-				TargetList: makeList(rt),
-				FromClause: makeList(fromRv),
-				WithClause: wc,
-			}
+			sel := makeRecursiveViewSelect(rv.Relname, $7, $11)
 			$$ = &nodes.ViewStmt{
 				View:            rv,
 				Aliases:         $7,
 				Options:         $9,
 				Query:           sel,
-				WithCheckOption: int($12),
+				WithCheckOption: nodes.ViewCheckOption($12),
 			}
 		}
 	| CREATE OR REPLACE OptTemp RECURSIVE VIEW qualified_name '(' columnList ')' opt_reloptions
 	AS SelectStmt opt_check_option
 		{
-			rv := makeRangeVar($7).(*nodes.RangeVar)
+			rv := makeRangeVar($7, $<loc>7).(*nodes.RangeVar)
 			rv.Relpersistence = relpersistenceForTemp($4)
-			cte := &nodes.CommonTableExpr{
-				Ctename:      rv.Relname,
-				Ctequery:     $13,
-				Aliascolnames:  $9,
-			}
-			wc := &nodes.WithClause{
-				Ctes:      makeList(cte),
-				Recursive: true,
-			}
-			cr := &nodes.ColumnRef{
-				Fields: &nodes.List{Items: []nodes.Node{&nodes.A_Star{}}},
-				Location: -1,
-			}
-			rt := &nodes.ResTarget{Val: cr, Location: -1}
-			fromRv := &nodes.RangeVar{Relname: rv.Relname, Inh: true, Relpersistence: 'p'}
-			sel := &nodes.SelectStmt{
-				TargetList: makeList(rt),
-				FromClause: makeList(fromRv),
-				WithClause: wc,
-			}
+			sel := makeRecursiveViewSelect(rv.Relname, $9, $13)
 			$$ = &nodes.ViewStmt{
 				View:            rv,
 				Aliases:         $9,
 				Options:         $11,
 				Query:           sel,
 				Replace:         true,
-				WithCheckOption: int($14),
+				WithCheckOption: nodes.ViewCheckOption($14),
 			}
 		}
 	;
 
 opt_check_option:
-	WITH CHECK OPTION              { $$ = int64(VIEW_CHECK_OPTION_LOCAL) }
+	WITH CHECK OPTION              { $$ = int64(VIEW_CHECK_OPTION_CASCADED) }
 	| WITH CASCADED CHECK OPTION   { $$ = int64(VIEW_CHECK_OPTION_CASCADED) }
 	| WITH LOCAL CHECK OPTION      { $$ = int64(VIEW_CHECK_OPTION_LOCAL) }
 	| /* EMPTY */                  { $$ = int64(VIEW_CHECK_OPTION_NONE) }
@@ -4439,12 +4414,12 @@ CreateFunctionStmt:
 	RETURNS func_return opt_createfunc_opt_list opt_routine_body
 		{
 			n := &nodes.CreateFunctionStmt{
-				IsOrReplace: $2,
-				Funcname:    $4,
-				Parameters:  $5,
-				ReturnType:  $7,
-				Options:     $8,
-				SqlBody:     $9,
+				Replace:    $2,
+				Funcname:   $4,
+				Parameters: $5,
+				ReturnType: $7,
+				Options:    $8,
+				SqlBody:    $9,
 			}
 			$$ = n
 		}
@@ -4454,12 +4429,12 @@ CreateFunctionStmt:
 			/* RETURNS TABLE(...) adds table columns to parameter list */
 			params := concatLists($5, $9)
 			n := &nodes.CreateFunctionStmt{
-				IsOrReplace: $2,
-				Funcname:    $4,
-				Parameters:  params,
-				ReturnType:  &nodes.TypeName{Names: makeFuncName("pg_catalog", "record"), Location: -1},
-				Options:     $11,
-				SqlBody:     $12,
+				Replace:    $2,
+				Funcname:   $4,
+				Parameters: params,
+				ReturnType: typeNameAt(tableFuncTypeName($9), $<loc>7),
+				Options:    $11,
+				SqlBody:    $12,
 			}
 			$$ = n
 		}
@@ -4467,11 +4442,11 @@ CreateFunctionStmt:
 	opt_createfunc_opt_list opt_routine_body
 		{
 			n := &nodes.CreateFunctionStmt{
-				IsOrReplace: $2,
-				Funcname:    $4,
-				Parameters:  $5,
-				Options:     $6,
-				SqlBody:     $7,
+				Replace:    $2,
+				Funcname:   $4,
+				Parameters: $5,
+				Options:    $6,
+				SqlBody:    $7,
 			}
 			$$ = n
 		}
@@ -4479,13 +4454,13 @@ CreateFunctionStmt:
 	opt_createfunc_opt_list opt_routine_body
 		{
 			n := &nodes.CreateFunctionStmt{
-				IsOrReplace: $2,
+				IsProcedure: true,
+				Replace:     $2,
 				Funcname:    $4,
 				Parameters:  $5,
 				Options:     $6,
 				SqlBody:     $7,
 			}
-			n.Options = appendList(n.Options, &nodes.DefElem{Defname: "isProcedure", Arg: &nodes.Integer{Ival: 1}})
 			$$ = n
 		}
 	;
@@ -4546,7 +4521,7 @@ func_arg:
 			$$ = &nodes.FunctionParameter{
 				Name:    $1,
 				ArgType: $2,
-				Mode:    nodes.FUNC_PARAM_IN,
+				Mode:    nodes.FUNC_PARAM_DEFAULT,
 			}
 		}
 	| arg_class func_type
@@ -4560,7 +4535,7 @@ func_arg:
 		{
 			$$ = &nodes.FunctionParameter{
 				ArgType: $1,
-				Mode:    nodes.FUNC_PARAM_IN,
+				Mode:    nodes.FUNC_PARAM_DEFAULT,
 			}
 		}
 	;
@@ -4603,19 +4578,19 @@ func_type:
 	Typename { $$ = $1 }
 	| type_function_name attrs '%' TYPE_P
 		{
-			names := prependList(&nodes.String{Str: $1}, $2)
+			names := prependList(&nodes.String{Sval: $1}, $2)
 			tn := makeTypeNameFromNameList(names).(*nodes.TypeName)
 			tn.PctType = true
-			tn.Location = -1
+			tn.Location = nodes.ParseLoc($<loc>1)
 			$$ = tn
 		}
 	| SETOF type_function_name attrs '%' TYPE_P
 		{
-			names := prependList(&nodes.String{Str: $2}, $3)
+			names := prependList(&nodes.String{Sval: $2}, $3)
 			tn := makeTypeNameFromNameList(names).(*nodes.TypeName)
 			tn.PctType = true
 			tn.Setof = true
-			tn.Location = -1
+			tn.Location = nodes.ParseLoc($<loc>2)
 			$$ = tn
 		}
 	;
@@ -4635,10 +4610,12 @@ createfunc_opt_list:
 opt_routine_body:
 	ReturnStmt
 		{
+			requireVersion(pglex, PG14, "SQL-standard function body", $<loc>1)
 			$$ = $1
 		}
 	| BEGIN_P ATOMIC routine_body_stmt_list END_P
 		{
+			requireVersion(pglex, PG14, "SQL-standard function body", $<loc>1)
 			/* A compound statement stored as a single-item list containing the stmt list */
 			$$ = makeList($3)
 		}
@@ -4687,29 +4664,33 @@ createfunc_opt_item:
 	AS func_as
 		{
 			$$ = &nodes.DefElem{
-				Defname: "as",
-				Arg:     $2,
+				Defname:  "as",
+				Arg:      $2,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| LANGUAGE NonReservedWord_or_Sconst
 		{
 			$$ = &nodes.DefElem{
-				Defname: "language",
-				Arg:     &nodes.String{Str: $2},
+				Defname:  "language",
+				Arg:      &nodes.String{Sval: $2},
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| TRANSFORM transform_type_list
 		{
 			$$ = &nodes.DefElem{
-				Defname: "transform",
-				Arg:     $2,
+				Defname:  "transform",
+				Arg:      $2,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| WINDOW
 		{
 			$$ = &nodes.DefElem{
-				Defname: "window",
-				Arg:     &nodes.Integer{Ival: 1},
+				Defname:  "window",
+				Arg:      &nodes.Boolean{Boolval: true},
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| common_func_opt_item { $$ = $1 }
@@ -4717,9 +4698,9 @@ createfunc_opt_item:
 
 func_as:
 	Sconst
-		{ $$ = makeList(&nodes.String{Str: $1}) }
+		{ $$ = makeList(&nodes.String{Sval: $1}) }
 	| Sconst ',' Sconst
-		{ $$ = makeList2(&nodes.String{Str: $1}, &nodes.String{Str: $3}) }
+		{ $$ = makeList2(&nodes.String{Sval: $1}, &nodes.String{Sval: $3}) }
 	;
 
 transform_type_list:
@@ -4733,113 +4714,129 @@ common_func_opt_item:
 	IMMUTABLE
 		{
 			$$ = &nodes.DefElem{
-				Defname: "volatility",
-				Arg:     &nodes.String{Str: "immutable"},
+				Defname:  "volatility",
+				Arg:      &nodes.String{Sval: "immutable"},
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| STABLE
 		{
 			$$ = &nodes.DefElem{
-				Defname: "volatility",
-				Arg:     &nodes.String{Str: "stable"},
+				Defname:  "volatility",
+				Arg:      &nodes.String{Sval: "stable"},
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| VOLATILE
 		{
 			$$ = &nodes.DefElem{
-				Defname: "volatility",
-				Arg:     &nodes.String{Str: "volatile"},
+				Defname:  "volatility",
+				Arg:      &nodes.String{Sval: "volatile"},
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| STRICT_P
 		{
 			$$ = &nodes.DefElem{
-				Defname: "strict",
-				Arg:     &nodes.Integer{Ival: 1},
+				Defname:  "strict",
+				Arg:      &nodes.Boolean{Boolval: true},
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| CALLED ON NULL_P INPUT_P
 		{
 			$$ = &nodes.DefElem{
-				Defname: "strict",
-				Arg:     &nodes.Integer{Ival: 0},
+				Defname:  "strict",
+				Arg:      &nodes.Boolean{Boolval: false},
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| RETURNS NULL_P ON NULL_P INPUT_P
 		{
 			$$ = &nodes.DefElem{
-				Defname: "strict",
-				Arg:     &nodes.Integer{Ival: 1},
+				Defname:  "strict",
+				Arg:      &nodes.Boolean{Boolval: true},
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| SECURITY DEFINER
 		{
 			$$ = &nodes.DefElem{
-				Defname: "security",
-				Arg:     &nodes.Integer{Ival: 1},
+				Defname:  "security",
+				Arg:      &nodes.Boolean{Boolval: true},
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| SECURITY INVOKER
 		{
 			$$ = &nodes.DefElem{
-				Defname: "security",
-				Arg:     &nodes.Integer{Ival: 0},
+				Defname:  "security",
+				Arg:      &nodes.Boolean{Boolval: false},
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| LEAKPROOF
 		{
 			$$ = &nodes.DefElem{
-				Defname: "leakproof",
-				Arg:     &nodes.Integer{Ival: 1},
+				Defname:  "leakproof",
+				Arg:      &nodes.Boolean{Boolval: true},
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| NOT LEAKPROOF
 		{
 			$$ = &nodes.DefElem{
-				Defname: "leakproof",
-				Arg:     &nodes.Integer{Ival: 0},
+				Defname:  "leakproof",
+				Arg:      &nodes.Boolean{Boolval: false},
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| COST NumericOnly
 		{
 			$$ = &nodes.DefElem{
-				Defname: "cost",
-				Arg:     $2,
+				Defname:  "cost",
+				Arg:      $2,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| ROWS NumericOnly
 		{
 			$$ = &nodes.DefElem{
-				Defname: "rows",
-				Arg:     $2,
+				Defname:  "rows",
+				Arg:      $2,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| PARALLEL ColId
 		{
 			$$ = &nodes.DefElem{
-				Defname: "parallel",
-				Arg:     &nodes.String{Str: $2},
+				Defname:  "parallel",
+				Arg:      &nodes.String{Sval: $2},
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| SET set_rest_more
 		{
 			$$ = &nodes.DefElem{
-				Defname: "set",
-				Arg:     $2,
+				Defname:  "set",
+				Arg:      $2,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| VariableResetStmt
 		{
 			$$ = &nodes.DefElem{
-				Defname: "set",
-				Arg:     $1,
+				Defname:  "set",
+				Arg:      $1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| SUPPORT any_name
 		{
 			$$ = &nodes.DefElem{
-				Defname: "support",
-				Arg:     $2,
+				Defname:  "support",
+				Arg:      $2,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -4854,99 +4851,113 @@ TransactionStmt:
 	ABORT_P opt_transaction opt_transaction_chain
 		{
 			$$ = &nodes.TransactionStmt{
-				Kind:  nodes.TRANS_STMT_ROLLBACK,
-				Chain: $3,
+				Kind:     nodes.TRANS_STMT_ROLLBACK,
+				Chain:    $3,
+				Location: -1,
 			}
 		}
 	| BEGIN_P opt_transaction transaction_mode_list_or_empty
 		{
 			$$ = &nodes.TransactionStmt{
-				Kind:    nodes.TRANS_STMT_BEGIN,
-				Options: $3,
+				Kind:     nodes.TRANS_STMT_BEGIN,
+				Options:  $3,
+				Location: -1,
 			}
 		}
 	| START TRANSACTION transaction_mode_list_or_empty
 		{
 			$$ = &nodes.TransactionStmt{
-				Kind:    nodes.TRANS_STMT_START,
-				Options: $3,
+				Kind:     nodes.TRANS_STMT_START,
+				Options:  $3,
+				Location: -1,
 			}
 		}
 	| PREPARE TRANSACTION Sconst
 		{
 			$$ = &nodes.TransactionStmt{
-				Kind: nodes.TRANS_STMT_PREPARE,
-				Gid:  $3,
+				Kind:     nodes.TRANS_STMT_PREPARE,
+				Gid:      $3,
+				Location: nodes.ParseLoc($<loc>3),
 			}
 		}
 	| COMMIT PREPARED Sconst
 		{
 			$$ = &nodes.TransactionStmt{
-				Kind: nodes.TRANS_STMT_COMMIT_PREPARED,
-				Gid:  $3,
+				Kind:     nodes.TRANS_STMT_COMMIT_PREPARED,
+				Gid:      $3,
+				Location: nodes.ParseLoc($<loc>3),
 			}
 		}
 	| ROLLBACK PREPARED Sconst
 		{
 			$$ = &nodes.TransactionStmt{
-				Kind: nodes.TRANS_STMT_ROLLBACK_PREPARED,
-				Gid:  $3,
+				Kind:     nodes.TRANS_STMT_ROLLBACK_PREPARED,
+				Gid:      $3,
+				Location: nodes.ParseLoc($<loc>3),
 			}
 		}
 	| COMMIT opt_transaction opt_transaction_chain
 		{
 			$$ = &nodes.TransactionStmt{
-				Kind:  nodes.TRANS_STMT_COMMIT,
-				Chain: $3,
+				Kind:     nodes.TRANS_STMT_COMMIT,
+				Chain:    $3,
+				Location: -1,
 			}
 		}
 	| END_P opt_transaction opt_transaction_chain
 		{
 			$$ = &nodes.TransactionStmt{
-				Kind:  nodes.TRANS_STMT_COMMIT,
-				Chain: $3,
+				Kind:     nodes.TRANS_STMT_COMMIT,
+				Chain:    $3,
+				Location: -1,
 			}
 		}
 	| ROLLBACK opt_transaction opt_transaction_chain
 		{
 			$$ = &nodes.TransactionStmt{
-				Kind:  nodes.TRANS_STMT_ROLLBACK,
-				Chain: $3,
+				Kind:     nodes.TRANS_STMT_ROLLBACK,
+				Chain:    $3,
+				Location: -1,
 			}
 		}
 	| SAVEPOINT ColId
 		{
 			$$ = &nodes.TransactionStmt{
-				Kind:      nodes.TRANS_STMT_SAVEPOINT,
-				Savepoint: $2,
+				Kind:          nodes.TRANS_STMT_SAVEPOINT,
+				SavepointName: $2,
+				Location:      nodes.ParseLoc($<loc>2),
 			}
 		}
 	| RELEASE SAVEPOINT ColId
 		{
 			$$ = &nodes.TransactionStmt{
-				Kind:      nodes.TRANS_STMT_RELEASE,
-				Savepoint: $3,
+				Kind:          nodes.TRANS_STMT_RELEASE,
+				SavepointName: $3,
+				Location:      nodes.ParseLoc($<loc>3),
 			}
 		}
 	| RELEASE ColId
 		{
 			$$ = &nodes.TransactionStmt{
-				Kind:      nodes.TRANS_STMT_RELEASE,
-				Savepoint: $2,
+				Kind:          nodes.TRANS_STMT_RELEASE,
+				SavepointName: $2,
+				Location:      nodes.ParseLoc($<loc>2),
 			}
 		}
 	| ROLLBACK opt_transaction TO SAVEPOINT ColId
 		{
 			$$ = &nodes.TransactionStmt{
-				Kind:      nodes.TRANS_STMT_ROLLBACK_TO,
-				Savepoint: $5,
+				Kind:          nodes.TRANS_STMT_ROLLBACK_TO,
+				SavepointName: $5,
+				Location:      nodes.ParseLoc($<loc>5),
 			}
 		}
 	| ROLLBACK opt_transaction TO ColId
 		{
 			$$ = &nodes.TransactionStmt{
-				Kind:      nodes.TRANS_STMT_ROLLBACK_TO,
-				Savepoint: $4,
+				Kind:          nodes.TRANS_STMT_ROLLBACK_TO,
+				SavepointName: $4,
+				Location:      nodes.ParseLoc($<loc>4),
 			}
 		}
 	;
@@ -4980,14 +4991,14 @@ ExplainStmt:
 		{
 			$$ = &nodes.ExplainStmt{
 				Query:   $3,
-				Options: makeList(&nodes.DefElem{Defname: "analyze"}),
+				Options: makeList(makeDefElem("analyze", nil, $<loc>2)),
 			}
 		}
 	| EXPLAIN VERBOSE ExplainableStmt
 		{
 			$$ = &nodes.ExplainStmt{
 				Query:   $3,
-				Options: makeList(&nodes.DefElem{Defname: "verbose"}),
+				Options: makeList(makeDefElem("verbose", nil, $<loc>2)),
 			}
 		}
 	| EXPLAIN ANALYZE VERBOSE ExplainableStmt
@@ -4995,8 +5006,8 @@ ExplainStmt:
 			$$ = &nodes.ExplainStmt{
 				Query: $4,
 				Options: &nodes.List{Items: []nodes.Node{
-					&nodes.DefElem{Defname: "analyze"},
-					&nodes.DefElem{Defname: "verbose"},
+					makeDefElem("analyze", nil, $<loc>2),
+					makeDefElem("verbose", nil, $<loc>3),
 				}},
 			}
 		}
@@ -5034,6 +5045,12 @@ CopyStmt:
 			copy_from opt_program copy_file_name copy_delimiter copy_opt_with
 			copy_options where_clause
 		{
+			if $6 && $7 == "" {
+				pglex.Error("STDIN/STDOUT not allowed with PROGRAM")
+			}
+			if $11 != nil && !$5 {
+				pglex.Error("WHERE clause not allowed with COPY TO")
+			}
 			rv := $3.(*nodes.RangeVar)
 			stmt := &nodes.CopyStmt{
 				Relation:    rv,
@@ -5057,6 +5074,9 @@ CopyStmt:
 		}
 	| COPY '(' PreparableStmt ')' TO opt_program copy_file_name copy_opt_with copy_options
 		{
+			if $6 && $7 == "" {
+				pglex.Error("STDIN/STDOUT not allowed with PROGRAM")
+			}
 			$$ = &nodes.CopyStmt{
 				Query:     $3,
 				IsFrom:    false,
@@ -5110,63 +5130,63 @@ copy_opt_list:
 copy_opt_item:
 	BINARY
 		{
-			$$ = &nodes.DefElem{Defname: "format", Arg: &nodes.String{Str: "binary"}}
+			$$ = makeDefElem("format", &nodes.String{Sval: "binary"}, $<loc>1)
 		}
 	| FREEZE
 		{
-			$$ = &nodes.DefElem{Defname: "freeze", Arg: &nodes.Boolean{Boolval: true}}
+			$$ = makeDefElem("freeze", &nodes.Boolean{Boolval: true}, $<loc>1)
 		}
 	| DELIMITER opt_as Sconst
 		{
-			$$ = &nodes.DefElem{Defname: "delimiter", Arg: &nodes.String{Str: $3}}
+			$$ = makeDefElem("delimiter", &nodes.String{Sval: $3}, $<loc>1)
 		}
 	| NULL_P opt_as Sconst
 		{
-			$$ = &nodes.DefElem{Defname: "null", Arg: &nodes.String{Str: $3}}
+			$$ = makeDefElem("null", &nodes.String{Sval: $3}, $<loc>1)
 		}
 	| CSV
 		{
-			$$ = &nodes.DefElem{Defname: "format", Arg: &nodes.String{Str: "csv"}}
+			$$ = makeDefElem("format", &nodes.String{Sval: "csv"}, $<loc>1)
 		}
 	| HEADER_P
 		{
-			$$ = &nodes.DefElem{Defname: "header", Arg: &nodes.Boolean{Boolval: true}}
+			$$ = makeDefElem("header", &nodes.Boolean{Boolval: true}, $<loc>1)
 		}
 	| QUOTE opt_as Sconst
 		{
-			$$ = &nodes.DefElem{Defname: "quote", Arg: &nodes.String{Str: $3}}
+			$$ = makeDefElem("quote", &nodes.String{Sval: $3}, $<loc>1)
 		}
 	| ESCAPE opt_as Sconst
 		{
-			$$ = &nodes.DefElem{Defname: "escape", Arg: &nodes.String{Str: $3}}
+			$$ = makeDefElem("escape", &nodes.String{Sval: $3}, $<loc>1)
 		}
 	| FORCE QUOTE columnList
 		{
-			$$ = &nodes.DefElem{Defname: "force_quote", Arg: $3}
+			$$ = makeDefElem("force_quote", $3, $<loc>1)
 		}
 	| FORCE QUOTE '*'
 		{
-			$$ = &nodes.DefElem{Defname: "force_quote", Arg: &nodes.A_Star{}}
+			$$ = makeDefElem("force_quote", &nodes.A_Star{}, $<loc>1)
 		}
 	| FORCE NOT NULL_P columnList
 		{
-			$$ = &nodes.DefElem{Defname: "force_not_null", Arg: $4}
+			$$ = makeDefElem("force_not_null", $4, $<loc>1)
 		}
 	| FORCE NOT NULL_P '*'
 		{
-			$$ = &nodes.DefElem{Defname: "force_not_null", Arg: &nodes.A_Star{}}
+			$$ = makeDefElem("force_not_null", &nodes.A_Star{}, $<loc>1)
 		}
 	| FORCE NULL_P columnList
 		{
-			$$ = &nodes.DefElem{Defname: "force_null", Arg: $3}
+			$$ = makeDefElem("force_null", $3, $<loc>1)
 		}
 	| FORCE NULL_P '*'
 		{
-			$$ = &nodes.DefElem{Defname: "force_null", Arg: &nodes.A_Star{}}
+			$$ = makeDefElem("force_null", &nodes.A_Star{}, $<loc>1)
 		}
 	| ENCODING Sconst
 		{
-			$$ = &nodes.DefElem{Defname: "encoding", Arg: &nodes.String{Str: $2}}
+			$$ = makeDefElem("encoding", &nodes.String{Sval: $2}, $<loc>1)
 		}
 	;
 
@@ -5175,7 +5195,7 @@ copy_opt_item:
 opt_binary:
 	BINARY
 		{
-			$$ = &nodes.DefElem{Defname: "format", Arg: &nodes.String{Str: "binary"}}
+			$$ = makeDefElem("format", &nodes.String{Sval: "binary"}, $<loc>1)
 		}
 	| /* EMPTY */ { $$ = nil }
 	;
@@ -5183,7 +5203,7 @@ opt_binary:
 copy_delimiter:
 	opt_using DELIMITERS Sconst
 		{
-			$$ = &nodes.DefElem{Defname: "delimiter", Arg: &nodes.String{Str: $3}}
+			$$ = makeDefElem("delimiter", &nodes.String{Sval: $3}, $<loc>2)
 		}
 	| /* EMPTY */ { $$ = nil }
 	;
@@ -5208,8 +5228,9 @@ utility_option_elem:
 	utility_option_name utility_option_arg
 		{
 			$$ = &nodes.DefElem{
-				Defname: $1,
-				Arg:     $2,
+				Defname:  $1,
+				Arg:      $2,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -5222,19 +5243,19 @@ utility_option_name:
 	;
 
 utility_option_arg:
-	opt_boolean_or_string   { $$ = &nodes.String{Str: $1} }
+	opt_boolean_or_string   { $$ = &nodes.String{Sval: $1} }
 	| NumericOnly           { $$ = $1 }
 	| '*'                   { $$ = &nodes.A_Star{} }
-	| DEFAULT               { $$ = &nodes.String{Str: "default"} }
+	| DEFAULT               { $$ = &nodes.String{Sval: "default"} }
 	| '(' utility_option_arg_list ')' { $$ = $2 }
 	| /* EMPTY */           { $$ = nil }
 	;
 
 utility_option_arg_list:
 	opt_boolean_or_string
-		{ $$ = makeList(&nodes.String{Str: $1}) }
+		{ $$ = makeList(&nodes.String{Sval: $1}) }
 	| utility_option_arg_list ',' opt_boolean_or_string
-		{ $$ = appendList($1, &nodes.String{Str: $3}) }
+		{ $$ = appendList($1, &nodes.String{Sval: $3}) }
 	;
 
 opt_boolean_or_string:
@@ -5301,39 +5322,39 @@ NonReservedWord:
  *****************************************************************************/
 
 GrantStmt:
-	GRANT privileges ON TABLE any_name_list TO grantee_list opt_grant_grant_option opt_granted_by
+	GRANT privileges ON TABLE qualified_name_list TO grantee_list opt_grant_grant_option opt_granted_by
 		{
 			$$ = &nodes.GrantStmt{
 				IsGrant:     true,
 				Targtype:    nodes.ACL_TARGET_OBJECT,
 				Objtype:     nodes.OBJECT_TABLE,
-				Objects:     makeRangeVarList($5),
+				Objects:     $5,
 				Privileges:  $2,
 				Grantees:    $7,
 				GrantOption: $8,
 				Grantor:     roleSpecOrNil($9),
 			}
 		}
-	| GRANT privileges ON any_name_list TO grantee_list opt_grant_grant_option opt_granted_by
+	| GRANT privileges ON qualified_name_list TO grantee_list opt_grant_grant_option opt_granted_by
 		{
 			$$ = &nodes.GrantStmt{
 				IsGrant:     true,
 				Targtype:    nodes.ACL_TARGET_OBJECT,
 				Objtype:     nodes.OBJECT_TABLE,
-				Objects:     makeRangeVarList($4),
+				Objects:     $4,
 				Privileges:  $2,
 				Grantees:    $6,
 				GrantOption: $7,
 				Grantor:     roleSpecOrNil($8),
 			}
 		}
-	| GRANT privileges ON SEQUENCE any_name_list TO grantee_list opt_grant_grant_option opt_granted_by
+	| GRANT privileges ON SEQUENCE qualified_name_list TO grantee_list opt_grant_grant_option opt_granted_by
 		{
 			$$ = &nodes.GrantStmt{
 				IsGrant:     true,
 				Targtype:    nodes.ACL_TARGET_OBJECT,
 				Objtype:     nodes.OBJECT_SEQUENCE,
-				Objects:     makeRangeVarList($5),
+				Objects:     $5,
 				Privileges:  $2,
 				Grantees:    $7,
 				GrantOption: $8,
@@ -5385,7 +5406,7 @@ GrantStmt:
 				IsGrant:     true,
 				Targtype:    nodes.ACL_TARGET_OBJECT,
 				Objtype:     nodes.OBJECT_DATABASE,
-				Objects:     makeNameListAsAnyNameList($5),
+				Objects:     $5,
 				Privileges:  $2,
 				Grantees:    $7,
 				GrantOption: $8,
@@ -5411,7 +5432,7 @@ GrantStmt:
 				IsGrant:     true,
 				Targtype:    nodes.ACL_TARGET_OBJECT,
 				Objtype:     nodes.OBJECT_LANGUAGE,
-				Objects:     makeNameListAsAnyNameList($5),
+				Objects:     $5,
 				Privileges:  $2,
 				Grantees:    $7,
 				GrantOption: $8,
@@ -5424,7 +5445,7 @@ GrantStmt:
 				IsGrant:     true,
 				Targtype:    nodes.ACL_TARGET_OBJECT,
 				Objtype:     nodes.OBJECT_SCHEMA,
-				Objects:     makeNameListAsAnyNameList($5),
+				Objects:     $5,
 				Privileges:  $2,
 				Grantees:    $7,
 				GrantOption: $8,
@@ -5437,7 +5458,7 @@ GrantStmt:
 				IsGrant:     true,
 				Targtype:    nodes.ACL_TARGET_OBJECT,
 				Objtype:     nodes.OBJECT_TABLESPACE,
-				Objects:     makeNameListAsAnyNameList($5),
+				Objects:     $5,
 				Privileges:  $2,
 				Grantees:    $7,
 				GrantOption: $8,
@@ -5463,7 +5484,7 @@ GrantStmt:
 				IsGrant:     true,
 				Targtype:    nodes.ACL_TARGET_OBJECT,
 				Objtype:     nodes.OBJECT_FDW,
-				Objects:     makeNameListAsAnyNameList($7),
+				Objects:     $7,
 				Privileges:  $2,
 				Grantees:    $9,
 				GrantOption: $10,
@@ -5476,7 +5497,7 @@ GrantStmt:
 				IsGrant:     true,
 				Targtype:    nodes.ACL_TARGET_OBJECT,
 				Objtype:     nodes.OBJECT_FOREIGN_SERVER,
-				Objects:     makeNameListAsAnyNameList($6),
+				Objects:     $6,
 				Privileges:  $2,
 				Grantees:    $8,
 				GrantOption: $9,
@@ -5489,7 +5510,7 @@ GrantStmt:
 				IsGrant:     true,
 				Targtype:    nodes.ACL_TARGET_ALL_IN_SCHEMA,
 				Objtype:     nodes.OBJECT_TABLE,
-				Objects:     makeNameListAsAnyNameList($8),
+				Objects:     $8,
 				Privileges:  $2,
 				Grantees:    $10,
 				GrantOption: $11,
@@ -5502,7 +5523,7 @@ GrantStmt:
 				IsGrant:     true,
 				Targtype:    nodes.ACL_TARGET_ALL_IN_SCHEMA,
 				Objtype:     nodes.OBJECT_SEQUENCE,
-				Objects:     makeNameListAsAnyNameList($8),
+				Objects:     $8,
 				Privileges:  $2,
 				Grantees:    $10,
 				GrantOption: $11,
@@ -5515,7 +5536,7 @@ GrantStmt:
 				IsGrant:     true,
 				Targtype:    nodes.ACL_TARGET_ALL_IN_SCHEMA,
 				Objtype:     nodes.OBJECT_FUNCTION,
-				Objects:     makeNameListAsAnyNameList($8),
+				Objects:     $8,
 				Privileges:  $2,
 				Grantees:    $10,
 				GrantOption: $11,
@@ -5528,7 +5549,7 @@ GrantStmt:
 				IsGrant:     true,
 				Targtype:    nodes.ACL_TARGET_ALL_IN_SCHEMA,
 				Objtype:     nodes.OBJECT_ROUTINE,
-				Objects:     makeNameListAsAnyNameList($8),
+				Objects:     $8,
 				Privileges:  $2,
 				Grantees:    $10,
 				GrantOption: $11,
@@ -5541,7 +5562,7 @@ GrantStmt:
 				IsGrant:     true,
 				Targtype:    nodes.ACL_TARGET_ALL_IN_SCHEMA,
 				Objtype:     nodes.OBJECT_PROCEDURE,
-				Objects:     makeNameListAsAnyNameList($8),
+				Objects:     $8,
 				Privileges:  $2,
 				Grantees:    $10,
 				GrantOption: $11,
@@ -5564,39 +5585,39 @@ GrantStmt:
 	;
 
 RevokeStmt:
-	REVOKE privileges ON TABLE any_name_list FROM grantee_list opt_granted_by opt_drop_behavior
+	REVOKE privileges ON TABLE qualified_name_list FROM grantee_list opt_granted_by opt_drop_behavior
 		{
 			$$ = &nodes.GrantStmt{
 				IsGrant:    false,
 				Targtype:   nodes.ACL_TARGET_OBJECT,
 				Objtype:    nodes.OBJECT_TABLE,
-				Objects:    makeRangeVarList($5),
+				Objects:    $5,
 				Privileges: $2,
 				Grantees:   $7,
 				Grantor:    roleSpecOrNil($8),
 				Behavior:   nodes.DropBehavior($9),
 			}
 		}
-	| REVOKE privileges ON any_name_list FROM grantee_list opt_granted_by opt_drop_behavior
+	| REVOKE privileges ON qualified_name_list FROM grantee_list opt_granted_by opt_drop_behavior
 		{
 			$$ = &nodes.GrantStmt{
 				IsGrant:    false,
 				Targtype:   nodes.ACL_TARGET_OBJECT,
 				Objtype:    nodes.OBJECT_TABLE,
-				Objects:    makeRangeVarList($4),
+				Objects:    $4,
 				Privileges: $2,
 				Grantees:   $6,
 				Grantor:    roleSpecOrNil($7),
 				Behavior:   nodes.DropBehavior($8),
 			}
 		}
-	| REVOKE privileges ON SEQUENCE any_name_list FROM grantee_list opt_granted_by opt_drop_behavior
+	| REVOKE privileges ON SEQUENCE qualified_name_list FROM grantee_list opt_granted_by opt_drop_behavior
 		{
 			$$ = &nodes.GrantStmt{
 				IsGrant:    false,
 				Targtype:   nodes.ACL_TARGET_OBJECT,
 				Objtype:    nodes.OBJECT_SEQUENCE,
-				Objects:    makeRangeVarList($5),
+				Objects:    $5,
 				Privileges: $2,
 				Grantees:   $7,
 				Grantor:    roleSpecOrNil($8),
@@ -5648,7 +5669,7 @@ RevokeStmt:
 				IsGrant:    false,
 				Targtype:   nodes.ACL_TARGET_OBJECT,
 				Objtype:    nodes.OBJECT_DATABASE,
-				Objects:    makeNameListAsAnyNameList($5),
+				Objects:    $5,
 				Privileges: $2,
 				Grantees:   $7,
 				Grantor:    roleSpecOrNil($8),
@@ -5674,7 +5695,7 @@ RevokeStmt:
 				IsGrant:    false,
 				Targtype:   nodes.ACL_TARGET_OBJECT,
 				Objtype:    nodes.OBJECT_LANGUAGE,
-				Objects:    makeNameListAsAnyNameList($5),
+				Objects:    $5,
 				Privileges: $2,
 				Grantees:   $7,
 				Grantor:    roleSpecOrNil($8),
@@ -5687,7 +5708,7 @@ RevokeStmt:
 				IsGrant:    false,
 				Targtype:   nodes.ACL_TARGET_OBJECT,
 				Objtype:    nodes.OBJECT_SCHEMA,
-				Objects:    makeNameListAsAnyNameList($5),
+				Objects:    $5,
 				Privileges: $2,
 				Grantees:   $7,
 				Grantor:    roleSpecOrNil($8),
@@ -5700,7 +5721,7 @@ RevokeStmt:
 				IsGrant:    false,
 				Targtype:   nodes.ACL_TARGET_OBJECT,
 				Objtype:    nodes.OBJECT_TABLESPACE,
-				Objects:    makeNameListAsAnyNameList($5),
+				Objects:    $5,
 				Privileges: $2,
 				Grantees:   $7,
 				Grantor:    roleSpecOrNil($8),
@@ -5726,7 +5747,7 @@ RevokeStmt:
 				IsGrant:    false,
 				Targtype:   nodes.ACL_TARGET_OBJECT,
 				Objtype:    nodes.OBJECT_FDW,
-				Objects:    makeNameListAsAnyNameList($7),
+				Objects:    $7,
 				Privileges: $2,
 				Grantees:   $9,
 				Grantor:    roleSpecOrNil($10),
@@ -5739,7 +5760,7 @@ RevokeStmt:
 				IsGrant:    false,
 				Targtype:   nodes.ACL_TARGET_OBJECT,
 				Objtype:    nodes.OBJECT_FOREIGN_SERVER,
-				Objects:    makeNameListAsAnyNameList($6),
+				Objects:    $6,
 				Privileges: $2,
 				Grantees:   $8,
 				Grantor:    roleSpecOrNil($9),
@@ -5752,7 +5773,7 @@ RevokeStmt:
 				IsGrant:    false,
 				Targtype:   nodes.ACL_TARGET_ALL_IN_SCHEMA,
 				Objtype:    nodes.OBJECT_TABLE,
-				Objects:    makeNameListAsAnyNameList($8),
+				Objects:    $8,
 				Privileges: $2,
 				Grantees:   $10,
 				Grantor:    roleSpecOrNil($11),
@@ -5765,7 +5786,7 @@ RevokeStmt:
 				IsGrant:    false,
 				Targtype:   nodes.ACL_TARGET_ALL_IN_SCHEMA,
 				Objtype:    nodes.OBJECT_SEQUENCE,
-				Objects:    makeNameListAsAnyNameList($8),
+				Objects:    $8,
 				Privileges: $2,
 				Grantees:   $10,
 				Grantor:    roleSpecOrNil($11),
@@ -5778,7 +5799,7 @@ RevokeStmt:
 				IsGrant:    false,
 				Targtype:   nodes.ACL_TARGET_ALL_IN_SCHEMA,
 				Objtype:    nodes.OBJECT_FUNCTION,
-				Objects:    makeNameListAsAnyNameList($8),
+				Objects:    $8,
 				Privileges: $2,
 				Grantees:   $10,
 				Grantor:    roleSpecOrNil($11),
@@ -5791,7 +5812,7 @@ RevokeStmt:
 				IsGrant:    false,
 				Targtype:   nodes.ACL_TARGET_ALL_IN_SCHEMA,
 				Objtype:    nodes.OBJECT_ROUTINE,
-				Objects:    makeNameListAsAnyNameList($8),
+				Objects:    $8,
 				Privileges: $2,
 				Grantees:   $10,
 				Grantor:    roleSpecOrNil($11),
@@ -5804,7 +5825,7 @@ RevokeStmt:
 				IsGrant:    false,
 				Targtype:   nodes.ACL_TARGET_ALL_IN_SCHEMA,
 				Objtype:    nodes.OBJECT_PROCEDURE,
-				Objects:    makeNameListAsAnyNameList($8),
+				Objects:    $8,
 				Privileges: $2,
 				Grantees:   $10,
 				Grantor:    roleSpecOrNil($11),
@@ -5824,13 +5845,13 @@ RevokeStmt:
 				Behavior:   nodes.DropBehavior($10),
 			}
 		}
-	| REVOKE GRANT OPTION FOR privileges ON TABLE any_name_list FROM grantee_list opt_granted_by opt_drop_behavior
+	| REVOKE GRANT OPTION FOR privileges ON TABLE qualified_name_list FROM grantee_list opt_granted_by opt_drop_behavior
 		{
 			$$ = &nodes.GrantStmt{
 				IsGrant:     false,
 				Targtype:    nodes.ACL_TARGET_OBJECT,
 				Objtype:     nodes.OBJECT_TABLE,
-				Objects:     makeRangeVarList($8),
+				Objects:     $8,
 				Privileges:  $5,
 				Grantees:    $10,
 				GrantOption: true,
@@ -5838,13 +5859,13 @@ RevokeStmt:
 				Behavior:    nodes.DropBehavior($12),
 			}
 		}
-	| REVOKE GRANT OPTION FOR privileges ON any_name_list FROM grantee_list opt_granted_by opt_drop_behavior
+	| REVOKE GRANT OPTION FOR privileges ON qualified_name_list FROM grantee_list opt_granted_by opt_drop_behavior
 		{
 			$$ = &nodes.GrantStmt{
 				IsGrant:     false,
 				Targtype:    nodes.ACL_TARGET_OBJECT,
 				Objtype:     nodes.OBJECT_TABLE,
-				Objects:     makeRangeVarList($7),
+				Objects:     $7,
 				Privileges:  $5,
 				Grantees:    $9,
 				GrantOption: true,
@@ -5852,13 +5873,13 @@ RevokeStmt:
 				Behavior:    nodes.DropBehavior($11),
 			}
 		}
-	| REVOKE GRANT OPTION FOR privileges ON SEQUENCE any_name_list FROM grantee_list opt_granted_by opt_drop_behavior
+	| REVOKE GRANT OPTION FOR privileges ON SEQUENCE qualified_name_list FROM grantee_list opt_granted_by opt_drop_behavior
 		{
 			$$ = &nodes.GrantStmt{
 				IsGrant:     false,
 				Targtype:    nodes.ACL_TARGET_OBJECT,
 				Objtype:     nodes.OBJECT_SEQUENCE,
-				Objects:     makeRangeVarList($8),
+				Objects:     $8,
 				Privileges:  $5,
 				Grantees:    $10,
 				GrantOption: true,
@@ -5914,7 +5935,7 @@ RevokeStmt:
 				IsGrant:     false,
 				Targtype:    nodes.ACL_TARGET_OBJECT,
 				Objtype:     nodes.OBJECT_DATABASE,
-				Objects:     makeNameListAsAnyNameList($8),
+				Objects:     $8,
 				Privileges:  $5,
 				Grantees:    $10,
 				GrantOption: true,
@@ -5942,7 +5963,7 @@ RevokeStmt:
 				IsGrant:     false,
 				Targtype:    nodes.ACL_TARGET_OBJECT,
 				Objtype:     nodes.OBJECT_LANGUAGE,
-				Objects:     makeNameListAsAnyNameList($8),
+				Objects:     $8,
 				Privileges:  $5,
 				Grantees:    $10,
 				GrantOption: true,
@@ -5956,7 +5977,7 @@ RevokeStmt:
 				IsGrant:     false,
 				Targtype:    nodes.ACL_TARGET_OBJECT,
 				Objtype:     nodes.OBJECT_SCHEMA,
-				Objects:     makeNameListAsAnyNameList($8),
+				Objects:     $8,
 				Privileges:  $5,
 				Grantees:    $10,
 				GrantOption: true,
@@ -5970,7 +5991,7 @@ RevokeStmt:
 				IsGrant:     false,
 				Targtype:    nodes.ACL_TARGET_OBJECT,
 				Objtype:     nodes.OBJECT_TABLESPACE,
-				Objects:     makeNameListAsAnyNameList($8),
+				Objects:     $8,
 				Privileges:  $5,
 				Grantees:    $10,
 				GrantOption: true,
@@ -5998,7 +6019,7 @@ RevokeStmt:
 				IsGrant:     false,
 				Targtype:    nodes.ACL_TARGET_OBJECT,
 				Objtype:     nodes.OBJECT_FDW,
-				Objects:     makeNameListAsAnyNameList($10),
+				Objects:     $10,
 				Privileges:  $5,
 				Grantees:    $12,
 				GrantOption: true,
@@ -6012,7 +6033,7 @@ RevokeStmt:
 				IsGrant:     false,
 				Targtype:    nodes.ACL_TARGET_OBJECT,
 				Objtype:     nodes.OBJECT_FOREIGN_SERVER,
-				Objects:     makeNameListAsAnyNameList($9),
+				Objects:     $9,
 				Privileges:  $5,
 				Grantees:    $11,
 				GrantOption: true,
@@ -6073,27 +6094,41 @@ grantee:
 RoleSpec:
 	ColId
 		{
-			$$ = &nodes.RoleSpec{
-				Roletype: int(nodes.ROLESPEC_CSTRING),
-				Rolename: $1,
+			if $1 == "none" {
+				pglex.Error("role name \"none\" is reserved")
+			}
+			if $1 == "public" {
+				$$ = &nodes.RoleSpec{
+					Roletype: nodes.ROLESPEC_PUBLIC,
+					Location: nodes.ParseLoc($<loc>1),
+				}
+			} else {
+				$$ = &nodes.RoleSpec{
+					Roletype: nodes.ROLESPEC_CSTRING,
+					Rolename: $1,
+					Location: nodes.ParseLoc($<loc>1),
+				}
 			}
 		}
 	| CURRENT_ROLE
 		{
 			$$ = &nodes.RoleSpec{
-				Roletype: int(nodes.ROLESPEC_CURRENT_ROLE),
+				Roletype: nodes.ROLESPEC_CURRENT_ROLE,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| CURRENT_USER
 		{
 			$$ = &nodes.RoleSpec{
-				Roletype: int(nodes.ROLESPEC_CURRENT_USER),
+				Roletype: nodes.ROLESPEC_CURRENT_USER,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| SESSION_USER
 		{
 			$$ = &nodes.RoleSpec{
-				Roletype: int(nodes.ROLESPEC_SESSION_USER),
+				Roletype: nodes.ROLESPEC_SESSION_USER,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -6144,7 +6179,7 @@ RevokeRoleStmt:
 		}
 	| REVOKE ColId OPTION FOR privilege_list FROM role_list opt_granted_by opt_drop_behavior
 		{
-			opt := makeDefElem($2, &nodes.Boolean{Boolval: false})
+			opt := makeDefElem($2, &nodes.Boolean{Boolval: false}, $<loc>2)
 			$$ = &nodes.GrantRoleStmt{
 				IsGrant:      false,
 				Opt:          makeList(opt),
@@ -6163,17 +6198,25 @@ grant_role_opt_list:
 		{ $$ = makeList($1) }
 	;
 
+/* Before 16 the only option is ADMIN OPTION */
 grant_role_opt:
-	ColLabel grant_role_opt_value
+	ColLabel OPTION
+		{
+			if $1 != "admin" {
+				requireVersion(pglex, PG16, "GRANT ... WITH "+strings.ToUpper($1)+" OPTION", $<loc>1)
+			}
+			$$ = makeDefElem($1, &nodes.Boolean{Boolval: true}, $<loc>1)
+		}
+	| ColLabel TRUE_P
 		{
-			$$ = makeDefElem($1, $2)
+			requireVersion(pglex, PG16, "GRANT ... WITH "+strings.ToUpper($1)+" TRUE", $<loc>1)
+			$$ = makeDefElem($1, &nodes.Boolean{Boolval: true}, $<loc>1)
+		}
+	| ColLabel FALSE_P
+		{
+			requireVersion(pglex, PG16, "GRANT ... WITH "+strings.ToUpper($1)+" FALSE", $<loc>1)
+			$$ = makeDefElem($1, &nodes.Boolean{Boolval: false}, $<loc>1)
 		}
-	;
-
-grant_role_opt_value:
-	OPTION   { $$ = &nodes.Boolean{Boolval: true} }
-	| TRUE_P { $$ = &nodes.Boolean{Boolval: true} }
-	| FALSE_P { $$ = &nodes.Boolean{Boolval: false} }
 	;
 
 opt_granted_by:
@@ -6251,15 +6294,15 @@ AlterOptRoleList:
 AlterOptRoleElem:
 	PASSWORD Sconst
 		{
-			$$ = makeDefElem("password", &nodes.String{Str: $2})
+			$$ = makeDefElem("password", &nodes.String{Sval: $2}, $<loc>1)
 		}
 	| PASSWORD NULL_P
 		{
-			$$ = makeDefElem("password", nil)
+			$$ = makeDefElem("password", nil, $<loc>1)
 		}
 	| ENCRYPTED PASSWORD Sconst
 		{
-			$$ = makeDefElem("password", &nodes.String{Str: $3})
+			$$ = makeDefElem("password", &nodes.String{Sval: $3}, $<loc>1)
 		}
 	| UNENCRYPTED PASSWORD Sconst
 		{
@@ -6268,49 +6311,49 @@ AlterOptRoleElem:
 		}
 	| INHERIT
 		{
-			$$ = makeDefElem("inherit", &nodes.Boolean{Boolval: true})
+			$$ = makeDefElem("inherit", &nodes.Boolean{Boolval: true}, $<loc>1)
 		}
 	| CONNECTION LIMIT SignedIconst
 		{
-			$$ = makeDefElem("connectionlimit", &nodes.Integer{Ival: int64($3)})
+			$$ = makeDefElem("connectionlimit", &nodes.Integer{Ival: int64($3)}, $<loc>1)
 		}
 	| VALID UNTIL Sconst
 		{
-			$$ = makeDefElem("validUntil", &nodes.String{Str: $3})
+			$$ = makeDefElem("validUntil", &nodes.String{Sval: $3}, $<loc>1)
 		}
 	| USER role_list
 		{
-			$$ = makeDefElem("rolemembers", $2)
+			$$ = makeDefElem("rolemembers", $2, $<loc>1)
 		}
 	| IDENT
 		{
 			switch $1 {
 			case "superuser":
-				$$ = makeDefElem("superuser", &nodes.Boolean{Boolval: true})
+				$$ = makeDefElem("superuser", &nodes.Boolean{Boolval: true}, $<loc>1)
 			case "nosuperuser":
-				$$ = makeDefElem("superuser", &nodes.Boolean{Boolval: false})
+				$$ = makeDefElem("superuser", &nodes.Boolean{Boolval: false}, $<loc>1)
 			case "createrole":
-				$$ = makeDefElem("createrole", &nodes.Boolean{Boolval: true})
+				$$ = makeDefElem("createrole", &nodes.Boolean{Boolval: true}, $<loc>1)
 			case "nocreaterole":
-				$$ = makeDefElem("createrole", &nodes.Boolean{Boolval: false})
+				$$ = makeDefElem("createrole", &nodes.Boolean{Boolval: false}, $<loc>1)
 			case "replication":
-				$$ = makeDefElem("isreplication", &nodes.Boolean{Boolval: true})
+				$$ = makeDefElem("isreplication", &nodes.Boolean{Boolval: true}, $<loc>1)
 			case "noreplication":
-				$$ = makeDefElem("isreplication", &nodes.Boolean{Boolval: false})
+				$$ = makeDefElem("isreplication", &nodes.Boolean{Boolval: false}, $<loc>1)
 			case "createdb":
-				$$ = makeDefElem("createdb", &nodes.Boolean{Boolval: true})
+				$$ = makeDefElem("createdb", &nodes.Boolean{Boolval: true}, $<loc>1)
 			case "nocreatedb":
-				$$ = makeDefElem("createdb", &nodes.Boolean{Boolval: false})
+				$$ = makeDefElem("createdb", &nodes.Boolean{Boolval: false}, $<loc>1)
 			case "login":
-				$$ = makeDefElem("canlogin", &nodes.Boolean{Boolval: true})
+				$$ = makeDefElem("canlogin", &nodes.Boolean{Boolval: true}, $<loc>1)
 			case "nologin":
-				$$ = makeDefElem("canlogin", &nodes.Boolean{Boolval: false})
+				$$ = makeDefElem("canlogin", &nodes.Boolean{Boolval: false}, $<loc>1)
 			case "bypassrls":
-				$$ = makeDefElem("bypassrls", &nodes.Boolean{Boolval: true})
+				$$ = makeDefElem("bypassrls", &nodes.Boolean{Boolval: true}, $<loc>1)
 			case "nobypassrls":
-				$$ = makeDefElem("bypassrls", &nodes.Boolean{Boolval: false})
+				$$ = makeDefElem("bypassrls", &nodes.Boolean{Boolval: false}, $<loc>1)
 			case "noinherit":
-				$$ = makeDefElem("inherit", &nodes.Boolean{Boolval: false})
+				$$ = makeDefElem("inherit", &nodes.Boolean{Boolval: false}, $<loc>1)
 			default:
 				pglex.Error("unrecognized role option \"" + $1 + "\"")
 				$$ = nil
@@ -6325,23 +6368,23 @@ CreateOptRoleElem:
 		}
 	| SYSID Iconst
 		{
-			$$ = makeDefElem("sysid", &nodes.Integer{Ival: int64($2)})
+			$$ = makeDefElem("sysid", &nodes.Integer{Ival: int64($2)}, $<loc>1)
 		}
 	| ADMIN role_list
 		{
-			$$ = makeDefElem("adminmembers", $2)
+			$$ = makeDefElem("adminmembers", $2, $<loc>1)
 		}
 	| ROLE role_list
 		{
-			$$ = makeDefElem("rolemembers", $2)
+			$$ = makeDefElem("rolemembers", $2, $<loc>1)
 		}
 	| IN_P ROLE role_list
 		{
-			$$ = makeDefElem("addroleto", $3)
+			$$ = makeDefElem("addroleto", $3, $<loc>1)
 		}
 	| IN_P GROUP_P role_list
 		{
-			$$ = makeDefElem("addroleto", $3)
+			$$ = makeDefElem("addroleto", $3, $<loc>1)
 		}
 	;
 
@@ -6525,7 +6568,7 @@ AlterGroupStmt:
 			$$ = &nodes.AlterRoleStmt{
 				Role:   $3.(*nodes.RoleSpec),
 				Action: int($4),
-				Options: makeList(makeDefElem("rolemembers", $6)),
+				Options: makeList(makeDefElem("rolemembers", $6, $<loc>6)),
 			}
 		}
 	;
@@ -6568,15 +6611,15 @@ createdb_opt_items:
 createdb_opt_item:
 	createdb_opt_name opt_equal NumericOnly
 		{
-			$$ = makeDefElem($1, $3)
+			$$ = makeDefElem($1, $3, $<loc>1)
 		}
 	| createdb_opt_name opt_equal opt_boolean_or_string
 		{
-			$$ = makeDefElem($1, &nodes.String{Str: $3})
+			$$ = makeDefElem($1, &nodes.String{Sval: $3}, $<loc>1)
 		}
 	| createdb_opt_name opt_equal DEFAULT
 		{
-			$$ = makeDefElem($1, nil)
+			$$ = makeDefElem($1, nil, $<loc>1)
 		}
 	;
 
@@ -6632,7 +6675,7 @@ AlterDatabaseStmt:
 		{
 			$$ = &nodes.AlterDatabaseStmt{
 				Dbname:  $3,
-				Options: makeList(makeDefElem("tablespace", &nodes.String{Str: $6})),
+				Options: makeList(makeDefElem("tablespace", &nodes.String{Sval: $6}, $<loc>6)),
 			}
 		}
 	;
@@ -6696,7 +6739,7 @@ drop_option_list:
 drop_option:
 	FORCE
 		{
-			$$ = makeDefElem("force", nil)
+			$$ = makeDefElem("force", nil, $<loc>1)
 		}
 	;
 
@@ -6745,6 +6788,9 @@ CreateSchemaStmt:
 		}
 	| CREATE SCHEMA IF_P NOT EXISTS opt_single_name AUTHORIZATION RoleSpec OptSchemaEltList
 		{
+			if $9 != nil {
+				pglex.Error("CREATE SCHEMA IF NOT EXISTS cannot include schema elements")
+			}
 			$$ = &nodes.CreateSchemaStmt{
 				Schemaname:  $6,
 				Authrole:    $8.(*nodes.RoleSpec),
@@ -6754,6 +6800,9 @@ CreateSchemaStmt:
 		}
 	| CREATE SCHEMA IF_P NOT EXISTS ColId OptSchemaEltList
 		{
+			if $7 != nil {
+				pglex.Error("CREATE SCHEMA IF NOT EXISTS cannot include schema elements")
+			}
 			$$ = &nodes.CreateSchemaStmt{
 				Schemaname:  $6,
 				SchemaElts:  $7,
@@ -6791,7 +6840,7 @@ schema_stmt:
 CreateSeqStmt:
 	CREATE OptTemp SEQUENCE qualified_name OptSeqOptList
 		{
-			rv := makeRangeVar($4)
+			rv := makeRangeVar($4, $<loc>4)
 			rv.(*nodes.RangeVar).Relpersistence = relpersistenceForTemp($2)
 			$$ = &nodes.CreateSeqStmt{
 				Sequence: rv.(*nodes.RangeVar),
@@ -6800,7 +6849,7 @@ CreateSeqStmt:
 		}
 	| CREATE OptTemp SEQUENCE IF_P NOT EXISTS qualified_name OptSeqOptList
 		{
-			rv := makeRangeVar($7)
+			rv := makeRangeVar($7, $<loc>7)
 			rv.(*nodes.RangeVar).Relpersistence = relpersistenceForTemp($2)
 			$$ = &nodes.CreateSeqStmt{
 				Sequence:    rv.(*nodes.RangeVar),
@@ -6813,7 +6862,7 @@ CreateSeqStmt:
 AlterSeqStmt:
 	ALTER SEQUENCE qualified_name SeqOptList
 		{
-			rv := makeRangeVar($3)
+			rv := makeRangeVar($3, $<loc>3)
 			$$ = &nodes.AlterSeqStmt{
 				Sequence: rv.(*nodes.RangeVar),
 				Options:  $4,
@@ -6821,7 +6870,7 @@ AlterSeqStmt:
 		}
 	| ALTER SEQUENCE IF_P EXISTS qualified_name SeqOptList
 		{
-			rv := makeRangeVar($5)
+			rv := makeRangeVar($5, $<loc>5)
 			$$ = &nodes.AlterSeqStmt{
 				Sequence:  rv.(*nodes.RangeVar),
 				Options:   $6,
@@ -6850,67 +6899,67 @@ SeqOptList:
 SeqOptElem:
 	AS SimpleTypename
 		{
-			$$ = makeDefElem("as", $2)
+			$$ = makeDefElem("as", $2, $<loc>1)
 		}
 	| CACHE NumericOnly
 		{
-			$$ = makeDefElem("cache", $2)
+			$$ = makeDefElem("cache", $2, $<loc>1)
 		}
 	| CYCLE
 		{
-			$$ = makeDefElem("cycle", &nodes.Boolean{Boolval: true})
+			$$ = makeDefElem("cycle", &nodes.Boolean{Boolval: true}, $<loc>1)
 		}
 	| NO CYCLE
 		{
-			$$ = makeDefElem("cycle", &nodes.Boolean{Boolval: false})
+			$$ = makeDefElem("cycle", &nodes.Boolean{Boolval: false}, $<loc>1)
 		}
 	| INCREMENT opt_by NumericOnly
 		{
-			$$ = makeDefElem("increment", $3)
+			$$ = makeDefElem("increment", $3, $<loc>1)
 		}
 	| MAXVALUE NumericOnly
 		{
-			$$ = makeDefElem("maxvalue", $2)
+			$$ = makeDefElem("maxvalue", $2, $<loc>1)
 		}
 	| MINVALUE NumericOnly
 		{
-			$$ = makeDefElem("minvalue", $2)
+			$$ = makeDefElem("minvalue", $2, $<loc>1)
 		}
 	| NO MAXVALUE
 		{
-			$$ = makeDefElem("maxvalue", nil)
+			$$ = makeDefElem("maxvalue", nil, $<loc>1)
 		}
 	| NO MINVALUE
 		{
-			$$ = makeDefElem("minvalue", nil)
+			$$ = makeDefElem("minvalue", nil, $<loc>1)
 		}
 	| OWNED BY any_name
 		{
-			$$ = makeDefElem("owned_by", $3)
+			$$ = makeDefElem("owned_by", $3, $<loc>1)
 		}
 	| SEQUENCE NAME_P any_name
 		{
-			$$ = makeDefElem("sequence_name", $3)
+			$$ = makeDefElem("sequence_name", $3, $<loc>1)
 		}
 	| START opt_with NumericOnly
 		{
-			$$ = makeDefElem("start", $3)
+			$$ = makeDefElem("start", $3, $<loc>1)
 		}
 	| RESTART
 		{
-			$$ = makeDefElem("restart", nil)
+			$$ = makeDefElem("restart", nil, $<loc>1)
 		}
 	| RESTART opt_with NumericOnly
 		{
-			$$ = makeDefElem("restart", $3)
+			$$ = makeDefElem("restart", $3, $<loc>1)
 		}
 	| LOGGED
 		{
-			$$ = makeDefElem("logged", &nodes.Boolean{Boolval: true})
+			$$ = makeDefElem("logged", &nodes.Boolean{Boolval: true}, $<loc>1)
 		}
 	| UNLOGGED
 		{
-			$$ = makeDefElem("logged", &nodes.Boolean{Boolval: false})
+			$$ = makeDefElem("logged", &nodes.Boolean{Boolval: false}, $<loc>1)
 		}
 	;
 
@@ -6934,11 +6983,11 @@ alter_identity_column_option_list:
 alter_identity_column_option:
 	RESTART
 		{
-			$$ = makeDefElem("restart", nil)
+			$$ = makeDefElem("restart", nil, $<loc>1)
 		}
 	| RESTART opt_with NumericOnly
 		{
-			$$ = makeDefElem("restart", $3)
+			$$ = makeDefElem("restart", $3, $<loc>1)
 		}
 	| SET SeqOptElem
 		{
@@ -6946,7 +6995,7 @@ alter_identity_column_option:
 		}
 	| SET GENERATED generated_when
 		{
-			$$ = makeDefElem("generated", makeIntConst($3))
+			$$ = makeDefElem("generated", &nodes.Integer{Ival: $3}, $<loc>1)
 		}
 	;
 
@@ -6959,11 +7008,12 @@ alter_identity_column_option:
 CreateDomainStmt:
 	CREATE DOMAIN_P any_name opt_as Typename opt_column_constraints
 		{
-			$$ = &nodes.CreateDomainStmt{
-				Domainname:  $3,
-				Typname:     $5,
-				Constraints: $6,
+			n := &nodes.CreateDomainStmt{
+				Domainname: $3,
+				TypeName:   $5,
 			}
+			n.Constraints, n.CollClause = splitCollateClause(pglex, $6)
+			$$ = n
 		}
 	;
 
@@ -6982,36 +7032,36 @@ AlterDomainStmt:
 	ALTER DOMAIN_P any_name alter_column_default
 		{
 			n := $4.(*nodes.AlterDomainStmt)
-			n.Typname = $3
+			n.TypeName = $3
 			$$ = n
 		}
 	| ALTER DOMAIN_P any_name DROP NOT NULL_P
 		{
 			$$ = &nodes.AlterDomainStmt{
-				Subtype: 'N',
-				Typname: $3,
+				Subtype:  'N',
+				TypeName: $3,
 			}
 		}
 	| ALTER DOMAIN_P any_name SET NOT NULL_P
 		{
 			$$ = &nodes.AlterDomainStmt{
-				Subtype: 'O',
-				Typname: $3,
+				Subtype:  'O',
+				TypeName: $3,
 			}
 		}
 	| ALTER DOMAIN_P any_name ADD_P DomainConstraint
 		{
 			$$ = &nodes.AlterDomainStmt{
-				Subtype: 'C',
-				Typname: $3,
-				Def:     $5,
+				Subtype:  'C',
+				TypeName: $3,
+				Def:      $5,
 			}
 		}
 	| ALTER DOMAIN_P any_name DROP CONSTRAINT name opt_drop_behavior
 		{
 			$$ = &nodes.AlterDomainStmt{
 				Subtype:  'X',
-				Typname:  $3,
+				TypeName: $3,
 				Name:     $6,
 				Behavior: nodes.DropBehavior($7),
 			}
@@ -7020,7 +7070,7 @@ AlterDomainStmt:
 		{
 			$$ = &nodes.AlterDomainStmt{
 				Subtype:   'X',
-				Typname:   $3,
+				TypeName:  $3,
 				Name:      $8,
 				Behavior:  nodes.DropBehavior($9),
 				MissingOk: true,
@@ -7029,9 +7079,9 @@ AlterDomainStmt:
 	| ALTER DOMAIN_P any_name VALIDATE CONSTRAINT name
 		{
 			$$ = &nodes.AlterDomainStmt{
-				Subtype: 'V',
-				Typname: $3,
-				Name:    $6,
+				Subtype:  'V',
+				TypeName: $3,
+				Name:     $6,
 			}
 		}
 	;
@@ -7062,37 +7112,38 @@ AlterEnumStmt:
 	ALTER TYPE_P any_name ADD_P VALUE_P opt_if_not_exists Sconst
 		{
 			$$ = &nodes.AlterEnumStmt{
-				Typname:            $3,
-				Newval:             $7,
-				SkipIfNewvalExists: $6,
+				TypeName:           $3,
+				NewVal:             $7,
+				NewValIsAfter:      true,
+				SkipIfNewValExists: $6,
 			}
 		}
 	| ALTER TYPE_P any_name ADD_P VALUE_P opt_if_not_exists Sconst BEFORE Sconst
 		{
 			$$ = &nodes.AlterEnumStmt{
-				Typname:            $3,
-				Newval:             $7,
-				NewvalNeighbor:     $9,
-				NewvalIsAfter:      false,
-				SkipIfNewvalExists: $6,
+				TypeName:           $3,
+				NewVal:             $7,
+				NewValNeighbor:     $9,
+				NewValIsAfter:      false,
+				SkipIfNewValExists: $6,
 			}
 		}
 	| ALTER TYPE_P any_name ADD_P VALUE_P opt_if_not_exists Sconst AFTER Sconst
 		{
 			$$ = &nodes.AlterEnumStmt{
-				Typname:            $3,
-				Newval:             $7,
-				NewvalNeighbor:     $9,
-				NewvalIsAfter:      true,
-				SkipIfNewvalExists: $6,
+				TypeName:           $3,
+				NewVal:             $7,
+				NewValNeighbor:     $9,
+				NewValIsAfter:      true,
+				SkipIfNewValExists: $6,
 			}
 		}
 	| ALTER TYPE_P any_name RENAME VALUE_P Sconst TO Sconst
 		{
 			$$ = &nodes.AlterEnumStmt{
-				Typname: $3,
-				Oldval:  $6,
-				Newval:  $8,
+				TypeName: $3,
+				OldVal:   $6,
+				NewVal:   $8,
 			}
 		}
 	;
@@ -7114,29 +7165,13 @@ AlterCollationStmt:
 AlterCompositeTypeStmt:
 	ALTER TYPE_P any_name alter_type_cmds
 		{
-			rv := &nodes.RangeVar{
-				Inh:     true,
-				Location: -1,
-			}
-			/* Convert any_name to schema.rel */
-			names := $3
-			if names != nil && len(names.Items) > 0 {
-				switch len(names.Items) {
-				case 1:
-					rv.Relname = names.Items[0].(*nodes.String).Str
-				case 2:
-					rv.Schemaname = names.Items[0].(*nodes.String).Str
-					rv.Relname = names.Items[1].(*nodes.String).Str
-				case 3:
-					rv.Catalogname = names.Items[0].(*nodes.String).Str
-					rv.Schemaname = names.Items[1].(*nodes.String).Str
-					rv.Relname = names.Items[2].(*nodes.String).Str
-				}
-			}
+			/* A composite type's RangeVar, like PostgreSQL's, has inh unset */
+			rv := makeRangeVarFromAnyName($3, $<loc>3)
+			rv.Inh = false
 			$$ = &nodes.AlterTableStmt{
 				Relation: rv,
 				Cmds:     $4,
-				ObjType:  int(nodes.OBJECT_TYPE),
+				Objtype:  nodes.OBJECT_TYPE,
 			}
 		}
 	;
@@ -7152,60 +7187,58 @@ alter_type_cmd:
 	ADD_P ATTRIBUTE TableFuncElement opt_drop_behavior
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype:  int(nodes.AT_AddColumn),
+				Subtype:  nodes.AT_AddColumn,
 				Def:      $3,
-				Behavior: int($4),
+				Behavior: nodes.DropBehavior($4),
 			}
 		}
 	| DROP ATTRIBUTE ColId opt_drop_behavior
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype:  int(nodes.AT_DropColumn),
+				Subtype:  nodes.AT_DropColumn,
 				Name:     $3,
-				Behavior: int($4),
+				Behavior: nodes.DropBehavior($4),
 			}
 		}
 	| DROP ATTRIBUTE IF_P EXISTS ColId opt_drop_behavior
 		{
 			$$ = &nodes.AlterTableCmd{
-				Subtype:    int(nodes.AT_DropColumn),
-				Name:       $5,
-				Behavior:   int($6),
-				Missing_ok: true,
+				Subtype:   nodes.AT_DropColumn,
+				Name:      $5,
+				Behavior:  nodes.DropBehavior($6),
+				MissingOk: true,
 			}
 		}
 	| ALTER ATTRIBUTE ColId SET DATA_P TYPE_P Typename opt_collate_clause opt_drop_behavior
 		{
 			coldef := &nodes.ColumnDef{
-				Colname:    $3,
-				TypeName:   $7,
-				CollClause: nil,
+				TypeName: $7,
+				Location: nodes.ParseLoc($<loc>3),
 			}
 			if $8 != nil {
 				coldef.CollClause = $8.(*nodes.CollateClause)
 			}
 			$$ = &nodes.AlterTableCmd{
-				Subtype:  int(nodes.AT_AlterColumnType),
+				Subtype:  nodes.AT_AlterColumnType,
 				Name:     $3,
 				Def:      coldef,
-				Behavior: int($9),
+				Behavior: nodes.DropBehavior($9),
 			}
 		}
 	| ALTER ATTRIBUTE ColId TYPE_P Typename opt_collate_clause opt_drop_behavior
 		{
 			coldef := &nodes.ColumnDef{
-				Colname:    $3,
-				TypeName:   $5,
-				CollClause: nil,
+				TypeName: $5,
+				Location: nodes.ParseLoc($<loc>3),
 			}
 			if $6 != nil {
 				coldef.CollClause = $6.(*nodes.CollateClause)
 			}
 			$$ = &nodes.AlterTableCmd{
-				Subtype:  int(nodes.AT_AlterColumnType),
+				Subtype:  nodes.AT_AlterColumnType,
 				Name:     $3,
 				Def:      coldef,
-				Behavior: int($7),
+				Behavior: nodes.DropBehavior($7),
 			}
 		}
 	;
@@ -7217,7 +7250,7 @@ TableFuncElement:
 				Colname:  $1,
 				TypeName: $2,
 				IsLocal:  true,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 			if $3 != nil {
 				coldef.CollClause = $3.(*nodes.CollateClause)
@@ -7231,7 +7264,7 @@ opt_collate_clause:
 		{
 			$$ = &nodes.CollateClause{
 				Collname: $2,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| /* EMPTY */
@@ -7346,7 +7379,7 @@ DefineStmt:
 			$$ = &nodes.DefineStmt{
 				Kind:       nodes.OBJECT_COLLATION,
 				Defnames:   $3,
-				Definition: makeList(makeDefElem("from", $5)),
+				Definition: makeList(makeDefElem("from", $5, $<loc>5)),
 			}
 		}
 	| CREATE COLLATION IF_P NOT EXISTS any_name FROM any_name
@@ -7354,7 +7387,7 @@ DefineStmt:
 			$$ = &nodes.DefineStmt{
 				Kind:        nodes.OBJECT_COLLATION,
 				Defnames:    $6,
-				Definition:  makeList(makeDefElem("from", $8)),
+				Definition:  makeList(makeDefElem("from", $8, $<loc>8)),
 				IfNotExists: true,
 			}
 		}
@@ -7363,8 +7396,10 @@ DefineStmt:
 CompositeTypeStmt:
 	CREATE TYPE_P any_name AS '(' OptTableFuncElementList ')'
 		{
+			rv := makeRangeVarFromAnyName($3, $<loc>3)
+			rv.Inh = false
 			$$ = &nodes.CompositeTypeStmt{
-				Typevar:    makeRangeVarFromAnyName($3),
+				Typevar:    rv,
 				Coldeflist: $6,
 			}
 		}
@@ -7411,11 +7446,11 @@ def_list:
 def_elem:
 	ColLabel '=' def_arg
 		{
-			$$ = makeDefElem($1, $3)
+			$$ = makeDefElem($1, $3, $<loc>1)
 		}
 	| ColLabel
 		{
-			$$ = makeDefElem($1, nil)
+			$$ = makeDefElem($1, nil, $<loc>1)
 		}
 	;
 
@@ -7427,7 +7462,7 @@ def_arg:
 		}
 	| reserved_keyword
 		{
-			$$ = &nodes.String{Str: $1}
+			$$ = &nodes.String{Sval: $1}
 		}
 	| qual_all_Op
 		{
@@ -7439,11 +7474,11 @@ def_arg:
 		}
 	| Sconst
 		{
-			$$ = &nodes.String{Str: $1}
+			$$ = &nodes.String{Sval: $1}
 		}
 	| NONE
 		{
-			$$ = &nodes.String{Str: "none"}
+			$$ = &nodes.String{Sval: "none"}
 		}
 	;
 
@@ -7473,7 +7508,7 @@ old_aggr_list:
 old_aggr_elem:
 	IDENT '=' def_arg
 		{
-			$$ = makeDefElem($1, $3)
+			$$ = makeDefElem($1, $3, $<loc>1)
 		}
 	;
 
@@ -7491,11 +7526,11 @@ opt_enum_val_list:
 enum_val_list:
 	Sconst
 		{
-			$$ = makeList(&nodes.String{Str: $1})
+			$$ = makeList(&nodes.String{Sval: $1})
 		}
 	| enum_val_list ',' Sconst
 		{
-			$$ = appendList($1, &nodes.String{Str: $3})
+			$$ = appendList($1, &nodes.String{Sval: $3})
 		}
 	;
 
@@ -7540,7 +7575,7 @@ aggr_args:
 	| '(' aggr_args_list ORDER BY aggr_args_list ')'
 		{
 			/* ordered-set agg with direct args and ordered args */
-			$$ = makeOrderedSetArgs($2, $5)
+			$$ = makeOrderedSetArgs(pglex, $2, $5)
 		}
 	;
 
@@ -7571,11 +7606,11 @@ aggr_arg:
 any_operator:
 	all_Op
 		{
-			$$ = makeList(&nodes.String{Str: $1})
+			$$ = makeList(&nodes.String{Sval: $1})
 		}
 	| ColId '.' any_operator
 		{
-			$$ = prependList(&nodes.String{Str: $1}, $3)
+			$$ = prependList(&nodes.String{Sval: $1}, $3)
 		}
 	;
 
@@ -7602,7 +7637,7 @@ MathOp:
 qual_all_Op:
 	all_Op
 		{
-			$$ = makeList(&nodes.String{Str: $1})
+			$$ = makeList(&nodes.String{Sval: $1})
 		}
 	| OPERATOR '(' any_operator ')'
 		{
@@ -7613,7 +7648,7 @@ qual_all_Op:
 qual_Op:
 	Op
 		{
-			$$ = makeList(&nodes.String{Str: $1})
+			$$ = makeList(&nodes.String{Sval: $1})
 		}
 	| OPERATOR '(' any_operator ')'
 		{
@@ -7631,7 +7666,11 @@ RoleId:
 	RoleSpec
 		{
 			spc := $1.(*nodes.RoleSpec)
-			if spc.Roletype != int(nodes.ROLESPEC_CSTRING) {
+			switch nodes.RoleSpecType(spc.Roletype) {
+			case nodes.ROLESPEC_CSTRING:
+			case nodes.ROLESPEC_PUBLIC:
+				pglex.Error("role name \"public\" is reserved")
+			default:
 				pglex.Error("role name cannot be a reserved keyword here")
 			}
 			$$ = spc.Rolename
@@ -7676,44 +7715,43 @@ select_no_parens:
 	| select_clause sort_clause
 		{
 			n := $1.(*nodes.SelectStmt)
-			n.SortClause = $2
+			insertSelectOptions(pglex, n, $2, nil, nil, nil)
 			$$ = n
 		}
 	| select_clause opt_sort_clause for_locking_clause opt_select_limit
 		{
 			n := $1.(*nodes.SelectStmt)
-			insertSelectOptions(n, $2, $3, $4, nil)
+			insertSelectOptions(pglex, n, $2, $3, $4, nil)
 			$$ = n
 		}
 	| select_clause opt_sort_clause select_limit opt_for_locking_clause
 		{
 			n := $1.(*nodes.SelectStmt)
-			insertSelectOptions(n, $2, $4, $3, nil)
+			insertSelectOptions(pglex, n, $2, $4, $3, nil)
 			$$ = n
 		}
 	| with_clause select_clause
 		{
 			n := $2.(*nodes.SelectStmt)
-			n.WithClause = $1.(*nodes.WithClause)
+			insertSelectOptions(pglex, n, nil, nil, nil, $1.(*nodes.WithClause))
 			$$ = n
 		}
 	| with_clause select_clause sort_clause
 		{
 			n := $2.(*nodes.SelectStmt)
-			n.WithClause = $1.(*nodes.WithClause)
-			n.SortClause = $3
+			insertSelectOptions(pglex, n, $3, nil, nil, $1.(*nodes.WithClause))
 			$$ = n
 		}
 	| with_clause select_clause opt_sort_clause for_locking_clause opt_select_limit
 		{
 			n := $2.(*nodes.SelectStmt)
-			insertSelectOptions(n, $3, $4, $5, $1.(*nodes.WithClause))
+			insertSelectOptions(pglex, n, $3, $4, $5, $1.(*nodes.WithClause))
 			$$ = n
 		}
 	| with_clause select_clause opt_sort_clause select_limit opt_for_locking_clause
 		{
 			n := $2.(*nodes.SelectStmt)
-			insertSelectOptions(n, $3, $5, $4, $1.(*nodes.WithClause))
+			insertSelectOptions(pglex, n, $3, $5, $4, $1.(*nodes.WithClause))
 			$$ = n
 		}
 	;
@@ -7875,6 +7913,7 @@ with_clause:
 			$$ = &nodes.WithClause{
 				Ctes:      $2,
 				Recursive: false,
+				Location:  nodes.ParseLoc($<loc>1),
 			}
 		}
 	| WITH_LA cte_list
@@ -7882,6 +7921,7 @@ with_clause:
 			$$ = &nodes.WithClause{
 				Ctes:      $2,
 				Recursive: false,
+				Location:  nodes.ParseLoc($<loc>1),
 			}
 		}
 	| WITH RECURSIVE cte_list
@@ -7889,6 +7929,7 @@ with_clause:
 			$$ = &nodes.WithClause{
 				Ctes:      $3,
 				Recursive: true,
+				Location:  nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -7910,14 +7951,15 @@ common_table_expr:
 			cte := &nodes.CommonTableExpr{
 				Ctename:         $1,
 				Aliascolnames:   $2,
-				Ctematerialized: int($4),
+				Ctematerialized: nodes.CTEMaterialize($4),
 				Ctequery:        $6,
+				Location:        nodes.ParseLoc($<loc>1),
 			}
 			if $8 != nil {
-				cte.SearchClause = $8
+				cte.SearchClause = $8.(*nodes.CTESearchClause)
 			}
 			if $9 != nil {
-				cte.CycleClause = $9
+				cte.CycleClause = $9.(*nodes.CTECycleClause)
 			}
 			$$ = cte
 		}
@@ -7926,14 +7968,15 @@ common_table_expr:
 			cte := &nodes.CommonTableExpr{
 				Ctename:         $1,
 				Aliascolnames:   $3,
-				Ctematerialized: int($6),
+				Ctematerialized: nodes.CTEMaterialize($6),
 				Ctequery:        $8,
+				Location:        nodes.ParseLoc($<loc>1),
 			}
 			if $10 != nil {
-				cte.SearchClause = $10
+				cte.SearchClause = $10.(*nodes.CTESearchClause)
 			}
 			if $11 != nil {
-				cte.CycleClause = $11
+				cte.CycleClause = $11.(*nodes.CTECycleClause)
 			}
 			$$ = cte
 		}
@@ -7948,20 +7991,22 @@ opt_materialized:
 opt_search_clause:
 	SEARCH DEPTH FIRST_P BY columnList SET ColId
 		{
+			requireVersion(pglex, PG14, "SEARCH", $<loc>1)
 			$$ = &nodes.CTESearchClause{
 				SearchColList:      $5,
 				SearchBreadthFirst: false,
 				SearchSeqColumn:    $7,
-				Location:           -1,
+				Location:           nodes.ParseLoc($<loc>1),
 			}
 		}
 	| SEARCH BREADTH FIRST_P BY columnList SET ColId
 		{
+			requireVersion(pglex, PG14, "SEARCH", $<loc>1)
 			$$ = &nodes.CTESearchClause{
 				SearchColList:      $5,
 				SearchBreadthFirst: true,
 				SearchSeqColumn:    $7,
-				Location:           -1,
+				Location:           nodes.ParseLoc($<loc>1),
 			}
 		}
 	| /* EMPTY */ { $$ = nil }
@@ -7970,24 +8015,26 @@ opt_search_clause:
 opt_cycle_clause:
 	CYCLE columnList SET ColId TO AexprConst DEFAULT AexprConst USING ColId
 		{
+			requireVersion(pglex, PG14, "CYCLE", $<loc>1)
 			$$ = &nodes.CTECycleClause{
 				CycleColList:     $2,
 				CycleMarkColumn:  $4,
 				CycleMarkValue:   $6,
 				CycleMarkDefault: $8,
 				CyclePathColumn:  $10,
-				Location:         -1,
+				Location:         nodes.ParseLoc($<loc>1),
 			}
 		}
 	| CYCLE columnList SET ColId USING ColId
 		{
+			requireVersion(pglex, PG14, "CYCLE", $<loc>1)
 			$$ = &nodes.CTECycleClause{
 				CycleColList:     $2,
 				CycleMarkColumn:  $4,
-				CycleMarkValue:   makeBoolAConst(1),
-				CycleMarkDefault: makeBoolAConst(0),
+				CycleMarkValue:   makeBoolAConst(true),
+				CycleMarkDefault: makeBoolAConst(false),
 				CyclePathColumn:  $6,
-				Location:         -1,
+				Location:         nodes.ParseLoc($<loc>1),
 			}
 		}
 	| /* EMPTY */ { $$ = nil }
@@ -8010,15 +8057,15 @@ into_clause:
 	;
 
 OptTempTableName:
-	TEMPORARY opt_table qualified_name  { rv := makeRangeVar($3); rv.(*nodes.RangeVar).Relpersistence = 't'; $$ = rv }
-	| TEMP opt_table qualified_name     { rv := makeRangeVar($3); rv.(*nodes.RangeVar).Relpersistence = 't'; $$ = rv }
-	| LOCAL TEMPORARY opt_table qualified_name  { rv := makeRangeVar($4); rv.(*nodes.RangeVar).Relpersistence = 't'; $$ = rv }
-	| LOCAL TEMP opt_table qualified_name  { rv := makeRangeVar($4); rv.(*nodes.RangeVar).Relpersistence = 't'; $$ = rv }
-	| GLOBAL TEMPORARY opt_table qualified_name  { rv := makeRangeVar($4); rv.(*nodes.RangeVar).Relpersistence = 't'; $$ = rv }
-	| GLOBAL TEMP opt_table qualified_name  { rv := makeRangeVar($4); rv.(*nodes.RangeVar).Relpersistence = 't'; $$ = rv }
-	| UNLOGGED opt_table qualified_name  { rv := makeRangeVar($3); rv.(*nodes.RangeVar).Relpersistence = 'u'; $$ = rv }
-	| TABLE qualified_name  { $$ = makeRangeVar($2) }
-	| qualified_name        { $$ = makeRangeVar($1) }
+	TEMPORARY opt_table qualified_name  { rv := makeRangeVar($3, $<loc>3); rv.(*nodes.RangeVar).Relpersistence = 't'; $$ = rv }
+	| TEMP opt_table qualified_name     { rv := makeRangeVar($3, $<loc>3); rv.(*nodes.RangeVar).Relpersistence = 't'; $$ = rv }
+	| LOCAL TEMPORARY opt_table qualified_name  { rv := makeRangeVar($4, $<loc>4); rv.(*nodes.RangeVar).Relpersistence = 't'; $$ = rv }
+	| LOCAL TEMP opt_table qualified_name  { rv := makeRangeVar($4, $<loc>4); rv.(*nodes.RangeVar).Relpersistence = 't'; $$ = rv }
+	| GLOBAL TEMPORARY opt_table qualified_name  { rv := makeRangeVar($4, $<loc>4); rv.(*nodes.RangeVar).Relpersistence = 't'; $$ = rv }
+	| GLOBAL TEMP opt_table qualified_name  { rv := makeRangeVar($4, $<loc>4); rv.(*nodes.RangeVar).Relpersistence = 't'; $$ = rv }
+	| UNLOGGED opt_table qualified_name  { rv := makeRangeVar($3, $<loc>3); rv.(*nodes.RangeVar).Relpersistence = 'u'; $$ = rv }
+	| TABLE qualified_name  { $$ = makeRangeVar($2, $<loc>2) }
+	| qualified_name        { $$ = makeRangeVar($1, $<loc>1) }
 	;
 
 target_list:
@@ -8036,29 +8083,34 @@ target_el:
 	a_expr AS ColLabel
 		{
 			$$ = &nodes.ResTarget{
-				Name: $3,
-				Val:  $1,
+				Name:     $3,
+				Val:      $1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
-	| a_expr IDENT
+	| a_expr BareColLabel
 		{
 			$$ = &nodes.ResTarget{
-				Name: $2,
-				Val:  $1,
+				Name:     $2,
+				Val:      $1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| a_expr
 		{
 			$$ = &nodes.ResTarget{
-				Val: $1,
+				Val:      $1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| '*'
 		{
 			$$ = &nodes.ResTarget{
 				Val: &nodes.ColumnRef{
-					Fields: &nodes.List{Items: []nodes.Node{&nodes.A_Star{}}},
+					Fields:   &nodes.List{Items: []nodes.Node{&nodes.A_Star{}}},
+					Location: nodes.ParseLoc($<loc>1),
 				},
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -8266,6 +8318,7 @@ join_qual:
 join_using_alias:
 	AS ColId
 		{
+			requireVersion(pglex, PG14, "JOIN USING alias", $<loc>1)
 			$$ = &nodes.Alias{Aliasname: $2}
 		}
 	| /* EMPTY */ { $$ = nil }
@@ -8274,23 +8327,27 @@ join_using_alias:
 relation_expr:
 	qualified_name
 		{
-			$$ = makeRangeVar($1)
+			$$ = makeRangeVar($1, $<loc>1)
 		}
-	| qualified_name '*'
+	| extended_relation_expr	{ $$ = $1 }
+	;
+
+extended_relation_expr:
+	qualified_name '*'
 		{
-			rv := makeRangeVar($1)
+			rv := makeRangeVar($1, $<loc>1)
 			rv.(*nodes.RangeVar).Inh = true
 			$$ = rv
 		}
 	| ONLY qualified_name
 		{
-			rv := makeRangeVar($2)
+			rv := makeRangeVar($2, $<loc>2)
 			rv.(*nodes.RangeVar).Inh = false
 			$$ = rv
 		}
 	| ONLY '(' qualified_name ')'
 		{
-			rv := makeRangeVar($3)
+			rv := makeRangeVar($3, $<loc>3)
 			rv.(*nodes.RangeVar).Inh = false
 			$$ = rv
 		}
@@ -8348,7 +8405,7 @@ func_table:
 		{
 			$$ = &nodes.RangeFunction{
 				Ordinality: $2,
-				Functions:  makeList(makeList($1)),
+				Functions:  makeList(makeList2($1, nil)),
 			}
 		}
 	| ROWS FROM '(' rowsfrom_list ')' opt_ordinality
@@ -8371,7 +8428,11 @@ rowsfrom_list:
 rowsfrom_item:
 	func_expr_windowless opt_col_def_list
 		{
-			$$ = makeList2($1, $2)
+			if $2 == nil {
+				$$ = makeList2($1, nil)
+			} else {
+				$$ = makeList2($1, $2)
+			}
 		}
 	;
 
@@ -8392,7 +8453,7 @@ tablesample_clause:
 				Method: $2,
 				Args:   $4,
 				Repeatable: $6,
-				Location: -1,
+				Location:   nodes.ParseLoc($<loc>2),
 			}
 		}
 	;
@@ -8423,6 +8484,12 @@ where_or_current_clause:
 group_clause:
 	GROUP_P BY set_quantifier group_by_list
 		{
+			switch $3 {
+			case SET_QUANTIFIER_DISTINCT:
+				requireVersion(pglex, PG14, "GROUP BY DISTINCT", $<loc>3)
+			case SET_QUANTIFIER_ALL:
+				requireVersion(pglex, PG14, "GROUP BY ALL", $<loc>3)
+			}
 			$$ = &GroupClause{
 				Distinct: $3 == SET_QUANTIFIER_DISTINCT,
 				List:     $4,
@@ -8452,28 +8519,28 @@ group_by_item:
 empty_grouping_set:
 	'(' ')'
 		{
-			$$ = &nodes.GroupingSet{Kind: nodes.GROUPING_SET_EMPTY, Location: -1}
+			$$ = &nodes.GroupingSet{Kind: nodes.GROUPING_SET_EMPTY, Location: nodes.ParseLoc($<loc>1)}
 		}
 	;
 
 cube_clause:
 	CUBE '(' expr_list ')'
 		{
-			$$ = &nodes.GroupingSet{Kind: nodes.GROUPING_SET_CUBE, Content: $3, Location: -1}
+			$$ = &nodes.GroupingSet{Kind: nodes.GROUPING_SET_CUBE, Content: $3, Location: nodes.ParseLoc($<loc>1)}
 		}
 	;
 
 rollup_clause:
 	ROLLUP '(' expr_list ')'
 		{
-			$$ = &nodes.GroupingSet{Kind: nodes.GROUPING_SET_ROLLUP, Content: $3, Location: -1}
+			$$ = &nodes.GroupingSet{Kind: nodes.GROUPING_SET_ROLLUP, Content: $3, Location: nodes.ParseLoc($<loc>1)}
 		}
 	;
 
 grouping_sets_clause:
 	GROUPING SETS '(' group_by_list ')'
 		{
-			$$ = &nodes.GroupingSet{Kind: nodes.GROUPING_SET_SETS, Content: $4, Location: -1}
+			$$ = &nodes.GroupingSet{Kind: nodes.GROUPING_SET_SETS, Content: $4, Location: nodes.ParseLoc($<loc>1)}
 		}
 	;
 
@@ -8512,6 +8579,7 @@ sortby:
 				SortbyDir:   nodes.SORTBY_USING,
 				SortbyNulls: nodes.SortByNulls($4),
 				UseOp:       $3,
+				Location:    nodes.ParseLoc($<loc>3),
 			}
 		}
 	| a_expr opt_asc_desc opt_nulls_order
@@ -8520,6 +8588,7 @@ sortby:
 				Node:        $1,
 				SortbyDir:   nodes.SortByDir($2),
 				SortbyNulls: nodes.SortByNulls($3),
+				Location:    -1, /* no operator */
 			}
 		}
 	;
@@ -8589,14 +8658,14 @@ limit_clause:
 	| FETCH first_or_next row_or_rows ONLY
 		{
 			$$ = &SelectLimit{
-				LimitCount:  makeIntConst(1),
+				LimitCount:  makeIntConst(1, -1),
 				LimitOption: nodes.LIMIT_OPTION_COUNT,
 			}
 		}
 	| FETCH first_or_next row_or_rows WITH TIES
 		{
 			$$ = &SelectLimit{
-				LimitCount:  makeIntConst(1),
+				LimitCount:  makeIntConst(1, -1),
 				LimitOption: nodes.LIMIT_OPTION_WITH_TIES,
 			}
 		}
@@ -8624,7 +8693,7 @@ select_limit_value:
 	| ALL
 		{
 			/* LIMIT ALL is represented as a NULL constant */
-			$$ = &nodes.A_Const{Isnull: true}
+			$$ = makeNullAConst($<loc>1)
 		}
 	;
 
@@ -8640,7 +8709,7 @@ select_fetch_first_value:
 		}
 	| '-' c_expr
 		{
-			$$ = doNegate($2)
+			$$ = doNegate($2, $<loc>1)
 		}
 	;
 
@@ -8672,8 +8741,8 @@ for_locking_item:
 		{
 			$$ = &nodes.LockingClause{
 				LockedRels: $2,
-				Strength:   int($1),
-				WaitPolicy: int($3),
+				Strength:   nodes.LockClauseStrength($1),
+				WaitPolicy: nodes.LockWaitPolicy($3),
 			}
 		}
 	;
@@ -8701,81 +8770,82 @@ a_expr:
 	c_expr { $$ = $1 }
 	| a_expr '+' a_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, "+", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, "+", $1, $3, $<loc>2)
 		}
 	| a_expr '-' a_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, "-", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, "-", $1, $3, $<loc>2)
 		}
 	| a_expr '*' a_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, "*", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, "*", $1, $3, $<loc>2)
 		}
 	| a_expr '/' a_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, "/", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, "/", $1, $3, $<loc>2)
 		}
 	| a_expr '%' a_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, "%", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, "%", $1, $3, $<loc>2)
 		}
 	| a_expr '^' a_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, "^", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, "^", $1, $3, $<loc>2)
 		}
 	| a_expr '<' a_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, "<", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, "<", $1, $3, $<loc>2)
 		}
 	| a_expr '>' a_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, ">", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, ">", $1, $3, $<loc>2)
 		}
 	| a_expr '=' a_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, "=", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, "=", $1, $3, $<loc>2)
 		}
 	| a_expr LESS_EQUALS a_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, "<=", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, "<=", $1, $3, $<loc>2)
 		}
 	| a_expr GREATER_EQUALS a_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, ">=", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, ">=", $1, $3, $<loc>2)
 		}
 	| a_expr NOT_EQUALS a_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, "<>", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, "<>", $1, $3, $<loc>2)
 		}
 	| a_expr qual_Op a_expr				%prec Op
 		{
-			$$ = makeAExprFromList(nodes.AEXPR_OP, $2, $1, $3)
+			$$ = makeAExprFromList(nodes.AEXPR_OP, $2, $1, $3, $<loc>2)
 		}
 	| qual_Op a_expr					%prec Op
 		{
-			$$ = makeAExprFromList(nodes.AEXPR_OP, $1, nil, $2)
+			$$ = makeAExprFromList(nodes.AEXPR_OP, $1, nil, $2, $<loc>1)
 		}
 	| a_expr AND a_expr
 		{
-			$$ = makeBoolExpr(nodes.AND_EXPR, $1, $3)
+			$$ = makeBoolExpr(nodes.AND_EXPR, $1, $3, $<loc>2)
 		}
 	| a_expr OR a_expr
 		{
-			$$ = makeBoolExpr(nodes.OR_EXPR, $1, $3)
+			$$ = makeBoolExpr(nodes.OR_EXPR, $1, $3, $<loc>2)
 		}
 	| NOT a_expr
 		{
-			$$ = makeBoolExpr(nodes.NOT_EXPR, $2, nil)
+			$$ = makeBoolExpr(nodes.NOT_EXPR, $2, nil, $<loc>1)
 		}
 	| NOT_LA a_expr							%prec NOT
 		{
-			$$ = makeBoolExpr(nodes.NOT_EXPR, $2, nil)
+			$$ = makeBoolExpr(nodes.NOT_EXPR, $2, nil, $<loc>1)
 		}
 	| a_expr IS NULL_P
 		{
 			$$ = &nodes.NullTest{
 				Arg:         $1,
 				Nulltesttype: nodes.IS_NULL,
+				Location:     nodes.ParseLoc($<loc>2),
 			}
 		}
 	| a_expr IS NOT NULL_P
@@ -8783,6 +8853,7 @@ a_expr:
 			$$ = &nodes.NullTest{
 				Arg:         $1,
 				Nulltesttype: nodes.IS_NOT_NULL,
+				Location:     nodes.ParseLoc($<loc>2),
 			}
 		}
 	| a_expr IS TRUE_P
@@ -8790,6 +8861,7 @@ a_expr:
 			$$ = &nodes.BooleanTest{
 				Arg:          $1,
 				Booltesttype: nodes.IS_TRUE,
+				Location:     nodes.ParseLoc($<loc>2),
 			}
 		}
 	| a_expr IS FALSE_P
@@ -8797,6 +8869,7 @@ a_expr:
 			$$ = &nodes.BooleanTest{
 				Arg:          $1,
 				Booltesttype: nodes.IS_FALSE,
+				Location:     nodes.ParseLoc($<loc>2),
 			}
 		}
 	| a_expr IS NOT TRUE_P                     %prec IS
@@ -8804,6 +8877,7 @@ a_expr:
 			$$ = &nodes.BooleanTest{
 				Arg:          $1,
 				Booltesttype: nodes.IS_NOT_TRUE,
+				Location:     nodes.ParseLoc($<loc>2),
 			}
 		}
 	| a_expr IS NOT FALSE_P                    %prec IS
@@ -8811,6 +8885,7 @@ a_expr:
 			$$ = &nodes.BooleanTest{
 				Arg:          $1,
 				Booltesttype: nodes.IS_NOT_FALSE,
+				Location:     nodes.ParseLoc($<loc>2),
 			}
 		}
 	| a_expr IS UNKNOWN                        %prec IS
@@ -8818,6 +8893,7 @@ a_expr:
 			$$ = &nodes.BooleanTest{
 				Arg:          $1,
 				Booltesttype: nodes.IS_UNKNOWN,
+				Location:     nodes.ParseLoc($<loc>2),
 			}
 		}
 	| a_expr IS NOT UNKNOWN                    %prec IS
@@ -8825,6 +8901,7 @@ a_expr:
 			$$ = &nodes.BooleanTest{
 				Arg:          $1,
 				Booltesttype: nodes.IS_NOT_UNKNOWN,
+				Location:     nodes.ParseLoc($<loc>2),
 			}
 		}
 	| a_expr ISNULL
@@ -8832,6 +8909,7 @@ a_expr:
 			$$ = &nodes.NullTest{
 				Arg:          $1,
 				Nulltesttype: nodes.IS_NULL,
+				Location:     nodes.ParseLoc($<loc>2),
 			}
 		}
 	| a_expr NOTNULL
@@ -8839,15 +8917,16 @@ a_expr:
 			$$ = &nodes.NullTest{
 				Arg:          $1,
 				Nulltesttype: nodes.IS_NOT_NULL,
+				Location:     nodes.ParseLoc($<loc>2),
 			}
 		}
 	| a_expr IS DISTINCT FROM a_expr           %prec IS
 		{
-			$$ = makeAExpr(nodes.AEXPR_DISTINCT, "=", $1, $5)
+			$$ = makeAExpr(nodes.AEXPR_DISTINCT, "=", $1, $5, $<loc>2)
 		}
 	| a_expr IS NOT DISTINCT FROM a_expr       %prec IS
 		{
-			$$ = makeAExpr(nodes.AEXPR_NOT_DISTINCT, "=", $1, $6)
+			$$ = makeAExpr(nodes.AEXPR_NOT_DISTINCT, "=", $1, $6, $<loc>2)
 		}
 	| row OVERLAPS row
 		{
@@ -8864,10 +8943,10 @@ a_expr:
 				args = appendList(args, $3)
 			}
 			$$ = &nodes.FuncCall{
-				Funcname:       makeFuncName("overlaps"),
-				Args:           args,
-				FuncFormat:     int(nodes.COERCE_SQL_SYNTAX),
-				Location:       -1,
+				Funcname:   makeFuncName("pg_catalog", "overlaps"),
+				Args:       args,
+				Funcformat: nodes.COERCE_SQL_SYNTAX,
+				Location:   nodes.ParseLoc($<loc>2),
 			}
 		}
 	| a_expr IS DOCUMENT_P                             %prec IS
@@ -8875,7 +8954,7 @@ a_expr:
 			$$ = &nodes.XmlExpr{
 				Op:       nodes.IS_DOCUMENT,
 				Args:     makeList($1),
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>2),
 			}
 		}
 	| a_expr IS NOT DOCUMENT_P                         %prec IS
@@ -8883,214 +8962,218 @@ a_expr:
 			$$ = makeNotExpr(&nodes.XmlExpr{
 				Op:       nodes.IS_DOCUMENT,
 				Args:     makeList($1),
-				Location: -1,
-			})
+				Location: nodes.ParseLoc($<loc>2),
+			}, $<loc>2)
 		}
 	| a_expr IS json_predicate_type_constraint json_key_uniqueness_constraint_opt   %prec IS
 		{
+			requireVersion(pglex, PG16, "IS JSON", $<loc>2)
 			$$ = &nodes.JsonIsPredicate{
 				Expr:       $1,
+				Format:     makeJsonFormat(nodes.JS_FORMAT_DEFAULT, nodes.JS_ENC_DEFAULT, -1),
 				ItemType:   nodes.JsonValueType($3),
 				UniqueKeys: $4 != 0,
-				Location:   -1,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	| a_expr IS NOT json_predicate_type_constraint json_key_uniqueness_constraint_opt   %prec IS
 		{
+			requireVersion(pglex, PG16, "IS JSON", $<loc>2)
 			$$ = makeNotExpr(&nodes.JsonIsPredicate{
 				Expr:       $1,
+				Format:     makeJsonFormat(nodes.JS_FORMAT_DEFAULT, nodes.JS_ENC_DEFAULT, -1),
 				ItemType:   nodes.JsonValueType($4),
 				UniqueKeys: $5 != 0,
-				Location:   -1,
-			})
+				Location:   nodes.ParseLoc($<loc>1),
+			}, $<loc>1)
 		}
 	| a_expr IS unicode_normal_form NORMALIZED                    %prec IS
 		{
 			$$ = &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "is_normalized"),
-				Args:       makeList2($1, makeStringConst($3)),
-				FuncFormat: int(nodes.COERCE_SQL_SYNTAX),
-				Location:   -1,
+				Args:       makeList2($1, makeStringConst($3, $<loc>3)),
+				Funcformat: nodes.COERCE_SQL_SYNTAX,
+				Location:   nodes.ParseLoc($<loc>2),
 			}
 		}
 	| a_expr IS NOT unicode_normal_form NORMALIZED                %prec IS
 		{
 			$$ = makeNotExpr(&nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "is_normalized"),
-				Args:       makeList2($1, makeStringConst($4)),
-				FuncFormat: int(nodes.COERCE_SQL_SYNTAX),
-				Location:   -1,
-			})
+				Args:       makeList2($1, makeStringConst($4, $<loc>4)),
+				Funcformat: nodes.COERCE_SQL_SYNTAX,
+				Location:   nodes.ParseLoc($<loc>2),
+			}, $<loc>2)
 		}
 	| a_expr IS NORMALIZED                                        %prec IS
 		{
 			$$ = &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "is_normalized"),
-				Args:       makeList2($1, makeStringConst("NFC")),
-				FuncFormat: int(nodes.COERCE_SQL_SYNTAX),
-				Location:   -1,
+				Args:       makeList($1),
+				Funcformat: nodes.COERCE_SQL_SYNTAX,
+				Location:   nodes.ParseLoc($<loc>2),
 			}
 		}
 	| a_expr IS NOT NORMALIZED                                    %prec IS
 		{
 			$$ = makeNotExpr(&nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "is_normalized"),
-				Args:       makeList2($1, makeStringConst("NFC")),
-				FuncFormat: int(nodes.COERCE_SQL_SYNTAX),
-				Location:   -1,
-			})
+				Args:       makeList($1),
+				Funcformat: nodes.COERCE_SQL_SYNTAX,
+				Location:   nodes.ParseLoc($<loc>2),
+			}, $<loc>2)
 		}
 	| a_expr LIKE a_expr                              %prec LIKE
 		{
-			$$ = makeAExpr(nodes.AEXPR_LIKE, "~~", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_LIKE, "~~", $1, $3, $<loc>2)
 		}
 	| a_expr LIKE a_expr ESCAPE a_expr                 %prec LIKE
 		{
 			esc := &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "like_escape"),
 				Args:       makeList2($3, $5),
-				FuncFormat: int(nodes.COERCE_EXPLICIT_CALL),
-				Location:   -1,
+				Funcformat: nodes.COERCE_EXPLICIT_CALL,
+				Location:   nodes.ParseLoc($<loc>2),
 			}
-			$$ = makeAExpr(nodes.AEXPR_LIKE, "~~", $1, esc)
+			$$ = makeAExpr(nodes.AEXPR_LIKE, "~~", $1, esc, $<loc>2)
 		}
 	| a_expr NOT_LA LIKE a_expr                        %prec NOT_LA
 		{
-			$$ = makeAExpr(nodes.AEXPR_LIKE, "!~~", $1, $4)
+			$$ = makeAExpr(nodes.AEXPR_LIKE, "!~~", $1, $4, $<loc>2)
 		}
 	| a_expr NOT_LA LIKE a_expr ESCAPE a_expr          %prec NOT_LA
 		{
 			esc := &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "like_escape"),
 				Args:       makeList2($4, $6),
-				FuncFormat: int(nodes.COERCE_EXPLICIT_CALL),
-				Location:   -1,
+				Funcformat: nodes.COERCE_EXPLICIT_CALL,
+				Location:   nodes.ParseLoc($<loc>2),
 			}
-			$$ = makeAExpr(nodes.AEXPR_LIKE, "!~~", $1, esc)
+			$$ = makeAExpr(nodes.AEXPR_LIKE, "!~~", $1, esc, $<loc>2)
 		}
 	| a_expr ILIKE a_expr                              %prec ILIKE
 		{
-			$$ = makeAExpr(nodes.AEXPR_ILIKE, "~~*", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_ILIKE, "~~*", $1, $3, $<loc>2)
 		}
 	| a_expr ILIKE a_expr ESCAPE a_expr                %prec ILIKE
 		{
 			esc := &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "like_escape"),
 				Args:       makeList2($3, $5),
-				FuncFormat: int(nodes.COERCE_EXPLICIT_CALL),
-				Location:   -1,
+				Funcformat: nodes.COERCE_EXPLICIT_CALL,
+				Location:   nodes.ParseLoc($<loc>2),
 			}
-			$$ = makeAExpr(nodes.AEXPR_ILIKE, "~~*", $1, esc)
+			$$ = makeAExpr(nodes.AEXPR_ILIKE, "~~*", $1, esc, $<loc>2)
 		}
 	| a_expr NOT_LA ILIKE a_expr                       %prec NOT_LA
 		{
-			$$ = makeAExpr(nodes.AEXPR_ILIKE, "!~~*", $1, $4)
+			$$ = makeAExpr(nodes.AEXPR_ILIKE, "!~~*", $1, $4, $<loc>2)
 		}
 	| a_expr NOT_LA ILIKE a_expr ESCAPE a_expr         %prec NOT_LA
 		{
 			esc := &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "like_escape"),
 				Args:       makeList2($4, $6),
-				FuncFormat: int(nodes.COERCE_EXPLICIT_CALL),
-				Location:   -1,
+				Funcformat: nodes.COERCE_EXPLICIT_CALL,
+				Location:   nodes.ParseLoc($<loc>2),
 			}
-			$$ = makeAExpr(nodes.AEXPR_ILIKE, "!~~*", $1, esc)
+			$$ = makeAExpr(nodes.AEXPR_ILIKE, "!~~*", $1, esc, $<loc>2)
 		}
 	| a_expr SIMILAR TO a_expr                         %prec SIMILAR
 		{
 			esc := &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "similar_to_escape"),
 				Args:       makeList($4),
-				FuncFormat: int(nodes.COERCE_EXPLICIT_CALL),
-				Location:   -1,
+				Funcformat: nodes.COERCE_EXPLICIT_CALL,
+				Location:   nodes.ParseLoc($<loc>2),
 			}
-			$$ = makeAExpr(nodes.AEXPR_SIMILAR, "~", $1, esc)
+			$$ = makeAExpr(nodes.AEXPR_SIMILAR, "~", $1, esc, $<loc>2)
 		}
 	| a_expr SIMILAR TO a_expr ESCAPE a_expr           %prec SIMILAR
 		{
 			esc := &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "similar_to_escape"),
 				Args:       makeList2($4, $6),
-				FuncFormat: int(nodes.COERCE_EXPLICIT_CALL),
-				Location:   -1,
+				Funcformat: nodes.COERCE_EXPLICIT_CALL,
+				Location:   nodes.ParseLoc($<loc>2),
 			}
-			$$ = makeAExpr(nodes.AEXPR_SIMILAR, "~", $1, esc)
+			$$ = makeAExpr(nodes.AEXPR_SIMILAR, "~", $1, esc, $<loc>2)
 		}
 	| a_expr NOT_LA SIMILAR TO a_expr                  %prec NOT_LA
 		{
 			esc := &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "similar_to_escape"),
 				Args:       makeList($5),
-				FuncFormat: int(nodes.COERCE_EXPLICIT_CALL),
-				Location:   -1,
+				Funcformat: nodes.COERCE_EXPLICIT_CALL,
+				Location:   nodes.ParseLoc($<loc>2),
 			}
-			$$ = makeAExpr(nodes.AEXPR_SIMILAR, "!~", $1, esc)
+			$$ = makeAExpr(nodes.AEXPR_SIMILAR, "!~", $1, esc, $<loc>2)
 		}
 	| a_expr NOT_LA SIMILAR TO a_expr ESCAPE a_expr    %prec NOT_LA
 		{
 			esc := &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "similar_to_escape"),
 				Args:       makeList2($5, $7),
-				FuncFormat: int(nodes.COERCE_EXPLICIT_CALL),
-				Location:   -1,
+				Funcformat: nodes.COERCE_EXPLICIT_CALL,
+				Location:   nodes.ParseLoc($<loc>2),
 			}
-			$$ = makeAExpr(nodes.AEXPR_SIMILAR, "!~", $1, esc)
+			$$ = makeAExpr(nodes.AEXPR_SIMILAR, "!~", $1, esc, $<loc>2)
 		}
 	| a_expr BETWEEN opt_asymmetric b_expr AND a_expr  %prec BETWEEN
 		{
 			$$ = makeAExpr(nodes.AEXPR_BETWEEN, "BETWEEN", $1,
-				&nodes.List{Items: []nodes.Node{$4, $6}})
+				&nodes.List{Items: []nodes.Node{$4, $6}}, $<loc>2)
 		}
 	| a_expr NOT_LA BETWEEN opt_asymmetric b_expr AND a_expr %prec NOT_LA
 		{
 			$$ = makeAExpr(nodes.AEXPR_NOT_BETWEEN, "NOT BETWEEN", $1,
-				&nodes.List{Items: []nodes.Node{$5, $7}})
+				&nodes.List{Items: []nodes.Node{$5, $7}}, $<loc>2)
 		}
 	| a_expr BETWEEN SYMMETRIC b_expr AND a_expr       %prec BETWEEN
 		{
 			$$ = makeAExpr(nodes.AEXPR_BETWEEN_SYM, "BETWEEN SYMMETRIC", $1,
-				&nodes.List{Items: []nodes.Node{$4, $6}})
+				&nodes.List{Items: []nodes.Node{$4, $6}}, $<loc>2)
 		}
 	| a_expr NOT_LA BETWEEN SYMMETRIC b_expr AND a_expr %prec NOT_LA
 		{
 			$$ = makeAExpr(nodes.AEXPR_NOT_BETWEEN_SYM, "NOT BETWEEN SYMMETRIC", $1,
-				&nodes.List{Items: []nodes.Node{$5, $7}})
+				&nodes.List{Items: []nodes.Node{$5, $7}}, $<loc>2)
 		}
 	| a_expr IN_P '(' expr_list ')'
 		{
-			$$ = makeAExpr(nodes.AEXPR_IN, "=", $1, makeListNode($4))
+			$$ = makeAExpr(nodes.AEXPR_IN, "=", $1, makeListNode($4), $<loc>2)
 		}
 	| a_expr NOT_LA IN_P '(' expr_list ')'             %prec NOT_LA
 		{
-			$$ = makeAExpr(nodes.AEXPR_IN, "<>", $1, makeListNode($5))
+			$$ = makeAExpr(nodes.AEXPR_IN, "<>", $1, makeListNode($5), $<loc>2)
 		}
 	| a_expr IN_P select_with_parens
 		{
 			$$ = &nodes.SubLink{
-				SubLinkType: int(nodes.ANY_SUBLINK),
+				SubLinkType: nodes.ANY_SUBLINK,
 				Testexpr:    $1,
 				Subselect:   $3,
-				Location:    -1,
+				Location:    nodes.ParseLoc($<loc>2),
 			}
 		}
 	| a_expr NOT_LA IN_P select_with_parens            %prec NOT_LA
 		{
 			sublink := &nodes.SubLink{
-				SubLinkType: int(nodes.ANY_SUBLINK),
+				SubLinkType: nodes.ANY_SUBLINK,
 				Testexpr:    $1,
 				Subselect:   $4,
-				Location:    -1,
+				Location:    nodes.ParseLoc($<loc>2),
 			}
-			$$ = makeBoolExpr(nodes.NOT_EXPR, sublink, nil)
+			$$ = makeBoolExpr(nodes.NOT_EXPR, sublink, nil, $<loc>2)
 		}
 	| a_expr subquery_Op sub_type select_with_parens %prec Op
 		{
 			$$ = &nodes.SubLink{
-				SubLinkType: int($3),
+				SubLinkType: nodes.SubLinkType($3),
 				Testexpr:    $1,
 				OperName:    $2,
 				Subselect:   $4,
-				Location:    -1,
+				Location:    nodes.ParseLoc($<loc>2),
 			}
 		}
 	| a_expr subquery_Op sub_type '(' a_expr ')' %prec Op
@@ -9100,7 +9183,7 @@ a_expr:
 			if $3 == int64(nodes.ALL_SUBLINK) {
 				kind = nodes.AEXPR_OP_ALL
 			}
-			$$ = makeAExprFromList(kind, $2, $1, $5)
+			$$ = makeAExprFromList(kind, $2, $1, $5, $<loc>2)
 		}
 	| UNIQUE opt_unique_null_treatment select_with_parens
 		{
@@ -9112,7 +9195,7 @@ a_expr:
 			$$ = &nodes.CollateClause{
 				Arg:      $1,
 				Collname: $3,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>2),
 			}
 		}
 	| a_expr AT TIME ZONE a_expr                       %prec AT
@@ -9120,8 +9203,8 @@ a_expr:
 			$$ = &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "timezone"),
 				Args:       makeList2($5, $1),
-				FuncFormat: int(nodes.COERCE_SQL_SYNTAX),
-				Location:   -1,
+				Funcformat: nodes.COERCE_SQL_SYNTAX,
+				Location:   nodes.ParseLoc($<loc>2),
 			}
 		}
 	| a_expr AT LOCAL                                  %prec AT
@@ -9129,13 +9212,13 @@ a_expr:
 			$$ = &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "timezone"),
 				Args:       makeList($1),
-				FuncFormat: int(nodes.COERCE_SQL_SYNTAX),
+				Funcformat: nodes.COERCE_SQL_SYNTAX,
 				Location:   -1,
 			}
 		}
 	| DEFAULT
 		{
-			$$ = &nodes.SetToDefault{Location: -1}
+			$$ = &nodes.SetToDefault{Location: nodes.ParseLoc($<loc>1)}
 		}
 	| a_expr '[' a_expr ']'
 		{
@@ -9160,16 +9243,16 @@ a_expr:
 			$$ = &nodes.TypeCast{
 				Arg:      $1,
 				TypeName: $3,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>2),
 			}
 		}
 	| '+' a_expr %prec UMINUS
 		{
-			$$ = $2
+			$$ = makeAExpr(nodes.AEXPR_OP, "+", nil, $2, $<loc>1)
 		}
 	| '-' a_expr %prec UMINUS
 		{
-			$$ = doNegate($2)
+			$$ = doNegate($2, $<loc>1)
 		}
 	;
 
@@ -9187,7 +9270,7 @@ sub_type:
 subquery_Op:
 	all_Op
 		{
-			$$ = makeList(&nodes.String{Str: $1})
+			$$ = makeList(&nodes.String{Sval: $1})
 		}
 	| OPERATOR '(' any_operator ')'
 		{
@@ -9195,19 +9278,19 @@ subquery_Op:
 		}
 	| LIKE
 		{
-			$$ = makeList(&nodes.String{Str: "~~"})
+			$$ = makeList(&nodes.String{Sval: "~~"})
 		}
 	| NOT_LA LIKE
 		{
-			$$ = makeList(&nodes.String{Str: "!~~"})
+			$$ = makeList(&nodes.String{Sval: "!~~"})
 		}
 	| ILIKE
 		{
-			$$ = makeList(&nodes.String{Str: "~~*"})
+			$$ = makeList(&nodes.String{Sval: "~~*"})
 		}
 	| NOT_LA ILIKE
 		{
-			$$ = makeList(&nodes.String{Str: "!~~*"})
+			$$ = makeList(&nodes.String{Sval: "!~~*"})
 		}
 	;
 
@@ -9218,7 +9301,7 @@ case_expr:
 				Arg:       $2,
 				Args:      $3,
 				Defresult: $4,
-				Location:  -1,
+				Location:  nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -9240,7 +9323,7 @@ when_clause:
 			$$ = &nodes.CaseWhen{
 				Expr:     $2,
 				Result:   $4,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -9266,20 +9349,20 @@ array_expr:
 		{
 			$$ = &nodes.A_ArrayExpr{
 				Elements: $2,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| '[' array_expr_list ']'
 		{
 			$$ = &nodes.A_ArrayExpr{
 				Elements: $2,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| '[' ']'
 		{
 			$$ = &nodes.A_ArrayExpr{
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -9311,7 +9394,7 @@ row:
 			$$ = &nodes.RowExpr{
 				Args:     $1,
 				RowFormat: nodes.COERCE_IMPLICIT_CAST,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -9322,14 +9405,14 @@ explicit_row:
 			$$ = &nodes.RowExpr{
 				Args:     $3,
 				RowFormat: nodes.COERCE_EXPLICIT_CALL,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| ROW '(' ')'
 		{
 			$$ = &nodes.RowExpr{
 				RowFormat: nodes.COERCE_EXPLICIT_CALL,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -9345,74 +9428,74 @@ b_expr:
 	c_expr { $$ = $1 }
 	| b_expr '+' b_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, "+", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, "+", $1, $3, $<loc>2)
 		}
 	| b_expr '-' b_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, "-", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, "-", $1, $3, $<loc>2)
 		}
 	| b_expr '*' b_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, "*", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, "*", $1, $3, $<loc>2)
 		}
 	| b_expr '/' b_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, "/", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, "/", $1, $3, $<loc>2)
 		}
 	| b_expr '%' b_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, "%", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, "%", $1, $3, $<loc>2)
 		}
 	| b_expr '^' b_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, "^", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, "^", $1, $3, $<loc>2)
 		}
 	| b_expr '<' b_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, "<", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, "<", $1, $3, $<loc>2)
 		}
 	| b_expr '>' b_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, ">", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, ">", $1, $3, $<loc>2)
 		}
 	| b_expr '=' b_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, "=", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, "=", $1, $3, $<loc>2)
 		}
 	| b_expr LESS_EQUALS b_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, "<=", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, "<=", $1, $3, $<loc>2)
 		}
 	| b_expr GREATER_EQUALS b_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, ">=", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, ">=", $1, $3, $<loc>2)
 		}
 	| b_expr NOT_EQUALS b_expr
 		{
-			$$ = makeAExpr(nodes.AEXPR_OP, "<>", $1, $3)
+			$$ = makeAExpr(nodes.AEXPR_OP, "<>", $1, $3, $<loc>2)
 		}
 	| b_expr qual_Op b_expr					%prec Op
 		{
-			$$ = makeAExprFromList(nodes.AEXPR_OP, $2, $1, $3)
+			$$ = makeAExprFromList(nodes.AEXPR_OP, $2, $1, $3, $<loc>2)
 		}
 	| qual_Op b_expr						%prec Op
 		{
-			$$ = makeAExprFromList(nodes.AEXPR_OP, $1, nil, $2)
+			$$ = makeAExprFromList(nodes.AEXPR_OP, $1, nil, $2, $<loc>1)
 		}
 	| b_expr IS DISTINCT FROM b_expr		%prec IS
 		{
-			$$ = makeAExpr(nodes.AEXPR_DISTINCT, "=", $1, $5)
+			$$ = makeAExpr(nodes.AEXPR_DISTINCT, "=", $1, $5, $<loc>2)
 		}
 	| b_expr IS NOT DISTINCT FROM b_expr	%prec IS
 		{
-			$$ = makeAExpr(nodes.AEXPR_NOT_DISTINCT, "=", $1, $6)
+			$$ = makeAExpr(nodes.AEXPR_NOT_DISTINCT, "=", $1, $6, $<loc>2)
 		}
 	| b_expr IS DOCUMENT_P					%prec IS
 		{
 			$$ = &nodes.XmlExpr{
 				Op:       nodes.IS_DOCUMENT,
 				Args:     makeList($1),
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>2),
 			}
 		}
 	| b_expr IS NOT DOCUMENT_P				%prec IS
@@ -9420,24 +9503,24 @@ b_expr:
 			$$ = makeNotExpr(&nodes.XmlExpr{
 				Op:       nodes.IS_DOCUMENT,
 				Args:     makeList($1),
-				Location: -1,
-			})
+				Location: nodes.ParseLoc($<loc>2),
+			}, $<loc>2)
 		}
 	| b_expr TYPECAST Typename
 		{
 			$$ = &nodes.TypeCast{
 				Arg:      $1,
 				TypeName: $3,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>2),
 			}
 		}
 	| '+' b_expr %prec UMINUS
 		{
-			$$ = $2
+			$$ = makeAExpr(nodes.AEXPR_OP, "+", nil, $2, $<loc>1)
 		}
 	| '-' b_expr %prec UMINUS
 		{
-			$$ = doNegate($2)
+			$$ = doNegate($2, $<loc>1)
 		}
 	;
 
@@ -9448,7 +9531,7 @@ c_expr:
 		{
 			p := &nodes.ParamRef{
 				Number:   int($1),
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 			if $2 != nil {
 				$$ = &nodes.A_Indirection{
@@ -9474,17 +9557,17 @@ c_expr:
 	| select_with_parens %prec UMINUS
 		{
 			$$ = &nodes.SubLink{
-				SubLinkType: int(nodes.EXPR_SUBLINK),
+				SubLinkType: nodes.EXPR_SUBLINK,
 				Subselect:   $1,
-				Location:    -1,
+				Location:    nodes.ParseLoc($<loc>1),
 			}
 		}
 	| select_with_parens indirection
 		{
 			sublink := &nodes.SubLink{
-				SubLinkType: int(nodes.EXPR_SUBLINK),
+				SubLinkType: nodes.EXPR_SUBLINK,
 				Subselect:   $1,
-				Location:    -1,
+				Location:    nodes.ParseLoc($<loc>1),
 			}
 			$$ = &nodes.A_Indirection{
 				Arg:         sublink,
@@ -9494,23 +9577,25 @@ c_expr:
 	| EXISTS select_with_parens
 		{
 			$$ = &nodes.SubLink{
-				SubLinkType: int(nodes.EXISTS_SUBLINK),
+				SubLinkType: nodes.EXISTS_SUBLINK,
 				Subselect:   $2,
-				Location:    -1,
+				Location:    nodes.ParseLoc($<loc>1),
 			}
 		}
 	| case_expr { $$ = $1 }
 	| ARRAY select_with_parens
 		{
 			$$ = &nodes.SubLink{
-				SubLinkType: int(nodes.ARRAY_SUBLINK),
+				SubLinkType: nodes.ARRAY_SUBLINK,
 				Subselect:   $2,
-				Location:    -1,
+				Location:    nodes.ParseLoc($<loc>1),
 			}
 		}
 	| ARRAY array_expr
 		{
-			$$ = $2
+			n := $2.(*nodes.A_ArrayExpr)
+			n.Location = nodes.ParseLoc($<loc>1)
+			$$ = n
 		}
 	| explicit_row
 		{
@@ -9521,7 +9606,7 @@ c_expr:
 			$$ = &nodes.RowExpr{
 				Args:     $1,
 				RowFormat: nodes.COERCE_IMPLICIT_CAST,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -9532,6 +9617,15 @@ func_expr:
 		{
 			n := $1.(*nodes.FuncCall)
 			if $2 != nil {
+				if n.AggOrder != nil && len(n.AggOrder.Items) > 0 {
+					pglex.Error("cannot use multiple ORDER BY clauses with WITHIN GROUP")
+				}
+				if n.AggDistinct {
+					pglex.Error("cannot use DISTINCT with WITHIN GROUP")
+				}
+				if n.FuncVariadic {
+					pglex.Error("cannot use VARIADIC with WITHIN GROUP")
+				}
 				n.AggOrder = $2.(*nodes.List)
 				n.AggWithinGroup = true
 			}
@@ -9539,7 +9633,7 @@ func_expr:
 				n.AggFilter = $3
 			}
 			if $4 != nil {
-				n.Over = $4
+				n.Over = $4.(*nodes.WindowDef)
 			}
 			$$ = n
 		}
@@ -9554,7 +9648,7 @@ func_expr:
 				c = v.Constructor
 			}
 			if c != nil {
-				c.Agg_filter = $2
+				c.AggFilter = $2
 				if $3 != nil {
 					c.Over = $3.(*nodes.WindowDef)
 				}
@@ -9586,7 +9680,7 @@ over_clause:
 			$$ = &nodes.WindowDef{
 				Name:         $2,
 				FrameOptions: nodes.FRAMEOPTION_DEFAULTS,
-				Location:     -1,
+				Location:     nodes.ParseLoc($<loc>2),
 			}
 		}
 	| /* EMPTY */				{ $$ = nil }
@@ -9622,7 +9716,7 @@ window_specification:
 			if $4 != nil {
 				n.OrderClause = $4
 			}
-			n.Location = -1
+			n.Location = nodes.ParseLoc($<loc>1)
 			$$ = n
 		}
 	;
@@ -9706,6 +9800,7 @@ func_application:
 		{
 			$$ = &nodes.FuncCall{
 				Funcname: $1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| func_name '(' func_arg_list opt_sort_clause ')'
@@ -9713,6 +9808,7 @@ func_application:
 			n := &nodes.FuncCall{
 				Funcname: $1,
 				Args:     $3,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 			if $4 != nil {
 				n.AggOrder = $4
@@ -9726,6 +9822,7 @@ func_application:
 				Args:         makeList($4),
 				FuncVariadic: true,
 				AggOrder:     $5,
+				Location:     nodes.ParseLoc($<loc>1),
 			}
 		}
 	| func_name '(' func_arg_list ',' VARIADIC func_arg_expr opt_sort_clause ')'
@@ -9735,6 +9832,7 @@ func_application:
 				Args:         appendList($3, $6),
 				FuncVariadic: true,
 				AggOrder:     $7,
+				Location:     nodes.ParseLoc($<loc>1),
 			}
 		}
 	| func_name '(' '*' ')'
@@ -9742,6 +9840,7 @@ func_application:
 			$$ = &nodes.FuncCall{
 				Funcname: $1,
 				AggStar:  true,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| func_name '(' DISTINCT func_arg_list opt_sort_clause ')'
@@ -9751,6 +9850,7 @@ func_application:
 				Args:        $4,
 				AggDistinct: true,
 				AggOrder:    $5,
+				Location:    nodes.ParseLoc($<loc>1),
 			}
 		}
 	| func_name '(' ALL func_arg_list opt_sort_clause ')'
@@ -9758,6 +9858,7 @@ func_application:
 			n := &nodes.FuncCall{
 				Funcname: $1,
 				Args:     $4,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 			if $5 != nil {
 				n.AggOrder = $5
@@ -9777,91 +9878,91 @@ func_expr_common_subexpr:
 			$$ = &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "pg_collation_for"),
 				Args:       makeList($4),
-				FuncFormat: int(nodes.COERCE_SQL_SYNTAX),
-				Location:   -1,
+				Funcformat: nodes.COERCE_SQL_SYNTAX,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	| CURRENT_DATE
 		{
-			$$ = makeSQLValueFunction(nodes.SVFOP_CURRENT_DATE, -1)
+			$$ = makeSQLValueFunction(nodes.SVFOP_CURRENT_DATE, -1, $<loc>1)
 		}
 	| CURRENT_TIME
 		{
-			$$ = makeSQLValueFunction(nodes.SVFOP_CURRENT_TIME, -1)
+			$$ = makeSQLValueFunction(nodes.SVFOP_CURRENT_TIME, -1, $<loc>1)
 		}
 	| CURRENT_TIME '(' Iconst ')'
 		{
-			$$ = makeSQLValueFunction(nodes.SVFOP_CURRENT_TIME_N, int($3))
+			$$ = makeSQLValueFunction(nodes.SVFOP_CURRENT_TIME_N, int($3), $<loc>1)
 		}
 	| CURRENT_TIMESTAMP
 		{
-			$$ = makeSQLValueFunction(nodes.SVFOP_CURRENT_TIMESTAMP, -1)
+			$$ = makeSQLValueFunction(nodes.SVFOP_CURRENT_TIMESTAMP, -1, $<loc>1)
 		}
 	| CURRENT_TIMESTAMP '(' Iconst ')'
 		{
-			$$ = makeSQLValueFunction(nodes.SVFOP_CURRENT_TIMESTAMP_N, int($3))
+			$$ = makeSQLValueFunction(nodes.SVFOP_CURRENT_TIMESTAMP_N, int($3), $<loc>1)
 		}
 	| LOCALTIME
 		{
-			$$ = makeSQLValueFunction(nodes.SVFOP_LOCALTIME, -1)
+			$$ = makeSQLValueFunction(nodes.SVFOP_LOCALTIME, -1, $<loc>1)
 		}
 	| LOCALTIME '(' Iconst ')'
 		{
-			$$ = makeSQLValueFunction(nodes.SVFOP_LOCALTIME_N, int($3))
+			$$ = makeSQLValueFunction(nodes.SVFOP_LOCALTIME_N, int($3), $<loc>1)
 		}
 	| LOCALTIMESTAMP
 		{
-			$$ = makeSQLValueFunction(nodes.SVFOP_LOCALTIMESTAMP, -1)
+			$$ = makeSQLValueFunction(nodes.SVFOP_LOCALTIMESTAMP, -1, $<loc>1)
 		}
 	| LOCALTIMESTAMP '(' Iconst ')'
 		{
-			$$ = makeSQLValueFunction(nodes.SVFOP_LOCALTIMESTAMP_N, int($3))
+			$$ = makeSQLValueFunction(nodes.SVFOP_LOCALTIMESTAMP_N, int($3), $<loc>1)
 		}
 	| CURRENT_ROLE
 		{
-			$$ = makeSQLValueFunction(nodes.SVFOP_CURRENT_ROLE, -1)
+			$$ = makeSQLValueFunction(nodes.SVFOP_CURRENT_ROLE, -1, $<loc>1)
 		}
 	| CURRENT_USER
 		{
-			$$ = makeSQLValueFunction(nodes.SVFOP_CURRENT_USER, -1)
+			$$ = makeSQLValueFunction(nodes.SVFOP_CURRENT_USER, -1, $<loc>1)
 		}
 	| SESSION_USER
 		{
-			$$ = makeSQLValueFunction(nodes.SVFOP_SESSION_USER, -1)
+			$$ = makeSQLValueFunction(nodes.SVFOP_SESSION_USER, -1, $<loc>1)
 		}
 	| SYSTEM_USER
 		{
 			$$ = &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "system_user"),
-				FuncFormat: int(nodes.COERCE_SQL_SYNTAX),
-				Location:   -1,
+				Funcformat: nodes.COERCE_SQL_SYNTAX,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	| USER
 		{
-			$$ = makeSQLValueFunction(nodes.SVFOP_USER, -1)
+			$$ = makeSQLValueFunction(nodes.SVFOP_USER, -1, $<loc>1)
 		}
 	| CURRENT_CATALOG
 		{
-			$$ = makeSQLValueFunction(nodes.SVFOP_CURRENT_CATALOG, -1)
+			$$ = makeSQLValueFunction(nodes.SVFOP_CURRENT_CATALOG, -1, $<loc>1)
 		}
 	| CURRENT_SCHEMA
 		{
-			$$ = makeSQLValueFunction(nodes.SVFOP_CURRENT_SCHEMA, -1)
+			$$ = makeSQLValueFunction(nodes.SVFOP_CURRENT_SCHEMA, -1, $<loc>1)
 		}
 	| CAST '(' a_expr AS Typename ')'
 		{
-			$$ = makeTypeCast($3, $5)
+			$$ = makeTypeCast($3, $5, $<loc>1)
 		}
 	| NULLIF '(' a_expr ',' a_expr ')'
 		{
-			$$ = makeAExpr(nodes.AEXPR_NULLIF, "=", $3, $5)
+			$$ = makeAExpr(nodes.AEXPR_NULLIF, "=", $3, $5, $<loc>1)
 		}
 	| COALESCE '(' expr_list ')'
 		{
 			$$ = &nodes.CoalesceExpr{
 				Args:     $3,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| GREATEST '(' expr_list ')'
@@ -9869,7 +9970,7 @@ func_expr_common_subexpr:
 			$$ = &nodes.MinMaxExpr{
 				Op:       nodes.IS_GREATEST,
 				Args:     $3,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| LEAST '(' expr_list ')'
@@ -9877,7 +9978,7 @@ func_expr_common_subexpr:
 			$$ = &nodes.MinMaxExpr{
 				Op:       nodes.IS_LEAST,
 				Args:     $3,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| EXTRACT '(' extract_list ')'
@@ -9885,8 +9986,8 @@ func_expr_common_subexpr:
 			$$ = &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "extract"),
 				Args:       $3,
-				FuncFormat: int(nodes.COERCE_SQL_SYNTAX),
-				Location:   -1,
+				Funcformat: nodes.COERCE_SQL_SYNTAX,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	| NORMALIZE '(' a_expr ')'
@@ -9894,17 +9995,17 @@ func_expr_common_subexpr:
 			$$ = &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "normalize"),
 				Args:       makeList($3),
-				FuncFormat: int(nodes.COERCE_SQL_SYNTAX),
-				Location:   -1,
+				Funcformat: nodes.COERCE_SQL_SYNTAX,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	| NORMALIZE '(' a_expr ',' unicode_normal_form ')'
 		{
 			$$ = &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "normalize"),
-				Args:       makeList2($3, makeStringConst($5)),
-				FuncFormat: int(nodes.COERCE_SQL_SYNTAX),
-				Location:   -1,
+				Args:       makeList2($3, makeStringConst($5, $<loc>5)),
+				Funcformat: nodes.COERCE_SQL_SYNTAX,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	| OVERLAY '(' overlay_list ')'
@@ -9912,8 +10013,8 @@ func_expr_common_subexpr:
 			$$ = &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "overlay"),
 				Args:       $3,
-				FuncFormat: int(nodes.COERCE_SQL_SYNTAX),
-				Location:   -1,
+				Funcformat: nodes.COERCE_SQL_SYNTAX,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	| OVERLAY '(' func_arg_list_opt ')'
@@ -9921,8 +10022,8 @@ func_expr_common_subexpr:
 			$$ = &nodes.FuncCall{
 				Funcname:   makeFuncName("overlay"),
 				Args:       $3,
-				FuncFormat: int(nodes.COERCE_EXPLICIT_CALL),
-				Location:   -1,
+				Funcformat: nodes.COERCE_EXPLICIT_CALL,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	| POSITION '(' position_list ')'
@@ -9930,8 +10031,8 @@ func_expr_common_subexpr:
 			$$ = &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "position"),
 				Args:       $3,
-				FuncFormat: int(nodes.COERCE_SQL_SYNTAX),
-				Location:   -1,
+				Funcformat: nodes.COERCE_SQL_SYNTAX,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	| SUBSTRING '(' substr_list ')'
@@ -9939,8 +10040,8 @@ func_expr_common_subexpr:
 			$$ = &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "substring"),
 				Args:       $3,
-				FuncFormat: int(nodes.COERCE_SQL_SYNTAX),
-				Location:   -1,
+				Funcformat: nodes.COERCE_SQL_SYNTAX,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	| SUBSTRING '(' func_arg_list_opt ')'
@@ -9948,8 +10049,8 @@ func_expr_common_subexpr:
 			$$ = &nodes.FuncCall{
 				Funcname:   makeFuncName("substring"),
 				Args:       $3,
-				FuncFormat: int(nodes.COERCE_EXPLICIT_CALL),
-				Location:   -1,
+				Funcformat: nodes.COERCE_EXPLICIT_CALL,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	| TREAT '(' a_expr AS Typename ')'
@@ -9957,7 +10058,7 @@ func_expr_common_subexpr:
 			funcName := ""
 			if $5 != nil && $5.Names != nil && len($5.Names.Items) > 0 {
 				if nameNode, ok := $5.Names.Items[len($5.Names.Items)-1].(*nodes.String); ok {
-					funcName = nameNode.Str
+					funcName = nameNode.Sval
 				}
 			}
 			if funcName == "" {
@@ -9966,8 +10067,8 @@ func_expr_common_subexpr:
 			$$ = &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", funcName),
 				Args:       makeList($3),
-				FuncFormat: int(nodes.COERCE_EXPLICIT_CALL),
-				Location:   -1,
+				Funcformat: nodes.COERCE_EXPLICIT_CALL,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	| TRIM '(' BOTH trim_list ')'
@@ -9975,8 +10076,8 @@ func_expr_common_subexpr:
 			$$ = &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "btrim"),
 				Args:       $4,
-				FuncFormat: int(nodes.COERCE_SQL_SYNTAX),
-				Location:   -1,
+				Funcformat: nodes.COERCE_SQL_SYNTAX,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	| TRIM '(' LEADING trim_list ')'
@@ -9984,8 +10085,8 @@ func_expr_common_subexpr:
 			$$ = &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "ltrim"),
 				Args:       $4,
-				FuncFormat: int(nodes.COERCE_SQL_SYNTAX),
-				Location:   -1,
+				Funcformat: nodes.COERCE_SQL_SYNTAX,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	| TRIM '(' TRAILING trim_list ')'
@@ -9993,8 +10094,8 @@ func_expr_common_subexpr:
 			$$ = &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "rtrim"),
 				Args:       $4,
-				FuncFormat: int(nodes.COERCE_SQL_SYNTAX),
-				Location:   -1,
+				Funcformat: nodes.COERCE_SQL_SYNTAX,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	| TRIM '(' trim_list ')'
@@ -10002,15 +10103,15 @@ func_expr_common_subexpr:
 			$$ = &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "btrim"),
 				Args:       $3,
-				FuncFormat: int(nodes.COERCE_SQL_SYNTAX),
-				Location:   -1,
+				Funcformat: nodes.COERCE_SQL_SYNTAX,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	| GROUPING '(' expr_list ')'
 		{
 			$$ = &nodes.GroupingFunc{
 				Args:     $3,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| XMLCONCAT '(' expr_list ')'
@@ -10018,7 +10119,7 @@ func_expr_common_subexpr:
 			$$ = &nodes.XmlExpr{
 				Op:       nodes.IS_XMLCONCAT,
 				Args:     $3,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| XMLELEMENT '(' NAME_P ColLabel ')'
@@ -10026,7 +10127,7 @@ func_expr_common_subexpr:
 			$$ = &nodes.XmlExpr{
 				Op:       nodes.IS_XMLELEMENT,
 				Name:     $4,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| XMLELEMENT '(' NAME_P ColLabel ',' xml_attributes ')'
@@ -10035,7 +10136,7 @@ func_expr_common_subexpr:
 				Op:        nodes.IS_XMLELEMENT,
 				Name:      $4,
 				NamedArgs: $6,
-				Location:  -1,
+				Location:  nodes.ParseLoc($<loc>1),
 			}
 		}
 	| XMLELEMENT '(' NAME_P ColLabel ',' expr_list ')'
@@ -10044,7 +10145,7 @@ func_expr_common_subexpr:
 				Op:       nodes.IS_XMLELEMENT,
 				Name:     $4,
 				Args:     $6,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| XMLELEMENT '(' NAME_P ColLabel ',' xml_attributes ',' expr_list ')'
@@ -10054,7 +10155,7 @@ func_expr_common_subexpr:
 				Name:      $4,
 				NamedArgs: $6,
 				Args:      $8,
-				Location:  -1,
+				Location:  nodes.ParseLoc($<loc>1),
 			}
 		}
 	| XMLEXISTS '(' c_expr xmlexists_argument ')'
@@ -10063,8 +10164,8 @@ func_expr_common_subexpr:
 			$$ = &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "xmlexists"),
 				Args:       makeList2($3, $4),
-				FuncFormat: int(nodes.COERCE_SQL_SYNTAX),
-				Location:   -1,
+				Funcformat: nodes.COERCE_SQL_SYNTAX,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	| XMLFOREST '(' xml_attribute_list ')'
@@ -10072,16 +10173,16 @@ func_expr_common_subexpr:
 			$$ = &nodes.XmlExpr{
 				Op:        nodes.IS_XMLFOREST,
 				NamedArgs: $3,
-				Location:  -1,
+				Location:  nodes.ParseLoc($<loc>1),
 			}
 		}
 	| XMLPARSE '(' document_or_content a_expr xml_whitespace_option ')'
 		{
 			x := &nodes.XmlExpr{
 				Op:       nodes.IS_XMLPARSE,
-				Args:     makeList2($4, makeBoolAConst($5)),
+				Args:     makeList2($4, makeBoolAConst($5 != 0)),
 				Xmloption: nodes.XmlOptionType($3),
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 			$$ = x
 		}
@@ -10090,7 +10191,7 @@ func_expr_common_subexpr:
 			$$ = &nodes.XmlExpr{
 				Op:       nodes.IS_XMLPI,
 				Name:     $4,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| XMLPI '(' NAME_P ColLabel ',' a_expr ')'
@@ -10099,7 +10200,7 @@ func_expr_common_subexpr:
 				Op:       nodes.IS_XMLPI,
 				Name:     $4,
 				Args:     makeList($6),
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| XMLROOT '(' a_expr ',' xml_root_version opt_xml_root_standalone ')'
@@ -10107,7 +10208,7 @@ func_expr_common_subexpr:
 			$$ = &nodes.XmlExpr{
 				Op:       nodes.IS_XMLROOT,
 				Args:     &nodes.List{Items: []nodes.Node{$3, $5, $6}},
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| XMLSERIALIZE '(' document_or_content a_expr AS SimpleTypename xml_indent_option ')'
@@ -10117,7 +10218,7 @@ func_expr_common_subexpr:
 				Expr:      $4,
 				TypeName:  $6,
 				Indent:    $7 != 0,
-				Location:  -1,
+				Location:  nodes.ParseLoc($<loc>1),
 			}
 		}
 	/* SQL/JSON function expressions */
@@ -10127,81 +10228,93 @@ func_expr_common_subexpr:
 			$$ = &nodes.FuncCall{
 				Funcname:   makeFuncName("pg_catalog", "json_object"),
 				Args:       $3,
-				FuncFormat: int(nodes.COERCE_EXPLICIT_CALL),
-				Location:   -1,
+				Funcformat: nodes.COERCE_EXPLICIT_CALL,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	| JSON_OBJECT '(' json_name_and_value_list json_object_constructor_null_clause_opt
 		json_key_uniqueness_constraint_opt json_returning_clause_opt ')'
 		{
+			requireVersion(pglex, PG16, "JSON_OBJECT", $<loc>1)
 			$$ = &nodes.JsonObjectConstructor{
 				Exprs:        $3,
 				Output:       asJsonOutput($6),
 				AbsentOnNull: $4 != 0,
-				UniqueKeys:   $5 != 0,
-				Location:     -1,
+				Unique:       $5 != 0,
+				Location:     nodes.ParseLoc($<loc>1),
 			}
 		}
 	| JSON_OBJECT '(' json_returning_clause_opt ')'
 		{
+			requireVersion(pglex, PG16, "JSON_OBJECT", $<loc>1)
 			$$ = &nodes.JsonObjectConstructor{
 				Output:   asJsonOutput($3),
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| JSON_ARRAY '(' json_value_expr_list json_array_constructor_null_clause_opt
 		json_returning_clause_opt ')'
 		{
+			requireVersion(pglex, PG16, "JSON_ARRAY", $<loc>1)
 			$$ = &nodes.JsonArrayConstructor{
 				Exprs:        $3,
 				AbsentOnNull: $4 != 0,
 				Output:       asJsonOutput($5),
-				Location:     -1,
+				Location:     nodes.ParseLoc($<loc>1),
 			}
 		}
 	| JSON_ARRAY '(' select_no_parens json_format_clause_opt json_returning_clause_opt ')'
 		{
+			requireVersion(pglex, PG16, "JSON_ARRAY", $<loc>1)
 			$$ = &nodes.JsonArrayQueryConstructor{
-				Query:  $3,
-				Output: asJsonOutput($5),
-				Location: -1,
+				Query:        $3,
+				Output:       asJsonOutput($5),
+				Format:       $4.(*nodes.JsonFormat),
+				AbsentOnNull: true,
+				Location:     nodes.ParseLoc($<loc>1),
 			}
 		}
 	| JSON_ARRAY '(' json_returning_clause_opt ')'
 		{
+			requireVersion(pglex, PG16, "JSON_ARRAY", $<loc>1)
 			$$ = &nodes.JsonArrayConstructor{
-				Output:   asJsonOutput($3),
-				Location: -1,
+				Output:       asJsonOutput($3),
+				AbsentOnNull: true,
+				Location:     nodes.ParseLoc($<loc>1),
 			}
 		}
 	| JSON '(' json_value_expr json_key_uniqueness_constraint_opt ')'
 		{
+			requireVersion(pglex, PG17, "JSON()", $<loc>1)
 			$$ = &nodes.JsonParseExpr{
 				Expr:       $3.(*nodes.JsonValueExpr),
 				UniqueKeys: $4 != 0,
-				Location:   -1,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	| JSON_SCALAR '(' a_expr ')'
 		{
+			requireVersion(pglex, PG17, "JSON_SCALAR", $<loc>1)
 			$$ = &nodes.JsonScalarExpr{
 				Expr:     $3,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| JSON_SERIALIZE '(' json_value_expr json_returning_clause_opt ')'
 		{
+			requireVersion(pglex, PG17, "JSON_SERIALIZE", $<loc>1)
 			$$ = &nodes.JsonSerializeExpr{
 				Expr:   $3.(*nodes.JsonValueExpr),
 				Output: asJsonOutput($4),
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| JSON_QUERY '(' json_value_expr ',' a_expr json_passing_clause_opt
 		json_returning_clause_opt json_wrapper_behavior json_quotes_clause_opt
 		json_behavior_clause_opt ')'
 		{
-			onEmpty, onError := splitJsonBehaviorClause($10)
+			requireVersion(pglex, PG17, "JSON_QUERY", $<loc>1)
+			onEmpty, onError := splitJsonBehaviorClause(pglex, $10)
 			$$ = &nodes.JsonFuncExpr{
 				Op:          nodes.JSON_QUERY_OP,
 				ContextItem: $3.(*nodes.JsonValueExpr),
@@ -10212,25 +10325,27 @@ func_expr_common_subexpr:
 				Quotes:      nodes.JsonQuotes($9),
 				OnEmpty:     onEmpty,
 				OnError:     onError,
-				Location:    -1,
+				Location:    nodes.ParseLoc($<loc>1),
 			}
 		}
 	| JSON_EXISTS '(' json_value_expr ',' a_expr json_passing_clause_opt
 		json_on_error_clause_opt ')'
 		{
+			requireVersion(pglex, PG17, "JSON_EXISTS", $<loc>1)
 			$$ = &nodes.JsonFuncExpr{
 				Op:          nodes.JSON_EXISTS_OP,
 				ContextItem: $3.(*nodes.JsonValueExpr),
 				Pathspec:    $5,
 				Passing:     $6,
-				OnError:     asJsonBehavior($7),
-				Location:    -1,
+				OnError:     asJsonBehavior(pglex, $7),
+				Location:    nodes.ParseLoc($<loc>1),
 			}
 		}
 	| JSON_VALUE '(' json_value_expr ',' a_expr json_passing_clause_opt
 		json_returning_clause_opt json_behavior_clause_opt ')'
 		{
-			onEmpty, onError := splitJsonBehaviorClause($8)
+			requireVersion(pglex, PG17, "JSON_VALUE", $<loc>1)
+			onEmpty, onError := splitJsonBehaviorClause(pglex, $8)
 			$$ = &nodes.JsonFuncExpr{
 				Op:          nodes.JSON_VALUE_OP,
 				ContextItem: $3.(*nodes.JsonValueExpr),
@@ -10239,14 +10354,14 @@ func_expr_common_subexpr:
 				Output:      asJsonOutput($7),
 				OnEmpty:     onEmpty,
 				OnError:     onError,
-				Location:    -1,
+				Location:    nodes.ParseLoc($<loc>1),
 			}
 		}
 	| MERGE_ACTION '(' ')'
 		{
-			$$ = &nodes.FuncCall{
-				Funcname:   makeFuncName("merge_action"),
-				Location:   -1,
+			$$ = &nodes.MergeSupportFunc{
+				Msftype:  25, /* TEXTOID */
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -10257,7 +10372,7 @@ func_expr_common_subexpr:
 extract_list:
 	extract_arg FROM a_expr
 		{
-			$$ = makeList2(makeStringConst($1), $3)
+			$$ = makeList2(makeStringConst($1, $<loc>1), $3)
 		}
 	;
 
@@ -10313,10 +10428,14 @@ substr_list:
 		}
 	| a_expr FOR a_expr
 		{
+			/*
+			 * No form of substring takes a textual FOR value, so the
+			 * length is cast to int4 as PostgreSQL does.
+			 */
 			$$ = &nodes.List{Items: []nodes.Node{
 				$1,
-				makeIntConst(1),
-				$3,
+				makeIntConst(1, -1),
+				makeTypeCast($3, makeTypeName("int4"), -1),
 			}}
 		}
 	| a_expr SIMILAR a_expr ESCAPE a_expr
@@ -10333,7 +10452,7 @@ substr_list:
 trim_list:
 	a_expr FROM expr_list
 		{
-			$$ = prependList($1, $3)
+			$$ = appendList($3, $1)
 		}
 	| FROM expr_list
 		{
@@ -10355,18 +10474,18 @@ xml_root_version:
 	VERSION_P a_expr
 		{ $$ = $2 }
 	| VERSION_P NO VALUE_P
-		{ $$ = makeNullAConst() }
+		{ $$ = makeNullAConst(-1) }
 	;
 
 opt_xml_root_standalone:
 	',' STANDALONE_P YES_P
-		{ $$ = makeIntConst(int64(nodes.XML_STANDALONE_YES)) }
+		{ $$ = makeIntConst(int64(nodes.XML_STANDALONE_YES), -1) }
 	| ',' STANDALONE_P NO
-		{ $$ = makeIntConst(int64(nodes.XML_STANDALONE_NO)) }
+		{ $$ = makeIntConst(int64(nodes.XML_STANDALONE_NO), -1) }
 	| ',' STANDALONE_P NO VALUE_P
-		{ $$ = makeIntConst(int64(nodes.XML_STANDALONE_NO_VALUE)) }
+		{ $$ = makeIntConst(int64(nodes.XML_STANDALONE_NO_VALUE), -1) }
 	| /* EMPTY */
-		{ $$ = makeIntConst(int64(nodes.XML_STANDALONE_OMITTED)) }
+		{ $$ = makeIntConst(int64(nodes.XML_STANDALONE_OMITTED), -1) }
 	;
 
 xml_attributes:
@@ -10386,14 +10505,14 @@ xml_attribute_el:
 			$$ = &nodes.ResTarget{
 				Name:     $3,
 				Val:      $1,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| a_expr
 		{
 			$$ = &nodes.ResTarget{
 				Val:      $1,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -10444,7 +10563,7 @@ xmltable:
 				Rowexpr:  $3,
 				Docexpr:  $4,
 				Columns:  $6,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| XMLTABLE '(' XMLNAMESPACES '(' xml_namespace_list ')' ','
@@ -10455,7 +10574,7 @@ xmltable:
 				Docexpr:    $9,
 				Columns:    $11,
 				Namespaces: $5,
-				Location:   -1,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -10473,7 +10592,7 @@ xmltable_column_el:
 			$$ = &nodes.RangeTableFuncCol{
 				Colname:  $1,
 				TypeName: $2,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| ColId Typename xmltable_column_option_list
@@ -10481,21 +10600,32 @@ xmltable_column_el:
 			fc := &nodes.RangeTableFuncCol{
 				Colname:  $1,
 				TypeName: $2,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
+			nullabilitySeen := false
 			for _, item := range $3.Items {
 				defel := item.(*nodes.DefElem)
-				switch defel.Defname {
-				case "default":
+				// Only NULL and NOT NULL make a Boolean __pg__is_not_null.
+				b, isBool := defel.Arg.(*nodes.Boolean)
+				switch {
+				case defel.Defname == "default":
+					if fc.Coldefexpr != nil {
+						pglex.Error("only one DEFAULT value is allowed")
+					}
 					fc.Coldefexpr = defel.Arg
-				case "path":
+				case defel.Defname == "path":
+					if fc.Colexpr != nil {
+						pglex.Error("only one PATH value per column is allowed")
+					}
 					fc.Colexpr = defel.Arg
-				case "__pg__is_not_null":
-					if defel.Arg != nil {
-						if b, ok := defel.Arg.(*nodes.Boolean); ok && b.Boolval {
-							fc.IsNotNull = true
-						}
+				case defel.Defname == "__pg__is_not_null" && isBool:
+					if nullabilitySeen {
+						pglex.Error(fmt.Sprintf("conflicting or redundant NULL / NOT NULL declarations for column \"%s\"", fc.Colname))
 					}
+					fc.IsNotNull = b.Boolval
+					nullabilitySeen = true
+				default:
+					pglex.Error(fmt.Sprintf("unrecognized column option \"%s\"", defel.Defname))
 				}
 			}
 			$$ = fc
@@ -10505,7 +10635,7 @@ xmltable_column_el:
 			$$ = &nodes.RangeTableFuncCol{
 				Colname:       $1,
 				ForOrdinality: true,
-				Location:      -1,
+				Location:      nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -10520,23 +10650,23 @@ xmltable_column_option_list:
 xmltable_column_option_el:
 	IDENT b_expr
 		{
-			$$ = makeDefElem($1, $2)
+			$$ = makeDefElem($1, $2, $<loc>1)
 		}
 	| DEFAULT b_expr
 		{
-			$$ = makeDefElem("default", $2)
+			$$ = makeDefElem("default", $2, $<loc>1)
 		}
 	| NOT NULL_P
 		{
-			$$ = makeDefElem("__pg__is_not_null", &nodes.Boolean{Boolval: true})
+			$$ = makeDefElem("__pg__is_not_null", &nodes.Boolean{Boolval: true}, $<loc>1)
 		}
 	| NULL_P
 		{
-			$$ = makeDefElem("__pg__is_not_null", &nodes.Boolean{Boolval: false})
+			$$ = makeDefElem("__pg__is_not_null", &nodes.Boolean{Boolval: false}, $<loc>1)
 		}
 	| PATH b_expr
 		{
-			$$ = makeDefElem("path", $2)
+			$$ = makeDefElem("path", $2, $<loc>1)
 		}
 	;
 
@@ -10553,14 +10683,14 @@ xml_namespace_el:
 			$$ = &nodes.ResTarget{
 				Name:     $3,
 				Val:      $1,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| DEFAULT b_expr
 		{
 			$$ = &nodes.ResTarget{
 				Val:      $2,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -10576,6 +10706,7 @@ json_value_expr:
 		{
 			$$ = &nodes.JsonValueExpr{
 				RawExpr: $1,
+				Format:  $2.(*nodes.JsonFormat),
 			}
 		}
 	;
@@ -10583,30 +10714,36 @@ json_value_expr:
 json_format_clause:
 	FORMAT_LA JSON
 		{
-			$$ = &nodes.JsonFormat{
-				FormatType: nodes.JS_FORMAT_JSON,
-				Location:   -1,
-			}
+			$$ = makeJsonFormat(nodes.JS_FORMAT_JSON, nodes.JS_ENC_DEFAULT, $<loc>1)
 		}
 	| FORMAT_LA JSON ENCODING name
 		{
-			$$ = &nodes.JsonFormat{
-				FormatType: nodes.JS_FORMAT_JSON,
-				Location:   -1,
+			var encoding nodes.JsonEncoding
+			switch strings.ToLower($4) {
+			case "utf8":
+				encoding = nodes.JS_ENC_UTF8
+			case "utf16":
+				encoding = nodes.JS_ENC_UTF16
+			case "utf32":
+				encoding = nodes.JS_ENC_UTF32
+			default:
+				pglex.Error("unrecognized JSON encoding: " + $4)
 			}
+			$$ = makeJsonFormat(nodes.JS_FORMAT_JSON, encoding, $<loc>1)
 		}
 	;
 
 json_format_clause_opt:
 	json_format_clause      { $$ = $1 }
-	| /* EMPTY */           { $$ = nil }
+	| /* EMPTY */           { $$ = makeJsonFormat(nodes.JS_FORMAT_DEFAULT, nodes.JS_ENC_DEFAULT, -1) }
 	;
 
 json_returning_clause_opt:
 	RETURNING Typename json_format_clause_opt
 		{
 			$$ = &nodes.JsonOutput{
-				TypeName: $2,
+				TypeName:  $2,
+				Returning: &nodes.JsonReturning{Format: $3.(*nodes.JsonFormat)},
 			}
 		}
 	| /* EMPTY */
@@ -10621,16 +10758,16 @@ json_behavior:
 	DEFAULT a_expr
 		{
 			$$ = &nodes.JsonBehavior{
-				Btype: nodes.JSON_BEHAVIOR_DEFAULT,
-				Expr:  $2,
-				Location: -1,
+				Btype:    nodes.JSON_BEHAVIOR_DEFAULT,
+				Expr:     $2,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| json_behavior_type
 		{
 			$$ = &nodes.JsonBehavior{
-				Btype: nodes.JsonBehaviorType($1),
-				Location: -1,
+				Btype:    nodes.JsonBehaviorType($1),
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -10777,24 +10914,26 @@ json_aggregate_func:
 	JSON_OBJECTAGG '(' json_name_and_value json_object_constructor_null_clause_opt
 		json_key_uniqueness_constraint_opt json_returning_clause_opt ')'
 		{
+			requireVersion(pglex, PG16, "JSON_OBJECTAGG", $<loc>1)
 			$$ = &nodes.JsonObjectAgg{
 				Constructor: &nodes.JsonAggConstructor{
 					Output:   asJsonOutput($6),
-					Location: -1,
+					Location: nodes.ParseLoc($<loc>1),
 				},
 				Arg:          $3.(*nodes.JsonKeyValue),
 				AbsentOnNull: $4 != 0,
-				UniqueKeys:   $5 != 0,
+				Unique:       $5 != 0,
 			}
 		}
 	| JSON_ARRAYAGG '(' json_value_expr json_array_aggregate_order_by_clause_opt
 		json_array_constructor_null_clause_opt json_returning_clause_opt ')'
 		{
+			requireVersion(pglex, PG16, "JSON_ARRAYAGG", $<loc>1)
 			$$ = &nodes.JsonArrayAgg{
 				Constructor: &nodes.JsonAggConstructor{
-					Output:    asJsonOutput($6),
-					Agg_order: $4,
-					Location:  -1,
+					Output:   asJsonOutput($6),
+					AggOrder: $4,
+					Location: nodes.ParseLoc($<loc>1),
 				},
 				Arg:          $3.(*nodes.JsonValueExpr),
 				AbsentOnNull: $5 != 0,
@@ -10821,17 +10960,19 @@ json_table:
 		json_on_error_clause_opt
 	')'
 		{
+			requireVersion(pglex, PG17, "JSON_TABLE", $<loc>1)
+			if c, ok := $5.(*nodes.A_Const); !ok {
+				pglex.Error("only string constants are supported in JSON_TABLE path specification")
+			} else if _, ok := c.Val.(*nodes.String); !ok {
+				pglex.Error("only string constants are supported in JSON_TABLE path specification")
+			}
 			$$ = &nodes.JsonTable{
 				ContextItem: $3.(*nodes.JsonValueExpr),
-				Pathspec: &nodes.JsonTablePathSpec{
-					String:   $5,
-					Name:     $6,
-					Location: -1,
-				},
-				Passing:  $7,
-				Columns:  $10,
-				OnError:  asJsonBehavior($12),
-				Location: -1,
+				Pathspec:    makeJsonTablePathSpec($5, $6, $<loc>5, $<loc>6),
+				Passing:     $7,
+				Columns:     $10,
+				OnError:     asJsonBehavior(pglex, $12),
+				Location:    nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -10854,31 +10995,32 @@ json_table_column_definition:
 			$$ = &nodes.JsonTableColumn{
 				Coltype:  nodes.JTC_FOR_ORDINALITY,
 				Name:     $1,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| ColId Typename json_table_column_path_clause_opt
 		json_wrapper_behavior json_quotes_clause_opt
 		json_behavior_clause_opt
 		{
-			onEmpty, onError := splitJsonBehaviorClause($6)
+			onEmpty, onError := splitJsonBehaviorClause(pglex, $6)
 			$$ = &nodes.JsonTableColumn{
 				Coltype:  nodes.JTC_REGULAR,
 				Name:     $1,
 				TypeName: $2,
+				Format:   makeJsonFormat(nodes.JS_FORMAT_DEFAULT, nodes.JS_ENC_DEFAULT, -1),
 				Pathspec: asJsonTablePathSpec($3),
 				Wrapper:  nodes.JsonWrapper($4),
 				Quotes:   nodes.JsonQuotes($5),
 				OnEmpty:  onEmpty,
 				OnError:  onError,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| ColId Typename json_format_clause json_table_column_path_clause_opt
 		json_wrapper_behavior json_quotes_clause_opt
 		json_behavior_clause_opt
 		{
-			onEmpty, onError := splitJsonBehaviorClause($7)
+			onEmpty, onError := splitJsonBehaviorClause(pglex, $7)
 			$$ = &nodes.JsonTableColumn{
 				Coltype:  nodes.JTC_FORMATTED,
 				Name:     $1,
@@ -10889,7 +11031,7 @@ json_table_column_definition:
 				Quotes:   nodes.JsonQuotes($6),
 				OnEmpty:  onEmpty,
 				OnError:  onError,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| ColId Typename EXISTS json_table_column_path_clause_opt
@@ -10899,23 +11041,31 @@ json_table_column_definition:
 				Coltype:  nodes.JTC_EXISTS,
 				Name:     $1,
 				TypeName: $2,
+				Format:   makeJsonFormat(nodes.JS_FORMAT_DEFAULT, nodes.JS_ENC_DEFAULT, -1),
+				Wrapper:  nodes.JSW_NONE,
 				Pathspec: asJsonTablePathSpec($4),
-				OnError:  asJsonBehavior($5),
-				Location: -1,
+				OnError:  asJsonBehavior(pglex, $5),
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
-	| NESTED path_opt Sconst json_table_path_name_opt
+	| NESTED path_opt Sconst
 		COLUMNS '(' json_table_column_definition_list ')'
 		{
 			$$ = &nodes.JsonTableColumn{
-				Coltype: nodes.JTC_NESTED,
-				Pathspec: &nodes.JsonTablePathSpec{
-					String:   makeStringConst($3),
-					Name:     $4,
-					Location: -1,
-				},
-				Columns:  $7,
-				Location: -1,
+				Coltype:  nodes.JTC_NESTED,
+				Pathspec: makeJsonTablePathSpec(makeStringConst($3, $<loc>3), "", $<loc>3, -1),
+				Columns:  $6,
+				Location: nodes.ParseLoc($<loc>1),
+			}
+		}
+	| NESTED path_opt Sconst AS name
+		COLUMNS '(' json_table_column_definition_list ')'
+		{
+			$$ = &nodes.JsonTableColumn{
+				Coltype:  nodes.JTC_NESTED,
+				Pathspec: makeJsonTablePathSpec(makeStringConst($3, $<loc>3), $5, $<loc>3, $<loc>5),
+				Columns:  $8,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -10923,10 +11073,7 @@ json_table_column_definition:
 json_table_column_path_clause_opt:
 	PATH Sconst
 		{
-			$$ = &nodes.JsonTablePathSpec{
-				String:   makeStringConst($2),
-				Location: -1,
-			}
+			$$ = makeJsonTablePathSpec(makeStringConst($2, $<loc>2), "", $<loc>2, -1)
 		}
 	| /* EMPTY */
 		{ $$ = nil }
@@ -10941,13 +11088,13 @@ json_table_column_option_list:
 
 json_table_column_option_el:
 	DEFAULT b_expr
-		{ $$ = makeDefElem("default", $2) }
+		{ $$ = makeDefElem("default", $2, $<loc>1) }
 	| PATH b_expr
-		{ $$ = makeDefElem("path", $2) }
+		{ $$ = makeDefElem("path", $2, $<loc>1) }
 	| NOT NULL_P
-		{ $$ = makeDefElem("__pg__is_not_null", &nodes.Boolean{Boolval: true}) }
+		{ $$ = makeDefElem("__pg__is_not_null", &nodes.Boolean{Boolval: true}, $<loc>1) }
 	| NULL_P
-		{ $$ = makeDefElem("__pg__is_not_null", &nodes.Boolean{Boolval: false}) }
+		{ $$ = makeDefElem("__pg__is_not_null", &nodes.Boolean{Boolval: false}, $<loc>1) }
 	;
 
 path_opt:
@@ -10985,7 +11132,7 @@ func_arg_expr:
 				Name:      $1,
 				Arg:       $3,
 				Argnumber: -1,
-				Location:  -1,
+				Location:  nodes.ParseLoc($<loc>1),
 			}
 		}
 	| param_name EQUALS_GREATER a_expr
@@ -10994,7 +11141,7 @@ func_arg_expr:
 				Name:      $1,
 				Arg:       $3,
 				Argnumber: -1,
-				Location:  -1,
+				Location:  nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -11002,12 +11149,12 @@ func_arg_expr:
 func_name:
 	type_function_name
 		{
-			$$ = makeList(&nodes.String{Str: $1})
+			$$ = makeList(&nodes.String{Sval: $1})
 		}
 	| ColId indirection
 		{
 			/* schema.funcname or catalog.schema.funcname */
-			l := &nodes.List{Items: []nodes.Node{&nodes.String{Str: $1}}}
+			l := &nodes.List{Items: []nodes.Node{&nodes.String{Sval: $1}}}
 			if $2 != nil {
 				for _, item := range $2.Items {
 					l.Items = append(l.Items, item)
@@ -11021,7 +11168,8 @@ columnref:
 	ColId
 		{
 			$$ = &nodes.ColumnRef{
-				Fields: &nodes.List{Items: []nodes.Node{&nodes.String{Str: $1}}},
+				Fields:   &nodes.List{Items: []nodes.Node{&nodes.String{Sval: $1}}},
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| ColId indirection
@@ -11030,7 +11178,7 @@ columnref:
 			// If indirection contains A_Indices (subscripts), split the list.
 			// Field selections before the first subscript go into ColumnRef.Fields,
 			// everything from the first subscript onward goes into A_Indirection.
-			c := &nodes.ColumnRef{}
+			c := &nodes.ColumnRef{Location: nodes.ParseLoc($<loc>1)}
 			nfields := 0
 			var indirList *nodes.List
 			if $2 != nil {
@@ -11043,13 +11191,13 @@ columnref:
 						ind := &nodes.A_Indirection{}
 						if nfields == 0 {
 							// Easy case: all indirection goes to A_Indirection
-							c.Fields = &nodes.List{Items: []nodes.Node{&nodes.String{Str: $1}}}
+							c.Fields = &nodes.List{Items: []nodes.Node{&nodes.String{Sval: $1}}}
 							ind.Indirection = indirList
 						} else {
 							// Split: field selections before subscript go to ColumnRef.Fields,
 							// subscript and after go to A_Indirection.Indirection
 							fieldItems := make([]nodes.Node, 0, nfields+1)
-							fieldItems = append(fieldItems, &nodes.String{Str: $1})
+							fieldItems = append(fieldItems, &nodes.String{Sval: $1})
 							fieldItems = append(fieldItems, indirList.Items[:idx]...)
 							c.Fields = &nodes.List{Items: fieldItems}
 							remaining := make([]nodes.Node, len(indirList.Items)-idx)
@@ -11066,7 +11214,7 @@ columnref:
 			}
 			if !foundSubscript {
 				// No subscripting: all indirection gets added to field list
-				fieldItems := []nodes.Node{&nodes.String{Str: $1}}
+				fieldItems := []nodes.Node{&nodes.String{Sval: $1}}
 				if indirList != nil {
 					fieldItems = append(fieldItems, indirList.Items...)
 				}
@@ -11090,7 +11238,7 @@ indirection:
 indirection_el:
 	'.' attr_name
 		{
-			$$ = &nodes.String{Str: $2}
+			$$ = &nodes.String{Sval: $2}
 		}
 	| '.' '*'
 		{
@@ -11119,79 +11267,85 @@ opt_indirection:
 attrs:
 	'.' attr_name
 		{
-			$$ = makeList(&nodes.String{Str: $2})
+			$$ = makeList(&nodes.String{Sval: $2})
 		}
 	| attrs '.' attr_name
 		{
-			$$ = appendList($1, &nodes.String{Str: $3})
+			$$ = appendList($1, &nodes.String{Sval: $3})
 		}
 	;
 
 AexprConst:
 	Iconst
 		{
-			$$ = &nodes.A_Const{Val: &nodes.Integer{Ival: $1}}
+			$$ = &nodes.A_Const{Val: &nodes.Integer{Ival: $1}, Location: nodes.ParseLoc($<loc>1)}
 		}
 	| FCONST
 		{
-			$$ = &nodes.A_Const{Val: &nodes.Float{Fval: $1}}
+			$$ = &nodes.A_Const{Val: &nodes.Float{Fval: $1}, Location: nodes.ParseLoc($<loc>1)}
 		}
 	| Sconst
 		{
-			$$ = &nodes.A_Const{Val: &nodes.String{Str: $1}}
+			$$ = &nodes.A_Const{Val: &nodes.String{Sval: $1}, Location: nodes.ParseLoc($<loc>1)}
 		}
 	| BCONST
 		{
-			$$ = &nodes.A_Const{Val: &nodes.BitString{Bsval: $1}}
+			$$ = &nodes.A_Const{Val: &nodes.BitString{Bsval: $1}, Location: nodes.ParseLoc($<loc>1)}
 		}
 	| XCONST
 		{
-			$$ = &nodes.A_Const{Val: &nodes.BitString{Bsval: $1}}
+			$$ = &nodes.A_Const{Val: &nodes.BitString{Bsval: $1}, Location: nodes.ParseLoc($<loc>1)}
 		}
 	| TRUE_P
 		{
-			$$ = &nodes.A_Const{Val: &nodes.Boolean{Boolval: true}}
+			$$ = &nodes.A_Const{Val: &nodes.Boolean{Boolval: true}, Location: nodes.ParseLoc($<loc>1)}
 		}
 	| FALSE_P
 		{
-			$$ = &nodes.A_Const{Val: &nodes.Boolean{Boolval: false}}
+			$$ = &nodes.A_Const{Val: &nodes.Boolean{Boolval: false}, Location: nodes.ParseLoc($<loc>1)}
 		}
 	| NULL_P
 		{
-			$$ = &nodes.A_Const{Isnull: true}
+			$$ = &nodes.A_Const{Isnull: true, Location: nodes.ParseLoc($<loc>1)}
 		}
 	| func_name Sconst
 		{
 			/* generic type 'literal' syntax */
-			t := makeTypeNameFromNameList($1).(*nodes.TypeName)
-			$$ = makeStringConstCast($2, t)
+			t := typeNameAt(makeTypeNameFromNameList($1).(*nodes.TypeName), $<loc>1)
+			$$ = makeStringConstCast($2, $<loc>2, t)
 		}
 	| func_name '(' func_arg_list opt_sort_clause ')' Sconst
 		{
 			/* generic syntax with a type modifier */
-			t := makeTypeNameFromNameList($1).(*nodes.TypeName)
-			if $3 != nil {
-				t.Typmods = $3
+			t := typeNameAt(makeTypeNameFromNameList($1).(*nodes.TypeName), $<loc>1)
+			for _, arg := range $3.Items {
+				if _, ok := arg.(*nodes.NamedArgExpr); ok {
+					pglex.Error("type modifier cannot have parameter name")
+				}
 			}
-			$$ = makeStringConstCast($6, t)
+			if $4 != nil {
+				pglex.Error("type modifier cannot have ORDER BY")
+			}
+			t.Typmods = $3
+			$$ = makeStringConstCast($6, $<loc>6, t)
 		}
 	| ConstTypename Sconst
 		{
-			$$ = makeStringConstCast($2, $1)
+			$$ = makeStringConstCast($2, $<loc>2, $1)
 		}
 	| ConstInterval Sconst opt_interval
 		{
-			t := $1
+			t := typeNameAt($1, $<loc>1)
 			if $3 != nil {
 				t.Typmods = $3
 			}
-			$$ = makeStringConstCast($2, t)
+			$$ = makeStringConstCast($2, $<loc>2, t)
 		}
 	| ConstInterval '(' Iconst ')' Sconst
 		{
-			t := $1
-			t.Typmods = makeList2(makeIntConst(int64(nodes.INTERVAL_FULL_RANGE)), makeIntConst($3))
-			$$ = makeStringConstCast($5, t)
+			t := typeNameAt($1, $<loc>1)
+			t.Typmods = makeList2(makeIntConst(int64(nodes.INTERVAL_FULL_RANGE), -1), makeIntConst($3, $<loc>3))
+			$$ = makeStringConstCast($5, $<loc>5, t)
 		}
 	;
 
@@ -11218,6 +11372,12 @@ ColLabel:
 	| reserved_keyword { $$ = $1 }
 	;
 
+/* Column label without AS --- keywords that could follow an expression are excluded */
+BareColLabel:
+	IDENT { $$ = $1 }
+	| bare_label_keyword { $$ = $1 }
+	;
+
 attr_name:
 	ColLabel { $$ = $1 }
 	;
@@ -11229,29 +11389,37 @@ name:
 qualified_name:
 	ColId
 		{
-			$$ = makeList(&nodes.String{Str: $1})
-		}
-	| ColId '.' attr_name
-		{
-			l := makeList(&nodes.String{Str: $1})
-			$$ = appendList(l, &nodes.String{Str: $3})
+			$$ = makeList(&nodes.String{Sval: $1})
 		}
-	| ColId '.' attr_name '.' attr_name
+	| ColId indirection
 		{
-			l := makeList(&nodes.String{Str: $1})
-			l = appendList(l, &nodes.String{Str: $3})
-			$$ = appendList(l, &nodes.String{Str: $5})
+			/* schema.relname or catalog.schema.relname */
+			l := makeList(&nodes.String{Sval: $1})
+			name := $1
+			for _, item := range $2.Items {
+				s, ok := item.(*nodes.String)
+				if !ok {
+					pglex.Error("syntax error")
+					break
+				}
+				l = appendList(l, s)
+				name += "." + s.Sval
+			}
+			if len(l.Items) > 3 {
+				pglex.Error("improper qualified name (too many dotted names): " + name)
+			}
+			$$ = l
 		}
 	;
 
 any_name:
 	ColId
 		{
-			$$ = makeList(&nodes.String{Str: $1})
+			$$ = makeList(&nodes.String{Sval: $1})
 		}
 	| ColId '.' any_name
 		{
-			$$ = prependList(&nodes.String{Str: $1}, $3)
+			$$ = prependList(&nodes.String{Sval: $1}, $3)
 		}
 	;
 
@@ -11263,11 +11431,11 @@ opt_name_list:
 name_list:
 	name
 		{
-			$$ = makeList(&nodes.String{Str: $1})
+			$$ = makeList(&nodes.String{Sval: $1})
 		}
 	| name_list ',' name
 		{
-			$$ = appendList($1, &nodes.String{Str: $3})
+			$$ = appendList($1, &nodes.String{Sval: $3})
 		}
 	;
 
@@ -11345,43 +11513,47 @@ opt_array_bounds:
 
 SimpleTypename:
 	GenericType       { $$ = $1 }
-	| Numeric         { $$ = $1 }
-	| Bit             { $$ = $1 }
-	| Character       { $$ = $1 }
-	| ConstDatetime   { $$ = $1 }
+	| Numeric         { $$ = typeNameAt($1, $<loc>1) }
+	| Bit             { $$ = typeNameAt($1, $<loc>1) }
+	| Character       { $$ = typeNameAt($1, $<loc>1) }
+	| ConstDatetime   { $$ = typeNameAt($1, $<loc>1) }
 	| ConstInterval opt_interval
 		{
 			$$ = $1
 			if $2 != nil {
 				$$.Typmods = $2
 			}
+			$$.Location = nodes.ParseLoc($<loc>1)
 		}
 	| ConstInterval '(' Iconst ')'
 		{
 			$$ = $1
-			$$.Typmods = makeList2(makeIntConst(int64(nodes.INTERVAL_FULL_RANGE)), makeIntConst($3))
+			$$.Typmods = makeList2(makeIntConst(int64(nodes.INTERVAL_FULL_RANGE), -1), makeIntConst($3, $<loc>3))
+			$$.Location = nodes.ParseLoc($<loc>1)
 		}
-	| BOOLEAN_P       { $$ = makeTypeName("bool") }
-	| JSON            { $$ = makeTypeName("json") }
+	| BOOLEAN_P       { $$ = typeNameAt(makeTypeName("bool"), $<loc>1) }
+	| JSON            { $$ = typeNameAt(makeTypeName("json"), $<loc>1) }
 	;
 
 GenericType:
 	type_function_name opt_type_modifiers
 		{
 			$$ = &nodes.TypeName{
-				Names:    makeList(&nodes.String{Str: $1}),
+				Names:    makeList(&nodes.String{Sval: $1}),
 				Typmods:  $2,
-				Location: -1,
+				Typemod:  -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| type_function_name '.' attr_name opt_type_modifiers
 		{
-			l := makeList(&nodes.String{Str: $1})
-			l = appendList(l, &nodes.String{Str: $3})
+			l := makeList(&nodes.String{Sval: $1})
+			l = appendList(l, &nodes.String{Sval: $3})
 			$$ = &nodes.TypeName{
 				Names:    l,
 				Typmods:  $4,
-				Location: -1,
+				Typemod:  -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -11422,10 +11594,15 @@ Numeric:
 opt_float:
 	'(' Iconst ')'
 		{
-			if $2 <= 24 {
+			switch {
+			case $2 < 1:
+				pglex.Error("precision for type float must be at least 1 bit")
+			case $2 <= 24:
 				$$ = makeTypeName("float4")
-			} else {
+			case $2 <= 53:
 				$$ = makeTypeName("float8")
+			default:
+				pglex.Error("precision for type float must be less than 54 bits")
 			}
 		}
 	| /* EMPTY */
@@ -11442,7 +11619,7 @@ Character:
 			} else {
 				$$ = makeTypeName("bpchar")
 			}
-			$$.Typmods = makeList(&nodes.Integer{Ival: $4})
+			$$.Typmods = makeList(makeIntConst($4, $<loc>4))
 		}
 	| CHARACTER opt_varying
 		{
@@ -11450,6 +11627,7 @@ Character:
 				$$ = makeTypeName("varchar")
 			} else {
 				$$ = makeTypeName("bpchar")
+				$$.Typmods = makeList(makeIntConst(1, -1))
 			}
 		}
 	| CHAR_P opt_varying '(' Iconst ')'
@@ -11459,7 +11637,7 @@ Character:
 			} else {
 				$$ = makeTypeName("bpchar")
 			}
-			$$.Typmods = makeList(&nodes.Integer{Ival: $4})
+			$$.Typmods = makeList(makeIntConst($4, $<loc>4))
 		}
 	| CHAR_P opt_varying
 		{
@@ -11467,6 +11645,7 @@ Character:
 				$$ = makeTypeName("varchar")
 			} else {
 				$$ = makeTypeName("bpchar")
+				$$.Typmods = makeList(makeIntConst(1, -1))
 			}
 		}
 	| NATIONAL CHARACTER opt_varying '(' Iconst ')'
@@ -11476,7 +11655,7 @@ Character:
 			} else {
 				$$ = makeTypeName("bpchar")
 			}
-			$$.Typmods = makeList(&nodes.Integer{Ival: $5})
+			$$.Typmods = makeList(makeIntConst($5, $<loc>5))
 		}
 	| NATIONAL CHARACTER opt_varying
 		{
@@ -11484,6 +11663,7 @@ Character:
 				$$ = makeTypeName("varchar")
 			} else {
 				$$ = makeTypeName("bpchar")
+				$$.Typmods = makeList(makeIntConst(1, -1))
 			}
 		}
 	| NATIONAL CHAR_P opt_varying '(' Iconst ')'
@@ -11493,7 +11673,7 @@ Character:
 			} else {
 				$$ = makeTypeName("bpchar")
 			}
-			$$.Typmods = makeList(&nodes.Integer{Ival: $5})
+			$$.Typmods = makeList(makeIntConst($5, $<loc>5))
 		}
 	| NATIONAL CHAR_P opt_varying
 		{
@@ -11501,6 +11681,7 @@ Character:
 				$$ = makeTypeName("varchar")
 			} else {
 				$$ = makeTypeName("bpchar")
+				$$.Typmods = makeList(makeIntConst(1, -1))
 			}
 		}
 	| NCHAR opt_varying '(' Iconst ')'
@@ -11510,7 +11691,7 @@ Character:
 			} else {
 				$$ = makeTypeName("bpchar")
 			}
-			$$.Typmods = makeList(&nodes.Integer{Ival: $4})
+			$$.Typmods = makeList(makeIntConst($4, $<loc>4))
 		}
 	| NCHAR opt_varying
 		{
@@ -11518,12 +11699,13 @@ Character:
 				$$ = makeTypeName("varchar")
 			} else {
 				$$ = makeTypeName("bpchar")
+				$$.Typmods = makeList(makeIntConst(1, -1))
 			}
 		}
 	| VARCHAR '(' Iconst ')'
 		{
 			$$ = makeTypeName("varchar")
-			$$.Typmods = makeList(&nodes.Integer{Ival: $3})
+			$$.Typmods = makeList(makeIntConst($3, $<loc>3))
 		}
 	| VARCHAR
 		{
@@ -11560,25 +11742,26 @@ BitWithoutLength:
 				$$ = makeTypeName("varbit")
 			} else {
 				$$ = makeTypeName("bit")
-				$$.Typmods = makeList(makeIntConst(1))
+				$$.Typmods = makeList(makeIntConst(1, -1))
 			}
 		}
 	;
 
 ConstTypename:
-	Numeric				{ $$ = $1 }
-	| ConstBit			{ $$ = $1 }
-	| ConstCharacter	{ $$ = $1 }
-	| ConstDatetime		{ $$ = $1 }
-	| JSON              { $$ = makeTypeName("json") }
+	Numeric				{ $$ = typeNameAt($1, $<loc>1) }
+	| ConstBit			{ $$ = typeNameAt($1, $<loc>1) }
+	| ConstCharacter	{ $$ = typeNameAt($1, $<loc>1) }
+	| ConstDatetime		{ $$ = typeNameAt($1, $<loc>1) }
+	| JSON              { $$ = typeNameAt(makeTypeName("json"), $<loc>1) }
 	;
 
 ConstBit:
 	BitWithLength		{ $$ = $1 }
 	| BitWithoutLength
 		{
+			/* bit defaults to bit(1) in a column, but a constant's length is not limited */
 			$$ = $1
-			/* ConstBit defaults to unspecified length for BIT */
+			$$.Typmods = nil
 		}
 	;
 
@@ -11590,7 +11773,7 @@ ConstCharacter:
 			} else {
 				$$ = makeTypeName("bpchar")
 			}
-			$$.Typmods = makeList(&nodes.Integer{Ival: $4})
+			$$.Typmods = makeList(makeIntConst($4, $<loc>4))
 		}
 	| CHARACTER opt_varying
 		{
@@ -11608,7 +11791,7 @@ ConstCharacter:
 			} else {
 				$$ = makeTypeName("bpchar")
 			}
-			$$.Typmods = makeList(&nodes.Integer{Ival: $4})
+			$$.Typmods = makeList(makeIntConst($4, $<loc>4))
 		}
 	| CHAR_P opt_varying
 		{
@@ -11625,7 +11808,7 @@ ConstCharacter:
 			} else {
 				$$ = makeTypeName("bpchar")
 			}
-			$$.Typmods = makeList(&nodes.Integer{Ival: $5})
+			$$.Typmods = makeList(makeIntConst($5, $<loc>5))
 		}
 	| NATIONAL CHARACTER opt_varying
 		{
@@ -11642,7 +11825,7 @@ ConstCharacter:
 			} else {
 				$$ = makeTypeName("bpchar")
 			}
-			$$.Typmods = makeList(&nodes.Integer{Ival: $5})
+			$$.Typmods = makeList(makeIntConst($5, $<loc>5))
 		}
 	| NATIONAL CHAR_P opt_varying
 		{
@@ -11659,7 +11842,7 @@ ConstCharacter:
 			} else {
 				$$ = makeTypeName("bpchar")
 			}
-			$$.Typmods = makeList(&nodes.Integer{Ival: $4})
+			$$.Typmods = makeList(makeIntConst($4, $<loc>4))
 		}
 	| NCHAR opt_varying
 		{
@@ -11672,7 +11855,7 @@ ConstCharacter:
 	| VARCHAR '(' Iconst ')'
 		{
 			$$ = makeTypeName("varchar")
-			$$.Typmods = makeList(&nodes.Integer{Ival: $3})
+			$$.Typmods = makeList(makeIntConst($3, $<loc>3))
 		}
 	| VARCHAR
 		{
@@ -11688,7 +11871,7 @@ ConstDatetime:
 			} else {
 				$$ = makeTypeName("timestamp")
 			}
-			$$.Typmods = makeList(makeIntConst($3))
+			$$.Typmods = makeList(makeIntConst($3, $<loc>3))
 		}
 	| TIMESTAMP opt_timezone
 		{
@@ -11705,7 +11888,7 @@ ConstDatetime:
 			} else {
 				$$ = makeTypeName("time")
 			}
-			$$.Typmods = makeList(makeIntConst($3))
+			$$.Typmods = makeList(makeIntConst($3, $<loc>3))
 		}
 	| TIME opt_timezone
 		{
@@ -11732,47 +11915,47 @@ opt_timezone:
 
 opt_interval:
 	YEAR_P
-		{ $$ = makeList(makeIntConst(int64(nodes.INTERVAL_MASK_YEAR))) }
+		{ $$ = makeList(makeIntConst(int64(nodes.INTERVAL_MASK_YEAR), $<loc>1)) }
 	| MONTH_P
-		{ $$ = makeList(makeIntConst(int64(nodes.INTERVAL_MASK_MONTH))) }
+		{ $$ = makeList(makeIntConst(int64(nodes.INTERVAL_MASK_MONTH), $<loc>1)) }
 	| DAY_P
-		{ $$ = makeList(makeIntConst(int64(nodes.INTERVAL_MASK_DAY))) }
+		{ $$ = makeList(makeIntConst(int64(nodes.INTERVAL_MASK_DAY), $<loc>1)) }
 	| HOUR_P
-		{ $$ = makeList(makeIntConst(int64(nodes.INTERVAL_MASK_HOUR))) }
+		{ $$ = makeList(makeIntConst(int64(nodes.INTERVAL_MASK_HOUR), $<loc>1)) }
 	| MINUTE_P
-		{ $$ = makeList(makeIntConst(int64(nodes.INTERVAL_MASK_MINUTE))) }
+		{ $$ = makeList(makeIntConst(int64(nodes.INTERVAL_MASK_MINUTE), $<loc>1)) }
 	| interval_second
 		{ $$ = $1 }
 	| YEAR_P TO MONTH_P
 		{
-			$$ = makeList(makeIntConst(int64(nodes.INTERVAL_MASK_YEAR | nodes.INTERVAL_MASK_MONTH)))
+			$$ = makeList(makeIntConst(int64(nodes.INTERVAL_MASK_YEAR | nodes.INTERVAL_MASK_MONTH), $<loc>1))
 		}
 	| DAY_P TO HOUR_P
 		{
-			$$ = makeList(makeIntConst(int64(nodes.INTERVAL_MASK_DAY | nodes.INTERVAL_MASK_HOUR)))
+			$$ = makeList(makeIntConst(int64(nodes.INTERVAL_MASK_DAY | nodes.INTERVAL_MASK_HOUR), $<loc>1))
 		}
 	| DAY_P TO MINUTE_P
 		{
-			$$ = makeList(makeIntConst(int64(nodes.INTERVAL_MASK_DAY | nodes.INTERVAL_MASK_HOUR | nodes.INTERVAL_MASK_MINUTE)))
+			$$ = makeList(makeIntConst(int64(nodes.INTERVAL_MASK_DAY | nodes.INTERVAL_MASK_HOUR | nodes.INTERVAL_MASK_MINUTE), $<loc>1))
 		}
 	| DAY_P TO interval_second
 		{
 			$$ = $3
-			$$.Items[0] = makeIntConst(int64(nodes.INTERVAL_MASK_DAY | nodes.INTERVAL_MASK_HOUR | nodes.INTERVAL_MASK_MINUTE | nodes.INTERVAL_MASK_SECOND))
+			$$.Items[0] = makeIntConst(int64(nodes.INTERVAL_MASK_DAY | nodes.INTERVAL_MASK_HOUR | nodes.INTERVAL_MASK_MINUTE | nodes.INTERVAL_MASK_SECOND), $<loc>1)
 		}
 	| HOUR_P TO MINUTE_P
 		{
-			$$ = makeList(makeIntConst(int64(nodes.INTERVAL_MASK_HOUR | nodes.INTERVAL_MASK_MINUTE)))
+			$$ = makeList(makeIntConst(int64(nodes.INTERVAL_MASK_HOUR | nodes.INTERVAL_MASK_MINUTE), $<loc>1))
 		}
 	| HOUR_P TO interval_second
 		{
 			$$ = $3
-			$$.Items[0] = makeIntConst(int64(nodes.INTERVAL_MASK_HOUR | nodes.INTERVAL_MASK_MINUTE | nodes.INTERVAL_MASK_SECOND))
+			$$.Items[0] = makeIntConst(int64(nodes.INTERVAL_MASK_HOUR | nodes.INTERVAL_MASK_MINUTE | nodes.INTERVAL_MASK_SECOND), $<loc>1)
 		}
 	| MINUTE_P TO interval_second
 		{
 			$$ = $3
-			$$.Items[0] = makeIntConst(int64(nodes.INTERVAL_MASK_MINUTE | nodes.INTERVAL_MASK_SECOND))
+			$$.Items[0] = makeIntConst(int64(nodes.INTERVAL_MASK_MINUTE | nodes.INTERVAL_MASK_SECOND), $<loc>1)
 		}
 	| /* EMPTY */
 		{ $$ = nil }
@@ -11781,11 +11964,11 @@ opt_interval:
 interval_second:
 	SECOND_P
 		{
-			$$ = makeList(makeIntConst(int64(nodes.INTERVAL_MASK_SECOND)))
+			$$ = makeList(makeIntConst(int64(nodes.INTERVAL_MASK_SECOND), $<loc>1))
 		}
 	| SECOND_P '(' Iconst ')'
 		{
-			$$ = makeList2(makeIntConst(int64(nodes.INTERVAL_MASK_SECOND)), makeIntConst($3))
+			$$ = makeList2(makeIntConst(int64(nodes.INTERVAL_MASK_SECOND), $<loc>1), makeIntConst($3, $<loc>3))
 		}
 	;
 
@@ -11951,11 +12134,11 @@ constraints_set_mode:
 qualified_name_list:
 	qualified_name
 		{
-			$$ = makeList(makeRangeVar($1))
+			$$ = makeList(makeRangeVar($1, $<loc>1))
 		}
 	| qualified_name_list ',' qualified_name
 		{
-			$$ = appendList($1, makeRangeVar($3))
+			$$ = appendList($1, makeRangeVar($3, $<loc>3))
 		}
 	;
 
@@ -12077,7 +12260,7 @@ set_rest_more:
 			$$ = &nodes.VariableSetStmt{
 				Kind: nodes.VAR_SET_VALUE,
 				Name: "search_path",
-				Args: makeList(makeStringConst($2)),
+				Args: makeList(makeStringConst($2, $<loc>2)),
 			}
 		}
 	| NAMES opt_encoding
@@ -12087,7 +12270,7 @@ set_rest_more:
 				Name: "client_encoding",
 			}
 			if $2 != "" {
-				n.Args = makeList(makeStringConst($2))
+				n.Args = makeList(makeStringConst($2, $<loc>2))
 			} else {
 				n.Kind = nodes.VAR_SET_DEFAULT
 			}
@@ -12098,7 +12281,7 @@ set_rest_more:
 			$$ = &nodes.VariableSetStmt{
 				Kind: nodes.VAR_SET_VALUE,
 				Name: "role",
-				Args: makeList(makeStringConst($2)),
+				Args: makeList(makeStringConst($2, $<loc>2)),
 			}
 		}
 	| SESSION AUTHORIZATION NonReservedWord_or_Sconst
@@ -12106,7 +12289,7 @@ set_rest_more:
 			$$ = &nodes.VariableSetStmt{
 				Kind: nodes.VAR_SET_VALUE,
 				Name: "session_authorization",
-				Args: makeList(makeStringConst($3)),
+				Args: makeList(makeStringConst($3, $<loc>3)),
 			}
 		}
 	| SESSION AUTHORIZATION DEFAULT
@@ -12127,7 +12310,7 @@ set_rest_more:
 			$$ = &nodes.VariableSetStmt{
 				Kind: nodes.VAR_SET_VALUE,
 				Name: "xmloption",
-				Args: makeList(makeStringConst(val)),
+				Args: makeList(makeStringConst(val, $<loc>3)),
 			}
 		}
 	| TRANSACTION SNAPSHOT Sconst
@@ -12135,7 +12318,7 @@ set_rest_more:
 			$$ = &nodes.VariableSetStmt{
 				Kind: nodes.VAR_SET_MULTI,
 				Name: "TRANSACTION SNAPSHOT",
-				Args: makeList(makeStringConst($3)),
+				Args: makeList(makeStringConst($3, $<loc>3)),
 			}
 		}
 	;
@@ -12165,26 +12348,26 @@ var_list:
 var_value:
 	opt_boolean_or_string
 		{
-			$$ = makeStringConst($1)
+			$$ = makeStringConst($1, $<loc>1)
 		}
 	| NumericOnly
 		{
-			$$ = &nodes.A_Const{Val: $1}
+			$$ = &nodes.A_Const{Val: $1, Location: nodes.ParseLoc($<loc>1)}
 		}
 	;
 
 zone_value:
 	Sconst
 		{
-			$$ = makeStringConst($1)
+			$$ = makeStringConst($1, $<loc>1)
 		}
 	| IDENT
 		{
-			$$ = makeStringConst($1)
+			$$ = makeStringConst($1, $<loc>1)
 		}
 	| NumericOnly
 		{
-			$$ = &nodes.A_Const{Val: $1}
+			$$ = &nodes.A_Const{Val: $1, Location: nodes.ParseLoc($<loc>1)}
 		}
 	| DEFAULT
 		{
@@ -12212,23 +12395,23 @@ iso_level:
 transaction_mode_item:
 	ISOLATION LEVEL iso_level
 		{
-			$$ = makeDefElem("transaction_isolation", makeStringConst($3))
+			$$ = makeDefElem("transaction_isolation", makeStringConst($3, $<loc>3), $<loc>1)
 		}
 	| READ ONLY
 		{
-			$$ = makeDefElem("transaction_read_only", makeIntConst(1))
+			$$ = makeDefElem("transaction_read_only", makeIntConst(1, $<loc>1), $<loc>1)
 		}
 	| READ WRITE
 		{
-			$$ = makeDefElem("transaction_read_only", makeIntConst(0))
+			$$ = makeDefElem("transaction_read_only", makeIntConst(0, $<loc>1), $<loc>1)
 		}
 	| DEFERRABLE
 		{
-			$$ = makeDefElem("transaction_deferrable", makeIntConst(1))
+			$$ = makeDefElem("transaction_deferrable", makeIntConst(1, $<loc>1), $<loc>1)
 		}
 	| NOT DEFERRABLE
 		{
-			$$ = makeDefElem("transaction_deferrable", makeIntConst(0))
+			$$ = makeDefElem("transaction_deferrable", makeIntConst(0, $<loc>1), $<loc>1)
 		}
 	;
 
@@ -12408,25 +12591,29 @@ DeallocateStmt:
 	DEALLOCATE name
 		{
 			$$ = &nodes.DeallocateStmt{
-				Name: $2,
+				Name:     $2,
+				Location: nodes.ParseLoc($<loc>2),
 			}
 		}
 	| DEALLOCATE PREPARE name
 		{
 			$$ = &nodes.DeallocateStmt{
-				Name: $3,
+				Name:     $3,
+				Location: nodes.ParseLoc($<loc>3),
 			}
 		}
 	| DEALLOCATE ALL
 		{
 			$$ = &nodes.DeallocateStmt{
-				IsAll: true,
+				Isall:    true,
+				Location: -1,
 			}
 		}
 	| DEALLOCATE PREPARE ALL
 		{
 			$$ = &nodes.DeallocateStmt{
-				IsAll: true,
+				Isall:    true,
+				Location: -1,
 			}
 		}
 	;
@@ -12518,21 +12705,21 @@ VacuumStmt:
 	VACUUM opt_full opt_freeze opt_verbose opt_analyze opt_vacuum_relation_list
 		{
 			n := &nodes.VacuumStmt{
-				IsVacuumCmd: true,
+				IsVacuumcmd: true,
 				Rels:        $6,
 			}
 			var opts []nodes.Node
 			if $2 {
-				opts = append(opts, makeDefElem("full", nil))
+				opts = append(opts, makeDefElem("full", nil, $<loc>2))
 			}
 			if $3 {
-				opts = append(opts, makeDefElem("freeze", nil))
+				opts = append(opts, makeDefElem("freeze", nil, $<loc>3))
 			}
 			if $4 {
-				opts = append(opts, makeDefElem("verbose", nil))
+				opts = append(opts, makeDefElem("verbose", nil, $<loc>4))
 			}
 			if $5 {
-				opts = append(opts, makeDefElem("analyze", nil))
+				opts = append(opts, makeDefElem("analyze", nil, $<loc>5))
 			}
 			if len(opts) > 0 {
 				n.Options = &nodes.List{Items: opts}
@@ -12544,7 +12731,7 @@ VacuumStmt:
 			$$ = &nodes.VacuumStmt{
 				Options:     $3,
 				Rels:        $5,
-				IsVacuumCmd: true,
+				IsVacuumcmd: true,
 			}
 		}
 	;
@@ -12553,11 +12740,11 @@ AnalyzeStmt:
 	analyze_keyword opt_verbose opt_vacuum_relation_list
 		{
 			n := &nodes.VacuumStmt{
-				IsVacuumCmd: false,
+				IsVacuumcmd: false,
 				Rels:        $3,
 			}
 			if $2 {
-				n.Options = &nodes.List{Items: []nodes.Node{makeDefElem("verbose", nil)}}
+				n.Options = &nodes.List{Items: []nodes.Node{makeDefElem("verbose", nil, $<loc>2)}}
 			}
 			$$ = n
 		}
@@ -12566,7 +12753,7 @@ AnalyzeStmt:
 			$$ = &nodes.VacuumStmt{
 				Options:     $3,
 				Rels:        $5,
-				IsVacuumCmd: false,
+				IsVacuumcmd: false,
 			}
 		}
 	;
@@ -12600,7 +12787,7 @@ vacuum_relation:
 	qualified_name opt_column_list
 		{
 			$$ = &nodes.VacuumRelation{
-				Relation: makeRangeVar($1).(*nodes.RangeVar),
+				Relation: makeRangeVar($1, $<loc>1).(*nodes.RangeVar),
 				VaCols:   $2,
 			}
 		}
@@ -12632,7 +12819,7 @@ ClusterStmt:
 	CLUSTER '(' utility_option_list ')' qualified_name cluster_index_specification
 		{
 			$$ = &nodes.ClusterStmt{
-				Relation: makeRangeVar($5).(*nodes.RangeVar),
+				Relation: makeRangeVar($5, $<loc>5).(*nodes.RangeVar),
 				Indexname: $6,
 				Params:   $3,
 			}
@@ -12646,11 +12833,11 @@ ClusterStmt:
 	| CLUSTER opt_verbose qualified_name cluster_index_specification
 		{
 			n := &nodes.ClusterStmt{
-				Relation: makeRangeVar($3).(*nodes.RangeVar),
+				Relation: makeRangeVar($3, $<loc>3).(*nodes.RangeVar),
 				Indexname: $4,
 			}
 			if $2 {
-				n.Params = &nodes.List{Items: []nodes.Node{makeDefElem("verbose", nil)}}
+				n.Params = &nodes.List{Items: []nodes.Node{makeDefElem("verbose", nil, $<loc>2)}}
 			}
 			$$ = n
 		}
@@ -12658,7 +12845,7 @@ ClusterStmt:
 		{
 			n := &nodes.ClusterStmt{}
 			if $2 {
-				n.Params = &nodes.List{Items: []nodes.Node{makeDefElem("verbose", nil)}}
+				n.Params = &nodes.List{Items: []nodes.Node{makeDefElem("verbose", nil, $<loc>2)}}
 			}
 			$$ = n
 		}
@@ -12666,11 +12853,11 @@ ClusterStmt:
 	| CLUSTER opt_verbose name ON qualified_name
 		{
 			n := &nodes.ClusterStmt{
-				Relation:  makeRangeVar($5).(*nodes.RangeVar),
+				Relation:  makeRangeVar($5, $<loc>5).(*nodes.RangeVar),
 				Indexname: $3,
 			}
 			if $2 {
-				n.Params = &nodes.List{Items: []nodes.Node{makeDefElem("verbose", nil)}}
+				n.Params = &nodes.List{Items: []nodes.Node{makeDefElem("verbose", nil, $<loc>2)}}
 			}
 			$$ = n
 		}
@@ -12692,11 +12879,11 @@ ReindexStmt:
 		{
 			n := &nodes.ReindexStmt{
 				Kind:     nodes.ReindexObjectType($3),
-				Relation: makeRangeVar($5).(*nodes.RangeVar),
+				Relation: makeRangeVar($5, $<loc>5).(*nodes.RangeVar),
 				Params:   $2,
 			}
 			if $4 {
-				n.Params = appendList(n.Params, makeDefElem("concurrently", nil))
+				n.Params = appendList(n.Params, makeDefElem("concurrently", nil, $<loc>4))
 			}
 			$$ = n
 		}
@@ -12708,19 +12895,22 @@ ReindexStmt:
 				Params: $2,
 			}
 			if $4 {
-				n.Params = appendList(n.Params, makeDefElem("concurrently", nil))
+				n.Params = appendList(n.Params, makeDefElem("concurrently", nil, $<loc>4))
 			}
 			$$ = n
 		}
-	| REINDEX opt_reindex_option_list reindex_target_multitable opt_concurrently name
+	| REINDEX opt_reindex_option_list reindex_target_multitable opt_concurrently opt_single_name
 		{
+			if $5 == "" {
+				requireVersion(pglex, PG17, "REINDEX SYSTEM or DATABASE without a name", $<loc>3)
+			}
 			n := &nodes.ReindexStmt{
 				Kind:   nodes.ReindexObjectType($3),
 				Name:   $5,
 				Params: $2,
 			}
 			if $4 {
-				n.Params = appendList(n.Params, makeDefElem("concurrently", nil))
+				n.Params = appendList(n.Params, makeDefElem("concurrently", nil, $<loc>4))
 			}
 			$$ = n
 		}
@@ -12768,11 +12958,11 @@ CommentStmt:
 		{
 			$$ = &nodes.CommentStmt{
 				Objtype: nodes.ObjectType($3),
-				Object:  &nodes.String{Str: $4},
+				Object:  &nodes.String{Sval: $4},
 				Comment: $6,
 			}
 		}
-	| COMMENT ON TYPE_P any_name IS comment_text
+	| COMMENT ON TYPE_P Typename IS comment_text
 		{
 			$$ = &nodes.CommentStmt{
 				Objtype: nodes.OBJECT_TYPE,
@@ -12780,7 +12970,7 @@ CommentStmt:
 				Comment: $6,
 			}
 		}
-	| COMMENT ON DOMAIN_P any_name IS comment_text
+	| COMMENT ON DOMAIN_P Typename IS comment_text
 		{
 			$$ = &nodes.CommentStmt{
 				Objtype: nodes.OBJECT_DOMAIN,
@@ -12832,7 +13022,7 @@ CommentStmt:
 		{
 			$$ = &nodes.CommentStmt{
 				Objtype: nodes.OBJECT_TABCONSTRAINT,
-				Object:  appendList($6, &nodes.String{Str: $4}),
+				Object:  appendList($6, &nodes.String{Sval: $4}),
 				Comment: $8,
 			}
 		}
@@ -12840,7 +13030,7 @@ CommentStmt:
 		{
 			$$ = &nodes.CommentStmt{
 				Objtype: nodes.OBJECT_DOMCONSTRAINT,
-				Object:  &nodes.List{Items: []nodes.Node{makeTypeNameFromNameList($7), &nodes.String{Str: $4}}},
+				Object:  &nodes.List{Items: []nodes.Node{makeTypeNameFromNameList($7), &nodes.String{Sval: $4}}},
 				Comment: $9,
 			}
 		}
@@ -12848,7 +13038,7 @@ CommentStmt:
 		{
 			$$ = &nodes.CommentStmt{
 				Objtype: nodes.ObjectType($3),
-				Object:  appendList($6, &nodes.String{Str: $4}),
+				Object:  appendList($6, &nodes.String{Sval: $4}),
 				Comment: $8,
 			}
 		}
@@ -12856,7 +13046,7 @@ CommentStmt:
 		{
 			$$ = &nodes.CommentStmt{
 				Objtype: nodes.OBJECT_TRANSFORM,
-				Object:  &nodes.List{Items: []nodes.Node{$5, &nodes.String{Str: $7}}},
+				Object:  &nodes.List{Items: []nodes.Node{$5, &nodes.String{Sval: $7}}},
 				Comment: $9,
 			}
 		}
@@ -12864,7 +13054,7 @@ CommentStmt:
 		{
 			$$ = &nodes.CommentStmt{
 				Objtype: nodes.OBJECT_OPCLASS,
-				Object:  prependList(&nodes.String{Str: $7}, $5),
+				Object:  prependList(&nodes.String{Sval: $7}, $5),
 				Comment: $9,
 			}
 		}
@@ -12872,7 +13062,7 @@ CommentStmt:
 		{
 			$$ = &nodes.CommentStmt{
 				Objtype: nodes.OBJECT_OPFAMILY,
-				Object:  prependList(&nodes.String{Str: $7}, $5),
+				Object:  prependList(&nodes.String{Sval: $7}, $5),
 				Comment: $9,
 			}
 		}
@@ -12888,7 +13078,7 @@ CommentStmt:
 		{
 			$$ = &nodes.CommentStmt{
 				Objtype: nodes.OBJECT_FDW,
-				Object:  &nodes.String{Str: $6},
+				Object:  &nodes.String{Sval: $6},
 				Comment: $8,
 			}
 		}
@@ -12904,7 +13094,7 @@ CommentStmt:
 		{
 			$$ = &nodes.CommentStmt{
 				Objtype: nodes.OBJECT_EVENT_TRIGGER,
-				Object:  &nodes.String{Str: $5},
+				Object:  &nodes.String{Sval: $5},
 				Comment: $7,
 			}
 		}
@@ -12923,6 +13113,8 @@ object_type_name:
 	| SUBSCRIPTION { $$ = int64(nodes.OBJECT_SUBSCRIPTION) }
 	| PUBLICATION  { $$ = int64(nodes.OBJECT_PUBLICATION) }
 	| SERVER       { $$ = int64(nodes.OBJECT_FOREIGN_SERVER) }
+	| EXTENSION    { $$ = int64(nodes.OBJECT_EXTENSION) }
+	| opt_procedural LANGUAGE { $$ = int64(nodes.OBJECT_LANGUAGE) }
 	;
 
 object_type_name_on_any_name:
@@ -12960,12 +13152,12 @@ SecLabelStmt:
 		{
 			$$ = &nodes.SecLabelStmt{
 				Objtype:  nodes.ObjectType($5),
-				Object:   &nodes.String{Str: $6},
+				Object:   &nodes.String{Sval: $6},
 				Provider: $3,
 				Label:    $8,
 			}
 		}
-	| SECURITY LABEL opt_provider ON TYPE_P any_name IS security_label
+	| SECURITY LABEL opt_provider ON TYPE_P Typename IS security_label
 		{
 			$$ = &nodes.SecLabelStmt{
 				Objtype:  nodes.OBJECT_TYPE,
@@ -12974,7 +13166,7 @@ SecLabelStmt:
 				Label:    $8,
 			}
 		}
-	| SECURITY LABEL opt_provider ON DOMAIN_P any_name IS security_label
+	| SECURITY LABEL opt_provider ON DOMAIN_P Typename IS security_label
 		{
 			$$ = &nodes.SecLabelStmt{
 				Objtype:  nodes.OBJECT_DOMAIN,
@@ -13260,6 +13452,10 @@ opt_from_in:
 MergeStmt:
 	opt_with_clause MERGE INTO relation_expr_opt_alias USING table_ref ON a_expr merge_when_list returning_clause
 		{
+			requireVersion(pglex, PG15, "MERGE", $<loc>2)
+			if $10 != nil {
+				requireVersion(pglex, PG17, "MERGE ... RETURNING", $<loc>10)
+			}
 			m := &nodes.MergeStmt{}
 			if $1 != nil {
 				m.WithClause = $1.(*nodes.WithClause)
@@ -13268,7 +13464,7 @@ MergeStmt:
 			m.SourceRelation = $6
 			m.JoinCondition = $8
 			m.MergeWhenClauses = $9
-			m.ReturningList = $10
+			m.ReturningList, m.ReturningOptions = returningLists($10)
 			$$ = m
 		}
 	;
@@ -13288,28 +13484,28 @@ merge_when_clause:
 	merge_when_tgt_matched opt_merge_when_condition THEN merge_update
 		{
 			n := $4.(*nodes.MergeWhenClause)
-			n.Kind = nodes.MergeMatchKind($1)
+			n.MatchKind = nodes.MergeMatchKind($1)
 			n.Condition = $2
 			$$ = n
 		}
 	| merge_when_tgt_matched opt_merge_when_condition THEN merge_delete
 		{
 			n := $4.(*nodes.MergeWhenClause)
-			n.Kind = nodes.MergeMatchKind($1)
+			n.MatchKind = nodes.MergeMatchKind($1)
 			n.Condition = $2
 			$$ = n
 		}
 	| merge_when_tgt_not_matched opt_merge_when_condition THEN merge_insert
 		{
 			n := $4.(*nodes.MergeWhenClause)
-			n.Kind = nodes.MergeMatchKind($1)
+			n.MatchKind = nodes.MergeMatchKind($1)
 			n.Condition = $2
 			$$ = n
 		}
 	| merge_when_tgt_matched opt_merge_when_condition THEN DO NOTHING
 		{
 			$$ = &nodes.MergeWhenClause{
-				Kind:        nodes.MergeMatchKind($1),
+				MatchKind:   nodes.MergeMatchKind($1),
 				CommandType: nodes.CMD_NOTHING,
 				Condition:   $2,
 			}
@@ -13317,7 +13513,7 @@ merge_when_clause:
 	| merge_when_tgt_not_matched opt_merge_when_condition THEN DO NOTHING
 		{
 			$$ = &nodes.MergeWhenClause{
-				Kind:        nodes.MergeMatchKind($1),
+				MatchKind:   nodes.MergeMatchKind($1),
 				CommandType: nodes.CMD_NOTHING,
 				Condition:   $2,
 			}
@@ -13331,6 +13527,7 @@ merge_when_tgt_matched:
 		}
 	| WHEN NOT MATCHED BY SOURCE
 		{
+			requireVersion(pglex, PG17, "WHEN NOT MATCHED BY SOURCE", $<loc>4)
 			$$ = int64(nodes.MERGE_WHEN_NOT_MATCHED_BY_SOURCE)
 		}
 	;
@@ -13342,6 +13539,7 @@ merge_when_tgt_not_matched:
 		}
 	| WHEN NOT MATCHED BY TARGET
 		{
+			requireVersion(pglex, PG17, "WHEN NOT MATCHED BY TARGET", $<loc>4)
 			$$ = int64(nodes.MERGE_WHEN_NOT_MATCHED_BY_TARGET)
 		}
 	;
@@ -13485,15 +13683,17 @@ dostmt_opt_item:
 	Sconst
 		{
 			$$ = &nodes.DefElem{
-				Defname: "as",
-				Arg:     &nodes.String{Str: $1},
+				Defname:  "as",
+				Arg:      &nodes.String{Sval: $1},
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| LANGUAGE NonReservedWord_or_Sconst
 		{
 			$$ = &nodes.DefElem{
-				Defname: "language",
-				Arg:     &nodes.String{Str: $2},
+				Defname:  "language",
+				Arg:      &nodes.String{Sval: $2},
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -13564,55 +13764,55 @@ RemoveFuncStmt:
 	DROP FUNCTION function_with_argtypes_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				RemoveType: int(nodes.OBJECT_FUNCTION),
+				RemoveType: nodes.OBJECT_FUNCTION,
 				Objects:    $3,
-				Behavior:   int($4),
-				Missing_ok: false,
+				Behavior:   nodes.DropBehavior($4),
+				MissingOk:  false,
 			}
 		}
 	| DROP FUNCTION IF_P EXISTS function_with_argtypes_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				RemoveType: int(nodes.OBJECT_FUNCTION),
+				RemoveType: nodes.OBJECT_FUNCTION,
 				Objects:    $5,
-				Behavior:   int($6),
-				Missing_ok: true,
+				Behavior:   nodes.DropBehavior($6),
+				MissingOk:  true,
 			}
 		}
 	| DROP PROCEDURE function_with_argtypes_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				RemoveType: int(nodes.OBJECT_PROCEDURE),
+				RemoveType: nodes.OBJECT_PROCEDURE,
 				Objects:    $3,
-				Behavior:   int($4),
-				Missing_ok: false,
+				Behavior:   nodes.DropBehavior($4),
+				MissingOk:  false,
 			}
 		}
 	| DROP PROCEDURE IF_P EXISTS function_with_argtypes_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				RemoveType: int(nodes.OBJECT_PROCEDURE),
+				RemoveType: nodes.OBJECT_PROCEDURE,
 				Objects:    $5,
-				Behavior:   int($6),
-				Missing_ok: true,
+				Behavior:   nodes.DropBehavior($6),
+				MissingOk:  true,
 			}
 		}
 	| DROP ROUTINE function_with_argtypes_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				RemoveType: int(nodes.OBJECT_ROUTINE),
+				RemoveType: nodes.OBJECT_ROUTINE,
 				Objects:    $3,
-				Behavior:   int($4),
-				Missing_ok: false,
+				Behavior:   nodes.DropBehavior($4),
+				MissingOk:  false,
 			}
 		}
 	| DROP ROUTINE IF_P EXISTS function_with_argtypes_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				RemoveType: int(nodes.OBJECT_ROUTINE),
+				RemoveType: nodes.OBJECT_ROUTINE,
 				Objects:    $5,
-				Behavior:   int($6),
-				Missing_ok: true,
+				Behavior:   nodes.DropBehavior($6),
+				MissingOk:  true,
 			}
 		}
 	;
@@ -13627,19 +13827,19 @@ RemoveAggrStmt:
 	DROP AGGREGATE aggregate_with_argtypes_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				RemoveType: int(nodes.OBJECT_AGGREGATE),
+				RemoveType: nodes.OBJECT_AGGREGATE,
 				Objects:    $3,
-				Behavior:   int($4),
-				Missing_ok: false,
+				Behavior:   nodes.DropBehavior($4),
+				MissingOk:  false,
 			}
 		}
 	| DROP AGGREGATE IF_P EXISTS aggregate_with_argtypes_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				RemoveType: int(nodes.OBJECT_AGGREGATE),
+				RemoveType: nodes.OBJECT_AGGREGATE,
 				Objects:    $5,
-				Behavior:   int($6),
-				Missing_ok: true,
+				Behavior:   nodes.DropBehavior($6),
+				MissingOk:  true,
 			}
 		}
 	;
@@ -13654,19 +13854,19 @@ RemoveOperStmt:
 	DROP OPERATOR operator_with_argtypes_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				RemoveType: int(nodes.OBJECT_OPERATOR),
+				RemoveType: nodes.OBJECT_OPERATOR,
 				Objects:    $3,
-				Behavior:   int($4),
-				Missing_ok: false,
+				Behavior:   nodes.DropBehavior($4),
+				MissingOk:  false,
 			}
 		}
 	| DROP OPERATOR IF_P EXISTS operator_with_argtypes_list opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				RemoveType: int(nodes.OBJECT_OPERATOR),
+				RemoveType: nodes.OBJECT_OPERATOR,
 				Objects:    $5,
-				Behavior:   int($6),
-				Missing_ok: true,
+				Behavior:   nodes.DropBehavior($6),
+				MissingOk:  true,
 			}
 		}
 	;
@@ -13688,28 +13888,29 @@ function_with_argtypes:
 	func_name func_args
 		{
 			$$ = &nodes.ObjectWithArgs{
-				Objname: $1,
-				Objargs: extractArgTypes($2),
+				Objname:     $1,
+				Objargs:     extractArgTypes($2),
+				Objfuncargs: $2,
 			}
 		}
 	| type_func_name_keyword
 		{
 			$$ = &nodes.ObjectWithArgs{
-				Objname:        &nodes.List{Items: []nodes.Node{&nodes.String{Str: $1}}},
+				Objname:         &nodes.List{Items: []nodes.Node{&nodes.String{Sval: $1}}},
 				ArgsUnspecified: true,
 			}
 		}
 	| ColId
 		{
 			$$ = &nodes.ObjectWithArgs{
-				Objname:        &nodes.List{Items: []nodes.Node{&nodes.String{Str: $1}}},
+				Objname:         &nodes.List{Items: []nodes.Node{&nodes.String{Sval: $1}}},
 				ArgsUnspecified: true,
 			}
 		}
 	| ColId indirection
 		{
 			$$ = &nodes.ObjectWithArgs{
-				Objname:        checkFuncName(prependList(&nodes.String{Str: $1}, $2)),
+				Objname:         checkFuncName(prependList(&nodes.String{Sval: $1}, $2)),
 				ArgsUnspecified: true,
 			}
 		}
@@ -13738,10 +13939,14 @@ func_args_list:
 aggregate_with_argtypes:
 	func_name aggr_args
 		{
-			$$ = &nodes.ObjectWithArgs{
+			n := &nodes.ObjectWithArgs{
 				Objname: $1,
 				Objargs: extractAggrArgTypes($2),
 			}
+			if $2 != nil && len($2.Items) > 0 {
+				n.Objfuncargs, _ = $2.Items[0].(*nodes.List)
+			}
+			$$ = n
 		}
 	;
 
@@ -13808,6 +14013,9 @@ CreateTrigStmt:
 	qualified_name TriggerReferencing TriggerForSpec TriggerWhen
 	EXECUTE FUNCTION_or_PROCEDURE func_name '(' TriggerFuncArgs ')'
 		{
+			if $2 {
+				requireVersion(pglex, PG14, "CREATE OR REPLACE TRIGGER", $<loc>2)
+			}
 			eventsInt := $6.Items[0].(*nodes.Integer).Ival
 			var columns *nodes.List
 			if len($6.Items) > 1 && $6.Items[1] != nil {
@@ -13815,19 +14023,19 @@ CreateTrigStmt:
 			}
 			$$ = &nodes.CreateTrigStmt{
 				Replace:        $2,
-				IsConstraint:   false,
-				Trigname:        $4,
-				Relation:        makeRangeVarFromAnyName($8),
-				Funcname:        $14,
-				Args:            $16,
-				Row:             $10,
-				Timing:          int16($5),
-				Events:          int16(eventsInt),
-				Columns:         columns,
-				WhenClause:      $11,
-				TransitionRels:  $9,
-				Deferrable:      false,
-				Initdeferred:    false,
+				Isconstraint:   false,
+				Trigname:       $4,
+				Relation:       makeRangeVarFromAnyName($8, $<loc>8),
+				Funcname:       $14,
+				Args:           $16,
+				Row:            $10,
+				Timing:         int16($5),
+				Events:         int16(eventsInt),
+				Columns:        columns,
+				WhenClause:     $11,
+				TransitionRels: $9,
+				Deferrable:     false,
+				Initdeferred:   false,
 			}
 		}
 	| CREATE opt_or_replace CONSTRAINT TRIGGER name AFTER TriggerEvents ON
@@ -13840,28 +14048,27 @@ CreateTrigStmt:
 			if len($7.Items) > 1 && $7.Items[1] != nil {
 				columns = $7.Items[1].(*nodes.List)
 			}
-			casBits := $11
-			deferrable := (casBits & int64(nodes.CAS_DEFERRABLE)) != 0
-			initdeferred := (casBits & int64(nodes.CAS_INITIALLY_DEFERRED)) != 0
+			var deferrable, initdeferred bool
+			processCASbits(pglex, $11, "TRIGGER", &deferrable, &initdeferred, nil, nil)
 			var constrrel *nodes.RangeVar
 			if $10 != nil {
 				constrrel = $10.(*nodes.RangeVar)
 			}
 			$$ = &nodes.CreateTrigStmt{
-				Replace:        $2,
-				IsConstraint:   true,
-				Trigname:        $5,
-				Relation:        makeRangeVarFromAnyName($9),
-				Funcname:        $18,
-				Args:            $20,
-				Row:             true,
-				Timing:          int16(nodes.TRIGGER_TYPE_AFTER),
-				Events:          int16(eventsInt),
-				Columns:         columns,
-				WhenClause:      $15,
-				Deferrable:      deferrable,
-				Initdeferred:    initdeferred,
-				Constrrel:       constrrel,
+				Replace:      $2,
+				Isconstraint: true,
+				Trigname:     $5,
+				Relation:     makeRangeVarFromAnyName($9, $<loc>9),
+				Funcname:     $18,
+				Args:         $20,
+				Row:          true,
+				Timing:       int16(nodes.TRIGGER_TYPE_AFTER),
+				Events:       int16(eventsInt),
+				Columns:      columns,
+				WhenClause:   $15,
+				Deferrable:   deferrable,
+				Initdeferred: initdeferred,
+				Constrrel:    constrrel,
 			}
 		}
 	;
@@ -13886,6 +14093,9 @@ TriggerEvents:
 			if len($3.Items) > 1 && $3.Items[1] != nil {
 				columns2 = $3.Items[1].(*nodes.List)
 			}
+			if events1&events2 != 0 {
+				pglex.Error("duplicate trigger events specified")
+			}
 			mergedCols := concatLists(columns1, columns2)
 			var mergedColsNode nodes.Node
 			if mergedCols != nil {
@@ -14013,18 +14223,18 @@ TriggerFuncArgs:
 
 TriggerFuncArg:
 	Iconst
-		{ $$ = &nodes.String{Str: intToString($1)} }
+		{ $$ = &nodes.String{Sval: intToString($1)} }
 	| FCONST
-		{ $$ = &nodes.String{Str: $1} }
+		{ $$ = &nodes.String{Sval: $1} }
 	| Sconst
-		{ $$ = &nodes.String{Str: $1} }
+		{ $$ = &nodes.String{Sval: $1} }
 	| ColLabel
-		{ $$ = &nodes.String{Str: $1} }
+		{ $$ = &nodes.String{Sval: $1} }
 	;
 
 OptConstrFromTable:
 	FROM qualified_name
-		{ $$ = makeRangeVar($2) }
+		{ $$ = makeRangeVar($2, $<loc>2) }
 	| /* EMPTY */
 		{ $$ = nil }
 	;
@@ -14033,7 +14243,19 @@ ConstraintAttributeSpec:
 	/* EMPTY */
 		{ $$ = 0 }
 	| ConstraintAttributeSpec ConstraintAttributeElem
-		{ $$ = $1 | $2 }
+		{
+			spec := $1 | $2
+			notDeferred := int64(nodes.CAS_NOT_DEFERRABLE | nodes.CAS_INITIALLY_DEFERRED)
+			both := int64(nodes.CAS_NOT_DEFERRABLE | nodes.CAS_DEFERRABLE)
+			when := int64(nodes.CAS_INITIALLY_IMMEDIATE | nodes.CAS_INITIALLY_DEFERRED)
+			if spec&notDeferred == notDeferred {
+				pglex.Error("constraint declared INITIALLY DEFERRED must be DEFERRABLE")
+			}
+			if spec&both == both || spec&when == when {
+				pglex.Error("conflicting constraint properties")
+			}
+			$$ = spec
+		}
 	;
 
 ConstraintAttributeElem:
@@ -14051,8 +14273,16 @@ no_inherit:
 	;
 
 opt_unique_null_treatment:
-	NULLS_LA DISTINCT      { $$ = false }
-	| NULLS_LA NOT DISTINCT { $$ = true }
+	NULLS_LA DISTINCT
+		{
+			requireVersion(pglex, PG15, "NULLS DISTINCT", $<loc>1)
+			$$ = false
+		}
+	| NULLS_LA NOT DISTINCT
+		{
+			requireVersion(pglex, PG15, "NULLS NOT DISTINCT", $<loc>1)
+			$$ = true
+		}
 	| /* EMPTY */           { $$ = false }
 	;
 
@@ -14096,17 +14326,18 @@ event_trigger_when_item:
 	ColId IN_P '(' event_trigger_value_list ')'
 		{
 			$$ = &nodes.DefElem{
-				Defname: $1,
-				Arg:     $4,
+				Defname:  $1,
+				Arg:      $4,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
 
 event_trigger_value_list:
 	Sconst
-		{ $$ = makeList(&nodes.String{Str: $1}) }
+		{ $$ = makeList(&nodes.String{Sval: $1}) }
 	| event_trigger_value_list ',' Sconst
-		{ $$ = appendList($1, &nodes.String{Str: $3}) }
+		{ $$ = appendList($1, &nodes.String{Sval: $3}) }
 	;
 
 AlterEventTrigStmt:
@@ -14139,7 +14370,7 @@ RuleStmt:
 		{
 			$$ = &nodes.RuleStmt{
 				Replace:     $2,
-				Relation:    makeRangeVarFromAnyName($9),
+				Relation:    makeRangeVarFromAnyName($9, $<loc>9),
 				Rulename:    $4,
 				WhereClause: $10,
 				Event:       nodes.CmdType($7),
@@ -14240,9 +14471,9 @@ opt_trusted:
 
 handler_name:
 	name
-		{ $$ = &nodes.List{Items: []nodes.Node{&nodes.String{Str: $1}}} }
+		{ $$ = &nodes.List{Items: []nodes.Node{&nodes.String{Sval: $1}}} }
 	| name attrs
-		{ $$ = prependList(&nodes.String{Str: $1}, $2) }
+		{ $$ = prependList(&nodes.String{Sval: $1}, $2) }
 	;
 
 opt_inline_handler:
@@ -14282,19 +14513,19 @@ CreateFdwStmt:
 fdw_option:
 	HANDLER handler_name
 		{
-			$$ = makeDefElem("handler", $2)
+			$$ = makeDefElem("handler", $2, $<loc>1)
 		}
 	| NO HANDLER
 		{
-			$$ = makeDefElem("handler", nil)
+			$$ = makeDefElem("handler", nil, $<loc>1)
 		}
 	| VALIDATOR handler_name
 		{
-			$$ = makeDefElem("validator", $2)
+			$$ = makeDefElem("validator", $2, $<loc>1)
 		}
 	| NO VALIDATOR
 		{
-			$$ = makeDefElem("validator", nil)
+			$$ = makeDefElem("validator", nil, $<loc>1)
 		}
 	;
 
@@ -14374,21 +14605,21 @@ alter_generic_option_elem:
 	| SET generic_option_elem
 		{
 			n := $2.(*nodes.DefElem)
-			n.Defaction = int(nodes.DEFELEM_SET)
+			n.Defaction = nodes.DEFELEM_SET
 			$$ = n
 		}
 	| ADD_P generic_option_elem
 		{
 			n := $2.(*nodes.DefElem)
-			n.Defaction = int(nodes.DEFELEM_ADD)
+			n.Defaction = nodes.DEFELEM_ADD
 			$$ = n
 		}
 	| DROP generic_option_name
 		{
 			$$ = &nodes.DefElem{
 				Defname:   $2,
-				Defaction: int(nodes.DEFELEM_DROP),
-				Location:  -1,
+				Defaction: nodes.DEFELEM_DROP,
+				Location:  nodes.ParseLoc($<loc>2),
 			}
 		}
 	;
@@ -14399,7 +14630,7 @@ generic_option_elem:
 			$$ = &nodes.DefElem{
 				Defname:  $1,
 				Arg:      $2,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -14409,7 +14640,7 @@ generic_option_name:
 	;
 
 generic_option_arg:
-	Sconst  { $$ = &nodes.String{Str: $1} }
+	Sconst  { $$ = &nodes.String{Sval: $1} }
 	;
 
 /*****************************************************************************
@@ -14504,7 +14735,7 @@ CreateForeignTableStmt:
 	  '(' OptTableElementList ')'
 	  OptInherit SERVER name create_generic_options
 		{
-			rv := makeRangeVar($4)
+			rv := makeRangeVar($4, $<loc>4)
 			rv.(*nodes.RangeVar).Relpersistence = 'p'
 			$$ = &nodes.CreateForeignTableStmt{
 				Base: nodes.CreateStmt{
@@ -14521,7 +14752,7 @@ CreateForeignTableStmt:
 	  '(' OptTableElementList ')'
 	  OptInherit SERVER name create_generic_options
 		{
-			rv := makeRangeVar($7)
+			rv := makeRangeVar($7, $<loc>7)
 			rv.(*nodes.RangeVar).Relpersistence = 'p'
 			$$ = &nodes.CreateForeignTableStmt{
 				Base: nodes.CreateStmt{
@@ -14538,8 +14769,8 @@ CreateForeignTableStmt:
 	  PARTITION OF qualified_name OptTypedTableElementList ForValues
 	  SERVER name create_generic_options
 		{
-			rv := makeRangeVar($4)
-			inh := makeRangeVar($7)
+			rv := makeRangeVar($4, $<loc>4)
+			inh := makeRangeVar($7, $<loc>7)
 			rv.(*nodes.RangeVar).Relpersistence = 'p'
 			$$ = &nodes.CreateForeignTableStmt{
 				Base: nodes.CreateStmt{
@@ -14557,8 +14788,8 @@ CreateForeignTableStmt:
 	  PARTITION OF qualified_name OptTypedTableElementList ForValues
 	  SERVER name create_generic_options
 		{
-			rv := makeRangeVar($7)
-			inh := makeRangeVar($10)
+			rv := makeRangeVar($7, $<loc>7)
+			inh := makeRangeVar($10, $<loc>10)
 			rv.(*nodes.RangeVar).Relpersistence = 'p'
 			$$ = &nodes.CreateForeignTableStmt{
 				Base: nodes.CreateStmt{
@@ -14653,7 +14884,8 @@ auth_ident:
 	| USER
 		{
 			$$ = &nodes.RoleSpec{
-				Roletype: int(nodes.ROLESPEC_CURRENT_USER),
+				Roletype: nodes.ROLESPEC_CURRENT_USER,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -14771,11 +15003,11 @@ reloption_list:
 reloption_elem:
 	ColLabel '=' def_arg
 		{
-			$$ = makeDefElem($1, $3)
+			$$ = makeDefElem($1, $3, $<loc>1)
 		}
 	| ColLabel
 		{
-			$$ = makeDefElem($1, nil)
+			$$ = makeDefElem($1, nil, $<loc>1)
 		}
 	| ColLabel '.' ColLabel '=' def_arg
 		{
@@ -14783,7 +15015,7 @@ reloption_elem:
 				Defnamespace: $1,
 				Defname:      $3,
 				Arg:          $5,
-				Location:     -1,
+				Location:     nodes.ParseLoc($<loc>1),
 			}
 		}
 	| ColLabel '.' ColLabel
@@ -14791,7 +15023,7 @@ reloption_elem:
 			$$ = &nodes.DefElem{
 				Defnamespace: $1,
 				Defname:      $3,
-				Location:     -1,
+				Location:     nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -14831,15 +15063,15 @@ create_extension_opt_list:
 create_extension_opt_item:
 	SCHEMA name
 		{
-			$$ = makeDefElem("schema", &nodes.String{Str: $2})
+			$$ = makeDefElem("schema", &nodes.String{Sval: $2}, $<loc>1)
 		}
 	| VERSION_P NonReservedWord_or_Sconst
 		{
-			$$ = makeDefElem("new_version", &nodes.String{Str: $2})
+			$$ = makeDefElem("new_version", &nodes.String{Sval: $2}, $<loc>1)
 		}
 	| CASCADE
 		{
-			$$ = makeDefElem("cascade", &nodes.Boolean{Boolval: true})
+			$$ = makeDefElem("cascade", &nodes.Boolean{Boolval: true}, $<loc>1)
 		}
 	;
 
@@ -14869,7 +15101,7 @@ alter_extension_opt_list:
 alter_extension_opt_item:
 	TO NonReservedWord_or_Sconst
 		{
-			$$ = makeDefElem("new_version", &nodes.String{Str: $2})
+			$$ = makeDefElem("new_version", &nodes.String{Sval: $2}, $<loc>1)
 		}
 	;
 
@@ -14886,7 +15118,7 @@ AlterExtensionContentsStmt:
 				Extname: $3,
 				Action:  int($4),
 				Objtype: nodes.ObjectType($5),
-				Object:  &nodes.String{Str: $6},
+				Object:  &nodes.String{Sval: $6},
 			}
 		}
 	| ALTER EXTENSION name add_drop object_type_any_name any_name
@@ -14949,7 +15181,7 @@ AlterExtensionContentsStmt:
 				Extname: $3,
 				Action:  int($4),
 				Objtype: nodes.OBJECT_OPCLASS,
-				Object:  prependList(&nodes.String{Str: $9}, $7),
+				Object:  prependList(&nodes.String{Sval: $9}, $7),
 			}
 		}
 	| ALTER EXTENSION name add_drop OPERATOR FAMILY any_name USING name
@@ -14958,7 +15190,7 @@ AlterExtensionContentsStmt:
 				Extname: $3,
 				Action:  int($4),
 				Objtype: nodes.OBJECT_OPFAMILY,
-				Object:  prependList(&nodes.String{Str: $9}, $7),
+				Object:  prependList(&nodes.String{Sval: $9}, $7),
 			}
 		}
 	| ALTER EXTENSION name add_drop DOMAIN_P Typename
@@ -15016,7 +15248,7 @@ CreatePolicyStmt:
 		{
 			$$ = &nodes.CreatePolicyStmt{
 				PolicyName: $3,
-				Table:      makeRangeVarFromAnyName($5),
+				Table:      makeRangeVarFromAnyName($5, $<loc>5),
 				Permissive: $6,
 				CmdName:    $7,
 				Roles:      $8,
@@ -15032,7 +15264,7 @@ AlterPolicyStmt:
 		{
 			$$ = &nodes.AlterPolicyStmt{
 				PolicyName: $3,
-				Table:      makeRangeVarFromAnyName($5),
+				Table:      makeRangeVarFromAnyName($5, $<loc>5),
 				Roles:      $6,
 				Qual:       $7,
 				WithCheck:  $8,
@@ -15056,7 +15288,7 @@ RowSecurityDefaultToRole:
 		{
 			/* Default is PUBLIC */
 			$$ = makeList(&nodes.RoleSpec{
-				Roletype: int(nodes.ROLESPEC_PUBLIC),
+				Roletype: nodes.ROLESPEC_PUBLIC,
 				Location: -1,
 			})
 		}
@@ -15119,6 +15351,7 @@ CreatePublicationStmt:
 		}
 	| CREATE PUBLICATION name FOR pub_obj_list opt_definition
 		{
+			preprocessPubObjList(pglex, $5)
 			$$ = &nodes.CreatePublicationStmt{
 				Pubname:    $3,
 				Options:    $6,
@@ -15137,26 +15370,29 @@ AlterPublicationStmt:
 		}
 	| ALTER PUBLICATION name ADD_P pub_obj_list
 		{
+			preprocessPubObjList(pglex, $5)
 			$$ = &nodes.AlterPublicationStmt{
 				Pubname:    $3,
 				Pubobjects: $5,
-				Action:     nodes.DEFELEM_ADD,
+				Action:     nodes.AP_AddObjects,
 			}
 		}
 	| ALTER PUBLICATION name SET pub_obj_list
 		{
+			preprocessPubObjList(pglex, $5)
 			$$ = &nodes.AlterPublicationStmt{
 				Pubname:    $3,
 				Pubobjects: $5,
-				Action:     nodes.DEFELEM_SET,
+				Action:     nodes.AP_SetObjects,
 			}
 		}
 	| ALTER PUBLICATION name DROP pub_obj_list
 		{
+			preprocessPubObjList(pglex, $5)
 			$$ = &nodes.AlterPublicationStmt{
 				Pubname:    $3,
 				Pubobjects: $5,
-				Action:     nodes.DEFELEM_DROP,
+				Action:     nodes.AP_DropObjects,
 			}
 		}
 	;
@@ -15169,6 +15405,9 @@ opt_definition:
 PublicationObjSpec:
 	TABLE relation_expr opt_column_list OptWhereClause
 		{
+			if $3 != nil {
+				requireVersion(pglex, PG15, "publication column list", $<loc>3)
+			}
 			var cols *nodes.List
 			if $3 != nil {
 				cols = $3
@@ -15187,19 +15426,53 @@ PublicationObjSpec:
 		}
 	| TABLES IN_P SCHEMA ColId
 		{
+			requireVersion(pglex, PG15, "TABLES IN SCHEMA", $<loc>1)
 			$$ = &nodes.PublicationObjSpec{
 				Pubobjtype: nodes.PUBLICATIONOBJ_TABLES_IN_SCHEMA,
 				Name:       $4,
+				Location:   nodes.ParseLoc($<loc>4),
 			}
 		}
 	| TABLES IN_P SCHEMA CURRENT_SCHEMA
 		{
+			requireVersion(pglex, PG15, "TABLES IN SCHEMA", $<loc>1)
 			$$ = &nodes.PublicationObjSpec{
 				Pubobjtype: nodes.PUBLICATIONOBJ_TABLES_IN_CUR_SCHEMA,
+				Location:   nodes.ParseLoc($<loc>4),
+			}
+		}
+	/*
+	 * A bare name without a column list or WHERE clause can be a table or
+	 * a schema, depending on what comes before it; preprocessPubObjList
+	 * decides.
+	 */
+	| qualified_name opt_column_list OptWhereClause
+		{
+			if $2 != nil {
+				requireVersion(pglex, PG15, "publication column list", $<loc>2)
+			}
+			n := &nodes.PublicationObjSpec{
+				Pubobjtype: nodes.PUBLICATIONOBJ_CONTINUATION,
+				Location:   nodes.ParseLoc($<loc>1),
+			}
+			if len($1.Items) == 1 && $2 == nil && $3 == nil {
+				n.Name = $1.Items[0].(*nodes.String).Sval
+			} else {
+				n.Pubtable = &nodes.PublicationTable{
+					Relation: makeRangeVar($1, $<loc>1).(*nodes.RangeVar),
+					Columns:  $2,
+				}
+				if $3 != nil {
+					n.Pubtable.WhereClause = $3.Items[0]
+				}
 			}
+			$$ = n
 		}
-	| relation_expr opt_column_list OptWhereClause
+	| extended_relation_expr opt_column_list OptWhereClause
 		{
+			if $2 != nil {
+				requireVersion(pglex, PG15, "publication column list", $<loc>2)
+			}
 			pt := &nodes.PublicationTable{
 				Relation: $1.(*nodes.RangeVar),
 				Columns:  $2,
@@ -15216,12 +15489,17 @@ PublicationObjSpec:
 		{
 			$$ = &nodes.PublicationObjSpec{
 				Pubobjtype: nodes.PUBLICATIONOBJ_CONTINUATION,
+				Location:   nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
 
 OptWhereClause:
-	WHERE '(' a_expr ')'   { $$ = makeList($3) }
+	WHERE '(' a_expr ')'
+		{
+			requireVersion(pglex, PG15, "publication WHERE", $<loc>1)
+			$$ = makeList($3)
+		}
 	| /* EMPTY */           { $$ = nil }
 	;
 
@@ -15307,7 +15585,7 @@ AlterSubscriptionStmt:
 			$$ = &nodes.AlterSubscriptionStmt{
 				Kind:    nodes.ALTER_SUBSCRIPTION_ENABLED,
 				Subname: $3,
-				Options: makeList(makeDefElem("enabled", &nodes.Boolean{Boolval: true})),
+				Options: makeList(makeDefElem("enabled", &nodes.Boolean{Boolval: true}, $<loc>1)),
 			}
 		}
 	| ALTER SUBSCRIPTION name DISABLE_P
@@ -15315,7 +15593,7 @@ AlterSubscriptionStmt:
 			$$ = &nodes.AlterSubscriptionStmt{
 				Kind:    nodes.ALTER_SUBSCRIPTION_ENABLED,
 				Subname: $3,
-				Options: makeList(makeDefElem("enabled", &nodes.Boolean{Boolval: false})),
+				Options: makeList(makeDefElem("enabled", &nodes.Boolean{Boolval: false}, $<loc>1)),
 			}
 		}
 	| ALTER SUBSCRIPTION name SKIP definition
@@ -15360,7 +15638,7 @@ AlterObjectDependsStmt:
 			$$ = &nodes.AlterObjectDependsStmt{
 				ObjectType: nodes.OBJECT_FUNCTION,
 				Object:     $3,
-				Extname:    &nodes.String{Str: $8},
+				Extname:    &nodes.String{Sval: $8},
 				Remove:     $4,
 			}
 		}
@@ -15369,7 +15647,7 @@ AlterObjectDependsStmt:
 			$$ = &nodes.AlterObjectDependsStmt{
 				ObjectType: nodes.OBJECT_PROCEDURE,
 				Object:     $3,
-				Extname:    &nodes.String{Str: $8},
+				Extname:    &nodes.String{Sval: $8},
 				Remove:     $4,
 			}
 		}
@@ -15378,7 +15656,7 @@ AlterObjectDependsStmt:
 			$$ = &nodes.AlterObjectDependsStmt{
 				ObjectType: nodes.OBJECT_ROUTINE,
 				Object:     $3,
-				Extname:    &nodes.String{Str: $8},
+				Extname:    &nodes.String{Sval: $8},
 				Remove:     $4,
 			}
 		}
@@ -15386,9 +15664,9 @@ AlterObjectDependsStmt:
 		{
 			$$ = &nodes.AlterObjectDependsStmt{
 				ObjectType: nodes.OBJECT_TRIGGER,
-				Relation:   makeRangeVarFromAnyName($5),
-				Object:     makeList(&nodes.String{Str: $3}),
-				Extname:    &nodes.String{Str: $10},
+				Relation:   makeRangeVarFromAnyName($5, $<loc>5),
+				Object:     makeList(&nodes.String{Sval: $3}),
+				Extname:    &nodes.String{Sval: $10},
 				Remove:     $6,
 			}
 		}
@@ -15396,8 +15674,8 @@ AlterObjectDependsStmt:
 		{
 			$$ = &nodes.AlterObjectDependsStmt{
 				ObjectType: nodes.OBJECT_MATVIEW,
-				Relation:   makeRangeVarFromAnyName($4),
-				Extname:    &nodes.String{Str: $9},
+				Relation:   makeRangeVarFromAnyName($4, $<loc>4),
+				Extname:    &nodes.String{Sval: $9},
 				Remove:     $5,
 			}
 		}
@@ -15405,8 +15683,8 @@ AlterObjectDependsStmt:
 		{
 			$$ = &nodes.AlterObjectDependsStmt{
 				ObjectType: nodes.OBJECT_INDEX,
-				Relation:   makeRangeVarFromAnyName($3),
-				Extname:    &nodes.String{Str: $8},
+				Relation:   makeRangeVarFromAnyName($3, $<loc>3),
+				Extname:    &nodes.String{Sval: $8},
 				Remove:     $4,
 			}
 		}
@@ -15462,7 +15740,7 @@ AlterObjectSchemaStmt:
 		{
 			$$ = &nodes.AlterObjectSchemaStmt{
 				ObjectType: nodes.OBJECT_EXTENSION,
-				Object:     &nodes.String{Str: $3},
+				Object:     &nodes.String{Sval: $3},
 				Newschema:  $6,
 			}
 		}
@@ -15486,7 +15764,7 @@ AlterObjectSchemaStmt:
 		{
 			$$ = &nodes.AlterObjectSchemaStmt{
 				ObjectType: nodes.OBJECT_OPCLASS,
-				Object:     prependList(&nodes.String{Str: $6}, $4),
+				Object:     prependList(&nodes.String{Sval: $6}, $4),
 				Newschema:  $9,
 			}
 		}
@@ -15494,7 +15772,7 @@ AlterObjectSchemaStmt:
 		{
 			$$ = &nodes.AlterObjectSchemaStmt{
 				ObjectType: nodes.OBJECT_OPFAMILY,
-				Object:     prependList(&nodes.String{Str: $6}, $4),
+				Object:     prependList(&nodes.String{Sval: $6}, $4),
 				Newschema:  $9,
 			}
 		}
@@ -15573,7 +15851,7 @@ AlterObjectSchemaStmt:
 		}
 	| ALTER SEQUENCE qualified_name SET SCHEMA name
 		{
-			rv := makeRangeVarFromAnyName($3)
+			rv := makeRangeVarFromAnyName($3, $<loc>3)
 			$$ = &nodes.AlterObjectSchemaStmt{
 				ObjectType: nodes.OBJECT_SEQUENCE,
 				Relation:   rv,
@@ -15582,7 +15860,7 @@ AlterObjectSchemaStmt:
 		}
 	| ALTER SEQUENCE IF_P EXISTS qualified_name SET SCHEMA name
 		{
-			rv := makeRangeVarFromAnyName($5)
+			rv := makeRangeVarFromAnyName($5, $<loc>5)
 			$$ = &nodes.AlterObjectSchemaStmt{
 				ObjectType: nodes.OBJECT_SEQUENCE,
 				Relation:   rv,
@@ -15592,7 +15870,7 @@ AlterObjectSchemaStmt:
 		}
 	| ALTER VIEW qualified_name SET SCHEMA name
 		{
-			rv := makeRangeVarFromAnyName($3)
+			rv := makeRangeVarFromAnyName($3, $<loc>3)
 			$$ = &nodes.AlterObjectSchemaStmt{
 				ObjectType: nodes.OBJECT_VIEW,
 				Relation:   rv,
@@ -15601,7 +15879,7 @@ AlterObjectSchemaStmt:
 		}
 	| ALTER VIEW IF_P EXISTS qualified_name SET SCHEMA name
 		{
-			rv := makeRangeVarFromAnyName($5)
+			rv := makeRangeVarFromAnyName($5, $<loc>5)
 			$$ = &nodes.AlterObjectSchemaStmt{
 				ObjectType: nodes.OBJECT_VIEW,
 				Relation:   rv,
@@ -15611,7 +15889,7 @@ AlterObjectSchemaStmt:
 		}
 	| ALTER MATERIALIZED VIEW qualified_name SET SCHEMA name
 		{
-			rv := makeRangeVarFromAnyName($4)
+			rv := makeRangeVarFromAnyName($4, $<loc>4)
 			$$ = &nodes.AlterObjectSchemaStmt{
 				ObjectType: nodes.OBJECT_MATVIEW,
 				Relation:   rv,
@@ -15620,7 +15898,7 @@ AlterObjectSchemaStmt:
 		}
 	| ALTER MATERIALIZED VIEW IF_P EXISTS qualified_name SET SCHEMA name
 		{
-			rv := makeRangeVarFromAnyName($6)
+			rv := makeRangeVarFromAnyName($6, $<loc>6)
 			$$ = &nodes.AlterObjectSchemaStmt{
 				ObjectType: nodes.OBJECT_MATVIEW,
 				Relation:   rv,
@@ -15683,7 +15961,7 @@ operator_def_elem:
 		{
 			$$ = &nodes.DefElem{
 				Defname:  $1,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| ColLabel '=' operator_def_arg
@@ -15691,14 +15969,14 @@ operator_def_elem:
 			$$ = &nodes.DefElem{
 				Defname:  $1,
 				Arg:      $3,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| ColLabel
 		{
 			$$ = &nodes.DefElem{
 				Defname:  $1,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -15707,13 +15985,13 @@ operator_def_arg:
 	func_type
 		{ $$ = $1 }
 	| reserved_keyword
-		{ $$ = &nodes.String{Str: $1} }
+		{ $$ = &nodes.String{Sval: $1} }
 	| qual_all_Op
 		{ $$ = $1 }
 	| NumericOnly
 		{ $$ = $1 }
 	| Sconst
-		{ $$ = &nodes.String{Str: $1} }
+		{ $$ = &nodes.String{Sval: $1} }
 	;
 
 /*****************************************************************************
@@ -15767,7 +16045,7 @@ AlterOwnerStmt:
 		{
 			$$ = &nodes.AlterOwnerStmt{
 				ObjectType: nodes.OBJECT_DATABASE,
-				Object:     &nodes.String{Str: $3},
+				Object:     &nodes.String{Sval: $3},
 				Newowner:   $6.(*nodes.RoleSpec),
 			}
 		}
@@ -15791,7 +16069,7 @@ AlterOwnerStmt:
 		{
 			$$ = &nodes.AlterOwnerStmt{
 				ObjectType: nodes.OBJECT_LANGUAGE,
-				Object:     &nodes.String{Str: $4},
+				Object:     &nodes.String{Sval: $4},
 				Newowner:   $7.(*nodes.RoleSpec),
 			}
 		}
@@ -15815,7 +16093,7 @@ AlterOwnerStmt:
 		{
 			$$ = &nodes.AlterOwnerStmt{
 				ObjectType: nodes.OBJECT_OPCLASS,
-				Object:     prependList(&nodes.String{Str: $6}, $4),
+				Object:     prependList(&nodes.String{Sval: $6}, $4),
 				Newowner:   $9.(*nodes.RoleSpec),
 			}
 		}
@@ -15823,7 +16101,7 @@ AlterOwnerStmt:
 		{
 			$$ = &nodes.AlterOwnerStmt{
 				ObjectType: nodes.OBJECT_OPFAMILY,
-				Object:     prependList(&nodes.String{Str: $6}, $4),
+				Object:     prependList(&nodes.String{Sval: $6}, $4),
 				Newowner:   $9.(*nodes.RoleSpec),
 			}
 		}
@@ -15847,7 +16125,7 @@ AlterOwnerStmt:
 		{
 			$$ = &nodes.AlterOwnerStmt{
 				ObjectType: nodes.OBJECT_SCHEMA,
-				Object:     &nodes.String{Str: $3},
+				Object:     &nodes.String{Sval: $3},
 				Newowner:   $6.(*nodes.RoleSpec),
 			}
 		}
@@ -15863,7 +16141,7 @@ AlterOwnerStmt:
 		{
 			$$ = &nodes.AlterOwnerStmt{
 				ObjectType: nodes.OBJECT_TABLESPACE,
-				Object:     &nodes.String{Str: $3},
+				Object:     &nodes.String{Sval: $3},
 				Newowner:   $6.(*nodes.RoleSpec),
 			}
 		}
@@ -15895,7 +16173,7 @@ AlterOwnerStmt:
 		{
 			$$ = &nodes.AlterOwnerStmt{
 				ObjectType: nodes.OBJECT_FDW,
-				Object:     &nodes.String{Str: $5},
+				Object:     &nodes.String{Sval: $5},
 				Newowner:   $8.(*nodes.RoleSpec),
 			}
 		}
@@ -15903,7 +16181,7 @@ AlterOwnerStmt:
 		{
 			$$ = &nodes.AlterOwnerStmt{
 				ObjectType: nodes.OBJECT_FOREIGN_SERVER,
-				Object:     &nodes.String{Str: $3},
+				Object:     &nodes.String{Sval: $3},
 				Newowner:   $6.(*nodes.RoleSpec),
 			}
 		}
@@ -15911,7 +16189,7 @@ AlterOwnerStmt:
 		{
 			$$ = &nodes.AlterOwnerStmt{
 				ObjectType: nodes.OBJECT_EVENT_TRIGGER,
-				Object:     &nodes.String{Str: $4},
+				Object:     &nodes.String{Sval: $4},
 				Newowner:   $7.(*nodes.RoleSpec),
 			}
 		}
@@ -15919,7 +16197,7 @@ AlterOwnerStmt:
 		{
 			$$ = &nodes.AlterOwnerStmt{
 				ObjectType: nodes.OBJECT_PUBLICATION,
-				Object:     &nodes.String{Str: $3},
+				Object:     &nodes.String{Sval: $3},
 				Newowner:   $6.(*nodes.RoleSpec),
 			}
 		}
@@ -15927,7 +16205,7 @@ AlterOwnerStmt:
 		{
 			$$ = &nodes.AlterOwnerStmt{
 				ObjectType: nodes.OBJECT_SUBSCRIPTION,
-				Object:     &nodes.String{Str: $3},
+				Object:     &nodes.String{Sval: $3},
 				Newowner:   $6.(*nodes.RoleSpec),
 			}
 		}
@@ -15962,7 +16240,7 @@ DefACLOption:
 			$$ = &nodes.DefElem{
 				Defname: "schemas",
 				Arg:     $3,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| FOR ROLE role_list
@@ -15970,7 +16248,7 @@ DefACLOption:
 			$$ = &nodes.DefElem{
 				Defname: "roles",
 				Arg:     $3,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	| FOR USER role_list
@@ -15978,7 +16256,7 @@ DefACLOption:
 			$$ = &nodes.DefElem{
 				Defname: "roles",
 				Arg:     $3,
-				Location: -1,
+				Location: nodes.ParseLoc($<loc>1),
 			}
 		}
 	;
@@ -16198,25 +16476,19 @@ stats_param:
 AlterStatsStmt:
 	ALTER STATISTICS any_name SET STATISTICS set_statistics_value
 		{
-			n := &nodes.AlterStatsStmt{
-				Defnames:  $3,
-				MissingOk: false,
-			}
-			if iv, ok := $6.(*nodes.Integer); ok {
-				n.Stxstattarget = int(iv.Ival)
+			$$ = &nodes.AlterStatsStmt{
+				Defnames:      $3,
+				Stxstattarget: $6,
+				MissingOk:     false,
 			}
-			$$ = n
 		}
 	| ALTER STATISTICS IF_P EXISTS any_name SET STATISTICS set_statistics_value
 		{
-			n := &nodes.AlterStatsStmt{
-				Defnames:  $5,
-				MissingOk: true,
+			$$ = &nodes.AlterStatsStmt{
+				Defnames:      $5,
+				Stxstattarget: $8,
+				MissingOk:     true,
 			}
-			if iv, ok := $8.(*nodes.Integer); ok {
-				n.Stxstattarget = int(iv.Ival)
-			}
-			$$ = n
 		}
 	;
 
@@ -16411,18 +16683,18 @@ DropOpClassStmt:
 	DROP OPERATOR CLASS any_name USING name opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    makeList(prependList(&nodes.String{Str: $6}, $4)),
-				RemoveType: int(nodes.OBJECT_OPCLASS),
-				Behavior:   int($7),
+				Objects:    makeList(prependList(&nodes.String{Sval: $6}, $4)),
+				RemoveType: nodes.OBJECT_OPCLASS,
+				Behavior:   nodes.DropBehavior($7),
 			}
 		}
 	| DROP OPERATOR CLASS IF_P EXISTS any_name USING name opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    makeList(prependList(&nodes.String{Str: $8}, $6)),
-				RemoveType: int(nodes.OBJECT_OPCLASS),
-				Behavior:   int($9),
-				Missing_ok: true,
+				Objects:    makeList(prependList(&nodes.String{Sval: $8}, $6)),
+				RemoveType: nodes.OBJECT_OPCLASS,
+				Behavior:   nodes.DropBehavior($9),
+				MissingOk:  true,
 			}
 		}
 	;
@@ -16431,18 +16703,18 @@ DropOpFamilyStmt:
 	DROP OPERATOR FAMILY any_name USING name opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    makeList(prependList(&nodes.String{Str: $6}, $4)),
-				RemoveType: int(nodes.OBJECT_OPFAMILY),
-				Behavior:   int($7),
+				Objects:    makeList(prependList(&nodes.String{Sval: $6}, $4)),
+				RemoveType: nodes.OBJECT_OPFAMILY,
+				Behavior:   nodes.DropBehavior($7),
 			}
 		}
 	| DROP OPERATOR FAMILY IF_P EXISTS any_name USING name opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				Objects:    makeList(prependList(&nodes.String{Str: $8}, $6)),
-				RemoveType: int(nodes.OBJECT_OPFAMILY),
-				Behavior:   int($9),
-				Missing_ok: true,
+				Objects:    makeList(prependList(&nodes.String{Sval: $8}, $6)),
+				RemoveType: nodes.OBJECT_OPFAMILY,
+				Behavior:   nodes.DropBehavior($9),
+				MissingOk:  true,
 			}
 		}
 	;
@@ -16495,10 +16767,10 @@ DropCastStmt:
 	DROP CAST opt_if_exists '(' Typename AS Typename ')' opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				RemoveType: int(nodes.OBJECT_CAST),
+				RemoveType: nodes.OBJECT_CAST,
 				Objects:    makeList(makeList2($5, $7)),
-				Behavior:   int($9),
-				Missing_ok: $3,
+				Behavior:   nodes.DropBehavior($9),
+				MissingOk:  $3,
 			}
 		}
 	;
@@ -16561,10 +16833,10 @@ DropTransformStmt:
 	DROP TRANSFORM opt_if_exists FOR Typename LANGUAGE name opt_drop_behavior
 		{
 			$$ = &nodes.DropStmt{
-				RemoveType: int(nodes.OBJECT_TRANSFORM),
-				Objects:    makeList(makeList2($5, &nodes.String{Str: $7})),
-				Behavior:   int($8),
-				Missing_ok: $3,
+				RemoveType: nodes.OBJECT_TRANSFORM,
+				Objects:    makeList(makeList2($5, &nodes.String{Sval: $7})),
+				Behavior:   nodes.DropBehavior($8),
+				MissingOk:  $3,
 			}
 		}
 	;
@@ -16681,7 +16953,7 @@ CreateAsStmt:
 create_as_target:
 	qualified_name opt_column_list OptAccessMethod OptWith OnCommitOption OptTableSpace
 		{
-			rv := makeRangeVarFromAnyName($1)
+			rv := makeRangeVarFromAnyName($1, $<loc>1)
 			$$ = &nodes.IntoClause{
 				Rel:            rv,
 				ColNames:       $2,
@@ -16739,7 +17011,7 @@ CreateMatViewStmt:
 create_mv_target:
 	qualified_name opt_column_list OptAccessMethod opt_reloptions OptTableSpace
 		{
-			rv := makeRangeVarFromAnyName($1)
+			rv := makeRangeVarFromAnyName($1, $<loc>1)
 			$$ = &nodes.IntoClause{
 				Rel:            rv,
 				ColNames:       $2,
@@ -16759,7 +17031,7 @@ create_mv_target:
 RefreshMatViewStmt:
 	REFRESH MATERIALIZED VIEW opt_concurrently qualified_name opt_with_data
 		{
-			rv := makeRangeVarFromAnyName($5)
+			rv := makeRangeVarFromAnyName($5, $<loc>5)
 			$$ = &nodes.RefreshMatViewStmt{
 				Concurrent: $4,
 				Relation:   rv,
@@ -17095,6 +17367,7 @@ unreserved_keyword:
 	| VERSION_P
 	| VIEW
 	| VIEWS
+	| VIRTUAL
 	| VOLATILE
 	| WHITESPACE_P
 	| WITHIN
@@ -17281,274 +17554,827 @@ reserved_keyword:
 	| WITH
 	;
 
-%%
-
-// OnConflict action constants
-const (
-	ONCONFLICT_NONE    = 0
-	ONCONFLICT_NOTHING = 1
-	ONCONFLICT_UPDATE  = 2
-)
-
-// ViewCheckOption constants
-const (
-	VIEW_CHECK_OPTION_NONE     = 0
-	VIEW_CHECK_OPTION_LOCAL    = 1
-	VIEW_CHECK_OPTION_CASCADED = 2
-)
-
-// Helper functions called from grammar actions
-
-// setParseResult stores the parse result in the lexer.
-func setParseResult(lex pgLexer, result *nodes.List) {
-	if pl, ok := lex.(*parserLexer); ok {
-		pl.result = result
-	}
-}
-
-func makeList(n nodes.Node) *nodes.List {
-	if n == nil {
-		return &nodes.List{}
-	}
-	return &nodes.List{Items: []nodes.Node{n}}
-}
-
-func appendList(l *nodes.List, n nodes.Node) *nodes.List {
-	if l == nil {
-		return makeList(n)
-	}
-	if n != nil {
-		l.Items = append(l.Items, n)
-	}
-	return l
-}
-
-func prependList(n nodes.Node, l *nodes.List) *nodes.List {
-	if l == nil {
-		return makeList(n)
-	}
-	if n != nil {
-		l.Items = append([]nodes.Node{n}, l.Items...)
-	}
-	return l
-}
-
-func makeList2(a nodes.Node, b nodes.Node) *nodes.List {
-	return &nodes.List{Items: []nodes.Node{a, b}}
-}
-
-func makeListNode(l *nodes.List) nodes.Node {
-	return l
-}
-
-func makeRangeVar(names *nodes.List) nodes.Node {
-	rv := &nodes.RangeVar{Inh: true, Relpersistence: 'p'}
-	if names != nil && len(names.Items) > 0 {
-		switch len(names.Items) {
-		case 1:
-			rv.Relname = names.Items[0].(*nodes.String).Str
-		case 2:
-			rv.Schemaname = names.Items[0].(*nodes.String).Str
-			rv.Relname = names.Items[1].(*nodes.String).Str
-		case 3:
-			rv.Catalogname = names.Items[0].(*nodes.String).Str
-			rv.Schemaname = names.Items[1].(*nodes.String).Str
-			rv.Relname = names.Items[2].(*nodes.String).Str
-		}
-	}
-	return rv
-}
-
-func makeRangeVarList(nameList *nodes.List) *nodes.List {
-	if nameList == nil {
-		return nil
-	}
-	result := &nodes.List{}
-	for _, item := range nameList.Items {
-		rv := makeRangeVar(item.(*nodes.List))
-		result.Items = append(result.Items, rv)
-	}
-	return result
-}
-
-func makeAExpr(kind nodes.A_Expr_Kind, op string, lexpr, rexpr nodes.Node) nodes.Node {
-	return &nodes.A_Expr{
-		Kind:  kind,
-		Name:  &nodes.List{Items: []nodes.Node{&nodes.String{Str: op}}},
-		Lexpr: lexpr,
-		Rexpr: rexpr,
-	}
-}
-
-// makeAExprFromList creates an A_Expr with an operator name list (from qual_Op).
-// makeNameListAsAnyNameList wraps each name string into a single-element
-// List (matching PG's any_name format for DropStmt.Objects).
-func makeNameListAsAnyNameList(names *nodes.List) *nodes.List {
-	if names == nil {
-		return nil
-	}
-	result := &nodes.List{}
-	for _, item := range names.Items {
-		result.Items = append(result.Items, &nodes.List{Items: []nodes.Node{item}})
-	}
-	return result
-}
-
-func makeStringConstCast(s string, typeName *nodes.TypeName) nodes.Node {
-	return &nodes.TypeCast{
-		Arg:      &nodes.A_Const{Val: &nodes.String{Str: s}},
-		TypeName: typeName,
-		Location: -1,
-	}
-}
-
-func parsePartitionStrategy(strategy string) string {
-	switch strategy {
-	case "list", "LIST", "List":
-		return "l"
-	case "range", "RANGE", "Range":
-		return "r"
-	case "hash", "HASH", "Hash":
-		return "h"
-	default:
-		return strategy
-	}
-}
-
-func makeAExprFromList(kind nodes.A_Expr_Kind, name *nodes.List, lexpr, rexpr nodes.Node) nodes.Node {
-	return &nodes.A_Expr{
-		Kind:  kind,
-		Name:  name,
-		Lexpr: lexpr,
-		Rexpr: rexpr,
-	}
-}
-
-func makeBoolExpr(boolop nodes.BoolExprType, arg1, arg2 nodes.Node) nodes.Node {
-	be := &nodes.BoolExpr{
-		Boolop: boolop,
-		Args:   &nodes.List{},
-	}
-	if arg1 != nil {
-		be.Args.Items = append(be.Args.Items, arg1)
-	}
-	if arg2 != nil {
-		be.Args.Items = append(be.Args.Items, arg2)
-	}
-	return be
-}
-
-func makeBetweenArgs(lower, upper nodes.Node) nodes.Node {
-	return &nodes.List{Items: []nodes.Node{lower, upper}}
-}
-
-func doNegate(n nodes.Node) nodes.Node {
-	// For numeric constants, negate in place
-	if ac, ok := n.(*nodes.A_Const); ok {
-		if i, ok := ac.Val.(*nodes.Integer); ok {
-			i.Ival = -i.Ival
-			return n
-		}
-		if f, ok := ac.Val.(*nodes.Float); ok {
-			if f.Fval[0] == '-' {
-				f.Fval = f.Fval[1:]
-			} else {
-				f.Fval = "-" + f.Fval
-			}
-			return n
-		}
-	}
-	// Otherwise, create unary minus expression
-	return makeAExpr(nodes.AEXPR_OP, "-", nil, n)
-}
-
-func concatLists(a, b *nodes.List) *nodes.List {
-	if a == nil { return b }
-	if b == nil { return a }
-	result := &nodes.List{Items: make([]nodes.Node, 0, len(a.Items)+len(b.Items))}
-	result.Items = append(result.Items, a.Items...)
-	result.Items = append(result.Items, b.Items...)
-	return result
-}
-
-func makeSetOp(op nodes.SetOperation, quantifier int64, larg, rarg nodes.Node) nodes.Node {
-	n := &nodes.SelectStmt{
-		Op:   op,
-		All:  quantifier == SET_QUANTIFIER_ALL,
-		Larg: larg.(*nodes.SelectStmt),
-		Rarg: rarg.(*nodes.SelectStmt),
-	}
-	return n
-}
-
-func makeTypeName(typeName string) *nodes.TypeName {
-	return &nodes.TypeName{
-		Names: &nodes.List{Items: []nodes.Node{
-			&nodes.String{Str: "pg_catalog"},
-			&nodes.String{Str: typeName},
-		}},
-		Location: -1,
-	}
-}
-
-func makeIntConst(val int64) nodes.Node {
-	return &nodes.A_Const{Val: &nodes.Integer{Ival: val}}
-}
-
-func makeStringConst(str string) nodes.Node {
-	return &nodes.A_Const{Val: &nodes.String{Str: str}}
-}
-
-func makeBoolAConst(val int64) nodes.Node {
-	if val != 0 {
-		return &nodes.A_Const{Val: &nodes.String{Str: "t"}}
-	}
-	return &nodes.A_Const{Val: &nodes.String{Str: "f"}}
-}
-
-func makeNullAConst() nodes.Node {
-	return &nodes.A_Const{Isnull: true}
-}
-
-func makeNotExpr(expr nodes.Node) nodes.Node {
-	return &nodes.BoolExpr{
-		Boolop: nodes.NOT_EXPR,
-		Args:   &nodes.List{Items: []nodes.Node{expr}},
-		Location: -1,
-	}
-}
-
-func setFuncAlias(n *nodes.RangeFunction, clause nodes.Node) {
-	if clause == nil {
-		return
-	}
-	// If it's an Alias, just set the alias
-	if alias, ok := clause.(*nodes.Alias); ok {
-		n.Alias = alias
-		return
-	}
-	// If it's a List with [alias, coldeflist], it's a func_alias_clause with column defs
-	if list, ok := clause.(*nodes.List); ok && len(list.Items) == 2 {
-		if alias, ok2 := list.Items[0].(*nodes.Alias); ok2 {
-			n.Alias = alias
-		}
-		if coldeflist, ok2 := list.Items[1].(*nodes.List); ok2 {
-			n.Coldeflist = coldeflist
-		}
-	}
-}
-
-func setJoinQual(n *nodes.JoinExpr, qual nodes.Node) {
-	if qual == nil {
-		return
-	}
-	if list, ok := qual.(*nodes.List); ok {
+/*
+ * Keywords that can be used as a column label without AS, as in
+ * "SELECT 1 type". The rest need AS, since a label there would be
+ * ambiguous with what can follow an expression.
+ */
+bare_label_keyword:
+	ABORT_P
+	| ABSENT
+	| ABSOLUTE_P
+	| ACCESS
+	| ACTION
+	| ADD_P
+	| ADMIN
+	| AFTER
+	| AGGREGATE
+	| ALL
+	| ALSO
+	| ALTER
+	| ALWAYS
+	| ANALYSE
+	| ANALYZE
+	| AND
+	| ANY
+	| ASC
+	| ASENSITIVE
+	| ASSERTION
+	| ASSIGNMENT
+	| ASYMMETRIC
+	| AT
+	| ATOMIC
+	| ATTACH
+	| ATTRIBUTE
+	| AUTHORIZATION
+	| BACKWARD
+	| BEFORE
+	| BEGIN_P
+	| BETWEEN
+	| BIGINT
+	| BINARY
+	| BIT
+	| BOOLEAN_P
+	| BOTH
+	| BREADTH
+	| BY
+	| CACHE
+	| CALL
+	| CALLED
+	| CASCADE
+	| CASCADED
+	| CASE
+	| CAST
+	| CATALOG_P
+	| CHAIN
+	| CHARACTERISTICS
+	| CHECK
+	| CHECKPOINT
+	| CLASS
+	| CLOSE
+	| CLUSTER
+	| COALESCE
+	| COLLATE
+	| COLLATION
+	| COLUMN
+	| COLUMNS
+	| COMMENT
+	| COMMENTS
+	| COMMIT
+	| COMMITTED
+	| COMPRESSION
+	| CONCURRENTLY
+	| CONDITIONAL
+	| CONFIGURATION
+	| CONFLICT
+	| CONNECTION
+	| CONSTRAINT
+	| CONSTRAINTS
+	| CONTENT_P
+	| CONTINUE_P
+	| CONVERSION_P
+	| COPY
+	| COST
+	| CROSS
+	| CSV
+	| CUBE
+	| CURRENT_P
+	| CURRENT_CATALOG
+	| CURRENT_DATE
+	| CURRENT_ROLE
+	| CURRENT_SCHEMA
+	| CURRENT_TIME
+	| CURRENT_TIMESTAMP
+	| CURRENT_USER
+	| CURSOR
+	| CYCLE
+	| DATA_P
+	| DATABASE
+	| DEALLOCATE
+	| DEC
+	| DECIMAL_P
+	| DECLARE
+	| DEFAULT
+	| DEFAULTS
+	| DEFERRABLE
+	| DEFERRED
+	| DEFINER
+	| DELETE_P
+	| DELIMITER
+	| DELIMITERS
+	| DEPENDS
+	| DEPTH
+	| DESC
+	| DETACH
+	| DICTIONARY
+	| DISABLE_P
+	| DISCARD
+	| DISTINCT
+	| DO
+	| DOCUMENT_P
+	| DOMAIN_P
+	| DOUBLE_P
+	| DROP
+	| EACH
+	| ELSE
+	| EMPTY_P
+	| ENABLE_P
+	| ENCODING
+	| ENCRYPTED
+	| END_P
+	| ENUM_P
+	| ERROR_P
+	| ESCAPE
+	| EVENT
+	| EXCLUDE
+	| EXCLUDING
+	| EXCLUSIVE
+	| EXECUTE
+	| EXISTS
+	| EXPLAIN
+	| EXPRESSION
+	| EXTENSION
+	| EXTERNAL
+	| EXTRACT
+	| FALSE_P
+	| FAMILY
+	| FINALIZE
+	| FIRST_P
+	| FLOAT_P
+	| FOLLOWING
+	| FORCE
+	| FOREIGN
+	| FORMAT
+	| FORWARD
+	| FREEZE
+	| FULL
+	| FUNCTION
+	| FUNCTIONS
+	| GENERATED
+	| GLOBAL
+	| GRANTED
+	| GREATEST
+	| GROUPING
+	| GROUPS
+	| HANDLER
+	| HEADER_P
+	| HOLD
+	| IDENTITY_P
+	| IF_P
+	| ILIKE
+	| IMMEDIATE
+	| IMMUTABLE
+	| IMPLICIT_P
+	| IMPORT_P
+	| IN_P
+	| INCLUDE
+	| INCLUDING
+	| INCREMENT
+	| INDENT
+	| INDEX
+	| INDEXES
+	| INHERIT
+	| INHERITS
+	| INITIALLY
+	| INLINE_P
+	| INNER_P
+	| INOUT
+	| INPUT_P
+	| INSENSITIVE
+	| INSERT
+	| INSTEAD
+	| INT_P
+	| INTEGER
+	| INTERVAL
+	| INVOKER
+	| IS
+	| ISOLATION
+	| JOIN
+	| JSON
+	| JSON_ARRAY
+	| JSON_ARRAYAGG
+	| JSON_EXISTS
+	| JSON_OBJECT
+	| JSON_OBJECTAGG
+	| JSON_QUERY
+	| JSON_SCALAR
+	| JSON_SERIALIZE
+	| JSON_TABLE
+	| JSON_VALUE
+	| KEEP
+	| KEY
+	| KEYS
+	| LABEL
+	| LANGUAGE
+	| LARGE_P
+	| LAST_P
+	| LATERAL_P
+	| LEADING
+	| LEAKPROOF
+	| LEAST
+	| LEFT
+	| LEVEL
+	| LIKE
+	| LISTEN
+	| LOAD
+	| LOCAL
+	| LOCALTIME
+	| LOCALTIMESTAMP
+	| LOCATION
+	| LOCK_P
+	| LOCKED
+	| LOGGED
+	| MAPPING
+	| MATCH
+	| MATCHED
+	| MATERIALIZED
+	| MAXVALUE
+	| MERGE
+	| MERGE_ACTION
+	| METHOD
+	| MINVALUE
+	| MODE
+	| MOVE
+	| NAME_P
+	| NAMES
+	| NATIONAL
+	| NATURAL
+	| NCHAR
+	| NESTED
+	| NEW
+	| NEXT
+	| NFC
+	| NFD
+	| NFKC
+	| NFKD
+	| NO
+	| NONE
+	| NORMALIZE
+	| NORMALIZED
+	| NOT
+	| NOTHING
+	| NOTIFY
+	| NOWAIT
+	| NULL_P
+	| NULLIF
+	| NULLS_P
+	| NUMERIC
+	| OBJECT_P
+	| OF
+	| OFF
+	| OIDS
+	| OLD
+	| OMIT
+	| ONLY
+	| OPERATOR
+	| OPTION
+	| OPTIONS
+	| OR
+	| ORDINALITY
+	| OTHERS
+	| OUT_P
+	| OUTER_P
+	| OVERLAY
+	| OVERRIDING
+	| OWNED
+	| OWNER
+	| PARALLEL
+	| PARAMETER
+	| PARSER
+	| PARTIAL
+	| PARTITION
+	| PASSING
+	| PASSWORD
+	| PATH
+	| PLACING
+	| PLAN
+	| PLANS
+	| POLICY
+	| POSITION
+	| PRECEDING
+	| PREPARE
+	| PREPARED
+	| PRESERVE
+	| PRIMARY
+	| PRIOR
+	| PRIVILEGES
+	| PROCEDURAL
+	| PROCEDURE
+	| PROCEDURES
+	| PROGRAM
+	| PUBLICATION
+	| QUOTE
+	| QUOTES
+	| RANGE
+	| READ
+	| REAL
+	| REASSIGN
+	| RECHECK
+	| RECURSIVE
+	| REF_P
+	| REFERENCES
+	| REFERENCING
+	| REFRESH
+	| REINDEX
+	| RELATIVE_P
+	| RELEASE
+	| RENAME
+	| REPEATABLE
+	| REPLACE
+	| REPLICA
+	| RESET
+	| RESTART
+	| RESTRICT
+	| RETURN
+	| RETURNS
+	| REVOKE
+	| RIGHT
+	| ROLE
+	| ROLLBACK
+	| ROLLUP
+	| ROUTINE
+	| ROUTINES
+	| ROW
+	| ROWS
+	| RULE
+	| SAVEPOINT
+	| SCALAR
+	| SCHEMA
+	| SCHEMAS
+	| SCROLL
+	| SEARCH
+	| SECURITY
+	| SELECT
+	| SEQUENCE
+	| SEQUENCES
+	| SERIALIZABLE
+	| SERVER
+	| SESSION
+	| SESSION_USER
+	| SET
+	| SETOF
+	| SETS
+	| SHARE
+	| SHOW
+	| SIMILAR
+	| SIMPLE
+	| SKIP
+	| SMALLINT
+	| SNAPSHOT
+	| SOME
+	| SOURCE
+	| SQL_P
+	| STABLE
+	| STANDALONE_P
+	| START
+	| STATEMENT
+	| STATISTICS
+	| STDIN
+	| STDOUT
+	| STORAGE
+	| STORED
+	| STRICT_P
+	| STRING_P
+	| STRIP_P
+	| SUBSCRIPTION
+	| SUBSTRING
+	| SUPPORT
+	| SYMMETRIC
+	| SYSID
+	| SYSTEM_P
+	| SYSTEM_USER
+	| TABLE
+	| TABLES
+	| TABLESAMPLE
+	| TABLESPACE
+	| TARGET
+	| TEMP
+	| TEMPLATE
+	| TEMPORARY
+	| TEXT_P
+	| THEN
+	| TIES
+	| TIME
+	| TIMESTAMP
+	| TRAILING
+	| TRANSACTION
+	| TRANSFORM
+	| TREAT
+	| TRIGGER
+	| TRIM
+	| TRUE_P
+	| TRUNCATE
+	| TRUSTED
+	| TYPE_P
+	| TYPES_P
+	| UESCAPE
+	| UNBOUNDED
+	| UNCOMMITTED
+	| UNCONDITIONAL
+	| UNENCRYPTED
+	| UNIQUE
+	| UNKNOWN
+	| UNLISTEN
+	| UNLOGGED
+	| UNTIL
+	| UPDATE
+	| USER
+	| USING
+	| VACUUM
+	| VALID
+	| VALIDATE
+	| VALIDATOR
+	| VALUE_P
+	| VALUES
+	| VARCHAR
+	| VARIADIC
+	| VERBOSE
+	| VERSION_P
+	| VIEW
+	| VIEWS
+	| VIRTUAL
+	| VOLATILE
+	| WHEN
+	| WHITESPACE_P
+	| WORK
+	| WRAPPER
+	| WRITE
+	| XML_P
+	| XMLATTRIBUTES
+	| XMLCONCAT
+	| XMLELEMENT
+	| XMLEXISTS
+	| XMLFOREST
+	| XMLNAMESPACES
+	| XMLPARSE
+	| XMLPI
+	| XMLROOT
+	| XMLSERIALIZE
+	| XMLTABLE
+	| YES_P
+	| ZONE
+	;
+
+%%
+
+// OnConflict action constants
+const (
+	ONCONFLICT_NONE    = 0
+	ONCONFLICT_NOTHING = 1
+	ONCONFLICT_UPDATE  = 2
+)
+
+// ViewCheckOption constants
+const (
+	VIEW_CHECK_OPTION_NONE     = 0
+	VIEW_CHECK_OPTION_LOCAL    = 1
+	VIEW_CHECK_OPTION_CASCADED = 2
+)
+
+// Helper functions called from grammar actions
+
+// setParseResult stores the parse result in the lexer.
+func setParseResult(lex pgLexer, result *nodes.List) {
+	if pl, ok := lex.(*parserLexer); ok {
+		pl.result = result
+	}
+}
+
+// makeRawStmt records a RawStmt wrapper for a top-level statement starting
+// at location. The statement length is left as 0 ("rest of string") until
+// updateRawStmtEnd sees the terminating semicolon.
+func makeRawStmt(lex pgLexer, stmt nodes.Node, location int) {
+	if pl, ok := lex.(*parserLexer); ok {
+		pl.rawStmts = append(pl.rawStmts, &nodes.RawStmt{
+			Stmt:         stmt,
+			StmtLocation: nodes.ParseLoc(location),
+		})
+	}
+}
+
+// updateRawStmtEnd sets the length of the most recent RawStmt, given the
+// location of the semicolon that ends it. If the length is already set, it
+// is left alone: in "select foo ;; select bar" the same statement is last
+// in the list for more than one cycle.
+func updateRawStmtEnd(lex pgLexer, endLocation int) {
+	if pl, ok := lex.(*parserLexer); ok && len(pl.rawStmts) > 0 {
+		rs := pl.rawStmts[len(pl.rawStmts)-1]
+		if rs.StmtLen > 0 {
+			return
+		}
+		rs.StmtLen = nodes.ParseLoc(endLocation) - rs.StmtLocation
+	}
+}
+
+// firstTokenLoc returns the location of the first token of the input.
+func firstTokenLoc(lex pgLexer) int {
+	if pl, ok := lex.(*parserLexer); ok {
+		return pl.firstLoc
+	}
+	return 0
+}
+
+func makeList(n nodes.Node) *nodes.List {
+	if n == nil {
+		return &nodes.List{}
+	}
+	return &nodes.List{Items: []nodes.Node{n}}
+}
+
+func appendList(l *nodes.List, n nodes.Node) *nodes.List {
+	if l == nil {
+		return makeList(n)
+	}
+	if n != nil {
+		l.Items = append(l.Items, n)
+	}
+	return l
+}
+
+func prependList(n nodes.Node, l *nodes.List) *nodes.List {
+	if l == nil {
+		return makeList(n)
+	}
+	if n != nil {
+		l.Items = append([]nodes.Node{n}, l.Items...)
+	}
+	return l
+}
+
+func makeList2(a nodes.Node, b nodes.Node) *nodes.List {
+	return &nodes.List{Items: []nodes.Node{a, b}}
+}
+
+func makeListNode(l *nodes.List) nodes.Node {
+	return l
+}
+
+func makeRangeVar(names *nodes.List, loc int) nodes.Node {
+	rv := &nodes.RangeVar{Inh: true, Relpersistence: 'p', Location: nodes.ParseLoc(loc)}
+	if names != nil && len(names.Items) > 0 {
+		switch len(names.Items) {
+		case 1:
+			rv.Relname = names.Items[0].(*nodes.String).Sval
+		case 2:
+			rv.Schemaname = names.Items[0].(*nodes.String).Sval
+			rv.Relname = names.Items[1].(*nodes.String).Sval
+		case 3:
+			rv.Catalogname = names.Items[0].(*nodes.String).Sval
+			rv.Schemaname = names.Items[1].(*nodes.String).Sval
+			rv.Relname = names.Items[2].(*nodes.String).Sval
+		}
+	}
+	return rv
+}
+
+// returningLists returns the expressions and the OLD/NEW options of a
+// returning_clause, which may be nil.
+func returningLists(n nodes.Node) (*nodes.List, *nodes.List) {
+	if n == nil {
+		return nil, nil
+	}
+	rc := n.(*nodes.ReturningClause)
+	return rc.Exprs, rc.Options
+}
+
+func makeAExpr(kind nodes.A_Expr_Kind, op string, lexpr, rexpr nodes.Node, loc int) nodes.Node {
+	return &nodes.A_Expr{
+		Kind:     kind,
+		Name:     &nodes.List{Items: []nodes.Node{&nodes.String{Sval: op}}},
+		Lexpr:    lexpr,
+		Rexpr:    rexpr,
+		Location: nodes.ParseLoc(loc),
+	}
+}
+
+// makeStringConstCast returns the string constant s cast to typeName, as
+// written in type 'string' syntax.
+func makeStringConstCast(s string, loc int, typeName *nodes.TypeName) nodes.Node {
+	return &nodes.TypeCast{
+		Arg:      &nodes.A_Const{Val: &nodes.String{Sval: s}, Location: nodes.ParseLoc(loc)},
+		TypeName: typeName,
+		Location: -1,
+	}
+}
+
+// preprocessPubObjList gives each object of a publication object list
+// without a TABLE or TABLES IN SCHEMA of its own the type of the one
+// before it, as PostgreSQL's preprocess_pubobj_list does, and checks that
+// the object suits its type.
+func preprocessPubObjList(lex pgLexer, list *nodes.List) {
+	if list == nil || len(list.Items) == 0 {
+		return
+	}
+	if list.Items[0].(*nodes.PublicationObjSpec).Pubobjtype == nodes.PUBLICATIONOBJ_CONTINUATION {
+		lex.Error("invalid publication object list")
+		return
+	}
+	prevobjtype := nodes.PUBLICATIONOBJ_CONTINUATION
+	for _, item := range list.Items {
+		pubobj := item.(*nodes.PublicationObjSpec)
+		if pubobj.Pubobjtype == nodes.PUBLICATIONOBJ_CONTINUATION {
+			pubobj.Pubobjtype = prevobjtype
+		}
+		switch pubobj.Pubobjtype {
+		case nodes.PUBLICATIONOBJ_TABLE:
+			// The relation name or the table must be set.
+			if pubobj.Name == "" && pubobj.Pubtable == nil {
+				lex.Error("invalid table name")
+			}
+			if pubobj.Name != "" {
+				pubobj.Pubtable = &nodes.PublicationTable{
+					Relation: &nodes.RangeVar{
+						Relname:        pubobj.Name,
+						Inh:            true,
+						Relpersistence: 'p',
+						Location:       pubobj.Location,
+					},
+				}
+				pubobj.Name = ""
+			}
+		case nodes.PUBLICATIONOBJ_TABLES_IN_SCHEMA, nodes.PUBLICATIONOBJ_TABLES_IN_CUR_SCHEMA:
+			if pubobj.Pubtable != nil && pubobj.Pubtable.WhereClause != nil {
+				lex.Error("WHERE clause not allowed for schema")
+			}
+			if pubobj.Pubtable != nil && pubobj.Pubtable.Columns != nil {
+				lex.Error("column specification not allowed for schema")
+			}
+			switch {
+			case pubobj.Name != "":
+				pubobj.Pubobjtype = nodes.PUBLICATIONOBJ_TABLES_IN_SCHEMA
+			case pubobj.Pubtable == nil:
+				pubobj.Pubobjtype = nodes.PUBLICATIONOBJ_TABLES_IN_CUR_SCHEMA
+			default:
+				lex.Error("invalid schema name")
+			}
+		}
+		prevobjtype = pubobj.Pubobjtype
+	}
+}
+
+// parsePartitionStrategy returns the PartitionStrategy named by strategy,
+// which must be list, range or hash.
+func parsePartitionStrategy(lex pgLexer, strategy string) nodes.PartitionStrategy {
+	switch strings.ToLower(strategy) {
+	case "list":
+		return nodes.PARTITION_STRATEGY_LIST
+	case "range":
+		return nodes.PARTITION_STRATEGY_RANGE
+	case "hash":
+		return nodes.PARTITION_STRATEGY_HASH
+	}
+	lex.Error(fmt.Sprintf("unrecognized partitioning strategy \"%s\"", strategy))
+	return nodes.PARTITION_STRATEGY_LIST
+}
+
+// makeAExprFromList creates an A_Expr with an operator name list (from qual_Op).
+func makeAExprFromList(kind nodes.A_Expr_Kind, name *nodes.List, lexpr, rexpr nodes.Node, loc int) nodes.Node {
+	return &nodes.A_Expr{
+		Kind:     kind,
+		Name:     name,
+		Lexpr:    lexpr,
+		Rexpr:    rexpr,
+		Location: nodes.ParseLoc(loc),
+	}
+}
+
+// makeBoolExpr builds an AND, OR or NOT expression. Like PostgreSQL's
+// makeAndExpr and makeOrExpr, it appends to the left operand instead when
+// that is already an AND or OR of the same kind, so that a chain of ANDs
+// or ORs is one flat BoolExpr.
+func makeBoolExpr(boolop nodes.BoolExprType, arg1, arg2 nodes.Node, loc int) nodes.Node {
+	if be, ok := arg1.(*nodes.BoolExpr); ok && boolop != nodes.NOT_EXPR && be.Boolop == boolop {
+		be.Args.Items = append(be.Args.Items, arg2)
+		return be
+	}
+	be := &nodes.BoolExpr{
+		Boolop:   boolop,
+		Args:     &nodes.List{},
+		Location: nodes.ParseLoc(loc),
+	}
+	if arg1 != nil {
+		be.Args.Items = append(be.Args.Items, arg1)
+	}
+	if arg2 != nil {
+		be.Args.Items = append(be.Args.Items, arg2)
+	}
+	return be
+}
+
+func makeBetweenArgs(lower, upper nodes.Node) nodes.Node {
+	return &nodes.List{Items: []nodes.Node{lower, upper}}
+}
+
+func doNegate(n nodes.Node, loc int) nodes.Node {
+	// For numeric constants, negate in place; the constant then starts at
+	// the minus sign
+	if ac, ok := n.(*nodes.A_Const); ok {
+		if i, ok := ac.Val.(*nodes.Integer); ok {
+			i.Ival = -i.Ival
+			ac.Location = nodes.ParseLoc(loc)
+			return n
+		}
+		if f, ok := ac.Val.(*nodes.Float); ok {
+			ac.Location = nodes.ParseLoc(loc)
+			if f.Fval[0] == '-' {
+				f.Fval = f.Fval[1:]
+			} else {
+				f.Fval = "-" + f.Fval
+			}
+			return n
+		}
+	}
+	// Otherwise, create unary minus expression
+	return makeAExpr(nodes.AEXPR_OP, "-", nil, n, loc)
+}
+
+func concatLists(a, b *nodes.List) *nodes.List {
+	if a == nil { return b }
+	if b == nil { return a }
+	result := &nodes.List{Items: make([]nodes.Node, 0, len(a.Items)+len(b.Items))}
+	result.Items = append(result.Items, a.Items...)
+	result.Items = append(result.Items, b.Items...)
+	return result
+}
+
+func makeSetOp(op nodes.SetOperation, quantifier int64, larg, rarg nodes.Node) nodes.Node {
+	n := &nodes.SelectStmt{
+		Op:   op,
+		All:  quantifier == SET_QUANTIFIER_ALL,
+		Larg: larg.(*nodes.SelectStmt),
+		Rarg: rarg.(*nodes.SelectStmt),
+	}
+	return n
+}
+
+func makeTypeName(typeName string) *nodes.TypeName {
+	return &nodes.TypeName{
+		Names: &nodes.List{Items: []nodes.Node{
+			&nodes.String{Sval: "pg_catalog"},
+			&nodes.String{Sval: typeName},
+		}},
+		Typemod:  -1,
+		Location: -1,
+	}
+}
+
+// makeIntConst returns the integer constant val at loc, or -1 for one with
+// no place in the source.
+func makeIntConst(val int64, loc int) nodes.Node {
+	return &nodes.A_Const{Val: &nodes.Integer{Ival: val}, Location: nodes.ParseLoc(loc)}
+}
+
+func makeStringConst(str string, loc int) nodes.Node {
+	return &nodes.A_Const{Val: &nodes.String{Sval: str}, Location: nodes.ParseLoc(loc)}
+}
+
+func makeBoolAConst(state bool) nodes.Node {
+	return &nodes.A_Const{Val: &nodes.Boolean{Boolval: state}, Location: -1}
+}
+
+func makeNullAConst(loc int) nodes.Node {
+	return &nodes.A_Const{Isnull: true, Location: nodes.ParseLoc(loc)}
+}
+
+func makeNotExpr(expr nodes.Node, loc int) nodes.Node {
+	return &nodes.BoolExpr{
+		Boolop:   nodes.NOT_EXPR,
+		Args:     &nodes.List{Items: []nodes.Node{expr}},
+		Location: nodes.ParseLoc(loc),
+	}
+}
+
+func setFuncAlias(n *nodes.RangeFunction, clause nodes.Node) {
+	if clause == nil {
+		return
+	}
+	// If it's an Alias, just set the alias
+	if alias, ok := clause.(*nodes.Alias); ok {
+		n.Alias = alias
+		return
+	}
+	// If it's a List with [alias, coldeflist], it's a func_alias_clause with column defs
+	if list, ok := clause.(*nodes.List); ok && len(list.Items) == 2 {
+		if alias, ok2 := list.Items[0].(*nodes.Alias); ok2 {
+			n.Alias = alias
+		}
+		if coldeflist, ok2 := list.Items[1].(*nodes.List); ok2 {
+			n.Coldeflist = coldeflist
+		}
+	}
+}
+
+func setJoinQual(n *nodes.JoinExpr, qual nodes.Node) {
+	if qual == nil {
+		return
+	}
+	if list, ok := qual.(*nodes.List); ok {
 		// Check if this is a USING clause with alias: [nameList, alias]
 		if len(list.Items) == 2 {
 			if innerList, ok2 := list.Items[0].(*nodes.List); ok2 {
 				if alias, ok3 := list.Items[1].(*nodes.Alias); ok3 {
 					n.UsingClause = innerList
-					n.JoinUsing = alias
+					n.JoinUsingAlias = alias
 					return
 				}
 			}
@@ -17559,14 +18385,48 @@ func setJoinQual(n *nodes.JoinExpr, qual nodes.Node) {
 	}
 }
 
+// typeNameAt sets the location of a type name built without one and
+// returns it. t is nil if its rule has already reported an error.
+func typeNameAt(t *nodes.TypeName, loc int) *nodes.TypeName {
+	if t != nil {
+		t.Location = nodes.ParseLoc(loc)
+	}
+	return t
+}
+
 func makeTypeNameFromNameList(names *nodes.List) nodes.Node {
-	tn := &nodes.TypeName{Location: -1}
+	tn := &nodes.TypeName{Typemod: -1, Location: -1}
 	if names != nil {
 		tn.Names = names
 	}
 	return tn
 }
 
+// makeJsonTablePathSpec returns the path of a JSON_TABLE or of one of its
+// columns, as PostgreSQL's function of the same name does. An unnamed
+// path has no name location.
+func makeJsonTablePathSpec(str nodes.Node, name string, loc, nameLoc int) *nodes.JsonTablePathSpec {
+	if name == "" {
+		nameLoc = -1
+	}
+	return &nodes.JsonTablePathSpec{
+		String:       str,
+		Name:         name,
+		NameLocation: nodes.ParseLoc(nameLoc),
+		Location:     nodes.ParseLoc(loc),
+	}
+}
+
+// makeJsonFormat returns a JsonFormat, as PostgreSQL's function of the
+// same name does.
+func makeJsonFormat(formatType nodes.JsonFormatType, encoding nodes.JsonEncoding, loc int) *nodes.JsonFormat {
+	return &nodes.JsonFormat{
+		FormatType: formatType,
+		Encoding:   encoding,
+		Location:   nodes.ParseLoc(loc),
+	}
+}
+
 func asJsonOutput(n nodes.Node) *nodes.JsonOutput {
 	if n == nil {
 		return nil
@@ -17574,24 +18434,40 @@ func asJsonOutput(n nodes.Node) *nodes.JsonOutput {
 	return n.(*nodes.JsonOutput)
 }
 
-func asJsonBehavior(n nodes.Node) *nodes.JsonBehavior {
+// asJsonBehavior returns the JsonBehavior of an ON ERROR clause. Anything
+// else is reported as a syntax error.
+func asJsonBehavior(lex pgLexer, n nodes.Node) *nodes.JsonBehavior {
 	if n == nil {
 		return nil
 	}
-	return n.(*nodes.JsonBehavior)
+	b, ok := n.(*nodes.JsonBehavior)
+	if !ok {
+		lex.Error("syntax error")
+	}
+	return b
 }
 
-func splitJsonBehaviorClause(n nodes.Node) (*nodes.JsonBehavior, *nodes.JsonBehavior) {
+// splitJsonBehaviorClause splits the [on_empty, on_error] list of
+// json_behavior_clause_opt. Anything else is reported as a syntax error.
+func splitJsonBehaviorClause(lex pgLexer, n nodes.Node) (*nodes.JsonBehavior, *nodes.JsonBehavior) {
 	if n == nil {
 		return nil, nil
 	}
-	list := n.(*nodes.List)
+	list, ok := n.(*nodes.List)
+	if !ok || len(list.Items) != 2 {
+		lex.Error("syntax error")
+		return nil, nil
+	}
 	var onEmpty, onError *nodes.JsonBehavior
 	if list.Items[0] != nil {
-		onEmpty = list.Items[0].(*nodes.JsonBehavior)
+		if onEmpty, ok = list.Items[0].(*nodes.JsonBehavior); !ok {
+			lex.Error("syntax error")
+		}
 	}
 	if list.Items[1] != nil {
-		onError = list.Items[1].(*nodes.JsonBehavior)
+		if onError, ok = list.Items[1].(*nodes.JsonBehavior); !ok {
+			lex.Error("syntax error")
+		}
 	}
 	return onEmpty, onError
 }
@@ -17611,11 +18487,11 @@ func doNegateFloat(f *nodes.Float) {
 	}
 }
 
-func makeDefElem(name string, arg nodes.Node) nodes.Node {
+func makeDefElem(name string, arg nodes.Node, loc int) nodes.Node {
 	return &nodes.DefElem{
 		Defname:  name,
 		Arg:      arg,
-		Location: -1,
+		Location: nodes.ParseLoc(loc),
 	}
 }
 
@@ -17625,19 +18501,61 @@ func makeDefElem(name string, arg nodes.Node) nodes.Node {
 func makeFuncName(parts ...string) *nodes.List {
 	items := make([]nodes.Node, len(parts))
 	for i, p := range parts {
-		items[i] = &nodes.String{Str: p}
+		items[i] = &nodes.String{Sval: p}
 	}
 	return &nodes.List{Items: items}
 }
 
+// tableFuncTypeName returns the result type of a RETURNS TABLE function:
+// SETOF the column's type if there is a single column, else SETOF record
+// (TableFuncTypeName).
+func tableFuncTypeName(columns *nodes.List) *nodes.TypeName {
+	var result *nodes.TypeName
+	if columns.Len() == 1 {
+		if p, ok := columns.Items[0].(*nodes.FunctionParameter); ok && p.ArgType != nil {
+			t := *p.ArgType
+			result = &t
+		}
+	}
+	if result == nil {
+		result = &nodes.TypeName{Names: makeFuncName("pg_catalog", "record"), Typemod: -1, Location: -1}
+	}
+	result.Setof = true
+	return result
+}
+
 // makeSQLValueFunction creates a SQLValueFunction node.
-func makeSQLValueFunction(op nodes.SVFOp, typmod int) nodes.Node {
-	return &nodes.SQLValueFunction{Op: op, Typmod: int32(typmod), Location: -1}
+func makeSQLValueFunction(op nodes.SQLValueFunctionOp, typmod, loc int) nodes.Node {
+	return &nodes.SQLValueFunction{Op: op, Typmod: int32(typmod), Location: nodes.ParseLoc(loc)}
+}
+
+// makeRecursiveViewSelect returns the query of a recursive view: a SELECT
+// of the view's columns from a WITH RECURSIVE CTE named after the view, as
+// PostgreSQL's function of the same name builds.
+func makeRecursiveViewSelect(relname string, aliases *nodes.List, query nodes.Node) *nodes.SelectStmt {
+	cte := &nodes.CommonTableExpr{
+		Ctename:       relname,
+		Aliascolnames: aliases,
+		Ctequery:      query,
+		Location:      -1,
+	}
+	var targetList []nodes.Node
+	for _, a := range aliases.Items {
+		targetList = append(targetList, &nodes.ResTarget{
+			Val:      &nodes.ColumnRef{Fields: makeList(a), Location: -1},
+			Location: -1,
+		})
+	}
+	return &nodes.SelectStmt{
+		TargetList: &nodes.List{Items: targetList},
+		FromClause: makeList(&nodes.RangeVar{Relname: relname, Inh: true, Relpersistence: 'p', Location: -1}),
+		WithClause: &nodes.WithClause{Ctes: makeList(cte), Recursive: true, Location: -1},
+	}
 }
 
 // makeTypeCast creates a TypeCast node.
-func makeTypeCast(arg nodes.Node, typeName *nodes.TypeName) nodes.Node {
-	return &nodes.TypeCast{Arg: arg, TypeName: typeName, Location: -1}
+func makeTypeCast(arg nodes.Node, typeName *nodes.TypeName, loc int) nodes.Node {
+	return &nodes.TypeCast{Arg: arg, TypeName: typeName, Location: nodes.ParseLoc(loc)}
 }
 
 // roleSpecOrNil safely casts a node to *nodes.RoleSpec, returning nil if the node is nil.
@@ -17694,26 +18612,84 @@ type importQualification struct {
 
 func (n *importQualification) Tag() nodes.NodeTag { return nodes.T_Invalid }
 
-// splitColQualList separates a list of column qualifiers (constraints and COLLATE)
-// into the ColumnDef's constraints and collClause fields.
-// This matches PostgreSQL's SplitColQualList function.
-func applyConstraintAttrs(n *nodes.Constraint, attrs int64) {
-	if attrs&int64(nodes.CAS_DEFERRABLE) != 0 {
-		n.Deferrable = true
-	}
-	if attrs&int64(nodes.CAS_INITIALLY_DEFERRED) != 0 {
-		n.Initdeferred = true
-		n.Deferrable = true
+// applyConstraintAttrs sets the flags of the constraint attributes in
+// attrs that apply to n's kind of constraint.
+func applyConstraintAttrs(lex pgLexer, n *nodes.Constraint, attrs int64) {
+	switch n.Contype {
+	case nodes.CONSTR_CHECK:
+		processCASbits(lex, attrs, "CHECK", nil, nil, &n.SkipValidation, &n.IsNoInherit)
+	case nodes.CONSTR_NOTNULL:
+		processCASbits(lex, attrs, "NOT NULL", nil, nil, nil, &n.IsNoInherit)
+	case nodes.CONSTR_UNIQUE:
+		processCASbits(lex, attrs, "UNIQUE", &n.Deferrable, &n.Initdeferred, nil, nil)
+	case nodes.CONSTR_PRIMARY:
+		processCASbits(lex, attrs, "PRIMARY KEY", &n.Deferrable, &n.Initdeferred, nil, nil)
+	case nodes.CONSTR_EXCLUSION:
+		processCASbits(lex, attrs, "EXCLUDE", &n.Deferrable, &n.Initdeferred, nil, nil)
+	case nodes.CONSTR_FOREIGN:
+		processCASbits(lex, attrs, "FOREIGN KEY", &n.Deferrable, &n.Initdeferred, &n.SkipValidation, nil)
 	}
-	if attrs&int64(nodes.CAS_NOT_VALID) != 0 {
-		n.SkipValidation = true
+	if n.SkipValidation {
 		n.InitiallyValid = false
 	}
-	if attrs&int64(nodes.CAS_NO_INHERIT) != 0 {
-		n.IsNoInherit = true
+}
+
+// processCASbits sets the flags of the constraint attributes in casBits,
+// as PostgreSQL's function of the same name does. A nil flag is one a
+// constraint of type constrType cannot have, and setting it is an error.
+func processCASbits(lex pgLexer, casBits int64, constrType string, deferrable, initdeferred, notValid, noInherit *bool) {
+	set := func(flag *bool, what string) {
+		if flag == nil {
+			lex.Error(fmt.Sprintf("%s constraints cannot be marked %s", constrType, what))
+			return
+		}
+		*flag = true
+	}
+	if casBits&int64(nodes.CAS_DEFERRABLE) != 0 {
+		set(deferrable, "DEFERRABLE")
+	}
+	if casBits&int64(nodes.CAS_INITIALLY_DEFERRED) != 0 {
+		set(initdeferred, "DEFERRABLE")
+		// INITIALLY DEFERRED implies DEFERRABLE.
+		if deferrable != nil {
+			*deferrable = true
+		}
+	}
+	if casBits&int64(nodes.CAS_NOT_VALID) != 0 {
+		set(notValid, "NOT VALID")
+	}
+	if casBits&int64(nodes.CAS_NO_INHERIT) != 0 {
+		set(noInherit, "NO INHERIT")
+	}
+}
+
+// splitCollateClause separates the COLLATE clause from a domain's list of
+// constraints, as PostgreSQL's SplitColQualList does.
+func splitCollateClause(lex pgLexer, qualList *nodes.List) (*nodes.List, *nodes.CollateClause) {
+	var constraints []nodes.Node
+	var collClause *nodes.CollateClause
+	if qualList == nil {
+		return nil, nil
+	}
+	for _, item := range qualList.Items {
+		if cc, ok := item.(*nodes.CollateClause); ok {
+			if collClause != nil {
+				lex.Error("multiple COLLATE clauses not allowed")
+			}
+			collClause = cc
+		} else {
+			constraints = append(constraints, item)
+		}
+	}
+	if len(constraints) == 0 {
+		return nil, collClause
 	}
+	return &nodes.List{Items: constraints}, collClause
 }
 
+// splitColQualList separates a list of column qualifiers (constraints and COLLATE)
+// into the ColumnDef's constraints and collClause fields.
+// This matches PostgreSQL's SplitColQualList function.
 func splitColQualList(qualList *nodes.List, coldef *nodes.ColumnDef) {
 	if qualList == nil {
 		return
@@ -17724,11 +18700,11 @@ func splitColQualList(qualList *nodes.List, coldef *nodes.ColumnDef) {
 			coldef.CollClause = cc
 		} else if de, ok := item.(*nodes.DefElem); ok && de.Defname == "compression" {
 			if s, ok := de.Arg.(*nodes.String); ok {
-				coldef.Compression = s.Str
+				coldef.Compression = s.Sval
 			}
 		} else if de, ok := item.(*nodes.DefElem); ok && de.Defname == "storage" {
 			if s, ok := de.Arg.(*nodes.String); ok {
-				coldef.StorageName = s.Str
+				coldef.StorageName = s.Sval
 			}
 		} else if de, ok := item.(*nodes.DefElem); ok && de.Defname == "fdwoptions" {
 			if l, ok := de.Arg.(*nodes.List); ok {
@@ -17743,20 +18719,54 @@ func splitColQualList(qualList *nodes.List, coldef *nodes.ColumnDef) {
 	}
 }
 
-func insertSelectOptions(stmt *nodes.SelectStmt, sortClause *nodes.List, lockingClause *nodes.List,
+// insertSelectOptions adds the trailing clauses of a SELECT to stmt, which
+// may already have some of its own from inside parentheses. Like
+// PostgreSQL, it allows only one of each clause but the locking clauses.
+func insertSelectOptions(lex pgLexer, stmt *nodes.SelectStmt, sortClause *nodes.List, lockingClause *nodes.List,
 	limitClause *SelectLimit, withClause *nodes.WithClause) {
 	if sortClause != nil {
+		if stmt.SortClause != nil {
+			lex.Error("multiple ORDER BY clauses not allowed")
+		}
 		stmt.SortClause = sortClause
 	}
 	if lockingClause != nil {
-		stmt.LockingClause = lockingClause
+		if stmt.LockingClause == nil {
+			stmt.LockingClause = &nodes.List{}
+		}
+		stmt.LockingClause.Items = append(stmt.LockingClause.Items, lockingClause.Items...)
 	}
-	if limitClause != nil {
+	if limitClause != nil && limitClause.LimitOffset != nil {
+		if stmt.LimitOffset != nil {
+			lex.Error("multiple OFFSET clauses not allowed")
+		}
 		stmt.LimitOffset = limitClause.LimitOffset
+	}
+	if limitClause != nil && limitClause.LimitCount != nil {
+		if stmt.LimitCount != nil {
+			lex.Error("multiple LIMIT clauses not allowed")
+		}
 		stmt.LimitCount = limitClause.LimitCount
+	}
+	if limitClause != nil {
+		if limitClause.LimitOption == nodes.LIMIT_OPTION_WITH_TIES {
+			if stmt.SortClause == nil {
+				lex.Error("WITH TIES cannot be specified without ORDER BY clause")
+			}
+			if stmt.LockingClause != nil {
+				for _, item := range stmt.LockingClause.Items {
+					if lc, ok := item.(*nodes.LockingClause); ok && lc.WaitPolicy == nodes.LockWaitSkip {
+						lex.Error("SKIP LOCKED and WITH TIES options cannot be used together")
+					}
+				}
+			}
+		}
 		stmt.LimitOption = limitClause.LimitOption
 	}
 	if withClause != nil {
+		if stmt.WithClause != nil {
+			lex.Error("multiple WITH clauses not allowed")
+		}
 		stmt.WithClause = withClause
 	}
 }
@@ -17775,6 +18785,7 @@ func relpersistenceForTemp(tempFlag int64) byte {
 }
 
 // extractArgTypes extracts the type names from a list of FunctionParameter nodes.
+// OUT parameters are not part of a function's identity and are skipped.
 func extractArgTypes(args *nodes.List) *nodes.List {
 	if args == nil {
 		return nil
@@ -17782,7 +18793,7 @@ func extractArgTypes(args *nodes.List) *nodes.List {
 	result := &nodes.List{}
 	for _, item := range args.Items {
 		fp, ok := item.(*nodes.FunctionParameter)
-		if ok {
+		if ok && fp.Mode != nodes.FUNC_PARAM_OUT {
 			result.Items = append(result.Items, fp.ArgType)
 		}
 	}
@@ -17814,14 +18825,21 @@ func extractAggrArgTypes(args *nodes.List) *nodes.List {
 // makeOrderedSetArgs builds the aggr_args result for ordered-set aggregates
 // with both direct args and ORDER BY args.
 // Returns a 2-element list: [combined_args_list, Integer{ndirectargs}].
-// The combined list has the direct args followed by the ORDER BY args.
-func makeOrderedSetArgs(directArgs *nodes.List, orderedArgs *nodes.List) *nodes.List {
-	combined := &nodes.List{}
-	ndirect := 0
-	if directArgs != nil {
-		combined.Items = append(combined.Items, directArgs.Items...)
-		ndirect = len(directArgs.Items)
+// The combined list has the direct args followed by the ORDER BY args,
+// except that a VARIADIC last direct arg, which must be repeated as the
+// only ORDER BY arg, is not listed twice.
+func makeOrderedSetArgs(lex pgLexer, directArgs *nodes.List, orderedArgs *nodes.List) *nodes.List {
+	lastd := directArgs.Items[len(directArgs.Items)-1].(*nodes.FunctionParameter)
+	if lastd.Mode == nodes.FUNC_PARAM_VARIADIC {
+		firsto := orderedArgs.Items[0].(*nodes.FunctionParameter)
+		if len(orderedArgs.Items) != 1 || firsto.Mode != nodes.FUNC_PARAM_VARIADIC ||
+			!nodes.Equal(lastd.ArgType, firsto.ArgType) {
+			lex.Error("an ordered-set aggregate with a VARIADIC direct argument must have one VARIADIC aggregated argument of the same data type")
+		}
+		orderedArgs = nil
 	}
+	ndirect := len(directArgs.Items)
+	combined := &nodes.List{Items: append([]nodes.Node{}, directArgs.Items...)}
 	if orderedArgs != nil {
 		combined.Items = append(combined.Items, orderedArgs.Items...)
 	}
@@ -17841,25 +18859,25 @@ func intToString(val int64) string {
 
 // makeRangeVarFromAnyName creates a RangeVar from a qualified name list (list of String nodes).
 // It handles 1-part (name), 2-part (schema.name), and 3-part (catalog.schema.name) names.
-func makeRangeVarFromAnyName(names *nodes.List) *nodes.RangeVar {
+func makeRangeVarFromAnyName(names *nodes.List, loc int) *nodes.RangeVar {
 	rv := &nodes.RangeVar{
 		Inh:            true,
 		Relpersistence: 'p',
-		Location:       -1,
+		Location:       nodes.ParseLoc(loc),
 	}
 	if names == nil {
 		return rv
 	}
 	switch len(names.Items) {
 	case 1:
-		rv.Relname = names.Items[0].(*nodes.String).Str
+		rv.Relname = names.Items[0].(*nodes.String).Sval
 	case 2:
-		rv.Schemaname = names.Items[0].(*nodes.String).Str
-		rv.Relname = names.Items[1].(*nodes.String).Str
+		rv.Schemaname = names.Items[0].(*nodes.String).Sval
+		rv.Relname = names.Items[1].(*nodes.String).Sval
 	case 3:
-		rv.Catalogname = names.Items[0].(*nodes.String).Str
-		rv.Schemaname = names.Items[1].(*nodes.String).Str
-		rv.Relname = names.Items[2].(*nodes.String).Str
+		rv.Catalogname = names.Items[0].(*nodes.String).Sval
+		rv.Schemaname = names.Items[1].(*nodes.String).Sval
+		rv.Relname = names.Items[2].(*nodes.String).Sval
 	}
 	return rv
 }
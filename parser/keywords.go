@@ -15,7 +15,6 @@ const (
 	ReservedKeyword
 )
 
-
 // Keyword represents a PostgreSQL keyword.
 type Keyword struct {
 	Name     string
@@ -533,4 +532,3 @@ var keywordMap = func() map[string]*Keyword {
 func LookupKeyword(name string) *Keyword {
 	return keywordMap[strings.ToLower(name)]
 }
-
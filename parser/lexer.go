@@ -23,6 +23,10 @@ const keywordTokenBase = 258
 // Keywords use 258 + 0..491 (492 keywords), so we start at 800
 const nonKeywordTokenBase = 800
 
+// namedatalen is PostgreSQL's NAMEDATALEN: names are at most
+// namedatalen-1 bytes long.
+const namedatalen = 64
+
 const (
 	// Special tokens
 	lex_EOF = 0
@@ -108,9 +112,14 @@ type Lexer struct {
 
 	// Location tracking
 	savedLoc int
+	quoteEnd int // end of the last quoted string, before the space after it
 
 	// Error handling
 	Err error
+
+	// Notices are the notices PostgreSQL's scanner raises, such as for an
+	// identifier it truncates.
+	Notices []Notice
 }
 
 // BackslashQuote values
@@ -135,14 +144,11 @@ func NewLexer(input string) *Lexer {
 // NextToken returns the next token from the input.
 func (l *Lexer) NextToken() Token {
 	for {
-		if l.pos >= len(l.input) {
-			// At EOF - if we're in a string state, that's an error
-			// If we're in quote-stop state, return the completed string
-			if l.state == stateXQS {
-				return l.lexQuoteContinue()
-			}
+		if l.pos >= len(l.input) && l.state == stateInitial {
 			return Token{Type: lex_EOF, Loc: l.pos}
 		}
+		// At EOF in any other state, the state's handler returns the
+		// completed string or reports what is unterminated.
 
 		switch l.state {
 		case stateInitial:
@@ -180,6 +186,12 @@ func (l *Lexer) lexInitial() Token {
 	l.start = l.pos
 	ch := l.input[l.pos]
 
+	// A NUL byte would otherwise end the input here, hiding what follows.
+	if ch == 0 {
+		l.Err = fmt.Errorf("invalid byte sequence for encoding \"UTF8\": 0x00")
+		return Token{Type: lex_EOF, Loc: l.start}
+	}
+
 	// Check for C-style comment start
 	if ch == '/' && l.pos+1 < len(l.input) && l.input[l.pos+1] == '*' {
 		l.pos += 2
@@ -452,8 +464,16 @@ func (l *Lexer) lexQuotedString() Token {
 
 		// For extended strings, handle escape sequences
 		if l.state == stateXE && ch == '\\' && l.pos+1 < len(l.input) {
+			escLoc := l.pos
 			l.pos++
 			l.handleEscapeSequence()
+			if l.Err != nil {
+				l.state = stateInitial
+				return Token{Type: lex_EOF, Loc: escLoc}
+			}
+			if l.state == stateXEU {
+				return l.NextToken()
+			}
 			continue
 		}
 
@@ -507,21 +527,25 @@ func (l *Lexer) handleEscapeSequence() {
 		} else {
 			l.literalbuf.WriteByte('x')
 		}
-	case 'u':
-		// Unicode escape: \uXXXX
-		if l.pos+3 < len(l.input) {
-			val := l.scanHex(4)
-			l.writeUnicodeChar(rune(val))
-		} else {
-			l.literalbuf.WriteByte('u')
+	case 'u', 'U':
+		// Unicode escape: \uXXXX or \UXXXXXXXX
+		n := 4
+		if ch == 'U' {
+			n = 8
 		}
-	case 'U':
-		// Unicode escape: \UXXXXXXXX
-		if l.pos+7 < len(l.input) {
-			val := l.scanHex(8)
-			l.writeUnicodeChar(rune(val))
-		} else {
-			l.literalbuf.WriteByte('U')
+		if !l.hexDigitsAhead(n) {
+			l.Err = fmt.Errorf("invalid Unicode escape")
+			return
+		}
+		val := rune(l.scanHex(n))
+		switch {
+		case isUTF16SurrogateFirst(val):
+			l.utf16FirstPart = val
+			l.state = stateXEU
+		case isUTF16SurrogateSecond(val):
+			l.Err = fmt.Errorf("invalid Unicode surrogate pair")
+		default:
+			l.writeUnicodeChar(val)
 		}
 	default:
 		l.literalbuf.WriteByte(ch)
@@ -556,8 +580,26 @@ func (l *Lexer) scanHex(n int) int {
 	return val
 }
 
-// writeUnicodeChar writes a Unicode code point to the literal buffer.
+// hexDigitsAhead reports whether the next n bytes are hex digits.
+func (l *Lexer) hexDigitsAhead(n int) bool {
+	if l.pos+n > len(l.input) {
+		return false
+	}
+	for i := 0; i < n; i++ {
+		if !isHexDigit(l.input[l.pos+i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// writeUnicodeChar writes a Unicode code point to the literal buffer. A
+// code point PostgreSQL does not accept is an error.
 func (l *Lexer) writeUnicodeChar(r rune) {
+	if !isValidUnicodeCodepoint(r) {
+		l.Err = fmt.Errorf("invalid Unicode escape value")
+		return
+	}
 	var buf [4]byte
 	n := utf8.EncodeRune(buf[:], r)
 	l.literalbuf.Write(buf[:n])
@@ -568,6 +610,7 @@ func (l *Lexer) lexQuoteContinue() Token {
 	// Check if there's whitespace with newline followed by quote
 	// SQL requires at least one newline in the whitespace to continue a string
 	hasNewline := false
+	end := l.pos
 
 	for l.pos < len(l.input) {
 		ch := l.input[l.pos]
@@ -595,6 +638,7 @@ func (l *Lexer) lexQuoteContinue() Token {
 	// No continuation - return the completed string
 	// Don't rewind position, just return from current spot
 	l.state = stateInitial
+	l.quoteEnd = end
 
 	str := l.literalbuf.String()
 
@@ -606,41 +650,9 @@ func (l *Lexer) lexQuoteContinue() Token {
 	case stateXQ, stateXE:
 		return Token{Type: lex_SCONST, Str: str, Loc: l.start}
 	case stateXUS:
-		// Unicode string: U&'...'
-		// Check for UESCAPE clause
-		escapeChar, err := l.scanUESCAPE()
-		if err != nil {
-			l.Err = err
-			return Token{Type: lex_EOF, Loc: l.start}
-		}
-
-		// Decode the string using the determined escape char
-		decoded, err := l.decodeUnicodeString(str, escapeChar)
-		if err != nil {
-			l.Err = err
-			return Token{Type: lex_EOF, Loc: l.start}
-		}
-
-		return Token{Type: lex_USCONST, Str: decoded, Loc: l.start}
-	case stateXUI:
-		// Unicode identifier: U&"..."
-		// Check for UESCAPE clause
-		// fmt.Printf("DEBUG: checking UESCAPE for identifier %s\n", str)
-		escapeChar, err := l.scanUESCAPE()
-		if err != nil {
-			l.Err = err
-			return Token{Type: lex_EOF, Loc: l.start}
-		}
-
-		// Decode the string using the determined escape char
-		decoded, err := l.decodeUnicodeString(str, escapeChar)
-		if err != nil {
-			l.Err = err
-			return Token{Type: lex_EOF, Loc: l.start}
-		}
-
-		// It's an identifier
-		return Token{Type: lex_IDENT, Str: decoded, Loc: l.start}
+		// The escapes of U&'...' are decoded by the parser, which first
+		// looks for a UESCAPE clause, as PostgreSQL's parser.c does.
+		return Token{Type: lex_USCONST, Str: str, Loc: l.start}
 	default:
 		return Token{Type: lex_SCONST, Str: str, Loc: l.start}
 	}
@@ -649,15 +661,20 @@ func (l *Lexer) lexQuoteContinue() Token {
 // lexUnicodeSurrogate handles Unicode surrogate pairs in E'...' strings.
 func (l *Lexer) lexUnicodeSurrogate() Token {
 	// Looking for the second part of a surrogate pair
+	loc := l.pos
 	if l.pos+1 < len(l.input) && l.input[l.pos] == '\\' {
-		l.pos++
-		ch := l.input[l.pos]
+		ch := l.input[l.pos+1]
 		if ch == 'u' || ch == 'U' {
-			l.pos++
+			l.pos += 2
 			n := 4
 			if ch == 'U' {
 				n = 8
 			}
+			if !l.hexDigitsAhead(n) {
+				l.Err = fmt.Errorf("invalid Unicode escape")
+				l.state = stateInitial
+				return Token{Type: lex_EOF, Loc: loc}
+			}
 			val := l.scanHex(n)
 			// Combine surrogate pair
 			if isUTF16SurrogateSecond(rune(val)) {
@@ -671,7 +688,7 @@ func (l *Lexer) lexUnicodeSurrogate() Token {
 
 	l.Err = fmt.Errorf("invalid Unicode surrogate pair")
 	l.state = stateInitial
-	return Token{Type: lex_EOF, Loc: l.start}
+	return Token{Type: lex_EOF, Loc: loc}
 }
 
 // lexDelimitedIdent handles "..." identifiers.
@@ -692,18 +709,17 @@ func (l *Lexer) lexDelimitedIdent() Token {
 			oldState := l.state
 			l.state = stateInitial
 
-			// If it's a Unicode identifier (U&"..."), we need to handle optional UESCAPE
-			if oldState == stateXUI {
-				l.stateBeforeStrStop = stateXUI
-				return l.lexQuoteContinue()
-			}
-
 			str := l.literalbuf.String()
 			if str == "" {
 				l.Err = fmt.Errorf("zero-length delimited identifier")
 				return Token{Type: lex_EOF, Loc: l.start}
 			}
-			return Token{Type: lex_IDENT, Str: str, Loc: l.start}
+			// A U&"..." identifier is decoded and truncated by the
+			// parser, after its UESCAPE clause.
+			if oldState == stateXUI {
+				return Token{Type: lex_UIDENT, Str: str, Loc: l.start}
+			}
+			return Token{Type: lex_IDENT, Str: l.truncateIdentifier(str, l.start), Loc: l.start}
 		}
 
 		l.literalbuf.WriteByte(ch)
@@ -862,7 +878,7 @@ func (l *Lexer) lexOperator() Token {
 		}
 	}
 
-	if len(op) >= 63 { // NAMEDATALEN
+	if len(op) >= namedatalen {
 		l.Err = fmt.Errorf("operator too long")
 		return Token{Type: lex_EOF, Loc: l.start}
 	}
@@ -926,34 +942,50 @@ done:
 		return Token{Type: lex_EOF, Loc: l.start}
 	}
 
-	numStr := l.input[start:l.pos]
-	// Remove underscores for parsing
-	numStr = strings.ReplaceAll(numStr, "_", "")
-
 	if isFloat {
-		return Token{Type: lex_FCONST, Str: numStr, Loc: l.start}
+		return Token{Type: lex_FCONST, Str: l.input[start:l.pos], Loc: l.start}
 	}
+	return l.integerToken(l.input[start:l.pos], 0, 10)
+}
 
-	// Try to parse as integer
-	val, err := strconv.ParseInt(numStr, 10, 64)
+// integerToken returns the token for the integer literal text, whose
+// digits start at offset skip and are in the given base. As in
+// PostgreSQL's process_integer_literal, an integer that does not fit in
+// 32 bits is an FCONST keeping the literal's text, underscores and all.
+func (l *Lexer) integerToken(text string, skip, base int) Token {
+	numStr := strings.ReplaceAll(text, "_", "")
+	val, err := strconv.ParseInt(numStr[skip:], base, 32)
 	if err != nil {
-		// Too large, treat as float
-		return Token{Type: lex_FCONST, Str: numStr, Loc: l.start}
+		return Token{Type: lex_FCONST, Str: text, Loc: l.start}
 	}
-
 	return Token{Type: lex_ICONST, Ival: val, Str: numStr, Loc: l.start}
 }
 
 // scanDecDigits scans decimal digits with optional underscores.
 func (l *Lexer) scanDecDigits() {
+	l.scanDigits(isDigit, false)
+}
+
+// scanDigits scans the digits digit accepts, each optionally after one
+// underscore, as PostgreSQL's decinteger and hexinteger patterns do. An
+// underscore before the first digit is taken only if leading is set, and
+// one not followed by a digit is left for the trailing junk check. It
+// reports whether there were any digits.
+func (l *Lexer) scanDigits(digit func(byte) bool, leading bool) bool {
+	seen := false
 	for l.pos < len(l.input) {
 		ch := l.input[l.pos]
-		if isDigit(ch) || ch == '_' {
+		switch {
+		case digit(ch):
 			l.pos++
-		} else {
-			break
+			seen = true
+		case ch == '_' && (seen || leading) && l.pos+1 < len(l.input) && digit(l.input[l.pos+1]):
+			l.pos++
+		default:
+			return seen
 		}
 	}
+	return seen
 }
 
 // lexHexNumber handles 0x... hex integers.
@@ -961,29 +993,17 @@ func (l *Lexer) lexHexNumber() Token {
 	start := l.pos
 	l.pos += 2 // skip 0x
 
-	if l.pos >= len(l.input) || (!isHexDigit(l.input[l.pos]) && l.input[l.pos] != '_') {
+	if !l.scanDigits(isHexDigit, true) {
 		l.Err = fmt.Errorf("invalid hexadecimal integer")
 		return Token{Type: lex_EOF, Loc: l.start}
 	}
 
-	for l.pos < len(l.input) && (isHexDigit(l.input[l.pos]) || l.input[l.pos] == '_') {
-		l.pos++
-	}
-
 	if l.pos < len(l.input) && isIdentStart(l.input[l.pos]) {
 		l.Err = fmt.Errorf("trailing junk after numeric literal")
 		return Token{Type: lex_EOF, Loc: l.start}
 	}
 
-	numStr := l.input[start:l.pos]
-	numStr = strings.ReplaceAll(numStr, "_", "")
-
-	val, err := strconv.ParseInt(numStr[2:], 16, 64)
-	if err != nil {
-		return Token{Type: lex_FCONST, Str: numStr, Loc: l.start}
-	}
-
-	return Token{Type: lex_ICONST, Ival: val, Str: numStr, Loc: l.start}
+	return l.integerToken(l.input[start:l.pos], 2, 16)
 }
 
 // lexOctalNumber handles 0o... octal integers.
@@ -991,29 +1011,17 @@ func (l *Lexer) lexOctalNumber() Token {
 	start := l.pos
 	l.pos += 2 // skip 0o
 
-	if l.pos >= len(l.input) || (!isOctalDigit(l.input[l.pos]) && l.input[l.pos] != '_') {
+	if !l.scanDigits(isOctalDigit, true) {
 		l.Err = fmt.Errorf("invalid octal integer")
 		return Token{Type: lex_EOF, Loc: l.start}
 	}
 
-	for l.pos < len(l.input) && (isOctalDigit(l.input[l.pos]) || l.input[l.pos] == '_') {
-		l.pos++
-	}
-
 	if l.pos < len(l.input) && isIdentStart(l.input[l.pos]) {
 		l.Err = fmt.Errorf("trailing junk after numeric literal")
 		return Token{Type: lex_EOF, Loc: l.start}
 	}
 
-	numStr := l.input[start:l.pos]
-	numStr = strings.ReplaceAll(numStr, "_", "")
-
-	val, err := strconv.ParseInt(numStr[2:], 8, 64)
-	if err != nil {
-		return Token{Type: lex_FCONST, Str: numStr, Loc: l.start}
-	}
-
-	return Token{Type: lex_ICONST, Ival: val, Str: numStr, Loc: l.start}
+	return l.integerToken(l.input[start:l.pos], 2, 8)
 }
 
 // lexBinaryNumber handles 0b... binary integers.
@@ -1021,29 +1029,17 @@ func (l *Lexer) lexBinaryNumber() Token {
 	start := l.pos
 	l.pos += 2 // skip 0b
 
-	if l.pos >= len(l.input) || (!isBinaryDigit(l.input[l.pos]) && l.input[l.pos] != '_') {
+	if !l.scanDigits(isBinaryDigit, true) {
 		l.Err = fmt.Errorf("invalid binary integer")
 		return Token{Type: lex_EOF, Loc: l.start}
 	}
 
-	for l.pos < len(l.input) && (isBinaryDigit(l.input[l.pos]) || l.input[l.pos] == '_') {
-		l.pos++
-	}
-
 	if l.pos < len(l.input) && isIdentStart(l.input[l.pos]) {
 		l.Err = fmt.Errorf("trailing junk after numeric literal")
 		return Token{Type: lex_EOF, Loc: l.start}
 	}
 
-	numStr := l.input[start:l.pos]
-	numStr = strings.ReplaceAll(numStr, "_", "")
-
-	val, err := strconv.ParseInt(numStr[2:], 2, 64)
-	if err != nil {
-		return Token{Type: lex_FCONST, Str: numStr, Loc: l.start}
-	}
-
-	return Token{Type: lex_ICONST, Ival: val, Str: numStr, Loc: l.start}
+	return l.integerToken(l.input[start:l.pos], 2, 2)
 }
 
 // lexIdent handles identifiers and keywords.
@@ -1059,6 +1055,9 @@ func (l *Lexer) lexIdent() Token {
 
 	// Check if it's a keyword
 	kw := LookupKeyword(ident)
+	if later, ok := laterKeywords[l.downcase(ident)]; ok {
+		kw = &later.Keyword
+	}
 	if kw != nil {
 		return Token{Type: kw.Token, Str: kw.Name, Loc: l.start}
 	}
@@ -1066,17 +1065,37 @@ func (l *Lexer) lexIdent() Token {
 	// Convert to lowercase for non-keywords
 	ident = l.downcase(ident)
 
-	// Truncate if too long
-	if len(ident) >= 63 { // NAMEDATALEN
-		ident = ident[:63]
-	}
-
-	return Token{Type: lex_IDENT, Str: ident, Loc: l.start}
+	return Token{Type: lex_IDENT, Str: l.truncateIdentifier(ident, l.start), Loc: l.start}
 }
 
-// downcase converts an identifier to lowercase.
+// downcase converts an identifier to lowercase. Like PostgreSQL with a
+// multibyte encoding, it only folds ASCII letters.
 func (l *Lexer) downcase(s string) string {
-	return strings.ToLower(s)
+	b := []byte(s)
+	for i, ch := range b {
+		if ch >= 'A' && ch <= 'Z' {
+			b[i] = ch + 'a' - 'A'
+		}
+	}
+	return string(b)
+}
+
+// truncateIdentifier clips an identifier at loc to the longest prefix of
+// whole characters that fits in NAMEDATALEN-1 bytes, and records the
+// notice PostgreSQL raises when it does.
+func (l *Lexer) truncateIdentifier(ident string, loc int) string {
+	if len(ident) < namedatalen {
+		return ident
+	}
+	n := namedatalen - 1
+	for n > 0 && !utf8.RuneStart(ident[n]) {
+		n--
+	}
+	l.Notices = append(l.Notices, Notice{
+		Message:  fmt.Sprintf("identifier \"%s\" will be truncated to \"%s\"", ident, ident[:n]),
+		Position: loc,
+	})
+	return ident[:n]
 }
 
 // Character classification functions
@@ -1153,153 +1172,12 @@ func surrogateToCodepoint(high, low rune) rune {
 	return ((high - 0xD800) << 10) + (low - 0xDC00) + 0x10000
 }
 
-// isValidUnicodeCodepoint checks if a code point is valid.
+// isValidUnicodeCodepoint reports whether r is a code point an escape can
+// give, as PostgreSQL's is_valid_unicode_codepoint does.
 func isValidUnicodeCodepoint(r rune) bool {
-	return r <= unicode.MaxRune && !isUTF16SurrogateFirst(r) && !isUTF16SurrogateSecond(r)
+	return r > 0 && r <= unicode.MaxRune
 }
 
 func isSpaceByte(c byte) bool {
 	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
 }
-
-// scanUESCAPE attempts to scan a UESCAPE clause.
-// It returns the escape character (default backslash) and any error encountered.
-// If no UESCAPE clause is present, it returns '\\' and nil.
-// If a UESCAPE clause is present but invalid, it rewinds and returns '\\', nil,
-// effectively treating "UESCAPE" as the next token.
-func (l *Lexer) scanUESCAPE() (rune, error) {
-	escapeChar := '\\'
-	savedPos := l.pos
-
-	l.skipWhitespaceAndComments()
-
-	if l.pos+7 <= len(l.input) {
-		word := l.input[l.pos : l.pos+7]
-		if strings.EqualFold(word, "UESCAPE") {
-			l.pos += 7
-			l.skipWhitespaceAndComments()
-
-			if l.pos < len(l.input) && l.input[l.pos] == '\'' {
-				l.pos++
-				if l.pos < len(l.input) {
-					ch := l.input[l.pos]
-					escapeChar = rune(ch)
-					l.pos++
-
-					if ch == '\'' && l.pos < len(l.input) && l.input[l.pos] == '\'' {
-						escapeChar = '\''
-						l.pos++
-					}
-
-					if l.pos < len(l.input) && l.input[l.pos] == '\'' {
-						l.pos++
-						return escapeChar, nil
-					}
-				}
-			}
-			// Invalid UESCAPE clause, rollback
-			l.pos = savedPos
-			return '\\', nil
-		}
-	}
-
-	l.pos = savedPos
-	return '\\', nil
-}
-
-// skipWhitespaceAndComments skips whitespace and comments (both -- and /* */).
-func (l *Lexer) skipWhitespaceAndComments() {
-	for l.pos < len(l.input) {
-		ch := l.input[l.pos]
-
-		if isSpaceByte(ch) {
-			l.pos++
-			continue
-		}
-
-		if ch == '-' && l.pos+1 < len(l.input) && l.input[l.pos+1] == '-' {
-			l.skipLineComment()
-			continue
-		}
-
-		if ch == '/' && l.pos+1 < len(l.input) && l.input[l.pos+1] == '*' {
-			l.pos += 2
-			depth := 1
-			for l.pos < len(l.input) && depth > 0 {
-				if l.pos >= len(l.input) {
-					break
-				}
-				if l.input[l.pos] == '*' && l.pos+1 < len(l.input) && l.input[l.pos+1] == '/' {
-					depth--
-					l.pos += 2
-				} else if l.input[l.pos] == '/' && l.pos+1 < len(l.input) && l.input[l.pos+1] == '*' {
-					depth++
-					l.pos += 2
-				} else {
-					l.pos++
-				}
-			}
-			continue
-		}
-
-		break
-	}
-}
-
-// decodeUnicodeString decodes a U& string with the given escape character.
-func (l *Lexer) decodeUnicodeString(s string, escape rune) (string, error) {
-	var buf strings.Builder
-	runes := []rune(s)
-	n := len(runes)
-
-	for i := 0; i < n; i++ {
-		r := runes[i]
-
-		if r == escape {
-			if i+1 >= n {
-				return "", fmt.Errorf("invalid Unicode escape sequence at end of string")
-			}
-
-			next := runes[i+1]
-
-			// Escaped escape char
-			if next == escape {
-				buf.WriteRune(escape)
-				i++
-				continue
-			}
-
-			// + indicates 6-digit hex
-			if next == '+' {
-				if i+8 > n {
-					return "", fmt.Errorf("invalid Unicode escape sequence")
-				}
-				hexStr := string(runes[i+2 : i+8])
-				val, err := strconv.ParseInt(hexStr, 16, 32)
-				if err != nil {
-					return "", fmt.Errorf("invalid Unicode escape sequence: %v", err)
-				}
-				buf.WriteRune(rune(val))
-				i += 7
-				continue
-			}
-
-			// Otherwise 4-digit hex
-			if i+5 > n {
-				return "", fmt.Errorf("invalid Unicode escape sequence")
-			}
-			hexStr := string(runes[i+1 : i+5])
-			val, err := strconv.ParseInt(hexStr, 16, 32)
-			if err != nil {
-				return "", fmt.Errorf("invalid Unicode escape sequence: %v", err)
-			}
-			buf.WriteRune(rune(val))
-			i += 4
-			continue
-		}
-
-		buf.WriteRune(r)
-	}
-
-	return buf.String(), nil
-}
@@ -53,6 +53,7 @@ func TestLexerIdentifiers(t *testing.T) {
 		{"_foo", lex_IDENT, "_foo"},
 		{"foo123", lex_IDENT, "foo123"},
 		{"foo$bar", lex_IDENT, "foo$bar"},
+		{"ÄRGER", lex_IDENT, "Ärger"}, // only ASCII letters are folded
 	}
 
 	for _, tt := range tests {
@@ -96,10 +97,10 @@ func TestLexerDelimitedIdentifiers(t *testing.T) {
 
 func TestLexerNumbers(t *testing.T) {
 	tests := []struct {
-		input    string
-		tokType  int
-		ival     int64
-		str      string
+		input   string
+		tokType int
+		ival    int64
+		str     string
 	}{
 		{"0", lex_ICONST, 0, "0"},
 		{"42", lex_ICONST, 42, "42"},
@@ -113,6 +114,11 @@ func TestLexerNumbers(t *testing.T) {
 		{".5", lex_FCONST, 0, ".5"},
 		{"1e10", lex_FCONST, 0, "1e10"},
 		{"1.5e-3", lex_FCONST, 0, "1.5e-3"},
+		{"1_000.000_5", lex_FCONST, 0, "1_000.000_5"},
+		{"2147483647", lex_ICONST, 2147483647, "2147483647"},
+		{"2147483648", lex_FCONST, 0, "2147483648"},
+		{"5_000_000_000", lex_FCONST, 0, "5_000_000_000"},
+		{"0x80000000", lex_FCONST, 0, "0x80000000"},
 	}
 
 	for _, tt := range tests {
@@ -140,12 +146,12 @@ func TestLexerStrings(t *testing.T) {
 	}{
 		{"'hello'", lex_SCONST, "hello"},
 		{"'hello world'", lex_SCONST, "hello world"},
-		{"'it''s'", lex_SCONST, "it's"},      // escaped quote
-		{"''", lex_SCONST, ""},               // empty string
+		{"'it''s'", lex_SCONST, "it's"},                  // escaped quote
+		{"''", lex_SCONST, ""},                           // empty string
 		{"E'hello\\nworld'", lex_SCONST, "hello\nworld"}, // escape string
 		{"E'tab\\there'", lex_SCONST, "tab\there"},
-		{"B'101'", lex_BCONST, "b101"},       // bit string
-		{"X'FF'", lex_XCONST, "xFF"},         // hex string
+		{"B'101'", lex_BCONST, "b101"}, // bit string
+		{"X'FF'", lex_XCONST, "xFF"},   // hex string
 	}
 
 	for _, tt := range tests {
@@ -384,6 +390,11 @@ func TestLexerErrors(t *testing.T) {
 		{`"unterminated`, "unterminated quoted identifier"},
 		{"/* unterminated", "unterminated /* comment"},
 		{"$$unterminated", "unterminated dollar-quoted string"},
+		{"'", "unterminated quoted string"},
+		{`"`, "unterminated quoted identifier"},
+		{"/*", "unterminated /* comment"},
+		{"x'", "unterminated hexadecimal string literal"},
+		{"SELECT \x00", "invalid byte sequence for encoding \"UTF8\": 0x00"},
 	}
 
 	for _, tt := range tests {
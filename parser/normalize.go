@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// Normalize returns input with each constant replaced by a parameter
+// reference, numbered $1, $2 and so on after the highest one the input
+// already uses, as pg_stat_statements shows queries. Constants in type
+// names, such as the length of varchar(10), are kept, as are those the
+// grammar builds without a source location.
+func Normalize(input string) (string, error) {
+	stmts, err := Parse(input)
+	if err != nil {
+		return "", err
+	}
+	if stmts == nil {
+		return input, nil
+	}
+	consts := map[int]bool{}
+	param := 0
+	for _, stmt := range stmts.Items {
+		nodes.Walk(stmt, func(n nodes.Node) bool {
+			switch n := n.(type) {
+			case *nodes.TypeName:
+				return false
+			case *nodes.ParamRef:
+				if n.Number > param {
+					param = n.Number
+				}
+			case *nodes.A_Const:
+				// No statement starts with a constant, so 0 is unset.
+				if n.Location > 0 {
+					consts[int(n.Location)] = true
+				}
+			}
+			return true
+		})
+	}
+	if len(consts) == 0 {
+		return input, nil
+	}
+
+	toks, err := Scan(input)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	pos := 0
+	for i := 0; i < len(toks); i++ {
+		tok := toks[i]
+		if !consts[tok.Start] || !isConstToken(toks, i) {
+			continue
+		}
+		end := tok.End
+		if tok.Name == "'-'" {
+			// A negated number starts at the minus sign.
+			i++
+			end = toks[i].End
+		} else if i+2 < len(toks) && toks[i+1].Name == "UESCAPE" && toks[i+2].Name == "SCONST" &&
+			strings.EqualFold(input[tok.Start:tok.Start+2], "U&") {
+			// A Unicode string ends after its UESCAPE clause.
+			i += 2
+			end = toks[i].End
+		}
+		param++
+		b.WriteString(input[pos:tok.Start])
+		b.WriteString("$" + strconv.Itoa(param))
+		pos = end
+	}
+	b.WriteString(input[pos:])
+	return b.String(), nil
+}
+
+// isConstToken reports whether toks[i] starts a constant: a literal, or a
+// minus sign before a number.
+func isConstToken(toks []ScanToken, i int) bool {
+	switch toks[i].Name {
+	case "ICONST", "FCONST", "SCONST", "BCONST", "XCONST", "TRUE_P", "FALSE_P", "NULL_P":
+		return true
+	case "'-'":
+		return i+1 < len(toks) && (toks[i+1].Name == "ICONST" || toks[i+1].Name == "FCONST")
+	}
+	return false
+}
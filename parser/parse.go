@@ -5,6 +5,7 @@ package parser
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/pgplex/pgparser/nodes"
 )
@@ -15,23 +16,62 @@ type ParseResult struct {
 	Err   error
 }
 
+// Version is a PostgreSQL major version, whose syntax a parse accepts.
+type Version int
+
+// The versions whose syntax Options.Version can select.
+const (
+	PG13 Version = 13
+	PG14 Version = 14
+	PG15 Version = 15
+	PG16 Version = 16
+	PG17 Version = 17
+	PG18 Version = 18
+)
+
+// DefaultVersion is the version whose syntax Parse and ParseRaw accept,
+// the one the grammar is translated from.
+const DefaultVersion = PG17
+
+// Options configures a parse.
+type Options struct {
+	// Version selects the PostgreSQL major version whose syntax is
+	// accepted, from PG13 to PG18; zero means DefaultVersion. The grammar
+	// is 17's: the later syntax it checks for, listed in the README, fails
+	// with an error naming the version it needs, and other syntax added
+	// since 13 is accepted at any version.
+	Version Version
+}
+
 // parserLexer adapts our Lexer to the goyacc-generated pgLexer interface.
 type parserLexer struct {
-	lexer  *Lexer
-	result *nodes.List
-	err    error
+	lexer   *Lexer
+	version Version
+	result  *nodes.List
+	err     error
+
+	// Top-level statements with their source spans, built alongside result.
+	rawStmts []*nodes.RawStmt
+	firstLoc int  // location of the first token
+	seenTok  bool // whether any token has been returned yet
+	lastLoc  int  // location of the last token returned, where errors are reported
 
 	// One-token lookahead for NOT_LA, NULLS_LA, WITH_LA, FORMAT_LA.
 	// PostgreSQL's parser.c uses this to disambiguate tokens based on context.
 	haveLookahead    bool
 	lookaheadToken   Token
 	lookaheadTokType int
+
+	// A token of the lexer read to look for UESCAPE after a U& token.
+	havePeek  bool
+	peekToken Token
 }
 
 // newParserLexer creates a new lexer adapter for the parser.
-func newParserLexer(input string) *parserLexer {
+func newParserLexer(input string, version Version) *parserLexer {
 	return &parserLexer{
-		lexer: NewLexer(input),
+		lexer:   NewLexer(input),
+		version: version,
 	}
 }
 
@@ -49,14 +89,14 @@ func (l *parserLexer) Lex(lval *pgSymType) int {
 		tokType = l.lookaheadTokType
 		l.haveLookahead = false
 	} else {
-		tok = l.lexer.NextToken()
+		tok = l.next()
 		tokType = l.mapTokenType(tok)
 	}
 
 	// Check if this token needs lookahead-based replacement
 	if l.needsLookahead(tokType) {
 		// Peek at the next token
-		nextTok := l.lexer.NextToken()
+		nextTok := l.next()
 		nextTokType := l.mapTokenType(nextTok)
 
 		// Save it for the next Lex() call
@@ -68,11 +108,18 @@ func (l *parserLexer) Lex(lval *pgSymType) int {
 		tokType = l.applyLookahead(tokType, nextTokType)
 	}
 
+	lval.loc = tok.Loc
+	l.lastLoc = tok.Loc
+	if !l.seenTok {
+		l.seenTok = true
+		l.firstLoc = tok.Loc
+	}
+
 	// Set semantic values based on token type
 	switch tokType {
 	case IDENT:
 		lval.str = tok.Str
-	case ICONST:
+	case ICONST, PARAM:
 		lval.ival = tok.Ival
 	case FCONST, SCONST, BCONST, XCONST:
 		lval.str = tok.Str
@@ -144,11 +191,189 @@ func (l *parserLexer) applyLookahead(curToken, nextToken int) int {
 	return curToken
 }
 
-// Error implements pgLexer.Error
+// next returns the next token of the lexer, with a U&"..." identifier or
+// U&'...' string turned into an IDENT or SCONST as PostgreSQL's parser.c
+// does: a UESCAPE clause after it is consumed, its escapes are decoded and
+// an identifier is truncated. An error ends the input: it is recorded, as
+// the error of the parse, and EOF is returned.
+func (l *parserLexer) next() Token {
+	tok := l.nextRaw()
+	if tok.Type != lex_UIDENT && tok.Type != lex_USCONST {
+		return tok
+	}
+	escape := byte('\\')
+	if next := l.nextRaw(); next.Type == UESCAPE {
+		esc := l.nextRaw()
+		if esc.Type != lex_SCONST {
+			return l.fail("UESCAPE must be followed by a simple string literal", esc.Loc)
+		}
+		if len(esc.Str) != 1 || !isUescapeChar(esc.Str[0]) {
+			return l.fail("invalid Unicode escape character", esc.Loc)
+		}
+		escape = esc.Str[0]
+	} else {
+		l.havePeek = true
+		l.peekToken = next
+	}
+	str, err := udeunescape(tok.Str, escape, tok.Loc)
+	if err != nil {
+		return l.fail(err.Message, err.Position)
+	}
+	if tok.Type == lex_USCONST {
+		return Token{Type: lex_SCONST, Str: str, Loc: tok.Loc}
+	}
+	return Token{Type: lex_IDENT, Str: l.lexer.truncateIdentifier(str, tok.Loc), Loc: tok.Loc}
+}
+
+// nextRaw returns the next token of the lexer as it is.
+func (l *parserLexer) nextRaw() Token {
+	if l.havePeek {
+		l.havePeek = false
+		return l.peekToken
+	}
+	tok := l.lexer.NextToken()
+	if l.lexer.Err != nil {
+		return l.fail(l.lexer.Err.Error(), tok.Loc)
+	}
+	return l.versionToken(tok)
+}
+
+// laterKeyword is a keyword added after 17, the release Keywords follows,
+// with the version that added it.
+type laterKeyword struct {
+	Keyword
+	since Version
+}
+
+// laterKeywords are the keywords added after 17, by name. The lexer scans
+// them as keywords, and the parser reads them as identifiers before the
+// version that added them.
+var laterKeywords = map[string]*laterKeyword{
+	"virtual": {Keyword{"virtual", VIRTUAL, UnreservedKeyword}, PG18},
+}
+
+// versionToken returns tok as the version being parsed scans it: a keyword
+// added in a later release is an identifier.
+func (l *parserLexer) versionToken(tok Token) Token {
+	if kw, ok := laterKeywords[tok.Str]; ok && kw.Token == tok.Type && l.version < kw.since {
+		tok.Type = lex_IDENT
+	}
+	return tok
+}
+
+// fail records an error at loc and returns the EOF that ends the input.
+func (l *parserLexer) fail(msg string, loc int) Token {
+	l.errorAt(msg, loc)
+	return Token{Type: lex_EOF, Loc: loc}
+}
+
+// errorAt records an error at loc, unless there already is one.
+func (l *parserLexer) errorAt(msg string, loc int) {
+	if l.err == nil {
+		l.err = &ParseError{Message: msg, Position: loc}
+	}
+}
+
+// requireVersion records an error at loc if the version being parsed is
+// older than since, the first with the syntax named by what. The grammar's
+// actions call it for the syntax PostgreSQL has added since 13.
+func requireVersion(lex pgLexer, since Version, what string, loc int) {
+	if l, ok := lex.(*parserLexer); ok && l.version < since {
+		l.errorAt(fmt.Sprintf("%s requires PostgreSQL %d or later", what, since), loc)
+	}
+}
+
+// isUescapeChar reports whether ch can be the escape character of a
+// UESCAPE clause, as PostgreSQL's check_uescapechar does.
+func isUescapeChar(ch byte) bool {
+	return !isHexDigit(ch) && ch != '+' && ch != '\'' && ch != '"' && !isSpaceByte(ch)
+}
+
+// udeunescape decodes the escapes in the body of a U& token at loc, as
+// PostgreSQL's str_udeunescape does. An error points at the escape, as an
+// offset into the body after the three bytes of U&' or U&".
+func udeunescape(str string, escape byte, loc int) (string, *ParseError) {
+	var b strings.Builder
+	var pairFirst rune
+	invalidPair := func(i int) *ParseError {
+		return &ParseError{Message: "invalid Unicode surrogate pair", Position: loc + 3 + i}
+	}
+	for i := 0; i < len(str); i++ {
+		if str[i] != escape {
+			if pairFirst != 0 {
+				return "", invalidPair(i)
+			}
+			b.WriteByte(str[i])
+			continue
+		}
+		var digits, width int
+		switch {
+		case i+1 < len(str) && str[i+1] == escape:
+			if pairFirst != 0 {
+				return "", invalidPair(i)
+			}
+			b.WriteByte(escape)
+			i++
+			continue
+		case isHexRun(str, i+1, 4):
+			digits, width = i+1, 4
+		case i+1 < len(str) && str[i+1] == '+' && isHexRun(str, i+2, 6):
+			digits, width = i+2, 7
+		default:
+			return "", &ParseError{Message: "invalid Unicode escape", Position: loc + 3 + i}
+		}
+		var c rune
+		for j := digits; j < i+1+width; j++ {
+			c = c<<4 + rune(hexValue(str[j]))
+		}
+		if c == 0 || c > 0x10FFFF {
+			return "", &ParseError{Message: "invalid Unicode escape value", Position: loc + 3 + i}
+		}
+		if pairFirst != 0 {
+			if !isUTF16SurrogateSecond(c) {
+				return "", invalidPair(i)
+			}
+			c = surrogateToCodepoint(pairFirst, c)
+			pairFirst = 0
+		} else if isUTF16SurrogateSecond(c) {
+			return "", invalidPair(i)
+		}
+		if isUTF16SurrogateFirst(c) {
+			pairFirst = c
+		} else {
+			b.WriteRune(c)
+		}
+		i += width
+	}
+	if pairFirst != 0 {
+		return "", invalidPair(len(str))
+	}
+	return b.String(), nil
+}
+
+// isHexRun reports whether str has n hex digits at i.
+func isHexRun(str string, i, n int) bool {
+	if i+n > len(str) {
+		return false
+	}
+	for j := i; j < i+n; j++ {
+		if !isHexDigit(str[j]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Error implements pgLexer.Error. Like PostgreSQL, it reports the error at
+// the start of the token the parser could not accept. An error of the
+// lexer, which ended the input early, takes precedence.
 func (l *parserLexer) Error(s string) {
+	if l.err != nil {
+		return
+	}
 	l.err = &ParseError{
 		Message:  s,
-		Position: l.lexer.pos,
+		Position: l.lastLoc,
 	}
 }
 
@@ -183,11 +408,11 @@ func (l *parserLexer) mapTokenType(tok Token) int {
 			return BCONST
 		case 4: // lex_XCONST
 			return XCONST
-		case 5: // lex_USCONST - map to SCONST for now
+		case 5: // lex_USCONST, which next has decoded unless scanning
 			return SCONST
 		case 6: // lex_IDENT
 			return IDENT
-		case 7: // lex_UIDENT - map to IDENT for now
+		case 7: // lex_UIDENT, which next has decoded unless scanning
 			return IDENT
 		case 8: // lex_TYPECAST
 			return TYPECAST
@@ -227,18 +452,87 @@ func (e *ParseError) Error() string {
 	return e.Message
 }
 
+// Notice is a message PostgreSQL reports while parsing without failing,
+// such as for an identifier it truncates to NAMEDATALEN-1 bytes.
+type Notice struct {
+	Message  string
+	Position int
+}
+
 // Parse parses the given SQL input and returns a list of statements.
 func Parse(input string) (*nodes.List, error) {
-	lexer := newParserLexer(input)
+	return ParseWith(input, Options{})
+}
+
+// ParseWith is Parse with options.
+func ParseWith(input string, opts Options) (*nodes.List, error) {
+	lexer, err := run(input, opts)
+	if err != nil {
+		return nil, err
+	}
+	return lexer.result, nil
+}
+
+// ParseRaw parses the given SQL input and returns its top-level statements
+// wrapped in RawStmt nodes carrying their source spans, like PostgreSQL's
+// raw_parser. StmtLocation is the byte offset where the statement starts
+// (for statements after the first, just past the preceding semicolon) and
+// StmtLen is its length up to the terminating semicolon, or 0 for the last
+// statement if it extends to the end of the input.
+func ParseRaw(input string) ([]*nodes.RawStmt, error) {
+	return ParseRawWith(input, Options{})
+}
+
+// ParseRawWith is ParseRaw with options.
+func ParseRawWith(input string, opts Options) ([]*nodes.RawStmt, error) {
+	lexer, err := run(input, opts)
+	if err != nil {
+		return nil, err
+	}
+	return lexer.rawStmts, nil
+}
+
+// ParseNotices is ParseRaw that also returns the notices of the parse,
+// which are returned even if it fails.
+func ParseNotices(input string) ([]*nodes.RawStmt, []Notice, error) {
+	return ParseNoticesWith(input, Options{})
+}
+
+// ParseNoticesWith is ParseNotices with options.
+func ParseNoticesWith(input string, opts Options) ([]*nodes.RawStmt, []Notice, error) {
+	lexer, err := run(input, opts)
+	if err != nil {
+		return nil, lexer.lexer.Notices, err
+	}
+	return lexer.rawStmts, lexer.lexer.Notices, nil
+}
+
+// run parses input. A panic in the parser, which would be a bug, is
+// returned as a *ParseError at the last token read rather than crashing
+// the caller, since input may come from anyone.
+func run(input string, opts Options) (lexer *parserLexer, err error) {
+	version := opts.Version
+	if version == 0 {
+		version = DefaultVersion
+	}
+	if version < PG13 || version > PG18 {
+		return nil, fmt.Errorf("unsupported PostgreSQL version %d: pgparser accepts 13 to 18", version)
+	}
+	lexer = newParserLexer(input, version)
+	defer func() {
+		if r := recover(); r != nil {
+			err = &ParseError{Message: fmt.Sprintf("internal parser error: %v", r), Position: lexer.lastLoc}
+		}
+	}()
 	ret := pgParse(lexer)
 
 	if lexer.err != nil {
-		return nil, lexer.err
+		return lexer, lexer.err
 	}
 
 	if ret != 0 {
-		return nil, &ParseError{Message: fmt.Sprintf("parse error (ret=%d)", ret), Position: lexer.lexer.pos}
+		return lexer, &ParseError{Message: fmt.Sprintf("parse error (ret=%d)", ret), Position: lexer.lastLoc}
 	}
 
-	return lexer.result, nil
+	return lexer, nil
 }
@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/pgplex/pgparser/nodes"
@@ -24,7 +26,7 @@ func TestLexerTokenMapping(t *testing.T) {
 func TestParserLexerMapping(t *testing.T) {
 	// Debug: Check what tokens the parserLexer produces for the parser
 	input := "SELECT 1"
-	pl := newParserLexer(input)
+	pl := newParserLexer(input, DefaultVersion)
 
 	var lval pgSymType
 	tok1 := pl.Lex(&lval)
@@ -181,8 +183,8 @@ func TestParseSelectWithGroupBy(t *testing.T) {
 		t.Fatalf("expected *nodes.String, got %T", colRef.Fields.Items[0])
 	}
 
-	if str.Str != "a" {
-		t.Errorf("expected group by column 'a', got %q", str.Str)
+	if str.Sval != "a" {
+		t.Errorf("expected group by column 'a', got %q", str.Sval)
 	}
 
 	// Verify no HAVING clause
@@ -295,8 +297,8 @@ func TestParseUnion(t *testing.T) {
 	lTarget := stmt.Larg.TargetList.Items[0].(*nodes.ResTarget)
 	lCol := lTarget.Val.(*nodes.ColumnRef)
 	lStr := lCol.Fields.Items[0].(*nodes.String)
-	if lStr.Str != "a" {
-		t.Errorf("expected left target 'a', got %q", lStr.Str)
+	if lStr.Sval != "a" {
+		t.Errorf("expected left target 'a', got %q", lStr.Sval)
 	}
 
 	// Verify right side is "SELECT b FROM t2"
@@ -306,8 +308,8 @@ func TestParseUnion(t *testing.T) {
 	rTarget := stmt.Rarg.TargetList.Items[0].(*nodes.ResTarget)
 	rCol := rTarget.Val.(*nodes.ColumnRef)
 	rStr := rCol.Fields.Items[0].(*nodes.String)
-	if rStr.Str != "b" {
-		t.Errorf("expected right target 'b', got %q", rStr.Str)
+	if rStr.Sval != "b" {
+		t.Errorf("expected right target 'b', got %q", rStr.Sval)
 	}
 }
 
@@ -403,8 +405,8 @@ func TestParseUnionChained(t *testing.T) {
 	rTarget := stmt.Rarg.TargetList.Items[0].(*nodes.ResTarget)
 	rCol := rTarget.Val.(*nodes.ColumnRef)
 	rStr := rCol.Fields.Items[0].(*nodes.String)
-	if rStr.Str != "c" {
-		t.Errorf("expected right target 'c', got %q", rStr.Str)
+	if rStr.Sval != "c" {
+		t.Errorf("expected right target 'c', got %q", rStr.Sval)
 	}
 
 	// Left side should be another UNION (SELECT a FROM t1 UNION SELECT b FROM t2)
@@ -422,8 +424,8 @@ func TestParseUnionChained(t *testing.T) {
 	llTarget := stmt.Larg.Larg.TargetList.Items[0].(*nodes.ResTarget)
 	llCol := llTarget.Val.(*nodes.ColumnRef)
 	llStr := llCol.Fields.Items[0].(*nodes.String)
-	if llStr.Str != "a" {
-		t.Errorf("expected left-left target 'a', got %q", llStr.Str)
+	if llStr.Sval != "a" {
+		t.Errorf("expected left-left target 'a', got %q", llStr.Sval)
 	}
 
 	// Left-right should be "SELECT b FROM t2"
@@ -433,8 +435,8 @@ func TestParseUnionChained(t *testing.T) {
 	lrTarget := stmt.Larg.Rarg.TargetList.Items[0].(*nodes.ResTarget)
 	lrCol := lrTarget.Val.(*nodes.ColumnRef)
 	lrStr := lrCol.Fields.Items[0].(*nodes.String)
-	if lrStr.Str != "b" {
-		t.Errorf("expected left-right target 'b', got %q", lrStr.Str)
+	if lrStr.Sval != "b" {
+		t.Errorf("expected left-right target 'b', got %q", lrStr.Sval)
 	}
 }
 
@@ -558,8 +560,8 @@ func TestParseSelectWithMultipleGroupBy(t *testing.T) {
 		t.Fatalf("expected *nodes.String, got %T", colRef1.Fields.Items[0])
 	}
 
-	if str1.Str != "a" {
-		t.Errorf("expected first group by column 'a', got %q", str1.Str)
+	if str1.Sval != "a" {
+		t.Errorf("expected first group by column 'a', got %q", str1.Sval)
 	}
 
 	// Verify second group by item is column "b"
@@ -573,8 +575,8 @@ func TestParseSelectWithMultipleGroupBy(t *testing.T) {
 		t.Fatalf("expected *nodes.String, got %T", colRef2.Fields.Items[0])
 	}
 
-	if str2.Str != "b" {
-		t.Errorf("expected second group by column 'b', got %q", str2.Str)
+	if str2.Sval != "b" {
+		t.Errorf("expected second group by column 'b', got %q", str2.Sval)
 	}
 }
 
@@ -681,3 +683,180 @@ func TestParseGrant(t *testing.T) {
 		}
 	}
 }
+
+func TestParseRawStmtSpans(t *testing.T) {
+	input := "  SELECT 1;\n-- comment\nINSERT INTO t VALUES (1) ;;CREATE FUNCTION f() RETURNS int BEGIN ATOMIC SELECT 1; END;\nSELECT 2"
+
+	stmts, err := ParseRaw(input)
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	want := []struct {
+		tag  nodes.NodeTag
+		text string
+	}{
+		{nodes.T_SelectStmt, "SELECT 1"},
+		{nodes.T_InsertStmt, "\n-- comment\nINSERT INTO t VALUES (1) "},
+		{nodes.T_CreateFunctionStmt, "CREATE FUNCTION f() RETURNS int BEGIN ATOMIC SELECT 1; END"},
+		{nodes.T_SelectStmt, "\nSELECT 2"},
+	}
+	if len(stmts) != len(want) {
+		t.Fatalf("expected %d statements, got %d", len(want), len(stmts))
+	}
+	for i, w := range want {
+		rs := stmts[i]
+		if rs.Stmt.Tag() != w.tag {
+			t.Errorf("stmt %d: expected tag %d, got %d", i, w.tag, rs.Stmt.Tag())
+		}
+		end := len(input)
+		if rs.StmtLen != 0 {
+			end = int(rs.StmtLocation + rs.StmtLen)
+		}
+		if got := input[rs.StmtLocation:end]; got != w.text {
+			t.Errorf("stmt %d: expected text %q, got %q", i, w.text, got)
+		}
+	}
+	if stmts[3].StmtLen != 0 {
+		t.Errorf("expected StmtLen 0 for final unterminated statement, got %d", stmts[3].StmtLen)
+	}
+}
+
+func TestParseLexerErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+		pos   int
+	}{
+		{"SELECT $1a; SELECT 1", "trailing junk after parameter", 7},
+		{"SELECT 1; SELECT 'abc", "unterminated quoted string", 17},
+		{"SELECT 1;\x00; DROP TABLE t", "invalid byte sequence for encoding \"UTF8\": 0x00", 9},
+		{"SELECT U&'a' UESCAPE +", "UESCAPE must be followed by a simple string literal", 21},
+		{"SELECT U&'a' UESCAPE 'ab'", "invalid Unicode escape character", 21},
+		{"SELECT U&'a' UESCAPE '+'", "invalid Unicode escape character", 21},
+		{"SELECT U&'wrong: \\061'", "invalid Unicode escape", 17},
+		{"SELECT U&'wrong: \\+2FFFFF'", "invalid Unicode escape value", 17},
+		{"SELECT U&'wrong: \\db99xy'", "invalid Unicode surrogate pair", 22},
+		{"SELECT U&'wrong: \\db99'", "invalid Unicode surrogate pair", 22},
+		{`SELECT U&""`, "zero-length delimited identifier", 7},
+		{`SELECT E'wrong: \u061'`, "invalid Unicode escape", 16},
+		{`SELECT E'wrong: \U002FFFFF'`, "invalid Unicode escape value", 16},
+		{`SELECT E'wrong: \udb99xy'`, "invalid Unicode surrogate pair", 22},
+		{`SELECT E'wrong: \udb99\u0061'`, "invalid Unicode surrogate pair", 22},
+		{`SELECT E'wrong: \udc00'`, "invalid Unicode surrogate pair", 16},
+		{"SELECT 100_", "trailing junk after numeric literal", 7},
+		{"SELECT 1_000._5", "trailing junk after numeric literal", 7},
+		{"SELECT 0x_", "invalid hexadecimal integer", 7},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.input)
+		perr, ok := err.(*ParseError)
+		if !ok || perr.Message != tt.err || perr.Position != tt.pos {
+			t.Errorf("Parse(%q) error = %v, want %q at %d", tt.input, err, tt.err, tt.pos)
+		}
+	}
+}
+
+func TestUnicodeEscapes(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{`SELECT U&'d\0061t\+000061'`, `"data"`},
+		{`SELECT U&'d!0061t!+000061' UESCAPE '!'`, `"data"`},
+		{`SELECT U&'a!!b' /* comment */ UESCAPE '!'`, `"a!b"`},
+		{`SELECT U&'\D83D\DE00'`, "\"\U0001F600\""},
+		{`SELECT E'\uD83D\uDE00'`, "\"\U0001F600\""},
+		{`SELECT E'\u0061\U00000062'`, `"ab"`},
+		{`SELECT U&"d\0061t\+000061"`, `"data"`},
+		{`SELECT U&"A#0042" UESCAPE '#'`, `"AB"`},
+	}
+	for _, tt := range tests {
+		stmts, err := Parse(tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.input, err)
+			continue
+		}
+		var got string
+		switch v := stmts.Items[0].(*nodes.SelectStmt).TargetList.Items[0].(*nodes.ResTarget).Val.(type) {
+		case *nodes.A_Const:
+			got = strconv.Quote(v.Val.(*nodes.String).Sval)
+		case *nodes.ColumnRef:
+			got = strconv.Quote(v.Fields.Items[0].(*nodes.String).Sval)
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestIdentifierTruncation(t *testing.T) {
+	long := strings.Repeat("a", 62)
+	tests := []struct {
+		input     string
+		want      string
+		truncated bool
+	}{
+		{"SELECT " + long + "b", long + "b", false},
+		{"SELECT " + long + "bc", long + "b", true},
+		{`SELECT "` + long + `BC"`, long + "B", true},
+		{`SELECT U&"` + long + `\0042C"`, long + "B", true},
+		{"SELECT " + long + "é", long, true}, // é does not fit in the 63rd byte
+	}
+	for _, tt := range tests {
+		raws, notices, err := ParseNotices(tt.input)
+		if err != nil {
+			t.Errorf("ParseNotices(%q): %v", tt.input, err)
+			continue
+		}
+		ref := raws[0].Stmt.(*nodes.SelectStmt).TargetList.Items[0].(*nodes.ResTarget).Val.(*nodes.ColumnRef)
+		if got := ref.Fields.Items[0].(*nodes.String).Sval; got != tt.want {
+			t.Errorf("ParseNotices(%q) name = %q, want %q", tt.input, got, tt.want)
+		}
+		if tt.truncated && (len(notices) != 1 || notices[0].Position != 7 || !strings.HasSuffix(notices[0].Message, `will be truncated to "`+tt.want+`"`)) {
+			t.Errorf("ParseNotices(%q) notices = %v, want a truncation notice", tt.input, notices)
+		}
+		if !tt.truncated && len(notices) != 0 {
+			t.Errorf("ParseNotices(%q) notices = %v, want none", tt.input, notices)
+		}
+	}
+}
+
+func TestOperatorLength(t *testing.T) {
+	op := strings.Repeat("+", 62) + "!"
+	if _, err := Parse("SELECT 1 " + op + " 2"); err != nil {
+		t.Errorf("a %d-byte operator should parse: %v", len(op), err)
+	}
+	if _, err := Parse("SELECT 1 " + op + "! 2"); err == nil || err.Error() != "operator too long" {
+		t.Errorf("a %d-byte operator: error = %v, want operator too long", len(op)+1, err)
+	}
+}
+
+func TestSplitJsonBehaviorClause(t *testing.T) {
+	onError := &nodes.JsonBehavior{Btype: nodes.JSON_BEHAVIOR_NULL}
+	lex := &parserLexer{}
+	if e, o := splitJsonBehaviorClause(lex, &nodes.List{Items: []nodes.Node{nil, onError}}); e != nil || o != onError || lex.err != nil {
+		t.Errorf("split = %v, %v, error %v", e, o, lex.err)
+	}
+	for _, n := range []nodes.Node{
+		&nodes.String{Sval: "x"},
+		&nodes.List{Items: []nodes.Node{onError}},
+		&nodes.List{Items: []nodes.Node{&nodes.String{Sval: "x"}, nil}},
+	} {
+		lex := &parserLexer{}
+		splitJsonBehaviorClause(lex, n)
+		if lex.err == nil || lex.err.Error() != "syntax error" {
+			t.Errorf("split(%v) error = %v, want a syntax error", n, lex.err)
+		}
+	}
+}
+
+func TestAsJsonBehavior(t *testing.T) {
+	b := &nodes.JsonBehavior{Btype: nodes.JSON_BEHAVIOR_ERROR}
+	lex := &parserLexer{}
+	if got := asJsonBehavior(lex, b); got != b || lex.err != nil {
+		t.Errorf("asJsonBehavior = %v, error %v", got, lex.err)
+	}
+	if got := asJsonBehavior(lex, &nodes.String{Sval: "x"}); got != nil || lex.err == nil || lex.err.Error() != "syntax error" {
+		t.Errorf("asJsonBehavior(String) = %v, error %v, want a syntax error", got, lex.err)
+	}
+}
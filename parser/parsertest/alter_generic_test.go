@@ -133,7 +133,7 @@ func TestAlterTableOwnerTo(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.AlterTableCmd, got %T", stmt.Cmds.Items[0])
 	}
-	if cmd.Subtype != int(nodes.AT_ChangeOwner) {
+	if cmd.Subtype != nodes.AT_ChangeOwner {
 		t.Errorf("expected AT_ChangeOwner, got %d", cmd.Subtype)
 	}
 	if cmd.Newowner == nil || cmd.Newowner.Rolename != "new_owner" {
@@ -225,8 +225,7 @@ func TestAlterFunctionDependsOnExtension(t *testing.T) {
 	if stmt.Remove {
 		t.Error("expected Remove to be false")
 	}
-	extStr, ok := stmt.Extname.(*nodes.String)
-	if !ok || extStr.Str != "ext" {
+	if stmt.Extname == nil || stmt.Extname.Sval != "ext" {
 		t.Error("expected extname 'ext'")
 	}
 }
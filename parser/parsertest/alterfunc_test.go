@@ -35,8 +35,8 @@ func TestAlterFunctionImmutable(t *testing.T) {
 	if stmt.Func.Objname == nil || len(stmt.Func.Objname.Items) != 1 {
 		t.Fatal("expected 1 name component")
 	}
-	if stmt.Func.Objname.Items[0].(*nodes.String).Str != "func" {
-		t.Errorf("expected 'func', got %q", stmt.Func.Objname.Items[0].(*nodes.String).Str)
+	if stmt.Func.Objname.Items[0].(*nodes.String).Sval != "func" {
+		t.Errorf("expected 'func', got %q", stmt.Func.Objname.Items[0].(*nodes.String).Sval)
 	}
 	if stmt.Actions == nil || len(stmt.Actions.Items) != 1 {
 		t.Fatal("expected 1 action")
@@ -130,10 +130,10 @@ func TestDropFunction(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.DropStmt, got %T", result.Items[0])
 	}
-	if stmt.RemoveType != int(nodes.OBJECT_FUNCTION) {
+	if stmt.RemoveType != nodes.OBJECT_FUNCTION {
 		t.Errorf("expected OBJECT_FUNCTION (%d), got %d", nodes.OBJECT_FUNCTION, stmt.RemoveType)
 	}
-	if stmt.Missing_ok {
+	if stmt.MissingOk {
 		t.Error("expected Missing_ok to be false")
 	}
 }
@@ -146,7 +146,7 @@ func TestDropFunctionIfExists(t *testing.T) {
 		t.Fatalf("Parse error: %v", err)
 	}
 	stmt := result.Items[0].(*nodes.DropStmt)
-	if !stmt.Missing_ok {
+	if !stmt.MissingOk {
 		t.Error("expected Missing_ok to be true")
 	}
 }
@@ -159,7 +159,7 @@ func TestDropFunctionCascade(t *testing.T) {
 		t.Fatalf("Parse error: %v", err)
 	}
 	stmt := result.Items[0].(*nodes.DropStmt)
-	if stmt.Behavior != int(nodes.DROP_CASCADE) {
+	if stmt.Behavior != nodes.DROP_CASCADE {
 		t.Errorf("expected DROP_CASCADE (%d), got %d", nodes.DROP_CASCADE, stmt.Behavior)
 	}
 }
@@ -172,7 +172,7 @@ func TestDropFunctionTwoArgs(t *testing.T) {
 		t.Fatalf("Parse error: %v", err)
 	}
 	stmt := result.Items[0].(*nodes.DropStmt)
-	if stmt.RemoveType != int(nodes.OBJECT_FUNCTION) {
+	if stmt.RemoveType != nodes.OBJECT_FUNCTION {
 		t.Errorf("expected OBJECT_FUNCTION, got %d", stmt.RemoveType)
 	}
 	if stmt.Objects == nil || len(stmt.Objects.Items) != 1 {
@@ -192,7 +192,7 @@ func TestDropProcedure(t *testing.T) {
 		t.Fatalf("Parse error: %v", err)
 	}
 	stmt := result.Items[0].(*nodes.DropStmt)
-	if stmt.RemoveType != int(nodes.OBJECT_PROCEDURE) {
+	if stmt.RemoveType != nodes.OBJECT_PROCEDURE {
 		t.Errorf("expected OBJECT_PROCEDURE (%d), got %d", nodes.OBJECT_PROCEDURE, stmt.RemoveType)
 	}
 }
@@ -212,7 +212,7 @@ func TestDropAggregate(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.DropStmt, got %T", result.Items[0])
 	}
-	if stmt.RemoveType != int(nodes.OBJECT_AGGREGATE) {
+	if stmt.RemoveType != nodes.OBJECT_AGGREGATE {
 		t.Errorf("expected OBJECT_AGGREGATE (%d), got %d", nodes.OBJECT_AGGREGATE, stmt.RemoveType)
 	}
 }
@@ -225,10 +225,10 @@ func TestDropAggregateIfExistsCascade(t *testing.T) {
 		t.Fatalf("Parse error: %v", err)
 	}
 	stmt := result.Items[0].(*nodes.DropStmt)
-	if !stmt.Missing_ok {
+	if !stmt.MissingOk {
 		t.Error("expected Missing_ok to be true")
 	}
-	if stmt.Behavior != int(nodes.DROP_CASCADE) {
+	if stmt.Behavior != nodes.DROP_CASCADE {
 		t.Errorf("expected DROP_CASCADE, got %d", stmt.Behavior)
 	}
 }
@@ -248,7 +248,7 @@ func TestDropOperator(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.DropStmt, got %T", result.Items[0])
 	}
-	if stmt.RemoveType != int(nodes.OBJECT_OPERATOR) {
+	if stmt.RemoveType != nodes.OBJECT_OPERATOR {
 		t.Errorf("expected OBJECT_OPERATOR (%d), got %d", nodes.OBJECT_OPERATOR, stmt.RemoveType)
 	}
 }
@@ -261,13 +261,13 @@ func TestDropOperatorIfExistsCascade(t *testing.T) {
 		t.Fatalf("Parse error: %v", err)
 	}
 	stmt := result.Items[0].(*nodes.DropStmt)
-	if !stmt.Missing_ok {
+	if !stmt.MissingOk {
 		t.Error("expected Missing_ok to be true")
 	}
-	if stmt.Behavior != int(nodes.DROP_CASCADE) {
+	if stmt.Behavior != nodes.DROP_CASCADE {
 		t.Errorf("expected DROP_CASCADE, got %d", stmt.Behavior)
 	}
-	if stmt.RemoveType != int(nodes.OBJECT_OPERATOR) {
+	if stmt.RemoveType != nodes.OBJECT_OPERATOR {
 		t.Errorf("expected OBJECT_OPERATOR, got %d", stmt.RemoveType)
 	}
 }
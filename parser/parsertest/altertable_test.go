@@ -28,10 +28,10 @@ func TestAlterTableAddColumn(t *testing.T) {
 	if stmt.Relation == nil || stmt.Relation.Relname != "t" {
 		t.Fatalf("expected table name 't'")
 	}
-	if stmt.ObjType != int(nodes.OBJECT_TABLE) {
-		t.Errorf("expected ObjType OBJECT_TABLE, got %d", stmt.ObjType)
+	if stmt.Objtype != nodes.OBJECT_TABLE {
+		t.Errorf("expected ObjType OBJECT_TABLE, got %d", stmt.Objtype)
 	}
-	if stmt.Missing_ok {
+	if stmt.MissingOk {
 		t.Error("expected Missing_ok to be false")
 	}
 
@@ -43,7 +43,7 @@ func TestAlterTableAddColumn(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.AlterTableCmd, got %T", stmt.Cmds.Items[0])
 	}
-	if cmd.Subtype != int(nodes.AT_AddColumn) {
+	if cmd.Subtype != nodes.AT_AddColumn {
 		t.Errorf("expected AT_AddColumn, got %d", cmd.Subtype)
 	}
 	if cmd.Def == nil {
@@ -69,7 +69,7 @@ func TestAlterTableAddColumnWithoutKeyword(t *testing.T) {
 
 	stmt := result.Items[0].(*nodes.AlterTableStmt)
 	cmd := stmt.Cmds.Items[0].(*nodes.AlterTableCmd)
-	if cmd.Subtype != int(nodes.AT_AddColumn) {
+	if cmd.Subtype != nodes.AT_AddColumn {
 		t.Errorf("expected AT_AddColumn, got %d", cmd.Subtype)
 	}
 	coldef := cmd.Def.(*nodes.ColumnDef)
@@ -89,10 +89,10 @@ func TestAlterTableAddColumnIfNotExists(t *testing.T) {
 
 	stmt := result.Items[0].(*nodes.AlterTableStmt)
 	cmd := stmt.Cmds.Items[0].(*nodes.AlterTableCmd)
-	if cmd.Subtype != int(nodes.AT_AddColumn) {
+	if cmd.Subtype != nodes.AT_AddColumn {
 		t.Errorf("expected AT_AddColumn, got %d", cmd.Subtype)
 	}
-	if !cmd.Missing_ok {
+	if !cmd.MissingOk {
 		t.Error("expected Missing_ok to be true")
 	}
 	coldef := cmd.Def.(*nodes.ColumnDef)
@@ -112,13 +112,13 @@ func TestAlterTableDropColumn(t *testing.T) {
 
 	stmt := result.Items[0].(*nodes.AlterTableStmt)
 	cmd := stmt.Cmds.Items[0].(*nodes.AlterTableCmd)
-	if cmd.Subtype != int(nodes.AT_DropColumn) {
+	if cmd.Subtype != nodes.AT_DropColumn {
 		t.Errorf("expected AT_DropColumn, got %d", cmd.Subtype)
 	}
 	if cmd.Name != "name" {
 		t.Errorf("expected column name 'name', got %q", cmd.Name)
 	}
-	if cmd.Behavior != int(nodes.DROP_RESTRICT) {
+	if cmd.Behavior != nodes.DROP_RESTRICT {
 		t.Errorf("expected DROP_RESTRICT (default), got %d", cmd.Behavior)
 	}
 }
@@ -134,16 +134,16 @@ func TestAlterTableDropColumnIfExistsCascade(t *testing.T) {
 
 	stmt := result.Items[0].(*nodes.AlterTableStmt)
 	cmd := stmt.Cmds.Items[0].(*nodes.AlterTableCmd)
-	if cmd.Subtype != int(nodes.AT_DropColumn) {
+	if cmd.Subtype != nodes.AT_DropColumn {
 		t.Errorf("expected AT_DropColumn, got %d", cmd.Subtype)
 	}
 	if cmd.Name != "name" {
 		t.Errorf("expected column name 'name', got %q", cmd.Name)
 	}
-	if !cmd.Missing_ok {
+	if !cmd.MissingOk {
 		t.Error("expected Missing_ok to be true")
 	}
-	if cmd.Behavior != int(nodes.DROP_CASCADE) {
+	if cmd.Behavior != nodes.DROP_CASCADE {
 		t.Errorf("expected DROP_CASCADE, got %d", cmd.Behavior)
 	}
 }
@@ -159,7 +159,7 @@ func TestAlterTableAlterColumnSetDefault(t *testing.T) {
 
 	stmt := result.Items[0].(*nodes.AlterTableStmt)
 	cmd := stmt.Cmds.Items[0].(*nodes.AlterTableCmd)
-	if cmd.Subtype != int(nodes.AT_ColumnDefault) {
+	if cmd.Subtype != nodes.AT_ColumnDefault {
 		t.Errorf("expected AT_ColumnDefault, got %d", cmd.Subtype)
 	}
 	if cmd.Name != "name" {
@@ -176,8 +176,8 @@ func TestAlterTableAlterColumnSetDefault(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String, got %T", aConst.Val)
 	}
-	if strVal.Str != "unknown" {
-		t.Errorf("expected 'unknown', got %q", strVal.Str)
+	if strVal.Sval != "unknown" {
+		t.Errorf("expected 'unknown', got %q", strVal.Sval)
 	}
 }
 
@@ -192,7 +192,7 @@ func TestAlterTableAlterColumnDropDefault(t *testing.T) {
 
 	stmt := result.Items[0].(*nodes.AlterTableStmt)
 	cmd := stmt.Cmds.Items[0].(*nodes.AlterTableCmd)
-	if cmd.Subtype != int(nodes.AT_ColumnDefault) {
+	if cmd.Subtype != nodes.AT_ColumnDefault {
 		t.Errorf("expected AT_ColumnDefault, got %d", cmd.Subtype)
 	}
 	if cmd.Name != "name" {
@@ -214,7 +214,7 @@ func TestAlterTableAlterColumnSetNotNull(t *testing.T) {
 
 	stmt := result.Items[0].(*nodes.AlterTableStmt)
 	cmd := stmt.Cmds.Items[0].(*nodes.AlterTableCmd)
-	if cmd.Subtype != int(nodes.AT_SetNotNull) {
+	if cmd.Subtype != nodes.AT_SetNotNull {
 		t.Errorf("expected AT_SetNotNull, got %d", cmd.Subtype)
 	}
 	if cmd.Name != "name" {
@@ -233,7 +233,7 @@ func TestAlterTableAlterColumnDropNotNull(t *testing.T) {
 
 	stmt := result.Items[0].(*nodes.AlterTableStmt)
 	cmd := stmt.Cmds.Items[0].(*nodes.AlterTableCmd)
-	if cmd.Subtype != int(nodes.AT_DropNotNull) {
+	if cmd.Subtype != nodes.AT_DropNotNull {
 		t.Errorf("expected AT_DropNotNull, got %d", cmd.Subtype)
 	}
 	if cmd.Name != "name" {
@@ -252,7 +252,7 @@ func TestAlterTableAlterColumnType(t *testing.T) {
 
 	stmt := result.Items[0].(*nodes.AlterTableStmt)
 	cmd := stmt.Cmds.Items[0].(*nodes.AlterTableCmd)
-	if cmd.Subtype != int(nodes.AT_AlterColumnType) {
+	if cmd.Subtype != nodes.AT_AlterColumnType {
 		t.Errorf("expected AT_AlterColumnType, got %d", cmd.Subtype)
 	}
 	if cmd.Name != "name" {
@@ -281,7 +281,7 @@ func TestAlterTableAddConstraint(t *testing.T) {
 
 	stmt := result.Items[0].(*nodes.AlterTableStmt)
 	cmd := stmt.Cmds.Items[0].(*nodes.AlterTableCmd)
-	if cmd.Subtype != int(nodes.AT_AddConstraint) {
+	if cmd.Subtype != nodes.AT_AddConstraint {
 		t.Errorf("expected AT_AddConstraint, got %d", cmd.Subtype)
 	}
 	if cmd.Def == nil {
@@ -310,13 +310,13 @@ func TestAlterTableDropConstraint(t *testing.T) {
 
 	stmt := result.Items[0].(*nodes.AlterTableStmt)
 	cmd := stmt.Cmds.Items[0].(*nodes.AlterTableCmd)
-	if cmd.Subtype != int(nodes.AT_DropConstraint) {
+	if cmd.Subtype != nodes.AT_DropConstraint {
 		t.Errorf("expected AT_DropConstraint, got %d", cmd.Subtype)
 	}
 	if cmd.Name != "pk_id" {
 		t.Errorf("expected constraint name 'pk_id', got %q", cmd.Name)
 	}
-	if cmd.Behavior != int(nodes.DROP_RESTRICT) {
+	if cmd.Behavior != nodes.DROP_RESTRICT {
 		t.Errorf("expected DROP_RESTRICT (default), got %d", cmd.Behavior)
 	}
 }
@@ -332,16 +332,16 @@ func TestAlterTableDropConstraintIfExistsCascade(t *testing.T) {
 
 	stmt := result.Items[0].(*nodes.AlterTableStmt)
 	cmd := stmt.Cmds.Items[0].(*nodes.AlterTableCmd)
-	if cmd.Subtype != int(nodes.AT_DropConstraint) {
+	if cmd.Subtype != nodes.AT_DropConstraint {
 		t.Errorf("expected AT_DropConstraint, got %d", cmd.Subtype)
 	}
 	if cmd.Name != "pk_id" {
 		t.Errorf("expected constraint name 'pk_id', got %q", cmd.Name)
 	}
-	if !cmd.Missing_ok {
+	if !cmd.MissingOk {
 		t.Error("expected Missing_ok to be true")
 	}
-	if cmd.Behavior != int(nodes.DROP_CASCADE) {
+	if cmd.Behavior != nodes.DROP_CASCADE {
 		t.Errorf("expected DROP_CASCADE, got %d", cmd.Behavior)
 	}
 }
@@ -362,7 +362,7 @@ func TestAlterTableMultipleCommands(t *testing.T) {
 
 	// First command: ADD COLUMN a integer
 	cmd1 := stmt.Cmds.Items[0].(*nodes.AlterTableCmd)
-	if cmd1.Subtype != int(nodes.AT_AddColumn) {
+	if cmd1.Subtype != nodes.AT_AddColumn {
 		t.Errorf("cmd1: expected AT_AddColumn, got %d", cmd1.Subtype)
 	}
 	coldef := cmd1.Def.(*nodes.ColumnDef)
@@ -372,7 +372,7 @@ func TestAlterTableMultipleCommands(t *testing.T) {
 
 	// Second command: DROP COLUMN b
 	cmd2 := stmt.Cmds.Items[1].(*nodes.AlterTableCmd)
-	if cmd2.Subtype != int(nodes.AT_DropColumn) {
+	if cmd2.Subtype != nodes.AT_DropColumn {
 		t.Errorf("cmd2: expected AT_DropColumn, got %d", cmd2.Subtype)
 	}
 	if cmd2.Name != "b" {
@@ -390,7 +390,7 @@ func TestAlterTableIfNotExists(t *testing.T) {
 	}
 
 	stmt := result.Items[0].(*nodes.AlterTableStmt)
-	if !stmt.Missing_ok {
+	if !stmt.MissingOk {
 		t.Error("expected Missing_ok to be true")
 	}
 	if stmt.Relation == nil || stmt.Relation.Relname != "t" {
@@ -507,8 +507,8 @@ func TestAlterTableRenameConstraint(t *testing.T) {
 	if stmt.RenameType != nodes.OBJECT_TABCONSTRAINT {
 		t.Errorf("expected OBJECT_TABCONSTRAINT, got %d", stmt.RenameType)
 	}
-	if stmt.RelationType != nodes.OBJECT_TABLE {
-		t.Errorf("expected RelationType OBJECT_TABLE, got %d", stmt.RelationType)
+	if stmt.RelationType != 0 {
+		t.Errorf("expected RelationType unset, got %d", stmt.RelationType)
 	}
 	if stmt.Subname != "old_con" {
 		t.Errorf("expected old name 'old_con', got %q", stmt.Subname)
@@ -550,7 +550,7 @@ func TestAlterTableDropColumnRestrict(t *testing.T) {
 
 	stmt := result.Items[0].(*nodes.AlterTableStmt)
 	cmd := stmt.Cmds.Items[0].(*nodes.AlterTableCmd)
-	if cmd.Behavior != int(nodes.DROP_RESTRICT) {
+	if cmd.Behavior != nodes.DROP_RESTRICT {
 		t.Errorf("expected DROP_RESTRICT, got %d", cmd.Behavior)
 	}
 }
@@ -566,7 +566,7 @@ func TestAlterTableDropColumnCascade(t *testing.T) {
 
 	stmt := result.Items[0].(*nodes.AlterTableStmt)
 	cmd := stmt.Cmds.Items[0].(*nodes.AlterTableCmd)
-	if cmd.Behavior != int(nodes.DROP_CASCADE) {
+	if cmd.Behavior != nodes.DROP_CASCADE {
 		t.Errorf("expected DROP_CASCADE, got %d", cmd.Behavior)
 	}
 }
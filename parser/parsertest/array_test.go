@@ -91,7 +91,7 @@ func TestParseArraySubquery(t *testing.T) {
 		t.Fatalf("expected *nodes.SubLink, got %T", rt.Val)
 	}
 
-	if sublink.SubLinkType != int(nodes.ARRAY_SUBLINK) {
+	if sublink.SubLinkType != nodes.ARRAY_SUBLINK {
 		t.Errorf("expected ARRAY_SUBLINK (%d), got %d", int(nodes.ARRAY_SUBLINK), sublink.SubLinkType)
 	}
 
@@ -189,8 +189,8 @@ func TestParseArraySubscript(t *testing.T) {
 		t.Fatalf("expected *nodes.ColumnRef for Arg, got %T", indir.Arg)
 	}
 	str := colRef.Fields.Items[0].(*nodes.String)
-	if str.Str != "arr" {
-		t.Errorf("expected column 'arr', got %q", str.Str)
+	if str.Sval != "arr" {
+		t.Errorf("expected column 'arr', got %q", str.Sval)
 	}
 
 	// Indirection should have 1 A_Indices element
@@ -259,8 +259,8 @@ func TestParseArraySlice(t *testing.T) {
 		t.Fatalf("expected *nodes.ColumnRef for Arg, got %T", indir.Arg)
 	}
 	str := colRef.Fields.Items[0].(*nodes.String)
-	if str.Str != "arr" {
-		t.Errorf("expected column 'arr', got %q", str.Str)
+	if str.Sval != "arr" {
+		t.Errorf("expected column 'arr', got %q", str.Sval)
 	}
 
 	// Indirection should have 1 A_Indices element
@@ -422,8 +422,8 @@ func TestParseArrayInWhere(t *testing.T) {
 		t.Fatalf("expected *nodes.ColumnRef for Arg, got %T", indir.Arg)
 	}
 	str := colRef.Fields.Items[0].(*nodes.String)
-	if str.Str != "arr" {
-		t.Errorf("expected column 'arr', got %q", str.Str)
+	if str.Sval != "arr" {
+		t.Errorf("expected column 'arr', got %q", str.Sval)
 	}
 
 	// Indirection should have A_Indices with Uidx=1
@@ -66,8 +66,8 @@ func TestCaseSearched(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String, got %T", aConst.Val)
 	}
-	if strVal.Str != "one" {
-		t.Errorf("expected THEN result 'one', got %q", strVal.Str)
+	if strVal.Sval != "one" {
+		t.Errorf("expected THEN result 'one', got %q", strVal.Sval)
 	}
 
 	// Defresult should be non-nil (ELSE clause)
@@ -82,8 +82,8 @@ func TestCaseSearched(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String for ELSE value, got %T", defConst.Val)
 	}
-	if defStr.Str != "other" {
-		t.Errorf("expected ELSE result 'other', got %q", defStr.Str)
+	if defStr.Sval != "other" {
+		t.Errorf("expected ELSE result 'other', got %q", defStr.Sval)
 	}
 }
 
@@ -119,8 +119,8 @@ func TestCaseSimple(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String, got %T", colRef.Fields.Items[0])
 	}
-	if str.Str != "status" {
-		t.Errorf("expected Arg column 'status', got %q", str.Str)
+	if str.Sval != "status" {
+		t.Errorf("expected Arg column 'status', got %q", str.Sval)
 	}
 
 	// Should have 2 WHEN clauses
@@ -193,7 +193,7 @@ func TestCaseMultipleWhen(t *testing.T) {
 	if aexpr1.Name == nil || len(aexpr1.Name.Items) == 0 {
 		t.Fatal("expected operator name in A_Expr")
 	}
-	opStr1 := aexpr1.Name.Items[0].(*nodes.String).Str
+	opStr1 := aexpr1.Name.Items[0].(*nodes.String).Sval
 	if opStr1 != ">" {
 		t.Errorf("expected '>' operator, got %q", opStr1)
 	}
@@ -204,7 +204,7 @@ func TestCaseMultipleWhen(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.A_Expr for second WHEN, got %T", cw2.Expr)
 	}
-	opStr2 := aexpr2.Name.Items[0].(*nodes.String).Str
+	opStr2 := aexpr2.Name.Items[0].(*nodes.String).Sval
 	if opStr2 != "<" {
 		t.Errorf("expected '<' operator, got %q", opStr2)
 	}
@@ -221,8 +221,8 @@ func TestCaseMultipleWhen(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String for ELSE value, got %T", defConst.Val)
 	}
-	if defStr.Str != "zero" {
-		t.Errorf("expected ELSE result 'zero', got %q", defStr.Str)
+	if defStr.Sval != "zero" {
+		t.Errorf("expected ELSE result 'zero', got %q", defStr.Sval)
 	}
 }
 
@@ -301,8 +301,8 @@ func TestCaseNested(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String, got %T", innerDefConst.Val)
 	}
-	if innerDefStr.Str != "b" {
-		t.Errorf("expected inner ELSE 'b', got %q", innerDefStr.Str)
+	if innerDefStr.Sval != "b" {
+		t.Errorf("expected inner ELSE 'b', got %q", innerDefStr.Sval)
 	}
 
 	// Outer CASE should have ELSE 'c'
@@ -317,8 +317,8 @@ func TestCaseNested(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String, got %T", outerDefConst.Val)
 	}
-	if outerDefStr.Str != "c" {
-		t.Errorf("expected outer ELSE 'c', got %q", outerDefStr.Str)
+	if outerDefStr.Sval != "c" {
+		t.Errorf("expected outer ELSE 'c', got %q", outerDefStr.Sval)
 	}
 }
 
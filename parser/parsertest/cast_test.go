@@ -103,7 +103,7 @@ func TestDropCast(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.DropStmt, got %T", result.Items[0])
 	}
-	if stmt.RemoveType != int(nodes.OBJECT_CAST) {
+	if stmt.RemoveType != nodes.OBJECT_CAST {
 		t.Errorf("expected OBJECT_CAST, got %d", stmt.RemoveType)
 	}
 }
@@ -115,10 +115,10 @@ func TestDropCastIfExists(t *testing.T) {
 		t.Fatalf("Parse error: %v", err)
 	}
 	stmt := result.Items[0].(*nodes.DropStmt)
-	if !stmt.Missing_ok {
+	if !stmt.MissingOk {
 		t.Error("expected Missing_ok to be true")
 	}
-	if stmt.Behavior != int(nodes.DROP_CASCADE) {
+	if stmt.Behavior != nodes.DROP_CASCADE {
 		t.Errorf("expected DROP_CASCADE, got %d", stmt.Behavior)
 	}
 }
@@ -195,7 +195,7 @@ func TestDropTransform(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.DropStmt, got %T", result.Items[0])
 	}
-	if stmt.RemoveType != int(nodes.OBJECT_TRANSFORM) {
+	if stmt.RemoveType != nodes.OBJECT_TRANSFORM {
 		t.Errorf("expected OBJECT_TRANSFORM, got %d", stmt.RemoveType)
 	}
 }
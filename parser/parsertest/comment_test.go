@@ -98,6 +98,42 @@ func TestCommentOnDatabase(t *testing.T) {
 	}
 }
 
+func TestCommentOnExtensionAndLanguage(t *testing.T) {
+	tests := []struct {
+		sql     string
+		objtype nodes.ObjectType
+	}{
+		{"COMMENT ON EXTENSION plpgsql IS 'PL/pgSQL procedural language'", nodes.OBJECT_EXTENSION},
+		{"COMMENT ON LANGUAGE plpgsql IS 'x'", nodes.OBJECT_LANGUAGE},
+		{"COMMENT ON PROCEDURAL LANGUAGE plpgsql IS 'x'", nodes.OBJECT_LANGUAGE},
+	}
+	for _, tt := range tests {
+		stmt := parseStmt(t, tt.sql)
+		cs, ok := stmt.(*nodes.CommentStmt)
+		if !ok {
+			t.Fatalf("%s: expected *nodes.CommentStmt, got %T", tt.sql, stmt)
+		}
+		if cs.Objtype != tt.objtype {
+			t.Errorf("%s: expected object type %d, got %d", tt.sql, tt.objtype, cs.Objtype)
+		}
+		if s, ok := cs.Object.(*nodes.String); !ok || s.Sval != "plpgsql" {
+			t.Errorf("%s: expected object String plpgsql, got %#v", tt.sql, cs.Object)
+		}
+	}
+}
+
+func TestCommentOnFunctionSkipsOutArgs(t *testing.T) {
+	stmt := parseStmt(t, "COMMENT ON FUNCTION f(a integer, OUT b text) IS 'x'")
+	cs := stmt.(*nodes.CommentStmt)
+	owa, ok := cs.Object.(*nodes.ObjectWithArgs)
+	if !ok {
+		t.Fatalf("expected *nodes.ObjectWithArgs, got %T", cs.Object)
+	}
+	if owa.Objargs == nil || len(owa.Objargs.Items) != 1 {
+		t.Fatalf("expected 1 argument type, got %v", owa.Objargs)
+	}
+}
+
 /*****************************************************************************
  *
  *      SECURITY LABEL tests
@@ -0,0 +1,139 @@
+package parsertest
+
+import (
+	"testing"
+
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+)
+
+// =============================================================================
+// Constructs the compatibility notes listed as missing
+// =============================================================================
+
+func parseTarget(t *testing.T, input string) *nodes.ResTarget {
+	t.Helper()
+	result, err := parser.Parse(input)
+	if err != nil {
+		t.Fatalf("Parse(%q) error: %v", input, err)
+	}
+	stmt := result.Items[0].(*nodes.SelectStmt)
+	return stmt.TargetList.Items[0].(*nodes.ResTarget)
+}
+
+// stringList returns the values of a list of String nodes.
+func stringList(l *nodes.List) []string {
+	var out []string
+	if l == nil {
+		return out
+	}
+	for _, item := range l.Items {
+		out = append(out, item.(*nodes.String).Sval)
+	}
+	return out
+}
+
+func TestTreat(t *testing.T) {
+	fc, ok := parseTarget(t, "SELECT TREAT(a AS int)").Val.(*nodes.FuncCall)
+	if !ok {
+		t.Fatal("expected *nodes.FuncCall")
+	}
+	if got := stringList(fc.Funcname); len(got) != 2 || got[0] != "pg_catalog" || got[1] != "int4" {
+		t.Errorf("expected funcname pg_catalog.int4, got %v", got)
+	}
+	if fc.Args == nil || len(fc.Args.Items) != 1 {
+		t.Error("expected 1 argument")
+	}
+}
+
+func TestQualifiedOperatorAnyAll(t *testing.T) {
+	tests := []struct {
+		input string
+		kind  nodes.A_Expr_Kind
+	}{
+		{"SELECT a OPERATOR(pg_catalog.=) ANY (ARRAY[1, 2])", nodes.AEXPR_OP_ANY},
+		{"SELECT a OPERATOR(pg_catalog.<) ALL (ARRAY[1, 2])", nodes.AEXPR_OP_ALL},
+	}
+	for _, tt := range tests {
+		expr, ok := parseTarget(t, tt.input).Val.(*nodes.A_Expr)
+		if !ok {
+			t.Fatalf("%s: expected *nodes.A_Expr", tt.input)
+		}
+		if expr.Kind != tt.kind {
+			t.Errorf("%s: expected kind %d, got %d", tt.input, tt.kind, expr.Kind)
+		}
+		if got := stringList(expr.Name); len(got) != 2 || got[0] != "pg_catalog" {
+			t.Errorf("%s: expected a qualified operator name, got %v", tt.input, got)
+		}
+	}
+
+	sub, ok := parseTarget(t, "SELECT a OPERATOR(pg_catalog.=) ANY (SELECT 1)").Val.(*nodes.SubLink)
+	if !ok {
+		t.Fatal("expected *nodes.SubLink")
+	}
+	if sub.SubLinkType != nodes.ANY_SUBLINK || len(stringList(sub.OperName)) != 2 {
+		t.Errorf("expected ANY sublink with a qualified operator, got type %d and %v", sub.SubLinkType, stringList(sub.OperName))
+	}
+}
+
+func TestSubqueryIndirection(t *testing.T) {
+	for _, input := range []string{"SELECT (SELECT ARRAY[1, 2])[1]", "SELECT (SELECT r FROM t).f", "SELECT (SELECT r FROM t).*"} {
+		ind, ok := parseTarget(t, input).Val.(*nodes.A_Indirection)
+		if !ok {
+			t.Fatalf("%s: expected *nodes.A_Indirection", input)
+		}
+		if _, ok := ind.Arg.(*nodes.SubLink); !ok {
+			t.Errorf("%s: expected a SubLink argument, got %T", input, ind.Arg)
+		}
+	}
+}
+
+func TestNationalCharacter(t *testing.T) {
+	input := "CREATE TABLE t (a NATIONAL CHAR(3), b NCHAR, c NCHAR VARYING(4), d NATIONAL CHARACTER VARYING)"
+	result, err := parser.Parse(input)
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	stmt := result.Items[0].(*nodes.CreateStmt)
+	want := []string{"bpchar", "bpchar", "varchar", "varchar"}
+	for i, elt := range stmt.TableElts.Items {
+		names := stringList(elt.(*nodes.ColumnDef).TypeName.Names)
+		if got := names[len(names)-1]; got != want[i] {
+			t.Errorf("column %d: expected type %s, got %s", i, want[i], got)
+		}
+	}
+}
+
+func TestBareColumnLabel(t *testing.T) {
+	tests := []struct {
+		input, name string
+	}{
+		{"SELECT 1 a", "a"},
+		{"SELECT 'regtype'::regtype type", "type"},
+		{"SELECT 1 name", "name"},
+		{"SELECT 1 select", "select"},
+	}
+	for _, tt := range tests {
+		if got := parseTarget(t, tt.input).Name; got != tt.name {
+			t.Errorf("%s: expected label %q, got %q", tt.input, tt.name, got)
+		}
+	}
+
+	// Keywords that could continue the expression need AS.
+	for _, input := range []string{"SELECT 1 year", "SELECT 1 over", "SELECT 1 with"} {
+		if _, err := parser.Parse(input); err == nil {
+			t.Errorf("%s: expected a syntax error", input)
+		}
+	}
+	if _, ok := parseTarget(t, "SELECT 1 AND 2").Val.(*nodes.BoolExpr); !ok {
+		t.Error("SELECT 1 AND 2: expected AND to be an operator, not a label")
+	}
+}
+
+func TestUniquePredicate(t *testing.T) {
+	// PostgreSQL rejects the predicate in the grammar with this error.
+	_, err := parser.Parse("SELECT UNIQUE (SELECT 1)")
+	if err == nil || err.Error() != "UNIQUE predicate is not yet implemented" {
+		t.Errorf("expected the not-implemented error, got %v", err)
+	}
+}
@@ -89,15 +89,15 @@ func TestCopyWithColumns(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String, got %T", stmt.Attlist.Items[0])
 	}
-	if col1.Str != "col1" {
-		t.Errorf("expected column 'col1', got %q", col1.Str)
+	if col1.Sval != "col1" {
+		t.Errorf("expected column 'col1', got %q", col1.Sval)
 	}
 	col2, ok := stmt.Attlist.Items[1].(*nodes.String)
 	if !ok {
 		t.Fatalf("expected *nodes.String, got %T", stmt.Attlist.Items[1])
 	}
-	if col2.Str != "col2" {
-		t.Errorf("expected column 'col2', got %q", col2.Str)
+	if col2.Sval != "col2" {
+		t.Errorf("expected column 'col2', got %q", col2.Sval)
 	}
 }
 
@@ -164,8 +164,8 @@ func TestCopyFromWithOptions(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String arg, got %T", de.Arg)
 	}
-	if strArg.Str != "csv" {
-		t.Errorf("expected format arg 'csv', got %q", strArg.Str)
+	if strArg.Sval != "csv" {
+		t.Errorf("expected format arg 'csv', got %q", strArg.Sval)
 	}
 }
 
@@ -42,13 +42,13 @@ func findDefElem(opts *nodes.List, name string) *nodes.DefElem {
 func TestCreateFunctionBasic(t *testing.T) {
 	stmt := parseCreateFunctionStmt(t, "CREATE FUNCTION foo() RETURNS void LANGUAGE sql AS 'SELECT 1'")
 
-	if stmt.IsOrReplace {
+	if stmt.Replace {
 		t.Error("expected IsOrReplace to be false")
 	}
 	if stmt.Funcname == nil || len(stmt.Funcname.Items) != 1 {
 		t.Fatalf("expected 1 name component, got %v", stmt.Funcname)
 	}
-	fname := stmt.Funcname.Items[0].(*nodes.String).Str
+	fname := stmt.Funcname.Items[0].(*nodes.String).Sval
 	if fname != "foo" {
 		t.Errorf("expected function name 'foo', got %q", fname)
 	}
@@ -66,8 +66,8 @@ func TestCreateFunctionBasic(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected language arg to be *nodes.String, got %T", langDef.Arg)
 	}
-	if langStr.Str != "sql" {
-		t.Errorf("expected language 'sql', got %q", langStr.Str)
+	if langStr.Sval != "sql" {
+		t.Errorf("expected language 'sql', got %q", langStr.Sval)
 	}
 	asDef := findDefElem(stmt.Options, "as")
 	if asDef == nil {
@@ -84,8 +84,8 @@ func TestCreateFunctionBasic(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected AS list item to be *nodes.String, got %T", asList.Items[0])
 	}
-	if asStr.Str != "SELECT 1" {
-		t.Errorf("expected as 'SELECT 1', got %q", asStr.Str)
+	if asStr.Sval != "SELECT 1" {
+		t.Errorf("expected as 'SELECT 1', got %q", asStr.Sval)
 	}
 }
 
@@ -96,7 +96,7 @@ func TestCreateFunctionWithParams(t *testing.T) {
 	if stmt.Funcname == nil || len(stmt.Funcname.Items) != 1 {
 		t.Fatalf("expected 1 name component, got %v", stmt.Funcname)
 	}
-	fname := stmt.Funcname.Items[0].(*nodes.String).Str
+	fname := stmt.Funcname.Items[0].(*nodes.String).Sval
 	if fname != "add" {
 		t.Errorf("expected function name 'add', got %q", fname)
 	}
@@ -112,8 +112,8 @@ func TestCreateFunctionWithParams(t *testing.T) {
 	if p1.ArgType == nil {
 		t.Fatal("expected param 1 type to be set")
 	}
-	if p1.Mode != nodes.FUNC_PARAM_IN {
-		t.Errorf("expected param 1 mode IN (%d), got %d", nodes.FUNC_PARAM_IN, p1.Mode)
+	if p1.Mode != nodes.FUNC_PARAM_DEFAULT {
+		t.Errorf("expected param 1 mode DEFAULT (%d), got %d", nodes.FUNC_PARAM_DEFAULT, p1.Mode)
 	}
 
 	// Check second parameter
@@ -124,8 +124,8 @@ func TestCreateFunctionWithParams(t *testing.T) {
 	if p2.ArgType == nil {
 		t.Fatal("expected param 2 type to be set")
 	}
-	if p2.Mode != nodes.FUNC_PARAM_IN {
-		t.Errorf("expected param 2 mode IN (%d), got %d", nodes.FUNC_PARAM_IN, p2.Mode)
+	if p2.Mode != nodes.FUNC_PARAM_DEFAULT {
+		t.Errorf("expected param 2 mode DEFAULT (%d), got %d", nodes.FUNC_PARAM_DEFAULT, p2.Mode)
 	}
 
 	if stmt.ReturnType == nil {
@@ -147,8 +147,8 @@ func TestCreateFunctionUnnamedParam(t *testing.T) {
 	if p1.ArgType == nil {
 		t.Fatal("expected param type to be set")
 	}
-	if p1.Mode != nodes.FUNC_PARAM_IN {
-		t.Errorf("expected param mode IN (%d), got %d", nodes.FUNC_PARAM_IN, p1.Mode)
+	if p1.Mode != nodes.FUNC_PARAM_DEFAULT {
+		t.Errorf("expected param mode DEFAULT (%d), got %d", nodes.FUNC_PARAM_DEFAULT, p1.Mode)
 	}
 }
 
@@ -156,13 +156,13 @@ func TestCreateFunctionUnnamedParam(t *testing.T) {
 func TestCreateOrReplaceFunction(t *testing.T) {
 	stmt := parseCreateFunctionStmt(t, "CREATE OR REPLACE FUNCTION foo() RETURNS void LANGUAGE sql AS 'SELECT 1'")
 
-	if !stmt.IsOrReplace {
+	if !stmt.Replace {
 		t.Error("expected IsOrReplace to be true")
 	}
 	if stmt.Funcname == nil || len(stmt.Funcname.Items) != 1 {
 		t.Fatalf("expected 1 name component, got %v", stmt.Funcname)
 	}
-	fname := stmt.Funcname.Items[0].(*nodes.String).Str
+	fname := stmt.Funcname.Items[0].(*nodes.String).Sval
 	if fname != "foo" {
 		t.Errorf("expected function name 'foo', got %q", fname)
 	}
@@ -222,8 +222,8 @@ func TestCreateFunctionImmutable(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected volatility arg to be *nodes.String, got %T", volDef.Arg)
 	}
-	if volStr.Str != "immutable" {
-		t.Errorf("expected volatility 'immutable', got %q", volStr.Str)
+	if volStr.Sval != "immutable" {
+		t.Errorf("expected volatility 'immutable', got %q", volStr.Sval)
 	}
 }
 
@@ -235,12 +235,12 @@ func TestCreateFunctionStrict(t *testing.T) {
 	if strictDef == nil {
 		t.Fatal("expected strict option")
 	}
-	strictVal, ok := strictDef.Arg.(*nodes.Integer)
+	strictVal, ok := strictDef.Arg.(*nodes.Boolean)
 	if !ok {
-		t.Fatalf("expected strict arg to be *nodes.Integer, got %T", strictDef.Arg)
+		t.Fatalf("expected strict arg to be *nodes.Boolean, got %T", strictDef.Arg)
 	}
-	if strictVal.Ival != 1 {
-		t.Errorf("expected strict value 1, got %d", strictVal.Ival)
+	if !strictVal.Boolval {
+		t.Errorf("expected strict value true, got %v", strictVal.Boolval)
 	}
 }
 
@@ -252,12 +252,12 @@ func TestCreateFunctionSecurityDefiner(t *testing.T) {
 	if secDef == nil {
 		t.Fatal("expected security option")
 	}
-	secVal, ok := secDef.Arg.(*nodes.Integer)
+	secVal, ok := secDef.Arg.(*nodes.Boolean)
 	if !ok {
-		t.Fatalf("expected security arg to be *nodes.Integer, got %T", secDef.Arg)
+		t.Fatalf("expected security arg to be *nodes.Boolean, got %T", secDef.Arg)
 	}
-	if secVal.Ival != 1 {
-		t.Errorf("expected security value 1 (DEFINER), got %d", secVal.Ival)
+	if !secVal.Boolval {
+		t.Errorf("expected security value true (DEFINER), got %v", secVal.Boolval)
 	}
 }
 
@@ -265,30 +265,59 @@ func TestCreateFunctionSecurityDefiner(t *testing.T) {
 func TestCreateProcedure(t *testing.T) {
 	stmt := parseCreateFunctionStmt(t, "CREATE PROCEDURE do_something() LANGUAGE sql AS 'SELECT 1'")
 
-	if stmt.IsOrReplace {
+	if stmt.Replace {
 		t.Error("expected IsOrReplace to be false")
 	}
+	if !stmt.IsProcedure {
+		t.Error("expected IsProcedure to be true")
+	}
 	if stmt.Funcname == nil || len(stmt.Funcname.Items) != 1 {
 		t.Fatalf("expected 1 name component, got %v", stmt.Funcname)
 	}
-	fname := stmt.Funcname.Items[0].(*nodes.String).Str
+	fname := stmt.Funcname.Items[0].(*nodes.String).Sval
 	if fname != "do_something" {
 		t.Errorf("expected procedure name 'do_something', got %q", fname)
 	}
 	if stmt.ReturnType != nil {
 		t.Errorf("expected nil ReturnType for procedure, got %v", stmt.ReturnType)
 	}
-	// Check isProcedure marker
-	procDef := findDefElem(stmt.Options, "isProcedure")
-	if procDef == nil {
-		t.Fatal("expected isProcedure option for procedure")
+	if d := findDefElem(stmt.Options, "isProcedure"); d != nil {
+		t.Errorf("unexpected isProcedure option %v", d)
 	}
-	procVal, ok := procDef.Arg.(*nodes.Integer)
-	if !ok {
-		t.Fatalf("expected isProcedure arg to be *nodes.Integer, got %T", procDef.Arg)
+}
+
+// TestCreateFunctionReturnsTable tests that RETURNS TABLE adds the columns
+// as TABLE parameters and returns SETOF the column type, or SETOF record
+// for several columns.
+func TestCreateFunctionReturnsTable(t *testing.T) {
+	tests := []struct {
+		input   string
+		params  int
+		retType string
+	}{
+		{"CREATE FUNCTION f(a int) RETURNS TABLE (x text) LANGUAGE sql AS 'SELECT 1'", 2, "text"},
+		{"CREATE FUNCTION f() RETURNS TABLE (x int, y text) LANGUAGE sql AS 'SELECT 1'", 2, "record"},
 	}
-	if procVal.Ival != 1 {
-		t.Errorf("expected isProcedure value 1, got %d", procVal.Ival)
+	for _, tt := range tests {
+		stmt := parseCreateFunctionStmt(t, tt.input)
+		if stmt.IsProcedure {
+			t.Errorf("%s: expected IsProcedure to be false", tt.input)
+		}
+		if stmt.Parameters == nil || len(stmt.Parameters.Items) != tt.params {
+			t.Fatalf("%s: expected %d parameters, got %v", tt.input, tt.params, stmt.Parameters)
+		}
+		last := stmt.Parameters.Items[len(stmt.Parameters.Items)-1].(*nodes.FunctionParameter)
+		if last.Mode != nodes.FUNC_PARAM_TABLE {
+			t.Errorf("%s: expected FUNC_PARAM_TABLE, got %c", tt.input, last.Mode)
+		}
+		rt := stmt.ReturnType
+		if rt == nil || !rt.Setof {
+			t.Fatalf("%s: expected a SETOF return type, got %+v", tt.input, rt)
+		}
+		names := rt.Names.Items
+		if got := names[len(names)-1].(*nodes.String).Sval; got != tt.retType {
+			t.Errorf("%s: expected return type %s, got %s", tt.input, tt.retType, got)
+		}
 	}
 }
 
@@ -300,7 +329,7 @@ func TestCreateFunctionNoReturns(t *testing.T) {
 	if stmt.Funcname == nil || len(stmt.Funcname.Items) != 1 {
 		t.Fatalf("expected 1 name component, got %v", stmt.Funcname)
 	}
-	fname := stmt.Funcname.Items[0].(*nodes.String).Str
+	fname := stmt.Funcname.Items[0].(*nodes.String).Sval
 	if fname != "foo" {
 		t.Errorf("expected function name 'foo', got %q", fname)
 	}
@@ -329,8 +358,8 @@ func TestCreateFunctionStableVolatile(t *testing.T) {
 			if !ok {
 				t.Fatalf("expected volatility arg to be *nodes.String, got %T", volDef.Arg)
 			}
-			if volStr.Str != tt.expected {
-				t.Errorf("expected volatility %q, got %q", tt.expected, volStr.Str)
+			if volStr.Sval != tt.expected {
+				t.Errorf("expected volatility %q, got %q", tt.expected, volStr.Sval)
 			}
 		})
 	}
@@ -344,12 +373,12 @@ func TestCreateFunctionCalledOnNullInput(t *testing.T) {
 	if strictDef == nil {
 		t.Fatal("expected strict option")
 	}
-	strictVal, ok := strictDef.Arg.(*nodes.Integer)
+	strictVal, ok := strictDef.Arg.(*nodes.Boolean)
 	if !ok {
-		t.Fatalf("expected strict arg to be *nodes.Integer, got %T", strictDef.Arg)
+		t.Fatalf("expected strict arg to be *nodes.Boolean, got %T", strictDef.Arg)
 	}
-	if strictVal.Ival != 0 {
-		t.Errorf("expected strict value 0 (CALLED ON NULL INPUT), got %d", strictVal.Ival)
+	if strictVal.Boolval {
+		t.Errorf("expected strict value false (CALLED ON NULL INPUT), got %v", strictVal.Boolval)
 	}
 }
 
@@ -361,12 +390,12 @@ func TestCreateFunctionReturnsNullOnNullInput(t *testing.T) {
 	if strictDef == nil {
 		t.Fatal("expected strict option")
 	}
-	strictVal, ok := strictDef.Arg.(*nodes.Integer)
+	strictVal, ok := strictDef.Arg.(*nodes.Boolean)
 	if !ok {
-		t.Fatalf("expected strict arg to be *nodes.Integer, got %T", strictDef.Arg)
+		t.Fatalf("expected strict arg to be *nodes.Boolean, got %T", strictDef.Arg)
 	}
-	if strictVal.Ival != 1 {
-		t.Errorf("expected strict value 1 (RETURNS NULL ON NULL INPUT), got %d", strictVal.Ival)
+	if !strictVal.Boolval {
+		t.Errorf("expected strict value true (RETURNS NULL ON NULL INPUT), got %v", strictVal.Boolval)
 	}
 }
 
@@ -378,12 +407,12 @@ func TestCreateFunctionSecurityInvoker(t *testing.T) {
 	if secDef == nil {
 		t.Fatal("expected security option")
 	}
-	secVal, ok := secDef.Arg.(*nodes.Integer)
+	secVal, ok := secDef.Arg.(*nodes.Boolean)
 	if !ok {
-		t.Fatalf("expected security arg to be *nodes.Integer, got %T", secDef.Arg)
+		t.Fatalf("expected security arg to be *nodes.Boolean, got %T", secDef.Arg)
 	}
-	if secVal.Ival != 0 {
-		t.Errorf("expected security value 0 (INVOKER), got %d", secVal.Ival)
+	if secVal.Boolval {
+		t.Errorf("expected security value false (INVOKER), got %v", secVal.Boolval)
 	}
 }
 
@@ -402,8 +431,8 @@ func TestCreateFunctionAsTwoStrings(t *testing.T) {
 	if len(asList.Items) != 2 {
 		t.Fatalf("expected 2 items in AS list, got %d", len(asList.Items))
 	}
-	s1 := asList.Items[0].(*nodes.String).Str
-	s2 := asList.Items[1].(*nodes.String).Str
+	s1 := asList.Items[0].(*nodes.String).Sval
+	s2 := asList.Items[1].(*nodes.String).Sval
 	if s1 != "obj_file" {
 		t.Errorf("expected first AS string 'obj_file', got %q", s1)
 	}
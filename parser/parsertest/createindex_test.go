@@ -46,8 +46,8 @@ func TestCreateIndexBasic(t *testing.T) {
 	if stmt.IfNotExists {
 		t.Error("expected IfNotExists to be false")
 	}
-	if stmt.AccessMethod != "" {
-		t.Errorf("expected empty AccessMethod, got %q", stmt.AccessMethod)
+	if stmt.AccessMethod != "btree" {
+		t.Errorf("expected the default AccessMethod btree, got %q", stmt.AccessMethod)
 	}
 	if stmt.WhereClause != nil {
 		t.Error("expected WhereClause to be nil")
@@ -269,8 +269,8 @@ func TestCreateTableColumnReferences(t *testing.T) {
 		t.Fatalf("expected 1 referenced column, got %v", constr.PkAttrs)
 	}
 	refCol := constr.PkAttrs.Items[0].(*nodes.String)
-	if refCol.Str != "id" {
-		t.Errorf("expected referenced column 'id', got %q", refCol.Str)
+	if refCol.Sval != "id" {
+		t.Errorf("expected referenced column 'id', got %q", refCol.Sval)
 	}
 }
 
@@ -300,8 +300,8 @@ func TestCreateTableTablePrimaryKey(t *testing.T) {
 		t.Fatalf("expected 1 key column, got %v", constr.Keys)
 	}
 	keyCol := constr.Keys.Items[0].(*nodes.String)
-	if keyCol.Str != "id" {
-		t.Errorf("expected key column 'id', got %q", keyCol.Str)
+	if keyCol.Sval != "id" {
+		t.Errorf("expected key column 'id', got %q", keyCol.Sval)
 	}
 }
 
@@ -330,8 +330,8 @@ func TestCreateTableTableUnique(t *testing.T) {
 		t.Fatalf("expected 1 key, got %v", constr.Keys)
 	}
 	keyCol := constr.Keys.Items[0].(*nodes.String)
-	if keyCol.Str != "name" {
-		t.Errorf("expected key column 'name', got %q", keyCol.Str)
+	if keyCol.Sval != "name" {
+		t.Errorf("expected key column 'name', got %q", keyCol.Sval)
 	}
 }
 
@@ -362,8 +362,8 @@ func TestCreateTableTableForeignKey(t *testing.T) {
 		t.Fatalf("expected 1 FK column, got %v", constr.FkAttrs)
 	}
 	fkCol := constr.FkAttrs.Items[0].(*nodes.String)
-	if fkCol.Str != "id" {
-		t.Errorf("expected FK column 'id', got %q", fkCol.Str)
+	if fkCol.Sval != "id" {
+		t.Errorf("expected FK column 'id', got %q", fkCol.Sval)
 	}
 
 	// Referenced table
@@ -376,8 +376,8 @@ func TestCreateTableTableForeignKey(t *testing.T) {
 		t.Fatalf("expected 1 PK column, got %v", constr.PkAttrs)
 	}
 	pkCol := constr.PkAttrs.Items[0].(*nodes.String)
-	if pkCol.Str != "id" {
-		t.Errorf("expected PK column 'id', got %q", pkCol.Str)
+	if pkCol.Sval != "id" {
+		t.Errorf("expected PK column 'id', got %q", pkCol.Sval)
 	}
 }
 
@@ -464,8 +464,8 @@ func TestCreateTableMultipleConstraints(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String, got %T", aConst.Val)
 	}
-	if strVal.Str != "unknown" {
-		t.Errorf("expected 'unknown', got %q", strVal.Str)
+	if strVal.Sval != "unknown" {
+		t.Errorf("expected 'unknown', got %q", strVal.Sval)
 	}
 }
 
@@ -110,15 +110,15 @@ func TestCreateViewWithColumns(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String for alias 1, got %T", stmt.Aliases.Items[0])
 	}
-	if alias1.Str != "col1" {
-		t.Errorf("expected alias 1 'col1', got %q", alias1.Str)
+	if alias1.Sval != "col1" {
+		t.Errorf("expected alias 1 'col1', got %q", alias1.Sval)
 	}
 	alias2, ok := stmt.Aliases.Items[1].(*nodes.String)
 	if !ok {
 		t.Fatalf("expected *nodes.String for alias 2, got %T", stmt.Aliases.Items[1])
 	}
-	if alias2.Str != "col2" {
-		t.Errorf("expected alias 2 'col2', got %q", alias2.Str)
+	if alias2.Sval != "col2" {
+		t.Errorf("expected alias 2 'col2', got %q", alias2.Sval)
 	}
 	if stmt.Query == nil {
 		t.Fatal("expected Query to be set")
@@ -153,8 +153,8 @@ func TestCreateViewWithCheckOption(t *testing.T) {
 	if stmt.View.Relname != "v" {
 		t.Errorf("expected View.Relname 'v', got %q", stmt.View.Relname)
 	}
-	if stmt.WithCheckOption != parser.VIEW_CHECK_OPTION_LOCAL {
-		t.Errorf("expected WithCheckOption LOCAL (%d), got %d", parser.VIEW_CHECK_OPTION_LOCAL, stmt.WithCheckOption)
+	if stmt.WithCheckOption != parser.VIEW_CHECK_OPTION_CASCADED {
+		t.Errorf("expected WithCheckOption CASCADED (%d), got %d", parser.VIEW_CHECK_OPTION_CASCADED, stmt.WithCheckOption)
 	}
 	if stmt.Query == nil {
 		t.Fatal("expected Query to be set")
@@ -236,16 +236,16 @@ func TestParseCTEWithColumnNames(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String for first column name, got %T", cte.Aliascolnames.Items[0])
 	}
-	if col1.Str != "a" {
-		t.Errorf("expected first column name 'a', got %q", col1.Str)
+	if col1.Sval != "a" {
+		t.Errorf("expected first column name 'a', got %q", col1.Sval)
 	}
 
 	col2, ok := cte.Aliascolnames.Items[1].(*nodes.String)
 	if !ok {
 		t.Fatalf("expected *nodes.String for second column name, got %T", cte.Aliascolnames.Items[1])
 	}
-	if col2.Str != "b" {
-		t.Errorf("expected second column name 'b', got %q", col2.Str)
+	if col2.Sval != "b" {
+		t.Errorf("expected second column name 'b', got %q", col2.Sval)
 	}
 }
 
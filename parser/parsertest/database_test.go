@@ -120,8 +120,8 @@ func TestCreateDatabaseOwner(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected string arg, got %T", opt.Arg)
 	}
-	if arg.Str != "myuser" {
-		t.Errorf("expected 'myuser', got %q", arg.Str)
+	if arg.Sval != "myuser" {
+		t.Errorf("expected 'myuser', got %q", arg.Sval)
 	}
 }
 
@@ -255,8 +255,8 @@ func TestAlterDatabaseSetTablespace(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected string arg, got %T", opt.Arg)
 	}
-	if arg.Str != "newtbs" {
-		t.Errorf("expected 'newtbs', got %q", arg.Str)
+	if arg.Sval != "newtbs" {
+		t.Errorf("expected 'newtbs', got %q", arg.Sval)
 	}
 }
 
@@ -38,8 +38,8 @@ func TestCreateAggregateNewStyle(t *testing.T) {
 	if stmt.Defnames == nil || len(stmt.Defnames.Items) != 1 {
 		t.Fatal("expected 1 name component")
 	}
-	if stmt.Defnames.Items[0].(*nodes.String).Str != "myagg" {
-		t.Errorf("expected 'myagg', got %q", stmt.Defnames.Items[0].(*nodes.String).Str)
+	if stmt.Defnames.Items[0].(*nodes.String).Sval != "myagg" {
+		t.Errorf("expected 'myagg', got %q", stmt.Defnames.Items[0].(*nodes.String).Sval)
 	}
 	if stmt.Args == nil || len(stmt.Args.Items) == 0 {
 		t.Fatal("expected aggregate args")
@@ -162,8 +162,8 @@ func TestCreateOperatorBasic(t *testing.T) {
 	if stmt.Defnames == nil || len(stmt.Defnames.Items) != 1 {
 		t.Fatal("expected 1 name component for operator")
 	}
-	if stmt.Defnames.Items[0].(*nodes.String).Str != "===" {
-		t.Errorf("expected '===', got %q", stmt.Defnames.Items[0].(*nodes.String).Str)
+	if stmt.Defnames.Items[0].(*nodes.String).Sval != "===" {
+		t.Errorf("expected '===', got %q", stmt.Defnames.Items[0].(*nodes.String).Sval)
 	}
 	if stmt.Definition == nil || len(stmt.Definition.Items) < 3 {
 		t.Fatal("expected at least 3 definition elements")
@@ -187,8 +187,8 @@ func TestCreateOperatorPlus(t *testing.T) {
 	if stmt.Kind != nodes.OBJECT_OPERATOR {
 		t.Errorf("expected OBJECT_OPERATOR, got %d", stmt.Kind)
 	}
-	if stmt.Defnames.Items[0].(*nodes.String).Str != "+" {
-		t.Errorf("expected '+', got %q", stmt.Defnames.Items[0].(*nodes.String).Str)
+	if stmt.Defnames.Items[0].(*nodes.String).Sval != "+" {
+		t.Errorf("expected '+', got %q", stmt.Defnames.Items[0].(*nodes.String).Sval)
 	}
 }
 
@@ -212,11 +212,11 @@ func TestCreateOperatorSchemaQualified(t *testing.T) {
 	if stmt.Defnames == nil || len(stmt.Defnames.Items) != 2 {
 		t.Fatalf("expected 2 name components, got %d", stmt.Defnames.Len())
 	}
-	if stmt.Defnames.Items[0].(*nodes.String).Str != "myschema" {
-		t.Errorf("expected 'myschema', got %q", stmt.Defnames.Items[0].(*nodes.String).Str)
+	if stmt.Defnames.Items[0].(*nodes.String).Sval != "myschema" {
+		t.Errorf("expected 'myschema', got %q", stmt.Defnames.Items[0].(*nodes.String).Sval)
 	}
-	if stmt.Defnames.Items[1].(*nodes.String).Str != "+" {
-		t.Errorf("expected '+', got %q", stmt.Defnames.Items[1].(*nodes.String).Str)
+	if stmt.Defnames.Items[1].(*nodes.String).Sval != "+" {
+		t.Errorf("expected '+', got %q", stmt.Defnames.Items[1].(*nodes.String).Sval)
 	}
 }
 
@@ -244,8 +244,8 @@ func TestCreateTypeShell(t *testing.T) {
 	if stmt.Defnames == nil || len(stmt.Defnames.Items) != 1 {
 		t.Fatal("expected 1 name component")
 	}
-	if stmt.Defnames.Items[0].(*nodes.String).Str != "mytype" {
-		t.Errorf("expected 'mytype', got %q", stmt.Defnames.Items[0].(*nodes.String).Str)
+	if stmt.Defnames.Items[0].(*nodes.String).Sval != "mytype" {
+		t.Errorf("expected 'mytype', got %q", stmt.Defnames.Items[0].(*nodes.String).Sval)
 	}
 	if stmt.Definition != nil {
 		t.Error("shell type should have nil definition")
@@ -326,15 +326,15 @@ func TestCreateTypeEnum(t *testing.T) {
 	if stmt.TypeName == nil || len(stmt.TypeName.Items) != 1 {
 		t.Fatal("expected 1 name component")
 	}
-	if stmt.TypeName.Items[0].(*nodes.String).Str != "mood" {
-		t.Errorf("expected 'mood', got %q", stmt.TypeName.Items[0].(*nodes.String).Str)
+	if stmt.TypeName.Items[0].(*nodes.String).Sval != "mood" {
+		t.Errorf("expected 'mood', got %q", stmt.TypeName.Items[0].(*nodes.String).Sval)
 	}
 	if stmt.Vals == nil || len(stmt.Vals.Items) != 3 {
 		t.Fatalf("expected 3 enum values, got %d", stmt.Vals.Len())
 	}
 	vals := []string{"sad", "ok", "happy"}
 	for i, v := range vals {
-		got := stmt.Vals.Items[i].(*nodes.String).Str
+		got := stmt.Vals.Items[i].(*nodes.String).Sval
 		if got != v {
 			t.Errorf("expected val[%d]=%q, got %q", i, v, got)
 		}
@@ -377,8 +377,8 @@ func TestCreateTypeRange(t *testing.T) {
 	if stmt.TypeName == nil || len(stmt.TypeName.Items) != 1 {
 		t.Fatal("expected 1 name component")
 	}
-	if stmt.TypeName.Items[0].(*nodes.String).Str != "float8_range" {
-		t.Errorf("expected 'float8_range', got %q", stmt.TypeName.Items[0].(*nodes.String).Str)
+	if stmt.TypeName.Items[0].(*nodes.String).Sval != "float8_range" {
+		t.Errorf("expected 'float8_range', got %q", stmt.TypeName.Items[0].(*nodes.String).Sval)
 	}
 	if stmt.Params == nil || len(stmt.Params.Items) < 1 {
 		t.Fatal("expected at least 1 range parameter")
@@ -409,8 +409,8 @@ func TestCreateTextSearchParser(t *testing.T) {
 	if stmt.Defnames == nil || len(stmt.Defnames.Items) != 1 {
 		t.Fatal("expected 1 name component")
 	}
-	if stmt.Defnames.Items[0].(*nodes.String).Str != "myparser" {
-		t.Errorf("expected 'myparser', got %q", stmt.Defnames.Items[0].(*nodes.String).Str)
+	if stmt.Defnames.Items[0].(*nodes.String).Sval != "myparser" {
+		t.Errorf("expected 'myparser', got %q", stmt.Defnames.Items[0].(*nodes.String).Sval)
 	}
 	if stmt.Definition == nil || len(stmt.Definition.Items) != 4 {
 		t.Fatalf("expected 4 definition elements, got %d", stmt.Definition.Len())
@@ -434,8 +434,8 @@ func TestCreateTextSearchDictionary(t *testing.T) {
 	if stmt.Kind != nodes.OBJECT_TSDICTIONARY {
 		t.Errorf("expected OBJECT_TSDICTIONARY, got %d", stmt.Kind)
 	}
-	if stmt.Defnames.Items[0].(*nodes.String).Str != "mydict" {
-		t.Errorf("expected 'mydict', got %q", stmt.Defnames.Items[0].(*nodes.String).Str)
+	if stmt.Defnames.Items[0].(*nodes.String).Sval != "mydict" {
+		t.Errorf("expected 'mydict', got %q", stmt.Defnames.Items[0].(*nodes.String).Sval)
 	}
 	if stmt.Definition == nil || len(stmt.Definition.Items) != 2 {
 		t.Fatalf("expected 2 definition elements, got %d", stmt.Definition.Len())
@@ -459,8 +459,8 @@ func TestCreateTextSearchTemplate(t *testing.T) {
 	if stmt.Kind != nodes.OBJECT_TSTEMPLATE {
 		t.Errorf("expected OBJECT_TSTEMPLATE, got %d", stmt.Kind)
 	}
-	if stmt.Defnames.Items[0].(*nodes.String).Str != "mytempl" {
-		t.Errorf("expected 'mytempl', got %q", stmt.Defnames.Items[0].(*nodes.String).Str)
+	if stmt.Defnames.Items[0].(*nodes.String).Sval != "mytempl" {
+		t.Errorf("expected 'mytempl', got %q", stmt.Defnames.Items[0].(*nodes.String).Sval)
 	}
 }
 
@@ -481,8 +481,8 @@ func TestCreateTextSearchConfiguration(t *testing.T) {
 	if stmt.Kind != nodes.OBJECT_TSCONFIGURATION {
 		t.Errorf("expected OBJECT_TSCONFIGURATION, got %d", stmt.Kind)
 	}
-	if stmt.Defnames.Items[0].(*nodes.String).Str != "myconfig" {
-		t.Errorf("expected 'myconfig', got %q", stmt.Defnames.Items[0].(*nodes.String).Str)
+	if stmt.Defnames.Items[0].(*nodes.String).Sval != "myconfig" {
+		t.Errorf("expected 'myconfig', got %q", stmt.Defnames.Items[0].(*nodes.String).Sval)
 	}
 }
 
@@ -510,8 +510,8 @@ func TestCreateCollationDefinition(t *testing.T) {
 	if stmt.Defnames == nil || len(stmt.Defnames.Items) != 1 {
 		t.Fatal("expected 1 name component")
 	}
-	if stmt.Defnames.Items[0].(*nodes.String).Str != "mycoll" {
-		t.Errorf("expected 'mycoll', got %q", stmt.Defnames.Items[0].(*nodes.String).Str)
+	if stmt.Defnames.Items[0].(*nodes.String).Sval != "mycoll" {
+		t.Errorf("expected 'mycoll', got %q", stmt.Defnames.Items[0].(*nodes.String).Sval)
 	}
 	if stmt.Definition == nil || len(stmt.Definition.Items) != 1 {
 		t.Fatal("expected 1 definition element")
@@ -560,8 +560,8 @@ func TestCreateCollationFrom(t *testing.T) {
 	if stmt.Kind != nodes.OBJECT_COLLATION {
 		t.Errorf("expected OBJECT_COLLATION, got %d", stmt.Kind)
 	}
-	if stmt.Defnames.Items[0].(*nodes.String).Str != "mycoll" {
-		t.Errorf("expected 'mycoll', got %q", stmt.Defnames.Items[0].(*nodes.String).Str)
+	if stmt.Defnames.Items[0].(*nodes.String).Sval != "mycoll" {
+		t.Errorf("expected 'mycoll', got %q", stmt.Defnames.Items[0].(*nodes.String).Sval)
 	}
 	// Definition should have "from" element
 	if stmt.Definition == nil || len(stmt.Definition.Items) != 1 {
@@ -593,8 +593,8 @@ func TestCreateCollationIfNotExistsFrom(t *testing.T) {
 	if !stmt.IfNotExists {
 		t.Error("expected IfNotExists=true")
 	}
-	if stmt.Defnames.Items[0].(*nodes.String).Str != "mycoll" {
-		t.Errorf("expected 'mycoll', got %q", stmt.Defnames.Items[0].(*nodes.String).Str)
+	if stmt.Defnames.Items[0].(*nodes.String).Sval != "mycoll" {
+		t.Errorf("expected 'mycoll', got %q", stmt.Defnames.Items[0].(*nodes.String).Sval)
 	}
 	// Definition should have "from" element
 	if stmt.Definition == nil || len(stmt.Definition.Items) != 1 {
@@ -650,11 +650,11 @@ func TestCreateTypeSchemaQualified(t *testing.T) {
 	if stmt.Defnames == nil || len(stmt.Defnames.Items) != 2 {
 		t.Fatalf("expected 2 name components, got %d", stmt.Defnames.Len())
 	}
-	if stmt.Defnames.Items[0].(*nodes.String).Str != "myschema" {
-		t.Errorf("expected 'myschema', got %q", stmt.Defnames.Items[0].(*nodes.String).Str)
+	if stmt.Defnames.Items[0].(*nodes.String).Sval != "myschema" {
+		t.Errorf("expected 'myschema', got %q", stmt.Defnames.Items[0].(*nodes.String).Sval)
 	}
-	if stmt.Defnames.Items[1].(*nodes.String).Str != "mytype" {
-		t.Errorf("expected 'mytype', got %q", stmt.Defnames.Items[1].(*nodes.String).Str)
+	if stmt.Defnames.Items[1].(*nodes.String).Sval != "mytype" {
+		t.Errorf("expected 'mytype', got %q", stmt.Defnames.Items[1].(*nodes.String).Sval)
 	}
 }
 
@@ -733,8 +733,8 @@ func TestDefElemStringValue(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String arg, got %T", defElem.Arg)
 	}
-	if str.Str != "english" {
-		t.Errorf("expected 'english', got %q", str.Str)
+	if str.Sval != "english" {
+		t.Errorf("expected 'english', got %q", str.Sval)
 	}
 }
 
@@ -159,8 +159,8 @@ func TestDeleteReturning(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String, got %T", colRef.Fields.Items[0])
 	}
-	if str.Str != "id" {
-		t.Errorf("expected 'id', got %q", str.Str)
+	if str.Sval != "id" {
+		t.Errorf("expected 'id', got %q", str.Sval)
 	}
 }
 
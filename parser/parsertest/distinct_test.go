@@ -82,8 +82,8 @@ func TestParseSelectDistinctOn(t *testing.T) {
 		t.Fatalf("expected *nodes.ColumnRef in DistinctClause, got %T", stmt.DistinctClause.Items[0])
 	}
 	colStr := colRef.Fields.Items[0].(*nodes.String)
-	if colStr.Str != "a" {
-		t.Errorf("expected DISTINCT ON column 'a', got %q", colStr.Str)
+	if colStr.Sval != "a" {
+		t.Errorf("expected DISTINCT ON column 'a', got %q", colStr.Sval)
 	}
 }
 
@@ -28,10 +28,10 @@ func TestCreateDomainBasic(t *testing.T) {
 	if stmt.Domainname == nil || len(stmt.Domainname.Items) != 1 {
 		t.Fatal("expected 1 name component")
 	}
-	if stmt.Domainname.Items[0].(*nodes.String).Str != "d" {
-		t.Errorf("expected domain name 'd', got %q", stmt.Domainname.Items[0].(*nodes.String).Str)
+	if stmt.Domainname.Items[0].(*nodes.String).Sval != "d" {
+		t.Errorf("expected domain name 'd', got %q", stmt.Domainname.Items[0].(*nodes.String).Sval)
 	}
-	if stmt.Typname == nil {
+	if stmt.TypeName == nil {
 		t.Fatal("expected type name to be set")
 	}
 }
@@ -53,11 +53,11 @@ func TestCreateDomainSchemaQualified(t *testing.T) {
 	if stmt.Domainname == nil || len(stmt.Domainname.Items) != 2 {
 		t.Fatalf("expected 2 name components, got %d", stmt.Domainname.Len())
 	}
-	if stmt.Domainname.Items[0].(*nodes.String).Str != "myschema" {
-		t.Errorf("expected 'myschema', got %q", stmt.Domainname.Items[0].(*nodes.String).Str)
+	if stmt.Domainname.Items[0].(*nodes.String).Sval != "myschema" {
+		t.Errorf("expected 'myschema', got %q", stmt.Domainname.Items[0].(*nodes.String).Sval)
 	}
-	if stmt.Domainname.Items[1].(*nodes.String).Str != "d" {
-		t.Errorf("expected 'd', got %q", stmt.Domainname.Items[1].(*nodes.String).Str)
+	if stmt.Domainname.Items[1].(*nodes.String).Sval != "d" {
+		t.Errorf("expected 'd', got %q", stmt.Domainname.Items[1].(*nodes.String).Sval)
 	}
 }
 
@@ -144,7 +144,7 @@ func TestCreateDomainWithoutAs(t *testing.T) {
 		t.Fatalf("expected *nodes.CreateDomainStmt, got %T", result.Items[0])
 	}
 
-	if stmt.Typname == nil {
+	if stmt.TypeName == nil {
 		t.Fatal("expected type name to be set")
 	}
 }
@@ -339,10 +339,10 @@ func TestAlterEnumAddValue(t *testing.T) {
 		t.Fatalf("expected *nodes.AlterEnumStmt, got %T", result.Items[0])
 	}
 
-	if stmt.Newval != "new" {
-		t.Errorf("expected new value 'new', got %q", stmt.Newval)
+	if stmt.NewVal != "new" {
+		t.Errorf("expected new value 'new', got %q", stmt.NewVal)
 	}
-	if stmt.SkipIfNewvalExists {
+	if stmt.SkipIfNewValExists {
 		t.Error("expected SkipIfNewvalExists to be false")
 	}
 }
@@ -361,10 +361,10 @@ func TestAlterEnumAddValueIfNotExists(t *testing.T) {
 		t.Fatalf("expected *nodes.AlterEnumStmt, got %T", result.Items[0])
 	}
 
-	if stmt.Newval != "new" {
-		t.Errorf("expected new value 'new', got %q", stmt.Newval)
+	if stmt.NewVal != "new" {
+		t.Errorf("expected new value 'new', got %q", stmt.NewVal)
 	}
-	if !stmt.SkipIfNewvalExists {
+	if !stmt.SkipIfNewValExists {
 		t.Error("expected SkipIfNewvalExists to be true")
 	}
 }
@@ -383,13 +383,13 @@ func TestAlterEnumAddValueBefore(t *testing.T) {
 		t.Fatalf("expected *nodes.AlterEnumStmt, got %T", result.Items[0])
 	}
 
-	if stmt.Newval != "new" {
-		t.Errorf("expected new value 'new', got %q", stmt.Newval)
+	if stmt.NewVal != "new" {
+		t.Errorf("expected new value 'new', got %q", stmt.NewVal)
 	}
-	if stmt.NewvalNeighbor != "old" {
-		t.Errorf("expected neighbor 'old', got %q", stmt.NewvalNeighbor)
+	if stmt.NewValNeighbor != "old" {
+		t.Errorf("expected neighbor 'old', got %q", stmt.NewValNeighbor)
 	}
-	if stmt.NewvalIsAfter {
+	if stmt.NewValIsAfter {
 		t.Error("expected NewvalIsAfter to be false")
 	}
 }
@@ -408,13 +408,13 @@ func TestAlterEnumAddValueAfter(t *testing.T) {
 		t.Fatalf("expected *nodes.AlterEnumStmt, got %T", result.Items[0])
 	}
 
-	if stmt.Newval != "new" {
-		t.Errorf("expected new value 'new', got %q", stmt.Newval)
+	if stmt.NewVal != "new" {
+		t.Errorf("expected new value 'new', got %q", stmt.NewVal)
 	}
-	if stmt.NewvalNeighbor != "old" {
-		t.Errorf("expected neighbor 'old', got %q", stmt.NewvalNeighbor)
+	if stmt.NewValNeighbor != "old" {
+		t.Errorf("expected neighbor 'old', got %q", stmt.NewValNeighbor)
 	}
-	if !stmt.NewvalIsAfter {
+	if !stmt.NewValIsAfter {
 		t.Error("expected NewvalIsAfter to be true")
 	}
 }
@@ -433,11 +433,11 @@ func TestAlterEnumRenameValue(t *testing.T) {
 		t.Fatalf("expected *nodes.AlterEnumStmt, got %T", result.Items[0])
 	}
 
-	if stmt.Oldval != "old" {
-		t.Errorf("expected old value 'old', got %q", stmt.Oldval)
+	if stmt.OldVal != "old" {
+		t.Errorf("expected old value 'old', got %q", stmt.OldVal)
 	}
-	if stmt.Newval != "new" {
-		t.Errorf("expected new value 'new', got %q", stmt.Newval)
+	if stmt.NewVal != "new" {
+		t.Errorf("expected new value 'new', got %q", stmt.NewVal)
 	}
 }
 
@@ -458,11 +458,11 @@ func TestAlterCollationRefreshVersion(t *testing.T) {
 	if stmt.Collname == nil || len(stmt.Collname.Items) != 2 {
 		t.Fatalf("expected 2 name components, got %d", stmt.Collname.Len())
 	}
-	if stmt.Collname.Items[0].(*nodes.String).Str != "myschema" {
-		t.Errorf("expected 'myschema', got %q", stmt.Collname.Items[0].(*nodes.String).Str)
+	if stmt.Collname.Items[0].(*nodes.String).Sval != "myschema" {
+		t.Errorf("expected 'myschema', got %q", stmt.Collname.Items[0].(*nodes.String).Sval)
 	}
-	if stmt.Collname.Items[1].(*nodes.String).Str != "mycoll" {
-		t.Errorf("expected 'mycoll', got %q", stmt.Collname.Items[1].(*nodes.String).Str)
+	if stmt.Collname.Items[1].(*nodes.String).Sval != "mycoll" {
+		t.Errorf("expected 'mycoll', got %q", stmt.Collname.Items[1].(*nodes.String).Sval)
 	}
 }
 
@@ -480,8 +480,8 @@ func TestAlterCompositeTypeAddAttribute(t *testing.T) {
 		t.Fatalf("expected *nodes.AlterTableStmt, got %T", result.Items[0])
 	}
 
-	if stmt.ObjType != int(nodes.OBJECT_TYPE) {
-		t.Errorf("expected OBJECT_TYPE, got %d", stmt.ObjType)
+	if stmt.Objtype != nodes.OBJECT_TYPE {
+		t.Errorf("expected OBJECT_TYPE, got %d", stmt.Objtype)
 	}
 	if stmt.Relation == nil || stmt.Relation.Relname != "mytype" {
 		t.Fatal("expected relation name 'mytype'")
@@ -490,7 +490,7 @@ func TestAlterCompositeTypeAddAttribute(t *testing.T) {
 		t.Fatalf("expected 1 command, got %d", stmt.Cmds.Len())
 	}
 	cmd := stmt.Cmds.Items[0].(*nodes.AlterTableCmd)
-	if cmd.Subtype != int(nodes.AT_AddColumn) {
+	if cmd.Subtype != nodes.AT_AddColumn {
 		t.Errorf("expected AT_AddColumn, got %d", cmd.Subtype)
 	}
 }
@@ -509,14 +509,14 @@ func TestAlterCompositeTypeDropAttribute(t *testing.T) {
 		t.Fatalf("expected *nodes.AlterTableStmt, got %T", result.Items[0])
 	}
 
-	if stmt.ObjType != int(nodes.OBJECT_TYPE) {
-		t.Errorf("expected OBJECT_TYPE, got %d", stmt.ObjType)
+	if stmt.Objtype != nodes.OBJECT_TYPE {
+		t.Errorf("expected OBJECT_TYPE, got %d", stmt.Objtype)
 	}
 	if stmt.Cmds == nil || len(stmt.Cmds.Items) != 1 {
 		t.Fatalf("expected 1 command, got %d", stmt.Cmds.Len())
 	}
 	cmd := stmt.Cmds.Items[0].(*nodes.AlterTableCmd)
-	if cmd.Subtype != int(nodes.AT_DropColumn) {
+	if cmd.Subtype != nodes.AT_DropColumn {
 		t.Errorf("expected AT_DropColumn, got %d", cmd.Subtype)
 	}
 	if cmd.Name != "a" {
@@ -538,14 +538,14 @@ func TestAlterCompositeTypeAlterAttribute(t *testing.T) {
 		t.Fatalf("expected *nodes.AlterTableStmt, got %T", result.Items[0])
 	}
 
-	if stmt.ObjType != int(nodes.OBJECT_TYPE) {
-		t.Errorf("expected OBJECT_TYPE, got %d", stmt.ObjType)
+	if stmt.Objtype != nodes.OBJECT_TYPE {
+		t.Errorf("expected OBJECT_TYPE, got %d", stmt.Objtype)
 	}
 	if stmt.Cmds == nil || len(stmt.Cmds.Items) != 1 {
 		t.Fatalf("expected 1 command, got %d", stmt.Cmds.Len())
 	}
 	cmd := stmt.Cmds.Items[0].(*nodes.AlterTableCmd)
-	if cmd.Subtype != int(nodes.AT_AlterColumnType) {
+	if cmd.Subtype != nodes.AT_AlterColumnType {
 		t.Errorf("expected AT_AlterColumnType, got %d", cmd.Subtype)
 	}
 	if cmd.Name != "a" {
@@ -40,7 +40,7 @@ func getObjectName(t *testing.T, stmt *nodes.DropStmt, index int) []string {
 		if !ok {
 			t.Fatalf("expected *nodes.String in name list, got %T", item)
 		}
-		parts = append(parts, s.Str)
+		parts = append(parts, s.Sval)
 	}
 	return parts
 }
@@ -49,13 +49,13 @@ func getObjectName(t *testing.T, stmt *nodes.DropStmt, index int) []string {
 func TestDropTable(t *testing.T) {
 	stmt := parseDropStmt(t, "DROP TABLE foo")
 
-	if stmt.RemoveType != int(nodes.OBJECT_TABLE) {
+	if stmt.RemoveType != nodes.OBJECT_TABLE {
 		t.Errorf("expected RemoveType OBJECT_TABLE (%d), got %d", nodes.OBJECT_TABLE, stmt.RemoveType)
 	}
-	if stmt.Missing_ok {
+	if stmt.MissingOk {
 		t.Error("expected Missing_ok to be false")
 	}
-	if stmt.Behavior != int(nodes.DROP_RESTRICT) {
+	if stmt.Behavior != nodes.DROP_RESTRICT {
 		t.Errorf("expected Behavior DROP_RESTRICT (%d), got %d", nodes.DROP_RESTRICT, stmt.Behavior)
 	}
 
@@ -69,13 +69,13 @@ func TestDropTable(t *testing.T) {
 func TestDropTableIfExists(t *testing.T) {
 	stmt := parseDropStmt(t, "DROP TABLE IF EXISTS foo")
 
-	if stmt.RemoveType != int(nodes.OBJECT_TABLE) {
+	if stmt.RemoveType != nodes.OBJECT_TABLE {
 		t.Errorf("expected RemoveType OBJECT_TABLE (%d), got %d", nodes.OBJECT_TABLE, stmt.RemoveType)
 	}
-	if !stmt.Missing_ok {
+	if !stmt.MissingOk {
 		t.Error("expected Missing_ok to be true")
 	}
-	if stmt.Behavior != int(nodes.DROP_RESTRICT) {
+	if stmt.Behavior != nodes.DROP_RESTRICT {
 		t.Errorf("expected Behavior DROP_RESTRICT (%d), got %d", nodes.DROP_RESTRICT, stmt.Behavior)
 	}
 
@@ -89,10 +89,10 @@ func TestDropTableIfExists(t *testing.T) {
 func TestDropTableCascade(t *testing.T) {
 	stmt := parseDropStmt(t, "DROP TABLE foo CASCADE")
 
-	if stmt.RemoveType != int(nodes.OBJECT_TABLE) {
+	if stmt.RemoveType != nodes.OBJECT_TABLE {
 		t.Errorf("expected RemoveType OBJECT_TABLE, got %d", stmt.RemoveType)
 	}
-	if stmt.Behavior != int(nodes.DROP_CASCADE) {
+	if stmt.Behavior != nodes.DROP_CASCADE {
 		t.Errorf("expected Behavior DROP_CASCADE (%d), got %d", nodes.DROP_CASCADE, stmt.Behavior)
 	}
 }
@@ -101,10 +101,10 @@ func TestDropTableCascade(t *testing.T) {
 func TestDropTableRestrict(t *testing.T) {
 	stmt := parseDropStmt(t, "DROP TABLE foo RESTRICT")
 
-	if stmt.RemoveType != int(nodes.OBJECT_TABLE) {
+	if stmt.RemoveType != nodes.OBJECT_TABLE {
 		t.Errorf("expected RemoveType OBJECT_TABLE, got %d", stmt.RemoveType)
 	}
-	if stmt.Behavior != int(nodes.DROP_RESTRICT) {
+	if stmt.Behavior != nodes.DROP_RESTRICT {
 		t.Errorf("expected Behavior DROP_RESTRICT (%d), got %d", nodes.DROP_RESTRICT, stmt.Behavior)
 	}
 }
@@ -148,7 +148,7 @@ func TestDropTableMultiple(t *testing.T) {
 func TestDropView(t *testing.T) {
 	stmt := parseDropStmt(t, "DROP VIEW myview")
 
-	if stmt.RemoveType != int(nodes.OBJECT_VIEW) {
+	if stmt.RemoveType != nodes.OBJECT_VIEW {
 		t.Errorf("expected RemoveType OBJECT_VIEW (%d), got %d", nodes.OBJECT_VIEW, stmt.RemoveType)
 	}
 
@@ -162,7 +162,7 @@ func TestDropView(t *testing.T) {
 func TestDropIndex(t *testing.T) {
 	stmt := parseDropStmt(t, "DROP INDEX myindex")
 
-	if stmt.RemoveType != int(nodes.OBJECT_INDEX) {
+	if stmt.RemoveType != nodes.OBJECT_INDEX {
 		t.Errorf("expected RemoveType OBJECT_INDEX (%d), got %d", nodes.OBJECT_INDEX, stmt.RemoveType)
 	}
 
@@ -176,7 +176,7 @@ func TestDropIndex(t *testing.T) {
 func TestDropSequence(t *testing.T) {
 	stmt := parseDropStmt(t, "DROP SEQUENCE myseq")
 
-	if stmt.RemoveType != int(nodes.OBJECT_SEQUENCE) {
+	if stmt.RemoveType != nodes.OBJECT_SEQUENCE {
 		t.Errorf("expected RemoveType OBJECT_SEQUENCE (%d), got %d", nodes.OBJECT_SEQUENCE, stmt.RemoveType)
 	}
 
@@ -190,7 +190,7 @@ func TestDropSequence(t *testing.T) {
 func TestDropMaterializedView(t *testing.T) {
 	stmt := parseDropStmt(t, "DROP MATERIALIZED VIEW mymatview")
 
-	if stmt.RemoveType != int(nodes.OBJECT_MATVIEW) {
+	if stmt.RemoveType != nodes.OBJECT_MATVIEW {
 		t.Errorf("expected RemoveType OBJECT_MATVIEW (%d), got %d", nodes.OBJECT_MATVIEW, stmt.RemoveType)
 	}
 
@@ -204,13 +204,13 @@ func TestDropMaterializedView(t *testing.T) {
 func TestDropTableIfExistsCascade(t *testing.T) {
 	stmt := parseDropStmt(t, "DROP TABLE IF EXISTS foo CASCADE")
 
-	if stmt.RemoveType != int(nodes.OBJECT_TABLE) {
+	if stmt.RemoveType != nodes.OBJECT_TABLE {
 		t.Errorf("expected RemoveType OBJECT_TABLE, got %d", stmt.RemoveType)
 	}
-	if !stmt.Missing_ok {
+	if !stmt.MissingOk {
 		t.Error("expected Missing_ok to be true")
 	}
-	if stmt.Behavior != int(nodes.DROP_CASCADE) {
+	if stmt.Behavior != nodes.DROP_CASCADE {
 		t.Errorf("expected Behavior DROP_CASCADE (%d), got %d", nodes.DROP_CASCADE, stmt.Behavior)
 	}
 
@@ -224,7 +224,7 @@ func TestDropTableIfExistsCascade(t *testing.T) {
 func TestDropForeignTable(t *testing.T) {
 	stmt := parseDropStmt(t, "DROP FOREIGN TABLE ft")
 
-	if stmt.RemoveType != int(nodes.OBJECT_FOREIGN_TABLE) {
+	if stmt.RemoveType != nodes.OBJECT_FOREIGN_TABLE {
 		t.Errorf("expected RemoveType OBJECT_FOREIGN_TABLE (%d), got %d", nodes.OBJECT_FOREIGN_TABLE, stmt.RemoveType)
 	}
 
@@ -257,10 +257,10 @@ func TestDropMultipleQualified(t *testing.T) {
 func TestDropViewIfExists(t *testing.T) {
 	stmt := parseDropStmt(t, "DROP VIEW IF EXISTS v1")
 
-	if stmt.RemoveType != int(nodes.OBJECT_VIEW) {
+	if stmt.RemoveType != nodes.OBJECT_VIEW {
 		t.Errorf("expected RemoveType OBJECT_VIEW (%d), got %d", nodes.OBJECT_VIEW, stmt.RemoveType)
 	}
-	if !stmt.Missing_ok {
+	if !stmt.MissingOk {
 		t.Error("expected Missing_ok to be true")
 	}
 
@@ -274,13 +274,13 @@ func TestDropViewIfExists(t *testing.T) {
 func TestDropIndexIfExistsCascade(t *testing.T) {
 	stmt := parseDropStmt(t, "DROP INDEX IF EXISTS idx1 CASCADE")
 
-	if stmt.RemoveType != int(nodes.OBJECT_INDEX) {
+	if stmt.RemoveType != nodes.OBJECT_INDEX {
 		t.Errorf("expected RemoveType OBJECT_INDEX (%d), got %d", nodes.OBJECT_INDEX, stmt.RemoveType)
 	}
-	if !stmt.Missing_ok {
+	if !stmt.MissingOk {
 		t.Error("expected Missing_ok to be true")
 	}
-	if stmt.Behavior != int(nodes.DROP_CASCADE) {
+	if stmt.Behavior != nodes.DROP_CASCADE {
 		t.Errorf("expected Behavior DROP_CASCADE (%d), got %d", nodes.DROP_CASCADE, stmt.Behavior)
 	}
 
@@ -289,3 +289,33 @@ func TestDropIndexIfExistsCascade(t *testing.T) {
 		t.Errorf("expected object name [idx1], got %v", parts)
 	}
 }
+
+// TestDropTriggerOnTable tests: DROP TRIGGER trg ON myschema.tbl
+// The object name is the relation name followed by the trigger name.
+func TestDropTriggerOnTable(t *testing.T) {
+	stmt := parseDropStmt(t, "DROP TRIGGER trg ON myschema.tbl")
+
+	if stmt.RemoveType != nodes.OBJECT_TRIGGER {
+		t.Errorf("expected RemoveType OBJECT_TRIGGER (%d), got %d", nodes.OBJECT_TRIGGER, stmt.RemoveType)
+	}
+	parts := getObjectName(t, stmt, 0)
+	if len(parts) != 3 || parts[0] != "myschema" || parts[1] != "tbl" || parts[2] != "trg" {
+		t.Errorf("expected [myschema tbl trg], got %v", parts)
+	}
+}
+
+// TestDropPolicyIfExists tests: DROP POLICY IF EXISTS p ON tbl CASCADE
+func TestDropPolicyIfExists(t *testing.T) {
+	stmt := parseDropStmt(t, "DROP POLICY IF EXISTS p ON tbl CASCADE")
+
+	if stmt.RemoveType != nodes.OBJECT_POLICY {
+		t.Errorf("expected RemoveType OBJECT_POLICY (%d), got %d", nodes.OBJECT_POLICY, stmt.RemoveType)
+	}
+	if !stmt.MissingOk {
+		t.Error("expected Missing_ok to be true")
+	}
+	parts := getObjectName(t, stmt, 0)
+	if len(parts) != 2 || parts[0] != "tbl" || parts[1] != "p" {
+		t.Errorf("expected [tbl p], got %v", parts)
+	}
+}
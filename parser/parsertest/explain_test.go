@@ -198,8 +198,8 @@ func TestExplainFormatJSON(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String arg for format, got %T", formatOpt.Arg)
 	}
-	if strArg.Str != "json" {
-		t.Errorf("expected format arg 'json', got %q", strArg.Str)
+	if strArg.Sval != "json" {
+		t.Errorf("expected format arg 'json', got %q", strArg.Sval)
 	}
 }
 
@@ -67,8 +67,8 @@ func TestCreateExtensionSchema(t *testing.T) {
 	if opt.Defname != "schema" {
 		t.Errorf("expected defname 'schema', got %q", opt.Defname)
 	}
-	if opt.Arg.(*nodes.String).Str != "public" {
-		t.Errorf("expected schema 'public', got %q", opt.Arg.(*nodes.String).Str)
+	if opt.Arg.(*nodes.String).Sval != "public" {
+		t.Errorf("expected schema 'public', got %q", opt.Arg.(*nodes.String).Sval)
 	}
 }
 
@@ -87,8 +87,8 @@ func TestCreateExtensionVersion(t *testing.T) {
 	if opt.Defname != "new_version" {
 		t.Errorf("expected defname 'new_version', got %q", opt.Defname)
 	}
-	if opt.Arg.(*nodes.String).Str != "1.3" {
-		t.Errorf("expected version '1.3', got %q", opt.Arg.(*nodes.String).Str)
+	if opt.Arg.(*nodes.String).Sval != "1.3" {
+		t.Errorf("expected version '1.3', got %q", opt.Arg.(*nodes.String).Sval)
 	}
 }
 
@@ -140,8 +140,8 @@ func TestAlterExtensionUpdateTo(t *testing.T) {
 	if opt.Defname != "new_version" {
 		t.Errorf("expected defname 'new_version', got %q", opt.Defname)
 	}
-	if opt.Arg.(*nodes.String).Str != "2.0" {
-		t.Errorf("expected version '2.0', got %q", opt.Arg.(*nodes.String).Str)
+	if opt.Arg.(*nodes.String).Sval != "2.0" {
+		t.Errorf("expected version '2.0', got %q", opt.Arg.(*nodes.String).Sval)
 	}
 }
 
@@ -300,7 +300,7 @@ func TestCreateAmIndex(t *testing.T) {
 	if stmt.HandlerName == nil || stmt.HandlerName.Len() != 1 {
 		t.Fatal("expected handler name with 1 element")
 	}
-	handlerStr := stmt.HandlerName.Items[0].(*nodes.String).Str
+	handlerStr := stmt.HandlerName.Items[0].(*nodes.String).Sval
 	if handlerStr != "handler_func" {
 		t.Errorf("expected handler 'handler_func', got %q", handlerStr)
 	}
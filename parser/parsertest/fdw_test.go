@@ -102,8 +102,8 @@ func TestCreateFdwOptions(t *testing.T) {
 		t.Errorf("expected option name 'debug', got %q", opt.Defname)
 	}
 	val := opt.Arg.(*nodes.String)
-	if val.Str != "true" {
-		t.Errorf("expected option value 'true', got %q", val.Str)
+	if val.Sval != "true" {
+		t.Errorf("expected option value 'true', got %q", val.Sval)
 	}
 }
 
@@ -132,7 +132,7 @@ func TestAlterFdwOptionsAdd(t *testing.T) {
 	if opt.Defname != "debug" {
 		t.Errorf("expected option name 'debug', got %q", opt.Defname)
 	}
-	if opt.Defaction != int(nodes.DEFELEM_ADD) {
+	if opt.Defaction != nodes.DEFELEM_ADD {
 		t.Errorf("expected DEFELEM_ADD (%d), got %d", nodes.DEFELEM_ADD, opt.Defaction)
 	}
 }
@@ -166,7 +166,7 @@ func TestAlterFdwOptionsSet(t *testing.T) {
 		t.Fatalf("expected 1 option, got %d", stmt.Options.Len())
 	}
 	opt := stmt.Options.Items[0].(*nodes.DefElem)
-	if opt.Defaction != int(nodes.DEFELEM_SET) {
+	if opt.Defaction != nodes.DEFELEM_SET {
 		t.Errorf("expected DEFELEM_SET (%d), got %d", nodes.DEFELEM_SET, opt.Defaction)
 	}
 }
@@ -186,7 +186,7 @@ func TestAlterFdwOptionsDrop(t *testing.T) {
 	if opt.Defname != "debug" {
 		t.Errorf("expected option name 'debug', got %q", opt.Defname)
 	}
-	if opt.Defaction != int(nodes.DEFELEM_DROP) {
+	if opt.Defaction != nodes.DEFELEM_DROP {
 		t.Errorf("expected DEFELEM_DROP (%d), got %d", nodes.DEFELEM_DROP, opt.Defaction)
 	}
 	if opt.Arg != nil {
@@ -313,7 +313,7 @@ func TestAlterServerOptions(t *testing.T) {
 	if opt.Defname != "host" {
 		t.Errorf("expected option name 'host', got %q", opt.Defname)
 	}
-	if opt.Defaction != int(nodes.DEFELEM_SET) {
+	if opt.Defaction != nodes.DEFELEM_SET {
 		t.Errorf("expected DEFELEM_SET, got %d", opt.Defaction)
 	}
 }
@@ -426,7 +426,7 @@ func TestCreateUserMappingCurrentUser(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.CreateUserMappingStmt, got %T", result.Items[0])
 	}
-	if stmt.User == nil || stmt.User.Roletype != int(nodes.ROLESPEC_CURRENT_USER) {
+	if stmt.User == nil || stmt.User.Roletype != nodes.ROLESPEC_CURRENT_USER {
 		t.Error("expected ROLESPEC_CURRENT_USER")
 	}
 	if stmt.Servername != "myserver" {
@@ -462,7 +462,7 @@ func TestCreateUserMappingNamedUser(t *testing.T) {
 		t.Fatalf("Parse error: %v", err)
 	}
 	stmt := result.Items[0].(*nodes.CreateUserMappingStmt)
-	if stmt.User == nil || stmt.User.Roletype != int(nodes.ROLESPEC_CSTRING) {
+	if stmt.User == nil || stmt.User.Roletype != nodes.ROLESPEC_CSTRING {
 		t.Errorf("expected ROLESPEC_CSTRING, got %d", stmt.User.Roletype)
 	}
 	if stmt.User.Rolename != "myuser" {
@@ -479,7 +479,7 @@ func TestCreateUserMappingForUser(t *testing.T) {
 		t.Fatalf("Parse error: %v", err)
 	}
 	stmt := result.Items[0].(*nodes.CreateUserMappingStmt)
-	if stmt.User == nil || stmt.User.Roletype != int(nodes.ROLESPEC_CURRENT_USER) {
+	if stmt.User == nil || stmt.User.Roletype != nodes.ROLESPEC_CURRENT_USER {
 		t.Errorf("expected ROLESPEC_CURRENT_USER, got %d", stmt.User.Roletype)
 	}
 }
@@ -499,7 +499,7 @@ func TestAlterUserMapping(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.AlterUserMappingStmt, got %T", result.Items[0])
 	}
-	if stmt.User == nil || stmt.User.Roletype != int(nodes.ROLESPEC_CURRENT_USER) {
+	if stmt.User == nil || stmt.User.Roletype != nodes.ROLESPEC_CURRENT_USER {
 		t.Error("expected ROLESPEC_CURRENT_USER")
 	}
 	if stmt.Servername != "myserver" {
@@ -509,7 +509,7 @@ func TestAlterUserMapping(t *testing.T) {
 		t.Fatalf("expected 1 option, got %d", stmt.Options.Len())
 	}
 	opt := stmt.Options.Items[0].(*nodes.DefElem)
-	if opt.Defaction != int(nodes.DEFELEM_SET) {
+	if opt.Defaction != nodes.DEFELEM_SET {
 		t.Errorf("expected DEFELEM_SET, got %d", opt.Defaction)
 	}
 }
@@ -529,7 +529,7 @@ func TestDropUserMapping(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.DropUserMappingStmt, got %T", result.Items[0])
 	}
-	if stmt.User == nil || stmt.User.Roletype != int(nodes.ROLESPEC_CURRENT_USER) {
+	if stmt.User == nil || stmt.User.Roletype != nodes.ROLESPEC_CURRENT_USER {
 		t.Error("expected ROLESPEC_CURRENT_USER")
 	}
 	if stmt.Servername != "myserver" {
@@ -689,15 +689,15 @@ func TestAlterFdwMultipleAlterOptions(t *testing.T) {
 		t.Fatalf("expected 3 options, got %d", stmt.Options.Len())
 	}
 	opt1 := stmt.Options.Items[0].(*nodes.DefElem)
-	if opt1.Defname != "key1" || opt1.Defaction != int(nodes.DEFELEM_ADD) {
+	if opt1.Defname != "key1" || opt1.Defaction != nodes.DEFELEM_ADD {
 		t.Errorf("expected ADD key1, got action=%d name=%q", opt1.Defaction, opt1.Defname)
 	}
 	opt2 := stmt.Options.Items[1].(*nodes.DefElem)
-	if opt2.Defname != "key2" || opt2.Defaction != int(nodes.DEFELEM_SET) {
+	if opt2.Defname != "key2" || opt2.Defaction != nodes.DEFELEM_SET {
 		t.Errorf("expected SET key2, got action=%d name=%q", opt2.Defaction, opt2.Defname)
 	}
 	opt3 := stmt.Options.Items[2].(*nodes.DefElem)
-	if opt3.Defname != "key3" || opt3.Defaction != int(nodes.DEFELEM_DROP) {
+	if opt3.Defname != "key3" || opt3.Defaction != nodes.DEFELEM_DROP {
 		t.Errorf("expected DROP key3, got action=%d name=%q", opt3.Defaction, opt3.Defname)
 	}
 }
@@ -43,15 +43,15 @@ func TestForeignKeyOnDeleteSetNullColumns(t *testing.T) {
 		t.Fatal("expected a FOREIGN KEY constraint")
 	}
 
-	if fk.FkDelaction != 'n' {
-		t.Errorf("expected FkDelaction='n' (SET NULL), got %q", fk.FkDelaction)
+	if fk.FkDelAction != 'n' {
+		t.Errorf("expected FkDelaction='n' (SET NULL), got %q", fk.FkDelAction)
 	}
 
-	if fk.FkDelsetcols == nil {
+	if fk.FkDelSetCols == nil {
 		t.Fatal("expected FkDelsetcols to be set for ON DELETE SET NULL (col_list)")
 	}
-	if len(fk.FkDelsetcols.Items) != 2 {
-		t.Fatalf("expected 2 columns in FkDelsetcols, got %d", len(fk.FkDelsetcols.Items))
+	if len(fk.FkDelSetCols.Items) != 2 {
+		t.Fatalf("expected 2 columns in FkDelsetcols, got %d", len(fk.FkDelSetCols.Items))
 	}
 }
 
@@ -69,15 +69,15 @@ func TestForeignKeyOnDeleteSetDefaultColumns(t *testing.T) {
 		t.Fatal("expected a FOREIGN KEY constraint")
 	}
 
-	if fk.FkDelaction != 'd' {
-		t.Errorf("expected FkDelaction='d' (SET DEFAULT), got %q", fk.FkDelaction)
+	if fk.FkDelAction != 'd' {
+		t.Errorf("expected FkDelaction='d' (SET DEFAULT), got %q", fk.FkDelAction)
 	}
 
-	if fk.FkDelsetcols == nil {
+	if fk.FkDelSetCols == nil {
 		t.Fatal("expected FkDelsetcols to be set for ON DELETE SET DEFAULT (col_list)")
 	}
-	if len(fk.FkDelsetcols.Items) != 1 {
-		t.Fatalf("expected 1 column in FkDelsetcols, got %d", len(fk.FkDelsetcols.Items))
+	if len(fk.FkDelSetCols.Items) != 1 {
+		t.Fatalf("expected 1 column in FkDelsetcols, got %d", len(fk.FkDelSetCols.Items))
 	}
 }
 
@@ -95,11 +95,11 @@ func TestForeignKeyOnDeleteSetNullNoColumns(t *testing.T) {
 		t.Fatal("expected a FOREIGN KEY constraint")
 	}
 
-	if fk.FkDelaction != 'n' {
-		t.Errorf("expected FkDelaction='n' (SET NULL), got %q", fk.FkDelaction)
+	if fk.FkDelAction != 'n' {
+		t.Errorf("expected FkDelaction='n' (SET NULL), got %q", fk.FkDelAction)
 	}
 
-	if fk.FkDelsetcols != nil {
-		t.Errorf("expected FkDelsetcols to be nil without column list, got %v", fk.FkDelsetcols)
+	if fk.FkDelSetCols != nil {
+		t.Errorf("expected FkDelsetcols to be nil without column list, got %v", fk.FkDelSetCols)
 	}
 }
@@ -103,7 +103,7 @@ func TestGrantSelectOnTable(t *testing.T) {
 		t.Fatalf("expected 1 grantee, got %v", stmt.Grantees)
 	}
 	grantee := getGrantee(t, stmt, 0)
-	if grantee.Roletype != int(nodes.ROLESPEC_CSTRING) {
+	if grantee.Roletype != nodes.ROLESPEC_CSTRING {
 		t.Errorf("expected ROLESPEC_CSTRING (%d), got %d", nodes.ROLESPEC_CSTRING, grantee.Roletype)
 	}
 	if grantee.Rolename != "user1" {
@@ -225,7 +225,7 @@ func TestGrantToCurrentUser(t *testing.T) {
 	}
 
 	grantee := getGrantee(t, stmt, 0)
-	if grantee.Roletype != int(nodes.ROLESPEC_CURRENT_USER) {
+	if grantee.Roletype != nodes.ROLESPEC_CURRENT_USER {
 		t.Errorf("expected ROLESPEC_CURRENT_USER (%d), got %d", nodes.ROLESPEC_CURRENT_USER, grantee.Roletype)
 	}
 	if grantee.Rolename != "" {
@@ -415,3 +415,33 @@ func TestRevokeGrantOptionFor(t *testing.T) {
 		t.Errorf("expected privilege 'select', got %q", priv.PrivName)
 	}
 }
+
+// TestGrantToPublic tests: GRANT SELECT ON TABLE foo TO PUBLIC
+// PUBLIC is not a keyword; the role name "public" denotes it wherever a
+// role is expected, and "none" is reserved.
+func TestGrantToPublic(t *testing.T) {
+	for _, input := range []string{
+		"GRANT SELECT ON TABLE foo TO PUBLIC",
+		`GRANT SELECT ON TABLE foo TO "public"`,
+		"GRANT SELECT ON TABLE foo TO GROUP public",
+	} {
+		stmt := parseGrantStmt(t, input)
+		grantee := getGrantee(t, stmt, 0)
+		if grantee.Roletype != nodes.ROLESPEC_PUBLIC {
+			t.Errorf("%s: expected ROLESPEC_PUBLIC (%d), got %d", input, nodes.ROLESPEC_PUBLIC, grantee.Roletype)
+		}
+		if grantee.Rolename != "" {
+			t.Errorf("%s: expected empty Rolename for PUBLIC, got %q", input, grantee.Rolename)
+		}
+	}
+
+	for _, input := range []string{
+		"GRANT SELECT ON TABLE foo TO none",
+		"CREATE ROLE public",
+		"ALTER TABLE foo OWNER TO none",
+	} {
+		if _, err := parser.Parse(input); err == nil {
+			t.Errorf("%s: expected an error", input)
+		}
+	}
+}
@@ -84,8 +84,8 @@ func TestInsertBasicValues(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String, got %T", val2.Val)
 	}
-	if strVal.Str != "hello" {
-		t.Errorf("expected string 'hello', got %q", strVal.Str)
+	if strVal.Sval != "hello" {
+		t.Errorf("expected string 'hello', got %q", strVal.Sval)
 	}
 
 	// No ON CONFLICT, RETURNING, or WITH
@@ -262,8 +262,8 @@ func TestInsertReturning(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String, got %T", colRef.Fields.Items[0])
 	}
-	if str.Str != "id" {
-		t.Errorf("expected 'id', got %q", str.Str)
+	if str.Sval != "id" {
+		t.Errorf("expected 'id', got %q", str.Sval)
 	}
 }
 
@@ -341,8 +341,8 @@ func TestInsertOnConflictDoUpdate(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String, got %T", aConst.Val)
 	}
-	if strVal.Str != "new" {
-		t.Errorf("expected 'new', got %q", strVal.Str)
+	if strVal.Sval != "new" {
+		t.Errorf("expected 'new', got %q", strVal.Sval)
 	}
 }
 
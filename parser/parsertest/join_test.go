@@ -217,8 +217,8 @@ func TestParseJoinUsing(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String in UsingClause, got %T", join.UsingClause.Items[0])
 	}
-	if str.Str != "id" {
-		t.Errorf("expected USING column 'id', got %q", str.Str)
+	if str.Sval != "id" {
+		t.Errorf("expected USING column 'id', got %q", str.Sval)
 	}
 }
 
@@ -246,11 +246,11 @@ func TestParseJoinUsingMultipleColumns(t *testing.T) {
 
 	str0 := join.UsingClause.Items[0].(*nodes.String)
 	str1 := join.UsingClause.Items[1].(*nodes.String)
-	if str0.Str != "a" {
-		t.Errorf("expected first USING column 'a', got %q", str0.Str)
+	if str0.Sval != "a" {
+		t.Errorf("expected first USING column 'a', got %q", str0.Sval)
 	}
-	if str1.Str != "b" {
-		t.Errorf("expected second USING column 'b', got %q", str1.Str)
+	if str1.Sval != "b" {
+		t.Errorf("expected second USING column 'b', got %q", str1.Sval)
 	}
 }
 
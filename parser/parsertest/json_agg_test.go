@@ -25,7 +25,7 @@ func TestJsonObjectAggFilter(t *testing.T) {
 	if agg.Constructor == nil {
 		t.Fatal("expected Constructor to be set")
 	}
-	if agg.Constructor.Agg_filter == nil {
+	if agg.Constructor.AggFilter == nil {
 		t.Fatal("expected Agg_filter to be set for FILTER clause")
 	}
 }
@@ -68,7 +68,7 @@ func TestJsonObjectAggNoFilterNoOver(t *testing.T) {
 		t.Fatalf("expected *nodes.JsonObjectAgg, got %T", rt.Val)
 	}
 
-	if agg.Constructor.Agg_filter != nil {
+	if agg.Constructor.AggFilter != nil {
 		t.Error("expected Agg_filter to be nil without FILTER clause")
 	}
 	if agg.Constructor.Over != nil {
@@ -257,8 +257,8 @@ func TestParseOrderByLimit(t *testing.T) {
 	sb := stmt.SortClause.Items[0].(*nodes.SortBy)
 	colRef := sb.Node.(*nodes.ColumnRef)
 	colStr := colRef.Fields.Items[0].(*nodes.String)
-	if colStr.Str != "id" {
-		t.Errorf("expected sort by 'id', got %q", colStr.Str)
+	if colStr.Sval != "id" {
+		t.Errorf("expected sort by 'id', got %q", colStr.Sval)
 	}
 
 	// Verify LimitCount is 10
@@ -316,7 +316,7 @@ func TestParseCTEWithLimit(t *testing.T) {
 }
 
 func TestParseFetchFirstWithTies(t *testing.T) {
-	input := "SELECT * FROM t FETCH FIRST 3 ROWS WITH TIES"
+	input := "SELECT * FROM t ORDER BY a FETCH FIRST 3 ROWS WITH TIES"
 
 	result, err := parser.Parse(input)
 	if err != nil {
@@ -343,7 +343,7 @@ func TestParseFetchFirstWithTies(t *testing.T) {
 
 func TestParseFetchNextRowWithTies(t *testing.T) {
 	// FETCH NEXT ROW WITH TIES implies LIMIT 1 WITH TIES
-	input := "SELECT * FROM t FETCH NEXT ROW WITH TIES"
+	input := "SELECT * FROM t ORDER BY a FETCH NEXT ROW WITH TIES"
 
 	result, err := parser.Parse(input)
 	if err != nil {
@@ -199,8 +199,8 @@ func TestVacuumSimple(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.VacuumStmt, got %T", stmt)
 	}
-	if vs.IsVacuumCmd != true {
-		t.Errorf("expected IsVacuumCmd true, got %v", vs.IsVacuumCmd)
+	if vs.IsVacuumcmd != true {
+		t.Errorf("expected IsVacuumCmd true, got %v", vs.IsVacuumcmd)
 	}
 	if vs.Rels != nil && vs.Rels.Len() > 0 {
 		t.Errorf("expected nil Rels, got %v", vs.Rels)
@@ -213,8 +213,8 @@ func TestVacuumTable(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.VacuumStmt, got %T", stmt)
 	}
-	if vs.IsVacuumCmd != true {
-		t.Errorf("expected IsVacuumCmd true, got %v", vs.IsVacuumCmd)
+	if vs.IsVacuumcmd != true {
+		t.Errorf("expected IsVacuumCmd true, got %v", vs.IsVacuumcmd)
 	}
 	if vs.Rels == nil || vs.Rels.Len() != 1 {
 		t.Fatalf("expected 1 relation, got %v", vs.Rels)
@@ -238,8 +238,8 @@ func TestVacuumAnalyze(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.VacuumStmt, got %T", stmt)
 	}
-	if vs.IsVacuumCmd != true {
-		t.Errorf("expected IsVacuumCmd true, got %v", vs.IsVacuumCmd)
+	if vs.IsVacuumcmd != true {
+		t.Errorf("expected IsVacuumCmd true, got %v", vs.IsVacuumcmd)
 	}
 }
 
@@ -255,8 +255,8 @@ func TestAnalyzeSimple(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.VacuumStmt, got %T", stmt)
 	}
-	if vs.IsVacuumCmd != false {
-		t.Errorf("expected IsVacuumCmd false, got %v", vs.IsVacuumCmd)
+	if vs.IsVacuumcmd != false {
+		t.Errorf("expected IsVacuumCmd false, got %v", vs.IsVacuumcmd)
 	}
 }
 
@@ -266,8 +266,8 @@ func TestAnalyzeTable(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.VacuumStmt, got %T", stmt)
 	}
-	if vs.IsVacuumCmd != false {
-		t.Errorf("expected IsVacuumCmd false, got %v", vs.IsVacuumCmd)
+	if vs.IsVacuumcmd != false {
+		t.Errorf("expected IsVacuumCmd false, got %v", vs.IsVacuumcmd)
 	}
 	if vs.Rels == nil || vs.Rels.Len() != 1 {
 		t.Fatalf("expected 1 relation, got %v", vs.Rels)
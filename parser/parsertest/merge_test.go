@@ -37,8 +37,8 @@ func TestMergeUpdateBasic(t *testing.T) {
 		t.Fatalf("expected 1 merge when clause, got %v", ms.MergeWhenClauses)
 	}
 	wc := ms.MergeWhenClauses.Items[0].(*nodes.MergeWhenClause)
-	if wc.Kind != nodes.MERGE_WHEN_MATCHED {
-		t.Errorf("expected MERGE_WHEN_MATCHED, got %d", wc.Kind)
+	if wc.MatchKind != nodes.MERGE_WHEN_MATCHED {
+		t.Errorf("expected MERGE_WHEN_MATCHED, got %d", wc.MatchKind)
 	}
 	if wc.CommandType != nodes.CMD_UPDATE {
 		t.Errorf("expected CMD_UPDATE, got %d", wc.CommandType)
@@ -58,8 +58,8 @@ func TestMergeInsertBasic(t *testing.T) {
 		t.Fatalf("expected 1 merge when clause")
 	}
 	wc := ms.MergeWhenClauses.Items[0].(*nodes.MergeWhenClause)
-	if wc.Kind != nodes.MERGE_WHEN_NOT_MATCHED_BY_TARGET {
-		t.Errorf("expected MERGE_WHEN_NOT_MATCHED_BY_TARGET, got %d", wc.Kind)
+	if wc.MatchKind != nodes.MERGE_WHEN_NOT_MATCHED_BY_TARGET {
+		t.Errorf("expected MERGE_WHEN_NOT_MATCHED_BY_TARGET, got %d", wc.MatchKind)
 	}
 	if wc.CommandType != nodes.CMD_INSERT {
 		t.Errorf("expected CMD_INSERT, got %d", wc.CommandType)
@@ -97,8 +97,8 @@ func TestMergeDoNothingMatched(t *testing.T) {
 	if wc.CommandType != nodes.CMD_NOTHING {
 		t.Errorf("expected CMD_NOTHING, got %d", wc.CommandType)
 	}
-	if wc.Kind != nodes.MERGE_WHEN_MATCHED {
-		t.Errorf("expected MERGE_WHEN_MATCHED, got %d", wc.Kind)
+	if wc.MatchKind != nodes.MERGE_WHEN_MATCHED {
+		t.Errorf("expected MERGE_WHEN_MATCHED, got %d", wc.MatchKind)
 	}
 }
 
@@ -112,8 +112,8 @@ func TestMergeDoNothingNotMatched(t *testing.T) {
 	if wc.CommandType != nodes.CMD_NOTHING {
 		t.Errorf("expected CMD_NOTHING, got %d", wc.CommandType)
 	}
-	if wc.Kind != nodes.MERGE_WHEN_NOT_MATCHED_BY_TARGET {
-		t.Errorf("expected MERGE_WHEN_NOT_MATCHED_BY_TARGET, got %d", wc.Kind)
+	if wc.MatchKind != nodes.MERGE_WHEN_NOT_MATCHED_BY_TARGET {
+		t.Errorf("expected MERGE_WHEN_NOT_MATCHED_BY_TARGET, got %d", wc.MatchKind)
 	}
 }
 
@@ -195,7 +195,7 @@ func TestCallNoArgs(t *testing.T) {
 	if cs.Funccall.Funcname == nil || len(cs.Funccall.Funcname.Items) != 1 {
 		t.Fatalf("expected 1 name component")
 	}
-	fname := cs.Funccall.Funcname.Items[0].(*nodes.String).Str
+	fname := cs.Funccall.Funcname.Items[0].(*nodes.String).Sval
 	if fname != "myfunc" {
 		t.Errorf("expected function name 'myfunc', got %q", fname)
 	}
@@ -250,7 +250,7 @@ func TestDoWithLanguage(t *testing.T) {
 	if de0.Defname != "language" {
 		t.Errorf("expected first arg Defname 'language', got %q", de0.Defname)
 	}
-	langStr := de0.Arg.(*nodes.String).Str
+	langStr := de0.Arg.(*nodes.String).Sval
 	if langStr != "plpgsql" {
 		t.Errorf("expected language 'plpgsql', got %q", langStr)
 	}
@@ -111,7 +111,7 @@ func TestDropOpClass(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.DropStmt, got %T", result.Items[0])
 	}
-	if stmt.RemoveType != int(nodes.OBJECT_OPCLASS) {
+	if stmt.RemoveType != nodes.OBJECT_OPCLASS {
 		t.Errorf("expected OBJECT_OPCLASS, got %d", stmt.RemoveType)
 	}
 }
@@ -123,10 +123,10 @@ func TestDropOpClassIfExists(t *testing.T) {
 		t.Fatalf("Parse error: %v", err)
 	}
 	stmt := result.Items[0].(*nodes.DropStmt)
-	if !stmt.Missing_ok {
+	if !stmt.MissingOk {
 		t.Error("expected Missing_ok to be true")
 	}
-	if stmt.Behavior != int(nodes.DROP_CASCADE) {
+	if stmt.Behavior != nodes.DROP_CASCADE {
 		t.Errorf("expected DROP_CASCADE, got %d", stmt.Behavior)
 	}
 }
@@ -138,7 +138,7 @@ func TestDropOpFamily(t *testing.T) {
 		t.Fatalf("Parse error: %v", err)
 	}
 	stmt := result.Items[0].(*nodes.DropStmt)
-	if stmt.RemoveType != int(nodes.OBJECT_OPFAMILY) {
+	if stmt.RemoveType != nodes.OBJECT_OPFAMILY {
 		t.Errorf("expected OBJECT_OPFAMILY, got %d", stmt.RemoveType)
 	}
 }
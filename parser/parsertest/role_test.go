@@ -178,7 +178,7 @@ func TestCreateRolePassword(t *testing.T) {
 		t.Errorf("expected option 'password', got %q", de.Defname)
 	}
 	sval, ok := de.Arg.(*nodes.String)
-	if !ok || sval.Str != "secret" {
+	if !ok || sval.Sval != "secret" {
 		t.Errorf("expected password='secret', got %v", de.Arg)
 	}
 }
@@ -208,7 +208,7 @@ func TestCreateRoleValidUntil(t *testing.T) {
 		t.Errorf("expected option 'validUntil', got %q", de.Defname)
 	}
 	sval, ok := de.Arg.(*nodes.String)
-	if !ok || sval.Str != "2025-12-31" {
+	if !ok || sval.Sval != "2025-12-31" {
 		t.Errorf("expected validUntil='2025-12-31', got %v", de.Arg)
 	}
 }
@@ -354,7 +354,7 @@ func TestAlterRolePassword(t *testing.T) {
 		t.Errorf("expected option 'password', got %q", de.Defname)
 	}
 	sval, ok := de.Arg.(*nodes.String)
-	if !ok || sval.Str != "newpass" {
+	if !ok || sval.Sval != "newpass" {
 		t.Errorf("expected password='newpass', got %v", de.Arg)
 	}
 }
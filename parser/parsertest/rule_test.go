@@ -163,8 +163,8 @@ func TestCreateTrustedLanguageWithHandler(t *testing.T) {
 	if stmt.Plhandler == nil || len(stmt.Plhandler.Items) != 1 {
 		t.Fatal("expected handler name")
 	}
-	if stmt.Plhandler.Items[0].(*nodes.String).Str != "plpgsql_call_handler" {
-		t.Errorf("expected handler 'plpgsql_call_handler', got %q", stmt.Plhandler.Items[0].(*nodes.String).Str)
+	if stmt.Plhandler.Items[0].(*nodes.String).Sval != "plpgsql_call_handler" {
+		t.Errorf("expected handler 'plpgsql_call_handler', got %q", stmt.Plhandler.Items[0].(*nodes.String).Sval)
 	}
 	if stmt.Plinline == nil || len(stmt.Plinline.Items) != 1 {
 		t.Fatal("expected inline handler name")
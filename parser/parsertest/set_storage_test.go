@@ -25,8 +25,8 @@ func TestAlterColumnSetStoragePlain(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String for Def, got %T", cmd.Def)
 	}
-	if str.Str != "plain" {
-		t.Errorf("expected storage mode 'plain', got %q", str.Str)
+	if str.Sval != "plain" {
+		t.Errorf("expected storage mode 'plain', got %q", str.Sval)
 	}
 }
 
@@ -45,8 +45,8 @@ func TestAlterColumnSetStorageExternal(t *testing.T) {
 		t.Fatal("expected Def to be set")
 	}
 	str := cmd.Def.(*nodes.String)
-	if str.Str != "external" {
-		t.Errorf("expected storage mode 'external', got %q", str.Str)
+	if str.Sval != "external" {
+		t.Errorf("expected storage mode 'external', got %q", str.Sval)
 	}
 }
 
@@ -65,7 +65,7 @@ func TestAlterColumnSetStorageDefault(t *testing.T) {
 		t.Fatal("expected Def to be set")
 	}
 	str := cmd.Def.(*nodes.String)
-	if str.Str != "default" {
-		t.Errorf("expected storage mode 'default', got %q", str.Str)
+	if str.Sval != "default" {
+		t.Errorf("expected storage mode 'default', got %q", str.Sval)
 	}
 }
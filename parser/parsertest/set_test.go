@@ -257,8 +257,8 @@ func TestSetXmlOptionContent(t *testing.T) {
 		ac, ok := vs.Args.Items[0].(*nodes.A_Const)
 		if ok {
 			s, ok := ac.Val.(*nodes.String)
-			if ok && s.Str != "CONTENT" {
-				t.Errorf("expected xmloption arg 'CONTENT', got %q", s.Str)
+			if ok && s.Sval != "CONTENT" {
+				t.Errorf("expected xmloption arg 'CONTENT', got %q", s.Sval)
 			}
 		}
 	}
@@ -503,7 +503,7 @@ func TestDeallocateName(t *testing.T) {
 	if ds.Name != "p" {
 		t.Errorf("expected Name 'p', got %q", ds.Name)
 	}
-	if ds.IsAll {
+	if ds.Isall {
 		t.Errorf("expected IsAll false")
 	}
 }
@@ -517,7 +517,7 @@ func TestDeallocatePrepareName(t *testing.T) {
 	if ds.Name != "p" {
 		t.Errorf("expected Name 'p', got %q", ds.Name)
 	}
-	if ds.IsAll {
+	if ds.Isall {
 		t.Errorf("expected IsAll false")
 	}
 }
@@ -528,7 +528,7 @@ func TestDeallocateAll(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.DeallocateStmt, got %T", stmt)
 	}
-	if !ds.IsAll {
+	if !ds.Isall {
 		t.Errorf("expected IsAll true")
 	}
 }
@@ -539,7 +539,7 @@ func TestDeallocatePrepareAll(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.DeallocateStmt, got %T", stmt)
 	}
-	if !ds.IsAll {
+	if !ds.Isall {
 		t.Errorf("expected IsAll true")
 	}
 }
@@ -0,0 +1,73 @@
+package parsertest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+	"github.com/pgplex/pgparser/parser/pgregress"
+)
+
+// TestShapeGolden compares the trees of the statements in
+// testdata/shape.sql with PostgreSQL's in testdata/shape.golden, written by
+// tools/pg_tree_golden in pg_query's JSON form: the type names and
+// relations must match PostgreSQL's raw parser. Locations are left out of
+// the comparison, since the parser does not track them everywhere yet.
+func TestShapeGolden(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "shape.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden, err := os.ReadFile(filepath.Join("testdata", "shape.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := strings.Split(strings.TrimSuffix(string(golden), "\n\n"), "\n\n")
+	stmts := pgregress.ExtractStatements("shape.sql", content)
+	if len(entries) != len(stmts) {
+		t.Fatalf("shape.golden has %d trees, shape.sql has %d statements", len(entries), len(stmts))
+	}
+	for i, stmt := range stmts {
+		sql, tree, _ := strings.Cut(entries[i], "\n")
+		if !strings.HasSuffix(stmt.SQL, sql) {
+			t.Fatalf("line %d: shape.golden has the tree of %q", stmt.StartLine, sql)
+		}
+		want, err := pgregress.CanonicalTree([]byte(tree), true)
+		if err != nil {
+			t.Fatalf("line %d: shape.golden: %v", stmt.StartLine, err)
+		}
+		list, err := parser.Parse(stmt.SQL)
+		if err != nil {
+			t.Fatalf("line %d: %v", stmt.StartLine, err)
+		}
+		got, err := pgregress.CanonicalTree([]byte(nodes.NodeToJSON(list.Items[0])), true)
+		if err != nil {
+			t.Fatalf("line %d: %v", stmt.StartLine, err)
+		}
+		if got != want {
+			t.Errorf("line %d: tree differs from PostgreSQL's:\ngot  %s\nwant %s", stmt.StartLine, got, want)
+		}
+	}
+}
+
+func TestShapeErrors(t *testing.T) {
+	tests := []struct {
+		sql, want string
+	}{
+		{"SELECT * FROM a.b.c.d", "improper qualified name (too many dotted names): a.b.c.d"},
+		{"SELECT * FROM a.b[1]", "syntax error"},
+		{"SELECT foo(a => 1) 'x'", "type modifier cannot have parameter name"},
+		{"SELECT foo(1 ORDER BY 1) 'x'", "type modifier cannot have ORDER BY"},
+		{"SELECT 1::float(0)", "precision for type float must be at least 1 bit"},
+		{"SELECT 1::float(54)", "precision for type float must be less than 54 bits"},
+	}
+	for _, tt := range tests {
+		_, err := parser.Parse(tt.sql)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Parse(%q) = %v, want error containing %q", tt.sql, err, tt.want)
+		}
+	}
+}
@@ -90,8 +90,8 @@ func TestAlterStatistics(t *testing.T) {
 	if stmt.Defnames == nil {
 		t.Fatal("expected non-nil Defnames")
 	}
-	if stmt.Stxstattarget != 100 {
-		t.Errorf("expected stxstattarget 100, got %d", stmt.Stxstattarget)
+	if iv, ok := stmt.Stxstattarget.(*nodes.Integer); !ok || iv.Ival != 100 {
+		t.Errorf("expected stxstattarget 100, got %v", stmt.Stxstattarget)
 	}
 	if stmt.MissingOk {
 		t.Error("expected MissingOk to be false")
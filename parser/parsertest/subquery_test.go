@@ -41,7 +41,7 @@ func TestParseScalarSubquery(t *testing.T) {
 		t.Fatalf("expected *nodes.SubLink, got %T", rt.Val)
 	}
 
-	if sublink.SubLinkType != int(nodes.EXPR_SUBLINK) {
+	if sublink.SubLinkType != nodes.EXPR_SUBLINK {
 		t.Errorf("expected EXPR_SUBLINK (%d), got %d", int(nodes.EXPR_SUBLINK), sublink.SubLinkType)
 	}
 
@@ -99,7 +99,7 @@ func TestParseExistsSubquery(t *testing.T) {
 		t.Fatalf("expected *nodes.SubLink in where clause, got %T", stmt.WhereClause)
 	}
 
-	if sublink.SubLinkType != int(nodes.EXISTS_SUBLINK) {
+	if sublink.SubLinkType != nodes.EXISTS_SUBLINK {
 		t.Errorf("expected EXISTS_SUBLINK (%d), got %d", int(nodes.EXISTS_SUBLINK), sublink.SubLinkType)
 	}
 
@@ -147,7 +147,7 @@ func TestParseInSubquery(t *testing.T) {
 	}
 
 	// IN subquery becomes ANY_SUBLINK
-	if sublink.SubLinkType != int(nodes.ANY_SUBLINK) {
+	if sublink.SubLinkType != nodes.ANY_SUBLINK {
 		t.Errorf("expected ANY_SUBLINK (%d), got %d", int(nodes.ANY_SUBLINK), sublink.SubLinkType)
 	}
 
@@ -160,8 +160,8 @@ func TestParseInSubquery(t *testing.T) {
 		t.Fatalf("expected *nodes.ColumnRef for Testexpr, got %T", sublink.Testexpr)
 	}
 	str := colRef.Fields.Items[0].(*nodes.String)
-	if str.Str != "id" {
-		t.Errorf("expected Testexpr column 'id', got %q", str.Str)
+	if str.Sval != "id" {
+		t.Errorf("expected Testexpr column 'id', got %q", str.Sval)
 	}
 
 	// Subselect should be present
@@ -216,7 +216,7 @@ func TestParseNotInSubquery(t *testing.T) {
 		t.Fatalf("expected *nodes.SubLink wrapped by NOT, got %T", boolExpr.Args.Items[0])
 	}
 
-	if sublink.SubLinkType != int(nodes.ANY_SUBLINK) {
+	if sublink.SubLinkType != nodes.ANY_SUBLINK {
 		t.Errorf("expected ANY_SUBLINK (%d), got %d", int(nodes.ANY_SUBLINK), sublink.SubLinkType)
 	}
 
@@ -229,8 +229,8 @@ func TestParseNotInSubquery(t *testing.T) {
 		t.Fatalf("expected *nodes.ColumnRef for Testexpr, got %T", sublink.Testexpr)
 	}
 	str := colRef.Fields.Items[0].(*nodes.String)
-	if str.Str != "id" {
-		t.Errorf("expected Testexpr column 'id', got %q", str.Str)
+	if str.Sval != "id" {
+		t.Errorf("expected Testexpr column 'id', got %q", str.Sval)
 	}
 
 	// Subselect should be present
@@ -258,7 +258,7 @@ func TestParseEqualAnySubquery(t *testing.T) {
 		t.Fatalf("expected *nodes.SubLink in where clause, got %T", stmt.WhereClause)
 	}
 
-	if sublink.SubLinkType != int(nodes.ANY_SUBLINK) {
+	if sublink.SubLinkType != nodes.ANY_SUBLINK {
 		t.Errorf("expected ANY_SUBLINK (%d), got %d", int(nodes.ANY_SUBLINK), sublink.SubLinkType)
 	}
 
@@ -271,8 +271,8 @@ func TestParseEqualAnySubquery(t *testing.T) {
 		t.Fatalf("expected *nodes.ColumnRef for Testexpr, got %T", sublink.Testexpr)
 	}
 	str := colRef.Fields.Items[0].(*nodes.String)
-	if str.Str != "id" {
-		t.Errorf("expected Testexpr column 'id', got %q", str.Str)
+	if str.Sval != "id" {
+		t.Errorf("expected Testexpr column 'id', got %q", str.Sval)
 	}
 
 	// OperName should be "="
@@ -283,8 +283,8 @@ func TestParseEqualAnySubquery(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String in OperName, got %T", sublink.OperName.Items[0])
 	}
-	if opStr.Str != "=" {
-		t.Errorf("expected OperName '=', got %q", opStr.Str)
+	if opStr.Sval != "=" {
+		t.Errorf("expected OperName '=', got %q", opStr.Sval)
 	}
 
 	// Subselect should be present
@@ -312,7 +312,7 @@ func TestParseEqualAllSubquery(t *testing.T) {
 		t.Fatalf("expected *nodes.SubLink in where clause, got %T", stmt.WhereClause)
 	}
 
-	if sublink.SubLinkType != int(nodes.ALL_SUBLINK) {
+	if sublink.SubLinkType != nodes.ALL_SUBLINK {
 		t.Errorf("expected ALL_SUBLINK (%d), got %d", int(nodes.ALL_SUBLINK), sublink.SubLinkType)
 	}
 
@@ -326,8 +326,8 @@ func TestParseEqualAllSubquery(t *testing.T) {
 		t.Fatalf("expected 1 item in OperName")
 	}
 	opStr := sublink.OperName.Items[0].(*nodes.String)
-	if opStr.Str != "=" {
-		t.Errorf("expected OperName '=', got %q", opStr.Str)
+	if opStr.Sval != "=" {
+		t.Errorf("expected OperName '=', got %q", opStr.Sval)
 	}
 
 	// Subselect should be present
@@ -355,7 +355,7 @@ func TestParseInSubqueryWithLimit(t *testing.T) {
 		t.Fatalf("expected *nodes.SubLink in where clause, got %T", stmt.WhereClause)
 	}
 
-	if sublink.SubLinkType != int(nodes.ANY_SUBLINK) {
+	if sublink.SubLinkType != nodes.ANY_SUBLINK {
 		t.Errorf("expected ANY_SUBLINK (%d), got %d", int(nodes.ANY_SUBLINK), sublink.SubLinkType)
 	}
 
@@ -402,7 +402,7 @@ func TestParseGreaterThanSomeSubquery(t *testing.T) {
 	}
 
 	// SOME is the same as ANY
-	if sublink.SubLinkType != int(nodes.ANY_SUBLINK) {
+	if sublink.SubLinkType != nodes.ANY_SUBLINK {
 		t.Errorf("expected ANY_SUBLINK (%d), got %d", int(nodes.ANY_SUBLINK), sublink.SubLinkType)
 	}
 
@@ -411,8 +411,8 @@ func TestParseGreaterThanSomeSubquery(t *testing.T) {
 		t.Fatal("expected OperName to be set")
 	}
 	opStr := sublink.OperName.Items[0].(*nodes.String)
-	if opStr.Str != ">" {
-		t.Errorf("expected OperName '>', got %q", opStr.Str)
+	if opStr.Sval != ">" {
+		t.Errorf("expected OperName '>', got %q", opStr.Sval)
 	}
 }
 
@@ -435,7 +435,7 @@ func TestParseLessThanAllSubquery(t *testing.T) {
 		t.Fatalf("expected *nodes.SubLink in where clause, got %T", stmt.WhereClause)
 	}
 
-	if sublink.SubLinkType != int(nodes.ALL_SUBLINK) {
+	if sublink.SubLinkType != nodes.ALL_SUBLINK {
 		t.Errorf("expected ALL_SUBLINK (%d), got %d", int(nodes.ALL_SUBLINK), sublink.SubLinkType)
 	}
 
@@ -444,8 +444,8 @@ func TestParseLessThanAllSubquery(t *testing.T) {
 		t.Fatal("expected OperName to be set")
 	}
 	opStr := sublink.OperName.Items[0].(*nodes.String)
-	if opStr.Str != "<" {
-		t.Errorf("expected OperName '<', got %q", opStr.Str)
+	if opStr.Sval != "<" {
+		t.Errorf("expected OperName '<', got %q", opStr.Sval)
 	}
 }
 
@@ -468,7 +468,7 @@ func TestParseNotEqualsAnySubquery(t *testing.T) {
 		t.Fatalf("expected *nodes.SubLink in where clause, got %T", stmt.WhereClause)
 	}
 
-	if sublink.SubLinkType != int(nodes.ANY_SUBLINK) {
+	if sublink.SubLinkType != nodes.ANY_SUBLINK {
 		t.Errorf("expected ANY_SUBLINK (%d), got %d", int(nodes.ANY_SUBLINK), sublink.SubLinkType)
 	}
 
@@ -477,8 +477,8 @@ func TestParseNotEqualsAnySubquery(t *testing.T) {
 		t.Fatal("expected OperName to be set")
 	}
 	opStr := sublink.OperName.Items[0].(*nodes.String)
-	if opStr.Str != "<>" {
-		t.Errorf("expected OperName '<>', got %q", opStr.Str)
+	if opStr.Sval != "<>" {
+		t.Errorf("expected OperName '<>', got %q", opStr.Sval)
 	}
 }
 
@@ -517,7 +517,7 @@ func TestParseExistsWithAnd(t *testing.T) {
 		t.Fatalf("expected *nodes.SubLink as second AND arg, got %T", boolExpr.Args.Items[1])
 	}
 
-	if sublink.SubLinkType != int(nodes.EXISTS_SUBLINK) {
+	if sublink.SubLinkType != nodes.EXISTS_SUBLINK {
 		t.Errorf("expected EXISTS_SUBLINK (%d), got %d", int(nodes.EXISTS_SUBLINK), sublink.SubLinkType)
 	}
 }
@@ -555,7 +555,7 @@ func TestParseScalarSubqueryInExpression(t *testing.T) {
 		t.Fatalf("expected *nodes.SubLink on left side, got %T", aexpr.Lexpr)
 	}
 
-	if sublink.SubLinkType != int(nodes.EXPR_SUBLINK) {
+	if sublink.SubLinkType != nodes.EXPR_SUBLINK {
 		t.Errorf("expected EXPR_SUBLINK (%d), got %d", int(nodes.EXPR_SUBLINK), sublink.SubLinkType)
 	}
 
@@ -34,7 +34,7 @@ func TestCreateSubscriptionBasic(t *testing.T) {
 	if stmt.Publication == nil || stmt.Publication.Len() != 1 {
 		t.Fatalf("expected 1 publication, got %d", stmt.Publication.Len())
 	}
-	pubName := stmt.Publication.Items[0].(*nodes.String).Str
+	pubName := stmt.Publication.Items[0].(*nodes.String).Sval
 	if pubName != "pub1" {
 		t.Errorf("expected publication 'pub1', got %q", pubName)
 	}
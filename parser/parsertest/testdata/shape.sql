@@ -0,0 +1,10 @@
+-- Type names and their default modifiers
+CREATE TABLE t (a char, b char(3), c varchar, d varchar(10), e bit, f bit varying, g nchar, h national character varying(2), i json, j float(10), k float(30));
+SELECT char 'x', bit '1', varchar(2) 'y', json '{}', foo(3) 'z';
+SELECT CAST(a AS char), a::bit, a::character varying;
+SELECT interval '1' day, interval(3) '1', interval '1' day to second(2), timestamp(2) '2000-01-01';
+-- Qualified names become RangeVars
+SELECT * FROM s.t, c.s.u;
+DELETE FROM c.s.t;
+UPDATE ONLY s.t SET a = 1;
+CREATE TABLE s.c (a int) INHERITS (s.p, q);
@@ -136,8 +136,8 @@ func TestTransactionSavepoint(t *testing.T) {
 	if stmt.Kind != nodes.TRANS_STMT_SAVEPOINT {
 		t.Errorf("expected Kind TRANS_STMT_SAVEPOINT (%d), got %d", nodes.TRANS_STMT_SAVEPOINT, stmt.Kind)
 	}
-	if stmt.Savepoint != "sp1" {
-		t.Errorf("expected Savepoint 'sp1', got %q", stmt.Savepoint)
+	if stmt.SavepointName != "sp1" {
+		t.Errorf("expected Savepoint 'sp1', got %q", stmt.SavepointName)
 	}
 }
 
@@ -148,8 +148,8 @@ func TestTransactionReleaseSavepoint(t *testing.T) {
 	if stmt.Kind != nodes.TRANS_STMT_RELEASE {
 		t.Errorf("expected Kind TRANS_STMT_RELEASE (%d), got %d", nodes.TRANS_STMT_RELEASE, stmt.Kind)
 	}
-	if stmt.Savepoint != "sp1" {
-		t.Errorf("expected Savepoint 'sp1', got %q", stmt.Savepoint)
+	if stmt.SavepointName != "sp1" {
+		t.Errorf("expected Savepoint 'sp1', got %q", stmt.SavepointName)
 	}
 }
 
@@ -160,8 +160,8 @@ func TestTransactionRelease(t *testing.T) {
 	if stmt.Kind != nodes.TRANS_STMT_RELEASE {
 		t.Errorf("expected Kind TRANS_STMT_RELEASE (%d), got %d", nodes.TRANS_STMT_RELEASE, stmt.Kind)
 	}
-	if stmt.Savepoint != "sp1" {
-		t.Errorf("expected Savepoint 'sp1', got %q", stmt.Savepoint)
+	if stmt.SavepointName != "sp1" {
+		t.Errorf("expected Savepoint 'sp1', got %q", stmt.SavepointName)
 	}
 }
 
@@ -172,8 +172,8 @@ func TestTransactionRollbackToSavepoint(t *testing.T) {
 	if stmt.Kind != nodes.TRANS_STMT_ROLLBACK_TO {
 		t.Errorf("expected Kind TRANS_STMT_ROLLBACK_TO (%d), got %d", nodes.TRANS_STMT_ROLLBACK_TO, stmt.Kind)
 	}
-	if stmt.Savepoint != "sp1" {
-		t.Errorf("expected Savepoint 'sp1', got %q", stmt.Savepoint)
+	if stmt.SavepointName != "sp1" {
+		t.Errorf("expected Savepoint 'sp1', got %q", stmt.SavepointName)
 	}
 }
 
@@ -184,8 +184,8 @@ func TestTransactionRollbackTo(t *testing.T) {
 	if stmt.Kind != nodes.TRANS_STMT_ROLLBACK_TO {
 		t.Errorf("expected Kind TRANS_STMT_ROLLBACK_TO (%d), got %d", nodes.TRANS_STMT_ROLLBACK_TO, stmt.Kind)
 	}
-	if stmt.Savepoint != "sp1" {
-		t.Errorf("expected Savepoint 'sp1', got %q", stmt.Savepoint)
+	if stmt.SavepointName != "sp1" {
+		t.Errorf("expected Savepoint 'sp1', got %q", stmt.SavepointName)
 	}
 }
 
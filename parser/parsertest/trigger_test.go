@@ -37,7 +37,7 @@ func TestCreateTriggerBeforeInsert(t *testing.T) {
 	if stmt.Relation == nil || stmt.Relation.Relname != "t" {
 		t.Error("expected relation 't'")
 	}
-	if stmt.IsConstraint {
+	if stmt.Isconstraint {
 		t.Error("expected IsConstraint to be false")
 	}
 }
@@ -154,7 +154,7 @@ func TestCreateConstraintTrigger(t *testing.T) {
 		t.Fatalf("Parse error: %v", err)
 	}
 	stmt := result.Items[0].(*nodes.CreateTrigStmt)
-	if !stmt.IsConstraint {
+	if !stmt.Isconstraint {
 		t.Error("expected IsConstraint to be true")
 	}
 	if !stmt.Deferrable {
@@ -57,8 +57,8 @@ func assertTypeNamePgCatalog(t *testing.T, tn *nodes.TypeName, expectedName stri
 		t.Fatalf("expected 2-element Names list (pg_catalog.%s), got %d items",
 			expectedName, listLen(tn.Names))
 	}
-	schema := tn.Names.Items[0].(*nodes.String).Str
-	typname := tn.Names.Items[1].(*nodes.String).Str
+	schema := tn.Names.Items[0].(*nodes.String).Sval
+	typname := tn.Names.Items[1].(*nodes.String).Sval
 	if schema != "pg_catalog" {
 		t.Errorf("expected schema 'pg_catalog', got %q", schema)
 	}
@@ -77,7 +77,7 @@ func assertTypeNameGeneric(t *testing.T, tn *nodes.TypeName, expectedName string
 		t.Fatalf("expected 1-element Names list for generic type %q, got %d items",
 			expectedName, listLen(tn.Names))
 	}
-	typname := tn.Names.Items[0].(*nodes.String).Str
+	typname := tn.Names.Items[0].(*nodes.String).Sval
 	if typname != expectedName {
 		t.Errorf("expected type name %q, got %q", expectedName, typname)
 	}
@@ -144,8 +144,8 @@ func TestVarcharCast(t *testing.T) {
 		t.Fatal("expected 1 field in ColumnRef")
 	}
 	str := colRef.Fields.Items[0].(*nodes.String)
-	if str.Str != "x" {
-		t.Errorf("expected column 'x', got %q", str.Str)
+	if str.Sval != "x" {
+		t.Errorf("expected column 'x', got %q", str.Sval)
 	}
 
 	// TypeName should be pg_catalog.varchar with Typmods [100]
@@ -153,9 +153,13 @@ func TestVarcharCast(t *testing.T) {
 	if tc.TypeName.Typmods == nil || len(tc.TypeName.Typmods.Items) != 1 {
 		t.Fatalf("expected 1 typmod for varchar(100), got %d", listLen(tc.TypeName.Typmods))
 	}
-	typmod, ok := tc.TypeName.Typmods.Items[0].(*nodes.Integer)
+	ac, ok := tc.TypeName.Typmods.Items[0].(*nodes.A_Const)
 	if !ok {
-		t.Fatalf("expected *nodes.Integer for typmod, got %T", tc.TypeName.Typmods.Items[0])
+		t.Fatalf("expected *nodes.A_Const for typmod, got %T", tc.TypeName.Typmods.Items[0])
+	}
+	typmod, ok := ac.Val.(*nodes.Integer)
+	if !ok {
+		t.Fatalf("expected *nodes.Integer typmod value, got %T", ac.Val)
 	}
 	if typmod.Ival != 100 {
 		t.Errorf("expected typmod 100, got %d", typmod.Ival)
@@ -255,8 +259,8 @@ func TestGenericTypeCast(t *testing.T) {
 		t.Fatalf("expected *nodes.ColumnRef for arg, got %T", tc.Arg)
 	}
 	str := colRef.Fields.Items[0].(*nodes.String)
-	if str.Str != "x" {
-		t.Errorf("expected column 'x', got %q", str.Str)
+	if str.Sval != "x" {
+		t.Errorf("expected column 'x', got %q", str.Sval)
 	}
 
 	// TypeName should be generic: Names=["text"]
@@ -286,8 +290,8 @@ func TestChainedCasts(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String, got %T", aConst.Val)
 	}
-	if strVal.Str != "2024-01-01" {
-		t.Errorf("expected string '2024-01-01', got %q", strVal.Str)
+	if strVal.Sval != "2024-01-01" {
+		t.Errorf("expected string '2024-01-01', got %q", strVal.Sval)
 	}
 }
 
@@ -307,7 +311,7 @@ func TestCastInWhere(t *testing.T) {
 	if aexpr.Kind != nodes.AEXPR_OP {
 		t.Errorf("expected AEXPR_OP, got %d", aexpr.Kind)
 	}
-	opStr := aexpr.Name.Items[0].(*nodes.String).Str
+	opStr := aexpr.Name.Items[0].(*nodes.String).Sval
 	if opStr != ">" {
 		t.Errorf("expected operator '>', got %q", opStr)
 	}
@@ -321,8 +325,8 @@ func TestCastInWhere(t *testing.T) {
 		t.Fatalf("expected *nodes.ColumnRef for cast arg, got %T", tc.Arg)
 	}
 	str := colRef.Fields.Items[0].(*nodes.String)
-	if str.Str != "x" {
-		t.Errorf("expected column 'x', got %q", str.Str)
+	if str.Sval != "x" {
+		t.Errorf("expected column 'x', got %q", str.Sval)
 	}
 
 	// Right side should be A_Const(5)
@@ -374,7 +378,7 @@ func TestCharCast(t *testing.T) {
 	if tc.TypeName.Typmods == nil || len(tc.TypeName.Typmods.Items) != 1 {
 		t.Fatalf("expected 1 typmod for char(10), got %d", listLen(tc.TypeName.Typmods))
 	}
-	typmod := tc.TypeName.Typmods.Items[0].(*nodes.Integer)
+	typmod := tc.TypeName.Typmods.Items[0].(*nodes.A_Const).Val.(*nodes.Integer)
 	if typmod.Ival != 10 {
 		t.Errorf("expected typmod 10, got %d", typmod.Ival)
 	}
@@ -389,7 +393,7 @@ func TestCharacterVaryingCast(t *testing.T) {
 	if tc.TypeName.Typmods == nil || len(tc.TypeName.Typmods.Items) != 1 {
 		t.Fatalf("expected 1 typmod for character varying(50), got %d", listLen(tc.TypeName.Typmods))
 	}
-	typmod := tc.TypeName.Typmods.Items[0].(*nodes.Integer)
+	typmod := tc.TypeName.Typmods.Items[0].(*nodes.A_Const).Val.(*nodes.Integer)
 	if typmod.Ival != 50 {
 		t.Errorf("expected typmod 50, got %d", typmod.Ival)
 	}
@@ -481,8 +485,8 @@ func TestCastStringToVarchar(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String, got %T", aConst.Val)
 	}
-	if strVal.Str != "hello" {
-		t.Errorf("expected 'hello', got %q", strVal.Str)
+	if strVal.Sval != "hello" {
+		t.Errorf("expected 'hello', got %q", strVal.Sval)
 	}
 
 	// TypeName should be pg_catalog.varchar with Typmods [10]
@@ -490,7 +494,7 @@ func TestCastStringToVarchar(t *testing.T) {
 	if tc.TypeName.Typmods == nil || len(tc.TypeName.Typmods.Items) != 1 {
 		t.Fatalf("expected 1 typmod, got %d", listLen(tc.TypeName.Typmods))
 	}
-	typmod := tc.TypeName.Typmods.Items[0].(*nodes.Integer)
+	typmod := tc.TypeName.Typmods.Items[0].(*nodes.A_Const).Val.(*nodes.Integer)
 	if typmod.Ival != 10 {
 		t.Errorf("expected typmod 10, got %d", typmod.Ival)
 	}
@@ -55,8 +55,8 @@ func TestUpdateBasic(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String, got %T", aConst.Val)
 	}
-	if strVal.Str != "new" {
-		t.Errorf("expected 'new', got %q", strVal.Str)
+	if strVal.Sval != "new" {
+		t.Errorf("expected 'new', got %q", strVal.Sval)
 	}
 
 	// Verify WHERE clause is set
@@ -214,8 +214,8 @@ func TestUpdateReturning(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String, got %T", colRef1.Fields.Items[0])
 	}
-	if str1.Str != "id" {
-		t.Errorf("expected 'id', got %q", str1.Str)
+	if str1.Sval != "id" {
+		t.Errorf("expected 'id', got %q", str1.Sval)
 	}
 
 	// Second returning item: name
@@ -231,8 +231,8 @@ func TestUpdateReturning(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected *nodes.String, got %T", colRef2.Fields.Items[0])
 	}
-	if str2.Str != "name" {
-		t.Errorf("expected 'name', got %q", str2.Str)
+	if str2.Sval != "name" {
+		t.Errorf("expected 'name', got %q", str2.Sval)
 	}
 }
 
@@ -226,12 +226,14 @@ func splitStatements(filename string, lines []processedLine) []ExtractedStmt {
 	inCopyData := false
 	parenDepth := 0         // tracks nesting depth of (...) in normal SQL
 	atomicDepth := 0        // tracks nesting depth of BEGIN ATOMIC ... END blocks
+	caseDepth := 0          // tracks nesting depth of CASE ... END inside them
 	lastWord := ""          // previous completed word (uppercase)
 	currentWord := []byte{} // word currently being accumulated
 
 	// finishWord is called when a non-identifier character is seen in stNormal.
 	// It checks whether the last two words form "BEGIN ATOMIC" or the current
-	// word is "END" to manage atomicDepth.
+	// word is "END" to manage atomicDepth. Inside a block, an END may close
+	// a CASE instead.
 	finishWord := func() {
 		if len(currentWord) == 0 {
 			return
@@ -239,9 +241,14 @@ func splitStatements(filename string, lines []processedLine) []ExtractedStmt {
 		word := strings.ToUpper(string(currentWord))
 		currentWord = currentWord[:0]
 
-		if word == "ATOMIC" && lastWord == "BEGIN" {
+		switch {
+		case word == "ATOMIC" && lastWord == "BEGIN":
 			atomicDepth++
-		} else if word == "END" && atomicDepth > 0 && parenDepth == 0 {
+		case word == "CASE" && atomicDepth > 0:
+			caseDepth++
+		case word == "END" && caseDepth > 0:
+			caseDepth--
+		case word == "END" && atomicDepth > 0 && parenDepth == 0:
 			atomicDepth--
 		}
 		lastWord = word
@@ -267,6 +274,7 @@ func splitStatements(filename string, lines []processedLine) []ExtractedStmt {
 		stmtStartLine = 0
 		parenDepth = 0
 		atomicDepth = 0
+		caseDepth = 0
 		lastWord = ""
 		currentWord = currentWord[:0]
 	}
@@ -589,6 +597,26 @@ func ContainsPsqlVariable(sql string) bool {
 	return replaced
 }
 
+// ParseSQL returns the text of the statement to parse: its SQL with psql
+// variables replaced by ReplacePsqlVariables. A statement that is just a
+// variable, or an EXPLAIN of one, becomes SELECT 1 in its place.
+func (s ExtractedStmt) ParseSQL() string {
+	if !s.HasPsqlVar {
+		return s.SQL
+	}
+	sql, _ := ReplacePsqlVariables(s.SQL)
+	trimmed := strings.TrimSpace(sql)
+	if trimmed == "psql_var" || trimmed == "psql_var;" {
+		return "SELECT 1"
+	}
+	expIdx := strings.Index(strings.ToUpper(sql), "EXPLAIN")
+	varIdx := strings.Index(sql, "psql_var")
+	if expIdx >= 0 && varIdx > expIdx {
+		sql = strings.Replace(sql, "psql_var", "SELECT 1", 1)
+	}
+	return sql
+}
+
 // ReplacePsqlVariables replaces psql variable interpolation patterns with
 // valid SQL placeholders, allowing the statement to be parsed for grammar checking.
 // Returns the sanitized SQL and true if any replacements were made.
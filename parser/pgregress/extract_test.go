@@ -133,6 +133,17 @@ $$ LANGUAGE plpgsql;`
 	}
 }
 
+func TestExtract_BeginAtomicWithCase(t *testing.T) {
+	input := "CREATE FUNCTION f(x int) RETURNS boolean LANGUAGE SQL\nBEGIN ATOMIC\n  SELECT CASE WHEN x > 0 THEN true ELSE false END;\nEND;\nSELECT 1;"
+	stmts := ExtractStatements("test.sql", []byte(input))
+	if len(stmts) != 2 {
+		t.Fatalf("expected 2 statements, got %d", len(stmts))
+	}
+	if stmts[1].SQL != "SELECT 1" {
+		t.Errorf("stmt[1] = %q, want %q", stmts[1].SQL, "SELECT 1")
+	}
+}
+
 func TestExtract_PsqlMetacommand(t *testing.T) {
 	input := "\\set foo bar\nSELECT 1;"
 	stmts := ExtractStatements("test.sql", []byte(input))
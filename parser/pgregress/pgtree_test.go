@@ -0,0 +1,145 @@
+package pgregress
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+)
+
+// goldenDir holds PostgreSQL's raw parse tree of the statements of
+// testdata/sql, one <name>.json per file, written by tools/pg_tree_golden.
+// The trees are pg_query_go's output: PostgreSQL 17.4's own grammar, with
+// its raw parse trees written in pg_query's JSON form rather than by
+// nodeToString. It writes an empty string field that PostgreSQL set to ""
+// but not one left NULL, which a Go string cannot tell apart, so the
+// statements with such a field are the known mismatches left.
+const goldenDir = "testdata/pgtrees"
+
+// KnownMismatches maps filename -> sorted list of 0-based statement indices
+// whose tree differs from PostgreSQL's.
+type KnownMismatches map[string][]int
+
+// TestPGTreeGolden compares the tree of every statement that has a golden
+// tree with PostgreSQL's, locations included, failing on new mismatches and
+// on known ones that now match. Run with -update to regenerate
+// known_mismatches.json.
+func TestPGTreeGolden(t *testing.T) {
+	goldens, err := filepath.Glob(filepath.Join(goldenDir, "*.json"))
+	if err != nil {
+		t.Fatalf("glob: %v", err)
+	}
+	if len(goldens) == 0 {
+		t.Fatalf("no golden trees in %s; generate them with tools/pg_tree_golden", goldenDir)
+	}
+
+	known := KnownMismatches{}
+	if data, err := os.ReadFile("known_mismatches.json"); err == nil {
+		if err := json.Unmarshal(data, &known); err != nil {
+			t.Fatalf("parse known_mismatches.json: %v", err)
+		}
+	} else if !os.IsNotExist(err) {
+		t.Fatalf("read known_mismatches.json: %v", err)
+	}
+
+	mismatches := KnownMismatches{}
+	var total, matched int
+	sort.Strings(goldens)
+	for _, golden := range goldens {
+		base := strings.TrimSuffix(filepath.Base(golden), ".json") + ".sql"
+		data, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatalf("read %s: %v", golden, err)
+		}
+		var want map[int]json.RawMessage
+		if err := json.Unmarshal(data, &want); err != nil {
+			t.Fatalf("parse %s: %v", golden, err)
+		}
+		content, err := os.ReadFile(filepath.Join("testdata", "sql", base))
+		if err != nil {
+			t.Fatalf("read %s: %v", base, err)
+		}
+		stmts := ExtractStatements(base, content)
+
+		indices := make([]int, 0, len(want))
+		for i := range want {
+			indices = append(indices, i)
+		}
+		sort.Ints(indices)
+		for _, i := range indices {
+			if i >= len(stmts) {
+				t.Errorf("%s: golden tree for stmt[%d], but the file has %d statements", base, i, len(stmts))
+				continue
+			}
+			total++
+			pg := canonicalTree(t, want[i], false)
+			got := "<parse error>"
+			if list, err := parser.Parse(stmts[i].SQL); err == nil && list != nil && len(list.Items) == 1 {
+				got = canonicalTree(t, []byte(nodes.NodeToJSON(list.Items[0])), false)
+			}
+			isKnown := intSliceContains(known[base], i)
+			if got == pg {
+				matched++
+				if isKnown && !*update {
+					t.Errorf("FIXED %s stmt[%d] line %d now matches, remove from known_mismatches", base, i, stmts[i].StartLine)
+				}
+				continue
+			}
+			mismatches[base] = append(mismatches[base], i)
+			if !isKnown && !*update {
+				at := firstDiff(got, pg)
+				t.Errorf("NEW MISMATCH %s stmt[%d] line %d at byte %d\n  SQL: %.200s\n  PG:       %s\n  pgparser: %s",
+					base, i, stmts[i].StartLine, at, stmts[i].SQL, around(pg, at), around(got, at))
+			}
+		}
+	}
+	t.Logf("trees: %d of %d statements match PostgreSQL", matched, total)
+
+	if *update {
+		data, err := json.MarshalIndent(mismatches, "", "  ")
+		if err != nil {
+			t.Fatalf("marshal known_mismatches: %v", err)
+		}
+		if err := os.WriteFile("known_mismatches.json", data, 0644); err != nil {
+			t.Fatalf("write known_mismatches.json: %v", err)
+		}
+	}
+}
+
+// canonicalTree is CanonicalTree that fails the test on malformed data.
+func canonicalTree(t *testing.T, data []byte, dropLocations bool) string {
+	t.Helper()
+	tree, err := CanonicalTree(data, dropLocations)
+	if err != nil {
+		t.Fatalf("parse tree %.200s: %v", data, err)
+	}
+	return tree
+}
+
+func firstDiff(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// around returns the text of s around offset at.
+func around(s string, at int) string {
+	start, end := at-40, at+40
+	if start < 0 {
+		start = 0
+	}
+	if end > len(s) {
+		end = len(s)
+	}
+	if start > end {
+		start = end
+	}
+	return s[start:end]
+}
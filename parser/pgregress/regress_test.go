@@ -15,7 +15,8 @@ import (
 
 var update = flag.Bool("update", false, "regenerate known_failures.json from current parse results")
 
-// KnownFailures maps filename -> sorted list of 0-based statement indices that fail.
+// KnownFailures maps filename -> sorted list of 0-based statement indices
+// that fail to parse though PostgreSQL accepts them.
 type KnownFailures map[string][]int
 
 func loadKnownFailures(t *testing.T) KnownFailures {
@@ -34,6 +35,24 @@ func loadKnownFailures(t *testing.T) KnownFailures {
 	return kf
 }
 
+// ExpectedErrors maps filename -> 0-based statement index -> the error
+// PostgreSQL reports for a statement it rejects. tools/pg_tree_golden
+// writes it.
+type ExpectedErrors map[string]map[int]string
+
+func loadExpectedErrors(t *testing.T) ExpectedErrors {
+	t.Helper()
+	data, err := os.ReadFile("expected_errors.json")
+	if err != nil {
+		t.Fatalf("read expected_errors.json: %v", err)
+	}
+	var ee ExpectedErrors
+	if err := json.Unmarshal(data, &ee); err != nil {
+		t.Fatalf("parse expected_errors.json: %v", err)
+	}
+	return ee
+}
+
 func intSliceContains(slice []int, val int) bool {
 	for _, v := range slice {
 		if v == val {
@@ -43,6 +62,9 @@ func intSliceContains(slice []int, val int) bool {
 	return false
 }
 
+// TestPGRegress parses every statement of the regression tests. The
+// statements in expected_errors.json, which PostgreSQL rejects, must fail;
+// the rest must parse, but for those in known_failures.json.
 func TestPGRegress(t *testing.T) {
 	files, err := filepath.Glob("testdata/sql/*.sql")
 	if err != nil {
@@ -53,6 +75,7 @@ func TestPGRegress(t *testing.T) {
 	}
 
 	knownFailures := loadKnownFailures(t)
+	expectedErrors := loadExpectedErrors(t)
 
 	// Collect new failures if updating
 	var newFailures KnownFailures
@@ -61,7 +84,7 @@ func TestPGRegress(t *testing.T) {
 	}
 
 	// Aggregate statistics
-	var totalStmts, totalPassed, totalKnown, totalNew, totalFixed, totalPsqlVar int
+	var totalStmts, totalPassed, totalKnown, totalNew, totalFixed, totalPsqlVar, totalRejected int
 
 	sort.Strings(files)
 
@@ -77,33 +100,25 @@ func TestPGRegress(t *testing.T) {
 
 			stmts := ExtractStatements(base, content)
 			kf := knownFailures[base]
+			ee := expectedErrors[base]
 
-			var passed, known, newFail, fixed, psqlVar int
+			var passed, known, newFail, fixed, psqlVar, rejected int
 			var failIndices []int
 
 			for i, stmt := range stmts {
 
-				sqlToParse := stmt.SQL
-				if stmt.HasPsqlVar {
-					sqlToParse, _ = ReplacePsqlVariables(stmt.SQL)
-					// Special case for full psql variable statements like ":variable;"
-					// which become "psql_var;" after replacement. This is invalid syntax
-					// (identifier as statement). Treat it as a passing SELECT 1.
-					trimmed := strings.TrimSpace(sqlToParse)
-					if trimmed == "psql_var" || trimmed == "psql_var;" {
-						sqlToParse = "SELECT 1"
+				_, parseErr := parser.Parse(stmt.ParseSQL())
+
+				// PostgreSQL rejects the statement too, so it must fail.
+				if pgErr, ok := ee[i]; ok {
+					if parseErr == nil {
+						t.Errorf("ACCEPTED stmt[%d] line %d, which PostgreSQL rejects: %s\n  SQL: %.200s",
+							i, stmt.StartLine, pgErr, stmt.SQL)
 					} else {
-						// Handle "EXPLAIN ... :var" -> "EXPLAIN ... psql_var" -> "EXPLAIN ... SELECT 1"
-						// We check if EXPLAIN appears before psql_var.
-						upper := strings.ToUpper(sqlToParse)
-						expIdx := strings.Index(upper, "EXPLAIN")
-						varIdx := strings.Index(sqlToParse, "psql_var")
-						if expIdx >= 0 && varIdx > expIdx {
-							sqlToParse = strings.Replace(sqlToParse, "psql_var", "SELECT 1", 1)
-						}
+						rejected++
 					}
+					continue
 				}
-				_, parseErr := parser.Parse(sqlToParse)
 
 				isKnown := intSliceContains(kf, i)
 
@@ -147,22 +162,24 @@ func TestPGRegress(t *testing.T) {
 			totalNew += newFail
 			totalFixed += fixed
 			totalPsqlVar += psqlVar
+			totalRejected += rejected
 
-			t.Logf("%s: %d stmts, %d passed, %d failed (%d known, %d new, %d fixed, %d psql-var)",
-				base, len(stmts), passed, len(failIndices), known, newFail, fixed, psqlVar)
+			t.Logf("%s: %d stmts, %d passed, %d rejected as by PostgreSQL, %d failed (%d known, %d new, %d fixed, %d psql-var)",
+				base, len(stmts), passed, rejected, len(failIndices), known, newFail, fixed, psqlVar)
 		})
 	}
 
 	// Overall summary
 	totalFailed := totalKnown + totalNew
 	passRate := float64(0)
-	if totalStmts > 0 {
-		passRate = 100 * float64(totalPassed) / float64(totalStmts)
+	if totalStmts > totalRejected {
+		passRate = 100 * float64(totalPassed) / float64(totalStmts-totalRejected)
 	}
 	t.Logf("\n=== PG REGRESS SUMMARY ===")
 	t.Logf("Files:    %d", len(files))
 	t.Logf("Stmts:    %d", totalStmts)
 	t.Logf("Passed:   %d (%.1f%%)", totalPassed, passRate)
+	t.Logf("Rejected: %d (PostgreSQL rejects them too)", totalRejected)
 	t.Logf("Failed:   %d (known: %d, new: %d)", totalFailed, totalKnown, totalNew)
 	if totalPsqlVar > 0 {
 		t.Logf("Psql-var: %d (psql variable interpolation, not real grammar failures)", totalPsqlVar)
@@ -217,23 +234,7 @@ func TestPGRegressStats(t *testing.T) {
 		passed := 0
 		psqlVar := 0
 		for _, stmt := range stmts {
-			sql := stmt.SQL
-			if stmt.HasPsqlVar {
-				sql, _ = ReplacePsqlVariables(stmt.SQL)
-				// Special case for full psql variable statements like ":variable;"
-				trimmed := strings.TrimSpace(sql)
-				if trimmed == "psql_var" || trimmed == "psql_var;" {
-					sql = "SELECT 1"
-				} else {
-					upper := strings.ToUpper(sql)
-					expIdx := strings.Index(upper, "EXPLAIN")
-					varIdx := strings.Index(sql, "psql_var")
-					if expIdx >= 0 && varIdx > expIdx {
-						sql = strings.Replace(sql, "psql_var", "SELECT 1", 1)
-					}
-				}
-			}
-			if _, err := parser.Parse(sql); err == nil {
+			if _, err := parser.Parse(stmt.ParseSQL()); err == nil {
 				passed++
 			} else if stmt.HasPsqlVar {
 				psqlVar++
@@ -0,0 +1,36 @@
+package pgregress
+
+import "encoding/json"
+
+// CanonicalTree returns a parse tree in pg_query's JSON form with its
+// object keys sorted and, if dropLocations is set, without its "location"
+// fields, so that trees can be compared as strings.
+func CanonicalTree(data []byte, dropLocations bool) (string, error) {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return "", err
+	}
+	if dropLocations {
+		v = withoutLocations(v)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func withoutLocations(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		delete(v, "location")
+		for k, e := range v {
+			v[k] = withoutLocations(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = withoutLocations(e)
+		}
+	}
+	return v
+}
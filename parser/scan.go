@@ -0,0 +1,46 @@
+package parser
+
+// ScanToken is a token of SQL text, as Scan returns it.
+type ScanToken struct {
+	Start   int      // byte offset of the start of the token
+	End     int      // byte offset just past the end of the token
+	Name    string   // the grammar's name for the token, such as IDENT, SELECT or '('
+	Keyword *Keyword // the keyword the token is, or nil
+}
+
+// Scan splits input into the tokens the grammar reads, as PostgreSQL's
+// scanner does. Comments and whitespace are skipped, and the lookahead
+// substitutions of the parser, such as NOT_LA for NOT before BETWEEN, are
+// not made. If the input cannot be scanned, Scan returns the tokens before
+// the error and a *ParseError.
+func Scan(input string) ([]ScanToken, error) {
+	pl := parserLexer{version: DefaultVersion}
+	lexer := NewLexer(input)
+	var toks []ScanToken
+	for {
+		tok := lexer.NextToken()
+		if lexer.Err != nil {
+			return toks, &ParseError{Message: lexer.Err.Error(), Position: tok.Loc}
+		}
+		if tok.Type == lex_EOF {
+			return toks, nil
+		}
+		tok = pl.versionToken(tok)
+		st := ScanToken{Start: tok.Loc, End: lexer.pos, Name: tokenName(pl.mapTokenType(tok))}
+		if lexer.quoteEnd > tok.Loc {
+			// A quoted string is only known to end once the space after
+			// it has been scanned for a continuation.
+			st.End = lexer.quoteEnd
+		}
+		if kw := LookupKeyword(tok.Str); kw != nil && kw.Token == tok.Type {
+			st.Keyword = kw
+		}
+		toks = append(toks, st)
+	}
+}
+
+// tokenName returns the grammar's name for a token type, as the parser's
+// debugging output shows it.
+func tokenName(typ int) string {
+	return pgTokname(tokenNumber(typ))
+}
@@ -0,0 +1,71 @@
+package parser
+
+import "testing"
+
+func TestScan(t *testing.T) {
+	input := "SELECT a, 'x'\n'y' FROM t WHERE b >= $1 -- c\n"
+	toks, err := Scan(input)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	want := []struct {
+		text, name string
+		keyword    bool
+	}{
+		{"SELECT", "SELECT", true},
+		{"a", "IDENT", false},
+		{",", "','", false},
+		{"'x'\n'y'", "SCONST", false},
+		{"FROM", "FROM", true},
+		{"t", "IDENT", false},
+		{"WHERE", "WHERE", true},
+		{"b", "IDENT", false},
+		{">=", "GREATER_EQUALS", false},
+		{"$1", "PARAM", false},
+	}
+	if len(toks) != len(want) {
+		t.Fatalf("Scan returned %d tokens, want %d: %+v", len(toks), len(want), toks)
+	}
+	for i, w := range want {
+		tok := toks[i]
+		if text := input[tok.Start:tok.End]; text != w.text || tok.Name != w.name || (tok.Keyword != nil) != w.keyword {
+			t.Errorf("token %d = %q %s keyword=%v, want %q %s keyword=%v",
+				i, text, tok.Name, tok.Keyword != nil, w.text, w.name, w.keyword)
+		}
+	}
+
+	toks, err = Scan("SELECT 'abc")
+	if err == nil {
+		t.Fatal("Scan of an unterminated string should fail")
+	}
+	if perr, ok := err.(*ParseError); !ok || perr.Position != 7 || len(toks) != 1 {
+		t.Errorf("Scan error = %v at %d tokens, want a ParseError at 7 after 1 token", err, len(toks))
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		input, want string
+	}{
+		{"SELECT 1", "SELECT $1"},
+		{"SELECT a FROM t WHERE b = 'x' AND c > -2.5", "SELECT a FROM t WHERE b = $1 AND c > $2"},
+		{"SELECT a::varchar(10), $2, true, NULL", "SELECT a::varchar(10), $2, $3, $4"},
+		{"SELECT date '2024-01-01', x - 1", "SELECT date $1, x - $2"},
+		{"INSERT INTO t VALUES (1, 'a'), (2, 'b')", "INSERT INTO t VALUES ($1, $2), ($3, $4)"},
+		{"SELECT a FROM t", "SELECT a FROM t"},
+		{"SELECT U&'d!0061ta' UESCAPE '!', 'x' uescape", "SELECT $1, $2 uescape"},
+	}
+	for _, tt := range tests {
+		got, err := Normalize(tt.input)
+		if err != nil {
+			t.Errorf("Normalize(%q): %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+	if _, err := Normalize("SELECT FROM ("); err == nil {
+		t.Error("Normalize of invalid SQL should fail")
+	}
+}
@@ -0,0 +1,151 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgparser/nodes"
+)
+
+func TestVersionGates(t *testing.T) {
+	tests := []struct {
+		input string
+		since Version
+		what  string
+		pos   int
+	}{
+		{"MERGE INTO t USING s ON t.a = s.a WHEN MATCHED THEN DELETE", PG15, "MERGE", 0},
+		{"MERGE INTO t USING s ON t.a = s.a WHEN MATCHED THEN DELETE RETURNING *", PG17, "MERGE ... RETURNING", 59},
+		{"CREATE TABLE t (a int UNIQUE NULLS NOT DISTINCT)", PG15, "NULLS NOT DISTINCT", 29},
+		{"SELECT JSON_OBJECT('a' VALUE 1)", PG16, "JSON_OBJECT", 7},
+		{"SELECT JSON_ARRAY(1, 2)", PG16, "JSON_ARRAY", 7},
+		{"SELECT '1' IS JSON", PG16, "IS JSON", 11},
+		{"SELECT * FROM JSON_TABLE('[]', '$[*]' COLUMNS (a int PATH '$'))", PG17, "JSON_TABLE", 14},
+		{"SELECT a FROM t GROUP BY DISTINCT a", PG14, "GROUP BY DISTINCT", 25},
+		{"INSERT INTO t VALUES (1) RETURNING WITH (OLD AS o) o.a", PG18, "RETURNING WITH", 35},
+		{"CREATE TABLE t (a int, b int GENERATED ALWAYS AS (a))", PG18, "virtual generated column", 29},
+		{"ALTER TABLE p DETACH PARTITION c CONCURRENTLY", PG14, "DETACH PARTITION ... CONCURRENTLY", 33},
+		{"ALTER TABLE p DETACH PARTITION c FINALIZE", PG14, "DETACH PARTITION ... FINALIZE", 33},
+		{"CREATE TABLE t (a text COMPRESSION pglz)", PG14, "COMPRESSION", 23},
+		{"ALTER TABLE t ALTER COLUMN a SET COMPRESSION lz4", PG14, "COMPRESSION", 33},
+		{"CREATE TABLE t (LIKE s INCLUDING COMPRESSION)", PG14, "LIKE ... INCLUDING COMPRESSION", 33},
+		{"CREATE PUBLICATION p FOR TABLES IN SCHEMA s", PG15, "TABLES IN SCHEMA", 25},
+		{"CREATE PUBLICATION p FOR TABLE t WHERE (a > 1)", PG15, "publication WHERE", 33},
+		{"CREATE PUBLICATION p FOR TABLE t (a, b)", PG15, "publication column list", 33},
+		{"GRANT r TO u WITH INHERIT TRUE", PG16, "GRANT ... WITH INHERIT TRUE", 18},
+		{"GRANT r TO u WITH ADMIN FALSE", PG16, "GRANT ... WITH ADMIN FALSE", 18},
+		{"ALTER TABLE t ALTER COLUMN a SET EXPRESSION AS (b + 1)", PG17, "SET EXPRESSION", 29},
+		{"REINDEX DATABASE", PG17, "REINDEX SYSTEM or DATABASE without a name", 8},
+	}
+	for _, tt := range tests {
+		if _, err := ParseWith(tt.input, Options{Version: tt.since}); err != nil {
+			t.Errorf("ParseWith(%q, %d): %v", tt.input, tt.since, err)
+		}
+		_, err := ParseWith(tt.input, Options{Version: tt.since - 1})
+		want := fmt.Sprintf("%s requires PostgreSQL %d or later", tt.what, tt.since)
+		perr, ok := err.(*ParseError)
+		if !ok || perr.Message != want || perr.Position != tt.pos {
+			t.Errorf("ParseWith(%q, %d) error = %v, want %q at %d", tt.input, tt.since-1, err, want, tt.pos)
+		}
+	}
+}
+
+func TestVersionGeneratedKind(t *testing.T) {
+	tests := []struct {
+		input   string
+		version Version
+		want    byte
+	}{
+		{"CREATE TABLE t (a int, b int GENERATED ALWAYS AS (a) STORED)", PG17, 0},
+		{"CREATE TABLE t (a int, b int GENERATED ALWAYS AS (a) STORED)", PG18, 's'},
+		{"CREATE TABLE t (a int, b int GENERATED ALWAYS AS (a) VIRTUAL)", PG18, 'v'},
+		{"CREATE TABLE t (a int, b int GENERATED ALWAYS AS (a))", PG18, 'v'},
+	}
+	for _, tt := range tests {
+		stmts, err := ParseWith(tt.input, Options{Version: tt.version})
+		if err != nil {
+			t.Errorf("ParseWith(%q, %d): %v", tt.input, tt.version, err)
+			continue
+		}
+		col := stmts.Items[0].(*nodes.CreateStmt).TableElts.Items[1].(*nodes.ColumnDef)
+		con := col.Constraints.Items[0].(*nodes.Constraint)
+		if con.GeneratedKind != tt.want {
+			t.Errorf("ParseWith(%q, %d) GeneratedKind = %q, want %q", tt.input, tt.version, con.GeneratedKind, tt.want)
+		}
+	}
+}
+
+func TestVersionKeywords(t *testing.T) {
+	tests := []struct {
+		input   string
+		version Version
+		err     string
+	}{
+		// A quoted "virtual" is an identifier, not the keyword.
+		{`CREATE TABLE t (a int, b int GENERATED ALWAYS AS (a) "virtual")`, PG18, "syntax error"},
+		{"CREATE TABLE t (a int, b int GENERATED ALWAYS AS (a) VIRTUAL)", PG17, "virtual generated column requires PostgreSQL 18 or later"},
+		{"CREATE TABLE virtual (virtual int)", PG17, ""},
+		{"CREATE TABLE virtual (virtual int)", PG18, ""},
+		{"SELECT 1 virtual", PG18, ""},
+		{"GRANT r TO u WITH ADMIN OPTION", PG13, ""},
+	}
+	for _, tt := range tests {
+		_, err := ParseWith(tt.input, Options{Version: tt.version})
+		if got := fmt.Sprint(err); tt.err != "" && got != tt.err || tt.err == "" && err != nil {
+			t.Errorf("ParseWith(%q, %d) error = %v, want %q", tt.input, tt.version, err, tt.err)
+		}
+	}
+	if kw := LookupKeyword("virtual"); kw != nil {
+		t.Errorf("LookupKeyword(virtual) = %v, want nil: Keywords is 17's list", kw)
+	}
+	toks, err := Scan("virtual")
+	if err != nil || len(toks) != 1 || toks[0].Name != "IDENT" || toks[0].Keyword != nil {
+		t.Errorf("Scan(virtual) = %+v, %v, want an identifier", toks, err)
+	}
+}
+
+func TestVersionNotices(t *testing.T) {
+	long := strings.Repeat("a", 64)
+	_, notices, err := ParseNoticesWith("CREATE TABLE t (b int GENERATED ALWAYS AS (1) VIRTUAL, "+long+" int)", Options{Version: PG18})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notices) != 1 {
+		t.Errorf("notices = %v, want a truncation notice", notices)
+	}
+	if _, _, err := ParseNoticesWith("CREATE TABLE t (b int GENERATED ALWAYS AS (1) VIRTUAL)", Options{Version: PG17}); err == nil {
+		t.Error("ParseNoticesWith(PG17) accepted a virtual generated column")
+	}
+}
+
+func TestVersionReturningOptions(t *testing.T) {
+	stmts, err := ParseWith("UPDATE t SET a = 1 RETURNING WITH (OLD AS o, NEW AS n) o.a, n.a", Options{Version: PG18})
+	if err != nil {
+		t.Fatal(err)
+	}
+	upd := stmts.Items[0].(*nodes.UpdateStmt)
+	if upd.ReturningList == nil || len(upd.ReturningList.Items) != 2 {
+		t.Fatalf("ReturningList = %v, want 2 items", upd.ReturningList)
+	}
+	if upd.ReturningOptions == nil || len(upd.ReturningOptions.Items) != 2 {
+		t.Fatalf("ReturningOptions = %v, want 2 items", upd.ReturningOptions)
+	}
+	old := upd.ReturningOptions.Items[0].(*nodes.ReturningOption)
+	if old.Option != nodes.RETURNING_OPTION_OLD || old.Value != "o" || old.Location != 35 {
+		t.Errorf("first option = %+v, want OLD AS o at 35", old)
+	}
+	if nw := upd.ReturningOptions.Items[1].(*nodes.ReturningOption); nw.Option != nodes.RETURNING_OPTION_NEW || nw.Value != "n" {
+		t.Errorf("second option = %+v, want NEW AS n", nw)
+	}
+}
+
+func TestVersionUnsupported(t *testing.T) {
+	for _, v := range []Version{12, 19} {
+		_, err := ParseWith("SELECT 1", Options{Version: v})
+		want := fmt.Sprintf("unsupported PostgreSQL version %d: pgparser accepts 13 to 18", v)
+		if err == nil || err.Error() != want {
+			t.Errorf("ParseWith(version %d) error = %v, want %q", v, err, want)
+		}
+	}
+}
@@ -0,0 +1,911 @@
+package pgdump
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pgplex/pgparser/catalog"
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// Object is an object of a dump's inventory.
+type Object struct {
+	Type     string `json:"type"`               // object type as written in COMMENT ON, e.g. "TABLE" or "FUNCTION"
+	Database string `json:"database,omitempty"` // database selected by the last \connect, if any
+	Schema   string `json:"schema,omitempty"`   // "" for objects outside schemas, such as schemas and extensions
+	Name     string `json:"name"`
+	Table    string `json:"table,omitempty"` // relation of a constraint, trigger, policy or rule
+	Args     string `json:"args,omitempty"`  // input argument types of a routine, e.g. "integer, text"
+
+	Owner          string            `json:"owner,omitempty"`
+	ACL            []Grant           `json:"acl,omitempty"`
+	Comment        string            `json:"comment,omitempty"`
+	ColumnComments map[string]string `json:"column_comments,omitempty"`
+
+	// Line is the line of the statement defining the object, or 0 for
+	// objects the dump alters without defining them, such as schema
+	// public.
+	Line int `json:"line"`
+}
+
+// String returns the object type and name, as in "TABLE public.t",
+// "FUNCTION public.f(integer)" or "TRIGGER audit ON public.t".
+func (o *Object) String() string {
+	name := o.Name
+	if o.Table != "" {
+		table := o.Table
+		if o.Schema != "" {
+			table = o.Schema + "." + table
+		}
+		return fmt.Sprintf("%s %s ON %s", o.Type, name, table)
+	}
+	if o.Schema != "" {
+		name = o.Schema + "." + name
+	}
+	if isRoutine(o.Type) {
+		name += "(" + o.Args + ")"
+	}
+	return o.Type + " " + name
+}
+
+// Grant is a privilege granted on an object, or on one of its columns.
+// Only explicit grants are listed: the privileges an owner holds, and
+// those PUBLIC holds by default, are not.
+type Grant struct {
+	Grantee   string `json:"grantee"`          // role name, or "PUBLIC"
+	Privilege string `json:"privilege"`        // "SELECT", "USAGE", ...
+	Column    string `json:"column,omitempty"` // for column privileges
+	Grantable bool   `json:"grantable,omitempty"`
+}
+
+// Error is a statement of a dump that did not parse.
+type Error struct {
+	Line int
+	SQL  string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// Inventory lists the objects a dump defines, in the order it defines
+// them, with their owner, access privileges and comment.
+type Inventory struct {
+	Objects []*Object
+	Errors  []*Error // statements that did not parse, which are otherwise skipped
+
+	database string
+	path     []string
+	index    map[key]*Object
+}
+
+// key identifies an object. Objects sharing a namespace, such as tables
+// and views, share a class.
+type key struct {
+	database, class, schema, name, table, args string
+}
+
+// New returns an empty inventory, to which statements read with a
+// Reader are added with Add.
+func New() *Inventory {
+	return &Inventory{path: defaultPath(), index: map[key]*Object{}}
+}
+
+// Load reads a dump and returns its inventory. Only errors reading the
+// dump are returned; statements that do not parse are listed in the
+// inventory's Errors.
+func Load(r io.Reader) (*Inventory, error) {
+	inv := New()
+	rd := NewReader(r)
+	for {
+		stmt, err := rd.Next()
+		if err == io.EOF {
+			return inv, nil
+		}
+		if err != nil {
+			return inv, err
+		}
+		inv.Add(stmt)
+	}
+}
+
+// Add records the effect of a statement on the inventory.
+func (inv *Inventory) Add(stmt *Stmt) {
+	switch {
+	case stmt.Meta != "":
+		inv.meta(stmt.Meta)
+	case stmt.Err != nil:
+		inv.Errors = append(inv.Errors, &Error{Line: stmt.Line, SQL: stmt.SQL, Err: stmt.Err})
+	default:
+		inv.stmt(stmt.Node, stmt.Line)
+	}
+}
+
+// Lookup returns the object of the given type and name, or nil. schema
+// and name are as in Object; for routines, name includes the argument
+// types, as in "f(integer, text)", and for constraints, triggers,
+// policies and rules it is "name ON table".
+func (inv *Inventory) Lookup(objType, schema, name string) *Object {
+	for _, o := range inv.Objects {
+		if o.Type != objType || o.Schema != schema {
+			continue
+		}
+		full := o.Name
+		switch {
+		case o.Table != "":
+			full += " ON " + o.Table
+		case isRoutine(o.Type):
+			full += "(" + o.Args + ")"
+		}
+		if full == name {
+			return o
+		}
+	}
+	return nil
+}
+
+func defaultPath() []string {
+	return []string{"$user", "public"}
+}
+
+// meta handles a psql metacommand.
+func (inv *Inventory) meta(line string) {
+	fields := strings.Fields(line)
+	switch fields[0] {
+	case `\connect`, `\c`:
+		// \connect dbname, or pg_dumpall's
+		// \connect -reuse-previous=on "dbname='app'".
+		for _, f := range fields[1:] {
+			if strings.HasPrefix(f, "-") {
+				continue
+			}
+			f = unquote(f, '"')
+			if strings.HasPrefix(f, "dbname=") {
+				f = unquote(strings.TrimPrefix(f, "dbname="), '\'')
+			}
+			inv.database = f
+			inv.path = defaultPath()
+			break
+		}
+	}
+}
+
+// unquote removes the quotes around s, undoubling embedded quotes.
+func unquote(s string, q byte) string {
+	if len(s) >= 2 && s[0] == q && s[len(s)-1] == q {
+		return strings.ReplaceAll(s[1:len(s)-1], string([]byte{q, q}), string(q))
+	}
+	return s
+}
+
+// stmt records the effect of a parsed statement.
+func (inv *Inventory) stmt(n nodes.Node, line int) {
+	switch n := n.(type) {
+	case *nodes.RawStmt:
+		inv.stmt(n.Stmt, line)
+	case *nodes.VariableSetStmt:
+		inv.variableSet(n)
+	case *nodes.SelectStmt:
+		inv.setConfig(n)
+
+	case *nodes.CreatedbStmt:
+		inv.define(nodes.OBJECT_DATABASE, "", n.Dbname, line)
+	case *nodes.CreateSchemaStmt:
+		o := inv.define(nodes.OBJECT_SCHEMA, "", n.Schemaname, line)
+		if n.Authrole != nil {
+			o.Owner = roleName(n.Authrole)
+		}
+		saved := inv.path
+		inv.path = []string{n.Schemaname}
+		for _, elt := range listItems(n.SchemaElts) {
+			inv.stmt(elt, line)
+		}
+		inv.path = saved
+	case *nodes.CreateExtensionStmt:
+		inv.define(nodes.OBJECT_EXTENSION, "", n.Extname, line)
+	case *nodes.CreatePLangStmt:
+		inv.define(nodes.OBJECT_LANGUAGE, "", n.Plname, line)
+	case *nodes.CreateFdwStmt:
+		inv.define(nodes.OBJECT_FDW, "", n.Fdwname, line)
+	case *nodes.CreateForeignServerStmt:
+		inv.define(nodes.OBJECT_FOREIGN_SERVER, "", n.Servername, line)
+	case *nodes.CreateEventTrigStmt:
+		inv.define(nodes.OBJECT_EVENT_TRIGGER, "", n.Trigname, line)
+	case *nodes.CreatePublicationStmt:
+		inv.define(nodes.OBJECT_PUBLICATION, "", n.Pubname, line)
+
+	case *nodes.CreateStmt:
+		inv.createTable(nodes.OBJECT_TABLE, n, line)
+	case *nodes.CreateForeignTableStmt:
+		inv.createTable(nodes.OBJECT_FOREIGN_TABLE, &n.Base, line)
+	case *nodes.ViewStmt:
+		inv.defineRel(nodes.OBJECT_VIEW, n.View, line)
+	case *nodes.CreateTableAsStmt:
+		if n.Into != nil && n.Into.Rel != nil {
+			t := nodes.OBJECT_TABLE
+			if n.Objtype == nodes.OBJECT_MATVIEW {
+				t = nodes.OBJECT_MATVIEW
+			}
+			inv.defineRel(t, n.Into.Rel, line)
+		}
+	case *nodes.CreateSeqStmt:
+		inv.defineRel(nodes.OBJECT_SEQUENCE, n.Sequence, line)
+	case *nodes.IndexStmt:
+		if n.Idxname != "" {
+			rel := inv.resolve(classOf(nodes.OBJECT_TABLE), n.Relation.Schemaname, n.Relation.Relname)
+			inv.define(nodes.OBJECT_INDEX, rel, n.Idxname, line)
+		}
+	case *nodes.CreateStatsStmt:
+		schema, name := qualifiedName(n.Defnames)
+		inv.define(nodes.OBJECT_STATISTIC_EXT, inv.creationSchema(schema), name, line)
+
+	case *nodes.CreateTrigStmt:
+		inv.defineOn(nodes.OBJECT_TRIGGER, n.Relation, n.Trigname, line)
+	case *nodes.CreatePolicyStmt:
+		inv.defineOn(nodes.OBJECT_POLICY, n.Table, n.PolicyName, line)
+	case *nodes.RuleStmt:
+		inv.defineOn(nodes.OBJECT_RULE, n.Relation, n.Rulename, line)
+
+	case *nodes.CreateFunctionStmt:
+		t := nodes.OBJECT_FUNCTION
+		if n.IsProcedure {
+			t = nodes.OBJECT_PROCEDURE
+		}
+		var args []*nodes.TypeName
+		for _, item := range listItems(n.Parameters) {
+			if p, ok := item.(*nodes.FunctionParameter); ok && p.Mode != nodes.FUNC_PARAM_OUT && p.Mode != nodes.FUNC_PARAM_TABLE {
+				args = append(args, p.ArgType)
+			}
+		}
+		schema, name := qualifiedName(n.Funcname)
+		inv.define(t, inv.creationSchema(schema), name, line, formatArgs(args))
+	case *nodes.DefineStmt:
+		schema, name := qualifiedName(n.Defnames)
+		switch n.Kind {
+		case nodes.OBJECT_AGGREGATE:
+			var args []*nodes.TypeName
+			if items := listItems(n.Args); len(items) > 0 {
+				if params, ok := items[0].(*nodes.List); ok {
+					for _, item := range params.Items {
+						if p, ok := item.(*nodes.FunctionParameter); ok {
+							args = append(args, p.ArgType)
+						}
+					}
+				}
+			}
+			inv.define(nodes.OBJECT_AGGREGATE, inv.creationSchema(schema), name, line, formatArgs(args))
+		case nodes.OBJECT_TYPE, nodes.OBJECT_COLLATION:
+			inv.define(n.Kind, inv.creationSchema(schema), name, line)
+		}
+	case *nodes.CreateEnumStmt:
+		inv.defineType(nodes.OBJECT_TYPE, n.TypeName, line)
+	case *nodes.CreateRangeStmt:
+		inv.defineType(nodes.OBJECT_TYPE, n.TypeName, line)
+	case *nodes.CreateDomainStmt:
+		inv.defineType(nodes.OBJECT_DOMAIN, n.Domainname, line)
+	case *nodes.CompositeTypeStmt:
+		inv.define(nodes.OBJECT_TYPE, inv.creationSchema(n.Typevar.Schemaname), n.Typevar.Relname, line)
+
+	case *nodes.AlterTableStmt:
+		inv.alterTable(n, line)
+	case *nodes.AlterOwnerStmt:
+		var o *Object
+		if n.Relation != nil {
+			o = inv.lookupRel(n.ObjectType, n.Relation)
+		} else {
+			o = inv.lookup(n.ObjectType, n.Object)
+		}
+		if o != nil {
+			o.Owner = roleName(n.Newowner)
+		}
+	case *nodes.CommentStmt:
+		inv.comment(n)
+	case *nodes.GrantStmt:
+		inv.grant(n)
+	}
+}
+
+// variableSet tracks changes to search_path.
+func (inv *Inventory) variableSet(n *nodes.VariableSetStmt) {
+	if !strings.EqualFold(n.Name, "search_path") {
+		return
+	}
+	switch n.Kind {
+	case nodes.VAR_SET_VALUE:
+		var path []string
+		for _, arg := range listItems(n.Args) {
+			if s, ok := stringConst(arg); ok {
+				path = append(path, splitSearchPath(s)...)
+			}
+		}
+		inv.path = path
+	case nodes.VAR_SET_DEFAULT, nodes.VAR_RESET, nodes.VAR_RESET_ALL:
+		inv.path = defaultPath()
+	}
+}
+
+// setConfig tracks the call to pg_catalog.set_config with which pg_dump
+// empties search_path.
+func (inv *Inventory) setConfig(n *nodes.SelectStmt) {
+	if n.FromClause != nil || n.TargetList == nil || len(n.TargetList.Items) != 1 {
+		return
+	}
+	rt, ok := n.TargetList.Items[0].(*nodes.ResTarget)
+	if !ok {
+		return
+	}
+	fc, ok := rt.Val.(*nodes.FuncCall)
+	if !ok {
+		return
+	}
+	if schema, name := qualifiedName(fc.Funcname); name != "set_config" || (schema != "" && schema != "pg_catalog") {
+		return
+	}
+	args := listItems(fc.Args)
+	if len(args) != 3 {
+		return
+	}
+	if param, ok := stringConst(args[0]); ok && strings.EqualFold(param, "search_path") {
+		if value, ok := stringConst(args[1]); ok {
+			inv.path = splitSearchPath(value)
+		}
+	}
+}
+
+// createTable defines a table and its named constraints.
+func (inv *Inventory) createTable(t nodes.ObjectType, n *nodes.CreateStmt, line int) {
+	rel := inv.defineRel(t, n.Relation, line)
+	constraint := func(c *nodes.Constraint) {
+		if c.Conname != "" {
+			inv.define(nodes.OBJECT_TABCONSTRAINT, rel.Schema, c.Conname, line, "", rel.Name)
+		}
+	}
+	for _, elt := range listItems(n.TableElts) {
+		switch elt := elt.(type) {
+		case *nodes.ColumnDef:
+			for _, item := range listItems(elt.Constraints) {
+				if c, ok := item.(*nodes.Constraint); ok {
+					constraint(c)
+				}
+			}
+		case *nodes.Constraint:
+			constraint(elt)
+		}
+	}
+	for _, item := range listItems(n.Constraints) {
+		if c, ok := item.(*nodes.Constraint); ok {
+			constraint(c)
+		}
+	}
+}
+
+// alterTable handles ALTER TABLE ... ADD CONSTRAINT and OWNER TO, which
+// pg_dump also writes for sequences and views.
+func (inv *Inventory) alterTable(n *nodes.AlterTableStmt, line int) {
+	if n.Relation == nil {
+		return
+	}
+	for _, item := range listItems(n.Cmds) {
+		cmd, ok := item.(*nodes.AlterTableCmd)
+		if !ok {
+			continue
+		}
+		switch nodes.AlterTableType(cmd.Subtype) {
+		case nodes.AT_AddConstraint:
+			if c, ok := cmd.Def.(*nodes.Constraint); ok && c.Conname != "" {
+				schema := inv.resolve(classOf(nodes.OBJECT_TABLE), n.Relation.Schemaname, n.Relation.Relname)
+				inv.define(nodes.OBJECT_TABCONSTRAINT, schema, c.Conname, line, "", n.Relation.Relname)
+			}
+		case nodes.AT_ChangeOwner:
+			if o := inv.lookupRel(nodes.ObjectType(n.Objtype), n.Relation); o != nil {
+				o.Owner = roleName(cmd.Newowner)
+			}
+		}
+	}
+}
+
+// comment handles COMMENT ON.
+func (inv *Inventory) comment(n *nodes.CommentStmt) {
+	if n.Objtype == nodes.OBJECT_COLUMN {
+		names := stringList(asList(n.Object))
+		if len(names) < 2 {
+			return
+		}
+		rel := inv.lookupNames(nodes.OBJECT_TABLE, names[:len(names)-1])
+		if rel == nil {
+			return
+		}
+		col := names[len(names)-1]
+		if n.Comment == "" {
+			delete(rel.ColumnComments, col)
+			return
+		}
+		if rel.ColumnComments == nil {
+			rel.ColumnComments = map[string]string{}
+		}
+		rel.ColumnComments[col] = n.Comment
+		return
+	}
+	if o := inv.lookup(n.Objtype, n.Object); o != nil {
+		o.Comment = n.Comment
+	}
+}
+
+// grant handles GRANT and REVOKE on objects.
+func (inv *Inventory) grant(n *nodes.GrantStmt) {
+	if n.Targtype != nodes.ACL_TARGET_OBJECT {
+		return
+	}
+	type priv struct{ name, column string }
+	var privs []priv
+	if n.Privileges == nil {
+		for _, p := range allPrivileges(n.Objtype) {
+			privs = append(privs, priv{name: p})
+		}
+	}
+	for _, item := range listItems(n.Privileges) {
+		ap, ok := item.(*nodes.AccessPriv)
+		if !ok {
+			continue
+		}
+		names := []string{privilegeName(ap.PrivName)}
+		if ap.PrivName == "" {
+			names = columnPrivileges
+		}
+		cols := stringList(ap.Cols)
+		for _, name := range names {
+			if len(cols) == 0 {
+				privs = append(privs, priv{name: name})
+			}
+			for _, col := range cols {
+				privs = append(privs, priv{name: name, column: col})
+			}
+		}
+	}
+	var grantees []string
+	for _, item := range listItems(n.Grantees) {
+		if rs, ok := item.(*nodes.RoleSpec); ok {
+			grantees = append(grantees, granteeName(rs))
+		}
+	}
+	for _, obj := range listItems(n.Objects) {
+		var o *Object
+		if rv, ok := obj.(*nodes.RangeVar); ok {
+			o = inv.lookupRel(n.Objtype, rv)
+		} else {
+			o = inv.lookup(n.Objtype, obj)
+		}
+		if o == nil {
+			continue
+		}
+		for _, grantee := range grantees {
+			for _, p := range privs {
+				if n.IsGrant {
+					o.addGrant(Grant{Grantee: grantee, Privilege: p.name, Column: p.column, Grantable: n.GrantOption})
+				} else {
+					o.revoke(grantee, p.name, p.column, n.GrantOption)
+				}
+			}
+		}
+	}
+}
+
+// addGrant adds a grant, or the grant option of an existing one.
+func (o *Object) addGrant(g Grant) {
+	for i, old := range o.ACL {
+		if old.Grantee == g.Grantee && old.Privilege == g.Privilege && old.Column == g.Column {
+			o.ACL[i].Grantable = old.Grantable || g.Grantable
+			return
+		}
+	}
+	o.ACL = append(o.ACL, g)
+}
+
+// revoke removes a privilege, or only its grant option. Revoking a
+// privilege on the object also revokes it on every column.
+func (o *Object) revoke(grantee, privilege, column string, grantOption bool) {
+	kept := o.ACL[:0]
+	for _, g := range o.ACL {
+		if g.Grantee == grantee && g.Privilege == privilege && (column == "" || g.Column == column) {
+			if !grantOption {
+				continue
+			}
+			g.Grantable = false
+		}
+		kept = append(kept, g)
+	}
+	o.ACL = kept
+}
+
+// define adds an object defined at line, or returns the one already
+// listed. rest holds the arguments of a routine, and the table of a
+// constraint, trigger, policy or rule.
+func (inv *Inventory) define(t nodes.ObjectType, schema, name string, line int, rest ...string) *Object {
+	o := inv.object(t, schema, name, rest...)
+	if o.Line == 0 {
+		o.Line = line
+	}
+	return o
+}
+
+// object returns the listed object, adding it if needed.
+func (inv *Inventory) object(t nodes.ObjectType, schema, name string, rest ...string) *Object {
+	var args, table string
+	if len(rest) > 0 {
+		args = rest[0]
+	}
+	if len(rest) > 1 {
+		table = rest[1]
+	}
+	k := key{database: inv.database, class: classOf(t), schema: schema, name: name, table: table, args: args}
+	if o, ok := inv.index[k]; ok {
+		return o
+	}
+	o := &Object{Type: typeName(t), Database: inv.database, Schema: schema, Name: name, Table: table, Args: args}
+	inv.index[k] = o
+	inv.Objects = append(inv.Objects, o)
+	return o
+}
+
+func (inv *Inventory) defineRel(t nodes.ObjectType, rv *nodes.RangeVar, line int) *Object {
+	return inv.define(t, inv.creationSchema(rv.Schemaname), rv.Relname, line)
+}
+
+func (inv *Inventory) defineType(t nodes.ObjectType, names *nodes.List, line int) *Object {
+	schema, name := qualifiedName(names)
+	return inv.define(t, inv.creationSchema(schema), name, line)
+}
+
+// defineOn defines a trigger, policy or rule of a relation.
+func (inv *Inventory) defineOn(t nodes.ObjectType, rv *nodes.RangeVar, name string, line int) *Object {
+	schema := inv.resolve(classOf(nodes.OBJECT_TABLE), rv.Schemaname, rv.Relname)
+	return inv.define(t, schema, name, line, "", rv.Relname)
+}
+
+// lookupRel returns the relation rv names, adding it if the dump has not
+// defined it.
+func (inv *Inventory) lookupRel(t nodes.ObjectType, rv *nodes.RangeVar) *Object {
+	if rv == nil {
+		return nil
+	}
+	schema := inv.resolve(classOf(t), rv.Schemaname, rv.Relname)
+	return inv.object(t, schema, rv.Relname)
+}
+
+// lookupNames returns the relation named by a qualified name, adding it
+// if needed.
+func (inv *Inventory) lookupNames(t nodes.ObjectType, names []string) *Object {
+	rv := &nodes.RangeVar{}
+	switch len(names) {
+	case 1:
+		rv.Relname = names[0]
+	case 2, 3:
+		rv.Schemaname, rv.Relname = names[len(names)-2], names[len(names)-1]
+	default:
+		return nil
+	}
+	return inv.lookupRel(t, rv)
+}
+
+// lookup returns the object named by the object of COMMENT ON, ALTER ...
+// OWNER TO or GRANT, adding it if the dump has not defined it. It returns
+// nil for object types the inventory does not list.
+func (inv *Inventory) lookup(t nodes.ObjectType, n nodes.Node) *Object {
+	if _, ok := typeNames[t]; !ok && t != nodes.OBJECT_ROUTINE {
+		return nil
+	}
+	switch n := n.(type) {
+	case *nodes.String:
+		return inv.object(t, "", n.Sval)
+	case *nodes.TypeName:
+		return inv.lookup(t, n.Names)
+	case *nodes.ObjectWithArgs:
+		schema, name := qualifiedName(n.Objname)
+		var args []*nodes.TypeName
+		for _, item := range listItems(n.Objargs) {
+			if tn, ok := item.(*nodes.TypeName); ok {
+				args = append(args, tn)
+			}
+		}
+		formatted := formatArgs(args)
+		schema = inv.resolveRoutine(schema, name, formatted)
+		if t == nodes.OBJECT_ROUTINE {
+			// Either a function or a procedure.
+			for _, rt := range []nodes.ObjectType{nodes.OBJECT_FUNCTION, nodes.OBJECT_PROCEDURE} {
+				if o, ok := inv.index[key{inv.database, classOf(rt), schema, name, "", formatted}]; ok {
+					return o
+				}
+			}
+			t = nodes.OBJECT_FUNCTION
+		}
+		return inv.object(t, schema, name, formatted)
+	case *nodes.List:
+		if len(n.Items) == 1 {
+			if tn, ok := n.Items[0].(*nodes.TypeName); ok {
+				return inv.lookup(t, tn.Names)
+			}
+		}
+		names := stringList(n)
+		if len(names) != len(n.Items) || len(names) == 0 {
+			return nil
+		}
+		switch t {
+		case nodes.OBJECT_TABCONSTRAINT, nodes.OBJECT_TRIGGER, nodes.OBJECT_POLICY, nodes.OBJECT_RULE:
+			if len(names) < 2 {
+				return nil
+			}
+			rel := names[:len(names)-1]
+			schema := ""
+			if len(rel) > 1 {
+				schema = rel[len(rel)-2]
+			}
+			table := rel[len(rel)-1]
+			schema = inv.resolve(classOf(nodes.OBJECT_TABLE), schema, table)
+			return inv.object(t, schema, names[len(names)-1], "", table)
+		}
+		if !inSchema(t) {
+			return inv.object(t, "", names[len(names)-1])
+		}
+		if classOf(t) == classOf(nodes.OBJECT_TABLE) {
+			return inv.lookupNames(t, names)
+		}
+		schema := ""
+		if len(names) > 1 {
+			schema = names[len(names)-2]
+		}
+		name := names[len(names)-1]
+		return inv.object(t, inv.resolve(classOf(t), schema, name), name)
+	}
+	return nil
+}
+
+// resolve returns the schema of a name: the schema it is qualified with,
+// the first schema of the search path holding an object of the class
+// with that name, or the creation schema.
+func (inv *Inventory) resolve(class, schema, name string) string {
+	if schema != "" {
+		return schema
+	}
+	for _, s := range inv.path {
+		if _, ok := inv.index[key{database: inv.database, class: class, schema: s, name: name}]; ok {
+			return s
+		}
+	}
+	return inv.creationSchema("")
+}
+
+// resolveRoutine is resolve for routines, which are told apart by their
+// argument types as well.
+func (inv *Inventory) resolveRoutine(schema, name, args string) string {
+	if schema != "" {
+		return schema
+	}
+	for _, s := range inv.path {
+		if _, ok := inv.index[key{database: inv.database, class: "routine", schema: s, name: name, args: args}]; ok {
+			return s
+		}
+	}
+	return inv.creationSchema("")
+}
+
+// creationSchema returns the schema an object is created in: the schema
+// it is qualified with, or the first schema of the search path.
+func (inv *Inventory) creationSchema(schema string) string {
+	if schema != "" {
+		return schema
+	}
+	for _, s := range inv.path {
+		if s != "$user" && s != "pg_temp" {
+			return s
+		}
+	}
+	return ""
+}
+
+// typeNames gives the names of the object types the inventory lists.
+var typeNames = map[nodes.ObjectType]string{
+	nodes.OBJECT_AGGREGATE:      "AGGREGATE",
+	nodes.OBJECT_COLLATION:      "COLLATION",
+	nodes.OBJECT_DATABASE:       "DATABASE",
+	nodes.OBJECT_DOMAIN:         "DOMAIN",
+	nodes.OBJECT_EVENT_TRIGGER:  "EVENT TRIGGER",
+	nodes.OBJECT_EXTENSION:      "EXTENSION",
+	nodes.OBJECT_FDW:            "FOREIGN DATA WRAPPER",
+	nodes.OBJECT_FOREIGN_SERVER: "SERVER",
+	nodes.OBJECT_FOREIGN_TABLE:  "FOREIGN TABLE",
+	nodes.OBJECT_FUNCTION:       "FUNCTION",
+	nodes.OBJECT_INDEX:          "INDEX",
+	nodes.OBJECT_LANGUAGE:       "LANGUAGE",
+	nodes.OBJECT_MATVIEW:        "MATERIALIZED VIEW",
+	nodes.OBJECT_POLICY:         "POLICY",
+	nodes.OBJECT_PROCEDURE:      "PROCEDURE",
+	nodes.OBJECT_PUBLICATION:    "PUBLICATION",
+	nodes.OBJECT_RULE:           "RULE",
+	nodes.OBJECT_SCHEMA:         "SCHEMA",
+	nodes.OBJECT_SEQUENCE:       "SEQUENCE",
+	nodes.OBJECT_STATISTIC_EXT:  "STATISTICS",
+	nodes.OBJECT_TABCONSTRAINT:  "CONSTRAINT",
+	nodes.OBJECT_TABLE:          "TABLE",
+	nodes.OBJECT_TRIGGER:        "TRIGGER",
+	nodes.OBJECT_TYPE:           "TYPE",
+	nodes.OBJECT_VIEW:           "VIEW",
+}
+
+func typeName(t nodes.ObjectType) string {
+	if name, ok := typeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("ObjectType(%d)", int(t))
+}
+
+// classOf returns the namespace objects of type t share.
+func classOf(t nodes.ObjectType) string {
+	switch t {
+	case nodes.OBJECT_TABLE, nodes.OBJECT_FOREIGN_TABLE, nodes.OBJECT_VIEW, nodes.OBJECT_MATVIEW,
+		nodes.OBJECT_SEQUENCE, nodes.OBJECT_INDEX:
+		return "relation"
+	case nodes.OBJECT_TYPE, nodes.OBJECT_DOMAIN:
+		return "type"
+	case nodes.OBJECT_FUNCTION, nodes.OBJECT_PROCEDURE, nodes.OBJECT_AGGREGATE, nodes.OBJECT_ROUTINE:
+		return "routine"
+	}
+	return typeName(t)
+}
+
+// inSchema reports whether objects of type t belong to a schema.
+func inSchema(t nodes.ObjectType) bool {
+	switch t {
+	case nodes.OBJECT_DATABASE, nodes.OBJECT_SCHEMA, nodes.OBJECT_EXTENSION, nodes.OBJECT_LANGUAGE,
+		nodes.OBJECT_FDW, nodes.OBJECT_FOREIGN_SERVER, nodes.OBJECT_EVENT_TRIGGER, nodes.OBJECT_PUBLICATION:
+		return false
+	}
+	return true
+}
+
+func isRoutine(objType string) bool {
+	return objType == "FUNCTION" || objType == "PROCEDURE" || objType == "AGGREGATE"
+}
+
+// columnPrivileges are the privileges ALL grants on columns.
+var columnPrivileges = []string{"SELECT", "INSERT", "UPDATE", "REFERENCES"}
+
+// allPrivileges returns the privileges ALL PRIVILEGES stands for on
+// objects of type t.
+func allPrivileges(t nodes.ObjectType) []string {
+	switch t {
+	case nodes.OBJECT_TABLE, nodes.OBJECT_FOREIGN_TABLE, nodes.OBJECT_VIEW, nodes.OBJECT_MATVIEW:
+		return []string{"INSERT", "SELECT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER", "MAINTAIN"}
+	case nodes.OBJECT_SEQUENCE:
+		return []string{"USAGE", "SELECT", "UPDATE"}
+	case nodes.OBJECT_FUNCTION, nodes.OBJECT_PROCEDURE, nodes.OBJECT_ROUTINE:
+		return []string{"EXECUTE"}
+	case nodes.OBJECT_SCHEMA:
+		return []string{"USAGE", "CREATE"}
+	case nodes.OBJECT_DATABASE:
+		return []string{"CREATE", "TEMPORARY", "CONNECT"}
+	case nodes.OBJECT_TABLESPACE:
+		return []string{"CREATE"}
+	case nodes.OBJECT_LARGEOBJECT:
+		return []string{"SELECT", "UPDATE"}
+	case nodes.OBJECT_PARAMETER_ACL:
+		return []string{"SET", "ALTER SYSTEM"}
+	}
+	return []string{"USAGE"} // types, domains, languages, foreign data wrappers and servers
+}
+
+// privilegeName returns the canonical name of a privilege.
+func privilegeName(name string) string {
+	name = strings.ToUpper(name)
+	if name == "TEMP" {
+		return "TEMPORARY"
+	}
+	return name
+}
+
+// roleName returns the name of a role given to OWNER TO or AUTHORIZATION.
+func roleName(r *nodes.RoleSpec) string {
+	if r == nil {
+		return ""
+	}
+	switch nodes.RoleSpecType(r.Roletype) {
+	case nodes.ROLESPEC_CSTRING:
+		return r.Rolename
+	case nodes.ROLESPEC_CURRENT_ROLE:
+		return "CURRENT_ROLE"
+	case nodes.ROLESPEC_CURRENT_USER:
+		return "CURRENT_USER"
+	case nodes.ROLESPEC_SESSION_USER:
+		return "SESSION_USER"
+	}
+	return "PUBLIC"
+}
+
+// granteeName returns the name of a grantee, "PUBLIC" for PUBLIC.
+func granteeName(r *nodes.RoleSpec) string {
+	if nodes.RoleSpecType(r.Roletype) == nodes.ROLESPEC_PUBLIC {
+		return "PUBLIC"
+	}
+	return roleName(r)
+}
+
+// formatArgs formats argument types as pg_dump writes them.
+func formatArgs(args []*nodes.TypeName) string {
+	parts := make([]string, len(args))
+	for i, tn := range args {
+		parts[i] = catalog.FormatTypeName(tn)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// splitSearchPath splits a search_path value given as a single string,
+// e.g. '"$user", public'.
+func splitSearchPath(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if len(part) >= 2 && part[0] == '"' && part[len(part)-1] == '"' {
+			part = strings.ReplaceAll(part[1:len(part)-1], `""`, `"`)
+		} else {
+			part = strings.ToLower(part)
+		}
+		out = append(out, part)
+	}
+	return out
+}
+
+// stringConst returns the value of a string literal.
+func stringConst(n nodes.Node) (string, bool) {
+	if ac, ok := n.(*nodes.A_Const); ok {
+		if s, ok := ac.Val.(*nodes.String); ok {
+			return s.Sval, true
+		}
+	}
+	return "", false
+}
+
+// qualifiedName splits a possibly qualified name given as a list of
+// String nodes. A database name in front of the schema is ignored.
+func qualifiedName(l *nodes.List) (schema, name string) {
+	parts := stringList(l)
+	switch len(parts) {
+	case 0:
+		return "", ""
+	case 1:
+		return "", parts[0]
+	}
+	return parts[len(parts)-2], parts[len(parts)-1]
+}
+
+// asList returns n as a list, wrapping a lone node.
+func asList(n nodes.Node) *nodes.List {
+	if l, ok := n.(*nodes.List); ok {
+		return l
+	}
+	return &nodes.List{Items: []nodes.Node{n}}
+}
+
+// stringList returns the values of the String nodes of a list.
+func stringList(l *nodes.List) []string {
+	var out []string
+	for _, item := range listItems(l) {
+		if s, ok := item.(*nodes.String); ok {
+			out = append(out, s.Sval)
+		}
+	}
+	return out
+}
+
+// listItems returns the items of a possibly-nil list.
+func listItems(l *nodes.List) []nodes.Node {
+	if l == nil {
+		return nil
+	}
+	return l.Items
+}
@@ -0,0 +1,242 @@
+package pgdump
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// dump is a trimmed pg_dump script in the layout of PostgreSQL 17.
+var dump = `--
+-- PostgreSQL database dump
+--
+
+\restrict abc123
+
+SET statement_timeout = 0;
+SET client_encoding = 'UTF8';
+SELECT pg_catalog.set_config('search_path', '', false);
+SET check_function_bodies = false;
+
+--
+-- Name: app; Type: SCHEMA; Schema: -; Owner: alice
+--
+
+CREATE SCHEMA app;
+
+
+ALTER SCHEMA app OWNER TO alice;
+
+COMMENT ON EXTENSION plpgsql IS 'PL/pgSQL procedural language';
+
+CREATE FUNCTION app.total(a integer, OUT s bigint) RETURNS bigint
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+  s := a; -- not the end;
+END;
+$$;
+
+
+ALTER FUNCTION app.total(a integer, OUT s bigint) OWNER TO alice;
+
+COMMENT ON FUNCTION app.total(a integer, OUT s bigint) IS 'Sums; ''quoted''.';
+
+CREATE FUNCTION app.twice(x integer) RETURNS integer
+    LANGUAGE sql
+    BEGIN ATOMIC
+ SELECT CASE WHEN x > 0 THEN x * 2 ELSE 0 END;
+ SELECT 1;
+END;
+
+CREATE TABLE public.t (
+    id integer NOT NULL,
+    a text DEFAULT 'x;y'::text,
+    CONSTRAINT t_a_check CHECK ((a <> ''::text))
+);
+
+
+ALTER TABLE public.t OWNER TO bob;
+
+COMMENT ON TABLE public.t IS 'The t table'; COMMENT ON COLUMN public.t.a IS 'Column a';
+
+COPY public.t (id, a) FROM stdin;
+1	CREATE TABLE fake (x int);
+2	it's; "odd
+` + strings.Repeat("z", 200000) + `
+\.
+
+
+ALTER TABLE ONLY public.t
+    ADD CONSTRAINT t_pkey PRIMARY KEY (id);
+
+REVOKE USAGE ON SCHEMA public FROM PUBLIC;
+GRANT ALL ON SCHEMA public TO PUBLIC;
+REVOKE ALL ON FUNCTION app.total(a integer, OUT s bigint) FROM PUBLIC;
+GRANT ALL ON FUNCTION app.total(a integer, OUT s bigint) TO carol;
+GRANT SELECT,UPDATE(a) ON TABLE public.t TO carol WITH GRANT OPTION;
+ALTER TABLE public.t FROBNICATE;
+
+\unrestrict abc123
+
+\connect other
+
+CREATE TABLE public.t (x integer);
+`
+
+func TestReader(t *testing.T) {
+	rd := NewReader(strings.NewReader(dump))
+	var got []*Stmt
+	for {
+		stmt, err := rd.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, stmt)
+	}
+	find := func(prefix string) *Stmt {
+		for _, s := range got {
+			if strings.HasPrefix(s.SQL, prefix) || (s.Meta != "" && strings.HasPrefix(s.Meta, prefix)) {
+				return s
+			}
+		}
+		t.Fatalf("no statement starting with %q", prefix)
+		return nil
+	}
+	if s := find(`\restrict`); s.Line != 5 || s.Meta != `\restrict abc123` {
+		t.Errorf("restrict = line %d %q", s.Line, s.Meta)
+	}
+	if s := find("CREATE SCHEMA"); s.Line != 16 || s.Node == nil {
+		t.Errorf("CREATE SCHEMA = line %d, node %v", s.Line, s.Node)
+	}
+	if s := find("CREATE FUNCTION app.total"); s.Err != nil || !strings.HasSuffix(s.SQL, "$$") {
+		t.Errorf("dollar-quoted function = %q, %v", s.SQL, s.Err)
+	}
+	if s := find("CREATE FUNCTION app.twice"); s.Err != nil || !strings.HasSuffix(s.SQL, "END") {
+		t.Errorf("BEGIN ATOMIC function = %q, %v", s.SQL, s.Err)
+	}
+	if s := find("COMMENT ON COLUMN"); s.Line != 52 {
+		t.Errorf("second statement of a line starts on line %d", s.Line)
+	}
+	if s := find("ALTER TABLE ONLY"); s.Line != 61 {
+		t.Errorf("statement after COPY data starts on line %d", s.Line)
+	}
+	for _, s := range got {
+		if strings.Contains(s.SQL, "fake") {
+			t.Errorf("COPY data read as a statement: %q", s.SQL)
+		}
+	}
+	if s := find("ALTER TABLE public.t FROBNICATE"); s.Err == nil || s.Node != nil {
+		t.Errorf("broken statement = %v, %v", s.Node, s.Err)
+	}
+	if s := find(`\connect`); s.Meta != `\connect other` {
+		t.Errorf("connect = %q", s.Meta)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	inv, err := Load(strings.NewReader(dump))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, o := range inv.Objects {
+		names = append(names, o.String())
+	}
+	want := []string{
+		"SCHEMA app",
+		"EXTENSION plpgsql",
+		"FUNCTION app.total(integer)",
+		"FUNCTION app.twice(integer)",
+		"TABLE public.t",
+		"CONSTRAINT t_a_check ON public.t",
+		"CONSTRAINT t_pkey ON public.t",
+		"SCHEMA public",
+		"TABLE public.t",
+	}
+	if got := strings.Join(names, "\n"); got != strings.Join(want, "\n") {
+		t.Errorf("objects =\n%s\nwant\n%s", got, strings.Join(want, "\n"))
+	}
+
+	if o := inv.Lookup("SCHEMA", "", "app"); o == nil || o.Owner != "alice" || o.Line != 16 {
+		t.Errorf("schema app = %+v", o)
+	}
+	if o := inv.Lookup("EXTENSION", "", "plpgsql"); o == nil || o.Line != 0 || o.Comment != "PL/pgSQL procedural language" {
+		t.Errorf("extension plpgsql = %+v", o)
+	}
+
+	f := inv.Lookup("FUNCTION", "app", "total(integer)")
+	if f == nil {
+		t.Fatal("function app.total(integer) not found")
+	}
+	if f.Owner != "alice" || f.Comment != "Sums; 'quoted'." {
+		t.Errorf("function = %+v", f)
+	}
+	if len(f.ACL) != 1 || f.ACL[0] != (Grant{Grantee: "carol", Privilege: "EXECUTE"}) {
+		t.Errorf("function ACL = %+v", f.ACL)
+	}
+
+	tbl := inv.Objects[4]
+	if tbl.Owner != "bob" || tbl.Comment != "The t table" || tbl.ColumnComments["a"] != "Column a" || tbl.Database != "" {
+		t.Errorf("table = %+v", tbl)
+	}
+	wantACL := []Grant{
+		{Grantee: "carol", Privilege: "SELECT", Grantable: true},
+		{Grantee: "carol", Privilege: "UPDATE", Column: "a", Grantable: true},
+	}
+	if len(tbl.ACL) != len(wantACL) {
+		t.Fatalf("table ACL = %+v", tbl.ACL)
+	}
+	for i, g := range wantACL {
+		if tbl.ACL[i] != g {
+			t.Errorf("table ACL[%d] = %+v, want %+v", i, tbl.ACL[i], g)
+		}
+	}
+
+	public := inv.Lookup("SCHEMA", "", "public")
+	if public == nil || len(public.ACL) != 2 || public.ACL[0].Privilege != "USAGE" || public.ACL[1].Privilege != "CREATE" {
+		t.Errorf("schema public = %+v", public)
+	}
+
+	if other := inv.Objects[8]; other.Database != "other" || other.Owner != "" {
+		t.Errorf("table in other database = %+v", other)
+	}
+
+	if len(inv.Errors) != 1 || inv.Errors[0].Line != 69 {
+		t.Fatalf("errors = %v", inv.Errors)
+	}
+	if err := inv.Errors[0]; !strings.HasPrefix(err.Error(), "line 69: ") || errors.Unwrap(err) == nil {
+		t.Errorf("error = %v", err)
+	}
+}
+
+func TestLoadSearchPath(t *testing.T) {
+	inv, err := Load(strings.NewReader(`
+SET search_path = app, public;
+CREATE TABLE t (a int);
+CREATE SCHEMA s CREATE TABLE u (b int);
+COMMENT ON TABLE t IS 'in app';
+COMMENT ON TABLE public.t IS 'in public';
+GRANT SELECT ON u TO dave;
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o := inv.Lookup("TABLE", "app", "t"); o == nil || o.Comment != "in app" {
+		t.Errorf("app.t = %+v", o)
+	}
+	if o := inv.Lookup("TABLE", "public", "t"); o == nil || o.Line != 0 || o.Comment != "in public" {
+		t.Errorf("public.t = %+v", o)
+	}
+	if o := inv.Lookup("TABLE", "s", "u"); o == nil || o.Line != 4 {
+		t.Errorf("s.u = %+v", o)
+	}
+	// u is not on the search path, so the grant is for app.u.
+	if o := inv.Lookup("TABLE", "app", "u"); o == nil || len(o.ACL) != 1 {
+		t.Errorf("app.u = %+v", o)
+	}
+}
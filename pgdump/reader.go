@@ -0,0 +1,334 @@
+// Package pgdump reads the plain-text SQL scripts written by pg_dump and
+// pg_dumpall, and lists the objects they define.
+//
+// A dump is read one statement at a time, so that its size does not
+// matter. The data of COPY ... FROM stdin is skipped without being held
+// in memory, and psql metacommands such as \connect and the \restrict and
+// \unrestrict lines of PostgreSQL 17.6 and later are returned apart from
+// SQL statements.
+package pgdump
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+)
+
+// Stmt is a statement or psql metacommand read from a dump.
+type Stmt struct {
+	Line int    // 1-based line the statement starts on
+	SQL  string // statement text, without the terminating semicolon; "" for metacommands
+	Meta string // metacommand line, such as `\connect app`; "" for statements
+
+	Node nodes.Node // the parsed statement; nil for metacommands and statements that did not parse
+	Err  error      // the parse error
+}
+
+// Reader reads the statements of a dump.
+type Reader struct {
+	r    *bufio.Reader
+	line int // number of lines read
+
+	// rest is the part of the current line after the semicolon ending
+	// the previous statement.
+	rest     string
+	restLine int
+}
+
+// NewReader returns a Reader reading a dump from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReaderSize(r, 64*1024)}
+}
+
+// Next returns the next statement of the dump, or io.EOF once all have
+// been read. A statement that does not parse is returned with Err set;
+// only errors reading the dump are returned as errors.
+func (r *Reader) Next() (*Stmt, error) {
+	var sc scanner
+	var buf strings.Builder
+	start := 0
+	for {
+		text, line, err := r.readLine()
+		if err != nil {
+			if err != io.EOF {
+				return nil, err
+			}
+			if sc.hasSQL {
+				return r.finish(buf.String(), start)
+			}
+			return nil, io.EOF
+		}
+		if !sc.hasSQL && sc.state == stNormal {
+			if trimmed := strings.TrimSpace(text); strings.HasPrefix(trimmed, `\`) {
+				return &Stmt{Line: line, Meta: trimmed}, nil
+			}
+		}
+		hadSQL := sc.hasSQL
+		end := sc.scan(text)
+		if !hadSQL && sc.hasSQL {
+			start = line
+		}
+		if end >= 0 {
+			buf.WriteString(text[:end])
+			if rest := text[end+1:]; strings.TrimSpace(rest) != "" {
+				r.rest, r.restLine = rest, line
+			}
+			return r.finish(buf.String(), start)
+		}
+		if !sc.hasSQL && sc.state == stNormal {
+			// Drop the comments in front of a statement.
+			buf.Reset()
+			continue
+		}
+		buf.WriteString(text)
+		buf.WriteByte('\n')
+	}
+}
+
+// readLine returns the next line, without its line terminator, and its
+// number.
+func (r *Reader) readLine() (string, int, error) {
+	if r.rest != "" {
+		text := r.rest
+		r.rest = ""
+		return text, r.restLine, nil
+	}
+	text, err := r.r.ReadString('\n')
+	if err != nil && (err != io.EOF || text == "") {
+		return "", 0, err
+	}
+	r.line++
+	text = strings.TrimSuffix(text, "\n")
+	return strings.TrimSuffix(text, "\r"), r.line, nil
+}
+
+// copyFromStdin matches COPY ... FROM stdin, for statements that do not
+// parse.
+var copyFromStdin = regexp.MustCompile(`(?is)^\s*COPY\b.*\bFROM\s+STDIN\b`)
+
+// finish parses a statement, and skips the data following a COPY ...
+// FROM stdin.
+func (r *Reader) finish(sql string, line int) (*Stmt, error) {
+	stmt := &Stmt{Line: line, SQL: strings.TrimSpace(sql)}
+	list, err := parser.Parse(stmt.SQL)
+	switch {
+	case err != nil:
+		stmt.Err = err
+	case list != nil && len(list.Items) > 0:
+		stmt.Node = list.Items[0]
+	}
+	copyData := false
+	if cs, ok := stmt.Node.(*nodes.CopyStmt); ok {
+		copyData = cs.IsFrom && cs.Filename == "" && !cs.IsProgram
+	} else if stmt.Err != nil {
+		copyData = copyFromStdin.MatchString(stmt.SQL)
+	}
+	if copyData {
+		if err := r.skipCopyData(); err != nil {
+			return nil, err
+		}
+	}
+	return stmt, nil
+}
+
+// skipCopyData skips the lines of COPY data up to and including the
+// terminating `\.` line. Lines are read in place, however long they are.
+func (r *Reader) skipCopyData() error {
+	r.rest = ""
+	atStart := true
+	for {
+		chunk, err := r.r.ReadSlice('\n')
+		complete := err == nil
+		if complete {
+			r.line++
+		}
+		if atStart && isCopyEnd(chunk) {
+			return nil
+		}
+		atStart = complete
+		switch err {
+		case nil, bufio.ErrBufferFull:
+		case io.EOF:
+			return nil // the data is cut short
+		default:
+			return err
+		}
+	}
+}
+
+// isCopyEnd reports whether line is the `\.` line ending COPY data.
+func isCopyEnd(line []byte) bool {
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	line = bytes.TrimSuffix(line, []byte("\r"))
+	return string(line) == `\.`
+}
+
+// scanState is the lexical context at a point of a statement.
+type scanState int
+
+const (
+	stNormal       scanState = iota
+	stSingleQuote            // inside '...'
+	stEscapeString           // inside E'...', with backslash escapes
+	stDoubleQuote            // inside "..."
+	stDollarQuote            // inside $tag$...$tag$
+	stBlockComment           // inside /* ... */
+)
+
+// scanner finds the semicolon ending a statement, line by line. A
+// semicolon ends the statement unless it is quoted, in a comment, inside
+// parentheses or in the body of a BEGIN ATOMIC ... END function.
+type scanner struct {
+	state      scanState
+	dollarTag  string
+	blockDepth int
+	parenDepth int
+	hasSQL     bool // the statement has text other than comments
+
+	atomicDepth int // nesting of BEGIN ATOMIC bodies
+	caseDepth   int // nesting of CASE expressions inside them, whose END is not the body's
+	word        []byte
+	lastWord    string
+}
+
+// scan scans a line and returns the index of the semicolon ending the
+// statement, or -1 if the statement goes on.
+func (s *scanner) scan(text string) int {
+	n := len(text)
+	for i := 0; i < n; i++ {
+		c := text[i]
+		switch s.state {
+		case stNormal:
+			if isIdentChar(c) {
+				s.word = append(s.word, c)
+			} else {
+				s.endWord()
+			}
+			switch {
+			case c == ';' && s.parenDepth == 0 && s.atomicDepth == 0:
+				return i
+			case c == '-' && i+1 < n && text[i+1] == '-':
+				return -1
+			case c == '/' && i+1 < n && text[i+1] == '*':
+				s.state, s.blockDepth = stBlockComment, 1
+				i++
+				continue
+			case c == '\'':
+				s.state = stSingleQuote
+				if i > 0 && (text[i-1] == 'E' || text[i-1] == 'e') && (i == 1 || !isIdentChar(text[i-2])) {
+					s.state = stEscapeString
+				}
+			case c == '"':
+				s.state = stDoubleQuote
+			case c == '$' && (i == 0 || !isIdentChar(text[i-1])):
+				if tag := dollarTag(text, i); tag != "" {
+					s.state, s.dollarTag = stDollarQuote, tag
+					i += len(tag) - 1
+				}
+			case c == '(':
+				s.parenDepth++
+			case c == ')':
+				if s.parenDepth > 0 {
+					s.parenDepth--
+				}
+			}
+			if c != ' ' && c != '\t' {
+				s.hasSQL = true
+			}
+		case stSingleQuote, stDoubleQuote:
+			quote := byte('\'')
+			if s.state == stDoubleQuote {
+				quote = '"'
+			}
+			if c == quote {
+				if i+1 < n && text[i+1] == quote {
+					i++
+				} else {
+					s.state = stNormal
+				}
+			}
+		case stEscapeString:
+			switch {
+			case c == '\\':
+				i++
+			case c == '\'' && i+1 < n && text[i+1] == '\'':
+				i++
+			case c == '\'':
+				s.state = stNormal
+			}
+		case stDollarQuote:
+			if c == '$' && strings.HasPrefix(text[i:], s.dollarTag) {
+				i += len(s.dollarTag) - 1
+				s.state = stNormal
+			}
+		case stBlockComment:
+			switch {
+			case c == '/' && i+1 < n && text[i+1] == '*':
+				s.blockDepth++
+				i++
+			case c == '*' && i+1 < n && text[i+1] == '/':
+				s.blockDepth--
+				i++
+				if s.blockDepth == 0 {
+					s.state = stNormal
+				}
+			}
+		}
+	}
+	if s.state == stNormal {
+		s.endWord()
+	}
+	return -1
+}
+
+// endWord tracks the keywords delimiting BEGIN ATOMIC bodies.
+func (s *scanner) endWord() {
+	if len(s.word) == 0 {
+		return
+	}
+	word := strings.ToUpper(string(s.word))
+	s.word = s.word[:0]
+	switch {
+	case word == "ATOMIC" && s.lastWord == "BEGIN":
+		s.atomicDepth++
+	case word == "CASE" && s.atomicDepth > 0:
+		s.caseDepth++
+	case word == "END" && s.caseDepth > 0:
+		s.caseDepth--
+	case word == "END" && s.atomicDepth > 0 && s.parenDepth == 0:
+		s.atomicDepth--
+	}
+	s.lastWord = word
+}
+
+// dollarTag returns the dollar-quote tag starting at text[i], such as
+// "$$" or "$body$", or "" if there is none.
+func dollarTag(text string, i int) string {
+	j := i + 1
+	if j < len(text) && text[j] == '$' {
+		return "$$"
+	}
+	if j >= len(text) || isDigit(text[j]) || !isIdentChar(text[j]) {
+		return ""
+	}
+	for j < len(text) && isIdentChar(text[j]) {
+		j++
+	}
+	if j < len(text) && text[j] == '$' {
+		return text[i : j+1]
+	}
+	return ""
+}
+
+func isIdentChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || isDigit(c) || c >= 0x80
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
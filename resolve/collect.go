@@ -0,0 +1,282 @@
+package resolve
+
+import (
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// name is a name found in a raw parse tree, before resolution.
+type name struct {
+	kind      Kind
+	schema    string
+	name      string
+	creates   bool
+	missingOK bool // DROP ... IF EXISTS: a missing object is not a problem
+	loc       nodes.ParseLoc
+}
+
+// definitionFuncs holds the options of CREATE OPERATOR, CREATE AGGREGATE
+// and CREATE TYPE whose value names a function.
+var definitionFuncs = map[string]bool{
+	"function": true, "procedure": true, "restrict": true, "join": true,
+	"sfunc": true, "finalfunc": true, "combinefunc": true, "serialfunc": true,
+	"deserialfunc": true, "msfunc": true, "minvfunc": true, "mfinalfunc": true,
+	"input": true, "output": true, "receive": true, "send": true,
+	"typmod_in": true, "typmod_out": true, "analyze": true, "subscript": true,
+}
+
+// collector gathers the names of a statement.
+type collector struct {
+	names []name
+	ctes  []map[string]bool // CTE names in scope, innermost last
+}
+
+func (c *collector) add(kind Kind, schema, n string, loc nodes.ParseLoc) {
+	if n != "" {
+		c.names = append(c.names, name{kind: kind, schema: schema, name: n, loc: loc})
+	}
+}
+
+func (c *collector) addList(kind Kind, l *nodes.List, loc nodes.ParseLoc) {
+	schema, n := qualifiedName(l)
+	c.add(kind, schema, n, loc)
+}
+
+// create records the name of an object a statement creates.
+func (c *collector) create(kind Kind, schema, n string, loc nodes.ParseLoc) {
+	if n != "" {
+		c.names = append(c.names, name{kind: kind, schema: schema, name: n, creates: true, loc: loc})
+	}
+}
+
+func (c *collector) createList(kind Kind, l *nodes.List) {
+	schema, n := qualifiedName(l)
+	c.create(kind, schema, n, -1)
+}
+
+// isCTE reports whether an unqualified relation name refers to a CTE in
+// scope.
+func (c *collector) isCTE(n string) bool {
+	for i := len(c.ctes) - 1; i >= 0; i-- {
+		if c.ctes[i][n] {
+			return true
+		}
+	}
+	return false
+}
+
+// withScope walks the statement body and its WITH clause with the CTE
+// names of with in scope.
+func (c *collector) withScope(with *nodes.WithClause, body nodes.Node) {
+	scope := map[string]bool{}
+	if with.Ctes != nil {
+		for _, item := range with.Ctes.Items {
+			scope[item.(*nodes.CommonTableExpr).Ctename] = true
+		}
+	}
+	c.ctes = append(c.ctes, scope)
+	c.walk(with)
+	c.walk(body)
+	c.ctes = c.ctes[:len(c.ctes)-1]
+}
+
+// walk collects the names below node.
+func (c *collector) walk(node nodes.Node) {
+	nodes.Walk(node, c.visit)
+}
+
+// walkBody is like walk, but does not treat node itself as a statement;
+// it is used to walk the rest of a statement whose own names have been
+// collected.
+func (c *collector) walkBody(node nodes.Node) {
+	nodes.Walk(node, func(n nodes.Node) bool {
+		return n == node || c.visit(n)
+	})
+}
+
+// visit collects the names of n and reports whether to walk its children.
+func (c *collector) visit(n nodes.Node) bool {
+	switch n := n.(type) {
+	case *nodes.SelectStmt:
+		if n.WithClause != nil {
+			body := *n
+			body.WithClause = nil
+			c.withScope(n.WithClause, &body)
+			return false
+		}
+		if n.IntoClause != nil {
+			c.create(Relation, n.IntoClause.Rel.Schemaname, n.IntoClause.Rel.Relname, n.IntoClause.Rel.Location)
+			body := *n
+			body.IntoClause = nil
+			c.walk(&body)
+			return false
+		}
+	case *nodes.InsertStmt:
+		if n.WithClause != nil {
+			body := *n
+			body.WithClause = nil
+			c.withScope(n.WithClause, &body)
+			return false
+		}
+	case *nodes.UpdateStmt:
+		if n.WithClause != nil {
+			body := *n
+			body.WithClause = nil
+			c.withScope(n.WithClause, &body)
+			return false
+		}
+	case *nodes.DeleteStmt:
+		if n.WithClause != nil {
+			body := *n
+			body.WithClause = nil
+			c.withScope(n.WithClause, &body)
+			return false
+		}
+	case *nodes.MergeStmt:
+		if n.WithClause != nil {
+			body := *n
+			body.WithClause = nil
+			c.withScope(n.WithClause, &body)
+			return false
+		}
+
+	case *nodes.RangeVar:
+		if n.Schemaname == "" && c.isCTE(n.Relname) {
+			return false
+		}
+		c.add(Relation, n.Schemaname, n.Relname, n.Location)
+		return false
+	case *nodes.FuncCall:
+		c.addList(Function, n.Funcname, n.Location)
+	case *nodes.TypeName:
+		if !n.PctType {
+			c.addList(Type, n.Names, n.Location)
+		}
+	case *nodes.A_Expr:
+		switch n.Kind {
+		case nodes.AEXPR_BETWEEN, nodes.AEXPR_NOT_BETWEEN, nodes.AEXPR_BETWEEN_SYM, nodes.AEXPR_NOT_BETWEEN_SYM:
+		default:
+			c.addList(Operator, n.Name, n.Location)
+		}
+
+	case *nodes.CreateStmt:
+		c.create(Relation, n.Relation.Schemaname, n.Relation.Relname, n.Relation.Location)
+		body := *n
+		body.Relation = nil
+		c.walkBody(&body)
+		return false
+	case *nodes.CreateForeignTableStmt:
+		c.walk(&n.Base)
+		return false
+	case *nodes.ViewStmt:
+		c.create(Relation, n.View.Schemaname, n.View.Relname, n.View.Location)
+		c.walk(n.Query)
+		return false
+	case *nodes.CreateTableAsStmt:
+		if n.Into != nil && n.Into.Rel != nil {
+			c.create(Relation, n.Into.Rel.Schemaname, n.Into.Rel.Relname, n.Into.Rel.Location)
+		}
+		c.walk(n.Query)
+		return false
+	case *nodes.CreateSeqStmt:
+		c.create(Relation, n.Sequence.Schemaname, n.Sequence.Relname, n.Sequence.Location)
+		c.walk(n.Options)
+		return false
+	case *nodes.CompositeTypeStmt:
+		c.create(Type, n.Typevar.Schemaname, n.Typevar.Relname, n.Typevar.Location)
+		c.walk(n.Coldeflist)
+		return false
+	case *nodes.CreateEnumStmt:
+		c.createList(Type, n.TypeName)
+		return false
+	case *nodes.CreateRangeStmt:
+		c.createList(Type, n.TypeName)
+		c.walk(n.Params)
+		return false
+	case *nodes.CreateDomainStmt:
+		c.createList(Type, n.Domainname)
+		body := *n
+		body.Domainname = nil
+		c.walkBody(&body)
+		return false
+	case *nodes.CreateFunctionStmt:
+		c.createList(Function, n.Funcname)
+		body := *n
+		body.Funcname = nil
+		c.walkBody(&body)
+		return false
+	case *nodes.DefineStmt:
+		switch n.Kind {
+		case nodes.OBJECT_AGGREGATE:
+			c.createList(Function, n.Defnames)
+		case nodes.OBJECT_OPERATOR:
+			c.createList(Operator, n.Defnames)
+		case nodes.OBJECT_TYPE:
+			c.createList(Type, n.Defnames)
+		}
+		c.walk(n.Args)
+		if n.Definition != nil {
+			for _, item := range n.Definition.Items {
+				d, ok := item.(*nodes.DefElem)
+				if !ok {
+					continue
+				}
+				// Support functions are written like type names.
+				if tn, ok := d.Arg.(*nodes.TypeName); ok && definitionFuncs[strings.ToLower(d.Defname)] {
+					c.addList(Function, tn.Names, tn.Location)
+					continue
+				}
+				c.walk(d)
+			}
+		}
+		return false
+
+	case *nodes.DropStmt:
+		c.drop(n)
+		return false
+	case *nodes.AlterFunctionStmt:
+		if n.Func != nil {
+			c.addList(Function, n.Func.Objname, -1)
+			c.walk(n.Func.Objargs)
+		}
+		c.walk(n.Actions)
+		return false
+	}
+	return true
+}
+
+// drop collects the names of the objects a DROP statement removes.
+func (c *collector) drop(n *nodes.DropStmt) {
+	var kind Kind
+	switch nodes.ObjectType(n.RemoveType) {
+	case nodes.OBJECT_TABLE, nodes.OBJECT_VIEW, nodes.OBJECT_MATVIEW, nodes.OBJECT_SEQUENCE,
+		nodes.OBJECT_INDEX, nodes.OBJECT_FOREIGN_TABLE:
+		kind = Relation
+	case nodes.OBJECT_FUNCTION, nodes.OBJECT_PROCEDURE, nodes.OBJECT_ROUTINE, nodes.OBJECT_AGGREGATE:
+		kind = Function
+	case nodes.OBJECT_TYPE, nodes.OBJECT_DOMAIN:
+		kind = Type
+	default:
+		return
+	}
+	start := len(c.names)
+	if n.Objects != nil {
+		for _, obj := range n.Objects.Items {
+			switch obj := obj.(type) {
+			case *nodes.List:
+				c.addList(kind, obj, -1)
+			case *nodes.ObjectWithArgs:
+				c.addList(kind, obj.Objname, -1)
+				c.walk(obj.Objargs)
+			case *nodes.TypeName:
+				c.addList(kind, obj.Names, obj.Location)
+			}
+		}
+	}
+	if n.MissingOk {
+		for i := start; i < len(c.names); i++ {
+			c.names[i].missingOK = true
+		}
+	}
+}
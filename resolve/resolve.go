@@ -0,0 +1,306 @@
+// Package resolve qualifies the names a SQL script refers to, emulating
+// PostgreSQL's search_path lookup.
+//
+// Every reference to a relation, function, type or operator, and every
+// name of an object a statement creates, is resolved to the schema that
+// PostgreSQL would pick given the catalog and the search path in effect
+// at that point of the script. SET search_path and RESET search_path
+// statements take effect for the statements after them, and DDL is
+// applied as it is reached, so objects created by the script are seen by
+// the statements that follow.
+//
+// Relations, types and functions created by DDL are known to the catalog;
+// built-in types, functions and operators come from the snapshot of
+// package sema. Operators created with CREATE OPERATOR are known only if
+// the script creates them. Since the snapshot does not hold every system
+// object, names qualified with pg_catalog or information_schema are
+// trusted, and unqualified names beginning with "pg_" that are not found
+// are taken to be in pg_catalog.
+package resolve
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pgplex/pgparser/catalog"
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+	"github.com/pgplex/pgparser/sema"
+)
+
+// Kind is the kind of object a name refers to.
+type Kind int
+
+const (
+	Relation Kind = iota // table, view, sequence, index, ...
+	Function             // function, procedure or aggregate
+	Type
+	Operator
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Relation:
+		return "relation"
+	case Function:
+		return "function"
+	case Type:
+		return "type"
+	case Operator:
+		return "operator"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Status tells whether a reference resolved.
+type Status int
+
+const (
+	Resolved   Status = iota
+	Unresolved        // no such object is visible; or, for a new object, no schema to create it in
+	Ambiguous         // the name exists in more than one schema of the search path
+)
+
+func (s Status) String() string {
+	switch s {
+	case Resolved:
+		return "resolved"
+	case Unresolved:
+		return "unresolved"
+	case Ambiguous:
+		return "ambiguous"
+	}
+	return fmt.Sprintf("Status(%d)", int(s))
+}
+
+// Ref is a name referenced or defined by a statement.
+type Ref struct {
+	Kind      Kind
+	Name      string
+	Schema    string // the schema the name resolves to; as written if Qualified; "" if unresolved
+	Qualified bool   // the schema was written out
+	Creates   bool   // the name is that of an object the statement creates
+	Status    Status
+
+	// Candidates lists the schemas of the search path holding the
+	// name, in search order, when Status is Ambiguous. Schema is the
+	// first of them, which PostgreSQL picks for relations and types; a
+	// function or operator is chosen by its argument types as well.
+	Candidates []string
+
+	Stmt     int            // index of the statement in the script
+	Location nodes.ParseLoc // token location, or -1 if unknown
+}
+
+// String returns the qualified name, or the name as written if it could
+// not be resolved.
+func (r Ref) String() string {
+	if r.Schema == "" {
+		return r.Name
+	}
+	return r.Schema + "." + r.Name
+}
+
+// Script resolves the names of every statement of sql, applying each
+// statement to c after resolving it. It stops at the first statement c
+// cannot apply; c then reflects the statements before it.
+func Script(sql string, c *catalog.Catalog) ([]Ref, error) {
+	stmts, err := parser.Parse(sql)
+	if err != nil {
+		return nil, err
+	}
+	r := New(c)
+	var out []Ref
+	var list []nodes.Node
+	if stmts != nil {
+		list = stmts.Items
+	}
+	for i, stmt := range list {
+		refs := r.Stmt(stmt)
+		for j := range refs {
+			refs[j].Stmt = i
+		}
+		out = append(out, refs...)
+		if err := r.Apply(stmt); err != nil {
+			return out, fmt.Errorf("statement %d: %w", i+1, err)
+		}
+	}
+	return out, nil
+}
+
+// Resolver resolves names against a catalog that changes as statements
+// are applied to it.
+type Resolver struct {
+	cat *catalog.Catalog
+
+	snap      sema.Catalog    // built-in objects and cat; nil when stale
+	operators map[string]bool // "schema.name" of operators created by applied statements
+}
+
+// New returns a resolver for names in c.
+func New(c *catalog.Catalog) *Resolver {
+	return &Resolver{cat: c, operators: map[string]bool{}}
+}
+
+// Apply applies stmt to the catalog, so that the statements after it see
+// its effects, including changes to search_path.
+func (r *Resolver) Apply(stmt nodes.Node) error {
+	if err := r.cat.Apply(stmt); err != nil {
+		return err
+	}
+	if raw, ok := stmt.(*nodes.RawStmt); ok {
+		stmt = raw.Stmt
+	}
+	switch n := stmt.(type) {
+	case *nodes.SelectStmt:
+		if n.IntoClause == nil {
+			return nil
+		}
+	case *nodes.InsertStmt, *nodes.UpdateStmt, *nodes.DeleteStmt, *nodes.MergeStmt, *nodes.VariableSetStmt:
+		return nil
+	case *nodes.DefineStmt:
+		if n.Kind == nodes.OBJECT_OPERATOR {
+			if schema, name := qualifiedName(n.Defnames); name != "" {
+				if schema == "" {
+					schema = r.cat.CreationSchema()
+				}
+				r.operators[schema+"."+name] = true
+			}
+		}
+	}
+	r.snap = nil
+	return nil
+}
+
+// Stmt returns the names a statement refers to or defines, resolved with
+// the current search path, in the order they appear in the parse tree.
+// It does not apply the statement.
+func (r *Resolver) Stmt(stmt nodes.Node) []Ref {
+	if r.snap == nil {
+		r.snap = sema.FromCatalog(r.cat)
+	}
+	col := &collector{}
+	col.walk(stmt)
+	var out []Ref
+	for _, n := range col.names {
+		ref := r.resolve(n)
+		if n.missingOK && ref.Status == Unresolved {
+			continue
+		}
+		out = append(out, ref)
+	}
+	return out
+}
+
+// resolve looks up a name found in a statement.
+func (r *Resolver) resolve(n name) Ref {
+	ref := Ref{Kind: n.kind, Name: n.name, Schema: n.schema, Qualified: n.schema != "", Creates: n.creates, Location: n.loc}
+	if n.creates {
+		if n.schema == "" {
+			ref.Schema = r.cat.CreationSchema()
+		}
+		if ref.Schema == "" || r.cat.Schema(ref.Schema) == nil {
+			ref.Status = Unresolved
+		}
+		return ref
+	}
+	if n.schema != "" {
+		if !r.exists(n.kind, n.schema, n.name) && !trusted(n.schema) {
+			ref.Status = Unresolved
+		}
+		return ref
+	}
+	var hits []string
+	for _, schema := range r.cat.ActiveSearchPath() {
+		if schema == "pg_temp" && (n.kind == Function || n.kind == Operator) {
+			continue // never searched for functions and operators
+		}
+		if r.exists(n.kind, schema, n.name) {
+			hits = append(hits, schema)
+		}
+	}
+	switch len(hits) {
+	case 0:
+		if strings.HasPrefix(n.name, "pg_") {
+			// Presumably a system object the snapshot lacks.
+			ref.Schema = "pg_catalog"
+			break
+		}
+		ref.Status = Unresolved
+	case 1:
+		ref.Schema = hits[0]
+	default:
+		ref.Schema, ref.Status, ref.Candidates = hits[0], Ambiguous, hits
+	}
+	return ref
+}
+
+// exists reports whether schema holds an object of the given kind and
+// name.
+func (r *Resolver) exists(kind Kind, schema, name string) bool {
+	switch kind {
+	case Relation:
+		s := r.cat.Schema(schema)
+		return s != nil && s.Relation(name) != nil
+	case Function:
+		return len(r.snap.LookupFunctions(schema, name)) > 0
+	case Type:
+		return r.snap.LookupType(schema, name) != nil
+	case Operator:
+		if schema == "pg_catalog" {
+			return len(r.snap.LookupOperators(schema, name)) > 0
+		}
+		return r.operators[schema+"."+name]
+	}
+	return false
+}
+
+// trusted reports whether names qualified with schema are assumed to
+// exist.
+func trusted(schema string) bool {
+	return schema == "pg_catalog" || schema == "information_schema"
+}
+
+// qualifiedName splits a possibly qualified name given as a list of
+// String nodes. A database name in front of the schema is ignored.
+func qualifiedName(l *nodes.List) (schema, name string) {
+	if l == nil {
+		return "", ""
+	}
+	var parts []string
+	for _, item := range l.Items {
+		s, ok := item.(*nodes.String)
+		if !ok {
+			return "", ""
+		}
+		parts = append(parts, s.Sval)
+	}
+	switch len(parts) {
+	case 0:
+		return "", ""
+	case 1:
+		return "", parts[0]
+	}
+	return parts[len(parts)-2], parts[len(parts)-1]
+}
+
+// Format renders refs one per line, as "kind name -> schema.name",
+// followed by the problem for names that did not resolve cleanly.
+func Format(refs []Ref) string {
+	var b strings.Builder
+	for _, ref := range refs {
+		verb := "->"
+		if ref.Creates {
+			verb = "creates"
+		}
+		fmt.Fprintf(&b, "%s %s %s %s", ref.Kind, ref.Name, verb, ref)
+		switch ref.Status {
+		case Unresolved:
+			b.WriteString(" (unresolved)")
+		case Ambiguous:
+			fmt.Fprintf(&b, " (ambiguous: %s)", strings.Join(ref.Candidates, ", "))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
@@ -0,0 +1,132 @@
+package resolve
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pgplex/pgparser/catalog"
+)
+
+// script resolves sql against a catalog prepared with setup and returns
+// the references in Format's layout, one per line.
+func script(t *testing.T, setup, sql string) []string {
+	t.Helper()
+	c := catalog.New()
+	if err := c.Exec(setup); err != nil {
+		t.Fatal(err)
+	}
+	refs, err := Script(sql, c)
+	if err != nil {
+		t.Fatalf("Script(%q) error: %v", sql, err)
+	}
+	return strings.Split(strings.TrimSuffix(Format(refs), "\n"), "\n")
+}
+
+func checkLines(t *testing.T, got, want []string) {
+	t.Helper()
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got\n  %s\nwant\n  %s", strings.Join(got, "\n  "), strings.Join(want, "\n  "))
+	}
+}
+
+func TestScript(t *testing.T) {
+	got := script(t, "CREATE SCHEMA app; CREATE TABLE users (id int)", `
+		SELECT lower(name), id + 1 FROM users u JOIN app.missing m USING (id) WHERE name LIKE 'a%';
+		SET search_path = app, public;
+		CREATE TABLE users (id bigint);
+		WITH recent AS (SELECT * FROM users) SELECT count(*) FROM recent, public.users;
+		RESET search_path;
+		INSERT INTO users VALUES (nextval('s'));
+		SELECT nofunc(1)::mood;
+	`)
+	checkLines(t, got, []string{
+		"function lower -> pg_catalog.lower",
+		"operator + -> pg_catalog.+",
+		"relation users -> public.users",
+		"relation missing -> app.missing (unresolved)",
+		"operator ~~ -> pg_catalog.~~",
+		"relation users creates app.users",
+		"type int8 -> pg_catalog.int8",
+		"relation users -> app.users (ambiguous: app, public)",
+		"function count -> pg_catalog.count",
+		"relation users -> public.users",
+		"relation users -> public.users",
+		"function nextval -> pg_catalog.nextval",
+		"function nofunc -> nofunc (unresolved)",
+		"type mood -> mood (unresolved)",
+	})
+}
+
+func TestScriptDDL(t *testing.T) {
+	got := script(t, "CREATE SCHEMA app", `
+		SET search_path TO app;
+		CREATE TYPE mood AS ENUM ('ok');
+		CREATE FUNCTION cheer(m mood) RETURNS text LANGUAGE sql AS 'SELECT 1';
+		CREATE OPERATOR === (function = cheer, leftarg = mood);
+		CREATE VIEW v AS SELECT 'ok'::mood === NULL AS c;
+		DROP VIEW IF EXISTS v, nope;
+		DROP FUNCTION cheer(mood);
+		SELECT * FROM pg_class;
+	`)
+	checkLines(t, got, []string{
+		"type mood creates app.mood",
+		"function cheer creates app.cheer",
+		"type mood -> app.mood",
+		"type text -> pg_catalog.text",
+		"operator === creates app.===",
+		"function cheer -> app.cheer",
+		"type mood -> app.mood",
+		"relation v creates app.v",
+		"operator === -> app.===",
+		"type mood -> app.mood",
+		"relation v -> app.v",
+		"function cheer -> app.cheer",
+		"type mood -> app.mood",
+		"relation pg_class -> pg_catalog.pg_class",
+	})
+}
+
+func TestPublicDependencies(t *testing.T) {
+	// The intended use: find unqualified names that land in public.
+	c := catalog.New()
+	refs, err := Script(`
+		CREATE SCHEMA billing;
+		CREATE TABLE billing.invoices (id int);
+		CREATE TABLE audit (id int);
+		INSERT INTO audit SELECT id FROM billing.invoices;
+	`, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var public []string
+	for _, ref := range refs {
+		if !ref.Qualified && ref.Schema == "public" {
+			public = append(public, ref.String())
+		}
+	}
+	if got, want := strings.Join(public, ","), "public.audit,public.audit"; got != want {
+		t.Errorf("unqualified public names = %s, want %s", got, want)
+	}
+	if refs[len(refs)-1].Stmt != 3 {
+		t.Errorf("Stmt = %d, want 3", refs[len(refs)-1].Stmt)
+	}
+}
+
+func TestScriptError(t *testing.T) {
+	c := catalog.New()
+	refs, err := Script("CREATE TABLE a (id int); CREATE TABLE a (id int)", c)
+	if err == nil || err.Error() != `statement 2: relation "a" already exists` {
+		t.Errorf("Script error = %v", err)
+	}
+	if len(refs) != 4 {
+		t.Errorf("got %d references, want those of both statements", len(refs))
+	}
+
+	refs, err = Script("SET search_path = nope; CREATE TABLE t (a int)", catalog.New())
+	if err == nil || err.Error() != "statement 2: no schema has been selected to create in" {
+		t.Errorf("Script error = %v", err)
+	}
+	if got := Format(refs[:1]); got != "relation t creates t (unresolved)\n" {
+		t.Errorf("got %q", got)
+	}
+}
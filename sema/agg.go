@@ -0,0 +1,187 @@
+package sema
+
+import (
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// parseCheckAggregates checks a grouped or aggregated query: every column
+// of this query level used in the target list or HAVING outside an
+// aggregate must be grouped, or functionally dependent on the grouping
+// columns through a primary key (parseCheckAggregates). It also fills in
+// the references of GROUPING() calls.
+func (ps *pstate) parseCheckAggregates(q *nodes.Query) error {
+	var tlist []*nodes.TargetEntry
+	for _, item := range items(q.TargetList) {
+		tlist = append(tlist, item.(*nodes.TargetEntry))
+	}
+	var groupExprs []nodes.Node
+	var groupRefs []uint32
+	for _, sgc := range sortGroupClauses(q.GroupClause) {
+		if tle := targetEntryByRef(tlist, sgc.TleSortGroupRef); tle != nil {
+			groupExprs = append(groupExprs, tle.Expr)
+			groupRefs = append(groupRefs, sgc.TleSortGroupRef)
+		}
+	}
+
+	// Grouped Vars let functionally dependent columns through.
+	groupedVars := map[int][]nodes.AttrNumber{}
+	for _, e := range groupExprs {
+		if v, ok := e.(*nodes.Var); ok && v.Varlevelsup == 0 {
+			groupedVars[v.Varno] = append(groupedVars[v.Varno], v.Varattno)
+		}
+	}
+
+	c := &ungroupedChecker{ps: ps, groupExprs: groupExprs, groupRefs: groupRefs, groupedVars: groupedVars}
+	for _, tle := range tlist {
+		if err := c.check(tle.Expr, 0); err != nil {
+			return err
+		}
+	}
+	return c.check(q.HavingQual, 0)
+}
+
+// ungroupedChecker walks the expressions of a grouped query looking for
+// ungrouped column references (check_ungrouped_columns).
+type ungroupedChecker struct {
+	ps          *pstate
+	groupExprs  []nodes.Node
+	groupRefs   []uint32
+	groupedVars map[int][]nodes.AttrNumber
+}
+
+func (c *ungroupedChecker) check(n nodes.Node, depth int) error {
+	if n == nil {
+		return nil
+	}
+	var err error
+	walkLevels(n, depth, func(n nodes.Node, depth int) bool {
+		if err != nil {
+			return false
+		}
+		switch e := n.(type) {
+		case *nodes.Aggref:
+			// The arguments of an aggregate of this level need not be
+			// grouped.
+			if int(e.Agglevelsup) == depth {
+				return false
+			}
+		case *nodes.GroupingFunc:
+			if int(e.Agglevelsup) == depth {
+				err = c.groupingFuncRefs(e, depth)
+				return false
+			}
+		case *nodes.Var:
+			if int(e.Varlevelsup) != depth {
+				return true
+			}
+			if c.isGrouped(e) {
+				return false
+			}
+			if c.functionallyDependent(e) {
+				return false
+			}
+			err = c.ungroupedError(e, depth)
+			return false
+		}
+		if depth == 0 {
+			for _, g := range c.groupExprs {
+				if equalExprs(n, g) {
+					return false
+				}
+			}
+		}
+		return true
+	})
+	return err
+}
+
+// isGrouped reports whether a column of this query level, referenced
+// from any depth, is a grouping column.
+func (c *ungroupedChecker) isGrouped(v *nodes.Var) bool {
+	for _, attno := range c.groupedVars[v.Varno] {
+		if attno == v.Varattno {
+			return true
+		}
+	}
+	return false
+}
+
+// functionallyDependent reports whether v's table is grouped by all the
+// columns of its primary key, so that every column of the table is
+// determined by the grouping columns (check_functional_grouping).
+func (c *ungroupedChecker) functionallyDependent(v *nodes.Var) bool {
+	if v.Varno <= 0 || v.Varno > len(c.ps.rtable) {
+		return false
+	}
+	rte := c.ps.rtable[v.Varno-1]
+	if rte.Rtekind != nodes.RTE_RELATION {
+		return false
+	}
+	rel := c.ps.cat.RelationByOid(rte.Relid)
+	if rel == nil || len(rel.PrimaryKey) == 0 {
+		return false
+	}
+	for _, pk := range rel.PrimaryKey {
+		found := false
+		for _, attno := range c.groupedVars[v.Varno] {
+			if attno == pk {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *ungroupedChecker) ungroupedError(v *nodes.Var, depth int) error {
+	relname, colname := "?", "?"
+	if p := c.ps; v.Varno > 0 && v.Varno <= len(p.rtable) {
+		rte := p.rtable[v.Varno-1]
+		relname = rte.Eref.Aliasname
+		names := stringList(rte.Eref.Colnames)
+		switch {
+		case v.Varattno == 0:
+			colname = "*"
+		case v.Varattno > 0 && int(v.Varattno) <= len(names):
+			colname = names[v.Varattno-1]
+		case v.Varattno < 0:
+			for _, sc := range systemColumns {
+				if sc.attno == v.Varattno {
+					colname = sc.name
+				}
+			}
+		}
+	}
+	if depth > 0 {
+		return errorf(CodeGroupingError, v.Location, "subquery uses ungrouped column \"%s.%s\" from outer query", relname, colname)
+	}
+	return errorf(CodeGroupingError, v.Location, "column \"%s.%s\" must appear in the GROUP BY clause or be used in an aggregate function", relname, colname)
+}
+
+// groupingFuncRefs sets the grouping references of a GROUPING() call,
+// whose arguments must all be grouping expressions
+// (finalize_grouping_exprs).
+func (c *ungroupedChecker) groupingFuncRefs(g *nodes.GroupingFunc, depth int) error {
+	if depth > 0 {
+		// GROUPING() of an outer level is checked at that level.
+		return nil
+	}
+	refs := &nodes.List{}
+	for _, arg := range items(g.Args) {
+		var ref uint32
+		for i, e := range c.groupExprs {
+			if equalExprs(arg, e) {
+				ref = c.groupRefs[i]
+				break
+			}
+		}
+		if ref == 0 {
+			return errorf(CodeGroupingError, exprLocation(arg), "arguments to GROUPING must be grouping expressions of the associated query level")
+		}
+		refs.Items = append(refs.Items, &nodes.Integer{Ival: int64(ref)})
+	}
+	g.Refs = refs
+	return nil
+}
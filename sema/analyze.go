@@ -0,0 +1,1146 @@
+// Package sema implements parse analysis: it transforms the raw parse
+// trees produced by the parser into Query trees whose names have been
+// resolved against a Catalog, as PostgreSQL's parse_analyze does.
+//
+// A Query has a range table of the relations, subqueries, functions and
+// joins the statement reads, a join tree, and target entries whose
+// expressions are built from Vars, Consts, function and operator calls
+// with their result types filled in. Together they answer questions such
+// as which column of which table an output column comes from.
+//
+// The analyzer is deliberately lenient where the catalog is incomplete:
+// a function or operator whose name the catalog does not know at all is
+// left unresolved, with InvalidOid for its OID and result type, instead of
+// being reported as an error. AnalyzeUnresolved reports such calls. This
+// matters for the built-in objects of FromCatalog, which include only a
+// curated subset of pg_proc and pg_operator, mostly without OIDs.
+package sema
+
+import (
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// Analyze transforms a raw statement into a Query tree. stmt may be a
+// *nodes.RawStmt or a bare statement node. Statements other than SELECT,
+// VALUES, INSERT, UPDATE, DELETE and MERGE are returned as CMD_UTILITY
+// queries wrapping the original statement.
+func Analyze(stmt nodes.Node, cat Catalog) (*nodes.Query, error) {
+	q, _, err := AnalyzeParams(stmt, cat, nil)
+	return q, err
+}
+
+// AnalyzeParams is like Analyze, but also infers the types of $n
+// parameters from the context they are used in, as
+// parse_analyze_varparams does. paramTypes gives the types already known;
+// InvalidOid entries are to be inferred. It returns the types of all
+// parameters referenced, with UNKNOWNOID for those that could not be
+// determined.
+func AnalyzeParams(stmt nodes.Node, cat Catalog, paramTypes []nodes.Oid) (*nodes.Query, []nodes.Oid, error) {
+	q, types, _, err := AnalyzeUnresolved(stmt, cat, paramTypes)
+	return q, types, err
+}
+
+// UnresolvedCall is a function or operator call that analysis left
+// unresolved because the catalog has no function or operator of its name.
+// With the built-in catalog it may be a built-in outside the subset the
+// catalog holds.
+type UnresolvedCall struct {
+	Kind     string      // "function" or "operator"
+	Name     string      // as written, such as "pg_catalog.now"
+	ArgTypes []nodes.Oid // types of the arguments or operands
+	Location nodes.ParseLoc
+}
+
+// AnalyzeUnresolved is AnalyzeParams that also returns the calls left
+// unresolved, innermost first.
+func AnalyzeUnresolved(stmt nodes.Node, cat Catalog, paramTypes []nodes.Oid) (*nodes.Query, []nodes.Oid, []UnresolvedCall, error) {
+	ps := &pstate{
+		cat:             cat,
+		params:          &paramState{types: append([]nodes.Oid(nil), paramTypes...)},
+		unresolved:      &unresolvedState{},
+		resolveUnknowns: true,
+	}
+	loc, length := nodes.ParseLoc(0), nodes.ParseLoc(0)
+	if raw, ok := stmt.(*nodes.RawStmt); ok {
+		stmt, loc, length = raw.Stmt, raw.StmtLocation, raw.StmtLen
+	}
+	var q *nodes.Query
+	if sel, ok := stmt.(*nodes.SelectStmt); ok && sel.IntoClause != nil {
+		// SELECT INTO is CREATE TABLE AS in disguise.
+		q = &nodes.Query{CommandType: nodes.CMD_UTILITY, UtilityStmt: stmt}
+	} else {
+		var err error
+		if q, err = ps.transformStmt(stmt); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	q.CanSetTag = true
+	q.StmtLocation, q.StmtLen = loc, length
+	ps.fixParamTypes(q)
+	types := make([]nodes.Oid, len(ps.params.types))
+	for i, t := range ps.params.types {
+		if t == nodes.InvalidOid {
+			t = UNKNOWNOID
+		}
+		types[i] = t
+	}
+	return q, types, ps.unresolved.calls, nil
+}
+
+// pstate is the state of analysis of one query level (ParseState).
+type pstate struct {
+	parent     *pstate
+	cat        Catalog
+	params     *paramState      // shared by all levels
+	unresolved *unresolvedState // shared by all levels
+
+	rtable    []*nodes.RangeTblEntry
+	joinlist  []nodes.Node // join tree items for the FROM clause
+	namespace []*nsItem    // items visible to expressions
+
+	ctes      []*nodes.CommonTableExpr // WITH queries visible at this level
+	parentCte *nodes.CommonTableExpr   // recursive CTE whose query this is
+
+	targetRel    *Relation // target of INSERT/UPDATE/DELETE/MERGE
+	targetNSItem *nsItem
+
+	exprKind   exprKind
+	windowDefs []*nodes.WindowDef // raw WINDOW clause and OVER definitions
+
+	multiAssign      *nodes.SubLink // MULTIEXPR sublink of UPDATE SET (a, b) = (SELECT ...)
+	multiAssignRow   *nodes.RowExpr // source of UPDATE SET (a, b) = ROW(...)
+	multiAssignCount int            // MULTIEXPR sublinks so far, numbering SubLinkId
+
+	hasAggs         bool
+	hasWindowFuncs  bool
+	hasTargetSRFs   bool
+	hasSubLinks     bool
+	hasModifyingCTE bool
+
+	lateralActive   bool // LATERAL references are allowed
+	resolveUnknowns bool // resolve unknown-type target list entries to text
+}
+
+// paramState tracks the types of $n parameters.
+type paramState struct {
+	types []nodes.Oid
+}
+
+// unresolvedState collects the calls left unresolved.
+type unresolvedState struct {
+	calls []UnresolvedCall
+}
+
+// noteUnresolved records a call left unresolved because the catalog has
+// nothing of its name.
+func (ps *pstate) noteUnresolved(kind string, name []string, argTypes []nodes.Oid, loc nodes.ParseLoc) {
+	ps.unresolved.calls = append(ps.unresolved.calls, UnresolvedCall{
+		Kind:     kind,
+		Name:     strings.Join(name, "."),
+		ArgTypes: argTypes,
+		Location: loc,
+	})
+}
+
+// newChild returns a pstate for a subquery of ps.
+func (ps *pstate) newChild() *pstate {
+	return &pstate{
+		parent:          ps,
+		cat:             ps.cat,
+		params:          ps.params,
+		unresolved:      ps.unresolved,
+		resolveUnknowns: true,
+	}
+}
+
+// levelUp returns the pstate levels query levels above ps.
+func (ps *pstate) levelUp(levels int) *pstate {
+	for ; levels > 0 && ps != nil; levels-- {
+		ps = ps.parent
+	}
+	return ps
+}
+
+// fixParamTypes updates Params whose type was inferred only after they
+// were built (check_variable_parameters).
+func (ps *pstate) fixParamTypes(q *nodes.Query) {
+	nodes.Walk(q, func(n nodes.Node) bool {
+		if p, ok := n.(*nodes.Param); ok && p.Paramkind == nodes.PARAM_EXTERN && p.Paramtype == UNKNOWNOID {
+			if p.Paramid <= len(ps.params.types) {
+				if t := ps.params.types[p.Paramid-1]; t != nodes.InvalidOid {
+					p.Paramtype = t
+					p.Paramcollid = typeCollation(ps.cat, t)
+				}
+			}
+		}
+		return true
+	})
+}
+
+// transformStmt analyzes one statement (transformStmt).
+func (ps *pstate) transformStmt(stmt nodes.Node) (*nodes.Query, error) {
+	switch n := stmt.(type) {
+	case *nodes.SelectStmt:
+		switch {
+		case n.ValuesLists != nil:
+			return ps.transformValuesClause(n)
+		case n.Op == nodes.SETOP_NONE:
+			return ps.transformSelectStmt(n)
+		default:
+			return ps.transformSetOperationStmt(n)
+		}
+	case *nodes.InsertStmt:
+		return ps.transformInsertStmt(n)
+	case *nodes.UpdateStmt:
+		return ps.transformUpdateStmt(n)
+	case *nodes.DeleteStmt:
+		return ps.transformDeleteStmt(n)
+	case *nodes.MergeStmt:
+		return ps.transformMergeStmt(n)
+	}
+	return &nodes.Query{CommandType: nodes.CMD_UTILITY, UtilityStmt: stmt}, nil
+}
+
+// subAnalyze analyzes a subquery in a new query level below ps
+// (parse_sub_analyze).
+func (ps *pstate) subAnalyze(stmt nodes.Node, parentCte *nodes.CommonTableExpr, lateral, resolveUnknowns bool) (*nodes.Query, error) {
+	sub := ps.newChild()
+	sub.parentCte = parentCte
+	sub.resolveUnknowns = resolveUnknowns
+	if sel, ok := stmt.(*nodes.SelectStmt); ok && sel.IntoClause != nil {
+		return nil, errorf(CodeSyntaxError, -1, "SELECT ... INTO is not allowed here")
+	}
+	// A LATERAL subquery may refer to the preceding FROM items of ps.
+	saved := ps.lateralActive
+	ps.lateralActive = lateral
+	defer func() { ps.lateralActive = saved }()
+	return sub.transformStmt(stmt)
+}
+
+// finishQuery copies the state accumulated while analyzing a query into
+// it.
+func (ps *pstate) finishQuery(q *nodes.Query, quals nodes.Node) {
+	q.Rtable = rtableList(ps.rtable)
+	q.Jointree = &nodes.FromExpr{Fromlist: nodeList(ps.joinlist), Quals: quals}
+	q.HasSubLinks = ps.hasSubLinks
+	q.HasAggs = ps.hasAggs
+	q.HasWindowFuncs = ps.hasWindowFuncs
+	q.HasTargetSRFs = ps.hasTargetSRFs
+	q.HasModifyingCTE = ps.hasModifyingCTE
+}
+
+// transformSelectStmt analyzes a plain SELECT.
+func (ps *pstate) transformSelectStmt(stmt *nodes.SelectStmt) (*nodes.Query, error) {
+	q := &nodes.Query{CommandType: nodes.CMD_SELECT}
+	if stmt.WithClause != nil {
+		q.HasRecursive = stmt.WithClause.Recursive
+		ctes, err := ps.transformWithClause(stmt.WithClause)
+		if err != nil {
+			return nil, err
+		}
+		q.CteList = ctes
+	}
+	if stmt.IntoClause != nil {
+		return nil, errorf(CodeSyntaxError, -1, "SELECT ... INTO is not allowed here")
+	}
+	if err := ps.transformFromClause(stmt.FromClause); err != nil {
+		return nil, err
+	}
+	for _, item := range items(stmt.WindowClause) {
+		ps.windowDefs = append(ps.windowDefs, item.(*nodes.WindowDef))
+	}
+	tlist, err := ps.transformTargetList(stmt.TargetList, exprKindSelectTarget)
+	if err != nil {
+		return nil, err
+	}
+	ps.markTargetListOrigins(tlist)
+	where, err := ps.transformWhereClause(stmt.WhereClause, exprKindWhere, "WHERE")
+	if err != nil {
+		return nil, err
+	}
+	having, err := ps.transformWhereClause(stmt.HavingClause, exprKindHaving, "HAVING")
+	if err != nil {
+		return nil, err
+	}
+	sortClause, err := ps.transformSortClause(stmt.SortClause, &tlist, exprKindOrderBy, false)
+	if err != nil {
+		return nil, err
+	}
+	groupClause, groupingSets, err := ps.transformGroupClause(stmt.GroupClause, &tlist, sortClause, exprKindGroupBy, false)
+	if err != nil {
+		return nil, err
+	}
+	q.GroupDistinct = stmt.GroupDistinct
+	if stmt.DistinctClause != nil {
+		if len(stmt.DistinctClause.Items) == 1 && stmt.DistinctClause.Items[0] == nil {
+			q.DistinctClause, err = ps.transformDistinctClause(&tlist, sortClause, false)
+		} else {
+			q.DistinctClause, err = ps.transformDistinctOnClause(stmt.DistinctClause, &tlist, sortClause)
+			q.HasDistinctOn = true
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if q.LimitOffset, err = ps.transformLimitClause(stmt.LimitOffset, exprKindOffset, "OFFSET", stmt.LimitOption); err != nil {
+		return nil, err
+	}
+	if q.LimitCount, err = ps.transformLimitClause(stmt.LimitCount, exprKindLimit, "LIMIT", stmt.LimitOption); err != nil {
+		return nil, err
+	}
+	q.LimitOption = stmt.LimitOption
+	if q.WindowClause, err = ps.transformWindowDefinitions(&tlist); err != nil {
+		return nil, err
+	}
+	if ps.resolveUnknowns {
+		ps.resolveTargetListUnknowns(tlist)
+	}
+	q.TargetList = tleList(tlist)
+	q.SortClause = sortClause
+	q.GroupClause = groupClause
+	q.GroupingSets = groupingSets
+	q.HavingQual = having
+	ps.finishQuery(q, where)
+	if ps.hasAggs || q.GroupClause != nil || q.GroupingSets != nil || having != nil {
+		if err := ps.parseCheckAggregates(q); err != nil {
+			return nil, err
+		}
+	}
+	if err := ps.transformLockingClause(q, stmt.LockingClause); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// transformValuesClause analyzes a VALUES list used as a query. The rows
+// become a VALUES range table entry that the target list reads.
+func (ps *pstate) transformValuesClause(stmt *nodes.SelectStmt) (*nodes.Query, error) {
+	q := &nodes.Query{CommandType: nodes.CMD_SELECT}
+	if stmt.WithClause != nil {
+		q.HasRecursive = stmt.WithClause.Recursive
+		ctes, err := ps.transformWithClause(stmt.WithClause)
+		if err != nil {
+			return nil, err
+		}
+		q.CteList = ctes
+	}
+	var rows [][]nodes.Node
+	for _, item := range items(stmt.ValuesLists) {
+		row, err := ps.transformExpressionList(item.(*nodes.List), exprKindValues, true)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) > 0 && len(row) != len(rows[0]) {
+			return nil, errorf(CodeSyntaxError, exprLocation(item), "VALUES lists must all be the same length")
+		}
+		rows = append(rows, row)
+	}
+	types, typmods, colls, err := ps.coerceValuesColumns(rows)
+	if err != nil {
+		return nil, err
+	}
+	it, err := ps.addRangeTableEntryForValues(rows, types, typmods, colls, nil, false, true)
+	if err != nil {
+		return nil, err
+	}
+	ps.joinlist = append(ps.joinlist, &nodes.RangeTblRef{Rtindex: it.rtindex})
+	ps.namespace = append(ps.namespace, it)
+	var tlist []*nodes.TargetEntry
+	for i := range it.columns {
+		tlist = append(tlist, &nodes.TargetEntry{
+			Expr:    buildVar(&it.columns[i], 0, -1),
+			Resno:   nodes.AttrNumber(i + 1),
+			Resname: it.colnames[i],
+		})
+	}
+	sortClause, err := ps.transformSortClause(stmt.SortClause, &tlist, exprKindOrderBy, false)
+	if err != nil {
+		return nil, err
+	}
+	if q.LimitOffset, err = ps.transformLimitClause(stmt.LimitOffset, exprKindOffset, "OFFSET", stmt.LimitOption); err != nil {
+		return nil, err
+	}
+	if q.LimitCount, err = ps.transformLimitClause(stmt.LimitCount, exprKindLimit, "LIMIT", stmt.LimitOption); err != nil {
+		return nil, err
+	}
+	q.LimitOption = stmt.LimitOption
+	if stmt.LockingClause != nil {
+		return nil, errorf(CodeFeatureNotSupported, -1, "FOR %s cannot be applied to VALUES", lockingStrength(stmt.LockingClause.Items[0].(*nodes.LockingClause)))
+	}
+	q.TargetList = tleList(tlist)
+	q.SortClause = sortClause
+	ps.finishQuery(q, nil)
+	return q, nil
+}
+
+// coerceValuesColumns resolves the type of each column of a VALUES list
+// and coerces the rows to it.
+func (ps *pstate) coerceValuesColumns(rows [][]nodes.Node) ([]nodes.Oid, []int32, []nodes.Oid, error) {
+	if len(rows) == 0 {
+		return nil, nil, nil, nil
+	}
+	ncols := len(rows[0])
+	types := make([]nodes.Oid, ncols)
+	typmods := make([]int32, ncols)
+	colls := make([]nodes.Oid, ncols)
+	for c := 0; c < ncols; c++ {
+		col := make([]nodes.Node, len(rows))
+		for r := range rows {
+			col[r] = rows[r][c]
+		}
+		typ, err := ps.selectCommonType(col, "VALUES")
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		for r := range rows {
+			if rows[r][c], err = ps.coerceToCommonType(rows[r][c], typ, "VALUES"); err != nil {
+				return nil, nil, nil, err
+			}
+			col[r] = rows[r][c]
+		}
+		types[c] = typ
+		typmods[c] = selectCommonTypmod(col, typ)
+		colls[c] = ps.selectCommonCollation(col)
+	}
+	return types, typmods, colls, nil
+}
+
+// setOpColumn describes an output column of a set operation tree.
+type setOpColumn struct {
+	typ    nodes.Oid
+	typmod int32
+	collid nodes.Oid
+	leaf   *nodes.TargetEntry // the leaf query's entry, for a leaf
+}
+
+// transformSetOperationStmt analyzes UNION, INTERSECT and EXCEPT. Each
+// leaf SELECT becomes a subquery range table entry; the result columns
+// are Vars referring to the leftmost leaf.
+func (ps *pstate) transformSetOperationStmt(stmt *nodes.SelectStmt) (*nodes.Query, error) {
+	q := &nodes.Query{CommandType: nodes.CMD_SELECT}
+	if stmt.WithClause != nil {
+		q.HasRecursive = stmt.WithClause.Recursive
+		ctes, err := ps.transformWithClause(stmt.WithClause)
+		if err != nil {
+			return nil, err
+		}
+		q.CteList = ctes
+	}
+	if stmt.LockingClause != nil {
+		return nil, errorf(CodeFeatureNotSupported, -1, "FOR %s is not allowed with UNION/INTERSECT/EXCEPT", lockingStrength(stmt.LockingClause.Items[0].(*nodes.LockingClause)))
+	}
+	leftmost := stmt
+	for leftmost.Op != nodes.SETOP_NONE {
+		leftmost = leftmost.Larg
+	}
+	if leftmost.IntoClause != nil {
+		return nil, errorf(CodeSyntaxError, -1, "SELECT ... INTO is not allowed here")
+	}
+	tree, cols, err := ps.transformSetOperationTree(stmt, true)
+	if err != nil {
+		return nil, err
+	}
+	q.SetOperations = tree
+
+	// The leftmost leaf supplies the column names.
+	leftRTI := tree.(*nodes.SetOperationStmt)
+	var leftRef nodes.Node = leftRTI
+	for {
+		if so, ok := leftRef.(*nodes.SetOperationStmt); ok {
+			leftRef = so.Larg
+			continue
+		}
+		break
+	}
+	rti := leftRef.(*nodes.RangeTblRef).Rtindex
+	leftQuery := ps.rtable[rti-1].Subquery
+	it := &nsItem{rte: ps.rtable[rti-1], rtindex: rti, rowType: RECORDOID, colsVisible: true, lateralOK: true}
+	var tlist []*nodes.TargetEntry
+	i := 0
+	for _, item := range items(leftQuery.TargetList) {
+		ltle := item.(*nodes.TargetEntry)
+		if ltle.Resjunk {
+			continue
+		}
+		c := cols[i]
+		col := nsColumn{varno: rti, varattno: nodes.AttrNumber(i + 1), typ: c.typ, typmod: c.typmod, collid: c.collid, varnosyn: rti, varattnosyn: nodes.AttrNumber(i + 1)}
+		it.colnames = append(it.colnames, ltle.Resname)
+		it.columns = append(it.columns, col)
+		tlist = append(tlist, &nodes.TargetEntry{
+			Expr:    buildVar(&col, 0, exprLocation(ltle.Expr)),
+			Resno:   nodes.AttrNumber(i + 1),
+			Resname: ltle.Resname,
+		})
+		i++
+	}
+
+	// ORDER BY may refer only to the result columns.
+	savedNS := ps.namespace
+	ps.namespace = []*nsItem{it}
+	n := len(tlist)
+	sortClause, err := ps.transformSortClause(stmt.SortClause, &tlist, exprKindOrderBy, false)
+	if err != nil {
+		return nil, err
+	}
+	ps.namespace = savedNS
+	if len(tlist) != n {
+		return nil, errorf(CodeFeatureNotSupported, -1, "invalid UNION/INTERSECT/EXCEPT ORDER BY clause").
+			withDetail("Only result column names can be used, not expressions or functions.").
+			withHint("Add the expression/function to every SELECT, or move the UNION into a FROM clause.")
+	}
+	if q.LimitOffset, err = ps.transformLimitClause(stmt.LimitOffset, exprKindOffset, "OFFSET", stmt.LimitOption); err != nil {
+		return nil, err
+	}
+	if q.LimitCount, err = ps.transformLimitClause(stmt.LimitCount, exprKindLimit, "LIMIT", stmt.LimitOption); err != nil {
+		return nil, err
+	}
+	q.LimitOption = stmt.LimitOption
+	q.TargetList = tleList(tlist)
+	q.SortClause = sortClause
+	ps.finishQuery(q, nil)
+	q.Jointree.Fromlist = nil
+	return q, nil
+}
+
+// transformSetOperationTree analyzes one node of a set operation tree,
+// returning the transformed node and its output columns.
+func (ps *pstate) transformSetOperationTree(stmt *nodes.SelectStmt, top bool) (nodes.Node, []setOpColumn, error) {
+	isLeaf := stmt.Op == nodes.SETOP_NONE ||
+		stmt.SortClause != nil || stmt.LimitCount != nil || stmt.LimitOffset != nil ||
+		stmt.LockingClause != nil || stmt.WithClause != nil
+	if isLeaf && !top {
+		if stmt.IntoClause != nil {
+			return nil, nil, errorf(CodeSyntaxError, -1, "INTO is only allowed on first SELECT of UNION/INTERSECT/EXCEPT")
+		}
+		q, err := ps.subAnalyze(stmt, nil, false, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, item := range items(q.Rtable) {
+			if rte := item.(*nodes.RangeTblEntry); rte.Rtekind == nodes.RTE_CTE && rte.SelfReference && q.SortClause != nil {
+				return nil, nil, errorf(CodeInvalidRecursion, -1, "ORDER BY in a recursive query is not implemented")
+			}
+		}
+		it, err := ps.addRangeTableEntryForSubquery(q, nil, "*SELECT* "+itoa(len(ps.rtable)+1), false, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		var cols []setOpColumn
+		for _, item := range items(q.TargetList) {
+			tle := item.(*nodes.TargetEntry)
+			if tle.Resjunk {
+				continue
+			}
+			cols = append(cols, setOpColumn{ExprType(tle.Expr), ExprTypmod(tle.Expr), ExprCollation(tle.Expr), tle})
+		}
+		return &nodes.RangeTblRef{Rtindex: it.rtindex}, cols, nil
+	}
+
+	op := &nodes.SetOperationStmt{Op: stmt.Op, All: stmt.All}
+	larg, lcols, err := ps.transformSetOperationTree(stmt.Larg, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if top && ps.parentCte != nil && ps.parentCte.Cterecursive {
+		ps.determineRecursiveColTypes(lcols)
+	}
+	rarg, rcols, err := ps.transformSetOperationTree(stmt.Rarg, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	context := setOpName(stmt.Op)
+	if len(lcols) != len(rcols) {
+		return nil, nil, errorf(CodeSyntaxError, -1, "each %s query must have the same number of columns", context)
+	}
+	op.Larg, op.Rarg = larg, rarg
+	op.ColTypes, op.ColTypmods, op.ColCollations = &nodes.OidList{}, &nodes.IntList{}, &nodes.OidList{}
+	var cols []setOpColumn
+	for i := range lcols {
+		l, r := lcols[i], rcols[i]
+		lnode, rnode := setOpColumnNode(l), setOpColumnNode(r)
+		typ, err := ps.selectCommonType([]nodes.Node{lnode, rnode}, context)
+		if err != nil {
+			return nil, nil, err
+		}
+		typmod := int32(-1)
+		if l.typ == r.typ && l.typmod == r.typmod {
+			typmod = l.typmod
+		}
+		for _, c := range []setOpColumn{l, r} {
+			if c.typ == UNKNOWNOID && c.leaf != nil {
+				if c.leaf.Expr, err = ps.coerceToCommonType(c.leaf.Expr, typ, context); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+		collid := l.collid
+		if collid == nodes.InvalidOid {
+			collid = r.collid
+		}
+		if typeCollation(ps.cat, typ) == nodes.InvalidOid {
+			collid = nodes.InvalidOid
+		} else if collid == nodes.InvalidOid {
+			collid = typeCollation(ps.cat, typ)
+		}
+		op.ColTypes.Items = append(op.ColTypes.Items, typ)
+		op.ColTypmods.Items = append(op.ColTypmods.Items, int(typmod))
+		op.ColCollations.Items = append(op.ColCollations.Items, collid)
+		if op.Op != nodes.SETOP_UNION || !op.All {
+			eq, lt := ps.sortOperators(typ)
+			op.GroupClauses = appendNode(op.GroupClauses, &nodes.SortGroupClause{Eqop: eq, Sortop: lt, Hashable: false})
+		}
+		cols = append(cols, setOpColumn{typ: typ, typmod: typmod, collid: collid})
+	}
+	return op, cols, nil
+}
+
+// setOpColumnNode returns an expression standing for a set operation
+// column, for type resolution.
+func setOpColumnNode(c setOpColumn) nodes.Node {
+	if c.leaf != nil {
+		return c.leaf.Expr
+	}
+	return &nodes.CaseTestExpr{TypeId: c.typ, TypeMod: c.typmod, Collation: c.collid}
+}
+
+func setOpName(op nodes.SetOperation) string {
+	switch op {
+	case nodes.SETOP_INTERSECT:
+		return "INTERSECT"
+	case nodes.SETOP_EXCEPT:
+		return "EXCEPT"
+	}
+	return "UNION"
+}
+
+// transformInsertStmt analyzes INSERT.
+func (ps *pstate) transformInsertStmt(stmt *nodes.InsertStmt) (*nodes.Query, error) {
+	q := &nodes.Query{CommandType: nodes.CMD_INSERT, Override: stmt.Override}
+	if stmt.WithClause != nil {
+		q.HasRecursive = stmt.WithClause.Recursive
+		ctes, err := ps.transformWithClause(stmt.WithClause)
+		if err != nil {
+			return nil, err
+		}
+		q.CteList = ctes
+	}
+	sel, _ := stmt.SelectStmt.(*nodes.SelectStmt)
+	isGeneralSelect := sel != nil && (sel.ValuesLists == nil || sel.SortClause != nil ||
+		sel.LimitOffset != nil || sel.LimitCount != nil || sel.LockingClause != nil || sel.WithClause != nil)
+	var err error
+	if q.ResultRelation, err = ps.setTargetTable(stmt.Relation, false, false); err != nil {
+		return nil, err
+	}
+	cols, attnos, err := ps.checkInsertTargets(stmt.Cols)
+	if err != nil {
+		return nil, err
+	}
+
+	var exprs []nodes.Node
+	switch {
+	case sel == nil:
+		// DEFAULT VALUES
+	case isGeneralSelect:
+		sub, err := ps.subAnalyze(sel, nil, false, false)
+		if err != nil {
+			return nil, err
+		}
+		it, err := ps.addRangeTableEntryForSubquery(sub, &nodes.Alias{Aliasname: "*SELECT*"}, "*SELECT*", false, false)
+		if err != nil {
+			return nil, err
+		}
+		it.rte.Alias = nil
+		ps.joinlist = append(ps.joinlist, &nodes.RangeTblRef{Rtindex: it.rtindex})
+		i := 0
+		for _, item := range items(sub.TargetList) {
+			tle := item.(*nodes.TargetEntry)
+			if tle.Resjunk {
+				continue
+			}
+			switch tle.Expr.(type) {
+			case *nodes.Const, *nodes.Param:
+				if ExprType(tle.Expr) == UNKNOWNOID {
+					exprs = append(exprs, tle.Expr)
+					i++
+					continue
+				}
+			}
+			v := buildVar(&it.columns[i], 0, exprLocation(tle.Expr))
+			exprs = append(exprs, v)
+			i++
+		}
+		if exprs, err = ps.transformInsertRow(exprs, cols, attnos, stmt.Cols, false); err != nil {
+			return nil, err
+		}
+	case sel.ValuesLists.Len() > 1:
+		var rows [][]nodes.Node
+		for _, item := range items(sel.ValuesLists) {
+			row, err := ps.transformExpressionList(item.(*nodes.List), exprKindValues, true)
+			if err != nil {
+				return nil, err
+			}
+			if len(rows) > 0 && len(row) != len(rows[0]) {
+				return nil, errorf(CodeSyntaxError, exprLocation(item), "VALUES lists must all be the same length")
+			}
+			if row, err = ps.transformInsertRow(row, cols, attnos, stmt.Cols, true); err != nil {
+				return nil, err
+			}
+			rows = append(rows, row)
+		}
+		var types, colls []nodes.Oid
+		var typmods []int32
+		for c := range rows[0] {
+			col := make([]nodes.Node, len(rows))
+			for r := range rows {
+				col[r] = rows[r][c]
+			}
+			typ := ExprType(rows[0][c])
+			types = append(types, typ)
+			typmods = append(typmods, selectCommonTypmod(col, typ))
+			colls = append(colls, ps.selectCommonCollation(col))
+		}
+		it, err := ps.addRangeTableEntryForValues(rows, types, typmods, colls, nil, false, true)
+		if err != nil {
+			return nil, err
+		}
+		ps.joinlist = append(ps.joinlist, &nodes.RangeTblRef{Rtindex: it.rtindex})
+		for i := range it.columns {
+			exprs = append(exprs, buildVar(&it.columns[i], 0, -1))
+		}
+	default:
+		row, err := ps.transformExpressionList(sel.ValuesLists.Items[0].(*nodes.List), exprKindValuesSingle, true)
+		if err != nil {
+			return nil, err
+		}
+		if exprs, err = ps.transformInsertRow(row, cols, attnos, stmt.Cols, false); err != nil {
+			return nil, err
+		}
+	}
+	var tlist []*nodes.TargetEntry
+	for i, e := range exprs {
+		tlist = append(tlist, &nodes.TargetEntry{Expr: e, Resno: attnos[i], Resname: cols[i].Name})
+	}
+	q.TargetList = tleList(tlist)
+
+	if stmt.OnConflictClause != nil || stmt.ReturningList != nil {
+		ps.namespace = []*nsItem{ps.targetNSItem}
+	}
+	if stmt.OnConflictClause != nil {
+		if q.OnConflict, err = ps.transformOnConflictClause(stmt.OnConflictClause); err != nil {
+			return nil, err
+		}
+	}
+	if q.ReturningList, err = ps.transformReturningList(stmt.ReturningList); err != nil {
+		return nil, err
+	}
+	ps.finishQuery(q, nil)
+	return q, nil
+}
+
+// checkInsertTargets resolves the target column list of INSERT, which
+// defaults to all columns of the target table.
+func (ps *pstate) checkInsertTargets(targets *nodes.List) ([]*Column, []nodes.AttrNumber, error) {
+	rel := ps.targetRel
+	var cols []*Column
+	var attnos []nodes.AttrNumber
+	if targets == nil {
+		for i, c := range rel.Columns {
+			cols = append(cols, c)
+			attnos = append(attnos, nodes.AttrNumber(i+1))
+		}
+		return cols, attnos, nil
+	}
+	seen := map[nodes.AttrNumber]bool{}
+	for _, item := range targets.Items {
+		rt := item.(*nodes.ResTarget)
+		col, attno := rel.Column(rt.Name)
+		if col == nil {
+			return nil, nil, errorf(CodeUndefinedColumn, rt.Location, "column %q of relation %q does not exist", rt.Name, rel.Name)
+		}
+		if seen[attno] && rt.Indirection == nil {
+			return nil, nil, errorf(CodeDuplicateColumn, rt.Location, "column %q specified more than once", rt.Name)
+		}
+		seen[attno] = true
+		cols = append(cols, col)
+		attnos = append(attnos, attno)
+	}
+	return cols, attnos, nil
+}
+
+// transformInsertRow coerces one row of INSERT source expressions to the
+// types of the target columns.
+func (ps *pstate) transformInsertRow(exprs []nodes.Node, cols []*Column, attnos []nodes.AttrNumber, targets *nodes.List, stripIndirection bool) ([]nodes.Node, error) {
+	if len(exprs) > len(cols) {
+		return nil, errorf(CodeSyntaxError, exprLocation(exprs[len(cols)]), "INSERT has more expressions than target columns")
+	}
+	// Without an explicit column list the remaining columns are
+	// defaulted.
+	if len(exprs) < len(cols) && targets != nil {
+		err := errorf(CodeSyntaxError, -1, "INSERT has more target columns than expressions")
+		if len(exprs) == 1 {
+			if row, ok := exprs[0].(*nodes.RowExpr); ok && row.Args.Len() == len(cols) {
+				err.withHint("The insertion source is a row expression containing the same number of columns expected by the INSERT. Did you accidentally use extra parentheses?")
+			}
+		}
+		return nil, err
+	}
+	out := make([]nodes.Node, len(exprs))
+	for i, e := range exprs {
+		var indirection *nodes.List
+		loc := exprLocation(e)
+		if targets != nil {
+			rt := targets.Items[i].(*nodes.ResTarget)
+			indirection, loc = rt.Indirection, rt.Location
+		}
+		var err error
+		if out[i], err = ps.transformAssignedExpr(e, exprKindInsertTarget, cols[i].Name, attnos[i], indirection, loc); err != nil {
+			return nil, err
+		}
+		if stripIndirection {
+			if sr, ok := out[i].(*nodes.SubscriptingRef); ok && sr.Refassgnexpr != nil {
+				out[i] = sr.Refassgnexpr
+			}
+		}
+	}
+	return out, nil
+}
+
+// transformOnConflictClause analyzes ON CONFLICT.
+func (ps *pstate) transformOnConflictClause(occ *nodes.OnConflictClause) (*nodes.OnConflictExpr, error) {
+	oc := &nodes.OnConflictExpr{Action: nodes.OnConflictAction(occ.Action)}
+	if occ.Infer != nil {
+		for _, item := range items(occ.Infer.IndexElems) {
+			ie := item.(*nodes.IndexElem)
+			elem := &nodes.InferenceElem{}
+			if ie.Name != "" {
+				v, err := ps.scanNSItemForColumn(ps.targetNSItem, 0, ie.Name, -1)
+				if err != nil {
+					return nil, err
+				}
+				if v == nil {
+					return nil, ps.errorMissingColumn("", ie.Name, -1)
+				}
+				elem.Expr = v
+			} else {
+				e, err := ps.transformExpr(ie.Expr, exprKindIndexExpression)
+				if err != nil {
+					return nil, err
+				}
+				elem.Expr = e
+			}
+			if ie.Collation != nil {
+				schema, name := qualifiedName(stringList(ie.Collation))
+				elem.Infercollid = ps.cat.LookupCollation(schema, name)
+			}
+			oc.ArbiterElems = appendNode(oc.ArbiterElems, elem)
+		}
+		where, err := ps.transformWhereClause(occ.Infer.WhereClause, exprKindIndexPredicate, "WHERE")
+		if err != nil {
+			return nil, err
+		}
+		oc.ArbiterWhere = where
+	} else if oc.Action == nodes.ONCONFLICT_UPDATE {
+		return nil, errorf(CodeSyntaxError, occ.Location, "ON CONFLICT DO UPDATE requires inference specification or constraint name").
+			withHint("For example, ON CONFLICT (column_name).")
+	}
+	if oc.Action != nodes.ONCONFLICT_UPDATE {
+		return oc, nil
+	}
+	excl, err := ps.addRangeTableEntry(ps.targetRel, &nodes.Alias{Aliasname: "excluded"}, false, false, nodes.RowExclusiveLock)
+	if err != nil {
+		return nil, err
+	}
+	oc.ExclRelIndex = excl.rtindex
+	var tl []*nodes.TargetEntry
+	for i := range excl.columns {
+		tl = append(tl, &nodes.TargetEntry{Expr: buildVar(&excl.columns[i], 0, -1), Resno: nodes.AttrNumber(i + 1), Resname: excl.colnames[i]})
+	}
+	tl = append(tl, &nodes.TargetEntry{Expr: wholeRowVar(excl, 0, -1), Resno: nodes.AttrNumber(len(tl) + 1), Resjunk: true})
+	oc.ExclRelTlist = tleList(tl)
+	ps.namespace = append(ps.namespace, excl)
+	set, err := ps.transformUpdateTargetList(occ.TargetList)
+	if err != nil {
+		return nil, err
+	}
+	oc.OnConflictSet = tleList(set)
+	if oc.OnConflictWhere, err = ps.transformWhereClause(occ.WhereClause, exprKindWhere, "WHERE"); err != nil {
+		return nil, err
+	}
+	ps.namespace = ps.namespace[:len(ps.namespace)-1]
+	return oc, nil
+}
+
+// transformUpdateStmt analyzes UPDATE.
+func (ps *pstate) transformUpdateStmt(stmt *nodes.UpdateStmt) (*nodes.Query, error) {
+	q := &nodes.Query{CommandType: nodes.CMD_UPDATE}
+	if stmt.WithClause != nil {
+		q.HasRecursive = stmt.WithClause.Recursive
+		ctes, err := ps.transformWithClause(stmt.WithClause)
+		if err != nil {
+			return nil, err
+		}
+		q.CteList = ctes
+	}
+	var err error
+	if q.ResultRelation, err = ps.setTargetTable(stmt.Relation, stmt.Relation.Inh, true); err != nil {
+		return nil, err
+	}
+	if err := ps.transformFromClause(stmt.FromClause); err != nil {
+		return nil, err
+	}
+	where, err := ps.transformWhereClause(stmt.WhereClause, exprKindWhere, "WHERE")
+	if err != nil {
+		return nil, err
+	}
+	if q.ReturningList, err = ps.transformReturningList(stmt.ReturningList); err != nil {
+		return nil, err
+	}
+	tlist, err := ps.transformUpdateTargetList(stmt.TargetList)
+	if err != nil {
+		return nil, err
+	}
+	q.TargetList = tleList(tlist)
+	ps.finishQuery(q, where)
+	return q, nil
+}
+
+// transformUpdateTargetList analyzes the SET list of UPDATE or ON
+// CONFLICT DO UPDATE. Each entry's resno is the target column's number.
+func (ps *pstate) transformUpdateTargetList(targets *nodes.List) ([]*nodes.TargetEntry, error) {
+	tlist, err := ps.transformTargetList(targets, exprKindUpdateSource)
+	if err != nil {
+		return nil, err
+	}
+	rel := ps.targetRel
+	seen := map[nodes.AttrNumber]bool{}
+	var out []*nodes.TargetEntry
+	i := 0
+	for _, tle := range tlist {
+		if tle.Resjunk {
+			out = append(out, tle)
+			continue
+		}
+		rt := targets.Items[i].(*nodes.ResTarget)
+		i++
+		col, attno := rel.Column(rt.Name)
+		if col == nil {
+			return nil, errorf(CodeUndefinedColumn, rt.Location, "column %q of relation %q does not exist", rt.Name, rel.Name)
+		}
+		if seen[attno] && rt.Indirection == nil {
+			return nil, errorf(CodeSyntaxError, rt.Location, "multiple assignments to same column %q", rt.Name)
+		}
+		seen[attno] = true
+		e, err := ps.transformAssignedExpr(tle.Expr, exprKindUpdateTarget, rt.Name, attno, rt.Indirection, rt.Location)
+		if err != nil {
+			return nil, err
+		}
+		tle.Expr, tle.Resno, tle.Resname = e, attno, rt.Name
+		out = append(out, tle)
+	}
+	// Junk entries (MULTIEXPR sublinks) are numbered after the real ones.
+	n := 0
+	for _, tle := range out {
+		if !tle.Resjunk {
+			n++
+		}
+	}
+	for _, tle := range out {
+		if tle.Resjunk {
+			n++
+			tle.Resno = nodes.AttrNumber(n)
+		}
+	}
+	return out, nil
+}
+
+// transformDeleteStmt analyzes DELETE.
+func (ps *pstate) transformDeleteStmt(stmt *nodes.DeleteStmt) (*nodes.Query, error) {
+	q := &nodes.Query{CommandType: nodes.CMD_DELETE}
+	if stmt.WithClause != nil {
+		q.HasRecursive = stmt.WithClause.Recursive
+		ctes, err := ps.transformWithClause(stmt.WithClause)
+		if err != nil {
+			return nil, err
+		}
+		q.CteList = ctes
+	}
+	var err error
+	if q.ResultRelation, err = ps.setTargetTable(stmt.Relation, stmt.Relation.Inh, true); err != nil {
+		return nil, err
+	}
+	if err := ps.transformFromClause(stmt.UsingClause); err != nil {
+		return nil, err
+	}
+	where, err := ps.transformWhereClause(stmt.WhereClause, exprKindWhere, "WHERE")
+	if err != nil {
+		return nil, err
+	}
+	if q.ReturningList, err = ps.transformReturningList(stmt.ReturningList); err != nil {
+		return nil, err
+	}
+	ps.finishQuery(q, where)
+	return q, nil
+}
+
+// transformMergeStmt analyzes MERGE. The target relation is not part of
+// the join tree; the join condition is kept in MergeJoinCondition.
+func (ps *pstate) transformMergeStmt(stmt *nodes.MergeStmt) (*nodes.Query, error) {
+	q := &nodes.Query{CommandType: nodes.CMD_MERGE}
+	if stmt.WithClause != nil {
+		if stmt.WithClause.Recursive {
+			return nil, errorf(CodeFeatureNotSupported, stmt.WithClause.Location, "WITH RECURSIVE is not supported for MERGE statement")
+		}
+		ctes, err := ps.transformWithClause(stmt.WithClause)
+		if err != nil {
+			return nil, err
+		}
+		q.CteList = ctes
+	}
+	unconditional := map[nodes.MergeMatchKind]bool{}
+	for _, item := range items(stmt.MergeWhenClauses) {
+		mwc := item.(*nodes.MergeWhenClause)
+		if unconditional[mwc.MatchKind] {
+			return nil, errorf(CodeSyntaxError, -1, "unreachable WHEN clause specified after unconditional WHEN clause")
+		}
+		if mwc.Condition == nil {
+			unconditional[mwc.MatchKind] = true
+		}
+	}
+	var err error
+	if q.ResultRelation, err = ps.setTargetTable(stmt.Relation, stmt.Relation.Inh, false); err != nil {
+		return nil, err
+	}
+	q.MergeTargetRelation = q.ResultRelation
+	target := ps.targetNSItem
+	if err := ps.transformFromClause(&nodes.List{Items: []nodes.Node{stmt.SourceRelation}}); err != nil {
+		return nil, err
+	}
+	source := append([]*nsItem(nil), ps.namespace...)
+	for _, it := range source {
+		if it.relVisible && it.name != "" && it.name == target.name {
+			return nil, errorf(CodeDuplicateAlias, -1, "name %q specified more than once", target.name).
+				withDetail("The name is used both as MERGE target table and data source.")
+		}
+	}
+	ps.namespace = append(ps.namespace, target)
+	if q.MergeJoinCondition, err = ps.transformExpr(stmt.JoinCondition, exprKindJoinOn); err != nil {
+		return nil, err
+	}
+	if q.MergeJoinCondition, err = ps.coerceToBoolean(q.MergeJoinCondition, "JOIN/ON"); err != nil {
+		return nil, err
+	}
+	if q.ReturningList, err = ps.transformReturningList(stmt.ReturningList); err != nil {
+		return nil, err
+	}
+	for _, item := range items(stmt.MergeWhenClauses) {
+		mwc := item.(*nodes.MergeWhenClause)
+		// Set the visibility of target and source for this action.
+		targetVisible := mwc.MatchKind != nodes.MERGE_WHEN_NOT_MATCHED_BY_TARGET
+		sourceVisible := mwc.MatchKind != nodes.MERGE_WHEN_NOT_MATCHED_BY_SOURCE
+		target.relVisible, target.colsVisible = targetVisible, targetVisible
+		for _, it := range source {
+			it.relVisible, it.colsVisible = sourceVisible, sourceVisible
+		}
+		action := &nodes.MergeAction{MatchKind: mwc.MatchKind, CommandType: mwc.CommandType, Override: mwc.Override}
+		if action.Qual, err = ps.transformWhereClause(mwc.Condition, exprKindMergeWhen, "WHEN"); err != nil {
+			return nil, err
+		}
+		switch mwc.CommandType {
+		case nodes.CMD_INSERT:
+			cols, attnos, err := ps.checkInsertTargets(mwc.TargetList)
+			if err != nil {
+				return nil, err
+			}
+			if mwc.Values != nil {
+				row, err := ps.transformExpressionList(mwc.Values, exprKindValuesSingle, true)
+				if err != nil {
+					return nil, err
+				}
+				if row, err = ps.transformInsertRow(row, cols, attnos, mwc.TargetList, false); err != nil {
+					return nil, err
+				}
+				var tlist []*nodes.TargetEntry
+				for i, e := range row {
+					tlist = append(tlist, &nodes.TargetEntry{Expr: e, Resno: attnos[i], Resname: cols[i].Name})
+				}
+				action.TargetList = tleList(tlist)
+			}
+		case nodes.CMD_UPDATE:
+			tlist, err := ps.transformUpdateTargetList(mwc.TargetList)
+			if err != nil {
+				return nil, err
+			}
+			action.TargetList = tleList(tlist)
+			action.UpdateColnos = &nodes.IntList{}
+			for _, tle := range tlist {
+				if !tle.Resjunk {
+					action.UpdateColnos.Items = append(action.UpdateColnos.Items, int(tle.Resno))
+				}
+			}
+		}
+		q.MergeActionList = appendNode(q.MergeActionList, action)
+	}
+	ps.finishQuery(q, nil)
+	return q, nil
+}
+
+// transformReturningList analyzes a RETURNING list.
+func (ps *pstate) transformReturningList(returning *nodes.List) (*nodes.List, error) {
+	if returning == nil {
+		return nil, nil
+	}
+	tlist, err := ps.transformTargetList(returning, exprKindReturning)
+	if err != nil {
+		return nil, err
+	}
+	if len(tlist) == 0 {
+		return nil, errorf(CodeSyntaxError, -1, "RETURNING must have at least one column")
+	}
+	ps.markTargetListOrigins(tlist)
+	if ps.resolveUnknowns {
+		ps.resolveTargetListUnknowns(tlist)
+	}
+	return tleList(tlist), nil
+}
+
+// rtableList, tleList and nodeList convert slices to Lists; an empty
+// slice becomes a nil list.
+func rtableList(rtes []*nodes.RangeTblEntry) *nodes.List {
+	if len(rtes) == 0 {
+		return nil
+	}
+	l := &nodes.List{}
+	for _, rte := range rtes {
+		l.Items = append(l.Items, rte)
+	}
+	return l
+}
+
+func tleList(tles []*nodes.TargetEntry) *nodes.List {
+	if len(tles) == 0 {
+		return nil
+	}
+	l := &nodes.List{}
+	for _, tle := range tles {
+		l.Items = append(l.Items, tle)
+	}
+	return l
+}
+
+func nodeList(ns []nodes.Node) *nodes.List {
+	if len(ns) == 0 {
+		return nil
+	}
+	return &nodes.List{Items: ns}
+}
+
+// appendNode appends n to a possibly nil list.
+func appendNode(l *nodes.List, n nodes.Node) *nodes.List {
+	if l == nil {
+		l = &nodes.List{}
+	}
+	l.Items = append(l.Items, n)
+	return l
+}
@@ -0,0 +1,700 @@
+package sema
+
+import "github.com/pgplex/pgparser/nodes"
+
+// OIDs of built-in types, from pg_type.dat.
+const (
+	BOOLOID                    nodes.Oid = 16
+	BYTEAOID                   nodes.Oid = 17
+	CHAROID                    nodes.Oid = 18
+	NAMEOID                    nodes.Oid = 19
+	INT8OID                    nodes.Oid = 20
+	INT2OID                    nodes.Oid = 21
+	INT2VECTOROID              nodes.Oid = 22
+	INT4OID                    nodes.Oid = 23
+	REGPROCOID                 nodes.Oid = 24
+	TEXTOID                    nodes.Oid = 25
+	OIDOID                     nodes.Oid = 26
+	TIDOID                     nodes.Oid = 27
+	XIDOID                     nodes.Oid = 28
+	CIDOID                     nodes.Oid = 29
+	OIDVECTOROID               nodes.Oid = 30
+	PG_DDL_COMMANDOID          nodes.Oid = 32
+	JSONOID                    nodes.Oid = 114
+	XMLOID                     nodes.Oid = 142
+	XMLARRAYOID                nodes.Oid = 143
+	PG_NODE_TREEOID            nodes.Oid = 194
+	TABLE_AM_HANDLEROID        nodes.Oid = 269
+	INDEX_AM_HANDLEROID        nodes.Oid = 325
+	POINTOID                   nodes.Oid = 600
+	LSEGOID                    nodes.Oid = 601
+	PATHOID                    nodes.Oid = 602
+	BOXOID                     nodes.Oid = 603
+	POLYGONOID                 nodes.Oid = 604
+	LINEOID                    nodes.Oid = 628
+	CIDROID                    nodes.Oid = 650
+	FLOAT4OID                  nodes.Oid = 700
+	FLOAT8OID                  nodes.Oid = 701
+	UNKNOWNOID                 nodes.Oid = 705
+	CIRCLEOID                  nodes.Oid = 718
+	MACADDR8OID                nodes.Oid = 774
+	MONEYOID                   nodes.Oid = 790
+	MACADDROID                 nodes.Oid = 829
+	INETOID                    nodes.Oid = 869
+	NAMEARRAYOID               nodes.Oid = 1003
+	INT4ARRAYOID               nodes.Oid = 1007
+	TEXTARRAYOID               nodes.Oid = 1009
+	ACLITEMOID                 nodes.Oid = 1033
+	BPCHAROID                  nodes.Oid = 1042
+	VARCHAROID                 nodes.Oid = 1043
+	DATEOID                    nodes.Oid = 1082
+	TIMEOID                    nodes.Oid = 1083
+	TIMESTAMPOID               nodes.Oid = 1114
+	TIMESTAMPTZOID             nodes.Oid = 1184
+	INTERVALOID                nodes.Oid = 1186
+	TIMETZOID                  nodes.Oid = 1266
+	BITOID                     nodes.Oid = 1560
+	VARBITOID                  nodes.Oid = 1562
+	NUMERICOID                 nodes.Oid = 1700
+	REFCURSOROID               nodes.Oid = 1790
+	REGPROCEDUREOID            nodes.Oid = 2202
+	REGOPEROID                 nodes.Oid = 2203
+	REGOPERATOROID             nodes.Oid = 2204
+	REGCLASSOID                nodes.Oid = 2205
+	REGTYPEOID                 nodes.Oid = 2206
+	RECORDOID                  nodes.Oid = 2249
+	CSTRINGOID                 nodes.Oid = 2275
+	ANYOID                     nodes.Oid = 2276
+	ANYARRAYOID                nodes.Oid = 2277
+	VOIDOID                    nodes.Oid = 2278
+	TRIGGEROID                 nodes.Oid = 2279
+	INTERNALOID                nodes.Oid = 2281
+	LANGUAGE_HANDLEROID        nodes.Oid = 2280
+	ANYELEMENTOID              nodes.Oid = 2283
+	RECORDARRAYOID             nodes.Oid = 2287
+	ANYNONARRAYOID             nodes.Oid = 2776
+	UUIDOID                    nodes.Oid = 2950
+	TXID_SNAPSHOTOID           nodes.Oid = 2970
+	FDW_HANDLEROID             nodes.Oid = 3115
+	PG_LSNOID                  nodes.Oid = 3220
+	TSM_HANDLEROID             nodes.Oid = 3310
+	ANYENUMOID                 nodes.Oid = 3500
+	TSVECTOROID                nodes.Oid = 3614
+	TSQUERYOID                 nodes.Oid = 3615
+	REGCONFIGOID               nodes.Oid = 3734
+	REGDICTIONARYOID           nodes.Oid = 3769
+	JSONBOID                   nodes.Oid = 3802
+	EVENT_TRIGGEROID           nodes.Oid = 3838
+	ANYRANGEOID                nodes.Oid = 3831
+	INT4RANGEOID               nodes.Oid = 3904
+	NUMRANGEOID                nodes.Oid = 3906
+	TSRANGEOID                 nodes.Oid = 3908
+	TSTZRANGEOID               nodes.Oid = 3910
+	DATERANGEOID               nodes.Oid = 3912
+	INT8RANGEOID               nodes.Oid = 3926
+	JSONPATHOID                nodes.Oid = 4072
+	REGNAMESPACEOID            nodes.Oid = 4089
+	REGROLEOID                 nodes.Oid = 4096
+	REGCOLLATIONOID            nodes.Oid = 4191
+	INT4MULTIRANGEOID          nodes.Oid = 4451
+	NUMMULTIRANGEOID           nodes.Oid = 4532
+	TSMULTIRANGEOID            nodes.Oid = 4533
+	TSTZMULTIRANGEOID          nodes.Oid = 4534
+	DATEMULTIRANGEOID          nodes.Oid = 4535
+	INT8MULTIRANGEOID          nodes.Oid = 4536
+	ANYMULTIRANGEOID           nodes.Oid = 4537
+	ANYCOMPATIBLEMULTIRANGEOID nodes.Oid = 4538
+	PG_SNAPSHOTOID             nodes.Oid = 5038
+	XID8OID                    nodes.Oid = 5069
+	ANYCOMPATIBLEOID           nodes.Oid = 5077
+	ANYCOMPATIBLEARRAYOID      nodes.Oid = 5078
+	ANYCOMPATIBLENONARRAYOID   nodes.Oid = 5079
+	ANYCOMPATIBLERANGEOID      nodes.Oid = 5080
+)
+
+// OIDs of the built-in functions that callers may need to recognize in
+// analyzed trees, from pg_proc.dat. Other built-in functions have no OID.
+const (
+	F_COUNT_ANY     nodes.Oid = 2147 // count("any")
+	F_COUNT_        nodes.Oid = 2803 // count(*)
+	F_ROW_NUMBER    nodes.Oid = 3100
+	F_RANK_         nodes.Oid = 3101
+	F_DENSE_RANK_   nodes.Oid = 3102
+	F_PERCENT_RANK_ nodes.Oid = 3103
+	F_CUME_DIST_    nodes.Oid = 3104
+)
+
+// OIDs of built-in collations, from pg_collation.dat.
+const (
+	DEFAULT_COLLATION_OID nodes.Oid = 100
+	C_COLLATION_OID       nodes.Oid = 950
+	POSIX_COLLATION_OID   nodes.Oid = 951
+)
+
+// FirstNormalObjectId is the first OID assigned to user-defined objects.
+const FirstNormalObjectId nodes.Oid = 16384
+
+// builtinType is a row of the built-in type table. Every type with a
+// non-zero array OID also gets an array type named "_" + name.
+type builtinType struct {
+	oid       nodes.Oid
+	name      string
+	length    int
+	byVal     bool
+	category  byte
+	preferred bool
+	array     nodes.Oid
+	elem      nodes.Oid
+	kind      byte
+}
+
+var builtinTypeTable = []builtinType{
+	{BOOLOID, "bool", 1, true, 'B', true, 1000, 0, 'b'},
+	{BYTEAOID, "bytea", -1, false, 'U', false, 1001, 0, 'b'},
+	{CHAROID, "char", 1, true, 'Z', false, 1002, 0, 'b'},
+	{NAMEOID, "name", 64, false, 'S', false, 1003, CHAROID, 'b'},
+	{INT8OID, "int8", 8, true, 'N', false, 1016, 0, 'b'},
+	{INT2OID, "int2", 2, true, 'N', false, 1005, 0, 'b'},
+	{INT2VECTOROID, "int2vector", -1, false, 'A', false, 1006, INT2OID, 'b'},
+	{INT4OID, "int4", 4, true, 'N', false, 1007, 0, 'b'},
+	{REGPROCOID, "regproc", 4, true, 'N', false, 1008, 0, 'b'},
+	{TEXTOID, "text", -1, false, 'S', true, 1009, 0, 'b'},
+	{OIDOID, "oid", 4, true, 'N', true, 1028, 0, 'b'},
+	{TIDOID, "tid", 6, false, 'U', false, 1010, 0, 'b'},
+	{XIDOID, "xid", 4, true, 'U', false, 1011, 0, 'b'},
+	{CIDOID, "cid", 4, true, 'U', false, 1012, 0, 'b'},
+	{OIDVECTOROID, "oidvector", -1, false, 'A', false, 1013, OIDOID, 'b'},
+	{PG_DDL_COMMANDOID, "pg_ddl_command", 8, true, 'P', false, 0, 0, 'p'},
+	{JSONOID, "json", -1, false, 'U', false, 199, 0, 'b'},
+	{XMLOID, "xml", -1, false, 'U', false, 143, 0, 'b'},
+	{PG_NODE_TREEOID, "pg_node_tree", -1, false, 'Z', false, 0, 0, 'b'},
+	{TABLE_AM_HANDLEROID, "table_am_handler", 4, true, 'P', false, 0, 0, 'p'},
+	{INDEX_AM_HANDLEROID, "index_am_handler", 4, true, 'P', false, 0, 0, 'p'},
+	{POINTOID, "point", 16, false, 'G', false, 1017, FLOAT8OID, 'b'},
+	{LSEGOID, "lseg", 32, false, 'G', false, 1018, POINTOID, 'b'},
+	{PATHOID, "path", -1, false, 'G', false, 1019, 0, 'b'},
+	{BOXOID, "box", 32, false, 'G', false, 1020, POINTOID, 'b'},
+	{POLYGONOID, "polygon", -1, false, 'G', false, 1027, 0, 'b'},
+	{LINEOID, "line", 24, false, 'G', false, 629, FLOAT8OID, 'b'},
+	{CIDROID, "cidr", -1, false, 'I', false, 651, 0, 'b'},
+	{FLOAT4OID, "float4", 4, true, 'N', false, 1021, 0, 'b'},
+	{FLOAT8OID, "float8", 8, true, 'N', true, 1022, 0, 'b'},
+	{UNKNOWNOID, "unknown", -2, false, 'X', false, 0, 0, 'p'},
+	{CIRCLEOID, "circle", 24, false, 'G', false, 719, 0, 'b'},
+	{MACADDR8OID, "macaddr8", 8, false, 'U', false, 775, 0, 'b'},
+	{MONEYOID, "money", 8, true, 'N', false, 791, 0, 'b'},
+	{MACADDROID, "macaddr", 6, false, 'U', false, 1040, 0, 'b'},
+	{INETOID, "inet", -1, false, 'I', true, 1041, 0, 'b'},
+	{ACLITEMOID, "aclitem", 16, false, 'U', false, 1034, 0, 'b'},
+	{BPCHAROID, "bpchar", -1, false, 'S', false, 1014, 0, 'b'},
+	{VARCHAROID, "varchar", -1, false, 'S', false, 1015, 0, 'b'},
+	{DATEOID, "date", 4, true, 'D', false, 1182, 0, 'b'},
+	{TIMEOID, "time", 8, true, 'D', false, 1183, 0, 'b'},
+	{TIMESTAMPOID, "timestamp", 8, true, 'D', false, 1115, 0, 'b'},
+	{TIMESTAMPTZOID, "timestamptz", 8, true, 'D', true, 1185, 0, 'b'},
+	{INTERVALOID, "interval", 16, false, 'T', true, 1187, 0, 'b'},
+	{TIMETZOID, "timetz", 12, false, 'D', false, 1270, 0, 'b'},
+	{BITOID, "bit", -1, false, 'V', false, 1561, 0, 'b'},
+	{VARBITOID, "varbit", -1, false, 'V', true, 1563, 0, 'b'},
+	{NUMERICOID, "numeric", -1, false, 'N', false, 1231, 0, 'b'},
+	{REFCURSOROID, "refcursor", -1, false, 'U', false, 2201, 0, 'b'},
+	{REGPROCEDUREOID, "regprocedure", 4, true, 'N', false, 2207, 0, 'b'},
+	{REGOPEROID, "regoper", 4, true, 'N', false, 2208, 0, 'b'},
+	{REGOPERATOROID, "regoperator", 4, true, 'N', false, 2209, 0, 'b'},
+	{REGCLASSOID, "regclass", 4, true, 'N', false, 2210, 0, 'b'},
+	{REGTYPEOID, "regtype", 4, true, 'N', false, 2211, 0, 'b'},
+	{RECORDOID, "record", -1, false, 'P', false, RECORDARRAYOID, 0, 'p'},
+	{CSTRINGOID, "cstring", -2, false, 'P', false, 1263, 0, 'p'},
+	{ANYOID, "any", 4, true, 'P', false, 0, 0, 'p'},
+	{ANYARRAYOID, "anyarray", -1, false, 'P', false, 0, 0, 'p'},
+	{VOIDOID, "void", 4, true, 'P', false, 0, 0, 'p'},
+	{TRIGGEROID, "trigger", 4, true, 'P', false, 0, 0, 'p'},
+	{LANGUAGE_HANDLEROID, "language_handler", 4, true, 'P', false, 0, 0, 'p'},
+	{INTERNALOID, "internal", 8, true, 'P', false, 0, 0, 'p'},
+	{ANYELEMENTOID, "anyelement", 4, true, 'P', false, 0, 0, 'p'},
+	{ANYNONARRAYOID, "anynonarray", 4, true, 'P', false, 0, 0, 'p'},
+	{UUIDOID, "uuid", 16, false, 'U', false, 2951, 0, 'b'},
+	{TXID_SNAPSHOTOID, "txid_snapshot", -1, false, 'U', false, 2949, 0, 'b'},
+	{FDW_HANDLEROID, "fdw_handler", 4, true, 'P', false, 0, 0, 'p'},
+	{PG_LSNOID, "pg_lsn", 8, true, 'U', false, 3221, 0, 'b'},
+	{TSM_HANDLEROID, "tsm_handler", 4, true, 'P', false, 0, 0, 'p'},
+	{ANYENUMOID, "anyenum", 4, true, 'P', false, 0, 0, 'p'},
+	{TSVECTOROID, "tsvector", -1, false, 'U', false, 3643, 0, 'b'},
+	{TSQUERYOID, "tsquery", -1, false, 'U', false, 3645, 0, 'b'},
+	{REGCONFIGOID, "regconfig", 4, true, 'N', false, 3735, 0, 'b'},
+	{REGDICTIONARYOID, "regdictionary", 4, true, 'N', false, 3770, 0, 'b'},
+	{JSONBOID, "jsonb", -1, false, 'U', false, 3807, 0, 'b'},
+	{EVENT_TRIGGEROID, "event_trigger", 4, true, 'P', false, 0, 0, 'p'},
+	{ANYRANGEOID, "anyrange", -1, false, 'P', false, 0, 0, 'p'},
+	{INT4RANGEOID, "int4range", -1, false, 'R', false, 3905, 0, 'r'},
+	{NUMRANGEOID, "numrange", -1, false, 'R', false, 3907, 0, 'r'},
+	{TSRANGEOID, "tsrange", -1, false, 'R', false, 3909, 0, 'r'},
+	{TSTZRANGEOID, "tstzrange", -1, false, 'R', false, 3911, 0, 'r'},
+	{DATERANGEOID, "daterange", -1, false, 'R', false, 3913, 0, 'r'},
+	{INT8RANGEOID, "int8range", -1, false, 'R', false, 3927, 0, 'r'},
+	{JSONPATHOID, "jsonpath", -1, false, 'U', false, 4073, 0, 'b'},
+	{REGNAMESPACEOID, "regnamespace", 4, true, 'N', false, 4090, 0, 'b'},
+	{REGROLEOID, "regrole", 4, true, 'N', false, 4097, 0, 'b'},
+	{REGCOLLATIONOID, "regcollation", 4, true, 'N', false, 4192, 0, 'b'},
+	{INT4MULTIRANGEOID, "int4multirange", -1, false, 'R', false, 6150, 0, 'm'},
+	{NUMMULTIRANGEOID, "nummultirange", -1, false, 'R', false, 6151, 0, 'm'},
+	{TSMULTIRANGEOID, "tsmultirange", -1, false, 'R', false, 6152, 0, 'm'},
+	{TSTZMULTIRANGEOID, "tstzmultirange", -1, false, 'R', false, 6153, 0, 'm'},
+	{DATEMULTIRANGEOID, "datemultirange", -1, false, 'R', false, 6155, 0, 'm'},
+	{INT8MULTIRANGEOID, "int8multirange", -1, false, 'R', false, 6157, 0, 'm'},
+	{ANYMULTIRANGEOID, "anymultirange", -1, false, 'P', false, 0, 0, 'p'},
+	{PG_SNAPSHOTOID, "pg_snapshot", -1, false, 'U', false, 5039, 0, 'b'},
+	{XID8OID, "xid8", 8, true, 'U', false, 271, 0, 'b'},
+	{ANYCOMPATIBLEOID, "anycompatible", 4, true, 'P', false, 0, 0, 'p'},
+	{ANYCOMPATIBLEARRAYOID, "anycompatiblearray", -1, false, 'P', false, 0, 0, 'p'},
+	{ANYCOMPATIBLENONARRAYOID, "anycompatiblenonarray", 4, true, 'P', false, 0, 0, 'p'},
+	{ANYCOMPATIBLERANGEOID, "anycompatiblerange", -1, false, 'P', false, 0, 0, 'p'},
+	{ANYCOMPATIBLEMULTIRANGEOID, "anycompatiblemultirange", -1, false, 'P', false, 0, 0, 'p'},
+}
+
+// builtinRangeSubtypes maps the built-in range and multirange types to
+// the type of their bounds (pg_range.rngsubtype).
+var builtinRangeSubtypes = map[nodes.Oid]nodes.Oid{
+	INT4RANGEOID: INT4OID, INT4MULTIRANGEOID: INT4OID,
+	NUMRANGEOID: NUMERICOID, NUMMULTIRANGEOID: NUMERICOID,
+	TSRANGEOID: TIMESTAMPOID, TSMULTIRANGEOID: TIMESTAMPOID,
+	TSTZRANGEOID: TIMESTAMPTZOID, TSTZMULTIRANGEOID: TIMESTAMPTZOID,
+	DATERANGEOID: DATEOID, DATEMULTIRANGEOID: DATEOID,
+	INT8RANGEOID: INT8OID, INT8MULTIRANGEOID: INT8OID,
+}
+
+// builtinTypes returns the built-in types, including array types, in OID
+// order of the table above.
+func builtinTypes() []*Type {
+	var out []*Type
+	for _, b := range builtinTypeTable {
+		t := &Type{
+			Oid:       b.oid,
+			Schema:    "pg_catalog",
+			Name:      b.name,
+			Kind:      b.kind,
+			Category:  b.category,
+			Preferred: b.preferred,
+			Len:       b.length,
+			ByVal:     b.byVal,
+			Elem:      b.elem,
+			Array:     b.array,
+			Subtype:   builtinRangeSubtypes[b.oid],
+		}
+		switch b.oid {
+		case TEXTOID, VARCHAROID, BPCHAROID:
+			t.Collation = DEFAULT_COLLATION_OID
+		case NAMEOID:
+			t.Collation = C_COLLATION_OID
+		}
+		out = append(out, t)
+		if b.array != 0 {
+			out = append(out, &Type{
+				Oid:       b.array,
+				Schema:    "pg_catalog",
+				Name:      "_" + b.name,
+				Kind:      'b',
+				Category:  'A',
+				Len:       -1,
+				Elem:      b.oid,
+				Collation: t.Collation,
+			})
+		}
+	}
+	return out
+}
+
+// builtinCast is a row of the built-in cast table.
+type builtinCast struct {
+	source, target  nodes.Oid
+	context, method byte
+}
+
+// builtinCastTable lists the casts of pg_cast.dat between the types of
+// builtinTypeTable, other than those between geometric types and those
+// involving the reg* OID alias types, which builtinCasts generates. Cast
+// function OIDs are not included.
+var builtinCastTable = []builtinCast{
+	{INT2OID, INT4OID, 'i', 'f'}, {INT2OID, INT8OID, 'i', 'f'},
+	{INT2OID, FLOAT4OID, 'i', 'f'}, {INT2OID, FLOAT8OID, 'i', 'f'},
+	{INT2OID, NUMERICOID, 'i', 'f'}, {INT2OID, OIDOID, 'i', 'f'},
+	{INT4OID, INT8OID, 'i', 'f'}, {INT4OID, INT2OID, 'a', 'f'},
+	{INT4OID, FLOAT4OID, 'i', 'f'}, {INT4OID, FLOAT8OID, 'i', 'f'},
+	{INT4OID, NUMERICOID, 'i', 'f'}, {INT4OID, BOOLOID, 'e', 'f'},
+	{INT4OID, OIDOID, 'i', 'b'}, {INT4OID, MONEYOID, 'a', 'f'},
+	{INT4OID, CHAROID, 'e', 'f'},
+	{INT8OID, INT2OID, 'a', 'f'}, {INT8OID, INT4OID, 'a', 'f'},
+	{INT8OID, FLOAT4OID, 'i', 'f'}, {INT8OID, FLOAT8OID, 'i', 'f'},
+	{INT8OID, NUMERICOID, 'i', 'f'}, {INT8OID, OIDOID, 'i', 'f'},
+	{INT8OID, MONEYOID, 'a', 'f'},
+	{FLOAT4OID, INT2OID, 'a', 'f'}, {FLOAT4OID, INT4OID, 'a', 'f'},
+	{FLOAT4OID, INT8OID, 'a', 'f'}, {FLOAT4OID, FLOAT8OID, 'i', 'f'},
+	{FLOAT4OID, NUMERICOID, 'a', 'f'},
+	{FLOAT8OID, INT2OID, 'a', 'f'}, {FLOAT8OID, INT4OID, 'a', 'f'},
+	{FLOAT8OID, INT8OID, 'a', 'f'}, {FLOAT8OID, FLOAT4OID, 'a', 'f'},
+	{FLOAT8OID, NUMERICOID, 'a', 'f'},
+	{NUMERICOID, INT2OID, 'a', 'f'}, {NUMERICOID, INT4OID, 'a', 'f'},
+	{NUMERICOID, INT8OID, 'a', 'f'}, {NUMERICOID, FLOAT4OID, 'i', 'f'},
+	{NUMERICOID, FLOAT8OID, 'i', 'f'}, {NUMERICOID, MONEYOID, 'a', 'f'},
+	{OIDOID, INT4OID, 'a', 'b'}, {OIDOID, INT8OID, 'a', 'f'},
+	{BOOLOID, INT4OID, 'e', 'f'},
+	{TEXTOID, VARCHAROID, 'i', 'b'}, {TEXTOID, BPCHAROID, 'i', 'b'},
+	{TEXTOID, NAMEOID, 'i', 'f'}, {TEXTOID, CHAROID, 'a', 'f'},
+	{TEXTOID, XMLOID, 'e', 'f'},
+	{VARCHAROID, TEXTOID, 'i', 'b'}, {VARCHAROID, BPCHAROID, 'i', 'b'},
+	{VARCHAROID, NAMEOID, 'i', 'f'}, {VARCHAROID, CHAROID, 'a', 'f'},
+	{VARCHAROID, XMLOID, 'e', 'f'},
+	{BPCHAROID, TEXTOID, 'i', 'f'}, {BPCHAROID, VARCHAROID, 'i', 'f'},
+	{BPCHAROID, NAMEOID, 'i', 'f'}, {BPCHAROID, CHAROID, 'a', 'f'},
+	{BPCHAROID, XMLOID, 'e', 'f'},
+	{NAMEOID, TEXTOID, 'i', 'f'}, {NAMEOID, VARCHAROID, 'a', 'f'},
+	{NAMEOID, BPCHAROID, 'a', 'f'},
+	{CHAROID, TEXTOID, 'i', 'f'}, {CHAROID, VARCHAROID, 'a', 'f'},
+	{CHAROID, BPCHAROID, 'a', 'f'}, {CHAROID, INT4OID, 'e', 'f'},
+	{XMLOID, TEXTOID, 'a', 'b'}, {XMLOID, VARCHAROID, 'a', 'b'},
+	{XMLOID, BPCHAROID, 'a', 'b'},
+	{DATEOID, TIMESTAMPOID, 'i', 'f'}, {DATEOID, TIMESTAMPTZOID, 'i', 'f'},
+	{TIMESTAMPOID, DATEOID, 'a', 'f'}, {TIMESTAMPOID, TIMEOID, 'a', 'f'},
+	{TIMESTAMPOID, TIMESTAMPTZOID, 'i', 'f'},
+	{TIMESTAMPTZOID, DATEOID, 'a', 'f'}, {TIMESTAMPTZOID, TIMEOID, 'a', 'f'},
+	{TIMESTAMPTZOID, TIMESTAMPOID, 'a', 'f'}, {TIMESTAMPTZOID, TIMETZOID, 'a', 'f'},
+	{TIMEOID, INTERVALOID, 'i', 'f'}, {TIMEOID, TIMETZOID, 'i', 'f'},
+	{TIMETZOID, TIMEOID, 'a', 'f'}, {INTERVALOID, TIMEOID, 'a', 'f'},
+	{BITOID, VARBITOID, 'i', 'b'}, {VARBITOID, BITOID, 'i', 'b'},
+	{BITOID, INT4OID, 'e', 'f'}, {INT4OID, BITOID, 'e', 'f'},
+	{BITOID, INT8OID, 'e', 'f'}, {INT8OID, BITOID, 'e', 'f'},
+	{CIDROID, INETOID, 'i', 'b'}, {INETOID, CIDROID, 'a', 'f'},
+	{INETOID, TEXTOID, 'a', 'f'}, {CIDROID, TEXTOID, 'a', 'f'},
+	{INETOID, VARCHAROID, 'a', 'f'}, {CIDROID, VARCHAROID, 'a', 'f'},
+	{INETOID, BPCHAROID, 'a', 'f'}, {CIDROID, BPCHAROID, 'a', 'f'},
+	{MACADDROID, MACADDR8OID, 'i', 'f'}, {MACADDR8OID, MACADDROID, 'i', 'f'},
+	{JSONOID, JSONBOID, 'a', 'i'}, {JSONBOID, JSONOID, 'a', 'i'},
+	{JSONBOID, BOOLOID, 'e', 'f'}, {JSONBOID, NUMERICOID, 'e', 'f'},
+	{JSONBOID, INT2OID, 'e', 'f'}, {JSONBOID, INT4OID, 'e', 'f'},
+	{JSONBOID, INT8OID, 'e', 'f'}, {JSONBOID, FLOAT4OID, 'e', 'f'},
+	{JSONBOID, FLOAT8OID, 'e', 'f'},
+	{MONEYOID, NUMERICOID, 'a', 'f'},
+	{INT4RANGEOID, INT4MULTIRANGEOID, 'e', 'f'}, {NUMRANGEOID, NUMMULTIRANGEOID, 'e', 'f'},
+	{TSRANGEOID, TSMULTIRANGEOID, 'e', 'f'}, {TSTZRANGEOID, TSTZMULTIRANGEOID, 'e', 'f'},
+	{DATERANGEOID, DATEMULTIRANGEOID, 'e', 'f'}, {INT8RANGEOID, INT8MULTIRANGEOID, 'e', 'f'},
+	// Length coercion functions.
+	{BPCHAROID, BPCHAROID, 'i', 'f'}, {VARCHAROID, VARCHAROID, 'i', 'f'},
+	{NUMERICOID, NUMERICOID, 'i', 'f'}, {BITOID, BITOID, 'i', 'f'},
+	{VARBITOID, VARBITOID, 'i', 'f'}, {TIMESTAMPOID, TIMESTAMPOID, 'i', 'f'},
+	{TIMESTAMPTZOID, TIMESTAMPTZOID, 'i', 'f'}, {TIMEOID, TIMEOID, 'i', 'f'},
+	{TIMETZOID, TIMETZOID, 'i', 'f'}, {INTERVALOID, INTERVALOID, 'i', 'f'},
+}
+
+// builtinCasts returns the built-in casts: the rows of builtinCastTable,
+// followed by the casts between the reg* OID alias types and the integer
+// types, which follow the same pattern for every alias type.
+func builtinCasts() []*Cast {
+	var out []*Cast
+	add := func(source, target nodes.Oid, context, method byte) {
+		out = append(out, &Cast{Source: source, Target: target, Context: context, Method: method})
+	}
+	for _, b := range builtinCastTable {
+		add(b.source, b.target, b.context, b.method)
+	}
+	aliases := []nodes.Oid{
+		REGPROCOID, REGPROCEDUREOID, REGOPEROID, REGOPERATOROID, REGCLASSOID,
+		REGCOLLATIONOID, REGTYPEOID, REGCONFIGOID, REGDICTIONARYOID,
+		REGROLEOID, REGNAMESPACEOID,
+	}
+	for _, t := range aliases {
+		add(OIDOID, t, 'i', 'b')
+		add(t, OIDOID, 'i', 'b')
+		add(INT8OID, t, 'i', 'f')
+		add(INT2OID, t, 'i', 'f')
+		add(INT4OID, t, 'i', 'b')
+		add(t, INT8OID, 'a', 'f')
+		add(t, INT4OID, 'a', 'b')
+	}
+	add(REGPROCOID, REGPROCEDUREOID, 'i', 'b')
+	add(REGPROCEDUREOID, REGPROCOID, 'i', 'b')
+	add(REGOPEROID, REGOPERATOROID, 'i', 'b')
+	add(REGOPERATOROID, REGOPEROID, 'i', 'b')
+	add(TEXTOID, REGCLASSOID, 'i', 'f')
+	add(VARCHAROID, REGCLASSOID, 'i', 'f')
+	return out
+}
+
+// builtinFunction is a row of the built-in function table.
+type builtinFunction struct {
+	name   string
+	kind   byte
+	args   []nodes.Oid
+	result nodes.Oid
+}
+
+// builtinFunctionTable lists the aggregate and window functions of
+// pg_proc.dat, which the analyzer must know about to check grouping and to
+// build Aggref and WindowFunc nodes. Function OIDs are not included, other
+// than those of the F_ constants, which builtinFunctions fills in.
+var builtinFunctionTable = []builtinFunction{
+	{"count", 'a', nil, INT8OID},
+	{"count", 'a', []nodes.Oid{ANYOID}, INT8OID},
+	{"sum", 'a', []nodes.Oid{INT2OID}, INT8OID},
+	{"sum", 'a', []nodes.Oid{INT4OID}, INT8OID},
+	{"sum", 'a', []nodes.Oid{INT8OID}, NUMERICOID},
+	{"sum", 'a', []nodes.Oid{NUMERICOID}, NUMERICOID},
+	{"sum", 'a', []nodes.Oid{FLOAT4OID}, FLOAT4OID},
+	{"sum", 'a', []nodes.Oid{FLOAT8OID}, FLOAT8OID},
+	{"sum", 'a', []nodes.Oid{MONEYOID}, MONEYOID},
+	{"sum", 'a', []nodes.Oid{INTERVALOID}, INTERVALOID},
+	{"avg", 'a', []nodes.Oid{INT2OID}, NUMERICOID},
+	{"avg", 'a', []nodes.Oid{INT4OID}, NUMERICOID},
+	{"avg", 'a', []nodes.Oid{INT8OID}, NUMERICOID},
+	{"avg", 'a', []nodes.Oid{NUMERICOID}, NUMERICOID},
+	{"avg", 'a', []nodes.Oid{FLOAT4OID}, FLOAT8OID},
+	{"avg", 'a', []nodes.Oid{FLOAT8OID}, FLOAT8OID},
+	{"avg", 'a', []nodes.Oid{INTERVALOID}, INTERVALOID},
+	{"min", 'a', []nodes.Oid{ANYELEMENTOID}, ANYELEMENTOID},
+	{"max", 'a', []nodes.Oid{ANYELEMENTOID}, ANYELEMENTOID},
+	{"bool_and", 'a', []nodes.Oid{BOOLOID}, BOOLOID},
+	{"bool_or", 'a', []nodes.Oid{BOOLOID}, BOOLOID},
+	{"every", 'a', []nodes.Oid{BOOLOID}, BOOLOID},
+	{"bit_and", 'a', []nodes.Oid{INT4OID}, INT4OID},
+	{"bit_or", 'a', []nodes.Oid{INT4OID}, INT4OID},
+	{"bit_and", 'a', []nodes.Oid{INT8OID}, INT8OID},
+	{"bit_or", 'a', []nodes.Oid{INT8OID}, INT8OID},
+	{"bit_xor", 'a', []nodes.Oid{INT4OID}, INT4OID},
+	{"bit_xor", 'a', []nodes.Oid{INT8OID}, INT8OID},
+	{"any_value", 'a', []nodes.Oid{ANYELEMENTOID}, ANYELEMENTOID},
+	{"array_agg", 'a', []nodes.Oid{ANYNONARRAYOID}, ANYARRAYOID},
+	{"array_agg", 'a', []nodes.Oid{ANYARRAYOID}, ANYARRAYOID},
+	{"string_agg", 'a', []nodes.Oid{TEXTOID, TEXTOID}, TEXTOID},
+	{"string_agg", 'a', []nodes.Oid{BYTEAOID, BYTEAOID}, BYTEAOID},
+	{"json_agg", 'a', []nodes.Oid{ANYELEMENTOID}, JSONOID},
+	{"jsonb_agg", 'a', []nodes.Oid{ANYELEMENTOID}, JSONBOID},
+	{"json_agg_strict", 'a', []nodes.Oid{ANYELEMENTOID}, JSONOID},
+	{"jsonb_agg_strict", 'a', []nodes.Oid{ANYELEMENTOID}, JSONBOID},
+	{"json_object_agg", 'a', []nodes.Oid{ANYOID, ANYOID}, JSONOID},
+	{"jsonb_object_agg", 'a', []nodes.Oid{ANYOID, ANYOID}, JSONBOID},
+	{"xmlagg", 'a', []nodes.Oid{XMLOID}, XMLOID},
+	{"stddev", 'a', []nodes.Oid{FLOAT8OID}, FLOAT8OID},
+	{"stddev", 'a', []nodes.Oid{NUMERICOID}, NUMERICOID},
+	{"variance", 'a', []nodes.Oid{FLOAT8OID}, FLOAT8OID},
+	{"variance", 'a', []nodes.Oid{NUMERICOID}, NUMERICOID},
+	{"stddev_pop", 'a', []nodes.Oid{FLOAT8OID}, FLOAT8OID},
+	{"stddev_pop", 'a', []nodes.Oid{NUMERICOID}, NUMERICOID},
+	{"stddev_samp", 'a', []nodes.Oid{FLOAT8OID}, FLOAT8OID},
+	{"stddev_samp", 'a', []nodes.Oid{NUMERICOID}, NUMERICOID},
+	{"var_pop", 'a', []nodes.Oid{FLOAT8OID}, FLOAT8OID},
+	{"var_pop", 'a', []nodes.Oid{NUMERICOID}, NUMERICOID},
+	{"var_samp", 'a', []nodes.Oid{FLOAT8OID}, FLOAT8OID},
+	{"var_samp", 'a', []nodes.Oid{NUMERICOID}, NUMERICOID},
+	{"corr", 'a', []nodes.Oid{FLOAT8OID, FLOAT8OID}, FLOAT8OID},
+	{"covar_pop", 'a', []nodes.Oid{FLOAT8OID, FLOAT8OID}, FLOAT8OID},
+	{"covar_samp", 'a', []nodes.Oid{FLOAT8OID, FLOAT8OID}, FLOAT8OID},
+	{"regr_count", 'a', []nodes.Oid{FLOAT8OID, FLOAT8OID}, INT8OID},
+	{"regr_slope", 'a', []nodes.Oid{FLOAT8OID, FLOAT8OID}, FLOAT8OID},
+	{"regr_intercept", 'a', []nodes.Oid{FLOAT8OID, FLOAT8OID}, FLOAT8OID},
+	{"regr_r2", 'a', []nodes.Oid{FLOAT8OID, FLOAT8OID}, FLOAT8OID},
+	{"row_number", 'w', nil, INT8OID},
+	{"rank", 'w', nil, INT8OID},
+	{"dense_rank", 'w', nil, INT8OID},
+	{"percent_rank", 'w', nil, FLOAT8OID},
+	{"cume_dist", 'w', nil, FLOAT8OID},
+	{"ntile", 'w', []nodes.Oid{INT4OID}, INT4OID},
+	{"lag", 'w', []nodes.Oid{ANYELEMENTOID}, ANYELEMENTOID},
+	{"lag", 'w', []nodes.Oid{ANYELEMENTOID, INT4OID}, ANYELEMENTOID},
+	{"lag", 'w', []nodes.Oid{ANYCOMPATIBLEOID, INT4OID, ANYCOMPATIBLEOID}, ANYCOMPATIBLEOID},
+	{"lead", 'w', []nodes.Oid{ANYELEMENTOID}, ANYELEMENTOID},
+	{"lead", 'w', []nodes.Oid{ANYELEMENTOID, INT4OID}, ANYELEMENTOID},
+	{"lead", 'w', []nodes.Oid{ANYCOMPATIBLEOID, INT4OID, ANYCOMPATIBLEOID}, ANYCOMPATIBLEOID},
+	{"first_value", 'w', []nodes.Oid{ANYELEMENTOID}, ANYELEMENTOID},
+	{"last_value", 'w', []nodes.Oid{ANYELEMENTOID}, ANYELEMENTOID},
+	{"nth_value", 'w', []nodes.Oid{ANYELEMENTOID, INT4OID}, ANYELEMENTOID},
+}
+
+// builtinCollations maps the names of the built-in collations to their
+// OIDs.
+var builtinCollations = map[string]nodes.Oid{
+	"default": DEFAULT_COLLATION_OID,
+	"C":       C_COLLATION_OID,
+	"POSIX":   POSIX_COLLATION_OID,
+}
+
+// builtinOperator is a row of the built-in operator table. left is
+// InvalidOid for a prefix operator.
+type builtinOperator struct {
+	name                string
+	left, right, result nodes.Oid
+}
+
+// builtinOperatorTable lists operators of pg_operator.dat that are not
+// generated by builtinOperators. Operator
+// and function OIDs are not included.
+var builtinOperatorTable = []builtinOperator{
+	// Cross-type integer and float arithmetic.
+	{"+", INT2OID, INT4OID, INT4OID}, {"+", INT4OID, INT2OID, INT4OID},
+	{"+", INT2OID, INT8OID, INT8OID}, {"+", INT8OID, INT2OID, INT8OID},
+	{"+", INT4OID, INT8OID, INT8OID}, {"+", INT8OID, INT4OID, INT8OID},
+	{"-", INT2OID, INT4OID, INT4OID}, {"-", INT4OID, INT2OID, INT4OID},
+	{"-", INT2OID, INT8OID, INT8OID}, {"-", INT8OID, INT2OID, INT8OID},
+	{"-", INT4OID, INT8OID, INT8OID}, {"-", INT8OID, INT4OID, INT8OID},
+	{"*", INT2OID, INT4OID, INT4OID}, {"*", INT4OID, INT2OID, INT4OID},
+	{"*", INT2OID, INT8OID, INT8OID}, {"*", INT8OID, INT2OID, INT8OID},
+	{"*", INT4OID, INT8OID, INT8OID}, {"*", INT8OID, INT4OID, INT8OID},
+	{"/", INT2OID, INT4OID, INT4OID}, {"/", INT4OID, INT2OID, INT4OID},
+	{"/", INT2OID, INT8OID, INT8OID}, {"/", INT8OID, INT2OID, INT8OID},
+	{"/", INT4OID, INT8OID, INT8OID}, {"/", INT8OID, INT4OID, INT8OID},
+	{"+", FLOAT4OID, FLOAT8OID, FLOAT8OID}, {"+", FLOAT8OID, FLOAT4OID, FLOAT8OID},
+	{"-", FLOAT4OID, FLOAT8OID, FLOAT8OID}, {"-", FLOAT8OID, FLOAT4OID, FLOAT8OID},
+	{"*", FLOAT4OID, FLOAT8OID, FLOAT8OID}, {"*", FLOAT8OID, FLOAT4OID, FLOAT8OID},
+	{"/", FLOAT4OID, FLOAT8OID, FLOAT8OID}, {"/", FLOAT8OID, FLOAT4OID, FLOAT8OID},
+	{"^", FLOAT8OID, FLOAT8OID, FLOAT8OID}, {"^", NUMERICOID, NUMERICOID, NUMERICOID},
+	{"|/", 0, FLOAT8OID, FLOAT8OID}, {"||/", 0, FLOAT8OID, FLOAT8OID},
+	{"+", MONEYOID, MONEYOID, MONEYOID}, {"-", MONEYOID, MONEYOID, MONEYOID},
+	{"*", MONEYOID, FLOAT8OID, MONEYOID}, {"/", MONEYOID, FLOAT8OID, MONEYOID},
+
+	// Date and time arithmetic.
+	{"+", DATEOID, INT4OID, DATEOID}, {"+", INT4OID, DATEOID, DATEOID},
+	{"-", DATEOID, INT4OID, DATEOID}, {"-", DATEOID, DATEOID, INT4OID},
+	{"+", DATEOID, INTERVALOID, TIMESTAMPOID}, {"+", INTERVALOID, DATEOID, TIMESTAMPOID},
+	{"-", DATEOID, INTERVALOID, TIMESTAMPOID},
+	{"+", DATEOID, TIMEOID, TIMESTAMPOID}, {"+", TIMEOID, DATEOID, TIMESTAMPOID},
+	{"+", TIMESTAMPOID, INTERVALOID, TIMESTAMPOID}, {"+", INTERVALOID, TIMESTAMPOID, TIMESTAMPOID},
+	{"-", TIMESTAMPOID, INTERVALOID, TIMESTAMPOID}, {"-", TIMESTAMPOID, TIMESTAMPOID, INTERVALOID},
+	{"+", TIMESTAMPTZOID, INTERVALOID, TIMESTAMPTZOID}, {"+", INTERVALOID, TIMESTAMPTZOID, TIMESTAMPTZOID},
+	{"-", TIMESTAMPTZOID, INTERVALOID, TIMESTAMPTZOID}, {"-", TIMESTAMPTZOID, TIMESTAMPTZOID, INTERVALOID},
+	{"+", TIMEOID, INTERVALOID, TIMEOID}, {"+", INTERVALOID, TIMEOID, TIMEOID},
+	{"-", TIMEOID, INTERVALOID, TIMEOID}, {"-", TIMEOID, TIMEOID, INTERVALOID},
+	{"+", INTERVALOID, INTERVALOID, INTERVALOID}, {"-", INTERVALOID, INTERVALOID, INTERVALOID},
+	{"*", INTERVALOID, FLOAT8OID, INTERVALOID}, {"*", FLOAT8OID, INTERVALOID, INTERVALOID},
+	{"/", INTERVALOID, FLOAT8OID, INTERVALOID}, {"-", 0, INTERVALOID, INTERVALOID},
+
+	// Strings and pattern matching.
+	{"||", TEXTOID, TEXTOID, TEXTOID},
+	{"||", TEXTOID, ANYNONARRAYOID, TEXTOID}, {"||", ANYNONARRAYOID, TEXTOID, TEXTOID},
+	{"||", BYTEAOID, BYTEAOID, BYTEAOID}, {"||", VARBITOID, VARBITOID, VARBITOID},
+	{"^@", TEXTOID, TEXTOID, BOOLOID},
+
+	// Arrays.
+	{"||", ANYCOMPATIBLEARRAYOID, ANYCOMPATIBLEARRAYOID, ANYCOMPATIBLEARRAYOID},
+	{"||", ANYCOMPATIBLEARRAYOID, ANYCOMPATIBLEOID, ANYCOMPATIBLEARRAYOID},
+	{"||", ANYCOMPATIBLEOID, ANYCOMPATIBLEARRAYOID, ANYCOMPATIBLEARRAYOID},
+	{"@>", ANYARRAYOID, ANYARRAYOID, BOOLOID}, {"<@", ANYARRAYOID, ANYARRAYOID, BOOLOID},
+	{"&&", ANYARRAYOID, ANYARRAYOID, BOOLOID},
+
+	// Ranges.
+	{"@>", ANYRANGEOID, ANYRANGEOID, BOOLOID}, {"<@", ANYRANGEOID, ANYRANGEOID, BOOLOID},
+	{"@>", ANYRANGEOID, ANYELEMENTOID, BOOLOID}, {"<@", ANYELEMENTOID, ANYRANGEOID, BOOLOID},
+	{"&&", ANYRANGEOID, ANYRANGEOID, BOOLOID},
+	{"+", ANYRANGEOID, ANYRANGEOID, ANYRANGEOID}, {"*", ANYRANGEOID, ANYRANGEOID, ANYRANGEOID},
+	{"-", ANYRANGEOID, ANYRANGEOID, ANYRANGEOID},
+
+	// json and jsonb.
+	{"->", JSONOID, TEXTOID, JSONOID}, {"->", JSONOID, INT4OID, JSONOID},
+	{"->>", JSONOID, TEXTOID, TEXTOID}, {"->>", JSONOID, INT4OID, TEXTOID},
+	{"#>", JSONOID, TEXTARRAYOID, JSONOID}, {"#>>", JSONOID, TEXTARRAYOID, TEXTOID},
+	{"->", JSONBOID, TEXTOID, JSONBOID}, {"->", JSONBOID, INT4OID, JSONBOID},
+	{"->>", JSONBOID, TEXTOID, TEXTOID}, {"->>", JSONBOID, INT4OID, TEXTOID},
+	{"#>", JSONBOID, TEXTARRAYOID, JSONBOID}, {"#>>", JSONBOID, TEXTARRAYOID, TEXTOID},
+	{"@>", JSONBOID, JSONBOID, BOOLOID}, {"<@", JSONBOID, JSONBOID, BOOLOID},
+	{"?", JSONBOID, TEXTOID, BOOLOID},
+	{"?|", JSONBOID, TEXTARRAYOID, BOOLOID}, {"?&", JSONBOID, TEXTARRAYOID, BOOLOID},
+	{"||", JSONBOID, JSONBOID, JSONBOID},
+	{"-", JSONBOID, TEXTOID, JSONBOID}, {"-", JSONBOID, INT4OID, JSONBOID},
+	{"-", JSONBOID, TEXTARRAYOID, JSONBOID}, {"#-", JSONBOID, TEXTARRAYOID, JSONBOID},
+	{"@?", JSONBOID, JSONPATHOID, BOOLOID}, {"@@", JSONBOID, JSONPATHOID, BOOLOID},
+
+	// Text search.
+	{"@@", TSVECTOROID, TSQUERYOID, BOOLOID}, {"@@", TSQUERYOID, TSVECTOROID, BOOLOID},
+	{"@@", TEXTOID, TSQUERYOID, BOOLOID}, {"@@", TEXTOID, TEXTOID, BOOLOID},
+	{"||", TSVECTOROID, TSVECTOROID, TSVECTOROID},
+	{"&&", TSQUERYOID, TSQUERYOID, TSQUERYOID}, {"||", TSQUERYOID, TSQUERYOID, TSQUERYOID},
+	{"!!", 0, TSQUERYOID, TSQUERYOID},
+
+	// Network addresses.
+	{"<<", INETOID, INETOID, BOOLOID}, {"<<=", INETOID, INETOID, BOOLOID},
+	{">>", INETOID, INETOID, BOOLOID}, {">>=", INETOID, INETOID, BOOLOID},
+	{"&&", INETOID, INETOID, BOOLOID},
+	{"+", INETOID, INT8OID, INETOID}, {"-", INETOID, INT8OID, INETOID},
+	{"-", INETOID, INETOID, INT8OID},
+
+	// Bit strings.
+	{"&", BITOID, BITOID, BITOID}, {"|", BITOID, BITOID, BITOID},
+	{"#", BITOID, BITOID, BITOID}, {"~", 0, BITOID, BITOID},
+	{"<<", BITOID, INT4OID, BITOID}, {">>", BITOID, INT4OID, BITOID},
+}
+
+// builtinOperators returns the built-in operators: the comparison,
+// arithmetic and pattern-matching operators that exist for many types,
+// followed by the rows of builtinOperatorTable. An operator is listed once
+// even if builtinOperatorTable repeats one of the generated signatures.
+func builtinOperators() []*Operator {
+	var out []*Operator
+	type signature struct {
+		name        string
+		left, right nodes.Oid
+	}
+	seen := map[signature]bool{}
+	add := func(name string, left, right, result nodes.Oid) {
+		sig := signature{name, left, right}
+		if seen[sig] {
+			return
+		}
+		seen[sig] = true
+		out = append(out, &Operator{Schema: "pg_catalog", Name: name, Left: left, Right: right, Result: result})
+	}
+	comparable := []nodes.Oid{
+		BOOLOID, CHAROID, NAMEOID, INT2OID, INT4OID, INT8OID, FLOAT4OID, FLOAT8OID,
+		NUMERICOID, MONEYOID, TEXTOID, BPCHAROID, BYTEAOID, OIDOID, TIDOID,
+		DATEOID, TIMEOID, TIMETZOID, TIMESTAMPOID, TIMESTAMPTZOID, INTERVALOID,
+		BITOID, VARBITOID, INETOID, MACADDROID, MACADDR8OID, UUIDOID, PG_LSNOID,
+		JSONBOID, TSVECTOROID, TSQUERYOID, XID8OID,
+		ANYARRAYOID, ANYENUMOID, ANYRANGEOID, ANYMULTIRANGEOID, RECORDOID,
+	}
+	crossComparable := [][2]nodes.Oid{
+		{INT2OID, INT4OID}, {INT2OID, INT8OID}, {INT4OID, INT8OID},
+		{FLOAT4OID, FLOAT8OID},
+		{DATEOID, TIMESTAMPOID}, {DATEOID, TIMESTAMPTZOID}, {TIMESTAMPOID, TIMESTAMPTZOID},
+		{NAMEOID, TEXTOID},
+	}
+	for _, name := range []string{"=", "<>", "<", "<=", ">", ">="} {
+		for _, t := range comparable {
+			add(name, t, t, BOOLOID)
+		}
+		for _, p := range crossComparable {
+			add(name, p[0], p[1], BOOLOID)
+			add(name, p[1], p[0], BOOLOID)
+		}
+	}
+	add("=", XIDOID, XIDOID, BOOLOID)
+	add("<>", XIDOID, XIDOID, BOOLOID)
+	add("=", JSONPATHOID, JSONPATHOID, BOOLOID)
+
+	numeric := []nodes.Oid{INT2OID, INT4OID, INT8OID, FLOAT4OID, FLOAT8OID, NUMERICOID}
+	for _, t := range numeric {
+		for _, name := range []string{"+", "-", "*", "/"} {
+			add(name, t, t, t)
+		}
+		add("-", nodes.InvalidOid, t, t)
+		add("+", nodes.InvalidOid, t, t)
+		add("@", nodes.InvalidOid, t, t)
+	}
+	for _, t := range []nodes.Oid{INT2OID, INT4OID, INT8OID} {
+		for _, name := range []string{"%", "&", "|", "#"} {
+			add(name, t, t, t)
+		}
+		add("<<", t, INT4OID, t)
+		add(">>", t, INT4OID, t)
+		add("~", nodes.InvalidOid, t, t)
+	}
+	add("%", NUMERICOID, NUMERICOID, NUMERICOID)
+
+	for _, name := range []string{"~~", "!~~", "~~*", "!~~*", "~", "!~", "~*", "!~*"} {
+		for _, t := range []nodes.Oid{TEXTOID, BPCHAROID, NAMEOID} {
+			add(name, t, TEXTOID, BOOLOID)
+		}
+	}
+	add("~~", BYTEAOID, BYTEAOID, BOOLOID)
+	add("!~~", BYTEAOID, BYTEAOID, BOOLOID)
+
+	for _, b := range builtinOperatorTable {
+		add(b.name, b.left, b.right, b.result)
+	}
+	return out
+}
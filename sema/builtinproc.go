@@ -0,0 +1,697 @@
+package sema
+
+import "github.com/pgplex/pgparser/nodes"
+
+// builtinProc is a row of the built-in scalar function table.
+type builtinProc struct {
+	name   string
+	args   []nodes.Oid
+	result nodes.Oid
+	flags  procFlag
+}
+
+// procFlag marks a built-in function as variadic or set-returning.
+type procFlag uint8
+
+const (
+	procVariadic procFlag = 1 << iota // the last argument is VARIADIC
+	procRetset                        // returns SETOF result
+)
+
+// builtinProcTable lists scalar and set-returning functions of
+// pg_proc.dat. Functions named after a type, such as int4(float8), are
+// left out: a call of a type name with one argument is analyzed as a cast.
+// Function OIDs are not included. pgsema-gen -catalog generates the full
+// table, with builtinOperatorTable, into builtinproc_generated.go; the two
+// tables here are to be deleted when that file is checked in.
+var builtinProcTable = []builtinProc{
+	// Strings.
+	{"length", []nodes.Oid{TEXTOID}, INT4OID, 0},
+	{"length", []nodes.Oid{BPCHAROID}, INT4OID, 0},
+	{"length", []nodes.Oid{BYTEAOID}, INT4OID, 0},
+	{"length", []nodes.Oid{BITOID}, INT4OID, 0},
+	{"length", []nodes.Oid{TSVECTOROID}, INT4OID, 0},
+	{"char_length", []nodes.Oid{TEXTOID}, INT4OID, 0},
+	{"char_length", []nodes.Oid{BPCHAROID}, INT4OID, 0},
+	{"character_length", []nodes.Oid{TEXTOID}, INT4OID, 0},
+	{"character_length", []nodes.Oid{BPCHAROID}, INT4OID, 0},
+	{"octet_length", []nodes.Oid{TEXTOID}, INT4OID, 0},
+	{"octet_length", []nodes.Oid{BPCHAROID}, INT4OID, 0},
+	{"octet_length", []nodes.Oid{BYTEAOID}, INT4OID, 0},
+	{"octet_length", []nodes.Oid{BITOID}, INT4OID, 0},
+	{"bit_length", []nodes.Oid{TEXTOID}, INT4OID, 0},
+	{"bit_length", []nodes.Oid{BYTEAOID}, INT4OID, 0},
+	{"bit_length", []nodes.Oid{BITOID}, INT4OID, 0},
+	{"lower", []nodes.Oid{TEXTOID}, TEXTOID, 0},
+	{"upper", []nodes.Oid{TEXTOID}, TEXTOID, 0},
+	{"initcap", []nodes.Oid{TEXTOID}, TEXTOID, 0},
+	{"btrim", []nodes.Oid{TEXTOID}, TEXTOID, 0},
+	{"btrim", []nodes.Oid{TEXTOID, TEXTOID}, TEXTOID, 0},
+	{"btrim", []nodes.Oid{BYTEAOID, BYTEAOID}, BYTEAOID, 0},
+	{"ltrim", []nodes.Oid{TEXTOID}, TEXTOID, 0},
+	{"ltrim", []nodes.Oid{TEXTOID, TEXTOID}, TEXTOID, 0},
+	{"ltrim", []nodes.Oid{BYTEAOID, BYTEAOID}, BYTEAOID, 0},
+	{"rtrim", []nodes.Oid{TEXTOID}, TEXTOID, 0},
+	{"rtrim", []nodes.Oid{TEXTOID, TEXTOID}, TEXTOID, 0},
+	{"rtrim", []nodes.Oid{BYTEAOID, BYTEAOID}, BYTEAOID, 0},
+	{"substr", []nodes.Oid{TEXTOID, INT4OID}, TEXTOID, 0},
+	{"substr", []nodes.Oid{TEXTOID, INT4OID, INT4OID}, TEXTOID, 0},
+	{"substr", []nodes.Oid{BYTEAOID, INT4OID}, BYTEAOID, 0},
+	{"substr", []nodes.Oid{BYTEAOID, INT4OID, INT4OID}, BYTEAOID, 0},
+	{"substring", []nodes.Oid{TEXTOID, INT4OID}, TEXTOID, 0},
+	{"substring", []nodes.Oid{TEXTOID, INT4OID, INT4OID}, TEXTOID, 0},
+	{"substring", []nodes.Oid{TEXTOID, TEXTOID}, TEXTOID, 0},
+	{"substring", []nodes.Oid{TEXTOID, TEXTOID, TEXTOID}, TEXTOID, 0},
+	{"substring", []nodes.Oid{BYTEAOID, INT4OID}, BYTEAOID, 0},
+	{"substring", []nodes.Oid{BYTEAOID, INT4OID, INT4OID}, BYTEAOID, 0},
+	{"substring", []nodes.Oid{BITOID, INT4OID}, BITOID, 0},
+	{"substring", []nodes.Oid{BITOID, INT4OID, INT4OID}, BITOID, 0},
+	{"position", []nodes.Oid{TEXTOID, TEXTOID}, INT4OID, 0},
+	{"position", []nodes.Oid{BYTEAOID, BYTEAOID}, INT4OID, 0},
+	{"position", []nodes.Oid{BITOID, BITOID}, INT4OID, 0},
+	{"strpos", []nodes.Oid{TEXTOID, TEXTOID}, INT4OID, 0},
+	{"overlay", []nodes.Oid{TEXTOID, TEXTOID, INT4OID}, TEXTOID, 0},
+	{"overlay", []nodes.Oid{TEXTOID, TEXTOID, INT4OID, INT4OID}, TEXTOID, 0},
+	{"overlay", []nodes.Oid{BYTEAOID, BYTEAOID, INT4OID}, BYTEAOID, 0},
+	{"overlay", []nodes.Oid{BYTEAOID, BYTEAOID, INT4OID, INT4OID}, BYTEAOID, 0},
+	{"replace", []nodes.Oid{TEXTOID, TEXTOID, TEXTOID}, TEXTOID, 0},
+	{"translate", []nodes.Oid{TEXTOID, TEXTOID, TEXTOID}, TEXTOID, 0},
+	{"repeat", []nodes.Oid{TEXTOID, INT4OID}, TEXTOID, 0},
+	{"reverse", []nodes.Oid{TEXTOID}, TEXTOID, 0},
+	{"left", []nodes.Oid{TEXTOID, INT4OID}, TEXTOID, 0},
+	{"right", []nodes.Oid{TEXTOID, INT4OID}, TEXTOID, 0},
+	{"lpad", []nodes.Oid{TEXTOID, INT4OID}, TEXTOID, 0},
+	{"lpad", []nodes.Oid{TEXTOID, INT4OID, TEXTOID}, TEXTOID, 0},
+	{"rpad", []nodes.Oid{TEXTOID, INT4OID}, TEXTOID, 0},
+	{"rpad", []nodes.Oid{TEXTOID, INT4OID, TEXTOID}, TEXTOID, 0},
+	{"split_part", []nodes.Oid{TEXTOID, TEXTOID, INT4OID}, TEXTOID, 0},
+	{"starts_with", []nodes.Oid{TEXTOID, TEXTOID}, BOOLOID, 0},
+	{"ascii", []nodes.Oid{TEXTOID}, INT4OID, 0},
+	{"chr", []nodes.Oid{INT4OID}, TEXTOID, 0},
+	{"to_hex", []nodes.Oid{INT4OID}, TEXTOID, 0},
+	{"to_hex", []nodes.Oid{INT8OID}, TEXTOID, 0},
+	{"unistr", []nodes.Oid{TEXTOID}, TEXTOID, 0},
+	{"concat", []nodes.Oid{ANYOID}, TEXTOID, procVariadic},
+	{"concat_ws", []nodes.Oid{TEXTOID, ANYOID}, TEXTOID, procVariadic},
+	{"format", []nodes.Oid{TEXTOID}, TEXTOID, 0},
+	{"format", []nodes.Oid{TEXTOID, ANYOID}, TEXTOID, procVariadic},
+	{"quote_ident", []nodes.Oid{TEXTOID}, TEXTOID, 0},
+	{"quote_literal", []nodes.Oid{TEXTOID}, TEXTOID, 0},
+	{"quote_literal", []nodes.Oid{ANYELEMENTOID}, TEXTOID, 0},
+	{"quote_nullable", []nodes.Oid{TEXTOID}, TEXTOID, 0},
+	{"quote_nullable", []nodes.Oid{ANYELEMENTOID}, TEXTOID, 0},
+	{"similar_to_escape", []nodes.Oid{TEXTOID}, TEXTOID, 0},
+	{"similar_to_escape", []nodes.Oid{TEXTOID, TEXTOID}, TEXTOID, 0},
+	{"regexp_replace", []nodes.Oid{TEXTOID, TEXTOID, TEXTOID}, TEXTOID, 0},
+	{"regexp_replace", []nodes.Oid{TEXTOID, TEXTOID, TEXTOID, TEXTOID}, TEXTOID, 0},
+	{"regexp_replace", []nodes.Oid{TEXTOID, TEXTOID, TEXTOID, INT4OID}, TEXTOID, 0},
+	{"regexp_replace", []nodes.Oid{TEXTOID, TEXTOID, TEXTOID, INT4OID, INT4OID}, TEXTOID, 0},
+	{"regexp_replace", []nodes.Oid{TEXTOID, TEXTOID, TEXTOID, INT4OID, INT4OID, TEXTOID}, TEXTOID, 0},
+	{"regexp_match", []nodes.Oid{TEXTOID, TEXTOID}, TEXTARRAYOID, 0},
+	{"regexp_match", []nodes.Oid{TEXTOID, TEXTOID, TEXTOID}, TEXTARRAYOID, 0},
+	{"regexp_matches", []nodes.Oid{TEXTOID, TEXTOID}, TEXTARRAYOID, procRetset},
+	{"regexp_matches", []nodes.Oid{TEXTOID, TEXTOID, TEXTOID}, TEXTARRAYOID, procRetset},
+	{"regexp_like", []nodes.Oid{TEXTOID, TEXTOID}, BOOLOID, 0},
+	{"regexp_like", []nodes.Oid{TEXTOID, TEXTOID, TEXTOID}, BOOLOID, 0},
+	{"regexp_count", []nodes.Oid{TEXTOID, TEXTOID}, INT4OID, 0},
+	{"regexp_count", []nodes.Oid{TEXTOID, TEXTOID, INT4OID}, INT4OID, 0},
+	{"regexp_count", []nodes.Oid{TEXTOID, TEXTOID, INT4OID, TEXTOID}, INT4OID, 0},
+	{"regexp_instr", []nodes.Oid{TEXTOID, TEXTOID}, INT4OID, 0},
+	{"regexp_instr", []nodes.Oid{TEXTOID, TEXTOID, INT4OID}, INT4OID, 0},
+	{"regexp_substr", []nodes.Oid{TEXTOID, TEXTOID}, TEXTOID, 0},
+	{"regexp_substr", []nodes.Oid{TEXTOID, TEXTOID, INT4OID}, TEXTOID, 0},
+	{"regexp_split_to_array", []nodes.Oid{TEXTOID, TEXTOID}, TEXTARRAYOID, 0},
+	{"regexp_split_to_array", []nodes.Oid{TEXTOID, TEXTOID, TEXTOID}, TEXTARRAYOID, 0},
+	{"regexp_split_to_table", []nodes.Oid{TEXTOID, TEXTOID}, TEXTOID, procRetset},
+	{"regexp_split_to_table", []nodes.Oid{TEXTOID, TEXTOID, TEXTOID}, TEXTOID, procRetset},
+	{"string_to_array", []nodes.Oid{TEXTOID, TEXTOID}, TEXTARRAYOID, 0},
+	{"string_to_array", []nodes.Oid{TEXTOID, TEXTOID, TEXTOID}, TEXTARRAYOID, 0},
+	{"string_to_table", []nodes.Oid{TEXTOID, TEXTOID}, TEXTOID, procRetset},
+	{"string_to_table", []nodes.Oid{TEXTOID, TEXTOID, TEXTOID}, TEXTOID, procRetset},
+	{"md5", []nodes.Oid{TEXTOID}, TEXTOID, 0},
+	{"md5", []nodes.Oid{BYTEAOID}, TEXTOID, 0},
+	{"sha224", []nodes.Oid{BYTEAOID}, BYTEAOID, 0},
+	{"sha256", []nodes.Oid{BYTEAOID}, BYTEAOID, 0},
+	{"sha384", []nodes.Oid{BYTEAOID}, BYTEAOID, 0},
+	{"sha512", []nodes.Oid{BYTEAOID}, BYTEAOID, 0},
+	{"encode", []nodes.Oid{BYTEAOID, TEXTOID}, TEXTOID, 0},
+	{"decode", []nodes.Oid{TEXTOID, TEXTOID}, BYTEAOID, 0},
+	{"convert_from", []nodes.Oid{BYTEAOID, NAMEOID}, TEXTOID, 0},
+	{"convert_to", []nodes.Oid{TEXTOID, NAMEOID}, BYTEAOID, 0},
+	{"get_byte", []nodes.Oid{BYTEAOID, INT4OID}, INT4OID, 0},
+	{"set_byte", []nodes.Oid{BYTEAOID, INT4OID, INT4OID}, BYTEAOID, 0},
+	{"get_bit", []nodes.Oid{BYTEAOID, INT8OID}, INT4OID, 0},
+	{"set_bit", []nodes.Oid{BYTEAOID, INT8OID, INT4OID}, BYTEAOID, 0},
+	{"bit_count", []nodes.Oid{BYTEAOID}, INT8OID, 0},
+	{"bit_count", []nodes.Oid{BITOID}, INT8OID, 0},
+	{"to_char", []nodes.Oid{TIMESTAMPOID, TEXTOID}, TEXTOID, 0},
+	{"to_char", []nodes.Oid{TIMESTAMPTZOID, TEXTOID}, TEXTOID, 0},
+	{"to_char", []nodes.Oid{INTERVALOID, TEXTOID}, TEXTOID, 0},
+	{"to_char", []nodes.Oid{INT4OID, TEXTOID}, TEXTOID, 0},
+	{"to_char", []nodes.Oid{INT8OID, TEXTOID}, TEXTOID, 0},
+	{"to_char", []nodes.Oid{FLOAT4OID, TEXTOID}, TEXTOID, 0},
+	{"to_char", []nodes.Oid{FLOAT8OID, TEXTOID}, TEXTOID, 0},
+	{"to_char", []nodes.Oid{NUMERICOID, TEXTOID}, TEXTOID, 0},
+	{"to_number", []nodes.Oid{TEXTOID, TEXTOID}, NUMERICOID, 0},
+
+	// Numbers.
+	{"abs", []nodes.Oid{INT2OID}, INT2OID, 0},
+	{"abs", []nodes.Oid{INT4OID}, INT4OID, 0},
+	{"abs", []nodes.Oid{INT8OID}, INT8OID, 0},
+	{"abs", []nodes.Oid{FLOAT4OID}, FLOAT4OID, 0},
+	{"abs", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"abs", []nodes.Oid{NUMERICOID}, NUMERICOID, 0},
+	{"sign", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"sign", []nodes.Oid{NUMERICOID}, NUMERICOID, 0},
+	{"ceil", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"ceil", []nodes.Oid{NUMERICOID}, NUMERICOID, 0},
+	{"ceiling", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"ceiling", []nodes.Oid{NUMERICOID}, NUMERICOID, 0},
+	{"floor", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"floor", []nodes.Oid{NUMERICOID}, NUMERICOID, 0},
+	{"round", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"round", []nodes.Oid{NUMERICOID}, NUMERICOID, 0},
+	{"round", []nodes.Oid{NUMERICOID, INT4OID}, NUMERICOID, 0},
+	{"trunc", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"trunc", []nodes.Oid{NUMERICOID}, NUMERICOID, 0},
+	{"trunc", []nodes.Oid{NUMERICOID, INT4OID}, NUMERICOID, 0},
+	{"trunc", []nodes.Oid{MACADDROID}, MACADDROID, 0},
+	{"sqrt", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"sqrt", []nodes.Oid{NUMERICOID}, NUMERICOID, 0},
+	{"cbrt", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"exp", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"exp", []nodes.Oid{NUMERICOID}, NUMERICOID, 0},
+	{"ln", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"ln", []nodes.Oid{NUMERICOID}, NUMERICOID, 0},
+	{"log", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"log", []nodes.Oid{NUMERICOID}, NUMERICOID, 0},
+	{"log", []nodes.Oid{NUMERICOID, NUMERICOID}, NUMERICOID, 0},
+	{"log10", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"log10", []nodes.Oid{NUMERICOID}, NUMERICOID, 0},
+	{"power", []nodes.Oid{FLOAT8OID, FLOAT8OID}, FLOAT8OID, 0},
+	{"power", []nodes.Oid{NUMERICOID, NUMERICOID}, NUMERICOID, 0},
+	{"pow", []nodes.Oid{FLOAT8OID, FLOAT8OID}, FLOAT8OID, 0},
+	{"pow", []nodes.Oid{NUMERICOID, NUMERICOID}, NUMERICOID, 0},
+	{"mod", []nodes.Oid{INT2OID, INT2OID}, INT2OID, 0},
+	{"mod", []nodes.Oid{INT4OID, INT4OID}, INT4OID, 0},
+	{"mod", []nodes.Oid{INT8OID, INT8OID}, INT8OID, 0},
+	{"mod", []nodes.Oid{NUMERICOID, NUMERICOID}, NUMERICOID, 0},
+	{"div", []nodes.Oid{NUMERICOID, NUMERICOID}, NUMERICOID, 0},
+	{"gcd", []nodes.Oid{INT4OID, INT4OID}, INT4OID, 0},
+	{"gcd", []nodes.Oid{INT8OID, INT8OID}, INT8OID, 0},
+	{"gcd", []nodes.Oid{NUMERICOID, NUMERICOID}, NUMERICOID, 0},
+	{"lcm", []nodes.Oid{INT4OID, INT4OID}, INT4OID, 0},
+	{"lcm", []nodes.Oid{INT8OID, INT8OID}, INT8OID, 0},
+	{"lcm", []nodes.Oid{NUMERICOID, NUMERICOID}, NUMERICOID, 0},
+	{"factorial", []nodes.Oid{INT8OID}, NUMERICOID, 0},
+	{"scale", []nodes.Oid{NUMERICOID}, INT4OID, 0},
+	{"min_scale", []nodes.Oid{NUMERICOID}, INT4OID, 0},
+	{"trim_scale", []nodes.Oid{NUMERICOID}, NUMERICOID, 0},
+	{"width_bucket", []nodes.Oid{FLOAT8OID, FLOAT8OID, FLOAT8OID, INT4OID}, INT4OID, 0},
+	{"width_bucket", []nodes.Oid{NUMERICOID, NUMERICOID, NUMERICOID, INT4OID}, INT4OID, 0},
+	{"width_bucket", []nodes.Oid{ANYCOMPATIBLEOID, ANYCOMPATIBLEARRAYOID}, INT4OID, 0},
+	{"pi", nil, FLOAT8OID, 0},
+	{"random", nil, FLOAT8OID, 0},
+	{"random", []nodes.Oid{INT4OID, INT4OID}, INT4OID, 0},
+	{"random", []nodes.Oid{INT8OID, INT8OID}, INT8OID, 0},
+	{"random", []nodes.Oid{NUMERICOID, NUMERICOID}, NUMERICOID, 0},
+	{"setseed", []nodes.Oid{FLOAT8OID}, VOIDOID, 0},
+	{"degrees", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"radians", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"sin", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"cos", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"tan", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"cot", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"asin", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"acos", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"atan", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"atan2", []nodes.Oid{FLOAT8OID, FLOAT8OID}, FLOAT8OID, 0},
+	{"sinh", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"cosh", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"tanh", []nodes.Oid{FLOAT8OID}, FLOAT8OID, 0},
+	{"generate_series", []nodes.Oid{INT4OID, INT4OID}, INT4OID, procRetset},
+	{"generate_series", []nodes.Oid{INT4OID, INT4OID, INT4OID}, INT4OID, procRetset},
+	{"generate_series", []nodes.Oid{INT8OID, INT8OID}, INT8OID, procRetset},
+	{"generate_series", []nodes.Oid{INT8OID, INT8OID, INT8OID}, INT8OID, procRetset},
+	{"generate_series", []nodes.Oid{NUMERICOID, NUMERICOID}, NUMERICOID, procRetset},
+	{"generate_series", []nodes.Oid{NUMERICOID, NUMERICOID, NUMERICOID}, NUMERICOID, procRetset},
+	{"generate_series", []nodes.Oid{TIMESTAMPOID, TIMESTAMPOID, INTERVALOID}, TIMESTAMPOID, procRetset},
+	{"generate_series", []nodes.Oid{TIMESTAMPTZOID, TIMESTAMPTZOID, INTERVALOID}, TIMESTAMPTZOID, procRetset},
+	{"generate_series", []nodes.Oid{TIMESTAMPTZOID, TIMESTAMPTZOID, INTERVALOID, TEXTOID}, TIMESTAMPTZOID, procRetset},
+
+	// Dates and times.
+	{"now", nil, TIMESTAMPTZOID, 0},
+	{"statement_timestamp", nil, TIMESTAMPTZOID, 0},
+	{"transaction_timestamp", nil, TIMESTAMPTZOID, 0},
+	{"clock_timestamp", nil, TIMESTAMPTZOID, 0},
+	{"timeofday", nil, TEXTOID, 0},
+	{"age", []nodes.Oid{TIMESTAMPOID}, INTERVALOID, 0},
+	{"age", []nodes.Oid{TIMESTAMPOID, TIMESTAMPOID}, INTERVALOID, 0},
+	{"age", []nodes.Oid{TIMESTAMPTZOID}, INTERVALOID, 0},
+	{"age", []nodes.Oid{TIMESTAMPTZOID, TIMESTAMPTZOID}, INTERVALOID, 0},
+	{"age", []nodes.Oid{XIDOID}, INT4OID, 0},
+	{"date_part", []nodes.Oid{TEXTOID, DATEOID}, FLOAT8OID, 0},
+	{"date_part", []nodes.Oid{TEXTOID, TIMEOID}, FLOAT8OID, 0},
+	{"date_part", []nodes.Oid{TEXTOID, TIMETZOID}, FLOAT8OID, 0},
+	{"date_part", []nodes.Oid{TEXTOID, TIMESTAMPOID}, FLOAT8OID, 0},
+	{"date_part", []nodes.Oid{TEXTOID, TIMESTAMPTZOID}, FLOAT8OID, 0},
+	{"date_part", []nodes.Oid{TEXTOID, INTERVALOID}, FLOAT8OID, 0},
+	{"extract", []nodes.Oid{TEXTOID, DATEOID}, NUMERICOID, 0},
+	{"extract", []nodes.Oid{TEXTOID, TIMEOID}, NUMERICOID, 0},
+	{"extract", []nodes.Oid{TEXTOID, TIMETZOID}, NUMERICOID, 0},
+	{"extract", []nodes.Oid{TEXTOID, TIMESTAMPOID}, NUMERICOID, 0},
+	{"extract", []nodes.Oid{TEXTOID, TIMESTAMPTZOID}, NUMERICOID, 0},
+	{"extract", []nodes.Oid{TEXTOID, INTERVALOID}, NUMERICOID, 0},
+	{"date_trunc", []nodes.Oid{TEXTOID, TIMESTAMPOID}, TIMESTAMPOID, 0},
+	{"date_trunc", []nodes.Oid{TEXTOID, TIMESTAMPTZOID}, TIMESTAMPTZOID, 0},
+	{"date_trunc", []nodes.Oid{TEXTOID, TIMESTAMPTZOID, TEXTOID}, TIMESTAMPTZOID, 0},
+	{"date_trunc", []nodes.Oid{TEXTOID, INTERVALOID}, INTERVALOID, 0},
+	{"date_bin", []nodes.Oid{INTERVALOID, TIMESTAMPOID, TIMESTAMPOID}, TIMESTAMPOID, 0},
+	{"date_bin", []nodes.Oid{INTERVALOID, TIMESTAMPTZOID, TIMESTAMPTZOID}, TIMESTAMPTZOID, 0},
+	{"date_add", []nodes.Oid{TIMESTAMPTZOID, INTERVALOID}, TIMESTAMPTZOID, 0},
+	{"date_add", []nodes.Oid{TIMESTAMPTZOID, INTERVALOID, TEXTOID}, TIMESTAMPTZOID, 0},
+	{"date_subtract", []nodes.Oid{TIMESTAMPTZOID, INTERVALOID}, TIMESTAMPTZOID, 0},
+	{"date_subtract", []nodes.Oid{TIMESTAMPTZOID, INTERVALOID, TEXTOID}, TIMESTAMPTZOID, 0},
+	{"make_date", []nodes.Oid{INT4OID, INT4OID, INT4OID}, DATEOID, 0},
+	{"make_time", []nodes.Oid{INT4OID, INT4OID, FLOAT8OID}, TIMEOID, 0},
+	{"make_timestamp", []nodes.Oid{INT4OID, INT4OID, INT4OID, INT4OID, INT4OID, FLOAT8OID}, TIMESTAMPOID, 0},
+	{"make_timestamptz", []nodes.Oid{INT4OID, INT4OID, INT4OID, INT4OID, INT4OID, FLOAT8OID}, TIMESTAMPTZOID, 0},
+	{"make_timestamptz", []nodes.Oid{INT4OID, INT4OID, INT4OID, INT4OID, INT4OID, FLOAT8OID, TEXTOID}, TIMESTAMPTZOID, 0},
+	{"justify_days", []nodes.Oid{INTERVALOID}, INTERVALOID, 0},
+	{"justify_hours", []nodes.Oid{INTERVALOID}, INTERVALOID, 0},
+	{"justify_interval", []nodes.Oid{INTERVALOID}, INTERVALOID, 0},
+	{"isfinite", []nodes.Oid{DATEOID}, BOOLOID, 0},
+	{"isfinite", []nodes.Oid{TIMESTAMPOID}, BOOLOID, 0},
+	{"isfinite", []nodes.Oid{TIMESTAMPTZOID}, BOOLOID, 0},
+	{"isfinite", []nodes.Oid{INTERVALOID}, BOOLOID, 0},
+	{"to_date", []nodes.Oid{TEXTOID, TEXTOID}, DATEOID, 0},
+	{"to_timestamp", []nodes.Oid{TEXTOID, TEXTOID}, TIMESTAMPTZOID, 0},
+	{"to_timestamp", []nodes.Oid{FLOAT8OID}, TIMESTAMPTZOID, 0},
+	{"timezone", []nodes.Oid{TEXTOID, TIMESTAMPTZOID}, TIMESTAMPOID, 0},
+	{"timezone", []nodes.Oid{TEXTOID, TIMESTAMPOID}, TIMESTAMPTZOID, 0},
+	{"timezone", []nodes.Oid{TEXTOID, TIMETZOID}, TIMETZOID, 0},
+	{"timezone", []nodes.Oid{INTERVALOID, TIMESTAMPTZOID}, TIMESTAMPOID, 0},
+	{"timezone", []nodes.Oid{INTERVALOID, TIMESTAMPOID}, TIMESTAMPTZOID, 0},
+	{"timezone", []nodes.Oid{INTERVALOID, TIMETZOID}, TIMETZOID, 0},
+	{"timezone", []nodes.Oid{TIMESTAMPTZOID}, TIMESTAMPOID, 0},
+	{"timezone", []nodes.Oid{TIMESTAMPOID}, TIMESTAMPTZOID, 0},
+	{"timezone", []nodes.Oid{TIMETZOID}, TIMETZOID, 0},
+	{"overlaps", []nodes.Oid{TIMESTAMPOID, TIMESTAMPOID, TIMESTAMPOID, TIMESTAMPOID}, BOOLOID, 0},
+	{"overlaps", []nodes.Oid{TIMESTAMPTZOID, TIMESTAMPTZOID, TIMESTAMPTZOID, TIMESTAMPTZOID}, BOOLOID, 0},
+	{"overlaps", []nodes.Oid{TIMESTAMPOID, INTERVALOID, TIMESTAMPOID, INTERVALOID}, BOOLOID, 0},
+	{"overlaps", []nodes.Oid{TIMESTAMPTZOID, INTERVALOID, TIMESTAMPTZOID, INTERVALOID}, BOOLOID, 0},
+	{"overlaps", []nodes.Oid{TIMEOID, TIMEOID, TIMEOID, TIMEOID}, BOOLOID, 0},
+	{"overlaps", []nodes.Oid{TIMETZOID, TIMETZOID, TIMETZOID, TIMETZOID}, BOOLOID, 0},
+	{"pg_sleep", []nodes.Oid{FLOAT8OID}, VOIDOID, 0},
+	{"pg_sleep_for", []nodes.Oid{INTERVALOID}, VOIDOID, 0},
+	{"pg_sleep_until", []nodes.Oid{TIMESTAMPTZOID}, VOIDOID, 0},
+
+	// json and jsonb.
+	{"to_json", []nodes.Oid{ANYELEMENTOID}, JSONOID, 0},
+	{"to_jsonb", []nodes.Oid{ANYELEMENTOID}, JSONBOID, 0},
+	{"array_to_json", []nodes.Oid{ANYARRAYOID}, JSONOID, 0},
+	{"array_to_json", []nodes.Oid{ANYARRAYOID, BOOLOID}, JSONOID, 0},
+	{"row_to_json", []nodes.Oid{RECORDOID}, JSONOID, 0},
+	{"row_to_json", []nodes.Oid{RECORDOID, BOOLOID}, JSONOID, 0},
+	{"json_build_object", nil, JSONOID, 0},
+	{"json_build_object", []nodes.Oid{ANYOID}, JSONOID, procVariadic},
+	{"jsonb_build_object", nil, JSONBOID, 0},
+	{"jsonb_build_object", []nodes.Oid{ANYOID}, JSONBOID, procVariadic},
+	{"json_build_array", nil, JSONOID, 0},
+	{"json_build_array", []nodes.Oid{ANYOID}, JSONOID, procVariadic},
+	{"jsonb_build_array", nil, JSONBOID, 0},
+	{"jsonb_build_array", []nodes.Oid{ANYOID}, JSONBOID, procVariadic},
+	{"json_object", []nodes.Oid{TEXTARRAYOID}, JSONOID, 0},
+	{"json_object", []nodes.Oid{TEXTARRAYOID, TEXTARRAYOID}, JSONOID, 0},
+	{"jsonb_object", []nodes.Oid{TEXTARRAYOID}, JSONBOID, 0},
+	{"jsonb_object", []nodes.Oid{TEXTARRAYOID, TEXTARRAYOID}, JSONBOID, 0},
+	{"json_array_length", []nodes.Oid{JSONOID}, INT4OID, 0},
+	{"jsonb_array_length", []nodes.Oid{JSONBOID}, INT4OID, 0},
+	{"json_typeof", []nodes.Oid{JSONOID}, TEXTOID, 0},
+	{"jsonb_typeof", []nodes.Oid{JSONBOID}, TEXTOID, 0},
+	{"json_object_keys", []nodes.Oid{JSONOID}, TEXTOID, procRetset},
+	{"jsonb_object_keys", []nodes.Oid{JSONBOID}, TEXTOID, procRetset},
+	{"json_array_elements", []nodes.Oid{JSONOID}, JSONOID, procRetset},
+	{"jsonb_array_elements", []nodes.Oid{JSONBOID}, JSONBOID, procRetset},
+	{"json_array_elements_text", []nodes.Oid{JSONOID}, TEXTOID, procRetset},
+	{"jsonb_array_elements_text", []nodes.Oid{JSONBOID}, TEXTOID, procRetset},
+	{"json_extract_path", []nodes.Oid{JSONOID, TEXTARRAYOID}, JSONOID, procVariadic},
+	{"json_extract_path_text", []nodes.Oid{JSONOID, TEXTARRAYOID}, TEXTOID, procVariadic},
+	{"jsonb_extract_path", []nodes.Oid{JSONBOID, TEXTARRAYOID}, JSONBOID, procVariadic},
+	{"jsonb_extract_path_text", []nodes.Oid{JSONBOID, TEXTARRAYOID}, TEXTOID, procVariadic},
+	{"json_strip_nulls", []nodes.Oid{JSONOID}, JSONOID, 0},
+	{"jsonb_strip_nulls", []nodes.Oid{JSONBOID}, JSONBOID, 0},
+	{"jsonb_pretty", []nodes.Oid{JSONBOID}, TEXTOID, 0},
+	{"json_populate_record", []nodes.Oid{ANYELEMENTOID, JSONOID}, ANYELEMENTOID, 0},
+	{"jsonb_populate_record", []nodes.Oid{ANYELEMENTOID, JSONBOID}, ANYELEMENTOID, 0},
+	{"json_populate_recordset", []nodes.Oid{ANYELEMENTOID, JSONOID}, ANYELEMENTOID, procRetset},
+	{"jsonb_populate_recordset", []nodes.Oid{ANYELEMENTOID, JSONBOID}, ANYELEMENTOID, procRetset},
+	{"json_to_record", []nodes.Oid{JSONOID}, RECORDOID, 0},
+	{"jsonb_to_record", []nodes.Oid{JSONBOID}, RECORDOID, 0},
+	{"json_to_recordset", []nodes.Oid{JSONOID}, RECORDOID, procRetset},
+	{"jsonb_to_recordset", []nodes.Oid{JSONBOID}, RECORDOID, procRetset},
+
+	// Arrays.
+	{"array_length", []nodes.Oid{ANYARRAYOID, INT4OID}, INT4OID, 0},
+	{"array_lower", []nodes.Oid{ANYARRAYOID, INT4OID}, INT4OID, 0},
+	{"array_upper", []nodes.Oid{ANYARRAYOID, INT4OID}, INT4OID, 0},
+	{"array_ndims", []nodes.Oid{ANYARRAYOID}, INT4OID, 0},
+	{"array_dims", []nodes.Oid{ANYARRAYOID}, TEXTOID, 0},
+	{"cardinality", []nodes.Oid{ANYARRAYOID}, INT4OID, 0},
+	{"array_append", []nodes.Oid{ANYCOMPATIBLEARRAYOID, ANYCOMPATIBLEOID}, ANYCOMPATIBLEARRAYOID, 0},
+	{"array_prepend", []nodes.Oid{ANYCOMPATIBLEOID, ANYCOMPATIBLEARRAYOID}, ANYCOMPATIBLEARRAYOID, 0},
+	{"array_cat", []nodes.Oid{ANYCOMPATIBLEARRAYOID, ANYCOMPATIBLEARRAYOID}, ANYCOMPATIBLEARRAYOID, 0},
+	{"array_remove", []nodes.Oid{ANYCOMPATIBLEARRAYOID, ANYCOMPATIBLEOID}, ANYCOMPATIBLEARRAYOID, 0},
+	{"array_replace", []nodes.Oid{ANYCOMPATIBLEARRAYOID, ANYCOMPATIBLEOID, ANYCOMPATIBLEOID}, ANYCOMPATIBLEARRAYOID, 0},
+	{"array_position", []nodes.Oid{ANYCOMPATIBLEARRAYOID, ANYCOMPATIBLEOID}, INT4OID, 0},
+	{"array_position", []nodes.Oid{ANYCOMPATIBLEARRAYOID, ANYCOMPATIBLEOID, INT4OID}, INT4OID, 0},
+	{"array_positions", []nodes.Oid{ANYCOMPATIBLEARRAYOID, ANYCOMPATIBLEOID}, INT4ARRAYOID, 0},
+	{"array_fill", []nodes.Oid{ANYELEMENTOID, INT4ARRAYOID}, ANYARRAYOID, 0},
+	{"array_fill", []nodes.Oid{ANYELEMENTOID, INT4ARRAYOID, INT4ARRAYOID}, ANYARRAYOID, 0},
+	{"array_shuffle", []nodes.Oid{ANYARRAYOID}, ANYARRAYOID, 0},
+	{"array_sample", []nodes.Oid{ANYARRAYOID, INT4OID}, ANYARRAYOID, 0},
+	{"trim_array", []nodes.Oid{ANYARRAYOID, INT4OID}, ANYARRAYOID, 0},
+	{"array_to_string", []nodes.Oid{ANYARRAYOID, TEXTOID}, TEXTOID, 0},
+	{"array_to_string", []nodes.Oid{ANYARRAYOID, TEXTOID, TEXTOID}, TEXTOID, 0},
+	{"unnest", []nodes.Oid{ANYARRAYOID}, ANYELEMENTOID, procRetset},
+	{"generate_subscripts", []nodes.Oid{ANYARRAYOID, INT4OID}, INT4OID, procRetset},
+	{"generate_subscripts", []nodes.Oid{ANYARRAYOID, INT4OID, BOOLOID}, INT4OID, procRetset},
+
+	// Ranges.
+	{"lower", []nodes.Oid{ANYRANGEOID}, ANYELEMENTOID, 0},
+	{"upper", []nodes.Oid{ANYRANGEOID}, ANYELEMENTOID, 0},
+	{"isempty", []nodes.Oid{ANYRANGEOID}, BOOLOID, 0},
+	{"lower_inc", []nodes.Oid{ANYRANGEOID}, BOOLOID, 0},
+	{"upper_inc", []nodes.Oid{ANYRANGEOID}, BOOLOID, 0},
+	{"lower_inf", []nodes.Oid{ANYRANGEOID}, BOOLOID, 0},
+	{"upper_inf", []nodes.Oid{ANYRANGEOID}, BOOLOID, 0},
+	{"range_merge", []nodes.Oid{ANYRANGEOID, ANYRANGEOID}, ANYRANGEOID, 0},
+	{"int4range", []nodes.Oid{INT4OID, INT4OID}, INT4RANGEOID, 0},
+	{"int4range", []nodes.Oid{INT4OID, INT4OID, TEXTOID}, INT4RANGEOID, 0},
+	{"int8range", []nodes.Oid{INT8OID, INT8OID}, INT8RANGEOID, 0},
+	{"int8range", []nodes.Oid{INT8OID, INT8OID, TEXTOID}, INT8RANGEOID, 0},
+	{"numrange", []nodes.Oid{NUMERICOID, NUMERICOID}, NUMRANGEOID, 0},
+	{"numrange", []nodes.Oid{NUMERICOID, NUMERICOID, TEXTOID}, NUMRANGEOID, 0},
+	{"tsrange", []nodes.Oid{TIMESTAMPOID, TIMESTAMPOID}, TSRANGEOID, 0},
+	{"tsrange", []nodes.Oid{TIMESTAMPOID, TIMESTAMPOID, TEXTOID}, TSRANGEOID, 0},
+	{"tstzrange", []nodes.Oid{TIMESTAMPTZOID, TIMESTAMPTZOID}, TSTZRANGEOID, 0},
+	{"tstzrange", []nodes.Oid{TIMESTAMPTZOID, TIMESTAMPTZOID, TEXTOID}, TSTZRANGEOID, 0},
+	{"daterange", []nodes.Oid{DATEOID, DATEOID}, DATERANGEOID, 0},
+	{"daterange", []nodes.Oid{DATEOID, DATEOID, TEXTOID}, DATERANGEOID, 0},
+
+	// Enums.
+	{"enum_first", []nodes.Oid{ANYENUMOID}, ANYENUMOID, 0},
+	{"enum_last", []nodes.Oid{ANYENUMOID}, ANYENUMOID, 0},
+	{"enum_range", []nodes.Oid{ANYENUMOID}, ANYARRAYOID, 0},
+	{"enum_range", []nodes.Oid{ANYENUMOID, ANYENUMOID}, ANYARRAYOID, 0},
+
+	// Network addresses.
+	{"host", []nodes.Oid{INETOID}, TEXTOID, 0},
+	{"abbrev", []nodes.Oid{INETOID}, TEXTOID, 0},
+	{"abbrev", []nodes.Oid{CIDROID}, TEXTOID, 0},
+	{"broadcast", []nodes.Oid{INETOID}, INETOID, 0},
+	{"family", []nodes.Oid{INETOID}, INT4OID, 0},
+	{"masklen", []nodes.Oid{INETOID}, INT4OID, 0},
+	{"netmask", []nodes.Oid{INETOID}, INETOID, 0},
+	{"hostmask", []nodes.Oid{INETOID}, INETOID, 0},
+	{"network", []nodes.Oid{INETOID}, CIDROID, 0},
+	{"set_masklen", []nodes.Oid{INETOID, INT4OID}, INETOID, 0},
+	{"set_masklen", []nodes.Oid{CIDROID, INT4OID}, CIDROID, 0},
+	{"inet_same_family", []nodes.Oid{INETOID, INETOID}, BOOLOID, 0},
+	{"inet_merge", []nodes.Oid{INETOID, INETOID}, CIDROID, 0},
+
+	// Text search.
+	{"to_tsvector", []nodes.Oid{TEXTOID}, TSVECTOROID, 0},
+	{"to_tsvector", []nodes.Oid{REGCONFIGOID, TEXTOID}, TSVECTOROID, 0},
+	{"to_tsquery", []nodes.Oid{TEXTOID}, TSQUERYOID, 0},
+	{"to_tsquery", []nodes.Oid{REGCONFIGOID, TEXTOID}, TSQUERYOID, 0},
+	{"plainto_tsquery", []nodes.Oid{TEXTOID}, TSQUERYOID, 0},
+	{"plainto_tsquery", []nodes.Oid{REGCONFIGOID, TEXTOID}, TSQUERYOID, 0},
+	{"phraseto_tsquery", []nodes.Oid{TEXTOID}, TSQUERYOID, 0},
+	{"phraseto_tsquery", []nodes.Oid{REGCONFIGOID, TEXTOID}, TSQUERYOID, 0},
+	{"websearch_to_tsquery", []nodes.Oid{TEXTOID}, TSQUERYOID, 0},
+	{"websearch_to_tsquery", []nodes.Oid{REGCONFIGOID, TEXTOID}, TSQUERYOID, 0},
+	{"ts_rank", []nodes.Oid{TSVECTOROID, TSQUERYOID}, FLOAT4OID, 0},
+	{"ts_rank", []nodes.Oid{TSVECTOROID, TSQUERYOID, INT4OID}, FLOAT4OID, 0},
+	{"ts_rank_cd", []nodes.Oid{TSVECTOROID, TSQUERYOID}, FLOAT4OID, 0},
+	{"ts_rank_cd", []nodes.Oid{TSVECTOROID, TSQUERYOID, INT4OID}, FLOAT4OID, 0},
+	{"ts_headline", []nodes.Oid{TEXTOID, TSQUERYOID}, TEXTOID, 0},
+	{"ts_headline", []nodes.Oid{TEXTOID, TSQUERYOID, TEXTOID}, TEXTOID, 0},
+	{"ts_headline", []nodes.Oid{REGCONFIGOID, TEXTOID, TSQUERYOID}, TEXTOID, 0},
+	{"ts_headline", []nodes.Oid{REGCONFIGOID, TEXTOID, TSQUERYOID, TEXTOID}, TEXTOID, 0},
+	{"setweight", []nodes.Oid{TSVECTOROID, CHAROID}, TSVECTOROID, 0},
+	{"strip", []nodes.Oid{TSVECTOROID}, TSVECTOROID, 0},
+	{"numnode", []nodes.Oid{TSQUERYOID}, INT4OID, 0},
+	{"querytree", []nodes.Oid{TSQUERYOID}, TEXTOID, 0},
+	{"array_to_tsvector", []nodes.Oid{TEXTARRAYOID}, TSVECTOROID, 0},
+	{"tsvector_to_array", []nodes.Oid{TSVECTOROID}, TEXTARRAYOID, 0},
+
+	// XML.
+	{"xmlcomment", []nodes.Oid{TEXTOID}, XMLOID, 0},
+	{"xml_is_well_formed", []nodes.Oid{TEXTOID}, BOOLOID, 0},
+	{"xpath", []nodes.Oid{TEXTOID, XMLOID}, XMLARRAYOID, 0},
+	{"xpath", []nodes.Oid{TEXTOID, XMLOID, TEXTARRAYOID}, XMLARRAYOID, 0},
+	{"xpath_exists", []nodes.Oid{TEXTOID, XMLOID}, BOOLOID, 0},
+	{"xpath_exists", []nodes.Oid{TEXTOID, XMLOID, TEXTARRAYOID}, BOOLOID, 0},
+
+	// Sequences, locks and session information.
+	{"nextval", []nodes.Oid{REGCLASSOID}, INT8OID, 0},
+	{"currval", []nodes.Oid{REGCLASSOID}, INT8OID, 0},
+	{"setval", []nodes.Oid{REGCLASSOID, INT8OID}, INT8OID, 0},
+	{"setval", []nodes.Oid{REGCLASSOID, INT8OID, BOOLOID}, INT8OID, 0},
+	{"lastval", nil, INT8OID, 0},
+	{"pg_get_serial_sequence", []nodes.Oid{TEXTOID, TEXTOID}, TEXTOID, 0},
+	{"gen_random_uuid", nil, UUIDOID, 0},
+	{"pg_advisory_lock", []nodes.Oid{INT8OID}, VOIDOID, 0},
+	{"pg_advisory_lock", []nodes.Oid{INT4OID, INT4OID}, VOIDOID, 0},
+	{"pg_advisory_xact_lock", []nodes.Oid{INT8OID}, VOIDOID, 0},
+	{"pg_advisory_xact_lock", []nodes.Oid{INT4OID, INT4OID}, VOIDOID, 0},
+	{"pg_try_advisory_lock", []nodes.Oid{INT8OID}, BOOLOID, 0},
+	{"pg_try_advisory_lock", []nodes.Oid{INT4OID, INT4OID}, BOOLOID, 0},
+	{"pg_try_advisory_xact_lock", []nodes.Oid{INT8OID}, BOOLOID, 0},
+	{"pg_try_advisory_xact_lock", []nodes.Oid{INT4OID, INT4OID}, BOOLOID, 0},
+	{"pg_advisory_unlock", []nodes.Oid{INT8OID}, BOOLOID, 0},
+	{"pg_advisory_unlock", []nodes.Oid{INT4OID, INT4OID}, BOOLOID, 0},
+	{"pg_advisory_unlock_all", nil, VOIDOID, 0},
+	{"pg_notify", []nodes.Oid{TEXTOID, TEXTOID}, VOIDOID, 0},
+	{"current_database", nil, NAMEOID, 0},
+	{"current_schema", nil, NAMEOID, 0},
+	{"current_schemas", []nodes.Oid{BOOLOID}, NAMEARRAYOID, 0},
+	{"current_setting", []nodes.Oid{TEXTOID}, TEXTOID, 0},
+	{"current_setting", []nodes.Oid{TEXTOID, BOOLOID}, TEXTOID, 0},
+	{"set_config", []nodes.Oid{TEXTOID, TEXTOID, BOOLOID}, TEXTOID, 0},
+	{"version", nil, TEXTOID, 0},
+	{"pg_backend_pid", nil, INT4OID, 0},
+	{"pg_cancel_backend", []nodes.Oid{INT4OID}, BOOLOID, 0},
+	{"pg_postmaster_start_time", nil, TIMESTAMPTZOID, 0},
+	{"inet_client_addr", nil, INETOID, 0},
+	{"inet_server_addr", nil, INETOID, 0},
+	{"txid_current", nil, INT8OID, 0},
+	{"pg_current_xact_id", nil, XID8OID, 0},
+
+	// System catalog information.
+	{"pg_typeof", []nodes.Oid{ANYOID}, REGTYPEOID, 0},
+	{"pg_collation_for", []nodes.Oid{ANYOID}, TEXTOID, 0},
+	{"pg_column_size", []nodes.Oid{ANYOID}, INT4OID, 0},
+	{"num_nulls", []nodes.Oid{ANYOID}, INT4OID, procVariadic},
+	{"num_nonnulls", []nodes.Oid{ANYOID}, INT4OID, procVariadic},
+	{"pg_input_is_valid", []nodes.Oid{TEXTOID, TEXTOID}, BOOLOID, 0},
+	{"format_type", []nodes.Oid{OIDOID, INT4OID}, TEXTOID, 0},
+	{"to_regclass", []nodes.Oid{TEXTOID}, REGCLASSOID, 0},
+	{"to_regtype", []nodes.Oid{TEXTOID}, REGTYPEOID, 0},
+	{"to_regproc", []nodes.Oid{TEXTOID}, REGPROCOID, 0},
+	{"to_regnamespace", []nodes.Oid{TEXTOID}, REGNAMESPACEOID, 0},
+	{"to_regrole", []nodes.Oid{TEXTOID}, REGROLEOID, 0},
+	{"pg_get_viewdef", []nodes.Oid{OIDOID}, TEXTOID, 0},
+	{"pg_get_viewdef", []nodes.Oid{OIDOID, BOOLOID}, TEXTOID, 0},
+	{"pg_get_indexdef", []nodes.Oid{OIDOID}, TEXTOID, 0},
+	{"pg_get_constraintdef", []nodes.Oid{OIDOID}, TEXTOID, 0},
+	{"pg_get_functiondef", []nodes.Oid{OIDOID}, TEXTOID, 0},
+	{"pg_get_triggerdef", []nodes.Oid{OIDOID}, TEXTOID, 0},
+	{"pg_get_expr", []nodes.Oid{PG_NODE_TREEOID, OIDOID}, TEXTOID, 0},
+	{"obj_description", []nodes.Oid{OIDOID, NAMEOID}, TEXTOID, 0},
+	{"col_description", []nodes.Oid{OIDOID, INT4OID}, TEXTOID, 0},
+	{"has_table_privilege", []nodes.Oid{TEXTOID, TEXTOID}, BOOLOID, 0},
+	{"has_table_privilege", []nodes.Oid{NAMEOID, TEXTOID, TEXTOID}, BOOLOID, 0},
+	{"has_schema_privilege", []nodes.Oid{TEXTOID, TEXTOID}, BOOLOID, 0},
+	{"has_schema_privilege", []nodes.Oid{NAMEOID, TEXTOID, TEXTOID}, BOOLOID, 0},
+	{"pg_relation_size", []nodes.Oid{REGCLASSOID}, INT8OID, 0},
+	{"pg_table_size", []nodes.Oid{REGCLASSOID}, INT8OID, 0},
+	{"pg_indexes_size", []nodes.Oid{REGCLASSOID}, INT8OID, 0},
+	{"pg_total_relation_size", []nodes.Oid{REGCLASSOID}, INT8OID, 0},
+	{"pg_database_size", []nodes.Oid{NAMEOID}, INT8OID, 0},
+	{"pg_size_pretty", []nodes.Oid{INT8OID}, TEXTOID, 0},
+	{"pg_size_pretty", []nodes.Oid{NUMERICOID}, TEXTOID, 0},
+}
+
+// builtinNamedProcs lists the built-in functions whose callers may name
+// their arguments or leave out the trailing ones that have defaults, and
+// those returning a record with OUT parameters.
+var builtinNamedProcs = []*Function{
+	{
+		Name:        "make_interval",
+		ArgTypes:    []nodes.Oid{INT4OID, INT4OID, INT4OID, INT4OID, INT4OID, INT4OID, FLOAT8OID},
+		ArgNames:    []string{"years", "months", "weeks", "days", "hours", "mins", "secs"},
+		NumDefaults: 7,
+		ReturnType:  INTERVALOID,
+	},
+	{
+		Name:        "random_normal",
+		ArgTypes:    []nodes.Oid{FLOAT8OID, FLOAT8OID},
+		ArgNames:    []string{"mean", "stddev"},
+		NumDefaults: 2,
+		ReturnType:  FLOAT8OID,
+	},
+	{
+		Name:        "normalize",
+		ArgTypes:    []nodes.Oid{TEXTOID, TEXTOID},
+		NumDefaults: 1,
+		ReturnType:  TEXTOID,
+	},
+	{
+		Name:        "is_normalized",
+		ArgTypes:    []nodes.Oid{TEXTOID, TEXTOID},
+		NumDefaults: 1,
+		ReturnType:  BOOLOID,
+	},
+	{
+		Name:        "pg_terminate_backend",
+		ArgTypes:    []nodes.Oid{INT4OID, INT8OID},
+		ArgNames:    []string{"pid", "timeout"},
+		NumDefaults: 1,
+		ReturnType:  BOOLOID,
+	},
+	{
+		Name:        "jsonb_set",
+		ArgTypes:    []nodes.Oid{JSONBOID, TEXTARRAYOID, JSONBOID, BOOLOID},
+		ArgNames:    []string{"jsonb_in", "path", "replacement", "create_if_missing"},
+		NumDefaults: 1,
+		ReturnType:  JSONBOID,
+	},
+	{
+		Name:        "jsonb_set_lax",
+		ArgTypes:    []nodes.Oid{JSONBOID, TEXTARRAYOID, JSONBOID, BOOLOID, TEXTOID},
+		ArgNames:    []string{"jsonb_in", "path", "replacement", "create_if_missing", "null_value_treatment"},
+		NumDefaults: 2,
+		ReturnType:  JSONBOID,
+	},
+	{
+		Name:        "jsonb_insert",
+		ArgTypes:    []nodes.Oid{JSONBOID, TEXTARRAYOID, JSONBOID, BOOLOID},
+		ArgNames:    []string{"jsonb_in", "path", "replacement", "insert_after"},
+		NumDefaults: 1,
+		ReturnType:  JSONBOID,
+	},
+	{
+		Name:        "jsonb_path_exists",
+		ArgTypes:    []nodes.Oid{JSONBOID, JSONPATHOID, JSONBOID, BOOLOID},
+		ArgNames:    []string{"target", "path", "vars", "silent"},
+		NumDefaults: 2,
+		ReturnType:  BOOLOID,
+	},
+	{
+		Name:        "jsonb_path_match",
+		ArgTypes:    []nodes.Oid{JSONBOID, JSONPATHOID, JSONBOID, BOOLOID},
+		ArgNames:    []string{"target", "path", "vars", "silent"},
+		NumDefaults: 2,
+		ReturnType:  BOOLOID,
+	},
+	{
+		Name:        "jsonb_path_query",
+		ArgTypes:    []nodes.Oid{JSONBOID, JSONPATHOID, JSONBOID, BOOLOID},
+		ArgNames:    []string{"target", "path", "vars", "silent"},
+		NumDefaults: 2,
+		ReturnType:  JSONBOID,
+		ReturnsSet:  true,
+	},
+	{
+		Name:        "jsonb_path_query_array",
+		ArgTypes:    []nodes.Oid{JSONBOID, JSONPATHOID, JSONBOID, BOOLOID},
+		ArgNames:    []string{"target", "path", "vars", "silent"},
+		NumDefaults: 2,
+		ReturnType:  JSONBOID,
+	},
+	{
+		Name:        "jsonb_path_query_first",
+		ArgTypes:    []nodes.Oid{JSONBOID, JSONPATHOID, JSONBOID, BOOLOID},
+		ArgNames:    []string{"target", "path", "vars", "silent"},
+		NumDefaults: 2,
+		ReturnType:  JSONBOID,
+	},
+	{
+		Name:       "json_each",
+		ArgTypes:   []nodes.Oid{JSONOID},
+		ArgNames:   []string{"from_json"},
+		ReturnType: RECORDOID,
+		ReturnsSet: true,
+		OutColumns: []*Column{{Name: "key", Type: TEXTOID, Typmod: -1, Collation: DEFAULT_COLLATION_OID}, {Name: "value", Type: JSONOID, Typmod: -1}},
+	},
+	{
+		Name:       "json_each_text",
+		ArgTypes:   []nodes.Oid{JSONOID},
+		ArgNames:   []string{"from_json"},
+		ReturnType: RECORDOID,
+		ReturnsSet: true,
+		OutColumns: []*Column{{Name: "key", Type: TEXTOID, Typmod: -1, Collation: DEFAULT_COLLATION_OID}, {Name: "value", Type: TEXTOID, Typmod: -1, Collation: DEFAULT_COLLATION_OID}},
+	},
+	{
+		Name:       "jsonb_each",
+		ArgTypes:   []nodes.Oid{JSONBOID},
+		ArgNames:   []string{"from_json"},
+		ReturnType: RECORDOID,
+		ReturnsSet: true,
+		OutColumns: []*Column{{Name: "key", Type: TEXTOID, Typmod: -1, Collation: DEFAULT_COLLATION_OID}, {Name: "value", Type: JSONBOID, Typmod: -1}},
+	},
+	{
+		Name:       "jsonb_each_text",
+		ArgTypes:   []nodes.Oid{JSONBOID},
+		ArgNames:   []string{"from_json"},
+		ReturnType: RECORDOID,
+		ReturnsSet: true,
+		OutColumns: []*Column{{Name: "key", Type: TEXTOID, Typmod: -1, Collation: DEFAULT_COLLATION_OID}, {Name: "value", Type: TEXTOID, Typmod: -1, Collation: DEFAULT_COLLATION_OID}},
+	},
+}
+
+// builtinFunctions returns the built-in functions: the aggregate and
+// window functions of builtinFunctionTable, the scalar functions of
+// builtinProcTable and builtinNamedProcs.
+func builtinFunctions() []*Function {
+	var out []*Function
+	for _, b := range builtinFunctionTable {
+		f := &Function{Schema: "pg_catalog", Name: b.name, Kind: b.kind, ArgTypes: b.args, ReturnType: b.result}
+		switch {
+		case b.name == "count" && len(b.args) == 0:
+			f.Oid = F_COUNT_
+		case b.name == "count":
+			f.Oid = F_COUNT_ANY
+		case b.name == "row_number":
+			f.Oid = F_ROW_NUMBER
+		case b.name == "rank":
+			f.Oid = F_RANK_
+		case b.name == "dense_rank":
+			f.Oid = F_DENSE_RANK_
+		case b.name == "percent_rank":
+			f.Oid = F_PERCENT_RANK_
+		case b.name == "cume_dist":
+			f.Oid = F_CUME_DIST_
+		}
+		out = append(out, f)
+	}
+	for _, b := range builtinProcTable {
+		out = append(out, &Function{
+			Schema:     "pg_catalog",
+			Name:       b.name,
+			Kind:       'f',
+			ArgTypes:   b.args,
+			Variadic:   b.flags&procVariadic != 0,
+			ReturnType: b.result,
+			ReturnsSet: b.flags&procRetset != 0,
+		})
+	}
+	for _, f := range builtinNamedProcs {
+		fn := *f
+		fn.Schema, fn.Kind = "pg_catalog", 'f'
+		out = append(out, &fn)
+	}
+	return out
+}
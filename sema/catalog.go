@@ -0,0 +1,134 @@
+package sema
+
+import "github.com/pgplex/pgparser/nodes"
+
+// Catalog supplies the schema information parse analysis needs. It plays
+// the role of the system catalogs and syscache lookups in PostgreSQL's
+// parser. Lookups return nil when the object does not exist.
+//
+// Implementations are expected to include the built-in pg_catalog objects
+// as well as user-defined ones; FromCatalog builds one from a
+// *catalog.Catalog.
+type Catalog interface {
+	// LookupRelation finds a relation by optional schema and name,
+	// searching the search path when schema is "".
+	LookupRelation(schema, name string) *Relation
+
+	// RelationByOid returns the relation with the given OID.
+	RelationByOid(oid nodes.Oid) *Relation
+
+	// LookupType finds a type by optional schema and name. Array types
+	// are named with a leading underscore, as in pg_type.
+	LookupType(schema, name string) *Type
+
+	// TypeByOid returns the type with the given OID.
+	TypeByOid(oid nodes.Oid) *Type
+
+	// LookupFunctions returns the functions, aggregates and window
+	// functions with the given name that are candidates for a call:
+	// those in schema, or in the search path when schema is "".
+	LookupFunctions(schema, name string) []*Function
+
+	// LookupOperators returns the operators with the given name that are
+	// candidates for an expression.
+	LookupOperators(schema, name string) []*Operator
+
+	// LookupCast returns the pg_cast entry converting source to target.
+	// A cast from a type to itself is a length coercion function.
+	LookupCast(source, target nodes.Oid) *Cast
+
+	// LookupCollation returns the OID of the named collation, or
+	// InvalidOid.
+	LookupCollation(schema, name string) nodes.Oid
+}
+
+// Relation describes a table, view or other relation with columns
+// (pg_class).
+type Relation struct {
+	Oid        nodes.Oid
+	Schema     string
+	Name       string
+	Kind       byte      // relkind
+	RowType    nodes.Oid // pg_type OID of the relation's row type
+	Columns    []*Column
+	PrimaryKey []nodes.AttrNumber // primary key columns, if any
+}
+
+// Column returns the named column and its attribute number, or nil and 0.
+func (r *Relation) Column(name string) (*Column, nodes.AttrNumber) {
+	for i, c := range r.Columns {
+		if c.Name == name {
+			return c, nodes.AttrNumber(i + 1)
+		}
+	}
+	return nil, 0
+}
+
+// Column is a column of a relation (pg_attribute).
+type Column struct {
+	Name      string
+	Type      nodes.Oid
+	Typmod    int32
+	Collation nodes.Oid
+	NotNull   bool
+	Default   bool // column has a default expression
+	Identity  byte // ATTRIBUTE_IDENTITY_ALWAYS ('a'), BY DEFAULT ('d') or 0
+	Generated byte // ATTRIBUTE_GENERATED_STORED ('s') or 0
+}
+
+// Type describes a data type (pg_type).
+type Type struct {
+	Oid       nodes.Oid
+	Schema    string
+	Name      string    // typname
+	Kind      byte      // typtype: 'b', 'c', 'd', 'e', 'p', 'r' or 'm'
+	Category  byte      // typcategory
+	Preferred bool      // typispreferred
+	Len       int       // typlen
+	ByVal     bool      // typbyval
+	Elem      nodes.Oid // element type, for array types
+	Array     nodes.Oid // array type with this type as element, if any
+	Subtype   nodes.Oid // bound type, for range and multirange types
+	Relid     nodes.Oid // relation, for composite types
+	BaseType  nodes.Oid // base type, for domains
+	Typmod    int32     // typmod applied to the base type, for domains
+	Collation nodes.Oid // typcollation; InvalidOid if not collatable
+}
+
+// Function describes a function, aggregate or window function (pg_proc).
+type Function struct {
+	Oid         nodes.Oid
+	Schema      string
+	Name        string
+	Kind        byte        // prokind: 'f', 'a', 'w' or 'p'
+	ArgTypes    []nodes.Oid // input argument types
+	ArgNames    []string    // input argument names; "" for unnamed arguments
+	NumDefaults int         // number of trailing arguments with defaults
+	Variadic    bool        // last argument is VARIADIC
+	ReturnType  nodes.Oid
+	ReturnsSet  bool
+	// OutColumns are the result columns of a function with OUT or TABLE
+	// parameters; such a function returns record.
+	OutColumns []*Column
+}
+
+// Operator describes an operator (pg_operator). Left is InvalidOid for a
+// prefix operator.
+type Operator struct {
+	Oid    nodes.Oid
+	Schema string
+	Name   string
+	Left   nodes.Oid
+	Right  nodes.Oid
+	Result nodes.Oid
+	Func   nodes.Oid // underlying function
+}
+
+// Cast describes a cast between two types (pg_cast).
+type Cast struct {
+	Source  nodes.Oid
+	Target  nodes.Oid
+	Func    nodes.Oid // cast function, or InvalidOid
+	Context byte      // castcontext: 'i' implicit, 'a' assignment, 'e' explicit
+	Method  byte      // castmethod: 'f' function, 'i' I/O, 'b' binary
+}
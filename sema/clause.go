@@ -0,0 +1,796 @@
+package sema
+
+import (
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// transformWhereClause analyzes a WHERE, HAVING or similar condition,
+// which must be boolean (transformWhereClause).
+func (ps *pstate) transformWhereClause(n nodes.Node, kind exprKind, construct string) (nodes.Node, error) {
+	if n == nil {
+		return nil, nil
+	}
+	e, err := ps.transformExpr(n, kind)
+	if err != nil {
+		return nil, err
+	}
+	return ps.coerceToBoolean(e, construct)
+}
+
+// transformLimitClause analyzes a LIMIT or OFFSET expression, which is
+// converted to bigint and may not refer to columns (transformLimitClause).
+func (ps *pstate) transformLimitClause(n nodes.Node, kind exprKind, construct string, opt nodes.LimitOption) (nodes.Node, error) {
+	if n == nil {
+		return nil, nil
+	}
+	e, err := ps.transformExpr(n, kind)
+	if err != nil {
+		return nil, err
+	}
+	if e, err = ps.coerceToSpecificType(e, INT8OID, construct); err != nil {
+		return nil, err
+	}
+	if err := checkExprIsVarFree(e, construct); err != nil {
+		return nil, err
+	}
+	if c, ok := e.(*nodes.Const); ok && c.Constisnull && kind == exprKindLimit && opt == nodes.LIMIT_OPTION_WITH_TIES {
+		return nil, errorf(CodeInvalidParameterValue, exprLocation(n), "row count cannot be null in FETCH FIRST ... WITH TIES clause")
+	}
+	return e, nil
+}
+
+// checkExprIsVarFree rejects column references of this query level in
+// an expression that must be evaluated once per query.
+func checkExprIsVarFree(e nodes.Node, construct string) error {
+	var err error
+	walkLevels(e, 0, func(n nodes.Node, depth int) bool {
+		if v, ok := n.(*nodes.Var); ok && int(v.Varlevelsup) == depth {
+			err = errorf(CodeInvalidColumnReference, v.Location, "argument of %s must not contain variables", construct)
+		}
+		return err == nil
+	})
+	return err
+}
+
+// transformSortClause analyzes an ORDER BY list, adding junk entries to
+// the target list for sort expressions that are not output columns
+// (transformSortClause). With useSQL99, names and numbers are not looked
+// up among the output columns first.
+func (ps *pstate) transformSortClause(l *nodes.List, tlist *[]*nodes.TargetEntry, kind exprKind, useSQL99 bool) (*nodes.List, error) {
+	var sortlist []*nodes.SortGroupClause
+	for _, item := range items(l) {
+		sb := item.(*nodes.SortBy)
+		var tle *nodes.TargetEntry
+		var err error
+		if useSQL99 {
+			tle, err = ps.findTargetlistEntrySQL99(sb.Node, tlist, kind)
+		} else {
+			tle, err = ps.findTargetlistEntrySQL92(sb.Node, tlist, kind)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if sortlist, err = ps.addTargetToSortList(tle, sortlist, *tlist, sb); err != nil {
+			return nil, err
+		}
+	}
+	return sortGroupList(sortlist), nil
+}
+
+// findTargetlistEntrySQL92 finds the output column an ORDER BY, GROUP BY
+// or DISTINCT ON item denotes under the SQL92 rules: a bare name may be
+// an output column name and an integer an output column number. Other
+// items are handled by findTargetlistEntrySQL99.
+func (ps *pstate) findTargetlistEntrySQL92(n nodes.Node, tlist *[]*nodes.TargetEntry, kind exprKind) (*nodes.TargetEntry, error) {
+	clause := kind.String()
+	if cr, ok := n.(*nodes.ColumnRef); ok && cr.Fields.Len() == 1 {
+		if s, ok := cr.Fields.Items[0].(*nodes.String); ok {
+			name := s.Sval
+			if kind == exprKindGroupBy {
+				// In GROUP BY an input column takes precedence over an
+				// output column of the same name.
+				if v, _ := ps.colNameToVar(name, cr.Location); v != nil {
+					name = ""
+				}
+			}
+			if name != "" {
+				var target *nodes.TargetEntry
+				for _, tle := range *tlist {
+					if tle.Resjunk || tle.Resname != name {
+						continue
+					}
+					if target != nil && !equalExprs(target.Expr, tle.Expr) {
+						return nil, errorf(CodeAmbiguousColumn, cr.Location, "%s %q is ambiguous", clause, name)
+					}
+					target = tle
+				}
+				if target != nil {
+					return target, nil
+				}
+			}
+		}
+	}
+	if c, ok := n.(*nodes.A_Const); ok {
+		i, ok := c.Val.(*nodes.Integer)
+		if !ok || c.Isnull {
+			return nil, errorf(CodeSyntaxError, c.Location, "non-integer constant in %s", clause)
+		}
+		pos := 0
+		for _, tle := range *tlist {
+			if tle.Resjunk {
+				continue
+			}
+			pos++
+			if pos == int(i.Ival) {
+				return tle, nil
+			}
+		}
+		return nil, errorf(CodeInvalidColumnReference, c.Location, "%s position %d is not in select list", clause, i.Ival)
+	}
+	return ps.findTargetlistEntrySQL99(n, tlist, kind)
+}
+
+// findTargetlistEntrySQL99 finds the target list entry whose expression
+// matches n, appending a junk entry if there is none.
+func (ps *pstate) findTargetlistEntrySQL99(n nodes.Node, tlist *[]*nodes.TargetEntry, kind exprKind) (*nodes.TargetEntry, error) {
+	e, err := ps.transformExpr(n, kind)
+	if err != nil {
+		return nil, err
+	}
+	for _, tle := range *tlist {
+		if equalExprs(e, stripImplicitCoercions(tle.Expr)) {
+			return tle, nil
+		}
+	}
+	tle := &nodes.TargetEntry{Expr: e, Resno: nodes.AttrNumber(len(*tlist) + 1), Resjunk: true}
+	*tlist = append(*tlist, tle)
+	return tle, nil
+}
+
+// stripImplicitCoercions removes implicit casts from the top of an
+// expression.
+func stripImplicitCoercions(n nodes.Node) nodes.Node {
+	for {
+		switch e := n.(type) {
+		case *nodes.FuncExpr:
+			if e.Funcformat != nodes.COERCE_IMPLICIT_CAST || e.Args.Len() == 0 {
+				return n
+			}
+			n = e.Args.Items[0]
+		case *nodes.RelabelType:
+			if e.Relabelformat != nodes.COERCE_IMPLICIT_CAST {
+				return n
+			}
+			n = e.Arg
+		case *nodes.CoerceViaIO:
+			if e.Coerceformat != nodes.COERCE_IMPLICIT_CAST {
+				return n
+			}
+			n = e.Arg
+		case *nodes.ArrayCoerceExpr:
+			if e.Coerceformat != nodes.COERCE_IMPLICIT_CAST {
+				return n
+			}
+			n = e.Arg
+		case *nodes.CoerceToDomain:
+			if e.Coercionformat != nodes.COERCE_IMPLICIT_CAST {
+				return n
+			}
+			n = e.Arg
+		default:
+			return n
+		}
+	}
+}
+
+// addTargetToSortList appends a sort item for tle unless tle is already
+// sorted on (addTargetToSortList).
+func (ps *pstate) addTargetToSortList(tle *nodes.TargetEntry, sortlist []*nodes.SortGroupClause, tlist []*nodes.TargetEntry, sb *nodes.SortBy) ([]*nodes.SortGroupClause, error) {
+	restype, err := ps.resolveSortTargetType(tle)
+	if err != nil {
+		return nil, err
+	}
+	reverse := false
+	var eq, sortop nodes.Oid
+	switch sb.SortbyDir {
+	case nodes.SORTBY_DESC:
+		reverse = true
+		if err := ps.checkSortable(restype, exprLocation(tle.Expr)); err != nil {
+			return nil, err
+		}
+		eq, _ = ps.sortOperators(restype)
+		if op := ps.lookupSortOperator(">", restype); op != nil {
+			sortop = op.Oid
+		}
+	case nodes.SORTBY_USING:
+		name := stringList(sb.UseOp)
+		_, opname := qualifiedName(name)
+		schema, _ := qualifiedName(name)
+		if cands := ps.cat.LookupOperators(schema, opname); len(cands) > 0 && restype != nodes.InvalidOid {
+			op, err := ps.operSelect(name, cands, false, []nodes.Oid{restype, restype}, sb.Location)
+			if err != nil {
+				return nil, err
+			}
+			if op.Result != BOOLOID || !strings.ContainsAny(opname, "<>") {
+				return nil, errorf(CodeWrongObjectType, sb.Location, "operator %s is not a valid ordering operator", opname).
+					withHint("Ordering operators must be \"<\" or \">\" members of btree operator families.")
+			}
+			sortop = op.Oid
+		}
+		reverse = strings.Contains(opname, ">")
+		eq, _ = ps.sortOperators(restype)
+	default:
+		if err := ps.checkSortable(restype, exprLocation(tle.Expr)); err != nil {
+			return nil, err
+		}
+		eq, sortop = ps.sortOperators(restype)
+	}
+	nullsFirst := reverse
+	switch sb.SortbyNulls {
+	case nodes.SORTBY_NULLS_FIRST:
+		nullsFirst = true
+	case nodes.SORTBY_NULLS_LAST:
+		nullsFirst = false
+	}
+	if tle.Ressortgroupref != 0 && findSortGroupClause(sortlist, tle.Ressortgroupref) != nil {
+		// Sorting on the same column again has no effect.
+		return sortlist, nil
+	}
+	return append(sortlist, &nodes.SortGroupClause{
+		TleSortGroupRef: assignSortGroupRef(tle, tlist),
+		Eqop:            eq,
+		Sortop:          sortop,
+		NullsFirst:      nullsFirst,
+		Hashable:        ps.lookupSortOperator("=", restype) != nil,
+	}), nil
+}
+
+// addTargetToGroupList appends a grouping item for tle with the default
+// ordering unless tle is already in the list (addTargetToGroupList).
+func (ps *pstate) addTargetToGroupList(tle *nodes.TargetEntry, grouplist []*nodes.SortGroupClause, tlist []*nodes.TargetEntry) ([]*nodes.SortGroupClause, error) {
+	restype, err := ps.resolveSortTargetType(tle)
+	if err != nil {
+		return nil, err
+	}
+	if tle.Ressortgroupref != 0 && findSortGroupClause(grouplist, tle.Ressortgroupref) != nil {
+		return grouplist, nil
+	}
+	if err := ps.checkGroupable(restype, exprLocation(tle.Expr)); err != nil {
+		return nil, err
+	}
+	eq, sortop := ps.sortOperators(restype)
+	return append(grouplist, &nodes.SortGroupClause{
+		TleSortGroupRef: assignSortGroupRef(tle, tlist),
+		Eqop:            eq,
+		Sortop:          sortop,
+		Hashable:        ps.lookupSortOperator("=", restype) != nil,
+	}), nil
+}
+
+// resolveSortTargetType returns the type a sort or group item compares
+// by, first converting an unknown-type literal to text.
+func (ps *pstate) resolveSortTargetType(tle *nodes.TargetEntry) (nodes.Oid, error) {
+	restype := ExprType(tle.Expr)
+	if restype == UNKNOWNOID {
+		e, err := ps.coerceType(tle.Expr, UNKNOWNOID, TEXTOID, -1, nodes.COERCION_IMPLICIT, nodes.COERCE_IMPLICIT_CAST, -1)
+		if err != nil {
+			return nodes.InvalidOid, err
+		}
+		tle.Expr = e
+		restype = TEXTOID
+	}
+	return restype, nil
+}
+
+// checkSortable reports an error if a built-in type has no ordering
+// operator. Types the catalog does not describe fully are assumed to be
+// sortable.
+func (ps *pstate) checkSortable(typ nodes.Oid, loc nodes.ParseLoc) error {
+	if !isBuiltinOid(typ) || ps.lookupSortOperator("<", typ) != nil {
+		return nil
+	}
+	return errorf(CodeUndefinedFunction, loc, "could not identify an ordering operator for type %s", FormatType(ps.cat, typ, -1)).
+		withHint("Use an explicit ordering operator or modify the query.")
+}
+
+// checkGroupable reports an error if a built-in type has no equality
+// operator.
+func (ps *pstate) checkGroupable(typ nodes.Oid, loc nodes.ParseLoc) error {
+	if !isBuiltinOid(typ) || ps.lookupSortOperator("=", typ) != nil {
+		return nil
+	}
+	return errorf(CodeUndefinedFunction, loc, "could not identify an equality operator for type %s", FormatType(ps.cat, typ, -1))
+}
+
+// isBuiltinOid reports whether an OID belongs to a built-in object, one
+// assigned below FirstNormalObjectId. InvalidOid and the pseudo-type
+// unknown are not considered built-in.
+func isBuiltinOid(oid nodes.Oid) bool {
+	return oid != nodes.InvalidOid && oid != UNKNOWNOID && oid < 16384
+}
+
+// assignSortGroupRef gives tle a sort/group reference number if it does
+// not have one yet (assignSortGroupRef).
+func assignSortGroupRef(tle *nodes.TargetEntry, tlist []*nodes.TargetEntry) uint32 {
+	if tle.Ressortgroupref != 0 {
+		return tle.Ressortgroupref
+	}
+	var max uint32
+	for _, t := range tlist {
+		if t.Ressortgroupref > max {
+			max = t.Ressortgroupref
+		}
+	}
+	tle.Ressortgroupref = max + 1
+	return tle.Ressortgroupref
+}
+
+// findSortGroupClause returns the item of a sort or group list with the
+// given reference, or nil.
+func findSortGroupClause(l []*nodes.SortGroupClause, ref uint32) *nodes.SortGroupClause {
+	for _, sgc := range l {
+		if sgc.TleSortGroupRef == ref {
+			return sgc
+		}
+	}
+	return nil
+}
+
+// sortGroupClauses returns the items of a SortGroupClause list.
+func sortGroupClauses(l *nodes.List) []*nodes.SortGroupClause {
+	var out []*nodes.SortGroupClause
+	for _, item := range items(l) {
+		out = append(out, item.(*nodes.SortGroupClause))
+	}
+	return out
+}
+
+func sortGroupList(l []*nodes.SortGroupClause) *nodes.List {
+	if len(l) == 0 {
+		return nil
+	}
+	out := &nodes.List{}
+	for _, sgc := range l {
+		out.Items = append(out.Items, sgc)
+	}
+	return out
+}
+
+// targetEntryByRef returns the target list entry with the given sort/group
+// reference.
+func targetEntryByRef(tlist []*nodes.TargetEntry, ref uint32) *nodes.TargetEntry {
+	for _, tle := range tlist {
+		if tle.Ressortgroupref == ref {
+			return tle
+		}
+	}
+	return nil
+}
+
+// transformGroupClause analyzes a GROUP BY list (transformGroupClause). It
+// returns the flat list of grouping items and, if the clause has grouping
+// sets, the sets with their contents given as sort/group references.
+// Grouping items that also appear in ORDER BY take their ordering from
+// there.
+func (ps *pstate) transformGroupClause(l *nodes.List, tlist *[]*nodes.TargetEntry, sortClause *nodes.List, kind exprKind, useSQL99 bool) (*nodes.List, *nodes.List, error) {
+	var result []*nodes.SortGroupClause
+	hasSets := false
+	for _, item := range items(l) {
+		if _, ok := item.(*nodes.GroupingSet); ok {
+			hasSets = true
+		}
+	}
+	var gsets []nodes.Node
+	var common []nodes.Node
+	for _, item := range items(l) {
+		if gs, ok := item.(*nodes.GroupingSet); ok {
+			set, err := ps.transformGroupingSet(gs, tlist, sortClause, &result, kind, useSQL99)
+			if err != nil {
+				return nil, nil, err
+			}
+			gsets = append(gsets, set)
+			continue
+		}
+		ref, err := ps.transformGroupClauseExpr(item, tlist, sortClause, &result, kind, useSQL99)
+		if err != nil {
+			return nil, nil, err
+		}
+		common = append(common, &nodes.Integer{Ival: int64(ref)})
+	}
+	if !hasSets {
+		return sortGroupList(result), nil, nil
+	}
+	if len(common) > 0 {
+		gsets = append([]nodes.Node{&nodes.GroupingSet{Kind: nodes.GROUPING_SET_SIMPLE, Content: nodeList(common), Location: -1}}, gsets...)
+	}
+	return sortGroupList(result), nodeList(gsets), nil
+}
+
+// transformGroupingSet analyzes one grouping set, adding its expressions
+// to the grouping items.
+func (ps *pstate) transformGroupingSet(gs *nodes.GroupingSet, tlist *[]*nodes.TargetEntry, sortClause *nodes.List, result *[]*nodes.SortGroupClause, kind exprKind, useSQL99 bool) (*nodes.GroupingSet, error) {
+	out := &nodes.GroupingSet{Kind: gs.Kind, Location: gs.Location}
+	var content []nodes.Node
+	for _, item := range items(gs.Content) {
+		if sub, ok := item.(*nodes.GroupingSet); ok {
+			set, err := ps.transformGroupingSet(sub, tlist, sortClause, result, kind, useSQL99)
+			if err != nil {
+				return nil, err
+			}
+			content = append(content, set)
+			continue
+		}
+		ref, err := ps.transformGroupClauseExpr(item, tlist, sortClause, result, kind, useSQL99)
+		if err != nil {
+			return nil, err
+		}
+		content = append(content, &nodes.Integer{Ival: int64(ref)})
+	}
+	out.Content = nodeList(content)
+	return out, nil
+}
+
+// transformGroupClauseExpr adds one GROUP BY expression to the grouping
+// items and returns its sort/group reference
+// (transformGroupClauseExpr).
+func (ps *pstate) transformGroupClauseExpr(n nodes.Node, tlist *[]*nodes.TargetEntry, sortClause *nodes.List, result *[]*nodes.SortGroupClause, kind exprKind, useSQL99 bool) (uint32, error) {
+	var tle *nodes.TargetEntry
+	var err error
+	if useSQL99 {
+		tle, err = ps.findTargetlistEntrySQL99(n, tlist, kind)
+	} else {
+		tle, err = ps.findTargetlistEntrySQL92(n, tlist, kind)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if tle.Ressortgroupref != 0 {
+		if findSortGroupClause(*result, tle.Ressortgroupref) != nil {
+			return tle.Ressortgroupref, nil
+		}
+		if sc := findSortGroupClause(sortGroupClauses(sortClause), tle.Ressortgroupref); sc != nil {
+			// Group in the order ORDER BY asks for.
+			c := *sc
+			*result = append(*result, &c)
+			return tle.Ressortgroupref, nil
+		}
+	}
+	if *result, err = ps.addTargetToGroupList(tle, *result, *tlist); err != nil {
+		return 0, err
+	}
+	return tle.Ressortgroupref, nil
+}
+
+// transformDistinctClause builds the DISTINCT list from the output columns,
+// following the ORDER BY items first (transformDistinctClause). isAgg is
+// set for the DISTINCT of an aggregate call.
+func (ps *pstate) transformDistinctClause(tlist *[]*nodes.TargetEntry, sortClause *nodes.List, isAgg bool) (*nodes.List, error) {
+	var result []*nodes.SortGroupClause
+	for _, sc := range sortGroupClauses(sortClause) {
+		tle := targetEntryByRef(*tlist, sc.TleSortGroupRef)
+		if tle.Resjunk {
+			msg := "for SELECT DISTINCT, ORDER BY expressions must appear in select list"
+			if isAgg {
+				msg = "in an aggregate with DISTINCT, ORDER BY expressions must appear in argument list"
+			}
+			return nil, errorf(CodeInvalidColumnReference, exprLocation(tle.Expr), "%s", msg)
+		}
+		if err := ps.checkGroupable(ExprType(tle.Expr), exprLocation(tle.Expr)); err != nil {
+			return nil, err
+		}
+		c := *sc
+		result = append(result, &c)
+	}
+	var err error
+	for _, tle := range *tlist {
+		if tle.Resjunk {
+			continue
+		}
+		if result, err = ps.addTargetToGroupList(tle, result, *tlist); err != nil {
+			return nil, err
+		}
+	}
+	return sortGroupList(result), nil
+}
+
+// transformDistinctOnClause builds the DISTINCT list for DISTINCT ON,
+// which must agree with the leading ORDER BY items
+// (transformDistinctOnClause).
+func (ps *pstate) transformDistinctOnClause(l *nodes.List, tlist *[]*nodes.TargetEntry, sortClause *nodes.List) (*nodes.List, error) {
+	var refs []uint32
+	var locs []nodes.ParseLoc
+	for _, item := range items(l) {
+		tle, err := ps.findTargetlistEntrySQL92(item, tlist, exprKindDistinctOn)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, assignSortGroupRef(tle, *tlist))
+		locs = append(locs, exprLocation(item))
+	}
+	inRefs := func(ref uint32) bool {
+		for _, r := range refs {
+			if r == ref {
+				return true
+			}
+		}
+		return false
+	}
+	var result []*nodes.SortGroupClause
+	skipped := false
+	for _, sc := range sortGroupClauses(sortClause) {
+		if inRefs(sc.TleSortGroupRef) {
+			if skipped {
+				return nil, errorf(CodeInvalidColumnReference, locs[0], "SELECT DISTINCT ON expressions must match initial ORDER BY expressions")
+			}
+			c := *sc
+			result = append(result, &c)
+		} else {
+			skipped = true
+		}
+	}
+	for i, ref := range refs {
+		if findSortGroupClause(result, ref) != nil {
+			continue
+		}
+		if skipped {
+			return nil, errorf(CodeInvalidColumnReference, locs[i], "SELECT DISTINCT ON expressions must match initial ORDER BY expressions")
+		}
+		var err error
+		if result, err = ps.addTargetToGroupList(targetEntryByRef(*tlist, ref), result, *tlist); err != nil {
+			return nil, err
+		}
+	}
+	return sortGroupList(result), nil
+}
+
+// transformWindowDefinitions analyzes the windows of the WINDOW clause
+// and the OVER clauses, numbering them in order
+// (transformWindowDefinitions).
+func (ps *pstate) transformWindowDefinitions(tlist *[]*nodes.TargetEntry) (*nodes.List, error) {
+	var result []*nodes.WindowClause
+	for i, wd := range ps.windowDefs {
+		if wd.Name != "" {
+			for _, prev := range result {
+				if prev.Name == wd.Name {
+					return nil, errorf(CodeWindowingError, wd.Location, "window %q is already defined", wd.Name)
+				}
+			}
+		}
+		var ref *nodes.WindowClause
+		if wd.Refname != "" {
+			for _, prev := range result {
+				if prev.Name == wd.Refname {
+					ref = prev
+				}
+			}
+			if ref == nil {
+				return nil, errorf(CodeUndefinedObject, wd.Location, "window %q does not exist", wd.Refname)
+			}
+		}
+		wc := &nodes.WindowClause{Name: wd.Name, Refname: wd.Refname, Winref: uint32(i + 1)}
+
+		var partition []*nodes.SortGroupClause
+		for _, item := range items(wd.PartitionClause) {
+			tle, err := ps.findTargetlistEntrySQL99(item, tlist, exprKindWindowPartition)
+			if err != nil {
+				return nil, err
+			}
+			if partition, err = ps.addTargetToGroupList(tle, partition, *tlist); err != nil {
+				return nil, err
+			}
+		}
+		order, err := ps.transformSortClause(wd.OrderClause, tlist, exprKindWindowOrder, true)
+		if err != nil {
+			return nil, err
+		}
+		if ref != nil {
+			if partition != nil {
+				return nil, errorf(CodeWindowingError, wd.Location, "cannot override PARTITION BY clause of window %q", wd.Refname)
+			}
+			wc.PartitionClause = ref.PartitionClause
+			if order != nil && ref.OrderClause != nil {
+				return nil, errorf(CodeWindowingError, wd.Location, "cannot override ORDER BY clause of window %q", wd.Refname)
+			}
+			if order == nil && ref.OrderClause != nil {
+				order = ref.OrderClause
+				wc.CopiedOrder = true
+			}
+			if ref.FrameOptions&nodes.FRAMEOPTION_NONDEFAULT != 0 {
+				hint := ""
+				if wd.Name == "" && wd.PartitionClause == nil && wd.OrderClause == nil && wd.FrameOptions&nodes.FRAMEOPTION_NONDEFAULT == 0 {
+					hint = "Omit the parentheses in this OVER clause."
+				}
+				e := errorf(CodeWindowingError, wd.Location, "cannot copy window %q because it has a frame clause", wd.Refname)
+				if hint != "" {
+					e = e.withHint("%s", hint)
+				}
+				return nil, e
+			}
+		} else {
+			wc.PartitionClause = sortGroupList(partition)
+		}
+		wc.OrderClause = order
+		wc.FrameOptions = wd.FrameOptions
+		if err := ps.transformFrameOffsets(wc, wd); err != nil {
+			return nil, err
+		}
+		result = append(result, wc)
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	out := &nodes.List{}
+	for _, wc := range result {
+		out.Items = append(out.Items, wc)
+	}
+	return out, nil
+}
+
+// transformFrameOffsets analyzes the offsets of a ROWS, RANGE or GROUPS
+// frame (transformFrameOffset).
+func (ps *pstate) transformFrameOffsets(wc *nodes.WindowClause, wd *nodes.WindowDef) error {
+	opts := wd.FrameOptions
+	if opts&nodes.FRAMEOPTION_GROUPS != 0 && wc.OrderClause == nil {
+		return errorf(CodeWindowingError, wd.Location, "GROUPS mode requires an ORDER BY clause")
+	}
+	transform := func(n nodes.Node) (nodes.Node, error) {
+		if n == nil {
+			return nil, nil
+		}
+		switch {
+		case opts&nodes.FRAMEOPTION_ROWS != 0:
+			return ps.transformFrameOffset(n, exprKindWindowFrameRows, "ROWS")
+		case opts&nodes.FRAMEOPTION_GROUPS != 0:
+			return ps.transformFrameOffset(n, exprKindWindowFrameGroups, "GROUPS")
+		}
+		if wc.OrderClause.Len() != 1 {
+			return nil, errorf(CodeWindowingError, exprLocation(n), "RANGE with offset PRECEDING/FOLLOWING requires exactly one ORDER BY column")
+		}
+		e, err := ps.transformExpr(n, exprKindWindowFrameRange)
+		if err != nil {
+			return nil, err
+		}
+		return e, checkExprIsVarFree(e, "RANGE")
+	}
+	var err error
+	if wc.StartOffset, err = transform(wd.StartOffset); err != nil {
+		return err
+	}
+	wc.EndOffset, err = transform(wd.EndOffset)
+	return err
+}
+
+// transformFrameOffset analyzes a ROWS or GROUPS offset, a bigint that may
+// not refer to columns.
+func (ps *pstate) transformFrameOffset(n nodes.Node, kind exprKind, construct string) (nodes.Node, error) {
+	e, err := ps.transformExpr(n, kind)
+	if err != nil {
+		return nil, err
+	}
+	if e, err = ps.coerceToSpecificType(e, INT8OID, construct); err != nil {
+		return nil, err
+	}
+	return e, checkExprIsVarFree(e, construct)
+}
+
+// transformLockingClause applies FOR UPDATE/SHARE clauses to the
+// relations they name, or to all relations in FROM
+// (transformLockingClause).
+func (ps *pstate) transformLockingClause(q *nodes.Query, l *nodes.List) error {
+	for _, item := range items(l) {
+		lc := item.(*nodes.LockingClause)
+		if err := checkSelectLocking(q, lc); err != nil {
+			return err
+		}
+		strength := nodes.LockClauseStrength(lc.Strength)
+		wait := nodes.LockWaitPolicy(lc.WaitPolicy)
+		if lc.LockedRels == nil {
+			for i, rte := range ps.rtable {
+				if rte.Rtekind == nodes.RTE_RELATION && rte.InFromCl {
+					applyLockingClause(q, rte, i+1, strength, wait)
+				}
+			}
+			continue
+		}
+		for _, r := range items(lc.LockedRels) {
+			rv := r.(*nodes.RangeVar)
+			if rv.Schemaname != "" {
+				return errorf(CodeSyntaxError, rv.Location, "FOR %s must specify unqualified relation names", lockingStrength(lc))
+			}
+			found := false
+			for i, rte := range ps.rtable {
+				if !rte.InFromCl || rte.Eref.Aliasname != rv.Relname {
+					continue
+				}
+				found = true
+				what := ""
+				switch rte.Rtekind {
+				case nodes.RTE_RELATION:
+					applyLockingClause(q, rte, i+1, strength, wait)
+				case nodes.RTE_SUBQUERY:
+					// The lock applies to the subquery's own relations;
+					// record the subquery as the locked entry.
+					applyLockingClause(q, rte, i+1, strength, wait)
+				case nodes.RTE_JOIN:
+					what = "a join"
+				case nodes.RTE_FUNCTION:
+					what = "a function"
+				case nodes.RTE_TABLEFUNC:
+					what = "a table function"
+				case nodes.RTE_VALUES:
+					what = "VALUES"
+				case nodes.RTE_CTE:
+					what = "a WITH query"
+				default:
+					what = "this kind of relation"
+				}
+				if what != "" {
+					return errorf(CodeFeatureNotSupported, rv.Location, "FOR %s cannot be applied to %s", lockingStrength(lc), what)
+				}
+			}
+			if !found {
+				return errorf(CodeUndefinedTable, rv.Location, "relation %q in FOR %s clause not found in FROM clause", rv.Relname, lockingStrength(lc))
+			}
+		}
+	}
+	return nil
+}
+
+// checkSelectLocking rejects FOR UPDATE/SHARE in queries whose rows do
+// not correspond to table rows (CheckSelectLocking).
+func checkSelectLocking(q *nodes.Query, lc *nodes.LockingClause) error {
+	var what string
+	switch {
+	case q.SetOperations != nil:
+		what = "UNION/INTERSECT/EXCEPT"
+	case q.DistinctClause != nil:
+		what = "DISTINCT clause"
+	case q.GroupClause != nil || q.GroupingSets != nil:
+		what = "GROUP BY clause"
+	case q.HavingQual != nil:
+		what = "HAVING clause"
+	case q.HasAggs:
+		what = "aggregate functions"
+	case q.HasWindowFuncs:
+		what = "window functions"
+	case q.HasTargetSRFs:
+		what = "set-returning functions in the target list"
+	default:
+		return nil
+	}
+	return errorf(CodeFeatureNotSupported, -1, "FOR %s is not allowed with %s", lockingStrength(lc), what)
+}
+
+// applyLockingClause records a row lock on range table entry rti,
+// combining it with any lock already requested (applyLockingClause).
+func applyLockingClause(q *nodes.Query, rte *nodes.RangeTblEntry, rti int, strength nodes.LockClauseStrength, wait nodes.LockWaitPolicy) {
+	q.HasForUpdate = true
+	if rte.Rtekind == nodes.RTE_RELATION {
+		rte.Rellockmode = nodes.RowShareLock
+	}
+	for _, item := range items(q.RowMarks) {
+		rc := item.(*nodes.RowMarkClause)
+		if rc.Rti == uint32(rti) {
+			rc.Strength = max(rc.Strength, strength)
+			rc.WaitPolicy = max(rc.WaitPolicy, wait)
+			return
+		}
+	}
+	q.RowMarks = appendNode(q.RowMarks, &nodes.RowMarkClause{Rti: uint32(rti), Strength: strength, WaitPolicy: wait})
+}
+
+// lockingStrength returns the SQL spelling of a locking clause's
+// strength, as in "FOR NO KEY UPDATE".
+func lockingStrength(lc *nodes.LockingClause) string {
+	switch nodes.LockClauseStrength(lc.Strength) {
+	case nodes.LCS_FORKEYSHARE:
+		return "KEY SHARE"
+	case nodes.LCS_FORSHARE:
+		return "SHARE"
+	case nodes.LCS_FORNOKEYUPDATE:
+		return "NO KEY UPDATE"
+	}
+	return "UPDATE"
+}
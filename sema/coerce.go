@@ -0,0 +1,758 @@
+package sema
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// coercionPath is the way one type is converted to another
+// (CoercionPathType).
+type coercionPath int
+
+const (
+	coercionPathNone        coercionPath = iota // failed to find any coercion pathway
+	coercionPathFunc                            // apply the cast function
+	coercionPathRelabel                         // binary-compatible cast, no function
+	coercionPathArrayCoerce                     // convert each element of an array
+	coercionPathCoerceViaIO                     // convert through text I/O
+)
+
+// coerceToTargetType converts expr, of type exprType, to targetType with
+// targetTypmod applied. It returns nil if no conversion is allowed in
+// ccontext (coerce_to_target_type).
+func (ps *pstate) coerceToTargetType(expr nodes.Node, exprType, targetType nodes.Oid, targetTypmod int32, ccontext nodes.CoercionContext, cformat nodes.CoercionForm, loc nodes.ParseLoc) (nodes.Node, error) {
+	if !ps.canCoerceType([]nodes.Oid{exprType}, []nodes.Oid{targetType}, ccontext) {
+		return nil, nil
+	}
+	// A COLLATE clause stays on top of the coerced expression.
+	origExpr := expr
+	coll, isColl := expr.(*nodes.CollateExpr)
+	if isColl {
+		expr = coll.Arg
+	}
+	result, err := ps.coerceType(expr, exprType, targetType, targetTypmod, ccontext, cformat, loc)
+	if err != nil {
+		return nil, err
+	}
+	result = ps.coerceTypeTypmod(result, targetType, targetTypmod, cformat, loc, ccontext == nodes.COERCION_EXPLICIT)
+	if isColl && result != expr {
+		c := *coll
+		c.Arg = result
+		return &c, nil
+	}
+	if isColl {
+		return origExpr, nil
+	}
+	return result, nil
+}
+
+// coerceType converts expr to targetType, without regard to typmod. The
+// caller must have checked canCoerceType (coerce_type).
+func (ps *pstate) coerceType(expr nodes.Node, inType, targetType nodes.Oid, targetTypmod int32, ccontext nodes.CoercionContext, cformat nodes.CoercionForm, loc nodes.ParseLoc) (nodes.Node, error) {
+	if targetType == inType || targetType == nodes.InvalidOid || expr == nil {
+		return expr, nil
+	}
+	switch targetType {
+	case ANYOID, ANYELEMENTOID, ANYNONARRAYOID, ANYCOMPATIBLEOID, ANYCOMPATIBLENONARRAYOID:
+		// Unknown literals stay unknown for a polymorphic argument.
+		return expr, nil
+	case ANYARRAYOID, ANYENUMOID, ANYRANGEOID, ANYMULTIRANGEOID, ANYCOMPATIBLEARRAYOID, ANYCOMPATIBLERANGEOID:
+		if inType != UNKNOWNOID {
+			return expr, nil
+		}
+	}
+	if inType == nodes.InvalidOid {
+		// Nothing is known about the input: record the conversion as an
+		// unresolved function call.
+		return &nodes.FuncExpr{Funcresulttype: targetType, Funcformat: cformat, Funccollid: typeCollation(ps.cat, targetType), Args: &nodes.List{Items: []nodes.Node{expr}}, Location: loc}, nil
+	}
+	if inType == UNKNOWNOID {
+		if c, ok := expr.(*nodes.Const); ok {
+			return ps.coerceUnknownConst(c, targetType, targetTypmod, cformat, loc)
+		}
+		if p, ok := expr.(*nodes.Param); ok && p.Paramkind == nodes.PARAM_EXTERN {
+			return ps.coerceUnknownParam(p, targetType)
+		}
+	}
+	if targetType == RECORDOID || targetType == RECORDARRAYOID {
+		return expr, nil
+	}
+	if inType == RECORDOID {
+		if _, ok := expr.(*nodes.RowExpr); ok {
+			return ps.coerceRecordToComplex(expr.(*nodes.RowExpr), targetType, cformat, loc)
+		}
+	}
+
+	// Coerce to a domain's base type, then check the domain.
+	baseTarget, baseTypmod := baseType(ps.cat, targetType, targetTypmod)
+	if baseTarget != targetType {
+		base, err := ps.coerceType(expr, inType, baseTarget, baseTypmod, ccontext, cformat, loc)
+		if err != nil {
+			return nil, err
+		}
+		base = ps.coerceTypeTypmod(base, baseTarget, baseTypmod, nodes.COERCE_IMPLICIT_CAST, loc, false)
+		return &nodes.CoerceToDomain{Arg: base, Resulttype: targetType, Resulttypmod: -1, Resultcollid: typeCollation(ps.cat, baseTarget), Coercionformat: cformat, Location: loc}, nil
+	}
+
+	path, _ := ps.findCoercionPathway(targetType, inType, ccontext)
+	switch path {
+	case coercionPathRelabel:
+		return &nodes.RelabelType{Arg: expr, Resulttype: targetType, Resulttypmod: -1, Resultcollid: typeCollation(ps.cat, targetType), Relabelformat: cformat, Location: loc}, nil
+	case coercionPathFunc:
+		return &nodes.FuncExpr{Funcresulttype: targetType, Funcformat: cformat, Funccollid: typeCollation(ps.cat, targetType), Args: &nodes.List{Items: []nodes.Node{expr}}, Location: loc}, nil
+	case coercionPathCoerceViaIO:
+		return &nodes.CoerceViaIO{Arg: expr, Resulttype: targetType, Resultcollid: typeCollation(ps.cat, targetType), Coerceformat: cformat, Location: loc}, nil
+	case coercionPathArrayCoerce:
+		inBase, _ := baseType(ps.cat, inType, -1)
+		inElem := ps.cat.TypeByOid(inBase).Elem
+		outElem := ps.cat.TypeByOid(targetType).Elem
+		placeholder := &nodes.CaseTestExpr{TypeId: inElem, TypeMod: ExprTypmod(expr), Collation: typeCollation(ps.cat, inElem)}
+		elem, err := ps.coerceToTargetType(placeholder, inElem, outElem, targetTypmod, ccontext, cformat, loc)
+		if err != nil {
+			return nil, err
+		}
+		if elem == nil {
+			return nil, errorf(CodeCannotCoerce, loc, "cannot cast type %s to %s", FormatType(ps.cat, inType, -1), FormatType(ps.cat, targetType, -1))
+		}
+		if inBase != inType {
+			expr = &nodes.RelabelType{Arg: expr, Resulttype: inBase, Resulttypmod: -1, Resultcollid: typeCollation(ps.cat, inBase), Relabelformat: nodes.COERCE_IMPLICIT_CAST, Location: -1}
+		}
+		return &nodes.ArrayCoerceExpr{Arg: expr, Elemexpr: elem, Resulttype: targetType, Resulttypmod: targetTypmod, Resultcollid: typeCollation(ps.cat, targetType), Coerceformat: cformat, Location: loc}, nil
+	}
+	if base, _ := baseType(ps.cat, inType, -1); base != inType {
+		// A domain value is usable as its base type.
+		return ps.coerceType(&nodes.RelabelType{Arg: expr, Resulttype: base, Resulttypmod: -1, Resultcollid: typeCollation(ps.cat, base), Relabelformat: nodes.COERCE_IMPLICIT_CAST, Location: -1},
+			base, targetType, targetTypmod, ccontext, cformat, loc)
+	}
+	if inType == UNKNOWNOID || ps.isComplexType(inType) || ps.isComplexType(targetType) {
+		return &nodes.CoerceViaIO{Arg: expr, Resulttype: targetType, Resultcollid: typeCollation(ps.cat, targetType), Coerceformat: cformat, Location: loc}, nil
+	}
+	return nil, errorf(CodeCannotCoerce, loc, "failed to find conversion function from %s to %s", FormatType(ps.cat, inType, -1), FormatType(ps.cat, targetType, -1))
+}
+
+// coerceUnknownConst gives an unknown-type literal the target type,
+// checking the literal when the type is one whose input syntax is known.
+func (ps *pstate) coerceUnknownConst(c *nodes.Const, targetType nodes.Oid, targetTypmod int32, cformat nodes.CoercionForm, loc nodes.ParseLoc) (nodes.Node, error) {
+	baseTarget, baseTypmod := baseType(ps.cat, targetType, targetTypmod)
+	t := ps.cat.TypeByOid(baseTarget)
+	nc := &nodes.Const{Consttype: baseTarget, Consttypmod: -1, Constcollid: typeCollation(ps.cat, baseTarget), Constlen: -1, Constvalue: c.Constvalue, Constisnull: c.Constisnull, Location: c.Location}
+	if t != nil {
+		nc.Constlen, nc.Constbyval = t.Len, t.ByVal
+	}
+	if baseTarget == INTERVALOID {
+		nc.Consttypmod = baseTypmod
+	}
+	if loc < 0 || c.Location >= 0 && c.Location < loc {
+		loc = c.Location
+	}
+	if s, ok := c.Constvalue.(*nodes.String); ok && !c.Constisnull {
+		v, err := ps.checkLiteral(s.Sval, baseTarget, loc)
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			nc.Constvalue = v
+		}
+	}
+	if baseTarget != targetType {
+		return &nodes.CoerceToDomain{Arg: nc, Resulttype: targetType, Resulttypmod: -1, Resultcollid: nc.Constcollid, Coercionformat: cformat, Location: loc}, nil
+	}
+	return nc, nil
+}
+
+// checkLiteral validates a string literal being converted to a numeric or
+// boolean type and returns its value in parsed form; literals of other
+// types are kept as strings and returned as nil.
+func (ps *pstate) checkLiteral(s string, typ nodes.Oid, loc nodes.ParseLoc) (nodes.Node, error) {
+	invalid := func() error {
+		return errorf(CodeInvalidTextRepresentation, loc, "invalid input syntax for type %s: %q", FormatType(ps.cat, typ, -1), s)
+	}
+	trimmed := strings.TrimSpace(s)
+	switch typ {
+	case INT2OID, INT4OID, INT8OID:
+		bits := map[nodes.Oid]int{INT2OID: 16, INT4OID: 32, INT8OID: 64}[typ]
+		i, err := strconv.ParseInt(trimmed, 10, bits)
+		if err != nil {
+			if ne, ok := err.(*strconv.NumError); ok && ne.Err == strconv.ErrRange {
+				return nil, errorf(CodeNumericValueOutOfRange, loc, "value %q is out of range for type %s", s, FormatType(ps.cat, typ, -1))
+			}
+			return nil, invalid()
+		}
+		return &nodes.Integer{Ival: i}, nil
+	case FLOAT4OID, FLOAT8OID, NUMERICOID:
+		switch strings.ToLower(trimmed) {
+		case "nan", "infinity", "+infinity", "-infinity", "inf", "+inf", "-inf":
+			return &nodes.Float{Fval: trimmed}, nil
+		}
+		if _, err := strconv.ParseFloat(trimmed, 64); err != nil {
+			ne, ok := err.(*strconv.NumError)
+			if !ok || ne.Err != strconv.ErrRange {
+				return nil, invalid()
+			}
+			if typ != NUMERICOID {
+				return nil, errorf(CodeNumericValueOutOfRange, loc, "%q is out of range for type %s", s, FormatType(ps.cat, typ, -1))
+			}
+		}
+		return &nodes.Float{Fval: trimmed}, nil
+	case BOOLOID:
+		switch v := strings.ToLower(trimmed); {
+		case v == "":
+			return nil, invalid()
+		case strings.HasPrefix("true", v), strings.HasPrefix("yes", v), v == "on", v == "1":
+			return &nodes.Boolean{Boolval: true}, nil
+		case strings.HasPrefix("false", v), strings.HasPrefix("no", v), strings.HasPrefix("off", v) && len(v) > 1, v == "0":
+			return &nodes.Boolean{Boolval: false}, nil
+		}
+		return nil, invalid()
+	}
+	return nil, nil
+}
+
+// coerceUnknownParam fixes the type of a parameter whose type was not
+// known (variable_coerce_param_hook).
+func (ps *pstate) coerceUnknownParam(p *nodes.Param, targetType nodes.Oid) (nodes.Node, error) {
+	n := p.Paramid
+	if n <= 0 || n > len(ps.params.types) {
+		return nil, errorf(CodeUndefinedParameter, p.Location, "there is no parameter $%d", n)
+	}
+	switch prev := ps.params.types[n-1]; prev {
+	case nodes.InvalidOid, UNKNOWNOID:
+		ps.params.types[n-1] = targetType
+	case targetType:
+	default:
+		return nil, errorf(CodeAmbiguousParameter, p.Location, "inconsistent types deduced for parameter $%d", n).
+			withDetail("%s versus %s", FormatType(ps.cat, prev, -1), FormatType(ps.cat, targetType, -1))
+	}
+	p.Paramtype = targetType
+	p.Paramtypmod = -1
+	p.Paramcollid = typeCollation(ps.cat, targetType)
+	return p, nil
+}
+
+// coerceRecordToComplex converts a ROW() expression to a composite type,
+// coercing each field to the type of the matching column.
+func (ps *pstate) coerceRecordToComplex(row *nodes.RowExpr, targetType nodes.Oid, cformat nodes.CoercionForm, loc nodes.ParseLoc) (nodes.Node, error) {
+	base, _ := baseType(ps.cat, targetType, -1)
+	t := ps.cat.TypeByOid(base)
+	var rel *Relation
+	if t != nil {
+		rel = ps.cat.RelationByOid(t.Relid)
+	}
+	if rel == nil {
+		return nil, errorf(CodeCannotCoerce, loc, "cannot cast type %s to %s", FormatType(ps.cat, RECORDOID, -1), FormatType(ps.cat, targetType, -1))
+	}
+	args := items(row.Args)
+	if len(args) != len(rel.Columns) {
+		return nil, errorf(CodeCannotCoerce, loc, "cannot cast type %s to %s", FormatType(ps.cat, RECORDOID, -1), FormatType(ps.cat, targetType, -1)).
+			withDetail("Input has too %s columns.", map[bool]string{true: "few", false: "many"}[len(args) < len(rel.Columns)])
+	}
+	var out []nodes.Node
+	var names []string
+	for i, arg := range args {
+		col := rel.Columns[i]
+		c, err := ps.coerceToTargetType(arg, ExprType(arg), col.Type, col.Typmod, nodes.COERCION_IMPLICIT, nodes.COERCE_IMPLICIT_CAST, -1)
+		if err != nil {
+			return nil, err
+		}
+		if c == nil {
+			return nil, errorf(CodeCannotCoerce, loc, "cannot cast type %s to %s", FormatType(ps.cat, RECORDOID, -1), FormatType(ps.cat, targetType, -1)).
+				withDetail("Cannot cast type %s to %s in column %d.", FormatType(ps.cat, ExprType(arg), -1), FormatType(ps.cat, col.Type, -1), i+1)
+		}
+		out = append(out, c)
+		names = append(names, col.Name)
+	}
+	result := &nodes.RowExpr{Args: nodeList(out), RowTypeid: base, RowFormat: cformat, Colnames: makeStringList(names), Location: row.Location}
+	if base != targetType {
+		return &nodes.CoerceToDomain{Arg: result, Resulttype: targetType, Resulttypmod: -1, Coercionformat: cformat, Location: loc}, nil
+	}
+	return result, nil
+}
+
+// coerceTypeTypmod applies a typmod to an expression of the given type by
+// calling the type's length coercion function (coerce_type_typmod).
+func (ps *pstate) coerceTypeTypmod(expr nodes.Node, targetType nodes.Oid, targetTypmod int32, cformat nodes.CoercionForm, loc nodes.ParseLoc, isExplicit bool) nodes.Node {
+	if targetTypmod < 0 || targetTypmod == ExprTypmod(expr) || targetType == nodes.InvalidOid {
+		return expr
+	}
+	if ps.cat.LookupCast(targetType, targetType) == nil {
+		return expr
+	}
+	return &nodes.FuncExpr{
+		Funcresulttype: targetType,
+		Funcformat:     cformat,
+		Funccollid:     typeCollation(ps.cat, targetType),
+		Args: &nodes.List{Items: []nodes.Node{
+			expr,
+			&nodes.Const{Consttype: INT4OID, Consttypmod: -1, Constlen: 4, Constbyval: true, Constvalue: &nodes.Integer{Ival: int64(targetTypmod)}, Location: -1},
+			&nodes.Const{Consttype: BOOLOID, Consttypmod: -1, Constlen: 1, Constbyval: true, Constvalue: &nodes.Boolean{Boolval: isExplicit}, Location: -1},
+		}},
+		Location: loc,
+	}
+}
+
+// findCoercionPathway finds how to convert source to target in ccontext
+// (find_coercion_pathway). Domains must already have been reduced to
+// their base types by the caller, except that a domain source is tried
+// as itself first.
+func (ps *pstate) findCoercionPathway(target, source nodes.Oid, ccontext nodes.CoercionContext) (coercionPath, *Cast) {
+	if target == source {
+		return coercionPathRelabel, nil
+	}
+	target, _ = baseType(ps.cat, target, -1)
+	source, _ = baseType(ps.cat, source, -1)
+	if target == source {
+		return coercionPathRelabel, nil
+	}
+	if c := ps.cat.LookupCast(source, target); c != nil {
+		allowed := false
+		switch c.Context {
+		case 'i':
+			allowed = true
+		case 'a':
+			allowed = ccontext >= nodes.COERCION_ASSIGNMENT
+		case 'e':
+			allowed = ccontext >= nodes.COERCION_EXPLICIT
+		}
+		if !allowed {
+			return coercionPathNone, nil
+		}
+		switch c.Method {
+		case 'b':
+			return coercionPathRelabel, c
+		case 'i':
+			return coercionPathCoerceViaIO, c
+		}
+		return coercionPathFunc, c
+	}
+	tt, st := ps.cat.TypeByOid(target), ps.cat.TypeByOid(source)
+	if isArrayType(tt) && isArrayType(st) && target != INT2VECTOROID && target != OIDVECTOROID {
+		elemPath, _ := ps.findCoercionPathway(tt.Elem, st.Elem, ccontext)
+		if elemPath != coercionPathNone {
+			return coercionPathArrayCoerce, nil
+		}
+		return coercionPathNone, nil
+	}
+	// Any type converts to a string type by I/O in assignment, and a
+	// string type to anything explicitly.
+	if ccontext >= nodes.COERCION_ASSIGNMENT && typeCategory(ps.cat, target) == 'S' {
+		return coercionPathCoerceViaIO, nil
+	}
+	if ccontext >= nodes.COERCION_EXPLICIT && typeCategory(ps.cat, source) == 'S' {
+		return coercionPathCoerceViaIO, nil
+	}
+	return coercionPathNone, nil
+}
+
+// canCoerceType reports whether each of inputTypes can be converted to the
+// matching targetTypes in ccontext (can_coerce_type). Polymorphic targets
+// accept any input of the right shape; their consistency is checked when
+// the call is resolved.
+func (ps *pstate) canCoerceType(inputTypes, targetTypes []nodes.Oid, ccontext nodes.CoercionContext) bool {
+	for i, in := range inputTypes {
+		target := targetTypes[i]
+		if in == target || target == ANYOID || in == nodes.InvalidOid || target == nodes.InvalidOid {
+			continue
+		}
+		if isPolymorphicType(target) {
+			if in == UNKNOWNOID {
+				continue
+			}
+			inBase, _ := baseType(ps.cat, in, -1)
+			t := ps.cat.TypeByOid(inBase)
+			switch target {
+			case ANYARRAYOID, ANYCOMPATIBLEARRAYOID:
+				if !isArrayType(t) {
+					return false
+				}
+			case ANYNONARRAYOID, ANYCOMPATIBLENONARRAYOID:
+				if isArrayType(t) {
+					return false
+				}
+			case ANYENUMOID:
+				if t == nil || t.Kind != 'e' {
+					return false
+				}
+			case ANYRANGEOID, ANYCOMPATIBLERANGEOID:
+				if t == nil || t.Kind != 'r' {
+					return false
+				}
+			case ANYMULTIRANGEOID:
+				if t == nil || t.Kind != 'm' {
+					return false
+				}
+			}
+			continue
+		}
+		if in == UNKNOWNOID {
+			continue
+		}
+		if target == RECORDOID && ps.isComplexType(in) {
+			continue
+		}
+		if target == RECORDARRAYOID {
+			if t := ps.cat.TypeByOid(in); isArrayType(t) && ps.isComplexType(t.Elem) {
+				continue
+			}
+		}
+		if path, _ := ps.findCoercionPathway(target, in, ccontext); path != coercionPathNone {
+			continue
+		}
+		if in == RECORDOID && ps.isComplexType(target) {
+			// Checked field by field by coerceRecordToComplex.
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// isComplexType reports whether typ is a composite type or record
+// (ISCOMPLEX).
+func (ps *pstate) isComplexType(typ nodes.Oid) bool {
+	if typ == RECORDOID {
+		return true
+	}
+	t := ps.cat.TypeByOid(typ)
+	return t != nil && t.Kind == 'c'
+}
+
+// isBinaryCoercible reports whether a value of type source can be used as
+// type target without conversion (IsBinaryCoercible).
+func (ps *pstate) isBinaryCoercible(source, target nodes.Oid) bool {
+	if source == target || target == ANYOID {
+		return true
+	}
+	source, _ = baseType(ps.cat, source, -1)
+	if source == target {
+		return true
+	}
+	if c := ps.cat.LookupCast(source, target); c != nil {
+		return c.Context == 'i' && c.Method == 'b'
+	}
+	return false
+}
+
+// selectCommonType chooses the type that the expressions of a CASE,
+// UNION, VALUES list and the like are all converted to
+// (select_common_type). Expressions of unresolved type are ignored; if
+// all are unknown-type literals the result is text.
+func (ps *pstate) selectCommonType(exprs []nodes.Node, context string) (nodes.Oid, error) {
+	typ, err := ps.selectCommonTypeNoError(exprs)
+	if err != nil {
+		return nodes.InvalidOid, errorf(CodeDatatypeMismatch, exprLocation(err.expr), "%s types %s and %s cannot be matched",
+			context, FormatType(ps.cat, err.ptype, -1), FormatType(ps.cat, err.ntype, -1))
+	}
+	return typ, nil
+}
+
+// commonTypeConflict describes why selectCommonTypeNoError failed.
+type commonTypeConflict struct {
+	ptype, ntype nodes.Oid
+	expr         nodes.Node
+}
+
+func (ps *pstate) selectCommonTypeNoError(exprs []nodes.Node) (nodes.Oid, *commonTypeConflict) {
+	ptype := UNKNOWNOID
+	allSame := true
+	for _, e := range exprs {
+		t := ExprType(e)
+		if t == UNKNOWNOID || t == nodes.InvalidOid {
+			continue
+		}
+		if ptype == UNKNOWNOID {
+			ptype = t
+		} else if t != ptype {
+			allSame = false
+		}
+	}
+	if ptype == UNKNOWNOID {
+		for _, e := range exprs {
+			if ExprType(e) == UNKNOWNOID {
+				return TEXTOID, nil
+			}
+		}
+		return nodes.InvalidOid, nil
+	}
+	if allSame {
+		return ptype, nil
+	}
+	ptype, _ = baseType(ps.cat, ptype, -1)
+	pcategory := typeCategory(ps.cat, ptype)
+	ppreferred := ps.isPreferredType(ptype)
+	for _, e := range exprs {
+		ntype := ExprType(e)
+		if ntype == UNKNOWNOID || ntype == nodes.InvalidOid {
+			continue
+		}
+		ntype, _ = baseType(ps.cat, ntype, -1)
+		if ntype == ptype {
+			continue
+		}
+		ncategory := typeCategory(ps.cat, ntype)
+		if ncategory != pcategory {
+			return nodes.InvalidOid, &commonTypeConflict{ptype: ptype, ntype: ntype, expr: e}
+		}
+		if !ppreferred &&
+			ps.canCoerceType([]nodes.Oid{ptype}, []nodes.Oid{ntype}, nodes.COERCION_IMPLICIT) &&
+			!ps.canCoerceType([]nodes.Oid{ntype}, []nodes.Oid{ptype}, nodes.COERCION_IMPLICIT) {
+			ptype, ppreferred = ntype, ps.isPreferredType(ntype)
+		}
+	}
+	return ptype, nil
+}
+
+// commonType is selectCommonType for callers that fall back to another
+// strategy when there is no common type: it reports whether every
+// expression can be implicitly converted to the type it found
+// (select_common_type with no context, plus verify_common_type).
+func (ps *pstate) commonType(exprs []nodes.Node) (nodes.Oid, bool) {
+	typ, conflict := ps.selectCommonTypeNoError(exprs)
+	if conflict != nil {
+		return nodes.InvalidOid, false
+	}
+	for _, e := range exprs {
+		if !ps.canCoerceType([]nodes.Oid{ExprType(e)}, []nodes.Oid{typ}, nodes.COERCION_IMPLICIT) {
+			return nodes.InvalidOid, false
+		}
+	}
+	return typ, true
+}
+
+func (ps *pstate) isPreferredType(typ nodes.Oid) bool {
+	t := ps.cat.TypeByOid(typ)
+	return t != nil && t.Preferred
+}
+
+// coerceToCommonType converts an expression to the type chosen by
+// selectCommonType (coerce_to_common_type).
+func (ps *pstate) coerceToCommonType(expr nodes.Node, targetType nodes.Oid, context string) (nodes.Node, error) {
+	inType := ExprType(expr)
+	if inType == targetType || targetType == nodes.InvalidOid {
+		return expr, nil
+	}
+	if ps.canCoerceType([]nodes.Oid{inType}, []nodes.Oid{targetType}, nodes.COERCION_IMPLICIT) {
+		return ps.coerceType(expr, inType, targetType, -1, nodes.COERCION_IMPLICIT, nodes.COERCE_IMPLICIT_CAST, -1)
+	}
+	return nil, errorf(CodeCannotCoerce, exprLocation(expr), "%s could not convert type %s to %s", context, FormatType(ps.cat, inType, -1), FormatType(ps.cat, targetType, -1))
+}
+
+// selectCommonTypmod returns the typmod shared by exprs, all of which have
+// been converted to typ, or -1 (select_common_typmod).
+func selectCommonTypmod(exprs []nodes.Node, typ nodes.Oid) int32 {
+	return commonTypmod(typ, exprs)
+}
+
+// selectCommonCollation derives the collation of a combination of
+// expressions (select_common_collation). An explicit COLLATE wins; among
+// implicit collations a non-default one beats the default, and two
+// different non-default ones leave the result indeterminate.
+func (ps *pstate) selectCommonCollation(exprs []nodes.Node) nodes.Oid {
+	var explicit, implicit nodes.Oid
+	conflict := false
+	for _, e := range exprs {
+		if e == nil {
+			continue
+		}
+		if c, ok := e.(*nodes.CollateExpr); ok {
+			if explicit == nodes.InvalidOid {
+				explicit = c.CollOid
+			}
+			continue
+		}
+		coll := ExprCollation(e)
+		switch {
+		case coll == nodes.InvalidOid || coll == implicit:
+		case implicit == nodes.InvalidOid || implicit == DEFAULT_COLLATION_OID:
+			implicit = coll
+		case coll != DEFAULT_COLLATION_OID:
+			conflict = true
+		}
+	}
+	if explicit != nodes.InvalidOid {
+		return explicit
+	}
+	if conflict {
+		return nodes.InvalidOid
+	}
+	return implicit
+}
+
+// coerceToBoolean converts an expression used as a condition to boolean
+// (coerce_to_boolean).
+func (ps *pstate) coerceToBoolean(expr nodes.Node, construct string) (nodes.Node, error) {
+	return ps.coerceToSpecificType(expr, BOOLOID, construct)
+}
+
+// coerceToSpecificType converts an expression to targetType by an
+// assignment cast, for constructs that require a particular type
+// (coerce_to_specific_type).
+func (ps *pstate) coerceToSpecificType(expr nodes.Node, targetType nodes.Oid, construct string) (nodes.Node, error) {
+	inType := ExprType(expr)
+	if inType != targetType && inType != nodes.InvalidOid {
+		c, err := ps.coerceToTargetType(expr, inType, targetType, -1, nodes.COERCION_ASSIGNMENT, nodes.COERCE_IMPLICIT_CAST, -1)
+		if err != nil {
+			return nil, err
+		}
+		if c == nil {
+			return nil, errorf(CodeDatatypeMismatch, exprLocation(expr), "argument of %s must be type %s, not type %s",
+				construct, FormatType(ps.cat, targetType, -1), FormatType(ps.cat, inType, -1))
+		}
+		expr = c
+	}
+	if expressionReturnsSet(expr) {
+		return nil, errorf(CodeDatatypeMismatch, exprLocation(expr), "argument of %s must not return a set", construct)
+	}
+	return expr, nil
+}
+
+// expressionReturnsSet reports whether an expression contains a
+// set-returning function call outside of a subquery.
+func expressionReturnsSet(expr nodes.Node) bool {
+	found := false
+	walkLevels(expr, 0, func(n nodes.Node, depth int) bool {
+		if depth > 0 {
+			return false
+		}
+		switch f := n.(type) {
+		case *nodes.FuncExpr:
+			found = found || f.Funcretset
+		case *nodes.OpExpr:
+			found = found || f.Opretset
+		case *nodes.Aggref, *nodes.WindowFunc:
+			return false
+		}
+		return !found
+	})
+	return found
+}
+
+// transformAssignedExpr converts an expression being stored into column
+// colname of the target relation, applying any subscripts or field names
+// in indirection (transformAssignedExpr).
+func (ps *pstate) transformAssignedExpr(expr nodes.Node, kind exprKind, colname string, attno nodes.AttrNumber, indirection *nodes.List, loc nodes.ParseLoc) (nodes.Node, error) {
+	saved := ps.exprKind
+	ps.exprKind = kind
+	defer func() { ps.exprKind = saved }()
+
+	col := ps.targetRel.Columns[attno-1]
+	if def, ok := expr.(*nodes.SetToDefault); ok {
+		if indirection != nil {
+			return nil, errorf(CodeFeatureNotSupported, def.Location, "cannot set an array element to DEFAULT")
+		}
+		d := *def
+		d.TypeId, d.TypeMod, d.Collation = col.Type, col.Typmod, col.Collation
+		return &d, nil
+	}
+	if col.Generated != 0 {
+		if kind == exprKindUpdateTarget {
+			return nil, errorf(CodeGeneratedAlways, loc, "column %q can only be updated to DEFAULT", colname).
+				withDetail("Column %q is a generated column.", colname)
+		}
+		return nil, errorf(CodeGeneratedAlways, loc, "cannot insert a non-DEFAULT value into column %q", colname).
+			withDetail("Column %q is a generated column.", colname)
+	}
+	if indirection != nil {
+		var colVar nodes.Node
+		if kind == exprKindInsertTarget {
+			colVar = &nodes.Const{Consttype: col.Type, Consttypmod: col.Typmod, Constcollid: col.Collation, Constlen: -1, Constisnull: true, Location: -1}
+		} else {
+			colVar = buildVar(&ps.targetNSItem.columns[attno-1], 0, loc)
+		}
+		return ps.transformAssignmentIndirection(colVar, colname, col.Type, col.Typmod, col.Collation, items(indirection), expr, loc)
+	}
+	return ps.coerceAssignment(expr, colname, col.Type, col.Typmod, loc)
+}
+
+// coerceAssignment converts a value being stored to the type of its
+// destination.
+func (ps *pstate) coerceAssignment(expr nodes.Node, colname string, typ nodes.Oid, typmod int32, loc nodes.ParseLoc) (nodes.Node, error) {
+	inType := ExprType(expr)
+	c, err := ps.coerceToTargetType(expr, inType, typ, typmod, nodes.COERCION_ASSIGNMENT, nodes.COERCE_IMPLICIT_CAST, -1)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, errorf(CodeDatatypeMismatch, exprLocation(expr), "column %q is of type %s but expression is of type %s",
+			colname, FormatType(ps.cat, typ, -1), FormatType(ps.cat, inType, -1)).
+			withHint("You will need to rewrite or cast the expression.")
+	}
+	return c, nil
+}
+
+// transformAssignmentIndirection builds the expression that stores rhs
+// into the part of base selected by indirection: a SubscriptingRef for
+// subscripts and a FieldStore for field names
+// (transformAssignmentIndirection).
+func (ps *pstate) transformAssignmentIndirection(base nodes.Node, colname string, typ nodes.Oid, typmod int32, coll nodes.Oid, indirection []nodes.Node, rhs nodes.Node, loc nodes.ParseLoc) (nodes.Node, error) {
+	var subscripts []*nodes.A_Indices
+	for i, item := range indirection {
+		switch ind := item.(type) {
+		case *nodes.A_Indices:
+			subscripts = append(subscripts, ind)
+			continue
+		case *nodes.A_Star:
+			return nil, errorf(CodeFeatureNotSupported, loc, "row expansion via \"*\" is not supported here")
+		case *nodes.String:
+			if subscripts != nil {
+				// Subscripts before the field: store into the element.
+				return ps.assignSubscripts(base, colname, typ, typmod, coll, subscripts, indirection[i:], rhs, loc)
+			}
+			ctype, _ := baseType(ps.cat, typ, -1)
+			t := ps.cat.TypeByOid(ctype)
+			var rel *Relation
+			if t != nil && t.Kind == 'c' {
+				rel = ps.cat.RelationByOid(t.Relid)
+			}
+			if rel == nil {
+				return nil, errorf(CodeDatatypeMismatch, loc, "cannot assign to field %q of column %q because its type %s is not a composite type",
+					ind.Sval, colname, FormatType(ps.cat, typ, -1))
+			}
+			field, attno := rel.Column(ind.Sval)
+			if field == nil {
+				return nil, errorf(CodeUndefinedColumn, loc, "cannot assign to field %q of column %q because there is no such column in data type %s",
+					ind.Sval, colname, FormatType(ps.cat, typ, -1))
+			}
+			placeholder := &nodes.CaseTestExpr{TypeId: field.Type, TypeMod: field.Typmod, Collation: field.Collation}
+			val, err := ps.transformAssignmentIndirection(placeholder, colname, field.Type, field.Typmod, field.Collation, indirection[i+1:], rhs, loc)
+			if err != nil {
+				return nil, err
+			}
+			return &nodes.FieldStore{
+				Arg:        base,
+				Newvals:    &nodes.List{Items: []nodes.Node{val}},
+				Fieldnums:  &nodes.IntList{Items: []int{int(attno)}},
+				Resulttype: typ,
+			}, nil
+		}
+	}
+	if subscripts != nil {
+		return ps.assignSubscripts(base, colname, typ, typmod, coll, subscripts, nil, rhs, loc)
+	}
+	return ps.coerceAssignment(rhs, colname, typ, typmod, loc)
+}
+
+// assignSubscripts builds a SubscriptingRef that stores into the element
+// of base selected by subscripts, applying the remaining indirection to
+// the element.
+func (ps *pstate) assignSubscripts(base nodes.Node, colname string, typ nodes.Oid, typmod int32, coll nodes.Oid, subscripts []*nodes.A_Indices, rest []nodes.Node, rhs nodes.Node, loc nodes.ParseLoc) (nodes.Node, error) {
+	fetch, err := ps.transformContainerSubscripts(base, subscripts)
+	if err != nil {
+		return nil, err
+	}
+	elemType := fetch.Refrestype
+	placeholder := &nodes.CaseTestExpr{TypeId: elemType, TypeMod: fetch.Reftypmod, Collation: coll}
+	val, err := ps.transformAssignmentIndirection(placeholder, colname, elemType, fetch.Reftypmod, coll, rest, rhs, loc)
+	if err != nil {
+		return nil, err
+	}
+	fetch.Refassgnexpr = val
+	fetch.Refrestype = fetch.Refcontainertype
+	if fetch.Refcontainertype != typ {
+		// Assigning to an element of a domain over an array yields the
+		// base type, which must be coerced back to the domain.
+		return ps.coerceAssignment(fetch, colname, typ, typmod, loc)
+	}
+	return fetch, nil
+}
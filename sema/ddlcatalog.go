@@ -0,0 +1,376 @@
+package sema
+
+import (
+	"strings"
+
+	"github.com/pgplex/pgparser/catalog"
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// ddlCatalog is a Catalog backed by a *catalog.Catalog built from DDL,
+// plus the built-in types, operators, casts and functions.
+type ddlCatalog struct {
+	cat *catalog.Catalog
+
+	types     map[nodes.Oid]*Type
+	typeNames map[string]*Type // by "schema.name"
+	userTypes map[*catalog.Type]*Type
+
+	relations map[*catalog.Relation]*Relation
+	relByOid  map[nodes.Oid]*Relation
+
+	functions map[string][]*Function // by "schema.name"
+	operators map[string][]*Operator // built-in operators by name
+	casts     map[[2]nodes.Oid]*Cast
+
+	// views holds the views whose column types have not been derived
+	// yet; they are analyzed on first use.
+	views map[*Relation]*catalog.Relation
+}
+
+// FromCatalog returns a Catalog describing the objects of c together with
+// the built-in types, operators, casts and functions.
+// User-defined objects are numbered from FirstNormalObjectId in schema and
+// name order, so the same schema always yields the same OIDs. The result
+// reflects c at the time of the call.
+func FromCatalog(c *catalog.Catalog) Catalog {
+	d := &ddlCatalog{
+		cat:       c,
+		types:     make(map[nodes.Oid]*Type),
+		typeNames: make(map[string]*Type),
+		userTypes: make(map[*catalog.Type]*Type),
+		relations: make(map[*catalog.Relation]*Relation),
+		relByOid:  make(map[nodes.Oid]*Relation),
+		functions: make(map[string][]*Function),
+		operators: make(map[string][]*Operator),
+		casts:     make(map[[2]nodes.Oid]*Cast),
+		views:     make(map[*Relation]*catalog.Relation),
+	}
+	for _, t := range builtinTypes() {
+		d.addType(t)
+	}
+	for _, c := range builtinCasts() {
+		d.casts[[2]nodes.Oid{c.Source, c.Target}] = c
+	}
+	for _, op := range builtinOperators() {
+		d.operators[op.Name] = append(d.operators[op.Name], op)
+	}
+	for _, f := range builtinFunctions() {
+		key := "pg_catalog." + f.Name
+		d.functions[key] = append(d.functions[key], f)
+	}
+
+	// Assign OIDs first, so that types can refer to each other in any
+	// order, then fill in the details.
+	next := FirstNormalObjectId
+	alloc := func() nodes.Oid {
+		next++
+		return next - 1
+	}
+	var uts []*catalog.Type
+	var rels []*catalog.Relation
+	for _, s := range c.Schemas() {
+		for _, ut := range s.Types() {
+			t := &Type{Oid: alloc(), Schema: s.Name, Name: ut.Name, Kind: byte(ut.Kind), Len: -1}
+			d.userTypes[ut] = t
+			uts = append(uts, ut)
+			d.addType(t)
+			if ut.Kind != catalog.TypeKindShell {
+				t.Array = alloc()
+				d.addType(&Type{Oid: t.Array, Schema: s.Name, Name: "_" + ut.Name, Kind: 'b', Category: 'A', Len: -1, Elem: t.Oid})
+			}
+		}
+		for _, cr := range s.Relations() {
+			if cr.Kind == catalog.RelKindIndex || cr.Kind == catalog.RelKindPartitionedIndex {
+				continue
+			}
+			r := &Relation{Oid: alloc(), Schema: s.Name, Name: cr.Name, Kind: byte(cr.Kind), RowType: alloc()}
+			d.relations[cr] = r
+			d.relByOid[r.Oid] = r
+			row := &Type{Oid: r.RowType, Schema: s.Name, Name: cr.Name, Kind: 'c', Category: 'C', Len: -1, Relid: r.Oid, Array: alloc()}
+			d.addType(row)
+			d.addType(&Type{Oid: row.Array, Schema: s.Name, Name: "_" + cr.Name, Kind: 'b', Category: 'A', Len: -1, Elem: row.Oid})
+			rels = append(rels, cr)
+		}
+	}
+	for _, ut := range uts {
+		d.defineType(ut, d.userTypes[ut], alloc)
+	}
+	for _, cr := range rels {
+		d.defineRelation(cr, d.relations[cr])
+	}
+	for _, s := range c.Schemas() {
+		for _, cf := range s.Functions() {
+			key := s.Name + "." + cf.Name
+			d.functions[key] = append(d.functions[key], d.function(cf, alloc()))
+		}
+	}
+	return d
+}
+
+func (d *ddlCatalog) addType(t *Type) {
+	d.types[t.Oid] = t
+	d.typeNames[t.Schema+"."+t.Name] = t
+}
+
+// resolve returns the type OID and typmod of a type name, or InvalidOid
+// if it cannot be resolved.
+func (d *ddlCatalog) resolve(tn *nodes.TypeName) (nodes.Oid, int32) {
+	if tn == nil {
+		return nodes.InvalidOid, -1
+	}
+	t, typmod, err := LookupTypeName(d, tn)
+	if err != nil {
+		return nodes.InvalidOid, -1
+	}
+	return t.Oid, typmod
+}
+
+func (d *ddlCatalog) defineType(ut *catalog.Type, t *Type, alloc func() nodes.Oid) {
+	switch ut.Kind {
+	case catalog.TypeKindEnum:
+		t.Category, t.Len, t.ByVal = 'E', 4, true
+	case catalog.TypeKindRange:
+		t.Category = 'R'
+		t.Subtype, _ = d.resolve(ut.Subtype)
+	case catalog.TypeKindDomain:
+		t.BaseType, t.Typmod = d.resolve(ut.BaseType)
+		if base := d.types[t.BaseType]; base != nil {
+			t.Category, t.Len, t.ByVal, t.Collation = base.Category, base.Len, base.ByVal, base.Collation
+		}
+		if ut.Collation != "" {
+			t.Collation = d.collation(ut.Collation)
+		}
+	case catalog.TypeKindComposite:
+		t.Category = 'C'
+		r := &Relation{Oid: alloc(), Schema: t.Schema, Name: t.Name, Kind: 'c', RowType: t.Oid}
+		t.Relid = r.Oid
+		d.relByOid[r.Oid] = r
+		r.Columns = d.columns(ut.Attributes)
+	case catalog.TypeKindShell:
+		t.Category = 'P'
+	default:
+		t.Category = 'U'
+	}
+}
+
+func (d *ddlCatalog) defineRelation(cr *catalog.Relation, r *Relation) {
+	if cr.Sequence != nil {
+		r.Columns = []*Column{
+			{Name: "last_value", Type: INT8OID, Typmod: -1, NotNull: true},
+			{Name: "log_cnt", Type: INT8OID, Typmod: -1, NotNull: true},
+			{Name: "is_called", Type: BOOLOID, Typmod: -1, NotNull: true},
+		}
+		return
+	}
+	r.Columns = d.columns(cr.Columns)
+	if pk := cr.PrimaryKey(); pk != nil {
+		for _, name := range pk.Columns {
+			if _, attno := r.Column(name); attno > 0 {
+				r.PrimaryKey = append(r.PrimaryKey, attno)
+			}
+		}
+	}
+	if cr.Query != nil {
+		for _, col := range r.Columns {
+			if col.Type == nodes.InvalidOid {
+				d.views[r] = cr
+				break
+			}
+		}
+	}
+}
+
+func (d *ddlCatalog) columns(cols []*catalog.Column) []*Column {
+	out := make([]*Column, len(cols))
+	for i, cc := range cols {
+		col := &Column{
+			Name:      cc.Name,
+			NotNull:   cc.NotNull,
+			Default:   cc.Default != nil && cc.Generated == 0,
+			Identity:  cc.Identity,
+			Generated: cc.Generated,
+		}
+		col.Type, col.Typmod = d.resolve(cc.Type)
+		col.Collation = typeCollation(d, col.Type)
+		if cc.Collation != "" && col.Collation != nodes.InvalidOid {
+			col.Collation = d.collation(cc.Collation)
+		}
+		out[i] = col
+	}
+	return out
+}
+
+func (d *ddlCatalog) collation(name string) nodes.Oid {
+	schema, name := "", strings.Trim(name, `"`)
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		schema, name = name[:i], name[i+1:]
+	}
+	return d.LookupCollation(schema, name)
+}
+
+func (d *ddlCatalog) function(cf *catalog.Function, oid nodes.Oid) *Function {
+	f := &Function{Oid: oid, Schema: cf.Schema.Name, Name: cf.Name, Kind: byte(cf.Kind), ReturnsSet: cf.ReturnsSet}
+	for _, p := range cf.Params {
+		typ, typmod := d.resolve(p.Type)
+		switch p.Mode {
+		case nodes.FUNC_PARAM_OUT, nodes.FUNC_PARAM_TABLE:
+			f.OutColumns = append(f.OutColumns, &Column{Name: p.Name, Type: typ, Typmod: typmod, Collation: typeCollation(d, typ)})
+			continue
+		case nodes.FUNC_PARAM_INOUT:
+			f.OutColumns = append(f.OutColumns, &Column{Name: p.Name, Type: typ, Typmod: typmod, Collation: typeCollation(d, typ)})
+		case nodes.FUNC_PARAM_VARIADIC:
+			f.Variadic = true
+		}
+		f.ArgTypes = append(f.ArgTypes, typ)
+		f.ArgNames = append(f.ArgNames, p.Name)
+		if p.Default != nil {
+			f.NumDefaults++
+		} else {
+			f.NumDefaults = 0
+		}
+	}
+	switch {
+	case cf.ReturnType != nil:
+		f.ReturnType, _ = d.resolve(cf.ReturnType)
+	case len(f.OutColumns) == 1:
+		f.ReturnType = f.OutColumns[0].Type
+		f.OutColumns = nil
+	case len(f.OutColumns) > 1:
+		f.ReturnType = RECORDOID
+	default:
+		f.ReturnType = VOIDOID
+	}
+	if f.ReturnType != RECORDOID {
+		f.OutColumns = nil
+	}
+	return f
+}
+
+// view returns r with its column types derived from the view's query, if
+// that has not been done yet.
+func (d *ddlCatalog) view(r *Relation) *Relation {
+	cr, ok := d.views[r]
+	if !ok {
+		return r
+	}
+	delete(d.views, r)
+	q, err := Analyze(cr.Query, d)
+	if err != nil {
+		return r
+	}
+	i := 0
+	for _, tle := range items(q.TargetList) {
+		te := tle.(*nodes.TargetEntry)
+		if te.Resjunk || i >= len(r.Columns) {
+			continue
+		}
+		col := r.Columns[i]
+		if col.Type == nodes.InvalidOid {
+			col.Type, col.Typmod, col.Collation = ExprType(te.Expr), ExprTypmod(te.Expr), ExprCollation(te.Expr)
+		}
+		i++
+	}
+	return r
+}
+
+func (d *ddlCatalog) LookupRelation(schema, name string) *Relation {
+	cr := d.cat.LookupRelation(schema, name)
+	if cr == nil {
+		return nil
+	}
+	r := d.relations[cr]
+	if r == nil {
+		return nil
+	}
+	return d.view(r)
+}
+
+func (d *ddlCatalog) RelationByOid(oid nodes.Oid) *Relation {
+	r := d.relByOid[oid]
+	if r == nil {
+		return nil
+	}
+	return d.view(r)
+}
+
+func (d *ddlCatalog) LookupType(schema, name string) *Type {
+	if schema == "" || schema == "pg_catalog" {
+		if t := d.typeNames["pg_catalog."+name]; t != nil {
+			return t
+		}
+		if schema != "" {
+			return nil
+		}
+	}
+	base := strings.TrimPrefix(name, "_")
+	if ut := d.cat.LookupType(schema, base); ut != nil {
+		if t := d.userTypes[ut]; t != nil {
+			return d.arrayIf(t, base != name)
+		}
+	}
+	if cr := d.cat.LookupRelation(schema, base); cr != nil {
+		if r := d.relations[cr]; r != nil {
+			return d.arrayIf(d.types[r.RowType], base != name)
+		}
+	}
+	return nil
+}
+
+func (d *ddlCatalog) arrayIf(t *Type, array bool) *Type {
+	if !array || t == nil {
+		return t
+	}
+	return d.types[t.Array]
+}
+
+func (d *ddlCatalog) TypeByOid(oid nodes.Oid) *Type {
+	return d.types[oid]
+}
+
+func (d *ddlCatalog) LookupFunctions(schema, name string) []*Function {
+	var out []*Function
+	if schema == "" || schema == "pg_catalog" {
+		out = append(out, d.functions["pg_catalog."+name]...)
+	}
+	for _, cf := range d.cat.LookupFunctions(schema, name) {
+		for _, f := range d.functions[cf.Schema.Name+"."+cf.Name] {
+			if f.Oid != nodes.InvalidOid && sameSignature(f, cf, d) {
+				out = append(out, f)
+			}
+		}
+	}
+	return out
+}
+
+// sameSignature reports whether f was built from cf.
+func sameSignature(f *Function, cf *catalog.Function, d *ddlCatalog) bool {
+	args := cf.ArgTypes()
+	if len(args) != len(f.ArgTypes) {
+		return false
+	}
+	for i, tn := range args {
+		if oid, _ := d.resolve(tn); oid != f.ArgTypes[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *ddlCatalog) LookupOperators(schema, name string) []*Operator {
+	if schema == "" || schema == "pg_catalog" {
+		return d.operators[name]
+	}
+	return nil
+}
+
+func (d *ddlCatalog) LookupCast(source, target nodes.Oid) *Cast {
+	return d.casts[[2]nodes.Oid{source, target}]
+}
+
+func (d *ddlCatalog) LookupCollation(schema, name string) nodes.Oid {
+	if schema == "" || schema == "pg_catalog" {
+		return builtinCollations[name]
+	}
+	return nodes.InvalidOid
+}
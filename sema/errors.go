@@ -0,0 +1,67 @@
+package sema
+
+import (
+	"fmt"
+
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// SQLSTATE codes reported by parse analysis. The names follow the
+// ERRCODE_* macros in PostgreSQL's errcodes.txt.
+const (
+	CodeFeatureNotSupported       = "0A000"
+	CodeInvalidParameterValue     = "22023"
+	CodeSyntaxError               = "42601"
+	CodeGroupingError             = "42803"
+	CodeWindowingError            = "42P20"
+	CodeInvalidRecursion          = "42P19"
+	CodeInvalidColumnReference    = "42P10"
+	CodeDatatypeMismatch          = "42804"
+	CodeCannotCoerce              = "42846"
+	CodeIndeterminateDatatype     = "42P18"
+	CodeWrongObjectType           = "42809"
+	CodeUndefinedColumn           = "42703"
+	CodeUndefinedFunction         = "42883"
+	CodeUndefinedTable            = "42P01"
+	CodeUndefinedObject           = "42704"
+	CodeUndefinedParameter        = "42P02"
+	CodeDuplicateColumn           = "42701"
+	CodeDuplicateAlias            = "42712"
+	CodeAmbiguousColumn           = "42702"
+	CodeAmbiguousFunction         = "42725"
+	CodeAmbiguousAlias            = "42P09"
+	CodeAmbiguousParameter        = "42P08"
+	CodeInvalidTextRepresentation = "22P02"
+	CodeNumericValueOutOfRange    = "22003"
+	CodeGeneratedAlways           = "428C9"
+	CodeTooManyColumns            = "54011"
+)
+
+// Error is an error raised by parse analysis. Its fields mirror the parts
+// of a PostgreSQL error report, so that callers can show the same message
+// the server would.
+type Error struct {
+	Code     string         // SQLSTATE
+	Message  string         // primary message
+	Detail   string         // optional detail
+	Hint     string         // optional hint
+	Position nodes.ParseLoc // location of the offending token, or -1
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func errorf(code string, loc nodes.ParseLoc, format string, args ...any) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...), Position: loc}
+}
+
+func (e *Error) withDetail(format string, args ...any) *Error {
+	e.Detail = fmt.Sprintf(format, args...)
+	return e
+}
+
+func (e *Error) withHint(format string, args ...any) *Error {
+	e.Hint = fmt.Sprintf(format, args...)
+	return e
+}
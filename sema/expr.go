@@ -0,0 +1,1508 @@
+package sema
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// exprKind identifies the part of a query an expression appears in, which
+// decides what it may contain (ParseExprKind).
+type exprKind int
+
+const (
+	exprKindNone exprKind = iota
+	exprKindJoinOn
+	exprKindJoinUsing
+	exprKindFromSubselect
+	exprKindFromFunction
+	exprKindWhere
+	exprKindHaving
+	exprKindFilter
+	exprKindWindowPartition
+	exprKindWindowOrder
+	exprKindWindowFrameRange
+	exprKindWindowFrameRows
+	exprKindWindowFrameGroups
+	exprKindSelectTarget
+	exprKindInsertTarget
+	exprKindUpdateSource
+	exprKindUpdateTarget
+	exprKindMergeWhen
+	exprKindGroupBy
+	exprKindOrderBy
+	exprKindDistinctOn
+	exprKindLimit
+	exprKindOffset
+	exprKindReturning
+	exprKindValues
+	exprKindValuesSingle
+	exprKindIndexExpression
+	exprKindIndexPredicate
+)
+
+// String returns the name of the construct used in error messages
+// (ParseExprKindName).
+func (k exprKind) String() string {
+	switch k {
+	case exprKindJoinOn:
+		return "JOIN/ON"
+	case exprKindJoinUsing:
+		return "JOIN/USING"
+	case exprKindFromSubselect:
+		return "sub-SELECT in FROM"
+	case exprKindFromFunction:
+		return "function in FROM"
+	case exprKindWhere:
+		return "WHERE"
+	case exprKindHaving:
+		return "HAVING"
+	case exprKindFilter:
+		return "FILTER"
+	case exprKindWindowPartition:
+		return "window PARTITION BY"
+	case exprKindWindowOrder:
+		return "window ORDER BY"
+	case exprKindWindowFrameRange:
+		return "window RANGE"
+	case exprKindWindowFrameRows:
+		return "window ROWS"
+	case exprKindWindowFrameGroups:
+		return "window GROUPS"
+	case exprKindSelectTarget:
+		return "SELECT"
+	case exprKindInsertTarget:
+		return "INSERT"
+	case exprKindUpdateSource, exprKindUpdateTarget:
+		return "UPDATE"
+	case exprKindMergeWhen:
+		return "MERGE WHEN"
+	case exprKindGroupBy:
+		return "GROUP BY"
+	case exprKindOrderBy:
+		return "ORDER BY"
+	case exprKindDistinctOn:
+		return "DISTINCT ON"
+	case exprKindLimit:
+		return "LIMIT"
+	case exprKindOffset:
+		return "OFFSET"
+	case exprKindReturning:
+		return "RETURNING"
+	case exprKindValues, exprKindValuesSingle:
+		return "VALUES"
+	case exprKindIndexExpression:
+		return "index expression"
+	case exprKindIndexPredicate:
+		return "index predicate"
+	}
+	return ""
+}
+
+// transformExpr analyzes a raw expression appearing in the given part of
+// the query (transformExpr).
+func (ps *pstate) transformExpr(n nodes.Node, kind exprKind) (nodes.Node, error) {
+	if n == nil {
+		return nil, nil
+	}
+	saved := ps.exprKind
+	ps.exprKind = kind
+	defer func() { ps.exprKind = saved }()
+	return ps.transformExprRecurse(n)
+}
+
+// transformExprRecurse analyzes a raw expression in the current
+// expression kind.
+func (ps *pstate) transformExprRecurse(n nodes.Node) (nodes.Node, error) {
+	switch e := n.(type) {
+	case nil:
+		return nil, nil
+	case *nodes.ColumnRef:
+		return ps.transformColumnRef(e)
+	case *nodes.ParamRef:
+		return ps.transformParamRef(e)
+	case *nodes.A_Const:
+		return makeConst(e), nil
+	case *nodes.TypeCast:
+		return ps.transformTypeCast(e)
+	case *nodes.CollateClause:
+		return ps.transformCollateClause(e)
+	case *nodes.A_Expr:
+		return ps.transformAExpr(e)
+	case *nodes.BoolExpr:
+		return ps.transformBoolExpr(e)
+	case *nodes.FuncCall:
+		expr, _, err := ps.transformFuncCall(e)
+		return expr, err
+	case *nodes.NamedArgExpr:
+		arg, err := ps.transformExprRecurse(e.Arg)
+		if err != nil {
+			return nil, err
+		}
+		return &nodes.NamedArgExpr{Arg: arg, Name: e.Name, Argnumber: -1, Location: e.Location}, nil
+	case *nodes.SubLink:
+		return ps.transformSubLink(e)
+	case *nodes.CaseExpr:
+		return ps.transformCaseExpr(e)
+	case *nodes.RowExpr:
+		return ps.transformRowExpr(e)
+	case *nodes.A_ArrayExpr:
+		return ps.transformArrayExpr(e, nodes.InvalidOid, nodes.InvalidOid)
+	case *nodes.CoalesceExpr:
+		return ps.transformCoalesceExpr(e)
+	case *nodes.MinMaxExpr:
+		return ps.transformMinMaxExpr(e)
+	case *nodes.SQLValueFunction:
+		return &nodes.SQLValueFunction{Op: e.Op, Typmod: e.Typmod, Location: e.Location}, nil
+	case *nodes.NullTest:
+		arg, err := ps.transformExprRecurse(e.Arg)
+		if err != nil {
+			return nil, err
+		}
+		return &nodes.NullTest{Arg: arg, Nulltesttype: e.Nulltesttype, Argisrow: ps.isRowType(ExprType(arg)), Location: e.Location}, nil
+	case *nodes.BooleanTest:
+		return ps.transformBooleanTest(e)
+	case *nodes.A_Indirection:
+		arg, err := ps.transformExprRecurse(e.Arg)
+		if err != nil {
+			return nil, err
+		}
+		return ps.transformIndirection(arg, e.Indirection)
+	case *nodes.CurrentOfExpr:
+		cur := *e
+		if ps.targetNSItem != nil {
+			cur.Cvarno = uint32(ps.targetNSItem.rtindex)
+		}
+		return &cur, nil
+	case *nodes.GroupingFunc:
+		return ps.transformGroupingFunc(e)
+	case *nodes.MultiAssignRef:
+		return ps.transformMultiAssignRef(e)
+	case *nodes.SetToDefault:
+		return nil, errorf(CodeSyntaxError, e.Location, "DEFAULT is not allowed in this context")
+	case *nodes.A_Star:
+		return nil, errorf(CodeFeatureNotSupported, -1, "row expansion via \"*\" is not supported here")
+	case *nodes.Var, *nodes.Const, *nodes.Param, *nodes.CaseTestExpr, *nodes.OpExpr,
+		*nodes.FuncExpr, *nodes.RelabelType, *nodes.CoerceViaIO:
+		// Already analyzed, as when a construct is rewritten into others.
+		return n, nil
+	}
+	return nil, errorf(CodeFeatureNotSupported, exprLocation(n), "%s expressions are not supported", strings.TrimPrefix(reflect.TypeOf(n).String(), "*nodes."))
+}
+
+// transformExpressionList analyzes a list of expressions, expanding
+// "foo.*" items into the columns of foo. With allowDefault, DEFAULT items
+// are passed through as SetToDefault nodes for the caller to resolve.
+func (ps *pstate) transformExpressionList(l *nodes.List, kind exprKind, allowDefault bool) ([]nodes.Node, error) {
+	var out []nodes.Node
+	for _, item := range items(l) {
+		if cr, ok := item.(*nodes.ColumnRef); ok && isStarRef(cr) {
+			saved := ps.exprKind
+			ps.exprKind = kind
+			tles, err := ps.expandColumnRefStar(cr)
+			ps.exprKind = saved
+			if err != nil {
+				return nil, err
+			}
+			for _, tle := range tles {
+				out = append(out, tle.Expr)
+			}
+			continue
+		}
+		if def, ok := item.(*nodes.SetToDefault); ok && allowDefault {
+			d := *def
+			out = append(out, &d)
+			continue
+		}
+		e, err := ps.transformExpr(item, kind)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// transformColumnRef resolves a possibly-qualified column reference
+// (transformColumnRef).
+func (ps *pstate) transformColumnRef(cr *nodes.ColumnRef) (nodes.Node, error) {
+	fields := items(cr.Fields)
+	name := func(i int) string {
+		if s, ok := fields[i].(*nodes.String); ok {
+			return s.Sval
+		}
+		return "*"
+	}
+	loc := cr.Location
+	switch len(fields) {
+	case 1:
+		if _, ok := fields[0].(*nodes.A_Star); ok {
+			return nil, errorf(CodeFeatureNotSupported, loc, "row expansion via \"*\" is not supported here")
+		}
+		col := name(0)
+		v, err := ps.colNameToVar(col, loc)
+		if err != nil || v != nil {
+			return v, err
+		}
+		// A bare relation name is a whole-row reference.
+		it, levels, err := ps.refnameNSItem("", col, loc)
+		if err != nil {
+			return nil, err
+		}
+		if it != nil {
+			return wholeRowVar(it, levels, loc), nil
+		}
+		return nil, ps.errorMissingColumn("", col, loc)
+	case 2:
+		rel, col := name(0), name(1)
+		it, levels, err := ps.refnameNSItem("", rel, loc)
+		if err != nil {
+			return nil, err
+		}
+		if it != nil {
+			return ps.columnOfItem(it, levels, rel, col, loc)
+		}
+		// Otherwise rel may be a column of composite type.
+		v, err := ps.colNameToVar(rel, loc)
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			if col == "*" {
+				return v, nil
+			}
+			return ps.fieldSelect(v, col, loc)
+		}
+		return nil, ps.errorMissingRTE(rel, loc)
+	case 3, 4:
+		if len(fields) == 4 {
+			fields = fields[1:]
+		}
+		schema, rel, col := name(0), name(1), name(2)
+		it, levels, err := ps.refnameNSItem(schema, rel, loc)
+		if err != nil {
+			return nil, err
+		}
+		if it != nil {
+			return ps.columnOfItem(it, levels, rel, col, loc)
+		}
+		if len(cr.Fields.Items) == 3 {
+			// relation.column.field
+			if it, levels, err = ps.refnameNSItem("", schema, loc); err != nil {
+				return nil, err
+			}
+			if it != nil {
+				v, err := ps.columnOfItem(it, levels, schema, rel, loc)
+				if err != nil {
+					return nil, err
+				}
+				if col == "*" {
+					return v, nil
+				}
+				return ps.fieldSelect(v, col, loc)
+			}
+		}
+		return nil, ps.errorMissingRTE(rel, loc)
+	}
+	return nil, errorf(CodeSyntaxError, loc, "improper qualified name (too many dotted names): %s", nameListString(cr.Fields))
+}
+
+// columnOfItem returns the named column of a namespace item, or its whole
+// row for "*".
+func (ps *pstate) columnOfItem(it *nsItem, levels int, rel, col string, loc nodes.ParseLoc) (nodes.Node, error) {
+	if col == "*" {
+		return wholeRowVar(it, levels, loc), nil
+	}
+	v, err := ps.scanNSItemForColumn(it, levels, col, loc)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, ps.errorMissingColumn(rel, col, loc)
+	}
+	return v, nil
+}
+
+// transformParamRef makes a Param for $n. Parameters of unknown type get
+// their type from the first context that determines it.
+func (ps *pstate) transformParamRef(pr *nodes.ParamRef) (nodes.Node, error) {
+	n := pr.Number
+	if n <= 0 {
+		return nil, errorf(CodeUndefinedParameter, pr.Location, "there is no parameter $%d", n)
+	}
+	for len(ps.params.types) < n {
+		ps.params.types = append(ps.params.types, nodes.InvalidOid)
+	}
+	typ := ps.params.types[n-1]
+	if typ == nodes.InvalidOid {
+		typ = UNKNOWNOID
+	}
+	return &nodes.Param{
+		Paramkind:   nodes.PARAM_EXTERN,
+		Paramid:     n,
+		Paramtype:   typ,
+		Paramtypmod: -1,
+		Paramcollid: typeCollation(ps.cat, typ),
+		Location:    pr.Location,
+	}, nil
+}
+
+// makeConst turns a literal into a Const of its initial type: integer,
+// bigint or numeric for numbers, unknown for strings (make_const).
+func makeConst(ac *nodes.A_Const) *nodes.Const {
+	c := &nodes.Const{Consttypmod: -1, Location: ac.Location}
+	if ac.Isnull || ac.Val == nil {
+		c.Consttype, c.Constlen, c.Constisnull = UNKNOWNOID, -2, true
+		return c
+	}
+	c.Constvalue = ac.Val
+	switch v := ac.Val.(type) {
+	case *nodes.Integer:
+		if v.Ival != int64(int32(v.Ival)) {
+			c.Consttype, c.Constlen, c.Constbyval = INT8OID, 8, true
+		} else {
+			c.Consttype, c.Constlen, c.Constbyval = INT4OID, 4, true
+		}
+	case *nodes.Float:
+		// The lexer makes integers that do not fit in 32 bits Float;
+		// those that fit in 64 are bigint, or int4 once negated into
+		// range.
+		if i, ok := floatInt(v.Fval); !ok {
+			c.Consttype, c.Constlen = NUMERICOID, -1
+		} else if i != int64(int32(i)) {
+			c.Consttype, c.Constlen, c.Constbyval = INT8OID, 8, true
+		} else {
+			c.Consttype, c.Constlen, c.Constbyval = INT4OID, 4, true
+		}
+	case *nodes.Boolean:
+		c.Consttype, c.Constlen, c.Constbyval = BOOLOID, 1, true
+	case *nodes.BitString:
+		c.Consttype, c.Constlen = BITOID, -1
+	default:
+		c.Consttype, c.Constlen = UNKNOWNOID, -2
+	}
+	return c
+}
+
+// floatInt returns the value of a Float constant that is an integer
+// literal, which may have a sign, a 0x, 0o or 0b prefix and underscores.
+func floatInt(fval string) (int64, bool) {
+	s := strings.ReplaceAll(fval, "_", "")
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	base := 10
+	if len(s) > 2 && s[0] == '0' {
+		switch s[1] {
+		case 'x', 'X':
+			base = 16
+		case 'o', 'O':
+			base = 8
+		case 'b', 'B':
+			base = 2
+		}
+		if base != 10 {
+			s = s[2:]
+		}
+	}
+	u, err := strconv.ParseUint(s, base, 64)
+	if err != nil {
+		return 0, false
+	}
+	if neg {
+		if u > 1<<63 {
+			return 0, false
+		}
+		return -int64(u), true
+	}
+	if u > 1<<63-1 {
+		return 0, false
+	}
+	return int64(u), true
+}
+
+// transformTypeCast analyzes expr::type and CAST(expr AS type).
+func (ps *pstate) transformTypeCast(tc *nodes.TypeCast) (nodes.Node, error) {
+	t, typmod, err := LookupTypeName(ps.cat, tc.TypeName)
+	if err != nil {
+		return nil, err
+	}
+	var expr nodes.Node
+	if arr, ok := tc.Arg.(*nodes.A_ArrayExpr); ok && isArrayType(t) {
+		// ARRAY[...]::type[] gives the elements their type, which also
+		// makes an empty ARRAY[] acceptable.
+		elem, _ := baseType(ps.cat, t.Elem, -1)
+		expr, err = ps.transformArrayExpr(arr, t.Oid, elem)
+	} else {
+		expr, err = ps.transformExprRecurse(tc.Arg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	loc := tc.Location
+	if loc < 0 {
+		loc = tc.TypeName.Location
+	}
+	inType := ExprType(expr)
+	result, err := ps.coerceToTargetType(expr, inType, t.Oid, typmod, nodes.COERCION_EXPLICIT, nodes.COERCE_EXPLICIT_CAST, loc)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, errorf(CodeCannotCoerce, loc, "cannot cast type %s to %s", FormatType(ps.cat, inType, -1), FormatType(ps.cat, t.Oid, typmod))
+	}
+	return result, nil
+}
+
+// transformCollateClause analyzes expr COLLATE name.
+func (ps *pstate) transformCollateClause(cc *nodes.CollateClause) (nodes.Node, error) {
+	arg, err := ps.transformExprRecurse(cc.Arg)
+	if err != nil {
+		return nil, err
+	}
+	typ := ExprType(arg)
+	if typ != nodes.InvalidOid && typ != UNKNOWNOID {
+		if base, _ := baseType(ps.cat, typ, -1); typeCollation(ps.cat, base) == nodes.InvalidOid {
+			return nil, errorf(CodeDatatypeMismatch, cc.Location, "collations are not supported by type %s", FormatType(ps.cat, typ, -1))
+		}
+	}
+	schema, name := qualifiedName(stringList(cc.Collname))
+	coll := ps.cat.LookupCollation(schema, name)
+	if coll == nodes.InvalidOid {
+		return nil, errorf(CodeUndefinedObject, cc.Location, "collation %q for encoding \"UTF8\" does not exist", nameListString(cc.Collname))
+	}
+	return &nodes.CollateExpr{Arg: arg, CollOid: coll, Location: cc.Location}, nil
+}
+
+// transformAExpr analyzes an operator expression of any kind.
+func (ps *pstate) transformAExpr(a *nodes.A_Expr) (nodes.Node, error) {
+	switch a.Kind {
+	case nodes.AEXPR_OP, nodes.AEXPR_LIKE, nodes.AEXPR_ILIKE, nodes.AEXPR_SIMILAR:
+		return ps.transformAExprOp(a)
+	case nodes.AEXPR_OP_ANY, nodes.AEXPR_OP_ALL:
+		l, r, err := ps.transformOperands(a)
+		if err != nil {
+			return nil, err
+		}
+		return ps.makeScalarArrayOp(stringList(a.Name), a.Kind == nodes.AEXPR_OP_ANY, l, r, a.Location)
+	case nodes.AEXPR_DISTINCT, nodes.AEXPR_NOT_DISTINCT:
+		return ps.transformAExprDistinct(a)
+	case nodes.AEXPR_NULLIF:
+		l, r, err := ps.transformOperands(a)
+		if err != nil {
+			return nil, err
+		}
+		op, err := ps.makeOp(stringList(a.Name), l, r, a.Location)
+		if err != nil {
+			return nil, err
+		}
+		if o, ok := op.(*nodes.OpExpr); ok {
+			if o.Opresulttype != BOOLOID && o.Opresulttype != nodes.InvalidOid {
+				return nil, errorf(CodeDatatypeMismatch, a.Location, "NULLIF requires = operator to yield boolean")
+			}
+			args := items(o.Args)
+			return &nodes.NullIfExpr{
+				Opno:         o.Opno,
+				Opfuncid:     o.Opfuncid,
+				Opresulttype: ExprType(args[0]),
+				Opcollid:     ExprCollation(args[0]),
+				Inputcollid:  o.Inputcollid,
+				Args:         o.Args,
+				Location:     a.Location,
+			}, nil
+		}
+		return nil, errorf(CodeDatatypeMismatch, a.Location, "NULLIF requires = operator to yield boolean")
+	case nodes.AEXPR_IN:
+		return ps.transformAExprIn(a)
+	case nodes.AEXPR_BETWEEN, nodes.AEXPR_NOT_BETWEEN, nodes.AEXPR_BETWEEN_SYM, nodes.AEXPR_NOT_BETWEEN_SYM:
+		return ps.transformAExprBetween(a)
+	}
+	return nil, errorf(CodeFeatureNotSupported, a.Location, "unrecognized A_Expr kind: %d", a.Kind)
+}
+
+// transformOperands analyzes the operands of an operator expression.
+func (ps *pstate) transformOperands(a *nodes.A_Expr) (nodes.Node, nodes.Node, error) {
+	l, err := ps.transformExprRecurse(a.Lexpr)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := ps.transformExprRecurse(a.Rexpr)
+	if err != nil {
+		return nil, nil, err
+	}
+	return l, r, nil
+}
+
+// transformAExprOp analyzes a plain operator, including row comparisons
+// such as (a, b) < (c, d).
+func (ps *pstate) transformAExprOp(a *nodes.A_Expr) (nodes.Node, error) {
+	lrow, lok := a.Lexpr.(*nodes.RowExpr)
+	if lok {
+		if rrow, ok := a.Rexpr.(*nodes.RowExpr); ok {
+			largs, err := ps.transformExpressionList(lrow.Args, ps.exprKind, false)
+			if err != nil {
+				return nil, err
+			}
+			rargs, err := ps.transformExpressionList(rrow.Args, ps.exprKind, false)
+			if err != nil {
+				return nil, err
+			}
+			return ps.makeRowComparisonOp(stringList(a.Name), largs, rargs, a.Location)
+		}
+		if sl, ok := a.Rexpr.(*nodes.SubLink); ok && nodes.SubLinkType(sl.SubLinkType) == nodes.EXPR_SUBLINK {
+			// (a, b) op (SELECT ...) is a row comparison with a subquery.
+			s := *sl
+			s.SubLinkType = nodes.ROWCOMPARE_SUBLINK
+			s.Testexpr = a.Lexpr
+			s.OperName = a.Name
+			s.Location = a.Location
+			return ps.transformSubLink(&s)
+		}
+	}
+	l, r, err := ps.transformOperands(a)
+	if err != nil {
+		return nil, err
+	}
+	return ps.makeOp(stringList(a.Name), l, r, a.Location)
+}
+
+// transformAExprDistinct analyzes IS [NOT] DISTINCT FROM.
+func (ps *pstate) transformAExprDistinct(a *nodes.A_Expr) (nodes.Node, error) {
+	var result nodes.Node
+	lrow, lok := a.Lexpr.(*nodes.RowExpr)
+	rrow, rok := a.Rexpr.(*nodes.RowExpr)
+	if lok && rok {
+		largs, err := ps.transformExpressionList(lrow.Args, ps.exprKind, false)
+		if err != nil {
+			return nil, err
+		}
+		rargs, err := ps.transformExpressionList(rrow.Args, ps.exprKind, false)
+		if err != nil {
+			return nil, err
+		}
+		if len(largs) != len(rargs) {
+			return nil, errorf(CodeSyntaxError, a.Location, "unequal number of entries in row expressions")
+		}
+		var ors []nodes.Node
+		for i := range largs {
+			d, err := ps.makeDistinctOp(stringList(a.Name), largs[i], rargs[i], a.Location)
+			if err != nil {
+				return nil, err
+			}
+			ors = append(ors, d)
+		}
+		result = makeBoolExpr(nodes.OR_EXPR, ors, a.Location)
+	} else {
+		l, r, err := ps.transformOperands(a)
+		if err != nil {
+			return nil, err
+		}
+		if result, err = ps.makeDistinctOp(stringList(a.Name), l, r, a.Location); err != nil {
+			return nil, err
+		}
+	}
+	if a.Kind == nodes.AEXPR_NOT_DISTINCT {
+		return makeBoolExpr(nodes.NOT_EXPR, []nodes.Node{result}, a.Location), nil
+	}
+	return result, nil
+}
+
+// makeDistinctOp builds a DistinctExpr from the equality operator.
+func (ps *pstate) makeDistinctOp(name []string, l, r nodes.Node, loc nodes.ParseLoc) (nodes.Node, error) {
+	op, err := ps.makeOp(name, l, r, loc)
+	if err != nil {
+		return nil, err
+	}
+	o, ok := op.(*nodes.OpExpr)
+	if !ok || o.Opresulttype != BOOLOID && o.Opresulttype != nodes.InvalidOid {
+		return nil, errorf(CodeDatatypeMismatch, loc, "IS DISTINCT FROM requires = operator to yield boolean")
+	}
+	return &nodes.DistinctExpr{
+		Opno:         o.Opno,
+		Opfuncid:     o.Opfuncid,
+		Opresulttype: BOOLOID,
+		Inputcollid:  o.Inputcollid,
+		Args:         o.Args,
+		Location:     loc,
+	}, nil
+}
+
+// transformAExprIn analyzes x [NOT] IN (list). Elements that do not refer
+// to columns are combined into x = ANY (ARRAY[...]) when they have a
+// common type; the rest become an OR (or AND, for NOT IN) of comparisons.
+func (ps *pstate) transformAExprIn(a *nodes.A_Expr) (nodes.Node, error) {
+	name := stringList(a.Name)
+	useOr := len(name) == 0 || name[len(name)-1] != "<>"
+	lexpr, err := ps.transformExprRecurse(a.Lexpr)
+	if err != nil {
+		return nil, err
+	}
+	var rexprs, rnonvars, rvars []nodes.Node
+	for _, item := range items(a.Rexpr.(*nodes.List)) {
+		e, err := ps.transformExprRecurse(item)
+		if err != nil {
+			return nil, err
+		}
+		rexprs = append(rexprs, e)
+		if containsVars(e) {
+			rvars = append(rvars, e)
+		} else {
+			rnonvars = append(rnonvars, e)
+		}
+	}
+	var result []nodes.Node
+	if _, isRow := lexpr.(*nodes.RowExpr); !isRow && len(rnonvars) > 1 {
+		all := append([]nodes.Node{lexpr}, rnonvars...)
+		if scalar, ok := ps.commonType(all); ok && scalar != nodes.InvalidOid {
+			if t := ps.cat.TypeByOid(scalar); t != nil && t.Array != nodes.InvalidOid {
+				arr := &nodes.ArrayExpr{ArrayTypeid: t.Array, ElementTypeid: scalar, Elements: &nodes.List{}, Location: -1}
+				for _, e := range rnonvars {
+					c, err := ps.coerceToCommonType(e, scalar, "IN")
+					if err != nil {
+						return nil, err
+					}
+					arr.Elements.Items = append(arr.Elements.Items, c)
+				}
+				arr.ArrayCollid = ps.selectCommonCollation(items(arr.Elements))
+				saop, err := ps.makeScalarArrayOp(name, useOr, lexpr, arr, a.Location)
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, saop)
+				rexprs = rvars
+			}
+		}
+	}
+	for _, r := range rexprs {
+		var cmp nodes.Node
+		lrow, lok := lexpr.(*nodes.RowExpr)
+		rrow, rok := r.(*nodes.RowExpr)
+		if lok && rok {
+			cmp, err = ps.makeRowComparisonOp(name, items(lrow.Args), items(rrow.Args), a.Location)
+		} else {
+			cmp, err = ps.makeOp(name, lexpr, r, a.Location)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if cmp, err = ps.coerceToBoolean(cmp, "IN"); err != nil {
+			return nil, err
+		}
+		result = append(result, cmp)
+	}
+	if len(result) == 1 {
+		return result[0], nil
+	}
+	op := nodes.OR_EXPR
+	if !useOr {
+		op = nodes.AND_EXPR
+	}
+	return makeBoolExpr(op, result, a.Location), nil
+}
+
+// transformAExprBetween rewrites the BETWEEN forms into comparisons and
+// analyzes those.
+func (ps *pstate) transformAExprBetween(a *nodes.A_Expr) (nodes.Node, error) {
+	bounds := items(a.Rexpr.(*nodes.List))
+	if len(bounds) != 2 {
+		return nil, errorf(CodeSyntaxError, a.Location, "BETWEEN requires two bounds")
+	}
+	x, lo, hi := a.Lexpr, bounds[0], bounds[1]
+	op := func(name string, l, r nodes.Node) nodes.Node {
+		return &nodes.A_Expr{Kind: nodes.AEXPR_OP, Name: makeStringList([]string{name}), Lexpr: l, Rexpr: r, Location: a.Location}
+	}
+	and := func(args ...nodes.Node) nodes.Node {
+		return &nodes.BoolExpr{Boolop: nodes.AND_EXPR, Args: &nodes.List{Items: args}, Location: a.Location}
+	}
+	or := func(args ...nodes.Node) nodes.Node {
+		return &nodes.BoolExpr{Boolop: nodes.OR_EXPR, Args: &nodes.List{Items: args}, Location: a.Location}
+	}
+	var rewritten nodes.Node
+	switch a.Kind {
+	case nodes.AEXPR_BETWEEN:
+		rewritten = and(op(">=", x, lo), op("<=", x, hi))
+	case nodes.AEXPR_NOT_BETWEEN:
+		rewritten = or(op("<", x, lo), op(">", x, hi))
+	case nodes.AEXPR_BETWEEN_SYM:
+		rewritten = or(and(op(">=", x, lo), op("<=", x, hi)), and(op(">=", x, hi), op("<=", x, lo)))
+	default:
+		rewritten = and(or(op("<", x, lo), op(">", x, hi)), or(op("<", x, hi), op(">", x, lo)))
+	}
+	return ps.transformExprRecurse(rewritten)
+}
+
+// transformBoolExpr analyzes AND, OR and NOT.
+func (ps *pstate) transformBoolExpr(b *nodes.BoolExpr) (nodes.Node, error) {
+	name := "AND"
+	switch b.Boolop {
+	case nodes.OR_EXPR:
+		name = "OR"
+	case nodes.NOT_EXPR:
+		name = "NOT"
+	}
+	var args []nodes.Node
+	for _, item := range items(b.Args) {
+		e, err := ps.transformExprRecurse(item)
+		if err != nil {
+			return nil, err
+		}
+		if e, err = ps.coerceToBoolean(e, name); err != nil {
+			return nil, err
+		}
+		args = append(args, e)
+	}
+	return makeBoolExpr(b.Boolop, args, b.Location), nil
+}
+
+func makeBoolExpr(op nodes.BoolExprType, args []nodes.Node, loc nodes.ParseLoc) *nodes.BoolExpr {
+	return &nodes.BoolExpr{Boolop: op, Args: &nodes.List{Items: args}, Location: loc}
+}
+
+// transformSubLink analyzes a subquery in an expression.
+func (ps *pstate) transformSubLink(sl *nodes.SubLink) (nodes.Node, error) {
+	switch ps.exprKind {
+	case exprKindGroupBy:
+		// Allowed, as in PostgreSQL.
+	case exprKindIndexExpression, exprKindIndexPredicate:
+		return nil, errorf(CodeFeatureNotSupported, sl.Location, "cannot use subquery in %s", ps.exprKind)
+	}
+	q, err := ps.subAnalyze(sl.Subselect, nil, false, true)
+	if err != nil {
+		return nil, err
+	}
+	if q.CommandType != nodes.CMD_SELECT {
+		return nil, errorf(CodeSyntaxError, sl.Location, "subquery must be a SELECT")
+	}
+	ps.hasSubLinks = true
+	result := &nodes.SubLink{SubLinkType: sl.SubLinkType, Subselect: q, Location: sl.Location}
+	var cols []*nodes.TargetEntry
+	for _, item := range items(q.TargetList) {
+		if tle := item.(*nodes.TargetEntry); !tle.Resjunk {
+			cols = append(cols, tle)
+		}
+	}
+	switch nodes.SubLinkType(sl.SubLinkType) {
+	case nodes.EXISTS_SUBLINK:
+		return result, nil
+	case nodes.EXPR_SUBLINK, nodes.ARRAY_SUBLINK:
+		if len(cols) != 1 {
+			return nil, errorf(CodeSyntaxError, sl.Location, "subquery must return only one column")
+		}
+		if nodes.SubLinkType(sl.SubLinkType) == nodes.ARRAY_SUBLINK {
+			if t := ps.cat.TypeByOid(ExprType(cols[0].Expr)); t != nil && t.Array == nodes.InvalidOid && !isArrayType(t) {
+				return nil, errorf(CodeUndefinedObject, sl.Location, "could not find array type for data type %s", FormatType(ps.cat, t.Oid, -1))
+			}
+		}
+		return result, nil
+	case nodes.MULTIEXPR_SUBLINK:
+		return result, nil
+	}
+
+	// ANY, ALL and row comparisons: compare the left-hand expressions
+	// with Params standing for the subquery's columns.
+	var left []nodes.Node
+	if row, ok := sl.Testexpr.(*nodes.RowExpr); ok {
+		if left, err = ps.transformExpressionList(row.Args, ps.exprKind, false); err != nil {
+			return nil, err
+		}
+	} else {
+		e, err := ps.transformExprRecurse(sl.Testexpr)
+		if err != nil {
+			return nil, err
+		}
+		left = []nodes.Node{e}
+	}
+	var right []nodes.Node
+	for _, tle := range cols {
+		right = append(right, &nodes.Param{
+			Paramkind:   nodes.PARAM_SUBLINK,
+			Paramid:     int(tle.Resno),
+			Paramtype:   ExprType(tle.Expr),
+			Paramtypmod: ExprTypmod(tle.Expr),
+			Paramcollid: ExprCollation(tle.Expr),
+			Location:    -1,
+		})
+	}
+	if len(left) < len(right) {
+		return nil, errorf(CodeSyntaxError, sl.Location, "subquery has too many columns")
+	}
+	if len(left) > len(right) {
+		return nil, errorf(CodeSyntaxError, sl.Location, "subquery has too few columns")
+	}
+	opname := stringList(sl.OperName)
+	if len(opname) == 0 {
+		opname = []string{"="}
+	}
+	result.OperName = sl.OperName
+	if len(left) == 1 {
+		result.Testexpr, err = ps.makeOp(opname, left[0], right[0], sl.Location)
+	} else {
+		result.Testexpr, err = ps.makeRowComparisonOp(opname, left, right, sl.Location)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// transformCaseExpr analyzes CASE. A CASE with a test expression compares
+// it with each WHEN value through a CaseTestExpr placeholder.
+func (ps *pstate) transformCaseExpr(c *nodes.CaseExpr) (nodes.Node, error) {
+	result := &nodes.CaseExpr{Location: c.Location}
+	var placeholder *nodes.CaseTestExpr
+	if c.Arg != nil {
+		arg, err := ps.transformExprRecurse(c.Arg)
+		if err != nil {
+			return nil, err
+		}
+		if ExprType(arg) == UNKNOWNOID {
+			if arg, err = ps.coerceToCommonType(arg, TEXTOID, "CASE"); err != nil {
+				return nil, err
+			}
+		}
+		result.Arg = arg
+		placeholder = &nodes.CaseTestExpr{TypeId: ExprType(arg), TypeMod: ExprTypmod(arg), Collation: ExprCollation(arg)}
+	}
+	var whens []*nodes.CaseWhen
+	for _, item := range items(c.Args) {
+		w := item.(*nodes.CaseWhen)
+		cond := w.Expr
+		if placeholder != nil {
+			cond = &nodes.A_Expr{Kind: nodes.AEXPR_OP, Name: makeStringList([]string{"="}), Lexpr: placeholder, Rexpr: w.Expr, Location: exprLocation(w.Expr)}
+		}
+		e, err := ps.transformExprRecurse(cond)
+		if err != nil {
+			return nil, err
+		}
+		if e, err = ps.coerceToBoolean(e, "CASE/WHEN"); err != nil {
+			return nil, err
+		}
+		r, err := ps.transformExprRecurse(w.Result)
+		if err != nil {
+			return nil, err
+		}
+		whens = append(whens, &nodes.CaseWhen{Expr: e, Result: r, Location: w.Location})
+	}
+	def := c.Defresult
+	if def == nil {
+		def = &nodes.A_Const{Isnull: true, Location: -1}
+	}
+	defresult, err := ps.transformExprRecurse(def)
+	if err != nil {
+		return nil, err
+	}
+	results := []nodes.Node{defresult}
+	for _, w := range whens {
+		results = append(results, w.Result)
+	}
+	typ, err := ps.selectCommonType(results, "CASE")
+	if err != nil {
+		return nil, err
+	}
+	if result.Defresult, err = ps.coerceToCommonType(defresult, typ, "CASE"); err != nil {
+		return nil, err
+	}
+	result.Args = &nodes.List{}
+	for _, w := range whens {
+		if w.Result, err = ps.coerceToCommonType(w.Result, typ, "CASE"); err != nil {
+			return nil, err
+		}
+		result.Args.Items = append(result.Args.Items, w)
+	}
+	result.Casetype = typ
+	results = []nodes.Node{result.Defresult}
+	for _, w := range whens {
+		results = append(results, w.Result)
+	}
+	result.Casecollid = ps.selectCommonCollation(results)
+	return result, nil
+}
+
+// transformRowExpr analyzes ROW(...) and (a, b, ...).
+func (ps *pstate) transformRowExpr(r *nodes.RowExpr) (nodes.Node, error) {
+	args, err := ps.transformExpressionList(r.Args, ps.exprKind, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) > 1664 {
+		return nil, errorf(CodeTooManyColumns, r.Location, "ROW expressions can have at most %d entries", 1664)
+	}
+	var names []string
+	for i := range args {
+		names = append(names, "f"+itoa(i+1))
+	}
+	return &nodes.RowExpr{Args: nodeList(args), RowTypeid: RECORDOID, RowFormat: r.RowFormat, Colnames: makeStringList(names), Location: r.Location}, nil
+}
+
+// transformArrayExpr analyzes ARRAY[...]. arrayType and elemType, if
+// set, are the type the array is being cast to.
+func (ps *pstate) transformArrayExpr(a *nodes.A_ArrayExpr, arrayType, elemType nodes.Oid) (nodes.Node, error) {
+	var elems []nodes.Node
+	multidims := false
+	for _, item := range items(a.Elements) {
+		var e nodes.Node
+		var err error
+		if sub, ok := item.(*nodes.A_ArrayExpr); ok {
+			e, err = ps.transformArrayExpr(sub, arrayType, elemType)
+			multidims = true
+		} else {
+			e, err = ps.transformExprRecurse(item)
+		}
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, e)
+	}
+	var coerceType nodes.Oid
+	explicit := arrayType != nodes.InvalidOid
+	switch {
+	case explicit:
+		coerceType = elemType
+		if multidims {
+			coerceType = arrayType
+		}
+	case len(elems) == 0:
+		return nil, errorf(CodeIndeterminateDatatype, a.Location, "cannot determine type of empty array").
+			withHint("Explicitly cast to the desired type, for example ARRAY[]::integer[].")
+	default:
+		var err error
+		if coerceType, err = ps.selectCommonType(elems, "ARRAY"); err != nil {
+			return nil, err
+		}
+		if t := ps.cat.TypeByOid(coerceType); isArrayType(t) {
+			multidims = true
+		}
+		if multidims {
+			arrayType = coerceType
+			if t := ps.cat.TypeByOid(coerceType); t != nil {
+				elemType = t.Elem
+			}
+		} else {
+			elemType = coerceType
+			if t := ps.cat.TypeByOid(coerceType); t != nil {
+				arrayType = t.Array
+			}
+			if arrayType == nodes.InvalidOid && coerceType != nodes.InvalidOid {
+				return nil, errorf(CodeUndefinedObject, a.Location, "could not find array type for data type %s", FormatType(ps.cat, coerceType, -1))
+			}
+		}
+	}
+	for i, e := range elems {
+		if !explicit {
+			var err error
+			if elems[i], err = ps.coerceToCommonType(e, coerceType, "ARRAY"); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		// Elements of an array being cast are cast to its element type.
+		c, err := ps.coerceToTargetType(e, ExprType(e), coerceType, -1, nodes.COERCION_EXPLICIT, nodes.COERCE_EXPLICIT_CAST, -1)
+		if err != nil {
+			return nil, err
+		}
+		if c == nil {
+			return nil, errorf(CodeCannotCoerce, exprLocation(e), "cannot cast type %s to %s", FormatType(ps.cat, ExprType(e), -1), FormatType(ps.cat, coerceType, -1))
+		}
+		elems[i] = c
+	}
+	return &nodes.ArrayExpr{
+		ArrayTypeid:   arrayType,
+		ArrayCollid:   ps.selectCommonCollation(elems),
+		ElementTypeid: elemType,
+		Elements:      nodeList(elems),
+		Multidims:     multidims,
+		Location:      a.Location,
+	}, nil
+}
+
+// transformCoalesceExpr analyzes COALESCE.
+func (ps *pstate) transformCoalesceExpr(c *nodes.CoalesceExpr) (nodes.Node, error) {
+	args, typ, err := ps.transformCommonArgs(c.Args, "COALESCE")
+	if err != nil {
+		return nil, err
+	}
+	return &nodes.CoalesceExpr{Coalescetype: typ, Coalescecollid: ps.selectCommonCollation(args), Args: nodeList(args), Location: c.Location}, nil
+}
+
+// transformMinMaxExpr analyzes GREATEST and LEAST.
+func (ps *pstate) transformMinMaxExpr(m *nodes.MinMaxExpr) (nodes.Node, error) {
+	name := "GREATEST"
+	if m.Op == nodes.IS_LEAST {
+		name = "LEAST"
+	}
+	args, typ, err := ps.transformCommonArgs(m.Args, name)
+	if err != nil {
+		return nil, err
+	}
+	return &nodes.MinMaxExpr{Minmaxtype: typ, Minmaxcollid: ps.selectCommonCollation(args), Op: m.Op, Args: nodeList(args), Location: m.Location}, nil
+}
+
+// transformCommonArgs analyzes arguments that must share a common type
+// and coerces them to it.
+func (ps *pstate) transformCommonArgs(l *nodes.List, context string) ([]nodes.Node, nodes.Oid, error) {
+	var args []nodes.Node
+	for _, item := range items(l) {
+		e, err := ps.transformExprRecurse(item)
+		if err != nil {
+			return nil, 0, err
+		}
+		args = append(args, e)
+	}
+	typ, err := ps.selectCommonType(args, context)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range args {
+		if args[i], err = ps.coerceToCommonType(args[i], typ, context); err != nil {
+			return nil, 0, err
+		}
+	}
+	return args, typ, nil
+}
+
+// transformBooleanTest analyzes IS [NOT] TRUE/FALSE/UNKNOWN.
+func (ps *pstate) transformBooleanTest(b *nodes.BooleanTest) (nodes.Node, error) {
+	names := map[nodes.BoolTestType]string{
+		nodes.IS_TRUE:        "IS TRUE",
+		nodes.IS_NOT_TRUE:    "IS NOT TRUE",
+		nodes.IS_FALSE:       "IS FALSE",
+		nodes.IS_NOT_FALSE:   "IS NOT FALSE",
+		nodes.IS_UNKNOWN:     "IS UNKNOWN",
+		nodes.IS_NOT_UNKNOWN: "IS NOT UNKNOWN",
+	}
+	arg, err := ps.transformExprRecurse(b.Arg)
+	if err != nil {
+		return nil, err
+	}
+	if arg, err = ps.coerceToBoolean(arg, names[b.Booltesttype]); err != nil {
+		return nil, err
+	}
+	return &nodes.BooleanTest{Arg: arg, Booltesttype: b.Booltesttype, Location: b.Location}, nil
+}
+
+// isRowType reports whether typ is a composite type or record.
+func (ps *pstate) isRowType(typ nodes.Oid) bool {
+	if typ == RECORDOID {
+		return true
+	}
+	typ, _ = baseType(ps.cat, typ, -1)
+	t := ps.cat.TypeByOid(typ)
+	return t != nil && t.Kind == 'c'
+}
+
+// transformIndirection applies subscripts and field selections to an
+// analyzed expression.
+func (ps *pstate) transformIndirection(expr nodes.Node, indirection *nodes.List) (nodes.Node, error) {
+	var subscripts []*nodes.A_Indices
+	for _, item := range items(indirection) {
+		switch i := item.(type) {
+		case *nodes.A_Indices:
+			subscripts = append(subscripts, i)
+		case *nodes.A_Star:
+			return nil, errorf(CodeFeatureNotSupported, exprLocation(expr), "row expansion via \"*\" is not supported here")
+		case *nodes.String:
+			if subscripts != nil {
+				var err error
+				if expr, err = ps.transformContainerSubscripts(expr, subscripts); err != nil {
+					return nil, err
+				}
+				subscripts = nil
+			}
+			var err error
+			if expr, err = ps.fieldSelect(expr, i.Sval, exprLocation(expr)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if subscripts != nil {
+		return ps.transformContainerSubscripts(expr, subscripts)
+	}
+	return expr, nil
+}
+
+// transformContainerSubscripts builds a SubscriptingRef fetching from an
+// array or jsonb value (transformContainerSubscripts). Assignments fill in
+// Refassgnexpr afterwards.
+func (ps *pstate) transformContainerSubscripts(container nodes.Node, indices []*nodes.A_Indices) (*nodes.SubscriptingRef, error) {
+	ctype := ExprType(container)
+	typmod := ExprTypmod(container)
+	base, _ := baseType(ps.cat, ctype, typmod)
+	isSlice := false
+	for _, ind := range indices {
+		if ind.IsSlice {
+			isSlice = true
+		}
+	}
+	ref := &nodes.SubscriptingRef{Refcontainertype: base, Reftypmod: typmod, Refcollid: ExprCollation(container), Refexpr: container}
+	isJSON := base == JSONBOID
+	t := ps.cat.TypeByOid(base)
+	switch {
+	case base == nodes.InvalidOid:
+	case isJSON:
+		ref.Refelemtype, ref.Reftypmod = JSONBOID, -1
+		if isSlice {
+			return nil, errorf(CodeDatatypeMismatch, exprLocation(container), "jsonb subscript does not support slices")
+		}
+	case isArrayType(t):
+		ref.Refelemtype = t.Elem
+	default:
+		return nil, errorf(CodeDatatypeMismatch, exprLocation(container), "cannot subscript type %s because it does not support subscripting", FormatType(ps.cat, ctype, -1))
+	}
+	ref.Refrestype = ref.Refelemtype
+	if isSlice {
+		ref.Refrestype = base
+		ref.Reflowerindexpr = &nodes.List{}
+	}
+	ref.Refupperindexpr = &nodes.List{}
+	subscript := func(n nodes.Node) (nodes.Node, error) {
+		if n == nil {
+			return nil, nil
+		}
+		e, err := ps.transformExprRecurse(n)
+		if err != nil {
+			return nil, err
+		}
+		if isJSON {
+			return e, nil
+		}
+		c, err := ps.coerceToTargetType(e, ExprType(e), INT4OID, -1, nodes.COERCION_ASSIGNMENT, nodes.COERCE_IMPLICIT_CAST, -1)
+		if err != nil {
+			return nil, err
+		}
+		if c == nil {
+			return nil, errorf(CodeDatatypeMismatch, exprLocation(e), "array subscript must have type integer")
+		}
+		return c, nil
+	}
+	for _, ind := range indices {
+		if isSlice {
+			lo := ind.Lidx
+			if !ind.IsSlice {
+				// A single subscript in a slice is an upper bound with
+				// lower bound 1.
+				lo = &nodes.A_Const{Val: &nodes.Integer{Ival: 1}, Location: -1}
+			}
+			l, err := subscript(lo)
+			if err != nil {
+				return nil, err
+			}
+			ref.Reflowerindexpr.Items = append(ref.Reflowerindexpr.Items, l)
+		}
+		u, err := subscript(ind.Uidx)
+		if err != nil {
+			return nil, err
+		}
+		ref.Refupperindexpr.Items = append(ref.Refupperindexpr.Items, u)
+	}
+	return ref, nil
+}
+
+// fieldSelect selects a field of a composite value (ParseComplexProjection).
+// A field of a whole-row Var becomes a plain Var for the column.
+func (ps *pstate) fieldSelect(expr nodes.Node, name string, loc nodes.ParseLoc) (nodes.Node, error) {
+	if v, ok := expr.(*nodes.Var); ok && v.Varattno == 0 {
+		if p := ps.levelUp(int(v.Varlevelsup)); p != nil && v.Varno > 0 && v.Varno <= len(p.rtable) {
+			rte := p.rtable[v.Varno-1]
+			for i, cn := range stringList(rte.Eref.Colnames) {
+				if cn != name {
+					continue
+				}
+				typ, typmod, coll := p.rteColumn(rte, i)
+				return &nodes.Var{
+					Varno:       v.Varno,
+					Varattno:    nodes.AttrNumber(i + 1),
+					Vartype:     typ,
+					Vartypmod:   typmod,
+					Varcollid:   coll,
+					Varlevelsup: v.Varlevelsup,
+					Varnosyn:    uint32(v.Varno),
+					Varattnosyn: nodes.AttrNumber(i + 1),
+					Location:    loc,
+				}, nil
+			}
+		}
+	}
+	typ := ExprType(expr)
+	if typ == nodes.InvalidOid {
+		return &nodes.FieldSelect{Arg: expr, Resulttypmod: -1}, nil
+	}
+	if typ == RECORDOID {
+		return nil, errorf(CodeUndefinedColumn, loc, "could not identify column %q in record data type", name)
+	}
+	base, _ := baseType(ps.cat, typ, -1)
+	t := ps.cat.TypeByOid(base)
+	if t == nil || t.Kind != 'c' {
+		return nil, errorf(CodeWrongObjectType, loc, "column notation .%s applied to type %s, which is not a composite type", name, FormatType(ps.cat, typ, -1))
+	}
+	if rel := ps.cat.RelationByOid(t.Relid); rel != nil {
+		if col, attno := rel.Column(name); col != nil {
+			return &nodes.FieldSelect{Arg: expr, Fieldnum: attno, Resulttype: col.Type, Resulttypmod: col.Typmod, Resultcollid: col.Collation}, nil
+		}
+	}
+	return nil, errorf(CodeUndefinedColumn, loc, "column %q not found in data type %s", name, FormatType(ps.cat, typ, -1))
+}
+
+// rteColumn returns the type, typmod and collation of column i (from 0)
+// of a range table entry at this level (expandRTE).
+func (ps *pstate) rteColumn(rte *nodes.RangeTblEntry, i int) (nodes.Oid, int32, nodes.Oid) {
+	switch rte.Rtekind {
+	case nodes.RTE_RELATION:
+		if rel := ps.cat.RelationByOid(rte.Relid); rel != nil && i < len(rel.Columns) {
+			c := rel.Columns[i]
+			return c.Type, c.Typmod, c.Collation
+		}
+	case nodes.RTE_SUBQUERY:
+		n := 0
+		for _, item := range items(rte.Subquery.TargetList) {
+			tle := item.(*nodes.TargetEntry)
+			if tle.Resjunk {
+				continue
+			}
+			if n == i {
+				return ExprType(tle.Expr), ExprTypmod(tle.Expr), ExprCollation(tle.Expr)
+			}
+			n++
+		}
+	case nodes.RTE_JOIN:
+		if vars := items(rte.Joinaliasvars); i < len(vars) {
+			return ExprType(vars[i]), ExprTypmod(vars[i]), ExprCollation(vars[i])
+		}
+	case nodes.RTE_FUNCTION:
+		for _, item := range items(rte.Functions) {
+			rtf := item.(*nodes.RangeTblFunction)
+			if i < rtf.Funccolcount {
+				if rtf.Funccoltypes != nil {
+					return rtf.Funccoltypes.Items[i], int32(rtf.Funccoltypmods.Items[i]), rtf.Funccolcollations.Items[i]
+				}
+				t := ExprType(rtf.Funcexpr)
+				if typ := ps.cat.TypeByOid(t); typ != nil && typ.Kind == 'c' {
+					if rel := ps.cat.RelationByOid(typ.Relid); rel != nil && i < len(rel.Columns) {
+						c := rel.Columns[i]
+						return c.Type, c.Typmod, c.Collation
+					}
+				}
+				return t, ExprTypmod(rtf.Funcexpr), ExprCollation(rtf.Funcexpr)
+			}
+			i -= rtf.Funccolcount
+		}
+		if rte.Funcordinality && i == 0 {
+			return INT8OID, -1, nodes.InvalidOid
+		}
+	case nodes.RTE_CTE:
+		if rte.Coltypes != nil && i < len(rte.Coltypes.Items) {
+			return rte.Coltypes.Items[i], int32(rte.Coltypmods.Items[i]), rte.Colcollations.Items[i]
+		}
+		if cte, _ := ps.scanNameSpaceForCTE(rte.Ctename); cte != nil && cte.Ctecoltypes != nil && i < len(cte.Ctecoltypes.Items) {
+			return cte.Ctecoltypes.Items[i], int32(cte.Ctecoltypmods.Items[i]), cte.Ctecolcollations.Items[i]
+		}
+	default:
+		if rte.Coltypes != nil && i < len(rte.Coltypes.Items) {
+			return rte.Coltypes.Items[i], int32(rte.Coltypmods.Items[i]), rte.Colcollations.Items[i]
+		}
+	}
+	return nodes.InvalidOid, -1, nodes.InvalidOid
+}
+
+// transformGroupingFunc analyzes GROUPING(...). Its arguments are matched
+// with the GROUP BY expressions by parseCheckAggregates.
+func (ps *pstate) transformGroupingFunc(g *nodes.GroupingFunc) (nodes.Node, error) {
+	if items(g.Args) == nil {
+		return nil, errorf(CodeSyntaxError, g.Location, "GROUPING must have at least one argument")
+	}
+	if len(g.Args.Items) > 31 {
+		return nil, errorf(CodeTooManyColumns, g.Location, "GROUPING must have fewer than 32 arguments")
+	}
+	var args []nodes.Node
+	for _, item := range g.Args.Items {
+		e, err := ps.transformExprRecurse(item)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, e)
+	}
+	result := &nodes.GroupingFunc{Args: nodeList(args), Location: g.Location}
+	levels := minVarLevel(args)
+	if levels < 0 {
+		levels = 0
+	}
+	result.Agglevelsup = uint32(levels)
+	owner := ps.levelUp(levels)
+	if msg := aggPlacementError(owner.exprKind, "grouping operations"); msg != "" {
+		return nil, errorf(CodeGroupingError, g.Location, "%s", msg)
+	}
+	owner.hasAggs = true
+	return result, nil
+}
+
+// transformMultiAssignRef analyzes one column of UPDATE ... SET (a, b) =
+// source. A sub-SELECT source becomes a MULTIEXPR SubLink, referenced by
+// PARAM_MULTIEXPR Params; a ROW source is split into its fields.
+func (ps *pstate) transformMultiAssignRef(m *nodes.MultiAssignRef) (nodes.Node, error) {
+	if ps.exprKind != exprKindUpdateSource {
+		return nil, errorf(CodeSyntaxError, exprLocation(m.Source), "multiple-column assignment is only allowed in UPDATE")
+	}
+	if m.Colno == 1 {
+		ps.multiAssign = nil
+		ps.multiAssignRow = nil
+		switch src := m.Source.(type) {
+		case *nodes.SubLink:
+			if nodes.SubLinkType(src.SubLinkType) != nodes.EXPR_SUBLINK {
+				break
+			}
+			s := *src
+			s.SubLinkType = nodes.MULTIEXPR_SUBLINK
+			e, err := ps.transformSubLink(&s)
+			if err != nil {
+				return nil, err
+			}
+			sl := e.(*nodes.SubLink)
+			n := 0
+			for _, item := range items(sl.Subselect.(*nodes.Query).TargetList) {
+				if !item.(*nodes.TargetEntry).Resjunk {
+					n++
+				}
+			}
+			if n != m.Ncolumns {
+				return nil, errorf(CodeSyntaxError, sl.Location, "number of columns does not match number of values")
+			}
+			ps.multiAssignCount++
+			sl.SubLinkId = ps.multiAssignCount
+			ps.multiAssign = sl
+		case *nodes.RowExpr:
+			e, err := ps.transformRowExpr(src)
+			if err != nil {
+				return nil, err
+			}
+			row := e.(*nodes.RowExpr)
+			if row.Args.Len() != m.Ncolumns {
+				return nil, errorf(CodeSyntaxError, row.Location, "number of columns does not match number of values")
+			}
+			ps.multiAssignRow = row
+		}
+		if ps.multiAssign == nil && ps.multiAssignRow == nil {
+			return nil, errorf(CodeSyntaxError, exprLocation(m.Source), "source for a multiple-column UPDATE item must be a sub-SELECT or ROW() expression")
+		}
+	}
+	if ps.multiAssignRow != nil {
+		return ps.multiAssignRow.Args.Items[m.Colno-1], nil
+	}
+	sl := ps.multiAssign
+	var tle *nodes.TargetEntry
+	for _, item := range items(sl.Subselect.(*nodes.Query).TargetList) {
+		if t := item.(*nodes.TargetEntry); int(t.Resno) == m.Colno {
+			tle = t
+		}
+	}
+	return &nodes.Param{
+		Paramkind:   nodes.PARAM_MULTIEXPR,
+		Paramid:     sl.SubLinkId<<16 | m.Colno,
+		Paramtype:   ExprType(tle.Expr),
+		Paramtypmod: ExprTypmod(tle.Expr),
+		Paramcollid: ExprCollation(tle.Expr),
+		Location:    exprLocation(tle.Expr),
+	}, nil
+}
+
+// containsVars reports whether an expression refers to any column of
+// this or an outer query level.
+func containsVars(n nodes.Node) bool {
+	found := false
+	walkLevels(n, 0, func(n nodes.Node, depth int) bool {
+		if v, ok := n.(*nodes.Var); ok && int(v.Varlevelsup) >= depth {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// containsLocalVars reports whether an expression refers to any column
+// of this query level (contain_vars_of_level(n, 0)).
+func containsLocalVars(n nodes.Node) bool {
+	found := false
+	walkLevels(n, 0, func(n nodes.Node, depth int) bool {
+		if v, ok := n.(*nodes.Var); ok && int(v.Varlevelsup) == depth {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// minVarLevel returns the smallest number of query levels up that a Var
+// in exprs refers to, relative to exprs, or -1 if there is none.
+func minVarLevel(exprs []nodes.Node) int {
+	min := -1
+	for _, e := range exprs {
+		walkLevels(e, 0, func(n nodes.Node, depth int) bool {
+			if v, ok := n.(*nodes.Var); ok {
+				if l := int(v.Varlevelsup) - depth; l >= 0 && (min < 0 || l < min) {
+					min = l
+				}
+			}
+			return true
+		})
+	}
+	return min
+}
+
+// walkLevels walks an analyzed expression like nodes.Walk, passing the
+// number of subquery levels below the starting point of each node.
+func walkLevels(n nodes.Node, depth int, fn func(n nodes.Node, depth int) bool) {
+	if n == nil {
+		return
+	}
+	nodes.Walk(n, func(c nodes.Node) bool {
+		if q, ok := c.(*nodes.Query); ok && c != n {
+			walkLevels(q, depth+1, fn)
+			return false
+		}
+		return fn(c, depth)
+	})
+}
+
+// isStarRef reports whether a ColumnRef ends in "*".
+func isStarRef(cr *nodes.ColumnRef) bool {
+	fields := items(cr.Fields)
+	if len(fields) == 0 {
+		return false
+	}
+	_, ok := fields[len(fields)-1].(*nodes.A_Star)
+	return ok
+}
+
+// exprLocation returns the token location of a node, or -1 if it has
+// none (exprLocation).
+func exprLocation(n nodes.Node) nodes.ParseLoc {
+	if n == nil {
+		return -1
+	}
+	switch e := n.(type) {
+	case *nodes.List:
+		for _, item := range e.Items {
+			if loc := exprLocation(item); loc >= 0 {
+				return loc
+			}
+		}
+		return -1
+	case *nodes.TargetEntry:
+		return exprLocation(e.Expr)
+	case *nodes.ResTarget:
+		return e.Location
+	}
+	v := reflect.ValueOf(n)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct {
+		if f := v.FieldByName("Location"); f.IsValid() && f.Type() == locationType {
+			return nodes.ParseLoc(f.Int())
+		}
+	}
+	return -1
+}
+
+func itoa(i int) string {
+	return strconv.Itoa(i)
+}
@@ -0,0 +1,554 @@
+package sema
+
+import (
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// transformFromClause analyzes the FROM clause, adding its items to the
+// range table, join list and namespace.
+func (ps *pstate) transformFromClause(from *nodes.List) error {
+	for _, n := range items(from) {
+		jt, _, ns, err := ps.transformFromClauseItem(n)
+		if err != nil {
+			return err
+		}
+		if err := checkNameSpaceConflicts(ps.namespace, ns); err != nil {
+			return err
+		}
+		// Later items may refer to this one only with LATERAL.
+		for _, it := range ns {
+			it.lateralOnly, it.lateralOK = true, true
+		}
+		ps.joinlist = append(ps.joinlist, jt)
+		ps.namespace = append(ps.namespace, ns...)
+	}
+	for _, it := range ps.namespace {
+		it.lateralOnly, it.lateralOK = false, true
+	}
+	return nil
+}
+
+// transformFromClauseItem analyzes one FROM-clause item. It returns the
+// join tree node, the namespace item for the item as a whole, and the
+// namespace items it makes visible.
+func (ps *pstate) transformFromClauseItem(n nodes.Node) (nodes.Node, *nsItem, []*nsItem, error) {
+	var it *nsItem
+	var err error
+	switch r := n.(type) {
+	case *nodes.RangeVar:
+		it, err = ps.transformTableEntry(r)
+	case *nodes.RangeSubselect:
+		it, err = ps.transformRangeSubselect(r)
+	case *nodes.RangeFunction:
+		it, err = ps.transformRangeFunction(r)
+	case *nodes.RangeTableSample:
+		it, err = ps.transformRangeTableSample(r)
+	case *nodes.RangeTableFunc:
+		it, err = ps.transformRangeTableFunc(r)
+	case *nodes.JoinExpr:
+		return ps.transformJoin(r)
+	default:
+		return nil, nil, nil, errorf(CodeFeatureNotSupported, exprLocation(n), "unsupported FROM-clause item %T", n)
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return &nodes.RangeTblRef{Rtindex: it.rtindex}, it, []*nsItem{it}, nil
+}
+
+// transformTableEntry adds a table, view or WITH query reference.
+func (ps *pstate) transformTableEntry(rv *nodes.RangeVar) (*nsItem, error) {
+	if rv.Schemaname == "" {
+		if cte, levels := ps.scanNameSpaceForCTE(rv.Relname); cte != nil {
+			return ps.addRangeTableEntryForCTE(cte, levels, rv)
+		}
+	}
+	rel, err := ps.openRelation(rv)
+	if err != nil {
+		return nil, err
+	}
+	return ps.addRangeTableEntry(rel, rv.Alias, rv.Inh, true, nodes.AccessShareLock)
+}
+
+// transformRangeSubselect adds a sub-SELECT in FROM.
+func (ps *pstate) transformRangeSubselect(r *nodes.RangeSubselect) (*nsItem, error) {
+	saved := ps.exprKind
+	ps.exprKind = exprKindFromSubselect
+	q, err := ps.subAnalyze(r.Subquery, nil, r.Lateral, true)
+	ps.exprKind = saved
+	if err != nil {
+		return nil, err
+	}
+	if q.CommandType != nodes.CMD_SELECT {
+		return nil, errorf(CodeSyntaxError, -1, "expected SELECT query from subquery in FROM")
+	}
+	return ps.addRangeTableEntryForSubquery(q, r.Alias, "unnamed_subquery", r.Lateral, true)
+}
+
+// transformRangeFunction adds a function call, or ROWS FROM of several,
+// in FROM.
+func (ps *pstate) transformRangeFunction(r *nodes.RangeFunction) (*nsItem, error) {
+	// Functions in FROM may refer to the items before them whether or not
+	// they are marked LATERAL; they are lateral if they do.
+	savedKind, savedLateral := ps.exprKind, ps.lateralActive
+	ps.exprKind, ps.lateralActive = exprKindFromFunction, true
+	defer func() { ps.exprKind, ps.lateralActive = savedKind, savedLateral }()
+
+	rte := &nodes.RangeTblEntry{
+		Rtekind:        nodes.RTE_FUNCTION,
+		Alias:          r.Alias,
+		Functions:      &nodes.List{},
+		Funcordinality: r.Ordinality,
+		Lateral:        r.Lateral,
+		InFromCl:       true,
+	}
+	var colnames []string
+	var types, colls []nodes.Oid
+	var typmods []int32
+	var refname string
+	fitems := items(r.Functions)
+	if r.Coldeflist != nil && r.IsRowsfrom && len(fitems) > 1 {
+		return nil, errorf(CodeSyntaxError, -1, "ROWS FROM() with multiple functions cannot have a column definition list").
+			withHint("Put a separate column definition list for each function inside ROWS FROM().")
+	}
+	for _, item := range fitems {
+		pair := item.(*nodes.List)
+		call := pair.Items[0]
+		var coldeflist *nodes.List
+		if len(pair.Items) > 1 && pair.Items[1] != nil {
+			coldeflist = pair.Items[1].(*nodes.List)
+		}
+		if coldeflist == nil && !r.IsRowsfrom {
+			coldeflist = r.Coldeflist
+		}
+		funcname := figureColname(call)
+		if refname == "" {
+			refname = funcname
+		}
+		var expr nodes.Node
+		var fn *Function
+		var err error
+		if fc, ok := call.(*nodes.FuncCall); ok {
+			expr, fn, err = ps.transformFuncCall(fc)
+		} else {
+			expr, err = ps.transformExprRecurse(call)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if containsLocalVars(expr) {
+			rte.Lateral = true
+		}
+		rtf := &nodes.RangeTblFunction{Funcexpr: expr}
+		names, ftypes, ftypmods, fcolls, err := ps.functionColumns(expr, fn, funcname, coldeflist)
+		if err != nil {
+			return nil, err
+		}
+		if coldeflist != nil {
+			rtf.Funccolnames = makeStringList(names)
+			rtf.Funccoltypes = &nodes.OidList{Items: ftypes}
+			rtf.Funccoltypmods = intList(ftypmods)
+			rtf.Funccolcollations = &nodes.OidList{Items: fcolls}
+		}
+		rtf.Funccolcount = len(names)
+		rte.Functions.Items = append(rte.Functions.Items, rtf)
+		colnames = append(colnames, names...)
+		types = append(types, ftypes...)
+		typmods = append(typmods, ftypmods...)
+		colls = append(colls, fcolls...)
+	}
+	// A scalar function's column takes the table alias as its name.
+	if len(fitems) == 1 && r.Alias != nil && r.Alias.Colnames == nil && len(colnames) == 1 && r.Coldeflist == nil {
+		if rtf := rte.Functions.Items[0].(*nodes.RangeTblFunction); !ps.isCompositeResult(rtf.Funcexpr) {
+			colnames[0] = r.Alias.Aliasname
+		}
+	}
+	if r.Ordinality {
+		colnames = append(colnames, "ordinality")
+		types = append(types, INT8OID)
+		typmods = append(typmods, -1)
+		colls = append(colls, nodes.InvalidOid)
+	}
+	if r.Alias != nil {
+		refname = r.Alias.Aliasname
+	}
+	eref, err := buildEref(refname, r.Alias, colnames, func(n int) *Error {
+		return errorf(CodeSyntaxError, -1, "too many column aliases specified for function %s", refname)
+	})
+	if err != nil {
+		return nil, err
+	}
+	rte.Eref = eref
+	it := newNSItem(rte, ps.addRTE(rte), types, typmods, colls)
+	if len(fitems) == 1 && !r.Ordinality {
+		rtf := rte.Functions.Items[0].(*nodes.RangeTblFunction)
+		if t := ExprType(rtf.Funcexpr); t != RECORDOID && ps.isCompositeResult(rtf.Funcexpr) {
+			it.rowType = t
+		}
+	}
+	return it, nil
+}
+
+// isCompositeResult reports whether a function call returns a composite
+// type or record.
+func (ps *pstate) isCompositeResult(expr nodes.Node) bool {
+	t := ExprType(expr)
+	if t == RECORDOID {
+		return true
+	}
+	typ := ps.cat.TypeByOid(t)
+	return typ != nil && typ.Kind == 'c'
+}
+
+// functionColumns returns the output columns of a function in FROM.
+func (ps *pstate) functionColumns(expr nodes.Node, fn *Function, funcname string, coldeflist *nodes.List) ([]string, []nodes.Oid, []int32, []nodes.Oid, error) {
+	var names []string
+	var types, colls []nodes.Oid
+	var typmods []int32
+	ret := ExprType(expr)
+	if coldeflist != nil {
+		if ret != RECORDOID && ret != nodes.InvalidOid {
+			return nil, nil, nil, nil, errorf(CodeSyntaxError, exprLocation(expr), "a column definition list is only allowed for functions returning \"record\"")
+		}
+		for _, item := range coldeflist.Items {
+			cd := item.(*nodes.ColumnDef)
+			t, typmod, err := LookupTypeName(ps.cat, cd.TypeName)
+			if err != nil {
+				return nil, nil, nil, nil, err
+			}
+			names = append(names, cd.Colname)
+			types = append(types, t.Oid)
+			typmods = append(typmods, typmod)
+			colls = append(colls, t.Collation)
+		}
+		return names, types, typmods, colls, nil
+	}
+	if fn != nil && len(fn.OutColumns) > 0 {
+		for _, c := range fn.OutColumns {
+			names = append(names, c.Name)
+			types = append(types, c.Type)
+			typmods = append(typmods, c.Typmod)
+			colls = append(colls, c.Collation)
+		}
+		return names, types, typmods, colls, nil
+	}
+	if ret == RECORDOID {
+		return nil, nil, nil, nil, errorf(CodeSyntaxError, exprLocation(expr), "a column definition list is required for functions returning \"record\"")
+	}
+	if t := ps.cat.TypeByOid(ret); t != nil && t.Kind == 'c' {
+		if rel := ps.cat.RelationByOid(t.Relid); rel != nil {
+			for _, c := range rel.Columns {
+				names = append(names, c.Name)
+				types = append(types, c.Type)
+				typmods = append(typmods, c.Typmod)
+				colls = append(colls, c.Collation)
+			}
+			return names, types, typmods, colls, nil
+		}
+	}
+	return []string{funcname}, []nodes.Oid{ret}, []int32{ExprTypmod(expr)}, []nodes.Oid{ExprCollation(expr)}, nil
+}
+
+// transformRangeTableSample adds a relation with a TABLESAMPLE clause.
+// The sampling method's arguments are analyzed for errors, but the
+// method itself is not resolved.
+func (ps *pstate) transformRangeTableSample(r *nodes.RangeTableSample) (*nsItem, error) {
+	rv, ok := r.Relation.(*nodes.RangeVar)
+	if !ok {
+		return nil, errorf(CodeSyntaxError, r.Location, "TABLESAMPLE clause can only be applied to tables and materialized views")
+	}
+	it, err := ps.transformTableEntry(rv)
+	if err != nil {
+		return nil, err
+	}
+	if it.rte.Rtekind != nodes.RTE_RELATION {
+		return nil, errorf(CodeSyntaxError, r.Location, "TABLESAMPLE clause can only be applied to tables and materialized views")
+	}
+	saved := ps.exprKind
+	ps.exprKind = exprKindFromFunction
+	defer func() { ps.exprKind = saved }()
+	for _, arg := range items(r.Args) {
+		if _, err := ps.transformExprRecurse(arg); err != nil {
+			return nil, err
+		}
+	}
+	if r.Repeatable != nil {
+		if _, err := ps.transformExprRecurse(r.Repeatable); err != nil {
+			return nil, err
+		}
+	}
+	return it, nil
+}
+
+// transformRangeTableFunc adds XMLTABLE. Its columns are taken from the
+// column definitions; the row and document expressions are checked but
+// not kept, since there is no TableFunc node.
+func (ps *pstate) transformRangeTableFunc(r *nodes.RangeTableFunc) (*nsItem, error) {
+	savedKind, savedLateral := ps.exprKind, ps.lateralActive
+	ps.exprKind, ps.lateralActive = exprKindFromFunction, true
+	defer func() { ps.exprKind, ps.lateralActive = savedKind, savedLateral }()
+	lateral := r.Lateral
+	for _, e := range []nodes.Node{r.Rowexpr, r.Docexpr} {
+		if e == nil {
+			continue
+		}
+		expr, err := ps.transformExprRecurse(e)
+		if err != nil {
+			return nil, err
+		}
+		lateral = lateral || containsLocalVars(expr)
+	}
+	var names []string
+	var types, colls []nodes.Oid
+	var typmods []int32
+	for _, item := range items(r.Columns) {
+		col := item.(*nodes.RangeTableFuncCol)
+		names = append(names, col.Colname)
+		if col.ForOrdinality {
+			types, typmods, colls = append(types, INT4OID), append(typmods, -1), append(colls, nodes.InvalidOid)
+			continue
+		}
+		t, typmod, err := LookupTypeName(ps.cat, col.TypeName)
+		if err != nil {
+			return nil, err
+		}
+		types, typmods, colls = append(types, t.Oid), append(typmods, typmod), append(colls, t.Collation)
+	}
+	refname := "xmltable"
+	if r.Alias != nil {
+		refname = r.Alias.Aliasname
+	}
+	eref, err := buildEref(refname, r.Alias, names, func(n int) *Error {
+		return errorf(CodeInvalidColumnReference, r.Location, "%s function has %d columns available but %d columns specified", "XMLTABLE", len(names), n)
+	})
+	if err != nil {
+		return nil, err
+	}
+	rte := &nodes.RangeTblEntry{
+		Rtekind:       nodes.RTE_TABLEFUNC,
+		Alias:         r.Alias,
+		Eref:          eref,
+		Coltypes:      &nodes.OidList{Items: types},
+		Coltypmods:    intList(typmods),
+		Colcollations: &nodes.OidList{Items: colls},
+		Lateral:       lateral,
+		InFromCl:      true,
+	}
+	return newNSItem(rte, ps.addRTE(rte), types, typmods, colls), nil
+}
+
+// transformJoin analyzes a JOIN. Columns merged by USING or NATURAL come
+// first in the join's output, followed by the remaining columns of the
+// left and right inputs.
+func (ps *pstate) transformJoin(j *nodes.JoinExpr) (nodes.Node, *nsItem, []*nsItem, error) {
+	larg, ltop, lns, err := ps.transformFromClauseItem(j.Larg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	// The right input may refer to the left one with LATERAL, except
+	// across a RIGHT or FULL join.
+	lateralOK := j.Jointype == nodes.JOIN_INNER || j.Jointype == nodes.JOIN_LEFT
+	for _, it := range lns {
+		it.lateralOnly, it.lateralOK = true, lateralOK
+	}
+	saved := ps.namespace
+	ps.namespace = append(append([]*nsItem(nil), ps.namespace...), lns...)
+	rarg, rtop, rns, err := ps.transformFromClauseItem(j.Rarg)
+	ps.namespace = saved
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for _, it := range lns {
+		it.lateralOnly, it.lateralOK = false, true
+	}
+	if err := checkNameSpaceConflicts(lns, rns); err != nil {
+		return nil, nil, nil, err
+	}
+
+	lnames, rnames := ltop.colnames, rtop.colnames
+	var using []string
+	if j.IsNatural {
+		for _, ln := range lnames {
+			for _, rn := range rnames {
+				if ln == rn {
+					using = append(using, ln)
+					break
+				}
+			}
+		}
+	} else {
+		using = stringList(j.UsingClause)
+	}
+
+	var colnames []string
+	var columns []nsColumn
+	var aliasvars []nodes.Node
+	var leftcols, rightcols []int
+	var quals []nodes.Node
+	lused := make([]bool, len(lnames))
+	rused := make([]bool, len(rnames))
+	seen := map[string]bool{}
+	for _, u := range using {
+		if seen[u] {
+			return nil, nil, nil, errorf(CodeDuplicateColumn, -1, "column name %q appears more than once in USING clause", u)
+		}
+		seen[u] = true
+		li, err := findJoinColumn(lnames, u, "left")
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		ri, err := findJoinColumn(rnames, u, "right")
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		lused[li], rused[ri] = true, true
+		lvar := buildVar(&ltop.columns[li], 0, -1)
+		rvar := buildVar(&rtop.columns[ri], 0, -1)
+		qual, err := ps.makeOp([]string{"="}, lvar, rvar, -1)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		quals = append(quals, qual)
+
+		typ, err := ps.selectCommonType([]nodes.Node{lvar, rvar}, "JOIN/USING")
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		typmod := int32(-1)
+		if lvar.Vartypmod == rvar.Vartypmod {
+			typmod = lvar.Vartypmod
+		}
+		lnode, err := ps.coerceToCommonType(lvar, typ, "JOIN/USING")
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		rnode, err := ps.coerceToCommonType(rvar, typ, "JOIN/USING")
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		var merged nodes.Node
+		var col nsColumn
+		switch j.Jointype {
+		case nodes.JOIN_INNER, nodes.JOIN_LEFT:
+			merged = lnode
+		case nodes.JOIN_RIGHT:
+			merged = rnode
+		default:
+			merged = &nodes.CoalesceExpr{Coalescetype: typ, Coalescecollid: ps.selectCommonCollation([]nodes.Node{lnode, rnode}), Args: &nodes.List{Items: []nodes.Node{lnode, rnode}}, Location: -1}
+		}
+		switch {
+		case merged == nodes.Node(lvar):
+			col = ltop.columns[li]
+		case merged == nodes.Node(rvar):
+			col = rtop.columns[ri]
+		default:
+			col = nsColumn{varattno: nodes.AttrNumber(len(colnames) + 1), typ: typ, typmod: typmod, collid: ExprCollation(merged), varattnosyn: nodes.AttrNumber(len(colnames) + 1)}
+		}
+		colnames = append(colnames, u)
+		columns = append(columns, col)
+		aliasvars = append(aliasvars, merged)
+		leftcols = append(leftcols, li+1)
+		rightcols = append(rightcols, ri+1)
+	}
+	nmerged := len(colnames)
+	for i, name := range lnames {
+		if lused[i] {
+			continue
+		}
+		colnames = append(colnames, name)
+		columns = append(columns, ltop.columns[i])
+		aliasvars = append(aliasvars, buildVar(&ltop.columns[i], 0, -1))
+		leftcols = append(leftcols, i+1)
+		rightcols = append(rightcols, 0)
+	}
+	for i, name := range rnames {
+		if rused[i] {
+			continue
+		}
+		colnames = append(colnames, name)
+		columns = append(columns, rtop.columns[i])
+		aliasvars = append(aliasvars, buildVar(&rtop.columns[i], 0, -1))
+		leftcols = append(leftcols, 0)
+		rightcols = append(rightcols, i+1)
+	}
+
+	out := &nodes.JoinExpr{
+		Jointype:       j.Jointype,
+		IsNatural:      j.IsNatural,
+		Larg:           larg,
+		Rarg:           rarg,
+		UsingClause:    j.UsingClause,
+		JoinUsingAlias: j.JoinUsingAlias,
+		Alias:          j.Alias,
+	}
+	switch {
+	case len(quals) == 1:
+		out.Quals = quals[0]
+	case len(quals) > 1:
+		out.Quals = &nodes.BoolExpr{Boolop: nodes.AND_EXPR, Args: &nodes.List{Items: quals}, Location: -1}
+	}
+	if j.Quals != nil {
+		// ON may refer only to the two inputs.
+		saved := ps.namespace
+		ps.namespace = append(append([]*nsItem(nil), lns...), rns...)
+		q, err := ps.transformWhereClause(j.Quals, exprKindJoinOn, "JOIN/ON")
+		ps.namespace = saved
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		out.Quals = q
+	}
+
+	jit, err := ps.addRangeTableEntryForJoin(colnames, columns, j, nmerged, aliasvars, leftcols, rightcols)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	out.Rtindex = jit.rtindex
+
+	var ns []*nsItem
+	if j.Alias != nil {
+		ns = []*nsItem{jit}
+	} else {
+		ns = append(append(ns, lns...), rns...)
+		for _, it := range ns {
+			it.colsVisible = false
+		}
+		jit.relVisible = false
+		ns = append(ns, jit)
+	}
+	if j.JoinUsingAlias != nil {
+		ua := &nsItem{
+			name:       j.JoinUsingAlias.Aliasname,
+			rte:        jit.rte,
+			rtindex:    jit.rtindex,
+			colnames:   colnames[:nmerged],
+			columns:    jit.columns[:nmerged],
+			rowType:    RECORDOID,
+			relVisible: true,
+			lateralOK:  true,
+		}
+		if err := checkNameSpaceConflicts([]*nsItem{ua}, ns); err != nil {
+			return nil, nil, nil, err
+		}
+		ns = append(ns, ua)
+	}
+	return out, jit, ns, nil
+}
+
+// findJoinColumn finds a USING column in one input of a join.
+func findJoinColumn(names []string, name, side string) (int, error) {
+	idx := -1
+	for i, n := range names {
+		if n != name {
+			continue
+		}
+		if idx >= 0 {
+			return 0, errorf(CodeAmbiguousColumn, -1, "common column name %q appears more than once in %s table", name, side)
+		}
+		idx = i
+	}
+	if idx < 0 {
+		return 0, errorf(CodeUndefinedColumn, -1, "column %q specified in USING clause does not exist in %s table", name, side)
+	}
+	return idx, nil
+}
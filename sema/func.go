@@ -0,0 +1,1385 @@
+package sema
+
+import (
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// candidate is a function or operator that a call might resolve to, with
+// the declared types of the arguments as supplied: in call order, with a
+// variadic parameter expanded to one element type per argument.
+type candidate struct {
+	fn      *Function
+	op      *Operator
+	args    []nodes.Oid // declared type of each supplied argument
+	argnums []int       // parameter index of each supplied argument
+	nvargs  int         // number of arguments gathered into the variadic array
+	vatype  nodes.Oid   // element type of the variadic array
+}
+
+// transformFuncCall analyzes a function call, which may turn out to be an
+// aggregate, a window function or a type coercion written as a function
+// (ParseFuncOrColumn). It also returns the function called, or nil if it
+// was not resolved.
+func (ps *pstate) transformFuncCall(fc *nodes.FuncCall) (nodes.Node, *Function, error) {
+	names := stringList(fc.Funcname)
+	schema, name := qualifiedName(names)
+	loc := fc.Location
+	isAggSyntax := fc.AggStar || fc.AggDistinct || fc.AggOrder != nil || fc.AggFilter != nil || fc.AggWithinGroup
+
+	var args []nodes.Node
+	var argNames []string
+	for _, item := range items(fc.Args) {
+		e, err := ps.transformExprRecurse(item)
+		if err != nil {
+			return nil, nil, err
+		}
+		argName := ""
+		if na, ok := e.(*nodes.NamedArgExpr); ok {
+			argName = na.Name
+			for _, prev := range argNames {
+				if prev == argName {
+					return nil, nil, errorf(CodeSyntaxError, na.Location, "argument name %q used more than once", argName)
+				}
+			}
+		} else if len(argNames) > 0 && argNames[len(argNames)-1] != "" {
+			return nil, nil, errorf(CodeSyntaxError, exprLocation(e), "positional argument cannot follow named argument")
+		}
+		args = append(args, e)
+		argNames = append(argNames, argName)
+	}
+	argTypes := make([]nodes.Oid, len(args))
+	for i, a := range args {
+		argTypes[i] = ExprType(a)
+	}
+	hasNames := len(argNames) > 0 && argNames[len(argNames)-1] != ""
+
+	fns := ps.cat.LookupFunctions(schema, name)
+	// A one-argument call of a type name is a cast, unless a function of
+	// that name takes exactly the argument's type.
+	if len(args) == 1 && !hasNames && !isAggSyntax && fc.Over == nil && !fc.FuncVariadic && !hasExactMatch(fns, argTypes) {
+		if t := ps.cat.LookupType(schema, name); t != nil && (len(fns) == 0 || ps.isFuncCoercion(args[0], argTypes[0], t.Oid)) {
+			c, err := ps.coerceToTargetType(args[0], argTypes[0], t.Oid, -1, nodes.COERCION_EXPLICIT, nodes.COERCE_EXPLICIT_CALL, loc)
+			if err != nil {
+				return nil, nil, err
+			}
+			if c != nil {
+				return c, nil, nil
+			}
+		}
+	}
+	if len(fns) == 0 {
+		// Unknown function: keep the call without resolving it.
+		ps.noteUnresolved("function", names, argTypes, loc)
+		return ps.buildCall(fc, nil, args, nodes.InvalidOid, false)
+	}
+
+	fn, cand, err := ps.funcGetDetail(fns, argTypes, argNames, fc.FuncVariadic, loc)
+	if err != nil {
+		return nil, nil, err
+	}
+	if fn == nil {
+		return nil, nil, errorf(CodeUndefinedFunction, loc, "function %s does not exist", funcSignatureString(ps.cat, names, argTypes, argNames)).
+			withHint("No function matches the given name and argument types. You might need to add explicit type casts.")
+	}
+	// Resolve polymorphic argument and result types.
+	declared, rettype, err := ps.resolvePolymorphicTypes(argTypes, cand.args, fn.ReturnType, loc)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range args {
+		if args[i], err = ps.coerceArgument(args[i], argTypes[i], declared[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+	variadic := fc.FuncVariadic
+	if cand.nvargs > 0 && cand.vatype != ANYOID {
+		// Gather the variadic arguments into an array.
+		first := len(args) - cand.nvargs
+		elem := declared[first]
+		arrType := builtinArrayType(elem)
+		if t := ps.cat.TypeByOid(elem); t != nil && t.Array != nodes.InvalidOid {
+			arrType = t.Array
+		}
+		arr := &nodes.ArrayExpr{ArrayTypeid: arrType, ElementTypeid: elem, Elements: nodeList(append([]nodes.Node(nil), args[first:]...)), Location: exprLocation(args[first])}
+		arr.ArrayCollid = ps.selectCommonCollation(args[first:])
+		args = append(args[:first], arr)
+		variadic = true
+	}
+	for i, a := range args {
+		if na, ok := a.(*nodes.NamedArgExpr); ok && i < len(cand.argnums) {
+			na.Argnumber = cand.argnums[i]
+		}
+	}
+	return ps.buildCall(fc, fn, args, rettype, variadic)
+}
+
+// buildCall makes the node for a resolved or unresolved call: an Aggref,
+// a WindowFunc or a FuncExpr.
+func (ps *pstate) buildCall(fc *nodes.FuncCall, fn *Function, args []nodes.Node, rettype nodes.Oid, variadic bool) (nodes.Node, *Function, error) {
+	name := nameListString(fc.Funcname)
+	loc := fc.Location
+	kind := byte('f')
+	if fn != nil {
+		kind = fn.Kind
+	} else if fc.AggStar || fc.AggDistinct || fc.AggOrder != nil || fc.AggFilter != nil || fc.AggWithinGroup {
+		// An unknown function with aggregate syntax is taken to be an
+		// aggregate.
+		kind = 'a'
+	} else if fc.Over != nil {
+		kind = 'w'
+	}
+	var fnoid nodes.Oid
+	retset := false
+	if fn != nil {
+		fnoid, retset = fn.Oid, fn.ReturnsSet
+	}
+	inputcoll := ps.selectCommonCollation(args)
+	resultcoll := nodes.InvalidOid
+	if rettype != nodes.InvalidOid && typeCollation(ps.cat, rettype) != nodes.InvalidOid {
+		resultcoll = inputcoll
+		if resultcoll == nodes.InvalidOid {
+			resultcoll = typeCollation(ps.cat, rettype)
+		}
+	}
+
+	if kind == 'f' || kind == 'p' {
+		if kind == 'p' {
+			return nil, nil, errorf(CodeWrongObjectType, loc, "%s is a procedure", funcSignatureString(ps.cat, stringList(fc.Funcname), exprTypes(args), nil)).
+				withHint("To call a procedure, use CALL.")
+		}
+		var what string
+		switch {
+		case fc.AggStar:
+			return nil, nil, errorf(CodeWrongObjectType, loc, "%s(*) specified, but %s is not an aggregate function", name, name)
+		case fc.AggDistinct:
+			what = "DISTINCT"
+		case fc.AggWithinGroup:
+			what = "WITHIN GROUP"
+		case fc.AggOrder != nil:
+			what = "ORDER BY"
+		case fc.AggFilter != nil:
+			what = "FILTER"
+		case fc.Over != nil:
+			return nil, nil, errorf(CodeWrongObjectType, loc, "OVER specified, but %s is not a window function nor an aggregate function", name)
+		}
+		if what != "" {
+			return nil, nil, errorf(CodeWrongObjectType, loc, "%s specified, but %s is not an aggregate function", what, name)
+		}
+		if retset {
+			if err := ps.checkSRFPlacement(loc); err != nil {
+				return nil, nil, err
+			}
+		}
+		format := nodes.COERCE_EXPLICIT_CALL
+		if nodes.CoercionForm(fc.Funcformat) == nodes.COERCE_SQL_SYNTAX {
+			format = nodes.COERCE_SQL_SYNTAX
+		}
+		return &nodes.FuncExpr{
+			Funcid:         fnoid,
+			Funcresulttype: rettype,
+			Funcretset:     retset,
+			Funcvariadic:   variadic,
+			Funcformat:     format,
+			Funccollid:     resultcoll,
+			Inputcollid:    inputcoll,
+			Args:           nodeList(args),
+			Location:       loc,
+		}, fn, nil
+	}
+
+	if kind == 'w' && fc.Over == nil {
+		return nil, nil, errorf(CodeWrongObjectType, loc, "window function %s requires an OVER clause", name)
+	}
+	if kind == 'w' && fc.AggWithinGroup {
+		return nil, nil, errorf(CodeWrongObjectType, loc, "window function %s cannot have WITHIN GROUP", name)
+	}
+	if fc.Over != nil {
+		return ps.buildWindowFunc(fc, fnoid, kind == 'a', args, rettype, resultcoll, inputcoll)
+	}
+	if fn != nil && fc.AggWithinGroup {
+		return nil, nil, errorf(CodeWrongObjectType, loc, "%s is not an ordered-set aggregate, so it cannot have WITHIN GROUP", name)
+	}
+	agg := &nodes.Aggref{
+		Aggfnoid:    fnoid,
+		Aggtype:     rettype,
+		Aggcollid:   resultcoll,
+		Inputcollid: inputcoll,
+		Aggstar:     fc.AggStar,
+		Aggvariadic: variadic,
+		Aggkind:     'n',
+		Location:    loc,
+	}
+	if err := ps.transformAggregateCall(agg, fc, args); err != nil {
+		return nil, nil, err
+	}
+	return agg, fn, nil
+}
+
+// transformAggregateCall fills in the arguments, ORDER BY, DISTINCT and
+// FILTER of an aggregate call and checks where it appears
+// (transformAggregateCall).
+func (ps *pstate) transformAggregateCall(agg *nodes.Aggref, fc *nodes.FuncCall, args []nodes.Node) error {
+	if fc.AggWithinGroup {
+		// An ordered-set aggregate: the parenthesized arguments are the
+		// direct arguments and the WITHIN GROUP ordering gives the
+		// aggregated ones.
+		agg.Aggkind = 'o'
+		agg.Aggdirectargs = nodeList(args)
+		args = nil
+		for _, item := range items(fc.AggOrder) {
+			e, err := ps.transformExpr(item.(*nodes.SortBy).Node, exprKindOrderBy)
+			if err != nil {
+				return err
+			}
+			args = append(args, e)
+		}
+	}
+	exprs := append([]nodes.Node(nil), args...)
+	exprs = append(exprs, items(agg.Aggdirectargs)...)
+	levels := minVarLevel(exprs)
+	if levels < 0 {
+		levels = 0
+	}
+	owner := ps.levelUp(levels)
+	if owner == nil {
+		return errorf(CodeGroupingError, agg.Location, "aggregate refers to a nonexistent query level")
+	}
+	if msg := aggPlacementError(owner.exprKind, "aggregate functions"); msg != "" {
+		return errorf(CodeGroupingError, agg.Location, "%s", msg)
+	}
+	if err := checkAggArguments(exprs, levels); err != nil {
+		return err
+	}
+	agg.Agglevelsup = uint32(levels)
+
+	var tlist []*nodes.TargetEntry
+	agg.Aggargtypes = &nodes.OidList{}
+	for _, e := range items(agg.Aggdirectargs) {
+		agg.Aggargtypes.Items = append(agg.Aggargtypes.Items, ExprType(e))
+	}
+	for i, e := range args {
+		tlist = append(tlist, &nodes.TargetEntry{Expr: e, Resno: nodes.AttrNumber(i + 1)})
+		agg.Aggargtypes.Items = append(agg.Aggargtypes.Items, ExprType(e))
+	}
+	if fc.AggOrder != nil && !fc.AggWithinGroup {
+		order, err := ps.transformSortClause(fc.AggOrder, &tlist, exprKindOrderBy, true)
+		if err != nil {
+			return err
+		}
+		agg.Aggorder = order
+	}
+	if fc.AggDistinct {
+		distinct, err := ps.transformDistinctClause(&tlist, agg.Aggorder, true)
+		if err != nil {
+			return err
+		}
+		agg.Aggdistinct = distinct
+	}
+	if fc.AggFilter != nil {
+		filter, err := ps.transformWhereClause(fc.AggFilter, exprKindFilter, "FILTER")
+		if err != nil {
+			return err
+		}
+		agg.Aggfilter = filter
+	}
+	agg.Args = tleList(tlist)
+	owner.hasAggs = true
+	return nil
+}
+
+// checkAggArguments rejects aggregates of the same query level and window
+// functions inside the arguments of an aggregate (check_agg_arguments).
+func checkAggArguments(exprs []nodes.Node, levels int) error {
+	var err error
+	for _, e := range exprs {
+		walkLevels(e, 0, func(n nodes.Node, depth int) bool {
+			switch x := n.(type) {
+			case *nodes.Aggref:
+				if int(x.Agglevelsup)-depth == levels {
+					err = errorf(CodeGroupingError, x.Location, "aggregate function calls cannot be nested")
+				}
+			case *nodes.GroupingFunc:
+				if int(x.Agglevelsup)-depth == levels {
+					err = errorf(CodeGroupingError, x.Location, "aggregate function calls cannot contain grouping operations")
+				}
+			case *nodes.WindowFunc:
+				if depth == 0 && levels == 0 {
+					err = errorf(CodeGroupingError, x.Location, "aggregate function calls cannot contain window function calls")
+				}
+			}
+			return err == nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// aggPlacementError returns the error message for an aggregate or
+// grouping operation (what) appearing in kind, or "" if it is allowed
+// there (check_agglevels_and_constraints).
+func aggPlacementError(kind exprKind, what string) string {
+	switch kind {
+	case exprKindNone, exprKindHaving, exprKindSelectTarget, exprKindOrderBy,
+		exprKindDistinctOn, exprKindWindowPartition, exprKindWindowOrder:
+		return ""
+	case exprKindJoinOn, exprKindJoinUsing:
+		return what + " are not allowed in JOIN conditions"
+	case exprKindFromSubselect:
+		return what + " are not allowed in FROM clause of their own query level"
+	case exprKindFromFunction:
+		return what + " are not allowed in functions in FROM"
+	case exprKindMergeWhen:
+		return what + " are not allowed in MERGE WHEN conditions"
+	case exprKindIndexExpression:
+		return what + " are not allowed in index expressions"
+	case exprKindIndexPredicate:
+		return what + " are not allowed in index predicates"
+	case exprKindFilter:
+		if what == "aggregate functions" {
+			return "aggregate functions are not allowed in FILTER"
+		}
+		return "grouping operations are not allowed in FILTER"
+	}
+	return what + " are not allowed in " + kind.String()
+}
+
+// buildWindowFunc makes a WindowFunc for a call with an OVER clause
+// (transformWindowFuncCall).
+func (ps *pstate) buildWindowFunc(fc *nodes.FuncCall, fnoid nodes.Oid, isAgg bool, args []nodes.Node, rettype, resultcoll, inputcoll nodes.Oid) (nodes.Node, *Function, error) {
+	loc := fc.Location
+	switch ps.exprKind {
+	case exprKindSelectTarget, exprKindOrderBy, exprKindDistinctOn:
+	case exprKindWindowPartition, exprKindWindowOrder, exprKindWindowFrameRange,
+		exprKindWindowFrameRows, exprKindWindowFrameGroups:
+		return nil, nil, errorf(CodeWindowingError, loc, "window functions are not allowed in window definitions")
+	case exprKindJoinOn, exprKindJoinUsing:
+		return nil, nil, errorf(CodeWindowingError, loc, "window functions are not allowed in JOIN conditions")
+	case exprKindFromFunction:
+		return nil, nil, errorf(CodeWindowingError, loc, "window functions are not allowed in functions in FROM")
+	default:
+		return nil, nil, errorf(CodeWindowingError, loc, "window functions are not allowed in %s", ps.exprKind)
+	}
+	for _, a := range args {
+		var nested *nodes.WindowFunc
+		walkLevels(a, 0, func(n nodes.Node, depth int) bool {
+			if w, ok := n.(*nodes.WindowFunc); ok && depth == 0 {
+				nested = w
+			}
+			return nested == nil
+		})
+		if nested != nil {
+			return nil, nil, errorf(CodeWindowingError, nested.Location, "window function calls cannot be nested")
+		}
+	}
+	if fc.AggDistinct {
+		return nil, nil, errorf(CodeFeatureNotSupported, loc, "DISTINCT is not implemented for window functions")
+	}
+	if fc.AggOrder != nil {
+		return nil, nil, errorf(CodeFeatureNotSupported, loc, "aggregate ORDER BY is not implemented for window functions")
+	}
+	if fc.AggFilter != nil && !isAgg {
+		return nil, nil, errorf(CodeFeatureNotSupported, loc, "FILTER is not implemented for non-aggregate window functions")
+	}
+	wf := &nodes.WindowFunc{
+		Winfnoid:    fnoid,
+		Wintype:     rettype,
+		Wincollid:   resultcoll,
+		Inputcollid: inputcoll,
+		Args:        nodeList(args),
+		Winstar:     fc.AggStar,
+		Winagg:      isAgg,
+		Location:    loc,
+	}
+	if fc.AggFilter != nil {
+		filter, err := ps.transformWhereClause(fc.AggFilter, exprKindFilter, "FILTER")
+		if err != nil {
+			return nil, nil, err
+		}
+		wf.Aggfilter = filter
+	}
+	winref, err := ps.findWindowRef(fc.Over)
+	if err != nil {
+		return nil, nil, err
+	}
+	wf.Winref = winref
+	ps.hasWindowFuncs = true
+	return wf, nil, nil
+}
+
+// findWindowRef returns the number of the window a window function uses:
+// a named window of the WINDOW clause, or an existing window with the
+// same definition, or a new one.
+func (ps *pstate) findWindowRef(over *nodes.WindowDef) (uint32, error) {
+	if over.Name != "" {
+		for i, w := range ps.windowDefs {
+			if w.Name == over.Name {
+				return uint32(i + 1), nil
+			}
+		}
+		return 0, errorf(CodeUndefinedObject, over.Location, "window %q does not exist", over.Name)
+	}
+	for i, w := range ps.windowDefs {
+		if w.Refname != over.Refname {
+			continue
+		}
+		if equalExprs(w.PartitionClause, over.PartitionClause) && equalExprs(w.OrderClause, over.OrderClause) &&
+			w.FrameOptions == over.FrameOptions && equalExprs(w.StartOffset, over.StartOffset) && equalExprs(w.EndOffset, over.EndOffset) {
+			return uint32(i + 1), nil
+		}
+	}
+	ps.windowDefs = append(ps.windowDefs, over)
+	return uint32(len(ps.windowDefs)), nil
+}
+
+// checkSRFPlacement reports an error if a set-returning function may not
+// appear in the current expression kind (check_srf_call_placement).
+func (ps *pstate) checkSRFPlacement(loc nodes.ParseLoc) error {
+	switch ps.exprKind {
+	case exprKindFromFunction:
+		return nil
+	case exprKindSelectTarget, exprKindOrderBy, exprKindGroupBy, exprKindDistinctOn, exprKindValuesSingle, exprKindNone:
+		ps.hasTargetSRFs = true
+		return nil
+	case exprKindJoinOn, exprKindJoinUsing:
+		return errorf(CodeFeatureNotSupported, loc, "set-returning functions are not allowed in JOIN conditions")
+	case exprKindIndexExpression:
+		return errorf(CodeFeatureNotSupported, loc, "set-returning functions are not allowed in index expressions")
+	case exprKindIndexPredicate:
+		return errorf(CodeFeatureNotSupported, loc, "set-returning functions are not allowed in index predicates")
+	}
+	return errorf(CodeFeatureNotSupported, loc, "set-returning functions are not allowed in %s", ps.exprKind)
+}
+
+// hasExactMatch reports whether one of fns takes exactly argTypes.
+func hasExactMatch(fns []*Function, argTypes []nodes.Oid) bool {
+	for _, f := range fns {
+		if !f.Variadic && oidsEqual(f.ArgTypes, argTypes) {
+			return true
+		}
+	}
+	return false
+}
+
+// isFuncCoercion reports whether a call of the type name target with the
+// single argument arg, of type source, is to be taken as a cast rather
+// than a function call: the argument is an untyped literal, or the
+// conversion needs no function of its own (func_get_detail).
+func (ps *pstate) isFuncCoercion(arg nodes.Node, source, target nodes.Oid) bool {
+	if _, ok := arg.(*nodes.Const); ok && source == UNKNOWNOID {
+		return true
+	}
+	switch path, _ := ps.findCoercionPathway(target, source, nodes.COERCION_EXPLICIT); path {
+	case coercionPathRelabel:
+		return true
+	case coercionPathCoerceViaIO:
+		// A composite value is not converted to a string this way.
+		return !((source == RECORDOID || ps.isComplexType(source)) && typeCategory(ps.cat, target) == 'S')
+	}
+	return false
+}
+
+// funcGetDetail chooses the function a call resolves to
+// (func_get_detail). It returns the function and the matching candidate,
+// or nil if no function matches.
+func (ps *pstate) funcGetDetail(fns []*Function, argTypes []nodes.Oid, argNames []string, explicitVariadic bool, loc nodes.ParseLoc) (*Function, *candidate, error) {
+	var cands []*candidate
+	for _, f := range fns {
+		if c := matchFunction(ps.cat, f, argNames, explicitVariadic); c != nil {
+			cands = append(cands, c)
+		}
+	}
+	for _, c := range cands {
+		if oidsEqual(c.args, argTypes) {
+			return c.fn, c, nil
+		}
+	}
+	var compatible []*candidate
+	for _, c := range cands {
+		if ps.canCoerceType(argTypes, c.args, nodes.COERCION_IMPLICIT) {
+			compatible = append(compatible, c)
+		}
+	}
+	switch len(compatible) {
+	case 0:
+		return nil, nil, nil
+	case 1:
+		return compatible[0].fn, compatible[0], nil
+	}
+	best := ps.selectCandidate(argTypes, compatible)
+	if best == nil {
+		f := compatible[0].fn
+		return nil, nil, errorf(CodeAmbiguousFunction, loc, "function %s is not unique", funcSignatureString(ps.cat, []string{f.Name}, argTypes, argNames)).
+			withHint("Could not choose a best candidate function. You might need to add explicit type casts.")
+	}
+	return best.fn, best, nil
+}
+
+// matchFunction returns f as a candidate for a call with the given
+// argument names ("" for positional arguments), or nil if the call
+// cannot be matched to f's parameters.
+func matchFunction(cat Catalog, f *Function, argNames []string, explicitVariadic bool) *candidate {
+	nargs := len(argNames)
+	nparams := len(f.ArgTypes)
+	c := &candidate{fn: f}
+	named := nargs > 0 && argNames[nargs-1] != ""
+	if f.Variadic && !explicitVariadic && !named && nargs >= nparams && nparams > 0 {
+		// Expand the variadic parameter into one per argument.
+		last := f.ArgTypes[nparams-1]
+		c.vatype = variadicElemType(cat, last)
+		c.args = append(c.args, f.ArgTypes[:nparams-1]...)
+		for i := nparams - 1; i < nargs; i++ {
+			c.args = append(c.args, c.vatype)
+		}
+		c.nvargs = nargs - nparams + 1
+		for i := range c.args {
+			c.argnums = append(c.argnums, min(i, nparams-1))
+		}
+		return c
+	}
+	if explicitVariadic && !f.Variadic {
+		return nil
+	}
+	if nargs > nparams {
+		return nil
+	}
+	used := make([]bool, nparams)
+	for i, name := range argNames {
+		idx := i
+		if name != "" {
+			idx = -1
+			for j, pn := range f.ArgNames {
+				if pn == name {
+					idx = j
+				}
+			}
+			if idx < 0 || used[idx] {
+				return nil
+			}
+		}
+		used[idx] = true
+		c.args = append(c.args, f.ArgTypes[idx])
+		c.argnums = append(c.argnums, idx)
+	}
+	// Parameters not supplied must have defaults.
+	for j := 0; j < nparams; j++ {
+		if !used[j] && j < nparams-f.NumDefaults {
+			return nil
+		}
+	}
+	return c
+}
+
+// variadicElemType returns the element type of a variadic parameter of
+// the given array type.
+func variadicElemType(cat Catalog, typ nodes.Oid) nodes.Oid {
+	switch typ {
+	case ANYOID:
+		return ANYOID
+	case ANYARRAYOID:
+		return ANYELEMENTOID
+	case ANYCOMPATIBLEARRAYOID:
+		return ANYCOMPATIBLEOID
+	}
+	if t := cat.TypeByOid(typ); t != nil && t.Elem != nodes.InvalidOid {
+		return t.Elem
+	}
+	return typ
+}
+
+// coerceArgument converts a function or operator argument to the type of
+// its parameter, keeping a NamedArgExpr on top (make_fn_arguments).
+func (ps *pstate) coerceArgument(arg nodes.Node, argType, declared nodes.Oid) (nodes.Node, error) {
+	if argType == declared || declared == ANYOID {
+		return arg, nil
+	}
+	if na, ok := arg.(*nodes.NamedArgExpr); ok {
+		inner, err := ps.coerceType(na.Arg, argType, declared, -1, nodes.COERCION_IMPLICIT, nodes.COERCE_IMPLICIT_CAST, -1)
+		if err != nil {
+			return nil, err
+		}
+		n := *na
+		n.Arg = inner
+		return &n, nil
+	}
+	return ps.coerceType(arg, argType, declared, -1, nodes.COERCION_IMPLICIT, nodes.COERCE_IMPLICIT_CAST, -1)
+}
+
+// resolvePolymorphicTypes replaces polymorphic parameter types with the
+// actual types they stand for in this call, and works out the result
+// type (enforce_generic_type_consistency).
+func (ps *pstate) resolvePolymorphicTypes(actual, declared []nodes.Oid, rettype nodes.Oid, loc nodes.ParseLoc) ([]nodes.Oid, nodes.Oid, error) {
+	hasPoly := isPolymorphicType(rettype)
+	for _, d := range declared {
+		if isPolymorphicType(d) {
+			hasPoly = true
+		}
+	}
+	if !hasPoly {
+		return declared, rettype, nil
+	}
+	mismatch := func(what string, a, b nodes.Oid) error {
+		return errorf(CodeDatatypeMismatch, loc, "arguments declared %q are not all alike", what).
+			withDetail("%s versus %s", FormatType(ps.cat, a, -1), FormatType(ps.cat, b, -1))
+	}
+	var elem, array, rng nodes.Oid
+	var compatExprs []nodes.Node
+	hasCompat := false
+	for i, d := range declared {
+		a := actual[i]
+		if a == UNKNOWNOID || a == nodes.InvalidOid {
+			if d == ANYCOMPATIBLEOID || d == ANYCOMPATIBLENONARRAYOID {
+				hasCompat = true
+			}
+			continue
+		}
+		base, _ := baseType(ps.cat, a, -1)
+		switch d {
+		case ANYELEMENTOID, ANYNONARRAYOID, ANYENUMOID:
+			if elem != nodes.InvalidOid && elem != a {
+				return nil, 0, mismatch(FormatType(ps.cat, d, -1), elem, a)
+			}
+			elem = a
+		case ANYARRAYOID:
+			if array != nodes.InvalidOid && array != base {
+				return nil, 0, mismatch("anyarray", array, base)
+			}
+			array = base
+		case ANYRANGEOID, ANYMULTIRANGEOID:
+			if rng != nodes.InvalidOid && rng != base {
+				return nil, 0, mismatch(FormatType(ps.cat, d, -1), rng, base)
+			}
+			rng = base
+		case ANYCOMPATIBLEOID, ANYCOMPATIBLENONARRAYOID:
+			hasCompat = true
+			compatExprs = append(compatExprs, &nodes.CaseTestExpr{TypeId: a, TypeMod: -1})
+		case ANYCOMPATIBLEARRAYOID:
+			hasCompat = true
+			if t := ps.cat.TypeByOid(base); t != nil && t.Elem != nodes.InvalidOid {
+				compatExprs = append(compatExprs, &nodes.CaseTestExpr{TypeId: t.Elem, TypeMod: -1})
+			}
+		}
+	}
+	if array != nodes.InvalidOid {
+		t := ps.cat.TypeByOid(array)
+		if t == nil || !isArrayType(t) {
+			return nil, 0, errorf(CodeDatatypeMismatch, loc, "argument declared %s is not an array but type %s", "anyarray", FormatType(ps.cat, array, -1))
+		}
+		if elem != nodes.InvalidOid && elem != t.Elem {
+			return nil, 0, errorf(CodeDatatypeMismatch, loc, "argument declared %s is not consistent with argument declared %s", "anyarray", "anyelement").
+				withDetail("%s versus %s", FormatType(ps.cat, array, -1), FormatType(ps.cat, elem, -1))
+		}
+		elem = t.Elem
+	}
+	if rng != nodes.InvalidOid {
+		var sub nodes.Oid
+		if t := ps.cat.TypeByOid(rng); t != nil {
+			sub = t.Subtype
+		}
+		if elem != nodes.InvalidOid && sub != nodes.InvalidOid && elem != sub {
+			return nil, 0, errorf(CodeDatatypeMismatch, loc, "argument declared %s is not consistent with argument declared %s", "anyrange", "anyelement").
+				withDetail("%s versus %s", FormatType(ps.cat, rng, -1), FormatType(ps.cat, elem, -1))
+		}
+		if sub != nodes.InvalidOid {
+			elem = sub
+		}
+	}
+	arrayOf := func(e nodes.Oid) (nodes.Oid, error) {
+		if e == nodes.InvalidOid {
+			return nodes.InvalidOid, nil
+		}
+		if t := ps.cat.TypeByOid(e); t != nil && t.Array != nodes.InvalidOid {
+			return t.Array, nil
+		}
+		if isArrayType(ps.cat.TypeByOid(e)) {
+			return e, nil
+		}
+		return nodes.InvalidOid, errorf(CodeUndefinedObject, loc, "could not find array type for data type %s", FormatType(ps.cat, e, -1))
+	}
+	needElem := false
+	for _, d := range declared {
+		switch d {
+		case ANYELEMENTOID, ANYNONARRAYOID, ANYENUMOID, ANYARRAYOID:
+			needElem = true
+		}
+	}
+	if elem == nodes.InvalidOid && needElem {
+		for i, d := range declared {
+			if actual[i] == UNKNOWNOID && (d == ANYELEMENTOID || d == ANYNONARRAYOID || d == ANYENUMOID || d == ANYARRAYOID) {
+				return nil, 0, errorf(CodeDatatypeMismatch, loc, "could not determine polymorphic type because input has type unknown")
+			}
+		}
+	}
+	var compat nodes.Oid
+	if hasCompat {
+		var err error
+		if len(compatExprs) == 0 {
+			compat = TEXTOID
+		} else if compat, err = ps.selectCommonType(compatExprs, "anycompatible"); err != nil {
+			return nil, 0, err
+		}
+	}
+	resolve := func(d nodes.Oid) (nodes.Oid, error) {
+		switch d {
+		case ANYELEMENTOID, ANYNONARRAYOID, ANYENUMOID:
+			return elem, nil
+		case ANYARRAYOID:
+			if array != nodes.InvalidOid {
+				return array, nil
+			}
+			return arrayOf(elem)
+		case ANYRANGEOID, ANYMULTIRANGEOID:
+			return rng, nil
+		case ANYCOMPATIBLEOID, ANYCOMPATIBLENONARRAYOID:
+			return compat, nil
+		case ANYCOMPATIBLEARRAYOID:
+			return arrayOf(compat)
+		}
+		return d, nil
+	}
+	out := make([]nodes.Oid, len(declared))
+	for i, d := range declared {
+		r, err := resolve(d)
+		if err != nil {
+			return nil, 0, err
+		}
+		if r == nodes.InvalidOid {
+			// Nothing to go by: leave the argument as it is.
+			r = actual[i]
+		}
+		out[i] = r
+	}
+	ret, err := resolve(rettype)
+	if err != nil {
+		return nil, 0, err
+	}
+	return out, ret, nil
+}
+
+// makeOp resolves an operator and builds an OpExpr applying it to l and
+// r; l is nil for a prefix operator (make_op). Operators whose name the
+// catalog does not know are left unresolved.
+func (ps *pstate) makeOp(name []string, l, r nodes.Node, loc nodes.ParseLoc) (nodes.Node, error) {
+	schema, opname := qualifiedName(name)
+	var ltype nodes.Oid
+	if l != nil {
+		ltype = ExprType(l)
+	}
+	rtype := ExprType(r)
+	var args []nodes.Node
+	var argTypes []nodes.Oid
+	if l != nil {
+		args, argTypes = append(args, l), append(argTypes, ltype)
+	}
+	args, argTypes = append(args, r), append(argTypes, rtype)
+
+	cands := ps.cat.LookupOperators(schema, opname)
+	unresolved := len(cands) == 0
+	if unresolved {
+		ps.noteUnresolved("operator", name, argTypes, loc)
+	}
+	for _, t := range argTypes {
+		if t == nodes.InvalidOid {
+			unresolved = true
+		}
+	}
+	if unresolved {
+		return &nodes.OpExpr{Args: nodeList(args), Inputcollid: ps.selectCommonCollation(args), Location: loc}, nil
+	}
+	op, err := ps.operSelect(name, cands, l == nil, argTypes, loc)
+	if err != nil {
+		return nil, err
+	}
+	declared := []nodes.Oid{op.Right}
+	if l != nil {
+		declared = []nodes.Oid{op.Left, op.Right}
+	}
+	declared, result, err := ps.resolvePolymorphicTypes(argTypes, declared, op.Result, loc)
+	if err != nil {
+		return nil, err
+	}
+	for i := range args {
+		if args[i], err = ps.coerceArgument(args[i], argTypes[i], declared[i]); err != nil {
+			return nil, err
+		}
+	}
+	inputcoll := ps.selectCommonCollation(args)
+	resultcoll := nodes.InvalidOid
+	if typeCollation(ps.cat, result) != nodes.InvalidOid {
+		resultcoll = inputcoll
+		if resultcoll == nodes.InvalidOid {
+			resultcoll = typeCollation(ps.cat, result)
+		}
+	}
+	return &nodes.OpExpr{
+		Opno:         op.Oid,
+		Opfuncid:     op.Func,
+		Opresulttype: result,
+		Opcollid:     resultcoll,
+		Inputcollid:  inputcoll,
+		Args:         nodeList(args),
+		Location:     loc,
+	}, nil
+}
+
+// operSelect chooses among the operators of a name for the given operand
+// types (oper, left_oper).
+func (ps *pstate) operSelect(name []string, ops []*Operator, prefix bool, argTypes []nodes.Oid, loc nodes.ParseLoc) (*Operator, error) {
+	var cands []*candidate
+	for _, op := range ops {
+		if (op.Left == nodes.InvalidOid) != prefix {
+			continue
+		}
+		c := &candidate{op: op, args: []nodes.Oid{op.Right}}
+		if !prefix {
+			c.args = []nodes.Oid{op.Left, op.Right}
+		}
+		cands = append(cands, c)
+	}
+	// An exact match, taking an unknown operand to be of the other
+	// operand's type (binary_oper_exact).
+	exact := argTypes
+	if len(argTypes) == 2 {
+		l, r := argTypes[0], argTypes[1]
+		if l == UNKNOWNOID && r != UNKNOWNOID {
+			l = r
+		} else if r == UNKNOWNOID && l != UNKNOWNOID {
+			r = l
+		}
+		exact = []nodes.Oid{l, r}
+	}
+	for _, try := range [][]nodes.Oid{exact, baseTypes(ps.cat, exact)} {
+		for _, c := range cands {
+			if oidsEqual(c.args, try) {
+				return c.op, nil
+			}
+		}
+	}
+	var compatible []*candidate
+	for _, c := range cands {
+		if ps.canCoerceType(argTypes, c.args, nodes.COERCION_IMPLICIT) {
+			compatible = append(compatible, c)
+		}
+	}
+	var best *candidate
+	switch len(compatible) {
+	case 0:
+		return nil, errorf(CodeUndefinedFunction, loc, "operator does not exist: %s", opSignatureString(ps.cat, name, argTypes)).
+			withHint("No operator matches the given name and argument types. You might need to add explicit type casts.")
+	case 1:
+		best = compatible[0]
+	default:
+		best = ps.selectCandidate(argTypes, compatible)
+	}
+	if best == nil {
+		return nil, errorf(CodeAmbiguousFunction, loc, "operator is not unique: %s", opSignatureString(ps.cat, name, argTypes)).
+			withHint("Could not choose a best candidate operator. You might need to add explicit type casts.")
+	}
+	return best.op, nil
+}
+
+// selectCandidate chooses the best of several candidates whose parameters
+// all accept the input types, by the heuristics of func_select_candidate.
+// It returns nil if no single candidate is best.
+func (ps *pstate) selectCandidate(inputTypes []nodes.Oid, cands []*candidate) *candidate {
+	nargs := len(inputTypes)
+	input := baseTypes(ps.cat, inputTypes)
+	nunknowns := 0
+	for _, t := range input {
+		if t == UNKNOWNOID || t == nodes.InvalidOid {
+			nunknowns++
+		}
+	}
+	known := func(t nodes.Oid) bool { return t != UNKNOWNOID && t != nodes.InvalidOid }
+
+	// keepBest keeps the candidates with the highest score.
+	keepBest := func(cs []*candidate, score func(c *candidate) int) []*candidate {
+		best := -1
+		var out []*candidate
+		for _, c := range cs {
+			s := score(c)
+			if s > best {
+				best, out = s, nil
+			}
+			if s == best {
+				out = append(out, c)
+			}
+		}
+		return out
+	}
+
+	// Most exact matches on known input types.
+	cands = keepBest(cands, func(c *candidate) int {
+		n := 0
+		for i := 0; i < nargs; i++ {
+			if known(input[i]) && c.args[i] == input[i] {
+				n++
+			}
+		}
+		return n
+	})
+	if len(cands) == 1 {
+		return cands[0]
+	}
+
+	// Most exact or preferred-type matches where conversion is needed.
+	cands = keepBest(cands, func(c *candidate) int {
+		n := 0
+		for i := 0; i < nargs; i++ {
+			if !known(input[i]) {
+				continue
+			}
+			if c.args[i] == input[i] || ps.isPreferredType(c.args[i]) && typeCategory(ps.cat, c.args[i]) == typeCategory(ps.cat, input[i]) {
+				n++
+			}
+		}
+		return n
+	})
+	if len(cands) == 1 {
+		return cands[0]
+	}
+	if nunknowns == 0 {
+		return nil
+	}
+
+	// Resolve unknown inputs by the type categories the candidates
+	// accept there, preferring the string category.
+	for i := 0; i < nargs; i++ {
+		if known(input[i]) {
+			continue
+		}
+		var category byte
+		preferred := false
+		conflict := false
+		for _, c := range cands {
+			cat := typeCategory(ps.cat, c.args[i])
+			switch {
+			case category == 0:
+				category, preferred = cat, ps.isPreferredType(c.args[i])
+			case cat == category:
+				preferred = preferred || ps.isPreferredType(c.args[i])
+			case cat == 'S':
+				category, preferred = cat, ps.isPreferredType(c.args[i])
+			case category != 'S':
+				conflict = true
+			}
+		}
+		if conflict {
+			continue
+		}
+		var kept []*candidate
+		for _, c := range cands {
+			if typeCategory(ps.cat, c.args[i]) != category {
+				continue
+			}
+			if preferred && !ps.isPreferredType(c.args[i]) {
+				continue
+			}
+			kept = append(kept, c)
+		}
+		if len(kept) > 0 {
+			cands = kept
+		}
+	}
+	if len(cands) == 1 {
+		return cands[0]
+	}
+
+	// If the known inputs are all of one type, assume the unknown ones
+	// are too.
+	var common nodes.Oid
+	for _, t := range input {
+		if !known(t) {
+			continue
+		}
+		if common != nodes.InvalidOid && t != common {
+			return nil
+		}
+		common = t
+	}
+	if common == nodes.InvalidOid {
+		return nil
+	}
+	var last *candidate
+	n := 0
+	for _, c := range cands {
+		ok := true
+		for i := 0; i < nargs; i++ {
+			if !known(input[i]) && !ps.canCoerceType([]nodes.Oid{common}, []nodes.Oid{c.args[i]}, nodes.COERCION_IMPLICIT) {
+				ok = false
+			}
+		}
+		if ok {
+			last = c
+			n++
+		}
+	}
+	if n == 1 {
+		return last
+	}
+	return nil
+}
+
+// makeScalarArrayOp builds "l op ANY/ALL (r)" for an array r
+// (make_scalar_array_op).
+func (ps *pstate) makeScalarArrayOp(name []string, useOr bool, l, r nodes.Node, loc nodes.ParseLoc) (nodes.Node, error) {
+	ltype, atype := ExprType(l), ExprType(r)
+	var rtype nodes.Oid
+	switch atype {
+	case UNKNOWNOID, nodes.InvalidOid:
+		rtype = atype
+	default:
+		base, _ := baseType(ps.cat, atype, -1)
+		t := ps.cat.TypeByOid(base)
+		if !isArrayType(t) {
+			return nil, errorf(CodeWrongObjectType, loc, "op ANY/ALL (array) requires array on right side")
+		}
+		rtype = t.Elem
+	}
+	schema, opname := qualifiedName(name)
+	cands := ps.cat.LookupOperators(schema, opname)
+	if len(cands) == 0 {
+		ps.noteUnresolved("operator", name, []nodes.Oid{ltype, rtype}, loc)
+	}
+	if len(cands) == 0 || ltype == nodes.InvalidOid || rtype == nodes.InvalidOid {
+		return &nodes.ScalarArrayOpExpr{UseOr: useOr, Inputcollid: ps.selectCommonCollation([]nodes.Node{l, r}), Args: nodeList([]nodes.Node{l, r}), Location: loc}, nil
+	}
+	op, err := ps.operSelect(name, cands, false, []nodes.Oid{ltype, rtype}, loc)
+	if err != nil {
+		return nil, err
+	}
+	declared, result, err := ps.resolvePolymorphicTypes([]nodes.Oid{ltype, rtype}, []nodes.Oid{op.Left, op.Right}, op.Result, loc)
+	if err != nil {
+		return nil, err
+	}
+	if result != BOOLOID {
+		return nil, errorf(CodeWrongObjectType, loc, "op ANY/ALL (array) requires operator to yield boolean")
+	}
+	arrType := builtinArrayType(declared[1])
+	if t := ps.cat.TypeByOid(declared[1]); t != nil && t.Array != nodes.InvalidOid {
+		arrType = t.Array
+	}
+	if arrType == nodes.InvalidOid {
+		return nil, errorf(CodeUndefinedObject, loc, "could not find array type for data type %s", FormatType(ps.cat, declared[1], -1))
+	}
+	if l, err = ps.coerceArgument(l, ltype, declared[0]); err != nil {
+		return nil, err
+	}
+	if r, err = ps.coerceArgument(r, atype, arrType); err != nil {
+		return nil, err
+	}
+	return &nodes.ScalarArrayOpExpr{
+		Opno:        op.Oid,
+		Opfuncid:    op.Func,
+		UseOr:       useOr,
+		Inputcollid: ps.selectCommonCollation([]nodes.Node{l, r}),
+		Args:        nodeList([]nodes.Node{l, r}),
+		Location:    loc,
+	}, nil
+}
+
+// makeRowComparisonOp compares two rows of expressions column by column
+// (make_row_comparison_op). = and <> become an AND or OR of the column
+// comparisons; the ordering operators become a RowCompareExpr.
+func (ps *pstate) makeRowComparisonOp(name []string, largs, rargs []nodes.Node, loc nodes.ParseLoc) (nodes.Node, error) {
+	if len(largs) != len(rargs) {
+		return nil, errorf(CodeSyntaxError, loc, "unequal number of entries in row expressions")
+	}
+	if len(largs) == 0 {
+		return nil, errorf(CodeFeatureNotSupported, loc, "cannot compare rows of zero length")
+	}
+	var ops []*nodes.OpExpr
+	for i := range largs {
+		e, err := ps.makeOp(name, largs[i], rargs[i], loc)
+		if err != nil {
+			return nil, err
+		}
+		op := e.(*nodes.OpExpr)
+		if op.Opresulttype != BOOLOID && op.Opresulttype != nodes.InvalidOid {
+			return nil, errorf(CodeDatatypeMismatch, loc, "row comparison operator must yield type boolean, not type %s", FormatType(ps.cat, op.Opresulttype, -1))
+		}
+		ops = append(ops, op)
+	}
+	if len(ops) == 1 {
+		return ops[0], nil
+	}
+	_, opname := qualifiedName(name)
+	rctype := map[string]nodes.RowCompareType{
+		"<": nodes.ROWCOMPARE_LT, "<=": nodes.ROWCOMPARE_LE, "=": nodes.ROWCOMPARE_EQ,
+		">=": nodes.ROWCOMPARE_GE, ">": nodes.ROWCOMPARE_GT, "<>": nodes.ROWCOMPARE_NE,
+	}[opname]
+	switch rctype {
+	case nodes.ROWCOMPARE_EQ, nodes.ROWCOMPARE_NE:
+		var parts []nodes.Node
+		for _, op := range ops {
+			parts = append(parts, op)
+		}
+		if rctype == nodes.ROWCOMPARE_EQ {
+			return makeBoolExpr(nodes.AND_EXPR, parts, loc), nil
+		}
+		return makeBoolExpr(nodes.OR_EXPR, parts, loc), nil
+	case 0:
+		return nil, errorf(CodeUndefinedFunction, loc, "could not determine interpretation of row comparison operator %s", opname).
+			withHint("Row comparison operators must be associated with btree operator families.")
+	}
+	rc := &nodes.RowCompareExpr{Rctype: rctype, Opnos: &nodes.OidList{}, Opfamilies: &nodes.OidList{}, Inputcollids: &nodes.OidList{}, Largs: &nodes.List{}, Rargs: &nodes.List{}}
+	for _, op := range ops {
+		rc.Opnos.Items = append(rc.Opnos.Items, op.Opno)
+		rc.Inputcollids.Items = append(rc.Inputcollids.Items, op.Inputcollid)
+		rc.Largs.Items = append(rc.Largs.Items, op.Args.Items[0])
+		rc.Rargs.Items = append(rc.Rargs.Items, op.Args.Items[1])
+	}
+	return rc, nil
+}
+
+// sortOperators returns the "=" and "<" operators of a type, for
+// SortGroupClauses. The OIDs are InvalidOid when the catalog does not
+// number its operators.
+func (ps *pstate) sortOperators(typ nodes.Oid) (eq, lt nodes.Oid) {
+	if op := ps.lookupSortOperator("=", typ); op != nil {
+		eq = op.Oid
+	}
+	if op := ps.lookupSortOperator("<", typ); op != nil {
+		lt = op.Oid
+	}
+	return eq, lt
+}
+
+// lookupSortOperator finds the operator name (= or <) taking two values of
+// typ, or nil if the type has none.
+func (ps *pstate) lookupSortOperator(name string, typ nodes.Oid) *Operator {
+	base, _ := baseType(ps.cat, typ, -1)
+	t := ps.cat.TypeByOid(base)
+	var generic nodes.Oid
+	switch {
+	case isArrayType(t):
+		generic = ANYARRAYOID
+	case t != nil && t.Kind == 'e':
+		generic = ANYENUMOID
+	case t != nil && t.Kind == 'r':
+		generic = ANYRANGEOID
+	case ps.isComplexType(base):
+		generic = RECORDOID
+	}
+	ops := ps.cat.LookupOperators("pg_catalog", name)
+	for _, op := range ops {
+		if op.Left == op.Right && (op.Left == base || op.Left == generic && generic != nodes.InvalidOid) {
+			return op
+		}
+	}
+	// A type such as varchar uses the operators of the type it is
+	// binary-coercible to.
+	for _, op := range ops {
+		if op.Left == op.Right && !isPolymorphicType(op.Left) && op.Left != RECORDOID && ps.isBinaryCoercible(base, op.Left) {
+			return op
+		}
+	}
+	return nil
+}
+
+// figureColname chooses the output column name for an expression that
+// has no alias (FigureColname).
+func figureColname(n nodes.Node) string {
+	if name, _ := figureColnameInternal(n); name != "" {
+		return name
+	}
+	return "?column?"
+}
+
+// figureColnameInternal returns a name for the expression and how strong
+// a choice it is: 2 for a name taken from the expression itself, 1 for a
+// fallback such as a type name, 0 for none.
+func figureColnameInternal(n nodes.Node) (string, int) {
+	switch e := n.(type) {
+	case *nodes.ColumnRef:
+		fields := items(e.Fields)
+		for i := len(fields) - 1; i >= 0; i-- {
+			if s, ok := fields[i].(*nodes.String); ok {
+				return s.Sval, 2
+			}
+		}
+	case *nodes.A_Indirection:
+		for i := len(items(e.Indirection)) - 1; i >= 0; i-- {
+			if s, ok := e.Indirection.Items[i].(*nodes.String); ok {
+				return s.Sval, 2
+			}
+		}
+		return figureColnameInternal(e.Arg)
+	case *nodes.FuncCall:
+		names := stringList(e.Funcname)
+		if len(names) > 0 {
+			return names[len(names)-1], 2
+		}
+	case *nodes.A_Expr:
+		if e.Kind == nodes.AEXPR_NULLIF {
+			return "nullif", 2
+		}
+	case *nodes.TypeCast:
+		if name, strength := figureColnameInternal(e.Arg); strength > 0 {
+			return name, strength
+		}
+		if e.TypeName != nil {
+			names := stringList(e.TypeName.Names)
+			if len(names) > 0 {
+				return names[len(names)-1], 1
+			}
+		}
+	case *nodes.CollateClause:
+		return figureColnameInternal(e.Arg)
+	case *nodes.GroupingFunc:
+		return "grouping", 2
+	case *nodes.SubLink:
+		switch nodes.SubLinkType(e.SubLinkType) {
+		case nodes.EXISTS_SUBLINK:
+			return "exists", 2
+		case nodes.ARRAY_SUBLINK:
+			return "array", 2
+		case nodes.EXPR_SUBLINK:
+			if sel, ok := e.Subselect.(*nodes.SelectStmt); ok {
+				if tl := items(sel.TargetList); len(tl) == 1 {
+					rt := tl[0].(*nodes.ResTarget)
+					if rt.Name != "" {
+						return rt.Name, 2
+					}
+					return figureColnameInternal(rt.Val)
+				}
+			}
+		}
+	case *nodes.CaseExpr:
+		if name, strength := figureColnameInternal(e.Defresult); strength > 0 {
+			return name, strength
+		}
+		return "case", 1
+	case *nodes.A_ArrayExpr:
+		return "array", 1
+	case *nodes.RowExpr:
+		return "row", 1
+	case *nodes.CoalesceExpr:
+		return "coalesce", 2
+	case *nodes.MinMaxExpr:
+		if e.Op == nodes.IS_LEAST {
+			return "least", 2
+		}
+		return "greatest", 2
+	case *nodes.SQLValueFunction:
+		return sqlValueFunctionName(e.Op), 2
+	case *nodes.XmlExpr:
+		return xmlExprName(e.Op), 2
+	case *nodes.XmlSerialize:
+		return "xmlserialize", 2
+	}
+	return "", 0
+}
+
+// sqlValueFunctionName returns the column name of a SQL value function
+// such as CURRENT_DATE.
+func sqlValueFunctionName(op nodes.SQLValueFunctionOp) string {
+	switch op {
+	case nodes.SVFOP_CURRENT_DATE:
+		return "current_date"
+	case nodes.SVFOP_CURRENT_TIME, nodes.SVFOP_CURRENT_TIME_N:
+		return "current_time"
+	case nodes.SVFOP_CURRENT_TIMESTAMP, nodes.SVFOP_CURRENT_TIMESTAMP_N:
+		return "current_timestamp"
+	case nodes.SVFOP_LOCALTIME, nodes.SVFOP_LOCALTIME_N:
+		return "localtime"
+	case nodes.SVFOP_LOCALTIMESTAMP, nodes.SVFOP_LOCALTIMESTAMP_N:
+		return "localtimestamp"
+	case nodes.SVFOP_CURRENT_ROLE:
+		return "current_role"
+	case nodes.SVFOP_CURRENT_USER:
+		return "current_user"
+	case nodes.SVFOP_USER:
+		return "user"
+	case nodes.SVFOP_SESSION_USER:
+		return "session_user"
+	case nodes.SVFOP_CURRENT_CATALOG:
+		return "current_catalog"
+	case nodes.SVFOP_CURRENT_SCHEMA:
+		return "current_schema"
+	}
+	return ""
+}
+
+// xmlExprName returns the column name of an XML function.
+func xmlExprName(op nodes.XmlExprOp) string {
+	switch op {
+	case nodes.IS_XMLCONCAT:
+		return "xmlconcat"
+	case nodes.IS_XMLELEMENT:
+		return "xmlelement"
+	case nodes.IS_XMLFOREST:
+		return "xmlforest"
+	case nodes.IS_XMLPARSE:
+		return "xmlparse"
+	case nodes.IS_XMLPI:
+		return "xmlpi"
+	case nodes.IS_XMLROOT:
+		return "xmlroot"
+	case nodes.IS_XMLSERIALIZE:
+		return "xmlserialize"
+	}
+	return "xmlexists"
+}
+
+// funcSignatureString formats a function call for error messages, as
+// funcname(integer, text) (func_signature_string).
+func funcSignatureString(cat Catalog, names []string, argTypes []nodes.Oid, argNames []string) string {
+	var b strings.Builder
+	b.WriteString(strings.Join(names, "."))
+	b.WriteByte('(')
+	for i, t := range argTypes {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		if i < len(argNames) && argNames[i] != "" {
+			b.WriteString(argNames[i])
+			b.WriteString(" => ")
+		}
+		b.WriteString(FormatType(cat, t, -1))
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// opSignatureString formats an operator and its operand types for error
+// messages, as integer + text (op_signature_string).
+func opSignatureString(cat Catalog, name []string, argTypes []nodes.Oid) string {
+	op := strings.Join(name, ".")
+	if len(argTypes) == 1 {
+		return op + " " + FormatType(cat, argTypes[0], -1)
+	}
+	return FormatType(cat, argTypes[0], -1) + " " + op + " " + FormatType(cat, argTypes[1], -1)
+}
+
+// baseTypes returns types with domains replaced by their base types.
+func baseTypes(cat Catalog, types []nodes.Oid) []nodes.Oid {
+	out := make([]nodes.Oid, len(types))
+	for i, t := range types {
+		out[i], _ = baseType(cat, t, -1)
+	}
+	return out
+}
+
+func exprTypes(exprs []nodes.Node) []nodes.Oid {
+	out := make([]nodes.Oid, len(exprs))
+	for i, e := range exprs {
+		out[i] = ExprType(e)
+	}
+	return out
+}
+
+func oidsEqual(a, b []nodes.Oid) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,363 @@
+package sema
+
+import (
+	"reflect"
+
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// ExprType returns the type OID of the value an analyzed expression
+// yields, as exprType in nodeFuncs.c does. It returns InvalidOid for
+// expressions whose type the analyzer could not resolve.
+func ExprType(expr nodes.Node) nodes.Oid {
+	switch n := expr.(type) {
+	case *nodes.Var:
+		return n.Vartype
+	case *nodes.Const:
+		return n.Consttype
+	case *nodes.Param:
+		return n.Paramtype
+	case *nodes.Aggref:
+		return n.Aggtype
+	case *nodes.GroupingFunc:
+		return INT4OID
+	case *nodes.WindowFunc:
+		return n.Wintype
+	case *nodes.SubscriptingRef:
+		return n.Refrestype
+	case *nodes.FuncExpr:
+		return n.Funcresulttype
+	case *nodes.NamedArgExpr:
+		return ExprType(n.Arg)
+	case *nodes.OpExpr:
+		return n.Opresulttype
+	case *nodes.DistinctExpr:
+		return n.Opresulttype
+	case *nodes.NullIfExpr:
+		return n.Opresulttype
+	case *nodes.ScalarArrayOpExpr, *nodes.BoolExpr, *nodes.RowCompareExpr,
+		*nodes.NullTest, *nodes.BooleanTest:
+		return BOOLOID
+	case *nodes.SubLink:
+		switch nodes.SubLinkType(n.SubLinkType) {
+		case nodes.EXPR_SUBLINK, nodes.ARRAY_SUBLINK:
+			tle := firstTargetEntry(n.Subselect)
+			if tle == nil {
+				return nodes.InvalidOid
+			}
+			t := ExprType(tle.Expr)
+			if nodes.SubLinkType(n.SubLinkType) == nodes.ARRAY_SUBLINK {
+				return builtinArrayType(t)
+			}
+			return t
+		case nodes.MULTIEXPR_SUBLINK:
+			return RECORDOID
+		}
+		return BOOLOID
+	case *nodes.FieldSelect:
+		return n.Resulttype
+	case *nodes.FieldStore:
+		return n.Resulttype
+	case *nodes.RelabelType:
+		return n.Resulttype
+	case *nodes.CoerceViaIO:
+		return n.Resulttype
+	case *nodes.ArrayCoerceExpr:
+		return n.Resulttype
+	case *nodes.CoerceToDomain:
+		return n.Resulttype
+	case *nodes.CollateExpr:
+		return ExprType(n.Arg)
+	case *nodes.CaseExpr:
+		return n.Casetype
+	case *nodes.CaseTestExpr:
+		return n.TypeId
+	case *nodes.ArrayExpr:
+		return n.ArrayTypeid
+	case *nodes.RowExpr:
+		return n.RowTypeid
+	case *nodes.CoalesceExpr:
+		return n.Coalescetype
+	case *nodes.MinMaxExpr:
+		return n.Minmaxtype
+	case *nodes.SQLValueFunction:
+		return sqlValueFunctionType(n.Op)
+	case *nodes.SetToDefault:
+		return n.TypeId
+	case *nodes.InferenceElem:
+		return ExprType(n.Expr)
+	}
+	return nodes.InvalidOid
+}
+
+// ExprTypmod returns the typmod of an analyzed expression, or -1 if it is
+// not known (exprTypmod).
+func ExprTypmod(expr nodes.Node) int32 {
+	switch n := expr.(type) {
+	case *nodes.Var:
+		return n.Vartypmod
+	case *nodes.Const:
+		return n.Consttypmod
+	case *nodes.Param:
+		return n.Paramtypmod
+	case *nodes.SubscriptingRef:
+		return n.Reftypmod
+	case *nodes.FuncExpr:
+		if mod, ok := lengthCoercionTypmod(n); ok {
+			return mod
+		}
+	case *nodes.NamedArgExpr:
+		return ExprTypmod(n.Arg)
+	case *nodes.NullIfExpr:
+		if args := items(n.Args); len(args) > 0 {
+			return ExprTypmod(args[0])
+		}
+	case *nodes.SubLink:
+		switch nodes.SubLinkType(n.SubLinkType) {
+		case nodes.EXPR_SUBLINK, nodes.ARRAY_SUBLINK:
+			if tle := firstTargetEntry(n.Subselect); tle != nil {
+				return ExprTypmod(tle.Expr)
+			}
+		}
+	case *nodes.FieldSelect:
+		return n.Resulttypmod
+	case *nodes.RelabelType:
+		return n.Resulttypmod
+	case *nodes.ArrayCoerceExpr:
+		return n.Resulttypmod
+	case *nodes.CoerceToDomain:
+		return n.Resulttypmod
+	case *nodes.CollateExpr:
+		return ExprTypmod(n.Arg)
+	case *nodes.CaseExpr:
+		exprs := []nodes.Node{n.Defresult}
+		for _, w := range items(n.Args) {
+			exprs = append(exprs, w.(*nodes.CaseWhen).Result)
+		}
+		return commonTypmod(n.Casetype, exprs)
+	case *nodes.CaseTestExpr:
+		return n.TypeMod
+	case *nodes.ArrayExpr:
+		if n.Multidims {
+			return commonTypmod(n.ArrayTypeid, items(n.Elements))
+		}
+		return commonTypmod(n.ElementTypeid, items(n.Elements))
+	case *nodes.CoalesceExpr:
+		return commonTypmod(n.Coalescetype, items(n.Args))
+	case *nodes.MinMaxExpr:
+		return commonTypmod(n.Minmaxtype, items(n.Args))
+	case *nodes.SQLValueFunction:
+		return n.Typmod
+	case *nodes.SetToDefault:
+		return n.TypeMod
+	}
+	return -1
+}
+
+// commonTypmod returns the typmod shared by all of exprs if they all have
+// type typ, or -1.
+func commonTypmod(typ nodes.Oid, exprs []nodes.Node) int32 {
+	mod := int32(-1)
+	for i, e := range exprs {
+		if e == nil {
+			continue
+		}
+		if ExprType(e) != typ {
+			return -1
+		}
+		m := ExprTypmod(e)
+		if i > 0 && m != mod || m < 0 {
+			return -1
+		}
+		mod = m
+	}
+	return mod
+}
+
+// lengthCoercionTypmod reports the typmod applied by a length coercion
+// function call, such as varchar(x, 14, false) (exprIsLengthCoercion).
+func lengthCoercionTypmod(f *nodes.FuncExpr) (int32, bool) {
+	if f.Funcformat != nodes.COERCE_EXPLICIT_CAST && f.Funcformat != nodes.COERCE_IMPLICIT_CAST {
+		return -1, false
+	}
+	args := items(f.Args)
+	if len(args) < 2 || len(args) > 3 || ExprType(args[0]) != f.Funcresulttype {
+		return -1, false
+	}
+	c, ok := args[1].(*nodes.Const)
+	if !ok || c.Consttype != INT4OID || c.Constisnull {
+		return -1, false
+	}
+	i, ok := c.Constvalue.(*nodes.Integer)
+	if !ok {
+		return -1, false
+	}
+	return int32(i.Ival), true
+}
+
+// ExprCollation returns the collation of the value an analyzed expression
+// yields, or InvalidOid if it is not of a collatable type (exprCollation).
+func ExprCollation(expr nodes.Node) nodes.Oid {
+	switch n := expr.(type) {
+	case *nodes.Var:
+		return n.Varcollid
+	case *nodes.Const:
+		return n.Constcollid
+	case *nodes.Param:
+		return n.Paramcollid
+	case *nodes.Aggref:
+		return n.Aggcollid
+	case *nodes.WindowFunc:
+		return n.Wincollid
+	case *nodes.SubscriptingRef:
+		return n.Refcollid
+	case *nodes.FuncExpr:
+		return n.Funccollid
+	case *nodes.NamedArgExpr:
+		return ExprCollation(n.Arg)
+	case *nodes.OpExpr:
+		return n.Opcollid
+	case *nodes.DistinctExpr:
+		return n.Opcollid
+	case *nodes.NullIfExpr:
+		return n.Opcollid
+	case *nodes.SubLink:
+		switch nodes.SubLinkType(n.SubLinkType) {
+		case nodes.EXPR_SUBLINK, nodes.ARRAY_SUBLINK:
+			if tle := firstTargetEntry(n.Subselect); tle != nil {
+				return ExprCollation(tle.Expr)
+			}
+		}
+	case *nodes.FieldSelect:
+		return n.Resultcollid
+	case *nodes.RelabelType:
+		return n.Resultcollid
+	case *nodes.CoerceViaIO:
+		return n.Resultcollid
+	case *nodes.ArrayCoerceExpr:
+		return n.Resultcollid
+	case *nodes.CoerceToDomain:
+		return n.Resultcollid
+	case *nodes.CollateExpr:
+		return n.CollOid
+	case *nodes.CaseExpr:
+		return n.Casecollid
+	case *nodes.CaseTestExpr:
+		return n.Collation
+	case *nodes.ArrayExpr:
+		return n.ArrayCollid
+	case *nodes.CoalesceExpr:
+		return n.Coalescecollid
+	case *nodes.MinMaxExpr:
+		return n.Minmaxcollid
+	case *nodes.SQLValueFunction:
+		if sqlValueFunctionType(n.Op) == NAMEOID {
+			return C_COLLATION_OID
+		}
+	case *nodes.SetToDefault:
+		return n.Collation
+	case *nodes.InferenceElem:
+		return ExprCollation(n.Expr)
+	}
+	return nodes.InvalidOid
+}
+
+// sqlValueFunctionType returns the result type of a SQLValueFunction.
+func sqlValueFunctionType(op nodes.SQLValueFunctionOp) nodes.Oid {
+	switch op {
+	case nodes.SVFOP_CURRENT_DATE:
+		return DATEOID
+	case nodes.SVFOP_CURRENT_TIME, nodes.SVFOP_CURRENT_TIME_N:
+		return TIMETZOID
+	case nodes.SVFOP_CURRENT_TIMESTAMP, nodes.SVFOP_CURRENT_TIMESTAMP_N:
+		return TIMESTAMPTZOID
+	case nodes.SVFOP_LOCALTIME, nodes.SVFOP_LOCALTIME_N:
+		return TIMEOID
+	case nodes.SVFOP_LOCALTIMESTAMP, nodes.SVFOP_LOCALTIMESTAMP_N:
+		return TIMESTAMPOID
+	}
+	return NAMEOID
+}
+
+// firstTargetEntry returns the first non-junk target entry of an analyzed
+// subquery.
+func firstTargetEntry(q nodes.Node) *nodes.TargetEntry {
+	query, ok := q.(*nodes.Query)
+	if !ok {
+		return nil
+	}
+	for _, item := range items(query.TargetList) {
+		if tle := item.(*nodes.TargetEntry); !tle.Resjunk {
+			return tle
+		}
+	}
+	return nil
+}
+
+// builtinArrayType returns the array type of a built-in element type,
+// for the cases where no Catalog is at hand.
+func builtinArrayType(elem nodes.Oid) nodes.Oid {
+	for _, b := range builtinTypeTable {
+		if b.oid == elem {
+			return b.array
+		}
+	}
+	return nodes.InvalidOid
+}
+
+var locationType = reflect.TypeOf(nodes.ParseLoc(0))
+
+// equalExprs reports whether two expression trees are equal, ignoring
+// token locations, as equal() does in PostgreSQL.
+func equalExprs(a, b nodes.Node) bool {
+	return equalValues(reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+func equalValues(a, b reflect.Value) bool {
+	if a.IsValid() != b.IsValid() {
+		return false
+	}
+	if !a.IsValid() {
+		return true
+	}
+	if a.Kind() == reflect.Interface {
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		a, b = a.Elem(), b.Elem()
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+	switch a.Kind() {
+	case reflect.Pointer:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		if a.Pointer() == b.Pointer() {
+			return true
+		}
+		return equalValues(a.Elem(), b.Elem())
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if a.Type().Field(i).Type == locationType {
+				continue
+			}
+			if !equalValues(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !equalValues(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	return a.Interface() == b.Interface()
+}
@@ -0,0 +1,601 @@
+package sema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// nsItem is an entry of a query level's namespace: a range table entry
+// together with the names it can be referenced by (ParseNamespaceItem).
+type nsItem struct {
+	name        string // reference name, or "" if the entry cannot be named
+	rte         *nodes.RangeTblEntry
+	rtindex     int
+	colnames    []string
+	columns     []nsColumn
+	rowType     nodes.Oid // type of a whole-row reference
+	relVisible  bool      // relation name is visible?
+	colsVisible bool      // unqualified column names are visible?
+	lateralOnly bool      // visible only to LATERAL expressions?
+	lateralOK   bool      // is a LATERAL reference allowed?
+}
+
+// nsColumn describes how to build a Var for a column of an nsItem
+// (ParseNamespaceColumn).
+type nsColumn struct {
+	varno       int
+	varattno    nodes.AttrNumber
+	typ         nodes.Oid
+	typmod      int32
+	collid      nodes.Oid
+	varnosyn    int
+	varattnosyn nodes.AttrNumber
+	dontExpand  bool // not included in star expansion
+}
+
+// System columns of a table (sysattr.h).
+var systemColumns = []struct {
+	name  string
+	attno nodes.AttrNumber
+	typ   nodes.Oid
+}{
+	{"ctid", -1, TIDOID},
+	{"xmin", -2, XIDOID},
+	{"cmin", -3, CIDOID},
+	{"xmax", -4, XIDOID},
+	{"cmax", -5, CIDOID},
+	{"tableoid", -6, OIDOID},
+}
+
+// addRTE appends an entry to the range table and returns its index.
+func (ps *pstate) addRTE(rte *nodes.RangeTblEntry) int {
+	ps.rtable = append(ps.rtable, rte)
+	return len(ps.rtable)
+}
+
+// newNSItem builds a namespace item for a range table entry whose columns
+// are the entry's own output columns.
+func newNSItem(rte *nodes.RangeTblEntry, rtindex int, types []nodes.Oid, typmods []int32, colls []nodes.Oid) *nsItem {
+	it := &nsItem{
+		name:        rte.Eref.Aliasname,
+		rte:         rte,
+		rtindex:     rtindex,
+		colnames:    stringList(rte.Eref.Colnames),
+		rowType:     RECORDOID,
+		relVisible:  true,
+		colsVisible: true,
+		lateralOK:   true,
+	}
+	for i := range types {
+		it.columns = append(it.columns, nsColumn{
+			varno:       rtindex,
+			varattno:    nodes.AttrNumber(i + 1),
+			typ:         types[i],
+			typmod:      typmods[i],
+			collid:      colls[i],
+			varnosyn:    rtindex,
+			varattnosyn: nodes.AttrNumber(i + 1),
+		})
+	}
+	return it
+}
+
+// buildEref builds the eref of a range table entry from its reference
+// name, the user's alias and the underlying column names.
+func buildEref(refname string, alias *nodes.Alias, colnames []string, tooMany func(n int) *Error) (*nodes.Alias, error) {
+	names := append([]string(nil), colnames...)
+	if alias != nil {
+		aliases := stringList(alias.Colnames)
+		if len(aliases) > len(names) {
+			return nil, tooMany(len(aliases))
+		}
+		copy(names, aliases)
+	}
+	return &nodes.Alias{Aliasname: refname, Colnames: makeStringList(names)}, nil
+}
+
+// addRangeTableEntry adds a relation to the range table
+// (addRangeTableEntryForRelation).
+func (ps *pstate) addRangeTableEntry(rel *Relation, alias *nodes.Alias, inh, inFromCl bool, lockmode int) (*nsItem, error) {
+	refname := rel.Name
+	if alias != nil {
+		refname = alias.Aliasname
+	}
+	var colnames []string
+	var types, colls []nodes.Oid
+	var typmods []int32
+	for _, c := range rel.Columns {
+		colnames = append(colnames, c.Name)
+		types = append(types, c.Type)
+		typmods = append(typmods, c.Typmod)
+		colls = append(colls, c.Collation)
+	}
+	eref, err := buildEref(refname, alias, colnames, func(n int) *Error {
+		return errorf(CodeInvalidColumnReference, -1, "table %q has %d columns available but %d columns specified", refname, len(colnames), n)
+	})
+	if err != nil {
+		return nil, err
+	}
+	rte := &nodes.RangeTblEntry{
+		Alias:       alias,
+		Eref:        eref,
+		Rtekind:     nodes.RTE_RELATION,
+		Relid:       rel.Oid,
+		Relkind:     rel.Kind,
+		Rellockmode: lockmode,
+		Inh:         inh,
+		InFromCl:    inFromCl,
+	}
+	it := newNSItem(rte, ps.addRTE(rte), types, typmods, colls)
+	it.rowType = rel.RowType
+	return it, nil
+}
+
+// addRangeTableEntryForSubquery adds a sub-SELECT to the range table.
+func (ps *pstate) addRangeTableEntryForSubquery(q *nodes.Query, alias *nodes.Alias, refname string, lateral, inFromCl bool) (*nsItem, error) {
+	var colnames []string
+	var types, colls []nodes.Oid
+	var typmods []int32
+	for _, item := range items(q.TargetList) {
+		tle := item.(*nodes.TargetEntry)
+		if tle.Resjunk {
+			continue
+		}
+		name := tle.Resname
+		if name == "" {
+			name = "?column?"
+		}
+		colnames = append(colnames, name)
+		types = append(types, ExprType(tle.Expr))
+		typmods = append(typmods, ExprTypmod(tle.Expr))
+		colls = append(colls, ExprCollation(tle.Expr))
+	}
+	if alias != nil {
+		refname = alias.Aliasname
+	}
+	eref, err := buildEref(refname, alias, colnames, func(n int) *Error {
+		return errorf(CodeInvalidColumnReference, -1, "table %q has %d columns available but %d columns specified", refname, len(colnames), n)
+	})
+	if err != nil {
+		return nil, err
+	}
+	rte := &nodes.RangeTblEntry{
+		Alias:    alias,
+		Eref:     eref,
+		Rtekind:  nodes.RTE_SUBQUERY,
+		Subquery: q,
+		Lateral:  lateral,
+		InFromCl: inFromCl,
+	}
+	it := newNSItem(rte, ps.addRTE(rte), types, typmods, colls)
+	if alias == nil && refname == "unnamed_subquery" {
+		it.name = ""
+	}
+	return it, nil
+}
+
+// addRangeTableEntryForValues adds a multi-row VALUES list to the range
+// table.
+func (ps *pstate) addRangeTableEntryForValues(lists [][]nodes.Node, types []nodes.Oid, typmods []int32, colls []nodes.Oid, alias *nodes.Alias, lateral, inFromCl bool) (*nsItem, error) {
+	refname := "*VALUES*"
+	if alias != nil {
+		refname = alias.Aliasname
+	}
+	var colnames []string
+	for i := range types {
+		colnames = append(colnames, fmt.Sprintf("column%d", i+1))
+	}
+	eref, err := buildEref(refname, alias, colnames, func(n int) *Error {
+		return errorf(CodeInvalidColumnReference, -1, "VALUES lists %q have %d columns available but %d columns specified", refname, len(colnames), n)
+	})
+	if err != nil {
+		return nil, err
+	}
+	vl := &nodes.List{}
+	for _, row := range lists {
+		vl.Items = append(vl.Items, &nodes.List{Items: row})
+	}
+	rte := &nodes.RangeTblEntry{
+		Alias:         alias,
+		Eref:          eref,
+		Rtekind:       nodes.RTE_VALUES,
+		ValuesLists:   vl,
+		Coltypes:      &nodes.OidList{Items: types},
+		Coltypmods:    intList(typmods),
+		Colcollations: &nodes.OidList{Items: colls},
+		Lateral:       lateral,
+		InFromCl:      inFromCl,
+	}
+	return newNSItem(rte, ps.addRTE(rte), types, typmods, colls), nil
+}
+
+// addRangeTableEntryForCTE adds a reference to a WITH query to the range
+// table. A reference made while the CTE's own query is still being
+// analyzed is a recursive self-reference.
+func (ps *pstate) addRangeTableEntryForCTE(cte *nodes.CommonTableExpr, levelsup int, rv *nodes.RangeVar) (*nsItem, error) {
+	refname := rv.Relname
+	if rv.Alias != nil {
+		refname = rv.Alias.Aliasname
+	}
+	_, analyzed := cte.Ctequery.(*nodes.Query)
+	selfRef := !analyzed
+	if selfRef && cte.Ctecolnames == nil {
+		return nil, errorf(CodeInvalidRecursion, rv.Location, "recursive reference to query %q must not appear within its non-recursive term", cte.Ctename)
+	}
+	if !selfRef {
+		cte.Cterefcount++
+	}
+	colnames := stringList(cte.Ctecolnames)
+	eref, err := buildEref(refname, rv.Alias, colnames, func(n int) *Error {
+		return errorf(CodeInvalidColumnReference, rv.Location, "table %q has %d columns available but %d columns specified", refname, len(colnames), n)
+	})
+	if err != nil {
+		return nil, err
+	}
+	types := append([]nodes.Oid(nil), cte.Ctecoltypes.Items...)
+	colls := append([]nodes.Oid(nil), cte.Ctecolcollations.Items...)
+	var typmods []int32
+	for _, m := range cte.Ctecoltypmods.Items {
+		typmods = append(typmods, int32(m))
+	}
+	rte := &nodes.RangeTblEntry{
+		Alias:         rv.Alias,
+		Eref:          eref,
+		Rtekind:       nodes.RTE_CTE,
+		Ctename:       cte.Ctename,
+		Ctelevelsup:   uint32(levelsup),
+		SelfReference: selfRef,
+		Coltypes:      &nodes.OidList{Items: types},
+		Coltypmods:    intList(typmods),
+		Colcollations: &nodes.OidList{Items: colls},
+		InFromCl:      true,
+	}
+	return newNSItem(rte, ps.addRTE(rte), types, typmods, colls), nil
+}
+
+// addRangeTableEntryForJoin adds a join to the range table. The columns
+// are supplied by the caller, since merged columns of JOIN USING refer to
+// the join itself.
+func (ps *pstate) addRangeTableEntryForJoin(colnames []string, columns []nsColumn, j *nodes.JoinExpr, nmerged int, aliasvars []nodes.Node, leftcols, rightcols []int) (*nsItem, error) {
+	refname := "unnamed_join"
+	if j.Alias != nil {
+		refname = j.Alias.Aliasname
+	}
+	eref, err := buildEref(refname, j.Alias, colnames, func(n int) *Error {
+		return errorf(CodeInvalidColumnReference, -1, "join expression %q has %d columns available but %d columns specified", refname, len(colnames), n)
+	})
+	if err != nil {
+		return nil, err
+	}
+	rte := &nodes.RangeTblEntry{
+		Alias:          j.Alias,
+		Eref:           eref,
+		Rtekind:        nodes.RTE_JOIN,
+		Jointype:       j.Jointype,
+		Joinmergedcols: nmerged,
+		Joinaliasvars:  &nodes.List{Items: aliasvars},
+		Joinleftcols:   &nodes.IntList{Items: leftcols},
+		Joinrightcols:  &nodes.IntList{Items: rightcols},
+		JoinUsingAlias: j.JoinUsingAlias,
+		InFromCl:       true,
+	}
+	idx := ps.addRTE(rte)
+	for i := range columns {
+		c := &columns[i]
+		if c.varno == 0 {
+			c.varno = idx
+		}
+		if c.varnosyn == 0 {
+			c.varnosyn = idx
+		}
+		if j.Alias != nil {
+			c.varnosyn, c.varattnosyn = idx, nodes.AttrNumber(i+1)
+		}
+	}
+	it := &nsItem{
+		name:        refname,
+		rte:         rte,
+		rtindex:     idx,
+		colnames:    stringList(eref.Colnames),
+		columns:     columns,
+		rowType:     RECORDOID,
+		relVisible:  true,
+		colsVisible: true,
+		lateralOK:   true,
+	}
+	if j.Alias == nil {
+		it.name = ""
+	}
+	return it, nil
+}
+
+// setTargetTable opens the target relation of INSERT, UPDATE, DELETE or
+// MERGE and adds it to the range table, and to the namespace if
+// alsoSource is set.
+func (ps *pstate) setTargetTable(rv *nodes.RangeVar, inh, alsoSource bool) (int, error) {
+	if rv.Schemaname == "" {
+		if cte, _ := ps.scanNameSpaceForCTE(rv.Relname); cte != nil {
+			return 0, errorf(CodeFeatureNotSupported, rv.Location, "WITH query name %q cannot be used as a target relation", rv.Relname).
+				withDetail("Target relations must be tables.")
+		}
+	}
+	rel, err := ps.openRelation(rv)
+	if err != nil {
+		return 0, err
+	}
+	it, err := ps.addRangeTableEntry(rel, rv.Alias, inh, false, nodes.RowExclusiveLock)
+	if err != nil {
+		return 0, err
+	}
+	ps.targetNSItem = it
+	ps.targetRel = rel
+	if alsoSource {
+		ps.namespace = append(ps.namespace, it)
+	}
+	return it.rtindex, nil
+}
+
+// openRelation looks up the relation a RangeVar names.
+func (ps *pstate) openRelation(rv *nodes.RangeVar) (*Relation, error) {
+	rel := ps.cat.LookupRelation(rv.Schemaname, rv.Relname)
+	if rel == nil {
+		name := rv.Relname
+		if rv.Schemaname != "" {
+			name = rv.Schemaname + "." + name
+		}
+		return nil, errorf(CodeUndefinedTable, rv.Location, "relation %q does not exist", name)
+	}
+	return rel, nil
+}
+
+// scanNameSpaceForCTE finds a WITH query visible at this level or an outer
+// one, returning it and the number of levels up it was found.
+func (ps *pstate) scanNameSpaceForCTE(name string) (*nodes.CommonTableExpr, int) {
+	levels := 0
+	for p := ps; p != nil; p = p.parent {
+		for _, cte := range p.ctes {
+			if cte.Ctename == name {
+				return cte, levels
+			}
+		}
+		levels++
+	}
+	return nil, 0
+}
+
+// visible reports whether a namespace item can be referenced from an
+// expression at this level.
+func (ps *pstate) visible(it *nsItem) bool {
+	return !it.lateralOnly || ps.lateralActive && it.lateralOK
+}
+
+// refnameNSItem finds the namespace item a relation reference denotes,
+// searching outward through the enclosing query levels
+// (refnameNamespaceItem). A schema-qualified name matches only an
+// unaliased entry for that relation.
+func (ps *pstate) refnameNSItem(schema, name string, loc nodes.ParseLoc) (*nsItem, int, error) {
+	var relid nodes.Oid
+	if schema != "" {
+		rel := ps.cat.LookupRelation(schema, name)
+		if rel == nil {
+			return nil, 0, nil
+		}
+		relid = rel.Oid
+	}
+	levels := 0
+	for p := ps; p != nil; p = p.parent {
+		var result *nsItem
+		for _, it := range p.namespace {
+			if !it.relVisible || !p.visible(it) {
+				continue
+			}
+			if schema == "" && (it.name == "" || it.name != name) {
+				continue
+			}
+			if schema != "" && (it.rte.Rtekind != nodes.RTE_RELATION || it.rte.Relid != relid || it.rte.Alias != nil) {
+				continue
+			}
+			if result != nil && result.rte != it.rte {
+				return nil, 0, errorf(CodeAmbiguousAlias, loc, "table reference %q is ambiguous", name)
+			}
+			result = it
+		}
+		if result != nil {
+			return result, levels, nil
+		}
+		levels++
+	}
+	return nil, 0, nil
+}
+
+// scanNSItemForColumn returns the Var for a column of a namespace item,
+// or nil if the item has no such column.
+func (ps *pstate) scanNSItemForColumn(it *nsItem, levelsup int, name string, loc nodes.ParseLoc) (nodes.Node, error) {
+	var result nodes.Node
+	for i, cn := range it.colnames {
+		if cn != name {
+			continue
+		}
+		if result != nil {
+			return nil, errorf(CodeAmbiguousColumn, loc, "column reference %q is ambiguous", name)
+		}
+		result = buildVar(&it.columns[i], levelsup, loc)
+	}
+	if result != nil {
+		return result, nil
+	}
+	if it.rte.Rtekind == nodes.RTE_RELATION && it.rte.Relkind != 'v' && it.rte.Relkind != 'c' {
+		for _, sc := range systemColumns {
+			if sc.name == name {
+				return &nodes.Var{
+					Varno:       it.rtindex,
+					Varattno:    sc.attno,
+					Vartype:     sc.typ,
+					Vartypmod:   -1,
+					Varlevelsup: uint32(levelsup),
+					Varnosyn:    uint32(it.rtindex),
+					Varattnosyn: sc.attno,
+					Location:    loc,
+				}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// colNameToVar resolves an unqualified column name, searching outward
+// through the enclosing query levels.
+func (ps *pstate) colNameToVar(name string, loc nodes.ParseLoc) (nodes.Node, error) {
+	levels := 0
+	for p := ps; p != nil; p = p.parent {
+		var result nodes.Node
+		for _, it := range p.namespace {
+			if !it.colsVisible || !p.visible(it) {
+				continue
+			}
+			v, err := ps.scanNSItemForColumn(it, levels, name, loc)
+			if err != nil {
+				return nil, err
+			}
+			if v == nil {
+				continue
+			}
+			if result != nil {
+				return nil, errorf(CodeAmbiguousColumn, loc, "column reference %q is ambiguous", name)
+			}
+			result = v
+		}
+		if result != nil {
+			return result, nil
+		}
+		levels++
+	}
+	return nil, nil
+}
+
+// buildVar makes a Var for a namespace column.
+func buildVar(c *nsColumn, levelsup int, loc nodes.ParseLoc) *nodes.Var {
+	return &nodes.Var{
+		Varno:       c.varno,
+		Varattno:    c.varattno,
+		Vartype:     c.typ,
+		Vartypmod:   c.typmod,
+		Varcollid:   c.collid,
+		Varlevelsup: uint32(levelsup),
+		Varnosyn:    uint32(c.varnosyn),
+		Varattnosyn: c.varattnosyn,
+		Location:    loc,
+	}
+}
+
+// wholeRowVar makes a Var referring to the whole row of a namespace item.
+func wholeRowVar(it *nsItem, levelsup int, loc nodes.ParseLoc) *nodes.Var {
+	typ := it.rowType
+	if it.rte.Rtekind == nodes.RTE_FUNCTION && len(it.columns) == 1 && typ == RECORDOID && it.rte.Functions.Len() == 1 {
+		if f := it.rte.Functions.Items[0].(*nodes.RangeTblFunction); f.Funccoltypes == nil && !it.rte.Funcordinality {
+			typ = it.columns[0].typ
+		}
+	}
+	return &nodes.Var{
+		Varno:       it.rtindex,
+		Vartype:     typ,
+		Vartypmod:   -1,
+		Varlevelsup: uint32(levelsup),
+		Varnosyn:    uint32(it.rtindex),
+		Location:    loc,
+	}
+}
+
+// errorMissingRTE reports a reference to a relation name that is not in
+// the namespace, with a hint if the name exists but is not visible here.
+func (ps *pstate) errorMissingRTE(name string, loc nodes.ParseLoc) error {
+	for p := ps; p != nil; p = p.parent {
+		for _, it := range p.namespace {
+			if it.name == name {
+				return errorf(CodeUndefinedTable, loc, "invalid reference to FROM-clause entry for table %q", name).
+					withHint("There is an entry for table %q, but it cannot be referenced from this part of the query.", name)
+			}
+			if it.rte.Rtekind == nodes.RTE_RELATION && it.rte.Alias != nil && it.rte.Eref != nil {
+				if rel := ps.cat.RelationByOid(it.rte.Relid); rel != nil && rel.Name == name {
+					return errorf(CodeUndefinedTable, loc, "invalid reference to FROM-clause entry for table %q", name).
+						withHint("Perhaps you meant to reference the table alias %q.", it.name)
+				}
+			}
+		}
+	}
+	return errorf(CodeUndefinedTable, loc, "missing FROM-clause entry for table %q", name)
+}
+
+// errorMissingColumn reports a column reference that could not be
+// resolved.
+func (ps *pstate) errorMissingColumn(relname, colname string, loc nodes.ParseLoc) error {
+	var err *Error
+	if relname != "" {
+		err = errorf(CodeUndefinedColumn, loc, "column %s.%s does not exist", relname, colname)
+	} else {
+		err = errorf(CodeUndefinedColumn, loc, "column %q does not exist", colname)
+	}
+	for p := ps; p != nil; p = p.parent {
+		for _, it := range p.namespace {
+			if it.colsVisible && p.visible(it) || it.name == "" {
+				continue
+			}
+			for _, cn := range it.colnames {
+				if cn == colname && (relname == "" || relname == it.name) {
+					return err.withHint("There is a column named %q in table %q, but it cannot be referenced from this part of the query.", colname, it.name)
+				}
+			}
+		}
+	}
+	return err
+}
+
+// checkNameSpaceConflicts reports an error if two namespace lists contain
+// entries with the same reference name.
+func checkNameSpaceConflicts(ns1, ns2 []*nsItem) error {
+	for _, a := range ns1 {
+		if !a.relVisible || a.name == "" {
+			continue
+		}
+		for _, b := range ns2 {
+			if !b.relVisible || b.name != a.name {
+				continue
+			}
+			if a.rte.Rtekind == nodes.RTE_RELATION && a.rte.Alias == nil &&
+				b.rte.Rtekind == nodes.RTE_RELATION && b.rte.Alias == nil &&
+				a.rte.Relid != b.rte.Relid {
+				continue
+			}
+			return errorf(CodeDuplicateAlias, -1, "table name %q specified more than once", a.name)
+		}
+	}
+	return nil
+}
+
+// makeStringList builds a list of String nodes.
+func makeStringList(names []string) *nodes.List {
+	if len(names) == 0 {
+		return nil
+	}
+	l := &nodes.List{}
+	for _, n := range names {
+		l.Items = append(l.Items, &nodes.String{Sval: n})
+	}
+	return l
+}
+
+// intList builds an IntList from typmods.
+func intList(mods []int32) *nodes.IntList {
+	l := &nodes.IntList{}
+	for _, m := range mods {
+		l.Items = append(l.Items, int(m))
+	}
+	return l
+}
+
+// nameListString joins a qualified name for messages.
+func nameListString(l *nodes.List) string {
+	return strings.Join(stringList(l), ".")
+}
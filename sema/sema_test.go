@@ -0,0 +1,291 @@
+package sema
+
+import (
+	"testing"
+
+	"github.com/pgplex/pgparser/catalog"
+	"github.com/pgplex/pgparser/nodes"
+	"github.com/pgplex/pgparser/parser"
+)
+
+const testSchema = `
+CREATE TABLE t (a int PRIMARY KEY, b text, c numeric(10,2), d jsonb, e int[]);
+CREATE TABLE u (x int, y varchar(10));
+CREATE VIEW v AS SELECT a, b FROM t;
+CREATE TYPE mood AS ENUM ('sad', 'happy');
+CREATE TABLE w (m mood, j json);
+`
+
+func testCatalog(t *testing.T) Catalog {
+	t.Helper()
+	list, err := parser.Parse(testSchema)
+	if err != nil {
+		t.Fatalf("Parse schema: %v", err)
+	}
+	c := catalog.New()
+	for _, stmt := range list.Items {
+		if err := c.Apply(stmt); err != nil {
+			t.Fatalf("Apply: %v", err)
+		}
+	}
+	return FromCatalog(c)
+}
+
+func analyze(t *testing.T, cat Catalog, sql string) (*nodes.Query, error) {
+	t.Helper()
+	list, err := parser.Parse(sql)
+	if err != nil {
+		t.Fatalf("Parse(%q) error: %v", sql, err)
+	}
+	return Analyze(list.Items[0], cat)
+}
+
+func TestOutputColumns(t *testing.T) {
+	cat := testCatalog(t)
+	tests := []struct {
+		sql   string
+		want  []string // name and type of each output column
+		origs []string // table.column each output column comes from
+	}{
+		{
+			"SELECT * FROM t",
+			[]string{"a integer", "b text", "c numeric(10,2)", "d jsonb", "e integer[]"},
+			[]string{"t.a", "t.b", "t.c", "t.d", "t.e"},
+		},
+		{
+			"SELECT a + 1, b || 'x' AS bx, 'lit', count(*), sum(c) FROM t GROUP BY a",
+			[]string{"?column? integer", "bx text", "?column? text", "count bigint", "sum numeric"},
+			[]string{"", "", "", "", ""},
+		},
+		{
+			"SELECT s.a, j.y FROM (SELECT a FROM t) s JOIN u j ON s.a = j.x",
+			[]string{"a integer", "y character varying(10)"},
+			[]string{"t.a", "u.y"},
+		},
+		{
+			"SELECT x, b FROM u JOIN t ON x = a UNION ALL SELECT 1, 'z'",
+			[]string{"x integer", "b text"},
+			[]string{"", ""},
+		},
+		{
+			"WITH r AS (SELECT b AS name FROM t) SELECT name FROM r",
+			[]string{"name text"},
+			[]string{"t.b"},
+		},
+		{
+			"SELECT coalesce(a, 1.5), greatest(x, 2), CASE WHEN a > 0 THEN y ELSE b END FROM t, u",
+			[]string{"coalesce numeric", "greatest integer", "b text"},
+			[]string{"", "", ""},
+		},
+		{
+			"SELECT d->>'k', e[1], ARRAY[a, x], ROW(a, b) FROM t, u",
+			[]string{"?column? text", "e integer", "array integer[]", "row record"},
+			[]string{"", "", "", ""},
+		},
+		{
+			"SELECT a::text, CAST(y AS int), int8(x) FROM t, u",
+			[]string{"a text", "y integer", "int8 bigint"},
+			[]string{"", "", ""},
+		},
+		{
+			"SELECT * FROM (VALUES (1, 'a'), (2.5, NULL)) AS vals (n, s)",
+			[]string{"n numeric", "s text"},
+			[]string{"", ""},
+		},
+		{
+			"SELECT a, row_number() OVER (PARTITION BY b ORDER BY c) FROM t",
+			[]string{"a integer", "row_number bigint"},
+			[]string{"t.a", ""},
+		},
+		{
+			"SELECT j.key, j.value, upper(b), length(b) FROM t, jsonb_each(d) j",
+			[]string{"key text", "value jsonb", "upper text", "length integer"},
+			[]string{"", "", "", ""},
+		},
+		{
+			"SELECT v.a FROM v",
+			[]string{"a integer"},
+			[]string{"v.a"},
+		},
+	}
+	for _, tt := range tests {
+		q, err := analyze(t, cat, tt.sql)
+		if err != nil {
+			t.Errorf("Analyze(%q) error: %v", tt.sql, err)
+			continue
+		}
+		var got, origs []string
+		for _, item := range items(q.TargetList) {
+			tle := item.(*nodes.TargetEntry)
+			if tle.Resjunk {
+				continue
+			}
+			got = append(got, tle.Resname+" "+FormatType(cat, ExprType(tle.Expr), ExprTypmod(tle.Expr)))
+			orig := ""
+			if rel := cat.RelationByOid(tle.Resorigtbl); rel != nil {
+				orig = rel.Name + "." + rel.Columns[tle.Resorigcol-1].Name
+			}
+			origs = append(origs, orig)
+		}
+		if !equalStrings(got, tt.want) {
+			t.Errorf("Analyze(%q) columns = %q, want %q", tt.sql, got, tt.want)
+		}
+		if !equalStrings(origs, tt.origs) {
+			t.Errorf("Analyze(%q) origins = %q, want %q", tt.sql, origs, tt.origs)
+		}
+	}
+}
+
+func TestModifyStatements(t *testing.T) {
+	cat := testCatalog(t)
+	tests := []struct {
+		sql     string
+		cmd     nodes.CmdType
+		targets []string // resname of each non-junk target entry
+	}{
+		{"INSERT INTO t (a, b) VALUES (1, 'x') RETURNING *", nodes.CMD_INSERT, []string{"a", "b"}},
+		{"INSERT INTO t VALUES (1), (2)", nodes.CMD_INSERT, []string{"a"}},
+		{"INSERT INTO u SELECT a, b FROM t ON CONFLICT DO NOTHING", nodes.CMD_INSERT, []string{"x", "y"}},
+		{"UPDATE t SET b = y, c = DEFAULT FROM u WHERE a = x", nodes.CMD_UPDATE, []string{"b", "c"}},
+		{"UPDATE t SET (b, c) = (SELECT y, x FROM u LIMIT 1)", nodes.CMD_UPDATE, []string{"b", "c"}},
+		{"DELETE FROM t USING u WHERE a = x RETURNING a", nodes.CMD_DELETE, nil},
+		{"MERGE INTO t USING u ON a = x WHEN MATCHED THEN UPDATE SET b = y WHEN NOT MATCHED THEN INSERT VALUES (x, y)", nodes.CMD_MERGE, nil},
+	}
+	for _, tt := range tests {
+		q, err := analyze(t, cat, tt.sql)
+		if err != nil {
+			t.Errorf("Analyze(%q) error: %v", tt.sql, err)
+			continue
+		}
+		if q.CommandType != tt.cmd {
+			t.Errorf("Analyze(%q) command = %d, want %d", tt.sql, q.CommandType, tt.cmd)
+		}
+		if q.ResultRelation == 0 {
+			t.Errorf("Analyze(%q) has no result relation", tt.sql)
+		}
+		var got []string
+		for _, item := range items(q.TargetList) {
+			if tle := item.(*nodes.TargetEntry); !tle.Resjunk {
+				got = append(got, tle.Resname)
+			}
+		}
+		if !equalStrings(got, tt.targets) {
+			t.Errorf("Analyze(%q) targets = %q, want %q", tt.sql, got, tt.targets)
+		}
+	}
+}
+
+func TestAnalyzeErrors(t *testing.T) {
+	cat := testCatalog(t)
+	tests := []struct {
+		sql  string
+		code string
+		msg  string
+	}{
+		{"SELECT nosuch FROM t", CodeUndefinedColumn, `column "nosuch" does not exist`},
+		{"SELECT * FROM nosuch", CodeUndefinedTable, `relation "nosuch" does not exist`},
+		{"SELECT x FROM t, u WHERE a = x GROUP BY a", CodeGroupingError, `column "u.x" must appear in the GROUP BY clause or be used in an aggregate function`},
+		{"SELECT a FROM t WHERE sum(a) > 1", CodeGroupingError, "aggregate functions are not allowed in WHERE"},
+		{"SELECT sum(sum(a)) FROM t", CodeGroupingError, "aggregate function calls cannot be nested"},
+		{"SELECT rank() FROM t", CodeWrongObjectType, "window function rank requires an OVER clause"},
+		{"SELECT a FROM t LIMIT a", CodeInvalidColumnReference, "argument of LIMIT must not contain variables"},
+		{"SELECT DISTINCT a FROM t ORDER BY b", CodeInvalidColumnReference, "for SELECT DISTINCT, ORDER BY expressions must appear in select list"},
+		{"SELECT DISTINCT ON (b) a, b FROM t ORDER BY a", CodeInvalidColumnReference, "SELECT DISTINCT ON expressions must match initial ORDER BY expressions"},
+		{"SELECT count(*) FROM t FOR UPDATE", CodeFeatureNotSupported, "FOR UPDATE is not allowed with aggregate functions"},
+		{"SELECT a FROM t ORDER BY 3", CodeInvalidColumnReference, "ORDER BY position 3 is not in select list"},
+		{"SELECT 1 + 'x'", CodeInvalidTextRepresentation, `invalid input syntax for type integer: "x"`},
+		{"SELECT a FROM t WHERE b", CodeDatatypeMismatch, "argument of WHERE must be type boolean, not type text"},
+		{"SELECT j FROM w GROUP BY j", CodeUndefinedFunction, `could not identify an equality operator for type "json"`},
+		{"SELECT a FROM t WHERE (a, b) = (1, 'x', 2)", CodeSyntaxError, "unequal number of entries in row expressions"},
+		{"SELECT sum(a) OVER w FROM t", CodeUndefinedObject, `window "w" does not exist`},
+		{"INSERT INTO t (a, b) VALUES (1)", CodeSyntaxError, "INSERT has more target columns than expressions"},
+		{"UPDATE t SET nosuch = 1", CodeUndefinedColumn, `column "nosuch" of relation "t" does not exist`},
+		{"SELECT * FROM t, (SELECT t.a) s", CodeUndefinedTable, `invalid reference to FROM-clause entry for table "t"`},
+	}
+	for _, tt := range tests {
+		_, err := analyze(t, cat, tt.sql)
+		e, ok := err.(*Error)
+		if !ok {
+			t.Errorf("Analyze(%q) error = %v, want %s", tt.sql, err, tt.msg)
+			continue
+		}
+		if e.Code != tt.code || e.Message != tt.msg {
+			t.Errorf("Analyze(%q) error = %s %q, want %s %q", tt.sql, e.Code, e.Message, tt.code, tt.msg)
+		}
+	}
+}
+
+func TestUnresolvedFunctions(t *testing.T) {
+	cat := testCatalog(t)
+	q, err := analyze(t, cat, "SELECT my_func(a), my_other_func(b) FROM t WHERE a ## 1")
+	if err != nil {
+		t.Fatalf("Analyze error: %v", err)
+	}
+	for _, item := range items(q.TargetList) {
+		fe, ok := item.(*nodes.TargetEntry).Expr.(*nodes.FuncExpr)
+		if !ok || fe.Funcid != nodes.InvalidOid || fe.Funcresulttype != nodes.InvalidOid {
+			t.Errorf("target %T: want unresolved FuncExpr", item.(*nodes.TargetEntry).Expr)
+		}
+	}
+	if op, ok := q.Jointree.Quals.(*nodes.OpExpr); !ok || op.Opresulttype != nodes.InvalidOid {
+		t.Errorf("quals = %T, want unresolved OpExpr", q.Jointree.Quals)
+	}
+}
+
+func TestAnalyzeParams(t *testing.T) {
+	cat := testCatalog(t)
+	list, err := parser.Parse("SELECT a FROM t WHERE b = $1 AND a > $2 LIMIT $3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, types, err := AnalyzeParams(list.Items[0], cat, nil)
+	if err != nil {
+		t.Fatalf("AnalyzeParams error: %v", err)
+	}
+	want := []nodes.Oid{TEXTOID, INT4OID, INT8OID}
+	if len(types) != len(want) {
+		t.Fatalf("param types = %v, want %v", types, want)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Errorf("$%d type = %d, want %d", i+1, types[i], want[i])
+		}
+	}
+}
+
+func TestGroupingAndWindows(t *testing.T) {
+	cat := testCatalog(t)
+	q, err := analyze(t, cat, "SELECT a, grouping(a), count(*) OVER w, rank() OVER (w ORDER BY b) FROM t GROUP BY ROLLUP (a, b) WINDOW w AS (PARTITION BY a)")
+	if err != nil {
+		t.Fatalf("Analyze error: %v", err)
+	}
+	if q.GroupClause.Len() != 2 || q.GroupingSets.Len() != 1 {
+		t.Errorf("group clause %d items, grouping sets %d, want 2 and 1", q.GroupClause.Len(), q.GroupingSets.Len())
+	}
+	if q.WindowClause.Len() != 2 {
+		t.Fatalf("window clause has %d items, want 2", q.WindowClause.Len())
+	}
+	wc := q.WindowClause.Items[1].(*nodes.WindowClause)
+	if wc.Refname != "w" || wc.PartitionClause.Len() != 1 || wc.OrderClause.Len() != 1 {
+		t.Errorf("second window = %+v, want refname w with inherited partition and own order", wc)
+	}
+	g := q.TargetList.Items[1].(*nodes.TargetEntry).Expr.(*nodes.GroupingFunc)
+	if g.Refs.Len() != 1 {
+		t.Errorf("GROUPING refs = %v, want one", g.Refs)
+	}
+	if !q.HasAggs || !q.HasWindowFuncs {
+		t.Errorf("HasAggs = %v, HasWindowFuncs = %v, want both", q.HasAggs, q.HasWindowFuncs)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
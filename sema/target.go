@@ -0,0 +1,288 @@
+package sema
+
+import (
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// transformTargetList analyzes a SELECT, RETURNING or UPDATE SET list
+// (transformTargetList). "*" and "foo.*" items are expanded into one
+// entry per column. In an UPDATE SET list, DEFAULT items are passed
+// through as SetToDefault for transformAssignedExpr, and the sub-SELECT
+// of each multiple-column assignment is appended as a junk entry.
+func (ps *pstate) transformTargetList(l *nodes.List, kind exprKind) ([]*nodes.TargetEntry, error) {
+	saved := ps.exprKind
+	ps.exprKind = kind
+	defer func() { ps.exprKind = saved }()
+
+	var tlist, multiExprs []*nodes.TargetEntry
+	for _, item := range items(l) {
+		rt := item.(*nodes.ResTarget)
+		switch v := rt.Val.(type) {
+		case *nodes.ColumnRef:
+			if isStarRef(v) {
+				tles, err := ps.expandColumnRefStar(v)
+				if err != nil {
+					return nil, err
+				}
+				for _, tle := range tles {
+					tle.Resno = nodes.AttrNumber(len(tlist) + 1)
+					tlist = append(tlist, tle)
+				}
+				continue
+			}
+		case *nodes.A_Indirection:
+			if ind := items(v.Indirection); len(ind) > 0 {
+				if _, ok := ind[len(ind)-1].(*nodes.A_Star); ok {
+					tles, err := ps.expandIndirectionStar(v)
+					if err != nil {
+						return nil, err
+					}
+					for _, tle := range tles {
+						tle.Resno = nodes.AttrNumber(len(tlist) + 1)
+						tlist = append(tlist, tle)
+					}
+					continue
+				}
+			}
+		case *nodes.SetToDefault:
+			if kind == exprKindUpdateSource {
+				d := *v
+				tlist = append(tlist, &nodes.TargetEntry{Expr: &d, Resno: nodes.AttrNumber(len(tlist) + 1), Resname: rt.Name})
+				continue
+			}
+		}
+		e, err := ps.transformExprRecurse(rt.Val)
+		if err != nil {
+			return nil, err
+		}
+		name := rt.Name
+		if name == "" && kind != exprKindUpdateSource {
+			name = figureColname(rt.Val)
+		}
+		tlist = append(tlist, &nodes.TargetEntry{Expr: e, Resno: nodes.AttrNumber(len(tlist) + 1), Resname: name})
+		if m, ok := rt.Val.(*nodes.MultiAssignRef); ok && m.Colno == m.Ncolumns && ps.multiAssign != nil {
+			multiExprs = append(multiExprs, &nodes.TargetEntry{Expr: ps.multiAssign, Resjunk: true})
+		}
+	}
+	for _, tle := range multiExprs {
+		tle.Resno = nodes.AttrNumber(len(tlist) + 1)
+		tlist = append(tlist, tle)
+	}
+	return tlist, nil
+}
+
+// expandColumnRefStar expands "*", "rel.*" or "compositecol.*" into one
+// target entry per column (ExpandColumnRefStar).
+func (ps *pstate) expandColumnRefStar(cr *nodes.ColumnRef) ([]*nodes.TargetEntry, error) {
+	fields := items(cr.Fields)
+	loc := cr.Location
+	if len(fields) == 1 {
+		var out []*nodes.TargetEntry
+		found := false
+		for _, it := range ps.namespace {
+			if !it.colsVisible || !ps.visible(it) {
+				continue
+			}
+			found = true
+			out = append(out, expandNSItem(it, 0, loc)...)
+		}
+		if !found {
+			return nil, errorf(CodeSyntaxError, loc, "SELECT * with no tables specified is not valid")
+		}
+		return out, nil
+	}
+	names := stringList(&nodes.List{Items: fields[:len(fields)-1]})
+	if len(names) == 3 {
+		// catalog.schema.rel
+		names = names[1:]
+	}
+	var schema, rel string
+	switch len(names) {
+	case 1:
+		rel = names[0]
+	case 2:
+		schema, rel = names[0], names[1]
+	default:
+		return nil, errorf(CodeSyntaxError, loc, "improper qualified name (too many dotted names): %s", nameListString(cr.Fields))
+	}
+	it, levels, err := ps.refnameNSItem(schema, rel, loc)
+	if err != nil {
+		return nil, err
+	}
+	if it != nil {
+		return expandNSItem(it, levels, loc), nil
+	}
+	// Otherwise the prefix may name a column of composite type.
+	col, err := ps.transformColumnRef(&nodes.ColumnRef{Fields: &nodes.List{Items: fields[:len(fields)-1]}, Location: loc})
+	if err != nil {
+		if len(names) == 1 {
+			return nil, ps.errorMissingRTE(rel, loc)
+		}
+		return nil, err
+	}
+	return ps.expandRecordColumns(col, loc)
+}
+
+// expandIndirectionStar expands "(expr).*" into one target entry per
+// field of the composite value (ExpandIndirectionStar).
+func (ps *pstate) expandIndirectionStar(ind *nodes.A_Indirection) ([]*nodes.TargetEntry, error) {
+	rest := items(ind.Indirection)
+	rest = rest[:len(rest)-1]
+	var expr nodes.Node
+	var err error
+	if len(rest) == 0 {
+		expr, err = ps.transformExprRecurse(ind.Arg)
+	} else {
+		expr, err = ps.transformExprRecurse(&nodes.A_Indirection{Arg: ind.Arg, Indirection: &nodes.List{Items: rest}})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ps.expandRecordColumns(expr, exprLocation(ind.Arg))
+}
+
+// expandRecordColumns returns a target entry selecting each field of a
+// composite-valued expression.
+func (ps *pstate) expandRecordColumns(expr nodes.Node, loc nodes.ParseLoc) ([]*nodes.TargetEntry, error) {
+	var out []*nodes.TargetEntry
+	if row, ok := expr.(*nodes.RowExpr); ok {
+		colnames := stringList(row.Colnames)
+		for i, arg := range items(row.Args) {
+			name := "f" + itoa(i+1)
+			if i < len(colnames) {
+				name = colnames[i]
+			}
+			out = append(out, &nodes.TargetEntry{Expr: arg, Resname: name})
+		}
+		return out, nil
+	}
+	typ := ExprType(expr)
+	if v, ok := expr.(*nodes.Var); ok && v.Varattno == 0 {
+		// A whole-row reference to a range table entry.
+		if p := ps.levelUp(int(v.Varlevelsup)); p != nil && v.Varno > 0 && v.Varno <= len(p.rtable) {
+			for _, name := range stringList(p.rtable[v.Varno-1].Eref.Colnames) {
+				if name == "" {
+					continue
+				}
+				e, err := ps.fieldSelect(expr, name, loc)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, &nodes.TargetEntry{Expr: e, Resname: name})
+			}
+			return out, nil
+		}
+	}
+	base, _ := baseType(ps.cat, typ, -1)
+	t := ps.cat.TypeByOid(base)
+	if t == nil || t.Kind != 'c' {
+		if typ == RECORDOID {
+			return nil, errorf(CodeWrongObjectType, loc, "record type has not been registered")
+		}
+		return nil, errorf(CodeWrongObjectType, loc, "type %s is not composite", FormatType(ps.cat, typ, -1))
+	}
+	rel := ps.cat.RelationByOid(t.Relid)
+	if rel == nil {
+		return nil, errorf(CodeWrongObjectType, loc, "type %s is not composite", FormatType(ps.cat, typ, -1))
+	}
+	for i, c := range rel.Columns {
+		out = append(out, &nodes.TargetEntry{
+			Expr:    &nodes.FieldSelect{Arg: expr, Fieldnum: nodes.AttrNumber(i + 1), Resulttype: c.Type, Resulttypmod: c.Typmod, Resultcollid: c.Collation},
+			Resname: c.Name,
+		})
+	}
+	return out, nil
+}
+
+// expandNSItem returns a target entry for each column of a namespace
+// item that star expansion includes.
+func expandNSItem(it *nsItem, levelsup int, loc nodes.ParseLoc) []*nodes.TargetEntry {
+	var out []*nodes.TargetEntry
+	for i := range it.columns {
+		if it.columns[i].dontExpand || it.colnames[i] == "" {
+			continue
+		}
+		out = append(out, &nodes.TargetEntry{Expr: buildVar(&it.columns[i], levelsup, loc), Resname: it.colnames[i]})
+	}
+	return out
+}
+
+// markTargetListOrigins records the table column each output column that
+// is a plain column reference comes from (markTargetListOrigins).
+func (ps *pstate) markTargetListOrigins(tlist []*nodes.TargetEntry) {
+	for _, tle := range tlist {
+		if v, ok := tle.Expr.(*nodes.Var); ok {
+			tle.Resorigtbl, tle.Resorigcol = ps.varOrigin(int(v.Varlevelsup), v.Varno, v.Varattno)
+		}
+	}
+}
+
+// varOrigin follows a column reference through subqueries, joins and
+// CTEs to the table column it reads, if any.
+func (ps *pstate) varOrigin(levelsup, varno int, attno nodes.AttrNumber) (nodes.Oid, nodes.AttrNumber) {
+	p := ps.levelUp(levelsup)
+	if p == nil || varno <= 0 || varno > len(p.rtable) || attno < 0 {
+		return nodes.InvalidOid, 0
+	}
+	rte := p.rtable[varno-1]
+	switch rte.Rtekind {
+	case nodes.RTE_RELATION:
+		return rte.Relid, attno
+	case nodes.RTE_SUBQUERY:
+		if attno > 0 && rte.Subquery != nil {
+			if tle := targetEntryByResno(rte.Subquery.TargetList, attno); tle != nil {
+				return tle.Resorigtbl, tle.Resorigcol
+			}
+		}
+	case nodes.RTE_JOIN:
+		if attno > 0 && int(attno) <= rte.Joinaliasvars.Len() {
+			if v, ok := rte.Joinaliasvars.Items[attno-1].(*nodes.Var); ok {
+				return p.varOrigin(int(v.Varlevelsup), v.Varno, v.Varattno)
+			}
+		}
+	case nodes.RTE_CTE:
+		cp := p.levelUp(int(rte.Ctelevelsup))
+		if attno == 0 || cp == nil {
+			break
+		}
+		for _, cte := range cp.ctes {
+			if cte.Ctename != rte.Ctename {
+				continue
+			}
+			if q, ok := cte.Ctequery.(*nodes.Query); ok {
+				tlist := q.TargetList
+				if q.CommandType != nodes.CMD_SELECT {
+					tlist = q.ReturningList
+				}
+				if tle := targetEntryByResno(tlist, attno); tle != nil {
+					return tle.Resorigtbl, tle.Resorigcol
+				}
+			}
+		}
+	}
+	return nodes.InvalidOid, 0
+}
+
+// targetEntryByResno returns the entry of a target list with the given
+// resno.
+func targetEntryByResno(tlist *nodes.List, resno nodes.AttrNumber) *nodes.TargetEntry {
+	for _, item := range items(tlist) {
+		if tle := item.(*nodes.TargetEntry); tle.Resno == resno {
+			return tle
+		}
+	}
+	return nil
+}
+
+// resolveTargetListUnknowns coerces output columns of type unknown, such
+// as bare string literals, to text (resolveTargetListUnknowns).
+func (ps *pstate) resolveTargetListUnknowns(tlist []*nodes.TargetEntry) {
+	for _, tle := range tlist {
+		if ExprType(tle.Expr) != UNKNOWNOID {
+			continue
+		}
+		if e, err := ps.coerceType(tle.Expr, UNKNOWNOID, TEXTOID, -1, nodes.COERCION_IMPLICIT, nodes.COERCE_IMPLICIT_CAST, -1); err == nil {
+			tle.Expr = e
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package sema
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pgplex/pgparser/catalog"
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// VARHDRSZ is the size of a varlena header, which length-limited types
+// include in their typmod.
+const VARHDRSZ = 4
+
+// Interval typmod components (datetime.h and timestamp.h).
+const (
+	intervalFullRange     = 0x7FFF
+	intervalFullPrecision = 0xFFFF
+)
+
+// LookupTypeName resolves a type name to a type and typmod, as
+// typenameTypeIdAndMod in parse_type.c does. %TYPE references are
+// resolved to the type of the named column.
+func LookupTypeName(cat Catalog, tn *nodes.TypeName) (*Type, int32, error) {
+	if tn.TypeOid != nodes.InvalidOid {
+		if t := cat.TypeByOid(tn.TypeOid); t != nil {
+			return t, tn.Typemod, nil
+		}
+		return nil, -1, errorf(CodeUndefinedObject, tn.Location, "type with OID %d does not exist", tn.TypeOid)
+	}
+	names := stringList(tn.Names)
+	var t *Type
+	typmod := int32(-1)
+	if tn.PctType {
+		if len(names) < 2 {
+			return nil, -1, errorf(CodeSyntaxError, tn.Location, "improper %%TYPE reference (too few dotted names): %s", strings.Join(names, "."))
+		}
+		colName := names[len(names)-1]
+		schema, relName := qualifiedName(names[:len(names)-1])
+		rel := cat.LookupRelation(schema, relName)
+		if rel == nil {
+			return nil, -1, errorf(CodeUndefinedTable, tn.Location, "relation %q does not exist", strings.Join(names[:len(names)-1], "."))
+		}
+		col, _ := rel.Column(colName)
+		if col == nil {
+			return nil, -1, errorf(CodeUndefinedColumn, tn.Location, "column %q of relation %q does not exist", colName, relName)
+		}
+		t, typmod = cat.TypeByOid(col.Type), col.Typmod
+	} else {
+		schema, name := qualifiedName(names)
+		t = cat.LookupType(schema, name)
+		if t == nil {
+			return nil, -1, errorf(CodeUndefinedObject, tn.Location, "type %q does not exist", strings.Join(names, "."))
+		}
+		if tn.Typmods.Len() > 0 {
+			var err error
+			if typmod, err = typmodIn(t, tn); err != nil {
+				return nil, -1, err
+			}
+		} else if tn.Typemod != 0 {
+			typmod = tn.Typemod
+		}
+	}
+	if t == nil {
+		return nil, -1, errorf(CodeUndefinedObject, tn.Location, "type %q does not exist", strings.Join(names, "."))
+	}
+	if tn.ArrayBounds.Len() > 0 && !isArrayType(t) {
+		arr := cat.TypeByOid(t.Array)
+		if arr == nil {
+			return nil, -1, errorf(CodeUndefinedObject, tn.Location, "could not find array type for data type %s", FormatType(cat, t.Oid, -1))
+		}
+		t = arr
+	}
+	return t, typmod, nil
+}
+
+// typmodIn computes the typmod for the modifiers of a type name, as the
+// types' typmodin functions do.
+func typmodIn(t *Type, tn *nodes.TypeName) (int32, error) {
+	var mods []int64
+	for _, item := range items(tn.Typmods) {
+		if c, ok := item.(*nodes.A_Const); ok {
+			item = c.Val
+		}
+		i, ok := item.(*nodes.Integer)
+		if !ok {
+			return -1, errorf(CodeSyntaxError, tn.Location, "type modifiers must be simple constants or identifiers")
+		}
+		mods = append(mods, i.Ival)
+	}
+	name := t.Name
+	if t.Schema != "pg_catalog" {
+		return -1, errorf(CodeSyntaxError, tn.Location, "type modifier is not allowed for type %q", name)
+	}
+	switch name {
+	case "bpchar", "varchar":
+		if len(mods) != 1 {
+			return -1, errorf(CodeInvalidParameterValue, tn.Location, "invalid type modifier")
+		}
+		if mods[0] < 1 {
+			return -1, errorf(CodeInvalidParameterValue, tn.Location, "length for type %s must be at least 1", name)
+		}
+		return int32(mods[0]) + VARHDRSZ, nil
+	case "bit", "varbit":
+		if len(mods) != 1 {
+			return -1, errorf(CodeInvalidParameterValue, tn.Location, "invalid type modifier")
+		}
+		if mods[0] < 1 {
+			return -1, errorf(CodeInvalidParameterValue, tn.Location, "length for type %s must be at least 1", name)
+		}
+		return int32(mods[0]), nil
+	case "numeric":
+		switch len(mods) {
+		case 1:
+			return int32(mods[0]<<16) + VARHDRSZ, nil
+		case 2:
+			return int32(mods[0]<<16|mods[1]&0x7ff) + VARHDRSZ, nil
+		}
+		return -1, errorf(CodeInvalidParameterValue, tn.Location, "invalid NUMERIC type modifier")
+	case "time", "timetz", "timestamp", "timestamptz":
+		if len(mods) != 1 {
+			return -1, errorf(CodeInvalidParameterValue, tn.Location, "invalid type modifier")
+		}
+		return int32(min(mods[0], 6)), nil
+	case "interval":
+		rng, prec := int64(intervalFullRange), int64(intervalFullPrecision)
+		switch len(mods) {
+		case 1:
+			rng = mods[0]
+		case 2:
+			rng, prec = mods[0], min(mods[1], 6)
+		default:
+			return -1, errorf(CodeInvalidParameterValue, tn.Location, "invalid INTERVAL type modifier")
+		}
+		if rng == intervalFullRange && prec == intervalFullPrecision {
+			return -1, nil
+		}
+		return int32(rng<<16 | prec), nil
+	}
+	return -1, errorf(CodeSyntaxError, tn.Location, "type modifier is not allowed for type %q", name)
+}
+
+// sqlTypeNames maps pg_catalog type names to the SQL-standard spelling
+// format_type uses for them.
+var sqlTypeNames = map[string]string{
+	"bool":        "boolean",
+	"int2":        "smallint",
+	"int4":        "integer",
+	"int8":        "bigint",
+	"float4":      "real",
+	"float8":      "double precision",
+	"bpchar":      "character",
+	"varchar":     "character varying",
+	"varbit":      "bit varying",
+	"time":        "time without time zone",
+	"timetz":      "time with time zone",
+	"timestamp":   "timestamp without time zone",
+	"timestamptz": "timestamp with time zone",
+	"char":        `"char"`,
+}
+
+// FormatType renders a type the way PostgreSQL's format_type(oid, typmod)
+// does, e.g. "character varying(10)" or "integer[]". Types not visible in
+// the search path are schema-qualified.
+func FormatType(cat Catalog, oid nodes.Oid, typmod int32) string {
+	if oid == nodes.InvalidOid {
+		return "-"
+	}
+	t := cat.TypeByOid(oid)
+	if t == nil {
+		return "???"
+	}
+	if isArrayType(t) && t.Category == 'A' {
+		return FormatType(cat, t.Elem, typmod) + "[]"
+	}
+	if t.Schema == "pg_catalog" {
+		if s := formatBuiltinType(t.Name, typmod); s != "" {
+			return s
+		}
+	}
+	name := catalog.QuoteIdentifier(t.Name)
+	if t.Schema != "pg_catalog" {
+		if v := cat.LookupType("", t.Name); v == nil || v.Oid != t.Oid {
+			name = catalog.QuoteIdentifier(t.Schema) + "." + name
+		}
+	}
+	return name
+}
+
+func formatBuiltinType(name string, typmod int32) string {
+	switch name {
+	case "bpchar":
+		if typmod < 0 {
+			return "bpchar"
+		}
+		return "character(" + strconv.Itoa(int(typmod-VARHDRSZ)) + ")"
+	case "varchar":
+		if typmod < 0 {
+			return sqlTypeNames[name]
+		}
+		return sqlTypeNames[name] + "(" + strconv.Itoa(int(typmod-VARHDRSZ)) + ")"
+	case "bit", "varbit":
+		s := name
+		if sql, ok := sqlTypeNames[name]; ok {
+			s = sql
+		}
+		if typmod >= 0 {
+			s += "(" + strconv.Itoa(int(typmod)) + ")"
+		}
+		return s
+	case "numeric":
+		if typmod < VARHDRSZ {
+			return "numeric"
+		}
+		t := typmod - VARHDRSZ
+		return "numeric(" + strconv.Itoa(int(t>>16&0xffff)) + "," + strconv.Itoa(int(t&0x7ff)) + ")"
+	case "time", "timetz", "timestamp", "timestamptz":
+		base, zone, _ := strings.Cut(sqlTypeNames[name], " ")
+		if typmod >= 0 {
+			base += "(" + strconv.Itoa(int(typmod)) + ")"
+		}
+		return base + " " + zone
+	case "interval":
+		if typmod >= 0 && typmod&0xffff != intervalFullPrecision {
+			return "interval(" + strconv.Itoa(int(typmod&0xffff)) + ")"
+		}
+		return "interval"
+	}
+	return sqlTypeNames[name]
+}
+
+// isArrayType reports whether t is an array type (type_is_array).
+func isArrayType(t *Type) bool {
+	return t != nil && t.Elem != nodes.InvalidOid && t.Len == -1
+}
+
+// isPolymorphicType reports whether oid is one of the polymorphic
+// pseudo-types (IsPolymorphicType).
+func isPolymorphicType(oid nodes.Oid) bool {
+	switch oid {
+	case ANYELEMENTOID, ANYARRAYOID, ANYNONARRAYOID, ANYENUMOID, ANYRANGEOID,
+		ANYMULTIRANGEOID, ANYCOMPATIBLEOID, ANYCOMPATIBLEARRAYOID,
+		ANYCOMPATIBLENONARRAYOID, ANYCOMPATIBLERANGEOID:
+		return true
+	}
+	return false
+}
+
+// baseType returns the base type of a domain, following nested domains,
+// together with the domain's typmod; for other types it returns the type
+// itself (getBaseTypeAndTypmod).
+func baseType(cat Catalog, oid nodes.Oid, typmod int32) (nodes.Oid, int32) {
+	for i := 0; i < 100; i++ {
+		t := cat.TypeByOid(oid)
+		if t == nil || t.Kind != 'd' {
+			break
+		}
+		oid, typmod = t.BaseType, t.Typmod
+	}
+	return oid, typmod
+}
+
+// typeCategory returns the typcategory of a type, with domains resolved to
+// their base type.
+func typeCategory(cat Catalog, oid nodes.Oid) byte {
+	oid, _ = baseType(cat, oid, -1)
+	if t := cat.TypeByOid(oid); t != nil {
+		return t.Category
+	}
+	return 'X'
+}
+
+// typeCollation returns the default collation of a type, or InvalidOid if
+// it is not collatable.
+func typeCollation(cat Catalog, oid nodes.Oid) nodes.Oid {
+	if t := cat.TypeByOid(oid); t != nil {
+		return t.Collation
+	}
+	return nodes.InvalidOid
+}
+
+// stringList returns the strings of a list of String nodes.
+func stringList(l *nodes.List) []string {
+	var out []string
+	for _, item := range items(l) {
+		if s, ok := item.(*nodes.String); ok {
+			out = append(out, s.Sval)
+		}
+	}
+	return out
+}
+
+// items returns the elements of a possibly nil list.
+func items(l *nodes.List) []nodes.Node {
+	if l == nil {
+		return nil
+	}
+	return l.Items
+}
+
+// qualifiedName splits a possibly-qualified name into schema and name,
+// ignoring a catalog name.
+func qualifiedName(names []string) (string, string) {
+	switch len(names) {
+	case 0:
+		return "", ""
+	case 1:
+		return "", names[0]
+	}
+	return names[len(names)-2], names[len(names)-1]
+}
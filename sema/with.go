@@ -0,0 +1,242 @@
+package sema
+
+import (
+	"github.com/pgplex/pgparser/nodes"
+)
+
+// transformWithClause analyzes the WITH queries of a statement and makes
+// them visible to it (transformWithClause in parse_cte.c). In a WITH
+// RECURSIVE list the queries are analyzed in dependency order, so each
+// can refer to any other that does not depend on it.
+func (ps *pstate) transformWithClause(with *nodes.WithClause) (*nodes.List, error) {
+	var ctes []*nodes.CommonTableExpr
+	seen := map[string]bool{}
+	for _, item := range items(with.Ctes) {
+		cte := item.(*nodes.CommonTableExpr)
+		if seen[cte.Ctename] {
+			return nil, errorf(CodeDuplicateAlias, cte.Location, "WITH query name %q specified more than once", cte.Ctename)
+		}
+		seen[cte.Ctename] = true
+		if _, ok := cte.Ctequery.(*nodes.SelectStmt); !ok {
+			if ps.parent != nil {
+				return nil, errorf(CodeFeatureNotSupported, cte.Location, "WITH clause containing a data-modifying statement must be at the top level")
+			}
+			ps.hasModifyingCTE = true
+		}
+		ctes = append(ctes, cte)
+	}
+
+	if !with.Recursive {
+		for _, cte := range ctes {
+			if err := ps.analyzeCTE(cte); err != nil {
+				return nil, err
+			}
+			ps.ctes = append(ps.ctes, cte)
+		}
+		return cteList(ctes), nil
+	}
+
+	// Find the references between the items and analyze them in an order
+	// that puts each after the items it refers to.
+	index := map[string]int{}
+	for i, cte := range ctes {
+		index[cte.Ctename] = i
+	}
+	deps := make([][]int, len(ctes))
+	for i, cte := range ctes {
+		for _, name := range cteReferences(cte.Ctequery) {
+			j, ok := index[name]
+			if !ok {
+				continue
+			}
+			if j == i {
+				cte.Cterecursive = true
+				continue
+			}
+			deps[i] = append(deps[i], j)
+		}
+	}
+	order, ok := topoOrder(deps)
+	if !ok {
+		return nil, errorf(CodeFeatureNotSupported, with.Location, "mutual recursion between WITH items is not implemented")
+	}
+	ps.ctes = append(ps.ctes, ctes...)
+	for _, i := range order {
+		cte := ctes[i]
+		if cte.Cterecursive {
+			if err := checkRecursiveForm(cte); err != nil {
+				return nil, err
+			}
+		}
+		if err := ps.analyzeCTE(cte); err != nil {
+			return nil, err
+		}
+	}
+	return cteList(ctes), nil
+}
+
+// analyzeCTE analyzes the query of one WITH item and sets its output
+// columns.
+func (ps *pstate) analyzeCTE(cte *nodes.CommonTableExpr) error {
+	var parentCte *nodes.CommonTableExpr
+	if cte.Cterecursive {
+		parentCte = cte
+	}
+	q, err := ps.subAnalyze(cte.Ctequery, parentCte, false, true)
+	if err != nil {
+		return err
+	}
+	cte.Ctequery = q
+	tlist := q.TargetList
+	if q.CommandType != nodes.CMD_SELECT {
+		if q.ReturningList == nil {
+			return errorf(CodeFeatureNotSupported, cte.Location, "WITH query %q does not have a RETURNING clause", cte.Ctename)
+		}
+		tlist = q.ReturningList
+	}
+	var names []string
+	var types, colls []nodes.Oid
+	var typmods []int
+	for _, item := range items(tlist) {
+		tle := item.(*nodes.TargetEntry)
+		if tle.Resjunk {
+			continue
+		}
+		names = append(names, tle.Resname)
+		types = append(types, ExprType(tle.Expr))
+		typmods = append(typmods, int(ExprTypmod(tle.Expr)))
+		colls = append(colls, ExprCollation(tle.Expr))
+	}
+	aliases := stringList(cte.Aliascolnames)
+	if len(aliases) > len(names) {
+		return errorf(CodeInvalidColumnReference, cte.Location, "WITH query %q has %d columns available but %d columns specified", cte.Ctename, len(names), len(aliases))
+	}
+	copy(names, aliases)
+	if cte.Cterecursive && cte.Ctecoltypes != nil {
+		// The types set from the non-recursive term must hold overall.
+		for i, t := range types {
+			if i < len(cte.Ctecoltypes.Items) && cte.Ctecoltypes.Items[i] != t {
+				return errorf(CodeDatatypeMismatch, cte.Location, "recursive query %q column %d has type %s in non-recursive term but type %s overall",
+					cte.Ctename, i+1, FormatType(ps.cat, cte.Ctecoltypes.Items[i], int32(cte.Ctecoltypmods.Items[i])), FormatType(ps.cat, t, int32(typmods[i]))).
+					withHint("Cast the output of the non-recursive term to the correct type.")
+			}
+		}
+	}
+	cte.Ctecolnames = makeStringList(names)
+	cte.Ctecoltypes = &nodes.OidList{Items: types}
+	cte.Ctecoltypmods = &nodes.IntList{Items: typmods}
+	cte.Ctecolcollations = &nodes.OidList{Items: colls}
+	return nil
+}
+
+// determineRecursiveColTypes sets the output columns of the recursive CTE
+// being analyzed from its non-recursive term, so that the recursive term
+// can refer to it.
+func (ps *pstate) determineRecursiveColTypes(cols []setOpColumn) {
+	cte := ps.parentCte
+	var names []string
+	var types, colls []nodes.Oid
+	var typmods []int
+	for _, c := range cols {
+		name := "?column?"
+		if c.leaf != nil && c.leaf.Resname != "" {
+			name = c.leaf.Resname
+		}
+		typ := c.typ
+		if typ == UNKNOWNOID {
+			typ = TEXTOID
+		}
+		names = append(names, name)
+		types = append(types, typ)
+		typmods = append(typmods, int(c.typmod))
+		coll := c.collid
+		if coll == nodes.InvalidOid {
+			coll = typeCollation(ps.cat, typ)
+		}
+		colls = append(colls, coll)
+	}
+	copy(names, stringList(cte.Aliascolnames))
+	cte.Ctecolnames = makeStringList(names)
+	cte.Ctecoltypes = &nodes.OidList{Items: types}
+	cte.Ctecoltypmods = &nodes.IntList{Items: typmods}
+	cte.Ctecolcollations = &nodes.OidList{Items: colls}
+}
+
+// checkRecursiveForm checks that a recursive CTE has the form
+// non-recursive-term UNION [ALL] recursive-term.
+func checkRecursiveForm(cte *nodes.CommonTableExpr) error {
+	sel, ok := cte.Ctequery.(*nodes.SelectStmt)
+	if !ok {
+		return errorf(CodeInvalidRecursion, cte.Location, "recursive query %q must not contain data-modifying statements", cte.Ctename)
+	}
+	if sel.Op != nodes.SETOP_UNION {
+		return errorf(CodeInvalidRecursion, cte.Location, "recursive query %q does not have the form non-recursive-term UNION [ALL] recursive-term", cte.Ctename)
+	}
+	for _, name := range cteReferences(sel.Larg) {
+		if name == cte.Ctename {
+			return errorf(CodeInvalidRecursion, cte.Location, "recursive reference to query %q must not appear within its non-recursive term", cte.Ctename)
+		}
+	}
+	if sel.SortClause != nil {
+		return errorf(CodeFeatureNotSupported, -1, "ORDER BY in a recursive query is not implemented")
+	}
+	if sel.LimitCount != nil || sel.LimitOffset != nil {
+		return errorf(CodeFeatureNotSupported, -1, "LIMIT in a recursive query is not implemented")
+	}
+	if sel.LockingClause != nil {
+		return errorf(CodeFeatureNotSupported, -1, "FOR UPDATE/SHARE in a recursive query is not implemented")
+	}
+	return nil
+}
+
+// cteReferences returns the unqualified relation names a raw statement
+// refers to, which may be references to WITH queries.
+func cteReferences(stmt nodes.Node) []string {
+	var names []string
+	nodes.Walk(stmt, func(n nodes.Node) bool {
+		if rv, ok := n.(*nodes.RangeVar); ok && rv.Schemaname == "" {
+			names = append(names, rv.Relname)
+		}
+		return true
+	})
+	return names
+}
+
+// topoOrder returns an order of the items such that each comes after the
+// items it depends on, or false if the dependencies contain a cycle.
+func topoOrder(deps [][]int) ([]int, bool) {
+	state := make([]int, len(deps)) // 0 unvisited, 1 in progress, 2 done
+	var order []int
+	var visit func(i int) bool
+	visit = func(i int) bool {
+		switch state[i] {
+		case 1:
+			return false
+		case 2:
+			return true
+		}
+		state[i] = 1
+		for _, j := range deps[i] {
+			if !visit(j) {
+				return false
+			}
+		}
+		state[i] = 2
+		order = append(order, i)
+		return true
+	}
+	for i := range deps {
+		if !visit(i) {
+			return nil, false
+		}
+	}
+	return order, true
+}
+
+func cteList(ctes []*nodes.CommonTableExpr) *nodes.List {
+	l := &nodes.List{}
+	for _, cte := range ctes {
+		l.Items = append(l.Items, cte)
+	}
+	return l
+}
@@ -51,10 +51,10 @@ func main() {
 	}
 }
 
-func runDir(helperPath, dirPath string) error {
+func sqlFiles(dirPath string) ([]string, error) {
 	entries, err := os.ReadDir(dirPath)
 	if err != nil {
-		return fmt.Errorf("read dir: %w", err)
+		return nil, fmt.Errorf("read dir: %w", err)
 	}
 	var files []string
 	for _, entry := range entries {
@@ -67,7 +67,15 @@ func runDir(helperPath, dirPath string) error {
 	}
 	sort.Strings(files)
 	if len(files) == 0 {
-		return fmt.Errorf("no .sql files in %s", dirPath)
+		return nil, fmt.Errorf("no .sql files in %s", dirPath)
+	}
+	return files, nil
+}
+
+func runDir(helperPath, dirPath string) error {
+	files, err := sqlFiles(dirPath)
+	if err != nil {
+		return err
 	}
 	for _, file := range files {
 		if err := runFile(helperPath, file); err != nil {
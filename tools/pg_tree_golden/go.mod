@@ -0,0 +1,12 @@
+module github.com/pgplex/pgparser/tools/pg_tree_golden
+
+go 1.21
+
+require (
+	github.com/pganalyze/pg_query_go/v6 v6.1.0
+	github.com/pgplex/pgparser v0.0.0
+)
+
+require google.golang.org/protobuf v1.31.0 // indirect
+
+replace github.com/pgplex/pgparser => ../..
@@ -0,0 +1,232 @@
+// Command pg_tree_golden writes PostgreSQL's raw parse tree of every
+// statement in the regression test files, as the golden corpus that
+// TestPGTreeGolden in parser/pgregress compares pgparser with.
+//
+// The trees come from PostgreSQL's own grammar, built into pg_query_go
+// (PostgreSQL 17.4), and are written in pg_query's JSON form, which
+// nodes.NodeToJSON also produces. The command lives in a module of its
+// own so that pgparser does not depend on cgo or on pg_query_go.
+//
+// Usage, from this directory:
+//
+//	go run . -dir ../../parser/pgregress/testdata/sql -out ../../parser/pgregress/testdata/pgtrees
+//
+// With -errors, it also writes the error PostgreSQL reports for each
+// statement it rejects, which TestPGRegress expects pgparser to reject
+// too:
+//
+//	go run . -dir ../../parser/pgregress/testdata/sql -out ../../parser/pgregress/testdata/pgtrees -errors ../../parser/pgregress/expected_errors.json
+//
+// With -file, it writes the trees of the statements of one file instead,
+// each after the last line of its SQL, as TestShapeGolden in
+// parser/parsertest reads them:
+//
+//	go run . -file ../../parser/parsertest/testdata/shape.sql -out ../../parser/parsertest/testdata/shape.golden
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+	"github.com/pgplex/pgparser/parser/pgregress"
+)
+
+func main() {
+	dir := flag.String("dir", "", "directory of regression test .sql files")
+	file := flag.String("file", "", "single .sql file to write the trees of, instead of -dir")
+	out := flag.String("out", "", "directory to write <name>.json files to, or the file to write with -file")
+	errs := flag.String("errors", "", "file to write PostgreSQL's errors for the statements it rejects to, with -dir")
+	flag.Parse()
+	if (*dir == "") == (*file == "") || *out == "" || (*errs != "" && *dir == "") {
+		fmt.Fprintln(os.Stderr, "usage: pg_tree_golden -dir <sql dir> -out <golden dir> [-errors <errors file>]")
+		fmt.Fprintln(os.Stderr, "       pg_tree_golden -file <sql file> -out <golden file>")
+		os.Exit(2)
+	}
+	var err error
+	if *file != "" {
+		err = runFile(*file, *out)
+	} else {
+		err = run(*dir, *out)
+	}
+	if err == nil && *errs != "" {
+		err = writeErrors(*dir, *errs)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(dir, out string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.sql"))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no .sql files in %s", dir)
+	}
+	sort.Strings(files)
+	if err := os.MkdirAll(out, 0755); err != nil {
+		return err
+	}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		base := filepath.Base(file)
+		data, n, err := goldenFile(base, content)
+		if err != nil {
+			return fmt.Errorf("%s: %w", base, err)
+		}
+		name := strings.TrimSuffix(base, ".sql") + ".json"
+		if err := os.WriteFile(filepath.Join(out, name), data, 0644); err != nil {
+			return err
+		}
+		fmt.Printf("wrote %s (%d statements)\n", name, n)
+	}
+	return nil
+}
+
+// goldenFile returns the corpus file for one .sql file: a JSON object from
+// the 0-based statement index, as numbered by pgregress.ExtractStatements,
+// to the statement's tree, one statement per line. Statements PostgreSQL
+// rejects and those using psql variables are left out.
+func goldenFile(name string, content []byte) ([]byte, int, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	n := 0
+	for i, stmt := range pgregress.ExtractStatements(name, content) {
+		if stmt.HasPsqlVar || scannerErrors[stmt.SQL] != "" {
+			continue
+		}
+		tree, err := pgTree(stmt.SQL)
+		if err != nil {
+			return nil, 0, fmt.Errorf("stmt[%d]: %w", i, err)
+		}
+		if tree == nil {
+			continue
+		}
+		if n > 0 {
+			buf.WriteString(",")
+		}
+		fmt.Fprintf(&buf, "\n%q: %s", fmt.Sprint(i), tree)
+		n++
+	}
+	buf.WriteString("\n}\n")
+	return buf.Bytes(), n, nil
+}
+
+// writeErrors writes, for the statements of the .sql files in dir that
+// PostgreSQL rejects, the error it reports, as a JSON object from file
+// name to an object from statement index to message. Statements are
+// parsed as TestPGRegress parses them, with psql variables replaced.
+func writeErrors(dir, out string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.sql"))
+	if err != nil {
+		return err
+	}
+	errs := make(map[string]map[string]string)
+	n := 0
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		base := filepath.Base(file)
+		for i, stmt := range pgregress.ExtractStatements(base, content) {
+			if msg := pgError(stmt.ParseSQL()); msg != "" {
+				if errs[base] == nil {
+					errs[base] = make(map[string]string)
+				}
+				errs[base][fmt.Sprint(i)] = msg
+				n++
+			}
+		}
+	}
+	data, err := json.MarshalIndent(errs, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(out, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	fmt.Printf("wrote %s (%d statements)\n", filepath.Base(out), n)
+	return nil
+}
+
+// runFile writes the trees of the statements of one .sql file to out,
+// each preceded by the last line of the statement's SQL and followed by a
+// blank line. Every statement must be one PostgreSQL accepts.
+func runFile(file, out string) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	stmts := pgregress.ExtractStatements(filepath.Base(file), content)
+	for _, stmt := range stmts {
+		tree, err := pgTree(stmt.SQL)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", stmt.StartLine, err)
+		}
+		if tree == nil {
+			return fmt.Errorf("line %d: PostgreSQL rejects the statement", stmt.StartLine)
+		}
+		sql := stmt.SQL[strings.LastIndex(stmt.SQL, "\n")+1:]
+		fmt.Fprintf(&buf, "%s\n%s\n\n", sql, tree)
+	}
+	if err := os.WriteFile(out, buf.Bytes(), 0644); err != nil {
+		return err
+	}
+	fmt.Printf("wrote %s (%d statements)\n", filepath.Base(out), len(stmts))
+	return nil
+}
+
+// scannerErrors are the statements of the regression tests that
+// PostgreSQL rejects but pg_query accepts, whose scanner lacks the check
+// for trailing junk after a parameter, with PostgreSQL's error.
+var scannerErrors = map[string]string{
+	"PREPARE p1 AS SELECT $1a":  `trailing junk after parameter at or near "$1a"`,
+	"PREPARE p1 AS SELECT $0_1": `trailing junk after parameter at or near "$0_1"`,
+}
+
+// pgError returns the error PostgreSQL reports for sql, or "" if it
+// accepts it.
+func pgError(sql string) string {
+	if msg := scannerErrors[sql]; msg != "" {
+		return msg
+	}
+	if _, err := pg_query.ParseToJSON(sql); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// pgTree returns PostgreSQL's tree of a single statement, or nil if
+// PostgreSQL rejects it or it holds more than one statement.
+func pgTree(sql string) (json.RawMessage, error) {
+	out, err := pg_query.ParseToJSON(sql)
+	if err != nil {
+		return nil, nil
+	}
+	var result struct {
+		Stmts []struct {
+			Stmt json.RawMessage `json:"stmt"`
+		} `json:"stmts"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		return nil, err
+	}
+	if len(result.Stmts) != 1 {
+		return nil, nil
+	}
+	return result.Stmts[0].Stmt, nil
+}